@@ -44,7 +44,7 @@ func gateway(ctx context.Context, log *logrus.Entry) error {
 	if err := env.ValidateVars(DatabaseAccountName); err != nil {
 		return err
 	}
-	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, nil, m, nil, os.Getenv(DatabaseAccountName))
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, nil, m, nil, os.Getenv(DatabaseAccountName), nil)
 	if err != nil {
 		return err
 	}
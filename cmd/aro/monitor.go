@@ -92,7 +92,7 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName)
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, &noop.Noop{}, aead, dbAccountName, nil)
 	if err != nil {
 		return err
 	}
@@ -116,6 +116,11 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
+	dbClusterHealth, err := database.NewClusterHealth(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
 	dialer, err := proxy.NewDialer(_env.IsLocalDevelopmentMode())
 	if err != nil {
 		return err
@@ -126,7 +131,7 @@ func monitor(ctx context.Context, log *logrus.Entry) error {
 		return err
 	}
 
-	mon := pkgmonitor.NewMonitor(log.WithField("component", "monitor"), dialer, dbMonitors, dbOpenShiftClusters, dbSubscriptions, m, clusterm, liveConfig)
+	mon := pkgmonitor.NewMonitor(log.WithField("component", "monitor"), dialer, dbMonitors, dbOpenShiftClusters, dbSubscriptions, dbClusterHealth, m, clusterm, liveConfig)
 
 	return mon.Run(ctx)
 }
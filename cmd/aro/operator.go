@@ -15,8 +15,11 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/env"
 	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/admissionwebhooks"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/alertwebhook"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/apiserveraudit"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/autosizednodes"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/azurefilecsi"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/banner"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/checkers/clusterdnschecker"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/checkers/ingresscertificatechecker"
@@ -24,25 +27,43 @@ import (
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/checkers/serviceprincipalchecker"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/cloudproviderconfig"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/clusteroperatoraro"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/customerloadbalancer"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/defaultnodeselector"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/dnsmasq"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/egressfirewall"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/etcdmaintenance"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/genevalogging"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/guardrails"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/identityprovider"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/imageconfig"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/ingress"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/ipaddresses"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/logforwarding"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/machine"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/machinehealthcheck"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/machineset"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/monitoring"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/muo"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/node"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/operatorresources"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/podsecurityadmission"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/previewfeature"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/projecttemplate"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/pullsecret"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/rbac"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/registrymirror"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/resourcetags"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/routefix"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/selinux"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/storageaccounts"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/storageclass"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/subnetaddresses"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/subnets"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/timeconfig"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/upgradeorder"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/workaround"
 	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	// +kubebuilder:scaffold:imports
 )
@@ -142,6 +163,66 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			client, dh)).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", dnsmasq.MachineConfigPoolControllerName, err)
 		}
+		if err = (timeconfig.NewReconciler(
+			log.WithField("controller", timeconfig.ControllerName),
+			client, dh)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", timeconfig.ControllerName, err)
+		}
+		if err = (selinux.NewReconciler(
+			log.WithField("controller", selinux.ControllerName),
+			client, dh)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", selinux.ControllerName, err)
+		}
+		if err = (upgradeorder.NewReconciler(
+			log.WithField("controller", upgradeorder.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", upgradeorder.ControllerName, err)
+		}
+		if err = (projecttemplate.NewReconciler(
+			log.WithField("controller", projecttemplate.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", projecttemplate.ControllerName, err)
+		}
+		if err = (identityprovider.NewReconciler(
+			log.WithField("controller", identityprovider.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", identityprovider.ControllerName, err)
+		}
+		if err = (registrymirror.NewReconciler(
+			log.WithField("controller", registrymirror.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", registrymirror.ControllerName, err)
+		}
+		if err = (storageclass.NewReconciler(
+			log.WithField("controller", storageclass.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", storageclass.ControllerName, err)
+		}
+		if err = (azurefilecsi.NewReconciler(
+			log.WithField("controller", azurefilecsi.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", azurefilecsi.ControllerName, err)
+		}
+		if err = (egressfirewall.NewReconciler(
+			log.WithField("controller", egressfirewall.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", egressfirewall.ControllerName, err)
+		}
+		if err = (admissionwebhooks.NewReconciler(
+			log.WithField("controller", admissionwebhooks.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", admissionwebhooks.ControllerName, err)
+		}
+		if err = (defaultnodeselector.NewReconciler(
+			log.WithField("controller", defaultnodeselector.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", defaultnodeselector.ControllerName, err)
+		}
+		if err = (logforwarding.NewReconciler(
+			log.WithField("controller", logforwarding.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", logforwarding.ControllerName, err)
+		}
 		if err = (node.NewReconciler(
 			log.WithField("controller", node.ControllerName),
 			client, kubernetescli)).SetupWithManager(mgr); err != nil {
@@ -152,6 +233,31 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			client)).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", subnets.ControllerName, err)
 		}
+		if err = (subnetaddresses.NewReconciler(
+			log.WithField("controller", subnetaddresses.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", subnetaddresses.ControllerName, err)
+		}
+		if err = (resourcetags.NewReconciler(
+			log.WithField("controller", resourcetags.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", resourcetags.ControllerName, err)
+		}
+		if err = (customerloadbalancer.NewReconciler(
+			log.WithField("controller", customerloadbalancer.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", customerloadbalancer.ControllerName, err)
+		}
+		if err = (ipaddresses.NewReconciler(
+			log.WithField("controller", ipaddresses.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", ipaddresses.ControllerName, err)
+		}
+		if err = (podsecurityadmission.NewReconciler(
+			log.WithField("controller", podsecurityadmission.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", podsecurityadmission.ControllerName, err)
+		}
 		if err = (machine.NewReconciler(
 			log.WithField("controller", machine.ControllerName),
 			client, isLocalDevelopmentMode, role)).SetupWithManager(mgr); err != nil {
@@ -171,6 +277,16 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			client)).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", imageconfig.ControllerName, err)
 		}
+		if err = (apiserveraudit.NewReconciler(
+			log.WithField("controller", apiserveraudit.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", apiserveraudit.ControllerName, err)
+		}
+		if err = (etcdmaintenance.NewReconciler(
+			log.WithField("controller", etcdmaintenance.ControllerName),
+			client, etcd.NewClient(log.WithField("controller", etcdmaintenance.ControllerName), restConfig, kubernetescli))).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", etcdmaintenance.ControllerName, err)
+		}
 		if err = (previewfeature.NewReconciler(
 			log.WithField("controller", previewfeature.ControllerName),
 			client)).SetupWithManager(mgr); err != nil {
@@ -226,6 +342,11 @@ func operator(ctx context.Context, log *logrus.Entry) error {
 			client)).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller %s: %v", cloudproviderconfig.ControllerName, err)
 		}
+		if err = (operatorresources.NewReconciler(
+			log.WithField("controller", operatorresources.ControllerName),
+			client)).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create controller %s: %v", operatorresources.ControllerName, err)
+		}
 	}
 
 	if err = (internetchecker.NewReconciler(
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Azure/go-autorest/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 	kmetrics "k8s.io/client-go/tools/metrics"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -36,6 +37,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics/statsd/k8s"
 	"github.com/Azure/ARO-RP/pkg/util/clusterdata"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	utiltracing "github.com/Azure/ARO-RP/pkg/util/tracing"
 )
 
 func rp(ctx context.Context, log, audit *logrus.Entry) error {
@@ -84,7 +86,15 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 
 	go g.Run()
 
-	tracing.Register(azure.New(metrics))
+	tp, err := utiltracing.NewTracerProvider(log.WithField("component", "tracing"), os.Getenv("OTEL_EXPORTER_ZIPKIN_ENDPOINT"))
+	if err != nil {
+		return err
+	}
+
+	go tp.Run(stop)
+
+	otel.SetTracerProvider(tp)
+	tracing.Register(utiltracing.NewAutorestTracer(azure.New(metrics)))
 	kmetrics.Register(kmetrics.RegisterOpts{
 		RequestResult:  k8s.NewResult(metrics),
 		RequestLatency: k8s.NewLatency(metrics),
@@ -112,7 +122,9 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
-	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, metrics, aead, dbAccountName)
+	dbCircuitBreaker := database.NewCircuitBreaker(log.WithField("component", "database"), metrics)
+
+	dbc, err := database.NewDatabaseClient(log.WithField("component", "database"), _env, dbAuthorizer, metrics, aead, dbAccountName, dbCircuitBreaker)
 	if err != nil {
 		return err
 	}
@@ -156,6 +168,11 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 		return err
 	}
 
+	dbClusterHealth, err := database.NewClusterHealth(ctx, dbc, dbName)
+	if err != nil {
+		return err
+	}
+
 	go database.EmitMetrics(ctx, log, dbOpenShiftClusters, metrics)
 
 	feAead, err := encryption.NewMulti(ctx, _env.ServiceKeyvault(), env.FrontendEncryptionSecretV2Name, env.FrontendEncryptionSecretName)
@@ -166,7 +183,7 @@ func rp(ctx context.Context, log, audit *logrus.Entry) error {
 	if err != nil {
 		return err
 	}
-	f, err := frontend.NewFrontend(ctx, audit, log.WithField("component", "frontend"), _env, dbAsyncOperations, dbClusterManagerConfiguration, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, api.APIs, metrics, clusterm, feAead, hiveClusterManager, adminactions.NewKubeActions, adminactions.NewAzureActions, clusterdata.NewParallelEnricher(metrics, _env))
+	f, err := frontend.NewFrontend(ctx, audit, log.WithField("component", "frontend"), _env, dbAsyncOperations, dbClusterManagerConfiguration, dbOpenShiftClusters, dbSubscriptions, dbOpenShiftVersions, dbClusterHealth, dbCircuitBreaker, api.APIs, metrics, clusterm, feAead, hiveClusterManager, adminactions.NewKubeActions, adminactions.NewAzureActions, clusterdata.NewParallelEnricher(metrics, _env))
 	if err != nil {
 		return err
 	}
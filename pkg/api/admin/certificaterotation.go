@@ -0,0 +1,22 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "time"
+
+// CertificateRotation represents the most recent rotation of a custom domain
+// certificate.
+type CertificateRotation struct {
+	// The certificate which was rotated, APIServer or Ingress.
+	Target string `json:"target,omitempty"`
+
+	// The secret holding the certificate.
+	SecretName string `json:"secretName,omitempty"`
+
+	// The time the certificate was rotated.
+	RotatedAt time.Time `json:"rotatedAt,omitempty"`
+
+	// The time the certificate expires.
+	NotAfter time.Time `json:"notAfter,omitempty"`
+}
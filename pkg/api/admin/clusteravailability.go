@@ -0,0 +1,28 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "time"
+
+// ClusterAvailability summarises the availability of a cluster, as observed by
+// the monitor, over a time window.
+type ClusterAvailability struct {
+	// The start and end of the window.
+	WindowStart time.Time `json:"windowStart"`
+	WindowEnd   time.Time `json:"windowEnd"`
+
+	// The time of the window during which the health of the cluster was
+	// known, and the time during which the cluster was unhealthy.
+	ObservedDuration  string `json:"observedDuration"`
+	UnhealthyDuration string `json:"unhealthyDuration"`
+
+	// The percentage of the observed time during which the cluster was
+	// healthy.  It is omitted if the health of the cluster was not known
+	// during the window.
+	UptimePercentage *float64 `json:"uptimePercentage,omitempty"`
+
+	// The number of separate intervals of the window during which the cluster
+	// was unhealthy.
+	DegradedIntervals int `json:"degradedIntervals"`
+}
@@ -0,0 +1,53 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ClusterInventory is a snapshot of the nodes, cluster operators and installed
+// operators of a cluster.
+type ClusterInventory struct {
+	// The OpenShift version reported by the cluster, or recorded by the RP if
+	// the cluster could not be reached.
+	Version string `json:"version,omitempty"`
+
+	Nodes            []*InventoryNode            `json:"nodes"`
+	ClusterOperators []*InventoryClusterOperator `json:"clusterOperators"`
+	Operators        []*InventoryOperator        `json:"operators"`
+
+	// Truncated is true if any of the lists was cut short to bound the size of
+	// the snapshot.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Errors describe the parts of the snapshot which could not be collected.
+	// The snapshot is partial if there are any.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// InventoryNode represents a node of the cluster.
+type InventoryNode struct {
+	Name           string   `json:"name"`
+	Roles          []string `json:"roles,omitempty"`
+	InstanceType   string   `json:"instanceType,omitempty"`
+	KubeletVersion string   `json:"kubeletVersion,omitempty"`
+	OSImage        string   `json:"osImage,omitempty"`
+	Ready          bool     `json:"ready"`
+	Unschedulable  bool     `json:"unschedulable,omitempty"`
+}
+
+// InventoryClusterOperator represents a cluster operator and its conditions.
+type InventoryClusterOperator struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Available   bool   `json:"available"`
+	Progressing bool   `json:"progressing"`
+	Degraded    bool   `json:"degraded"`
+}
+
+// InventoryOperator represents an operator installed by the Operator Lifecycle
+// Manager, i.e. a ClusterServiceVersion.
+type InventoryOperator struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Phase     string `json:"phase,omitempty"`
+}
@@ -0,0 +1,104 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ClusterOperationEventSchemaVersion is the version of the schema of
+// ClusterOperationEvent.  Fields may be added to the schema without changing
+// its version, but fields are never removed or changed in meaning.
+const ClusterOperationEventSchemaVersion = "1.0"
+
+// ClusterOperationEventList is the list of the operations on a cluster, oldest
+// first.
+type ClusterOperationEventList struct {
+	Events []*ClusterOperationEvent `json:"events"`
+}
+
+// ClusterOperationEvent describes a long running operation on a cluster which
+// has ended.
+type ClusterOperationEvent struct {
+	SchemaVersion string `json:"schemaVersion"`
+
+	ResourceID string `json:"resourceId"`
+
+	Operation       ClusterOperation `json:"operation"`
+	MaintenanceTask string           `json:"maintenanceTask,omitempty"`
+
+	// Actor is the principal which requested the operation, if known.
+	Actor         string `json:"actor,omitempty"`
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	DurationSeconds int64     `json:"durationSeconds"`
+
+	Outcome ClusterOperationOutcome `json:"outcome"`
+	Error   string                  `json:"error,omitempty"`
+
+	// Version is the OpenShift version of the cluster when the operation
+	// ended.
+	Version string `json:"version,omitempty"`
+}
+
+// ClusterOperation is the type of an operation on a cluster.
+type ClusterOperation string
+
+// ClusterOperation constants.
+const (
+	ClusterOperationCreate      ClusterOperation = "Create"
+	ClusterOperationUpdate      ClusterOperation = "Update"
+	ClusterOperationAdminUpdate ClusterOperation = "AdminUpdate"
+	ClusterOperationDelete      ClusterOperation = "Delete"
+)
+
+// ClusterOperationOutcome is the outcome of an operation on a cluster.
+type ClusterOperationOutcome string
+
+// ClusterOperationOutcome constants.
+const (
+	ClusterOperationOutcomeSucceeded ClusterOperationOutcome = "Succeeded"
+	ClusterOperationOutcomeFailed    ClusterOperationOutcome = "Failed"
+)
+
+// ClusterOperationEventFromHistory returns the event for an entry of the
+// operation history of the cluster resourceID.
+func ClusterOperationEventFromHistory(resourceID string, e *api.OperationHistoryEntry) *ClusterOperationEvent {
+	event := &ClusterOperationEvent{
+		SchemaVersion:   ClusterOperationEventSchemaVersion,
+		ResourceID:      resourceID,
+		MaintenanceTask: string(e.MaintenanceTask),
+		Actor:           e.ClientPrincipalName,
+		CorrelationID:   e.CorrelationID,
+		StartTime:       e.StartTime.UTC(),
+		EndTime:         e.EndTime.UTC(),
+		DurationSeconds: int64(e.EndTime.Sub(e.StartTime).Seconds()),
+		Outcome:         ClusterOperationOutcomeSucceeded,
+		Error:           e.Error,
+		Version:         e.Version,
+	}
+
+	switch e.InitialProvisioningState {
+	case api.ProvisioningStateCreating:
+		event.Operation = ClusterOperationCreate
+	case api.ProvisioningStateUpdating:
+		event.Operation = ClusterOperationUpdate
+	case api.ProvisioningStateAdminUpdating:
+		event.Operation = ClusterOperationAdminUpdate
+	case api.ProvisioningStateDeleting:
+		event.Operation = ClusterOperationDelete
+	default:
+		event.Operation = ClusterOperation(e.InitialProvisioningState)
+	}
+
+	if e.ProvisioningState == api.ProvisioningStateFailed {
+		event.Outcome = ClusterOperationOutcomeFailed
+	}
+
+	return event
+}
@@ -0,0 +1,35 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// DeprecatedVersionClusterList is the list of clusters whose OpenShift
+// version is deprecated or past end of life, furthest past end of life first.
+type DeprecatedVersionClusterList struct {
+	Clusters []*DeprecatedVersionCluster `json:"clusters"`
+}
+
+// DeprecatedVersionCluster is a cluster at a deprecated or end of life
+// OpenShift version.
+type DeprecatedVersionCluster struct {
+	ResourceID string                  `json:"resourceId"`
+	Version    string                  `json:"version"`
+	Status     DeprecatedVersionStatus `json:"status"`
+
+	// MinorVersionsPastEndOfLife is the number of minor versions between the
+	// version of the cluster and the oldest supported one.  It is zero for
+	// deprecated versions which are still supported.
+	MinorVersionsPastEndOfLife int `json:"minorVersionsPastEndOfLife"`
+}
+
+// DeprecatedVersionStatus is why the version of a cluster is reported
+type DeprecatedVersionStatus string
+
+const (
+	// DeprecatedVersionStatusDeprecated means that the version, or every
+	// supported version of its minor version, is deprecated.
+	DeprecatedVersionStatusDeprecated DeprecatedVersionStatus = "Deprecated"
+	// DeprecatedVersionStatusEndOfLife means that the minor version is older
+	// than every supported one.
+	DeprecatedVersionStatusEndOfLife DeprecatedVersionStatus = "EndOfLife"
+)
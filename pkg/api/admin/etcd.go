@@ -0,0 +1,49 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "time"
+
+// EtcdMemberStatusList represents the status of the etcd members of a cluster.
+type EtcdMemberStatusList struct {
+	Members []*EtcdMemberStatus `json:"members"`
+}
+
+// EtcdMemberStatus represents the status of an etcd member.
+type EtcdMemberStatus struct {
+	// The name of the etcd pod running the member.
+	Name string `json:"name"`
+
+	// Leader is true if the member is the raft leader.
+	Leader bool `json:"leader"`
+
+	// The size in bytes of the member's database, and of the part of it which
+	// is in use.
+	DBSize      int64 `json:"dbSize"`
+	DBSizeInUse int64 `json:"dbSizeInUse"`
+
+	// The percentage of the database which is unused and would be reclaimed
+	// by a defragmentation.
+	FragmentationPercentage float64 `json:"fragmentationPercentage"`
+}
+
+// EtcdBackup represents an etcd backup stored in the cluster storage account.
+// The encryption key of the backup is never returned.
+type EtcdBackup struct {
+	// The name of the backup blob in the etcd-backups container.
+	Name string `json:"name,omitempty"`
+
+	// The time the backup was taken.
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	// The cluster version at the time of the backup.  A backup can only be
+	// restored to a cluster at the same version.
+	ClusterVersion string `json:"clusterVersion,omitempty"`
+
+	// The master node the backup was taken from.
+	Node string `json:"node,omitempty"`
+
+	// The SHA-256 digest of the encrypted backup blob.
+	SHA256 string `json:"sha256,omitempty"`
+}
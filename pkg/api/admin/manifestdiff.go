@@ -0,0 +1,38 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OperatorManifestDiffList represents the ARO-owned manifests which differ
+// between what the RP would apply and what is in the cluster.
+type OperatorManifestDiffList struct {
+	Manifests []*OperatorManifestDiff `json:"manifests"`
+}
+
+// OperatorManifestDiff represents a manifest which differs from the cluster.
+type OperatorManifestDiff struct {
+	// The group and kind of the object, e.g. Deployment.apps.
+	GroupKind string `json:"groupKind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Missing is true if the object does not exist in the cluster.
+	Missing bool `json:"missing,omitempty"`
+
+	Fields []*OperatorManifestFieldDiff `json:"fields,omitempty"`
+}
+
+// OperatorManifestFieldDiff represents a field which differs from the
+// cluster.
+type OperatorManifestFieldDiff struct {
+	// The path of the field, e.g. spec.template.spec.containers[0].image.
+	Path string `json:"path"`
+
+	// Change is Added if the field is only set in the desired manifest,
+	// Removed if it is only set in the cluster and Changed otherwise.
+	Change string `json:"change"`
+
+	// The desired and actual values.  These are omitted for Secrets.
+	Desired interface{} `json:"desired,omitempty"`
+	Actual  interface{} `json:"actual,omitempty"`
+}
@@ -28,36 +28,65 @@ type OpenShiftCluster struct {
 
 // OpenShiftClusterProperties represents an OpenShift cluster's properties.
 type OpenShiftClusterProperties struct {
-	ArchitectureVersion     ArchitectureVersion     `json:"architectureVersion"` // ArchitectureVersion is int so 0 is valid value to be returned
-	ProvisioningState       ProvisioningState       `json:"provisioningState,omitempty"`
-	LastProvisioningState   ProvisioningState       `json:"lastProvisioningState,omitempty"`
-	FailedProvisioningState ProvisioningState       `json:"failedProvisioningState,omitempty"`
-	LastAdminUpdateError    string                  `json:"lastAdminUpdateError,omitempty"`
-	MaintenanceTask         MaintenanceTask         `json:"maintenanceTask,omitempty" mutable:"true"`
-	OperatorFlags           OperatorFlags           `json:"operatorFlags,omitempty" mutable:"true"`
-	OperatorVersion         string                  `json:"operatorVersion,omitempty" mutable:"true"`
-	CreatedAt               time.Time               `json:"createdAt,omitempty"`
-	CreatedBy               string                  `json:"createdBy,omitempty"`
-	ProvisionedBy           string                  `json:"provisionedBy,omitempty"`
-	ClusterProfile          ClusterProfile          `json:"clusterProfile,omitempty"`
-	FeatureProfile          FeatureProfile          `json:"featureProfile,omitempty"`
-	ConsoleProfile          ConsoleProfile          `json:"consoleProfile,omitempty"`
-	ServicePrincipalProfile ServicePrincipalProfile `json:"servicePrincipalProfile,omitempty"`
-	NetworkProfile          NetworkProfile          `json:"networkProfile,omitempty"`
-	MasterProfile           MasterProfile           `json:"masterProfile,omitempty"`
+	ArchitectureVersion        ArchitectureVersion          `json:"architectureVersion"` // ArchitectureVersion is int so 0 is valid value to be returned
+	ProvisioningState          ProvisioningState            `json:"provisioningState,omitempty"`
+	LastProvisioningState      ProvisioningState            `json:"lastProvisioningState,omitempty"`
+	FailedProvisioningState    ProvisioningState            `json:"failedProvisioningState,omitempty"`
+	LastAdminUpdateError       string                       `json:"lastAdminUpdateError,omitempty"`
+	MaintenanceTask            MaintenanceTask              `json:"maintenanceTask,omitempty" mutable:"true"`
+	MaintenanceTaskParameters  *MaintenanceTaskParameters   `json:"maintenanceTaskParameters,omitempty" mutable:"true"`
+	Quarantine                 *Quarantine                  `json:"quarantine,omitempty"`
+	NodeUpdatesPause           *NodeUpdatesPause            `json:"nodeUpdatesPause,omitempty"`
+	SupportAnnotations         map[string]SupportAnnotation `json:"supportAnnotations,omitempty"`
+	EtcdBackups                []EtcdBackup                 `json:"etcdBackups,omitempty"`
+	CertificateRotations       []CertificateRotation        `json:"certificateRotations,omitempty"`
+	MaintenanceWindow          *MaintenanceWindow           `json:"maintenanceWindow,omitempty"`
+	OverrideMaintenanceWindow  bool                         `json:"overrideMaintenanceWindow,omitempty" mutable:"true"`
+	MaintenanceDeferredUntil   *time.Time                   `json:"maintenanceDeferredUntil,omitempty"`
+	ProjectTemplateProfile     *ProjectTemplateProfile      `json:"projectTemplateProfile,omitempty"`
+	IdentityProviderProfile    *IdentityProviderProfile     `json:"identityProviderProfile,omitempty"`
+	RegistryMirrorProfiles     []RegistryMirrorProfile      `json:"registryMirrorProfiles,omitempty"`
+	DefaultStorageClassProfile *DefaultStorageClassProfile  `json:"defaultStorageClassProfile,omitempty"`
+	AzureFileCSIProfile        *AzureFileCSIProfile         `json:"azureFileCsiProfile,omitempty"`
+	MonitoringProfile          *MonitoringProfile           `json:"monitoringProfile,omitempty"`
+	UpgradeProfile             *UpgradeProfile              `json:"upgradeProfile,omitempty"`
+	NodeEvictionProfile        *NodeEvictionProfile         `json:"nodeEvictionProfile,omitempty"`
+	SecurityProfile            *SecurityProfile             `json:"securityProfile,omitempty"`
+	LogForwardingProfile       *LogForwardingProfile        `json:"logForwardingProfile,omitempty"`
+	AdmissionWebhookProfiles   []AdmissionWebhookProfile    `json:"admissionWebhookProfiles,omitempty"`
+	SchedulerProfile           *SchedulerProfile            `json:"schedulerProfile,omitempty"`
+	EgressFirewallProfile      *EgressFirewallProfile       `json:"egressFirewallProfile,omitempty"`
+	OperatorFlags              OperatorFlags                `json:"operatorFlags,omitempty" mutable:"true"`
+	OperatorVersion            string                       `json:"operatorVersion,omitempty" mutable:"true"`
+	CreatedAt                  time.Time                    `json:"createdAt,omitempty"`
+	CreatedBy                  string                       `json:"createdBy,omitempty"`
+	ProvisionedBy              string                       `json:"provisionedBy,omitempty"`
+	RPVersionPin               string                       `json:"rpVersionPin,omitempty" mutable:"true"`
+	DNSOverrides               *DNSOverrides                `json:"dnsOverrides,omitempty" mutable:"true"`
+	ClusterProfile             ClusterProfile               `json:"clusterProfile,omitempty"`
+	FeatureProfile             FeatureProfile               `json:"featureProfile,omitempty"`
+	ConsoleProfile             ConsoleProfile               `json:"consoleProfile,omitempty"`
+	ServicePrincipalProfile    ServicePrincipalProfile      `json:"servicePrincipalProfile,omitempty"`
+	ClusterIdentities          []ClusterIdentity            `json:"clusterIdentities,omitempty"`
+	NetworkProfile             NetworkProfile               `json:"networkProfile,omitempty"`
+	MasterProfile              MasterProfile                `json:"masterProfile,omitempty"`
 	// WorkerProfiles is used to store the worker profile data that was sent in the api request
 	WorkerProfiles []WorkerProfile `json:"workerProfiles,omitempty"`
 	// WorkerProfilesStatus is used to store the enriched worker profile data
-	WorkerProfilesStatus            []WorkerProfile   `json:"workerProfilesStatus,omitempty"`
-	APIServerProfile                APIServerProfile  `json:"apiserverProfile,omitempty"`
-	IngressProfiles                 []IngressProfile  `json:"ingressProfiles,omitempty"`
-	Install                         *Install          `json:"install,omitempty"`
-	StorageSuffix                   string            `json:"storageSuffix,omitempty"`
-	RegistryProfiles                []RegistryProfile `json:"registryProfiles,omitempty"`
-	ImageRegistryStorageAccountName string            `json:"imageRegistryStorageAccountName,omitempty"`
-	InfraID                         string            `json:"infraId,omitempty"`
-	HiveProfile                     HiveProfile       `json:"hiveProfile,omitempty"`
-	PucmPending                     bool              `json:"pucmPending,omitempty"`
+	WorkerProfilesStatus []WorkerProfile `json:"workerProfilesStatus,omitempty"`
+	// WorkerProfilesScaleStatus is used to store the progress of the worker profile scale operations
+	WorkerProfilesScaleStatus []WorkerProfileScale `json:"workerProfilesScaleStatus,omitempty"`
+	// WorkerMachineSetsProgress is used to store the progress of the creation of the worker machine sets during install
+	WorkerMachineSetsProgress       *WorkerMachineSetsProgress `json:"workerMachineSetsProgress,omitempty"`
+	APIServerProfile                APIServerProfile           `json:"apiserverProfile,omitempty"`
+	IngressProfiles                 []IngressProfile           `json:"ingressProfiles,omitempty"`
+	Install                         *Install                   `json:"install,omitempty"`
+	StorageSuffix                   string                     `json:"storageSuffix,omitempty"`
+	RegistryProfiles                []RegistryProfile          `json:"registryProfiles,omitempty"`
+	ImageRegistryStorageAccountName string                     `json:"imageRegistryStorageAccountName,omitempty"`
+	InfraID                         string                     `json:"infraId,omitempty"`
+	HiveProfile                     HiveProfile                `json:"hiveProfile,omitempty"`
+	PucmPending                     bool                       `json:"pucmPending,omitempty"`
 }
 
 // ProvisioningState represents a provisioning state.
@@ -85,21 +114,246 @@ const (
 type MaintenanceTask string
 
 const (
-	MaintenanceTaskEverything  MaintenanceTask = "Everything"
-	MaintenanceTaskOperator    MaintenanceTask = "OperatorUpdate"
-	MaintenanceTaskRenewCerts  MaintenanceTask = "CertificatesRenewal"
-	MaintenanceTaskPucmPending MaintenanceTask = "PucmPending"
+	MaintenanceTaskEverything            MaintenanceTask = "Everything"
+	MaintenanceTaskOperator              MaintenanceTask = "OperatorUpdate"
+	MaintenanceTaskRenewCerts            MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskRenewExpiringCerts    MaintenanceTask = "ExpiringCertificatesRenewal"
+	MaintenanceTaskPucmPending           MaintenanceTask = "PucmPending"
+	MaintenanceTaskSyncClusterProperties MaintenanceTask = "SyncClusterProperties"
+	MaintenanceTaskEtcdDefrag            MaintenanceTask = "EtcdDefragmentation"
+	MaintenanceTaskEtcdBackup            MaintenanceTask = "EtcdBackup"
+	MaintenanceTaskEtcdRestore           MaintenanceTask = "EtcdRestore"
+	MaintenanceTaskRebootNode            MaintenanceTask = "RebootNode"
+	MaintenanceTaskDrainMachineSet       MaintenanceTask = "DrainMachineSet"
+	MaintenanceTaskRotateCertificates    MaintenanceTask = "RotateCertificates"
 )
 
+// MaintenanceTaskParameters holds the parameters of a maintenance task.
+type MaintenanceTaskParameters struct {
+	// The name of the etcd backup restored by the EtcdRestore task.
+	EtcdBackupName string `json:"etcdBackupName,omitempty"`
+
+	// The name of the node rebooted by the RebootNode task.
+	VMName string `json:"vmName,omitempty"`
+
+	// The name of the machine set drained by the DrainMachineSet task.
+	MachineSetName string `json:"machineSetName,omitempty"`
+
+	// How long the RebootNode and DrainMachineSet tasks wait for the pods on
+	// a node to be evicted, e.g. 30m.  If it is not given, the RebootNode
+	// task refuses to drain a node whose pods are protected by a pod
+	// disruption budget.
+	DrainTimeout string `json:"drainTimeout,omitempty"`
+
+	// The certificate of the API server installed by the RotateCertificates
+	// task, valid for api.<domain>.  It is a PEM bundle of the private key
+	// and the certificate chain, leaf first.  It is never returned.
+	APIServerCertificate string `json:"apiServerCertificate,omitempty"`
+
+	// The default certificate of the ingress installed by the
+	// RotateCertificates task, valid for *.apps.<domain>.  It is a PEM bundle
+	// of the private key and the certificate chain, leaf first.  It is never
+	// returned.
+	IngressCertificate string `json:"ingressCertificate,omitempty"`
+}
+
+// Quarantine records why and when a cluster was quarantined.
+type Quarantine struct {
+	Reason        string    `json:"reason,omitempty"`
+	QuarantinedAt time.Time `json:"quarantinedAt,omitempty"`
+}
+
+// SupportAnnotation represents a note about the cluster on a support case.
+type SupportAnnotation struct {
+	Note        string    `json:"note,omitempty"`
+	AnnotatedAt time.Time `json:"annotatedAt,omitempty"`
+}
+
+// MaintenanceWindow represents the customer's weekly maintenance window.
+type MaintenanceWindow struct {
+	Days          []string `json:"days,omitempty"`
+	StartTime     string   `json:"startTime,omitempty"`
+	DurationHours int      `json:"durationHours,omitempty"`
+	TimeZone      string   `json:"timeZone,omitempty"`
+}
+
+// DNSOverrides represents how the hostnames of the cluster are resolved when
+// the RP dials the cluster.
+type DNSOverrides struct {
+	Hosts       []DNSHostOverride `json:"hosts,omitempty"`
+	Nameservers []string          `json:"nameservers,omitempty"`
+}
+
+// DNSHostOverride maps a hostname to a fixed IP address.
+type DNSHostOverride struct {
+	Hostname  string `json:"hostname,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// ProjectTemplateProfile represents the default resource quota and limit
+// range of projects requested by users.
+type ProjectTemplateProfile struct {
+	ResourceQuota   map[string]string `json:"resourceQuota,omitempty"`
+	DefaultLimits   map[string]string `json:"defaultLimits,omitempty"`
+	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
+}
+
+// IdentityProviderProfile represents the OpenID Connect identity provider of
+// the cluster.  The client secret is not exposed.
+type IdentityProviderProfile struct {
+	Name     string `json:"name,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// RegistryMirrorProfile represents a pull-through cache from which images of
+// the Source registry are pulled
+type RegistryMirrorProfile struct {
+	Source string `json:"source,omitempty"`
+	Mirror string `json:"mirror,omitempty"`
+}
+
+// DefaultStorageClassProfile represents the default storage class of the
+// cluster
+type DefaultStorageClassProfile struct {
+	Name       string            `json:"name,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AzureFileCSIProfile represents the Azure Files storage class of the
+// cluster for ReadWriteMany volumes
+type AzureFileCSIProfile struct {
+	StorageClassName string `json:"storageClassName,omitempty"`
+	SKUName          string `json:"skuName,omitempty"`
+}
+
+// MonitoringProfile represents the retention and persistent storage of the
+// platform Prometheus
+type MonitoringProfile struct {
+	Retention        string `json:"retention,omitempty"`
+	StorageSize      string `json:"storageSize,omitempty"`
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// UpgradeProfile represents how the worker nodes are updated during upgrades
+type UpgradeProfile struct {
+	LastNodeName   string `json:"lastNodeName,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// NodeUpdatesPause records why and until when the rollout of updates to the
+// worker nodes is paused by an admin.
+type NodeUpdatesPause struct {
+	Reason      string    `json:"reason,omitempty"`
+	PausedUntil time.Time `json:"pausedUntil,omitempty"`
+}
+
+// NodeEvictionProfile represents the kubelet eviction thresholds of the nodes
+type NodeEvictionProfile struct {
+	Hard            *EvictionThresholds `json:"hard,omitempty"`
+	Soft            *EvictionThresholds `json:"soft,omitempty"`
+	SoftGracePeriod string              `json:"softGracePeriod,omitempty"`
+}
+
+// EvictionThresholds represents the kubelet eviction thresholds of each
+// eviction signal
+type EvictionThresholds struct {
+	MemoryAvailable  string `json:"memoryAvailable,omitempty"`
+	NodeFSAvailable  string `json:"nodefsAvailable,omitempty"`
+	ImageFSAvailable string `json:"imagefsAvailable,omitempty"`
+}
+
+// SecurityProfile represents the seccomp and SELinux defaults of the nodes
+type SecurityProfile struct {
+	DefaultSeccompProfile SeccompProfile `json:"defaultSeccompProfile,omitempty"`
+	SELinuxBooleans       []string       `json:"seLinuxBooleans,omitempty"`
+}
+
+// SeccompProfile represents the seccomp profile of containers which do not
+// set one
+type SeccompProfile string
+
+// SeccompProfile constants
+const (
+	SeccompProfileUnconfined     SeccompProfile = "Unconfined"
+	SeccompProfileRuntimeDefault SeccompProfile = "RuntimeDefault"
+)
+
+// LogForwardingProfile represents the off-cluster destination to which the
+// cluster logs are forwarded
+type LogForwardingProfile struct {
+	Type        LogForwardingType `json:"type,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	WorkspaceID string            `json:"workspaceId,omitempty"`
+	LogTypes    []LogType         `json:"logTypes,omitempty"`
+}
+
+// LogForwardingType represents the type of a log forwarding destination
+type LogForwardingType string
+
+// LogForwardingType constants
+const (
+	LogForwardingTypeSyslog       LogForwardingType = "Syslog"
+	LogForwardingTypeAzureMonitor LogForwardingType = "AzureMonitor"
+)
+
+// LogType represents a type of cluster logs
+type LogType string
+
+// LogType constants
+const (
+	LogTypeApplication    LogType = "Application"
+	LogTypeInfrastructure LogType = "Infrastructure"
+	LogTypeAudit          LogType = "Audit"
+)
+
+// AdmissionWebhookProfile represents an admission webhook configuration of
+// the customer
+type AdmissionWebhookProfile struct {
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// SchedulerProfile represents the configuration of the cluster scheduler
+type SchedulerProfile struct {
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+}
+
+// EgressFirewallProfile represents the destinations outside the cluster to
+// which the pods of the customer namespaces may connect
+type EgressFirewallProfile struct {
+	AllowedDestinations []EgressDestination `json:"allowedDestinations,omitempty"`
+}
+
+// EgressDestination represents a destination outside the cluster
+type EgressDestination struct {
+	CIDR    string `json:"cidr,omitempty"`
+	DNSName string `json:"dnsName,omitempty"`
+}
+
 // Operator feature flags
 type OperatorFlags map[string]string
 
+// ExistingResourceGroup determines if the cluster resource group is created by
+// the customer rather than the RP.
+type ExistingResourceGroup string
+
+// ExistingResourceGroup constants.
+const (
+	ExistingResourceGroupEnabled  ExistingResourceGroup = "Enabled"
+	ExistingResourceGroupDisabled ExistingResourceGroup = "Disabled"
+)
+
 // ClusterProfile represents a cluster profile.
 type ClusterProfile struct {
-	Domain               string               `json:"domain,omitempty"`
-	Version              string               `json:"version,omitempty"`
-	ResourceGroupID      string               `json:"resourceGroupId,omitempty"`
-	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
+	Domain                string                `json:"domain,omitempty"`
+	Version               string                `json:"version,omitempty"`
+	ResourceGroupID       string                `json:"resourceGroupId,omitempty"`
+	FipsValidatedModules  FipsValidatedModules  `json:"fipsValidatedModules,omitempty"`
+	DNSRecordTTL          int                   `json:"dnsRecordTtl,omitempty"`
+	ResourceNameTemplate  string                `json:"resourceNameTemplate,omitempty"`
+	ExistingResourceGroup ExistingResourceGroup `json:"existingResourceGroup,omitempty"`
+	TimeZone              string                `json:"timeZone,omitempty"`
+	DNSZoneID             string                `json:"dnsZoneId,omitempty"`
 }
 
 // FeatureProfile represents a feature profile.
@@ -118,6 +372,17 @@ type ServicePrincipalProfile struct {
 	SPObjectID string `json:"spObjectId,omitempty"`
 }
 
+// ClusterIdentityComponent represents the Azure component of the cluster
+// which uses an identity.
+type ClusterIdentityComponent string
+
+// ClusterIdentity represents an Azure identity used by a cluster component.
+type ClusterIdentity struct {
+	Component ClusterIdentityComponent `json:"component,omitempty"`
+	ClientID  string                   `json:"clientId,omitempty"`
+	ObjectID  string                   `json:"objectId,omitempty"`
+}
+
 // SoftwareDefinedNetwork constants.
 type SoftwareDefinedNetwork string
 
@@ -156,6 +421,10 @@ type LoadBalancerProfile struct {
 	ManagedOutboundIPs *ManagedOutboundIPs `json:"managedOutboundIps,omitempty"`
 	// The list of effective outbound IP addresses of the public load balancer.
 	EffectiveOutboundIPs []EffectiveOutboundIP `json:"effectiveOutboundIps,omitempty"`
+	// The desired managed outbound IP prefix for the cluster public load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of individual managed outbound IPs.
+	ManagedOutboundIPPrefix *ManagedOutboundIPPrefix `json:"managedOutboundIpPrefix,omitempty"`
+	// The effective outbound IP prefix of the public load balancer.
+	EffectiveOutboundIPPrefix *EffectiveOutboundIPPrefix `json:"effectiveOutboundIpPrefix,omitempty"`
 	// The desired outbound IP resources for the cluster load balancer.
 	OutboundIPs []OutboundIP `json:"outboundIps,omitempty"`
 	// The desired outbound IP Prefix resources for the cluster load balancer.
@@ -173,6 +442,20 @@ type ManagedOutboundIPs struct {
 	Count int `json:"count,omitempty"`
 }
 
+// ManagedOutboundIPPrefix represents the desired managed outbound IP prefix for the cluster public load balancer.
+type ManagedOutboundIPPrefix struct {
+	// PrefixLength represents the length of the IPv4 public IP prefix created and managed by Azure for the cluster public load balancer.  Allowed values are in the range of 28 - 31.
+	PrefixLength int `json:"prefixLength,omitempty"`
+}
+
+// EffectiveOutboundIPPrefix represents the effective outbound IP prefix of the cluster public load balancer.
+type EffectiveOutboundIPPrefix struct {
+	// The resource ID of the public IP prefix.
+	ID string `json:"id,omitempty"`
+	// The CIDR of the public IP prefix.
+	IPPrefix string `json:"ipPrefix,omitempty"`
+}
+
 // OutboundIP represents a desired outbound IP resource for the cluster load balancer.
 type OutboundIP ResourceReference
 
@@ -188,14 +471,39 @@ type NetworkProfile struct {
 	ServiceCIDR  string       `json:"serviceCidr,omitempty"`
 	MTUSize      MTUSize      `json:"mtuSize,omitempty"`
 	OutboundType OutboundType `json:"outboundType,omitempty" mutable:"true"`
+	MaxPods      int          `json:"maxPods,omitempty"`
+
+	APIServerPrivateEndpointIP  string                       `json:"privateEndpointIp,omitempty"`
+	GatewayPrivateEndpointIP    string                       `json:"gatewayPrivateEndpointIp,omitempty"`
+	GatewayPrivateLinkID        string                       `json:"gatewayPrivateLinkId,omitempty"`
+	PreconfiguredNSG            PreconfiguredNSG             `json:"preconfigureNSG,omitempty"`
+	LoadBalancerProfile         *LoadBalancerProfile         `json:"loadBalancerProfile,omitempty"`
+	CustomerLoadBalancerProfile *CustomerLoadBalancerProfile `json:"customerLoadBalancerProfile,omitempty"`
+	EffectiveOutboundProfile    *EffectiveOutboundProfile    `json:"effectiveOutboundProfile,omitempty"`
+}
+
+// CustomerLoadBalancerProfile represents the customer load balancer which
+// fronts the API server and the default ingress.
+type CustomerLoadBalancerProfile struct {
+	ID                               string `json:"id,omitempty"`
+	APIServerFrontendIPConfiguration string `json:"apiServerFrontendIpConfiguration,omitempty"`
+	IngressFrontendIPConfiguration   string `json:"ingressFrontendIpConfiguration,omitempty"`
+}
 
-	APIServerPrivateEndpointIP string               `json:"privateEndpointIp,omitempty"`
-	GatewayPrivateEndpointIP   string               `json:"gatewayPrivateEndpointIp,omitempty"`
-	GatewayPrivateLinkID       string               `json:"gatewayPrivateLinkId,omitempty"`
-	PreconfiguredNSG           PreconfiguredNSG     `json:"preconfigureNSG,omitempty"`
-	LoadBalancerProfile        *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+// EffectiveOutboundProfile represents the outbound connectivity method which
+// the worker nodes actually use.
+type EffectiveOutboundProfile struct {
+	Method              EffectiveOutboundMethod `json:"method,omitempty"`
+	OutboundIPAddresses []string                `json:"outboundIpAddresses,omitempty"`
+	NATGatewayID        string                  `json:"natGatewayId,omitempty"`
+	RouteTableID        string                  `json:"routeTableId,omitempty"`
+	NextHopIPAddress    string                  `json:"nextHopIpAddress,omitempty"`
 }
 
+// EffectiveOutboundMethod represents the outbound connectivity method which
+// the worker nodes actually use.
+type EffectiveOutboundMethod string
+
 // PreconfiguredNSG represents whether customers want to use their own NSG attached to the subnets
 type PreconfiguredNSG string
 
@@ -214,6 +522,24 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskStorageAccountType represents the storage account type of a managed disk.
+type DiskStorageAccountType string
+
+// DiskStorageAccountType constants
+const (
+	DiskStorageAccountTypePremiumLRS     DiskStorageAccountType = "Premium_LRS"
+	DiskStorageAccountTypeStandardSSDLRS DiskStorageAccountType = "StandardSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking state.
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
 	VMSize              VMSize           `json:"vmSize,omitempty"`
@@ -347,13 +673,38 @@ const (
 
 // WorkerProfile represents a worker profile.
 type WorkerProfile struct {
-	Name                string           `json:"name,omitempty"`
-	VMSize              VMSize           `json:"vmSize,omitempty"`
-	DiskSizeGB          int              `json:"diskSizeGB,omitempty"`
-	SubnetID            string           `json:"subnetId,omitempty"`
-	Count               int              `json:"count,omitempty"`
-	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
-	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	VMSize                 VMSize                 `json:"vmSize,omitempty"`
+	DiskSizeGB             int                    `json:"diskSizeGB,omitempty"`
+	SubnetID               string                 `json:"subnetId,omitempty"`
+	Count                  int                    `json:"count,omitempty"`
+	EncryptionAtHost       EncryptionAtHost       `json:"encryptionAtHost,omitempty"`
+	DiskEncryptionSetID    string                 `json:"diskEncryptionSetId,omitempty"`
+	DiskStorageAccountType DiskStorageAccountType `json:"diskStorageAccountType,omitempty"`
+	AcceleratedNetworking  AcceleratedNetworking  `json:"acceleratedNetworking,omitempty"`
+
+	ProximityPlacementGroupProfile *ProximityPlacementGroupProfile `json:"proximityPlacementGroupProfile,omitempty"`
+}
+
+// ProximityPlacementGroupProfile represents the proximity placement group of
+// the VMs of a worker profile.
+type ProximityPlacementGroupProfile struct {
+	ID string `json:"id,omitempty"`
+}
+
+// WorkerProfileScale represents the scale operation of the machine set of a
+// worker profile.
+type WorkerProfileScale struct {
+	Name            string `json:"name,omitempty"`
+	CurrentReplicas int    `json:"currentReplicas"`
+	TargetReplicas  int    `json:"targetReplicas"`
+}
+
+// WorkerMachineSetsProgress represents the progress of the creation of the
+// worker machine sets.
+type WorkerMachineSetsProgress struct {
+	Created int `json:"created"`
+	Total   int `json:"total"`
 }
 
 // APIServerProfile represents an API server profile.
@@ -378,12 +729,15 @@ type IngressProfile struct {
 	Name       string     `json:"name,omitempty"`
 	Visibility Visibility `json:"visibility,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+	Domain     string     `json:"domain,omitempty"`
 }
 
 // Install represents an install process.
 type Install struct {
-	Now   time.Time    `json:"now,omitempty"`
-	Phase InstallPhase `json:"phase"`
+	Now            time.Time    `json:"now,omitempty"`
+	Phase          InstallPhase `json:"phase"`
+	CompletedSteps []string     `json:"completedSteps,omitempty"`
+	Resumable      bool         `json:"resumable,omitempty"`
 }
 
 // InstallPhase represents an install phase.
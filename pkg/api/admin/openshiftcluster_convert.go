@@ -20,23 +20,31 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		Type:     oc.Type,
 		Location: oc.Location,
 		Properties: OpenShiftClusterProperties{
-			ArchitectureVersion:     ArchitectureVersion(oc.Properties.ArchitectureVersion),
-			ProvisioningState:       ProvisioningState(oc.Properties.ProvisioningState),
-			LastProvisioningState:   ProvisioningState(oc.Properties.LastProvisioningState),
-			FailedProvisioningState: ProvisioningState(oc.Properties.FailedProvisioningState),
-			LastAdminUpdateError:    oc.Properties.LastAdminUpdateError,
-			MaintenanceTask:         MaintenanceTask(oc.Properties.MaintenanceTask),
-			OperatorFlags:           OperatorFlags(oc.Properties.OperatorFlags),
-			OperatorVersion:         oc.Properties.OperatorVersion,
-			CreatedAt:               oc.Properties.CreatedAt,
-			CreatedBy:               oc.Properties.CreatedBy,
-			ProvisionedBy:           oc.Properties.ProvisionedBy,
-			PucmPending:             oc.Properties.PucmPending,
+			ArchitectureVersion:       ArchitectureVersion(oc.Properties.ArchitectureVersion),
+			ProvisioningState:         ProvisioningState(oc.Properties.ProvisioningState),
+			LastProvisioningState:     ProvisioningState(oc.Properties.LastProvisioningState),
+			FailedProvisioningState:   ProvisioningState(oc.Properties.FailedProvisioningState),
+			LastAdminUpdateError:      oc.Properties.LastAdminUpdateError,
+			MaintenanceTask:           MaintenanceTask(oc.Properties.MaintenanceTask),
+			OverrideMaintenanceWindow: oc.Properties.OverrideMaintenanceWindow,
+			MaintenanceDeferredUntil:  oc.Properties.MaintenanceDeferredUntil,
+			OperatorFlags:             OperatorFlags(oc.Properties.OperatorFlags),
+			OperatorVersion:           oc.Properties.OperatorVersion,
+			CreatedAt:                 oc.Properties.CreatedAt,
+			CreatedBy:                 oc.Properties.CreatedBy,
+			ProvisionedBy:             oc.Properties.ProvisionedBy,
+			RPVersionPin:              oc.Properties.RPVersionPin,
+			PucmPending:               oc.Properties.PucmPending,
 			ClusterProfile: ClusterProfile{
-				Domain:               oc.Properties.ClusterProfile.Domain,
-				Version:              oc.Properties.ClusterProfile.Version,
-				ResourceGroupID:      oc.Properties.ClusterProfile.ResourceGroupID,
-				FipsValidatedModules: FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				Domain:                oc.Properties.ClusterProfile.Domain,
+				Version:               oc.Properties.ClusterProfile.Version,
+				ResourceGroupID:       oc.Properties.ClusterProfile.ResourceGroupID,
+				FipsValidatedModules:  FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				DNSRecordTTL:          oc.Properties.ClusterProfile.DNSRecordTTL,
+				ResourceNameTemplate:  oc.Properties.ClusterProfile.ResourceNameTemplate,
+				ExistingResourceGroup: ExistingResourceGroup(oc.Properties.ClusterProfile.ExistingResourceGroup),
+				TimeZone:              oc.Properties.ClusterProfile.TimeZone,
+				DNSZoneID:             oc.Properties.ClusterProfile.DNSZoneID,
 			},
 			FeatureProfile: FeatureProfile{
 				GatewayEnabled: oc.Properties.FeatureProfile.GatewayEnabled,
@@ -54,6 +62,7 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				ServiceCIDR:                oc.Properties.NetworkProfile.ServiceCIDR,
 				MTUSize:                    MTUSize(oc.Properties.NetworkProfile.MTUSize),
 				OutboundType:               OutboundType(oc.Properties.NetworkProfile.OutboundType),
+				MaxPods:                    oc.Properties.NetworkProfile.MaxPods,
 				APIServerPrivateEndpointIP: oc.Properties.NetworkProfile.APIServerPrivateEndpointIP,
 				GatewayPrivateEndpointIP:   oc.Properties.NetworkProfile.GatewayPrivateEndpointIP,
 				GatewayPrivateLinkID:       oc.Properties.NetworkProfile.GatewayPrivateLinkID,
@@ -89,6 +98,19 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			}
 		}
 
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix = &ManagedOutboundIPPrefix{
+				PrefixLength: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength,
+			}
+		}
+
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix = &EffectiveOutboundIPPrefix{
+				ID:       oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.ID,
+				IPPrefix: oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.IPPrefix,
+			}
+		}
+
 		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs != nil {
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]EffectiveOutboundIP, 0, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for _, effectiveOutboundIP := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
@@ -117,18 +139,43 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		}
 	}
 
+	if oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+		out.Properties.NetworkProfile.CustomerLoadBalancerProfile = &CustomerLoadBalancerProfile{
+			ID:                               oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID,
+			APIServerFrontendIPConfiguration: oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.APIServerFrontendIPConfiguration,
+			IngressFrontendIPConfiguration:   oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration,
+		}
+	}
+
+	if oc.Properties.NetworkProfile.EffectiveOutboundProfile != nil {
+		out.Properties.NetworkProfile.EffectiveOutboundProfile = &EffectiveOutboundProfile{
+			Method:              EffectiveOutboundMethod(oc.Properties.NetworkProfile.EffectiveOutboundProfile.Method),
+			OutboundIPAddresses: append([]string(nil), oc.Properties.NetworkProfile.EffectiveOutboundProfile.OutboundIPAddresses...),
+			NATGatewayID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.NATGatewayID,
+			RouteTableID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.RouteTableID,
+			NextHopIPAddress:    oc.Properties.NetworkProfile.EffectiveOutboundProfile.NextHopIPAddress,
+		}
+	}
+
 	if oc.Properties.WorkerProfiles != nil {
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfiles))
 		for _, p := range oc.Properties.WorkerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				DiskStorageAccountType: DiskStorageAccountType(p.DiskStorageAccountType),
+				AcceleratedNetworking:  AcceleratedNetworking(p.AcceleratedNetworking),
 			})
+			if p.ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfiles[len(out.Properties.WorkerProfiles)-1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: p.ProximityPlacementGroupProfile.ID,
+				}
+			}
 		}
 	}
 
@@ -136,13 +183,49 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfilesStatus = make([]WorkerProfile, 0, len(oc.Properties.WorkerProfilesStatus))
 		for _, p := range oc.Properties.WorkerProfilesStatus {
 			out.Properties.WorkerProfilesStatus = append(out.Properties.WorkerProfilesStatus, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				DiskStorageAccountType: DiskStorageAccountType(p.DiskStorageAccountType),
+				AcceleratedNetworking:  AcceleratedNetworking(p.AcceleratedNetworking),
+			})
+			if p.ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfilesStatus[len(out.Properties.WorkerProfilesStatus)-1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: p.ProximityPlacementGroupProfile.ID,
+				}
+			}
+		}
+	}
+
+	if oc.Properties.WorkerProfilesScaleStatus != nil {
+		out.Properties.WorkerProfilesScaleStatus = make([]WorkerProfileScale, 0, len(oc.Properties.WorkerProfilesScaleStatus))
+		for _, s := range oc.Properties.WorkerProfilesScaleStatus {
+			out.Properties.WorkerProfilesScaleStatus = append(out.Properties.WorkerProfilesScaleStatus, WorkerProfileScale{
+				Name:            s.Name,
+				CurrentReplicas: s.CurrentReplicas,
+				TargetReplicas:  s.TargetReplicas,
+			})
+		}
+	}
+
+	if oc.Properties.WorkerMachineSetsProgress != nil {
+		out.Properties.WorkerMachineSetsProgress = &WorkerMachineSetsProgress{
+			Created: oc.Properties.WorkerMachineSetsProgress.Created,
+			Total:   oc.Properties.WorkerMachineSetsProgress.Total,
+		}
+	}
+
+	if oc.Properties.ClusterIdentities != nil {
+		out.Properties.ClusterIdentities = make([]ClusterIdentity, 0, len(oc.Properties.ClusterIdentities))
+		for _, i := range oc.Properties.ClusterIdentities {
+			out.Properties.ClusterIdentities = append(out.Properties.ClusterIdentities, ClusterIdentity{
+				Component: ClusterIdentityComponent(i.Component),
+				ClientID:  i.ClientID,
+				ObjectID:  i.ObjectID,
 			})
 		}
 	}
@@ -154,14 +237,223 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				Name:       p.Name,
 				Visibility: Visibility(p.Visibility),
 				IP:         p.IP,
+				Domain:     p.Domain,
+			})
+		}
+	}
+
+	if oc.Properties.Quarantine != nil {
+		out.Properties.Quarantine = &Quarantine{
+			Reason:        oc.Properties.Quarantine.Reason,
+			QuarantinedAt: oc.Properties.Quarantine.QuarantinedAt,
+		}
+	}
+
+	if oc.Properties.NodeUpdatesPause != nil {
+		out.Properties.NodeUpdatesPause = &NodeUpdatesPause{
+			Reason:      oc.Properties.NodeUpdatesPause.Reason,
+			PausedUntil: oc.Properties.NodeUpdatesPause.PausedUntil,
+		}
+	}
+
+	if oc.Properties.MaintenanceTaskParameters != nil {
+		out.Properties.MaintenanceTaskParameters = &MaintenanceTaskParameters{
+			EtcdBackupName: oc.Properties.MaintenanceTaskParameters.EtcdBackupName,
+			VMName:         oc.Properties.MaintenanceTaskParameters.VMName,
+			MachineSetName: oc.Properties.MaintenanceTaskParameters.MachineSetName,
+			DrainTimeout:   oc.Properties.MaintenanceTaskParameters.DrainTimeout,
+		}
+	}
+
+	if oc.Properties.SupportAnnotations != nil {
+		out.Properties.SupportAnnotations = make(map[string]SupportAnnotation, len(oc.Properties.SupportAnnotations))
+		for caseID, a := range oc.Properties.SupportAnnotations {
+			out.Properties.SupportAnnotations[caseID] = SupportAnnotation{
+				Note:        a.Note,
+				AnnotatedAt: a.AnnotatedAt,
+			}
+		}
+	}
+
+	if oc.Properties.EtcdBackups != nil {
+		out.Properties.EtcdBackups = make([]EtcdBackup, 0, len(oc.Properties.EtcdBackups))
+		for _, b := range oc.Properties.EtcdBackups {
+			out.Properties.EtcdBackups = append(out.Properties.EtcdBackups, EtcdBackup{
+				Name:           b.Name,
+				CreatedAt:      b.CreatedAt,
+				ClusterVersion: b.ClusterVersion,
+				Node:           b.Node,
+				SHA256:         b.SHA256,
+			})
+		}
+	}
+
+	if oc.Properties.CertificateRotations != nil {
+		out.Properties.CertificateRotations = make([]CertificateRotation, 0, len(oc.Properties.CertificateRotations))
+		for _, r := range oc.Properties.CertificateRotations {
+			out.Properties.CertificateRotations = append(out.Properties.CertificateRotations, CertificateRotation{
+				Target:     string(r.Target),
+				SecretName: r.SecretName,
+				RotatedAt:  r.RotatedAt,
+				NotAfter:   r.NotAfter,
+			})
+		}
+	}
+
+	if oc.Properties.MaintenanceWindow != nil {
+		out.Properties.MaintenanceWindow = &MaintenanceWindow{
+			StartTime:     oc.Properties.MaintenanceWindow.StartTime,
+			DurationHours: oc.Properties.MaintenanceWindow.DurationHours,
+			TimeZone:      oc.Properties.MaintenanceWindow.TimeZone,
+		}
+		if oc.Properties.MaintenanceWindow.Days != nil {
+			out.Properties.MaintenanceWindow.Days = make([]string, 0, len(oc.Properties.MaintenanceWindow.Days))
+			for _, day := range oc.Properties.MaintenanceWindow.Days {
+				out.Properties.MaintenanceWindow.Days = append(out.Properties.MaintenanceWindow.Days, string(day))
+			}
+		}
+	}
+
+	if oc.Properties.DNSOverrides != nil {
+		out.Properties.DNSOverrides = &DNSOverrides{}
+		if oc.Properties.DNSOverrides.Hosts != nil {
+			out.Properties.DNSOverrides.Hosts = make([]DNSHostOverride, 0, len(oc.Properties.DNSOverrides.Hosts))
+			for _, h := range oc.Properties.DNSOverrides.Hosts {
+				out.Properties.DNSOverrides.Hosts = append(out.Properties.DNSOverrides.Hosts, DNSHostOverride{
+					Hostname:  h.Hostname,
+					IPAddress: h.IPAddress,
+				})
+			}
+		}
+		if oc.Properties.DNSOverrides.Nameservers != nil {
+			out.Properties.DNSOverrides.Nameservers = append([]string{}, oc.Properties.DNSOverrides.Nameservers...)
+		}
+	}
+
+	if oc.Properties.ProjectTemplateProfile != nil {
+		out.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+			ResourceQuota:   copyQuantities(oc.Properties.ProjectTemplateProfile.ResourceQuota),
+			DefaultLimits:   copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultLimits),
+			DefaultRequests: copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultRequests),
+		}
+	}
+
+	if oc.Properties.IdentityProviderProfile != nil {
+		out.Properties.IdentityProviderProfile = &IdentityProviderProfile{
+			Name:     oc.Properties.IdentityProviderProfile.Name,
+			Issuer:   oc.Properties.IdentityProviderProfile.Issuer,
+			ClientID: oc.Properties.IdentityProviderProfile.ClientID,
+		}
+	}
+
+	if oc.Properties.RegistryMirrorProfiles != nil {
+		out.Properties.RegistryMirrorProfiles = make([]RegistryMirrorProfile, 0, len(oc.Properties.RegistryMirrorProfiles))
+		for _, p := range oc.Properties.RegistryMirrorProfiles {
+			out.Properties.RegistryMirrorProfiles = append(out.Properties.RegistryMirrorProfiles, RegistryMirrorProfile{
+				Source: p.Source,
+				Mirror: p.Mirror,
+			})
+		}
+	}
+
+	if oc.Properties.DefaultStorageClassProfile != nil {
+		out.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+			Name:       oc.Properties.DefaultStorageClassProfile.Name,
+			Parameters: copyQuantities(oc.Properties.DefaultStorageClassProfile.Parameters),
+		}
+	}
+
+	if oc.Properties.AzureFileCSIProfile != nil {
+		out.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+			StorageClassName: oc.Properties.AzureFileCSIProfile.StorageClassName,
+			SKUName:          oc.Properties.AzureFileCSIProfile.SKUName,
+		}
+	}
+
+	if oc.Properties.MonitoringProfile != nil {
+		out.Properties.MonitoringProfile = &MonitoringProfile{
+			Retention:        oc.Properties.MonitoringProfile.Retention,
+			StorageSize:      oc.Properties.MonitoringProfile.StorageSize,
+			StorageClassName: oc.Properties.MonitoringProfile.StorageClassName,
+		}
+	}
+
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &UpgradeProfile{
+			LastNodeName:   oc.Properties.UpgradeProfile.LastNodeName,
+			MaxUnavailable: oc.Properties.UpgradeProfile.MaxUnavailable,
+		}
+	}
+
+	if oc.Properties.NodeEvictionProfile != nil {
+		out.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+			SoftGracePeriod: oc.Properties.NodeEvictionProfile.SoftGracePeriod,
+		}
+		if oc.Properties.NodeEvictionProfile.Hard != nil {
+			out.Properties.NodeEvictionProfile.Hard = &EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Hard.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Hard.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Hard.ImageFSAvailable,
+			}
+		}
+		if oc.Properties.NodeEvictionProfile.Soft != nil {
+			out.Properties.NodeEvictionProfile.Soft = &EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Soft.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Soft.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Soft.ImageFSAvailable,
+			}
+		}
+	}
+
+	if oc.Properties.SecurityProfile != nil {
+		out.Properties.SecurityProfile = &SecurityProfile{
+			DefaultSeccompProfile: SeccompProfile(oc.Properties.SecurityProfile.DefaultSeccompProfile),
+			SELinuxBooleans:       append([]string(nil), oc.Properties.SecurityProfile.SELinuxBooleans...),
+		}
+	}
+
+	if oc.Properties.LogForwardingProfile != nil {
+		out.Properties.LogForwardingProfile = &LogForwardingProfile{
+			Type:        LogForwardingType(oc.Properties.LogForwardingProfile.Type),
+			URL:         oc.Properties.LogForwardingProfile.URL,
+			WorkspaceID: oc.Properties.LogForwardingProfile.WorkspaceID,
+		}
+		for _, t := range oc.Properties.LogForwardingProfile.LogTypes {
+			out.Properties.LogForwardingProfile.LogTypes = append(out.Properties.LogForwardingProfile.LogTypes, LogType(t))
+		}
+	}
+
+	if oc.Properties.AdmissionWebhookProfiles != nil {
+		out.Properties.AdmissionWebhookProfiles = make([]AdmissionWebhookProfile, 0, len(oc.Properties.AdmissionWebhookProfiles))
+		for _, p := range oc.Properties.AdmissionWebhookProfiles {
+			out.Properties.AdmissionWebhookProfiles = append(out.Properties.AdmissionWebhookProfiles, AdmissionWebhookProfile{
+				Configuration: p.Configuration,
+			})
+		}
+	}
+
+	if oc.Properties.SchedulerProfile != nil {
+		out.Properties.SchedulerProfile = &SchedulerProfile{
+			DefaultNodeSelector: oc.Properties.SchedulerProfile.DefaultNodeSelector,
+		}
+	}
+
+	if oc.Properties.EgressFirewallProfile != nil {
+		out.Properties.EgressFirewallProfile = &EgressFirewallProfile{}
+		for _, d := range oc.Properties.EgressFirewallProfile.AllowedDestinations {
+			out.Properties.EgressFirewallProfile.AllowedDestinations = append(out.Properties.EgressFirewallProfile.AllowedDestinations, EgressDestination{
+				CIDR:    d.CIDR,
+				DNSName: d.DNSName,
 			})
 		}
 	}
 
 	if oc.Properties.Install != nil {
 		out.Properties.Install = &Install{
-			Now:   oc.Properties.Install.Now,
-			Phase: InstallPhase(oc.Properties.Install.Phase),
+			Now:            oc.Properties.Install.Now,
+			Phase:          InstallPhase(oc.Properties.Install.Phase),
+			CompletedSteps: append([]string(nil), oc.Properties.Install.CompletedSteps...),
+			Resumable:      oc.Properties.Install.Resumable,
 		}
 	}
 
@@ -230,23 +522,52 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.FailedProvisioningState = api.ProvisioningState(oc.Properties.FailedProvisioningState)
 	out.Properties.LastAdminUpdateError = oc.Properties.LastAdminUpdateError
 	out.Properties.MaintenanceTask = api.MaintenanceTask(oc.Properties.MaintenanceTask)
+	out.Properties.MaintenanceTaskParameters = nil
+	if oc.Properties.MaintenanceTaskParameters != nil {
+		out.Properties.MaintenanceTaskParameters = &api.MaintenanceTaskParameters{
+			EtcdBackupName:       oc.Properties.MaintenanceTaskParameters.EtcdBackupName,
+			VMName:               oc.Properties.MaintenanceTaskParameters.VMName,
+			MachineSetName:       oc.Properties.MaintenanceTaskParameters.MachineSetName,
+			DrainTimeout:         oc.Properties.MaintenanceTaskParameters.DrainTimeout,
+			APIServerCertificate: api.SecureString(oc.Properties.MaintenanceTaskParameters.APIServerCertificate),
+			IngressCertificate:   api.SecureString(oc.Properties.MaintenanceTaskParameters.IngressCertificate),
+		}
+	}
+	out.Properties.OverrideMaintenanceWindow = oc.Properties.OverrideMaintenanceWindow
+	out.Properties.MaintenanceDeferredUntil = oc.Properties.MaintenanceDeferredUntil
 	out.Properties.OperatorFlags = api.OperatorFlags(oc.Properties.OperatorFlags)
 	out.Properties.OperatorVersion = oc.Properties.OperatorVersion
 	out.Properties.CreatedBy = oc.Properties.CreatedBy
 	out.Properties.ProvisionedBy = oc.Properties.ProvisionedBy
+	out.Properties.RPVersionPin = oc.Properties.RPVersionPin
 	out.Properties.PucmPending = oc.Properties.PucmPending
 	out.Properties.ClusterProfile.Domain = oc.Properties.ClusterProfile.Domain
 	out.Properties.ClusterProfile.FipsValidatedModules = api.FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules)
+	out.Properties.ClusterProfile.DNSRecordTTL = oc.Properties.ClusterProfile.DNSRecordTTL
+	out.Properties.ClusterProfile.ResourceNameTemplate = oc.Properties.ClusterProfile.ResourceNameTemplate
+	out.Properties.ClusterProfile.ExistingResourceGroup = api.ExistingResourceGroup(oc.Properties.ClusterProfile.ExistingResourceGroup)
+	out.Properties.ClusterProfile.TimeZone = oc.Properties.ClusterProfile.TimeZone
+	out.Properties.ClusterProfile.DNSZoneID = oc.Properties.ClusterProfile.DNSZoneID
 	out.Properties.ClusterProfile.Version = oc.Properties.ClusterProfile.Version
 	out.Properties.ClusterProfile.ResourceGroupID = oc.Properties.ClusterProfile.ResourceGroupID
 	out.Properties.FeatureProfile.GatewayEnabled = oc.Properties.FeatureProfile.GatewayEnabled
 	out.Properties.ConsoleProfile.URL = oc.Properties.ConsoleProfile.URL
 	out.Properties.ServicePrincipalProfile.ClientID = oc.Properties.ServicePrincipalProfile.ClientID
 	out.Properties.ServicePrincipalProfile.SPObjectID = oc.Properties.ServicePrincipalProfile.SPObjectID
+	out.Properties.ClusterIdentities = nil
+	if oc.Properties.ClusterIdentities != nil {
+		out.Properties.ClusterIdentities = make([]api.ClusterIdentity, len(oc.Properties.ClusterIdentities))
+		for i := range oc.Properties.ClusterIdentities {
+			out.Properties.ClusterIdentities[i].Component = api.ClusterIdentityComponent(oc.Properties.ClusterIdentities[i].Component)
+			out.Properties.ClusterIdentities[i].ClientID = oc.Properties.ClusterIdentities[i].ClientID
+			out.Properties.ClusterIdentities[i].ObjectID = oc.Properties.ClusterIdentities[i].ObjectID
+		}
+	}
 	out.Properties.NetworkProfile.PodCIDR = oc.Properties.NetworkProfile.PodCIDR
 	out.Properties.NetworkProfile.ServiceCIDR = oc.Properties.NetworkProfile.ServiceCIDR
 	out.Properties.NetworkProfile.MTUSize = api.MTUSize(oc.Properties.NetworkProfile.MTUSize)
 	out.Properties.NetworkProfile.OutboundType = api.OutboundType(oc.Properties.NetworkProfile.OutboundType)
+	out.Properties.NetworkProfile.MaxPods = oc.Properties.NetworkProfile.MaxPods
 	out.Properties.NetworkProfile.SoftwareDefinedNetwork = api.SoftwareDefinedNetwork(oc.Properties.NetworkProfile.SoftwareDefinedNetwork)
 	out.Properties.NetworkProfile.APIServerPrivateEndpointIP = oc.Properties.NetworkProfile.APIServerPrivateEndpointIP
 	out.Properties.NetworkProfile.GatewayPrivateEndpointIP = oc.Properties.NetworkProfile.GatewayPrivateEndpointIP
@@ -263,6 +584,17 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 				Count: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs.Count,
 			}
 		}
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix = &api.ManagedOutboundIPPrefix{
+				PrefixLength: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength,
+			}
+		}
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix = &api.EffectiveOutboundIPPrefix{
+				ID:       oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.ID,
+				IPPrefix: oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.IPPrefix,
+			}
+		}
 		if oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs != nil {
 			out.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs = make([]api.OutboundIP, len(oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs))
 			for i := range oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs {
@@ -283,6 +615,24 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 		}
 	}
 
+	if oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+		out.Properties.NetworkProfile.CustomerLoadBalancerProfile = &api.CustomerLoadBalancerProfile{
+			ID:                               oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID,
+			APIServerFrontendIPConfiguration: oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.APIServerFrontendIPConfiguration,
+			IngressFrontendIPConfiguration:   oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration,
+		}
+	}
+
+	if oc.Properties.NetworkProfile.EffectiveOutboundProfile != nil {
+		out.Properties.NetworkProfile.EffectiveOutboundProfile = &api.EffectiveOutboundProfile{
+			Method:              api.EffectiveOutboundMethod(oc.Properties.NetworkProfile.EffectiveOutboundProfile.Method),
+			OutboundIPAddresses: append([]string(nil), oc.Properties.NetworkProfile.EffectiveOutboundProfile.OutboundIPAddresses...),
+			NATGatewayID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.NATGatewayID,
+			RouteTableID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.RouteTableID,
+			NextHopIPAddress:    oc.Properties.NetworkProfile.EffectiveOutboundProfile.NextHopIPAddress,
+		}
+	}
+
 	out.Properties.MasterProfile.VMSize = api.VMSize(oc.Properties.MasterProfile.VMSize)
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
@@ -300,6 +650,13 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].DiskStorageAccountType = api.DiskStorageAccountType(oc.Properties.WorkerProfiles[i].DiskStorageAccountType)
+			out.Properties.WorkerProfiles[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfiles[i].AcceleratedNetworking)
+			if oc.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile = &api.ProximityPlacementGroupProfile{
+					ID: oc.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile.ID,
+				}
+			}
 		}
 	}
 	out.Properties.WorkerProfilesStatus = nil
@@ -313,12 +670,39 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfilesStatus[i].Count = oc.Properties.WorkerProfilesStatus[i].Count
 			out.Properties.WorkerProfilesStatus[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfilesStatus[i].EncryptionAtHost)
 			out.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID = oc.Properties.WorkerProfilesStatus[i].DiskEncryptionSetID
+			out.Properties.WorkerProfilesStatus[i].DiskStorageAccountType = api.DiskStorageAccountType(oc.Properties.WorkerProfilesStatus[i].DiskStorageAccountType)
+			out.Properties.WorkerProfilesStatus[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfilesStatus[i].AcceleratedNetworking)
+			if oc.Properties.WorkerProfilesStatus[i].ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfilesStatus[i].ProximityPlacementGroupProfile = &api.ProximityPlacementGroupProfile{
+					ID: oc.Properties.WorkerProfilesStatus[i].ProximityPlacementGroupProfile.ID,
+				}
+			}
+		}
+	}
+	out.Properties.WorkerProfilesScaleStatus = nil
+	if oc.Properties.WorkerProfilesScaleStatus != nil {
+		out.Properties.WorkerProfilesScaleStatus = make([]api.WorkerProfileScale, len(oc.Properties.WorkerProfilesScaleStatus))
+		for i := range oc.Properties.WorkerProfilesScaleStatus {
+			out.Properties.WorkerProfilesScaleStatus[i].Name = oc.Properties.WorkerProfilesScaleStatus[i].Name
+			out.Properties.WorkerProfilesScaleStatus[i].CurrentReplicas = oc.Properties.WorkerProfilesScaleStatus[i].CurrentReplicas
+			out.Properties.WorkerProfilesScaleStatus[i].TargetReplicas = oc.Properties.WorkerProfilesScaleStatus[i].TargetReplicas
+		}
+	}
+	out.Properties.WorkerMachineSetsProgress = nil
+	if oc.Properties.WorkerMachineSetsProgress != nil {
+		out.Properties.WorkerMachineSetsProgress = &api.WorkerMachineSetsProgress{
+			Created: oc.Properties.WorkerMachineSetsProgress.Created,
+			Total:   oc.Properties.WorkerMachineSetsProgress.Total,
 		}
 	}
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
 	out.Properties.APIServerProfile.URL = oc.Properties.APIServerProfile.URL
 	out.Properties.APIServerProfile.IP = oc.Properties.APIServerProfile.IP
 	out.Properties.APIServerProfile.IntIP = oc.Properties.APIServerProfile.IntIP
+	servingCertificates := map[string]api.SecureString{}
+	for _, p := range out.Properties.IngressProfiles {
+		servingCertificates[p.Name] = p.ServingCertificate
+	}
 	out.Properties.IngressProfiles = nil
 	if oc.Properties.IngressProfiles != nil {
 		out.Properties.IngressProfiles = make([]api.IngressProfile, len(oc.Properties.IngressProfiles))
@@ -326,14 +710,225 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.IngressProfiles[i].Name = oc.Properties.IngressProfiles[i].Name
 			out.Properties.IngressProfiles[i].Visibility = api.Visibility(oc.Properties.IngressProfiles[i].Visibility)
 			out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
+			out.Properties.IngressProfiles[i].Domain = oc.Properties.IngressProfiles[i].Domain
+			out.Properties.IngressProfiles[i].ServingCertificate = servingCertificates[oc.Properties.IngressProfiles[i].Name]
+		}
+	}
+
+	out.Properties.Quarantine = nil
+	if oc.Properties.Quarantine != nil {
+		out.Properties.Quarantine = &api.Quarantine{
+			Reason:        oc.Properties.Quarantine.Reason,
+			QuarantinedAt: oc.Properties.Quarantine.QuarantinedAt,
+		}
+	}
+
+	out.Properties.NodeUpdatesPause = nil
+	if oc.Properties.NodeUpdatesPause != nil {
+		out.Properties.NodeUpdatesPause = &api.NodeUpdatesPause{
+			Reason:      oc.Properties.NodeUpdatesPause.Reason,
+			PausedUntil: oc.Properties.NodeUpdatesPause.PausedUntil,
+		}
+	}
+
+	out.Properties.SupportAnnotations = nil
+	if oc.Properties.SupportAnnotations != nil {
+		out.Properties.SupportAnnotations = make(map[string]api.SupportAnnotation, len(oc.Properties.SupportAnnotations))
+		for caseID, a := range oc.Properties.SupportAnnotations {
+			out.Properties.SupportAnnotations[caseID] = api.SupportAnnotation{
+				Note:        a.Note,
+				AnnotatedAt: a.AnnotatedAt,
+			}
+		}
+	}
+
+	// EtcdBackups are only written by the EtcdBackup maintenance task, and the
+	// external type has no encryption keys, so they are left as they are
+
+	// CertificateRotations are only written by the RotateCertificates
+	// maintenance task, so they are left as they are
+
+	out.Properties.MaintenanceWindow = nil
+	if oc.Properties.MaintenanceWindow != nil {
+		out.Properties.MaintenanceWindow = &api.MaintenanceWindow{
+			StartTime:     oc.Properties.MaintenanceWindow.StartTime,
+			DurationHours: oc.Properties.MaintenanceWindow.DurationHours,
+			TimeZone:      oc.Properties.MaintenanceWindow.TimeZone,
+		}
+		if oc.Properties.MaintenanceWindow.Days != nil {
+			out.Properties.MaintenanceWindow.Days = make([]api.Weekday, len(oc.Properties.MaintenanceWindow.Days))
+			for i, day := range oc.Properties.MaintenanceWindow.Days {
+				out.Properties.MaintenanceWindow.Days[i] = api.Weekday(day)
+			}
+		}
+	}
+
+	out.Properties.DNSOverrides = nil
+	if oc.Properties.DNSOverrides != nil {
+		out.Properties.DNSOverrides = &api.DNSOverrides{}
+		if oc.Properties.DNSOverrides.Hosts != nil {
+			out.Properties.DNSOverrides.Hosts = make([]api.DNSHostOverride, 0, len(oc.Properties.DNSOverrides.Hosts))
+			for _, h := range oc.Properties.DNSOverrides.Hosts {
+				out.Properties.DNSOverrides.Hosts = append(out.Properties.DNSOverrides.Hosts, api.DNSHostOverride{
+					Hostname:  h.Hostname,
+					IPAddress: h.IPAddress,
+				})
+			}
+		}
+		if oc.Properties.DNSOverrides.Nameservers != nil {
+			out.Properties.DNSOverrides.Nameservers = append([]string{}, oc.Properties.DNSOverrides.Nameservers...)
+		}
+	}
+
+	out.Properties.ProjectTemplateProfile = nil
+	if oc.Properties.ProjectTemplateProfile != nil {
+		out.Properties.ProjectTemplateProfile = &api.ProjectTemplateProfile{
+			ResourceQuota:   copyQuantities(oc.Properties.ProjectTemplateProfile.ResourceQuota),
+			DefaultLimits:   copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultLimits),
+			DefaultRequests: copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultRequests),
+		}
+	}
+
+	// the client secret is not exposed, so keep the current one
+	var clientSecret api.SecureString
+	if out.Properties.IdentityProviderProfile != nil {
+		clientSecret = out.Properties.IdentityProviderProfile.ClientSecret
+	}
+	out.Properties.IdentityProviderProfile = nil
+	if oc.Properties.IdentityProviderProfile != nil {
+		out.Properties.IdentityProviderProfile = &api.IdentityProviderProfile{
+			Name:         oc.Properties.IdentityProviderProfile.Name,
+			Issuer:       oc.Properties.IdentityProviderProfile.Issuer,
+			ClientID:     oc.Properties.IdentityProviderProfile.ClientID,
+			ClientSecret: clientSecret,
+		}
+	}
+
+	out.Properties.RegistryMirrorProfiles = nil
+	if oc.Properties.RegistryMirrorProfiles != nil {
+		out.Properties.RegistryMirrorProfiles = make([]api.RegistryMirrorProfile, 0, len(oc.Properties.RegistryMirrorProfiles))
+		for _, p := range oc.Properties.RegistryMirrorProfiles {
+			out.Properties.RegistryMirrorProfiles = append(out.Properties.RegistryMirrorProfiles, api.RegistryMirrorProfile{
+				Source: p.Source,
+				Mirror: p.Mirror,
+			})
+		}
+	}
+
+	out.Properties.DefaultStorageClassProfile = nil
+	if oc.Properties.DefaultStorageClassProfile != nil {
+		out.Properties.DefaultStorageClassProfile = &api.DefaultStorageClassProfile{
+			Name:       oc.Properties.DefaultStorageClassProfile.Name,
+			Parameters: copyQuantities(oc.Properties.DefaultStorageClassProfile.Parameters),
+		}
+	}
+
+	out.Properties.AzureFileCSIProfile = nil
+	if oc.Properties.AzureFileCSIProfile != nil {
+		out.Properties.AzureFileCSIProfile = &api.AzureFileCSIProfile{
+			StorageClassName: oc.Properties.AzureFileCSIProfile.StorageClassName,
+			SKUName:          oc.Properties.AzureFileCSIProfile.SKUName,
+		}
+	}
+
+	out.Properties.MonitoringProfile = nil
+	if oc.Properties.MonitoringProfile != nil {
+		out.Properties.MonitoringProfile = &api.MonitoringProfile{
+			Retention:        oc.Properties.MonitoringProfile.Retention,
+			StorageSize:      oc.Properties.MonitoringProfile.StorageSize,
+			StorageClassName: oc.Properties.MonitoringProfile.StorageClassName,
+		}
+	}
+
+	out.Properties.UpgradeProfile = nil
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &api.UpgradeProfile{
+			LastNodeName:   oc.Properties.UpgradeProfile.LastNodeName,
+			MaxUnavailable: oc.Properties.UpgradeProfile.MaxUnavailable,
+		}
+	}
+
+	out.Properties.NodeEvictionProfile = nil
+	if oc.Properties.NodeEvictionProfile != nil {
+		out.Properties.NodeEvictionProfile = &api.NodeEvictionProfile{
+			SoftGracePeriod: oc.Properties.NodeEvictionProfile.SoftGracePeriod,
+		}
+		if oc.Properties.NodeEvictionProfile.Hard != nil {
+			out.Properties.NodeEvictionProfile.Hard = &api.EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Hard.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Hard.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Hard.ImageFSAvailable,
+			}
+		}
+		if oc.Properties.NodeEvictionProfile.Soft != nil {
+			out.Properties.NodeEvictionProfile.Soft = &api.EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Soft.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Soft.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Soft.ImageFSAvailable,
+			}
+		}
+	}
+
+	out.Properties.SecurityProfile = nil
+	if oc.Properties.SecurityProfile != nil {
+		out.Properties.SecurityProfile = &api.SecurityProfile{
+			DefaultSeccompProfile: api.SeccompProfile(oc.Properties.SecurityProfile.DefaultSeccompProfile),
+			SELinuxBooleans:       append([]string(nil), oc.Properties.SecurityProfile.SELinuxBooleans...),
+		}
+	}
+
+	var sharedKey api.SecureString
+	if out.Properties.LogForwardingProfile != nil {
+		sharedKey = out.Properties.LogForwardingProfile.SharedKey
+	}
+	out.Properties.LogForwardingProfile = nil
+	if oc.Properties.LogForwardingProfile != nil {
+		out.Properties.LogForwardingProfile = &api.LogForwardingProfile{
+			Type:        api.LogForwardingType(oc.Properties.LogForwardingProfile.Type),
+			URL:         oc.Properties.LogForwardingProfile.URL,
+			WorkspaceID: oc.Properties.LogForwardingProfile.WorkspaceID,
+			SharedKey:   sharedKey,
+		}
+		for _, t := range oc.Properties.LogForwardingProfile.LogTypes {
+			out.Properties.LogForwardingProfile.LogTypes = append(out.Properties.LogForwardingProfile.LogTypes, api.LogType(t))
+		}
+	}
+
+	out.Properties.AdmissionWebhookProfiles = nil
+	if oc.Properties.AdmissionWebhookProfiles != nil {
+		out.Properties.AdmissionWebhookProfiles = make([]api.AdmissionWebhookProfile, 0, len(oc.Properties.AdmissionWebhookProfiles))
+		for _, p := range oc.Properties.AdmissionWebhookProfiles {
+			out.Properties.AdmissionWebhookProfiles = append(out.Properties.AdmissionWebhookProfiles, api.AdmissionWebhookProfile{
+				Configuration: p.Configuration,
+			})
+		}
+	}
+
+	out.Properties.SchedulerProfile = nil
+	if oc.Properties.SchedulerProfile != nil {
+		out.Properties.SchedulerProfile = &api.SchedulerProfile{
+			DefaultNodeSelector: oc.Properties.SchedulerProfile.DefaultNodeSelector,
+		}
+	}
+
+	out.Properties.EgressFirewallProfile = nil
+	if oc.Properties.EgressFirewallProfile != nil {
+		out.Properties.EgressFirewallProfile = &api.EgressFirewallProfile{}
+		for _, d := range oc.Properties.EgressFirewallProfile.AllowedDestinations {
+			out.Properties.EgressFirewallProfile.AllowedDestinations = append(out.Properties.EgressFirewallProfile.AllowedDestinations, api.EgressDestination{
+				CIDR:    d.CIDR,
+				DNSName: d.DNSName,
+			})
 		}
 	}
 
 	out.Properties.Install = nil
 	if oc.Properties.Install != nil {
 		out.Properties.Install = &api.Install{
-			Now:   oc.Properties.Install.Now,
-			Phase: api.InstallPhase(oc.Properties.Install.Phase),
+			Now:            oc.Properties.Install.Now,
+			Phase:          api.InstallPhase(oc.Properties.Install.Phase),
+			CompletedSteps: append([]string(nil), oc.Properties.Install.CompletedSteps...),
+			Resumable:      oc.Properties.Install.Resumable,
 		}
 	}
 
@@ -342,3 +937,16 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	// with filling the out.Properties.RegistryProfiles[i].Password as default is "" which erases the original value.
 	// Workaround would be filling the password when receiving request, but it is array and the logic would be to complex.
 }
+
+// copyQuantities returns a copy of a map of resource names to quantities
+func copyQuantities(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
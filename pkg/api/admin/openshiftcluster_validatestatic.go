@@ -4,12 +4,26 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/operator"
 )
 
+// MaxDrainTimeout bounds the drainTimeout which may be given to the
+// RebootNode and DrainMachineSet maintenance tasks
+const MaxDrainTimeout = time.Hour
+
+var rxKubernetesName = regexp.MustCompile(`(?i)^[-a-z0-9.]{1,255}$`)
+
 type openShiftClusterStaticValidator struct{}
 
 // Validate validates an OpenShift cluster
@@ -29,7 +43,22 @@ func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCl
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
 	}
 
-	return validateMaintenanceTask(oc.Properties.MaintenanceTask)
+	err = validateMaintenanceTask(oc.Properties.MaintenanceTask)
+	if err != nil {
+		return err
+	}
+
+	err = validateDNSOverrides(oc.Properties.DNSOverrides, current.Properties.NetworkProfile.APIServerPrivateEndpointIP)
+	if err != nil {
+		return err
+	}
+
+	err = validateMaintenanceTaskParameters(oc, current)
+	if err != nil {
+		return err
+	}
+
+	return validateOperatorFlags(oc.Properties.OperatorFlags)
 }
 
 func validateMaintenanceTask(task MaintenanceTask) error {
@@ -37,9 +66,202 @@ func validateMaintenanceTask(task MaintenanceTask) error {
 		task == MaintenanceTaskEverything ||
 		task == MaintenanceTaskOperator ||
 		task == MaintenanceTaskRenewCerts ||
-		task == MaintenanceTaskPucmPending) {
+		task == MaintenanceTaskRenewExpiringCerts ||
+		task == MaintenanceTaskPucmPending ||
+		task == MaintenanceTaskSyncClusterProperties ||
+		task == MaintenanceTaskEtcdDefrag ||
+		task == MaintenanceTaskEtcdBackup ||
+		task == MaintenanceTaskEtcdRestore ||
+		task == MaintenanceTaskRebootNode ||
+		task == MaintenanceTaskDrainMachineSet ||
+		task == MaintenanceTaskRotateCertificates) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTask", "Invalid enum parameter.")
 	}
 
 	return nil
 }
+
+// validateMaintenanceTaskParameters checks that the maintenance task is given
+// the parameters it needs, and no others
+func validateMaintenanceTaskParameters(oc, current *OpenShiftCluster) error {
+	params := oc.Properties.MaintenanceTaskParameters
+	if params == nil {
+		params = &MaintenanceTaskParameters{}
+	}
+
+	// the parameters which the task doesn't take
+	unexpected := *params
+
+	switch oc.Properties.MaintenanceTask {
+	case MaintenanceTaskEtcdRestore:
+		err := validateEtcdRestoreParameters(params, current)
+		if err != nil {
+			return err
+		}
+		unexpected.EtcdBackupName = ""
+	case MaintenanceTaskRebootNode:
+		err := validateRebootNodeParameters(params)
+		if err != nil {
+			return err
+		}
+		unexpected.VMName = ""
+		unexpected.DrainTimeout = ""
+	case MaintenanceTaskDrainMachineSet:
+		err := validateDrainMachineSetParameters(params)
+		if err != nil {
+			return err
+		}
+		unexpected.MachineSetName = ""
+		unexpected.DrainTimeout = ""
+	case MaintenanceTaskRotateCertificates:
+		if params.APIServerCertificate == "" && params.IngressCertificate == "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters", "The apiServerCertificate or the ingressCertificate to rotate must be given.")
+		}
+		unexpected.APIServerCertificate = ""
+		unexpected.IngressCertificate = ""
+	}
+
+	if unexpected != (MaintenanceTaskParameters{}) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters", "The maintenance task '%s' does not take some of the given parameters.", oc.Properties.MaintenanceTask)
+	}
+
+	return nil
+}
+
+// validateEtcdRestoreParameters checks that the etcd backup to restore was
+// taken at the version the cluster is at
+func validateEtcdRestoreParameters(params *MaintenanceTaskParameters, current *OpenShiftCluster) error {
+	if params.EtcdBackupName == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters.etcdBackupName", "The etcd backup to restore must be given.")
+	}
+
+	for _, b := range current.Properties.EtcdBackups {
+		if b.Name != params.EtcdBackupName {
+			continue
+		}
+
+		if b.ClusterVersion != current.Properties.ClusterProfile.Version {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "properties.maintenanceTaskParameters.etcdBackupName",
+				"The etcd backup '%s' was taken at cluster version %s and cannot be restored to a cluster at version %s.",
+				b.Name, b.ClusterVersion, current.Properties.ClusterProfile.Version)
+		}
+
+		return nil
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters.etcdBackupName", "The etcd backup '%s' was not found.", params.EtcdBackupName)
+}
+
+// validateRebootNodeParameters checks that the node to reboot is named and
+// that the drain timeout, if given, is within bounds
+func validateRebootNodeParameters(params *MaintenanceTaskParameters) error {
+	if !rxKubernetesName.MatchString(params.VMName) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters.vmName", "The provided vmName '%s' is invalid.", params.VMName)
+	}
+
+	return validateDrainTimeout(params.DrainTimeout)
+}
+
+// validateDrainMachineSetParameters checks that the machine set to drain is
+// named and that the drain timeout, if given, is within bounds
+func validateDrainMachineSetParameters(params *MaintenanceTaskParameters) error {
+	if !rxKubernetesName.MatchString(params.MachineSetName) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters.machineSetName", "The provided machineSetName '%s' is invalid.", params.MachineSetName)
+	}
+
+	return validateDrainTimeout(params.DrainTimeout)
+}
+
+func validateDrainTimeout(drainTimeout string) error {
+	if drainTimeout == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(drainTimeout)
+	if err != nil || d <= 0 || d > MaxDrainTimeout {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.maintenanceTaskParameters.drainTimeout", "The provided drainTimeout '%s' is invalid: it must be a duration between 0s and %s.", drainTimeout, MaxDrainTimeout)
+	}
+
+	return nil
+}
+
+// validateDNSOverrides validates the DNS overrides.  The RP can only reach the
+// cluster API at its private endpoint, so hosts may only be overridden to that
+// address.
+func validateDNSOverrides(overrides *DNSOverrides, privateEndpointIP string) error {
+	if overrides == nil {
+		return nil
+	}
+
+	hostnames := map[string]bool{}
+	for i, h := range overrides.Hosts {
+		path := fmt.Sprintf("properties.dnsOverrides.hosts[%d]", i)
+
+		if !validate.RxDomainNameRFC1123.MatchString(strings.ToLower(h.Hostname)) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".hostname", "The provided hostname '%s' is invalid.", h.Hostname)
+		}
+		if hostnames[strings.ToLower(h.Hostname)] {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".hostname", "The hostname '%s' is overridden more than once.", h.Hostname)
+		}
+		hostnames[strings.ToLower(h.Hostname)] = true
+
+		if net.ParseIP(h.IPAddress) == nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ipAddress", "The provided IP address '%s' is invalid.", h.IPAddress)
+		}
+		if !net.ParseIP(h.IPAddress).Equal(net.ParseIP(privateEndpointIP)) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ipAddress", "The provided IP address '%s' is not the API server private endpoint '%s'.", h.IPAddress, privateEndpointIP)
+		}
+	}
+
+	for i, nameserver := range overrides.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			host, port, err := net.SplitHostPort(nameserver)
+			if err == nil {
+				var p int
+				p, err = strconv.Atoi(port)
+				if err == nil && (p < 1 || p > 65535) {
+					err = fmt.Errorf("invalid port %d", p)
+				}
+			}
+			if err != nil || net.ParseIP(host) == nil {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("properties.dnsOverrides.nameservers[%d]", i), "The provided nameserver '%s' is invalid: it must be an IP address, optionally with a port.", nameserver)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOperatorFlags validates the values of operator flags which only
+// accept a fixed set or range of values
+func validateOperatorFlags(flags OperatorFlags) error {
+	switch flags["aro.apiserveraudit.profile"] {
+	case "", "Default", "WriteRequestBodies", "AllRequestBodies":
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.operatorFlags['aro.apiserveraudit.profile']", "Invalid enum parameter: the apiserver audit profile must be one of Default, WriteRequestBodies or AllRequestBodies.")
+	}
+
+	for _, role := range []string{operator.RoleMaster, operator.RoleWorker} {
+		_, err := operator.Resources(flags, role)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.operatorFlags", "The operator resources are invalid: %v.", err)
+		}
+	}
+
+	_, err := operator.EtcdMaintenance(flags)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.operatorFlags", "The etcd maintenance is invalid: %v.", err)
+	}
+
+	_, err = operator.SubnetAddressesThreshold(flags)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.operatorFlags", "The subnet addresses threshold is invalid: %v.", err)
+	}
+
+	_, err = operator.PodSecurityAdmissionLevel(flags)
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.operatorFlags", "The pod security admission level is invalid: %v.", err)
+	}
+
+	return nil
+}
@@ -27,4 +27,21 @@ type OpenShiftVersionProperties struct {
 	OpenShiftPullspec string `json:"openShiftPullspec,omitempty" mutable:"true"`
 	InstallerPullspec string `json:"installerPullspec,omitempty" mutable:"true"`
 	Enabled           bool   `json:"enabled" mutable:"true"`
+
+	// Status is how the version is supported: Installable (the default),
+	// UpgradeOnly or Deprecated.
+	Status OpenShiftVersionStatus `json:"status,omitempty" mutable:"true"`
+
+	// Locations are the regions where the release images of the version are
+	// available.  An empty list means the version is available everywhere.
+	Locations []string `json:"locations,omitempty" mutable:"true"`
 }
+
+// OpenShiftVersionStatus is how an OpenShift version is supported
+type OpenShiftVersionStatus string
+
+const (
+	OpenShiftVersionStatusInstallable OpenShiftVersionStatus = "Installable"
+	OpenShiftVersionStatusUpgradeOnly OpenShiftVersionStatus = "UpgradeOnly"
+	OpenShiftVersionStatusDeprecated  OpenShiftVersionStatus = "Deprecated"
+)
@@ -4,6 +4,8 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"strings"
+
 	"github.com/Azure/ARO-RP/pkg/api"
 )
 
@@ -21,9 +23,15 @@ func (openShiftVersionConverter) ToExternal(v *api.OpenShiftVersion) interface{}
 			OpenShiftPullspec: v.Properties.OpenShiftPullspec,
 			InstallerPullspec: v.Properties.InstallerPullspec,
 			Enabled:           v.Properties.Enabled,
+			Status:            OpenShiftVersionStatus(v.Properties.Status),
 		},
 	}
 
+	if v.Properties.Locations != nil {
+		out.Properties.Locations = make([]string, len(v.Properties.Locations))
+		copy(out.Properties.Locations, v.Properties.Locations)
+	}
+
 	return out
 }
 
@@ -52,4 +60,12 @@ func (c openShiftVersionConverter) ToInternal(_new interface{}, out *api.OpenShi
 	out.Properties.InstallerPullspec = new.Properties.InstallerPullspec
 	out.Properties.OpenShiftPullspec = new.Properties.OpenShiftPullspec
 	out.Properties.Version = new.Properties.Version
+	out.Properties.Status = api.OpenShiftVersionStatus(new.Properties.Status)
+	out.Properties.Locations = nil
+	if new.Properties.Locations != nil {
+		out.Properties.Locations = make([]string, len(new.Properties.Locations))
+		for i, l := range new.Properties.Locations {
+			out.Properties.Locations[i] = strings.ToLower(l)
+		}
+	}
 }
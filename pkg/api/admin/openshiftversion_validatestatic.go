@@ -4,7 +4,9 @@ package admin
 // Licensed under the Apache License 2.0.
 
 import (
+	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
@@ -12,6 +14,8 @@ import (
 
 type openShiftVersionStaticValidator struct{}
 
+var rxLocation = regexp.MustCompile(`(?i)^[a-z0-9]+$`)
+
 // Validate validates an OpenShift cluster
 func (sv openShiftVersionStaticValidator) Static(_new interface{}, _current *api.OpenShiftVersion) error {
 	new := _new.(*OpenShiftVersion)
@@ -45,6 +49,19 @@ func (sv openShiftVersionStaticValidator) validate(new *OpenShiftVersion, isCrea
 	if new.Properties.OpenShiftPullspec == "" {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.openShiftPullspec", "Must be provided")
 	}
+
+	switch new.Properties.Status {
+	case "", OpenShiftVersionStatusInstallable, OpenShiftVersionStatusUpgradeOnly, OpenShiftVersionStatusDeprecated:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.status", "The provided status '%s' is invalid.", new.Properties.Status)
+	}
+
+	for i, location := range new.Properties.Locations {
+		if !rxLocation.MatchString(location) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("properties.locations[%d]", i), "The provided location '%s' is invalid.", location)
+		}
+	}
+
 	return nil
 }
 
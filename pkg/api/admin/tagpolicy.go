@@ -0,0 +1,12 @@
+package admin
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// TagPolicy represents the tags which every cluster created in a subscription
+// must have.  The tags are propagated to the managed resources of the
+// clusters.  An empty list of required tags removes the policy.
+type TagPolicy struct {
+	// The names of the required tags, e.g. owner.
+	RequiredTags []string `json:"requiredTags"`
+}
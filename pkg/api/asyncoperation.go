@@ -20,5 +20,43 @@ type AsyncOperation struct {
 	StartTime time.Time  `json:"startTime,omitempty" deep:"-"`
 	EndTime   *time.Time `json:"endTime,omitempty" deep:"-"`
 
+	// PercentComplete and Properties report the progress of the operation
+	// while it runs.  If it failed, Properties names the step which failed.
+	PercentComplete float64                   `json:"percentComplete,omitempty"`
+	Properties      *AsyncOperationProperties `json:"properties,omitempty"`
+
 	Error *CloudErrorBody `json:"error,omitempty"`
 }
+
+// AsyncOperationProperties represents the progress of an asyncOperation
+type AsyncOperationProperties struct {
+	// Phase is the install phase, for cluster creations
+	Phase string `json:"phase,omitempty"`
+
+	// Step is the public phase of the step which is running, or which
+	// failed, e.g. WaitingForAPIServer
+	Step string `json:"step,omitempty"`
+
+	CompletedSteps int `json:"completedSteps"`
+	TotalSteps     int `json:"totalSteps"`
+}
+
+// NewAsyncOperationProperties returns the progress of the operation running
+// on the cluster, or nil if none was recorded
+func NewAsyncOperationProperties(oc *OpenShiftCluster) *AsyncOperationProperties {
+	progress := oc.Properties.OperationProgress
+	if progress == nil {
+		return nil
+	}
+
+	p := &AsyncOperationProperties{
+		Step:           progress.Step,
+		CompletedSteps: progress.CompletedSteps,
+		TotalSteps:     progress.TotalSteps,
+	}
+	if oc.Properties.Install != nil {
+		p.Phase = oc.Properties.Install.Phase.String()
+	}
+
+	return p
+}
@@ -0,0 +1,60 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import "time"
+
+const (
+	// ClusterHealthRefreshInterval is how often the health of a cluster is
+	// recorded again while it doesn't change, so that the record of a lasting
+	// health doesn't expire
+	ClusterHealthRefreshInterval = 12 * time.Hour
+
+	// ClusterHealthValidity is how long a recorded health holds at most if no
+	// later record follows it, e.g. because the cluster is no longer monitored
+	ClusterHealthValidity = 2 * ClusterHealthRefreshInterval
+
+	// ClusterHealthRetention is how long ClusterHealthDocuments are kept for.
+	// It must match the default TTL of the ClusterHealth collection.
+	ClusterHealthRetention = 30 * 24 * time.Hour
+)
+
+// ClusterHealthDocuments represents cluster health documents.
+// pkg/database/cosmosdb requires its definition.
+type ClusterHealthDocuments struct {
+	Count                  int                      `json:"_count,omitempty"`
+	ResourceID             string                   `json:"_rid,omitempty"`
+	ClusterHealthDocuments []*ClusterHealthDocument `json:"Documents,omitempty"`
+}
+
+// ClusterHealthDocument represents a cluster health document.
+// pkg/database/cosmosdb requires its definition.
+type ClusterHealthDocument struct {
+	MissingFields
+
+	ID          string                 `json:"id,omitempty"`
+	ResourceID  string                 `json:"_rid,omitempty"`
+	Timestamp   int                    `json:"_ts,omitempty"`
+	Self        string                 `json:"_self,omitempty"`
+	ETag        string                 `json:"_etag,omitempty" deep:"-"`
+	Attachments string                 `json:"_attachments,omitempty"`
+	LSN         int                    `json:"_lsn,omitempty"`
+	Metadata    map[string]interface{} `json:"_metadata,omitempty"`
+
+	Key string `json:"key,omitempty"` // also the partition key
+
+	ClusterHealth *ClusterHealth `json:"clusterHealth,omitempty"`
+}
+
+// ClusterHealth represents the health of a cluster, as observed by the
+// monitor.  It holds from Since until the next ClusterHealth of the cluster,
+// or for at most ClusterHealthValidity.
+type ClusterHealth struct {
+	MissingFields
+
+	// Since is the unix time at which the health was observed
+	Since int `json:"since,omitempty"`
+
+	Healthy bool `json:"healthy"`
+}
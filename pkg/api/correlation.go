@@ -25,4 +25,8 @@ type CorrelationData struct {
 
 	// RequestTime is the time that the request was received
 	RequestTime time.Time `json:"requestTime,omitempty"`
+
+	// TraceParent is the W3C traceparent of the request's trace span, which
+	// the backend continues the trace from
+	TraceParent string `json:"traceParent,omitempty"`
 }
@@ -78,30 +78,53 @@ func DefaultOperatorFlags() OperatorFlags {
 	// import this package. We should probably move this somewhere else.
 	// Maybe into a subpackage like `github.com/Azure/ARO-RP/pkg/api/defaults`?
 	return OperatorFlags{
+		"aro.admissionwebhooks.enabled":            flagTrue,
 		"aro.alertwebhook.enabled":                 flagTrue,
+		"aro.apiserveraudit.enabled":               flagTrue,
 		"aro.azuresubnets.enabled":                 flagTrue,
 		"aro.azuresubnets.nsg.managed":             flagTrue,
 		"aro.azuresubnets.serviceendpoint.managed": flagTrue,
 		"aro.banner.enabled":                       flagFalse,
 		"aro.checker.enabled":                      flagTrue,
+		"aro.defaultnodeselector.enabled":          flagTrue,
 		"aro.dnsmasq.enabled":                      flagTrue,
+		"aro.egressfirewall.enabled":               flagTrue,
+		"aro.etcdmaintenance.enabled":              flagTrue,
 		"aro.restartdnsmasq.enabled":               flagTrue,
 		"aro.genevalogging.enabled":                flagTrue,
+		"aro.identityprovider.enabled":             flagTrue,
 		"aro.imageconfig.enabled":                  flagTrue,
 		"aro.ingress.enabled":                      flagTrue,
+		"aro.ingress.replicas":                     "",
+		"aro.ingress.nodeselector":                 "",
+		"aro.ingress.tolerations":                  "",
+		"aro.logforwarding.enabled":                flagTrue,
 		"aro.machine.enabled":                      flagTrue,
 		"aro.machineset.enabled":                   flagTrue,
 		"aro.machinehealthcheck.enabled":           flagTrue,
 		"aro.machinehealthcheck.managed":           flagTrue,
 		"aro.monitoring.enabled":                   flagTrue,
 		"aro.nodedrainer.enabled":                  flagTrue,
+		"aro.operatorresources.enabled":            flagTrue,
+		"aro.podsecurityadmission.enabled":         flagTrue,
+		"aro.projecttemplate.enabled":              flagTrue,
 		"aro.pullsecret.enabled":                   flagTrue,
 		"aro.pullsecret.managed":                   flagTrue,
 		"aro.rbac.enabled":                         flagTrue,
+		"aro.registrymirror.enabled":               flagTrue,
+		"aro.resourcetags.enabled":                 flagTrue,
 		"aro.routefix.enabled":                     flagTrue,
+		"aro.selinux.enabled":                      flagTrue,
 		"aro.storageaccounts.enabled":              flagTrue,
+		"aro.storageclass.enabled":                 flagTrue,
+		"aro.subnetaddresses.enabled":              flagTrue,
+		"aro.ipaddresses.enabled":                  flagTrue,
+		"aro.customerloadbalancer.enabled":         flagTrue,
+		"aro.timeconfig.enabled":                   flagTrue,
 		"aro.workaround.enabled":                   flagTrue,
 		"aro.autosizednodes.enabled":               flagTrue,
+		"aro.azurefilecsi.enabled":                 flagTrue,
+		"aro.upgradeorder.enabled":                 flagTrue,
 		"rh.srep.muo.enabled":                      flagTrue,
 		"rh.srep.muo.managed":                      flagTrue,
 		"aro.guardrails.enabled":                   flagFalse,
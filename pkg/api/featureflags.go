@@ -22,4 +22,15 @@ const (
 	// FeatureFlagPreconfiguredNSG is used for indicating whether a customer subscription
 	// is registered for customer bringing their own NSG
 	FeatureFlagPreconfiguredNSG = "Microsoft.RedHatOpenShift/PreconfiguredNSG"
+
+	// FeatureFlagSkipSubnetConflictValidation is the feature in the
+	// subscription that disables the validation of the network security group
+	// rules and routes of the cluster subnets, for customers who accept the
+	// risk that their network configuration breaks the cluster
+	FeatureFlagSkipSubnetConflictValidation = "Microsoft.RedHatOpenShift/SkipSubnetConflictValidation"
+
+	// FeatureFlagWorkloadDeletionProtection is the feature in the subscription
+	// which refuses the deletion of clusters which run customer workloads,
+	// unless the deletion is forced
+	FeatureFlagWorkloadDeletionProtection = "Microsoft.RedHatOpenShift/WorkloadDeletionProtection"
 )
@@ -101,6 +101,104 @@ type OpenShiftClusterProperties struct {
 	LastAdminUpdateError    string              `json:"lastAdminUpdateError,omitempty"`
 	MaintenanceTask         MaintenanceTask     `json:"maintenanceTask,omitempty"`
 
+	// MaintenanceTaskParameters holds the parameters of the maintenance task
+	// requested by an admin, for the tasks which take any.  The admin update
+	// clears them when it starts the task, so that a later admin update
+	// does not repeat it.
+	MaintenanceTaskParameters *MaintenanceTaskParameters `json:"maintenanceTaskParameters,omitempty"`
+
+	// Quarantine is non-nil while automated operations on the cluster are
+	// paused, for example during an incident
+	Quarantine *Quarantine `json:"quarantine,omitempty"`
+
+	// NodeUpdatesPause is non-nil while an admin has the rollout of OS and
+	// configuration updates to the worker nodes paused.  It is only written
+	// by the nodeupdatespause admin action and expires at PausedUntil.
+	NodeUpdatesPause *NodeUpdatesPause `json:"nodeUpdatesPause,omitempty"`
+
+	// SupportAnnotations link the cluster to the support cases it is
+	// involved in, keyed by the lower case support case ID.  They are only
+	// written by the supportannotation admin action and are not returned by
+	// the customer API.
+	SupportAnnotations map[string]SupportAnnotation `json:"supportAnnotations,omitempty"`
+
+	// EtcdBackups lists the most recent etcd backups taken by the EtcdBackup
+	// maintenance task, oldest first
+	EtcdBackups []EtcdBackup `json:"etcdBackups,omitempty"`
+
+	// CertificateRotations records the most recent rotation of each custom
+	// domain certificate by the RotateCertificates maintenance task
+	CertificateRotations []CertificateRotation `json:"certificateRotations,omitempty"`
+
+	// MaintenanceWindow, if set, restricts admin updates to the weekly window
+	// chosen by the customer.  Admin updates requested outside the window are
+	// deferred to the start of the next window, recorded in
+	// MaintenanceDeferredUntil, unless OverrideMaintenanceWindow is set for
+	// an urgent or security update.  OverrideMaintenanceWindow and
+	// MaintenanceDeferredUntil are reset when the admin update ends.
+	MaintenanceWindow         *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	OverrideMaintenanceWindow bool               `json:"overrideMaintenanceWindow,omitempty"`
+	MaintenanceDeferredUntil  *time.Time         `json:"maintenanceDeferredUntil,omitempty"`
+
+	// ProjectTemplateProfile, if set, is the default resource quota and
+	// limit range which the ARO operator adds to the projects which users
+	// request
+	ProjectTemplateProfile *ProjectTemplateProfile `json:"projectTemplateProfile,omitempty"`
+
+	// IdentityProviderProfile, if set, is the OpenID Connect identity
+	// provider which the ARO operator adds to the cluster OAuth configuration
+	IdentityProviderProfile *IdentityProviderProfile `json:"identityProviderProfile,omitempty"`
+
+	// RegistryMirrorProfiles are the pull-through caches which the ARO
+	// operator configures the cluster to pull images of upstream registries
+	// through
+	RegistryMirrorProfiles []RegistryMirrorProfile `json:"registryMirrorProfiles,omitempty"`
+
+	// DefaultStorageClassProfile, if set, is the storage class which the ARO
+	// operator marks as the only default storage class of the cluster
+	DefaultStorageClassProfile *DefaultStorageClassProfile `json:"defaultStorageClassProfile,omitempty"`
+
+	// AzureFileCSIProfile, if set, is the storage class for ReadWriteMany
+	// volumes which the ARO operator creates with the Azure File CSI driver
+	AzureFileCSIProfile *AzureFileCSIProfile `json:"azureFileCsiProfile,omitempty"`
+
+	// MonitoringProfile, if set, is the retention and persistent storage
+	// which the ARO operator configures for the platform Prometheus
+	MonitoringProfile *MonitoringProfile `json:"monitoringProfile,omitempty"`
+
+	// UpgradeProfile, if set, is the order in which and the pace at which the
+	// ARO operator has the worker nodes updated during upgrades
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty"`
+
+	// NodeEvictionProfile, if set, are the kubelet eviction thresholds which
+	// the ARO operator configures on the nodes instead of the platform ones
+	NodeEvictionProfile *NodeEvictionProfile `json:"nodeEvictionProfile,omitempty"`
+
+	// SecurityProfile, if set, is the default seccomp profile and the SELinux
+	// booleans which the ARO operator configures on the nodes
+	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+
+	// LogForwardingProfile, if set, is the off-cluster destination to which
+	// the ARO operator has the cluster logs forwarded
+	LogForwardingProfile *LogForwardingProfile `json:"logForwardingProfile,omitempty"`
+
+	// AdmissionWebhookProfiles are the admission webhook configurations of
+	// the customer which the ARO operator registers on the cluster
+	AdmissionWebhookProfiles []AdmissionWebhookProfile `json:"admissionWebhookProfiles,omitempty"`
+
+	// SchedulerProfile, if set, is the cluster-wide default node selector
+	// which the ARO operator configures on the cluster scheduler
+	SchedulerProfile *SchedulerProfile `json:"schedulerProfile,omitempty"`
+
+	// EgressFirewallProfile, if set, is the egress which the ARO operator
+	// allows from the customer namespaces; any other egress is denied
+	EgressFirewallProfile *EgressFirewallProfile `json:"egressFirewallProfile,omitempty"`
+
+	// SyncedTags are the cluster tags as last set on the cluster resource
+	// group.  They are used to remove a tag from the resource group once it
+	// is removed from the cluster.
+	SyncedTags map[string]string `json:"syncedTags,omitempty"`
+
 	// Operator feature/option flags
 	OperatorFlags   OperatorFlags `json:"operatorFlags,omitempty"`
 	OperatorVersion string        `json:"operatorVersion,omitempty"`
@@ -114,6 +212,16 @@ type OpenShiftClusterProperties struct {
 	// admin updated this cluster
 	ProvisionedBy string `json:"provisionedBy,omitempty"`
 
+	// RPVersionPin is the RP version (Git commit hash) which must run the
+	// operations on this cluster.  It is only settable via the admin API and
+	// the cluster is not dequeued by backends running a different version.
+	RPVersionPin string `json:"rpVersionPin,omitempty"`
+
+	// DNSOverrides, if set, lists the hostnames which the RP and the monitor
+	// accept to dial at the API server private endpoint, for clusters behind
+	// split-horizon DNS.  It is only settable via the admin API.
+	DNSOverrides *DNSOverrides `json:"dnsOverrides,omitempty"`
+
 	ClusterProfile ClusterProfile `json:"clusterProfile,omitempty"`
 
 	FeatureProfile FeatureProfile `json:"featureProfile,omitempty"`
@@ -122,6 +230,10 @@ type OpenShiftClusterProperties struct {
 
 	ServicePrincipalProfile ServicePrincipalProfile `json:"servicePrincipalProfile,omitempty"`
 
+	// ClusterIdentities lists the Azure identities used by cluster components,
+	// so that customers can scope Azure RBAC and policy to them
+	ClusterIdentities []ClusterIdentity `json:"clusterIdentities,omitempty"`
+
 	NetworkProfile NetworkProfile `json:"networkProfile,omitempty"`
 
 	MasterProfile MasterProfile `json:"masterProfile,omitempty"`
@@ -132,6 +244,14 @@ type OpenShiftClusterProperties struct {
 	// WorkerProfilesStatus is used to store the enriched worker profile data
 	WorkerProfilesStatus []WorkerProfile `json:"workerProfilesStatus,omitempty"`
 
+	// WorkerProfilesScaleStatus records the progress of the scale operations
+	// requested by an update of the worker profile counts
+	WorkerProfilesScaleStatus []WorkerProfileScale `json:"workerProfilesScaleStatus,omitempty"`
+
+	// WorkerMachineSetsProgress records the progress of the creation of the
+	// machine sets of the additional worker profiles during install
+	WorkerMachineSetsProgress *WorkerMachineSetsProgress `json:"workerMachineSetsProgress,omitempty"`
+
 	APIServerProfile APIServerProfile `json:"apiserverProfile,omitempty"`
 
 	IngressProfiles []IngressProfile `json:"ingressProfiles,omitempty"`
@@ -139,6 +259,10 @@ type OpenShiftClusterProperties struct {
 	// Install is non-nil only when an install is in progress
 	Install *Install `json:"install,omitempty"`
 
+	// OperationProgress records the public phase of the running install,
+	// update or admin update, for the asyncOperation status
+	OperationProgress *OperationProgress `json:"operationProgress,omitempty"`
+
 	StorageSuffix                   string `json:"storageSuffix,omitempty"`
 	ImageRegistryStorageAccountName string `json:"imageRegistryStorageAccountName,omitempty"`
 
@@ -183,10 +307,364 @@ const (
 type MaintenanceTask string
 
 const (
-	MaintenanceTaskEverything  MaintenanceTask = "Everything"
-	MaintenanceTaskOperator    MaintenanceTask = "OperatorUpdate"
-	MaintenanceTaskRenewCerts  MaintenanceTask = "CertificatesRenewal"
-	MaintenanceTaskPucmPending MaintenanceTask = "PucmPending"
+	MaintenanceTaskEverything            MaintenanceTask = "Everything"
+	MaintenanceTaskOperator              MaintenanceTask = "OperatorUpdate"
+	MaintenanceTaskRenewCerts            MaintenanceTask = "CertificatesRenewal"
+	MaintenanceTaskRenewExpiringCerts    MaintenanceTask = "ExpiringCertificatesRenewal"
+	MaintenanceTaskPucmPending           MaintenanceTask = "PucmPending"
+	MaintenanceTaskSyncClusterProperties MaintenanceTask = "SyncClusterProperties"
+	MaintenanceTaskEtcdDefrag            MaintenanceTask = "EtcdDefragmentation"
+	MaintenanceTaskEtcdBackup            MaintenanceTask = "EtcdBackup"
+	MaintenanceTaskEtcdRestore           MaintenanceTask = "EtcdRestore"
+	MaintenanceTaskRebootNode            MaintenanceTask = "RebootNode"
+	MaintenanceTaskDrainMachineSet       MaintenanceTask = "DrainMachineSet"
+	MaintenanceTaskRotateCertificates    MaintenanceTask = "RotateCertificates"
+)
+
+// MaintenanceTaskParameters holds the parameters of a maintenance task
+type MaintenanceTaskParameters struct {
+	MissingFields
+
+	// EtcdBackupName is the name of the etcd backup restored by the
+	// EtcdRestore task
+	EtcdBackupName string `json:"etcdBackupName,omitempty"`
+
+	// VMName is the name of the node rebooted by the RebootNode task
+	VMName string `json:"vmName,omitempty"`
+
+	// MachineSetName is the name of the machine set drained by the
+	// DrainMachineSet task
+	MachineSetName string `json:"machineSetName,omitempty"`
+
+	// DrainTimeout is how long the RebootNode and DrainMachineSet tasks wait
+	// for the pods on a node to be evicted.  If it is not given, the
+	// RebootNode task refuses to drain a node whose pods are protected by a
+	// pod disruption budget.
+	DrainTimeout string `json:"drainTimeout,omitempty"`
+
+	// APIServerCertificate and IngressCertificate are the custom domain
+	// certificates installed by the RotateCertificates task.  Each is a PEM
+	// bundle of the private key and the certificate chain, leaf first.
+	APIServerCertificate SecureString `json:"apiServerCertificate,omitempty"`
+	IngressCertificate   SecureString `json:"ingressCertificate,omitempty"`
+}
+
+// Quarantine records why and when a cluster was quarantined.  While a cluster
+// is quarantined the operator controllers are disabled; admin updates and
+// actions are still allowed.
+type Quarantine struct {
+	MissingFields
+
+	Reason        string    `json:"reason,omitempty"`
+	QuarantinedAt time.Time `json:"quarantinedAt,omitempty"`
+}
+
+// NodeUpdatesPause records why and until when the rollout of updates to the
+// worker nodes is paused by an admin.
+type NodeUpdatesPause struct {
+	MissingFields
+
+	Reason      string    `json:"reason,omitempty"`
+	PausedUntil time.Time `json:"pausedUntil,omitempty"`
+}
+
+// SupportAnnotation records a note about the cluster on a support case.
+type SupportAnnotation struct {
+	MissingFields
+
+	Note        string    `json:"note,omitempty"`
+	AnnotatedAt time.Time `json:"annotatedAt,omitempty"`
+}
+
+// EtcdBackup records an etcd backup stored in the etcd-backups container of
+// the cluster storage account.  The backup is encrypted with EncryptionKey,
+// and SHA256 is the digest of the encrypted blob, checked before a restore.
+type EtcdBackup struct {
+	MissingFields
+
+	Name           string       `json:"name,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt,omitempty"`
+	ClusterVersion string       `json:"clusterVersion,omitempty"`
+	Node           string       `json:"node,omitempty"`
+	SHA256         string       `json:"sha256,omitempty"`
+	EncryptionKey  SecureString `json:"encryptionKey,omitempty"`
+}
+
+// CertificateTarget represents a certificate served by the cluster
+type CertificateTarget string
+
+// CertificateTarget constants
+const (
+	CertificateTargetAPIServer CertificateTarget = "APIServer"
+	CertificateTargetIngress   CertificateTarget = "Ingress"
+)
+
+// CertificateRotation records the rotation of a custom domain certificate.
+// SecretName is the secret holding the certificate and NotAfter is when it
+// expires.
+type CertificateRotation struct {
+	MissingFields
+
+	Target     CertificateTarget `json:"target,omitempty"`
+	SecretName string            `json:"secretName,omitempty"`
+	RotatedAt  time.Time         `json:"rotatedAt,omitempty"`
+	NotAfter   time.Time         `json:"notAfter,omitempty"`
+}
+
+// MaintenanceWindow represents a weekly maintenance window
+type MaintenanceWindow struct {
+	MissingFields
+
+	Days          []Weekday `json:"days,omitempty"`
+	StartTime     string    `json:"startTime,omitempty"`
+	DurationHours int       `json:"durationHours,omitempty"`
+	TimeZone      string    `json:"timeZone,omitempty"`
+}
+
+// DNSOverrides represents how the hostnames of the cluster are resolved when
+// the RP dials the cluster.  Hosts take precedence over Nameservers, which are
+// queried in order.  A hostname which resolves to any address other than the
+// API server private endpoint is not dialled.
+type DNSOverrides struct {
+	MissingFields
+
+	Hosts       []DNSHostOverride `json:"hosts,omitempty"`
+	Nameservers []string          `json:"nameservers,omitempty"`
+}
+
+// DNSHostOverride maps a hostname to a fixed IP address
+type DNSHostOverride struct {
+	MissingFields
+
+	Hostname  string `json:"hostname,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// ProjectTemplateProfile represents the default resource quota and limit
+// range of projects requested by users.  ResourceQuota maps a quota resource
+// to its hard limit; DefaultLimits and DefaultRequests map a container
+// resource to its default limit and request.  Values are Kubernetes
+// quantities.
+type ProjectTemplateProfile struct {
+	MissingFields
+
+	ResourceQuota   map[string]string `json:"resourceQuota,omitempty"`
+	DefaultLimits   map[string]string `json:"defaultLimits,omitempty"`
+	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
+}
+
+// IdentityProviderProfile represents an OpenID Connect identity provider,
+// e.g. Azure AD, with which users log in to the cluster.  ClientSecret is
+// never returned to the customer.
+type IdentityProviderProfile struct {
+	MissingFields
+
+	Name         string       `json:"name,omitempty"`
+	Issuer       string       `json:"issuer,omitempty"`
+	ClientID     string       `json:"clientId,omitempty"`
+	ClientSecret SecureString `json:"clientSecret,omitempty"`
+}
+
+// RegistryMirrorProfile represents a pull-through cache, e.g. an Azure
+// Container Registry cache rule, from which images of the Source registry are
+// pulled.  Source and Mirror are a registry host optionally followed by a
+// repository path.
+type RegistryMirrorProfile struct {
+	MissingFields
+
+	Source string `json:"source,omitempty"`
+	Mirror string `json:"mirror,omitempty"`
+}
+
+// DefaultStorageClassProfile represents the default storage class of the
+// cluster.  If Parameters is empty, Name is an existing storage class, e.g.
+// managed-csi; otherwise the ARO operator creates a storage class called Name
+// with the Azure Disk CSI driver and the Parameters.
+type DefaultStorageClassProfile struct {
+	MissingFields
+
+	Name       string            `json:"name,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AzureFileCSIProfile represents a storage class provisioning Azure Files
+// shares with the Azure File CSI driver, which supports ReadWriteMany
+// volumes.  The driver creates the storage accounts of the shares in the
+// cluster resource group with the SKUName, or Standard_LRS if it is empty.
+type AzureFileCSIProfile struct {
+	MissingFields
+
+	StorageClassName string `json:"storageClassName,omitempty"`
+	SKUName          string `json:"skuName,omitempty"`
+}
+
+// MonitoringProfile represents the retention and persistent storage of the
+// platform Prometheus.  If StorageSize is empty, Prometheus uses ephemeral
+// storage.
+type MonitoringProfile struct {
+	MissingFields
+
+	Retention        string `json:"retention,omitempty"`
+	StorageSize      string `json:"storageSize,omitempty"`
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// UpgradeProfile represents how the worker nodes are updated during
+// upgrades.  The node named LastNodeName is updated after all the other worker
+// nodes.  MaxUnavailable, a number of nodes, e.g. 2, or a percentage, e.g.
+// 10%, sets the update strategy of the worker MachineConfigPool; if it is
+// empty the platform behaviour is kept.
+type UpgradeProfile struct {
+	MissingFields
+
+	LastNodeName   string `json:"lastNodeName,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// NodeEvictionProfile represents the kubelet eviction thresholds of the nodes.
+// A hard threshold which is not set keeps its platform value; soft thresholds
+// are only set if given, and then evict after SoftGracePeriod.
+type NodeEvictionProfile struct {
+	MissingFields
+
+	Hard            *EvictionThresholds `json:"hard,omitempty"`
+	Soft            *EvictionThresholds `json:"soft,omitempty"`
+	SoftGracePeriod string              `json:"softGracePeriod,omitempty"`
+}
+
+// EvictionThresholds represents the kubelet eviction signals which may be
+// configured.  Each threshold is a quantity, e.g. 500Mi, or a percentage of
+// the capacity, e.g. 10%.
+type EvictionThresholds struct {
+	MissingFields
+
+	MemoryAvailable  string `json:"memoryAvailable,omitempty"`
+	NodeFSAvailable  string `json:"nodefsAvailable,omitempty"`
+	ImageFSAvailable string `json:"imagefsAvailable,omitempty"`
+}
+
+// PlatformEvictionHard are the hard eviction thresholds of the platform, which
+// are kept for the signals whose hard threshold is not set
+var PlatformEvictionHard = EvictionThresholds{
+	MemoryAvailable:  "100Mi",
+	NodeFSAvailable:  "10%",
+	ImageFSAvailable: "15%",
+}
+
+// SecurityProfile represents the seccomp and SELinux defaults of the nodes.
+// Fields which are not set keep the platform behaviour.
+type SecurityProfile struct {
+	MissingFields
+
+	DefaultSeccompProfile SeccompProfile `json:"defaultSeccompProfile,omitempty"`
+	SELinuxBooleans       []string       `json:"seLinuxBooleans,omitempty"`
+}
+
+// SeccompProfile represents the seccomp profile of containers which do not
+// set one
+type SeccompProfile string
+
+// SeccompProfile constants
+const (
+	// SeccompProfileUnconfined is the platform behaviour: containers run
+	// without a seccomp profile
+	SeccompProfileUnconfined SeccompProfile = "Unconfined"
+	// SeccompProfileRuntimeDefault applies the default seccomp profile of the
+	// container runtime
+	SeccompProfileRuntimeDefault SeccompProfile = "RuntimeDefault"
+)
+
+// LogForwardingProfile represents the off-cluster destination to which the
+// cluster logs are forwarded by the OpenShift Logging operator.  SharedKey is
+// never returned to the customer.
+type LogForwardingProfile struct {
+	MissingFields
+
+	Type LogForwardingType `json:"type,omitempty"`
+
+	// URL is the syslog server, e.g. tls://syslog.example.com:6514
+	URL string `json:"url,omitempty"`
+
+	// WorkspaceID and SharedKey are the Log Analytics workspace to which
+	// Azure Monitor logs are sent and its key
+	WorkspaceID string       `json:"workspaceId,omitempty"`
+	SharedKey   SecureString `json:"sharedKey,omitempty"`
+
+	// LogTypes are the types of logs which are forwarded; all of them if
+	// empty
+	LogTypes []LogType `json:"logTypes,omitempty"`
+}
+
+// LogForwardingType represents the type of a log forwarding destination
+type LogForwardingType string
+
+// LogForwardingType constants
+const (
+	LogForwardingTypeSyslog       LogForwardingType = "Syslog"
+	LogForwardingTypeAzureMonitor LogForwardingType = "AzureMonitor"
+)
+
+// LogType represents a type of cluster logs
+type LogType string
+
+// LogType constants
+const (
+	LogTypeApplication    LogType = "Application"
+	LogTypeInfrastructure LogType = "Infrastructure"
+	LogTypeAudit          LogType = "Audit"
+)
+
+// AdmissionWebhookProfile represents an admission webhook configuration of
+// the customer.  Configuration is a ValidatingWebhookConfiguration or a
+// MutatingWebhookConfiguration of API version admissionregistration.k8s.io/v1,
+// in JSON or YAML.
+type AdmissionWebhookProfile struct {
+	MissingFields
+
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// SchedulerProfile represents the configuration of the cluster scheduler.
+// DefaultNodeSelector is a comma separated list of key=value node labels, to
+// whose nodes the pods of projects without a node selector of their own are
+// constrained.
+type SchedulerProfile struct {
+	MissingFields
+
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+}
+
+// EgressFirewallProfile represents the destinations outside the cluster to
+// which the pods of the customer namespaces may connect.  Egress to any other
+// destination is denied by an EgressFirewall, or an EgressNetworkPolicy on
+// OpenShiftSDN clusters, in each customer namespace.
+type EgressFirewallProfile struct {
+	MissingFields
+
+	AllowedDestinations []EgressDestination `json:"allowedDestinations,omitempty"`
+}
+
+// EgressDestination represents a destination outside the cluster, either an
+// IP range or a DNS name
+type EgressDestination struct {
+	MissingFields
+
+	CIDR    string `json:"cidr,omitempty"`
+	DNSName string `json:"dnsName,omitempty"`
+}
+
+// Weekday represents a day of the week
+type Weekday string
+
+// Weekday constants
+const (
+	WeekdaySunday    Weekday = "Sunday"
+	WeekdayMonday    Weekday = "Monday"
+	WeekdayTuesday   Weekday = "Tuesday"
+	WeekdayWednesday Weekday = "Wednesday"
+	WeekdayThursday  Weekday = "Thursday"
+	WeekdayFriday    Weekday = "Friday"
+	WeekdaySaturday  Weekday = "Saturday"
 )
 
 // Cluster-scoped flags
@@ -210,6 +688,16 @@ const (
 	FipsValidatedModulesDisabled FipsValidatedModules = "Disabled"
 )
 
+// ExistingResourceGroup determines if the cluster resource group is created by
+// the customer rather than the RP.
+type ExistingResourceGroup string
+
+// ExistingResourceGroup constants.
+const (
+	ExistingResourceGroupEnabled  ExistingResourceGroup = "Enabled"
+	ExistingResourceGroupDisabled ExistingResourceGroup = "Disabled"
+)
+
 // ClusterProfile represents a cluster profile.
 type ClusterProfile struct {
 	MissingFields
@@ -219,6 +707,38 @@ type ClusterProfile struct {
 	Version              string               `json:"version,omitempty"`
 	ResourceGroupID      string               `json:"resourceGroupId,omitempty"`
 	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
+
+	// DNSRecordTTL is the TTL in seconds of the API and ingress DNS records
+	// which the RP manages for the cluster.  It was introduced in
+	// 2023-07-01-preview; zero means the default TTL.
+	DNSRecordTTL int `json:"dnsRecordTtl,omitempty"`
+
+	// ResourceNameTemplate is the template from which the infra ID, and
+	// therefore the names of the resources in the cluster resource group, is
+	// generated.  It was introduced in 2023-07-01-preview; empty means the
+	// cluster name.
+	ResourceNameTemplate string `json:"resourceNameTemplate,omitempty"`
+
+	// ExistingResourceGroup is set if the cluster is deployed into a
+	// resource group which the customer created before the cluster.  In
+	// that case the RP neither creates nor deletes the resource group: on
+	// delete only the cluster's resources in it are removed.  It was
+	// introduced in 2023-07-01-preview; empty means disabled.
+	ExistingResourceGroup ExistingResourceGroup `json:"existingResourceGroup,omitempty"`
+
+	// TimeZone is the tz database name of the time zone which the cluster
+	// nodes are set to, for example Europe/London.  The operator applies it
+	// to the nodes with a MachineConfig.  It was introduced in
+	// 2023-07-01-preview; empty means the node default, UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// DNSZoneID is the resource ID of the customer's Azure DNS zone, possibly
+	// in another subscription, in which the RP creates the API and ingress
+	// DNS records of a cluster with a custom domain.  The records are written
+	// with the cluster service principal.  It was introduced in
+	// 2023-07-01-preview; empty means the RP creates no records for a custom
+	// domain.
+	DNSZoneID string `json:"dnsZoneId,omitempty"`
 }
 
 // FeatureProfile represents a feature profile.
@@ -244,6 +764,29 @@ type ServicePrincipalProfile struct {
 	SPObjectID   string       `json:"spObjectId,omitempty"`
 }
 
+// ClusterIdentityComponent represents the Azure component of the cluster
+// which uses an identity
+type ClusterIdentityComponent string
+
+// ClusterIdentityComponent constants
+const (
+	ClusterIdentityComponentCloudControllerManager ClusterIdentityComponent = "CloudControllerManager"
+	ClusterIdentityComponentIngress                ClusterIdentityComponent = "Ingress"
+	ClusterIdentityComponentMachineAPI             ClusterIdentityComponent = "MachineAPI"
+	ClusterIdentityComponentImageRegistry          ClusterIdentityComponent = "ImageRegistry"
+	ClusterIdentityComponentDiskCSIDriver          ClusterIdentityComponent = "DiskCSIDriver"
+	ClusterIdentityComponentFileCSIDriver          ClusterIdentityComponent = "FileCSIDriver"
+)
+
+// ClusterIdentity represents an Azure identity used by a cluster component.
+type ClusterIdentity struct {
+	MissingFields
+
+	Component ClusterIdentityComponent `json:"component,omitempty"`
+	ClientID  string                   `json:"clientId,omitempty"`
+	ObjectID  string                   `json:"objectId,omitempty"`
+}
+
 // SoftwareDefinedNetwork
 type SoftwareDefinedNetwork string
 
@@ -282,6 +825,10 @@ type LoadBalancerProfile struct {
 	ManagedOutboundIPs *ManagedOutboundIPs `json:"managedOutboundIps,omitempty"`
 	// The list of effective outbound IP addresses of the public load balancer.
 	EffectiveOutboundIPs []EffectiveOutboundIP `json:"effectiveOutboundIps,omitempty"`
+	// The desired managed outbound IP prefix for the cluster public load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of individual managed outbound IPs.
+	ManagedOutboundIPPrefix *ManagedOutboundIPPrefix `json:"managedOutboundIpPrefix,omitempty"`
+	// The effective outbound IP prefix of the public load balancer.
+	EffectiveOutboundIPPrefix *EffectiveOutboundIPPrefix `json:"effectiveOutboundIpPrefix,omitempty"`
 	// The desired outbound IP resources for the cluster load balancer.
 	OutboundIPs []OutboundIP `json:"outboundIps,omitempty"`
 	// The desired outbound IP Prefix resources for the cluster load balancer.
@@ -299,6 +846,20 @@ type ManagedOutboundIPs struct {
 	Count int `json:"count,omitempty"`
 }
 
+// ManagedOutboundIPPrefix represents the desired managed outbound IP prefix for the cluster public load balancer.
+type ManagedOutboundIPPrefix struct {
+	// PrefixLength represents the length of the IPv4 public IP prefix created and managed by Azure for the cluster public load balancer.  Allowed values are in the range of 28 - 31.
+	PrefixLength int `json:"prefixLength,omitempty"`
+}
+
+// EffectiveOutboundIPPrefix represents the effective outbound IP prefix of the cluster public load balancer.
+type EffectiveOutboundIPPrefix struct {
+	// The resource ID of the public IP prefix.
+	ID string `json:"id,omitempty"`
+	// The CIDR of the public IP prefix.
+	IPPrefix string `json:"ipPrefix,omitempty"`
+}
+
 // OutboundIP represents a desired outbound IP resource for the cluster load balancer.
 type OutboundIP ResourceReference
 
@@ -315,13 +876,90 @@ type NetworkProfile struct {
 	MTUSize                MTUSize                `json:"mtuSize,omitempty"`
 	OutboundType           OutboundType           `json:"outboundType,omitempty"`
 
+	// MaxPods, if set, is the maximum number of pods per node, instead of
+	// the kubelet default.  Introduced in 2023-07-01-preview.
+	MaxPods int `json:"maxPods,omitempty"`
+
 	APIServerPrivateEndpointIP string               `json:"privateEndpointIp,omitempty"`
 	GatewayPrivateEndpointIP   string               `json:"gatewayPrivateEndpointIp,omitempty"`
 	GatewayPrivateLinkID       string               `json:"gatewayPrivateLinkId,omitempty"`
 	PreconfiguredNSG           PreconfiguredNSG     `json:"preconfiguredNSG,omitempty"`
 	LoadBalancerProfile        *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+
+	// CustomerLoadBalancerProfile, if set, is the customer's load balancer
+	// which fronts the API server and the default ingress instead of the
+	// managed frontends.  Introduced in 2023-07-01-preview.
+	CustomerLoadBalancerProfile *CustomerLoadBalancerProfile `json:"customerLoadBalancerProfile,omitempty"`
+
+	// EffectiveOutboundProfile is how the egress of the worker nodes reaches
+	// the internet, as found on the live Azure resources.  It is only
+	// written by the RP.  Introduced in 2023-07-01-preview.
+	EffectiveOutboundProfile *EffectiveOutboundProfile `json:"effectiveOutboundProfile,omitempty"`
+}
+
+// EffectiveOutboundProfile represents the outbound connectivity method which
+// the worker nodes actually use.  A default route of the route table of the
+// worker subnet to anything but the internet takes precedence over a NAT
+// gateway on the subnet, which takes precedence over the outbound rules of the
+// public load balancer.
+type EffectiveOutboundProfile struct {
+	MissingFields
+
+	Method EffectiveOutboundMethod `json:"method,omitempty"`
+
+	// OutboundIPAddresses are the public IP addresses, and the public IP
+	// prefixes in CIDR notation, from which the egress originates.  They are
+	// not known if the egress is user defined.
+	OutboundIPAddresses []string `json:"outboundIpAddresses,omitempty"`
+
+	NATGatewayID     string `json:"natGatewayId,omitempty"`
+	RouteTableID     string `json:"routeTableId,omitempty"`
+	NextHopIPAddress string `json:"nextHopIpAddress,omitempty"`
+}
+
+// EffectiveOutboundMethod represents the outbound connectivity method which
+// the worker nodes actually use.
+type EffectiveOutboundMethod string
+
+// EffectiveOutboundMethod constants
+const (
+	EffectiveOutboundMethodLoadBalancer       EffectiveOutboundMethod = "LoadBalancer"
+	EffectiveOutboundMethodNATGateway         EffectiveOutboundMethod = "NATGateway"
+	EffectiveOutboundMethodUserDefinedRouting EffectiveOutboundMethod = "UserDefinedRouting"
+)
+
+// CustomerLoadBalancerProfile represents an existing load balancer of the
+// customer, on which the RP registers the master nodes as the backends of the
+// API server and the worker nodes as the backends of the default ingress.
+type CustomerLoadBalancerProfile struct {
+	MissingFields
+
+	// ID is the resource ID of the load balancer, which is in the
+	// subscription of the cluster.
+	ID string `json:"id,omitempty"`
+
+	// APIServerFrontendIPConfiguration and IngressFrontendIPConfiguration
+	// are the names of the frontend IP configurations of the load balancer
+	// which serve the API server and the default ingress.  They may be the
+	// same.
+	APIServerFrontendIPConfiguration string `json:"apiServerFrontendIpConfiguration,omitempty"`
+	IngressFrontendIPConfiguration   string `json:"ingressFrontendIpConfiguration,omitempty"`
 }
 
+// NodePodCIDRHostPrefix is the prefix length of the block of the pod CIDR which
+// is allocated to each node
+const NodePodCIDRHostPrefix = 23
+
+// MaxPodsLimit is the largest maximum number of pods per node: the number of
+// addresses in a node's block of the pod CIDR, less the network and broadcast
+// addresses and the addresses which the SDN reserves for the node gateway and
+// management port
+const MaxPodsLimit = 1<<(32-NodePodCIDRHostPrefix) - 4
+
+// MaxPodsMinimum is the smallest maximum number of pods per node which leaves
+// room for the platform pods on each node
+const MaxPodsMinimum = 30
+
 // PreconfiguredNSG represents whether customers want to use their own NSG attached to the subnets
 type PreconfiguredNSG string
 
@@ -340,6 +978,25 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskStorageAccountType represents the storage account type of a managed
+// disk.
+type DiskStorageAccountType string
+
+// DiskStorageAccountType constants
+const (
+	DiskStorageAccountTypePremiumLRS     DiskStorageAccountType = "Premium_LRS"
+	DiskStorageAccountTypeStandardSSDLRS DiskStorageAccountType = "StandardSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking.
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
 // MasterProfile represents a master profile
 type MasterProfile struct {
 	MissingFields
@@ -636,6 +1293,57 @@ type WorkerProfile struct {
 	Count               int              `json:"count,omitempty"`
 	EncryptionAtHost    EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	DiskEncryptionSetID string           `json:"diskEncryptionSetId,omitempty"`
+
+	// DiskStorageAccountType was introduced in 2023-07-01-preview.  It is only
+	// set on additional worker profiles, whose machine sets the RP creates; if
+	// it is empty, the type of the installer's worker machine sets is used.
+	DiskStorageAccountType DiskStorageAccountType `json:"diskStorageAccountType,omitempty"`
+
+	// AcceleratedNetworking was introduced in 2023-07-01-preview.  If it is
+	// empty, it is enabled when supported by the VM size.
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+
+	// ProximityPlacementGroupProfile was introduced in 2023-07-01-preview.
+	// If it is nil, the VMs are spread across the availability zones.
+	ProximityPlacementGroupProfile *ProximityPlacementGroupProfile `json:"proximityPlacementGroupProfile,omitempty"`
+}
+
+// ProximityPlacementGroupProfile represents the proximity placement group of
+// the VMs of a worker profile
+type ProximityPlacementGroupProfile struct {
+	MissingFields
+
+	// ID is the resource ID of an existing proximity placement group.  If it
+	// is empty, one is created in the cluster resource group.
+	ID string `json:"id,omitempty"`
+}
+
+// WorkerProfileScale represents the scale operation of the machine set of a
+// worker profile
+type WorkerProfileScale struct {
+	MissingFields
+
+	Name            string `json:"name,omitempty"`
+	CurrentReplicas int    `json:"currentReplicas"`
+	TargetReplicas  int    `json:"targetReplicas"`
+}
+
+// OperationProgress represents the progress of the steps of an operation
+type OperationProgress struct {
+	MissingFields
+
+	Step           string `json:"step,omitempty"`
+	CompletedSteps int    `json:"completedSteps"`
+	TotalSteps     int    `json:"totalSteps"`
+}
+
+// WorkerMachineSetsProgress represents the progress of the creation of the
+// worker machine sets
+type WorkerMachineSetsProgress struct {
+	MissingFields
+
+	Created int `json:"created"`
+	Total   int `json:"total"`
 }
 
 // GetEnrichedWorkerProfiles returns WorkerProfilesStatus if not nil, otherwise WorkerProfiles
@@ -673,6 +1381,13 @@ type IngressProfile struct {
 	Name       string     `json:"name,omitempty"`
 	Visibility Visibility `json:"visibility,omitempty"`
 	IP         string     `json:"ip,omitempty"`
+
+	// Domain and ServingCertificate are only set on additional ingress
+	// profiles, i.e. those other than "default", which are provisioned by
+	// the RP.  ServingCertificate holds the PEM encoded private key and
+	// certificate chain.
+	Domain             string       `json:"domain,omitempty"`
+	ServingCertificate SecureString `json:"servingCertificate,omitempty"`
 }
 
 // RegistryProfile represents a registry's login
@@ -690,6 +1405,14 @@ type Install struct {
 
 	Now   time.Time    `json:"now,omitempty"`
 	Phase InstallPhase `json:"phase"`
+
+	// CompletedSteps are the names of the leading steps of the phase which
+	// completed, from which a failed install is resumed
+	CompletedSteps []string `json:"completedSteps,omitempty"`
+
+	// Resumable is true if the install failed transiently and may be resumed
+	// from its completed steps rather than started afresh
+	Resumable bool `json:"resumable,omitempty"`
 }
 
 // InstallPhase represents an install phase
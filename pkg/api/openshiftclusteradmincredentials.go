@@ -0,0 +1,27 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// AdminCredentialsFormat represents the format in which an OpenShift cluster's
+// admin credentials are returned
+type AdminCredentialsFormat string
+
+// AdminCredentialsFormat constants
+const (
+	// AdminCredentialsFormatKubeconfig returns the kubeconfig only
+	AdminCredentialsFormatKubeconfig AdminCredentialsFormat = "Kubeconfig"
+	// AdminCredentialsFormatStructured additionally returns the API server URL
+	// and the client certificate and key
+	AdminCredentialsFormatStructured AdminCredentialsFormat = "Structured"
+)
+
+// OpenShiftClusterAdminCredentials represents an OpenShift cluster's admin
+// credentials in structured form.  All fields are derived from the cluster's
+// UserAdminKubeconfig, so they are always consistent with it.
+type OpenShiftClusterAdminCredentials struct {
+	Kubeconfig        SecureBytes
+	APIServerURL      string
+	ClientCertificate []byte
+	ClientKey         SecureBytes
+}
@@ -33,6 +33,11 @@ type OpenShiftClusterDocument struct {
 	PartitionKey              string `json:"partitionKey,omitempty" deep:"-"`
 	ClusterResourceGroupIDKey string `json:"clusterResourceGroupIdKey,omitempty"`
 	ClientIDKey               string `json:"clientIdKey,omitempty"`
+	ClusterDomainKey          string `json:"clusterDomainKey,omitempty"`
+
+	// SupportCaseIDKeys are the sorted keys of the support annotations of
+	// the cluster, by which the clusters linked to a support case are found
+	SupportCaseIDKeys []string `json:"supportCaseIdKeys,omitempty"`
 
 	Bucket int `json:"bucket,omitempty"`
 
@@ -45,6 +50,10 @@ type OpenShiftClusterDocument struct {
 	OpenShiftCluster *OpenShiftCluster `json:"openShiftCluster,omitempty"`
 
 	CorrelationData *CorrelationData `json:"correlationData,omitempty" deep:"-"`
+
+	// OperationHistory holds the most recent operations on the cluster,
+	// oldest first
+	OperationHistory []*OperationHistoryEntry `json:"operationHistory,omitempty"`
 }
 
 func (c *OpenShiftClusterDocument) String() string {
@@ -18,6 +18,7 @@ func ExampleOpenShiftClusterDocument() *OpenShiftClusterDocument {
 		Bucket:                    42,
 		ClusterResourceGroupIDKey: "/subscriptions/subscriptionid/resourcegroups/clusterresourcegroup",
 		ClientIDKey:               "11111111-1111-1111-1111-111111111111",
+		ClusterDomainKey:          "cluster.location.aroapp.io",
 		OpenShiftCluster: &OpenShiftCluster{
 			ID:       "/subscriptions/subscriptionId/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/OpenShiftClusters/resourceName",
 			Name:     "resourceName",
@@ -3,6 +3,8 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import "strings"
+
 // OpenShiftVersion represents an OpenShift version that can be installed
 type OpenShiftVersion struct {
 	MissingFields
@@ -26,4 +28,51 @@ type OpenShiftVersionProperties struct {
 	InstallerPullspec string `json:"installerPullspec,omitempty"`
 	Enabled           bool   `json:"enabled,omitempty"`
 	Default           bool   `json:"default,omitempty"`
+
+	// Status is how the version is supported.  Versions configured before
+	// statuses were introduced have an empty status and are installable.
+	Status OpenShiftVersionStatus `json:"status,omitempty"`
+
+	// Locations are the regions where the release images of the version are
+	// available.  An empty list means the version is available everywhere.
+	Locations []string `json:"locations,omitempty"`
+}
+
+// OpenShiftVersionStatus is how an OpenShift version is supported
+type OpenShiftVersionStatus string
+
+const (
+	// OpenShiftVersionStatusInstallable means that new clusters can be
+	// installed at the version and existing clusters upgraded to it
+	OpenShiftVersionStatusInstallable OpenShiftVersionStatus = "Installable"
+	// OpenShiftVersionStatusUpgradeOnly means that existing clusters can be
+	// upgraded to the version but new clusters cannot be installed at it
+	OpenShiftVersionStatusUpgradeOnly OpenShiftVersionStatus = "UpgradeOnly"
+	// OpenShiftVersionStatusDeprecated means that clusters at the version are
+	// still supported but should be upgraded off it
+	OpenShiftVersionStatusDeprecated OpenShiftVersionStatus = "Deprecated"
+)
+
+// GetStatus returns the status of the version, defaulting to installable
+func (p *OpenShiftVersionProperties) GetStatus() OpenShiftVersionStatus {
+	if p.Status == "" {
+		return OpenShiftVersionStatusInstallable
+	}
+	return p.Status
+}
+
+// IsAvailableIn returns true if the release images of the version are
+// available in location
+func (p *OpenShiftVersionProperties) IsAvailableIn(location string) bool {
+	if len(p.Locations) == 0 {
+		return true
+	}
+
+	for _, l := range p.Locations {
+		if strings.EqualFold(l, location) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,17 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OpenShiftVersionMatrix is the set of OpenShift versions supported in a
+// region, with the versions clusters at each of them can be upgraded to
+type OpenShiftVersionMatrix struct {
+	Versions []OpenShiftVersionMatrixVersion
+}
+
+// OpenShiftVersionMatrixVersion is a supported OpenShift version
+type OpenShiftVersionMatrixVersion struct {
+	Version         string
+	Status          OpenShiftVersionStatus
+	UpgradeVersions []string
+}
@@ -120,6 +120,26 @@ var OperationOpenShiftClusterListAdminCredentials = Operation{
 	Origin: "user,system",
 }
 
+var OperationOpenShiftClusterValidate = Operation{
+	Name: "Microsoft.RedHatOpenShift/openShiftClusters/validate/action",
+	Display: Display{
+		Provider:  "Azure Red Hat OpenShift",
+		Resource:  "openShiftClusters",
+		Operation: "Validate a proposed OpenShift cluster",
+	},
+	Origin: "user,system",
+}
+
+var OperationOpenShiftClusterExportTemplate = Operation{
+	Name: "Microsoft.RedHatOpenShift/openShiftClusters/exportTemplate/action",
+	Display: Display{
+		Provider:  "Azure Red Hat OpenShift",
+		Resource:  "openShiftClusters",
+		Operation: "Export the configuration of an OpenShift cluster as a template",
+	},
+	Origin: "user,system",
+}
+
 var OperationOpenShiftClusterGetDetectors = Operation{
 	Name: "Microsoft.RedHatOpenShift/openShiftClusters/detectors/read",
 	Display: Display{
@@ -140,6 +160,16 @@ var OperationListInstallVersions = Operation{
 	Origin: "user,system",
 }
 
+var OperationOpenShiftVersionMatrixRead = Operation{
+	Name: "Microsoft.RedHatOpenShift/locations/openshiftversionmatrix/read",
+	Display: Display{
+		Provider:  "Azure Red Hat OpenShift",
+		Resource:  "openshiftversionmatrix",
+		Operation: "Gets the OpenShift versions supported in the specified location and their upgrade versions",
+	},
+	Origin: "user,system",
+}
+
 var OperationSyncSetsRead = Operation{
 	Name: "Microsoft.RedHatOpenShift/openShiftClusters/syncSets/read",
 	Display: Display{
@@ -0,0 +1,40 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+)
+
+// OperationHistoryLimit is the number of operations which are kept in the
+// operation history of a cluster document, the oldest being dropped first
+const OperationHistoryLimit = 50
+
+// OperationHistoryEntry records a long running operation on a cluster which
+// has ended
+type OperationHistoryEntry struct {
+	// InitialProvisioningState is the provisioning state of the cluster while
+	// the operation ran, for example Creating or AdminUpdating
+	InitialProvisioningState ProvisioningState `json:"initialProvisioningState,omitempty"`
+
+	// MaintenanceTask is the task of an admin update
+	MaintenanceTask MaintenanceTask `json:"maintenanceTask,omitempty"`
+
+	// ProvisioningState is the outcome of the operation, Succeeded or Failed
+	ProvisioningState ProvisioningState `json:"provisioningState,omitempty"`
+
+	// ClientPrincipalName and CorrelationID are copied from the
+	// correlation data of the request which started the operation
+	ClientPrincipalName string `json:"clientPrincipalName,omitempty"`
+	CorrelationID       string `json:"correlationId,omitempty"`
+
+	StartTime time.Time `json:"startTime,omitempty"`
+	EndTime   time.Time `json:"endTime,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// Version is the OpenShift version of the cluster when the operation
+	// ended
+	Version string `json:"version,omitempty"`
+}
@@ -26,12 +26,29 @@ type OpenShiftClusterAdminKubeconfigConverter interface {
 	ToExternal(*OpenShiftCluster) interface{}
 }
 
+type OpenShiftClusterAdminCredentialsConverter interface {
+	ToExternal(*OpenShiftClusterAdminCredentials) interface{}
+}
+
+type OpenShiftClusterValidationFindingsConverter interface {
+	ToExternal(*ValidationFindings) interface{}
+}
+
+type OpenShiftClusterTemplateConverter interface {
+	ToExternal(*OpenShiftCluster) interface{}
+	Prefill(interface{}, []byte) error
+}
+
 type OpenShiftVersionConverter interface {
 	ToExternal(*OpenShiftVersion) interface{}
 	ToExternalList([]*OpenShiftVersion) interface{}
 	ToInternal(interface{}, *OpenShiftVersion)
 }
 
+type OpenShiftVersionMatrixConverter interface {
+	ToExternal(*OpenShiftVersionMatrix) interface{}
+}
+
 type OpenShiftVersionStaticValidator interface {
 	Static(interface{}, *OpenShiftVersion) error
 }
@@ -62,18 +79,22 @@ type SecretConverter interface {
 
 // Version is a set of endpoints implemented by each API version
 type Version struct {
-	OpenShiftClusterConverter                OpenShiftClusterConverter
-	OpenShiftClusterStaticValidator          OpenShiftClusterStaticValidator
-	OpenShiftClusterCredentialsConverter     OpenShiftClusterCredentialsConverter
-	OpenShiftClusterAdminKubeconfigConverter OpenShiftClusterAdminKubeconfigConverter
-	OpenShiftVersionConverter                OpenShiftVersionConverter
-	OpenShiftVersionStaticValidator          OpenShiftVersionStaticValidator
-	OperationList                            OperationList
-	SyncSetConverter                         SyncSetConverter
-	MachinePoolConverter                     MachinePoolConverter
-	SyncIdentityProviderConverter            SyncIdentityProviderConverter
-	SecretConverter                          SecretConverter
-	ClusterManagerStaticValidator            ClusterManagerStaticValidator
+	OpenShiftClusterConverter                   OpenShiftClusterConverter
+	OpenShiftClusterStaticValidator             OpenShiftClusterStaticValidator
+	OpenShiftClusterCredentialsConverter        OpenShiftClusterCredentialsConverter
+	OpenShiftClusterAdminKubeconfigConverter    OpenShiftClusterAdminKubeconfigConverter
+	OpenShiftClusterAdminCredentialsConverter   OpenShiftClusterAdminCredentialsConverter
+	OpenShiftClusterValidationFindingsConverter OpenShiftClusterValidationFindingsConverter
+	OpenShiftClusterTemplateConverter           OpenShiftClusterTemplateConverter
+	OpenShiftVersionConverter                   OpenShiftVersionConverter
+	OpenShiftVersionMatrixConverter             OpenShiftVersionMatrixConverter
+	OpenShiftVersionStaticValidator             OpenShiftVersionStaticValidator
+	OperationList                               OperationList
+	SyncSetConverter                            SyncSetConverter
+	MachinePoolConverter                        MachinePoolConverter
+	SyncIdentityProviderConverter               SyncIdentityProviderConverter
+	SecretConverter                             SecretConverter
+	ClusterManagerStaticValidator               ClusterManagerStaticValidator
 }
 
 // APIs is the map of registered API versions
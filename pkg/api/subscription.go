@@ -3,6 +3,8 @@ package api
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import "strings"
+
 // Subscription represents a subscription
 type Subscription struct {
 	MissingFields
@@ -47,3 +49,39 @@ type RegisteredFeatureProfile struct {
 	Name  string `json:"name,omitempty"`
 	State string `json:"state,omitempty"`
 }
+
+// TagPolicy represents the tags which every cluster created in a subscription
+// must have.  The tags are propagated to the managed resources of the
+// clusters.
+type TagPolicy struct {
+	MissingFields
+
+	RequiredTags []string `json:"requiredTags,omitempty"`
+}
+
+// Match returns the tags of tags which are required by the policy, and the
+// names of the required tags which are missing or empty.  Tag names are
+// matched case-insensitively, as Azure does.
+func (p *TagPolicy) Match(tags map[string]string) (required map[string]string, missing []string) {
+	required = map[string]string{}
+	if p == nil {
+		return required, nil
+	}
+
+	for _, name := range p.RequiredTags {
+		found := false
+		for k, v := range tags {
+			if strings.EqualFold(k, name) && v != "" {
+				required[k] = v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+
+	return required, missing
+}
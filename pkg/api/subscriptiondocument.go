@@ -36,6 +36,10 @@ type SubscriptionDocument struct {
 	Deleting bool `json:"deleting,omitempty"`
 
 	Subscription *Subscription `json:"subscription,omitempty"`
+
+	// TagPolicy is set by an admin, not by ARM, so that it survives the
+	// subscription lifecycle PUTs which replace Subscription.
+	TagPolicy *TagPolicy `json:"tagPolicy,omitempty"`
 }
 
 func (c *SubscriptionDocument) String() string {
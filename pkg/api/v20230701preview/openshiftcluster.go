@@ -55,6 +55,9 @@ type OpenShiftClusterProperties struct {
 	// The cluster service principal profile.
 	ServicePrincipalProfile ServicePrincipalProfile `json:"servicePrincipalProfile,omitempty"`
 
+	// The Azure identities used by the cluster components, to which Azure RBAC and policy can be scoped.
+	ClusterIdentities []ClusterIdentity `json:"clusterIdentities,omitempty" mutable:"true"`
+
 	// The cluster network profile.
 	NetworkProfile NetworkProfile `json:"networkProfile,omitempty"`
 
@@ -64,11 +67,62 @@ type OpenShiftClusterProperties struct {
 	// The cluster worker profiles.
 	WorkerProfiles []WorkerProfile `json:"workerProfiles,omitempty"`
 
+	// The progress of the scale operations of the cluster worker profiles.
+	WorkerProfilesScaleStatus []WorkerProfileScale `json:"workerProfilesScaleStatus,omitempty" mutable:"true"`
+
+	// The progress of the creation of the machine sets of the additional worker profiles during install.
+	WorkerMachineSetsProgress *WorkerMachineSetsProgress `json:"workerMachineSetsProgress,omitempty" mutable:"true"`
+
 	// The cluster API server profile.
 	APIServerProfile APIServerProfile `json:"apiserverProfile,omitempty"`
 
 	// The cluster ingress profiles.
 	IngressProfiles []IngressProfile `json:"ingressProfiles,omitempty"`
+
+	// The cluster maintenance window.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty" mutable:"true"`
+
+	// The default resource quota and limit range of new projects.
+	ProjectTemplateProfile *ProjectTemplateProfile `json:"projectTemplateProfile,omitempty" mutable:"true"`
+
+	// The OpenID Connect identity provider with which users log in to the cluster.
+	IdentityProviderProfile *IdentityProviderProfile `json:"identityProviderProfile,omitempty"`
+
+	// The pull-through caches through which images of upstream registries are pulled by digest.
+	RegistryMirrorProfiles []RegistryMirrorProfile `json:"registryMirrorProfiles,omitempty" mutable:"true"`
+
+	// The storage class which is the default storage class of the cluster.
+	DefaultStorageClassProfile *DefaultStorageClassProfile `json:"defaultStorageClassProfile,omitempty" mutable:"true"`
+
+	// The Azure Files storage class for ReadWriteMany volumes.  If omitted, no Azure Files storage class is created.
+	AzureFileCSIProfile *AzureFileCSIProfile `json:"azureFileCsiProfile,omitempty" mutable:"true"`
+
+	// The retention and storage of the platform Prometheus.  If omitted, the platform defaults are used.
+	MonitoringProfile *MonitoringProfile `json:"monitoringProfile,omitempty" mutable:"true"`
+
+	// How the worker nodes are updated during upgrades.  If omitted, the machine config pool chooses the order and the pace of the updates.
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty" mutable:"true"`
+
+	// The kubelet eviction thresholds of the nodes.  If omitted, the platform thresholds are used.
+	NodeEvictionProfile *NodeEvictionProfile `json:"nodeEvictionProfile,omitempty" mutable:"true"`
+
+	// The default seccomp profile and the SELinux booleans of the nodes.  Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+
+	// The off-cluster destination to which the cluster logs are forwarded.  If omitted, logs are not forwarded.
+	LogForwardingProfile *LogForwardingProfile `json:"logForwardingProfile,omitempty" mutable:"true"`
+
+	// The admission webhook configurations which are registered on the cluster.  If omitted, none are registered.
+	AdmissionWebhookProfiles []AdmissionWebhookProfile `json:"admissionWebhookProfiles,omitempty" mutable:"true"`
+
+	// The cluster-wide default node selector of workloads.  If omitted, workloads are not constrained to any nodes by default.
+	SchedulerProfile *SchedulerProfile `json:"schedulerProfile,omitempty" mutable:"true"`
+
+	// The destinations outside the cluster to which the pods of the customer namespaces may connect.  If omitted, egress is not restricted.
+	EgressFirewallProfile *EgressFirewallProfile `json:"egressFirewallProfile,omitempty" mutable:"true"`
+
+	// A template exported from a cluster, whose properties pre-fill those which the request does not set.  Only used when the cluster is created; it is not returned in responses.
+	Template *OpenShiftClusterTemplate `json:"template,omitempty"`
 }
 
 // ProvisioningState represents a provisioning state.
@@ -95,6 +149,16 @@ const (
 	FipsValidatedModulesDisabled FipsValidatedModules = "Disabled"
 )
 
+// ExistingResourceGroup determines if the cluster resource group is created by
+// the customer rather than the RP.
+type ExistingResourceGroup string
+
+// ExistingResourceGroup constants.
+const (
+	ExistingResourceGroupEnabled  ExistingResourceGroup = "Enabled"
+	ExistingResourceGroupDisabled ExistingResourceGroup = "Disabled"
+)
+
 // ClusterProfile represents a cluster profile.
 type ClusterProfile struct {
 	// The pull secret for the cluster.
@@ -111,6 +175,33 @@ type ClusterProfile struct {
 
 	// If FIPS validated crypto modules are used
 	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
+
+	// The TTL in seconds of the cluster API and ingress DNS records, if the
+	// cluster domain is managed by the RP.
+	DNSRecordTTL int `json:"dnsRecordTtl,omitempty"`
+
+	// A template for the names of the resources which the RP creates in the
+	// cluster resource group, such as the load balancers, network interfaces
+	// and disks.  The placeholder {name} is replaced with the cluster name,
+	// and a stable random suffix is always appended to keep the names unique.
+	ResourceNameTemplate string `json:"resourceNameTemplate,omitempty"`
+
+	// If the cluster resource group already exists.  The resource group
+	// must be empty and in the same location as the cluster.  When the
+	// cluster is deleted, the resources which belong to the cluster are
+	// deleted but the resource group itself is not.
+	ExistingResourceGroup ExistingResourceGroup `json:"existingResourceGroup,omitempty"`
+
+	// The time zone of the cluster nodes, as a tz database name such as
+	// Europe/London.  If not specified, the nodes use UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// The resource ID of an Azure DNS zone, which may be in another
+	// subscription, in which to create the cluster API and ingress DNS
+	// records.  Only valid with a custom domain, which must be the zone or
+	// within it.  The cluster service principal must have DNS Zone
+	// Contributor on the zone.
+	DNSZoneID string `json:"dnsZoneId,omitempty"`
 }
 
 // ConsoleProfile represents a console profile.
@@ -128,6 +219,185 @@ type ServicePrincipalProfile struct {
 	ClientSecret string `json:"clientSecret,omitempty" mutable:"true"`
 }
 
+// ClusterIdentityComponent represents the cluster component which uses an identity.
+type ClusterIdentityComponent string
+
+// ClusterIdentityComponent constants.
+const (
+	ClusterIdentityComponentCloudControllerManager ClusterIdentityComponent = "CloudControllerManager"
+	ClusterIdentityComponentIngress                ClusterIdentityComponent = "Ingress"
+	ClusterIdentityComponentMachineAPI             ClusterIdentityComponent = "MachineAPI"
+	ClusterIdentityComponentImageRegistry          ClusterIdentityComponent = "ImageRegistry"
+	ClusterIdentityComponentDiskCSIDriver          ClusterIdentityComponent = "DiskCSIDriver"
+	ClusterIdentityComponentFileCSIDriver          ClusterIdentityComponent = "FileCSIDriver"
+)
+
+// ClusterIdentity represents an Azure identity used by a cluster component.
+type ClusterIdentity struct {
+	// The cluster component which uses the identity.
+	Component ClusterIdentityComponent `json:"component,omitempty"`
+
+	// The client ID of the identity.
+	ClientID string `json:"clientId,omitempty"`
+
+	// The object ID of the identity.
+	ObjectID string `json:"objectId,omitempty"`
+}
+
+// RegistryMirrorProfile represents a pull-through cache, e.g. an Azure Container Registry cache rule, from which the cluster pulls the images of an upstream registry by digest, falling back to the upstream registry if the cache is unavailable.
+type RegistryMirrorProfile struct {
+	// The upstream registry, e.g. docker.io, optionally followed by a repository path, e.g. docker.io/library.
+	Source string `json:"source,omitempty"`
+
+	// The pull-through cache, e.g. myregistry.azurecr.io/docker-hub, optionally followed by a repository path.  It must be reachable over https.
+	Mirror string `json:"mirror,omitempty"`
+}
+
+// DefaultStorageClassProfile represents the default storage class of the cluster.  Any other storage class marked as default is unmarked.
+type DefaultStorageClassProfile struct {
+	// The name of the storage class.  If no parameters are provided, it must be an existing storage class, e.g. managed-csi or managed-csi-encrypted-cmk.
+	Name string `json:"name,omitempty"`
+
+	// The Azure Disk CSI driver parameters of the storage class, e.g. skuName or cachingMode.  If provided, a storage class with these parameters is created.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AzureFileCSIProfile represents a storage class which provisions Azure Files shares with the Azure File CSI driver, for volumes with the ReadWriteMany access mode.
+type AzureFileCSIProfile struct {
+	// The name of the storage class, e.g. azurefile-rwx.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// The SKU of the storage accounts of the file shares, e.g. Standard_ZRS or Premium_LRS.  It must be available in the cluster location.  Defaults to Standard_LRS.
+	SKUName string `json:"skuName,omitempty"`
+}
+
+// MonitoringProfile represents the retention and persistent storage of the platform Prometheus of the cluster monitoring stack.
+type MonitoringProfile struct {
+	// The time for which metrics are kept, e.g. 30d, between 1d and 90d.  Retention beyond 15d requires persistent storage.  Defaults to 15d.
+	Retention string `json:"retention,omitempty"`
+
+	// The size of the persistent volume of each Prometheus replica, e.g. 100Gi, between 10Gi and 1Ti.  If omitted, Prometheus uses ephemeral storage and its metrics are lost when its pods are rescheduled.
+	StorageSize string `json:"storageSize,omitempty"`
+
+	// The storage class of the persistent volumes, e.g. managed-csi.  It must provision Azure disks.  Defaults to the default storage class of the cluster.
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// UpgradeProfile represents how the worker nodes of the cluster are updated during upgrades.
+type UpgradeProfile struct {
+	// The name of a worker node which is updated after all the other worker nodes, e.g. to keep a critical singleton workload running for as long as possible.
+	LastNodeName string `json:"lastNodeName,omitempty"`
+
+	// The number, e.g. 2, or the percentage, e.g. 10%, of worker nodes which may be unavailable at the same time while they are updated, between 1 and 20 nodes or 1% and 50%.  Defaults to the platform value of 1 node.
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// NodeEvictionProfile represents the kubelet eviction thresholds of the nodes of the cluster.  Changing them causes a rolling reboot of the nodes.
+type NodeEvictionProfile struct {
+	// The hard eviction thresholds, at which pods are evicted immediately.  A threshold which is omitted keeps its platform value.
+	Hard *EvictionThresholds `json:"hard,omitempty"`
+
+	// The soft eviction thresholds, at which pods are evicted gracefully once the threshold has been met for the soft grace period.
+	Soft *EvictionThresholds `json:"soft,omitempty"`
+
+	// How long a soft eviction threshold must be met before pods are evicted, e.g. 1m30s.  Required if soft thresholds are set.
+	SoftGracePeriod string `json:"softGracePeriod,omitempty"`
+}
+
+// EvictionThresholds represents the eviction thresholds of the kubelet eviction signals.  Each threshold is a quantity, e.g. 500Mi, or a percentage of the capacity, e.g. 10%.
+type EvictionThresholds struct {
+	// The threshold of the memory.available signal.
+	MemoryAvailable string `json:"memoryAvailable,omitempty"`
+
+	// The threshold of the nodefs.available signal.
+	NodeFSAvailable string `json:"nodefsAvailable,omitempty"`
+
+	// The threshold of the imagefs.available signal.
+	ImageFSAvailable string `json:"imagefsAvailable,omitempty"`
+}
+
+// SecurityProfile represents the seccomp and SELinux defaults of the nodes of the cluster.
+type SecurityProfile struct {
+	// The seccomp profile of containers which do not set one.  Unconfined, the platform behaviour, runs them without a seccomp profile.  RuntimeDefault applies the default profile of the container runtime; containers which need system calls it blocks must then set the Unconfined seccomp profile explicitly and be admitted by a security context constraint which allows it.  Supported from OpenShift 4.12.
+	DefaultSeccompProfile SeccompProfile `json:"defaultSeccompProfile,omitempty"`
+
+	// The SELinux booleans to turn on: deny_ptrace, which prevents processes from tracing others, including debuggers, and deny_execmem, which prevents unconfined processes from using writable and executable memory, including some just-in-time compilers.
+	SELinuxBooleans []string `json:"seLinuxBooleans,omitempty"`
+}
+
+// SeccompProfile represents the seccomp profile of containers which do not set one.
+type SeccompProfile string
+
+// SeccompProfile constants.
+const (
+	SeccompProfileUnconfined     SeccompProfile = "Unconfined"
+	SeccompProfileRuntimeDefault SeccompProfile = "RuntimeDefault"
+)
+
+// LogForwardingProfile represents the off-cluster destination to which the cluster logs are forwarded by the OpenShift Logging operator, which must be installed on the cluster.
+type LogForwardingProfile struct {
+	// The type of the destination.
+	Type LogForwardingType `json:"type,omitempty"`
+
+	// The URL of the syslog server, e.g. tls://syslog.example.com:6514.  The scheme is tcp, tls or udp.  Only used by the Syslog type.
+	URL string `json:"url,omitempty"`
+
+	// The ID of the Log Analytics workspace to which the logs are sent.  Only used by the AzureMonitor type.
+	WorkspaceID string `json:"workspaceId,omitempty"`
+
+	// The primary or secondary key of the Log Analytics workspace.  Only used by the AzureMonitor type.  It is not returned in responses.
+	SharedKey string `json:"sharedKey,omitempty"`
+
+	// The types of logs which are forwarded.  If omitted, all of them are forwarded.
+	LogTypes []LogType `json:"logTypes,omitempty"`
+}
+
+// LogForwardingType represents the type of a log forwarding destination.
+type LogForwardingType string
+
+// LogForwardingType constants.
+const (
+	LogForwardingTypeSyslog       LogForwardingType = "Syslog"
+	LogForwardingTypeAzureMonitor LogForwardingType = "AzureMonitor"
+)
+
+// LogType represents a type of cluster logs.
+type LogType string
+
+// LogType constants.
+const (
+	LogTypeApplication    LogType = "Application"
+	LogTypeInfrastructure LogType = "Infrastructure"
+	LogTypeAudit          LogType = "Audit"
+)
+
+// AdmissionWebhookProfile represents an admission webhook configuration which is registered on the cluster.
+type AdmissionWebhookProfile struct {
+	// The ValidatingWebhookConfiguration or MutatingWebhookConfiguration of API version admissionregistration.k8s.io/v1, in JSON or YAML.
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// SchedulerProfile represents the configuration of the cluster scheduler.
+type SchedulerProfile struct {
+	// The node labels, e.g. node-role.kubernetes.io/app=,region=east, to whose nodes the pods of projects without a node selector of their own are constrained.  At least one worker node must have the labels.
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+}
+
+// EgressFirewallProfile represents the destinations outside the cluster to which the pods of the customer namespaces may connect.  Egress to any other destination is denied.
+type EgressFirewallProfile struct {
+	// The allowed destinations.  The API servers of the cluster should be allowed, as pods which use the Kubernetes API cannot reach them otherwise.
+	AllowedDestinations []EgressDestination `json:"allowedDestinations,omitempty"`
+}
+
+// EgressDestination represents a destination outside the cluster.  Exactly one of cidr and dnsName must be set.
+type EgressDestination struct {
+	// The IP range of the destination, e.g. 203.0.113.0/24.
+	CIDR string `json:"cidr,omitempty"`
+
+	// The DNS name of the destination, e.g. www.example.com.
+	DNSName string `json:"dnsName,omitempty"`
+}
+
 // The outbound routing strategy used to provide your cluster egress to the internet.
 type OutboundType string
 
@@ -148,6 +418,10 @@ type LoadBalancerProfile struct {
 	ManagedOutboundIPs *ManagedOutboundIPs `json:"managedOutboundIps,omitempty" mutable:"true"`
 	// The list of effective outbound IP addresses of the public load balancer.
 	EffectiveOutboundIPs []EffectiveOutboundIP `json:"effectiveOutboundIps,omitempty"`
+	// The desired managed outbound IP prefix for the cluster public load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of individual managed outbound IPs.
+	ManagedOutboundIPPrefix *ManagedOutboundIPPrefix `json:"managedOutboundIpPrefix,omitempty"`
+	// The effective outbound IP prefix of the public load balancer.
+	EffectiveOutboundIPPrefix *EffectiveOutboundIPPrefix `json:"effectiveOutboundIpPrefix,omitempty"`
 	// The desired outbound IP resources for the cluster load balancer.
 	OutboundIPs []OutboundIP `json:"outboundIps,omitempty" mutable:"true"`
 	// The desired outbound IP Prefix resources for the cluster load balancer.
@@ -165,6 +439,20 @@ type ManagedOutboundIPs struct {
 	Count int `json:"count,omitempty"`
 }
 
+// ManagedOutboundIPPrefix represents the desired managed outbound IP prefix for the cluster public load balancer.
+type ManagedOutboundIPPrefix struct {
+	// PrefixLength represents the length of the IPv4 public IP prefix created and managed by Azure for the cluster public load balancer.  Allowed values are in the range of 28 - 31.
+	PrefixLength int `json:"prefixLength,omitempty"`
+}
+
+// EffectiveOutboundIPPrefix represents the effective outbound IP prefix of the cluster public load balancer.
+type EffectiveOutboundIPPrefix struct {
+	// The resource ID of the public IP prefix.
+	ID string `json:"id,omitempty"`
+	// The CIDR of the public IP prefix.
+	IPPrefix string `json:"ipPrefix,omitempty"`
+}
+
 // OutboundIP represents a desired outbound IP resource for the cluster load balancer.
 type OutboundIP ResourceReference
 
@@ -179,13 +467,83 @@ type NetworkProfile struct {
 	// The CIDR used for OpenShift/Kubernetes Services.
 	ServiceCIDR string `json:"serviceCidr,omitempty"`
 
+	// The software defined network (SDN) to use when installing the cluster.
+	// If not specified, the default for the OpenShift version is used.
+	SoftwareDefinedNetwork SoftwareDefinedNetwork `json:"softwareDefinedNetwork,omitempty"`
+
 	// The OutboundType used for egress traffic.
 	OutboundType OutboundType `json:"outboundType,omitempty"`
 
+	// The maximum number of pods per node.  If not specified, the kubelet
+	// default of 250 is used.
+	MaxPods int `json:"maxPods,omitempty"`
+
 	// The cluster load balancer profile.
 	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+
+	// An existing load balancer which fronts the API server and the default
+	// ingress instead of the managed load balancer frontends.
+	CustomerLoadBalancerProfile *CustomerLoadBalancerProfile `json:"customerLoadBalancerProfile,omitempty"`
+
+	// How the egress of the worker nodes reaches the internet, as found on the live Azure resources.
+	EffectiveOutboundProfile *EffectiveOutboundProfile `json:"effectiveOutboundProfile,omitempty" mutable:"true"`
+}
+
+// EffectiveOutboundProfile represents the outbound connectivity method which the worker nodes actually use.  A default route of the route table of the worker subnet to anything but the internet takes precedence over a NAT gateway on the subnet, which takes precedence over the outbound rules of the public load balancer.
+type EffectiveOutboundProfile struct {
+	// The outbound connectivity method.
+	Method EffectiveOutboundMethod `json:"method,omitempty"`
+
+	// The public IP addresses, and the public IP prefixes in CIDR notation, from which the egress originates.  Not set if the egress is user defined.
+	OutboundIPAddresses []string `json:"outboundIpAddresses,omitempty"`
+
+	// The resource ID of the NAT gateway of the worker subnet, if any.
+	NATGatewayID string `json:"natGatewayId,omitempty"`
+
+	// The resource ID of the route table of the worker subnet, if any.
+	RouteTableID string `json:"routeTableId,omitempty"`
+
+	// The next hop IP address of the default route of the route table, if the egress is user defined through a virtual appliance.
+	NextHopIPAddress string `json:"nextHopIpAddress,omitempty"`
 }
 
+// EffectiveOutboundMethod represents the outbound connectivity method which the worker nodes actually use.
+type EffectiveOutboundMethod string
+
+// EffectiveOutboundMethod constants.
+const (
+	EffectiveOutboundMethodLoadBalancer       EffectiveOutboundMethod = "LoadBalancer"
+	EffectiveOutboundMethodNATGateway         EffectiveOutboundMethod = "NATGateway"
+	EffectiveOutboundMethodUserDefinedRouting EffectiveOutboundMethod = "UserDefinedRouting"
+)
+
+// CustomerLoadBalancerProfile represents an existing Standard load balancer,
+// in the subscription and location of the cluster, on which the RP registers
+// the backends of the API server and the default ingress.  The API server and
+// the default ingress must have Private visibility.
+type CustomerLoadBalancerProfile struct {
+	// The resource ID of the load balancer.
+	ID string `json:"id,omitempty"`
+
+	// The name of the frontend IP configuration of the load balancer which
+	// serves the API server on port 6443.
+	APIServerFrontendIPConfiguration string `json:"apiServerFrontendIpConfiguration,omitempty"`
+
+	// The name of the frontend IP configuration of the load balancer which
+	// serves the default ingress on ports 80 and 443.
+	IngressFrontendIPConfiguration string `json:"ingressFrontendIpConfiguration,omitempty"`
+}
+
+// SoftwareDefinedNetwork represents the software defined network (SDN) of a
+// cluster.
+type SoftwareDefinedNetwork string
+
+// SoftwareDefinedNetwork constants.
+const (
+	SoftwareDefinedNetworkOVNKubernetes SoftwareDefinedNetwork = "OVNKubernetes"
+	SoftwareDefinedNetworkOpenShiftSDN  SoftwareDefinedNetwork = "OpenShiftSDN"
+)
+
 // EncryptionAtHost represents encryption at host state
 type EncryptionAtHost string
 
@@ -195,6 +553,24 @@ const (
 	EncryptionAtHostDisabled EncryptionAtHost = "Disabled"
 )
 
+// DiskStorageAccountType represents the storage account type of a managed disk
+type DiskStorageAccountType string
+
+// DiskStorageAccountType constants
+const (
+	DiskStorageAccountTypePremiumLRS     DiskStorageAccountType = "Premium_LRS"
+	DiskStorageAccountTypeStandardSSDLRS DiskStorageAccountType = "StandardSSD_LRS"
+)
+
+// AcceleratedNetworking represents accelerated networking state
+type AcceleratedNetworking string
+
+// AcceleratedNetworking constants
+const (
+	AcceleratedNetworkingEnabled  AcceleratedNetworking = "Enabled"
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+)
+
 // MasterProfile represents a master profile.
 type MasterProfile struct {
 	// The size of the master VMs.
@@ -230,13 +606,55 @@ type WorkerProfile struct {
 	SubnetID string `json:"subnetId,omitempty"`
 
 	// The number of worker VMs.
-	Count int `json:"count,omitempty"`
+	Count int `json:"count,omitempty" mutable:"true"`
 
 	// Whether master virtual machines are encrypted at host.
 	EncryptionAtHost EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 
 	// The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID string `json:"diskEncryptionSetId,omitempty"`
+
+	// The storage account type of the worker VM OS disks.  Only supported for
+	// additional worker profiles.  If unset, Premium_LRS is used when
+	// supported by the worker VM size, StandardSSD_LRS otherwise.
+	DiskStorageAccountType DiskStorageAccountType `json:"diskStorageAccountType,omitempty"`
+
+	// Whether the worker VM network interfaces use accelerated networking.  If
+	// unset, it is enabled when supported by the worker VM size.
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+
+	// The proximity placement group of the worker VMs.  If unset, the worker
+	// VMs are spread across the availability zones of the region.
+	ProximityPlacementGroupProfile *ProximityPlacementGroupProfile `json:"proximityPlacementGroupProfile,omitempty"`
+}
+
+// ProximityPlacementGroupProfile represents the proximity placement group of
+// the VMs of a worker profile.
+type ProximityPlacementGroupProfile struct {
+	// The resource ID of an existing proximity placement group.  If unset, a
+	// proximity placement group is created in the cluster resource group.
+	ID string `json:"id,omitempty"`
+}
+
+// WorkerProfileScale represents the scale operation of a worker profile.
+type WorkerProfileScale struct {
+	// The worker profile name.
+	Name string `json:"name,omitempty"`
+
+	// The number of ready worker VMs.
+	CurrentReplicas int `json:"currentReplicas"`
+
+	// The number of worker VMs requested.
+	TargetReplicas int `json:"targetReplicas"`
+}
+
+// WorkerMachineSetsProgress represents the progress of the creation of the worker machine sets.
+type WorkerMachineSetsProgress struct {
+	// The number of worker machine sets created.
+	Created int `json:"created"`
+
+	// The number of worker machine sets to create.
+	Total int `json:"total"`
 }
 
 // APIServerProfile represents an API server profile.
@@ -270,8 +688,70 @@ type IngressProfile struct {
 
 	// The IP of the ingress.
 	IP string `json:"ip,omitempty"`
+
+	// The domain of additional ingress profiles. It is not set on the default ingress profile.
+	Domain string `json:"domain,omitempty"`
+
+	// The PEM encoded private key and certificate chain served for *.<domain> by additional ingress profiles. It is not returned in responses.
+	ServingCertificate string `json:"servingCertificate,omitempty" mutable:"true"`
 }
 
+// MaintenanceWindow represents the weekly window in which automated maintenance of the cluster may start.  Maintenance requested outside the window is deferred to the start of the next window, unless it is urgent.
+type MaintenanceWindow struct {
+	// The days of the week on which the window opens.
+	Days []Weekday `json:"days,omitempty"`
+
+	// The time of day at which the window opens, in HH:MM 24 hour format.
+	StartTime string `json:"startTime,omitempty"`
+
+	// The duration of the window in hours.  Allowed values are in the range of 1 - 24.
+	DurationHours int `json:"durationHours,omitempty"`
+
+	// The IANA time zone of the start time, e.g. Europe/Berlin.  Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ProjectTemplateProfile represents the default resource quota and limit range which are created in each project requested by a user.  Values are Kubernetes resource quantities, e.g. 500m or 2Gi.
+type ProjectTemplateProfile struct {
+	// The hard limits of the default resource quota, keyed by quota resource, e.g. pods, requests.cpu or count/deployments.apps.
+	ResourceQuota map[string]string `json:"resourceQuota,omitempty"`
+
+	// The default container resource limits of the default limit range, keyed by cpu, memory or ephemeral-storage.
+	DefaultLimits map[string]string `json:"defaultLimits,omitempty"`
+
+	// The default container resource requests of the default limit range, keyed by cpu, memory or ephemeral-storage.  Requests may not exceed the corresponding default limits.
+	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
+}
+
+// IdentityProviderProfile represents an OpenID Connect identity provider, e.g. Azure AD, which is configured in the cluster OAuth server at install time.
+type IdentityProviderProfile struct {
+	// The name of the identity provider, which is shown on the login page and prefixes the names of its identities.
+	Name string `json:"name,omitempty"`
+
+	// The issuer URL of the identity provider, e.g. https://login.microsoftonline.com/<tenant id>/v2.0.  It must use https and serve /.well-known/openid-configuration.
+	Issuer string `json:"issuer,omitempty"`
+
+	// The client ID of the application registered with the identity provider.
+	ClientID string `json:"clientId,omitempty"`
+
+	// The client secret of the application registered with the identity provider. It is not returned in responses.
+	ClientSecret string `json:"clientSecret,omitempty" mutable:"true"`
+}
+
+// Weekday represents a day of the week.
+type Weekday string
+
+// Weekday constants
+const (
+	WeekdaySunday    Weekday = "Sunday"
+	WeekdayMonday    Weekday = "Monday"
+	WeekdayTuesday   Weekday = "Tuesday"
+	WeekdayWednesday Weekday = "Wednesday"
+	WeekdayThursday  Weekday = "Thursday"
+	WeekdayFriday    Weekday = "Friday"
+	WeekdaySaturday  Weekday = "Saturday"
+)
+
 // CreatedByType by defines user type, which executed the request
 type CreatedByType string
 
@@ -22,11 +22,16 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		Properties: OpenShiftClusterProperties{
 			ProvisioningState: ProvisioningState(oc.Properties.ProvisioningState),
 			ClusterProfile: ClusterProfile{
-				PullSecret:           string(oc.Properties.ClusterProfile.PullSecret),
-				Domain:               oc.Properties.ClusterProfile.Domain,
-				Version:              oc.Properties.ClusterProfile.Version,
-				ResourceGroupID:      oc.Properties.ClusterProfile.ResourceGroupID,
-				FipsValidatedModules: FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				PullSecret:            string(oc.Properties.ClusterProfile.PullSecret),
+				Domain:                oc.Properties.ClusterProfile.Domain,
+				Version:               oc.Properties.ClusterProfile.Version,
+				ResourceGroupID:       oc.Properties.ClusterProfile.ResourceGroupID,
+				FipsValidatedModules:  FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules),
+				DNSRecordTTL:          oc.Properties.ClusterProfile.DNSRecordTTL,
+				ResourceNameTemplate:  oc.Properties.ClusterProfile.ResourceNameTemplate,
+				ExistingResourceGroup: ExistingResourceGroup(oc.Properties.ClusterProfile.ExistingResourceGroup),
+				TimeZone:              oc.Properties.ClusterProfile.TimeZone,
+				DNSZoneID:             oc.Properties.ClusterProfile.DNSZoneID,
 			},
 			ConsoleProfile: ConsoleProfile{
 				URL: oc.Properties.ConsoleProfile.URL,
@@ -36,9 +41,11 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				ClientSecret: string(oc.Properties.ServicePrincipalProfile.ClientSecret),
 			},
 			NetworkProfile: NetworkProfile{
-				PodCIDR:      oc.Properties.NetworkProfile.PodCIDR,
-				ServiceCIDR:  oc.Properties.NetworkProfile.ServiceCIDR,
-				OutboundType: OutboundType(oc.Properties.NetworkProfile.OutboundType),
+				PodCIDR:                oc.Properties.NetworkProfile.PodCIDR,
+				ServiceCIDR:            oc.Properties.NetworkProfile.ServiceCIDR,
+				SoftwareDefinedNetwork: SoftwareDefinedNetwork(oc.Properties.NetworkProfile.SoftwareDefinedNetwork),
+				OutboundType:           OutboundType(oc.Properties.NetworkProfile.OutboundType),
+				MaxPods:                oc.Properties.NetworkProfile.MaxPods,
 			},
 			MasterProfile: MasterProfile{
 				VMSize:              VMSize(oc.Properties.MasterProfile.VMSize),
@@ -67,6 +74,19 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 			}
 		}
 
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix = &ManagedOutboundIPPrefix{
+				PrefixLength: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength,
+			}
+		}
+
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix = &EffectiveOutboundIPPrefix{
+				ID:       oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.ID,
+				IPPrefix: oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.IPPrefix,
+			}
+		}
+
 		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs != nil {
 			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = make([]EffectiveOutboundIP, 0, len(oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs))
 			for _, effectiveOutboundIP := range oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs {
@@ -95,6 +115,24 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		}
 	}
 
+	if oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+		out.Properties.NetworkProfile.CustomerLoadBalancerProfile = &CustomerLoadBalancerProfile{
+			ID:                               oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID,
+			APIServerFrontendIPConfiguration: oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.APIServerFrontendIPConfiguration,
+			IngressFrontendIPConfiguration:   oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration,
+		}
+	}
+
+	if oc.Properties.NetworkProfile.EffectiveOutboundProfile != nil {
+		out.Properties.NetworkProfile.EffectiveOutboundProfile = &EffectiveOutboundProfile{
+			Method:              EffectiveOutboundMethod(oc.Properties.NetworkProfile.EffectiveOutboundProfile.Method),
+			OutboundIPAddresses: append([]string(nil), oc.Properties.NetworkProfile.EffectiveOutboundProfile.OutboundIPAddresses...),
+			NATGatewayID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.NATGatewayID,
+			RouteTableID:        oc.Properties.NetworkProfile.EffectiveOutboundProfile.RouteTableID,
+			NextHopIPAddress:    oc.Properties.NetworkProfile.EffectiveOutboundProfile.NextHopIPAddress,
+		}
+	}
+
 	if oc.Properties.WorkerProfiles != nil {
 		workerProfiles := oc.Properties.WorkerProfiles
 
@@ -106,17 +144,53 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 		out.Properties.WorkerProfiles = make([]WorkerProfile, 0, len(workerProfiles))
 		for _, p := range workerProfiles {
 			out.Properties.WorkerProfiles = append(out.Properties.WorkerProfiles, WorkerProfile{
-				Name:                p.Name,
-				VMSize:              VMSize(p.VMSize),
-				DiskSizeGB:          p.DiskSizeGB,
-				SubnetID:            p.SubnetID,
-				Count:               p.Count,
-				EncryptionAtHost:    EncryptionAtHost(p.EncryptionAtHost),
-				DiskEncryptionSetID: p.DiskEncryptionSetID,
+				Name:                   p.Name,
+				VMSize:                 VMSize(p.VMSize),
+				DiskSizeGB:             p.DiskSizeGB,
+				SubnetID:               p.SubnetID,
+				Count:                  p.Count,
+				EncryptionAtHost:       EncryptionAtHost(p.EncryptionAtHost),
+				DiskEncryptionSetID:    p.DiskEncryptionSetID,
+				DiskStorageAccountType: DiskStorageAccountType(p.DiskStorageAccountType),
+				AcceleratedNetworking:  AcceleratedNetworking(p.AcceleratedNetworking),
+			})
+			if p.ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfiles[len(out.Properties.WorkerProfiles)-1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: p.ProximityPlacementGroupProfile.ID,
+				}
+			}
+		}
+	}
+
+	if oc.Properties.WorkerProfilesScaleStatus != nil {
+		out.Properties.WorkerProfilesScaleStatus = make([]WorkerProfileScale, 0, len(oc.Properties.WorkerProfilesScaleStatus))
+		for _, s := range oc.Properties.WorkerProfilesScaleStatus {
+			out.Properties.WorkerProfilesScaleStatus = append(out.Properties.WorkerProfilesScaleStatus, WorkerProfileScale{
+				Name:            s.Name,
+				CurrentReplicas: s.CurrentReplicas,
+				TargetReplicas:  s.TargetReplicas,
+			})
+		}
+	}
+
+	if oc.Properties.ClusterIdentities != nil {
+		out.Properties.ClusterIdentities = make([]ClusterIdentity, 0, len(oc.Properties.ClusterIdentities))
+		for _, i := range oc.Properties.ClusterIdentities {
+			out.Properties.ClusterIdentities = append(out.Properties.ClusterIdentities, ClusterIdentity{
+				Component: ClusterIdentityComponent(i.Component),
+				ClientID:  i.ClientID,
+				ObjectID:  i.ObjectID,
 			})
 		}
 	}
 
+	if oc.Properties.WorkerMachineSetsProgress != nil {
+		out.Properties.WorkerMachineSetsProgress = &WorkerMachineSetsProgress{
+			Created: oc.Properties.WorkerMachineSetsProgress.Created,
+			Total:   oc.Properties.WorkerMachineSetsProgress.Total,
+		}
+	}
+
 	if oc.Properties.IngressProfiles != nil {
 		out.Properties.IngressProfiles = make([]IngressProfile, 0, len(oc.Properties.IngressProfiles))
 		for _, p := range oc.Properties.IngressProfiles {
@@ -124,6 +198,139 @@ func (c openShiftClusterConverter) ToExternal(oc *api.OpenShiftCluster) interfac
 				Name:       p.Name,
 				Visibility: Visibility(p.Visibility),
 				IP:         p.IP,
+				Domain:     p.Domain,
+			})
+		}
+	}
+
+	if oc.Properties.MaintenanceWindow != nil {
+		out.Properties.MaintenanceWindow = &MaintenanceWindow{
+			StartTime:     oc.Properties.MaintenanceWindow.StartTime,
+			DurationHours: oc.Properties.MaintenanceWindow.DurationHours,
+			TimeZone:      oc.Properties.MaintenanceWindow.TimeZone,
+		}
+		if oc.Properties.MaintenanceWindow.Days != nil {
+			out.Properties.MaintenanceWindow.Days = make([]Weekday, 0, len(oc.Properties.MaintenanceWindow.Days))
+			for _, day := range oc.Properties.MaintenanceWindow.Days {
+				out.Properties.MaintenanceWindow.Days = append(out.Properties.MaintenanceWindow.Days, Weekday(day))
+			}
+		}
+	}
+
+	if oc.Properties.ProjectTemplateProfile != nil {
+		out.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+			ResourceQuota:   copyQuantities(oc.Properties.ProjectTemplateProfile.ResourceQuota),
+			DefaultLimits:   copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultLimits),
+			DefaultRequests: copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultRequests),
+		}
+	}
+
+	if oc.Properties.IdentityProviderProfile != nil {
+		out.Properties.IdentityProviderProfile = &IdentityProviderProfile{
+			Name:     oc.Properties.IdentityProviderProfile.Name,
+			Issuer:   oc.Properties.IdentityProviderProfile.Issuer,
+			ClientID: oc.Properties.IdentityProviderProfile.ClientID,
+		}
+	}
+
+	if oc.Properties.RegistryMirrorProfiles != nil {
+		out.Properties.RegistryMirrorProfiles = make([]RegistryMirrorProfile, 0, len(oc.Properties.RegistryMirrorProfiles))
+		for _, p := range oc.Properties.RegistryMirrorProfiles {
+			out.Properties.RegistryMirrorProfiles = append(out.Properties.RegistryMirrorProfiles, RegistryMirrorProfile{
+				Source: p.Source,
+				Mirror: p.Mirror,
+			})
+		}
+	}
+
+	if oc.Properties.DefaultStorageClassProfile != nil {
+		out.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+			Name:       oc.Properties.DefaultStorageClassProfile.Name,
+			Parameters: copyQuantities(oc.Properties.DefaultStorageClassProfile.Parameters),
+		}
+	}
+
+	if oc.Properties.AzureFileCSIProfile != nil {
+		out.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+			StorageClassName: oc.Properties.AzureFileCSIProfile.StorageClassName,
+			SKUName:          oc.Properties.AzureFileCSIProfile.SKUName,
+		}
+	}
+
+	if oc.Properties.MonitoringProfile != nil {
+		out.Properties.MonitoringProfile = &MonitoringProfile{
+			Retention:        oc.Properties.MonitoringProfile.Retention,
+			StorageSize:      oc.Properties.MonitoringProfile.StorageSize,
+			StorageClassName: oc.Properties.MonitoringProfile.StorageClassName,
+		}
+	}
+
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &UpgradeProfile{
+			LastNodeName:   oc.Properties.UpgradeProfile.LastNodeName,
+			MaxUnavailable: oc.Properties.UpgradeProfile.MaxUnavailable,
+		}
+	}
+
+	if oc.Properties.NodeEvictionProfile != nil {
+		out.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+			SoftGracePeriod: oc.Properties.NodeEvictionProfile.SoftGracePeriod,
+		}
+		if oc.Properties.NodeEvictionProfile.Hard != nil {
+			out.Properties.NodeEvictionProfile.Hard = &EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Hard.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Hard.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Hard.ImageFSAvailable,
+			}
+		}
+		if oc.Properties.NodeEvictionProfile.Soft != nil {
+			out.Properties.NodeEvictionProfile.Soft = &EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Soft.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Soft.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Soft.ImageFSAvailable,
+			}
+		}
+	}
+
+	if oc.Properties.SecurityProfile != nil {
+		out.Properties.SecurityProfile = &SecurityProfile{
+			DefaultSeccompProfile: SeccompProfile(oc.Properties.SecurityProfile.DefaultSeccompProfile),
+			SELinuxBooleans:       append([]string(nil), oc.Properties.SecurityProfile.SELinuxBooleans...),
+		}
+	}
+
+	if oc.Properties.LogForwardingProfile != nil {
+		out.Properties.LogForwardingProfile = &LogForwardingProfile{
+			Type:        LogForwardingType(oc.Properties.LogForwardingProfile.Type),
+			URL:         oc.Properties.LogForwardingProfile.URL,
+			WorkspaceID: oc.Properties.LogForwardingProfile.WorkspaceID,
+		}
+		for _, t := range oc.Properties.LogForwardingProfile.LogTypes {
+			out.Properties.LogForwardingProfile.LogTypes = append(out.Properties.LogForwardingProfile.LogTypes, LogType(t))
+		}
+	}
+
+	if oc.Properties.AdmissionWebhookProfiles != nil {
+		out.Properties.AdmissionWebhookProfiles = make([]AdmissionWebhookProfile, 0, len(oc.Properties.AdmissionWebhookProfiles))
+		for _, p := range oc.Properties.AdmissionWebhookProfiles {
+			out.Properties.AdmissionWebhookProfiles = append(out.Properties.AdmissionWebhookProfiles, AdmissionWebhookProfile{
+				Configuration: p.Configuration,
+			})
+		}
+	}
+
+	if oc.Properties.SchedulerProfile != nil {
+		out.Properties.SchedulerProfile = &SchedulerProfile{
+			DefaultNodeSelector: oc.Properties.SchedulerProfile.DefaultNodeSelector,
+		}
+	}
+
+	if oc.Properties.EgressFirewallProfile != nil {
+		out.Properties.EgressFirewallProfile = &EgressFirewallProfile{}
+		for _, d := range oc.Properties.EgressFirewallProfile.AllowedDestinations {
+			out.Properties.EgressFirewallProfile.AllowedDestinations = append(out.Properties.EgressFirewallProfile.AllowedDestinations, EgressDestination{
+				CIDR:    d.CIDR,
+				DNSName: d.DNSName,
 			})
 		}
 	}
@@ -187,11 +394,18 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 	out.Properties.ClusterProfile.ResourceGroupID = oc.Properties.ClusterProfile.ResourceGroupID
 	out.Properties.ConsoleProfile.URL = oc.Properties.ConsoleProfile.URL
 	out.Properties.ClusterProfile.FipsValidatedModules = api.FipsValidatedModules(oc.Properties.ClusterProfile.FipsValidatedModules)
+	out.Properties.ClusterProfile.DNSRecordTTL = oc.Properties.ClusterProfile.DNSRecordTTL
+	out.Properties.ClusterProfile.ResourceNameTemplate = oc.Properties.ClusterProfile.ResourceNameTemplate
+	out.Properties.ClusterProfile.ExistingResourceGroup = api.ExistingResourceGroup(oc.Properties.ClusterProfile.ExistingResourceGroup)
+	out.Properties.ClusterProfile.TimeZone = oc.Properties.ClusterProfile.TimeZone
+	out.Properties.ClusterProfile.DNSZoneID = oc.Properties.ClusterProfile.DNSZoneID
 	out.Properties.ServicePrincipalProfile.ClientID = oc.Properties.ServicePrincipalProfile.ClientID
 	out.Properties.ServicePrincipalProfile.ClientSecret = api.SecureString(oc.Properties.ServicePrincipalProfile.ClientSecret)
 	out.Properties.NetworkProfile.PodCIDR = oc.Properties.NetworkProfile.PodCIDR
 	out.Properties.NetworkProfile.ServiceCIDR = oc.Properties.NetworkProfile.ServiceCIDR
+	out.Properties.NetworkProfile.SoftwareDefinedNetwork = api.SoftwareDefinedNetwork(oc.Properties.NetworkProfile.SoftwareDefinedNetwork)
 	out.Properties.NetworkProfile.OutboundType = api.OutboundType(oc.Properties.NetworkProfile.OutboundType)
+	out.Properties.NetworkProfile.MaxPods = oc.Properties.NetworkProfile.MaxPods
 	if oc.Properties.NetworkProfile.LoadBalancerProfile != nil {
 		out.Properties.NetworkProfile.LoadBalancerProfile = &api.LoadBalancerProfile{}
 
@@ -204,6 +418,17 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 				Count: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs.Count,
 			}
 		}
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix = &api.ManagedOutboundIPPrefix{
+				PrefixLength: oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength,
+			}
+		}
+		if oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix != nil {
+			out.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix = &api.EffectiveOutboundIPPrefix{
+				ID:       oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.ID,
+				IPPrefix: oc.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix.IPPrefix,
+			}
+		}
 		if oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs != nil {
 			out.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs = make([]api.OutboundIP, len(oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs))
 			for i := range oc.Properties.NetworkProfile.LoadBalancerProfile.OutboundIPs {
@@ -224,6 +449,14 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 		}
 	}
 
+	if oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+		out.Properties.NetworkProfile.CustomerLoadBalancerProfile = &api.CustomerLoadBalancerProfile{
+			ID:                               oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID,
+			APIServerFrontendIPConfiguration: oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.APIServerFrontendIPConfiguration,
+			IngressFrontendIPConfiguration:   oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration,
+		}
+	}
+
 	out.Properties.MasterProfile.VMSize = api.VMSize(oc.Properties.MasterProfile.VMSize)
 	out.Properties.MasterProfile.SubnetID = oc.Properties.MasterProfile.SubnetID
 	out.Properties.MasterProfile.EncryptionAtHost = api.EncryptionAtHost(oc.Properties.MasterProfile.EncryptionAtHost)
@@ -239,11 +472,26 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.WorkerProfiles[i].Count = oc.Properties.WorkerProfiles[i].Count
 			out.Properties.WorkerProfiles[i].EncryptionAtHost = api.EncryptionAtHost(oc.Properties.WorkerProfiles[i].EncryptionAtHost)
 			out.Properties.WorkerProfiles[i].DiskEncryptionSetID = oc.Properties.WorkerProfiles[i].DiskEncryptionSetID
+			out.Properties.WorkerProfiles[i].DiskStorageAccountType = api.DiskStorageAccountType(oc.Properties.WorkerProfiles[i].DiskStorageAccountType)
+			out.Properties.WorkerProfiles[i].AcceleratedNetworking = api.AcceleratedNetworking(oc.Properties.WorkerProfiles[i].AcceleratedNetworking)
+			if oc.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile != nil {
+				out.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile = &api.ProximityPlacementGroupProfile{
+					ID: oc.Properties.WorkerProfiles[i].ProximityPlacementGroupProfile.ID,
+				}
+			}
 		}
 	}
+	// the scale status is reported by the service only, so keep the current
+	// one
 	out.Properties.APIServerProfile.Visibility = api.Visibility(oc.Properties.APIServerProfile.Visibility)
 	out.Properties.APIServerProfile.URL = oc.Properties.APIServerProfile.URL
 	out.Properties.APIServerProfile.IP = oc.Properties.APIServerProfile.IP
+	// the serving certificate is not returned to the customer, so keep the
+	// current one unless a new one is provided
+	servingCertificates := map[string]api.SecureString{}
+	for _, p := range out.Properties.IngressProfiles {
+		servingCertificates[p.Name] = p.ServingCertificate
+	}
 	out.Properties.IngressProfiles = nil
 	if oc.Properties.IngressProfiles != nil {
 		out.Properties.IngressProfiles = make([]api.IngressProfile, len(oc.Properties.IngressProfiles))
@@ -251,6 +499,180 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 			out.Properties.IngressProfiles[i].Name = oc.Properties.IngressProfiles[i].Name
 			out.Properties.IngressProfiles[i].Visibility = api.Visibility(oc.Properties.IngressProfiles[i].Visibility)
 			out.Properties.IngressProfiles[i].IP = oc.Properties.IngressProfiles[i].IP
+			out.Properties.IngressProfiles[i].Domain = oc.Properties.IngressProfiles[i].Domain
+			out.Properties.IngressProfiles[i].ServingCertificate = servingCertificates[oc.Properties.IngressProfiles[i].Name]
+			if oc.Properties.IngressProfiles[i].ServingCertificate != "" {
+				out.Properties.IngressProfiles[i].ServingCertificate = api.SecureString(oc.Properties.IngressProfiles[i].ServingCertificate)
+			}
+		}
+	}
+
+	out.Properties.MaintenanceWindow = nil
+	if oc.Properties.MaintenanceWindow != nil {
+		out.Properties.MaintenanceWindow = &api.MaintenanceWindow{
+			StartTime:     oc.Properties.MaintenanceWindow.StartTime,
+			DurationHours: oc.Properties.MaintenanceWindow.DurationHours,
+			TimeZone:      oc.Properties.MaintenanceWindow.TimeZone,
+		}
+		if oc.Properties.MaintenanceWindow.Days != nil {
+			out.Properties.MaintenanceWindow.Days = make([]api.Weekday, len(oc.Properties.MaintenanceWindow.Days))
+			for i, day := range oc.Properties.MaintenanceWindow.Days {
+				out.Properties.MaintenanceWindow.Days[i] = api.Weekday(day)
+			}
+		}
+	}
+
+	out.Properties.ProjectTemplateProfile = nil
+	if oc.Properties.ProjectTemplateProfile != nil {
+		out.Properties.ProjectTemplateProfile = &api.ProjectTemplateProfile{
+			ResourceQuota:   copyQuantities(oc.Properties.ProjectTemplateProfile.ResourceQuota),
+			DefaultLimits:   copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultLimits),
+			DefaultRequests: copyQuantities(oc.Properties.ProjectTemplateProfile.DefaultRequests),
+		}
+	}
+
+	// the client secret is not returned to the customer, so keep the current
+	// one unless a new one is provided
+	var clientSecret api.SecureString
+	if out.Properties.IdentityProviderProfile != nil {
+		clientSecret = out.Properties.IdentityProviderProfile.ClientSecret
+	}
+	out.Properties.IdentityProviderProfile = nil
+	if oc.Properties.IdentityProviderProfile != nil {
+		out.Properties.IdentityProviderProfile = &api.IdentityProviderProfile{
+			Name:         oc.Properties.IdentityProviderProfile.Name,
+			Issuer:       oc.Properties.IdentityProviderProfile.Issuer,
+			ClientID:     oc.Properties.IdentityProviderProfile.ClientID,
+			ClientSecret: clientSecret,
+		}
+		if oc.Properties.IdentityProviderProfile.ClientSecret != "" {
+			out.Properties.IdentityProviderProfile.ClientSecret = api.SecureString(oc.Properties.IdentityProviderProfile.ClientSecret)
+		}
+	}
+
+	out.Properties.RegistryMirrorProfiles = nil
+	if oc.Properties.RegistryMirrorProfiles != nil {
+		out.Properties.RegistryMirrorProfiles = make([]api.RegistryMirrorProfile, 0, len(oc.Properties.RegistryMirrorProfiles))
+		for _, p := range oc.Properties.RegistryMirrorProfiles {
+			out.Properties.RegistryMirrorProfiles = append(out.Properties.RegistryMirrorProfiles, api.RegistryMirrorProfile{
+				Source: p.Source,
+				Mirror: p.Mirror,
+			})
+		}
+	}
+
+	out.Properties.DefaultStorageClassProfile = nil
+	if oc.Properties.DefaultStorageClassProfile != nil {
+		out.Properties.DefaultStorageClassProfile = &api.DefaultStorageClassProfile{
+			Name:       oc.Properties.DefaultStorageClassProfile.Name,
+			Parameters: copyQuantities(oc.Properties.DefaultStorageClassProfile.Parameters),
+		}
+	}
+
+	out.Properties.AzureFileCSIProfile = nil
+	if oc.Properties.AzureFileCSIProfile != nil {
+		out.Properties.AzureFileCSIProfile = &api.AzureFileCSIProfile{
+			StorageClassName: oc.Properties.AzureFileCSIProfile.StorageClassName,
+			SKUName:          oc.Properties.AzureFileCSIProfile.SKUName,
+		}
+	}
+
+	out.Properties.MonitoringProfile = nil
+	if oc.Properties.MonitoringProfile != nil {
+		out.Properties.MonitoringProfile = &api.MonitoringProfile{
+			Retention:        oc.Properties.MonitoringProfile.Retention,
+			StorageSize:      oc.Properties.MonitoringProfile.StorageSize,
+			StorageClassName: oc.Properties.MonitoringProfile.StorageClassName,
+		}
+	}
+
+	out.Properties.UpgradeProfile = nil
+	if oc.Properties.UpgradeProfile != nil {
+		out.Properties.UpgradeProfile = &api.UpgradeProfile{
+			LastNodeName:   oc.Properties.UpgradeProfile.LastNodeName,
+			MaxUnavailable: oc.Properties.UpgradeProfile.MaxUnavailable,
+		}
+	}
+
+	out.Properties.NodeEvictionProfile = nil
+	if oc.Properties.NodeEvictionProfile != nil {
+		out.Properties.NodeEvictionProfile = &api.NodeEvictionProfile{
+			SoftGracePeriod: oc.Properties.NodeEvictionProfile.SoftGracePeriod,
+		}
+		if oc.Properties.NodeEvictionProfile.Hard != nil {
+			out.Properties.NodeEvictionProfile.Hard = &api.EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Hard.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Hard.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Hard.ImageFSAvailable,
+			}
+		}
+		if oc.Properties.NodeEvictionProfile.Soft != nil {
+			out.Properties.NodeEvictionProfile.Soft = &api.EvictionThresholds{
+				MemoryAvailable:  oc.Properties.NodeEvictionProfile.Soft.MemoryAvailable,
+				NodeFSAvailable:  oc.Properties.NodeEvictionProfile.Soft.NodeFSAvailable,
+				ImageFSAvailable: oc.Properties.NodeEvictionProfile.Soft.ImageFSAvailable,
+			}
+		}
+	}
+
+	out.Properties.SecurityProfile = nil
+	if oc.Properties.SecurityProfile != nil {
+		out.Properties.SecurityProfile = &api.SecurityProfile{
+			DefaultSeccompProfile: api.SeccompProfile(oc.Properties.SecurityProfile.DefaultSeccompProfile),
+			SELinuxBooleans:       append([]string(nil), oc.Properties.SecurityProfile.SELinuxBooleans...),
+		}
+	}
+
+	// the shared key is not returned to the customer, so keep the current
+	// one for the same workspace unless a new one is provided
+	var sharedKey api.SecureString
+	if out.Properties.LogForwardingProfile != nil {
+		sharedKey = out.Properties.LogForwardingProfile.SharedKey
+	}
+	current := out.Properties.LogForwardingProfile
+	out.Properties.LogForwardingProfile = nil
+	if oc.Properties.LogForwardingProfile != nil {
+		out.Properties.LogForwardingProfile = &api.LogForwardingProfile{
+			Type:        api.LogForwardingType(oc.Properties.LogForwardingProfile.Type),
+			URL:         oc.Properties.LogForwardingProfile.URL,
+			WorkspaceID: oc.Properties.LogForwardingProfile.WorkspaceID,
+		}
+		for _, t := range oc.Properties.LogForwardingProfile.LogTypes {
+			out.Properties.LogForwardingProfile.LogTypes = append(out.Properties.LogForwardingProfile.LogTypes, api.LogType(t))
+		}
+		if current != nil && current.WorkspaceID == oc.Properties.LogForwardingProfile.WorkspaceID {
+			out.Properties.LogForwardingProfile.SharedKey = sharedKey
+		}
+		if oc.Properties.LogForwardingProfile.SharedKey != "" {
+			out.Properties.LogForwardingProfile.SharedKey = api.SecureString(oc.Properties.LogForwardingProfile.SharedKey)
+		}
+	}
+
+	out.Properties.AdmissionWebhookProfiles = nil
+	if oc.Properties.AdmissionWebhookProfiles != nil {
+		out.Properties.AdmissionWebhookProfiles = make([]api.AdmissionWebhookProfile, 0, len(oc.Properties.AdmissionWebhookProfiles))
+		for _, p := range oc.Properties.AdmissionWebhookProfiles {
+			out.Properties.AdmissionWebhookProfiles = append(out.Properties.AdmissionWebhookProfiles, api.AdmissionWebhookProfile{
+				Configuration: p.Configuration,
+			})
+		}
+	}
+
+	out.Properties.SchedulerProfile = nil
+	if oc.Properties.SchedulerProfile != nil {
+		out.Properties.SchedulerProfile = &api.SchedulerProfile{
+			DefaultNodeSelector: oc.Properties.SchedulerProfile.DefaultNodeSelector,
+		}
+	}
+
+	out.Properties.EgressFirewallProfile = nil
+	if oc.Properties.EgressFirewallProfile != nil {
+		out.Properties.EgressFirewallProfile = &api.EgressFirewallProfile{}
+		for _, d := range oc.Properties.EgressFirewallProfile.AllowedDestinations {
+			out.Properties.EgressFirewallProfile.AllowedDestinations = append(out.Properties.EgressFirewallProfile.AllowedDestinations, api.EgressDestination{
+				CIDR:    d.CIDR,
+				DNSName: d.DNSName,
+			})
 		}
 	}
 
@@ -263,3 +685,16 @@ func (c openShiftClusterConverter) ToInternal(_oc interface{}, out *api.OpenShif
 		LastModifiedByType: api.CreatedByType(oc.SystemData.CreatedByType),
 	}
 }
+
+// copyQuantities returns a copy of a map of resource names to quantities
+func copyQuantities(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
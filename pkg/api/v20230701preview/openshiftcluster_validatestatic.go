@@ -4,22 +4,120 @@ package v20230701preview
 // Licensed under the Apache License 2.0.
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/util/immutable"
 	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
 	"github.com/Azure/ARO-RP/pkg/api/validate"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
 	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
+// maxAdditionalIngressProfiles is the number of ingress profiles which may be
+// provisioned alongside the default one
+const maxAdditionalIngressProfiles = 2
+
+// rxIngressProfileName matches names which can be used for the ingress
+// controller, router service and certificate secret of an ingress profile
+var rxIngressProfileName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,30}[a-z0-9])?$`)
+
+// maxAdditionalWorkerProfiles is the number of worker profiles which may be
+// provisioned alongside the default one
+const maxAdditionalWorkerProfiles = 4
+
+// rxWorkerProfileName matches names which, prefixed by the infra ID and
+// suffixed by the availability zone, can be used for the machine sets of a
+// worker profile
+var rxWorkerProfileName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,18}[a-z0-9])?$`)
+
+// rxIdentityProviderName matches names which can prefix the identities of an
+// identity provider in the cluster OAuth server
+var rxIdentityProviderName = regexp.MustCompile(`^[A-Za-z0-9]([-_.A-Za-z0-9]{0,61}[A-Za-z0-9])?$`)
+
+// rxRegistryMirror matches a registry host, optionally with a port, followed
+// by an optional repository path, e.g. myregistry.azurecr.io/docker-hub
+var rxRegistryMirror = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*(:[0-9]{1,5})?(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+
+// maxRegistryMirrorProfiles is the number of registry mirrors which may be
+// configured
+const maxRegistryMirrorProfiles = 8
+
+// maxAdmissionWebhookProfiles is the number of admission webhook
+// configurations which may be registered
+const maxAdmissionWebhookProfiles = 16
+
+// maxEgressDestinations is the number of destinations which may be allowed by
+// the egress firewall.  Each is a rule of the per-namespace objects, and an
+// EgressNetworkPolicy has at most 1000 rules.
+const maxEgressDestinations = 100
+
+// builtInStorageClasses are the storage classes created at install time,
+// which may be marked default but not replaced
+var builtInStorageClasses = []string{"azurefile-csi", "managed-csi", "managed-csi-encrypted-cmk", "managed-premium", "managed-premium-encrypted-cmk"}
+
+// ultraDiskSKUs are the disk SKUs whose IOPS and throughput are configurable,
+// and which don't support host caching
+var ultraDiskSKUs = []string{"PremiumV2_LRS", "UltraSSD_LRS"}
+
+// azureFileSKUs are the storage account SKUs with which the Azure File CSI
+// driver may create file shares
+var azureFileSKUs = []string{"Standard_LRS", "Standard_ZRS", "Standard_GRS", "Standard_RAGRS", "Standard_GZRS", "Standard_RAGZRS", "Premium_LRS", "Premium_ZRS"}
+
+// rxPrometheusRetention matches the Prometheus retention durations which may
+// be configured, e.g. 30d
+var rxPrometheusRetention = regexp.MustCompile(`^([1-9][0-9]{0,3})([hdw])$`)
+
+// rxMaxUnavailable matches the number of worker nodes, e.g. 2, or the
+// percentage of them, e.g. 10%, which may be updated at the same time
+var rxMaxUnavailable = regexp.MustCompile(`^([1-9][0-9]{0,2})(%?)$`)
+
+// The bounds of the Prometheus retention and storage size.  Retention beyond
+// the platform default needs more space than the ephemeral storage of the
+// Prometheus pods is expected to have.
+const (
+	minPrometheusRetention          = 24 * time.Hour
+	maxPrometheusRetention          = 90 * 24 * time.Hour
+	maxEphemeralPrometheusRetention = 15 * 24 * time.Hour
+)
+
+var (
+	minPrometheusStorageSize = resource.MustParse("10Gi")
+	maxPrometheusStorageSize = resource.MustParse("1Ti")
+)
+
+// The bounds of the kubelet eviction thresholds.  Thresholds below the
+// platform ones risk the node running out of memory or disk before pods are
+// evicted; thresholds above them leave much of the node unused.
+var (
+	minEvictionMemory     = resource.MustParse("100Mi")
+	maxEvictionMemory     = resource.MustParse("8Gi")
+	minEvictionFilesystem = resource.MustParse("1Gi")
+	maxEvictionFilesystem = resource.MustParse("100Gi")
+)
+
+const (
+	minEvictionPercentage      = 1
+	maxEvictionPercentage      = 50
+	minEvictionSoftGracePeriod = 10 * time.Second
+	maxEvictionSoftGracePeriod = 10 * time.Minute
+)
+
 type openShiftClusterStaticValidator struct {
 	location            string
 	domain              string
@@ -101,27 +199,107 @@ func (sv openShiftClusterStaticValidator) validateProperties(path string, p *Ope
 	if err := sv.validateLoadBalancerProfile(path+".networkProfile.loadBalancerProfile", p.NetworkProfile.LoadBalancerProfile, isCreate); err != nil {
 		return err
 	}
+	if err := sv.validateCustomerLoadBalancerProfile(path+".networkProfile.customerLoadBalancerProfile", p.NetworkProfile.CustomerLoadBalancerProfile, p.APIServerProfile.Visibility, p.IngressProfiles[0].Visibility); err != nil {
+		return err
+	}
 	if err := sv.validateMasterProfile(path+".masterProfile", &p.MasterProfile); err != nil {
 		return err
 	}
 	if err := sv.validateAPIServerProfile(path+".apiserverProfile", &p.APIServerProfile); err != nil {
 		return err
 	}
+	if err := sv.validateMaintenanceWindow(path+".maintenanceWindow", p.MaintenanceWindow); err != nil {
+		return err
+	}
+	if err := sv.validateProjectTemplateProfile(path+".projectTemplateProfile", p.ProjectTemplateProfile); err != nil {
+		return err
+	}
+	if err := sv.validateIdentityProviderProfile(path+".identityProviderProfile", p.IdentityProviderProfile, isCreate); err != nil {
+		return err
+	}
+	if err := sv.validateRegistryMirrorProfiles(path+".registryMirrorProfiles", p.RegistryMirrorProfiles); err != nil {
+		return err
+	}
+	if err := sv.validateDefaultStorageClassProfile(path+".defaultStorageClassProfile", p.DefaultStorageClassProfile); err != nil {
+		return err
+	}
+	if err := sv.validateAzureFileCSIProfile(path+".azureFileCsiProfile", p.AzureFileCSIProfile, p.DefaultStorageClassProfile); err != nil {
+		return err
+	}
+	if err := sv.validateMonitoringProfile(path+".monitoringProfile", p.MonitoringProfile, p.AzureFileCSIProfile); err != nil {
+		return err
+	}
+	if err := sv.validateUpgradeProfile(path+".upgradeProfile", p.UpgradeProfile); err != nil {
+		return err
+	}
+	if err := sv.validateNodeEvictionProfile(path+".nodeEvictionProfile", p.NodeEvictionProfile); err != nil {
+		return err
+	}
+	if err := sv.validateSecurityProfile(path+".securityProfile", p.SecurityProfile); err != nil {
+		return err
+	}
+	if err := sv.validateLogForwardingProfile(path+".logForwardingProfile", p.LogForwardingProfile, isCreate); err != nil {
+		return err
+	}
+	if err := sv.validateAdmissionWebhookProfiles(path+".admissionWebhookProfiles", p.AdmissionWebhookProfiles); err != nil {
+		return err
+	}
+	if err := sv.validateSchedulerProfile(path+".schedulerProfile", p.SchedulerProfile); err != nil {
+		return err
+	}
+	if err := sv.validateEgressFirewallProfile(path+".egressFirewallProfile", p.EgressFirewallProfile); err != nil {
+		return err
+	}
 
 	if isCreate {
-		if len(p.WorkerProfiles) != 1 {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be exactly one worker profile.")
+		if len(p.WorkerProfiles) < 1 || len(p.WorkerProfiles) > 1+maxAdditionalWorkerProfiles {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles", "There should be exactly one default worker profile and at most %d additional worker profiles.", maxAdditionalWorkerProfiles)
+		}
+		if p.WorkerProfiles[0].Name != "worker" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles['"+p.WorkerProfiles[0].Name+"'].name", "The provided worker name '%s' is invalid.", p.WorkerProfiles[0].Name)
 		}
 		if err := sv.validateWorkerProfile(path+".workerProfiles['"+p.WorkerProfiles[0].Name+"']", &p.WorkerProfiles[0], &p.MasterProfile); err != nil {
 			return err
 		}
+		// the default worker profile is provisioned by the installer, which
+		// spreads it across the availability zones
+		if p.WorkerProfiles[0].ProximityPlacementGroupProfile != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles['"+p.WorkerProfiles[0].Name+"'].proximityPlacementGroupProfile", "The default worker profile cannot be placed in a proximity placement group: use an additional worker profile.")
+		}
+		// the installer always picks the OS disk storage account type of the
+		// default worker profile itself
+		if p.WorkerProfiles[0].DiskStorageAccountType != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workerProfiles['"+p.WorkerProfiles[0].Name+"'].diskStorageAccountType", "The OS disk storage account type of the default worker profile cannot be set: use an additional worker profile.")
+		}
+		if err := sv.validateAdditionalWorkerProfiles(path+".workerProfiles", p.WorkerProfiles[1:], &p.MasterProfile); err != nil {
+			return err
+		}
 
-		if len(p.IngressProfiles) != 1 {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ingressProfiles", "There should be exactly one ingress profile.")
+		if len(p.IngressProfiles) < 1 || len(p.IngressProfiles) > 1+maxAdditionalIngressProfiles {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ingressProfiles", "There should be exactly one default ingress profile and at most %d additional ingress profiles.", maxAdditionalIngressProfiles)
 		}
 		if err := sv.validateIngressProfile(path+".ingressProfiles['"+p.IngressProfiles[0].Name+"']", &p.IngressProfiles[0]); err != nil {
 			return err
 		}
+		if err := sv.validateAdditionalIngressProfiles(path+".ingressProfiles", p.IngressProfiles[1:], &p.ClusterProfile, p.NetworkProfile.OutboundType); err != nil {
+			return err
+		}
+	} else {
+		// the serving certificates of additional ingress profiles may be
+		// rotated
+		for i := range p.IngressProfiles {
+			ip := &p.IngressProfiles[i]
+			if ip.ServingCertificate == "" {
+				continue
+			}
+			path := path + ".ingressProfiles['" + ip.Name + "'].servingCertificate"
+			if ip.Domain == "" {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: the serving certificate of the default ingress profile is managed by the service.")
+			}
+			if err := sv.validateIngressServingCertificate(path, ip); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -170,6 +348,37 @@ func (sv openShiftClusterStaticValidator) validateClusterProfile(path string, cp
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".fipsValidatedModules", "The provided value '%s' is invalid.", cp.FipsValidatedModules)
 	}
 
+	if cp.DNSRecordTTL != 0 && !validate.DNSRecordTTLIsValid(cp.DNSRecordTTL) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".dnsRecordTtl", "The provided DNS record TTL '%d' is invalid.", cp.DNSRecordTTL)
+	}
+
+	if cp.ResourceNameTemplate != "" && !validate.ResourceNameTemplateIsValid(cp.ResourceNameTemplate) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".resourceNameTemplate", "The provided resource name template '%s' is invalid.", cp.ResourceNameTemplate)
+	}
+
+	switch cp.ExistingResourceGroup {
+	case "", ExistingResourceGroupDisabled, ExistingResourceGroupEnabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".existingResourceGroup", "The provided value '%s' is invalid.", cp.ExistingResourceGroup)
+	}
+
+	if cp.TimeZone != "" && !validate.TimeZoneIsValid(cp.TimeZone) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".timeZone", "The provided time zone '%s' is invalid.", cp.TimeZone)
+	}
+
+	if cp.DNSZoneID != "" {
+		if !validate.RxDNSZoneID.MatchString(cp.DNSZoneID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".dnsZoneId", "The provided DNS zone '%s' is invalid.", cp.DNSZoneID)
+		}
+		// the RP creates the records of managed domains in its own zone
+		if !strings.ContainsRune(cp.Domain, '.') || strings.HasSuffix(cp.Domain, "."+sv.domain) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".dnsZoneId", "The provided DNS zone '%s' is invalid: a DNS zone can only be used with a custom domain.", cp.DNSZoneID)
+		}
+		if !validate.DomainIsInDNSZone(cp.Domain, cp.DNSZoneID[strings.LastIndexByte(cp.DNSZoneID, '/')+1:]) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".dnsZoneId", "The provided DNS zone '%s' is invalid: the domain '%s' must be in the zone.", cp.DNSZoneID, cp.Domain)
+		}
+	}
+
 	return nil
 }
 
@@ -223,6 +432,16 @@ func (sv openShiftClusterStaticValidator) validateNetworkProfile(path string, np
 		}
 	}
 
+	if np.MaxPods != 0 && (np.MaxPods < api.MaxPodsMinimum || np.MaxPods > api.MaxPodsLimit) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".maxPods", "The provided maxPods '%d' is invalid: must be between %d and %d, the number of pod addresses allocated to each node.", np.MaxPods, api.MaxPodsMinimum, api.MaxPodsLimit)
+	}
+
+	switch np.SoftwareDefinedNetwork {
+	case "", SoftwareDefinedNetworkOVNKubernetes, SoftwareDefinedNetworkOpenShiftSDN:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".softwareDefinedNetwork", "The provided softwareDefinedNetwork '%s' is invalid: must be OVNKubernetes or OpenShiftSDN.", np.SoftwareDefinedNetwork)
+	}
+
 	if np.OutboundType != "" {
 		if np.OutboundType != OutboundTypeLoadbalancer && np.OutboundType != OutboundTypeUserDefinedRouting {
 			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundType", "The provided outboundType '%s' is invalid: must be UserDefinedRouting or Loadbalancer.", np.OutboundType)
@@ -255,6 +474,11 @@ func (sv openShiftClusterStaticValidator) validateLoadBalancerProfile(path strin
 		if err != nil {
 			return err
 		}
+	case lbp.ManagedOutboundIPPrefix != nil:
+		err := validateManagedOutboundIPPrefix(path, *lbp.ManagedOutboundIPPrefix)
+		if err != nil {
+			return err
+		}
 	case lbp.OutboundIPs != nil:
 		err := validateOutboundIPs(path, lbp.OutboundIPs)
 		if err != nil {
@@ -276,20 +500,29 @@ func (sv openShiftClusterStaticValidator) validateLoadBalancerProfile(path strin
 	if lbp.EffectiveOutboundIPs != nil && isCreate {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".effectiveOutboundIps", "The field effectiveOutboundIps is read only.")
 	}
+	if lbp.EffectiveOutboundIPPrefix != nil && isCreate {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".effectiveOutboundIpPrefix", "The field effectiveOutboundIpPrefix is read only.")
+	}
 	return nil
 }
 
 func checkPickedExactlyOne(path string, lbp *LoadBalancerProfile) error {
-	var isManagedOutboundIPCount = lbp.ManagedOutboundIPs != nil
-	var isOutboundIPs = lbp.OutboundIPs != nil
-	var isOutboundIPPrefixes = lbp.OutboundIPPrefixes != nil
+	var picked int
+	for _, isSet := range []bool{
+		lbp.ManagedOutboundIPs != nil,
+		lbp.ManagedOutboundIPPrefix != nil,
+		lbp.OutboundIPs != nil,
+		lbp.OutboundIPPrefixes != nil,
+	} {
+		if isSet {
+			picked++
+		}
+	}
 
-	if !isManagedOutboundIPCount && !isOutboundIPPrefixes && !isOutboundIPs {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided loadBalancerProfile is invalid: must specify one of managedOutboundIps, outboundIps, or outboundIpPrefixes.")
-	} else if !((isManagedOutboundIPCount && !isOutboundIPs && !isOutboundIPPrefixes) ||
-		(!isManagedOutboundIPCount && isOutboundIPs && !isOutboundIPPrefixes) ||
-		(!isManagedOutboundIPCount && !isOutboundIPs && isOutboundIPPrefixes)) {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, outboundIps, or outboundIpPrefixes at a time.")
+	if picked == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided loadBalancerProfile is invalid: must specify one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes.")
+	} else if picked > 1 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.")
 	}
 	return nil
 }
@@ -301,6 +534,15 @@ func validateManagedOutboundIPs(path string, managedOutboundIPs ManagedOutboundI
 	return nil
 }
 
+// validateManagedOutboundIPPrefix checks the prefix length against the range
+// of public IP prefix sizes which Azure allows (/28 to /31, i.e. 16 to 2 IPs)
+func validateManagedOutboundIPPrefix(path string, managedOutboundIPPrefix ManagedOutboundIPPrefix) error {
+	if !(managedOutboundIPPrefix.PrefixLength >= 28 && managedOutboundIPPrefix.PrefixLength <= 31) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".managedOutboundIpPrefix.prefixLength", "The provided managedOutboundIpPrefix.prefixLength %d is invalid: managedOutboundIpPrefix.prefixLength must be in the range of 28 to 31 (inclusive).", managedOutboundIPPrefix.PrefixLength)
+	}
+	return nil
+}
+
 func validateOutboundIPs(path string, outboundIPs []OutboundIP) error {
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundIps", "The field outboundIps is not implemented at this time, please check back later.")
 }
@@ -309,6 +551,34 @@ func validateOutboundIPPrefixes(path string, outboundIPPrefixes []OutboundIPPref
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".outboundIpPrefixes", "The field outboundIpPrefixes is not implemented at this time, please check back later.")
 }
 
+func (sv openShiftClusterStaticValidator) validateCustomerLoadBalancerProfile(path string, p *CustomerLoadBalancerProfile, apiServerVisibility Visibility, ingressVisibility Visibility) error {
+	if p == nil {
+		return nil
+	}
+
+	if !validate.RxLoadBalancerID.MatchString(p.ID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The provided load balancer '%s' is invalid.", p.ID)
+	}
+	// the RP manages the load balancer with the clients of the cluster
+	// subscription
+	if !strings.EqualFold(strings.Split(p.ID, "/")[2], sv.r.SubscriptionID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The provided load balancer '%s' is invalid: must be in the same subscription as the cluster.", p.ID)
+	}
+	if !validate.RxNetworkChildName.MatchString(p.APIServerFrontendIPConfiguration) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".apiServerFrontendIpConfiguration", "The provided frontend IP configuration '%s' is invalid.", p.APIServerFrontendIPConfiguration)
+	}
+	if !validate.RxNetworkChildName.MatchString(p.IngressFrontendIPConfiguration) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".ingressFrontendIpConfiguration", "The provided frontend IP configuration '%s' is invalid.", p.IngressFrontendIPConfiguration)
+	}
+	// the customer load balancer replaces the public frontends of the
+	// managed load balancers
+	if apiServerVisibility != VisibilityPrivate || ingressVisibility != VisibilityPrivate {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided customerLoadBalancerProfile is invalid: cannot use a customer load balancer if either API Server Visibility or Ingress Visibility is public.")
+	}
+
+	return nil
+}
+
 func (sv openShiftClusterStaticValidator) validateMasterProfile(path string, mp *MasterProfile) error {
 	if !validate.VMSizeIsValid(api.VMSize(mp.VMSize), sv.requireD2sV3Workers, true) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".vmSize", "The provided master VM size '%s' is invalid.", mp.VMSize)
@@ -345,9 +615,6 @@ func (sv openShiftClusterStaticValidator) validateMasterProfile(path string, mp
 }
 
 func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp *WorkerProfile, mp *MasterProfile) error {
-	if wp.Name != "worker" {
-		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
-	}
 	if !validate.VMSizeIsValid(api.VMSize(wp.VMSize), sv.requireD2sV3Workers, false) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".vmSize", "The provided worker VM size '%s' is invalid.", wp.VMSize)
 	}
@@ -362,6 +629,28 @@ func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp
 	default:
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".encryptionAtHost", "The provided value '%s' is invalid.", wp.EncryptionAtHost)
 	}
+	switch wp.DiskStorageAccountType {
+	case "", DiskStorageAccountTypePremiumLRS, DiskStorageAccountTypeStandardSSDLRS:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".diskStorageAccountType", "The provided worker disk storage account type '%s' is invalid.", wp.DiskStorageAccountType)
+	}
+	switch wp.AcceleratedNetworking {
+	case "", AcceleratedNetworkingEnabled, AcceleratedNetworkingDisabled:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".acceleratedNetworking", "The provided value '%s' is invalid.", wp.AcceleratedNetworking)
+	}
+	if wp.ProximityPlacementGroupProfile != nil && wp.ProximityPlacementGroupProfile.ID != "" {
+		if !validate.RxProximityPlacementGroupID.MatchString(wp.ProximityPlacementGroupProfile.ID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".proximityPlacementGroupProfile.id", "The provided proximity placement group '%s' is invalid.", wp.ProximityPlacementGroupProfile.ID)
+		}
+		ppgr, err := azure.ParseResourceID(wp.ProximityPlacementGroupProfile.ID)
+		if err != nil {
+			return err
+		}
+		if ppgr.SubscriptionID != sv.r.SubscriptionID {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".proximityPlacementGroupProfile.id", "The provided proximity placement group '%s' is invalid: must be in same subscription as cluster.", wp.ProximityPlacementGroupProfile.ID)
+		}
+	}
 	workerVnetID, _, err := apisubnet.Split(wp.SubnetID)
 	if err != nil {
 		return err
@@ -386,6 +675,32 @@ func (sv openShiftClusterStaticValidator) validateWorkerProfile(path string, wp
 	return nil
 }
 
+// validateAdditionalWorkerProfiles validates the worker profiles other than
+// the default one.  Each is provisioned as its own set of machine sets and may
+// use its own subnet in the cluster vnet.
+func (sv openShiftClusterStaticValidator) validateAdditionalWorkerProfiles(path string, wps []WorkerProfile, mp *MasterProfile) error {
+	names := map[string]struct{}{"worker": {}, "master": {}}
+
+	for i := range wps {
+		wp := &wps[i]
+		path := path + "['" + wp.Name + "']"
+
+		if !rxWorkerProfileName.MatchString(wp.Name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid.", wp.Name)
+		}
+		if _, found := names[wp.Name]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided worker name '%s' is invalid: must be unique.", wp.Name)
+		}
+		names[wp.Name] = struct{}{}
+
+		if err := sv.validateWorkerProfile(path, wp, mp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (sv openShiftClusterStaticValidator) validateAPIServerProfile(path string, ap *APIServerProfile) error {
 	switch ap.Visibility {
 	case VisibilityPublic, VisibilityPrivate:
@@ -410,10 +725,311 @@ func (sv openShiftClusterStaticValidator) validateAPIServerProfile(path string,
 	return nil
 }
 
+func (sv openShiftClusterStaticValidator) validateMaintenanceWindow(path string, mw *MaintenanceWindow) error {
+	if mw == nil {
+		return nil
+	}
+
+	if len(mw.Days) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".days", "The provided maintenance window days are invalid: at least one day must be specified.")
+	}
+	days := map[Weekday]struct{}{}
+	for _, day := range mw.Days {
+		switch day {
+		case WeekdaySunday, WeekdayMonday, WeekdayTuesday, WeekdayWednesday,
+			WeekdayThursday, WeekdayFriday, WeekdaySaturday:
+		default:
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".days", "The provided maintenance window day '%s' is invalid.", day)
+		}
+		if _, ok := days[day]; ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".days", "The provided maintenance window day '%s' is duplicated.", day)
+		}
+		days[day] = struct{}{}
+	}
+	if !validate.MaintenanceWindowStartTimeIsValid(mw.StartTime) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".startTime", "The provided maintenance window start time '%s' is invalid: the start time must be in HH:MM 24 hour format.", mw.StartTime)
+	}
+	if mw.DurationHours < 1 || mw.DurationHours > validate.MaxMaintenanceWindowDurationHours {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".durationHours", "The provided maintenance window duration %d is invalid: durationHours must be in the range of 1 to %d (inclusive).", mw.DurationHours, validate.MaxMaintenanceWindowDurationHours)
+	}
+	if !validate.MaintenanceWindowTimeZoneIsValid(mw.TimeZone) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".timeZone", "The provided maintenance window time zone '%s' is invalid.", mw.TimeZone)
+	}
+
+	return nil
+}
+
+func (sv openShiftClusterStaticValidator) validateProjectTemplateProfile(path string, p *ProjectTemplateProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.ResourceQuota) == 0 && len(p.DefaultLimits) == 0 && len(p.DefaultRequests) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided project template profile is invalid: at least one resource quota, default limit or default request must be specified.")
+	}
+
+	for _, name := range sortedKeys(p.ResourceQuota) {
+		if !validate.ResourceQuotaResourceIsValid(name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".resourceQuota", "The provided resource quota resource '%s' is invalid.", name)
+		}
+		if !validate.QuantityIsValid(p.ResourceQuota[name], validate.ResourceQuotaResourceIsCount(name)) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.resourceQuota[%s]", path, name), "The provided quantity '%s' is invalid.", p.ResourceQuota[name])
+		}
+	}
+
+	for _, field := range []struct {
+		name       string
+		quantities map[string]string
+	}{
+		{name: "defaultLimits", quantities: p.DefaultLimits},
+		{name: "defaultRequests", quantities: p.DefaultRequests},
+	} {
+		for _, name := range sortedKeys(field.quantities) {
+			if !validate.LimitRangeResourceIsValid(name) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+"."+field.name, "The provided limit range resource '%s' is invalid: only cpu, memory and ephemeral-storage are supported.", name)
+			}
+			if !validate.QuantityIsValid(field.quantities[name], false) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.%s[%s]", path, field.name, name), "The provided quantity '%s' is invalid.", field.quantities[name])
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(p.DefaultRequests) {
+		limit, ok := p.DefaultLimits[name]
+		if !ok {
+			continue
+		}
+		request := resource.MustParse(p.DefaultRequests[name])
+		if request.Cmp(resource.MustParse(limit)) > 0 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.defaultRequests[%s]", path, name), "The provided default request '%s' is invalid: it must not exceed the default limit '%s'.", p.DefaultRequests[name], limit)
+		}
+	}
+
+	return nil
+}
+
+// validateIdentityProviderProfile checks that the identity provider has a
+// usable name, an https issuer URL and client credentials.  The client secret
+// is not returned to the customer, so it is only required at create time.
+func (sv openShiftClusterStaticValidator) validateIdentityProviderProfile(path string, p *IdentityProviderProfile, isCreate bool) error {
+	if p == nil {
+		return nil
+	}
+
+	if !rxIdentityProviderName.MatchString(p.Name) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided identity provider name '%s' is invalid.", p.Name)
+	}
+
+	u, err := url.Parse(p.Issuer)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".issuer", "The provided issuer '%s' is invalid: it must be an https URL without a query or fragment.", p.Issuer)
+	}
+
+	if p.ClientID == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".clientId", "A client ID must be provided for the identity provider.")
+	}
+	if isCreate && p.ClientSecret == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".clientSecret", "A client secret must be provided for the identity provider.")
+	}
+
+	return nil
+}
+
+// validateRegistryMirrorProfiles checks that each upstream registry is
+// mirrored to at most one pull-through cache, and that neither is malformed
+func (sv openShiftClusterStaticValidator) validateRegistryMirrorProfiles(path string, ps []RegistryMirrorProfile) error {
+	if len(ps) > maxRegistryMirrorProfiles {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "At most %d registry mirrors may be provided.", maxRegistryMirrorProfiles)
+	}
+
+	sources := map[string]struct{}{}
+	for i, p := range ps {
+		if !rxRegistryMirror.MatchString(p.Source) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].source", path, i), "The provided registry '%s' is invalid.", p.Source)
+		}
+		if !rxRegistryMirror.MatchString(p.Mirror) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].mirror", path, i), "The provided registry '%s' is invalid.", p.Mirror)
+		}
+		if p.Mirror == p.Source {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].mirror", path, i), "The provided mirror '%s' is invalid: it must differ from its source.", p.Mirror)
+		}
+
+		if _, found := sources[p.Source]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].source", path, i), "The provided registry '%s' is duplicated.", p.Source)
+		}
+		sources[p.Source] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateDefaultStorageClassProfile checks that the storage class name is
+// valid and that its parameters are supported by the Azure Disk CSI driver.
+// Built-in storage classes may only be marked default, not replaced.
+func (sv openShiftClusterStaticValidator) validateDefaultStorageClassProfile(path string, p *DefaultStorageClassProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if len(validation.IsDNS1123Subdomain(p.Name)) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided storage class name '%s' is invalid.", p.Name)
+	}
+
+	if len(p.Parameters) == 0 {
+		return nil
+	}
+
+	for _, name := range builtInStorageClasses {
+		if p.Name == name {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided storage class name '%s' is invalid: parameters may not be provided for a built-in storage class.", p.Name)
+		}
+	}
+
+	var skuName, cachingMode string
+	seen := map[string]struct{}{}
+	for _, name := range sortedKeys(p.Parameters) {
+		if !validate.AzureDiskCSIParameterIsSupported(name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".parameters", "The provided storage class parameter '%s' is not supported.", name)
+		}
+		// the driver matches parameters case insensitively
+		if _, found := seen[strings.ToLower(name)]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".parameters", "The provided storage class parameter '%s' is duplicated.", name)
+		}
+		seen[strings.ToLower(name)] = struct{}{}
+		if !validate.AzureDiskCSIParameterValueIsValid(name, p.Parameters[name]) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.parameters[%s]", path, name), "The provided value '%s' of storage class parameter '%s' is invalid.", p.Parameters[name], name)
+		}
+
+		switch strings.ToLower(name) {
+		case "skuname":
+			skuName = p.Parameters[name]
+		case "cachingmode":
+			cachingMode = p.Parameters[name]
+		}
+	}
+
+	isUltraDisk := false
+	for _, sku := range ultraDiskSKUs {
+		if strings.EqualFold(skuName, sku) {
+			isUltraDisk = true
+		}
+	}
+
+	if isUltraDisk && cachingMode != "" && !strings.EqualFold(cachingMode, "None") {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".parameters", "The provided caching mode '%s' is invalid: disks of SKU '%s' only support caching mode 'None'.", cachingMode, skuName)
+	}
+
+	if !isUltraDisk {
+		for _, name := range sortedKeys(p.Parameters) {
+			if strings.EqualFold(name, "DiskIOPSReadWrite") || strings.EqualFold(name, "DiskMBpsReadWrite") {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".parameters", "The provided storage class parameter '%s' is invalid: it is only supported for disks of SKU %s.", name, strings.Join(ultraDiskSKUs, " or "))
+			}
+		}
+	}
+
+	return nil
+}
+
 func (sv openShiftClusterStaticValidator) validateIngressProfile(path string, p *IngressProfile) error {
 	if p.Name != "default" {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided ingress name '%s' is invalid.", p.Name)
 	}
+	if p.Domain != "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid: the domain of the default ingress profile is derived from the cluster domain.", p.Domain)
+	}
+	if p.ServingCertificate != "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".servingCertificate", "The provided serving certificate is invalid: the serving certificate of the default ingress profile is managed by the service.")
+	}
+
+	return sv.validateIngressVisibilityAndIP(path, p)
+}
+
+// validateAdditionalIngressProfiles validates the ingress profiles other than
+// the default one.  Each needs its own domain, which must not overlap with the
+// default ingress domain or with each other, and a serving certificate for it.
+func (sv openShiftClusterStaticValidator) validateAdditionalIngressProfiles(path string, ps []IngressProfile, cp *ClusterProfile, outboundType OutboundType) error {
+	clusterDomain := strings.ToLower(cp.Domain)
+	if !strings.ContainsRune(clusterDomain, '.') {
+		clusterDomain += "." + sv.domain
+	}
+	defaultDomain := "apps." + clusterDomain
+
+	names := map[string]struct{}{"default": {}}
+	domains := map[string]struct{}{defaultDomain: {}}
+
+	for i := range ps {
+		p := &ps[i]
+		path := path + "['" + p.Name + "']"
+
+		if !rxIngressProfileName.MatchString(p.Name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided ingress name '%s' is invalid.", p.Name)
+		}
+		if _, found := names[p.Name]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".name", "The provided ingress name '%s' is invalid: it is used by more than one ingress profile.", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		if err := sv.validateIngressVisibilityAndIP(path, p); err != nil {
+			return err
+		}
+		if p.Visibility == VisibilityPublic && outboundType == OutboundTypeUserDefinedRouting {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".visibility", "The provided visibility '%s' is invalid: cannot use a public ingress if outboundType is UserDefinedRouting.", p.Visibility)
+		}
+
+		if !validate.RxDomainName.MatchString(p.Domain) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid.", p.Domain)
+		}
+		if p.Domain == clusterDomain {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid: it must differ from the cluster domain.", p.Domain)
+		}
+		for d := range domains {
+			if p.Domain == d || strings.HasSuffix(p.Domain, "."+d) || strings.HasSuffix(d, "."+p.Domain) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".domain", "The provided domain '%s' is invalid: it conflicts with the ingress domain '%s'.", p.Domain, d)
+			}
+		}
+		domains[p.Domain] = struct{}{}
+
+		if p.ServingCertificate == "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".servingCertificate", "A serving certificate must be provided for an additional ingress profile.")
+		}
+		if err := sv.validateIngressServingCertificate(path+".servingCertificate", p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIngressServingCertificate checks that the serving certificate of an
+// additional ingress profile contains a private key and a matching, currently
+// valid, wildcard certificate for the ingress domain
+func (sv openShiftClusterStaticValidator) validateIngressServingCertificate(path string, p *IngressProfile) error {
+	key, certs, err := utilpem.Parse([]byte(p.ServingCertificate))
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: %s.", err)
+	}
+	if key == nil || len(certs) == 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: it must contain a private key and a certificate chain.")
+	}
+	if !key.PublicKey.Equal(certs[0].PublicKey) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: the private key does not match the certificate.")
+	}
+
+	now := time.Now()
+	if now.Before(certs[0].NotBefore) || now.After(certs[0].NotAfter) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: the certificate is not currently valid.")
+	}
+
+	for _, name := range certs[0].DNSNames {
+		if strings.EqualFold(name, "*."+p.Domain) {
+			return nil
+		}
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided serving certificate is invalid: the certificate is not valid for '*.%s'.", p.Domain)
+}
+
+func (sv openShiftClusterStaticValidator) validateIngressVisibilityAndIP(path string, p *IngressProfile) error {
 	switch p.Visibility {
 	case VisibilityPublic, VisibilityPrivate:
 	default:
@@ -439,5 +1055,461 @@ func (sv openShiftClusterStaticValidator) validateDelta(oc, current *OpenShiftCl
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodePropertyChangeNotAllowed, err.Target, err.Message)
 	}
 
+	// the worker counts may be changed to scale the worker machine sets; the
+	// rest of the worker profiles were validated as immutable above
+	for i := range oc.Properties.WorkerProfiles {
+		wp := &oc.Properties.WorkerProfiles[i]
+		if wp.Count != current.Properties.WorkerProfiles[i].Count && (wp.Count < 0 || wp.Count > 50) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.workerProfiles['"+wp.Name+"'].count", "The provided worker count '%d' is invalid.", wp.Count)
+		}
+	}
+
+	// the shared key is not returned to the customer, so it must be provided
+	// again whenever the logs are sent to a different workspace
+	if p := oc.Properties.LogForwardingProfile; p != nil && p.Type == LogForwardingTypeAzureMonitor && p.SharedKey == "" &&
+		(current.Properties.LogForwardingProfile == nil || current.Properties.LogForwardingProfile.WorkspaceID != p.WorkspaceID) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.logForwardingProfile.sharedKey", "A shared key must be provided for the workspace.")
+	}
+
 	return nil
 }
+
+// validateAzureFileCSIProfile checks that the storage class name is valid and
+// not taken by a built-in storage class or by the storage class created for
+// the default storage class profile, and that the SKU supports Azure Files.
+// Whether the SKU is available in the cluster location is validated
+// dynamically.
+func (sv openShiftClusterStaticValidator) validateAzureFileCSIProfile(path string, p *AzureFileCSIProfile, defaultStorageClass *DefaultStorageClassProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if len(validation.IsDNS1123Subdomain(p.StorageClassName)) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid.", p.StorageClassName)
+	}
+
+	for _, name := range builtInStorageClasses {
+		if p.StorageClassName == name {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid: it is the name of a built-in storage class.", p.StorageClassName)
+		}
+	}
+
+	if defaultStorageClass != nil && len(defaultStorageClass.Parameters) > 0 && p.StorageClassName == defaultStorageClass.Name {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid: it is the name of the storage class created for the default storage class profile.", p.StorageClassName)
+	}
+
+	if p.SKUName == "" {
+		return nil
+	}
+
+	for _, sku := range azureFileSKUs {
+		if p.SKUName == sku {
+			return nil
+		}
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".skuName", "The provided SKU '%s' is invalid: must be one of %s.", p.SKUName, strings.Join(azureFileSKUs, ", "))
+}
+
+// validateMonitoringProfile checks that the Prometheus retention and storage
+// size are within bounds, that retention beyond the platform default is
+// backed by persistent storage, and that the storage class isn't an Azure
+// Files one, which Prometheus doesn't support.  Whether the storage class
+// exists is checked by the operator.
+func (sv openShiftClusterStaticValidator) validateMonitoringProfile(path string, p *MonitoringProfile, azureFileCSI *AzureFileCSIProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Retention == "" && p.StorageSize == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided monitoring profile is invalid: a retention or storage size must be specified.")
+	}
+
+	if p.Retention != "" {
+		retention, ok := parsePrometheusRetention(p.Retention)
+		if !ok || retention < minPrometheusRetention || retention > maxPrometheusRetention {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".retention", "The provided retention '%s' is invalid: it must be between 1d and 90d, e.g. 30d.", p.Retention)
+		}
+		if retention > maxEphemeralPrometheusRetention && p.StorageSize == "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".retention", "The provided retention '%s' is invalid: a storage size must be specified for retention beyond 15d.", p.Retention)
+		}
+	}
+
+	if p.StorageSize == "" {
+		if p.StorageClassName != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid: a storage size must be specified.", p.StorageClassName)
+		}
+		return nil
+	}
+
+	size, err := resource.ParseQuantity(p.StorageSize)
+	if err != nil || size.Cmp(minPrometheusStorageSize) < 0 || size.Cmp(maxPrometheusStorageSize) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageSize", "The provided storage size '%s' is invalid: it must be between 10Gi and 1Ti.", p.StorageSize)
+	}
+
+	if p.StorageClassName == "" {
+		return nil
+	}
+
+	if len(validation.IsDNS1123Subdomain(p.StorageClassName)) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid.", p.StorageClassName)
+	}
+
+	if p.StorageClassName == "azurefile-csi" || (azureFileCSI != nil && p.StorageClassName == azureFileCSI.StorageClassName) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".storageClassName", "The provided storage class name '%s' is invalid: Prometheus does not support Azure Files storage.", p.StorageClassName)
+	}
+
+	return nil
+}
+
+// validateUpgradeProfile checks that the last node is named like a node.
+// Whether it exists and is a worker is checked by the operator, as the nodes
+// of the cluster aren't known to the RP.  The number of nodes updated at the
+// same time is bounded so that most of the worker capacity stays available.
+func (sv openShiftClusterStaticValidator) validateUpgradeProfile(path string, p *UpgradeProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if (p.LastNodeName != "" || p.MaxUnavailable == "") &&
+		(p.LastNodeName == "" || len(validation.IsDNS1123Subdomain(p.LastNodeName)) > 0) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".lastNodeName", "The provided node name '%s' is invalid.", p.LastNodeName)
+	}
+
+	if p.MaxUnavailable != "" && !validMaxUnavailable(p.MaxUnavailable) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".maxUnavailable", "The provided maxUnavailable '%s' is invalid: it must be between 1 and 20 nodes or 1%% and 50%%.", p.MaxUnavailable)
+	}
+
+	return nil
+}
+
+// validMaxUnavailable returns true if s is a number of nodes between 1 and 20
+// or a percentage between 1% and 50%
+func validMaxUnavailable(s string) bool {
+	m := rxMaxUnavailable.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+
+	n, _ := strconv.Atoi(m[1])
+	if m[2] == "%" {
+		return n <= 50
+	}
+	return n <= 20
+}
+
+// validateNodeEvictionProfile checks that the eviction thresholds are within
+// bounds, that each soft threshold is reached before the hard threshold of
+// its signal, and that soft thresholds have a grace period, without which the
+// kubelet refuses to start
+func (sv openShiftClusterStaticValidator) validateNodeEvictionProfile(path string, p *NodeEvictionProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Hard == nil && p.Soft == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided node eviction profile is invalid: hard or soft thresholds must be specified.")
+	}
+
+	hard := &EvictionThresholds{}
+	if p.Hard != nil {
+		hard = p.Hard
+	}
+	soft := &EvictionThresholds{}
+	if p.Soft != nil {
+		soft = p.Soft
+	}
+
+	for _, signal := range []struct {
+		path         string
+		hard         string
+		soft         string
+		platformHard string
+		min, max     resource.Quantity
+	}{
+		{path: "memoryAvailable", hard: hard.MemoryAvailable, soft: soft.MemoryAvailable, platformHard: api.PlatformEvictionHard.MemoryAvailable, min: minEvictionMemory, max: maxEvictionMemory},
+		{path: "nodefsAvailable", hard: hard.NodeFSAvailable, soft: soft.NodeFSAvailable, platformHard: api.PlatformEvictionHard.NodeFSAvailable, min: minEvictionFilesystem, max: maxEvictionFilesystem},
+		{path: "imagefsAvailable", hard: hard.ImageFSAvailable, soft: soft.ImageFSAvailable, platformHard: api.PlatformEvictionHard.ImageFSAvailable, min: minEvictionFilesystem, max: maxEvictionFilesystem},
+	} {
+		if signal.hard != "" {
+			if _, ok := parseEvictionThreshold(signal.hard, signal.min, signal.max); !ok {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".hard."+signal.path, "The provided threshold '%s' is invalid: it must be between %s and %s, or between %d%% and %d%%.", signal.hard, signal.min.String(), signal.max.String(), minEvictionPercentage, maxEvictionPercentage)
+			}
+		}
+
+		if signal.soft == "" {
+			continue
+		}
+
+		softThreshold, ok := parseEvictionThreshold(signal.soft, signal.min, signal.max)
+		if !ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".soft."+signal.path, "The provided threshold '%s' is invalid: it must be between %s and %s, or between %d%% and %d%%.", signal.soft, signal.min.String(), signal.max.String(), minEvictionPercentage, maxEvictionPercentage)
+		}
+
+		hardValue := signal.hard
+		if hardValue == "" {
+			hardValue = signal.platformHard
+		}
+
+		// thresholds of different units can't be compared without knowing the
+		// capacity of the nodes
+		hardThreshold, _ := parseEvictionThreshold(hardValue, signal.min, signal.max)
+		if hardThreshold.percentage == softThreshold.percentage && hardThreshold.quantity.Cmp(softThreshold.quantity) >= 0 {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".soft."+signal.path, "The provided threshold '%s' is invalid: it must be greater than the hard threshold '%s'.", signal.soft, hardValue)
+		}
+	}
+
+	if p.Soft == nil || *p.Soft == (EvictionThresholds{}) {
+		if p.SoftGracePeriod != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".softGracePeriod", "The provided soft grace period '%s' is invalid: soft thresholds must be specified.", p.SoftGracePeriod)
+		}
+		return nil
+	}
+
+	gracePeriod, err := time.ParseDuration(p.SoftGracePeriod)
+	if err != nil || gracePeriod < minEvictionSoftGracePeriod || gracePeriod > maxEvictionSoftGracePeriod {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".softGracePeriod", "The provided soft grace period '%s' is invalid: it must be between 10s and 10m, e.g. 1m30s.", p.SoftGracePeriod)
+	}
+
+	return nil
+}
+
+// evictionThreshold is a parsed eviction threshold.  If percentage is set,
+// quantity is the percentage of the capacity.
+type evictionThreshold struct {
+	quantity   resource.Quantity
+	percentage bool
+}
+
+// parseEvictionThreshold parses a quantity between minQuantity and maxQuantity
+// or a percentage between minEvictionPercentage and maxEvictionPercentage
+func parseEvictionThreshold(s string, minQuantity, maxQuantity resource.Quantity) (evictionThreshold, bool) {
+	if strings.HasSuffix(s, "%") {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+		if err != nil || n < minEvictionPercentage || n > maxEvictionPercentage {
+			return evictionThreshold{}, false
+		}
+		return evictionThreshold{quantity: *resource.NewQuantity(int64(n), resource.DecimalSI), percentage: true}, true
+	}
+
+	q, err := resource.ParseQuantity(s)
+	if err != nil || q.Cmp(minQuantity) < 0 || q.Cmp(maxQuantity) > 0 {
+		return evictionThreshold{}, false
+	}
+
+	return evictionThreshold{quantity: q}, true
+}
+
+// parsePrometheusRetention parses a retention matched by rxPrometheusRetention
+func parsePrometheusRetention(s string) (time.Duration, bool) {
+	m := rxPrometheusRetention.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+
+	unit := map[string]time.Duration{
+		"h": time.Hour,
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}[m[2]]
+
+	return time.Duration(n) * unit, true
+}
+
+// validateSecurityProfile checks that the seccomp profile and the SELinux
+// booleans are supported.  Whether the default seccomp profile is supported at
+// the version of the cluster is validated by the frontend.
+func (sv openShiftClusterStaticValidator) validateSecurityProfile(path string, p *SecurityProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	switch p.DefaultSeccompProfile {
+	case "", SeccompProfileUnconfined, SeccompProfileRuntimeDefault:
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".defaultSeccompProfile", "The provided default seccomp profile '%s' is invalid.", p.DefaultSeccompProfile)
+	}
+
+	booleans := map[string]struct{}{}
+	for i, b := range p.SELinuxBooleans {
+		if !validate.SELinuxBooleanIsValid(b) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.seLinuxBooleans[%d]", path, i), "The provided SELinux boolean '%s' is invalid: it must be %s.", b, strings.Join(validate.SELinuxBooleans, " or "))
+		}
+		if _, found := booleans[b]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.seLinuxBooleans[%d]", path, i), "The provided SELinux boolean '%s' is duplicated.", b)
+		}
+		booleans[b] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateLogForwardingProfile checks that the destination of the forwarded
+// logs is well formed for its type.  Whether it can be reached with the given
+// credentials is validated dynamically.  The shared key is not returned to the
+// customer, so it is only required at create time.
+func (sv openShiftClusterStaticValidator) validateLogForwardingProfile(path string, p *LogForwardingProfile, isCreate bool) error {
+	if p == nil {
+		return nil
+	}
+
+	switch p.Type {
+	case LogForwardingTypeSyslog:
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "tcp" && u.Scheme != "tls" && u.Scheme != "udp") || u.Hostname() == "" || u.Port() == "" ||
+			u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".url", "The provided syslog URL '%s' is invalid: it must be a tcp, tls or udp URL with a host and a port.", p.URL)
+		}
+		if p.WorkspaceID != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workspaceId", "A workspace ID must not be provided for the Syslog type.")
+		}
+		if p.SharedKey != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".sharedKey", "A shared key must not be provided for the Syslog type.")
+		}
+
+	case LogForwardingTypeAzureMonitor:
+		if p.URL != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".url", "A URL must not be provided for the AzureMonitor type.")
+		}
+		if !uuid.IsValid(p.WorkspaceID) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".workspaceId", "The provided workspace ID '%s' is invalid.", p.WorkspaceID)
+		}
+		if isCreate && p.SharedKey == "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".sharedKey", "A shared key must be provided for the workspace.")
+		}
+		if p.SharedKey != "" {
+			if _, err := base64.StdEncoding.DecodeString(p.SharedKey); err != nil {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".sharedKey", "The provided shared key is invalid: it must be base64 encoded.")
+			}
+		}
+
+	default:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".type", "The provided log forwarding type '%s' is invalid.", p.Type)
+	}
+
+	logTypes := map[LogType]struct{}{}
+	for i, t := range p.LogTypes {
+		switch t {
+		case LogTypeApplication, LogTypeInfrastructure, LogTypeAudit:
+		default:
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.logTypes[%d]", path, i), "The provided log type '%s' is invalid.", t)
+		}
+		if _, found := logTypes[t]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s.logTypes[%d]", path, i), "The provided log type '%s' is duplicated.", t)
+		}
+		logTypes[t] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateAdmissionWebhookProfiles checks that each admission webhook
+// configuration is well formed and would be accepted by the API server.
+// Webhooks which fail closed on the core namespaces are allowed; the validate
+// endpoint warns of them.
+func (sv openShiftClusterStaticValidator) validateAdmissionWebhookProfiles(path string, ps []AdmissionWebhookProfile) error {
+	if len(ps) > maxAdmissionWebhookProfiles {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "At most %d admission webhook configurations may be provided.", maxAdmissionWebhookProfiles)
+	}
+
+	configurations := map[string]struct{}{}
+	for i, p := range ps {
+		o, err := validate.DecodeAdmissionWebhookConfiguration(p.Configuration)
+		if err == nil {
+			err = validate.ValidateAdmissionWebhookConfiguration(o)
+		}
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].configuration", path, i), "The provided admission webhook configuration is invalid: %s.", err)
+		}
+
+		m, err := meta.Accessor(o)
+		if err != nil {
+			return err
+		}
+
+		key := o.GetObjectKind().GroupVersionKind().Kind + "/" + m.GetName()
+		if _, found := configurations[key]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, fmt.Sprintf("%s[%d].configuration", path, i), "The provided admission webhook configuration '%s' is duplicated.", key)
+		}
+		configurations[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateSchedulerProfile checks that the default node selector is a list of
+// key=value node labels which does not pin workloads to the control plane or
+// infra nodes.  Whether any worker node has the labels is only known on the
+// cluster, so the operator checks it before configuring the selector.
+func (sv openShiftClusterStaticValidator) validateSchedulerProfile(path string, p *SchedulerProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if !validate.DefaultNodeSelectorIsValid(p.DefaultNodeSelector) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".defaultNodeSelector", "The provided default node selector '%s' is invalid: it must be a comma separated list of key=value node labels.", p.DefaultNodeSelector)
+	}
+
+	if label := validate.DefaultNodeSelectorControlPlaneLabel(p.DefaultNodeSelector); label != "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".defaultNodeSelector", "The provided default node selector '%s' is invalid: workloads may not be pinned to the nodes labelled '%s'.", p.DefaultNodeSelector, label)
+	}
+
+	return nil
+}
+
+// validateEgressFirewallProfile checks that each allowed destination is either
+// a CIDR or a DNS name, and that none is repeated.  An empty list is valid and
+// denies all egress.  Whether the API servers are allowed is only known on the
+// cluster, so the operator warns if they are not.
+func (sv openShiftClusterStaticValidator) validateEgressFirewallProfile(path string, p *EgressFirewallProfile) error {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.AllowedDestinations) > maxEgressDestinations {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".allowedDestinations", "At most %d allowed destinations may be provided.", maxEgressDestinations)
+	}
+
+	destinations := map[EgressDestination]struct{}{}
+	for i, d := range p.AllowedDestinations {
+		dPath := fmt.Sprintf("%s.allowedDestinations[%d]", path, i)
+
+		switch {
+		case d.CIDR != "" && d.DNSName != "":
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, dPath, "Only one of cidr and dnsName may be provided.")
+		case d.CIDR != "":
+			if !validate.EgressDestinationCIDRIsValid(d.CIDR) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, dPath+".cidr", "The provided CIDR '%s' is invalid: it must be an IPv4 range in canonical form, e.g. 203.0.113.0/24.", d.CIDR)
+			}
+		case d.DNSName != "":
+			if !validate.EgressDestinationDNSNameIsValid(d.DNSName) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, dPath+".dnsName", "The provided DNS name '%s' is invalid: it must be a lower case DNS name without wildcards, e.g. www.example.com.", d.DNSName)
+			}
+		default:
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, dPath, "One of cidr and dnsName must be provided.")
+		}
+
+		if _, found := destinations[d]; found {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, dPath, "The provided destination is duplicated.")
+		}
+		destinations[d] = struct{}{}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of m in order, so that validation errors are
+// deterministic
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,6 +4,8 @@ package v20230701preview
 // Licensed under the Apache License 2.0.
 
 import (
+	"crypto/rsa"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"strings"
@@ -14,6 +16,8 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 	"github.com/Azure/ARO-RP/test/validate"
 )
@@ -82,9 +86,10 @@ func validOpenShiftCluster(name, location string) *OpenShiftCluster {
 				ClientID:     "11111111-1111-1111-1111-111111111111",
 			},
 			NetworkProfile: NetworkProfile{
-				PodCIDR:      "10.128.0.0/14",
-				ServiceCIDR:  "172.30.0.0/16",
-				OutboundType: OutboundTypeLoadbalancer,
+				PodCIDR:                "10.128.0.0/14",
+				ServiceCIDR:            "172.30.0.0/16",
+				SoftwareDefinedNetwork: SoftwareDefinedNetworkOVNKubernetes,
+				OutboundType:           OutboundTypeLoadbalancer,
 				LoadBalancerProfile: &LoadBalancerProfile{
 					ManagedOutboundIPs: &ManagedOutboundIPs{
 						Count: 1,
@@ -254,14 +259,14 @@ func TestOpenShiftClusterStaticValidateProperties(t *testing.T) {
 			modify: func(oc *OpenShiftCluster) {
 				oc.Properties.WorkerProfiles = nil
 			},
-			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one default worker profile and at most 4 additional worker profiles.",
 		},
 		{
-			name: "multiple workerProfiles invalid",
+			name: "too many workerProfiles invalid",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.WorkerProfiles = []WorkerProfile{{}, {}}
+				oc.Properties.WorkerProfiles = make([]WorkerProfile, 6)
 			},
-			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one worker profile.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles: There should be exactly one default worker profile and at most 4 additional worker profiles.",
 		},
 	}
 
@@ -375,6 +380,108 @@ func TestOpenShiftClusterStaticValidateClusterProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.clusterProfile.domain: The provided domain '4k7f9clk' is invalid.",
 		},
+		{
+			name: "dns record ttl valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.DNSRecordTTL = 30
+			},
+		},
+		{
+			name: "dns record ttl negative",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.DNSRecordTTL = -1
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.dnsRecordTtl: The provided DNS record TTL '-1' is invalid.",
+		},
+		{
+			name: "dns record ttl too large",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.DNSRecordTTL = 2147483648
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.dnsRecordTtl: The provided DNS record TTL '2147483648' is invalid.",
+		},
+		{
+			name: "resource name template valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ResourceNameTemplate = "prod-{name}"
+			},
+		},
+		{
+			name: "resource name template invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ResourceNameTemplate = "Prod_{name}"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceNameTemplate: The provided resource name template 'Prod_{name}' is invalid.",
+		},
+		{
+			name: "existing resource group enabled valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ExistingResourceGroup = ExistingResourceGroupEnabled
+			},
+		},
+		{
+			name: "existing resource group disabled valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ExistingResourceGroup = ExistingResourceGroupDisabled
+			},
+		},
+		{
+			name: "existing resource group invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ExistingResourceGroup = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.existingResourceGroup: The provided value 'invalid' is invalid.",
+		},
+		{
+			name: "time zone valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.TimeZone = "Europe/London"
+			},
+		},
+		{
+			name: "time zone invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.TimeZone = "Europe/Atlantis"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.timeZone: The provided time zone 'Europe/Atlantis' is invalid.",
+		},
+		{
+			name: "dns zone valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/example.com"
+			},
+		},
+		{
+			name: "dns zone is the domain valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/cluster.example.com"
+			},
+		},
+		{
+			name: "dns zone invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/privateDnsZones/example.com"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.dnsZoneId: The provided DNS zone '/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/privateDnsZones/example.com' is invalid.",
+		},
+		{
+			name: "dns zone with managed domain invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/location.aroapp.io"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.dnsZoneId: The provided DNS zone '/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/location.aroapp.io' is invalid: a DNS zone can only be used with a custom domain.",
+		},
+		{
+			name: "dns zone not containing the domain invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/ample.com"
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.dnsZoneId: The provided DNS zone '/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/ample.com' is invalid: the domain 'cluster.example.com' must be in the zone.",
+		},
 	}
 
 	updateTests := []*validateTest{
@@ -492,6 +599,45 @@ func TestOpenShiftClusterStaticValidateNetworkProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.networkProfile.serviceCidr: The provided vnet CIDR '10.0.0.0/23' is invalid: must be /22 or larger.",
 		},
+		{
+			name: "softwareDefinedNetwork is empty",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.SoftwareDefinedNetwork = ""
+			},
+		},
+		{
+			name: "softwareDefinedNetwork OpenShiftSDN is valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.SoftwareDefinedNetwork = SoftwareDefinedNetworkOpenShiftSDN
+			},
+		},
+		{
+			name: "softwareDefinedNetwork is invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.SoftwareDefinedNetwork = "Calico"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.softwareDefinedNetwork: The provided softwareDefinedNetwork 'Calico' is invalid: must be OVNKubernetes or OpenShiftSDN.",
+		},
+		{
+			name: "maxPods valid",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.MaxPods = 500
+			},
+		},
+		{
+			name: "maxPods too small",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.MaxPods = 10
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.maxPods: The provided maxPods '10' is invalid: must be between 30 and 508, the number of pod addresses allocated to each node.",
+		},
+		{
+			name: "maxPods exhausts the node pod addresses",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.MaxPods = 509
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.maxPods: The provided maxPods '509' is invalid: must be between 30 and 508, the number of pod addresses allocated to each node.",
+		},
 		{
 			name: "OutboundType is empty",
 			current: func(oc *OpenShiftCluster) {
@@ -570,7 +716,7 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 					OutboundIPPrefixes: nil,
 				}
 			},
-			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, outboundIps, or outboundIpPrefixes at a time.",
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.",
 		},
 		{
 			name: "LoadBalancerProfile is invalid with OutboundIPs and OutboundIPPrefixes set",
@@ -589,7 +735,7 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 					},
 				}
 			},
-			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, outboundIps, or outboundIpPrefixes at a time.",
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.",
 		},
 		{
 			name: "LoadBalancerProfile is invalid with ManagedOutboundIPs and OutboundIPPrefixes set",
@@ -605,7 +751,7 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 					},
 				}
 			},
-			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, outboundIps, or outboundIpPrefixes at a time.",
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.",
 		},
 		{
 			name: "LoadBalancerProfile is invalid with ManagedOutboundIPs, OutboundIPs, and OutboundIPPrefixes set",
@@ -626,7 +772,7 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 					},
 				}
 			},
-			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, outboundIps, or outboundIpPrefixes at a time.",
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.",
 		},
 		{
 			name: "LoadBalancerProfile is invalid with ManagedOutboundIPs, OutboundIPs, and OutboundIPPrefixes set to nil",
@@ -637,7 +783,7 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 					OutboundIPPrefixes: nil,
 				}
 			},
-			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: must specify one of managedOutboundIps, outboundIps, or outboundIpPrefixes.",
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: must specify one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes.",
 		},
 		{
 			name: "LoadBalancerProfile.ManagedOutboundIPs is valid with 20 managed IPs",
@@ -672,6 +818,53 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIps.count: The provided managedOutboundIps.count 0 is invalid: managedOutboundIps.count must be in the range of 1 to 20 (inclusive).",
 		},
+		{
+			name: "LoadBalancerProfile.ManagedOutboundIPPrefix is valid with a /28 prefix",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 28,
+					},
+				}
+			},
+			wantErr: "",
+		},
+		{
+			name: "LoadBalancerProfile.ManagedOutboundIPPrefix is invalid with a prefix larger than /28",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 27,
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIpPrefix.prefixLength: The provided managedOutboundIpPrefix.prefixLength 27 is invalid: managedOutboundIpPrefix.prefixLength must be in the range of 28 to 31 (inclusive).",
+		},
+		{
+			name: "LoadBalancerProfile.ManagedOutboundIPPrefix is invalid with a prefix smaller than /31",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 32,
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.managedOutboundIpPrefix.prefixLength: The provided managedOutboundIpPrefix.prefixLength 32 is invalid: managedOutboundIpPrefix.prefixLength must be in the range of 28 to 31 (inclusive).",
+		},
+		{
+			name: "LoadBalancerProfile is invalid with ManagedOutboundIPs and ManagedOutboundIPPrefix set",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPs: &ManagedOutboundIPs{
+						Count: 1,
+					},
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 30,
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile: The provided loadBalancerProfile is invalid: can only use one of managedOutboundIps, managedOutboundIpPrefix, outboundIps, or outboundIpPrefixes at a time.",
+		},
 		{
 			name: "LoadBalancerProfile.OutboundIPs is not supported",
 			current: func(oc *OpenShiftCluster) {
@@ -729,12 +922,110 @@ func TestOpenShiftClusterStaticValidateLoadBalancerProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.effectiveOutboundIps: The field effectiveOutboundIps is read only.",
 		},
+		{
+			name: "LoadBalancerProfile.EffectiveOutboundIPPrefix is read only",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 30,
+					},
+					EffectiveOutboundIPPrefix: &EffectiveOutboundIPPrefix{
+						ID:       "someId",
+						IPPrefix: "20.0.0.0/30",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.loadBalancerProfile.effectiveOutboundIpPrefix: The field effectiveOutboundIpPrefix is read only.",
+		},
 	}
 	runTests(t, testModeCreate, createTests)
 	runTests(t, testModeCreate, tests)
 	runTests(t, testModeUpdate, tests)
 }
 
+func TestOpenShiftClusterStaticValidateCustomerLoadBalancerProfile(t *testing.T) {
+	loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor", subscriptionID)
+
+	private := func(oc *OpenShiftCluster) {
+		oc.Properties.APIServerProfile.Visibility = VisibilityPrivate
+		oc.Properties.IngressProfiles[0].Visibility = VisibilityPrivate
+		oc.Properties.NetworkProfile.CustomerLoadBalancerProfile = &CustomerLoadBalancerProfile{
+			ID:                               loadBalancerID,
+			APIServerFrontendIPConfiguration: "api",
+			IngressFrontendIPConfiguration:   "ingress",
+		}
+	}
+
+	commonTests := []*validateTest{
+		{
+			name:    "valid",
+			current: private,
+		},
+		{
+			name:    "id invalid",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID = fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/applicationGateways/frontdoor", subscriptionID)
+			},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.id: The provided load balancer '/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/applicationGateways/frontdoor' is invalid.", subscriptionID),
+		},
+		{
+			name:    "id in another subscription",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.id: The provided load balancer '/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor' is invalid: must be in the same subscription as the cluster.",
+		},
+		{
+			name:    "api server frontend missing",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.APIServerFrontendIPConfiguration = ""
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.apiServerFrontendIpConfiguration: The provided frontend IP configuration '' is invalid.",
+		},
+		{
+			name:    "ingress frontend invalid",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration = "-ingress"
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.ingressFrontendIpConfiguration: The provided frontend IP configuration '-ingress' is invalid.",
+		},
+		{
+			name:    "public api server",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.Visibility = VisibilityPublic
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile: The provided customerLoadBalancerProfile is invalid: cannot use a customer load balancer if either API Server Visibility or Ingress Visibility is public.",
+		},
+		{
+			name:    "public ingress",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].Visibility = VisibilityPublic
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile: The provided customerLoadBalancerProfile is invalid: cannot use a customer load balancer if either API Server Visibility or Ingress Visibility is public.",
+		},
+	}
+
+	createTests := []*validateTest{
+		{
+			name:    "valid with a shared frontend",
+			current: private,
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.IngressFrontendIPConfiguration = "api"
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+}
+
 func TestOpenShiftClusterStaticValidateMasterProfile(t *testing.T) {
 	tests := []*validateTest{
 		{
@@ -849,6 +1140,33 @@ func TestOpenShiftClusterStaticValidateWorkerProfile(t *testing.T) {
 			},
 			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].diskSizeGB: The provided worker disk size '127' is invalid.",
 		},
+		{
+			name: "disk too big",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskSizeGB = 4096
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].diskSizeGB: The provided worker disk size '4096' is invalid.",
+		},
+		{
+			name: "disk storage account type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskStorageAccountType = "UltraSSD_LRS"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].diskStorageAccountType: The provided worker disk storage account type 'UltraSSD_LRS' is invalid.",
+		},
+		{
+			name: "accelerated networking valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = AcceleratedNetworkingDisabled
+			},
+		},
+		{
+			name: "accelerated networking invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = "On"
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].acceleratedNetworking: The provided value 'On' is invalid.",
+		},
 		{
 			name: "subnetId invalid",
 			modify: func(oc *OpenShiftCluster) {
@@ -912,107 +1230,2109 @@ func TestOpenShiftClusterStaticValidateWorkerProfile(t *testing.T) {
 	runTests(t, testModeCreate, tests)
 }
 
-func TestOpenShiftClusterStaticValidateAPIServerProfile(t *testing.T) {
-	commonTests := []*validateTest{
+func TestOpenShiftClusterStaticValidateAdditionalWorkerProfiles(t *testing.T) {
+	additionalWorkerProfile := func(oc *OpenShiftCluster, name, subnet string) {
+		wp := oc.Properties.WorkerProfiles[0]
+		wp.Name = name
+		wp.SubnetID = fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/%s", subscriptionID, subnet)
+		oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, wp)
+	}
+
+	tests := []*validateTest{
 		{
-			name: "valid",
+			name: "valid in own subnet",
+			modify: func(oc *OpenShiftCluster) {
+				additionalWorkerProfile(oc, "infra", "infra")
+			},
 		},
 		{
-			name: "visibility invalid",
+			name: "valid in default worker subnet",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.Visibility = "invalid"
+				additionalWorkerProfile(oc, "infra", "worker")
 			},
-			wantErr: "400: InvalidParameter: properties.apiserverProfile.visibility: The provided visibility 'invalid' is invalid.",
 		},
 		{
-			name: "url invalid",
+			name: "default worker profile not first",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.URL = "\x00"
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[0], oc.Properties.WorkerProfiles[1] = oc.Properties.WorkerProfiles[1], oc.Properties.WorkerProfiles[0]
 			},
-			wantErr: "400: InvalidParameter: properties.apiserverProfile.url: The provided URL '\x00' is invalid.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].name: The provided worker name 'infra' is invalid.",
 		},
 		{
-			name: "ip invalid",
+			name: "name invalid",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.IP = "invalid"
+				additionalWorkerProfile(oc, "Infra_Nodes", "infra")
 			},
-			wantErr: "400: InvalidParameter: properties.apiserverProfile.ip: The provided IP 'invalid' is invalid.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['Infra_Nodes'].name: The provided worker name 'Infra_Nodes' is invalid.",
 		},
 		{
-			name: "ipv6 ip invalid",
+			name: "name reserved",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.IP = "::"
+				additionalWorkerProfile(oc, "master", "infra")
 			},
-			wantErr: "400: InvalidParameter: properties.apiserverProfile.ip: The provided IP '::' is invalid: must be IPv4.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['master'].name: The provided worker name 'master' is invalid: must be unique.",
 		},
-	}
-
-	createTests := []*validateTest{
 		{
-			name: "empty url valid",
+			name: "name not unique",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.URL = ""
+				additionalWorkerProfile(oc, "infra", "infra")
+				additionalWorkerProfile(oc, "infra", "other")
 			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].name: The provided worker name 'infra' is invalid: must be unique.",
 		},
 		{
-			name: "empty ip valid",
+			name: "subnet in master subnet",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.APIServerProfile.IP = ""
+				additionalWorkerProfile(oc, "infra", "master")
 			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].subnetId: The provided worker VM subnet '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/master' is invalid: must be different to master VM subnet '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/master'.",
 		},
-	}
-
-	runTests(t, testModeCreate, createTests)
-	runTests(t, testModeCreate, commonTests)
-	runTests(t, testModeUpdate, commonTests)
-}
-
-func TestOpenShiftClusterStaticValidateIngressProfile(t *testing.T) {
-	tests := []*validateTest{
 		{
-			name: "valid",
+			name: "subnet not in cluster vnet",
+			modify: func(oc *OpenShiftCluster) {
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].SubnetID = fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/different-vnet/subnets/infra", subscriptionID)
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].subnetId: The provided worker VM subnet '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/different-vnet/subnets/infra' is invalid: must be in the same vnet as master VM subnet '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/master'.",
 		},
 		{
-			name: "name invalid",
+			name: "count invalid",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.IngressProfiles[0].Name = "invalid"
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].Count = 51
 			},
-			wantErr: "400: InvalidParameter: properties.ingressProfiles['invalid'].name: The provided ingress name 'invalid' is invalid.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].count: The provided worker count '51' is invalid.",
 		},
 		{
-			name: "visibility invalid",
+			name: "proximity placement group created",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.IngressProfiles[0].Visibility = "invalid"
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{}
 			},
-			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].visibility: The provided visibility 'invalid' is invalid.",
 		},
 		{
-			name: "ip invalid",
+			name: "proximity placement group existing",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.IngressProfiles[0].IP = "invalid"
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: fmt.Sprintf("/subscriptions/%s/resourceGroups/ppg/providers/Microsoft.Compute/proximityPlacementGroups/infra", subscriptionID),
+				}
 			},
-			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].ip: The provided IP 'invalid' is invalid.",
 		},
 		{
-			name: "ipv6 ip invalid",
+			name: "proximity placement group invalid",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.IngressProfiles[0].IP = "::"
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: fmt.Sprintf("/subscriptions/%s/resourceGroups/ppg/providers/Microsoft.Compute/availabilitySets/infra", subscriptionID),
+				}
 			},
-			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].ip: The provided IP '::' is invalid: must be IPv4.",
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].proximityPlacementGroupProfile.id: The provided proximity placement group '/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ppg/providers/Microsoft.Compute/availabilitySets/infra' is invalid.",
 		},
 		{
-			name: "empty ip valid",
+			name: "proximity placement group in other subscription",
 			modify: func(oc *OpenShiftCluster) {
-				oc.Properties.IngressProfiles[0].IP = ""
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{
+					ID: "/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/ppg/providers/Microsoft.Compute/proximityPlacementGroups/infra",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['infra'].proximityPlacementGroupProfile.id: The provided proximity placement group '/subscriptions/7a3036d1-60a1-4605-8a41-44955e050804/resourceGroups/ppg/providers/Microsoft.Compute/proximityPlacementGroups/infra' is invalid: must be in same subscription as cluster.",
+		},
+		{
+			name: "proximity placement group on default worker profile",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].proximityPlacementGroupProfile: The default worker profile cannot be placed in a proximity placement group: use an additional worker profile.",
+		},
+		{
+			name: "disk storage account type on additional worker profile",
+			modify: func(oc *OpenShiftCluster) {
+				additionalWorkerProfile(oc, "infra", "infra")
+				oc.Properties.WorkerProfiles[1].DiskStorageAccountType = DiskStorageAccountTypeStandardSSDLRS
+			},
+		},
+		{
+			name: "disk storage account type on default worker profile",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskStorageAccountType = DiskStorageAccountTypeStandardSSDLRS
 			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].diskStorageAccountType: The OS disk storage account type of the default worker profile cannot be set: use an additional worker profile.",
 		},
 	}
 
-	// we don't validate this on update as all fields are immutable and will
-	// be validated with "mutable" flag
 	runTests(t, testModeCreate, tests)
 }
 
+func TestOpenShiftClusterStaticValidateAPIServerProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "visibility invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.Visibility = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.visibility: The provided visibility 'invalid' is invalid.",
+		},
+		{
+			name: "url invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.URL = "\x00"
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.url: The provided URL '\x00' is invalid.",
+		},
+		{
+			name: "ip invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.IP = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.ip: The provided IP 'invalid' is invalid.",
+		},
+		{
+			name: "ipv6 ip invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.IP = "::"
+			},
+			wantErr: "400: InvalidParameter: properties.apiserverProfile.ip: The provided IP '::' is invalid: must be IPv4.",
+		},
+	}
+
+	createTests := []*validateTest{
+		{
+			name: "empty url valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.URL = ""
+			},
+		},
+		{
+			name: "empty ip valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.IP = ""
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+}
+
+func TestOpenShiftClusterStaticValidateMaintenanceWindow(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday, WeekdaySunday},
+					StartTime:     "22:00",
+					DurationHours: 6,
+					TimeZone:      "Europe/Berlin",
+				}
+			},
+		},
+		{
+			name: "valid without time zone",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdayTuesday},
+					StartTime:     "02:30",
+					DurationHours: 24,
+				}
+			},
+		},
+		{
+			name: "no days",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					StartTime:     "22:00",
+					DurationHours: 6,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.days: The provided maintenance window days are invalid: at least one day must be specified.",
+		},
+		{
+			name: "day invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{"Funday"},
+					StartTime:     "22:00",
+					DurationHours: 6,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.days: The provided maintenance window day 'Funday' is invalid.",
+		},
+		{
+			name: "day duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday, WeekdaySaturday},
+					StartTime:     "22:00",
+					DurationHours: 6,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.days: The provided maintenance window day 'Saturday' is duplicated.",
+		},
+		{
+			name: "start time invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday},
+					StartTime:     "10pm",
+					DurationHours: 6,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.startTime: The provided maintenance window start time '10pm' is invalid: the start time must be in HH:MM 24 hour format.",
+		},
+		{
+			name: "duration too short",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:      []Weekday{WeekdaySaturday},
+					StartTime: "22:00",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.durationHours: The provided maintenance window duration 0 is invalid: durationHours must be in the range of 1 to 24 (inclusive).",
+		},
+		{
+			name: "duration too long",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday},
+					StartTime:     "22:00",
+					DurationHours: 25,
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.durationHours: The provided maintenance window duration 25 is invalid: durationHours must be in the range of 1 to 24 (inclusive).",
+		},
+		{
+			name: "time zone invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday},
+					StartTime:     "22:00",
+					DurationHours: 6,
+					TimeZone:      "CEST",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.maintenanceWindow.timeZone: The provided maintenance window time zone 'CEST' is invalid.",
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+}
+
+func TestOpenShiftClusterStaticValidateProjectTemplateProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"requests.cpu":           "4",
+						"limits.memory":          "16Gi",
+						"pods":                   "20",
+						"count/deployments.apps": "10",
+					},
+					DefaultLimits: map[string]string{
+						"cpu":    "1",
+						"memory": "1Gi",
+					},
+					DefaultRequests: map[string]string{
+						"cpu":               "100m",
+						"memory":            "1Gi",
+						"ephemeral-storage": "1Gi",
+					},
+				}
+			},
+		},
+		{
+			name: "valid resource quota only",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"requests.storage": "100Gi",
+					},
+				}
+			},
+		},
+		{
+			name: "empty",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile: The provided project template profile is invalid: at least one resource quota, default limit or default request must be specified.",
+		},
+		{
+			name: "resource quota resource invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"requests.gpu": "1",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.resourceQuota: The provided resource quota resource 'requests.gpu' is invalid.",
+		},
+		{
+			name: "resource quota quantity invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"limits.cpu": "-2",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.resourceQuota[limits.cpu]: The provided quantity '-2' is invalid.",
+		},
+		{
+			name: "resource quota count not whole",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"pods": "500m",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.resourceQuota[pods]: The provided quantity '500m' is invalid.",
+		},
+		{
+			name: "default limit resource invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					DefaultLimits: map[string]string{
+						"pods": "1",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.defaultLimits: The provided limit range resource 'pods' is invalid: only cpu, memory and ephemeral-storage are supported.",
+		},
+		{
+			name: "default request quantity invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					DefaultRequests: map[string]string{
+						"memory": "a lot",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.defaultRequests[memory]: The provided quantity 'a lot' is invalid.",
+		},
+		{
+			name: "default request exceeds default limit",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					DefaultLimits: map[string]string{
+						"cpu": "500m",
+					},
+					DefaultRequests: map[string]string{
+						"cpu": "1",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.projectTemplateProfile.defaultRequests[cpu]: The provided default request '1' is invalid: it must not exceed the default limit '500m'.",
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+}
+
+func TestOpenShiftClusterStaticValidateIdentityProviderProfile(t *testing.T) {
+	identityProviderProfile := func() *IdentityProviderProfile {
+		return &IdentityProviderProfile{
+			Name:         "AzureAD",
+			Issuer:       "https://login.microsoftonline.com/22222222-2222-2222-2222-222222222222/v2.0",
+			ClientID:     "11111111-1111-1111-1111-111111111111",
+			ClientSecret: "secret",
+		}
+	}
+
+	createTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+			},
+		},
+		{
+			name: "name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+				oc.Properties.IdentityProviderProfile.Name = "azure:ad"
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.name: The provided identity provider name 'azure:ad' is invalid.",
+		},
+		{
+			name: "issuer not https",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+				oc.Properties.IdentityProviderProfile.Issuer = "http://issuer.example.com"
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer 'http://issuer.example.com' is invalid: it must be an https URL without a query or fragment.",
+		},
+		{
+			name: "issuer with query",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+				oc.Properties.IdentityProviderProfile.Issuer = "https://issuer.example.com/?tenant=1"
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer 'https://issuer.example.com/?tenant=1' is invalid: it must be an https URL without a query or fragment.",
+		},
+		{
+			name: "client ID missing",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+				oc.Properties.IdentityProviderProfile.ClientID = ""
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.clientId: A client ID must be provided for the identity provider.",
+		},
+		{
+			name: "client secret missing",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+				oc.Properties.IdentityProviderProfile.ClientSecret = ""
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.clientSecret: A client secret must be provided for the identity provider.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "client secret omitted",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile.ClientSecret = ""
+			},
+		},
+		{
+			name: "client secret rotated",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile.ClientSecret = "rotated"
+			},
+		},
+		{
+			name: "issuer change",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile.Issuer = "https://issuer.example.com"
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.identityProviderProfile.issuer: Changing property 'properties.identityProviderProfile.issuer' is not allowed.",
+		},
+		{
+			name: "identity provider added",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IdentityProviderProfile = identityProviderProfile()
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.identityProviderProfile: Changing property 'properties.identityProviderProfile' is not allowed.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateRegistryMirrorProfiles(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "myregistry.azurecr.io/docker-hub",
+					},
+					{
+						Source: "quay.io/myorg",
+						Mirror: "cache.example.com:5000/quay",
+					},
+				}
+			},
+		},
+		{
+			name: "too many mirrors",
+			modify: func(oc *OpenShiftCluster) {
+				for i := 0; i < maxRegistryMirrorProfiles+1; i++ {
+					oc.Properties.RegistryMirrorProfiles = append(oc.Properties.RegistryMirrorProfiles, RegistryMirrorProfile{
+						Source: fmt.Sprintf("registry%d.example.com", i),
+						Mirror: "myregistry.azurecr.io",
+					})
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.registryMirrorProfiles: At most 8 registry mirrors may be provided.",
+		},
+		{
+			name: "source invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "https://docker.io",
+						Mirror: "myregistry.azurecr.io",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.registryMirrorProfiles[0].source: The provided registry 'https://docker.io' is invalid.",
+		},
+		{
+			name: "mirror invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "MyRegistry.azurecr.io",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.registryMirrorProfiles[0].mirror: The provided registry 'MyRegistry.azurecr.io' is invalid.",
+		},
+		{
+			name: "mirror same as source",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "docker.io",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.registryMirrorProfiles[0].mirror: The provided mirror 'docker.io' is invalid: it must differ from its source.",
+		},
+		{
+			name: "source duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "myregistry.azurecr.io/docker-hub",
+					},
+					{
+						Source: "docker.io",
+						Mirror: "cache.example.com",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.registryMirrorProfiles[1].source: The provided registry 'docker.io' is duplicated.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "mirror changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "myregistry.azurecr.io/docker-hub",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles[0].Mirror = "cache.example.com"
+			},
+		},
+		{
+			name: "mirrors removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = []RegistryMirrorProfile{
+					{
+						Source: "docker.io",
+						Mirror: "myregistry.azurecr.io/docker-hub",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.RegistryMirrorProfiles = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateDefaultStorageClassProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid built-in storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "managed-csi-encrypted-cmk",
+				}
+			},
+		},
+		{
+			name: "valid storage class with parameters",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium-zrs",
+					Parameters: map[string]string{
+						"skuName":     "Premium_ZRS",
+						"cachingMode": "ReadOnly",
+						"fsType":      "xfs",
+					},
+				}
+			},
+		},
+		{
+			name: "valid ultra disk storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "ultra",
+					Parameters: map[string]string{
+						"skuName":           "UltraSSD_LRS",
+						"cachingMode":       "None",
+						"DiskIOPSReadWrite": "5000",
+					},
+				}
+			},
+		},
+		{
+			name: "name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "Premium",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.name: The provided storage class name 'Premium' is invalid.",
+		},
+		{
+			name: "built-in storage class with parameters",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "managed-csi",
+					Parameters: map[string]string{
+						"skuName": "Premium_ZRS",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.name: The provided storage class name 'managed-csi' is invalid: parameters may not be provided for a built-in storage class.",
+		},
+		{
+			name: "parameter not supported",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium",
+					Parameters: map[string]string{
+						"resourceGroup": "other",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.parameters: The provided storage class parameter 'resourceGroup' is not supported.",
+		},
+		{
+			name: "parameter duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium",
+					Parameters: map[string]string{
+						"skuName": "Premium_LRS",
+						"skuname": "Premium_ZRS",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.parameters: The provided storage class parameter 'skuname' is duplicated.",
+		},
+		{
+			name: "parameter value invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium",
+					Parameters: map[string]string{
+						"skuName": "Premium_GRS",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.parameters[skuName]: The provided value 'Premium_GRS' of storage class parameter 'skuName' is invalid.",
+		},
+		{
+			name: "caching not supported by ultra disks",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "ultra",
+					Parameters: map[string]string{
+						"skuName":     "UltraSSD_LRS",
+						"cachingMode": "ReadOnly",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.parameters: The provided caching mode 'ReadOnly' is invalid: disks of SKU 'UltraSSD_LRS' only support caching mode 'None'.",
+		},
+		{
+			name: "iops not configurable",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium",
+					Parameters: map[string]string{
+						"skuName":           "Premium_LRS",
+						"DiskIOPSReadWrite": "5000",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.defaultStorageClassProfile.parameters: The provided storage class parameter 'DiskIOPSReadWrite' is invalid: it is only supported for disks of SKU PremiumV2_LRS or UltraSSD_LRS.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "default storage class changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "managed-csi",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.DefaultStorageClassProfile.Name = "premium"
+				oc.Properties.DefaultStorageClassProfile.Parameters = map[string]string{
+					"skuName": "Premium_ZRS",
+				}
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateAzureFileCSIProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+					SKUName:          "Premium_ZRS",
+				}
+			},
+		},
+		{
+			name: "valid without sku",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+				}
+			},
+		},
+		{
+			name: "valid as the default storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+				}
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "azurefile-rwx",
+				}
+			},
+		},
+		{
+			name: "name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "AzureFile",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.storageClassName: The provided storage class name 'AzureFile' is invalid.",
+		},
+		{
+			name: "built-in storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-csi",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.storageClassName: The provided storage class name 'azurefile-csi' is invalid: it is the name of a built-in storage class.",
+		},
+		{
+			name: "storage class created for the default storage class profile",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "premium",
+				}
+				oc.Properties.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+					Name: "premium",
+					Parameters: map[string]string{
+						"skuName": "Premium_LRS",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.storageClassName: The provided storage class name 'premium' is invalid: it is the name of the storage class created for the default storage class profile.",
+		},
+		{
+			name: "sku invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+					SKUName:          "StandardSSD_LRS",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.skuName: The provided SKU 'StandardSSD_LRS' is invalid: must be one of Standard_LRS, Standard_ZRS, Standard_GRS, Standard_RAGRS, Standard_GZRS, Standard_RAGZRS, Premium_LRS, Premium_ZRS.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "azure file storage class enabled",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+				}
+			},
+		},
+		{
+			name: "azure file storage class disabled",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateMonitoringProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention:        "30d",
+					StorageSize:      "100Gi",
+					StorageClassName: "managed-csi",
+				}
+			},
+		},
+		{
+			name: "valid ephemeral retention",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention: "2w",
+				}
+			},
+		},
+		{
+			name: "valid storage with default retention and storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize: "1Ti",
+				}
+			},
+		},
+		{
+			name: "empty profile",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile: The provided monitoring profile is invalid: a retention or storage size must be specified.",
+		},
+		{
+			name: "retention invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention: "30 days",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.retention: The provided retention '30 days' is invalid: it must be between 1d and 90d, e.g. 30d.",
+		},
+		{
+			name: "retention too short",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention: "12h",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.retention: The provided retention '12h' is invalid: it must be between 1d and 90d, e.g. 30d.",
+		},
+		{
+			name: "retention too long",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention:   "13w",
+					StorageSize: "100Gi",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.retention: The provided retention '13w' is invalid: it must be between 1d and 90d, e.g. 30d.",
+		},
+		{
+			name: "retention beyond the platform default without storage",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention: "30d",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.retention: The provided retention '30d' is invalid: a storage size must be specified for retention beyond 15d.",
+		},
+		{
+			name: "storage size too small",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize: "5Gi",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageSize: The provided storage size '5Gi' is invalid: it must be between 10Gi and 1Ti.",
+		},
+		{
+			name: "storage size invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize: "lots",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageSize: The provided storage size 'lots' is invalid: it must be between 10Gi and 1Ti.",
+		},
+		{
+			name: "storage class without storage size",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention:        "7d",
+					StorageClassName: "managed-csi",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageClassName: The provided storage class name 'managed-csi' is invalid: a storage size must be specified.",
+		},
+		{
+			name: "storage class name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize:      "100Gi",
+					StorageClassName: "Managed_CSI",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageClassName: The provided storage class name 'Managed_CSI' is invalid.",
+		},
+		{
+			name: "built-in azure files storage class",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize:      "100Gi",
+					StorageClassName: "azurefile-csi",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageClassName: The provided storage class name 'azurefile-csi' is invalid: Prometheus does not support Azure Files storage.",
+		},
+		{
+			name: "azure files storage class of the cluster",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AzureFileCSIProfile = &AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+				}
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					StorageSize:      "100Gi",
+					StorageClassName: "azurefile-rwx",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.monitoringProfile.storageClassName: The provided storage class name 'azurefile-rwx' is invalid: Prometheus does not support Azure Files storage.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "retention and storage changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention: "7d",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention:   "60d",
+					StorageSize: "200Gi",
+				}
+			},
+		},
+		{
+			name: "platform defaults restored",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = &MonitoringProfile{
+					Retention:   "60d",
+					StorageSize: "200Gi",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MonitoringProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateUpgradeProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName: "cluster-1234-worker-eastus1-abcde",
+				}
+			},
+		},
+		{
+			name: "empty node name",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.lastNodeName: The provided node name '' is invalid.",
+		},
+		{
+			name: "invalid node name",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName: "Worker_1",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.lastNodeName: The provided node name 'Worker_1' is invalid.",
+		},
+		{
+			name: "valid update strategy",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "20",
+				}
+			},
+		},
+		{
+			name: "valid percentage",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName:   "cluster-1234-worker-eastus1-abcde",
+					MaxUnavailable: "50%",
+				}
+			},
+		},
+		{
+			name: "too many nodes unavailable",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "21",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maxUnavailable: The provided maxUnavailable '21' is invalid: it must be between 1 and 20 nodes or 1% and 50%.",
+		},
+		{
+			name: "too large a percentage unavailable",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "51%",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maxUnavailable: The provided maxUnavailable '51%' is invalid: it must be between 1 and 20 nodes or 1% and 50%.",
+		},
+		{
+			name: "no node unavailable",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "0",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.upgradeProfile.maxUnavailable: The provided maxUnavailable '0' is invalid: it must be between 1 and 20 nodes or 1% and 50%.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "last node changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName: "cluster-1234-worker-eastus1-abcde",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName: "cluster-1234-worker-eastus2-fghij",
+				}
+			},
+		},
+		{
+			name: "max unavailable changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "10%",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					MaxUnavailable: "2",
+				}
+			},
+		},
+		{
+			name: "last node removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = &UpgradeProfile{
+					LastNodeName: "cluster-1234-worker-eastus1-abcde",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.UpgradeProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateNodeEvictionProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid hard thresholds",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "500Mi",
+						NodeFSAvailable: "5%",
+					},
+				}
+			},
+		},
+		{
+			name: "valid soft thresholds",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "500Mi",
+					},
+					Soft: &EvictionThresholds{
+						MemoryAvailable:  "1Gi",
+						NodeFSAvailable:  "15%",
+						ImageFSAvailable: "20Gi",
+					},
+					SoftGracePeriod: "1m30s",
+				}
+			},
+		},
+		{
+			name: "no thresholds",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile: The provided node eviction profile is invalid: hard or soft thresholds must be specified.",
+		},
+		{
+			name: "hard memory threshold too low",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "50Mi",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.hard.memoryAvailable: The provided threshold '50Mi' is invalid: it must be between 100Mi and 8Gi, or between 1% and 50%.",
+		},
+		{
+			name: "hard filesystem percentage too high",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						ImageFSAvailable: "60%",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.hard.imagefsAvailable: The provided threshold '60%' is invalid: it must be between 1Gi and 100Gi, or between 1% and 50%.",
+		},
+		{
+			name: "invalid soft threshold",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Soft: &EvictionThresholds{
+						NodeFSAvailable: "lots",
+					},
+					SoftGracePeriod: "1m",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.soft.nodefsAvailable: The provided threshold 'lots' is invalid: it must be between 1Gi and 100Gi, or between 1% and 50%.",
+		},
+		{
+			name: "soft threshold not above hard threshold",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "1Gi",
+					},
+					Soft: &EvictionThresholds{
+						MemoryAvailable: "1024Mi",
+					},
+					SoftGracePeriod: "1m",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.soft.memoryAvailable: The provided threshold '1024Mi' is invalid: it must be greater than the hard threshold '1Gi'.",
+		},
+		{
+			name: "soft threshold not above platform hard threshold",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Soft: &EvictionThresholds{
+						ImageFSAvailable: "10%",
+					},
+					SoftGracePeriod: "1m",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.soft.imagefsAvailable: The provided threshold '10%' is invalid: it must be greater than the hard threshold '15%'.",
+		},
+		{
+			name: "soft threshold without grace period",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Soft: &EvictionThresholds{
+						MemoryAvailable: "1Gi",
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.softGracePeriod: The provided soft grace period '' is invalid: it must be between 10s and 10m, e.g. 1m30s.",
+		},
+		{
+			name: "grace period too long",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Soft: &EvictionThresholds{
+						MemoryAvailable: "1Gi",
+					},
+					SoftGracePeriod: "1h",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.softGracePeriod: The provided soft grace period '1h' is invalid: it must be between 10s and 10m, e.g. 1m30s.",
+		},
+		{
+			name: "grace period without soft thresholds",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "500Mi",
+					},
+					SoftGracePeriod: "1m",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.nodeEvictionProfile.softGracePeriod: The provided soft grace period '1m' is invalid: soft thresholds must be specified.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "thresholds changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "500Mi",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "1Gi",
+					},
+				}
+			},
+		},
+		{
+			name: "thresholds removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = &NodeEvictionProfile{
+					Hard: &EvictionThresholds{
+						MemoryAvailable: "500Mi",
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NodeEvictionProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateLogForwardingProfile(t *testing.T) {
+	syslog := func() *LogForwardingProfile {
+		return &LogForwardingProfile{
+			Type: LogForwardingTypeSyslog,
+			URL:  "tls://syslog.example.com:6514",
+		}
+	}
+
+	azureMonitor := func() *LogForwardingProfile {
+		return &LogForwardingProfile{
+			Type:        LogForwardingTypeAzureMonitor,
+			WorkspaceID: "11111111-1111-1111-1111-111111111111",
+			SharedKey:   "c2hhcmVka2V5",
+			LogTypes:    []LogType{LogTypeApplication, LogTypeAudit},
+		}
+	}
+
+	createTests := []*validateTest{
+		{
+			name: "valid syslog",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+			},
+		},
+		{
+			name: "valid azure monitor",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+			},
+		},
+		{
+			name: "type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.Type = "Splunk"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.type: The provided log forwarding type 'Splunk' is invalid.",
+		},
+		{
+			name: "syslog URL scheme invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.URL = "https://syslog.example.com:6514"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.url: The provided syslog URL 'https://syslog.example.com:6514' is invalid: it must be a tcp, tls or udp URL with a host and a port.",
+		},
+		{
+			name: "syslog URL without port",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.URL = "udp://syslog.example.com"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.url: The provided syslog URL 'udp://syslog.example.com' is invalid: it must be a tcp, tls or udp URL with a host and a port.",
+		},
+		{
+			name: "syslog with workspace ID",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.WorkspaceID = "11111111-1111-1111-1111-111111111111"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.workspaceId: A workspace ID must not be provided for the Syslog type.",
+		},
+		{
+			name: "azure monitor with URL",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+				oc.Properties.LogForwardingProfile.URL = "tls://syslog.example.com:6514"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.url: A URL must not be provided for the AzureMonitor type.",
+		},
+		{
+			name: "workspace ID invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+				oc.Properties.LogForwardingProfile.WorkspaceID = "workspace"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.workspaceId: The provided workspace ID 'workspace' is invalid.",
+		},
+		{
+			name: "shared key missing",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+				oc.Properties.LogForwardingProfile.SharedKey = ""
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.sharedKey: A shared key must be provided for the workspace.",
+		},
+		{
+			name: "shared key not base64",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+				oc.Properties.LogForwardingProfile.SharedKey = "shared key"
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.sharedKey: The provided shared key is invalid: it must be base64 encoded.",
+		},
+		{
+			name: "log type invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.LogTypes = []LogType{LogTypeAudit, "Kernel"}
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.logTypes[1]: The provided log type 'Kernel' is invalid.",
+		},
+		{
+			name: "log type duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+				oc.Properties.LogForwardingProfile.LogTypes = []LogType{LogTypeAudit, LogTypeAudit}
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.logTypes[1]: The provided log type 'Audit' is duplicated.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "forwarding added",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+			},
+		},
+		{
+			name: "forwarding removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = nil
+			},
+		},
+		{
+			name: "shared key omitted",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile.SharedKey = ""
+				oc.Properties.LogForwardingProfile.LogTypes = nil
+			},
+		},
+		{
+			name: "shared key omitted for new workspace",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile.WorkspaceID = "22222222-2222-2222-2222-222222222222"
+				oc.Properties.LogForwardingProfile.SharedKey = ""
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.sharedKey: A shared key must be provided for the workspace.",
+		},
+		{
+			name: "shared key omitted when switching from syslog",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = syslog()
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.LogForwardingProfile = azureMonitor()
+				oc.Properties.LogForwardingProfile.SharedKey = ""
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.sharedKey: A shared key must be provided for the workspace.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateAdmissionWebhookProfiles(t *testing.T) {
+	validating := `apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: policy
+webhooks:
+- name: policy.example.com
+  clientConfig:
+    url: https://policy.example.com/validate
+  rules:
+  - operations: ["CREATE", "UPDATE"]
+    apiGroups: ["apps"]
+    apiVersions: ["v1"]
+    resources: ["deployments"]
+  namespaceSelector:
+    matchLabels:
+      policy: enforced
+  sideEffects: None
+  admissionReviewVersions: ["v1"]
+`
+
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AdmissionWebhookProfiles = []AdmissionWebhookProfile{
+					{Configuration: validating},
+					{Configuration: `{"apiVersion": "admissionregistration.k8s.io/v1", "kind": "MutatingWebhookConfiguration", "metadata": {"name": "policy"}, "webhooks": [{"name": "defaults.example.com", "clientConfig": {"service": {"namespace": "policy", "name": "defaults"}}, "rules": [{"operations": ["CREATE"], "apiGroups": [""], "apiVersions": ["v1"], "resources": ["pods"]}], "failurePolicy": "Ignore", "sideEffects": "None", "admissionReviewVersions": ["v1"]}]}`},
+				}
+			},
+		},
+		{
+			name: "too many configurations",
+			modify: func(oc *OpenShiftCluster) {
+				for i := 0; i <= maxAdmissionWebhookProfiles; i++ {
+					oc.Properties.AdmissionWebhookProfiles = append(oc.Properties.AdmissionWebhookProfiles, AdmissionWebhookProfile{
+						Configuration: strings.Replace(validating, "name: policy\n", fmt.Sprintf("name: policy-%d\n", i), 1),
+					})
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.admissionWebhookProfiles: At most 16 admission webhook configurations may be provided.",
+		},
+		{
+			name: "not an admission webhook configuration",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AdmissionWebhookProfiles = []AdmissionWebhookProfile{
+					{Configuration: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingAdmissionPolicy\n"},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.admissionWebhookProfiles[0].configuration: The provided admission webhook configuration is invalid: it must be a ValidatingWebhookConfiguration or a MutatingWebhookConfiguration of API version admissionregistration.k8s.io/v1.",
+		},
+		{
+			name: "webhook invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AdmissionWebhookProfiles = []AdmissionWebhookProfile{
+					{Configuration: strings.Replace(validating, "  sideEffects: None\n", "", 1)},
+				}
+			},
+			wantErr: `400: InvalidParameter: properties.admissionWebhookProfiles[0].configuration: The provided admission webhook configuration is invalid: webhook 'policy.example.com': sideEffects is required.`,
+		},
+		{
+			name: "configuration duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.AdmissionWebhookProfiles = []AdmissionWebhookProfile{
+					{Configuration: validating},
+					{Configuration: validating},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.admissionWebhookProfiles[1].configuration: The provided admission webhook configuration 'ValidatingWebhookConfiguration/policy' is duplicated.",
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+}
+
+func TestOpenShiftClusterStaticValidateSchedulerProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{
+					DefaultNodeSelector: "node-role.kubernetes.io/app=,region=east",
+				}
+			},
+		},
+		{
+			name: "empty",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{}
+			},
+			wantErr: "400: InvalidParameter: properties.schedulerProfile.defaultNodeSelector: The provided default node selector '' is invalid: it must be a comma separated list of key=value node labels.",
+		},
+		{
+			name: "set based selector",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{
+					DefaultNodeSelector: "region in (east)",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.schedulerProfile.defaultNodeSelector: The provided default node selector 'region in (east)' is invalid: it must be a comma separated list of key=value node labels.",
+		},
+		{
+			name: "control plane nodes",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{
+					DefaultNodeSelector: "node-role.kubernetes.io/master=",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.schedulerProfile.defaultNodeSelector: The provided default node selector 'node-role.kubernetes.io/master=' is invalid: workloads may not be pinned to the nodes labelled 'node-role.kubernetes.io/master'.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "default node selector changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{
+					DefaultNodeSelector: "region=east",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile.DefaultNodeSelector = "region=west"
+			},
+		},
+		{
+			name: "default node selector removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = &SchedulerProfile{
+					DefaultNodeSelector: "region=east",
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SchedulerProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateEgressFirewallProfile(t *testing.T) {
+	commonTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{CIDR: "10.0.0.0/22"},
+						{DNSName: "www.example.com"},
+					},
+				}
+			},
+		},
+		{
+			name: "no destinations",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{}
+			},
+		},
+		{
+			name: "too many destinations",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{}
+				for i := 0; i <= maxEgressDestinations; i++ {
+					oc.Properties.EgressFirewallProfile.AllowedDestinations = append(oc.Properties.EgressFirewallProfile.AllowedDestinations, EgressDestination{
+						CIDR: fmt.Sprintf("10.0.%d.0/24", i),
+					})
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations: At most 100 allowed destinations may be provided.",
+		},
+		{
+			name: "empty destination",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{{}},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations[0]: One of cidr and dnsName must be provided.",
+		},
+		{
+			name: "cidr and dns name",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{CIDR: "10.0.0.0/22", DNSName: "www.example.com"},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations[0]: Only one of cidr and dnsName may be provided.",
+		},
+		{
+			name: "cidr invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{CIDR: "10.0.0.1/22"},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations[0].cidr: The provided CIDR '10.0.0.1/22' is invalid: it must be an IPv4 range in canonical form, e.g. 203.0.113.0/24.",
+		},
+		{
+			name: "dns name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{DNSName: "*.example.com"},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations[0].dnsName: The provided DNS name '*.example.com' is invalid: it must be a lower case DNS name without wildcards, e.g. www.example.com.",
+		},
+		{
+			name: "destination duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{DNSName: "www.example.com"},
+						{DNSName: "www.example.com"},
+					},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.egressFirewallProfile.allowedDestinations[1]: The provided destination is duplicated.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "allowed destinations changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{CIDR: "10.0.0.0/22"},
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile.AllowedDestinations = append(oc.Properties.EgressFirewallProfile.AllowedDestinations, EgressDestination{
+					DNSName: "www.example.com",
+				})
+			},
+		},
+		{
+			name: "egress firewall removed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = &EgressFirewallProfile{
+					AllowedDestinations: []EgressDestination{
+						{CIDR: "10.0.0.0/22"},
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.EgressFirewallProfile = nil
+			},
+		},
+	}
+
+	runTests(t, testModeCreate, commonTests)
+	runTests(t, testModeUpdate, commonTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateSecurityProfile(t *testing.T) {
+	createTests := []*validateTest{
+		{
+			name: "valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					DefaultSeccompProfile: SeccompProfileRuntimeDefault,
+					SELinuxBooleans:       []string{"deny_ptrace", "deny_execmem"},
+				}
+			},
+		},
+		{
+			name: "platform behaviour",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					DefaultSeccompProfile: SeccompProfileUnconfined,
+				}
+			},
+		},
+		{
+			name: "default seccomp profile invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					DefaultSeccompProfile: "Localhost",
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.securityProfile.defaultSeccompProfile: The provided default seccomp profile 'Localhost' is invalid.",
+		},
+		{
+			name: "SELinux boolean unsupported",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					SELinuxBooleans: []string{"deny_ptrace", "container_manage_cgroup"},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.securityProfile.seLinuxBooleans[1]: The provided SELinux boolean 'container_manage_cgroup' is invalid: it must be deny_execmem or deny_ptrace.",
+		},
+		{
+			name: "SELinux boolean duplicated",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					SELinuxBooleans: []string{"deny_ptrace", "deny_ptrace"},
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.securityProfile.seLinuxBooleans[1]: The provided SELinux boolean 'deny_ptrace' is duplicated.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name: "security profile changed",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile = &SecurityProfile{
+					DefaultSeccompProfile: SeccompProfileRuntimeDefault,
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.SecurityProfile.DefaultSeccompProfile = SeccompProfileUnconfined
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.securityProfile.defaultSeccompProfile: Changing property 'properties.securityProfile.defaultSeccompProfile' is not allowed.",
+		},
+	}
+
+	runTests(t, testModeCreate, createTests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+func TestOpenShiftClusterStaticValidateIngressProfile(t *testing.T) {
+	_, certs, servingCertificate := testServingCertificate(t, nil)
+	_, _, rotatedServingCertificate := testServingCertificate(t, nil)
+	_, _, expiredServingCertificate := testServingCertificate(t, func(template *x509.Certificate) {
+		template.NotBefore = time.Now().AddDate(-1, 0, 0)
+		template.NotAfter = time.Now().AddDate(0, 0, -1)
+	})
+
+	otherKey, _, _ := testServingCertificate(t, nil)
+	mismatchedKey, err := utilpem.Encode(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mismatchedCerts, err := utilpem.Encode(certs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mismatchedServingCertificate := string(append(mismatchedKey, mismatchedCerts...))
+
+	addIngressProfile := func(modify func(*IngressProfile)) func(*OpenShiftCluster) {
+		return func(oc *OpenShiftCluster) {
+			p := IngressProfile{
+				Name:               "internal",
+				Visibility:         VisibilityPrivate,
+				Domain:             "internal.example.com",
+				ServingCertificate: servingCertificate,
+			}
+			if modify != nil {
+				modify(&p)
+			}
+			oc.Properties.IngressProfiles = append(oc.Properties.IngressProfiles, p)
+		}
+	}
+
+	tests := []*validateTest{
+		{
+			name: "valid",
+		},
+		{
+			name: "name invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].Name = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['invalid'].name: The provided ingress name 'invalid' is invalid.",
+		},
+		{
+			name: "visibility invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].Visibility = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].visibility: The provided visibility 'invalid' is invalid.",
+		},
+		{
+			name: "ip invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].IP = "invalid"
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].ip: The provided IP 'invalid' is invalid.",
+		},
+		{
+			name: "ipv6 ip invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].IP = "::"
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].ip: The provided IP '::' is invalid: must be IPv4.",
+		},
+		{
+			name: "empty ip valid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].IP = ""
+			},
+		},
+		{
+			name: "default domain invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].Domain = "example.com"
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].domain: The provided domain 'example.com' is invalid: the domain of the default ingress profile is derived from the cluster domain.",
+		},
+		{
+			name: "default serving certificate invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].ServingCertificate = servingCertificate
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].servingCertificate: The provided serving certificate is invalid: the serving certificate of the default ingress profile is managed by the service.",
+		},
+		{
+			name:   "additional valid",
+			modify: addIngressProfile(nil),
+		},
+		{
+			name: "too many ingress profiles",
+			modify: func(oc *OpenShiftCluster) {
+				for _, name := range []string{"internal", "internal2", "internal3"} {
+					oc.Properties.IngressProfiles = append(oc.Properties.IngressProfiles, IngressProfile{Name: name})
+				}
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles: There should be exactly one default ingress profile and at most 2 additional ingress profiles.",
+		},
+		{
+			name: "additional name invalid",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Name = "Internal"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['Internal'].name: The provided ingress name 'Internal' is invalid.",
+		},
+		{
+			name: "additional name duplicate",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Name = "default"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].name: The provided ingress name 'default' is invalid: it is used by more than one ingress profile.",
+		},
+		{
+			name: "additional visibility invalid",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Visibility = "invalid"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].visibility: The provided visibility 'invalid' is invalid.",
+		},
+		{
+			name: "additional public visibility with UserDefinedRouting invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.OutboundType = OutboundTypeUserDefinedRouting
+				oc.Properties.NetworkProfile.LoadBalancerProfile = nil
+				oc.Properties.APIServerProfile.Visibility = VisibilityPrivate
+				oc.Properties.IngressProfiles[0].Visibility = VisibilityPrivate
+				addIngressProfile(func(p *IngressProfile) {
+					p.Visibility = VisibilityPublic
+				})(oc)
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].visibility: The provided visibility 'Public' is invalid: cannot use a public ingress if outboundType is UserDefinedRouting.",
+		},
+		{
+			name: "additional domain missing",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Domain = ""
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].domain: The provided domain '' is invalid.",
+		},
+		{
+			name: "additional domain is the cluster domain",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Domain = "cluster.location.aroapp.io"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].domain: The provided domain 'cluster.location.aroapp.io' is invalid: it must differ from the cluster domain.",
+		},
+		{
+			name: "additional domain within the default ingress domain",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Domain = "internal.apps.cluster.location.aroapp.io"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].domain: The provided domain 'internal.apps.cluster.location.aroapp.io' is invalid: it conflicts with the ingress domain 'apps.cluster.location.aroapp.io'.",
+		},
+		{
+			name: "additional domain containing the default ingress domain",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Domain = "location.aroapp.io"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].domain: The provided domain 'location.aroapp.io' is invalid: it conflicts with the ingress domain 'apps.cluster.location.aroapp.io'.",
+		},
+		{
+			name: "additional domains conflicting",
+			modify: func(oc *OpenShiftCluster) {
+				addIngressProfile(nil)(oc)
+				addIngressProfile(func(p *IngressProfile) {
+					p.Name = "internal2"
+				})(oc)
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal2'].domain: The provided domain 'internal.example.com' is invalid: it conflicts with the ingress domain 'internal.example.com'.",
+		},
+		{
+			name: "additional serving certificate missing",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.ServingCertificate = ""
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: A serving certificate must be provided for an additional ingress profile.",
+		},
+		{
+			name: "additional serving certificate without private key",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.ServingCertificate = "invalid"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: The provided serving certificate is invalid: it must contain a private key and a certificate chain.",
+		},
+		{
+			name: "additional serving certificate key mismatch",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.ServingCertificate = mismatchedServingCertificate
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: The provided serving certificate is invalid: the private key does not match the certificate.",
+		},
+		{
+			name: "additional serving certificate expired",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.ServingCertificate = expiredServingCertificate
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: The provided serving certificate is invalid: the certificate is not currently valid.",
+		},
+		{
+			name: "additional serving certificate for another domain",
+			modify: addIngressProfile(func(p *IngressProfile) {
+				p.Domain = "other.example.com"
+			}),
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: The provided serving certificate is invalid: the certificate is not valid for '*.other.example.com'.",
+		},
+	}
+
+	updateTests := []*validateTest{
+		{
+			name:    "additional serving certificate rotated",
+			current: addIngressProfile(nil),
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[1].ServingCertificate = rotatedServingCertificate
+			},
+		},
+		{
+			name:    "additional serving certificate unchanged",
+			current: addIngressProfile(nil),
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[1].ServingCertificate = ""
+			},
+		},
+		{
+			name:    "additional serving certificate rotated invalid",
+			current: addIngressProfile(nil),
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[1].ServingCertificate = expiredServingCertificate
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['internal'].servingCertificate: The provided serving certificate is invalid: the certificate is not currently valid.",
+		},
+		{
+			name: "default serving certificate invalid",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.IngressProfiles[0].ServingCertificate = servingCertificate
+			},
+			wantErr: "400: InvalidParameter: properties.ingressProfiles['default'].servingCertificate: The provided serving certificate is invalid: the serving certificate of the default ingress profile is managed by the service.",
+		},
+	}
+
+	// we don't validate the other fields on update as they are immutable and
+	// will be validated with "mutable" flag
+	runTests(t, testModeCreate, tests)
+	runTests(t, testModeUpdate, updateTests)
+}
+
+// testServingCertificate returns a PEM encoded private key and self-signed
+// certificate for *.internal.example.com, as used by an additional ingress
+// profile
+func testServingCertificate(t *testing.T, tweakTemplate func(*x509.Certificate)) (*rsa.PrivateKey, []*x509.Certificate, string) {
+	key, certs, err := utiltls.GenerateTestKeyAndCertificate("*.internal.example.com", nil, nil, false, false, tweakTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := utilpem.Encode(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb, err := utilpem.Encode(certs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, certs, string(append(b, cb...))
+}
+
 func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 	tests := []*validateTest{
 		{
@@ -1035,6 +3355,30 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Location = strings.ToUpper(oc.Location) },
 			wantErr: "400: PropertyChangeNotAllowed: location: Changing property 'location' is not allowed.",
 		},
+		{
+			name: "valid maintenanceWindow change",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow = &MaintenanceWindow{
+					Days:          []Weekday{WeekdaySaturday},
+					StartTime:     "22:00",
+					DurationHours: 6,
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.MaintenanceWindow.Days = []Weekday{WeekdaySunday}
+				oc.Properties.MaintenanceWindow.TimeZone = "Europe/Berlin"
+			},
+		},
+		{
+			name: "valid projectTemplateProfile change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ProjectTemplateProfile = &ProjectTemplateProfile{
+					ResourceQuota: map[string]string{
+						"pods": "20",
+					},
+				}
+			},
+		},
 		{
 			name:   "valid tags change",
 			modify: func(oc *OpenShiftCluster) { oc.Tags = Tags{"new": "value"} },
@@ -1071,6 +3415,60 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			},
 			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.resourceGroupId: Changing property 'properties.clusterProfile.resourceGroupId' is not allowed.",
 		},
+		{
+			name: "fips validated modules change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.FipsValidatedModules = FipsValidatedModulesEnabled
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.fipsValidatedModules: Changing property 'properties.clusterProfile.fipsValidatedModules' is not allowed.",
+		},
+		{
+			name:    "dns record ttl change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.ClusterProfile.DNSRecordTTL = 30 },
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.dnsRecordTtl: Changing property 'properties.clusterProfile.dnsRecordTtl' is not allowed.",
+		},
+		{
+			name:    "resource name template change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.ClusterProfile.ResourceNameTemplate = "prod-{name}" },
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.resourceNameTemplate: Changing property 'properties.clusterProfile.resourceNameTemplate' is not allowed.",
+		},
+		{
+			name: "existing resource group change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.ExistingResourceGroup = ExistingResourceGroupEnabled
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.existingResourceGroup: Changing property 'properties.clusterProfile.existingResourceGroup' is not allowed.",
+		},
+		{
+			name:    "time zone change",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.ClusterProfile.TimeZone = "Europe/London" },
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.timeZone: Changing property 'properties.clusterProfile.timeZone' is not allowed.",
+		},
+		{
+			name: "dns zone change",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.ClusterProfile.DNSZoneID = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/dns/providers/Microsoft.Network/dnsZones/example.com"
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.clusterProfile.dnsZoneId: Changing property 'properties.clusterProfile.dnsZoneId' is not allowed.",
+		},
+		{
+			name: "customer load balancer change",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.APIServerProfile.Visibility = VisibilityPrivate
+				oc.Properties.IngressProfiles[0].Visibility = VisibilityPrivate
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile = &CustomerLoadBalancerProfile{
+					ID:                               fmt.Sprintf("/subscriptions/%s/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor", subscriptionID),
+					APIServerFrontendIPConfiguration: "api",
+					IngressFrontendIPConfiguration:   "ingress",
+				}
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.customerLoadBalancerProfile: Changing property 'properties.networkProfile.customerLoadBalancerProfile' is not allowed.",
+		},
 		{
 			name: "apiServer private change",
 			modify: func(oc *OpenShiftCluster) {
@@ -1120,6 +3518,20 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.NetworkProfile.ServiceCIDR = "0.0.0.0/0" },
 			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.serviceCidr: Changing property 'properties.networkProfile.serviceCidr' is not allowed.",
 		},
+		{
+			name: "softwareDefinedNetwork change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.SoftwareDefinedNetwork = SoftwareDefinedNetworkOpenShiftSDN
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.softwareDefinedNetwork: Changing property 'properties.networkProfile.softwareDefinedNetwork' is not allowed.",
+		},
+		{
+			name: "maxPods change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.MaxPods = 500
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.maxPods: Changing property 'properties.networkProfile.maxPods' is not allowed.",
+		},
 		{
 			name: "outboundType change",
 			modify: func(oc *OpenShiftCluster) {
@@ -1149,6 +3561,20 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].DiskSizeGB++ },
 			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].diskSizeGB: Changing property 'properties.workerProfiles['worker'].diskSizeGB' is not allowed.",
 		},
+		{
+			name: "worker diskStorageAccountType change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].DiskStorageAccountType = DiskStorageAccountTypeStandardSSDLRS
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].diskStorageAccountType: Changing property 'properties.workerProfiles['worker'].diskStorageAccountType' is not allowed.",
+		},
+		{
+			name: "worker acceleratedNetworking change",
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.WorkerProfiles[0].AcceleratedNetworking = AcceleratedNetworkingEnabled
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].acceleratedNetworking: Changing property 'properties.workerProfiles['worker'].acceleratedNetworking' is not allowed.",
+		},
 		{
 			name: "worker subnetId change",
 			modify: func(oc *OpenShiftCluster) {
@@ -1157,9 +3583,18 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].subnetId: Changing property 'properties.workerProfiles['worker'].subnetId' is not allowed.",
 		},
 		{
-			name:    "workerProfiles count change",
-			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Count++ },
-			wantErr: "400: PropertyChangeNotAllowed: properties.workerProfiles['worker'].count: Changing property 'properties.workerProfiles['worker'].count' is not allowed.",
+			name:   "workerProfiles count change",
+			modify: func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Count++ },
+		},
+		{
+			name:    "workerProfiles count change invalid",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Count = 51 },
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].count: The provided worker count '51' is invalid.",
+		},
+		{
+			name:    "workerProfiles count change negative",
+			modify:  func(oc *OpenShiftCluster) { oc.Properties.WorkerProfiles[0].Count = -1 },
+			wantErr: "400: InvalidParameter: properties.workerProfiles['worker'].count: The provided worker count '-1' is invalid.",
 		},
 		{
 			name: "number of workerProfiles changes",
@@ -1221,6 +3656,20 @@ func TestOpenShiftClusterStaticValidateDelta(t *testing.T) {
 			},
 			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.loadBalancerProfile.effectiveOutboundIps[0].id: Changing property 'properties.networkProfile.loadBalancerProfile.effectiveOutboundIps[0].id' is not allowed.",
 		},
+		{
+			name: "update LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength",
+			current: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+					ManagedOutboundIPPrefix: &ManagedOutboundIPPrefix{
+						PrefixLength: 30,
+					},
+				}
+			},
+			modify: func(oc *OpenShiftCluster) {
+				oc.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix.PrefixLength = 29
+			},
+			wantErr: "400: PropertyChangeNotAllowed: properties.networkProfile.loadBalancerProfile.managedOutboundIpPrefix.prefixLength: Changing property 'properties.networkProfile.loadBalancerProfile.managedOutboundIpPrefix.prefixLength' is not allowed.",
+		},
 	}
 
 	runTests(t, testModeUpdate, tests)
@@ -7,4 +7,16 @@ package v20230701preview
 type OpenShiftClusterAdminKubeconfig struct {
 	// The base64-encoded kubeconfig file.
 	Kubeconfig []byte `json:"kubeconfig,omitempty"`
+
+	// The URL to access the cluster API server.  Only returned in the
+	// Structured format.
+	APIServerURL string `json:"apiServerUrl,omitempty"`
+
+	// The base64-encoded PEM client certificate.  Only returned in the
+	// Structured format.
+	ClientCertificate []byte `json:"clientCertificate,omitempty"`
+
+	// The base64-encoded PEM client key.  Only returned in the Structured
+	// format.
+	ClientKey []byte `json:"clientKey,omitempty"`
 }
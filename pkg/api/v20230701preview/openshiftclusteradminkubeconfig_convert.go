@@ -19,3 +19,18 @@ func (openShiftClusterAdminKubeconfigConverter) ToExternal(oc *api.OpenShiftClus
 		Kubeconfig: oc.Properties.UserAdminKubeconfig,
 	}
 }
+
+type openShiftClusterAdminCredentialsConverter struct{}
+
+// openShiftClusterAdminCredentialsConverter returns a new external
+// representation of the internal object.  ToExternal does not modify its
+// argument; there is no pointer aliasing between the passed and returned
+// objects.
+func (openShiftClusterAdminCredentialsConverter) ToExternal(creds *api.OpenShiftClusterAdminCredentials) interface{} {
+	return &OpenShiftClusterAdminKubeconfig{
+		Kubeconfig:        append([]byte(nil), creds.Kubeconfig...),
+		APIServerURL:      creds.APIServerURL,
+		ClientCertificate: append([]byte(nil), creds.ClientCertificate...),
+		ClientKey:         append([]byte(nil), creds.ClientKey...),
+	}
+}
@@ -0,0 +1,13 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OpenShiftClusterTemplate represents the creatable configuration of an
+// OpenShift cluster, without its secrets and the identifiers of the resources
+// it uses.  It can be passed when creating a cluster to pre-fill the
+// properties which the request does not set.
+type OpenShiftClusterTemplate struct {
+	// The cluster properties.
+	Properties OpenShiftClusterProperties `json:"properties,omitempty"`
+}
@@ -0,0 +1,267 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type openShiftClusterTemplateConverter struct{}
+
+// ToExternal returns a new external template of the internal object.
+// ToExternal does not modify its argument; there is no pointer aliasing
+// between the passed and returned objects.
+func (openShiftClusterTemplateConverter) ToExternal(oc *api.OpenShiftCluster) interface{} {
+	// the worker profile statuses are per machine set; the template takes
+	// the worker profiles as requested
+	c := &api.OpenShiftCluster{
+		Properties: oc.Properties,
+	}
+	c.Properties.WorkerProfilesStatus = nil
+
+	return &OpenShiftClusterTemplate{
+		Properties: templateProperties(&openShiftClusterConverter{}.ToExternal(c).(*OpenShiftCluster).Properties),
+	}
+}
+
+// Prefill validates a template against the API schema and sets the properties
+// of the external cluster ext which the template sets.  Properties which the
+// request sets are expected to be unmarshalled over ext afterwards.
+func (openShiftClusterTemplateConverter) Prefill(ext interface{}, b []byte) error {
+	var template *OpenShiftClusterTemplate
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	err := d.Decode(&template)
+	if err != nil || template == nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.template", "The provided template is invalid: it does not match the schema of api version '%s'.", APIVersion)
+	}
+
+	// reject rather than drop what a template may not set, so that a
+	// customer who adds e.g. a subnet to the template is told to set it in
+	// the request instead
+	if !reflect.DeepEqual(templateProperties(&template.Properties), template.Properties) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.template", "The provided template is invalid: it sets secrets, resource identifiers or read-only properties. Set them in the request instead.")
+	}
+
+	b, err = json.Marshal(template.Properties)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, &ext.(*OpenShiftCluster).Properties)
+}
+
+// templateProperties returns the properties of p which are part of a
+// template.  It is an allowlist: the secrets, the identifiers of the cluster
+// and of the resources it uses, the read-only properties and any property
+// added to the API later are left out unless they are copied here.  The
+// returned properties may share slices and maps with p, but neither is
+// modified.
+func templateProperties(p *OpenShiftClusterProperties) OpenShiftClusterProperties {
+	out := OpenShiftClusterProperties{
+		ClusterProfile: ClusterProfile{
+			Version:               p.ClusterProfile.Version,
+			FipsValidatedModules:  p.ClusterProfile.FipsValidatedModules,
+			DNSRecordTTL:          p.ClusterProfile.DNSRecordTTL,
+			ResourceNameTemplate:  p.ClusterProfile.ResourceNameTemplate,
+			ExistingResourceGroup: p.ClusterProfile.ExistingResourceGroup,
+			TimeZone:              p.ClusterProfile.TimeZone,
+		},
+		NetworkProfile: NetworkProfile{
+			PodCIDR:                p.NetworkProfile.PodCIDR,
+			ServiceCIDR:            p.NetworkProfile.ServiceCIDR,
+			SoftwareDefinedNetwork: p.NetworkProfile.SoftwareDefinedNetwork,
+			OutboundType:           p.NetworkProfile.OutboundType,
+			MaxPods:                p.NetworkProfile.MaxPods,
+		},
+		MasterProfile: MasterProfile{
+			VMSize:           p.MasterProfile.VMSize,
+			EncryptionAtHost: p.MasterProfile.EncryptionAtHost,
+		},
+		APIServerProfile: APIServerProfile{
+			Visibility: p.APIServerProfile.Visibility,
+		},
+	}
+
+	if lbp := p.NetworkProfile.LoadBalancerProfile; lbp != nil {
+		out.NetworkProfile.LoadBalancerProfile = &LoadBalancerProfile{
+			AllocatedOutboundPorts: lbp.AllocatedOutboundPorts,
+		}
+		if lbp.ManagedOutboundIPs != nil {
+			out.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPs = &ManagedOutboundIPs{
+				Count: lbp.ManagedOutboundIPs.Count,
+			}
+		}
+		if lbp.ManagedOutboundIPPrefix != nil {
+			out.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix = &ManagedOutboundIPPrefix{
+				PrefixLength: lbp.ManagedOutboundIPPrefix.PrefixLength,
+			}
+		}
+	}
+
+	if p.WorkerProfiles != nil {
+		out.WorkerProfiles = make([]WorkerProfile, 0, len(p.WorkerProfiles))
+		for _, wp := range p.WorkerProfiles {
+			twp := WorkerProfile{
+				Name:                   wp.Name,
+				VMSize:                 wp.VMSize,
+				DiskSizeGB:             wp.DiskSizeGB,
+				Count:                  wp.Count,
+				EncryptionAtHost:       wp.EncryptionAtHost,
+				DiskStorageAccountType: wp.DiskStorageAccountType,
+				AcceleratedNetworking:  wp.AcceleratedNetworking,
+			}
+			// without an ID, a proximity placement group is created for the
+			// new cluster
+			if wp.ProximityPlacementGroupProfile != nil {
+				twp.ProximityPlacementGroupProfile = &ProximityPlacementGroupProfile{}
+			}
+			out.WorkerProfiles = append(out.WorkerProfiles, twp)
+		}
+	}
+
+	if p.IngressProfiles != nil {
+		out.IngressProfiles = make([]IngressProfile, 0, len(p.IngressProfiles))
+		for _, ip := range p.IngressProfiles {
+			out.IngressProfiles = append(out.IngressProfiles, IngressProfile{
+				Name:       ip.Name,
+				Visibility: ip.Visibility,
+			})
+		}
+	}
+
+	if mw := p.MaintenanceWindow; mw != nil {
+		out.MaintenanceWindow = &MaintenanceWindow{
+			Days:          mw.Days,
+			StartTime:     mw.StartTime,
+			DurationHours: mw.DurationHours,
+			TimeZone:      mw.TimeZone,
+		}
+	}
+
+	if ptp := p.ProjectTemplateProfile; ptp != nil {
+		out.ProjectTemplateProfile = &ProjectTemplateProfile{
+			ResourceQuota:   ptp.ResourceQuota,
+			DefaultLimits:   ptp.DefaultLimits,
+			DefaultRequests: ptp.DefaultRequests,
+		}
+	}
+
+	if ipp := p.IdentityProviderProfile; ipp != nil {
+		out.IdentityProviderProfile = &IdentityProviderProfile{
+			Name:     ipp.Name,
+			Issuer:   ipp.Issuer,
+			ClientID: ipp.ClientID,
+		}
+	}
+
+	if p.RegistryMirrorProfiles != nil {
+		out.RegistryMirrorProfiles = make([]RegistryMirrorProfile, 0, len(p.RegistryMirrorProfiles))
+		for _, rmp := range p.RegistryMirrorProfiles {
+			out.RegistryMirrorProfiles = append(out.RegistryMirrorProfiles, RegistryMirrorProfile{
+				Source: rmp.Source,
+				Mirror: rmp.Mirror,
+			})
+		}
+	}
+
+	if dscp := p.DefaultStorageClassProfile; dscp != nil {
+		out.DefaultStorageClassProfile = &DefaultStorageClassProfile{
+			Name:       dscp.Name,
+			Parameters: dscp.Parameters,
+		}
+	}
+
+	if afcp := p.AzureFileCSIProfile; afcp != nil {
+		out.AzureFileCSIProfile = &AzureFileCSIProfile{
+			StorageClassName: afcp.StorageClassName,
+			SKUName:          afcp.SKUName,
+		}
+	}
+
+	if mp := p.MonitoringProfile; mp != nil {
+		out.MonitoringProfile = &MonitoringProfile{
+			Retention:        mp.Retention,
+			StorageSize:      mp.StorageSize,
+			StorageClassName: mp.StorageClassName,
+		}
+	}
+
+	if up := p.UpgradeProfile; up != nil {
+		out.UpgradeProfile = &UpgradeProfile{
+			MaxUnavailable: up.MaxUnavailable,
+		}
+	}
+
+	if nep := p.NodeEvictionProfile; nep != nil {
+		out.NodeEvictionProfile = &NodeEvictionProfile{
+			Hard:            templateEvictionThresholds(nep.Hard),
+			Soft:            templateEvictionThresholds(nep.Soft),
+			SoftGracePeriod: nep.SoftGracePeriod,
+		}
+	}
+
+	if sp := p.SecurityProfile; sp != nil {
+		out.SecurityProfile = &SecurityProfile{
+			DefaultSeccompProfile: sp.DefaultSeccompProfile,
+			SELinuxBooleans:       sp.SELinuxBooleans,
+		}
+	}
+
+	if lfp := p.LogForwardingProfile; lfp != nil {
+		out.LogForwardingProfile = &LogForwardingProfile{
+			Type:     lfp.Type,
+			URL:      lfp.URL,
+			LogTypes: lfp.LogTypes,
+		}
+	}
+
+	if p.AdmissionWebhookProfiles != nil {
+		out.AdmissionWebhookProfiles = make([]AdmissionWebhookProfile, 0, len(p.AdmissionWebhookProfiles))
+		for _, awp := range p.AdmissionWebhookProfiles {
+			out.AdmissionWebhookProfiles = append(out.AdmissionWebhookProfiles, AdmissionWebhookProfile{
+				Configuration: awp.Configuration,
+			})
+		}
+	}
+
+	if sp := p.SchedulerProfile; sp != nil {
+		out.SchedulerProfile = &SchedulerProfile{
+			DefaultNodeSelector: sp.DefaultNodeSelector,
+		}
+	}
+
+	if efp := p.EgressFirewallProfile; efp != nil {
+		out.EgressFirewallProfile = &EgressFirewallProfile{}
+		if efp.AllowedDestinations != nil {
+			out.EgressFirewallProfile.AllowedDestinations = make([]EgressDestination, 0, len(efp.AllowedDestinations))
+			for _, d := range efp.AllowedDestinations {
+				out.EgressFirewallProfile.AllowedDestinations = append(out.EgressFirewallProfile.AllowedDestinations, EgressDestination{
+					CIDR:    d.CIDR,
+					DNSName: d.DNSName,
+				})
+			}
+		}
+	}
+
+	return out
+}
+
+func templateEvictionThresholds(t *EvictionThresholds) *EvictionThresholds {
+	if t == nil {
+		return nil
+	}
+
+	return &EvictionThresholds{
+		MemoryAvailable:  t.MemoryAvailable,
+		NodeFSAvailable:  t.NodeFSAvailable,
+		ImageFSAvailable: t.ImageFSAvailable,
+	}
+}
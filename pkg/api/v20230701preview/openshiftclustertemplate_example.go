@@ -0,0 +1,14 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ExampleOpenShiftClusterTemplateResponse returns an example
+// OpenShiftClusterTemplate object that the RP might return to an end-user
+func ExampleOpenShiftClusterTemplateResponse() interface{} {
+	return (&openShiftClusterTemplateConverter{}).ToExternal(api.ExampleOpenShiftClusterDocument().OpenShiftCluster)
+}
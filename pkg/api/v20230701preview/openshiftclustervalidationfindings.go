@@ -0,0 +1,48 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OpenShiftClusterValidationFindings represents the findings of validating a
+// proposed OpenShift cluster.
+type OpenShiftClusterValidationFindings struct {
+	// The status of the validation.  Failed if any finding has severity Error.
+	Status ValidationStatus `json:"status,omitempty"`
+
+	// The findings of the validation.
+	Findings []ValidationFinding `json:"findings"`
+}
+
+// ValidationStatus represents the status of a validation.
+type ValidationStatus string
+
+// ValidationStatus constants.
+const (
+	ValidationStatusSucceeded ValidationStatus = "Succeeded"
+	ValidationStatusFailed    ValidationStatus = "Failed"
+)
+
+// ValidationFinding represents a failed validation.
+type ValidationFinding struct {
+	// The severity of the finding.  Error means that creating the cluster
+	// would fail.  Warning means that the validation could not be completed.
+	Severity ValidationSeverity `json:"severity,omitempty"`
+
+	// The error code of the finding.
+	Code string `json:"code,omitempty"`
+
+	// The property the finding refers to.
+	Target string `json:"target,omitempty"`
+
+	// The message of the finding.
+	Message string `json:"message,omitempty"`
+}
+
+// ValidationSeverity represents the severity of a validation finding.
+type ValidationSeverity string
+
+// ValidationSeverity constants.
+const (
+	ValidationSeverityError   ValidationSeverity = "Error"
+	ValidationSeverityWarning ValidationSeverity = "Warning"
+)
@@ -0,0 +1,32 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type openShiftClusterValidationFindingsConverter struct{}
+
+// openShiftClusterValidationFindingsConverter returns a new external
+// representation of the internal object.  ToExternal does not modify its
+// argument; there is no pointer aliasing between the passed and returned
+// objects.
+func (openShiftClusterValidationFindingsConverter) ToExternal(findings *api.ValidationFindings) interface{} {
+	out := &OpenShiftClusterValidationFindings{
+		Status:   ValidationStatus(findings.Status),
+		Findings: make([]ValidationFinding, 0, len(findings.Findings)),
+	}
+
+	for _, f := range findings.Findings {
+		out.Findings = append(out.Findings, ValidationFinding{
+			Severity: ValidationSeverity(f.Severity),
+			Code:     f.Code,
+			Target:   f.Target,
+			Message:  f.Message,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,26 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ExampleOpenShiftClusterValidationFindingsResponse returns an example
+// OpenShiftClusterValidationFindings object that the RP might return to an
+// end-user
+func ExampleOpenShiftClusterValidationFindingsResponse() interface{} {
+	return &OpenShiftClusterValidationFindings{
+		Status: ValidationStatusFailed,
+		Findings: []ValidationFinding{
+			{
+				Severity: ValidationSeverityError,
+				Code:     "QuotaExceeded",
+				Message:  "Resource quota of cores exceeded. Maximum allowed: 10, Current in use: 0, Additional requested: 36.",
+			},
+			{
+				Severity: ValidationSeverityError,
+				Code:     "InvalidServicePrincipalPermissions",
+				Target:   "properties.masterProfile.subnetId",
+				Message:  "The cluster service principal does not have Network Contributor permission on vnet '/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/virtualNetworks/vnet'.",
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// OpenShiftVersionMatrix represents the OpenShift versions supported in a
+// location and the versions clusters at each of them can be upgraded to.
+type OpenShiftVersionMatrix struct {
+	// The supported versions, sorted by version.
+	Versions []OpenShiftVersionMatrixVersion `json:"value"`
+}
+
+// OpenShiftVersionMatrixVersion represents an OpenShift version supported in
+// a location.
+type OpenShiftVersionMatrixVersion struct {
+	// The version.
+	Version string `json:"version,omitempty"`
+
+	// The status of the version.  Installable means that clusters can be
+	// installed at the version.  UpgradeOnly means that existing clusters can
+	// be upgraded to the version but not installed at it.  Deprecated means
+	// that clusters at the version should be upgraded off it.
+	Status OpenShiftVersionStatus `json:"status,omitempty"`
+
+	// The versions clusters at the version can be upgraded to.
+	UpgradeVersions []string `json:"upgradeVersions"`
+}
+
+// OpenShiftVersionStatus represents the status of an OpenShift version.
+type OpenShiftVersionStatus string
+
+// OpenShiftVersionStatus constants.
+const (
+	OpenShiftVersionStatusInstallable OpenShiftVersionStatus = "Installable"
+	OpenShiftVersionStatusUpgradeOnly OpenShiftVersionStatus = "UpgradeOnly"
+	OpenShiftVersionStatusDeprecated  OpenShiftVersionStatus = "Deprecated"
+)
@@ -0,0 +1,32 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+type openShiftVersionMatrixConverter struct{}
+
+// openShiftVersionMatrixConverter returns a new external representation of
+// the internal object.  ToExternal does not modify its argument; there is no
+// pointer aliasing between the passed and returned objects.
+func (openShiftVersionMatrixConverter) ToExternal(matrix *api.OpenShiftVersionMatrix) interface{} {
+	out := &OpenShiftVersionMatrix{
+		Versions: make([]OpenShiftVersionMatrixVersion, 0, len(matrix.Versions)),
+	}
+
+	for _, v := range matrix.Versions {
+		upgradeVersions := make([]string, len(v.UpgradeVersions))
+		copy(upgradeVersions, v.UpgradeVersions)
+
+		out.Versions = append(out.Versions, OpenShiftVersionMatrixVersion{
+			Version:         v.Version,
+			Status:          OpenShiftVersionStatus(v.Status),
+			UpgradeVersions: upgradeVersions,
+		})
+	}
+
+	return out
+}
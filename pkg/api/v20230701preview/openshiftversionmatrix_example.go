@@ -0,0 +1,28 @@
+package v20230701preview
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ExampleOpenShiftVersionMatrixResponse returns an example
+// OpenShiftVersionMatrix object that the RP might return to an end-user
+func ExampleOpenShiftVersionMatrixResponse() interface{} {
+	return &OpenShiftVersionMatrix{
+		Versions: []OpenShiftVersionMatrixVersion{
+			{
+				Version:         "4.10.40",
+				Status:          OpenShiftVersionStatusDeprecated,
+				UpgradeVersions: []string{"4.11.44"},
+			},
+			{
+				Version:         "4.11.44",
+				Status:          OpenShiftVersionStatusUpgradeOnly,
+				UpgradeVersions: []string{"4.12.25"},
+			},
+			{
+				Version:         "4.12.25",
+				Status:          OpenShiftVersionStatusInstallable,
+				UpgradeVersions: []string{},
+			},
+		},
+	}
+}
@@ -17,11 +17,15 @@ const (
 
 func init() {
 	api.APIs[APIVersion] = &api.Version{
-		OpenShiftClusterConverter:                openShiftClusterConverter{},
-		OpenShiftClusterStaticValidator:          openShiftClusterStaticValidator{},
-		OpenShiftClusterCredentialsConverter:     openShiftClusterCredentialsConverter{},
-		OpenShiftClusterAdminKubeconfigConverter: openShiftClusterAdminKubeconfigConverter{},
-		OpenShiftVersionConverter:                openShiftVersionConverter{},
+		OpenShiftClusterConverter:                   openShiftClusterConverter{},
+		OpenShiftClusterStaticValidator:             openShiftClusterStaticValidator{},
+		OpenShiftClusterCredentialsConverter:        openShiftClusterCredentialsConverter{},
+		OpenShiftClusterAdminKubeconfigConverter:    openShiftClusterAdminKubeconfigConverter{},
+		OpenShiftClusterAdminCredentialsConverter:   openShiftClusterAdminCredentialsConverter{},
+		OpenShiftClusterValidationFindingsConverter: openShiftClusterValidationFindingsConverter{},
+		OpenShiftClusterTemplateConverter:           openShiftClusterTemplateConverter{},
+		OpenShiftVersionConverter:                   openShiftVersionConverter{},
+		OpenShiftVersionMatrixConverter:             openShiftVersionMatrixConverter{},
 		OperationList: api.OperationList{
 			Operations: []api.Operation{
 				api.OperationResultsRead,
@@ -32,7 +36,10 @@ func init() {
 				api.OperationOpenShiftClusterDelete,
 				api.OperationOpenShiftClusterListCredentials,
 				api.OperationOpenShiftClusterListAdminCredentials,
+				api.OperationOpenShiftClusterValidate,
+				api.OperationOpenShiftClusterExportTemplate,
 				api.OperationListInstallVersions,
+				api.OperationOpenShiftVersionMatrixRead,
 				api.OperationSyncSetsRead,
 				api.OperationSyncSetsWrite,
 				api.OperationSyncSetsDelete,
@@ -0,0 +1,331 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// coreNamespaces are namespaces of the platform whose objects an admission
+// webhook must not block: if the webhook is unavailable while they are
+// updated, e.g. during an upgrade, the cluster cannot recover
+var coreNamespaces = []string{
+	"default",
+	"kube-system",
+	"openshift-apiserver",
+	"openshift-authentication",
+	"openshift-azure-operator",
+	"openshift-dns",
+	"openshift-etcd",
+	"openshift-ingress",
+	"openshift-kube-apiserver",
+	"openshift-machine-api",
+	"openshift-machine-config-operator",
+	"openshift-monitoring",
+	"openshift-network-operator",
+	"openshift-ovn-kubernetes",
+	"openshift-sdn",
+}
+
+var admissionWebhookDecoder kruntime.Decoder
+
+func init() {
+	scheme := kruntime.NewScheme()
+	utilruntime.Must(admissionregistrationv1.AddToScheme(scheme))
+	admissionWebhookDecoder = serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDeserializer()
+}
+
+// admissionWebhook holds the fields which ValidatingWebhooks and
+// MutatingWebhooks have in common
+type admissionWebhook struct {
+	name                    string
+	clientConfig            admissionregistrationv1.WebhookClientConfig
+	rules                   []admissionregistrationv1.RuleWithOperations
+	failurePolicy           *admissionregistrationv1.FailurePolicyType
+	matchPolicy             *admissionregistrationv1.MatchPolicyType
+	namespaceSelector       *metav1.LabelSelector
+	objectSelector          *metav1.LabelSelector
+	sideEffects             *admissionregistrationv1.SideEffectClass
+	timeoutSeconds          *int32
+	admissionReviewVersions []string
+	reinvocationPolicy      *admissionregistrationv1.ReinvocationPolicyType
+}
+
+// DecodeAdmissionWebhookConfiguration decodes a
+// ValidatingWebhookConfiguration or a MutatingWebhookConfiguration of API
+// version admissionregistration.k8s.io/v1 from JSON or YAML.  Unknown fields
+// are rejected.
+func DecodeAdmissionWebhookConfiguration(configuration string) (kruntime.Object, error) {
+	var tm metav1.TypeMeta
+	err := yaml.Unmarshal([]byte(configuration), &tm)
+	if err != nil {
+		return nil, fmt.Errorf("it is not valid JSON or YAML")
+	}
+
+	if tm.APIVersion != admissionregistrationv1.SchemeGroupVersion.String() ||
+		(tm.Kind != "ValidatingWebhookConfiguration" && tm.Kind != "MutatingWebhookConfiguration") {
+		return nil, fmt.Errorf("it must be a ValidatingWebhookConfiguration or a MutatingWebhookConfiguration of API version %s", admissionregistrationv1.SchemeGroupVersion)
+	}
+
+	o, _, err := admissionWebhookDecoder.Decode([]byte(configuration), nil, nil)
+	if err != nil {
+		// decoding errors quote the offending fields
+		return nil, fmt.Errorf("%s", strings.ReplaceAll(err.Error(), `"`, `'`))
+	}
+
+	return o, nil
+}
+
+// ValidateAdmissionWebhookConfiguration returns an error if the decoded
+// admission webhook configuration would be rejected by the API server
+func ValidateAdmissionWebhookConfiguration(o kruntime.Object) error {
+	name, webhooks := admissionWebhooks(o)
+
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("name '%s' is invalid: %s", name, strings.Join(errs, ", "))
+	}
+
+	if len(webhooks) == 0 {
+		return fmt.Errorf("it has no webhooks")
+	}
+
+	seen := map[string]struct{}{}
+	for _, w := range webhooks {
+		if _, found := seen[w.name]; found {
+			return fmt.Errorf("webhook name '%s' is duplicated", w.name)
+		}
+		seen[w.name] = struct{}{}
+
+		err := validateAdmissionWebhook(w)
+		if err != nil {
+			return fmt.Errorf("webhook '%s': %w", w.name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateAdmissionWebhook(w admissionWebhook) error {
+	if errs := validation.IsFullyQualifiedName(field.NewPath("name"), w.name); len(errs) > 0 {
+		return fmt.Errorf("the name must be fully qualified, e.g. policy.example.com")
+	}
+
+	err := validateAdmissionWebhookClientConfig(w.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	if len(w.rules) == 0 {
+		return fmt.Errorf("it has no rules")
+	}
+	for _, r := range w.rules {
+		err = validateAdmissionWebhookRule(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.failurePolicy != nil && *w.failurePolicy != admissionregistrationv1.Ignore && *w.failurePolicy != admissionregistrationv1.Fail {
+		return fmt.Errorf("failurePolicy '%s' is invalid", *w.failurePolicy)
+	}
+
+	if w.matchPolicy != nil && *w.matchPolicy != admissionregistrationv1.Exact && *w.matchPolicy != admissionregistrationv1.Equivalent {
+		return fmt.Errorf("matchPolicy '%s' is invalid", *w.matchPolicy)
+	}
+
+	for _, s := range []*metav1.LabelSelector{w.namespaceSelector, w.objectSelector} {
+		_, err = metav1.LabelSelectorAsSelector(s)
+		if err != nil {
+			return fmt.Errorf("selector is invalid: %s", strings.ReplaceAll(err.Error(), `"`, `'`))
+		}
+	}
+
+	if w.sideEffects == nil {
+		return fmt.Errorf("sideEffects is required")
+	}
+	if *w.sideEffects != admissionregistrationv1.SideEffectClassNone && *w.sideEffects != admissionregistrationv1.SideEffectClassNoneOnDryRun {
+		return fmt.Errorf("sideEffects '%s' is invalid", *w.sideEffects)
+	}
+
+	if w.timeoutSeconds != nil && (*w.timeoutSeconds < 1 || *w.timeoutSeconds > 30) {
+		return fmt.Errorf("timeoutSeconds %d is invalid: it must be between 1 and 30", *w.timeoutSeconds)
+	}
+
+	supported := false
+	for _, v := range w.admissionReviewVersions {
+		if v == "v1" || v == "v1beta1" {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("admissionReviewVersions must include v1 or v1beta1")
+	}
+
+	if w.reinvocationPolicy != nil && *w.reinvocationPolicy != admissionregistrationv1.NeverReinvocationPolicy && *w.reinvocationPolicy != admissionregistrationv1.IfNeededReinvocationPolicy {
+		return fmt.Errorf("reinvocationPolicy '%s' is invalid", *w.reinvocationPolicy)
+	}
+
+	return nil
+}
+
+func validateAdmissionWebhookClientConfig(c admissionregistrationv1.WebhookClientConfig) error {
+	switch {
+	case c.URL != nil && c.Service != nil:
+		return fmt.Errorf("clientConfig must have a url or a service, not both")
+
+	case c.URL != nil:
+		u, err := url.Parse(*c.URL)
+		if err != nil {
+			return fmt.Errorf("clientConfig url '%s' is invalid", *c.URL)
+		}
+		if u.Scheme != "https" || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("clientConfig url '%s' is invalid: it must be an https URL without user info, query or fragment", *c.URL)
+		}
+
+	case c.Service != nil:
+		if len(validation.IsDNS1123Label(c.Service.Namespace)) > 0 || len(validation.IsDNS1123Label(c.Service.Name)) > 0 {
+			return fmt.Errorf("clientConfig service %s/%s is invalid", c.Service.Namespace, c.Service.Name)
+		}
+		if c.Service.Port != nil && len(validation.IsValidPortNum(int(*c.Service.Port))) > 0 {
+			return fmt.Errorf("clientConfig service port %d is invalid", *c.Service.Port)
+		}
+		if c.Service.Path != nil && !strings.HasPrefix(*c.Service.Path, "/") {
+			return fmt.Errorf("clientConfig service path '%s' is invalid: it must start with /", *c.Service.Path)
+		}
+
+	default:
+		return fmt.Errorf("clientConfig must have a url or a service")
+	}
+
+	if len(c.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(c.CABundle) {
+		return fmt.Errorf("clientConfig caBundle has no PEM encoded certificates")
+	}
+
+	return nil
+}
+
+func validateAdmissionWebhookRule(r admissionregistrationv1.RuleWithOperations) error {
+	if len(r.Operations) == 0 || len(r.APIGroups) == 0 || len(r.APIVersions) == 0 || len(r.Resources) == 0 {
+		return fmt.Errorf("rules must have operations, apiGroups, apiVersions and resources")
+	}
+
+	for _, op := range r.Operations {
+		switch op {
+		case admissionregistrationv1.OperationAll, admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect:
+		default:
+			return fmt.Errorf("rule operation '%s' is invalid", op)
+		}
+	}
+
+	if r.Scope != nil {
+		switch *r.Scope {
+		case admissionregistrationv1.AllScopes, admissionregistrationv1.ClusterScope, admissionregistrationv1.NamespacedScope:
+		default:
+			return fmt.Errorf("rule scope '%s' is invalid", *r.Scope)
+		}
+	}
+
+	return nil
+}
+
+// AdmissionWebhooksBlockingCoreNamespaces returns the names of the webhooks of
+// the decoded admission webhook configuration which fail closed, i.e. whose
+// failurePolicy is Fail (the default), and whose rules apply to objects in the
+// core namespaces of the platform.  Such a webhook is allowed, but if it is
+// unavailable it may prevent the cluster from recovering.
+func AdmissionWebhooksBlockingCoreNamespaces(o kruntime.Object) []string {
+	_, webhooks := admissionWebhooks(o)
+
+	var names []string
+	for _, w := range webhooks {
+		if w.failurePolicy != nil && *w.failurePolicy == admissionregistrationv1.Ignore {
+			continue
+		}
+
+		namespaced := false
+		for _, r := range w.rules {
+			if r.Scope == nil || *r.Scope != admissionregistrationv1.ClusterScope {
+				namespaced = true
+			}
+		}
+		if !namespaced {
+			continue
+		}
+
+		// a missing namespaceSelector matches every namespace
+		selector := labels.Everything()
+		if w.namespaceSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(w.namespaceSelector)
+			if err != nil {
+				continue
+			}
+		}
+
+		for _, ns := range coreNamespaces {
+			if selector.Matches(labels.Set{"kubernetes.io/metadata.name": ns}) {
+				names = append(names, w.name)
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+func admissionWebhooks(o kruntime.Object) (string, []admissionWebhook) {
+	var webhooks []admissionWebhook
+
+	switch o := o.(type) {
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		for _, w := range o.Webhooks {
+			webhooks = append(webhooks, admissionWebhook{
+				name:                    w.Name,
+				clientConfig:            w.ClientConfig,
+				rules:                   w.Rules,
+				failurePolicy:           w.FailurePolicy,
+				matchPolicy:             w.MatchPolicy,
+				namespaceSelector:       w.NamespaceSelector,
+				objectSelector:          w.ObjectSelector,
+				sideEffects:             w.SideEffects,
+				timeoutSeconds:          w.TimeoutSeconds,
+				admissionReviewVersions: w.AdmissionReviewVersions,
+			})
+		}
+		return o.Name, webhooks
+
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		for _, w := range o.Webhooks {
+			webhooks = append(webhooks, admissionWebhook{
+				name:                    w.Name,
+				clientConfig:            w.ClientConfig,
+				rules:                   w.Rules,
+				failurePolicy:           w.FailurePolicy,
+				matchPolicy:             w.MatchPolicy,
+				namespaceSelector:       w.NamespaceSelector,
+				objectSelector:          w.ObjectSelector,
+				sideEffects:             w.SideEffects,
+				timeoutSeconds:          w.TimeoutSeconds,
+				admissionReviewVersions: w.AdmissionReviewVersions,
+				reinvocationPolicy:      w.ReinvocationPolicy,
+			})
+		}
+		return o.Name, webhooks
+	}
+
+	return "", nil
+}
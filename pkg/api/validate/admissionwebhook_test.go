@@ -0,0 +1,176 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+const testValidatingWebhookConfiguration = `apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: policy
+webhooks:
+- name: policy.example.com
+  clientConfig:
+    service:
+      namespace: policy
+      name: webhook
+      path: /validate
+  rules:
+  - operations: ["CREATE", "UPDATE"]
+    apiGroups: ["apps"]
+    apiVersions: ["v1"]
+    resources: ["deployments"]
+  namespaceSelector:
+    matchExpressions:
+    - key: kubernetes.io/metadata.name
+      operator: NotIn
+      values: ["policy"]
+  sideEffects: None
+  admissionReviewVersions: ["v1"]
+`
+
+func TestDecodeAndValidateAdmissionWebhookConfiguration(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		configuration string
+		wantErr       string
+	}{
+		{
+			name:          "valid",
+			configuration: testValidatingWebhookConfiguration,
+		},
+		{
+			name:          "valid mutating webhook in JSON",
+			configuration: `{"apiVersion": "admissionregistration.k8s.io/v1", "kind": "MutatingWebhookConfiguration", "metadata": {"name": "defaults"}, "webhooks": [{"name": "defaults.example.com", "clientConfig": {"url": "https://defaults.example.com/mutate"}, "rules": [{"operations": ["*"], "apiGroups": ["*"], "apiVersions": ["*"], "resources": ["pods"], "scope": "Namespaced"}], "sideEffects": "NoneOnDryRun", "reinvocationPolicy": "IfNeeded", "admissionReviewVersions": ["v1", "v1beta1"]}]}`,
+		},
+		{
+			name:          "not yaml",
+			configuration: "{",
+			wantErr:       "it is not valid JSON or YAML",
+		},
+		{
+			name:          "beta API version",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "admissionregistration.k8s.io/v1", "admissionregistration.k8s.io/v1beta1", 1),
+			wantErr:       "it must be a ValidatingWebhookConfiguration or a MutatingWebhookConfiguration of API version admissionregistration.k8s.io/v1",
+		},
+		{
+			name:          "unknown field",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  sideEffects: None\n", "  sideEffects: None\n  sideeffects: None\n", 1),
+			wantErr:       "strict decoding error: unknown field 'webhooks[0].sideeffects'",
+		},
+		{
+			name:          "name invalid",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "name: policy\n", "name: Policy\n", 1),
+			wantErr:       "name 'Policy' is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+		},
+		{
+			name:          "no webhooks",
+			configuration: "apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingWebhookConfiguration\nmetadata:\n  name: policy\n",
+			wantErr:       "it has no webhooks",
+		},
+		{
+			name:          "webhook name not qualified",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "- name: policy.example.com", "- name: policy", 1),
+			wantErr:       "webhook 'policy': the name must be fully qualified, e.g. policy.example.com",
+		},
+		{
+			name:          "url and service",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  clientConfig:\n", "  clientConfig:\n    url: https://policy.example.com\n", 1),
+			wantErr:       "webhook 'policy.example.com': clientConfig must have a url or a service, not both",
+		},
+		{
+			name:          "http url",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "    service:\n      namespace: policy\n      name: webhook\n      path: /validate\n", "    url: http://policy.example.com\n", 1),
+			wantErr:       "webhook 'policy.example.com': clientConfig url 'http://policy.example.com' is invalid: it must be an https URL without user info, query or fragment",
+		},
+		{
+			name:          "ca bundle invalid",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  clientConfig:\n", "  clientConfig:\n    caBundle: Zm9v\n", 1),
+			wantErr:       "webhook 'policy.example.com': clientConfig caBundle has no PEM encoded certificates",
+		},
+		{
+			name:          "rule operation invalid",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, `"CREATE", "UPDATE"`, `"CREATE", "PATCH"`, 1),
+			wantErr:       "webhook 'policy.example.com': rule operation 'PATCH' is invalid",
+		},
+		{
+			name:          "selector invalid",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "operator: NotIn", "operator: Within", 1),
+			wantErr:       "webhook 'policy.example.com': selector is invalid: 'Within' is not a valid pod selector operator",
+		},
+		{
+			name:          "timeout too long",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  sideEffects: None\n", "  sideEffects: None\n  timeoutSeconds: 60\n", 1),
+			wantErr:       "webhook 'policy.example.com': timeoutSeconds 60 is invalid: it must be between 1 and 30",
+		},
+		{
+			name:          "side effects",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "sideEffects: None", "sideEffects: Some", 1),
+			wantErr:       "webhook 'policy.example.com': sideEffects 'Some' is invalid",
+		},
+		{
+			name:          "admission review version unsupported",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, `admissionReviewVersions: ["v1"]`, `admissionReviewVersions: ["v2"]`, 1),
+			wantErr:       "webhook 'policy.example.com': admissionReviewVersions must include v1 or v1beta1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := DecodeAdmissionWebhookConfiguration(tt.configuration)
+			if err == nil {
+				err = ValidateAdmissionWebhookConfiguration(o)
+			}
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAdmissionWebhooksBlockingCoreNamespaces(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		configuration string
+		want          []string
+	}{
+		{
+			name:          "namespace selector excludes the core namespaces",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "operator: NotIn", "operator: In", 1),
+		},
+		{
+			name:          "namespace selector matches the core namespaces",
+			configuration: testValidatingWebhookConfiguration,
+			want:          []string{"policy.example.com"},
+		},
+		{
+			name:          "no namespace selector",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  namespaceSelector:\n    matchExpressions:\n    - key: kubernetes.io/metadata.name\n      operator: NotIn\n      values: [\"policy\"]\n", "", 1),
+			want:          []string{"policy.example.com"},
+		},
+		{
+			name:          "failure policy ignore",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, "  sideEffects: None\n", "  sideEffects: None\n  failurePolicy: Ignore\n", 1),
+		},
+		{
+			name:          "cluster scoped rules",
+			configuration: strings.Replace(testValidatingWebhookConfiguration, `resources: ["deployments"]`, `resources: ["nodes"]`+"\n    scope: Cluster", 1),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := DecodeAdmissionWebhookConfiguration(tt.configuration)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := AdmissionWebhooksBlockingCoreNamespaces(o)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"math"
+	"strings"
+)
+
+// DNSRecordTTLIsValid returns true if ttl, in seconds, is in the range which
+// Azure DNS accepts
+func DNSRecordTTLIsValid(ttl int) bool {
+	return ttl >= 1 && ttl <= math.MaxInt32
+}
+
+// DomainIsInDNSZone returns true if domain is the DNS zone zone or a subdomain
+// of it
+func DomainIsInDNSZone(domain, zone string) bool {
+	domain, zone = strings.ToLower(domain), strings.ToLower(zone)
+	return domain == zone || strings.HasSuffix(domain, "."+zone)
+}
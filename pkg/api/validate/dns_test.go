@@ -0,0 +1,68 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestDomainIsInDNSZone(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		domain        string
+		zone          string
+		desiredResult bool
+	}{
+		{
+			name:          "subdomain",
+			domain:        "cluster.example.com",
+			zone:          "example.com",
+			desiredResult: true,
+		},
+		{
+			name:          "nested subdomain",
+			domain:        "cluster.dev.example.com",
+			zone:          "example.com",
+			desiredResult: true,
+		},
+		{
+			name:          "zone apex",
+			domain:        "example.com",
+			zone:          "example.com",
+			desiredResult: true,
+		},
+		{
+			name:          "case insensitive",
+			domain:        "cluster.example.com",
+			zone:          "Example.COM",
+			desiredResult: true,
+		},
+		{
+			name:          "suffix is not a label",
+			domain:        "cluster.example.com",
+			zone:          "ample.com",
+			desiredResult: false,
+		},
+		{
+			name:          "other zone",
+			domain:        "cluster.example.com",
+			zone:          "example.org",
+			desiredResult: false,
+		},
+		{
+			name:          "parent of the zone",
+			domain:        "example.com",
+			zone:          "cluster.example.com",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DomainIsInDNSZone(tt.domain, tt.zone)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
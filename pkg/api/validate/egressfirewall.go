@@ -0,0 +1,27 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net"
+)
+
+// EgressDestinationCIDRIsValid returns true if cidr is an IPv4 range in
+// canonical form, e.g. 203.0.113.0/24, as accepted by both EgressFirewall and
+// EgressNetworkPolicy objects
+func EgressDestinationCIDRIsValid(cidr string) bool {
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+
+	return ip.To4() != nil && ip.Equal(ipnet.IP) && ipnet.String() == cidr
+}
+
+// EgressDestinationDNSNameIsValid returns true if name is a lower case, fully
+// qualified DNS name without a trailing dot, e.g. www.example.com.  Wildcards
+// are not supported by EgressNetworkPolicy objects.
+func EgressDestinationDNSNameIsValid(name string) bool {
+	return len(name) <= 253 && RxDomainNameRFC1123.MatchString(name) && net.ParseIP(name) == nil
+}
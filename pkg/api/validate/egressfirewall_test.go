@@ -0,0 +1,114 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEgressDestinationCIDRIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		cidr          string
+		desiredResult bool
+	}{
+		{
+			name:          "range",
+			cidr:          "203.0.113.0/24",
+			desiredResult: true,
+		},
+		{
+			name:          "single address",
+			cidr:          "203.0.113.10/32",
+			desiredResult: true,
+		},
+		{
+			name:          "everything",
+			cidr:          "0.0.0.0/0",
+			desiredResult: true,
+		},
+		{
+			name:          "host bits set",
+			cidr:          "203.0.113.10/24",
+			desiredResult: false,
+		},
+		{
+			name:          "address without prefix length",
+			cidr:          "203.0.113.10",
+			desiredResult: false,
+		},
+		{
+			name:          "ipv6",
+			cidr:          "2001:db8::/32",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EgressDestinationCIDRIsValid(tt.cidr)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestEgressDestinationDNSNameIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		dnsName       string
+		desiredResult bool
+	}{
+		{
+			name:          "fully qualified",
+			dnsName:       "www.example.com",
+			desiredResult: true,
+		},
+		{
+			name:          "single label",
+			dnsName:       "localhost",
+			desiredResult: true,
+		},
+		{
+			name:          "wildcard",
+			dnsName:       "*.example.com",
+			desiredResult: false,
+		},
+		{
+			name:          "trailing dot",
+			dnsName:       "www.example.com.",
+			desiredResult: false,
+		},
+		{
+			name:          "upper case",
+			dnsName:       "www.Example.com",
+			desiredResult: false,
+		},
+		{
+			name:          "ip address",
+			dnsName:       "203.0.113.10",
+			desiredResult: false,
+		},
+		{
+			name:          "too long",
+			dnsName:       strings.Repeat("a.", 127) + "com",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EgressDestinationDNSNameIsValid(tt.dnsName)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
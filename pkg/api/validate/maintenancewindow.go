@@ -0,0 +1,37 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"time"
+
+	// embed the time zone database so that maintenance window time zones can
+	// be validated and used regardless of the host's zoneinfo
+	_ "time/tzdata"
+)
+
+// MaintenanceWindowStartTimeLayout is the layout of the start time of a
+// maintenance window: hours and minutes on a 24 hour clock
+const MaintenanceWindowStartTimeLayout = "15:04"
+
+// MaxMaintenanceWindowDurationHours is the maximum duration of a maintenance
+// window.  Windows may not overlap the window starting on the next day.
+const MaxMaintenanceWindowDurationHours = 24
+
+// MaintenanceWindowStartTimeIsValid returns true if startTime is a valid
+// maintenance window start time, e.g. "02:30"
+func MaintenanceWindowStartTimeIsValid(startTime string) bool {
+	t, err := time.Parse(MaintenanceWindowStartTimeLayout, startTime)
+	return err == nil && t.Format(MaintenanceWindowStartTimeLayout) == startTime
+}
+
+// MaintenanceWindowTimeZoneIsValid returns true if timeZone is an IANA time
+// zone name, e.g. "Europe/Berlin".  The empty string means UTC.
+func MaintenanceWindowTimeZoneIsValid(timeZone string) bool {
+	if timeZone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timeZone)
+	return err == nil
+}
@@ -0,0 +1,100 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestMaintenanceWindowStartTimeIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		startTime     string
+		desiredResult bool
+	}{
+		{
+			name:          "valid",
+			startTime:     "02:30",
+			desiredResult: true,
+		},
+		{
+			name:          "midnight",
+			startTime:     "00:00",
+			desiredResult: true,
+		},
+		{
+			name:          "hour out of range",
+			startTime:     "24:00",
+			desiredResult: false,
+		},
+		{
+			name:          "no leading zero",
+			startTime:     "2:30",
+			desiredResult: false,
+		},
+		{
+			name:          "seconds",
+			startTime:     "02:30:00",
+			desiredResult: false,
+		},
+		{
+			name:          "12 hour clock",
+			startTime:     "02:30PM",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MaintenanceWindowStartTimeIsValid(tt.startTime)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowTimeZoneIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		timeZone      string
+		desiredResult bool
+	}{
+		{
+			name:          "IANA time zone",
+			timeZone:      "Europe/Berlin",
+			desiredResult: true,
+		},
+		{
+			name:          "UTC",
+			timeZone:      "UTC",
+			desiredResult: true,
+		},
+		{
+			name:          "empty means UTC",
+			desiredResult: true,
+		},
+		{
+			name:          "local time zone of the RP",
+			timeZone:      "Local",
+			desiredResult: false,
+		},
+		{
+			name:          "unknown time zone",
+			timeZone:      "Mars/Olympus_Mons",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MaintenanceWindowTimeZoneIsValid(tt.timeZone)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// controlPlaneNodeRoleLabels are the role labels of the nodes which run the
+// platform rather than the customer workloads
+var controlPlaneNodeRoleLabels = []string{
+	"node-role.kubernetes.io/master",
+	"node-role.kubernetes.io/control-plane",
+	"node-role.kubernetes.io/infra",
+}
+
+// DefaultNodeSelectorIsValid returns true if selector is a non-empty, comma
+// separated list of key=value node labels, e.g.
+// "node-role.kubernetes.io/app=,region=east"
+func DefaultNodeSelectorIsValid(selector string) bool {
+	if strings.TrimSpace(selector) == "" {
+		return false
+	}
+
+	_, err := labels.ConvertSelectorToLabelsMap(selector)
+	return err == nil
+}
+
+// DefaultNodeSelectorControlPlaneLabel returns the role label of the control
+// plane or infra nodes which selector selects on, if any.  selector must be
+// valid.
+func DefaultNodeSelectorControlPlaneLabel(selector string) string {
+	set, _ := labels.ConvertSelectorToLabelsMap(selector)
+	for _, label := range controlPlaneNodeRoleLabels {
+		if set.Has(label) {
+			return label
+		}
+	}
+	return ""
+}
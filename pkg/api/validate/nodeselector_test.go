@@ -0,0 +1,98 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestDefaultNodeSelectorIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		selector      string
+		desiredResult bool
+	}{
+		{
+			name:          "single label",
+			selector:      "region=east",
+			desiredResult: true,
+		},
+		{
+			name:          "label without value",
+			selector:      "node-role.kubernetes.io/app=",
+			desiredResult: true,
+		},
+		{
+			name:          "several labels",
+			selector:      "node-role.kubernetes.io/app=, region=east",
+			desiredResult: true,
+		},
+		{
+			name:          "label without equals sign",
+			selector:      "region",
+			desiredResult: false,
+		},
+		{
+			name:          "set based selector",
+			selector:      "region in (east,west)",
+			desiredResult: false,
+		},
+		{
+			name:          "inequality",
+			selector:      "region!=east",
+			desiredResult: false,
+		},
+		{
+			name:          "invalid key",
+			selector:      "-region=east",
+			desiredResult: false,
+		},
+		{
+			name:          "invalid value",
+			selector:      "region=east/1",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DefaultNodeSelectorIsValid(tt.selector)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestDefaultNodeSelectorControlPlaneLabel(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		selector      string
+		desiredResult string
+	}{
+		{
+			name:     "worker nodes",
+			selector: "node-role.kubernetes.io/worker=",
+		},
+		{
+			name:          "master nodes",
+			selector:      "region=east,node-role.kubernetes.io/master=",
+			desiredResult: "node-role.kubernetes.io/master",
+		},
+		{
+			name:          "infra nodes",
+			selector:      "node-role.kubernetes.io/infra=",
+			desiredResult: "node-role.kubernetes.io/infra",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DefaultNodeSelectorControlPlaneLabel(tt.selector)
+			if result != tt.desiredResult {
+				t.Errorf("Want %q, got %q", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// resourceQuotaResources are the standard resources which a ResourceQuota can
+// limit.  The value is true for object counts, which must be whole numbers.
+var resourceQuotaResources = map[corev1.ResourceName]bool{
+	corev1.ResourceCPU:                      false,
+	corev1.ResourceMemory:                   false,
+	corev1.ResourceEphemeralStorage:         false,
+	corev1.ResourceRequestsCPU:              false,
+	corev1.ResourceRequestsMemory:           false,
+	corev1.ResourceRequestsStorage:          false,
+	corev1.ResourceRequestsEphemeralStorage: false,
+	corev1.ResourceLimitsCPU:                false,
+	corev1.ResourceLimitsMemory:             false,
+	corev1.ResourceLimitsEphemeralStorage:   false,
+	corev1.ResourcePods:                     true,
+	corev1.ResourceServices:                 true,
+	corev1.ResourceServicesNodePorts:        true,
+	corev1.ResourceServicesLoadBalancers:    true,
+	corev1.ResourceReplicationControllers:   true,
+	corev1.ResourceQuotas:                   true,
+	corev1.ResourceSecrets:                  true,
+	corev1.ResourceConfigMaps:               true,
+	corev1.ResourcePersistentVolumeClaims:   true,
+}
+
+// object count quotas name a resource and optionally its API group, e.g.
+// count/deployments.apps
+var rxCountQuotaResource = regexp.MustCompile(`^count/[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// ResourceQuotaResourceIsValid returns true if name is a resource which a
+// ResourceQuota can limit, e.g. "requests.cpu", "pods" or
+// "count/deployments.apps"
+func ResourceQuotaResourceIsValid(name string) bool {
+	_, ok := resourceQuotaResources[corev1.ResourceName(name)]
+	return ok || rxCountQuotaResource.MatchString(name)
+}
+
+// ResourceQuotaResourceIsCount returns true if name is a ResourceQuota
+// resource which counts objects, so its hard limit must be a whole number
+func ResourceQuotaResourceIsCount(name string) bool {
+	return resourceQuotaResources[corev1.ResourceName(name)] || rxCountQuotaResource.MatchString(name)
+}
+
+// LimitRangeResourceIsValid returns true if name is a container resource
+// which can be given a default limit and request by a LimitRange
+func LimitRangeResourceIsValid(name string) bool {
+	switch corev1.ResourceName(name) {
+	case corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
+		return true
+	}
+	return false
+}
+
+// QuantityIsValid returns true if value is a non-negative Kubernetes resource
+// quantity, e.g. "500m" or "2Gi".  If whole is set, value must also be a
+// whole number.
+func QuantityIsValid(value string, whole bool) bool {
+	q, err := resource.ParseQuantity(value)
+	if err != nil || q.Sign() < 0 {
+		return false
+	}
+
+	return !whole || q.MilliValue()%1000 == 0
+}
@@ -0,0 +1,163 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestResourceQuotaResourceIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		resource      string
+		desiredResult bool
+		desiredCount  bool
+	}{
+		{
+			name:          "compute resource",
+			resource:      "requests.cpu",
+			desiredResult: true,
+		},
+		{
+			name:          "object count",
+			resource:      "pods",
+			desiredResult: true,
+			desiredCount:  true,
+		},
+		{
+			name:          "core object count",
+			resource:      "count/secrets",
+			desiredResult: true,
+			desiredCount:  true,
+		},
+		{
+			name:          "grouped object count",
+			resource:      "count/deployments.apps",
+			desiredResult: true,
+			desiredCount:  true,
+		},
+		{
+			name:          "uppercase object count",
+			resource:      "count/Deployments.apps",
+			desiredResult: false,
+		},
+		{
+			name:          "unknown resource",
+			resource:      "requests.gpu",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResourceQuotaResourceIsValid(tt.resource)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+
+			count := ResourceQuotaResourceIsCount(tt.resource)
+			if count != tt.desiredCount {
+				t.Errorf("Want count %v, got %v", tt.desiredCount, count)
+			}
+		})
+	}
+}
+
+func TestLimitRangeResourceIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		resource      string
+		desiredResult bool
+	}{
+		{
+			name:          "cpu",
+			resource:      "cpu",
+			desiredResult: true,
+		},
+		{
+			name:          "ephemeral storage",
+			resource:      "ephemeral-storage",
+			desiredResult: true,
+		},
+		{
+			name:          "quota resource",
+			resource:      "requests.memory",
+			desiredResult: false,
+		},
+		{
+			name:          "object count",
+			resource:      "pods",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LimitRangeResourceIsValid(tt.resource)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestQuantityIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		value         string
+		whole         bool
+		desiredResult bool
+	}{
+		{
+			name:          "millicores",
+			value:         "500m",
+			desiredResult: true,
+		},
+		{
+			name:          "binary suffix",
+			value:         "2Gi",
+			desiredResult: true,
+		},
+		{
+			name:          "zero",
+			value:         "0",
+			desiredResult: true,
+		},
+		{
+			name:          "whole number",
+			value:         "10",
+			whole:         true,
+			desiredResult: true,
+		},
+		{
+			name:          "fraction where whole number required",
+			value:         "1500m",
+			whole:         true,
+			desiredResult: false,
+		},
+		{
+			name:          "negative",
+			value:         "-1",
+			desiredResult: false,
+		},
+		{
+			name:          "not a quantity",
+			value:         "lots",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := QuantityIsValid(tt.value, tt.whole)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ResourceNameTemplateClusterName is the placeholder in a resource name
+// template which is replaced with the cluster name
+const ResourceNameTemplateClusterName = "{name}"
+
+// MaxResourceNameTemplateLength is the maximum length of the literal part of a
+// resource name template.  The infra ID which the template generates is at
+// most 27 characters long, 6 of which are taken by the random suffix.
+const MaxResourceNameTemplateLength = 21
+
+// names generated from the template are used in kubernetes object names as
+// well as Azure resource names, so only allow DNS-1123 label characters
+var rxResourceNameTemplate = regexp.MustCompile(`^(?:[a-z0-9]|\{name\})(?:[-a-z0-9]|\{name\})*$`)
+
+// ResourceNameTemplateIsValid returns true if template is a valid resource name
+// template: lowercase alphanumerics and dashes, starting with an alphanumeric,
+// with at most one cluster name placeholder
+func ResourceNameTemplateIsValid(template string) bool {
+	if !rxResourceNameTemplate.MatchString(template) {
+		return false
+	}
+
+	placeholders := strings.Count(template, ResourceNameTemplateClusterName)
+
+	return placeholders <= 1 &&
+		len(template)-placeholders*len(ResourceNameTemplateClusterName) <= MaxResourceNameTemplateLength
+}
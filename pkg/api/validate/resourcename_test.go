@@ -0,0 +1,75 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestResourceNameTemplateIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		template      string
+		desiredResult bool
+	}{
+		{
+			name:          "prefix",
+			template:      "prod-weu-{name}",
+			desiredResult: true,
+		},
+		{
+			name:          "suffix",
+			template:      "{name}-prod",
+			desiredResult: true,
+		},
+		{
+			name:          "no placeholder",
+			template:      "prod-weu-aro",
+			desiredResult: true,
+		},
+		{
+			name:          "literal part at the maximum length",
+			template:      "abcdefghijklmnopqrstu{name}",
+			desiredResult: true,
+		},
+		{
+			name:          "literal part too long",
+			template:      "abcdefghijklmnopqrstuv{name}",
+			desiredResult: false,
+		},
+		{
+			name:          "more than one placeholder",
+			template:      "{name}-{name}",
+			desiredResult: false,
+		},
+		{
+			name:          "uppercase",
+			template:      "PROD-{name}",
+			desiredResult: false,
+		},
+		{
+			name:          "invalid character",
+			template:      "prod_{name}",
+			desiredResult: false,
+		},
+		{
+			name:          "starts with a dash",
+			template:      "-prod-{name}",
+			desiredResult: false,
+		},
+		{
+			name:          "unknown placeholder",
+			template:      "{location}-{name}",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResourceNameTemplateIsValid(tt.template)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// SELinuxBooleans are the SELinux booleans which may be turned on on the
+// cluster nodes.  Both only restrict what processes may do, so turning them on
+// can't weaken the node security policy.
+var SELinuxBooleans = []string{"deny_execmem", "deny_ptrace"}
+
+// SELinuxBooleanIsValid returns true if name is one of SELinuxBooleans
+func SELinuxBooleanIsValid(name string) bool {
+	for _, b := range SELinuxBooleans {
+		if name == b {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestSELinuxBooleanIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		boolean       string
+		desiredResult bool
+	}{
+		{
+			name:          "supported boolean",
+			boolean:       "deny_ptrace",
+			desiredResult: true,
+		},
+		{
+			name:          "unsupported boolean",
+			boolean:       "container_manage_cgroup",
+			desiredResult: false,
+		},
+		{
+			name:          "empty",
+			boolean:       "",
+			desiredResult: false,
+		},
+		{
+			name:          "boolean with value",
+			boolean:       "deny_ptrace=off",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SELinuxBooleanIsValid(tt.boolean)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// rxStorageClassTags matches the tags parameter of the Azure Disk CSI driver,
+// e.g. "key1=value1,key2=value2"
+var rxStorageClassTags = regexp.MustCompile(`^[^=,]+=[^=,]*(,[^=,]+=[^=,]*)*$`)
+
+// azureDiskCSIParameters maps the StorageClass parameters supported by the
+// Azure Disk CSI driver, lower cased as the driver matches them case
+// insensitively, to a check of their value.  Parameters which would place
+// disks outside the cluster resource group, e.g. resourceGroup, are not
+// supported.
+var azureDiskCSIParameters = map[string]func(string) bool{
+	"skuname":               oneOf("Standard_LRS", "Premium_LRS", "StandardSSD_LRS", "PremiumV2_LRS", "UltraSSD_LRS", "Premium_ZRS", "StandardSSD_ZRS"),
+	"cachingmode":           oneOf("None", "ReadOnly", "ReadWrite"),
+	"fstype":                oneOf("ext2", "ext3", "ext4", "xfs"),
+	"diskencryptionsetid":   isResourceID,
+	"diskencryptiontype":    oneOf("EncryptionAtRestWithCustomerKey", "EncryptionAtRestWithPlatformAndCustomerKeys"),
+	"networkaccesspolicy":   oneOf("AllowAll", "DenyAll", "AllowPrivate"),
+	"diskaccessid":          isResourceID,
+	"enablebursting":        oneOf("true", "false"),
+	"enableperformanceplus": oneOf("true", "false"),
+	"perfprofile":           oneOf("None", "Basic", "Advanced"),
+	"logicalsectorsize":     oneOf("512", "4096"),
+	"diskiopsreadwrite":     isPositiveInteger,
+	"diskmbpsreadwrite":     isPositiveInteger,
+	"maxshares":             isPositiveInteger,
+	"tags":                  rxStorageClassTags.MatchString,
+}
+
+// AzureDiskCSIParameterIsSupported returns true if name is a StorageClass
+// parameter of the Azure Disk CSI driver which may be configured, e.g.
+// "skuName" or "cachingMode"
+func AzureDiskCSIParameterIsSupported(name string) bool {
+	_, ok := azureDiskCSIParameters[strings.ToLower(name)]
+	return ok
+}
+
+// AzureDiskCSIParameterValueIsValid returns true if value is a valid value of
+// the supported Azure Disk CSI driver StorageClass parameter name
+func AzureDiskCSIParameterValueIsValid(name, value string) bool {
+	isValid, ok := azureDiskCSIParameters[strings.ToLower(name)]
+	return ok && isValid(value)
+}
+
+func oneOf(values ...string) func(string) bool {
+	return func(value string) bool {
+		for _, v := range values {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isResourceID(value string) bool {
+	_, err := azure.ParseResourceID(value)
+	return err == nil
+}
+
+func isPositiveInteger(value string) bool {
+	i, err := strconv.Atoi(value)
+	return err == nil && i > 0
+}
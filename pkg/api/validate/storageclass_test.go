@@ -0,0 +1,121 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestAzureDiskCSIParameterIsSupported(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		parameter     string
+		desiredResult bool
+	}{
+		{
+			name:          "sku",
+			parameter:     "skuName",
+			desiredResult: true,
+		},
+		{
+			name:          "case insensitive",
+			parameter:     "SKUNAME",
+			desiredResult: true,
+		},
+		{
+			name:          "resource group",
+			parameter:     "resourceGroup",
+			desiredResult: false,
+		},
+		{
+			name:          "in-tree parameter",
+			parameter:     "kind",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AzureDiskCSIParameterIsSupported(tt.parameter)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
+
+func TestAzureDiskCSIParameterValueIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		parameter     string
+		value         string
+		desiredResult bool
+	}{
+		{
+			name:          "sku",
+			parameter:     "skuName",
+			value:         "Premium_ZRS",
+			desiredResult: true,
+		},
+		{
+			name:          "value is case insensitive",
+			parameter:     "cachingMode",
+			value:         "readonly",
+			desiredResult: true,
+		},
+		{
+			name:          "unknown sku",
+			parameter:     "skuName",
+			value:         "Premium_GRS",
+			desiredResult: false,
+		},
+		{
+			name:          "disk encryption set",
+			parameter:     "diskEncryptionSetID",
+			value:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des",
+			desiredResult: true,
+		},
+		{
+			name:          "invalid disk encryption set",
+			parameter:     "diskEncryptionSetID",
+			value:         "des",
+			desiredResult: false,
+		},
+		{
+			name:          "iops",
+			parameter:     "DiskIOPSReadWrite",
+			value:         "5000",
+			desiredResult: true,
+		},
+		{
+			name:          "negative iops",
+			parameter:     "DiskIOPSReadWrite",
+			value:         "-1",
+			desiredResult: false,
+		},
+		{
+			name:          "tags",
+			parameter:     "tags",
+			value:         "team=storage,env=",
+			desiredResult: true,
+		},
+		{
+			name:          "malformed tags",
+			parameter:     "tags",
+			value:         "team",
+			desiredResult: false,
+		},
+		{
+			name:          "unsupported parameter",
+			parameter:     "resourceGroup",
+			value:         "rg",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AzureDiskCSIParameterValueIsValid(tt.parameter, tt.value)
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"regexp"
+	"time"
+
+	// embed the tz database so that validation doesn't depend on the zoneinfo
+	// files installed wherever the RP runs
+	_ "time/tzdata"
+)
+
+// tz database names are made of components separated by slashes, for example
+// America/Argentina/Buenos_Aires or Etc/GMT+5
+var rxTimeZone = regexp.MustCompile(`^[A-Za-z0-9_+-]+(?:/[A-Za-z0-9_+-]+)*$`)
+
+// TimeZoneIsValid returns true if name is the name of a time zone in the tz
+// database.  "Local", which names the time zone of the machine rather than a
+// zone from the database, is not valid.
+func TimeZoneIsValid(name string) bool {
+	if !rxTimeZone.MatchString(name) || name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
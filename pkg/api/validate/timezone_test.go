@@ -0,0 +1,80 @@
+package validate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+)
+
+func TestTimeZoneIsValid(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		timeZone      string
+		desiredResult bool
+	}{
+		{
+			name:          "utc",
+			timeZone:      "UTC",
+			desiredResult: true,
+		},
+		{
+			name:          "region and city",
+			timeZone:      "Europe/London",
+			desiredResult: true,
+		},
+		{
+			name:          "nested city",
+			timeZone:      "America/Argentina/Buenos_Aires",
+			desiredResult: true,
+		},
+		{
+			name:          "fixed offset",
+			timeZone:      "Etc/GMT+5",
+			desiredResult: true,
+		},
+		{
+			name:          "empty",
+			timeZone:      "",
+			desiredResult: false,
+		},
+		{
+			name:          "local",
+			timeZone:      "Local",
+			desiredResult: false,
+		},
+		{
+			name:          "unknown zone",
+			timeZone:      "Europe/Atlantis",
+			desiredResult: false,
+		},
+		{
+			name:          "lowercase",
+			timeZone:      "europe/london",
+			desiredResult: false,
+		},
+		{
+			name:          "path traversal",
+			timeZone:      "../../etc/passwd",
+			desiredResult: false,
+		},
+		{
+			name:          "absolute path",
+			timeZone:      "/usr/share/zoneinfo/UTC",
+			desiredResult: false,
+		},
+		{
+			name:          "shell metacharacters",
+			timeZone:      "UTC; reboot",
+			desiredResult: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TimeZoneIsValid(tt.timeZone)
+
+			if result != tt.desiredResult {
+				t.Errorf("Want %v, got %v", tt.desiredResult, result)
+			}
+		})
+	}
+}
@@ -226,8 +226,10 @@ var supportedWorkerVmSizes = map[api.VMSize]api.VMSizeStruct{
 	api.VMSizeStandardNC24rsV3: api.VMSizeStandardNC24rsV3Struct,
 }
 
+// DiskSizeIsValid returns true if sizeGB is at least the minimum we require
+// for an OS disk and at most the maximum Azure supports
 func DiskSizeIsValid(sizeGB int) bool {
-	return sizeGB >= 128
+	return sizeGB >= 128 && sizeGB <= 4095
 }
 
 func VMSizeIsValid(vmSize api.VMSize, requiredD2sV3Workers, isMaster bool) bool {
@@ -0,0 +1,30 @@
+package api
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// ValidationFindings is the result of validating a proposed cluster
+type ValidationFindings struct {
+	// Status is Failed if any finding has severity Error
+	Status   ValidationStatus
+	Findings []ValidationFinding
+}
+
+// ValidationFinding is a failed validation of a proposed cluster
+type ValidationFinding struct {
+	Severity ValidationSeverity
+	Code     string
+	Target   string
+	Message  string
+}
+
+type ValidationSeverity string
+
+const (
+	// ValidationSeverityError means creating the cluster would fail
+	ValidationSeverityError ValidationSeverity = "Error"
+	// ValidationSeverityWarning means a validation could not be completed,
+	// and is run again when the cluster is created, or that the cluster may
+	// not behave as expected
+	ValidationSeverityWarning ValidationSeverity = "Warning"
+)
@@ -97,6 +97,8 @@ func (b *backend) Run(ctx context.Context, stop <-chan struct{}, done chan<- str
 	t := time.NewTicker(10 * time.Second)
 	defer t.Stop()
 
+	go b.emitMetrics(stop)
+
 	if stop != nil {
 		go func() {
 			defer recover.Panic(b.baseLog)
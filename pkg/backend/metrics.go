@@ -0,0 +1,38 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/util/recover"
+)
+
+// emitMetrics emits the worker utilization of the backend every minute, so
+// that it is reported while the number of workers is not changing.  Together
+// with database.openshiftclusters.queue.length it is the signal on which the
+// number of RP instances is scaled; see docs/backend-scaling.md.
+func (b *backend) emitMetrics(stop <-chan struct{}) {
+	defer recover.Panic(b.baseLog)
+
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for {
+		b.emitWorkerUtilization()
+
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// emitWorkerUtilization emits the cluster and subscription operations being
+// worked as a percentage of maxWorkers
+func (b *backend) emitWorkerUtilization() {
+	b.m.EmitGauge("backend.workers.utilization", int64(atomic.LoadInt32(&b.workers))*100/maxWorkers, nil)
+}
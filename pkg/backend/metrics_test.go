@@ -0,0 +1,27 @@
+package backend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitWorkerUtilization(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("backend.workers.utilization", int64(25), nil)
+
+	b := &backend{
+		m:       m,
+		workers: 25,
+	}
+
+	b.emitWorkerUtilization()
+}
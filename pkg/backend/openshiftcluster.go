@@ -13,8 +13,11 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
 	"github.com/Azure/ARO-RP/pkg/cluster"
 	"github.com/Azure/ARO-RP/pkg/database"
 	"github.com/Azure/ARO-RP/pkg/env"
@@ -23,19 +26,23 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/billing"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
+	"github.com/Azure/ARO-RP/pkg/util/maintenancewindow"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
+	"github.com/Azure/ARO-RP/pkg/util/tracing"
 )
 
 type openShiftClusterBackend struct {
 	*backend
 
 	newManager func(context.Context, *logrus.Entry, env.Interface, database.OpenShiftClusters, database.Gateway, database.OpenShiftVersions, encryption.AEAD, billing.Manager, *api.OpenShiftClusterDocument, *api.SubscriptionDocument, hive.ClusterManager, metrics.Emitter) (cluster.Interface, error)
+	now        func() time.Time
 }
 
 func newOpenShiftClusterBackend(b *backend) *openShiftClusterBackend {
 	return &openShiftClusterBackend{
 		backend:    b,
 		newManager: cluster.New,
+		now:        time.Now,
 	}
 }
 
@@ -77,10 +84,21 @@ func (ocb *openShiftClusterBackend) try(ctx context.Context) (bool, error) {
 			log.WithField("duration", time.Since(t).Seconds()).Print("done")
 		}()
 
-		err := ocb.handle(context.Background(), log, doc)
+		// continue the trace of the request which enqueued the operation
+		ctx := context.Background()
+		if doc.CorrelationData != nil {
+			ctx = tracing.ContextWithTraceParent(ctx, doc.CorrelationData.TraceParent)
+		}
+		ctx, span := tracing.Tracer().Start(ctx, "backend "+string(doc.OpenShiftCluster.Properties.ProvisioningState),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attribute.String("resource_id", doc.OpenShiftCluster.ID)),
+		)
+
+		err := ocb.handle(ctx, log, doc)
 		if err != nil {
 			log.Error(err)
 		}
+		tracing.EndSpan(span, err)
 	}()
 
 	return true, nil
@@ -157,6 +175,22 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 	case api.ProvisioningStateAdminUpdating:
 		log.Printf("admin updating (type: %s)", doc.OpenShiftCluster.Properties.MaintenanceTask)
 
+		// admin updates are deferred to the customer's maintenance
+		// window, unless they are urgent
+		if !doc.OpenShiftCluster.Properties.OverrideMaintenanceWindow {
+			now := ocb.now()
+			next, err := maintenancewindow.Next(doc.OpenShiftCluster.Properties.MaintenanceWindow, now)
+			if err != nil {
+				return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
+			}
+			if next.After(now) {
+				log.Printf("deferring admin update to the next maintenance window at %s", next.UTC().Format(time.RFC3339))
+				stop()
+				_, err = ocb.dbOpenShiftClusters.DeferAdminUpdate(ctx, doc.Key, next)
+				return err
+			}
+		}
+
 		err = m.AdminUpdate(ctx)
 		if err != nil {
 			return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
@@ -185,11 +219,17 @@ func (ocb *openShiftClusterBackend) handle(ctx context.Context, log *logrus.Entr
 			return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
 		}
 
-		err = ocb.updateAsyncOperation(ctx, log, doc.AsyncOperationID, nil, api.ProvisioningStateSucceeded, "", nil)
+		err = ocb.updateAsyncOperation(ctx, log, doc.AsyncOperationID, nil, nil, api.ProvisioningStateSucceeded, "", nil)
 		if err != nil {
 			return ocb.endLease(ctx, log, stop, doc, api.ProvisioningStateFailed, err)
 		}
 
+		// the document is deleted, so the operation can't be recorded in its
+		// history
+		entry := ocb.operationHistoryEntry(doc, api.ProvisioningStateDeleting, api.ProvisioningStateSucceeded, nil)
+		entry.Version = doc.OpenShiftCluster.Properties.ClusterProfile.Version
+		ocb.emitOperationEvent(log, doc.OpenShiftCluster.ID, entry)
+
 		stop()
 
 		// This Sleep ensures that the monitor has enough time
@@ -240,7 +280,7 @@ func (ocb *openShiftClusterBackend) heartbeat(ctx context.Context, cancel contex
 	}
 }
 
-func (ocb *openShiftClusterBackend) updateAsyncOperation(ctx context.Context, log *logrus.Entry, id string, oc *api.OpenShiftCluster, provisioningState, failedProvisioningState api.ProvisioningState, backendErr error) error {
+func (ocb *openShiftClusterBackend) updateAsyncOperation(ctx context.Context, log *logrus.Entry, id string, oc *api.OpenShiftCluster, properties *api.AsyncOperationProperties, provisioningState, failedProvisioningState api.ProvisioningState, backendErr error) error {
 	if id != "" {
 		_, err := ocb.dbAsyncOperations.Patch(ctx, id, func(asyncdoc *api.AsyncOperationDocument) error {
 			asyncdoc.AsyncOperation.ProvisioningState = provisioningState
@@ -248,7 +288,14 @@ func (ocb *openShiftClusterBackend) updateAsyncOperation(ctx context.Context, lo
 			now := time.Now()
 			asyncdoc.AsyncOperation.EndTime = &now
 
+			asyncdoc.AsyncOperation.PercentComplete = 100
+			asyncdoc.AsyncOperation.Properties = nil
+
 			if provisioningState == api.ProvisioningStateFailed {
+				// keep the step which failed
+				asyncdoc.AsyncOperation.PercentComplete = 0
+				asyncdoc.AsyncOperation.Properties = properties
+
 				// if type is CloudError - we want to propagate it to the
 				// asyncOperations errors. Otherwise - return generic error
 				err, ok := backendErr.(*api.CloudError)
@@ -298,12 +345,24 @@ func (ocb *openShiftClusterBackend) endLease(ctx context.Context, log *logrus.En
 	// If cluster is in the non-terminal state we are still in the same
 	// operational context and AsyncOperation should not be updated.
 	if provisioningState.IsTerminal() {
-		err := ocb.updateAsyncOperation(ctx, log, doc.AsyncOperationID, doc.OpenShiftCluster, provisioningState, failedProvisioningState, backendErr)
+		var properties *api.AsyncOperationProperties
+		if provisioningState == api.ProvisioningStateFailed {
+			properties = ocb.operationProgress(ctx, doc)
+		}
+
+		err := ocb.updateAsyncOperation(ctx, log, doc.AsyncOperationID, doc.OpenShiftCluster, properties, provisioningState, failedProvisioningState, backendErr)
 		if err != nil {
 			return err
 		}
 		ocb.asyncOperationResultLog(log, initialProvisioningState, backendErr)
 		ocb.emitMetrics(doc, provisioningState)
+
+		// the operation history is informational: failing to record it must
+		// not keep the lease from being released
+		err = ocb.recordOperation(ctx, log, doc, initialProvisioningState, provisioningState, backendErr)
+		if err != nil {
+			log.Error(err)
+		}
 	}
 
 	if initialProvisioningState == api.ProvisioningStateAdminUpdating {
@@ -325,6 +384,96 @@ func (ocb *openShiftClusterBackend) endLease(ctx context.Context, log *logrus.En
 	return err
 }
 
+// operationProgress returns the progress of the operation recorded on the
+// cluster document by the cluster manager, which doc may predate, or nil if it
+// cannot be read
+func (ocb *openShiftClusterBackend) operationProgress(ctx context.Context, doc *api.OpenShiftClusterDocument) *api.AsyncOperationProperties {
+	current, err := ocb.dbOpenShiftClusters.Get(ctx, doc.Key)
+	if err != nil {
+		return nil
+	}
+	return api.NewAsyncOperationProperties(current.OpenShiftCluster)
+}
+
+// recordOperation appends the operation which is ending to the operation
+// history of the cluster document, and logs it as an event if
+// FeatureEnableOperationEvents is set
+func (ocb *openShiftClusterBackend) recordOperation(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, initialProvisioningState, provisioningState api.ProvisioningState, backendErr error) error {
+	entry := ocb.operationHistoryEntry(doc, initialProvisioningState, provisioningState, backendErr)
+
+	_, err := ocb.dbOpenShiftClusters.PatchWithLease(ctx, doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		entry.Version = doc.OpenShiftCluster.Properties.ClusterProfile.Version
+
+		doc.OperationHistory = append(doc.OperationHistory, entry)
+		if len(doc.OperationHistory) > api.OperationHistoryLimit {
+			doc.OperationHistory = doc.OperationHistory[len(doc.OperationHistory)-api.OperationHistoryLimit:]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ocb.emitOperationEvent(log, doc.OpenShiftCluster.ID, entry)
+	return nil
+}
+
+func (ocb *openShiftClusterBackend) operationHistoryEntry(doc *api.OpenShiftClusterDocument, initialProvisioningState, provisioningState api.ProvisioningState, backendErr error) *api.OperationHistoryEntry {
+	entry := &api.OperationHistoryEntry{
+		InitialProvisioningState: initialProvisioningState,
+		ProvisioningState:        provisioningState,
+		EndTime:                  ocb.now(),
+	}
+
+	if initialProvisioningState == api.ProvisioningStateAdminUpdating {
+		entry.MaintenanceTask = doc.OpenShiftCluster.Properties.MaintenanceTask
+	}
+
+	// operations which were not started by a request, if any, are recorded
+	// as taking no time
+	entry.StartTime = entry.EndTime
+	if doc.CorrelationData != nil {
+		entry.ClientPrincipalName = doc.CorrelationData.ClientPrincipalName
+		entry.CorrelationID = doc.CorrelationData.CorrelationID
+		if !doc.CorrelationData.RequestTime.IsZero() {
+			entry.StartTime = doc.CorrelationData.RequestTime
+		}
+	}
+
+	if backendErr != nil {
+		entry.Error = backendErr.Error()
+	}
+
+	return entry
+}
+
+// emitOperationEvent logs the operation as a structured event if
+// FeatureEnableOperationEvents is set
+func (ocb *openShiftClusterBackend) emitOperationEvent(log *logrus.Entry, resourceID string, entry *api.OperationHistoryEntry) {
+	if !ocb.env.FeatureIsSet(env.FeatureEnableOperationEvents) {
+		return
+	}
+
+	event := admin.ClusterOperationEventFromHistory(resourceID, entry)
+
+	log.WithFields(logrus.Fields{
+		"LOGKIND":         "clusteroperationevent",
+		"schemaVersion":   event.SchemaVersion,
+		"resourceId":      event.ResourceID,
+		"operation":       event.Operation,
+		"maintenanceTask": event.MaintenanceTask,
+		"actor":           event.Actor,
+		"correlationId":   event.CorrelationID,
+		"startTime":       event.StartTime.Format(time.RFC3339),
+		"endTime":         event.EndTime.Format(time.RFC3339),
+		"durationSeconds": event.DurationSeconds,
+		"outcome":         event.Outcome,
+		"error":           event.Error,
+		"version":         event.Version,
+	}).Info("cluster operation ended")
+}
+
 func (ocb *openShiftClusterBackend) asyncOperationResultLog(log *logrus.Entry, initialProvisioningState api.ProvisioningState, backendErr error) {
 	log = log.WithFields(logrus.Fields{
 		"LOGKIND":       "asyncqos",
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
@@ -24,22 +25,28 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
 	mock_cluster "github.com/Azure/ARO-RP/pkg/util/mocks/cluster"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 	"github.com/Azure/ARO-RP/test/util/deterministicuuid"
 	"github.com/Azure/ARO-RP/test/util/testliveconfig"
 )
 
 type backendTestStruct struct {
-	name    string
-	mocks   func(*mock_cluster.MockInterface, database.OpenShiftClusters)
-	fixture func(*testdatabase.Fixture)
-	checker func(*testdatabase.Checker)
+	name       string
+	mocks      func(*mock_cluster.MockInterface, database.OpenShiftClusters)
+	fixture    func(*testdatabase.Fixture)
+	checker    func(*testdatabase.Checker)
+	wantNoWork bool
 }
 
 func TestBackendTry(t *testing.T) {
 	mockSubID := "00000000-0000-0000-0000-000000000000"
 	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
 
+	now := time.Date(2023, time.July, 5, 12, 0, 0, 0, time.UTC) // Wednesday
+	nextMaintenanceWindow := time.Date(2023, time.July, 8, 22, 0, 0, 0, time.UTC)
+	openedMaintenanceWindow := time.Date(2023, time.July, 5, 11, 0, 0, 0, time.UTC)
+
 	for _, tt := range []backendTestStruct{
 		{
 			name: "StateCreating success that sets an InstallPhase stays it in Creating",
@@ -92,6 +99,11 @@ func TestBackendTry(t *testing.T) {
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
 					Key: strings.ToLower(resourceID),
+					CorrelationData: &api.CorrelationData{
+						ClientPrincipalName: "user@example.com",
+						CorrelationID:       "00000000-0000-0000-0000-000000000001",
+						RequestTime:         now.Add(-time.Hour),
+					},
 					OpenShiftCluster: &api.OpenShiftCluster{
 						ID:       resourceID,
 						Name:     "resourceName",
@@ -118,6 +130,16 @@ func TestBackendTry(t *testing.T) {
 							ProvisioningState: api.ProvisioningStateSucceeded,
 						},
 					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateCreating,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							ClientPrincipalName:      "user@example.com",
+							CorrelationID:            "00000000-0000-0000-0000-000000000001",
+							StartTime:                now.Add(-time.Hour),
+							EndTime:                  now,
+						},
+					},
 				})
 			},
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
@@ -163,10 +185,80 @@ func TestBackendTry(t *testing.T) {
 							FailedProvisioningState: api.ProvisioningStateCreating,
 						},
 					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateCreating,
+							ProvisioningState:        api.ProvisioningStateFailed,
+							StartTime:                now,
+							EndTime:                  now,
+							Error:                    "something bad!",
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().Install(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+					return errors.New("something bad!")
+				})
+			},
+		},
+		{
+			name: "StateCreating that fails after recording its progress clears the progress",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateCreating,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:      strings.ToLower(resourceID),
+					Dequeues: 1,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:       api.ProvisioningStateFailed,
+							FailedProvisioningState: api.ProvisioningStateCreating,
+						},
+					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateCreating,
+							ProvisioningState:        api.ProvisioningStateFailed,
+							StartTime:                now,
+							EndTime:                  now,
+							Error:                    "something bad!",
+						},
+					},
 				})
 			},
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
 				manager.EXPECT().Install(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+					_, err := dbOpenShiftClusters.Patch(ctx, strings.ToLower(resourceID), func(doc *api.OpenShiftClusterDocument) error {
+						doc.OpenShiftCluster.Properties.OperationProgress = &api.OperationProgress{
+							Step:           "CreatingResources",
+							CompletedSteps: 1,
+							TotalSteps:     10,
+						}
+						return nil
+					})
+					if err != nil {
+						return err
+					}
 					return errors.New("something bad!")
 				})
 			},
@@ -205,6 +297,15 @@ func TestBackendTry(t *testing.T) {
 							ProvisioningState: api.ProvisioningStateSucceeded,
 						},
 					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							StartTime:                now,
+							EndTime:                  now,
+						},
+					},
 				})
 			},
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
@@ -247,12 +348,244 @@ func TestBackendTry(t *testing.T) {
 							LastAdminUpdateError:    "oh no!",
 						},
 					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateFailed,
+							StartTime:                now,
+							EndTime:                  now,
+							Error:                    "oh no!",
+						},
+					},
 				})
 			},
 			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
 				manager.EXPECT().AdminUpdate(gomock.Any()).Return(errors.New("oh no!"))
 			},
 		},
+		{
+			name: "StateAdminUpdating on a quarantined cluster runs the admin update, as it was requested by an SRE",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceTask:       api.MaintenanceTaskEverything,
+							Quarantine: &api.Quarantine{
+								Reason: "incident",
+							},
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							Quarantine: &api.Quarantine{
+								Reason: "incident",
+							},
+						},
+					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							StartTime:                now,
+							EndTime:                  now,
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().AdminUpdate(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "StateAdminUpdating outside the maintenance window defers the admin update",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceTask:       api.MaintenanceTaskEverything,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdaySaturday},
+								StartTime:     "22:00",
+								DurationHours: 6,
+							},
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceTask:       api.MaintenanceTaskEverything,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdaySaturday},
+								StartTime:     "22:00",
+								DurationHours: 6,
+							},
+							MaintenanceDeferredUntil: &nextMaintenanceWindow,
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {},
+		},
+		{
+			name: "StateAdminUpdating outside the maintenance window with override runs the admin update",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:         api.ProvisioningStateAdminUpdating,
+							LastProvisioningState:     api.ProvisioningStateSucceeded,
+							MaintenanceTask:           api.MaintenanceTaskEverything,
+							OverrideMaintenanceWindow: true,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdaySaturday},
+								StartTime:     "22:00",
+								DurationHours: 6,
+							},
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdaySaturday},
+								StartTime:     "22:00",
+								DurationHours: 6,
+							},
+						},
+					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							StartTime:                now,
+							EndTime:                  now,
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().AdminUpdate(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "StateAdminUpdating deferred to a maintenance window which has opened runs the admin update",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateAdminUpdating,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceTask:       api.MaintenanceTaskEverything,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdayWednesday},
+								StartTime:     "11:00",
+								DurationHours: 2,
+							},
+							MaintenanceDeferredUntil: &openedMaintenanceWindow,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							MaintenanceWindow: &api.MaintenanceWindow{
+								Days:          []api.Weekday{api.WeekdayWednesday},
+								StartTime:     "11:00",
+								DurationHours: 2,
+							},
+						},
+					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							StartTime:                now,
+							EndTime:                  now,
+						},
+					},
+				})
+			},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().AdminUpdate(gomock.Any()).Return(nil)
+			},
+		},
 		{
 			name: "StateDeleting success deletes the document",
 			fixture: func(f *testdatabase.Fixture) {
@@ -277,6 +610,69 @@ func TestBackendTry(t *testing.T) {
 				manager.EXPECT().Delete(gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "StateDeleting pinned to this RP version deletes the document",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+							RPVersionPin:      version.GitCommit,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {},
+			mocks: func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {
+				manager.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "StateDeleting pinned to another RP version is not dequeued",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+							RPVersionPin:      "othersha",
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+				})
+			},
+			checker: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       resourceID,
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/OpenShiftClusters",
+						Location: "location",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateDeleting,
+							RPVersionPin:      "othersha",
+						},
+					},
+				})
+			},
+			mocks:      func(manager *mock_cluster.MockInterface, dbOpenShiftClusters database.OpenShiftClusters) {},
+			wantNoWork: true,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -288,6 +684,7 @@ func TestBackendTry(t *testing.T) {
 			manager := mock_cluster.NewMockInterface(controller)
 			_env := mock_env.NewMockInterface(controller)
 			_env.EXPECT().LiveConfig().AnyTimes().Return(tlc)
+			_env.EXPECT().FeatureIsSet(env.FeatureEnableOperationEvents).AnyTimes().Return(false)
 
 			dbOpenShiftClusters, clientOpenShiftClusters := testdatabase.NewFakeOpenShiftClusters()
 			dbSubscriptions, _ := testdatabase.NewFakeSubscriptions()
@@ -314,14 +711,15 @@ func TestBackendTry(t *testing.T) {
 			b.ocb = &openShiftClusterBackend{
 				backend:    b,
 				newManager: createManager,
+				now:        func() time.Time { return now },
 			}
 
 			worked, err := b.ocb.try(ctx)
 			if err != nil {
 				t.Fatal(err)
 			}
-			if !worked {
-				t.Fatal("didnt do work")
+			if worked == tt.wantNoWork {
+				t.Fatalf("worked %v, wanted %v", worked, !tt.wantNoWork)
 			}
 
 			// wait on the workers to finish their tasks
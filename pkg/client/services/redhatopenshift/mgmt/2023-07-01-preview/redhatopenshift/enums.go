@@ -17,6 +17,59 @@ package redhatopenshift
 // Code generated by Microsoft (R) AutoRest Code Generator.
 // Changes may cause incorrect behavior and will be lost if the code is regenerated.
 
+// AcceleratedNetworking enumerates the values for accelerated networking.
+type AcceleratedNetworking string
+
+const (
+	// AcceleratedNetworkingDisabled ...
+	AcceleratedNetworkingDisabled AcceleratedNetworking = "Disabled"
+	// AcceleratedNetworkingEnabled ...
+	AcceleratedNetworkingEnabled AcceleratedNetworking = "Enabled"
+)
+
+// PossibleAcceleratedNetworkingValues returns an array of possible values for the AcceleratedNetworking const type.
+func PossibleAcceleratedNetworkingValues() []AcceleratedNetworking {
+	return []AcceleratedNetworking{AcceleratedNetworkingDisabled, AcceleratedNetworkingEnabled}
+}
+
+// AdminCredentialsFormat enumerates the values for admin credentials format.
+type AdminCredentialsFormat string
+
+const (
+	// Kubeconfig ...
+	Kubeconfig AdminCredentialsFormat = "Kubeconfig"
+	// Structured ...
+	Structured AdminCredentialsFormat = "Structured"
+)
+
+// PossibleAdminCredentialsFormatValues returns an array of possible values for the AdminCredentialsFormat const type.
+func PossibleAdminCredentialsFormatValues() []AdminCredentialsFormat {
+	return []AdminCredentialsFormat{Kubeconfig, Structured}
+}
+
+// ClusterIdentityComponent enumerates the values for cluster identity component.
+type ClusterIdentityComponent string
+
+const (
+	// CloudControllerManager ...
+	CloudControllerManager ClusterIdentityComponent = "CloudControllerManager"
+	// DiskCSIDriver ...
+	DiskCSIDriver ClusterIdentityComponent = "DiskCSIDriver"
+	// FileCSIDriver ...
+	FileCSIDriver ClusterIdentityComponent = "FileCSIDriver"
+	// ImageRegistry ...
+	ImageRegistry ClusterIdentityComponent = "ImageRegistry"
+	// Ingress ...
+	Ingress ClusterIdentityComponent = "Ingress"
+	// MachineAPI ...
+	MachineAPI ClusterIdentityComponent = "MachineAPI"
+)
+
+// PossibleClusterIdentityComponentValues returns an array of possible values for the ClusterIdentityComponent const type.
+func PossibleClusterIdentityComponentValues() []ClusterIdentityComponent {
+	return []ClusterIdentityComponent{CloudControllerManager, DiskCSIDriver, FileCSIDriver, ImageRegistry, Ingress, MachineAPI}
+}
+
 // CreatedByType enumerates the values for created by type.
 type CreatedByType string
 
@@ -36,6 +89,38 @@ func PossibleCreatedByTypeValues() []CreatedByType {
 	return []CreatedByType{Application, Key, ManagedIdentity, User}
 }
 
+// DiskStorageAccountType enumerates the values for disk storage account type.
+type DiskStorageAccountType string
+
+const (
+	// PremiumLRS ...
+	PremiumLRS DiskStorageAccountType = "Premium_LRS"
+	// StandardSSDLRS ...
+	StandardSSDLRS DiskStorageAccountType = "StandardSSD_LRS"
+)
+
+// PossibleDiskStorageAccountTypeValues returns an array of possible values for the DiskStorageAccountType const type.
+func PossibleDiskStorageAccountTypeValues() []DiskStorageAccountType {
+	return []DiskStorageAccountType{PremiumLRS, StandardSSDLRS}
+}
+
+// EffectiveOutboundMethod enumerates the values for effective outbound method.
+type EffectiveOutboundMethod string
+
+const (
+	// EffectiveOutboundMethodLoadBalancer ...
+	EffectiveOutboundMethodLoadBalancer EffectiveOutboundMethod = "LoadBalancer"
+	// EffectiveOutboundMethodNATGateway ...
+	EffectiveOutboundMethodNATGateway EffectiveOutboundMethod = "NATGateway"
+	// EffectiveOutboundMethodUserDefinedRouting ...
+	EffectiveOutboundMethodUserDefinedRouting EffectiveOutboundMethod = "UserDefinedRouting"
+)
+
+// PossibleEffectiveOutboundMethodValues returns an array of possible values for the EffectiveOutboundMethod const type.
+func PossibleEffectiveOutboundMethodValues() []EffectiveOutboundMethod {
+	return []EffectiveOutboundMethod{EffectiveOutboundMethodLoadBalancer, EffectiveOutboundMethodNATGateway, EffectiveOutboundMethodUserDefinedRouting}
+}
+
 // EncryptionAtHost enumerates the values for encryption at host.
 type EncryptionAtHost string
 
@@ -51,6 +136,21 @@ func PossibleEncryptionAtHostValues() []EncryptionAtHost {
 	return []EncryptionAtHost{Disabled, Enabled}
 }
 
+// ExistingResourceGroup enumerates the values for existing resource group.
+type ExistingResourceGroup string
+
+const (
+	// ExistingResourceGroupDisabled ...
+	ExistingResourceGroupDisabled ExistingResourceGroup = "Disabled"
+	// ExistingResourceGroupEnabled ...
+	ExistingResourceGroupEnabled ExistingResourceGroup = "Enabled"
+)
+
+// PossibleExistingResourceGroupValues returns an array of possible values for the ExistingResourceGroup const type.
+func PossibleExistingResourceGroupValues() []ExistingResourceGroup {
+	return []ExistingResourceGroup{ExistingResourceGroupDisabled, ExistingResourceGroupEnabled}
+}
+
 // FipsValidatedModules enumerates the values for fips validated modules.
 type FipsValidatedModules string
 
@@ -66,6 +166,55 @@ func PossibleFipsValidatedModulesValues() []FipsValidatedModules {
 	return []FipsValidatedModules{FipsValidatedModulesDisabled, FipsValidatedModulesEnabled}
 }
 
+// LogForwardingType enumerates the values for log forwarding type.
+type LogForwardingType string
+
+const (
+	// AzureMonitor ...
+	AzureMonitor LogForwardingType = "AzureMonitor"
+	// Syslog ...
+	Syslog LogForwardingType = "Syslog"
+)
+
+// PossibleLogForwardingTypeValues returns an array of possible values for the LogForwardingType const type.
+func PossibleLogForwardingTypeValues() []LogForwardingType {
+	return []LogForwardingType{AzureMonitor, Syslog}
+}
+
+// LogType enumerates the values for log type.
+type LogType string
+
+const (
+	// LogTypeApplication ...
+	LogTypeApplication LogType = "Application"
+	// LogTypeAudit ...
+	LogTypeAudit LogType = "Audit"
+	// LogTypeInfrastructure ...
+	LogTypeInfrastructure LogType = "Infrastructure"
+)
+
+// PossibleLogTypeValues returns an array of possible values for the LogType const type.
+func PossibleLogTypeValues() []LogType {
+	return []LogType{LogTypeApplication, LogTypeAudit, LogTypeInfrastructure}
+}
+
+// OpenShiftVersionStatus enumerates the values for open shift version status.
+type OpenShiftVersionStatus string
+
+const (
+	// Deprecated ...
+	Deprecated OpenShiftVersionStatus = "Deprecated"
+	// Installable ...
+	Installable OpenShiftVersionStatus = "Installable"
+	// UpgradeOnly ...
+	UpgradeOnly OpenShiftVersionStatus = "UpgradeOnly"
+)
+
+// PossibleOpenShiftVersionStatusValues returns an array of possible values for the OpenShiftVersionStatus const type.
+func PossibleOpenShiftVersionStatusValues() []OpenShiftVersionStatus {
+	return []OpenShiftVersionStatus{Deprecated, Installable, UpgradeOnly}
+}
+
 // OutboundType enumerates the values for outbound type.
 type OutboundType string
 
@@ -106,6 +255,66 @@ func PossibleProvisioningStateValues() []ProvisioningState {
 	return []ProvisioningState{AdminUpdating, Cancelled, Creating, Deleting, Failed, Succeeded, Updating}
 }
 
+// SeccompProfile enumerates the values for seccomp profile.
+type SeccompProfile string
+
+const (
+	// RuntimeDefault ...
+	RuntimeDefault SeccompProfile = "RuntimeDefault"
+	// Unconfined ...
+	Unconfined SeccompProfile = "Unconfined"
+)
+
+// PossibleSeccompProfileValues returns an array of possible values for the SeccompProfile const type.
+func PossibleSeccompProfileValues() []SeccompProfile {
+	return []SeccompProfile{RuntimeDefault, Unconfined}
+}
+
+// SoftwareDefinedNetwork enumerates the values for software defined network.
+type SoftwareDefinedNetwork string
+
+const (
+	// OpenShiftSDN ...
+	OpenShiftSDN SoftwareDefinedNetwork = "OpenShiftSDN"
+	// OVNKubernetes ...
+	OVNKubernetes SoftwareDefinedNetwork = "OVNKubernetes"
+)
+
+// PossibleSoftwareDefinedNetworkValues returns an array of possible values for the SoftwareDefinedNetwork const type.
+func PossibleSoftwareDefinedNetworkValues() []SoftwareDefinedNetwork {
+	return []SoftwareDefinedNetwork{OpenShiftSDN, OVNKubernetes}
+}
+
+// ValidationSeverity enumerates the values for validation severity.
+type ValidationSeverity string
+
+const (
+	// Error ...
+	Error ValidationSeverity = "Error"
+	// Warning ...
+	Warning ValidationSeverity = "Warning"
+)
+
+// PossibleValidationSeverityValues returns an array of possible values for the ValidationSeverity const type.
+func PossibleValidationSeverityValues() []ValidationSeverity {
+	return []ValidationSeverity{Error, Warning}
+}
+
+// ValidationStatus enumerates the values for validation status.
+type ValidationStatus string
+
+const (
+	// ValidationStatusFailed ...
+	ValidationStatusFailed ValidationStatus = "Failed"
+	// ValidationStatusSucceeded ...
+	ValidationStatusSucceeded ValidationStatus = "Succeeded"
+)
+
+// PossibleValidationStatusValues returns an array of possible values for the ValidationStatus const type.
+func PossibleValidationStatusValues() []ValidationStatus {
+	return []ValidationStatus{ValidationStatusFailed, ValidationStatusSucceeded}
+}
+
 // Visibility enumerates the values for visibility.
 type Visibility string
 
@@ -120,3 +329,28 @@ const (
 func PossibleVisibilityValues() []Visibility {
 	return []Visibility{Private, Public}
 }
+
+// Weekday enumerates the values for weekday.
+type Weekday string
+
+const (
+	// Friday ...
+	Friday Weekday = "Friday"
+	// Monday ...
+	Monday Weekday = "Monday"
+	// Saturday ...
+	Saturday Weekday = "Saturday"
+	// Sunday ...
+	Sunday Weekday = "Sunday"
+	// Thursday ...
+	Thursday Weekday = "Thursday"
+	// Tuesday ...
+	Tuesday Weekday = "Tuesday"
+	// Wednesday ...
+	Wednesday Weekday = "Wednesday"
+)
+
+// PossibleWeekdayValues returns an array of possible values for the Weekday const type.
+func PossibleWeekdayValues() []Weekday {
+	return []Weekday{Friday, Monday, Saturday, Sunday, Thursday, Tuesday, Wednesday}
+}
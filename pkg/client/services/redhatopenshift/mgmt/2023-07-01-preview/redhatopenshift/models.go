@@ -42,6 +42,13 @@ type APIServerProfile struct {
 	IP *string `json:"ip,omitempty"`
 }
 
+// AdmissionWebhookProfile admissionWebhookProfile represents a ValidatingWebhookConfiguration or
+// MutatingWebhookConfiguration which is registered on the cluster.
+type AdmissionWebhookProfile struct {
+	// Configuration - The ValidatingWebhookConfiguration or MutatingWebhookConfiguration of API version admissionregistration.k8s.io/v1, in JSON or YAML.
+	Configuration *string `json:"configuration,omitempty"`
+}
+
 // AzureEntityResource the resource model definition for an Azure Resource Manager resource with an etag.
 type AzureEntityResource struct {
 	// Etag - READ-ONLY; Resource Etag.
@@ -62,6 +69,15 @@ func (aer AzureEntityResource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// AzureFileCSIProfile azureFileCSIProfile represents a storage class which provisions Azure Files shares
+// with the Azure File CSI driver, for volumes with the ReadWriteMany access mode.
+type AzureFileCSIProfile struct {
+	// StorageClassName - The name of the storage class, e.g. azurefile-rwx.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// SkuName - The SKU of the storage accounts of the file shares, e.g. Standard_ZRS or Premium_LRS.  It must be available in the cluster location.  Defaults to Standard_LRS.
+	SkuName *string `json:"skuName,omitempty"`
+}
+
 // CloudError cloudError represents a cloud error.
 type CloudError struct {
 	// Error - An error response from the service.
@@ -80,6 +96,16 @@ type CloudErrorBody struct {
 	Details *[]CloudErrorBody `json:"details,omitempty"`
 }
 
+// ClusterIdentity clusterIdentity represents an Azure identity used by a cluster component.
+type ClusterIdentity struct {
+	// Component - The cluster component which uses the identity. Possible values include: 'CloudControllerManager', 'DiskCSIDriver', 'FileCSIDriver', 'ImageRegistry', 'Ingress', 'MachineAPI'
+	Component ClusterIdentityComponent `json:"component,omitempty"`
+	// ClientID - The client ID of the identity.
+	ClientID *string `json:"clientId,omitempty"`
+	// ObjectID - The object ID of the identity.
+	ObjectID *string `json:"objectId,omitempty"`
+}
+
 // ClusterProfile clusterProfile represents a cluster profile.
 type ClusterProfile struct {
 	// PullSecret - The pull secret for the cluster.
@@ -92,6 +118,16 @@ type ClusterProfile struct {
 	ResourceGroupID *string `json:"resourceGroupId,omitempty"`
 	// FipsValidatedModules - If FIPS validated crypto modules are used. Possible values include: 'FipsValidatedModulesDisabled', 'FipsValidatedModulesEnabled'
 	FipsValidatedModules FipsValidatedModules `json:"fipsValidatedModules,omitempty"`
+	// DNSRecordTTL - The TTL in seconds of the cluster API and ingress DNS records, if the cluster domain is managed by the RP.
+	DNSRecordTTL *int32 `json:"dnsRecordTtl,omitempty"`
+	// ResourceNameTemplate - A template for the names of the resources which the RP creates in the cluster resource group, such as the load balancers, network interfaces and disks. The placeholder {name} is replaced with the cluster name, and a stable random suffix is always appended to keep the names unique.
+	ResourceNameTemplate *string `json:"resourceNameTemplate,omitempty"`
+	// ExistingResourceGroup - If the cluster resource group already exists. The resource group must be empty and in the same location as the cluster. When the cluster is deleted, the resources which belong to the cluster are deleted but the resource group itself is not. Possible values include: 'ExistingResourceGroupDisabled', 'ExistingResourceGroupEnabled'
+	ExistingResourceGroup ExistingResourceGroup `json:"existingResourceGroup,omitempty"`
+	// TimeZone - The time zone of the cluster nodes, as a tz database name such as Europe/London. If not specified, the nodes use UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+	// DNSZoneID - The resource ID of an Azure DNS zone, which may be in another subscription, in which to create the cluster API and ingress DNS records. Only valid with a custom domain, which must be the zone or within it. The cluster service principal must have DNS Zone Contributor on the zone.
+	DNSZoneID *string `json:"dnsZoneId,omitempty"`
 }
 
 // ConsoleProfile consoleProfile represents a console profile.
@@ -100,6 +136,40 @@ type ConsoleProfile struct {
 	URL *string `json:"url,omitempty"`
 }
 
+// CustomerLoadBalancerProfile customerLoadBalancerProfile represents an existing Standard load
+// balancer, in the subscription and location of the cluster, on which the RP registers the backends of
+// the API server and the default ingress.  The API server and the default ingress must have Private
+// visibility.
+type CustomerLoadBalancerProfile struct {
+	// ID - The resource ID of the load balancer.
+	ID *string `json:"id,omitempty"`
+	// APIServerFrontendIPConfiguration - The name of the frontend IP configuration of the load balancer which serves the API server on port 6443.
+	APIServerFrontendIPConfiguration *string `json:"apiServerFrontendIpConfiguration,omitempty"`
+	// IngressFrontendIPConfiguration - The name of the frontend IP configuration of the load balancer which serves the default ingress on ports 80 and 443.
+	IngressFrontendIPConfiguration *string `json:"ingressFrontendIpConfiguration,omitempty"`
+}
+
+// DefaultStorageClassProfile defaultStorageClassProfile represents the default storage class of the
+// cluster.  Any other storage class marked as default is unmarked.
+type DefaultStorageClassProfile struct {
+	// Name - The name of the storage class.  If no parameters are provided, it must be an existing storage class, e.g. managed-csi or managed-csi-encrypted-cmk.
+	Name *string `json:"name,omitempty"`
+	// Parameters - The Azure Disk CSI driver parameters of the storage class, e.g. skuName or cachingMode.  If provided, a storage class with these parameters is created.
+	Parameters map[string]*string `json:"parameters"`
+}
+
+// MarshalJSON is the custom marshaler for DefaultStorageClassProfile.
+func (dscp DefaultStorageClassProfile) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if dscp.Name != nil {
+		objectMap["name"] = dscp.Name
+	}
+	if dscp.Parameters != nil {
+		objectMap["parameters"] = dscp.Parameters
+	}
+	return json.Marshal(objectMap)
+}
+
 // Display display represents the display details of an operation.
 type Display struct {
 	// Provider - Friendly name of the resource provider.
@@ -119,6 +189,72 @@ type EffectiveOutboundIP struct {
 	ID *string `json:"id,omitempty"`
 }
 
+// EffectiveOutboundIPPrefix effectiveOutboundIPPrefix represents the effective outbound IP prefix of the
+// cluster public load balancer.
+type EffectiveOutboundIPPrefix struct {
+	// ID - The resource ID of the public IP prefix.
+	ID *string `json:"id,omitempty"`
+	// IPPrefix - The CIDR of the public IP prefix.
+	IPPrefix *string `json:"ipPrefix,omitempty"`
+}
+
+// EffectiveOutboundProfile effectiveOutboundProfile represents the outbound connectivity method which
+// the worker nodes actually use.  A default route of the route table of the worker subnet to anything but
+// the internet takes precedence over a NAT gateway on the subnet, which takes precedence over the outbound
+// rules of the public load balancer.
+type EffectiveOutboundProfile struct {
+	// Method - The outbound connectivity method. Possible values include: 'EffectiveOutboundMethodLoadBalancer', 'EffectiveOutboundMethodNATGateway', 'EffectiveOutboundMethodUserDefinedRouting'
+	Method EffectiveOutboundMethod `json:"method,omitempty"`
+	// OutboundIPAddresses - The public IP addresses, and the public IP prefixes in CIDR notation, from which the egress originates.  Not set if the egress is user defined.
+	OutboundIPAddresses *[]string `json:"outboundIpAddresses,omitempty"`
+	// NatGatewayID - The resource ID of the NAT gateway of the worker subnet, if any.
+	NatGatewayID *string `json:"natGatewayId,omitempty"`
+	// RouteTableID - The resource ID of the route table of the worker subnet, if any.
+	RouteTableID *string `json:"routeTableId,omitempty"`
+	// NextHopIPAddress - The next hop IP address of the default route of the route table, if the egress is user defined through a virtual appliance.
+	NextHopIPAddress *string `json:"nextHopIpAddress,omitempty"`
+}
+
+// EgressDestination egressDestination represents a destination outside the cluster.  Exactly one of
+// cidr and dnsName must be set.
+type EgressDestination struct {
+	// Cidr - The IP range of the destination, e.g. 203.0.113.0/24.
+	Cidr *string `json:"cidr,omitempty"`
+	// DNSName - The DNS name of the destination, e.g. www.example.com.
+	DNSName *string `json:"dnsName,omitempty"`
+}
+
+// EgressFirewallProfile egressFirewallProfile represents the destinations outside the cluster to which
+// the pods of the customer namespaces may connect.  Egress to any other destination is denied.
+type EgressFirewallProfile struct {
+	// AllowedDestinations - The allowed destinations.  The API servers of the cluster should be allowed, as pods which use the Kubernetes API cannot reach them otherwise.
+	AllowedDestinations *[]EgressDestination `json:"allowedDestinations,omitempty"`
+}
+
+// EvictionThresholds evictionThresholds represents the eviction thresholds of the kubelet eviction
+// signals.  Each threshold is a quantity, e.g. 500Mi, or a percentage of the capacity, e.g. 10%.
+type EvictionThresholds struct {
+	// MemoryAvailable - The threshold of the memory.available signal.
+	MemoryAvailable *string `json:"memoryAvailable,omitempty"`
+	// NodefsAvailable - The threshold of the nodefs.available signal.
+	NodefsAvailable *string `json:"nodefsAvailable,omitempty"`
+	// ImagefsAvailable - The threshold of the imagefs.available signal.
+	ImagefsAvailable *string `json:"imagefsAvailable,omitempty"`
+}
+
+// IdentityProviderProfile identityProviderProfile represents an OpenID Connect identity provider, e.g.
+// Azure AD, which is configured in the cluster OAuth server at install time.
+type IdentityProviderProfile struct {
+	// Name - The name of the identity provider, which is shown on the login page and prefixes the names of its identities.
+	Name *string `json:"name,omitempty"`
+	// Issuer - The issuer URL of the identity provider, e.g. https://login.microsoftonline.com/<tenant id>/v2.0.  It must use https and serve /.well-known/openid-configuration.
+	Issuer *string `json:"issuer,omitempty"`
+	// ClientID - The client ID of the application registered with the identity provider.
+	ClientID *string `json:"clientId,omitempty"`
+	// ClientSecret - The client secret of the application registered with the identity provider. It is not returned in responses.
+	ClientSecret *string `json:"clientSecret,omitempty"`
+}
+
 // IngressProfile ingressProfile represents an ingress profile.
 type IngressProfile struct {
 	// Name - The ingress profile name.
@@ -127,6 +263,10 @@ type IngressProfile struct {
 	Visibility Visibility `json:"visibility,omitempty"`
 	// IP - The IP of the ingress.
 	IP *string `json:"ip,omitempty"`
+	// Domain - The domain of additional ingress profiles. It is not set on the default ingress profile.
+	Domain *string `json:"domain,omitempty"`
+	// ServingCertificate - The PEM encoded private key and certificate chain served for *.<domain> by additional ingress profiles. It is not returned in responses.
+	ServingCertificate *string `json:"servingCertificate,omitempty"`
 }
 
 // LoadBalancerProfile loadBalancerProfile represents the profile of the cluster public load balancer.
@@ -135,6 +275,10 @@ type LoadBalancerProfile struct {
 	ManagedOutboundIps *ManagedOutboundIPs `json:"managedOutboundIps,omitempty"`
 	// EffectiveOutboundIps - READ-ONLY; The list of effective outbound IP addresses of the public load balancer.
 	EffectiveOutboundIps *[]EffectiveOutboundIP `json:"effectiveOutboundIps,omitempty"`
+	// ManagedOutboundIPPrefix - The desired managed outbound IP prefix for the cluster public load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of individual managed outbound IPs.
+	ManagedOutboundIPPrefix *ManagedOutboundIPPrefix `json:"managedOutboundIpPrefix,omitempty"`
+	// EffectiveOutboundIPPrefix - READ-ONLY; The effective outbound IP prefix of the public load balancer.
+	EffectiveOutboundIPPrefix *EffectiveOutboundIPPrefix `json:"effectiveOutboundIpPrefix,omitempty"`
 	// OutboundIps - The desired outbound IP resources for the cluster load balancer.
 	OutboundIps *[]OutboundIP `json:"outboundIps,omitempty"`
 	// OutboundIPPrefixes - The desired outbound IP Prefix resources for the cluster load balancer.
@@ -149,6 +293,9 @@ func (lbp LoadBalancerProfile) MarshalJSON() ([]byte, error) {
 	if lbp.ManagedOutboundIps != nil {
 		objectMap["managedOutboundIps"] = lbp.ManagedOutboundIps
 	}
+	if lbp.ManagedOutboundIPPrefix != nil {
+		objectMap["managedOutboundIpPrefix"] = lbp.ManagedOutboundIPPrefix
+	}
 	if lbp.OutboundIps != nil {
 		objectMap["outboundIps"] = lbp.OutboundIps
 	}
@@ -161,6 +308,21 @@ func (lbp LoadBalancerProfile) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// LogForwardingProfile logForwardingProfile represents the off-cluster destination to which the cluster
+// logs are forwarded by the OpenShift Logging operator, which must be installed on the cluster.
+type LogForwardingProfile struct {
+	// Type - The type of the destination. Possible values include: 'Syslog', 'AzureMonitor'
+	Type LogForwardingType `json:"type,omitempty"`
+	// URL - The URL of the syslog server, e.g. tls://syslog.example.com:6514.  The scheme is tcp, tls or udp.  Only used by the Syslog type.
+	URL *string `json:"url,omitempty"`
+	// WorkspaceID - The ID of the Log Analytics workspace to which the logs are sent.  Only used by the AzureMonitor type.
+	WorkspaceID *string `json:"workspaceId,omitempty"`
+	// SharedKey - The primary or secondary key of the Log Analytics workspace.  Only used by the AzureMonitor type.  It is not returned in responses.
+	SharedKey *string `json:"sharedKey,omitempty"`
+	// LogTypes - The types of logs which are forwarded.  If omitted, all of them are forwarded.
+	LogTypes *[]LogType `json:"logTypes,omitempty"`
+}
+
 // MachinePool machinePool represents a MachinePool
 type MachinePool struct {
 	autorest.Response `json:"-"`
@@ -459,6 +621,27 @@ func (mpu *MachinePoolUpdate) UnmarshalJSON(body []byte) error {
 	return nil
 }
 
+// MaintenanceWindow maintenanceWindow represents the weekly window in which automated maintenance of the
+// cluster may start.  Maintenance requested outside the window is deferred to the start of the next window,
+// unless it is urgent.
+type MaintenanceWindow struct {
+	// Days - The days of the week on which the window opens.
+	Days *[]Weekday `json:"days,omitempty"`
+	// StartTime - The time of day at which the window opens, in HH:MM 24 hour format.
+	StartTime *string `json:"startTime,omitempty"`
+	// DurationHours - The duration of the window in hours.  Allowed values are in the range of 1 - 24.
+	DurationHours *int32 `json:"durationHours,omitempty"`
+	// TimeZone - The IANA time zone of the start time, e.g. Europe/Berlin.  Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// ManagedOutboundIPPrefix managedOutboundIPPrefix represents the desired managed outbound IP prefix for the
+// cluster public load balancer.
+type ManagedOutboundIPPrefix struct {
+	// PrefixLength - PrefixLength represents the length of the IPv4 public IP prefix created and managed by Azure for the cluster public load balancer.  Allowed values are in the range of 28 - 31.
+	PrefixLength *int32 `json:"prefixLength,omitempty"`
+}
+
 // ManagedOutboundIPs managedOutboundIPs represents the desired managed outbound IPs for the cluster public
 // load balancer.
 type ManagedOutboundIPs struct {
@@ -478,16 +661,73 @@ type MasterProfile struct {
 	DiskEncryptionSetID *string `json:"diskEncryptionSetId,omitempty"`
 }
 
+// MonitoringProfile monitoringProfile represents the retention and persistent storage of the platform
+// Prometheus of the cluster monitoring stack.
+type MonitoringProfile struct {
+	// Retention - The time for which metrics are kept, e.g. 30d, between 1d and 90d.  Retention beyond 15d requires persistent storage.  Defaults to 15d.
+	Retention *string `json:"retention,omitempty"`
+	// StorageSize - The size of the persistent volume of each Prometheus replica, e.g. 100Gi, between 10Gi and 1Ti.  If omitted, Prometheus uses ephemeral storage and its metrics are lost when its pods are rescheduled.
+	StorageSize *string `json:"storageSize,omitempty"`
+	// StorageClassName - The storage class of the persistent volumes, e.g. managed-csi.  It must provision Azure disks.  Defaults to the default storage class of the cluster.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
 // NetworkProfile networkProfile represents a network profile.
 type NetworkProfile struct {
 	// PodCidr - The CIDR used for OpenShift/Kubernetes Pods.
 	PodCidr *string `json:"podCidr,omitempty"`
 	// ServiceCidr - The CIDR used for OpenShift/Kubernetes Services.
 	ServiceCidr *string `json:"serviceCidr,omitempty"`
+	// SoftwareDefinedNetwork - The software defined network (SDN) to use when installing the cluster. If not specified, the default for the OpenShift version is used. Possible values include: 'OVNKubernetes', 'OpenShiftSDN'
+	SoftwareDefinedNetwork SoftwareDefinedNetwork `json:"softwareDefinedNetwork,omitempty"`
 	// OutboundType - The OutboundType used for egress traffic. Possible values include: 'Loadbalancer', 'UserDefinedRouting'
 	OutboundType OutboundType `json:"outboundType,omitempty"`
+	// MaxPods - The maximum number of pods per node. If not specified, the kubelet default of 250 is used.
+	MaxPods *int32 `json:"maxPods,omitempty"`
 	// LoadBalancerProfile - The cluster load balancer profile.
 	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
+	// CustomerLoadBalancerProfile - An existing load balancer which fronts the API server and the default ingress instead of the managed load balancer frontends.
+	CustomerLoadBalancerProfile *CustomerLoadBalancerProfile `json:"customerLoadBalancerProfile,omitempty"`
+	// EffectiveOutboundProfile - READ-ONLY; How the egress of the worker nodes reaches the internet, as found on the live Azure resources.
+	EffectiveOutboundProfile *EffectiveOutboundProfile `json:"effectiveOutboundProfile,omitempty"`
+}
+
+// MarshalJSON is the custom marshaler for NetworkProfile.
+func (np NetworkProfile) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if np.PodCidr != nil {
+		objectMap["podCidr"] = np.PodCidr
+	}
+	if np.ServiceCidr != nil {
+		objectMap["serviceCidr"] = np.ServiceCidr
+	}
+	if np.SoftwareDefinedNetwork != "" {
+		objectMap["softwareDefinedNetwork"] = np.SoftwareDefinedNetwork
+	}
+	if np.OutboundType != "" {
+		objectMap["outboundType"] = np.OutboundType
+	}
+	if np.MaxPods != nil {
+		objectMap["maxPods"] = np.MaxPods
+	}
+	if np.LoadBalancerProfile != nil {
+		objectMap["loadBalancerProfile"] = np.LoadBalancerProfile
+	}
+	if np.CustomerLoadBalancerProfile != nil {
+		objectMap["customerLoadBalancerProfile"] = np.CustomerLoadBalancerProfile
+	}
+	return json.Marshal(objectMap)
+}
+
+// NodeEvictionProfile nodeEvictionProfile represents the kubelet eviction thresholds of the nodes of
+// the cluster.  Changing them causes a rolling reboot of the nodes.
+type NodeEvictionProfile struct {
+	// Hard - The hard eviction thresholds, at which pods are evicted immediately.  A threshold which is omitted keeps its platform value.
+	Hard *EvictionThresholds `json:"hard,omitempty"`
+	// Soft - The soft eviction thresholds, at which pods are evicted gracefully once the threshold has been met for the soft grace period.
+	Soft *EvictionThresholds `json:"soft,omitempty"`
+	// SoftGracePeriod - How long a soft eviction threshold must be met before pods are evicted, e.g. 1m30s.  Required if soft thresholds are set.
+	SoftGracePeriod *string `json:"softGracePeriod,omitempty"`
 }
 
 // OpenShiftCluster openShiftCluster represents an Azure Red Hat OpenShift cluster.
@@ -608,6 +848,12 @@ type OpenShiftClusterAdminKubeconfig struct {
 	autorest.Response `json:"-"`
 	// Kubeconfig - The base64-encoded kubeconfig file.
 	Kubeconfig *string `json:"kubeconfig,omitempty"`
+	// APIServerURL - The URL to access the cluster API server.  Only returned in the Structured format.
+	APIServerURL *string `json:"apiServerUrl,omitempty"`
+	// ClientCertificate - The base64-encoded PEM client certificate.  Only returned in the Structured format.
+	ClientCertificate *string `json:"clientCertificate,omitempty"`
+	// ClientKey - The base64-encoded PEM client key.  Only returned in the Structured format.
+	ClientKey *string `json:"clientKey,omitempty"`
 }
 
 // OpenShiftClusterCredentials openShiftClusterCredentials represents an OpenShift cluster's credentials.
@@ -788,16 +1034,130 @@ type OpenShiftClusterProperties struct {
 	ConsoleProfile *ConsoleProfile `json:"consoleProfile,omitempty"`
 	// ServicePrincipalProfile - The cluster service principal profile.
 	ServicePrincipalProfile *ServicePrincipalProfile `json:"servicePrincipalProfile,omitempty"`
+	// ClusterIdentities - READ-ONLY; The Azure identities used by the cluster components, to which Azure RBAC and policy can be scoped.
+	ClusterIdentities *[]ClusterIdentity `json:"clusterIdentities,omitempty"`
 	// NetworkProfile - The cluster network profile.
 	NetworkProfile *NetworkProfile `json:"networkProfile,omitempty"`
 	// MasterProfile - The cluster master profile.
 	MasterProfile *MasterProfile `json:"masterProfile,omitempty"`
 	// WorkerProfiles - The cluster worker profiles.
 	WorkerProfiles *[]WorkerProfile `json:"workerProfiles,omitempty"`
+	// WorkerProfilesScaleStatus - READ-ONLY; The progress of the scale operations of the cluster worker profiles.
+	WorkerProfilesScaleStatus *[]WorkerProfileScale `json:"workerProfilesScaleStatus,omitempty"`
+	// WorkerMachineSetsProgress - READ-ONLY; The progress of the creation of the machine sets of the additional worker profiles during install.
+	WorkerMachineSetsProgress *WorkerMachineSetsProgress `json:"workerMachineSetsProgress,omitempty"`
 	// ApiserverProfile - The cluster API server profile.
 	ApiserverProfile *APIServerProfile `json:"apiserverProfile,omitempty"`
 	// IngressProfiles - The cluster ingress profiles.
 	IngressProfiles *[]IngressProfile `json:"ingressProfiles,omitempty"`
+	// MaintenanceWindow - The cluster maintenance window.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// ProjectTemplateProfile - The default resource quota and limit range of new projects.
+	ProjectTemplateProfile *ProjectTemplateProfile `json:"projectTemplateProfile,omitempty"`
+	// IdentityProviderProfile - The OpenID Connect identity provider with which users log in to the cluster.
+	IdentityProviderProfile *IdentityProviderProfile `json:"identityProviderProfile,omitempty"`
+	// RegistryMirrorProfiles - The pull-through caches through which images of upstream registries are pulled by digest.
+	RegistryMirrorProfiles *[]RegistryMirrorProfile `json:"registryMirrorProfiles,omitempty"`
+	// DefaultStorageClassProfile - The storage class which is the default storage class of the cluster.
+	DefaultStorageClassProfile *DefaultStorageClassProfile `json:"defaultStorageClassProfile,omitempty"`
+	// AzureFileCsiProfile - The Azure Files storage class for ReadWriteMany volumes.  If omitted, no Azure Files storage class is created.
+	AzureFileCsiProfile *AzureFileCSIProfile `json:"azureFileCsiProfile,omitempty"`
+	// MonitoringProfile - The retention and storage of the platform Prometheus.  If omitted, the platform defaults are used.
+	MonitoringProfile *MonitoringProfile `json:"monitoringProfile,omitempty"`
+	// UpgradeProfile - How the worker nodes are updated during upgrades.  If omitted, the machine config pool chooses the order and the pace of the updates.
+	UpgradeProfile *UpgradeProfile `json:"upgradeProfile,omitempty"`
+	// NodeEvictionProfile - The kubelet eviction thresholds of the nodes.  If omitted, the platform thresholds are used.
+	NodeEvictionProfile *NodeEvictionProfile `json:"nodeEvictionProfile,omitempty"`
+	// SecurityProfile - The default seccomp profile and the SELinux booleans of the nodes.  Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
+	// LogForwardingProfile - The off-cluster destination to which the cluster logs are forwarded.  If omitted, logs are not forwarded.
+	LogForwardingProfile *LogForwardingProfile `json:"logForwardingProfile,omitempty"`
+	// AdmissionWebhookProfiles - The admission webhook configurations which are registered on the cluster.  If omitted, none are registered.
+	AdmissionWebhookProfiles *[]AdmissionWebhookProfile `json:"admissionWebhookProfiles,omitempty"`
+	// SchedulerProfile - The cluster-wide default node selector of workloads.  If omitted, workloads are not constrained to any nodes by default.
+	SchedulerProfile *SchedulerProfile `json:"schedulerProfile,omitempty"`
+	// EgressFirewallProfile - The destinations outside the cluster to which the pods of the customer namespaces may connect.  If omitted, egress is not restricted.
+	EgressFirewallProfile *EgressFirewallProfile `json:"egressFirewallProfile,omitempty"`
+	// Template - A template exported from a cluster, whose properties pre-fill those which the request does not set.  Only used when the cluster is created; it is not returned in responses.
+	Template *OpenShiftClusterTemplate `json:"template,omitempty"`
+}
+
+// MarshalJSON is the custom marshaler for OpenShiftClusterProperties.
+func (ocp OpenShiftClusterProperties) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if ocp.ProvisioningState != "" {
+		objectMap["provisioningState"] = ocp.ProvisioningState
+	}
+	if ocp.ClusterProfile != nil {
+		objectMap["clusterProfile"] = ocp.ClusterProfile
+	}
+	if ocp.ConsoleProfile != nil {
+		objectMap["consoleProfile"] = ocp.ConsoleProfile
+	}
+	if ocp.ServicePrincipalProfile != nil {
+		objectMap["servicePrincipalProfile"] = ocp.ServicePrincipalProfile
+	}
+	if ocp.NetworkProfile != nil {
+		objectMap["networkProfile"] = ocp.NetworkProfile
+	}
+	if ocp.MasterProfile != nil {
+		objectMap["masterProfile"] = ocp.MasterProfile
+	}
+	if ocp.WorkerProfiles != nil {
+		objectMap["workerProfiles"] = ocp.WorkerProfiles
+	}
+	if ocp.ApiserverProfile != nil {
+		objectMap["apiserverProfile"] = ocp.ApiserverProfile
+	}
+	if ocp.IngressProfiles != nil {
+		objectMap["ingressProfiles"] = ocp.IngressProfiles
+	}
+	if ocp.MaintenanceWindow != nil {
+		objectMap["maintenanceWindow"] = ocp.MaintenanceWindow
+	}
+	if ocp.ProjectTemplateProfile != nil {
+		objectMap["projectTemplateProfile"] = ocp.ProjectTemplateProfile
+	}
+	if ocp.IdentityProviderProfile != nil {
+		objectMap["identityProviderProfile"] = ocp.IdentityProviderProfile
+	}
+	if ocp.RegistryMirrorProfiles != nil {
+		objectMap["registryMirrorProfiles"] = ocp.RegistryMirrorProfiles
+	}
+	if ocp.DefaultStorageClassProfile != nil {
+		objectMap["defaultStorageClassProfile"] = ocp.DefaultStorageClassProfile
+	}
+	if ocp.AzureFileCsiProfile != nil {
+		objectMap["azureFileCsiProfile"] = ocp.AzureFileCsiProfile
+	}
+	if ocp.MonitoringProfile != nil {
+		objectMap["monitoringProfile"] = ocp.MonitoringProfile
+	}
+	if ocp.UpgradeProfile != nil {
+		objectMap["upgradeProfile"] = ocp.UpgradeProfile
+	}
+	if ocp.NodeEvictionProfile != nil {
+		objectMap["nodeEvictionProfile"] = ocp.NodeEvictionProfile
+	}
+	if ocp.SecurityProfile != nil {
+		objectMap["securityProfile"] = ocp.SecurityProfile
+	}
+	if ocp.LogForwardingProfile != nil {
+		objectMap["logForwardingProfile"] = ocp.LogForwardingProfile
+	}
+	if ocp.AdmissionWebhookProfiles != nil {
+		objectMap["admissionWebhookProfiles"] = ocp.AdmissionWebhookProfiles
+	}
+	if ocp.SchedulerProfile != nil {
+		objectMap["schedulerProfile"] = ocp.SchedulerProfile
+	}
+	if ocp.EgressFirewallProfile != nil {
+		objectMap["egressFirewallProfile"] = ocp.EgressFirewallProfile
+	}
+	if ocp.Template != nil {
+		objectMap["template"] = ocp.Template
+	}
+	return json.Marshal(objectMap)
 }
 
 // OpenShiftClustersCreateOrUpdateFuture an abstraction for monitoring and retrieving the results of a
@@ -923,6 +1283,48 @@ func (future *OpenShiftClustersUpdateFuture) result(client OpenShiftClustersClie
 	return
 }
 
+// OpenShiftClusterTemplate openShiftClusterTemplate represents the creatable configuration of an
+// OpenShift cluster, without its secrets and the identifiers of the resources it uses.  It can be passed
+// when creating a cluster to pre-fill the properties which the request does not set.
+type OpenShiftClusterTemplate struct {
+	autorest.Response `json:"-"`
+	// OpenShiftClusterProperties - The cluster properties.
+	*OpenShiftClusterProperties `json:"properties,omitempty"`
+}
+
+// MarshalJSON is the custom marshaler for OpenShiftClusterTemplate.
+func (osct OpenShiftClusterTemplate) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if osct.OpenShiftClusterProperties != nil {
+		objectMap["properties"] = osct.OpenShiftClusterProperties
+	}
+	return json.Marshal(objectMap)
+}
+
+// UnmarshalJSON is the custom unmarshaler for OpenShiftClusterTemplate struct.
+func (osct *OpenShiftClusterTemplate) UnmarshalJSON(body []byte) error {
+	var m map[string]*json.RawMessage
+	err := json.Unmarshal(body, &m)
+	if err != nil {
+		return err
+	}
+	for k, v := range m {
+		switch k {
+		case "properties":
+			if v != nil {
+				var openShiftClusterProperties OpenShiftClusterProperties
+				err = json.Unmarshal(*v, &openShiftClusterProperties)
+				if err != nil {
+					return err
+				}
+				osct.OpenShiftClusterProperties = &openShiftClusterProperties
+			}
+		}
+	}
+
+	return nil
+}
+
 // OpenShiftClusterUpdate openShiftCluster represents an Azure Red Hat OpenShift cluster.
 type OpenShiftClusterUpdate struct {
 	// Tags - The resource tags.
@@ -987,6 +1389,16 @@ func (oscu *OpenShiftClusterUpdate) UnmarshalJSON(body []byte) error {
 	return nil
 }
 
+// OpenShiftClusterValidationFindings openShiftClusterValidationFindings represents the findings of
+// validating a proposed OpenShift cluster.
+type OpenShiftClusterValidationFindings struct {
+	autorest.Response `json:"-"`
+	// Status - The status of the validation.  Failed if any finding has severity Error. Possible values include: 'ValidationStatusFailed', 'ValidationStatusSucceeded'
+	Status ValidationStatus `json:"status,omitempty"`
+	// Findings - The findings of the validation.
+	Findings *[]ValidationFinding `json:"findings,omitempty"`
+}
+
 // OpenShiftVersion openShiftVersion represents an OpenShift version that can be installed.
 type OpenShiftVersion struct {
 	// OpenShiftVersionProperties - The properties for the OpenShiftVersion resource.
@@ -1229,6 +1641,25 @@ func NewOpenShiftVersionListPage(cur OpenShiftVersionList, getNextPage func(cont
 	}
 }
 
+// OpenShiftVersionMatrix openShiftVersionMatrix represents the OpenShift versions supported in a location and
+// the versions clusters at each of them can be upgraded to.
+type OpenShiftVersionMatrix struct {
+	autorest.Response `json:"-"`
+	// Value - The supported versions, sorted by version.
+	Value *[]OpenShiftVersionMatrixVersion `json:"value,omitempty"`
+}
+
+// OpenShiftVersionMatrixVersion openShiftVersionMatrixVersion represents an OpenShift version supported in a
+// location.
+type OpenShiftVersionMatrixVersion struct {
+	// Version - The version.
+	Version *string `json:"version,omitempty"`
+	// Status - The status of the version.  Installable means that clusters can be installed at the version.  UpgradeOnly means that existing clusters can be upgraded to the version but not installed at it.  Deprecated means that clusters at the version should be upgraded off it. Possible values include: 'Deprecated', 'Installable', 'UpgradeOnly'
+	Status OpenShiftVersionStatus `json:"status,omitempty"`
+	// UpgradeVersions - The versions clusters at the version can be upgraded to.
+	UpgradeVersions *[]string `json:"upgradeVersions,omitempty"`
+}
+
 // OpenShiftVersionProperties openShiftVersionProperties represents the properties of an OpenShiftVersion.
 type OpenShiftVersionProperties struct {
 	// Version - Version represents the version to create the cluster at.
@@ -1417,6 +1848,40 @@ type OutboundIPPrefix struct {
 	ID *string `json:"id,omitempty"`
 }
 
+// ProjectTemplateProfile projectTemplateProfile represents the default resource quota and limit range
+// which are created in each project requested by a user.  Values are Kubernetes resource quantities, e.g.
+// 500m or 2Gi.
+type ProjectTemplateProfile struct {
+	// ResourceQuota - The hard limits of the default resource quota, keyed by quota resource, e.g. pods, requests.cpu or count/deployments.apps.
+	ResourceQuota map[string]*string `json:"resourceQuota"`
+	// DefaultLimits - The default container resource limits of the default limit range, keyed by cpu, memory or ephemeral-storage.
+	DefaultLimits map[string]*string `json:"defaultLimits"`
+	// DefaultRequests - The default container resource requests of the default limit range, keyed by cpu, memory or ephemeral-storage.  Requests may not exceed the corresponding default limits.
+	DefaultRequests map[string]*string `json:"defaultRequests"`
+}
+
+// MarshalJSON is the custom marshaler for ProjectTemplateProfile.
+func (ptp ProjectTemplateProfile) MarshalJSON() ([]byte, error) {
+	objectMap := make(map[string]interface{})
+	if ptp.ResourceQuota != nil {
+		objectMap["resourceQuota"] = ptp.ResourceQuota
+	}
+	if ptp.DefaultLimits != nil {
+		objectMap["defaultLimits"] = ptp.DefaultLimits
+	}
+	if ptp.DefaultRequests != nil {
+		objectMap["defaultRequests"] = ptp.DefaultRequests
+	}
+	return json.Marshal(objectMap)
+}
+
+// ProximityPlacementGroupProfile proximityPlacementGroupProfile represents the proximity placement group
+// of the VMs of a worker profile.
+type ProximityPlacementGroupProfile struct {
+	// ID - The resource ID of an existing proximity placement group.  If unset, a proximity placement group is created in the cluster resource group.
+	ID *string `json:"id,omitempty"`
+}
+
 // ProxyResource the resource model definition for a Azure Resource Manager proxy resource. It will not
 // have tags and a location
 type ProxyResource struct {
@@ -1436,6 +1901,16 @@ func (pr ProxyResource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// RegistryMirrorProfile registryMirrorProfile represents a pull-through cache, e.g. an Azure Container
+// Registry cache rule, from which the cluster pulls the images of an upstream registry by digest, falling back to
+// the upstream registry if the cache is unavailable.
+type RegistryMirrorProfile struct {
+	// Source - The upstream registry, e.g. docker.io, optionally followed by a repository path, e.g. docker.io/library.
+	Source *string `json:"source,omitempty"`
+	// Mirror - The pull-through cache, e.g. myregistry.azurecr.io/docker-hub, optionally followed by a repository path.  It must be reachable over https.
+	Mirror *string `json:"mirror,omitempty"`
+}
+
 // Resource common fields that are returned in the response for all Azure Resource Manager resources
 type Resource struct {
 	// ID - READ-ONLY; Fully qualified resource ID for the resource. Ex - /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}
@@ -1454,6 +1929,12 @@ func (r Resource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// SchedulerProfile schedulerProfile represents the configuration of the cluster scheduler.
+type SchedulerProfile struct {
+	// DefaultNodeSelector - The node labels, e.g. node-role.kubernetes.io/app=,region=east, to whose nodes the pods of projects without a node selector of their own are constrained.  At least one worker node must have the labels.
+	DefaultNodeSelector *string `json:"defaultNodeSelector,omitempty"`
+}
+
 // Secret secret represents a secret.
 type Secret struct {
 	autorest.Response `json:"-"`
@@ -1753,6 +2234,15 @@ func (su *SecretUpdate) UnmarshalJSON(body []byte) error {
 	return nil
 }
 
+// SecurityProfile securityProfile represents the seccomp and SELinux defaults of the nodes of the
+// cluster.
+type SecurityProfile struct {
+	// DefaultSeccompProfile - The seccomp profile of containers which do not set one.  Unconfined, the platform behaviour, runs them without a seccomp profile.  RuntimeDefault applies the default profile of the container runtime; containers which need system calls it blocks must then set the Unconfined seccomp profile explicitly and be admitted by a security context constraint which allows it.  Supported from OpenShift 4.12. Possible values include: 'Unconfined', 'RuntimeDefault'
+	DefaultSeccompProfile SeccompProfile `json:"defaultSeccompProfile,omitempty"`
+	// SeLinuxBooleans - The SELinux booleans to turn on: deny_ptrace, which prevents processes from tracing others, including debuggers, and deny_execmem, which prevents unconfined processes from using writable and executable memory, including some just-in-time compilers.
+	SeLinuxBooleans *[]string `json:"seLinuxBooleans,omitempty"`
+}
+
 // ServicePrincipalProfile servicePrincipalProfile represents a service principal profile.
 type ServicePrincipalProfile struct {
 	// ClientID - The client ID used for the cluster.
@@ -2403,6 +2893,35 @@ func (tr TrackedResource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(objectMap)
 }
 
+// UpgradeProfile upgradeProfile represents how the worker nodes of the cluster are updated during upgrades.
+type UpgradeProfile struct {
+	// LastNodeName - The name of a worker node which is updated after all the other worker nodes, e.g. to keep a critical singleton workload running for as long as possible.
+	LastNodeName *string `json:"lastNodeName,omitempty"`
+	// MaxUnavailable - The number, e.g. 2, or the percentage, e.g. 10%, of worker nodes which may be unavailable at the same time while they are updated, between 1 and 20 nodes or 1% and 50%.  Defaults to the platform value of 1 node.
+	MaxUnavailable *string `json:"maxUnavailable,omitempty"`
+}
+
+// ValidationFinding validationFinding represents a failed validation.
+type ValidationFinding struct {
+	// Severity - The severity of the finding.  Error means that creating the cluster would fail.  Warning means that the validation could not be completed. Possible values include: 'Error', 'Warning'
+	Severity ValidationSeverity `json:"severity,omitempty"`
+	// Code - The error code of the finding.
+	Code *string `json:"code,omitempty"`
+	// Target - The property the finding refers to.
+	Target *string `json:"target,omitempty"`
+	// Message - The message of the finding.
+	Message *string `json:"message,omitempty"`
+}
+
+// WorkerMachineSetsProgress workerMachineSetsProgress represents the progress of the creation of the
+// worker machine sets.
+type WorkerMachineSetsProgress struct {
+	// Created - The number of worker machine sets created.
+	Created *int32 `json:"created,omitempty"`
+	// Total - The number of worker machine sets to create.
+	Total *int32 `json:"total,omitempty"`
+}
+
 // WorkerProfile workerProfile represents a worker profile.
 type WorkerProfile struct {
 	// Name - The worker profile name.
@@ -2419,4 +2938,20 @@ type WorkerProfile struct {
 	EncryptionAtHost EncryptionAtHost `json:"encryptionAtHost,omitempty"`
 	// DiskEncryptionSetID - The resource ID of an associated DiskEncryptionSet, if applicable.
 	DiskEncryptionSetID *string `json:"diskEncryptionSetId,omitempty"`
+	// DiskStorageAccountType - The storage account type of the worker VM OS disks.  Only supported for additional worker profiles.  If unset, Premium_LRS is used when supported by the worker VM size, StandardSSD_LRS otherwise. Possible values include: 'PremiumLRS', 'StandardSSDLRS'
+	DiskStorageAccountType DiskStorageAccountType `json:"diskStorageAccountType,omitempty"`
+	// AcceleratedNetworking - Whether the worker VM network interfaces use accelerated networking.  If unset, it is enabled when supported by the worker VM size. Possible values include: 'AcceleratedNetworkingDisabled', 'AcceleratedNetworkingEnabled'
+	AcceleratedNetworking AcceleratedNetworking `json:"acceleratedNetworking,omitempty"`
+	// ProximityPlacementGroupProfile - The proximity placement group of the worker VMs.  If unset, the worker VMs are spread across the availability zones of the region.
+	ProximityPlacementGroupProfile *ProximityPlacementGroupProfile `json:"proximityPlacementGroupProfile,omitempty"`
+}
+
+// WorkerProfileScale workerProfileScale represents the scale operation of a worker profile.
+type WorkerProfileScale struct {
+	// Name - The worker profile name.
+	Name *string `json:"name,omitempty"`
+	// CurrentReplicas - The number of ready worker VMs.
+	CurrentReplicas *int32 `json:"currentReplicas,omitempty"`
+	// TargetReplicas - The number of worker VMs requested.
+	TargetReplicas *int32 `json:"targetReplicas,omitempty"`
 }
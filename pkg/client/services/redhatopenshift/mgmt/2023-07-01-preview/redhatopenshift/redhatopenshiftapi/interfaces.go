@@ -41,18 +41,27 @@ type OpenShiftVersionsClientAPI interface {
 
 var _ OpenShiftVersionsClientAPI = (*redhatopenshift.OpenShiftVersionsClient)(nil)
 
+// OpenShiftVersionMatrixClientAPI contains the set of methods on the OpenShiftVersionMatrixClient type.
+type OpenShiftVersionMatrixClientAPI interface {
+	Get(ctx context.Context, location string) (result redhatopenshift.OpenShiftVersionMatrix, err error)
+}
+
+var _ OpenShiftVersionMatrixClientAPI = (*redhatopenshift.OpenShiftVersionMatrixClient)(nil)
+
 // OpenShiftClustersClientAPI contains the set of methods on the OpenShiftClustersClient type.
 type OpenShiftClustersClientAPI interface {
 	CreateOrUpdate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftCluster) (result redhatopenshift.OpenShiftClustersCreateOrUpdateFuture, err error)
 	Delete(ctx context.Context, resourceGroupName string, resourceName string) (result redhatopenshift.OpenShiftClustersDeleteFuture, err error)
+	ExportTemplate(ctx context.Context, resourceGroupName string, resourceName string) (result redhatopenshift.OpenShiftClusterTemplate, err error)
 	Get(ctx context.Context, resourceGroupName string, resourceName string) (result redhatopenshift.OpenShiftCluster, err error)
 	List(ctx context.Context) (result redhatopenshift.OpenShiftClusterListPage, err error)
 	ListComplete(ctx context.Context) (result redhatopenshift.OpenShiftClusterListIterator, err error)
-	ListAdminCredentials(ctx context.Context, resourceGroupName string, resourceName string) (result redhatopenshift.OpenShiftClusterAdminKubeconfig, err error)
+	ListAdminCredentials(ctx context.Context, resourceGroupName string, resourceName string, formatParameter redhatopenshift.AdminCredentialsFormat) (result redhatopenshift.OpenShiftClusterAdminKubeconfig, err error)
 	ListByResourceGroup(ctx context.Context, resourceGroupName string) (result redhatopenshift.OpenShiftClusterListPage, err error)
 	ListByResourceGroupComplete(ctx context.Context, resourceGroupName string) (result redhatopenshift.OpenShiftClusterListIterator, err error)
 	ListCredentials(ctx context.Context, resourceGroupName string, resourceName string) (result redhatopenshift.OpenShiftClusterCredentials, err error)
 	Update(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftClusterUpdate) (result redhatopenshift.OpenShiftClustersUpdateFuture, err error)
+	Validate(ctx context.Context, resourceGroupName string, resourceName string, parameters redhatopenshift.OpenShiftCluster) (result redhatopenshift.OpenShiftClusterValidationFindings, err error)
 }
 
 var _ OpenShiftClustersClientAPI = (*redhatopenshift.OpenShiftClustersClient)(nil)
@@ -50,6 +50,7 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
 				"[Action initializeOperatorDeployer-fm]",
@@ -73,6 +74,7 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
 				"[Action initializeOperatorDeployer-fm]",
@@ -93,6 +95,7 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
 				"[Action initializeOperatorDeployer-fm]",
@@ -115,7 +118,9 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action ensureResourceGroup-fm]",
+				"[Action reconcileTags-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
@@ -127,6 +132,8 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixSREKubeconfig-fm]",
 				"[Action fixUserAdminKubeconfig-fm]",
 				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action reconcileCustomerLoadBalancer-fm]",
+				"[Action updateEffectiveOutboundProfile-fm]",
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
@@ -161,7 +168,9 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action ensureResourceGroup-fm]",
+				"[Action reconcileTags-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
@@ -173,6 +182,8 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixSREKubeconfig-fm]",
 				"[Action fixUserAdminKubeconfig-fm]",
 				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action reconcileCustomerLoadBalancer-fm]",
+				"[Action updateEffectiveOutboundProfile-fm]",
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
@@ -203,7 +214,9 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action ensureResourceGroup-fm]",
+				"[Action reconcileTags-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
@@ -215,6 +228,8 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixSREKubeconfig-fm]",
 				"[Action fixUserAdminKubeconfig-fm]",
 				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action reconcileCustomerLoadBalancer-fm]",
+				"[Action updateEffectiveOutboundProfile-fm]",
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
@@ -244,7 +259,9 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action ensureResourceGroup-fm]",
+				"[Action reconcileTags-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
@@ -256,6 +273,8 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixSREKubeconfig-fm]",
 				"[Action fixUserAdminKubeconfig-fm]",
 				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action reconcileCustomerLoadBalancer-fm]",
+				"[Action updateEffectiveOutboundProfile-fm]",
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
@@ -289,6 +308,7 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action populateDatabaseIntIP-fm]",
 				"[Action startVMs-fm]",
 				"[Condition apiServersReady-fm, timeout 30m0s]",
@@ -300,6 +320,171 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action renewMDSDCertificate-fm]",
 			},
 		},
+		{
+			name: "Sync cluster properties from live state",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskSyncClusterProperties
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action syncClusterProperties-fm]",
+				"[Action reconcileTags-fm]",
+			},
+		},
+		{
+			name: "Defragment etcd",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEtcdDefrag
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action defragmentEtcd-fm]",
+			},
+		},
+		{
+			name: "Back up etcd",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEtcdBackup
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action backupEtcd-fm]",
+			},
+		},
+		{
+			name: "Restore etcd",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskEtcdRestore
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action restoreEtcd-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+			},
+		},
+		{
+			name: "Reboot node",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRebootNode
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action rebootNode-fm]",
+			},
+		},
+		{
+			name: "Drain machine set",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskDrainMachineSet
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action drainMachineSet-fm]",
+			},
+		},
+		{
+			name: "Rotate certificates",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRotateCertificates
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action rotateCertificates-fm]",
+			},
+		},
+		{
+			name: "Renew expiring certificates",
+			fixture: func() (*api.OpenShiftClusterDocument, bool) {
+				doc := baseClusterDoc()
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateAdminUpdating
+				doc.OpenShiftCluster.Properties.MaintenanceTask = api.MaintenanceTaskRenewExpiringCerts
+				return doc, true
+			},
+			shouldRunSteps: []string{
+				"[Action initializeKubernetesClients-fm]",
+				"[Action ensureBillingRecord-fm]",
+				"[Action ensureDefaults-fm]",
+				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
+				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
+				"[Action populateDatabaseIntIP-fm]",
+				"[Action startVMs-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Action renewExpiringCertificates-fm]",
+				"[Condition apiServersReady-fm, timeout 30m0s]",
+				"[Condition ingressControllerReady-fm, timeout 10m0s]",
+			},
+		},
 		{
 			name: "adminUpdate() does not adopt Hive-created clusters",
 			fixture: func() (*api.OpenShiftClusterDocument, bool) {
@@ -316,7 +501,9 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action ensureDefaults-fm]",
 				"[AuthorizationRetryingAction fixupClusterSPObjectID-fm]",
 				"[Action fixInfraID-fm]",
+				"[Action fixClusterDomainKey-fm]",
 				"[Action ensureResourceGroup-fm]",
+				"[Action reconcileTags-fm]",
 				"[Action createOrUpdateDenyAssignment-fm]",
 				"[Action ensureServiceEndpoints-fm]",
 				"[Action populateRegistryStorageAccountName-fm]",
@@ -328,6 +515,8 @@ func TestAdminUpdateSteps(t *testing.T) {
 				"[Action fixSREKubeconfig-fm]",
 				"[Action fixUserAdminKubeconfig-fm]",
 				"[Action createOrUpdateRouterIPFromCluster-fm]",
+				"[Action reconcileCustomerLoadBalancer-fm]",
+				"[Action updateEffectiveOutboundProfile-fm]",
 				"[Action fixMCSCert-fm]",
 				"[Action fixMCSUserData-fm]",
 				"[Action ensureGatewayUpgrade-fm]",
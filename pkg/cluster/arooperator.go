@@ -11,7 +11,12 @@ func (m *manager) isIngressProfileAvailable() bool {
 	// We try to acquire the IngressProfiles data at frontend best effort enrichment time only.
 	// When we start deallocated VMs and wait for the API do become available again, we don't pick
 	// the information up, even though it would be available.
-	return len(m.doc.OpenShiftCluster.Properties.IngressProfiles) != 0
+	for _, p := range m.doc.OpenShiftCluster.Properties.IngressProfiles {
+		if p.Name == "default" {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *manager) ensureAROOperator(ctx context.Context) error {
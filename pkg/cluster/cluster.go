@@ -9,6 +9,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/jongio/azidext/go/azidext"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	imageregistryclient "github.com/openshift/client-go/imageregistry/clientset/versioned"
 	machineclient "github.com/openshift/client-go/machine/clientset/versioned"
@@ -34,8 +35,10 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/privatedns"
 	"github.com/Azure/ARO-RP/pkg/util/billing"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
 	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
 	utilgraph "github.com/Azure/ARO-RP/pkg/util/graph"
 	"github.com/Azure/ARO-RP/pkg/util/refreshable"
 	"github.com/Azure/ARO-RP/pkg/util/storage"
@@ -69,10 +72,14 @@ type manager struct {
 	virtualMachines       compute.VirtualMachinesClient
 	interfaces            network.InterfacesClient
 	publicIPAddresses     network.PublicIPAddressesClient
+	publicIPPrefixes      network.PublicIPPrefixesClient
+	natGateways           network.NatGatewaysClient
+	routeTables           network.RouteTablesClient
 	loadBalancers         network.LoadBalancersClient
 	privateEndpoints      network.PrivateEndpointsClient
 	securityGroups        network.SecurityGroupsClient
 	deployments           features.DeploymentsClient
+	providers             features.ProvidersClient
 	resourceGroups        features.ResourceGroupsClient
 	resources             features.ResourcesClient
 	privateZones          privatedns.PrivateZonesClient
@@ -98,6 +105,7 @@ type manager struct {
 	securitycli      securityclient.Interface
 	arocli           aroclient.Interface
 	imageregistrycli imageregistryclient.Interface
+	etcdcli          etcd.Client
 
 	installViaHive     bool
 	adoptViaHive       bool
@@ -136,6 +144,28 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 
 	storage := storage.NewManager(_env, r.SubscriptionID, fpAuthorizer)
 
+	dnsManager := dns.NewManager(_env, localFPAuthorizer)
+	if doc.OpenShiftCluster.Properties.ClusterProfile.DNSZoneID != "" {
+		// the records are created in the customer's zone with the cluster
+		// service principal, which the customer grants access to the zone
+		spp := doc.OpenShiftCluster.Properties.ServicePrincipalProfile
+		spTokenCredential, err := clusterauthorizer.GetTokenCredential(_env.Environment(), &clusterauthorizer.Credentials{
+			ClientID:     []byte(spp.ClientID),
+			ClientSecret: []byte(spp.ClientSecret),
+			TenantID:     []byte(subscriptionDoc.Subscription.Properties.TenantID),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		spAuthorizer := azidext.NewTokenCredentialAdapter(spTokenCredential, []string{_env.Environment().ResourceManagerScope})
+
+		dnsManager, err = dns.NewZoneManager(_env, doc.OpenShiftCluster.Properties.ClusterProfile.DNSZoneID, spAuthorizer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	installViaHive, err := _env.LiveConfig().InstallViaHive(ctx)
 	if err != nil {
 		return nil, err
@@ -162,10 +192,14 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		virtualMachines:       compute.NewVirtualMachinesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		interfaces:            network.NewInterfacesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		publicIPAddresses:     network.NewPublicIPAddressesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		publicIPPrefixes:      network.NewPublicIPPrefixesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		natGateways:           network.NewNatGatewaysClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		routeTables:           network.NewRouteTablesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		loadBalancers:         network.NewLoadBalancersClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		privateEndpoints:      network.NewPrivateEndpointsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		securityGroups:        network.NewSecurityGroupsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		deployments:           features.NewDeploymentsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
+		providers:             features.NewProvidersClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		resourceGroups:        features.NewResourceGroupsClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		resources:             features.NewResourcesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		privateZones:          privatedns.NewPrivateZonesClient(_env.Environment(), r.SubscriptionID, fpAuthorizer),
@@ -176,7 +210,7 @@ func New(ctx context.Context, log *logrus.Entry, _env env.Interface, db database
 		fpPrivateEndpoints:    network.NewPrivateEndpointsClient(_env.Environment(), _env.SubscriptionID(), localFPAuthorizer),
 		rpPrivateLinkServices: network.NewPrivateLinkServicesClient(_env.Environment(), _env.SubscriptionID(), msiAuthorizer),
 
-		dns:     dns.NewManager(_env, localFPAuthorizer),
+		dns:     dnsManager,
 		storage: storage,
 		subnet:  subnet.NewManager(_env.Environment(), r.SubscriptionID, fpAuthorizer),
 		graph:   graph.NewManager(log, aead, storage),
@@ -0,0 +1,344 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/util/loadbalancer"
+)
+
+// The customer load balancer fronts the API server and the default ingress of
+// the cluster.  The RP registers the node IPs in IP based backend pools of the
+// load balancer, and creates the probes and load balancing rules of the API
+// server and of the node ports of the default router.  Everything the RP
+// creates on the load balancer is prefixed with the infraID of the cluster, so
+// that the rest of the customer's configuration is left alone.
+
+func (m *manager) getCustomerLoadBalancer(ctx context.Context) (mgmtnetwork.LoadBalancer, azure.Resource, error) {
+	r, err := azure.ParseResourceID(m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID)
+	if err != nil {
+		return mgmtnetwork.LoadBalancer{}, r, err
+	}
+
+	lb, err := m.loadBalancers.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	return lb, r, err
+}
+
+// customerLoadBalancerFrontendIP returns the private or public IP address of a
+// frontend IP configuration of the customer load balancer
+func (m *manager) customerLoadBalancerFrontendIP(ctx context.Context, name string) (string, error) {
+	lb, r, err := m.getCustomerLoadBalancer(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if lb.LoadBalancerPropertiesFormat != nil && lb.FrontendIPConfigurations != nil {
+		for _, f := range *lb.FrontendIPConfigurations {
+			if f.Name == nil || !strings.EqualFold(*f.Name, name) || f.FrontendIPConfigurationPropertiesFormat == nil {
+				continue
+			}
+
+			if f.PrivateIPAddress != nil && *f.PrivateIPAddress != "" {
+				return *f.PrivateIPAddress, nil
+			}
+
+			if f.PublicIPAddress != nil && f.PublicIPAddress.ID != nil {
+				pipr, err := azure.ParseResourceID(*f.PublicIPAddress.ID)
+				if err != nil {
+					return "", err
+				}
+
+				pip, err := m.publicIPAddresses.Get(ctx, pipr.ResourceGroup, pipr.ResourceName, "")
+				if err != nil {
+					return "", err
+				}
+
+				if pip.PublicIPAddressPropertiesFormat != nil && pip.IPAddress != nil {
+					return *pip.IPAddress, nil
+				}
+			}
+
+			return "", fmt.Errorf("frontend IP configuration %s of load balancer %s has no IP address", name, r.String())
+		}
+	}
+
+	return "", fmt.Errorf("load balancer %s has no frontend IP configuration %s", r.String(), name)
+}
+
+// reconcileCustomerLoadBalancer registers the master nodes as the backends of
+// the API server and the other nodes as the backends of the default ingress on
+// the customer load balancer, and creates their probes and load balancing
+// rules.  From then on the CustomerLoadBalancer controller of the operator
+// keeps the backend pools in sync with the nodes.
+func (m *manager) reconcileCustomerLoadBalancer(ctx context.Context) error {
+	clb := m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile
+	if clb == nil {
+		return nil
+	}
+
+	vnetID, _, err := apisubnet.Split(m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	masterIPs, workerIPs := loadbalancer.NodeIPs(nodes.Items)
+
+	svc, err := m.kubernetescli.CoreV1().Services("openshift-ingress").Get(ctx, "router-default", metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	var httpNodePort, httpsNodePort int32
+	for _, port := range svc.Spec.Ports {
+		switch port.Name {
+		case "http":
+			httpNodePort = port.NodePort
+		case "https":
+			httpsNodePort = port.NodePort
+		}
+	}
+	if httpNodePort == 0 || httpsNodePort == 0 {
+		return fmt.Errorf("service openshift-ingress/router-default has no http and https node ports")
+	}
+
+	lb, r, err := m.getCustomerLoadBalancer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil {
+		lb.LoadBalancerPropertiesFormat = &mgmtnetwork.LoadBalancerPropertiesFormat{}
+	}
+
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+	frontendID := func(name string) *string {
+		return to.StringPtr(*lb.ID + "/frontendIPConfigurations/" + name)
+	}
+
+	changed := loadbalancer.EnsureBackendAddressPool(&lb, loadbalancer.APIServerBackendAddressPoolName(infraID), vnetID, masterIPs)
+	changed = loadbalancer.EnsureBackendAddressPool(&lb, loadbalancer.IngressBackendAddressPoolName(infraID), vnetID, workerIPs) || changed
+
+	changed = ensureCustomerLoadBalancerProbe(&lb, mgmtnetwork.Probe{
+		ProbePropertiesFormat: &mgmtnetwork.ProbePropertiesFormat{
+			Protocol:          mgmtnetwork.ProbeProtocolHTTPS,
+			Port:              to.Int32Ptr(6443),
+			IntervalInSeconds: to.Int32Ptr(5),
+			NumberOfProbes:    to.Int32Ptr(2),
+			RequestPath:       to.StringPtr("/readyz"),
+		},
+		Name: to.StringPtr(infraID + "-api"),
+	}) || changed
+
+	// the router service has externalTrafficPolicy Local, so only the nodes
+	// which run a router accept connections on its node ports
+	changed = ensureCustomerLoadBalancerProbe(&lb, mgmtnetwork.Probe{
+		ProbePropertiesFormat: &mgmtnetwork.ProbePropertiesFormat{
+			Protocol:          mgmtnetwork.ProbeProtocolTCP,
+			Port:              to.Int32Ptr(httpsNodePort),
+			IntervalInSeconds: to.Int32Ptr(5),
+			NumberOfProbes:    to.Int32Ptr(2),
+		},
+		Name: to.StringPtr(infraID + "-ingress"),
+	}) || changed
+
+	for _, rule := range []struct {
+		name        string
+		frontend    string
+		pool        string
+		probe       string
+		port        int32
+		backendPort int32
+	}{
+		{
+			name:        infraID + "-api",
+			frontend:    clb.APIServerFrontendIPConfiguration,
+			pool:        loadbalancer.APIServerBackendAddressPoolName(infraID),
+			probe:       infraID + "-api",
+			port:        6443,
+			backendPort: 6443,
+		},
+		{
+			name:        infraID + "-ingress-http",
+			frontend:    clb.IngressFrontendIPConfiguration,
+			pool:        loadbalancer.IngressBackendAddressPoolName(infraID),
+			probe:       infraID + "-ingress",
+			port:        80,
+			backendPort: httpNodePort,
+		},
+		{
+			name:        infraID + "-ingress-https",
+			frontend:    clb.IngressFrontendIPConfiguration,
+			pool:        loadbalancer.IngressBackendAddressPoolName(infraID),
+			probe:       infraID + "-ingress",
+			port:        443,
+			backendPort: httpsNodePort,
+		},
+	} {
+		changed = ensureCustomerLoadBalancerRule(&lb, mgmtnetwork.LoadBalancingRule{
+			LoadBalancingRulePropertiesFormat: &mgmtnetwork.LoadBalancingRulePropertiesFormat{
+				FrontendIPConfiguration: &mgmtnetwork.SubResource{
+					ID: frontendID(rule.frontend),
+				},
+				BackendAddressPool: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(*lb.ID + "/backendAddressPools/" + rule.pool),
+				},
+				Probe: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(*lb.ID + "/probes/" + rule.probe),
+				},
+				Protocol:             mgmtnetwork.TransportProtocolTCP,
+				LoadDistribution:     mgmtnetwork.LoadDistributionDefault,
+				FrontendPort:         to.Int32Ptr(rule.port),
+				BackendPort:          to.Int32Ptr(rule.backendPort),
+				IdleTimeoutInMinutes: to.Int32Ptr(30),
+				DisableOutboundSnat:  to.BoolPtr(true),
+			},
+			Name: to.StringPtr(rule.name),
+		}) || changed
+	}
+
+	if !changed {
+		return nil
+	}
+
+	m.log.Printf("updating customer load balancer %s", r.String())
+	return m.loadBalancers.CreateOrUpdateAndWait(ctx, r.ResourceGroup, r.ResourceName, lb)
+}
+
+// removeCustomerLoadBalancerConfiguration removes the backend pools, probes
+// and load balancing rules of the cluster from the customer load balancer
+func (m *manager) removeCustomerLoadBalancerConfiguration(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile == nil ||
+		m.doc.OpenShiftCluster.Properties.InfraID == "" {
+		return nil
+	}
+
+	lb, r, err := m.getCustomerLoadBalancer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil {
+		return nil
+	}
+
+	prefix := m.doc.OpenShiftCluster.Properties.InfraID + "-"
+	var changed bool
+
+	if lb.LoadBalancingRules != nil {
+		rules := make([]mgmtnetwork.LoadBalancingRule, 0, len(*lb.LoadBalancingRules))
+		for _, rule := range *lb.LoadBalancingRules {
+			if rule.Name != nil && strings.HasPrefix(*rule.Name, prefix) {
+				changed = true
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		lb.LoadBalancingRules = &rules
+	}
+
+	if lb.Probes != nil {
+		probes := make([]mgmtnetwork.Probe, 0, len(*lb.Probes))
+		for _, probe := range *lb.Probes {
+			if probe.Name != nil && strings.HasPrefix(*probe.Name, prefix) {
+				changed = true
+				continue
+			}
+			probes = append(probes, probe)
+		}
+		lb.Probes = &probes
+	}
+
+	if lb.BackendAddressPools != nil {
+		pools := make([]mgmtnetwork.BackendAddressPool, 0, len(*lb.BackendAddressPools))
+		for _, pool := range *lb.BackendAddressPools {
+			if pool.Name != nil && strings.HasPrefix(*pool.Name, prefix) {
+				changed = true
+				continue
+			}
+			pools = append(pools, pool)
+		}
+		lb.BackendAddressPools = &pools
+	}
+
+	if !changed {
+		return nil
+	}
+
+	m.log.Printf("removing the cluster from customer load balancer %s", r.String())
+	return m.loadBalancers.CreateOrUpdateAndWait(ctx, r.ResourceGroup, r.ResourceName, lb)
+}
+
+// ensureCustomerLoadBalancerProbe ensures that the load balancer has the
+// probe, and reports whether it changed
+func ensureCustomerLoadBalancerProbe(lb *mgmtnetwork.LoadBalancer, probe mgmtnetwork.Probe) bool {
+	if lb.Probes == nil {
+		lb.Probes = &[]mgmtnetwork.Probe{}
+	}
+
+	for i, p := range *lb.Probes {
+		if p.Name == nil || !strings.EqualFold(*p.Name, *probe.Name) {
+			continue
+		}
+
+		if p.ProbePropertiesFormat != nil &&
+			p.Protocol == probe.Protocol &&
+			to.Int32(p.Port) == *probe.Port &&
+			to.String(p.RequestPath) == to.String(probe.RequestPath) {
+			return false
+		}
+
+		(*lb.Probes)[i] = probe
+		return true
+	}
+
+	*lb.Probes = append(*lb.Probes, probe)
+	return true
+}
+
+// ensureCustomerLoadBalancerRule ensures that the load balancer has the load
+// balancing rule, and reports whether it changed
+func ensureCustomerLoadBalancerRule(lb *mgmtnetwork.LoadBalancer, rule mgmtnetwork.LoadBalancingRule) bool {
+	if lb.LoadBalancingRules == nil {
+		lb.LoadBalancingRules = &[]mgmtnetwork.LoadBalancingRule{}
+	}
+
+	subResourceEqual := func(a, b *mgmtnetwork.SubResource) bool {
+		return a != nil && b != nil && strings.EqualFold(to.String(a.ID), to.String(b.ID))
+	}
+
+	for i, r := range *lb.LoadBalancingRules {
+		if r.Name == nil || !strings.EqualFold(*r.Name, *rule.Name) {
+			continue
+		}
+
+		if r.LoadBalancingRulePropertiesFormat != nil &&
+			subResourceEqual(r.FrontendIPConfiguration, rule.FrontendIPConfiguration) &&
+			subResourceEqual(r.BackendAddressPool, rule.BackendAddressPool) &&
+			subResourceEqual(r.Probe, rule.Probe) &&
+			to.Int32(r.FrontendPort) == *rule.FrontendPort &&
+			to.Int32(r.BackendPort) == *rule.BackendPort {
+			return false
+		}
+
+		(*lb.LoadBalancingRules)[i] = rule
+		return true
+	}
+
+	*lb.LoadBalancingRules = append(*lb.LoadBalancingRules, rule)
+	return true
+}
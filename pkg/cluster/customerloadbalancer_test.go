@@ -0,0 +1,341 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+const (
+	testCustomerLBID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor"
+	testVnetID       = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet"
+)
+
+func testCustomerLoadBalancerNode(name, ip string, master bool) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{},
+		},
+	}
+	if master {
+		node.Labels["node-role.kubernetes.io/master"] = ""
+	} else {
+		node.Labels["node-role.kubernetes.io/worker"] = ""
+	}
+	if ip != "" {
+		node.Status.Addresses = []corev1.NodeAddress{
+			{Type: corev1.NodeHostName, Address: name},
+			{Type: corev1.NodeInternalIP, Address: ip},
+		}
+	}
+	return node
+}
+
+func testCustomerLoadBalancerPool(name string, ips ...string) mgmtnetwork.BackendAddressPool {
+	addresses := []mgmtnetwork.LoadBalancerBackendAddress{}
+	for _, ip := range ips {
+		addresses = append(addresses, mgmtnetwork.LoadBalancerBackendAddress{
+			LoadBalancerBackendAddressPropertiesFormat: &mgmtnetwork.LoadBalancerBackendAddressPropertiesFormat{
+				VirtualNetwork: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(testVnetID),
+				},
+				IPAddress: to.StringPtr(ip),
+			},
+			Name: to.StringPtr(name + "-" + ip),
+		})
+	}
+
+	return mgmtnetwork.BackendAddressPool{
+		BackendAddressPoolPropertiesFormat: &mgmtnetwork.BackendAddressPoolPropertiesFormat{
+			LoadBalancerBackendAddresses: &addresses,
+		},
+		Name: to.StringPtr(name),
+	}
+}
+
+func testCustomerLoadBalancerRule(name, frontend, pool, probe string, port, backendPort int32) mgmtnetwork.LoadBalancingRule {
+	return mgmtnetwork.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &mgmtnetwork.LoadBalancingRulePropertiesFormat{
+			FrontendIPConfiguration: &mgmtnetwork.SubResource{
+				ID: to.StringPtr(testCustomerLBID + "/frontendIPConfigurations/" + frontend),
+			},
+			BackendAddressPool: &mgmtnetwork.SubResource{
+				ID: to.StringPtr(testCustomerLBID + "/backendAddressPools/" + pool),
+			},
+			Probe: &mgmtnetwork.SubResource{
+				ID: to.StringPtr(testCustomerLBID + "/probes/" + probe),
+			},
+			Protocol:             mgmtnetwork.TransportProtocolTCP,
+			LoadDistribution:     mgmtnetwork.LoadDistributionDefault,
+			FrontendPort:         to.Int32Ptr(port),
+			BackendPort:          to.Int32Ptr(backendPort),
+			IdleTimeoutInMinutes: to.Int32Ptr(30),
+			DisableOutboundSnat:  to.BoolPtr(true),
+		},
+		Name: to.StringPtr(name),
+	}
+}
+
+// testCustomerLoadBalancer returns the customer load balancer, with a pool,
+// probe and rule of the customer, and, if registered, with the configuration
+// of the cluster
+func testCustomerLoadBalancer(registered bool, workerIPs ...string) mgmtnetwork.LoadBalancer {
+	lb := mgmtnetwork.LoadBalancer{
+		ID: to.StringPtr(testCustomerLBID),
+		LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+				{Name: to.StringPtr("api")},
+				{Name: to.StringPtr("ingress")},
+			},
+			BackendAddressPools: &[]mgmtnetwork.BackendAddressPool{
+				testCustomerLoadBalancerPool("other", "10.1.0.10"),
+			},
+			Probes: &[]mgmtnetwork.Probe{
+				{
+					ProbePropertiesFormat: &mgmtnetwork.ProbePropertiesFormat{
+						Protocol: mgmtnetwork.ProbeProtocolTCP,
+						Port:     to.Int32Ptr(8080),
+					},
+					Name: to.StringPtr("other"),
+				},
+			},
+			LoadBalancingRules: &[]mgmtnetwork.LoadBalancingRule{
+				testCustomerLoadBalancerRule("other", "ingress", "other", "other", 8080, 8080),
+			},
+		},
+	}
+
+	if !registered {
+		return lb
+	}
+
+	*lb.BackendAddressPools = append(*lb.BackendAddressPools,
+		testCustomerLoadBalancerPool("infra-api", "10.0.0.4", "10.0.0.5", "10.0.0.6"),
+		testCustomerLoadBalancerPool("infra-ingress", workerIPs...),
+	)
+	*lb.Probes = append(*lb.Probes,
+		mgmtnetwork.Probe{
+			ProbePropertiesFormat: &mgmtnetwork.ProbePropertiesFormat{
+				Protocol:          mgmtnetwork.ProbeProtocolHTTPS,
+				Port:              to.Int32Ptr(6443),
+				IntervalInSeconds: to.Int32Ptr(5),
+				NumberOfProbes:    to.Int32Ptr(2),
+				RequestPath:       to.StringPtr("/readyz"),
+			},
+			Name: to.StringPtr("infra-api"),
+		},
+		mgmtnetwork.Probe{
+			ProbePropertiesFormat: &mgmtnetwork.ProbePropertiesFormat{
+				Protocol:          mgmtnetwork.ProbeProtocolTCP,
+				Port:              to.Int32Ptr(30443),
+				IntervalInSeconds: to.Int32Ptr(5),
+				NumberOfProbes:    to.Int32Ptr(2),
+			},
+			Name: to.StringPtr("infra-ingress"),
+		},
+	)
+	*lb.LoadBalancingRules = append(*lb.LoadBalancingRules,
+		testCustomerLoadBalancerRule("infra-api", "api", "infra-api", "infra-api", 6443, 6443),
+		testCustomerLoadBalancerRule("infra-ingress-http", "ingress", "infra-ingress", "infra-ingress", 80, 30080),
+		testCustomerLoadBalancerRule("infra-ingress-https", "ingress", "infra-ingress", "infra-ingress", 443, 30443),
+	)
+
+	return lb
+}
+
+func TestReconcileCustomerLoadBalancer(t *testing.T) {
+	ctx := context.Background()
+
+	routerDefault := func(httpNodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "router-default",
+				Namespace: "openshift-ingress",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, NodePort: httpNodePort},
+					{Name: "https", Port: 443, NodePort: 30443},
+				},
+			},
+		}
+	}
+
+	nodes := []*corev1.Node{
+		testCustomerLoadBalancerNode("master-0", "10.0.0.6", true),
+		testCustomerLoadBalancerNode("master-1", "10.0.0.4", true),
+		testCustomerLoadBalancerNode("master-2", "10.0.0.5", true),
+		testCustomerLoadBalancerNode("worker-0", "10.0.1.5", false),
+		testCustomerLoadBalancerNode("worker-1", "10.0.1.4", false),
+		testCustomerLoadBalancerNode("worker-2", "", false),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		profile *api.CustomerLoadBalancerProfile
+		service *corev1.Service
+		mocks   func(*mock_network.MockLoadBalancersClient)
+		wantErr string
+	}{
+		{
+			name: "no customer load balancer",
+		},
+		{
+			name: "registers the cluster",
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(testCustomerLoadBalancer(false), nil)
+				loadBalancers.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), "network", "frontdoor", testCustomerLoadBalancer(true, "10.0.1.4", "10.0.1.5")).
+					Return(nil)
+			},
+		},
+		{
+			name: "already registered",
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(testCustomerLoadBalancer(true, "10.0.1.5", "10.0.1.4"), nil)
+			},
+		},
+		{
+			name: "updates the ingress backends",
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(testCustomerLoadBalancer(true, "10.0.1.4", "10.0.1.9"), nil)
+				loadBalancers.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), "network", "frontdoor", testCustomerLoadBalancer(true, "10.0.1.4", "10.0.1.5")).
+					Return(nil)
+			},
+		},
+		{
+			name:    "router has no node ports",
+			service: routerDefault(0),
+			wantErr: "service openshift-ingress/router-default has no http and https node ports",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			loadBalancers := mock_network.NewMockLoadBalancersClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(loadBalancers)
+			}
+
+			if tt.service == nil {
+				tt.service = routerDefault(30080)
+			}
+
+			kubernetescli := fake.NewSimpleClientset(tt.service)
+			for _, node := range nodes {
+				_, err := kubernetescli.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					InfraID: "infra",
+					MasterProfile: api.MasterProfile{
+						SubnetID: testVnetID + "/subnets/master",
+					},
+				},
+			}
+			if tt.name != "no customer load balancer" {
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile = &api.CustomerLoadBalancerProfile{
+					ID:                               testCustomerLBID,
+					APIServerFrontendIPConfiguration: "api",
+					IngressFrontendIPConfiguration:   "ingress",
+				}
+			}
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				doc:           &api.OpenShiftClusterDocument{OpenShiftCluster: oc},
+				loadBalancers: loadBalancers,
+				kubernetescli: kubernetescli,
+			}
+
+			err := m.reconcileCustomerLoadBalancer(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRemoveCustomerLoadBalancerConfiguration(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name  string
+		mocks func(*mock_network.MockLoadBalancersClient)
+	}{
+		{
+			name: "removes the configuration of the cluster",
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(testCustomerLoadBalancer(true, "10.0.1.4"), nil)
+				loadBalancers.EXPECT().
+					CreateOrUpdateAndWait(gomock.Any(), "network", "frontdoor", testCustomerLoadBalancer(false)).
+					Return(nil)
+			},
+		},
+		{
+			name: "nothing to remove",
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(testCustomerLoadBalancer(false), nil)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			loadBalancers := mock_network.NewMockLoadBalancersClient(controller)
+			tt.mocks(loadBalancers)
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infra",
+							NetworkProfile: api.NetworkProfile{
+								CustomerLoadBalancerProfile: &api.CustomerLoadBalancerProfile{
+									ID: testCustomerLBID,
+								},
+							},
+						},
+					},
+				},
+				loadBalancers: loadBalancers,
+			}
+
+			err := m.removeCustomerLoadBalancerConfiguration(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
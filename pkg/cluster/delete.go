@@ -185,6 +185,12 @@ func (m *manager) deleteResources(ctx context.Context) error {
 	// group our resources by level
 	resourceMap := map[int][]*mgmtfeatures.GenericResourceExpanded{}
 	for i, resource := range resources {
+		// leave the customer's own resources in an existing resource group
+		if m.isExistingResourceGroup() && !m.isClusterResource(&resources[i]) {
+			m.log.Infof("skipping resource %s which does not belong to the cluster", *resource.ID)
+			continue
+		}
+
 		level := deleteOrder[strings.ToLower(*resource.Type)]
 		resourceMap[level] = append(resourceMap[level], &resources[i])
 	}
@@ -298,6 +304,13 @@ func (m *manager) deleteRoleAssignments(ctx context.Context) error {
 			continue
 		}
 
+		// in an existing resource group only the cluster service principal's
+		// role assignment was created by the RP
+		if m.isExistingResourceGroup() &&
+			(assignment.PrincipalID == nil || !strings.EqualFold(*assignment.PrincipalID, m.doc.OpenShiftCluster.Properties.ServicePrincipalProfile.SPObjectID)) {
+			continue
+		}
+
 		m.log.Infof("deleting role assignment %s", *assignment.Name)
 		_, err := m.roleAssignments.Delete(ctx, *assignment.Scope, *assignment.Name)
 		if err != nil {
@@ -374,9 +387,17 @@ func (m *manager) deleteGateway(ctx context.Context) error {
 	return nil
 }
 
+// deleteResourcesAndResourceGroup deletes the cluster resource group if the RP
+// created it.  An existing resource group is owned by the customer: only the
+// resources which belong to the cluster are deleted from it.
 func (m *manager) deleteResourcesAndResourceGroup(ctx context.Context) error {
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 
+	if m.isExistingResourceGroup() {
+		m.log.Printf("deleting cluster resources from existing resource group %s", resourceGroup)
+		return m.deleteResources(ctx)
+	}
+
 	shouldDelete, err := m.shouldDeleteResourceGroup(ctx, resourceGroup)
 	if err != nil || !shouldDelete {
 		return err
@@ -431,10 +452,22 @@ func (m *manager) Delete(ctx context.Context) error {
 
 	m.log.Printf("deleting dns")
 	err = m.dns.Delete(ctx, m.doc.OpenShiftCluster)
-	if err != nil {
+	if err != nil && m.doc.OpenShiftCluster.Properties.ClusterProfile.DNSZoneID != "" {
+		// the service principal may no longer have access to the customer's
+		// zone: don't block the deletion on it
+		m.log.Error(err)
+	} else if err != nil {
 		return err
 	}
 
+	m.log.Printf("removing the cluster from the customer load balancer")
+	err = m.removeCustomerLoadBalancerConfiguration(ctx)
+	if err != nil {
+		// the RP may no longer have access to the customer's load
+		// balancer: don't block the deletion on it
+		m.log.Error(err)
+	}
+
 	m.log.Print("deleting private endpoint")
 	err = m.fpPrivateEndpoints.DeleteAndWait(ctx, m.env.ResourceGroup(), env.RPPrivateEndpointPrefix+m.doc.ID)
 	if err != nil {
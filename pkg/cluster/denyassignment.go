@@ -12,7 +12,7 @@ import (
 )
 
 func (m *manager) createOrUpdateDenyAssignment(ctx context.Context) error {
-	if m.env.FeatureIsSet(env.FeatureDisableDenyAssignments) {
+	if m.env.FeatureIsSet(env.FeatureDisableDenyAssignments) || m.isExistingResourceGroup() {
 		return nil
 	}
 
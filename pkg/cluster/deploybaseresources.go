@@ -20,6 +20,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/arm"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
@@ -34,7 +35,8 @@ func (m *manager) ensureInfraID(ctx context.Context) (err error) {
 		return err
 	}
 	// generate an infra ID that is 27 characters long with 5 bytes of them random
-	infraID := generateInfraID(strings.ToLower(m.doc.OpenShiftCluster.Name), 27, 5)
+	base := expandResourceNameTemplate(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceNameTemplate, strings.ToLower(m.doc.OpenShiftCluster.Name), 27-(5+1))
+	infraID := generateInfraID(base, 27, 5)
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.InfraID = infraID
 		return nil
@@ -44,6 +46,11 @@ func (m *manager) ensureInfraID(ctx context.Context) (err error) {
 
 func (m *manager) ensureResourceGroup(ctx context.Context) (err error) {
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	if m.isExistingResourceGroup() {
+		return m.ensureExistingResourceGroup(ctx, resourceGroup)
+	}
+
 	group := mgmtfeatures.ResourceGroup{}
 
 	// Retain the existing resource group configuration (such as tags) if it exists
@@ -151,6 +158,12 @@ func (m *manager) deployBaseResourceTemplate(ctx context.Context) error {
 			m.networkPublicIPAddress(azureRegion, infraID+"-pip-v4"),
 			m.networkPublicLoadBalancer(azureRegion),
 		)
+		// If requested, egress uses a dedicated public IP prefix instead
+		if prefix := m.managedOutboundIPPrefix(); prefix != nil {
+			resources = append(resources,
+				m.networkPublicIPPrefix(azureRegion, infraID+outboundIPPrefixSuffix, prefix.PrefixLength),
+			)
+		}
 		// If the cluster is public we still want the default public IP address
 		if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
 			resources = append(resources,
@@ -165,13 +178,23 @@ func (m *manager) deployBaseResourceTemplate(ctx context.Context) error {
 		)
 	}
 
+	// The VMs of worker profiles in a proximity placement group are placed in
+	// it through an availability set, as the machine API cannot set it
+	for _, wp := range m.additionalWorkerProfiles() {
+		if wp.ProximityPlacementGroupProfile != nil {
+			resources = append(resources, m.workerProfilePlacementResources(azureRegion, &wp)...)
+		}
+	}
+
 	t := &arm.Template{
 		Schema:         "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
 		ContentVersion: "1.0.0.0",
 		Resources:      resources,
 	}
 
-	if !m.env.FeatureIsSet(env.FeatureDisableDenyAssignments) {
+	// the deny assignment would lock the customer out of a resource group
+	// which they own
+	if !m.env.FeatureIsSet(env.FeatureDisableDenyAssignments) && !m.isExistingResourceGroup() {
 		t.Resources = append(t.Resources, m.denyAssignment())
 	}
 
@@ -182,13 +205,12 @@ func (m *manager) attachNSGs(ctx context.Context) error {
 	if m.doc.OpenShiftCluster.Properties.NetworkProfile.PreconfiguredNSG == api.PreconfiguredNSGEnabled {
 		return nil
 	}
-	workerProfiles, _ := api.GetEnrichedWorkerProfiles(m.doc.OpenShiftCluster.Properties)
-	workerSubnetId := workerProfiles[0].SubnetID
+	subnetIDs, err := m.getSubnetIds()
+	if err != nil {
+		return err
+	}
 
-	for _, subnetID := range []string{
-		m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID,
-		workerSubnetId,
-	} {
+	for _, subnetID := range subnetIDs {
 		m.log.Printf("attaching network security group to subnet %s", subnetID)
 
 		// TODO: there is probably an undesirable race condition here - check if etags can help.
@@ -276,6 +298,26 @@ func (m *manager) setMasterSubnetPolicies(ctx context.Context) error {
 	return err
 }
 
+// expandResourceNameTemplate returns the base of the infra ID, which prefixes
+// the names of the resources in the cluster resource group.  The cluster name
+// is truncated rather than the rest of the template, so that the naming
+// convention of the customer is kept.
+func expandResourceNameTemplate(template string, name string, maxBaseLen int) string {
+	if template == "" {
+		return name
+	}
+
+	maxNameLen := maxBaseLen - (len(template) - strings.Count(template, validate.ResourceNameTemplateClusterName)*len(validate.ResourceNameTemplateClusterName))
+	if maxNameLen < 0 {
+		maxNameLen = 0
+	}
+	if len(name) > maxNameLen {
+		name = name[:maxNameLen]
+	}
+
+	return strings.Replace(template, validate.ResourceNameTemplateClusterName, name, 1)
+}
+
 // generateInfraID take base and returns a ID that
 // - is of length maxLen
 // - contains randomLen random bytes
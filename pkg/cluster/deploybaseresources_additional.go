@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
 	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
@@ -92,18 +93,24 @@ func (m *manager) storageAccount(name, region string, encrypted bool) *arm.Resou
 	// Virtual network rules to allow the cluster subnets to directly reach the storage accounts
 	// are only needed when egress lockdown is not enabled.
 	if !m.doc.OpenShiftCluster.Properties.FeatureProfile.GatewayEnabled {
+		virtualNetworkRules = append(virtualNetworkRules, mgmtstorage.VirtualNetworkRule{
+			VirtualNetworkResourceID: to.StringPtr(m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID),
+			Action:                   mgmtstorage.Allow,
+		})
+
 		workerProfiles, _ := api.GetEnrichedWorkerProfiles(m.doc.OpenShiftCluster.Properties)
-		workerSubnetId := workerProfiles[0].SubnetID
-		virtualNetworkRules = append(virtualNetworkRules, []mgmtstorage.VirtualNetworkRule{
-			{
-				VirtualNetworkResourceID: &m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID,
-				Action:                   mgmtstorage.Allow,
-			},
-			{
-				VirtualNetworkResourceID: &workerSubnetId,
+		workerSubnetIDs := map[string]bool{}
+		for _, wp := range workerProfiles {
+			if wp.SubnetID == "" || workerSubnetIDs[strings.ToLower(wp.SubnetID)] {
+				continue
+			}
+			workerSubnetIDs[strings.ToLower(wp.SubnetID)] = true
+
+			virtualNetworkRules = append(virtualNetworkRules, mgmtstorage.VirtualNetworkRule{
+				VirtualNetworkResourceID: to.StringPtr(wp.SubnetID),
 				Action:                   mgmtstorage.Allow,
-			},
-		}...)
+			})
+		}
 	}
 
 	// when installing via Hive we need to allow Hive to persist the installConfig graph in the cluster's storage account
@@ -281,6 +288,85 @@ func (m *manager) networkPublicIPAddress(azureRegion string, name string) *arm.R
 	}
 }
 
+func (m *manager) networkPublicIPPrefix(azureRegion string, name string, prefixLength int) *arm.Resource {
+	return &arm.Resource{
+		Resource: &mgmtnetwork.PublicIPPrefix{
+			Sku: &mgmtnetwork.PublicIPPrefixSku{
+				Name: mgmtnetwork.PublicIPPrefixSkuNameStandard,
+			},
+			PublicIPPrefixPropertiesFormat: &mgmtnetwork.PublicIPPrefixPropertiesFormat{
+				PublicIPAddressVersion: mgmtnetwork.IPv4,
+				PrefixLength:           to.Int32Ptr(int32(prefixLength)),
+			},
+			Name:     &name,
+			Type:     to.StringPtr("Microsoft.Network/publicIPPrefixes"),
+			Location: &azureRegion,
+		},
+		APIVersion: azureclient.APIVersion("Microsoft.Network"),
+	}
+}
+
+// workerProfilePlacementResources returns the availability set in which the
+// VMs of a worker profile are placed in its proximity placement group, and the
+// proximity placement group itself unless an existing one is used
+func (m *manager) workerProfilePlacementResources(azureRegion string, wp *api.WorkerProfile) []*arm.Resource {
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+
+	if wp.ProximityPlacementGroupProfile.ID != "" {
+		return []*arm.Resource{
+			m.computeAvailabilitySet(azureRegion, workerProfileAvailabilitySetName(infraID, wp), wp.ProximityPlacementGroupProfile.ID),
+		}
+	}
+
+	ppgName := workerProfileProximityPlacementGroupName(infraID, wp)
+	as := m.computeAvailabilitySet(azureRegion, workerProfileAvailabilitySetName(infraID, wp), fmt.Sprintf("[resourceId('Microsoft.Compute/proximityPlacementGroups', '%s')]", ppgName))
+	as.DependsOn = []string{
+		"Microsoft.Compute/proximityPlacementGroups/" + ppgName,
+	}
+
+	return []*arm.Resource{
+		m.computeProximityPlacementGroup(azureRegion, ppgName),
+		as,
+	}
+}
+
+func (m *manager) computeProximityPlacementGroup(azureRegion string, name string) *arm.Resource {
+	return &arm.Resource{
+		Resource: &mgmtcompute.ProximityPlacementGroup{
+			ProximityPlacementGroupProperties: &mgmtcompute.ProximityPlacementGroupProperties{
+				ProximityPlacementGroupType: mgmtcompute.Standard,
+			},
+			Name:     &name,
+			Type:     to.StringPtr("Microsoft.Compute/proximityPlacementGroups"),
+			Location: &azureRegion,
+		},
+		APIVersion: azureclient.APIVersion("Microsoft.Compute"),
+	}
+}
+
+// computeAvailabilitySet returns an availability set in the proximity
+// placement group.  Two fault domains are available in every region.
+func (m *manager) computeAvailabilitySet(azureRegion string, name string, proximityPlacementGroupID string) *arm.Resource {
+	return &arm.Resource{
+		Resource: &mgmtcompute.AvailabilitySet{
+			Sku: &mgmtcompute.Sku{
+				Name: to.StringPtr("Aligned"),
+			},
+			AvailabilitySetProperties: &mgmtcompute.AvailabilitySetProperties{
+				PlatformFaultDomainCount:  to.Int32Ptr(2),
+				PlatformUpdateDomainCount: to.Int32Ptr(5),
+				ProximityPlacementGroup: &mgmtcompute.SubResource{
+					ID: &proximityPlacementGroupID,
+				},
+			},
+			Name:     &name,
+			Type:     to.StringPtr("Microsoft.Compute/availabilitySets"),
+			Location: &azureRegion,
+		},
+		APIVersion: azureclient.APIVersion("Microsoft.Compute"),
+	}
+}
+
 func (m *manager) networkInternalLoadBalancer(azureRegion string) *arm.Resource {
 	return &arm.Resource{
 		Resource: &mgmtnetwork.LoadBalancer{
@@ -500,6 +586,31 @@ func (m *manager) networkPublicLoadBalancer(azureRegion string) *arm.Resource {
 		Location: &azureRegion,
 	}
 
+	dependsOn := []string{
+		"Microsoft.Network/publicIPAddresses/" + m.doc.OpenShiftCluster.Properties.InfraID + "-pip-v4",
+	}
+
+	// A frontend IP configuration backed by a public IP prefix uses all of
+	// the IPs in the prefix, but can only be referenced by outbound rules
+	if m.managedOutboundIPPrefix() != nil {
+		*lb.FrontendIPConfigurations = append(*lb.FrontendIPConfigurations, mgmtnetwork.FrontendIPConfiguration{
+			FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+				PublicIPPrefix: &mgmtnetwork.SubResource{
+					ID: to.StringPtr("[resourceId('Microsoft.Network/publicIPPrefixes', '" + m.doc.OpenShiftCluster.Properties.InfraID + outboundIPPrefixSuffix + "')]"),
+				},
+			},
+			Name: to.StringPtr(outboundIPPrefixFrontendIPConfigName),
+		})
+
+		(*lb.OutboundRules)[0].FrontendIPConfigurations = &[]mgmtnetwork.SubResource{
+			{
+				ID: to.StringPtr("[resourceId('Microsoft.Network/loadBalancers/frontendIPConfigurations', '" + m.doc.OpenShiftCluster.Properties.InfraID + "', '" + outboundIPPrefixFrontendIPConfigName + "')]"),
+			},
+		}
+
+		dependsOn = append(dependsOn, "Microsoft.Network/publicIPPrefixes/"+m.doc.OpenShiftCluster.Properties.InfraID+outboundIPPrefixSuffix)
+	}
+
 	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
 		*lb.LoadBalancingRules = append(*lb.LoadBalancingRules, mgmtnetwork.LoadBalancingRule{
 			LoadBalancingRulePropertiesFormat: &mgmtnetwork.LoadBalancingRulePropertiesFormat{
@@ -537,8 +648,6 @@ func (m *manager) networkPublicLoadBalancer(azureRegion string) *arm.Resource {
 	return &arm.Resource{
 		Resource:   lb,
 		APIVersion: azureclient.APIVersion("Microsoft.Network"),
-		DependsOn: []string{
-			"Microsoft.Network/publicIPAddresses/" + m.doc.OpenShiftCluster.Properties.InfraID + "-pip-v4",
-		},
+		DependsOn:  dependsOn,
 	}
 }
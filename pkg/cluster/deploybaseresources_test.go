@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
 	"github.com/Azure/go-autorest/autorest"
@@ -53,11 +55,19 @@ func TestEnsureResourceGroup(t *testing.T) {
 		ServiceError: &azure.ServiceError{Code: "ResourceGroupNotFound"},
 	}, "", "", &http.Response{StatusCode: http.StatusNotFound}, "")
 
+	existingGroup := mgmtfeatures.ResourceGroup{
+		Location: &location,
+		Tags: map[string]*string{
+			"yeet": to.StringPtr("yote"),
+		},
+	}
+
 	for _, tt := range []struct {
-		name              string
-		provisioningState api.ProvisioningState
-		mocks             func(*mock_features.MockResourceGroupsClient, *mock_env.MockInterface)
-		wantErr           string
+		name                  string
+		provisioningState     api.ProvisioningState
+		existingResourceGroup api.ExistingResourceGroup
+		mocks                 func(*mock_features.MockResourceGroupsClient, *mock_env.MockInterface)
+		wantErr               string
 	}{
 		{
 			name:              "success - rg doesn't exist",
@@ -196,6 +206,57 @@ func TestEnsureResourceGroup(t *testing.T) {
 			},
 			wantErr: "generic error",
 		},
+		{
+			name:                  "success - existing rg is not updated",
+			provisioningState:     api.ProvisioningStateCreating,
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(rg *mock_features.MockResourceGroupsClient, env *mock_env.MockInterface) {
+				rg.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(existingGroup, nil)
+
+				env.EXPECT().
+					EnsureARMResourceGroupRoleAssignment(gomock.Any(), resourceGroupName).
+					Return(nil)
+			},
+		},
+		{
+			name:                  "fail - existing rg doesn't exist",
+			provisioningState:     api.ProvisioningStateCreating,
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(rg *mock_features.MockResourceGroupsClient, env *mock_env.MockInterface) {
+				rg.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(mgmtfeatures.ResourceGroup{}, resourceGroupNotFound)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must already exist.",
+		},
+		{
+			name:                  "fail - existing rg location doesn't match",
+			provisioningState:     api.ProvisioningStateCreating,
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(rg *mock_features.MockResourceGroupsClient, env *mock_env.MockInterface) {
+				badLocation := existingGroup
+				badLocation.Location = to.StringPtr("bad-location")
+				rg.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(badLocation, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must be in the same location as the cluster.",
+		},
+		{
+			name:                  "fail - existing rg is managed",
+			provisioningState:     api.ProvisioningStateCreating,
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(rg *mock_features.MockResourceGroupsClient, env *mock_env.MockInterface) {
+				managed := existingGroup
+				managed.ManagedBy = to.StringPtr("some-managed-application")
+				rg.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(managed, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must not be managed by another resource.",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
@@ -214,7 +275,8 @@ func TestEnsureResourceGroup(t *testing.T) {
 					OpenShiftCluster: &api.OpenShiftCluster{
 						Properties: api.OpenShiftClusterProperties{
 							ClusterProfile: api.ClusterProfile{
-								ResourceGroupID: resourceGroup,
+								ResourceGroupID:       resourceGroup,
+								ExistingResourceGroup: tt.existingResourceGroup,
 							},
 							ProvisioningState: tt.provisioningState,
 						},
@@ -343,6 +405,60 @@ func TestEnsureInfraID(t *testing.T) {
 			},
 			wantedInfraID: "abcdefghijklmnopqrstu-cbhtc",
 		},
+		{
+			name: "infra ID not set, resource name template",
+			oc: &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   resourceID,
+					Name: "FoobarCluster",
+
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							ResourceNameTemplate: "prod-{name}",
+						},
+					},
+				},
+			},
+			wantedInfraID: "prod-foobarcluster-cbhtc",
+		},
+		{
+			name: "infra ID not set, resource name template and very long name",
+			oc: &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   resourceID,
+					Name: "abcdefghijklmnopqrstuvwxyzabc",
+
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							ResourceNameTemplate: "{name}-prod-weu",
+						},
+					},
+				},
+			},
+			wantedInfraID: "abcdefghijkl-prod-weu-cbhtc",
+		},
+		{
+			name: "infra ID not set, resource name template without cluster name",
+			oc: &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   resourceID,
+					Name: "FoobarCluster",
+
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							ResourceNameTemplate: "prod-aro",
+						},
+					},
+				},
+			},
+			wantedInfraID: "prod-aro-cbhtc",
+		},
 		{
 			name: "infra ID set and left alone",
 			oc: &api.OpenShiftClusterDocument{
@@ -400,3 +516,143 @@ func TestEnsureInfraID(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkPublicLoadBalancer(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		loadBalancerProfile    *api.LoadBalancerProfile
+		wantFrontendIPConfigs  []string
+		wantOutboundRuleConfig string
+		wantDependsOn          []string
+	}{
+		{
+			name: "individual managed ips",
+			loadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPs: &api.ManagedOutboundIPs{
+					Count: 1,
+				},
+			},
+			wantFrontendIPConfigs:  []string{"public-lb-ip-v4"},
+			wantOutboundRuleConfig: "[resourceId('Microsoft.Network/loadBalancers/frontendIPConfigurations', 'infraID', 'public-lb-ip-v4')]",
+			wantDependsOn: []string{
+				"Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+			},
+		},
+		{
+			name: "managed outbound ip prefix",
+			loadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+			},
+			wantFrontendIPConfigs:  []string{"public-lb-ip-v4", "outbound-prefix-v4"},
+			wantOutboundRuleConfig: "[resourceId('Microsoft.Network/loadBalancers/frontendIPConfigurations', 'infraID', 'outbound-prefix-v4')]",
+			wantDependsOn: []string{
+				"Microsoft.Network/publicIPAddresses/infraID-pip-v4",
+				"Microsoft.Network/publicIPPrefixes/infraID-outbound-pipprefix-v4",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+							NetworkProfile: api.NetworkProfile{
+								OutboundType:        api.OutboundTypeLoadbalancer,
+								LoadBalancerProfile: tt.loadBalancerProfile,
+							},
+						},
+					},
+				},
+			}
+
+			r := m.networkPublicLoadBalancer("eastus")
+			lb := r.Resource.(*mgmtnetwork.LoadBalancer)
+
+			var frontendIPConfigs []string
+			for _, fipConfig := range *lb.FrontendIPConfigurations {
+				frontendIPConfigs = append(frontendIPConfigs, *fipConfig.Name)
+			}
+			if !reflect.DeepEqual(frontendIPConfigs, tt.wantFrontendIPConfigs) {
+				t.Error(frontendIPConfigs)
+			}
+
+			outboundRuleConfigs := *(*lb.OutboundRules)[0].FrontendIPConfigurations
+			if len(outboundRuleConfigs) != 1 || *outboundRuleConfigs[0].ID != tt.wantOutboundRuleConfig {
+				t.Error(outboundRuleConfigs)
+			}
+
+			if !reflect.DeepEqual(r.DependsOn, tt.wantDependsOn) {
+				t.Error(r.DependsOn)
+			}
+		})
+	}
+}
+
+func TestWorkerProfilePlacementResources(t *testing.T) {
+	for _, tt := range []struct {
+		name                        string
+		proximityPlacementGroupID   string
+		wantResources               []string
+		wantProximityPlacementGroup string
+		wantDependsOn               []string
+	}{
+		{
+			name:                        "proximity placement group created",
+			wantResources:               []string{"infraID-infra-ppg", "infraID-infra-as"},
+			wantProximityPlacementGroup: "[resourceId('Microsoft.Compute/proximityPlacementGroups', 'infraID-infra-ppg')]",
+			wantDependsOn: []string{
+				"Microsoft.Compute/proximityPlacementGroups/infraID-infra-ppg",
+			},
+		},
+		{
+			name:                        "existing proximity placement group",
+			proximityPlacementGroupID:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ppg/providers/Microsoft.Compute/proximityPlacementGroups/infra",
+			wantResources:               []string{"infraID-infra-as"},
+			wantProximityPlacementGroup: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/ppg/providers/Microsoft.Compute/proximityPlacementGroups/infra",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							InfraID: "infraID",
+						},
+					},
+				},
+			}
+
+			resources := m.workerProfilePlacementResources("eastus", &api.WorkerProfile{
+				Name: "infra",
+				ProximityPlacementGroupProfile: &api.ProximityPlacementGroupProfile{
+					ID: tt.proximityPlacementGroupID,
+				},
+			})
+
+			var names []string
+			for _, r := range resources {
+				switch r := r.Resource.(type) {
+				case *mgmtcompute.ProximityPlacementGroup:
+					names = append(names, *r.Name)
+				case *mgmtcompute.AvailabilitySet:
+					names = append(names, *r.Name)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantResources) {
+				t.Error(names)
+			}
+
+			r := resources[len(resources)-1]
+			as := r.Resource.(*mgmtcompute.AvailabilitySet)
+			if *as.ProximityPlacementGroup.ID != tt.wantProximityPlacementGroup {
+				t.Error(*as.ProximityPlacementGroup.ID)
+			}
+			if !reflect.DeepEqual(r.DependsOn, tt.wantDependsOn) {
+				t.Error(r.DependsOn)
+			}
+		})
+	}
+}
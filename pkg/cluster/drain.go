@@ -0,0 +1,140 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/kubectl/pkg/drain"
+)
+
+// cordonNode sets whether the node is unschedulable
+func (m *manager) cordonNode(ctx context.Context, nodeName string, shouldCordon bool) error {
+	node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	return drain.RunCordonOrUncordon(m.drainer(ctx, 0), node, shouldCordon)
+}
+
+// drainNode drains the node using the eviction API, so that pod disruption
+// budgets are respected.  Evictions which are refused by a pod disruption
+// budget are retried until the timeout expires.
+func (m *manager) drainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	return drain.RunNodeDrain(m.drainer(ctx, timeout), nodeName)
+}
+
+func (m *manager) drainer(ctx context.Context, timeout time.Duration) *drain.Helper {
+	return &drain.Helper{
+		Ctx:                 ctx,
+		Client:              m.kubernetescli,
+		Force:               true,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		Timeout:             timeout,
+		DeleteEmptyDirData:  true,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			m.log.Printf("evicted pod %s/%s", pod.Namespace, pod.Name)
+		},
+		Out:    m.log.Writer(),
+		ErrOut: m.log.Writer(),
+	}
+}
+
+// nodeDisruptionBudgetViolations returns the namespace/name of each pod
+// disruption budget which would be violated by evicting all the pods which a
+// drain would evict from the node
+func (m *manager) nodeDisruptionBudgetViolations(ctx context.Context, nodeName string) ([]string, error) {
+	pods, err := m.kubernetescli.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	podsByNamespace := map[string][]corev1.Pod{}
+	for i := range pods.Items {
+		if !isEvictable(&pods.Items[i]) {
+			continue
+		}
+		podsByNamespace[pods.Items[i].Namespace] = append(podsByNamespace[pods.Items[i].Namespace], pods.Items[i])
+	}
+
+	var violations []string
+	for namespace, pods := range podsByNamespace {
+		pdbs, err := m.kubernetescli.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pdb := range pdbs.Items {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				return nil, err
+			}
+
+			var disrupted int32
+			for _, pod := range pods {
+				if selector.Matches(labels.Set(pod.Labels)) {
+					disrupted++
+				}
+			}
+
+			if disrupted > 0 && disrupted > pdb.Status.DisruptionsAllowed {
+				violations = append(violations, pdb.Namespace+"/"+pdb.Name)
+			}
+		}
+	}
+
+	sort.Strings(violations)
+
+	return violations, nil
+}
+
+// nodeEvictablePods returns the namespace/name of each pod on the node which a
+// drain would evict, i.e. the pods which a failed drain left behind
+func (m *manager) nodeEvictablePods(ctx context.Context, nodeName string) ([]string, error) {
+	pods, err := m.kubernetescli.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var evictable []string
+	for i := range pods.Items {
+		if isEvictable(&pods.Items[i]) {
+			evictable = append(evictable, pods.Items[i].Namespace+"/"+pods.Items[i].Name)
+		}
+	}
+
+	sort.Strings(evictable)
+
+	return evictable, nil
+}
+
+// isEvictable returns true if a drain would evict the pod, i.e. it is running
+// and it is neither a mirror pod nor managed by a DaemonSet
+func isEvictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+
+	if ref := metav1.GetControllerOf(pod); ref != nil && ref.Kind == "DaemonSet" {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,177 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNodeDisruptionBudgetViolations(t *testing.T) {
+	nodeName := "aro-worker-1"
+
+	pod := func(namespace, name string, labels map[string]string, phase corev1.PodPhase, ownerKind string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    labels,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+		if ownerKind != "" {
+			p.OwnerReferences = []metav1.OwnerReference{
+				{
+					Kind:       ownerKind,
+					Name:       "owner",
+					Controller: &[]bool{true}[0],
+				},
+			}
+		}
+		return p
+	}
+
+	pdb := func(namespace, name string, labels map[string]string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: disruptionsAllowed,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		objects []runtime.Object
+		want    []string
+	}{
+		{
+			name: "no pod disruption budgets",
+			objects: []runtime.Object{
+				pod("customer", "app-1", map[string]string{"app": "app"}, corev1.PodRunning, "ReplicaSet"),
+			},
+		},
+		{
+			name: "disruption allowed",
+			objects: []runtime.Object{
+				pod("customer", "app-1", map[string]string{"app": "app"}, corev1.PodRunning, "ReplicaSet"),
+				pdb("customer", "app", map[string]string{"app": "app"}, 1),
+			},
+		},
+		{
+			name: "disruption not allowed",
+			objects: []runtime.Object{
+				pod("customer", "app-1", map[string]string{"app": "app"}, corev1.PodRunning, "ReplicaSet"),
+				pdb("customer", "app", map[string]string{"app": "app"}, 0),
+				pdb("customer", "other", map[string]string{"app": "other"}, 0),
+			},
+			want: []string{"customer/app"},
+		},
+		{
+			name: "more pods on the node than disruptions allowed",
+			objects: []runtime.Object{
+				pod("customer", "db-1", map[string]string{"app": "db"}, corev1.PodRunning, "StatefulSet"),
+				pod("customer", "db-2", map[string]string{"app": "db"}, corev1.PodRunning, "StatefulSet"),
+				pdb("customer", "db", map[string]string{"app": "db"}, 1),
+			},
+			want: []string{"customer/db"},
+		},
+		{
+			name: "pods which are not evicted are ignored",
+			objects: []runtime.Object{
+				pod("customer", "ds-1", map[string]string{"app": "app"}, corev1.PodRunning, "DaemonSet"),
+				pod("customer", "job-1", map[string]string{"app": "app"}, corev1.PodSucceeded, "Job"),
+				pdb("customer", "app", map[string]string{"app": "app"}, 0),
+			},
+		},
+		{
+			name: "pod disruption budgets in other namespaces are ignored",
+			objects: []runtime.Object{
+				pod("customer", "app-1", map[string]string{"app": "app"}, corev1.PodRunning, "ReplicaSet"),
+				pdb("other", "app", map[string]string{"app": "app"}, 0),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				kubernetescli: fake.NewSimpleClientset(tt.objects...),
+			}
+
+			got, err := m.nodeDisruptionBudgetViolations(context.Background(), nodeName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeEvictablePods(t *testing.T) {
+	nodeName := "aro-worker-1"
+
+	pod := func(name string, phase corev1.PodPhase, ownerKind string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "customer",
+				Name:      name,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Kind:       ownerKind,
+						Name:       "owner",
+						Controller: &[]bool{true}[0],
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		kubernetescli: fake.NewSimpleClientset(
+			pod("db-1", corev1.PodRunning, "StatefulSet"),
+			pod("app-1", corev1.PodRunning, "ReplicaSet"),
+			pod("ds-1", corev1.PodRunning, "DaemonSet"),
+			pod("job-1", corev1.PodSucceeded, "Job"),
+		),
+	}
+
+	got, err := m.nodeEvictablePods(context.Background(), nodeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"customer/app-1", "customer/db-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// drainMachineSetDefaultDrainTimeout is how long the drain of the nodes waits
+// for their pods to be evicted, if no drainTimeout is given
+var drainMachineSetDefaultDrainTimeout = 10 * time.Minute
+
+// drainMachineSet cordons all the nodes of a machine set and drains them
+// using the eviction API, so that pod disruption budgets are respected.  The
+// nodes are drained in parallel, once they are all cordoned so that the
+// evicted pods aren't rescheduled onto them.  The nodes are left cordoned; if
+// some of them didn't finish draining, the pods which couldn't be evicted are
+// returned in the error.  It only runs when requested by the DrainMachineSet
+// maintenance task.
+func (m *manager) drainMachineSet(ctx context.Context) error {
+	params := m.doc.OpenShiftCluster.Properties.MaintenanceTaskParameters
+	if params == nil || params.MachineSetName == "" {
+		return errors.New("no machine set to drain was given")
+	}
+	machineSetName := params.MachineSetName
+
+	drainTimeout := drainMachineSetDefaultDrainTimeout
+	if params.DrainTimeout != "" {
+		var err error
+		drainTimeout, err = time.ParseDuration(params.DrainTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the parameters are consumed, so that the task isn't repeated by a later
+	// admin update
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MaintenanceTaskParameters = nil
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	nodes, err := m.machineSetNodes(ctx, machineSetName)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		m.log.Printf("cordoning node %s", node)
+		err = m.cordonNode(ctx, node, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	unevictedPods, err := m.drainNodes(ctx, nodes, drainTimeout)
+	if err != nil {
+		return err
+	}
+
+	if len(unevictedPods) > 0 {
+		var messages []string
+		for _, node := range nodes {
+			if pods, ok := unevictedPods[node]; ok {
+				messages = append(messages, fmt.Sprintf("%s: %s", node, strings.Join(pods, ", ")))
+			}
+		}
+		return fmt.Errorf("some nodes of machine set %s did not finish draining within %s, the pods which could not be evicted are %s", machineSetName, drainTimeout, strings.Join(messages, "; "))
+	}
+
+	m.log.Printf("machine set %s drained", machineSetName)
+	return nil
+}
+
+// machineSetNodes returns the names of the nodes of the machine set's
+// machines.  Machines which don't have a node yet are skipped.
+func (m *manager) machineSetNodes(ctx context.Context, machineSetName string) ([]string, error) {
+	// return a not found error if the machine set doesn't exist
+	_, err := m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, machineSetName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	machines, err := m.maocli.MachineV1beta1().Machines("openshift-machine-api").List(ctx, metav1.ListOptions{
+		LabelSelector: machineSetLabel + "=" + machineSetName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []string{}
+	for _, machine := range machines.Items {
+		if machine.Status.NodeRef != nil {
+			nodes = append(nodes, machine.Status.NodeRef.Name)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+// drainNodes drains the nodes in parallel, returning the pods left on each
+// node which didn't finish draining within the timeout
+func (m *manager) drainNodes(ctx context.Context, nodes []string, timeout time.Duration) (map[string][]string, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	unevictedPods := map[string][]string{}
+
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+
+			m.log.Printf("draining node %s (timeout %s)", node, timeout)
+			err := m.drainNode(ctx, node, timeout)
+			if err == nil {
+				return
+			}
+			m.log.Warnf("draining node %s: %v", node, err)
+
+			pods, err := m.nodeEvictablePods(ctx, node)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+
+			if len(pods) > 0 {
+				unevictedPods[node] = pods
+			}
+		}(node)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return unevictedPods, nil
+}
@@ -0,0 +1,143 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestDrainMachineSet(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	machineSetName := "aro-worker-australiasoutheast1"
+
+	machine := func(name, machineSet, nodeName string) *machinev1beta1.Machine {
+		m := &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-machine-api",
+				Name:      name,
+				Labels:    map[string]string{machineSetLabel: machineSet},
+			},
+		}
+		if nodeName != "" {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: nodeName}
+		}
+		return m
+	}
+
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	machineSet := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-machine-api",
+			Name:      machineSetName,
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		params         *api.MaintenanceTaskParameters
+		machineObjects []kruntime.Object
+		wantCordoned   []string
+		wantUncordoned []string
+		wantErr        string
+	}{
+		{
+			name:    "no parameters",
+			wantErr: "no machine set to drain was given",
+		},
+		{
+			name:    "machine set not found",
+			params:  &api.MaintenanceTaskParameters{MachineSetName: machineSetName},
+			wantErr: `machinesets.machine.openshift.io "` + machineSetName + `" not found`,
+		},
+		{
+			name:   "nodes of the machine set are cordoned and drained",
+			params: &api.MaintenanceTaskParameters{MachineSetName: machineSetName, DrainTimeout: "30m"},
+			machineObjects: []kruntime.Object{
+				machineSet,
+				machine("m1", machineSetName, "node-1"),
+				machine("m2", machineSetName, "node-2"),
+				machine("m3", machineSetName, ""),
+				machine("other", "aro-worker-australiasoutheast2", "node-other"),
+			},
+			wantCordoned:   []string{"node-1", "node-2"},
+			wantUncordoned: []string{"node-other"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:         api.ProvisioningStateAdminUpdating,
+						MaintenanceTask:           api.MaintenanceTaskDrainMachineSet,
+						MaintenanceTaskParameters: tt.params,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kubernetescli := fake.NewSimpleClientset(node("node-1"), node("node-2"), node("node-other"))
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				doc:           clusterdoc,
+				db:            fakeOpenShiftClustersDatabase,
+				kubernetescli: kubernetescli,
+				maocli:        machinefake.NewSimpleClientset(tt.machineObjects...),
+			}
+
+			err = m.drainMachineSet(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.OpenShiftCluster.Properties.MaintenanceTaskParameters != nil {
+				t.Error("maintenance task parameters were not cleared")
+			}
+
+			for unschedulable, want := range map[bool][]string{true: tt.wantCordoned, false: tt.wantUncordoned} {
+				for _, nodeName := range want {
+					node, err := kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if node.Spec.Unschedulable != unschedulable {
+						t.Errorf("node %s: got unschedulable %t", nodeName, node.Spec.Unschedulable)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// updateEffectiveOutboundProfile records how the egress of the worker nodes
+// reaches the internet in the cluster document.  It is best effort: the
+// egress of the cluster is not changed by the RP, so failing to read it must
+// not fail the operation.
+func (m *manager) updateEffectiveOutboundProfile(ctx context.Context) error {
+	corrections, err := m.syncEffectiveOutboundProfile(ctx)
+	if err != nil {
+		m.log.Warnf("skipping networkProfile.effectiveOutboundProfile: %v", err)
+		return nil
+	}
+
+	if len(corrections) == 0 {
+		return nil
+	}
+
+	return m.applyPropertyCorrections(ctx, corrections)
+}
+
+func (m *manager) syncEffectiveOutboundProfile(ctx context.Context) ([]propertyCorrection, error) {
+	p, err := m.effectiveOutboundProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return correction("networkProfile.effectiveOutboundProfile", formatEffectiveOutboundProfile(m.doc.OpenShiftCluster.Properties.NetworkProfile.EffectiveOutboundProfile), formatEffectiveOutboundProfile(p), func(doc *api.OpenShiftClusterDocument) {
+		doc.OpenShiftCluster.Properties.NetworkProfile.EffectiveOutboundProfile = p
+	}), nil
+}
+
+// effectiveOutboundProfile reads the outbound connectivity method of the
+// worker subnet of the default worker profile.  A default route to anything
+// but the internet takes precedence over a NAT gateway, which takes
+// precedence over the outbound rules of the public load balancer.
+func (m *manager) effectiveOutboundProfile(ctx context.Context) (*api.EffectiveOutboundProfile, error) {
+	subnetID := m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID
+	if len(m.doc.OpenShiftCluster.Properties.WorkerProfiles) > 0 {
+		subnetID = m.doc.OpenShiftCluster.Properties.WorkerProfiles[0].SubnetID
+	}
+
+	s, err := m.subnet.Get(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &api.EffectiveOutboundProfile{}
+
+	if s.SubnetPropertiesFormat != nil && s.RouteTable != nil && s.RouteTable.ID != nil {
+		p.RouteTableID = *s.RouteTable.ID
+
+		route, err := m.defaultRoute(ctx, p.RouteTableID)
+		if err != nil {
+			return nil, err
+		}
+
+		if route != nil && route.NextHopType != mgmtnetwork.RouteNextHopTypeInternet {
+			p.Method = api.EffectiveOutboundMethodUserDefinedRouting
+			p.NextHopIPAddress = to.String(route.NextHopIPAddress)
+			return p, nil
+		}
+	}
+
+	if s.SubnetPropertiesFormat != nil && s.NatGateway != nil && s.NatGateway.ID != nil {
+		p.Method = api.EffectiveOutboundMethodNATGateway
+		p.NATGatewayID = *s.NatGateway.ID
+		p.OutboundIPAddresses, err = m.natGatewayOutboundIPAddresses(ctx, p.NATGatewayID)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType == api.OutboundTypeUserDefinedRouting {
+		p.Method = api.EffectiveOutboundMethodUserDefinedRouting
+		return p, nil
+	}
+
+	p.Method = api.EffectiveOutboundMethodLoadBalancer
+	p.OutboundIPAddresses, err = m.loadBalancerOutboundIPAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// defaultRoute returns the 0.0.0.0/0 route of the route table, if any
+func (m *manager) defaultRoute(ctx context.Context, routeTableID string) (*mgmtnetwork.RoutePropertiesFormat, error) {
+	r, err := azure.ParseResourceID(routeTableID)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := m.routeTables.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if rt.RouteTablePropertiesFormat == nil || rt.Routes == nil {
+		return nil, nil
+	}
+
+	for _, route := range *rt.Routes {
+		if route.RoutePropertiesFormat != nil && to.String(route.AddressPrefix) == "0.0.0.0/0" {
+			return route.RoutePropertiesFormat, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *manager) natGatewayOutboundIPAddresses(ctx context.Context, natGatewayID string) ([]string, error) {
+	r, err := azure.ParseResourceID(natGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	ng, err := m.natGateways.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if ng.NatGatewayPropertiesFormat == nil {
+		return nil, nil
+	}
+
+	var ipIDs, prefixIDs []string
+	if ng.PublicIPAddresses != nil {
+		for _, ip := range *ng.PublicIPAddresses {
+			ipIDs = append(ipIDs, to.String(ip.ID))
+		}
+	}
+	if ng.PublicIPPrefixes != nil {
+		for _, prefix := range *ng.PublicIPPrefixes {
+			prefixIDs = append(prefixIDs, to.String(prefix.ID))
+		}
+	}
+
+	return m.outboundIPAddresses(ctx, ipIDs, prefixIDs)
+}
+
+// loadBalancerOutboundIPAddresses returns the addresses of the effective
+// outbound IPs of the public load balancer, which reconcileLoadBalancerProfile
+// keeps up to date in the cluster document
+func (m *manager) loadBalancerOutboundIPAddresses(ctx context.Context) ([]string, error) {
+	lbp := m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile
+	if lbp == nil {
+		return nil, nil
+	}
+
+	ipIDs := make([]string, 0, len(lbp.EffectiveOutboundIPs))
+	for _, ip := range lbp.EffectiveOutboundIPs {
+		ipIDs = append(ipIDs, ip.ID)
+	}
+
+	addresses, err := m.outboundIPAddresses(ctx, ipIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if lbp.EffectiveOutboundIPPrefix != nil && lbp.EffectiveOutboundIPPrefix.IPPrefix != "" {
+		addresses = append(addresses, lbp.EffectiveOutboundIPPrefix.IPPrefix)
+	}
+
+	return addresses, nil
+}
+
+// outboundIPAddresses returns the addresses of the public IP addresses and the
+// CIDRs of the public IP prefixes
+func (m *manager) outboundIPAddresses(ctx context.Context, ipIDs, prefixIDs []string) ([]string, error) {
+	var addresses []string
+
+	for _, id := range ipIDs {
+		r, err := azure.ParseResourceID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, err := m.publicIPAddresses.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if ip.PublicIPAddressPropertiesFormat != nil && ip.IPAddress != nil {
+			addresses = append(addresses, *ip.IPAddress)
+		}
+	}
+
+	for _, id := range prefixIDs {
+		r, err := azure.ParseResourceID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix, err := m.publicIPPrefixes.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if prefix.PublicIPPrefixPropertiesFormat != nil && prefix.IPPrefix != nil {
+			addresses = append(addresses, *prefix.IPPrefix)
+		}
+	}
+
+	return addresses, nil
+}
+
+func formatEffectiveOutboundProfile(p *api.EffectiveOutboundProfile) string {
+	if p == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("method=%s,outboundIpAddresses=%s,natGatewayId=%s,routeTableId=%s,nextHopIpAddress=%s",
+		p.Method, strings.Join(p.OutboundIPAddresses, " "), p.NATGatewayID, p.RouteTableID, p.NextHopIPAddress)
+}
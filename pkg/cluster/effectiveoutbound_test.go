@@ -0,0 +1,232 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	mock_subnet "github.com/Azure/ARO-RP/pkg/util/mocks/subnet"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEffectiveOutboundProfile(t *testing.T) {
+	ctx := context.Background()
+
+	vnetID := "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/virtualNetworks/vnet"
+	workerSubnetID := vnetID + "/subnets/worker"
+	routeTableID := "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/routeTables/rt"
+	natGatewayID := "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/natGateways/ng"
+	natGatewayIPID := "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/publicIPAddresses/ng-ip"
+	natGatewayPrefixID := "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/publicIPPrefixes/ng-prefix"
+	lbIPID := "/subscriptions/subscriptionId/resourceGroups/clusterResourceGroup/providers/Microsoft.Network/publicIPAddresses/infra-pip-v4"
+
+	subnet := func(routeTable, natGateway bool) *mgmtnetwork.Subnet {
+		s := &mgmtnetwork.Subnet{
+			SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{},
+		}
+		if routeTable {
+			s.RouteTable = &mgmtnetwork.RouteTable{ID: to.StringPtr(routeTableID)}
+		}
+		if natGateway {
+			s.NatGateway = &mgmtnetwork.SubResource{ID: to.StringPtr(natGatewayID)}
+		}
+		return s
+	}
+
+	routeTable := func(nextHopType mgmtnetwork.RouteNextHopType, nextHopIPAddress string) mgmtnetwork.RouteTable {
+		route := mgmtnetwork.Route{
+			RoutePropertiesFormat: &mgmtnetwork.RoutePropertiesFormat{
+				AddressPrefix: to.StringPtr("0.0.0.0/0"),
+				NextHopType:   nextHopType,
+			},
+		}
+		if nextHopIPAddress != "" {
+			route.NextHopIPAddress = to.StringPtr(nextHopIPAddress)
+		}
+		return mgmtnetwork.RouteTable{
+			RouteTablePropertiesFormat: &mgmtnetwork.RouteTablePropertiesFormat{
+				Routes: &[]mgmtnetwork.Route{route},
+			},
+		}
+	}
+
+	expectNATGateway := func(natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+		natGateways.EXPECT().
+			Get(gomock.Any(), "vnetResourceGroup", "ng", "").
+			Return(mgmtnetwork.NatGateway{
+				NatGatewayPropertiesFormat: &mgmtnetwork.NatGatewayPropertiesFormat{
+					PublicIPAddresses: &[]mgmtnetwork.SubResource{{ID: to.StringPtr(natGatewayIPID)}},
+					PublicIPPrefixes:  &[]mgmtnetwork.SubResource{{ID: to.StringPtr(natGatewayPrefixID)}},
+				},
+			}, nil)
+		publicIPAddresses.EXPECT().
+			Get(gomock.Any(), "vnetResourceGroup", "ng-ip", "").
+			Return(mgmtnetwork.PublicIPAddress{
+				PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+					IPAddress: to.StringPtr("20.0.0.1"),
+				},
+			}, nil)
+		publicIPPrefixes.EXPECT().
+			Get(gomock.Any(), "vnetResourceGroup", "ng-prefix", "").
+			Return(mgmtnetwork.PublicIPPrefix{
+				PublicIPPrefixPropertiesFormat: &mgmtnetwork.PublicIPPrefixPropertiesFormat{
+					IPPrefix: to.StringPtr("20.0.1.0/30"),
+				},
+			}, nil)
+	}
+
+	for _, tt := range []struct {
+		name         string
+		outboundType api.OutboundType
+		mocks        func(*mock_subnet.MockManager, *mock_network.MockRouteTablesClient, *mock_network.MockNatGatewaysClient, *mock_network.MockPublicIPAddressesClient, *mock_network.MockPublicIPPrefixesClient)
+		want         *api.EffectiveOutboundProfile
+		wantErr      string
+	}{
+		{
+			name:         "load balancer",
+			outboundType: api.OutboundTypeLoadbalancer,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(false, false), nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v4", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("10.0.0.1"),
+						},
+					}, nil)
+			},
+			want: &api.EffectiveOutboundProfile{
+				Method:              api.EffectiveOutboundMethodLoadBalancer,
+				OutboundIPAddresses: []string{"10.0.0.1"},
+			},
+		},
+		{
+			name:         "NAT gateway takes precedence over the load balancer",
+			outboundType: api.OutboundTypeLoadbalancer,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(false, true), nil)
+				expectNATGateway(natGateways, publicIPAddresses, publicIPPrefixes)
+			},
+			want: &api.EffectiveOutboundProfile{
+				Method:              api.EffectiveOutboundMethodNATGateway,
+				OutboundIPAddresses: []string{"20.0.0.1", "20.0.1.0/30"},
+				NATGatewayID:        natGatewayID,
+			},
+		},
+		{
+			name:         "default route to the internet does not take precedence over the NAT gateway",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(true, true), nil)
+				routeTables.EXPECT().
+					Get(gomock.Any(), "vnetResourceGroup", "rt", "").
+					Return(routeTable(mgmtnetwork.RouteNextHopTypeInternet, ""), nil)
+				expectNATGateway(natGateways, publicIPAddresses, publicIPPrefixes)
+			},
+			want: &api.EffectiveOutboundProfile{
+				Method:              api.EffectiveOutboundMethodNATGateway,
+				OutboundIPAddresses: []string{"20.0.0.1", "20.0.1.0/30"},
+				NATGatewayID:        natGatewayID,
+				RouteTableID:        routeTableID,
+			},
+		},
+		{
+			name:         "default route to a virtual appliance takes precedence over the NAT gateway",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(true, true), nil)
+				routeTables.EXPECT().
+					Get(gomock.Any(), "vnetResourceGroup", "rt", "").
+					Return(routeTable(mgmtnetwork.RouteNextHopTypeVirtualAppliance, "10.1.0.4"), nil)
+			},
+			want: &api.EffectiveOutboundProfile{
+				Method:           api.EffectiveOutboundMethodUserDefinedRouting,
+				RouteTableID:     routeTableID,
+				NextHopIPAddress: "10.1.0.4",
+			},
+		},
+		{
+			name:         "user defined routing without route table",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(false, false), nil)
+			},
+			want: &api.EffectiveOutboundProfile{
+				Method: api.EffectiveOutboundMethodUserDefinedRouting,
+			},
+		},
+		{
+			name:         "route table not readable",
+			outboundType: api.OutboundTypeUserDefinedRouting,
+			mocks: func(subnets *mock_subnet.MockManager, routeTables *mock_network.MockRouteTablesClient, natGateways *mock_network.MockNatGatewaysClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				subnets.EXPECT().Get(gomock.Any(), workerSubnetID).Return(subnet(true, false), nil)
+				routeTables.EXPECT().
+					Get(gomock.Any(), "vnetResourceGroup", "rt", "").
+					Return(mgmtnetwork.RouteTable{}, errors.New("authorization failed"))
+			},
+			wantErr: "authorization failed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			subnets := mock_subnet.NewMockManager(controller)
+			routeTables := mock_network.NewMockRouteTablesClient(controller)
+			natGateways := mock_network.NewMockNatGatewaysClient(controller)
+			publicIPAddresses := mock_network.NewMockPublicIPAddressesClient(controller)
+			publicIPPrefixes := mock_network.NewMockPublicIPPrefixesClient(controller)
+			tt.mocks(subnets, routeTables, natGateways, publicIPAddresses, publicIPPrefixes)
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							MasterProfile: api.MasterProfile{
+								SubnetID: vnetID + "/subnets/master",
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									SubnetID: workerSubnetID,
+								},
+							},
+							NetworkProfile: api.NetworkProfile{
+								OutboundType: tt.outboundType,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+										{ID: lbIPID},
+									},
+								},
+							},
+						},
+					},
+				},
+				subnet:            subnets,
+				routeTables:       routeTables,
+				natGateways:       natGateways,
+				publicIPAddresses: publicIPAddresses,
+				publicIPPrefixes:  publicIPPrefixes,
+			}
+
+			got, err := m.effectiveOutboundProfile(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(deep.Equal(got, tt.want))
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 )
@@ -22,16 +23,25 @@ func (m *manager) ensureServiceEndpoints(ctx context.Context) error {
 	return m.subnet.CreateOrUpdateFromIds(ctx, subnetIds, m.doc.OpenShiftCluster.Properties.FeatureProfile.GatewayEnabled)
 }
 
+// getSubnetIds returns the IDs of the master subnet and of each distinct
+// worker subnet; worker profiles may share a subnet
 func (m *manager) getSubnetIds() ([]string, error) {
 	subnets := []string{
 		m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID,
 	}
+	seen := map[string]bool{
+		strings.ToLower(m.doc.OpenShiftCluster.Properties.MasterProfile.SubnetID): true,
+	}
 	workerProfiles, _ := api.GetEnrichedWorkerProfiles(m.doc.OpenShiftCluster.Properties)
 
 	for _, wp := range workerProfiles {
 		if len(wp.SubnetID) == 0 {
 			return nil, fmt.Errorf("WorkerProfile '%s' has no SubnetID; check that the corresponding MachineSet is valid", wp.Name)
 		}
+		if seen[strings.ToLower(wp.SubnetID)] {
+			continue
+		}
+		seen[strings.ToLower(wp.SubnetID)] = true
 		subnets = append(subnets, wp.SubnetID)
 	}
 	return subnets, nil
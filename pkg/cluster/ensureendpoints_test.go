@@ -5,6 +5,8 @@ package cluster
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -104,3 +106,31 @@ func TestEnsureServiceEndpointsShouldReturnWorkerProfileHasNoSubnetIdErrorAndSho
 		t.Fatalf("expected error '%v', but got '%v'", expectedError, err)
 	}
 }
+
+func TestGetSubnetIdsDeduplicatesWorkerSubnets(t *testing.T) {
+	subnetIdInfra := "/subscriptions/" + subscriptionId + "/resourceGroups/" + vnetResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + vnetName + "/subnets/infra"
+
+	m := &manager{
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					MasterProfile: api.MasterProfile{SubnetID: subnetIdMaster},
+					WorkerProfiles: []api.WorkerProfile{
+						{Name: "worker", SubnetID: subnetIdWorker},
+						{Name: "infra", SubnetID: subnetIdInfra},
+						{Name: "other", SubnetID: strings.ToUpper(subnetIdWorker)},
+					},
+				},
+			},
+		},
+	}
+
+	subnetIds, err := m.getSubnetIds()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(subnetIds, []string{subnetIdMaster, subnetIdWorker, subnetIdInfra}) {
+		t.Error(subnetIds)
+	}
+}
@@ -0,0 +1,623 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+//go:embed scripts/etcdbackup.sh
+var etcdBackupScript string
+
+const (
+	// maxEtcdBackups is the number of etcd backups kept for a cluster.  The
+	// oldest backups are deleted when a new one is taken.
+	maxEtcdBackups = 10
+
+	// etcdBackupContainer is the container of the cluster storage account
+	// which holds the etcd backups
+	etcdBackupContainer = "etcd-backups"
+
+	etcdBackupNamespace    = "openshift-etcd"
+	etcdBackupPodContainer = "etcd-backup"
+	etcdBackupPodImage     = "ubi8/ubi-minimal"
+	etcdRestoreDir         = "/var/lib/etcd-restore"
+	etcdBackupSASValidity  = 15 * time.Minute
+)
+
+// etcdBackupPodTimeout is how long we wait for an etcd backup or restore pod
+// to complete.  Restoring etcd takes the API server down, so this also bounds
+// how long we wait for it to come back.
+var etcdBackupPodTimeout = 30 * time.Minute
+
+var etcdBackupPodInterval = 10 * time.Second
+
+var rxEtcdBackupSHA256 = regexp.MustCompile(`(?m)^sha256: ([0-9a-f]{64})$`)
+
+// backupEtcd takes an etcd backup on a master with a ready etcd member,
+// encrypts it with a key unique to the backup and uploads it to the
+// etcd-backups container of the cluster storage account.  The backup, its
+// key and the digest of the uploaded blob are recorded in the cluster
+// document, and the oldest backups are deleted.  It only runs when requested
+// by the EtcdBackup maintenance task.
+func (m *manager) backupEtcd(ctx context.Context) error {
+	node, err := m.etcdBackupNode(ctx)
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	if err != nil {
+		return err
+	}
+
+	now := m.now().UTC()
+	backup := api.EtcdBackup{
+		Name:           "etcd-backup-" + now.Format("20060102-150405"),
+		CreatedAt:      now,
+		ClusterVersion: m.doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		Node:           node,
+		EncryptionKey:  api.SecureString(hex.EncodeToString(key)),
+	}
+
+	blobURL, err := m.etcdBackupBlobURL(ctx, backup.Name, true)
+	if err != nil {
+		return err
+	}
+
+	logs, err := m.runEtcdBackupPod(ctx, backup.Name, node, "backup", map[string]string{
+		"BLOB_URL":       blobURL,
+		"ENCRYPTION_KEY": string(backup.EncryptionKey),
+	})
+	if err != nil {
+		return fmt.Errorf("etcd backup failed: %w", err)
+	}
+
+	match := rxEtcdBackupSHA256.FindSubmatch(logs)
+	if match == nil {
+		return errors.New("etcd backup failed: the backup pod did not report the backup digest")
+	}
+	backup.SHA256 = string(match[1])
+
+	var pruned []api.EtcdBackup
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.EtcdBackups, pruned = pruneEtcdBackups(append(doc.OpenShiftCluster.Properties.EtcdBackups, backup))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.log.Infof("took etcd backup %s on %s", backup.Name, node)
+
+	for _, b := range pruned {
+		m.log.Infof("deleting etcd backup %s", b.Name)
+		err = m.deleteEtcdBackupBlob(ctx, b.Name)
+		if err != nil {
+			m.log.Warnf("failed to delete etcd backup %s: %v", b.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// restoreEtcd restores the etcd backup given in the maintenance task
+// parameters, following the documented procedure for restoring to a previous
+// cluster state.  The parameters are cleared first, so that a later admin
+// update never restores the backup again.  The backup is downloaded, checked
+// against the recorded digest and decrypted on the recovery master before
+// anything is changed.  Etcd and the API server are then stopped on the other
+// masters, the backup is restored on the recovery master and the control
+// plane operators are made to redeploy their operands, which brings the other
+// masters back.  It only runs when requested by the EtcdRestore maintenance
+// task.
+func (m *manager) restoreEtcd(ctx context.Context) error {
+	params := m.doc.OpenShiftCluster.Properties.MaintenanceTaskParameters
+	if params == nil || params.EtcdBackupName == "" {
+		return errors.New("no etcd backup to restore was given")
+	}
+	backupName := params.EtcdBackupName
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MaintenanceTaskParameters = nil
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var backup *api.EtcdBackup
+	for i := range m.doc.OpenShiftCluster.Properties.EtcdBackups {
+		if m.doc.OpenShiftCluster.Properties.EtcdBackups[i].Name == backupName {
+			backup = &m.doc.OpenShiftCluster.Properties.EtcdBackups[i]
+		}
+	}
+	if backup == nil {
+		return fmt.Errorf("etcd backup %s was not found", backupName)
+	}
+
+	masters, err := m.etcdBackupMasters(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = validateEtcdRestore(m.doc.OpenShiftCluster, backup, masters)
+	if err != nil {
+		return err
+	}
+
+	recovery := masters[0].Name
+	for _, master := range masters {
+		if master.Name == backup.Node {
+			recovery = master.Name
+		}
+	}
+
+	blobURL, err := m.etcdBackupBlobURL(ctx, backup.Name, false)
+	if err != nil {
+		return err
+	}
+
+	ts := m.now().UTC().Format("20060102-150405")
+	restoreEnv := map[string]string{
+		"BLOB_URL":       blobURL,
+		"ENCRYPTION_KEY": string(backup.EncryptionKey),
+		"SHA256":         backup.SHA256,
+		"RESTORE_DIR":    etcdRestoreDir,
+	}
+	allLogs := &bytes.Buffer{}
+
+	logs, err := m.runEtcdBackupPod(ctx, "etcd-restore-prepare-"+ts, recovery, "prepare", restoreEnv)
+	allLogs.Write(logs)
+	if err != nil {
+		return fmt.Errorf("etcd backup %s could not be prepared for restore, no changes were made: %w", backup.Name, err)
+	}
+
+	for i, master := range masters {
+		if master.Name == recovery {
+			continue
+		}
+
+		logs, err = m.runEtcdBackupPod(ctx, fmt.Sprintf("etcd-restore-stop-%d-%s", i, ts), master.Name, "stop", nil)
+		allLogs.Write(logs)
+		if err != nil {
+			return fmt.Errorf("stopping etcd on master %s failed: %w", master.Name, err)
+		}
+	}
+
+	// the restored etcd has no record of the restore pod, so rather than
+	// waiting for it to succeed we wait for the API server to come back
+	// without it
+	name := "etcd-restore-" + ts
+	cleanup, err := m.createEtcdBackupPod(ctx, name, recovery, "restore", restoreEnv)
+	if err != nil {
+		return err
+	}
+
+	phase, found, err := m.waitEtcdBackupPod(ctx, name)
+	if err == nil && found && phase != corev1.PodSucceeded {
+		err = fmt.Errorf("pod %s %s", name, strings.ToLower(string(phase)))
+	}
+	if found {
+		logs, _ = m.etcdBackupPodLogs(ctx, name)
+		allLogs.Write(logs)
+	}
+	cleanup()
+	m.log.Info(allLogs.String())
+	if err != nil {
+		return fmt.Errorf("restoring etcd backup %s on master %s failed: %w", backup.Name, recovery, err)
+	}
+
+	// the backup pod and its secret were running when the backup was taken,
+	// so they are restored too
+	m.deleteEtcdBackupPod(ctx, backup.Name)
+
+	return m.redeployEtcdOperands(ctx, "etcd-restore-"+ts)
+}
+
+// pruneEtcdBackups returns the most recent maxEtcdBackups backups, and the
+// older ones which are no longer kept
+func pruneEtcdBackups(backups []api.EtcdBackup) ([]api.EtcdBackup, []api.EtcdBackup) {
+	if len(backups) <= maxEtcdBackups {
+		return backups, nil
+	}
+
+	n := len(backups) - maxEtcdBackups
+	return append([]api.EtcdBackup(nil), backups[n:]...), append([]api.EtcdBackup(nil), backups[:n]...)
+}
+
+// etcdBackupNode returns the first master, by name, running a ready etcd
+// member
+func (m *manager) etcdBackupNode(ctx context.Context) (string, error) {
+	pods, err := m.kubernetescli.CoreV1().Pods(etcdBackupNamespace).List(ctx, metav1.ListOptions{LabelSelector: "app=etcd"})
+	if err != nil {
+		return "", err
+	}
+
+	var nodes []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				nodes = append(nodes, pod.Spec.NodeName)
+			}
+		}
+	}
+
+	if len(nodes) == 0 {
+		return "", errors.New("etcd cannot be backed up, no etcd member is ready")
+	}
+
+	sort.Strings(nodes)
+
+	return nodes[0], nil
+}
+
+// etcdBackupMasters returns the master nodes, sorted by name
+func (m *manager) etcdBackupMasters(ctx context.Context) ([]corev1.Node, error) {
+	nodes, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/master"})
+	if err != nil {
+		return nil, err
+	}
+
+	masters := nodes.Items
+	sort.Slice(masters, func(i, j int) bool { return masters[i].Name < masters[j].Name })
+
+	return masters, nil
+}
+
+// validateEtcdRestore refuses to restore a backup unless it is safe to: the
+// cluster must be at the version the backup was taken at, and all the masters
+// must be ready, since the restore runs on each of them
+func validateEtcdRestore(oc *api.OpenShiftCluster, backup *api.EtcdBackup, masters []corev1.Node) error {
+	if backup.ClusterVersion != oc.Properties.ClusterProfile.Version {
+		return fmt.Errorf("etcd backup %s was taken at cluster version %s and cannot be restored to a cluster at version %s",
+			backup.Name, backup.ClusterVersion, oc.Properties.ClusterProfile.Version)
+	}
+
+	if len(masters) == 0 {
+		return errors.New("etcd cannot be restored, no master nodes were found")
+	}
+
+	for _, master := range masters {
+		var ready bool
+		for _, c := range master.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+
+		if !ready {
+			return fmt.Errorf("etcd cannot be restored, master node %s is not ready", master.Name)
+		}
+	}
+
+	return nil
+}
+
+// etcdBackupBlobURL returns the URL of the named etcd backup blob, including
+// a SAS token which is scoped to the etcd-backups container and expires
+// shortly, since it is handed to a pod on the cluster.  If write is set the
+// token allows the blob to be created, and the container is created if it
+// doesn't exist yet; otherwise it only allows the blob to be read.
+func (m *manager) etcdBackupBlobURL(ctx context.Context, name string, write bool) (string, error) {
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
+
+	blobService, err := m.storage.BlobService(ctx, resourceGroup, account, mgmtstorage.Permissions("c"), mgmtstorage.SignedResourceTypesC)
+	if err != nil {
+		return "", err
+	}
+
+	c := blobService.GetContainerReference(etcdBackupContainer)
+
+	permissions := mgmtstorage.R
+	if write {
+		permissions = mgmtstorage.Permissions("cw")
+
+		_, err = c.CreateIfNotExists(&azstorage.CreateContainerOptions{Access: azstorage.ContainerAccessTypePrivate})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	sas, err := m.storage.ContainerSAS(ctx, resourceGroup, account, etcdBackupContainer, permissions, etcdBackupSASValidity)
+	if err != nil {
+		return "", err
+	}
+
+	return c.GetBlobReference(name).GetURL() + "?" + sas.Encode(), nil
+}
+
+// deleteEtcdBackupBlob deletes the named etcd backup blob, if it exists
+func (m *manager) deleteEtcdBackupBlob(ctx context.Context, name string) error {
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
+
+	blobService, err := m.storage.BlobService(ctx, resourceGroup, account, mgmtstorage.D, mgmtstorage.SignedResourceTypesO)
+	if err != nil {
+		return err
+	}
+
+	_, err = blobService.GetContainerReference(etcdBackupContainer).GetBlobReference(name).DeleteIfExists(nil)
+	return err
+}
+
+// runEtcdBackupPod runs the etcd backup script with the given action and
+// environment in a pod on node, waits for it to succeed and returns its logs
+func (m *manager) runEtcdBackupPod(ctx context.Context, name, node, action string, env map[string]string) ([]byte, error) {
+	cleanup, err := m.createEtcdBackupPod(ctx, name, node, action, env)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	phase, found, err := m.waitEtcdBackupPod(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("pod %s was deleted", name)
+	}
+
+	logs, err := m.etcdBackupPodLogs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if phase != corev1.PodSucceeded {
+		m.log.Info(string(logs))
+		return logs, fmt.Errorf("pod %s %s", name, strings.ToLower(string(phase)))
+	}
+
+	return logs, nil
+}
+
+// createEtcdBackupPod creates a secret holding env and a privileged pod on
+// node, both called name, which runs the etcd backup script chrooted into the
+// host.  The pod is created with the admin credentials of the RP, so is
+// admitted as privileged without a dedicated service account.  It returns a
+// function which deletes them both.
+func (m *manager) createEtcdBackupPod(ctx context.Context, name, node, action string, env map[string]string) (func(), error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: etcdBackupNamespace,
+		},
+		StringData: env,
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: etcdBackupNamespace,
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                     node,
+			RestartPolicy:                corev1.RestartPolicyNever,
+			HostNetwork:                  true,
+			HostPID:                      true,
+			AutomountServiceAccountToken: to.BoolPtr(false),
+			Tolerations: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  etcdBackupPodContainer,
+					Image: etcdBackupPodImage,
+					Command: []string{
+						"chroot",
+						"/host",
+						"/bin/bash",
+						"-c",
+						etcdBackupScript,
+					},
+					Env: []corev1.EnvVar{
+						{
+							Name:  "ACTION",
+							Value: action,
+						},
+					},
+					EnvFrom: []corev1.EnvFromSource{
+						{
+							SecretRef: &corev1.SecretEnvSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: name,
+								},
+							},
+						},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: to.BoolPtr(true),
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "host",
+							MountPath: "/host",
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "host",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: "/",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cleanup := func() {
+		m.deleteEtcdBackupPod(ctx, name)
+	}
+
+	m.log.Infof("creating secret and pod %s on %s", name, node)
+	_, err := m.kubernetescli.CoreV1().Secrets(etcdBackupNamespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.kubernetescli.CoreV1().Pods(etcdBackupNamespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	return cleanup, nil
+}
+
+// deleteEtcdBackupPod deletes the named etcd backup pod and its secret.
+// Failures are only logged, so as not to mask the result of the backup or
+// restore.
+func (m *manager) deleteEtcdBackupPod(ctx context.Context, name string) {
+	err := m.kubernetescli.CoreV1().Pods(etcdBackupNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		m.log.Warnf("failed to delete pod %s: %v", name, err)
+	}
+
+	err = m.kubernetescli.CoreV1().Secrets(etcdBackupNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		m.log.Warnf("failed to delete secret %s: %v", name, err)
+	}
+}
+
+// waitEtcdBackupPod waits for the named pod to complete and returns its phase,
+// or that it was not found.  Errors getting the pod are retried, since
+// restoring etcd takes the API server down for a while.
+func (m *manager) waitEtcdBackupPod(ctx context.Context, name string) (corev1.PodPhase, bool, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, etcdBackupPodTimeout)
+	defer cancel()
+
+	var phase corev1.PodPhase
+	found := true
+	err := wait.PollImmediateUntil(etcdBackupPodInterval, func() (bool, error) {
+		pod, err := m.kubernetescli.CoreV1().Pods(etcdBackupNamespace).Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			found = false
+			return true, nil
+		}
+		if err != nil {
+			return false, nil
+		}
+
+		phase = pod.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	}, timeoutCtx.Done())
+	if err == wait.ErrWaitTimeout {
+		err = fmt.Errorf("timed out waiting for pod %s", name)
+	}
+
+	return phase, found, err
+}
+
+func (m *manager) etcdBackupPodLogs(ctx context.Context, name string) ([]byte, error) {
+	return m.kubernetescli.CoreV1().Pods(etcdBackupNamespace).GetLogs(name, &corev1.PodLogOptions{Container: etcdBackupPodContainer}).DoRaw(ctx)
+}
+
+// redeployEtcdOperands forces the control plane operators to redeploy their
+// operands after etcd is restored, as described in
+// https://docs.openshift.com/container-platform/4.10/backup_and_restore/control_plane_backup_and_restore/disaster_recovery/scenario-2-restoring-cluster-state.html
+func (m *manager) redeployEtcdOperands(ctx context.Context, reason string) error {
+	operators := []struct {
+		name   string
+		update func(context.Context, func(*operatorv1.StaticPodOperatorSpec)) error
+	}{
+		{
+			name: "etcd",
+			update: func(ctx context.Context, f func(*operatorv1.StaticPodOperatorSpec)) error {
+				o, err := m.operatorcli.OperatorV1().Etcds().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				f(&o.Spec.StaticPodOperatorSpec)
+				_, err = m.operatorcli.OperatorV1().Etcds().Update(ctx, o, metav1.UpdateOptions{})
+				return err
+			},
+		},
+		{
+			name: "kube-apiserver",
+			update: func(ctx context.Context, f func(*operatorv1.StaticPodOperatorSpec)) error {
+				o, err := m.operatorcli.OperatorV1().KubeAPIServers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				f(&o.Spec.StaticPodOperatorSpec)
+				_, err = m.operatorcli.OperatorV1().KubeAPIServers().Update(ctx, o, metav1.UpdateOptions{})
+				return err
+			},
+		},
+		{
+			name: "kube-controller-manager",
+			update: func(ctx context.Context, f func(*operatorv1.StaticPodOperatorSpec)) error {
+				o, err := m.operatorcli.OperatorV1().KubeControllerManagers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				f(&o.Spec.StaticPodOperatorSpec)
+				_, err = m.operatorcli.OperatorV1().KubeControllerManagers().Update(ctx, o, metav1.UpdateOptions{})
+				return err
+			},
+		},
+		{
+			name: "kube-scheduler",
+			update: func(ctx context.Context, f func(*operatorv1.StaticPodOperatorSpec)) error {
+				o, err := m.operatorcli.OperatorV1().KubeSchedulers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				f(&o.Spec.StaticPodOperatorSpec)
+				_, err = m.operatorcli.OperatorV1().KubeSchedulers().Update(ctx, o, metav1.UpdateOptions{})
+				return err
+			},
+		},
+	}
+
+	for _, operator := range operators {
+		m.log.Infof("forcing redeployment of %s", operator.name)
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return operator.update(ctx, func(spec *operatorv1.StaticPodOperatorSpec) {
+				spec.ForceRedeploymentReason = reason
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorfake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPruneEtcdBackups(t *testing.T) {
+	var backups []api.EtcdBackup
+	for i := 0; i < maxEtcdBackups+2; i++ {
+		backups = append(backups, api.EtcdBackup{Name: fmt.Sprint(i)})
+	}
+
+	kept, pruned := pruneEtcdBackups(backups[:maxEtcdBackups])
+	if len(kept) != maxEtcdBackups || pruned != nil {
+		t.Errorf("unexpected pruning %v %v", kept, pruned)
+	}
+
+	kept, pruned = pruneEtcdBackups(backups)
+	if len(kept) != maxEtcdBackups || kept[0].Name != "2" || len(pruned) != 2 || pruned[1].Name != "1" {
+		t.Errorf("unexpected pruning %v %v", kept, pruned)
+	}
+}
+
+func TestEtcdBackupNode(t *testing.T) {
+	ctx := context.Background()
+
+	etcdPod := func(node string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "etcd-" + node,
+				Namespace: etcdBackupNamespace,
+				Labels:    map[string]string{"app": "etcd"},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		pods     []kruntime.Object
+		wantNode string
+		wantErr  string
+	}{
+		{
+			name: "first ready member",
+			pods: []kruntime.Object{
+				etcdPod("master-2", corev1.ConditionTrue),
+				etcdPod("master-0", corev1.ConditionFalse),
+				etcdPod("master-1", corev1.ConditionTrue),
+			},
+			wantNode: "master-1",
+		},
+		{
+			name: "no ready member",
+			pods: []kruntime.Object{
+				etcdPod("master-0", corev1.ConditionFalse),
+			},
+			wantErr: "etcd cannot be backed up, no etcd member is ready",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				kubernetescli: fake.NewSimpleClientset(tt.pods...),
+			}
+
+			node, err := m.etcdBackupNode(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if node != tt.wantNode {
+				t.Error(node)
+			}
+		})
+	}
+}
+
+func TestRestoreEtcd(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+
+	master := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"node-role.kubernetes.io/master": ""},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+
+	// each case fails before anything is changed on the cluster
+	for _, tt := range []struct {
+		name    string
+		params  *api.MaintenanceTaskParameters
+		version string
+		nodes   []kruntime.Object
+		wantErr string
+	}{
+		{
+			name:    "no parameters",
+			wantErr: "no etcd backup to restore was given",
+		},
+		{
+			name:    "unknown backup",
+			params:  &api.MaintenanceTaskParameters{EtcdBackupName: "etcd-backup-2"},
+			wantErr: "etcd backup etcd-backup-2 was not found",
+		},
+		{
+			name:    "cluster upgraded since the backup",
+			params:  &api.MaintenanceTaskParameters{EtcdBackupName: "etcd-backup-1"},
+			version: "4.11.40",
+			nodes:   []kruntime.Object{master("master-0", corev1.ConditionTrue)},
+			wantErr: "etcd backup etcd-backup-1 was taken at cluster version 4.10.20 and cannot be restored to a cluster at version 4.11.40",
+		},
+		{
+			name:    "no masters",
+			params:  &api.MaintenanceTaskParameters{EtcdBackupName: "etcd-backup-1"},
+			wantErr: "etcd cannot be restored, no master nodes were found",
+		},
+		{
+			name:   "master not ready",
+			params: &api.MaintenanceTaskParameters{EtcdBackupName: "etcd-backup-1"},
+			nodes: []kruntime.Object{
+				master("master-0", corev1.ConditionTrue),
+				master("master-1", corev1.ConditionFalse),
+			},
+			wantErr: "etcd cannot be restored, master node master-1 is not ready",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			version := "4.10.20"
+			if tt.version != "" {
+				version = tt.version
+			}
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:         api.ProvisioningStateAdminUpdating,
+						MaintenanceTask:           api.MaintenanceTaskEtcdRestore,
+						MaintenanceTaskParameters: tt.params,
+						ClusterProfile: api.ClusterProfile{
+							Version: version,
+						},
+						EtcdBackups: []api.EtcdBackup{
+							{
+								Name:           "etcd-backup-1",
+								ClusterVersion: "4.10.20",
+								Node:           "master-0",
+							},
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				doc:           clusterdoc,
+				db:            fakeOpenShiftClustersDatabase,
+				kubernetescli: fake.NewSimpleClientset(tt.nodes...),
+			}
+
+			err = m.restoreEtcd(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.OpenShiftCluster.Properties.MaintenanceTaskParameters != nil {
+				t.Error("maintenance task parameters were not cleared")
+			}
+		})
+	}
+}
+
+func TestRedeployEtcdOperands(t *testing.T) {
+	ctx := context.Background()
+
+	operatorcli := operatorfake.NewSimpleClientset(
+		&operatorv1.Etcd{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		&operatorv1.KubeAPIServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		&operatorv1.KubeControllerManager{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		&operatorv1.KubeScheduler{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+	)
+
+	m := &manager{
+		log:         logrus.NewEntry(logrus.StandardLogger()),
+		operatorcli: operatorcli,
+	}
+
+	err := m.redeployEtcdOperands(ctx, "etcd-restore-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	etcd, err := operatorcli.OperatorV1().Etcds().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kas, err := operatorcli.OperatorV1().KubeAPIServers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kcm, err := operatorcli.OperatorV1().KubeControllerManagers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := operatorcli.OperatorV1().KubeSchedulers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []operatorv1.StaticPodOperatorSpec{etcd.Spec.StaticPodOperatorSpec, kas.Spec.StaticPodOperatorSpec, kcm.Spec.StaticPodOperatorSpec, ks.Spec.StaticPodOperatorSpec} {
+		if spec.ForceRedeploymentReason != "etcd-restore-1" {
+			t.Error(spec.ForceRedeploymentReason)
+		}
+	}
+}
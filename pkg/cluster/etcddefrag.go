@@ -0,0 +1,96 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+)
+
+// etcdDefragHealthTimeout is how long we wait for all etcd members to report
+// healthy after defragmenting a member, before giving up on the rest
+var etcdDefragHealthTimeout = 5 * time.Minute
+
+var etcdDefragHealthInterval = 10 * time.Second
+
+// defragmentEtcd defragments the etcd members one at a time, followers first
+// and the leader last, so that at most one member is unavailable at any time.
+// It refuses to start, and stops, if any member is unhealthy, since
+// defragmenting a member of a cluster which has already lost a member would
+// lose quorum.  It only runs when requested by the EtcdDefragmentation
+// maintenance task.
+func (m *manager) defragmentEtcd(ctx context.Context) error {
+	members, err := m.etcdcli.Members(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = m.etcdMembersHealthy(ctx, members)
+	if err != nil {
+		return fmt.Errorf("etcd is not healthy, not defragmenting: %w", err)
+	}
+
+	var leader string
+	for _, member := range members {
+		status, err := m.etcdcli.Status(ctx, member)
+		if err != nil {
+			return err
+		}
+		if status.Leader {
+			leader = member.Name
+		}
+	}
+
+	for _, member := range etcd.LeaderLast(members, leader) {
+		m.log.Infof("defragmenting etcd member %s", member.Name)
+		err = m.etcdcli.Defragment(ctx, member)
+		if err != nil {
+			return fmt.Errorf("defragmentation of etcd member %s failed: %w", member.Name, err)
+		}
+
+		err = m.waitEtcdMembersHealthy(ctx, members)
+		if err != nil {
+			return fmt.Errorf("etcd did not become healthy after defragmenting member %s, not defragmenting the remaining members: %w", member.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) etcdMembersHealthy(ctx context.Context, members []etcd.Member) error {
+	if len(members) == 0 {
+		return errors.New("no etcd members found")
+	}
+
+	for _, member := range members {
+		err := m.etcdcli.Health(ctx, member)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) waitEtcdMembersHealthy(ctx context.Context, members []etcd.Member) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, etcdDefragHealthTimeout)
+	defer cancel()
+
+	var err error
+	pollErr := wait.PollImmediateUntil(etcdDefragHealthInterval, func() (bool, error) {
+		err = m.etcdMembersHealthy(ctx, members)
+		return err == nil, nil
+	}, timeoutCtx.Done())
+	if pollErr != nil && err != nil {
+		return err
+	}
+
+	return pollErr
+}
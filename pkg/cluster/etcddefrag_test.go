@@ -0,0 +1,98 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+	mock_etcd "github.com/Azure/ARO-RP/pkg/util/mocks/etcd"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestDefragmentEtcd(t *testing.T) {
+	ctx := context.Background()
+
+	members := []etcd.Member{
+		{Name: "etcd-master-0", IP: "10.0.0.6"},
+		{Name: "etcd-master-1", IP: "10.0.0.7"},
+		{Name: "etcd-master-2", IP: "10.0.0.8"},
+	}
+
+	status := func(leader bool) *etcd.Status {
+		return &etcd.Status{Leader: leader}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_etcd.MockClient)
+		wantErr string
+	}{
+		{
+			name: "leader last",
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Health(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				gomock.InOrder(
+					etcdcli.EXPECT().Status(gomock.Any(), members[0]).Return(status(false), nil),
+					etcdcli.EXPECT().Status(gomock.Any(), members[1]).Return(status(true), nil),
+					etcdcli.EXPECT().Status(gomock.Any(), members[2]).Return(status(false), nil),
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[0]).Return(nil),
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[2]).Return(nil),
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[1]).Return(nil),
+				)
+			},
+		},
+		{
+			name: "refused, member unhealthy",
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Health(gomock.Any(), members[0]).Return(nil)
+				etcdcli.EXPECT().Health(gomock.Any(), members[1]).Return(errors.New("etcd member etcd-master-1 is unhealthy: RAFT NO LEADER"))
+			},
+			wantErr: "etcd is not healthy, not defragmenting: etcd member etcd-master-1 is unhealthy: RAFT NO LEADER",
+		},
+		{
+			name: "stops when member does not recover",
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[0]).Return(status(true), nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[1]).Return(status(false), nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[2]).Return(status(false), nil)
+				gomock.InOrder(
+					etcdcli.EXPECT().Health(gomock.Any(), gomock.Any()).Times(3).Return(nil),
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[1]).Return(nil),
+					etcdcli.EXPECT().Health(gomock.Any(), members[0]).AnyTimes().Return(errors.New("context deadline exceeded")),
+				)
+			},
+			wantErr: "etcd did not become healthy after defragmenting member etcd-master-1, not defragmenting the remaining members: context deadline exceeded",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			etcdcli := mock_etcd.NewMockClient(controller)
+			tt.mocks(etcdcli)
+
+			oldTimeout, oldInterval := etcdDefragHealthTimeout, etcdDefragHealthInterval
+			defer func() { etcdDefragHealthTimeout, etcdDefragHealthInterval = oldTimeout, oldInterval }()
+			etcdDefragHealthTimeout, etcdDefragHealthInterval = 50*time.Millisecond, 10*time.Millisecond
+
+			m := &manager{
+				log:     logrus.NewEntry(logrus.StandardLogger()),
+				etcdcli: etcdcli,
+			}
+
+			err := m.defragmentEtcd(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
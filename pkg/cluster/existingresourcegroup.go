@@ -0,0 +1,136 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+func (m *manager) isExistingResourceGroup() bool {
+	return m.doc.OpenShiftCluster.Properties.ClusterProfile.ExistingResourceGroup == api.ExistingResourceGroupEnabled
+}
+
+func invalidExistingResourceGroupError(resourceGroupID, reason string) error {
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.clusterProfile.resourceGroupId", "The provided resource group '%s' is invalid: %s.", resourceGroupID, reason)
+}
+
+// ensureExistingResourceGroup validates a cluster resource group which the
+// customer created before the cluster.  Unlike a resource group created by the
+// RP it is not re-PUT: its tags and other configuration belong to the
+// customer.
+func (m *manager) ensureExistingResourceGroup(ctx context.Context, resourceGroup string) error {
+	err := m.checkExistingResourceGroup(ctx, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	return m.env.EnsureARMResourceGroupRoleAssignment(ctx, resourceGroup)
+}
+
+// validateExistingResourceGroup fails an update if an existing cluster
+// resource group no longer meets the requirements it was installed with, e.g.
+// because the customer moved it under the management of another resource.
+func (m *manager) validateExistingResourceGroup(ctx context.Context) error {
+	if !m.isExistingResourceGroup() {
+		return nil
+	}
+
+	return m.checkExistingResourceGroup(ctx, stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/'))
+}
+
+// checkExistingResourceGroup returns an error if an existing cluster resource
+// group does not exist, is in another location than the cluster or is managed
+// by another resource
+func (m *manager) checkExistingResourceGroup(ctx context.Context, resourceGroup string) error {
+	resourceGroupID := m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID
+
+	group, err := m.resourceGroups.Get(ctx, resourceGroup)
+	if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+		return invalidExistingResourceGroupError(resourceGroupID, "must already exist")
+	}
+	if err != nil {
+		return err
+	}
+
+	if group.Location == nil || !strings.EqualFold(*group.Location, m.doc.OpenShiftCluster.Location) {
+		return invalidExistingResourceGroupError(resourceGroupID, "must be in the same location as the cluster")
+	}
+
+	if group.ManagedBy != nil && *group.ManagedBy != "" {
+		return invalidExistingResourceGroupError(resourceGroupID, "must not be managed by another resource")
+	}
+
+	return nil
+}
+
+// ensureExistingResourceGroupEmpty fails the installation if an existing
+// cluster resource group contains resources which do not belong to the
+// cluster.  Resources which belong to the cluster are tolerated so that the
+// step can be retried after a partial deployment.
+func (m *manager) ensureExistingResourceGroupEmpty(ctx context.Context) error {
+	if !m.isExistingResourceGroup() {
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	resources, err := m.resources.ListByResourceGroup(ctx, resourceGroup, "", "", nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range resources {
+		if !m.isClusterResource(&resources[i]) {
+			return invalidExistingResourceGroupError(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, "must be empty, but contains '"+*resources[i].ID+"'")
+		}
+	}
+
+	return nil
+}
+
+// isClusterResource returns true if a resource in the cluster resource group
+// belongs to the cluster.  The RP, the installer and the machine API name
+// their resources after the infra ID or tag them as owned by it; the only
+// exceptions are the cluster storage accounts, which have random names.
+func (m *manager) isClusterResource(resource *mgmtfeatures.GenericResourceExpanded) bool {
+	if resource.Name == nil {
+		return false
+	}
+
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+	if infraID != "" && strings.HasPrefix(strings.ToLower(*resource.Name), strings.ToLower(infraID)) {
+		return true
+	}
+
+	if m.doc.OpenShiftCluster.Properties.StorageSuffix != "" &&
+		strings.EqualFold(*resource.Name, "cluster"+m.doc.OpenShiftCluster.Properties.StorageSuffix) {
+		return true
+	}
+
+	if m.doc.OpenShiftCluster.Properties.ImageRegistryStorageAccountName != "" &&
+		strings.EqualFold(*resource.Name, m.doc.OpenShiftCluster.Properties.ImageRegistryStorageAccountName) {
+		return true
+	}
+
+	if infraID == "" {
+		return false
+	}
+
+	for k, v := range resource.Tags {
+		if (strings.EqualFold(k, "kubernetes.io_cluster."+infraID) || strings.EqualFold(k, "kubernetes.io-cluster-"+infraID)) &&
+			v != nil && strings.EqualFold(*v, "owned") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,245 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEnsureExistingResourceGroupEmpty(t *testing.T) {
+	ctx := context.Background()
+	resourceGroupName := "fakeResourceGroup"
+	resourceGroup := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/" + resourceGroupName
+
+	for _, tt := range []struct {
+		name                  string
+		existingResourceGroup api.ExistingResourceGroup
+		resources             []mgmtfeatures.GenericResourceExpanded
+		wantErr               string
+	}{
+		{
+			name: "rg created by the RP",
+		},
+		{
+			name:                  "empty",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+		},
+		{
+			name:                  "only cluster resources",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			resources: []mgmtfeatures.GenericResourceExpanded{
+				{
+					ID:   to.StringPtr(resourceGroup + "/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"),
+					Name: to.StringPtr("infra-nsg"),
+				},
+				{
+					ID:   to.StringPtr(resourceGroup + "/providers/Microsoft.Storage/storageAccounts/clustersuffix"),
+					Name: to.StringPtr("clustersuffix"),
+				},
+			},
+		},
+		{
+			name:                  "customer resource",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			resources: []mgmtfeatures.GenericResourceExpanded{
+				{
+					ID:   to.StringPtr(resourceGroup + "/providers/Microsoft.Network/networkSecurityGroups/infra-nsg"),
+					Name: to.StringPtr("infra-nsg"),
+				},
+				{
+					ID:   to.StringPtr(resourceGroup + "/providers/Microsoft.KeyVault/vaults/customer"),
+					Name: to.StringPtr("customer"),
+				},
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must be empty, but contains '" + resourceGroup + "/providers/Microsoft.KeyVault/vaults/customer'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resources := mock_features.NewMockResourcesClient(controller)
+			if tt.existingResourceGroup == api.ExistingResourceGroupEnabled {
+				resources.EXPECT().
+					ListByResourceGroup(gomock.Any(), resourceGroupName, "", "", nil).
+					Return(tt.resources, nil)
+			}
+
+			m := &manager{
+				log:       logrus.NewEntry(logrus.StandardLogger()),
+				resources: resources,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID:       resourceGroup,
+								ExistingResourceGroup: tt.existingResourceGroup,
+							},
+							InfraID:       "infra",
+							StorageSuffix: "suffix",
+						},
+					},
+				},
+			}
+
+			err := m.ensureExistingResourceGroupEmpty(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateExistingResourceGroup(t *testing.T) {
+	ctx := context.Background()
+	resourceGroupName := "fakeResourceGroup"
+	resourceGroup := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/" + resourceGroupName
+
+	for _, tt := range []struct {
+		name                  string
+		existingResourceGroup api.ExistingResourceGroup
+		mocks                 func(*mock_features.MockResourceGroupsClient)
+		wantErr               string
+	}{
+		{
+			name: "rg created by the RP",
+		},
+		{
+			name:                  "valid",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(mgmtfeatures.ResourceGroup{Location: to.StringPtr("eastus")}, nil)
+			},
+		},
+		{
+			name:                  "deleted",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(mgmtfeatures.ResourceGroup{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must already exist.",
+		},
+		{
+			name:                  "managed by another resource",
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), resourceGroupName).
+					Return(mgmtfeatures.ResourceGroup{
+						Location:  to.StringPtr("eastus"),
+						ManagedBy: to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Solutions/applications/app"),
+					}, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.clusterProfile.resourceGroupId: The provided resource group '" + resourceGroup + "' is invalid: must not be managed by another resource.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resourceGroups := mock_features.NewMockResourceGroupsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(resourceGroups)
+			}
+
+			m := &manager{
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				resourceGroups: resourceGroups,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID:       resourceGroup,
+								ExistingResourceGroup: tt.existingResourceGroup,
+							},
+						},
+					},
+				},
+			}
+
+			err := m.validateExistingResourceGroup(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIsClusterResource(t *testing.T) {
+	m := &manager{
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					InfraID:                         "Infra",
+					StorageSuffix:                   "suffix",
+					ImageRegistryStorageAccountName: "registry",
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		resource mgmtfeatures.GenericResourceExpanded
+		want     bool
+	}{
+		{
+			name:     "named after the infra ID",
+			resource: mgmtfeatures.GenericResourceExpanded{Name: to.StringPtr("infra-master-0")},
+			want:     true,
+		},
+		{
+			name:     "cluster storage account",
+			resource: mgmtfeatures.GenericResourceExpanded{Name: to.StringPtr("clustersuffix")},
+			want:     true,
+		},
+		{
+			name:     "image registry storage account",
+			resource: mgmtfeatures.GenericResourceExpanded{Name: to.StringPtr("registry")},
+			want:     true,
+		},
+		{
+			name: "owned by the infra ID",
+			resource: mgmtfeatures.GenericResourceExpanded{
+				Name: to.StringPtr("somedns.example.com"),
+				Tags: map[string]*string{"kubernetes.io_cluster.infra": to.StringPtr("owned")},
+			},
+			want: true,
+		},
+		{
+			name: "shared with the infra ID",
+			resource: mgmtfeatures.GenericResourceExpanded{
+				Name: to.StringPtr("customer"),
+				Tags: map[string]*string{"kubernetes.io-cluster-infra": to.StringPtr("shared")},
+			},
+		},
+		{
+			name:     "customer resource",
+			resource: mgmtfeatures.GenericResourceExpanded{Name: to.StringPtr("customer")},
+		},
+		{
+			name: "no name",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.isClusterResource(&tt.resource)
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+// fixClusterDomainKey backfills the cluster domain key of clusters created
+// before it was introduced, so that the frontend finds their domain when
+// validating the domain of a new cluster in the same subscription
+func (m *manager) fixClusterDomainKey(ctx context.Context) error {
+	if m.doc.ClusterDomainKey != "" {
+		return nil
+	}
+
+	key, err := dns.ClusterDomainKey(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	if err != nil {
+		return err
+	}
+
+	doc, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.ClusterDomainKey = key
+		return nil
+	})
+	// the key is unique in the subscription: clusters which already shared a
+	// domain before it was introduced can't all be keyed, and this mustn't
+	// block their updates
+	if cosmosdb.IsErrorStatusCode(err, http.StatusConflict) {
+		m.log.Warnf("not setting cluster domain key: %q is already in use in the subscription", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m.doc = doc
+	return nil
+}
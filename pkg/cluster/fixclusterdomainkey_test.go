@@ -0,0 +1,123 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestFixClusterDomainKey(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	otherResourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/other"
+
+	for _, tt := range []struct {
+		name      string
+		domain    string
+		key       string
+		otherKey  string
+		wantKey   string
+		wantError string
+	}{
+		{
+			name:    "managed domain",
+			domain:  "cluster",
+			wantKey: "cluster.eastus.aroapp.io",
+		},
+		{
+			name:    "custom domain",
+			domain:  "Cluster.Example.com",
+			wantKey: "cluster.example.com",
+		},
+		{
+			name:    "key already set",
+			domain:  "cluster.example.com",
+			key:     "cluster.example.com",
+			wantKey: "cluster.example.com",
+		},
+		{
+			name:     "domain already keyed by another cluster",
+			domain:   "cluster.example.com",
+			otherKey: "cluster.example.com",
+		},
+		{
+			name:      "invalid domain",
+			domain:    "cluster.",
+			wantError: `invalid domain "cluster."`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().Domain().AnyTimes().Return("eastus.aroapp.io")
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key:              strings.ToLower(resourceID),
+				ClusterDomainKey: tt.key,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateAdminUpdating,
+						ClusterProfile: api.ClusterProfile{
+							Domain: tt.domain,
+						},
+					},
+				},
+			})
+			if tt.otherKey != "" {
+				fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              strings.ToLower(otherResourceID),
+					ClusterDomainKey: tt.otherKey,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: otherResourceID,
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+						},
+					},
+				})
+			}
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				env: env,
+				doc: clusterdoc,
+				db:  fakeOpenShiftClustersDatabase,
+			}
+
+			err = m.fixClusterDomainKey(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantError)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.ClusterDomainKey != tt.wantKey {
+				t.Error(doc.ClusterDomainKey)
+			}
+		})
+	}
+}
@@ -21,6 +21,19 @@ import (
 )
 
 func (m *manager) fixMCSCert(ctx context.Context) error {
+	intIP := net.ParseIP(m.doc.OpenShiftCluster.Properties.APIServerProfile.IntIP)
+
+	_, err := m.ensureMCSCert(ctx, func(cert *x509.Certificate) bool {
+		return len(cert.IPAddresses) == 1 && cert.IPAddresses[0].Equal(intIP)
+	})
+	return err
+}
+
+// ensureMCSCert regenerates the machine config server certificate from the
+// cluster root CA unless valid returns true for the current certificate.  If
+// the certificate was regenerated, the machine config server pods are
+// restarted to pick it up and ensureMCSCert returns true.
+func (m *manager) ensureMCSCert(ctx context.Context, valid func(*x509.Certificate) bool) (bool, error) {
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 	account := "cluster" + m.doc.OpenShiftCluster.Properties.StorageSuffix
 
@@ -49,7 +62,7 @@ func (m *manager) fixMCSCert(ctx context.Context) error {
 			return fmt.Errorf("expected 1 certificate, got %d", len(certs))
 		}
 
-		if len(certs[0].IPAddresses) == 1 && certs[0].IPAddresses[0].Equal(intIP) {
+		if valid(certs[0]) {
 			return nil
 		}
 
@@ -97,12 +110,12 @@ func (m *manager) fixMCSCert(ctx context.Context) error {
 		return err
 	})
 	if err != nil || !certChanged {
-		return err
+		return false, err
 	}
 
 	/* don't crash */
 
-	return m.kubernetescli.CoreV1().Pods("openshift-machine-config-operator").DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
+	return true, m.kubernetescli.CoreV1().Pods("openshift-machine-config-operator").DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
 		LabelSelector: "k8s-app=machine-config-server",
 	})
 }
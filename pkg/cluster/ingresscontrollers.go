@@ -0,0 +1,171 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+)
+
+// additionalIngressProfiles returns the ingress profiles other than the
+// default one, which the RP provisions IngressControllers for
+func (m *manager) additionalIngressProfiles() []api.IngressProfile {
+	var ingressProfiles []api.IngressProfile
+	for _, p := range m.doc.OpenShiftCluster.Properties.IngressProfiles {
+		if p.Name != "default" && p.Domain != "" {
+			ingressProfiles = append(ingressProfiles, p)
+		}
+	}
+	return ingressProfiles
+}
+
+func additionalIngressCertificateName(docID, name string) string {
+	return docID + "-ingress-" + name
+}
+
+// ensureAdditionalIngressControllers creates or updates an IngressController,
+// and the secret holding its serving certificate, for each additional ingress
+// profile.  The ingress operator then creates the corresponding router
+// deployment and load balancer service.
+func (m *manager) ensureAdditionalIngressControllers(ctx context.Context) error {
+	for _, p := range m.additionalIngressProfiles() {
+		key, certs, err := utilpem.Parse([]byte(p.ServingCertificate))
+		if err != nil {
+			return err
+		}
+		if key == nil || len(certs) == 0 {
+			return fmt.Errorf("serving certificate of ingress profile %q is invalid", p.Name)
+		}
+
+		certificateName := additionalIngressCertificateName(m.doc.ID, p.Name)
+
+		err = ensureTLSSecret(ctx, m.kubernetescli.CoreV1().Secrets("openshift-ingress"), certificateName, key, certs)
+		if err != nil {
+			return err
+		}
+
+		scope := operatorv1.ExternalLoadBalancer
+		if p.Visibility == api.VisibilityPrivate {
+			scope = operatorv1.InternalLoadBalancer
+		}
+
+		endpointPublishingStrategy := &operatorv1.EndpointPublishingStrategy{
+			Type: operatorv1.LoadBalancerServiceStrategyType,
+			LoadBalancer: &operatorv1.LoadBalancerStrategy{
+				Scope: scope,
+			},
+		}
+
+		ic := &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      p.Name,
+				Namespace: "openshift-ingress-operator",
+			},
+			Spec: operatorv1.IngressControllerSpec{
+				Domain: p.Domain,
+				DefaultCertificate: &corev1.LocalObjectReference{
+					Name: certificateName,
+				},
+				EndpointPublishingStrategy: endpointPublishingStrategy,
+			},
+		}
+
+		m.log.Printf("ensuring ingresscontroller %s", p.Name)
+		_, err = m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Create(ctx, ic, metav1.CreateOptions{})
+		if kerrors.IsAlreadyExists(err) {
+			// the domain and endpoint publishing strategy of an
+			// IngressController can't be changed, so only the serving
+			// certificate is reconciled
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				ic, err := m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, p.Name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+
+				ic.Spec.DefaultCertificate = &corev1.LocalObjectReference{
+					Name: certificateName,
+				}
+
+				_, err = m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Update(ctx, ic, metav1.UpdateOptions{})
+				return err
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// additionalRouterIPs returns the load balancer IPs of the router services of
+// the additional ingress profiles which have one
+func (m *manager) additionalRouterIPs(ctx context.Context) (map[string]string, error) {
+	ips := map[string]string{}
+
+	for _, p := range m.additionalIngressProfiles() {
+		svc, err := m.kubernetescli.CoreV1().Services("openshift-ingress").Get(ctx, "router-"+p.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(svc.Status.LoadBalancer.Ingress) > 0 {
+			ips[p.Name] = svc.Status.LoadBalancer.Ingress[0].IP
+		}
+	}
+
+	return ips, nil
+}
+
+// additionalIngressControllersReady returns true once the router services of
+// all additional ingress profiles have a load balancer IP
+func (m *manager) additionalIngressControllersReady(ctx context.Context) (bool, error) {
+	ips, err := m.additionalRouterIPs(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	return len(ips) == len(m.additionalIngressProfiles()), nil
+}
+
+// updateAdditionalRouterIPs records the load balancer IPs of the additional
+// ingress profiles in the cluster document
+func (m *manager) updateAdditionalRouterIPs(ctx context.Context) error {
+	ips, err := m.additionalRouterIPs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(ips) == 0 {
+		return nil
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		for i, p := range doc.OpenShiftCluster.Properties.IngressProfiles {
+			if ip, found := ips[p.Name]; found {
+				doc.OpenShiftCluster.Properties.IngressProfiles[i].IP = ip
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// createOrUpdateAdditionalRouterDNS creates or updates the DNS records of the
+// additional ingress profiles whose domain is in the customer's DNS zone
+func (m *manager) createOrUpdateAdditionalRouterDNS(ctx context.Context) error {
+	return m.dns.CreateOrUpdateAdditionalRouters(ctx, m.doc.OpenShiftCluster)
+}
@@ -0,0 +1,311 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorfake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func testIngressServingCertificate(t *testing.T) string {
+	key, certs, err := utiltls.GenerateTestKeyAndCertificate("*.internal.example.com", nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := utilpem.Encode(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cb, err := utilpem.Encode(certs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(append(b, cb...))
+}
+
+func TestEnsureAdditionalIngressControllers(t *testing.T) {
+	ctx := context.Background()
+
+	servingCertificate := testIngressServingCertificate(t)
+
+	for _, tt := range []struct {
+		name            string
+		ingressProfiles []api.IngressProfile
+		operatorcli     *operatorfake.Clientset
+		wantScope       operatorv1.LoadBalancerScope
+		wantErr         string
+	}{
+		{
+			name: "default only",
+			ingressProfiles: []api.IngressProfile{
+				{
+					Name:       "default",
+					Visibility: api.VisibilityPublic,
+				},
+			},
+			operatorcli: operatorfake.NewSimpleClientset(),
+		},
+		{
+			name: "create private",
+			ingressProfiles: []api.IngressProfile{
+				{
+					Name:       "default",
+					Visibility: api.VisibilityPublic,
+				},
+				{
+					Name:               "internal",
+					Visibility:         api.VisibilityPrivate,
+					Domain:             "internal.example.com",
+					ServingCertificate: api.SecureString(servingCertificate),
+				},
+			},
+			operatorcli: operatorfake.NewSimpleClientset(),
+			wantScope:   operatorv1.InternalLoadBalancer,
+		},
+		{
+			name: "update existing",
+			ingressProfiles: []api.IngressProfile{
+				{
+					Name:               "internal",
+					Visibility:         api.VisibilityPublic,
+					Domain:             "internal.example.com",
+					ServingCertificate: api.SecureString(servingCertificate),
+				},
+			},
+			operatorcli: operatorfake.NewSimpleClientset(&operatorv1.IngressController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "internal",
+					Namespace: "openshift-ingress-operator",
+				},
+				Spec: operatorv1.IngressControllerSpec{
+					Domain: "internal.example.com",
+					DefaultCertificate: &corev1.LocalObjectReference{
+						Name: "old",
+					},
+					EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+						Type: operatorv1.LoadBalancerServiceStrategyType,
+						LoadBalancer: &operatorv1.LoadBalancerStrategy{
+							Scope: operatorv1.ExternalLoadBalancer,
+						},
+					},
+				},
+			}),
+			wantScope: operatorv1.ExternalLoadBalancer,
+		},
+		{
+			name: "invalid serving certificate",
+			ingressProfiles: []api.IngressProfile{
+				{
+					Name:       "internal",
+					Visibility: api.VisibilityPublic,
+					Domain:     "internal.example.com",
+				},
+			},
+			operatorcli: operatorfake.NewSimpleClientset(),
+			wantErr:     `serving certificate of ingress profile "internal" is invalid`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			kubernetescli := fake.NewSimpleClientset()
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: &api.OpenShiftClusterDocument{
+					ID: "id",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							IngressProfiles: tt.ingressProfiles,
+						},
+					},
+				},
+				kubernetescli: kubernetescli,
+				operatorcli:   tt.operatorcli,
+			}
+
+			err := m.ensureAdditionalIngressControllers(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			ics, err := tt.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantScope == "" {
+				if len(ics.Items) != 0 {
+					t.Fatalf("expected no ingresscontrollers, got %d", len(ics.Items))
+				}
+				return
+			}
+
+			if len(ics.Items) != 1 {
+				t.Fatalf("expected one ingresscontroller, got %d", len(ics.Items))
+			}
+
+			ic := ics.Items[0]
+			if ic.Spec.Domain != "internal.example.com" {
+				t.Error(ic.Spec.Domain)
+			}
+			if ic.Spec.DefaultCertificate == nil || ic.Spec.DefaultCertificate.Name != "id-ingress-internal" {
+				t.Error(ic.Spec.DefaultCertificate)
+			}
+			if ic.Spec.EndpointPublishingStrategy.LoadBalancer.Scope != tt.wantScope {
+				t.Error(ic.Spec.EndpointPublishingStrategy.LoadBalancer.Scope)
+			}
+
+			s, err := kubernetescli.CoreV1().Secrets("openshift-ingress").Get(ctx, "id-ingress-internal", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Type != corev1.SecretTypeTLS {
+				t.Error(s.Type)
+			}
+		})
+	}
+}
+
+func TestUpdateAdditionalRouterIPs(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
+
+	routerService := func(name, ip string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "router-" + name,
+				Namespace: "openshift-ingress",
+			},
+		}
+		if ip != "" {
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: ip}}
+		}
+		return svc
+	}
+
+	for _, tt := range []struct {
+		name           string
+		kubernetescli  *fake.Clientset
+		fixtureChecker func(*testdatabase.Fixture, *testdatabase.Checker, *cosmosdb.FakeOpenShiftClusterDocumentClient)
+		wantReady      bool
+	}{
+		{
+			name:          "additional router IPs recorded",
+			kubernetescli: fake.NewSimpleClientset(routerService("default", "1.2.3.4"), routerService("internal", "5.6.7.8")),
+			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+				doc := &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							IngressProfiles: []api.IngressProfile{
+								{
+									Name: "default",
+									IP:   "1.2.3.4",
+								},
+								{
+									Name:   "internal",
+									Domain: "internal.example.com",
+								},
+							},
+							ProvisioningState: api.ProvisioningStateCreating,
+						},
+					},
+				}
+				fixture.AddOpenShiftClusterDocuments(doc)
+
+				doc.Dequeues = 1
+				doc.OpenShiftCluster.Properties.IngressProfiles[1].IP = "5.6.7.8"
+				checker.AddOpenShiftClusterDocuments(doc)
+			},
+			wantReady: true,
+		},
+		{
+			name:          "router IP not yet allocated",
+			kubernetescli: fake.NewSimpleClientset(routerService("internal", "")),
+			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+				doc := &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							IngressProfiles: []api.IngressProfile{
+								{
+									Name:   "internal",
+									Domain: "internal.example.com",
+								},
+							},
+							ProvisioningState: api.ProvisioningStateCreating,
+						},
+					},
+				}
+				fixture.AddOpenShiftClusterDocuments(doc)
+
+				doc.Dequeues = 1
+				checker.AddOpenShiftClusterDocuments(doc)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbOpenShiftClusters, dbClient := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			checker := testdatabase.NewChecker()
+
+			tt.fixtureChecker(fixture, checker, dbClient)
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := dbOpenShiftClusters.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				doc:           doc,
+				db:            dbOpenShiftClusters,
+				kubernetescli: tt.kubernetescli,
+			}
+
+			ready, err := m.additionalIngressControllersReady(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ready != tt.wantReady {
+				t.Errorf("got ready %v, wanted %v", ready, tt.wantReady)
+			}
+
+			err = m.updateAdditionalRouterIPs(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, err = range checker.CheckOpenShiftClusters(dbClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database"
 	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
 	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
 	"github.com/Azure/ARO-RP/pkg/util/restconfig"
 	"github.com/Azure/ARO-RP/pkg/util/steps"
 	"github.com/Azure/ARO-RP/pkg/util/version"
@@ -36,7 +37,7 @@ const (
 // AdminUpdate performs an admin update of an ARO cluster
 func (m *manager) AdminUpdate(ctx context.Context) error {
 	toRun := m.adminUpdate()
-	return m.runSteps(ctx, toRun, "adminUpdate")
+	return m.runSteps(ctx, m.progressSteps(toRun, 0), "adminUpdate")
 }
 
 func (m *manager) adminUpdate() []steps.Step {
@@ -44,6 +45,14 @@ func (m *manager) adminUpdate() []steps.Step {
 	isEverything := task == api.MaintenanceTaskEverything || task == ""
 	isOperator := task == api.MaintenanceTaskOperator
 	isRenewCerts := task == api.MaintenanceTaskRenewCerts
+	isRenewExpiringCerts := task == api.MaintenanceTaskRenewExpiringCerts
+	isSyncProperties := task == api.MaintenanceTaskSyncClusterProperties
+	isEtcdDefrag := task == api.MaintenanceTaskEtcdDefrag
+	isEtcdBackup := task == api.MaintenanceTaskEtcdBackup
+	isEtcdRestore := task == api.MaintenanceTaskEtcdRestore
+	isRebootNode := task == api.MaintenanceTaskRebootNode
+	isDrainMachineSet := task == api.MaintenanceTaskDrainMachineSet
+	isRotateCertificates := task == api.MaintenanceTaskRotateCertificates
 
 	// Generic fix-up or setup actions that are fairly safe to always take, and
 	// don't require a running cluster
@@ -57,11 +66,13 @@ func (m *manager) adminUpdate() []steps.Step {
 		// to advance
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.fixupClusterSPObjectID),
 		steps.Action(m.fixInfraID), // Old clusters lacks infraID in the database. Which makes code prone to errors.
+		steps.Action(m.fixClusterDomainKey),
 	}
 
 	if isEverything {
 		toRun = append(toRun,
 			steps.Action(m.ensureResourceGroup), // re-create RP RBAC if needed after tenant migration
+			steps.Action(m.reconcileTags),
 			steps.Action(m.createOrUpdateDenyAssignment),
 			steps.Action(m.ensureServiceEndpoints),
 			steps.Action(m.populateRegistryStorageAccountName), // must go before migrateStorageAccounts
@@ -71,7 +82,7 @@ func (m *manager) adminUpdate() []steps.Step {
 		)
 	}
 
-	if isEverything || isRenewCerts {
+	if isEverything || isRenewCerts || isRenewExpiringCerts {
 		toRun = append(toRun,
 			steps.Action(m.populateDatabaseIntIP),
 		)
@@ -83,12 +94,70 @@ func (m *manager) adminUpdate() []steps.Step {
 		steps.Condition(m.apiServersReady, 30*time.Minute, true),
 	)
 
+	if isSyncProperties {
+		toRun = append(toRun,
+			steps.Action(m.syncClusterProperties),
+			steps.Action(m.reconcileTags),
+		)
+	}
+
+	if isEtcdDefrag {
+		toRun = append(toRun,
+			steps.Action(m.defragmentEtcd),
+		)
+	}
+
+	if isEtcdBackup {
+		toRun = append(toRun,
+			steps.Action(m.backupEtcd),
+		)
+	}
+
+	// Restoring etcd takes the API servers down until the control plane
+	// operators have redeployed them
+	if isEtcdRestore {
+		toRun = append(toRun,
+			steps.Action(m.restoreEtcd),
+			steps.Condition(m.apiServersReady, 30*time.Minute, true),
+		)
+	}
+
+	if isRebootNode {
+		toRun = append(toRun,
+			steps.Action(m.rebootNode),
+		)
+	}
+
+	if isDrainMachineSet {
+		toRun = append(toRun,
+			steps.Action(m.drainMachineSet),
+		)
+	}
+
+	if isRotateCertificates {
+		toRun = append(toRun,
+			steps.Action(m.rotateCertificates),
+		)
+	}
+
+	// Renew the certificates which are about to expire and make sure the
+	// cluster is still healthy once they are served
+	if isRenewExpiringCerts {
+		toRun = append(toRun,
+			steps.Action(m.renewExpiringCertificates),
+			steps.Condition(m.apiServersReady, 30*time.Minute, true),
+			steps.Condition(m.ingressControllerReady, 10*time.Minute, true),
+		)
+	}
+
 	// Requires Kubernetes clients
 	if isEverything {
 		toRun = append(toRun,
 			steps.Action(m.fixSREKubeconfig),
 			steps.Action(m.fixUserAdminKubeconfig),
 			steps.Action(m.createOrUpdateRouterIPFromCluster),
+			steps.Action(m.reconcileCustomerLoadBalancer),
+			steps.Action(m.updateEffectiveOutboundProfile),
 		)
 	}
 
@@ -182,6 +251,7 @@ func (m *manager) clusterWasCreatedByHive() bool {
 func (m *manager) Update(ctx context.Context) error {
 	s := []steps.Step{
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+		steps.Action(m.validateExistingResourceGroup),
 		steps.Action(m.initializeKubernetesClients), // All init steps are first
 		steps.Action(m.initializeOperatorDeployer),  // depends on kube clients
 		steps.Action(m.initializeClusterSPClients),
@@ -193,15 +263,23 @@ func (m *manager) Update(ctx context.Context) error {
 		// credentials rotation flow steps
 		steps.Action(m.createOrUpdateClusterServicePrincipalRBAC),
 		steps.Action(m.createOrUpdateDenyAssignment),
+		steps.Action(m.reconcileTags),
 		steps.Action(m.startVMs),
 		steps.Condition(m.apiServersReady, 30*time.Minute, true),
+		steps.Action(m.updateOperatorResourceTags),
 		steps.Action(m.rotateACRTokenPassword),
 		steps.Action(m.configureAPIServerCertificate),
 		steps.Action(m.configureIngressCertificate),
+		steps.Action(m.ensureAdditionalIngressControllers),
+		steps.Action(m.updateAdditionalRouterIPs),
+		steps.Action(m.createOrUpdateAdditionalRouterDNS),
 		steps.Action(m.renewMDSDCertificate),
 		steps.Action(m.updateOpenShiftSecret),
 		steps.Action(m.updateAROSecret),
 		steps.Action(m.reconcileLoadBalancerProfile),
+		steps.Action(m.reconcileCustomerLoadBalancer),
+		steps.Action(m.updateEffectiveOutboundProfile),
+		steps.Action(m.scaleWorkerProfiles),
 	}
 
 	if m.adoptViaHive {
@@ -215,7 +293,7 @@ func (m *manager) Update(ctx context.Context) error {
 		)
 	}
 
-	return m.runSteps(ctx, s, "update")
+	return m.runSteps(ctx, m.progressSteps(s, 0), "update")
 }
 
 func (m *manager) runPodmanInstaller(ctx context.Context) error {
@@ -260,6 +338,7 @@ func setFieldCreatedByHive(createdByHive bool) database.OpenShiftClusterDocument
 func (m *manager) bootstrap() []steps.Step {
 	s := []steps.Step{
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+		steps.Action(m.ensureResourceProvidersRegistered),
 		steps.Action(m.ensurePreconfiguredNSG),
 		steps.Action(m.ensureACRToken),
 		steps.Action(m.ensureInfraID),
@@ -275,9 +354,11 @@ func (m *manager) bootstrap() []steps.Step {
 		// to advance
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.clusterSPObjectID),
 		steps.Action(m.ensureResourceGroup),
+		steps.Action(m.ensureExistingResourceGroupEmpty),
 		steps.Action(m.ensureServiceEndpoints),
 		steps.Action(m.setMasterSubnetPolicies),
 		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployBaseResourceTemplate),
+		steps.Action(m.populateEffectiveOutboundIPPrefix),
 		steps.Action(m.attachNSGs),
 		steps.Action(m.updateAPIIPEarly),
 		steps.Action(m.createOrUpdateRouterIPEarly),
@@ -346,6 +427,7 @@ func (m *manager) Install(ctx context.Context) error {
 			steps.Action(m.configureAPIServerCertificate),
 			steps.Condition(m.apiServersReady, 30*time.Minute, true),
 			steps.Condition(m.minimumWorkerNodesReady, 30*time.Minute, true),
+			steps.Action(m.ensureAdditionalWorkerMachineSets),
 			steps.Condition(m.operatorConsoleExists, 30*time.Minute, true),
 			steps.Action(m.updateConsoleBranding),
 			steps.Condition(m.operatorConsoleReady, 20*time.Minute, true),
@@ -357,6 +439,12 @@ func (m *manager) Install(ctx context.Context) error {
 			steps.Action(m.updateClusterData),
 			steps.Action(m.configureIngressCertificate),
 			steps.Condition(m.ingressControllerReady, 30*time.Minute, true),
+			steps.Action(m.reconcileCustomerLoadBalancer),
+			steps.Action(m.ensureAdditionalIngressControllers),
+			steps.Condition(m.additionalIngressControllersReady, 10*time.Minute, true),
+			steps.Action(m.updateAdditionalRouterIPs),
+			steps.Action(m.createOrUpdateAdditionalRouterDNS),
+			steps.Action(m.updateEffectiveOutboundProfile),
 			steps.Action(m.configureDefaultStorageClass),
 			steps.Action(m.finishInstallation),
 		},
@@ -370,8 +458,19 @@ func (m *manager) Install(ctx context.Context) error {
 	if steps[m.doc.OpenShiftCluster.Properties.Install.Phase] == nil {
 		return fmt.Errorf("unrecognised phase %s", m.doc.OpenShiftCluster.Properties.Install.Phase)
 	}
-	m.log.Printf("starting phase %s", m.doc.OpenShiftCluster.Properties.Install.Phase)
-	return m.runSteps(ctx, steps[m.doc.OpenShiftCluster.Properties.Install.Phase], "install")
+	phase := m.doc.OpenShiftCluster.Properties.Install.Phase
+	if len(m.doc.OpenShiftCluster.Properties.Install.CompletedSteps) > 0 {
+		m.log.Printf("resuming phase %s", phase)
+	} else {
+		m.log.Printf("starting phase %s", phase)
+	}
+
+	resumed := m.resumableSteps(phase, steps[phase])
+	err = m.runSteps(ctx, m.progressSteps(resumed, len(steps[phase])-len(resumed)), "install")
+	if err != nil {
+		m.markInstallFailed(ctx, err)
+	}
+	return err
 }
 
 func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic string) error {
@@ -393,7 +492,8 @@ func (m *manager) runSteps(ctx context.Context, s []steps.Step, metricsTopic str
 	} else {
 		_, err = steps.Run(ctx, m.log, 10*time.Second, s, nil)
 	}
-	if err != nil {
+	// there is no point gathering failure logs with a cancelled context
+	if err != nil && !steps.IsCancelled(err) {
 		m.gatherFailureLogs(ctx)
 	}
 	return err
@@ -418,6 +518,7 @@ func (m *manager) incrInstallPhase(ctx context.Context) error {
 	var err error
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.Install.Phase++
+		doc.OpenShiftCluster.Properties.Install.CompletedSteps = nil
 		return nil
 	})
 	return err
@@ -486,13 +587,18 @@ func (m *manager) initializeKubernetesClients(ctx context.Context) error {
 	}
 
 	m.imageregistrycli, err = imageregistryclient.NewForConfig(restConfig)
-	return err
+	if err != nil {
+		return err
+	}
+
+	m.etcdcli = etcd.NewClient(m.log, restConfig, m.kubernetescli)
+	return nil
 }
 
 // initializeKubernetesClients initializes clients which are used
 // once the cluster is up later on in the install process.
 func (m *manager) initializeOperatorDeployer(ctx context.Context) (err error) {
-	m.aroOperatorDeployer, err = deploy.New(m.log, m.env, m.doc.OpenShiftCluster, m.arocli, m.extensionscli, m.kubernetescli)
+	m.aroOperatorDeployer, err = deploy.New(m.log, m.env, m.doc.OpenShiftCluster, m.subscriptionDoc.TagPolicy, m.arocli, m.configcli, m.extensionscli, m.kubernetescli)
 	return
 }
 
@@ -63,26 +63,42 @@ func (m *manager) createOrUpdateRouterIPFromCluster(ctx context.Context) error {
 		return nil
 	}
 
-	svc, err := m.kubernetescli.CoreV1().Services("openshift-ingress").Get(ctx, "router-default", metav1.GetOptions{})
-	// default ingress must be present in the cluster
-	if err != nil {
-		return err
-	}
+	var ipAddress string
+	if clb := m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile; clb != nil {
+		// the default ingress is served by the customer load balancer
+		var err error
+		ipAddress, err = m.customerLoadBalancerFrontendIP(ctx, clb.IngressFrontendIPConfiguration)
+		if err != nil {
+			return err
+		}
+	} else {
+		svc, err := m.kubernetescli.CoreV1().Services("openshift-ingress").Get(ctx, "router-default", metav1.GetOptions{})
+		// default ingress must be present in the cluster
+		if err != nil {
+			return err
+		}
 
-	// This must be present always. If not - we have an issue
-	if len(svc.Status.LoadBalancer.Ingress) == 0 {
-		return fmt.Errorf("routerIP not found")
-	}
+		// This must be present always. If not - we have an issue
+		if len(svc.Status.LoadBalancer.Ingress) == 0 {
+			return fmt.Errorf("routerIP not found")
+		}
 
-	ipAddress := svc.Status.LoadBalancer.Ingress[0].IP
+		ipAddress = svc.Status.LoadBalancer.Ingress[0].IP
+	}
 
-	err = m.dns.CreateOrUpdateRouter(ctx, m.doc.OpenShiftCluster, ipAddress)
+	err := m.dns.CreateOrUpdateRouter(ctx, m.doc.OpenShiftCluster, ipAddress)
 	if err != nil {
 		return err
 	}
 
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
-		doc.OpenShiftCluster.Properties.IngressProfiles[0].IP = ipAddress
+		// the enriched ingress profiles are sorted by name, so the default
+		// one isn't necessarily first
+		for i := range doc.OpenShiftCluster.Properties.IngressProfiles {
+			if doc.OpenShiftCluster.Properties.IngressProfiles[i].Name == "default" {
+				doc.OpenShiftCluster.Properties.IngressProfiles[i].IP = ipAddress
+			}
+		}
 		return nil
 	})
 	return err
@@ -93,7 +109,13 @@ func (m *manager) createOrUpdateRouterIPEarly(ctx context.Context) error {
 
 	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 	var ipAddress string
-	if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
+	if clb := m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile; clb != nil {
+		var err error
+		ipAddress, err = m.customerLoadBalancerFrontendIP(ctx, clb.IngressFrontendIPConfiguration)
+		if err != nil {
+			return err
+		}
+	} else if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].Visibility == api.VisibilityPublic {
 		ip, err := m.publicIPAddresses.Get(ctx, resourceGroup, infraID+"-default-v4", "")
 		if err != nil {
 			return err
@@ -131,25 +153,32 @@ func (m *manager) createOrUpdateRouterIPEarly(ctx context.Context) error {
 	return err
 }
 
-func (m *manager) populateDatabaseIntIP(ctx context.Context) error {
-	if m.doc.OpenShiftCluster.Properties.APIServerProfile.IntIP != "" {
-		return nil
-	}
+func (m *manager) internalLoadBalancerName() (string, error) {
 	infraID := m.doc.OpenShiftCluster.Properties.InfraID
 	if infraID == "" {
 		infraID = "aro"
 	}
 
-	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
-
-	var lbName string
 	switch m.doc.OpenShiftCluster.Properties.ArchitectureVersion {
 	case api.ArchitectureVersionV1:
-		lbName = infraID + "-internal-lb"
+		return infraID + "-internal-lb", nil
 	case api.ArchitectureVersionV2:
-		lbName = infraID + "-internal"
+		return infraID + "-internal", nil
 	default:
-		return fmt.Errorf("unknown architecture version %d", m.doc.OpenShiftCluster.Properties.ArchitectureVersion)
+		return "", fmt.Errorf("unknown architecture version %d", m.doc.OpenShiftCluster.Properties.ArchitectureVersion)
+	}
+}
+
+func (m *manager) populateDatabaseIntIP(ctx context.Context) error {
+	if m.doc.OpenShiftCluster.Properties.APIServerProfile.IntIP != "" {
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	lbName, err := m.internalLoadBalancerName()
+	if err != nil {
+		return err
 	}
 
 	lb, err := m.loadBalancers.Get(ctx, resourceGroup, lbName, "")
@@ -177,7 +206,12 @@ func (m *manager) updateAPIIPEarly(ctx context.Context) error {
 	intIPAddress := *((*lb.FrontendIPConfigurations)[0].PrivateIPAddress)
 
 	ipAddress := intIPAddress
-	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+	if clb := m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile; clb != nil {
+		ipAddress, err = m.customerLoadBalancerFrontendIP(ctx, clb.APIServerFrontendIPConfiguration)
+		if err != nil {
+			return err
+		}
+	} else if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
 		ip, err := m.publicIPAddresses.Get(ctx, resourceGroup, infraID+"-pip-v4", "")
 		if err != nil {
 			return err
@@ -640,6 +640,87 @@ func TestUpdateAPIIPEarly(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name: "customer load balancer",
+			fixtureChecker: func(fixture *testdatabase.Fixture, checker *testdatabase.Checker, dbClient *cosmosdb.FakeOpenShiftClusterDocumentClient) {
+				doc := &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: resourceGroupID,
+							},
+							NetworkProfile: api.NetworkProfile{
+								CustomerLoadBalancerProfile: &api.CustomerLoadBalancerProfile{
+									ID:                               "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network/providers/Microsoft.Network/loadBalancers/frontdoor",
+									APIServerFrontendIPConfiguration: "api",
+									IngressFrontendIPConfiguration:   "ingress",
+								},
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPrivate,
+							},
+							ProvisioningState: api.ProvisioningStateCreating,
+							InfraID:           "infra",
+						},
+					},
+				}
+				fixture.AddOpenShiftClusterDocuments(doc)
+
+				doc.Dequeues = 1
+				doc.OpenShiftCluster.Properties.APIServerProfile.IP = "5.6.7.8"
+				doc.OpenShiftCluster.Properties.APIServerProfile.IntIP = "10.0.0.1"
+				checker.AddOpenShiftClusterDocuments(doc)
+			},
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient, dns *mock_dns.MockManager) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(mgmtnetwork.LoadBalancer{
+						LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+							FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+								{
+									FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("10.0.0.1"),
+									},
+								},
+							},
+						},
+					}, nil)
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor", "").
+					Return(mgmtnetwork.LoadBalancer{
+						LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+							FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+								{
+									Name: to.StringPtr("ingress"),
+									FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("10.1.0.4"),
+									},
+								},
+								{
+									Name: to.StringPtr("api"),
+									FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+										PublicIPAddress: &mgmtnetwork.PublicIPAddress{
+											ID: to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network/providers/Microsoft.Network/publicIPAddresses/frontdoor-api"),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "network", "frontdoor-api", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("5.6.7.8"),
+						},
+					}, nil)
+				dns.EXPECT().
+					Update(gomock.Any(), gomock.Any(), "5.6.7.8").
+					Return(nil)
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := gomock.NewController(t)
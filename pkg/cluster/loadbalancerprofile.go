@@ -17,13 +17,23 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
-const outboundRuleV4 = "outbound-rule-v4"
+const (
+	outboundRuleV4 = "outbound-rule-v4"
+
+	outboundIPPrefixSuffix               = "-outbound-pipprefix-v4"
+	outboundIPPrefixFrontendIPConfigName = "outbound-prefix-v4"
+)
 
 func (m *manager) reconcileLoadBalancerProfile(ctx context.Context) error {
 	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer || m.doc.OpenShiftCluster.Properties.ArchitectureVersion == api.ArchitectureVersionV1 {
 		return nil
 	}
 
+	// the outbound IP prefix is immutable and is only set up at install time
+	if m.managedOutboundIPPrefix() != nil {
+		return nil
+	}
+
 	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
 	infraID := m.doc.OpenShiftCluster.Properties.InfraID
 
@@ -410,3 +420,46 @@ func areResourceRefsEqual(a, b []api.ResourceReference) bool {
 
 	return reflect.DeepEqual(refsA, refsB)
 }
+
+// managedOutboundIPPrefix returns the desired managed outbound IP prefix of the
+// cluster public load balancer, or nil if egress uses individual IPs
+func (m *manager) managedOutboundIPPrefix() *api.ManagedOutboundIPPrefix {
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer ||
+		m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile == nil {
+		return nil
+	}
+
+	return m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.ManagedOutboundIPPrefix
+}
+
+// populateEffectiveOutboundIPPrefix records the public IP prefix allocated by
+// Azure for the cluster egress in the cluster document, so that the customer
+// can allow list it
+func (m *manager) populateEffectiveOutboundIPPrefix(ctx context.Context) error {
+	if m.managedOutboundIPPrefix() == nil {
+		return nil
+	}
+
+	resourceGroupName := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	infraID := m.doc.OpenShiftCluster.Properties.InfraID
+
+	prefix, err := m.publicIPPrefixes.Get(ctx, resourceGroupName, infraID+outboundIPPrefixSuffix, "")
+	if err != nil {
+		return err
+	}
+
+	if prefix.PublicIPPrefixPropertiesFormat == nil || prefix.IPPrefix == nil {
+		return fmt.Errorf("public IP prefix %s has not been allocated", *prefix.Name)
+	}
+
+	m.log.Infof("outbound IP prefix is %s", *prefix.IPPrefix)
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPPrefix = &api.EffectiveOutboundIPPrefix{
+			ID:       *prefix.ID,
+			IPPrefix: *prefix.IPPrefix,
+		}
+		return nil
+	})
+	return err
+}
@@ -486,6 +486,41 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 			expectedLoadBalancerProfile: nil,
 			expectedErr:                 nil,
 		},
+		{
+			name:  "reconcile is skipped when using a managed outbound ip prefix",
+			uuids: []string{},
+			m: manager{
+				doc: &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       key,
+						Location: location,
+						Properties: api.OpenShiftClusterProperties{
+							ArchitectureVersion: api.ArchitectureVersionV2,
+							ProvisioningState:   api.ProvisioningStateUpdating,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: clusterRGID,
+							},
+							InfraID: infraID,
+							NetworkProfile: api.NetworkProfile{
+								OutboundType: api.OutboundTypeLoadbalancer,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+										PrefixLength: 30,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedLoadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+			},
+			expectedErr: nil,
+		},
 		{
 			name:  "default managed ips",
 			uuids: []string{},
@@ -1014,6 +1049,138 @@ func TestReconcileLoadBalancerProfile(t *testing.T) {
 	}
 }
 
+func TestPopulateEffectiveOutboundIPPrefix(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName"
+	prefixID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPPrefixes/infraID-outbound-pipprefix-v4"
+
+	for _, tt := range []struct {
+		name                        string
+		loadBalancerProfile         *api.LoadBalancerProfile
+		mocks                       func(*mock_network.MockPublicIPPrefixesClient)
+		expectedLoadBalancerProfile *api.LoadBalancerProfile
+		wantErr                     string
+	}{
+		{
+			name: "individual managed ips",
+			loadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPs: &api.ManagedOutboundIPs{
+					Count: 1,
+				},
+			},
+			mocks: func(*mock_network.MockPublicIPPrefixesClient) {},
+			expectedLoadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPs: &api.ManagedOutboundIPs{
+					Count: 1,
+				},
+			},
+		},
+		{
+			name: "managed outbound ip prefix",
+			loadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+			},
+			mocks: func(publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "clusterRG", "infraID-outbound-pipprefix-v4", "").
+					Return(mgmtnetwork.PublicIPPrefix{
+						ID:   &prefixID,
+						Name: to.StringPtr("infraID-outbound-pipprefix-v4"),
+						PublicIPPrefixPropertiesFormat: &mgmtnetwork.PublicIPPrefixPropertiesFormat{
+							PrefixLength: to.Int32Ptr(30),
+							IPPrefix:     to.StringPtr("20.1.2.4/30"),
+						},
+					}, nil)
+			},
+			expectedLoadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+				EffectiveOutboundIPPrefix: &api.EffectiveOutboundIPPrefix{
+					ID:       prefixID,
+					IPPrefix: "20.1.2.4/30",
+				},
+			},
+		},
+		{
+			name: "managed outbound ip prefix not allocated",
+			loadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+			},
+			mocks: func(publicIPPrefixes *mock_network.MockPublicIPPrefixesClient) {
+				publicIPPrefixes.EXPECT().
+					Get(gomock.Any(), "clusterRG", "infraID-outbound-pipprefix-v4", "").
+					Return(mgmtnetwork.PublicIPPrefix{
+						ID:                             &prefixID,
+						Name:                           to.StringPtr("infraID-outbound-pipprefix-v4"),
+						PublicIPPrefixPropertiesFormat: &mgmtnetwork.PublicIPPrefixPropertiesFormat{},
+					}, nil)
+			},
+			expectedLoadBalancerProfile: &api.LoadBalancerProfile{
+				ManagedOutboundIPPrefix: &api.ManagedOutboundIPPrefix{
+					PrefixLength: 30,
+				},
+			},
+			wantErr: "public IP prefix infraID-outbound-pipprefix-v4 has not been allocated",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			publicIPPrefixes := mock_network.NewMockPublicIPPrefixesClient(controller)
+			tt.mocks(publicIPPrefixes)
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(key),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: key,
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG",
+						},
+						InfraID: "infraID",
+						NetworkProfile: api.NetworkProfile{
+							OutboundType:        api.OutboundTypeLoadbalancer,
+							LoadBalancerProfile: tt.loadBalancerProfile,
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := openShiftClustersDatabase.Get(ctx, strings.ToLower(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:              logrus.NewEntry(logrus.StandardLogger()),
+				doc:              doc,
+				db:               openShiftClustersDatabase,
+				publicIPPrefixes: publicIPPrefixes,
+			}
+
+			err = m.populateEffectiveOutboundIPPrefix(ctx)
+			if err != nil && err.Error() != tt.wantErr ||
+				err == nil && tt.wantErr != "" {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedLoadBalancerProfile, m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile)
+		})
+	}
+}
+
 func getFakePublicIPAddress(name, location string) mgmtnetwork.PublicIPAddress {
 	id := fmt.Sprintf("/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/clusterRG/providers/Microsoft.Network/publicIPAddresses/%s", name)
 	return mgmtnetwork.PublicIPAddress{
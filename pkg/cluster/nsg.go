@@ -7,10 +7,10 @@ import (
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 
-	"github.com/Azure/ARO-RP/pkg/api"
 	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
 	"github.com/Azure/ARO-RP/pkg/util/arm"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
 )
 
 func (m *manager) clusterNSG(infraID, location string) *arm.Resource {
@@ -21,22 +21,8 @@ func (m *manager) clusterNSG(infraID, location string) *arm.Resource {
 		Location:                      &location,
 	}
 
-	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
-		nsg.SecurityRules = &[]mgmtnetwork.SecurityRule{
-			{
-				SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
-					Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
-					SourcePortRange:          to.StringPtr("*"),
-					DestinationPortRange:     to.StringPtr("6443"),
-					SourceAddressPrefix:      to.StringPtr("*"),
-					DestinationAddressPrefix: to.StringPtr("*"),
-					Access:                   mgmtnetwork.SecurityRuleAccessAllow,
-					Priority:                 to.Int32Ptr(120),
-					Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
-				},
-				Name: to.StringPtr("apiserver_in"),
-			},
-		}
+	if rules := subnet.RequiredSecurityRules(m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility); len(rules) > 0 {
+		nsg.SecurityRules = &rules
 	}
 
 	return &arm.Resource{
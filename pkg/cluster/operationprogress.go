@@ -0,0 +1,157 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+)
+
+// progressSteps wraps the steps of an operation so that each step with a
+// public phase records the phase on the cluster document before it runs, for
+// the asyncOperation status.  Internal steps are not recorded, so the phase
+// of the previous step stays shown while they run.  skipped is the number of
+// leading steps of the operation which are not run, e.g. because a resumed
+// install completed them.
+func (m *manager) progressSteps(s []steps.Step, skipped int) []steps.Step {
+	phases := m.operationPhases()
+
+	progress := make([]steps.Step, 0, len(s))
+	for i, step := range s {
+		phase, found := phases[steps.Name(step)]
+		if !found {
+			progress = append(progress, step)
+			continue
+		}
+
+		p := &api.OperationProgress{
+			Step:           phase,
+			CompletedSteps: skipped + i,
+			TotalSteps:     skipped + len(s),
+		}
+
+		progress = append(progress, steps.OnStart(step, func(ctx context.Context) error {
+			m.setOperationProgress(ctx, p)
+			return nil
+		}))
+	}
+
+	return progress
+}
+
+// operationPhases returns the public phase of each step which is reported in
+// the asyncOperation status, by step name.  The phase names are part of the
+// API and must not change; steps which are not listed are internal.
+func (m *manager) operationPhases() map[string]string {
+	phases := map[string]string{}
+
+	for phase, s := range map[string][]steps.Step{
+		"Validating": {
+			steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+			steps.Action(m.validateExistingResourceGroup),
+			steps.Action(m.ensureResourceProvidersRegistered),
+		},
+		"CreatingDNS": {
+			steps.Action(m.createDNS),
+		},
+		"CreatingResources": {
+			steps.Action(m.ensureResourceGroup),
+			steps.AuthorizationRetryingAction(m.fpAuthorizer, m.deployBaseResourceTemplate),
+			steps.Action(m.createAPIServerPrivateEndpoint),
+			steps.Action(m.createCertificates),
+		},
+		"InstallingCluster": {
+			steps.Action(m.runPodmanInstaller),
+			steps.Action(m.runHiveInstaller),
+			steps.Condition(m.hiveClusterInstallationComplete, 0, false),
+		},
+		"StartingVirtualMachines": {
+			steps.Action(m.startVMs),
+		},
+		"WaitingForAPIServer": {
+			steps.Condition(m.apiServersReady, 0, false),
+		},
+		"DeployingOperator": {
+			steps.Action(m.ensureAROOperator),
+			steps.Condition(m.aroDeploymentReady, 0, false),
+			steps.Condition(m.ensureAROOperatorRunningDesiredVersion, 0, false),
+		},
+		"RemovingBootstrap": {
+			steps.Action(m.removeBootstrap),
+		},
+		"WaitingForWorkerNodes": {
+			steps.Condition(m.minimumWorkerNodesReady, 0, false),
+			steps.Action(m.ensureAdditionalWorkerMachineSets),
+		},
+		"WaitingForClusterOperators": {
+			steps.Condition(m.operatorConsoleReady, 0, false),
+			steps.Condition(m.clusterVersionReady, 0, false),
+		},
+		"ConfiguringCertificates": {
+			steps.Action(m.configureAPIServerCertificate),
+			steps.Action(m.renewExpiringCertificates),
+			steps.Action(m.rotateCertificates),
+		},
+		"ConfiguringIngress": {
+			steps.Action(m.configureIngressCertificate),
+			steps.Condition(m.ingressControllerReady, 0, false),
+			steps.Action(m.ensureAdditionalIngressControllers),
+			steps.Condition(m.additionalIngressControllersReady, 0, false),
+		},
+		"UpdatingCredentials": {
+			steps.Action(m.createOrUpdateClusterServicePrincipalRBAC),
+			steps.Action(m.updateOpenShiftSecret),
+			steps.Action(m.updateAROSecret),
+		},
+		"ScalingWorkers": {
+			steps.Action(m.scaleWorkerProfiles),
+		},
+		"SyncingClusterProperties": {
+			steps.Action(m.syncClusterProperties),
+		},
+		"DefragmentingEtcd": {
+			steps.Action(m.defragmentEtcd),
+		},
+		"BackingUpEtcd": {
+			steps.Action(m.backupEtcd),
+		},
+		"RestoringEtcd": {
+			steps.Action(m.restoreEtcd),
+		},
+		"RebootingNode": {
+			steps.Action(m.rebootNode),
+		},
+		"DrainingMachineSet": {
+			steps.Action(m.drainMachineSet),
+		},
+		"FinishingInstallation": {
+			steps.Action(m.finishInstallation),
+		},
+	} {
+		for _, step := range s {
+			phases[steps.Name(step)] = phase
+		}
+	}
+
+	return phases
+}
+
+// setOperationProgress records the progress of the operation on the cluster
+// document.  It is cleared when the operation ends.  The progress is only
+// informational, so an error recording it is logged and doesn't fail the
+// operation.
+func (m *manager) setOperationProgress(ctx context.Context, progress *api.OperationProgress) {
+	doc, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.OperationProgress = progress
+		return nil
+	})
+	if err != nil {
+		m.log.Warnf("could not record the progress of the operation: %v", err)
+		return
+	}
+
+	m.doc = doc
+}
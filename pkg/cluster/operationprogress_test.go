@@ -0,0 +1,127 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestProgressSteps(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: key,
+			Properties: api.OpenShiftClusterProperties{
+				Install: &api.Install{},
+			},
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openShiftClustersDatabase.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: doc,
+		db:  openShiftClustersDatabase,
+	}
+
+	// each step sees the phase of the last public step recorded as running
+	var got []*api.OperationProgress
+	record := func(ctx context.Context) error {
+		doc, err := openShiftClustersDatabase.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		got = append(got, doc.OpenShiftCluster.Properties.OperationProgress)
+		return nil
+	}
+	fail := func(ctx context.Context) error {
+		return errors.New("oh no!")
+	}
+
+	_, err = steps.Run(ctx, m.log, 0, m.progressSteps([]steps.Step{
+		steps.Action(record),
+		steps.Action(m.finishInstallation),
+		steps.Action(record),
+		steps.Action(fail),
+	}, 2), nil)
+	utilerror.AssertErrorMessage(t, err, "oh no!")
+
+	finishing := &api.OperationProgress{Step: "FinishingInstallation", CompletedSteps: 3, TotalSteps: 6}
+
+	want := []*api.OperationProgress{nil, finishing}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v", got)
+	}
+
+	// the internal step which failed isn't recorded
+	doc, err = openShiftClustersDatabase.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(doc.OpenShiftCluster.Properties.OperationProgress, finishing) {
+		t.Errorf("got %#v", doc.OpenShiftCluster.Properties.OperationProgress)
+	}
+}
+
+func TestSetOperationProgressIsBestEffort(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	openShiftClustersDatabase, openShiftClustersClient := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+	fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+		Key: key,
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: key,
+		},
+	})
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openShiftClustersDatabase.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: doc,
+		db:  openShiftClustersDatabase,
+	}
+
+	openShiftClustersClient.SetError(errors.New("cosmos is down"))
+
+	m.setOperationProgress(ctx, &api.OperationProgress{Step: "Validating", TotalSteps: 1})
+
+	if m.doc != doc {
+		t.Error("the cluster document changed")
+	}
+}
@@ -0,0 +1,179 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// rebootNodeDefaultDrainTimeout is how long the drain of a node waits for its
+// pods to be evicted, if no drainTimeout is given
+var rebootNodeDefaultDrainTimeout = 10 * time.Minute
+
+// rebootNodeReadyTimeout is how long we wait for a node to come back Ready
+// after rebooting its VM
+var rebootNodeReadyTimeout = 15 * time.Minute
+
+var rebootNodeReadyInterval = 10 * time.Second
+
+// rebootNodeUncordonTimeout bounds how long we try to uncordon a node after
+// its drain failed
+var rebootNodeUncordonTimeout = time.Minute
+
+// rebootNode cordons and drains the node, reboots its VM and uncordons it once
+// it has come back Ready.  Nodes are rebooted one at a time: it refuses to
+// start if any other node is cordoned or not Ready.  Unless a drainTimeout is
+// given it also refuses to start if evicting the pods on the node would
+// violate a pod disruption budget.  It only runs when requested by the
+// RebootNode maintenance task.
+func (m *manager) rebootNode(ctx context.Context) error {
+	params := m.doc.OpenShiftCluster.Properties.MaintenanceTaskParameters
+	if params == nil || params.VMName == "" {
+		return errors.New("no node to reboot was given")
+	}
+	nodeName := params.VMName
+
+	drainTimeout := rebootNodeDefaultDrainTimeout
+	drainTimeoutOverride := params.DrainTimeout != ""
+	if drainTimeoutOverride {
+		var err error
+		drainTimeout, err = time.ParseDuration(params.DrainTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the parameters are consumed, so that the task isn't repeated by a later
+	// admin update
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MaintenanceTaskParameters = nil
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	node, err := m.checkNodesForReboot(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+
+	if !drainTimeoutOverride {
+		violations, err := m.nodeDisruptionBudgetViolations(ctx, nodeName)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return fmt.Errorf("draining node %s would violate the pod disruption budgets %s, give a drainTimeout to wait for them", nodeName, strings.Join(violations, ", "))
+		}
+	}
+
+	// leave a node which was cordoned before we started cordoned
+	wasCordoned := node.Spec.Unschedulable
+
+	m.log.Printf("cordoning node %s", nodeName)
+	err = m.cordonNode(ctx, nodeName, true)
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("draining node %s (timeout %s)", nodeName, drainTimeout)
+	err = m.drainNode(ctx, nodeName, drainTimeout)
+	if err != nil {
+		if !wasCordoned {
+			// uncordon on a fresh context, since ctx may be what failed
+			// the drain
+			m.log.Printf("drain failed, uncordoning node %s", nodeName)
+			uncordonCtx, cancel := context.WithTimeout(context.Background(), rebootNodeUncordonTimeout)
+			defer cancel()
+
+			uncordonErr := m.cordonNode(uncordonCtx, nodeName, false)
+			if uncordonErr != nil {
+				return fmt.Errorf("%v; additionally failed to uncordon node: %v", err, uncordonErr)
+			}
+		}
+		return err
+	}
+
+	m.log.Printf("rebooting VM %s", nodeName)
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+	err = m.virtualMachines.RestartAndWait(ctx, resourceGroup, nodeName)
+	if err != nil {
+		return err
+	}
+
+	m.log.Printf("waiting for node %s to be ready", nodeName)
+	err = m.waitNodeRebooted(ctx, nodeName, node.Status.NodeInfo.BootID)
+	if err != nil {
+		return fmt.Errorf("node %s did not become ready after rebooting, leaving it cordoned: %w", nodeName, err)
+	}
+
+	if !wasCordoned {
+		m.log.Printf("uncordoning node %s", nodeName)
+		err = m.cordonNode(ctx, nodeName, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.log.Printf("node %s rebooted", nodeName)
+	return nil
+}
+
+// checkNodesForReboot returns the node to reboot, after checking that all the
+// other nodes are schedulable and Ready
+func (m *manager) checkNodesForReboot(ctx context.Context, nodeName string) (*corev1.Node, error) {
+	nodes, err := m.kubernetescli.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var node *corev1.Node
+	for i := range nodes.Items {
+		if nodes.Items[i].Name == nodeName {
+			node = &nodes.Items[i]
+			continue
+		}
+
+		if nodes.Items[i].Spec.Unschedulable || !ready.NodeIsReady(&nodes.Items[i]) {
+			return nil, fmt.Errorf("node %s is cordoned or not ready, nodes are rebooted one at a time", nodes.Items[i].Name)
+		}
+	}
+
+	if node == nil {
+		return nil, fmt.Errorf("node %s was not found", nodeName)
+	}
+
+	return node, nil
+}
+
+// waitNodeRebooted waits for the node to report a new boot ID and be Ready.
+// Waiting for Ready alone isn't enough, since the node may not have been
+// marked NotReady while the VM restarted.
+func (m *manager) waitNodeRebooted(ctx context.Context, nodeName, bootID string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, rebootNodeReadyTimeout)
+	defer cancel()
+
+	return wait.PollImmediateUntil(rebootNodeReadyInterval, func() (bool, error) {
+		node, err := m.kubernetescli.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+
+		return node.Status.NodeInfo.BootID != bootID && ready.NodeIsReady(node), nil
+	}, timeoutCtx.Done())
+}
@@ -0,0 +1,209 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRebootNode(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	vmName := "aro-worker-australiasoutheast-7tcq7"
+
+	rebootNodeReadyInterval = time.Millisecond
+
+	node := func(name string, unschedulable bool, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: unschedulable,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				NodeInfo:   corev1.NodeSystemInfo{BootID: "boot1"},
+			},
+		}
+	}
+
+	protectedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "customer",
+			Name:      "db-1",
+			Labels:    map[string]string{"app": "db"},
+		},
+		Spec:   corev1.PodSpec{NodeName: vmName},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "customer",
+			Name:      "db",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		},
+	}
+
+	for _, tt := range []struct {
+		name              string
+		params            *api.MaintenanceTaskParameters
+		objects           []kruntime.Object
+		wantReboot        bool
+		wantUnschedulable bool
+		wantErr           string
+	}{
+		{
+			name:    "no parameters",
+			wantErr: "no node to reboot was given",
+		},
+		{
+			name:    "node not found",
+			params:  &api.MaintenanceTaskParameters{VMName: vmName},
+			objects: []kruntime.Object{node("other", false, corev1.ConditionTrue)},
+			wantErr: "node " + vmName + " was not found",
+		},
+		{
+			name:   "another node is not ready",
+			params: &api.MaintenanceTaskParameters{VMName: vmName},
+			objects: []kruntime.Object{
+				node(vmName, false, corev1.ConditionTrue),
+				node("other", false, corev1.ConditionFalse),
+			},
+			wantErr: "node other is cordoned or not ready, nodes are rebooted one at a time",
+		},
+		{
+			name:   "another node is cordoned",
+			params: &api.MaintenanceTaskParameters{VMName: vmName},
+			objects: []kruntime.Object{
+				node(vmName, false, corev1.ConditionTrue),
+				node("other", true, corev1.ConditionTrue),
+			},
+			wantErr: "node other is cordoned or not ready, nodes are rebooted one at a time",
+		},
+		{
+			name:   "pod disruption budget would be violated",
+			params: &api.MaintenanceTaskParameters{VMName: vmName},
+			objects: []kruntime.Object{
+				node(vmName, false, corev1.ConditionTrue),
+				protectedPod,
+				pdb,
+			},
+			wantErr: "draining node " + vmName + " would violate the pod disruption budgets customer/db, give a drainTimeout to wait for them",
+		},
+		{
+			name:   "node is rebooted",
+			params: &api.MaintenanceTaskParameters{VMName: vmName},
+			objects: []kruntime.Object{
+				node(vmName, false, corev1.ConditionTrue),
+				node("other", false, corev1.ConditionTrue),
+			},
+			wantReboot: true,
+		},
+		{
+			name:   "node which was cordoned is left cordoned",
+			params: &api.MaintenanceTaskParameters{VMName: vmName, DrainTimeout: "30m"},
+			objects: []kruntime.Object{
+				node(vmName, true, corev1.ConditionTrue),
+			},
+			wantReboot:        true,
+			wantUnschedulable: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:         api.ProvisioningStateAdminUpdating,
+						MaintenanceTask:           api.MaintenanceTaskRebootNode,
+						MaintenanceTaskParameters: tt.params,
+						ClusterProfile: api.ClusterProfile{
+							ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-cluster",
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kubernetescli := fake.NewSimpleClientset(tt.objects...)
+
+			virtualMachines := mock_compute.NewMockVirtualMachinesClient(controller)
+			if tt.wantReboot {
+				// the node comes back with a new boot ID
+				virtualMachines.EXPECT().RestartAndWait(gomock.Any(), "aro-cluster", vmName).DoAndReturn(func(ctx context.Context, resourceGroupName, vmName string) error {
+					node, err := kubernetescli.CoreV1().Nodes().Get(ctx, vmName, metav1.GetOptions{})
+					if err != nil {
+						return err
+					}
+					node.Status.NodeInfo.BootID = "boot2"
+					_, err = kubernetescli.CoreV1().Nodes().UpdateStatus(ctx, node, metav1.UpdateOptions{})
+					return err
+				})
+			}
+
+			m := &manager{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				doc:             clusterdoc,
+				db:              fakeOpenShiftClustersDatabase,
+				kubernetescli:   kubernetescli,
+				virtualMachines: virtualMachines,
+			}
+
+			err = m.rebootNode(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.OpenShiftCluster.Properties.MaintenanceTaskParameters != nil {
+				t.Error("maintenance task parameters were not cleared")
+			}
+
+			if tt.wantReboot {
+				node, err := kubernetescli.CoreV1().Nodes().Get(ctx, vmName, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if node.Spec.Unschedulable != tt.wantUnschedulable {
+					t.Errorf("got unschedulable %t, wanted %t", node.Spec.Unschedulable, tt.wantUnschedulable)
+				}
+			}
+		})
+	}
+}
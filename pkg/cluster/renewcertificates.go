@@ -0,0 +1,159 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+)
+
+const (
+	certificateRenewedMetricName       = "backend.openshiftcluster.certificate.renewed"
+	certificateRenewalFailedMetricName = "backend.openshiftcluster.certificate.renewal.failed"
+)
+
+// certificateRenewalWindow is how long before their expiry the RP-managed
+// certificates are renewed
+var certificateRenewalWindow = 30 * 24 * time.Hour
+
+// renewExpiringCertificates renews the RP-managed certificates of the cluster
+// which expire within the renewal window.  The machine config server
+// certificate is re-signed by the cluster root CA and the machine config
+// servers are restarted.  On managed domains, the API server and ingress
+// certificates are re-issued in the cluster key vault if needed and synced
+// into the cluster, where the API servers and routers roll out to serve them.
+// The certificates of custom domains belong to the customer and are left
+// alone.  A failure to renew one certificate does not stop the others from
+// being renewed; each renewal and each failure is emitted as a metric.
+func (m *manager) renewExpiringCertificates(ctx context.Context) error {
+	now := m.now()
+
+	var errs []string
+	for _, c := range []struct {
+		name  string
+		renew func(context.Context, time.Time) (bool, error)
+	}{
+		{
+			name:  "machineConfigServer",
+			renew: m.renewMCSCertificate,
+		},
+		{
+			name:  "apiServer",
+			renew: m.renewManagedDomainCertificate("-apiserver", "openshift-config", m.configureAPIServerCertificate),
+		},
+		{
+			name:  "ingress",
+			renew: m.renewManagedDomainCertificate("-ingress", "openshift-ingress", m.configureIngressCertificate),
+		},
+	} {
+		renewed, err := c.renew(ctx, now)
+		if err != nil {
+			m.log.Errorf("failed to renew the %s certificate: %v", c.name, err)
+			m.metricsEmitter.EmitGauge(certificateRenewalFailedMetricName, 1, map[string]string{
+				"certificate": c.name,
+			})
+			errs = append(errs, fmt.Sprintf("%s: %v", c.name, err))
+			continue
+		}
+
+		if renewed {
+			m.log.Printf("renewed the %s certificate", c.name)
+			m.metricsEmitter.EmitGauge(certificateRenewedMetricName, 1, map[string]string{
+				"certificate": c.name,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to renew certificates: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (m *manager) renewMCSCertificate(ctx context.Context, now time.Time) (bool, error) {
+	return m.ensureMCSCert(ctx, func(cert *x509.Certificate) bool {
+		return !certificateExpiring(cert, now)
+	})
+}
+
+// renewManagedDomainCertificate returns a function which renews the managed
+// domain certificate with the given suffix if the copy in namespace is
+// expiring.  The certificate is only re-issued in the key vault if the key
+// vault copy is expiring too; otherwise the cluster has simply not picked up
+// a renewal yet.  configure syncs the certificate into the cluster.
+func (m *manager) renewManagedDomainCertificate(suffix, namespace string, configure func(context.Context) error) func(context.Context, time.Time) (bool, error) {
+	return func(ctx context.Context, now time.Time) (bool, error) {
+		if m.env.FeatureIsSet(env.FeatureDisableSignedCertificates) {
+			return false, nil
+		}
+
+		managedDomain, err := dns.ManagedDomain(m.env, m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+		if err != nil {
+			return false, err
+		}
+
+		if managedDomain == "" {
+			m.log.Printf("skipping %s certificate: custom domain certificates are managed by the customer", strings.TrimPrefix(suffix, "-"))
+			return false, nil
+		}
+
+		certificateName := m.doc.ID + suffix
+
+		s, err := m.kubernetescli.CoreV1().Secrets(namespace).Get(ctx, certificateName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		_, certs, err := utilpem.Parse(s.Data[corev1.TLSCertKey])
+		if err != nil {
+			return false, err
+		}
+
+		if len(certs) > 0 && !certificateExpiring(certs[0], now) {
+			return false, nil
+		}
+
+		_, certs, err = m.env.ClusterKeyvault().GetCertificateSecret(ctx, certificateName)
+		if err != nil {
+			return false, err
+		}
+
+		if len(certs) == 0 || certificateExpiring(certs[0], now) {
+			commonName := "api." + managedDomain
+			if suffix == "-ingress" {
+				commonName = "*.apps." + managedDomain
+			}
+
+			m.log.Printf("re-issuing certificate %s", certificateName)
+			err = m.env.ClusterKeyvault().CreateSignedCertificate(ctx, "OneCertV2-PublicCA", certificateName, commonName, keyvault.EkuServerAuth)
+			if err != nil {
+				return false, err
+			}
+
+			err = m.env.ClusterKeyvault().WaitForCertificateOperation(ctx, certificateName)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		return true, configure(ctx)
+	}
+}
+
+// certificateExpiring returns true if cert expires within the renewal window
+func certificateExpiring(cert *x509.Certificate, now time.Time) bool {
+	return !now.Add(certificateRenewalWindow).Before(cert.NotAfter)
+}
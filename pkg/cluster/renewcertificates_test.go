@@ -0,0 +1,242 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/util/keyvault"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	mock_keyvault "github.com/Azure/ARO-RP/pkg/util/mocks/keyvault"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRenewManagedDomainCertificate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	certificate := func(notAfter time.Time) []*x509.Certificate {
+		_, certs, err := utiltls.GenerateTestKeyAndCertificate("api.cluster.location.aroapp.io", nil, nil, false, false, func(template *x509.Certificate) {
+			template.NotAfter = notAfter
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return certs
+	}
+
+	valid := certificate(now.Add(90 * 24 * time.Hour))
+	expiring := certificate(now.Add(7 * 24 * time.Hour))
+
+	secret := func(certs []*x509.Certificate) kruntime.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "id-apiserver",
+				Namespace: "openshift-config",
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certs[0].Raw}),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		domain        string
+		objects       []kruntime.Object
+		mocks         func(*mock_keyvault.MockManager)
+		wantRenewed   bool
+		wantConfigure bool
+		wantErr       string
+	}{
+		{
+			name:   "custom domain is skipped",
+			domain: "example.com",
+		},
+		{
+			name:    "certificate is not expiring",
+			domain:  "cluster",
+			objects: []kruntime.Object{secret(valid)},
+		},
+		{
+			name:    "key vault certificate was already renewed",
+			domain:  "cluster",
+			objects: []kruntime.Object{secret(expiring)},
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetCertificateSecret(gomock.Any(), "id-apiserver").Return(nil, valid, nil)
+			},
+			wantRenewed:   true,
+			wantConfigure: true,
+		},
+		{
+			name:    "key vault certificate is expiring",
+			domain:  "cluster",
+			objects: []kruntime.Object{secret(expiring)},
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetCertificateSecret(gomock.Any(), "id-apiserver").Return(nil, expiring, nil)
+				kv.EXPECT().CreateSignedCertificate(gomock.Any(), "OneCertV2-PublicCA", "id-apiserver", "api.cluster.location.aroapp.io", keyvault.EkuServerAuth).Return(nil)
+				kv.EXPECT().WaitForCertificateOperation(gomock.Any(), "id-apiserver").Return(nil)
+			},
+			wantRenewed:   true,
+			wantConfigure: true,
+		},
+		{
+			name:    "re-issuing the certificate fails",
+			domain:  "cluster",
+			objects: []kruntime.Object{secret(expiring)},
+			mocks: func(kv *mock_keyvault.MockManager) {
+				kv.EXPECT().GetCertificateSecret(gomock.Any(), "id-apiserver").Return(nil, expiring, nil)
+				kv.EXPECT().CreateSignedCertificate(gomock.Any(), "OneCertV2-PublicCA", "id-apiserver", "api.cluster.location.aroapp.io", keyvault.EkuServerAuth).Return(errors.New("random error"))
+			},
+			wantErr: "random error",
+		},
+		{
+			name:    "missing secret",
+			domain:  "cluster",
+			wantErr: `secrets "id-apiserver" not found`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			kv := mock_keyvault.NewMockManager(controller)
+			if tt.mocks != nil {
+				tt.mocks(kv)
+			}
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().FeatureIsSet(env.FeatureDisableSignedCertificates).Return(false)
+			_env.EXPECT().Domain().AnyTimes().Return("location.aroapp.io")
+			_env.EXPECT().ClusterKeyvault().AnyTimes().Return(kv)
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				env: _env,
+				doc: &api.OpenShiftClusterDocument{
+					ID: "id",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Domain: tt.domain,
+							},
+						},
+					},
+				},
+				kubernetescli: fake.NewSimpleClientset(tt.objects...),
+			}
+
+			var configured bool
+			renew := m.renewManagedDomainCertificate("-apiserver", "openshift-config", func(context.Context) error {
+				configured = true
+				return nil
+			})
+
+			renewed, err := renew(ctx, now)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if renewed != tt.wantRenewed {
+				t.Error(renewed)
+			}
+			if configured != tt.wantConfigure {
+				t.Error(configured)
+			}
+		})
+	}
+}
+
+func TestRenewExpiringCertificates(t *testing.T) {
+	ctx := context.Background()
+
+	_, mcsCerts, err := utiltls.GenerateKeyAndCertificate("system:machine-config-server", nil, nil, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mcsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-config-server-tls",
+			Namespace: "openshift-machine-config-operator",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mcsCerts[0].Raw}),
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		objects     []kruntime.Object
+		wantMetrics []string
+		wantErr     string
+	}{
+		{
+			name:    "nothing to renew",
+			objects: []kruntime.Object{mcsSecret},
+		},
+		{
+			name:        "renewal failure is reported",
+			wantMetrics: []string{certificateRenewalFailedMetricName},
+			wantErr:     `failed to renew certificates: machineConfigServer: secrets "machine-config-server-tls" not found`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().FeatureIsSet(env.FeatureDisableSignedCertificates).AnyTimes().Return(false)
+			_env.EXPECT().Domain().AnyTimes().Return("location.aroapp.io")
+
+			fm := newfakeMetricsEmitter()
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				env: _env,
+				doc: &api.OpenShiftClusterDocument{
+					ID: "id",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Domain: "example.com",
+							},
+							APIServerProfile: api.APIServerProfile{
+								IntIP: "10.0.0.1",
+							},
+						},
+					},
+				},
+				kubernetescli:  fake.NewSimpleClientset(tt.objects...),
+				metricsEmitter: fm,
+				now:            time.Now,
+			}
+
+			err := m.renewExpiringCertificates(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if len(fm.Metrics) != len(tt.wantMetrics) {
+				t.Errorf("got metrics %v", fm.Metrics)
+			}
+			for _, metric := range tt.wantMetrics {
+				if _, ok := fm.Metrics[metric]; !ok {
+					t.Errorf("missing metric %s", metric)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/util/resourceproviders"
+)
+
+var resourceProvidersRegistrationTimeout = 10 * time.Minute
+
+// ensureResourceProvidersRegistered requests registration of any required
+// resource providers which are not registered in the customer subscription,
+// and waits for their registration to complete.  If that does not happen
+// before the timeout, the providers which are still not registered are
+// returned in the error.
+func (m *manager) ensureResourceProvidersRegistered(ctx context.Context) error {
+	unregistered, registering, err := resourceproviders.Unregistered(ctx, m.providers)
+	if err != nil {
+		return err
+	}
+
+	if len(unregistered) == 0 {
+		return nil
+	}
+
+	failed := resourceproviders.Register(ctx, m.log, m.providers, unregistered, registering)
+	if len(failed) > 0 {
+		return resourceproviders.NotRegisteredError(failed)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, resourceProvidersRegistrationTimeout)
+	defer cancel()
+
+	// NOTE: Do not override err with the error returned by
+	// wait.PollImmediateUntil. Doing this will not propagate the latest error
+	// to the user in case when wait exceeds the timeout
+	_ = wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
+		unregistered, _, err = resourceproviders.Unregistered(ctx, m.providers)
+		if err != nil {
+			return false, err
+		}
+		if len(unregistered) > 0 {
+			m.log.Printf("waiting for resource providers %v to be registered", unregistered)
+			err = resourceproviders.NotRegisteredError(unregistered)
+			return false, nil
+		}
+		return true, nil
+	}, timeoutCtx.Done())
+
+	return err
+}
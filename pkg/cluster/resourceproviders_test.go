@@ -0,0 +1,125 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEnsureResourceProvidersRegistered(t *testing.T) {
+	ctx := context.Background()
+
+	providers := func(computeState string) []mgmtfeatures.Provider {
+		return []mgmtfeatures.Provider{
+			{
+				Namespace:         to.StringPtr("Microsoft.Authorization"),
+				RegistrationState: to.StringPtr("Registered"),
+			},
+			{
+				Namespace:         to.StringPtr("Microsoft.Compute"),
+				RegistrationState: to.StringPtr(computeState),
+			},
+			{
+				Namespace:         to.StringPtr("Microsoft.Network"),
+				RegistrationState: to.StringPtr("Registered"),
+			},
+			{
+				Namespace:         to.StringPtr("Microsoft.Storage"),
+				RegistrationState: to.StringPtr("Registered"),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_features.MockProvidersClient)
+		wantErr string
+	}{
+		{
+			name: "all registered",
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					List(gomock.Any(), nil, "").
+					Return(providers("Registered"), nil)
+			},
+		},
+		{
+			name: "registered on demand",
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				gomock.InOrder(
+					providersClient.EXPECT().
+						List(gomock.Any(), nil, "").
+						Return(providers("NotRegistered"), nil),
+					providersClient.EXPECT().
+						Register(gomock.Any(), "Microsoft.Compute").
+						Return(mgmtfeatures.Provider{}, nil),
+					providersClient.EXPECT().
+						List(gomock.Any(), nil, "").
+						Return(providers("Registered"), nil),
+				)
+			},
+		},
+		{
+			name: "registration not permitted",
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					List(gomock.Any(), nil, "").
+					Return(providers("NotRegistered"), nil)
+				providersClient.EXPECT().
+					Register(gomock.Any(), "Microsoft.Compute").
+					Return(mgmtfeatures.Provider{}, errors.New("forbidden"))
+			},
+			wantErr: "400: ResourceProviderNotRegistered: : The resource provider 'Microsoft.Compute' is not registered.",
+		},
+		{
+			name: "registration times out",
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					List(gomock.Any(), nil, "").
+					Return(providers("Registering"), nil).
+					MinTimes(2)
+			},
+			wantErr: "400: ResourceProviderNotRegistered: : The resource provider 'Microsoft.Compute' is not registered.",
+		},
+		{
+			name: "list error",
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					List(gomock.Any(), nil, "").
+					Return(nil, errors.New("random error"))
+			},
+			wantErr: "random error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			oldTimeout := resourceProvidersRegistrationTimeout
+			defer func() { resourceProvidersRegistrationTimeout = oldTimeout }()
+			resourceProvidersRegistrationTimeout = 0
+
+			providersClient := mock_features.NewMockProvidersClient(controller)
+			tt.mocks(providersClient)
+
+			m := &manager{
+				log:       logrus.NewEntry(logrus.StandardLogger()),
+				providers: providersClient,
+			}
+
+			err := m.ensureResourceProvidersRegistered(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+)
+
+// resumableSteps wraps the steps of the install phase so that the completion
+// of each is recorded on the cluster document, and drops the leading steps
+// which completed in a previous attempt at the phase.  The steps returned by
+// rerunSteps are kept even if they completed: they validate the
+// preconditions of the install or set up in-memory state for later steps.
+//
+// Only the leading run of completed steps is dropped, so if the steps of the
+// phase changed since the previous attempt, everything from the first step
+// which doesn't match is run again.
+func (m *manager) resumableSteps(phase api.InstallPhase, s []steps.Step) []steps.Step {
+	names := make([]string, 0, len(s))
+	for _, step := range s {
+		names = append(names, steps.Name(step))
+	}
+
+	rerun := map[string]bool{}
+	for _, step := range m.rerunSteps() {
+		rerun[steps.Name(step)] = true
+	}
+
+	completed := m.doc.OpenShiftCluster.Properties.Install.CompletedSteps
+
+	resumed := make([]steps.Step, 0, len(s))
+	for i, step := range s {
+		if i < len(completed) && completed[i] == names[i] {
+			if !rerun[names[i]] {
+				m.log.Infof("skipping completed step %s", step)
+				continue
+			}
+		} else {
+			// once a step which didn't complete runs, all of the following
+			// steps must run too
+			completed = nil
+		}
+
+		completedSteps := names[:i+1]
+		resumed = append(resumed, steps.OnCompletion(step, func(ctx context.Context) error {
+			return m.markStepsCompleted(ctx, phase, completedSteps)
+		}))
+	}
+
+	return resumed
+}
+
+// rerunSteps returns the steps which run again when an install is resumed
+func (m *manager) rerunSteps() []steps.Step {
+	return []steps.Step{
+		steps.AuthorizationRetryingAction(m.fpAuthorizer, m.validateResources),
+		steps.Action(m.initializeClusterSPClients),
+		steps.Action(m.initializeKubernetesClients),
+		steps.Action(m.initializeOperatorDeployer),
+	}
+}
+
+// markStepsCompleted records the completed steps of the phase, unless the
+// install has since moved on to the next phase or finished
+func (m *manager) markStepsCompleted(ctx context.Context, phase api.InstallPhase, completedSteps []string) error {
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.Install == nil ||
+			doc.OpenShiftCluster.Properties.Install.Phase != phase {
+			return nil
+		}
+
+		doc.OpenShiftCluster.Properties.Install.CompletedSteps = completedSteps
+		return nil
+	})
+	return err
+}
+
+// markInstallFailed records whether the failed install may be resumed.  A
+// cancelled install is left as is: the lease was lost or the backend is
+// stopping, and the install is retried anyway.
+func (m *manager) markInstallFailed(ctx context.Context, installErr error) {
+	if steps.IsCancelled(installErr) {
+		return
+	}
+
+	doc, err := m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.Install != nil {
+			doc.OpenShiftCluster.Properties.Install.Resumable = isTransientInstallError(installErr)
+		}
+		return nil
+	})
+	if err != nil {
+		m.log.Error(err)
+		return
+	}
+	m.doc = doc
+}
+
+// isTransientInstallError returns true if the failed step may succeed when
+// retried.  Errors which the customer must fix first, such as missing
+// permissions, invalid resources or exhausted quota, are permanent: they are
+// returned as CloudErrors with a 4xx status code, or by Azure with one.
+// Throttling and conflicts are transient, as are internal errors and timeouts.
+func isTransientInstallError(err error) bool {
+	isTransientStatusCode := func(statusCode int) bool {
+		return statusCode < http.StatusBadRequest ||
+			statusCode >= http.StatusInternalServerError ||
+			statusCode == http.StatusRequestTimeout ||
+			statusCode == http.StatusConflict ||
+			statusCode == http.StatusTooManyRequests
+	}
+
+	var cloudErr *api.CloudError
+	if errors.As(err, &cloudErr) {
+		return isTransientStatusCode(cloudErr.StatusCode)
+	}
+
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if statusCode, ok := detailedErr.StatusCode.(int); ok {
+			return isTransientStatusCode(statusCode)
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,254 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/steps"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestResumableSteps(t *testing.T) {
+	m := &manager{}
+
+	first := steps.Action(successfulActionStep)
+	second := steps.Condition(successfulConditionStep, time.Minute, true)
+	third := steps.Action(failingFunc)
+	rerun := steps.Action(m.initializeKubernetesClients)
+
+	for _, tt := range []struct {
+		name           string
+		steps          []steps.Step
+		completedSteps []string
+		wantSteps      []steps.Step
+	}{
+		{
+			name:      "new phase runs all steps",
+			steps:     []steps.Step{first, second, third},
+			wantSteps: []steps.Step{first, second, third},
+		},
+		{
+			name:           "completed steps are skipped",
+			steps:          []steps.Step{first, second, third},
+			completedSteps: []string{steps.Name(first), steps.Name(second)},
+			wantSteps:      []steps.Step{third},
+		},
+		{
+			name:           "completed steps which set up clients are rerun",
+			steps:          []steps.Step{rerun, first, second, third},
+			completedSteps: []string{steps.Name(rerun), steps.Name(first)},
+			wantSteps:      []steps.Step{rerun, second, third},
+		},
+		{
+			name:           "steps after a mismatch are rerun",
+			steps:          []steps.Step{first, third, second},
+			completedSteps: []string{steps.Name(first), steps.Name(second)},
+			wantSteps:      []steps.Step{third, second},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, log := testlog.New()
+
+			m.log = log
+			m.doc = &api.OpenShiftClusterDocument{
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						Install: &api.Install{
+							CompletedSteps: tt.completedSteps,
+						},
+					},
+				},
+			}
+
+			var got, want []string
+			for _, step := range m.resumableSteps(api.InstallPhaseBootstrap, tt.steps) {
+				got = append(got, steps.Name(step))
+			}
+			for _, step := range tt.wantSteps {
+				want = append(want, steps.Name(step))
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, wanted %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResumableStepsRecordCompletion(t *testing.T) {
+	ctx := context.Background()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
+
+	for _, tt := range []struct {
+		name        string
+		steps       func(*manager) []steps.Step
+		wantInstall func(*manager) *api.Install
+	}{
+		{
+			name: "completed steps are recorded until the failed step",
+			steps: func(m *manager) []steps.Step {
+				return []steps.Step{
+					steps.Action(successfulActionStep),
+					steps.Condition(successfulConditionStep, time.Minute, true),
+					steps.Action(failingFunc),
+				}
+			},
+			wantInstall: func(m *manager) *api.Install {
+				return &api.Install{
+					Phase: api.InstallPhaseBootstrap,
+					CompletedSteps: []string{
+						steps.Name(steps.Action(successfulActionStep)),
+						steps.Name(steps.Condition(successfulConditionStep, time.Minute, true)),
+					},
+					Resumable: true,
+				}
+			},
+		},
+		{
+			name: "completed steps are not recorded in the next phase",
+			steps: func(m *manager) []steps.Step {
+				return []steps.Step{
+					steps.Action(successfulActionStep),
+					steps.Action(m.incrInstallPhase),
+					steps.Action(failingFunc),
+				}
+			},
+			wantInstall: func(m *manager) *api.Install {
+				return &api.Install{
+					Phase:     api.InstallPhaseRemoveBootstrap,
+					Resumable: true,
+				}
+			},
+		},
+		{
+			name: "permanent failure is not resumable",
+			steps: func(m *manager) []steps.Step {
+				return []steps.Step{
+					steps.Action(func(context.Context) error {
+						return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedVNet, "", "The provided vnet is invalid.")
+					}),
+				}
+			},
+			wantInstall: func(m *manager) *api.Install {
+				return &api.Install{
+					Phase: api.InstallPhaseBootstrap,
+				}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, log := testlog.New()
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(key),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: key,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateCreating,
+						Install:           &api.Install{},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := openShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log: log,
+				doc: doc,
+				db:  openShiftClustersDatabase,
+			}
+
+			_, err = steps.Run(ctx, log, time.Millisecond, m.resumableSteps(api.InstallPhaseBootstrap, tt.steps(m)), nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			m.markInstallFailed(ctx, err)
+
+			doc, err = openShiftClustersDatabase.Get(ctx, strings.ToLower(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the install time is not under test
+			doc.OpenShiftCluster.Properties.Install.Now = time.Time{}
+
+			if !reflect.DeepEqual(doc.OpenShiftCluster.Properties.Install, tt.wantInstall(m)) {
+				t.Errorf("got install %#v", doc.OpenShiftCluster.Properties.Install)
+			}
+		})
+	}
+}
+
+func TestIsTransientInstallError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("oh no!"),
+			want: true,
+		},
+		{
+			name: "internal server error",
+			err:  api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeDeploymentFailed, "", "Timed out waiting for a condition."),
+			want: true,
+		},
+		{
+			name: "bad request",
+			err:  api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidServicePrincipalCredentials, "", "The provided service principal credentials are invalid."),
+		},
+		{
+			name: "wrapped bad request",
+			err:  fmt.Errorf("step failed: %w", api.NewCloudError(http.StatusForbidden, api.CloudErrorCodeInvalidResourceProviderPermissions, "", "The resource provider does not have enough permissions.")),
+		},
+		{
+			name: "azure throttling",
+			err:  autorest.DetailedError{StatusCode: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			name: "azure conflict",
+			err:  autorest.DetailedError{StatusCode: http.StatusConflict},
+			want: true,
+		},
+		{
+			name: "azure not found",
+			err:  autorest.DetailedError{StatusCode: http.StatusNotFound},
+		},
+		{
+			name: "azure error without a status code",
+			err:  autorest.DetailedError{},
+			want: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientInstallError(tt.err); got != tt.want {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
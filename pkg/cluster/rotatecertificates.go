@@ -0,0 +1,512 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/proxy"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	"github.com/Azure/ARO-RP/pkg/util/restconfig"
+)
+
+// certificateRotationTimeout is how long we wait for a rotated certificate to
+// be served before the rotation is rolled back
+var certificateRotationTimeout = 20 * time.Minute
+
+var certificateRotationInterval = 10 * time.Second
+
+// certificateRotationRollbackTimeout bounds the rollback of a failed rotation
+var certificateRotationRollbackTimeout = 5 * time.Minute
+
+// apiServerServingCertificate returns the leaf certificate which the API
+// server serves for serverName
+var apiServerServingCertificate = func(ctx context.Context, dialer proxy.Dialer, oc *api.OpenShiftCluster, serverName string) (*x509.Certificate, error) {
+	conn, err := restconfig.DialContext(dialer, oc)(ctx, "tcp", serverName+":6443")
+	if err != nil {
+		return nil, err
+	}
+
+	// we only compare the certificate which is served, so it is not verified
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true, // #nosec G402
+	})
+	defer tlsConn.Close()
+
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate was served")
+	}
+
+	return certs[0], nil
+}
+
+// certificateRotation is a certificate being rotated.  rollback is set once
+// the cluster has been switched to the new certificate.
+type certificateRotation struct {
+	target    api.CertificateTarget
+	dnsName   string
+	namespace string
+
+	key   *rsa.PrivateKey
+	certs []*x509.Certificate
+
+	// secretPrefix is the prefix of the names of the secrets created by
+	// rotations of the certificate
+	secretPrefix       string
+	secretName         string
+	secretCreated      bool
+	previousSecretName string
+	rollback           func(context.Context) error
+}
+
+// rotateCertificates installs new custom domain certificates for the API
+// server and/or the ingress.  The certificates are validated, stored in new
+// secrets and the cluster is switched to them.  Once all of them are served
+// the rotation is recorded in the cluster document; if any of them is not,
+// the cluster is switched back to the previous certificates.  It only runs
+// when requested by the RotateCertificates maintenance task.
+func (m *manager) rotateCertificates(ctx context.Context) error {
+	params := m.doc.OpenShiftCluster.Properties.MaintenanceTaskParameters
+	if params == nil || (params.APIServerCertificate == "" && params.IngressCertificate == "") {
+		return errors.New("no certificate to rotate was given")
+	}
+	apiServerCertificate, ingressCertificate := string(params.APIServerCertificate), string(params.IngressCertificate)
+
+	// the parameters are consumed, so that the task isn't repeated by a later
+	// admin update and the private keys aren't kept in the document
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MaintenanceTaskParameters = nil
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	domain := m.doc.OpenShiftCluster.Properties.ClusterProfile.Domain
+	managedDomain, err := dns.ManagedDomain(m.env, domain)
+	if err != nil {
+		return err
+	}
+	if managedDomain != "" {
+		return errors.New("certificates can only be rotated on clusters with a custom domain, the certificates of managed domains are rotated automatically")
+	}
+
+	now := m.now().UTC()
+
+	var rotations []*certificateRotation
+	for _, c := range []struct {
+		target    api.CertificateTarget
+		param     string
+		bundle    string
+		dnsName   string
+		namespace string
+		prefix    string
+	}{
+		{
+			target:    api.CertificateTargetAPIServer,
+			param:     "apiServerCertificate",
+			bundle:    apiServerCertificate,
+			dnsName:   "api." + domain,
+			namespace: "openshift-config",
+			prefix:    m.doc.ID + "-apiserver-",
+		},
+		{
+			target:    api.CertificateTargetIngress,
+			param:     "ingressCertificate",
+			bundle:    ingressCertificate,
+			dnsName:   "*.apps." + domain,
+			namespace: "openshift-ingress",
+			prefix:    m.doc.ID + "-ingress-",
+		},
+	} {
+		if c.bundle == "" {
+			continue
+		}
+
+		key, certs, err := validateRotatedCertificate(c.bundle, c.dnsName, now)
+		if err != nil {
+			return fmt.Errorf("the %s is invalid: %v", c.param, err)
+		}
+
+		rotations = append(rotations, &certificateRotation{
+			target:       c.target,
+			dnsName:      c.dnsName,
+			namespace:    c.namespace,
+			key:          key,
+			certs:        certs,
+			secretPrefix: c.prefix,
+			secretName:   c.prefix + now.Format("20060102-150405"),
+		})
+	}
+
+	err = m.switchCertificates(ctx, rotations)
+	if err != nil {
+		// roll back on a fresh context, since ctx may be what failed the
+		// rotation
+		m.log.Errorf("certificate rotation failed, rolling back: %v", err)
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), certificateRotationRollbackTimeout)
+		defer cancel()
+
+		rollbackErr := m.rollbackCertificateRotations(rollbackCtx, rotations)
+		if rollbackErr != nil {
+			return fmt.Errorf("certificate rotation failed: %v; additionally failed to roll back to the previous certificates: %v", err, rollbackErr)
+		}
+
+		return fmt.Errorf("certificate rotation failed, the previous certificates were restored: %v", err)
+	}
+
+	var records []api.CertificateRotation
+	for _, rotation := range rotations {
+		records = append(records, api.CertificateRotation{
+			Target:     rotation.target,
+			SecretName: rotation.secretName,
+			RotatedAt:  now,
+			NotAfter:   rotation.certs[0].NotAfter,
+		})
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.CertificateRotations = mergeCertificateRotations(doc.OpenShiftCluster.Properties.CertificateRotations, records)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the secrets of previous rotations are no longer used; secrets created
+	// by the customer are left alone
+	for _, rotation := range rotations {
+		if !strings.HasPrefix(rotation.previousSecretName, rotation.secretPrefix) {
+			continue
+		}
+
+		m.log.Infof("deleting secret %s/%s", rotation.namespace, rotation.previousSecretName)
+		err = m.kubernetescli.CoreV1().Secrets(rotation.namespace).Delete(ctx, rotation.previousSecretName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			m.log.Warnf("failed to delete secret %s/%s: %v", rotation.namespace, rotation.previousSecretName, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRotatedCertificate parses the PEM bundle of a rotated certificate
+// and checks that the private key matches the leaf certificate, that each
+// certificate of the chain is signed by the next one, that the chain is valid
+// now and that the leaf certificate is valid for dnsName.  A wildcard dnsName
+// must be matched exactly by a subject alternative name of the certificate.
+func validateRotatedCertificate(bundle, dnsName string, now time.Time) (*rsa.PrivateKey, []*x509.Certificate, error) {
+	key, certs, err := utilpem.Parse([]byte(bundle))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if key == nil || len(certs) == 0 {
+		return nil, nil, errors.New("the bundle must contain a private key and a certificate chain")
+	}
+
+	if !key.PublicKey.Equal(certs[0].PublicKey) {
+		return nil, nil, errors.New("the private key does not match the certificate")
+	}
+
+	for i, cert := range certs {
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return nil, nil, fmt.Errorf("certificate %d of the chain is not valid at the current time", i)
+		}
+
+		if i+1 < len(certs) {
+			err = cert.CheckSignatureFrom(certs[i+1])
+			if err != nil {
+				return nil, nil, fmt.Errorf("certificate %d of the chain is not signed by certificate %d", i, i+1)
+			}
+		}
+	}
+
+	err = certs[0].VerifyHostname(dnsName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the certificate is not valid for %s", dnsName)
+	}
+
+	return key, certs, nil
+}
+
+// mergeCertificateRotations replaces the records of the rotated certificates
+func mergeCertificateRotations(existing, records []api.CertificateRotation) []api.CertificateRotation {
+	merged := make([]api.CertificateRotation, 0, len(existing)+len(records))
+	for _, e := range existing {
+		replaced := false
+		for _, r := range records {
+			if e.Target == r.Target {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, e)
+		}
+	}
+
+	return append(merged, records...)
+}
+
+// switchCertificates switches the cluster to each of the new certificates in
+// turn and waits for them to be served
+func (m *manager) switchCertificates(ctx context.Context, rotations []*certificateRotation) error {
+	for _, rotation := range rotations {
+		m.log.Infof("rotating the %s certificate to secret %s/%s", rotation.target, rotation.namespace, rotation.secretName)
+
+		err := m.createCertificateSecret(ctx, rotation)
+		if err != nil {
+			return err
+		}
+		rotation.secretCreated = true
+
+		var served func(context.Context) (bool, error)
+		switch rotation.target {
+		case api.CertificateTargetAPIServer:
+			err = m.switchAPIServerCertificate(ctx, rotation)
+			served = func(ctx context.Context) (bool, error) {
+				cert, err := apiServerServingCertificate(ctx, m.env, m.doc.OpenShiftCluster, rotation.dnsName)
+				if err != nil {
+					m.log.Info(err)
+					return false, nil
+				}
+				return bytes.Equal(cert.Raw, rotation.certs[0].Raw), nil
+			}
+
+		case api.CertificateTargetIngress:
+			err = m.switchIngressCertificate(ctx, rotation)
+			served = func(ctx context.Context) (bool, error) {
+				return m.routerRolledOut(ctx, rotation.secretName)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, certificateRotationTimeout)
+		err = wait.PollImmediateUntil(certificateRotationInterval, func() (bool, error) {
+			return served(timeoutCtx)
+		}, timeoutCtx.Done())
+		cancel()
+		if err == wait.ErrWaitTimeout {
+			err = fmt.Errorf("timed out waiting for the %s certificate to be served", rotation.target)
+		}
+		if err != nil {
+			return err
+		}
+
+		m.log.Infof("the %s certificate is served", rotation.target)
+	}
+
+	return nil
+}
+
+// rollbackCertificateRotations switches the cluster back to the previous
+// certificates, in the reverse order of the rotation, and deletes the new
+// secrets
+func (m *manager) rollbackCertificateRotations(ctx context.Context, rotations []*certificateRotation) error {
+	for i := len(rotations) - 1; i >= 0; i-- {
+		rotation := rotations[i]
+
+		if rotation.rollback != nil {
+			m.log.Infof("restoring the previous %s certificate", rotation.target)
+			err := rotation.rollback(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rotation.secretCreated {
+			err := m.kubernetescli.CoreV1().Secrets(rotation.namespace).Delete(ctx, rotation.secretName, metav1.DeleteOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *manager) createCertificateSecret(ctx context.Context, rotation *certificateRotation) error {
+	cb, err := utilpem.Encode(rotation.certs...)
+	if err != nil {
+		return err
+	}
+
+	kb, err := utilpem.Encode(rotation.key)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.kubernetescli.CoreV1().Secrets(rotation.namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rotation.secretName,
+			Namespace: rotation.namespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cb,
+			corev1.TLSPrivateKeyKey: kb,
+		},
+		Type: corev1.SecretTypeTLS,
+	}, metav1.CreateOptions{})
+	return err
+}
+
+// switchAPIServerCertificate replaces the named certificate of the API server
+// for the API hostname
+func (m *manager) switchAPIServerCertificate(ctx context.Context, rotation *certificateRotation) error {
+	var previous []configv1.APIServerNamedServingCert
+
+	err := m.updateAPIServer(ctx, func(apiserver *configv1.APIServer) {
+		previous = apiserver.Spec.ServingCerts.NamedCertificates
+
+		namedCertificates := []configv1.APIServerNamedServingCert{}
+		for _, nc := range previous {
+			if hasName(nc.Names, rotation.dnsName) {
+				rotation.previousSecretName = nc.ServingCertificate.Name
+				continue
+			}
+			namedCertificates = append(namedCertificates, nc)
+		}
+
+		apiserver.Spec.ServingCerts.NamedCertificates = append(namedCertificates, configv1.APIServerNamedServingCert{
+			Names: []string{rotation.dnsName},
+			ServingCertificate: configv1.SecretNameReference{
+				Name: rotation.secretName,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	rotation.rollback = func(ctx context.Context) error {
+		return m.updateAPIServer(ctx, func(apiserver *configv1.APIServer) {
+			apiserver.Spec.ServingCerts.NamedCertificates = previous
+		})
+	}
+
+	return nil
+}
+
+// switchIngressCertificate replaces the default certificate of the default
+// ingress controller
+func (m *manager) switchIngressCertificate(ctx context.Context, rotation *certificateRotation) error {
+	var previous *corev1.LocalObjectReference
+
+	err := m.updateIngressController(ctx, func(ic *operatorv1.IngressController) {
+		previous = ic.Spec.DefaultCertificate
+		if previous != nil {
+			rotation.previousSecretName = previous.Name
+		}
+
+		ic.Spec.DefaultCertificate = &corev1.LocalObjectReference{
+			Name: rotation.secretName,
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	rotation.rollback = func(ctx context.Context) error {
+		return m.updateIngressController(ctx, func(ic *operatorv1.IngressController) {
+			ic.Spec.DefaultCertificate = previous
+		})
+	}
+
+	return nil
+}
+
+// routerRolledOut returns true once all the routers of the default ingress
+// controller mount secretName.  The RP cannot reach the ingress, so this is
+// how we know that the new certificate is served.
+func (m *manager) routerRolledOut(ctx context.Context, secretName string) (bool, error) {
+	deployment, err := m.kubernetescli.AppsV1().Deployments("openshift-ingress").Get(ctx, "router-default", metav1.GetOptions{})
+	if err != nil {
+		m.log.Info(err)
+		return false, nil
+	}
+
+	mounted := false
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		if v.Secret != nil && v.Secret.SecretName == secretName {
+			mounted = true
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return mounted &&
+		deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas, nil
+}
+
+func (m *manager) updateAPIServer(ctx context.Context, mutate func(*configv1.APIServer)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		apiserver, err := m.configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		mutate(apiserver)
+
+		_, err = m.configcli.ConfigV1().APIServers().Update(ctx, apiserver, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (m *manager) updateIngressController(ctx context.Context, mutate func(*operatorv1.IngressController)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ic, err := m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		mutate(ic)
+
+		_, err = m.operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Update(ctx, ic, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func hasName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
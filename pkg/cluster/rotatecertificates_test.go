@@ -0,0 +1,473 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	operatorfake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/proxy"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utiltls "github.com/Azure/ARO-RP/pkg/util/tls"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func testCertificateBundle(t *testing.T, dnsName string) (string, *x509.Certificate) {
+	caKey, caCerts, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, certs, err := utiltls.GenerateKeyAndCertificate(dnsName, caKey, caCerts[0], false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodeTestCertificateBundle(t, key, certs[0], caCerts[0]), certs[0]
+}
+
+func encodeTestCertificateBundle(t *testing.T, key *rsa.PrivateKey, certs ...*x509.Certificate) string {
+	var kb []byte
+	if key != nil {
+		var err error
+		kb, err = utilpem.Encode(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cb, err := utilpem.Encode(certs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(kb) + string(cb)
+}
+
+func TestValidateRotatedCertificate(t *testing.T) {
+	// the test certificates are valid from the time they are generated
+	now := time.Now().Add(time.Minute)
+
+	caKey, caCerts, err := utiltls.GenerateKeyAndCertificate("ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherCAKey, otherCACerts, err := utiltls.GenerateKeyAndCertificate("other-ca", nil, nil, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, certs, err := utiltls.GenerateKeyAndCertificate("api.example.com", caKey, caCerts[0], false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wildcardKey, wildcardCerts, err := utiltls.GenerateKeyAndCertificate("*.apps.example.com", caKey, caCerts[0], false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		bundle  string
+		dnsName string
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			bundle:  encodeTestCertificateBundle(t, key, certs[0], caCerts[0]),
+			dnsName: "api.example.com",
+		},
+		{
+			name:    "valid wildcard",
+			bundle:  encodeTestCertificateBundle(t, wildcardKey, wildcardCerts[0], caCerts[0]),
+			dnsName: "*.apps.example.com",
+		},
+		{
+			name:    "invalid PEM",
+			bundle:  "-----BEGIN CERTIFICATE-----\nZm9v\n-----END CERTIFICATE-----\n",
+			dnsName: "api.example.com",
+			wantErr: "x509: malformed certificate",
+		},
+		{
+			name:    "missing private key",
+			bundle:  encodeTestCertificateBundle(t, nil, certs[0], caCerts[0]),
+			dnsName: "api.example.com",
+			wantErr: "the bundle must contain a private key and a certificate chain",
+		},
+		{
+			name:    "private key does not match",
+			bundle:  encodeTestCertificateBundle(t, caKey, certs[0], caCerts[0]),
+			dnsName: "api.example.com",
+			wantErr: "the private key does not match the certificate",
+		},
+		{
+			name:    "broken chain",
+			bundle:  encodeTestCertificateBundle(t, key, certs[0], otherCACerts[0]),
+			dnsName: "api.example.com",
+			wantErr: "certificate 0 of the chain is not signed by certificate 1",
+		},
+		{
+			name:    "hostname not covered",
+			bundle:  encodeTestCertificateBundle(t, key, certs[0], caCerts[0]),
+			dnsName: "api.other.com",
+			wantErr: "the certificate is not valid for api.other.com",
+		},
+		{
+			name:    "wildcard must match exactly",
+			bundle:  encodeTestCertificateBundle(t, key, certs[0], caCerts[0]),
+			dnsName: "*.apps.example.com",
+			wantErr: "the certificate is not valid for *.apps.example.com",
+		},
+		{
+			name: "expired",
+			bundle: func() string {
+				key, certs, err := utiltls.GenerateTestKeyAndCertificate("api.example.com", otherCAKey, otherCACerts[0], false, false, func(template *x509.Certificate) {
+					template.NotBefore = now.Add(-48 * time.Hour)
+					template.NotAfter = now.Add(-24 * time.Hour)
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return encodeTestCertificateBundle(t, key, certs[0], otherCACerts[0])
+			}(),
+			dnsName: "api.example.com",
+			wantErr: "certificate 0 of the chain is not valid at the current time",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := validateRotatedCertificate(tt.bundle, tt.dnsName, now)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMergeCertificateRotations(t *testing.T) {
+	got := mergeCertificateRotations([]api.CertificateRotation{
+		{Target: api.CertificateTargetAPIServer, SecretName: "old-apiserver"},
+		{Target: api.CertificateTargetIngress, SecretName: "old-ingress"},
+	}, []api.CertificateRotation{
+		{Target: api.CertificateTargetAPIServer, SecretName: "new-apiserver"},
+	})
+
+	if len(got) != 2 || got[0].SecretName != "old-ingress" || got[1].SecretName != "new-apiserver" {
+		t.Errorf("unexpected rotations %#v", got)
+	}
+}
+
+func TestRotateCertificates(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+	docID := "00000000-0000-0000-0000-000000000001"
+	now := time.Now().UTC().Add(time.Minute).Truncate(time.Second)
+	suffix := now.Format("20060102-150405")
+
+	apiServerBundle, apiServerCert := testCertificateBundle(t, "api.example.com")
+	ingressBundle, ingressCert := testCertificateBundle(t, "*.apps.example.com")
+
+	previousRotations := []api.CertificateRotation{{Target: api.CertificateTargetIngress, SecretName: "old-ingress"}}
+
+	replicas := int32(2)
+	routerDeployment := func(updatedReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-ingress", Name: "router-default", Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "default-certificate",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{SecretName: docID + "-ingress-" + suffix},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           replicas,
+				UpdatedReplicas:    updatedReplicas,
+				AvailableReplicas:  replicas,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		domain         string
+		params         *api.MaintenanceTaskParameters
+		servedCert     *x509.Certificate
+		routerRollsOut bool
+		wantErr        string
+		wantRotations  []api.CertificateRotation
+		check          func(*testing.T, *fake.Clientset, *configfake.Clientset, *operatorfake.Clientset)
+	}{
+		{
+			name:    "no parameters",
+			domain:  "example.com",
+			wantErr: "no certificate to rotate was given",
+		},
+		{
+			name:    "managed domain",
+			domain:  "mycluster",
+			params:  &api.MaintenanceTaskParameters{APIServerCertificate: api.SecureString(apiServerBundle)},
+			wantErr: "certificates can only be rotated on clusters with a custom domain, the certificates of managed domains are rotated automatically",
+		},
+		{
+			name:    "invalid certificate",
+			domain:  "example.com",
+			params:  &api.MaintenanceTaskParameters{APIServerCertificate: api.SecureString(apiServerBundle), IngressCertificate: api.SecureString(apiServerBundle)},
+			wantErr: "the ingressCertificate is invalid: the certificate is not valid for *.apps.example.com",
+		},
+		{
+			name:           "certificates are rotated",
+			domain:         "example.com",
+			params:         &api.MaintenanceTaskParameters{APIServerCertificate: api.SecureString(apiServerBundle), IngressCertificate: api.SecureString(ingressBundle)},
+			servedCert:     apiServerCert,
+			routerRollsOut: true,
+			wantRotations: []api.CertificateRotation{
+				{
+					Target:     api.CertificateTargetAPIServer,
+					SecretName: docID + "-apiserver-" + suffix,
+					RotatedAt:  now,
+					NotAfter:   apiServerCert.NotAfter,
+				},
+				{
+					Target:     api.CertificateTargetIngress,
+					SecretName: docID + "-ingress-" + suffix,
+					RotatedAt:  now,
+					NotAfter:   ingressCert.NotAfter,
+				},
+			},
+			check: func(t *testing.T, kubernetescli *fake.Clientset, configcli *configfake.Clientset, operatorcli *operatorfake.Clientset) {
+				apiserver, err := configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				named := apiserver.Spec.ServingCerts.NamedCertificates
+				if len(named) != 2 || named[0].ServingCertificate.Name != "internal" || named[1].ServingCertificate.Name != docID+"-apiserver-"+suffix {
+					t.Errorf("unexpected named certificates %#v", named)
+				}
+
+				ic, err := operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ic.Spec.DefaultCertificate.Name != docID+"-ingress-"+suffix {
+					t.Errorf("unexpected default certificate %s", ic.Spec.DefaultCertificate.Name)
+				}
+
+				secret, err := kubernetescli.CoreV1().Secrets("openshift-config").Get(ctx, docID+"-apiserver-"+suffix, metav1.GetOptions{})
+				if err != nil || secret.Type != corev1.SecretTypeTLS {
+					t.Error("new API server secret was not created")
+				}
+				_, err = kubernetescli.CoreV1().Secrets("openshift-config").Get(ctx, docID+"-apiserver-20230101-000000", metav1.GetOptions{})
+				if err == nil {
+					t.Error("previous API server secret was not deleted")
+				}
+				_, err = kubernetescli.CoreV1().Secrets("openshift-ingress").Get(ctx, "customer-ingress", metav1.GetOptions{})
+				if err != nil {
+					t.Error("customer ingress secret was deleted")
+				}
+			},
+		},
+		{
+			name:       "rotation is rolled back if the ingress certificate is not served",
+			domain:     "example.com",
+			params:     &api.MaintenanceTaskParameters{APIServerCertificate: api.SecureString(apiServerBundle), IngressCertificate: api.SecureString(ingressBundle)},
+			servedCert: apiServerCert,
+			wantErr:    "certificate rotation failed, the previous certificates were restored: timed out waiting for the Ingress certificate to be served",
+			check: func(t *testing.T, kubernetescli *fake.Clientset, configcli *configfake.Clientset, operatorcli *operatorfake.Clientset) {
+				apiserver, err := configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				named := apiserver.Spec.ServingCerts.NamedCertificates
+				if len(named) != 2 || named[0].ServingCertificate.Name != docID+"-apiserver-20230101-000000" {
+					t.Errorf("unexpected named certificates %#v", named)
+				}
+
+				ic, err := operatorcli.OperatorV1().IngressControllers("openshift-ingress-operator").Get(ctx, "default", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ic.Spec.DefaultCertificate.Name != "customer-ingress" {
+					t.Errorf("unexpected default certificate %s", ic.Spec.DefaultCertificate.Name)
+				}
+
+				_, err = kubernetescli.CoreV1().Secrets("openshift-config").Get(ctx, docID+"-apiserver-"+suffix, metav1.GetOptions{})
+				if err == nil {
+					t.Error("new API server secret was not deleted")
+				}
+				_, err = kubernetescli.CoreV1().Secrets("openshift-ingress").Get(ctx, docID+"-ingress-"+suffix, metav1.GetOptions{})
+				if err == nil {
+					t.Error("new ingress secret was not deleted")
+				}
+				_, err = kubernetescli.CoreV1().Secrets("openshift-config").Get(ctx, docID+"-apiserver-20230101-000000", metav1.GetOptions{})
+				if err != nil {
+					t.Error("previous API server secret was deleted")
+				}
+			},
+		},
+		{
+			name:       "rotation is rolled back if the API server certificate is not served",
+			domain:     "example.com",
+			params:     &api.MaintenanceTaskParameters{APIServerCertificate: api.SecureString(apiServerBundle)},
+			servedCert: ingressCert,
+			wantErr:    "certificate rotation failed, the previous certificates were restored: timed out waiting for the APIServer certificate to be served",
+			check: func(t *testing.T, kubernetescli *fake.Clientset, configcli *configfake.Clientset, operatorcli *operatorfake.Clientset) {
+				apiserver, err := configcli.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				named := apiserver.Spec.ServingCerts.NamedCertificates
+				if len(named) != 2 || named[0].ServingCertificate.Name != docID+"-apiserver-20230101-000000" {
+					t.Errorf("unexpected named certificates %#v", named)
+				}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().Domain().AnyTimes().Return("location.aroapp.io")
+
+			oldTimeout, oldInterval, oldServingCertificate := certificateRotationTimeout, certificateRotationInterval, apiServerServingCertificate
+			defer func() {
+				certificateRotationTimeout, certificateRotationInterval, apiServerServingCertificate = oldTimeout, oldInterval, oldServingCertificate
+			}()
+			certificateRotationTimeout, certificateRotationInterval = 50*time.Millisecond, time.Millisecond
+			apiServerServingCertificate = func(ctx context.Context, dialer proxy.Dialer, oc *api.OpenShiftCluster, serverName string) (*x509.Certificate, error) {
+				return tt.servedCert, nil
+			}
+
+			routerUpdatedReplicas := int32(1)
+			if tt.routerRollsOut {
+				routerUpdatedReplicas = replicas
+			}
+
+			kubernetescli := fake.NewSimpleClientset(
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-config", Name: docID + "-apiserver-20230101-000000"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-ingress", Name: "customer-ingress"}},
+				routerDeployment(routerUpdatedReplicas),
+			)
+			configcli := configfake.NewSimpleClientset(&configv1.APIServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: configv1.APIServerSpec{
+					ServingCerts: configv1.APIServerServingCerts{
+						NamedCertificates: []configv1.APIServerNamedServingCert{
+							{
+								Names:              []string{"api.example.com"},
+								ServingCertificate: configv1.SecretNameReference{Name: docID + "-apiserver-20230101-000000"},
+							},
+							{
+								Names:              []string{"api.internal.example.com"},
+								ServingCertificate: configv1.SecretNameReference{Name: "internal"},
+							},
+						},
+					},
+				},
+			})
+			operatorcli := operatorfake.NewSimpleClientset(&operatorv1.IngressController{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-ingress-operator", Name: "default"},
+				Spec: operatorv1.IngressControllerSpec{
+					DefaultCertificate: &corev1.LocalObjectReference{Name: "customer-ingress"},
+				},
+			})
+
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				ID:  docID,
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState:         api.ProvisioningStateAdminUpdating,
+						MaintenanceTask:           api.MaintenanceTaskRotateCertificates,
+						MaintenanceTaskParameters: tt.params,
+						ClusterProfile: api.ClusterProfile{
+							Domain: tt.domain,
+						},
+						CertificateRotations: previousRotations,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:           logrus.NewEntry(logrus.StandardLogger()),
+				env:           _env,
+				doc:           clusterdoc,
+				db:            fakeOpenShiftClustersDatabase,
+				kubernetescli: kubernetescli,
+				configcli:     configcli,
+				operatorcli:   operatorcli,
+				now:           func() time.Time { return now },
+			}
+
+			err = m.rotateCertificates(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.OpenShiftCluster.Properties.MaintenanceTaskParameters != nil {
+				t.Error("maintenance task parameters were not cleared")
+			}
+
+			wantRotations := tt.wantRotations
+			if wantRotations == nil {
+				wantRotations = previousRotations
+			}
+			got := doc.OpenShiftCluster.Properties.CertificateRotations
+			if len(got) != len(wantRotations) {
+				t.Fatalf("got %d rotations, wanted %d", len(got), len(wantRotations))
+			}
+			for i := range got {
+				if got[i].Target != wantRotations[i].Target || got[i].SecretName != wantRotations[i].SecretName ||
+					!got[i].RotatedAt.Equal(wantRotations[i].RotatedAt) || !got[i].NotAfter.Equal(wantRotations[i].NotAfter) {
+					t.Errorf("unexpected rotation %d: %#v", i, got[i])
+				}
+			}
+
+			if tt.check != nil {
+				tt.check(t, kubernetescli, configcli, operatorcli)
+			}
+		})
+	}
+}
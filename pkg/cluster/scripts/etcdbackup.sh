@@ -0,0 +1,118 @@
+#!/bin/bash
+#
+# Backs up etcd to, and restores it from, a blob in the cluster storage account.
+# Runs in a privileged pod, chrooted into the host.
+#
+# See for more information: https://docs.openshift.com/container-platform/4.10/backup_and_restore/control_plane_backup_and_restore/disaster_recovery/scenario-2-restoring-cluster-state.html
+#
+# ACTION=backup  takes a backup with cluster-backup.sh, encrypts it with
+#                ENCRYPTION_KEY and uploads it to BLOB_URL.  The SHA-256 digest
+#                of the uploaded blob is printed as "sha256: <digest>".
+# ACTION=prepare downloads the backup from BLOB_URL, checks that its digest is
+#                SHA256, then decrypts and extracts it to RESTORE_DIR.
+# ACTION=stop    stops etcd and kube-apiserver on a master which isn't the
+#                recovery master, and moves its etcd data aside.
+# ACTION=restore restores the prepared backup in RESTORE_DIR with
+#                cluster-restore.sh on the recovery master.
+
+set -o pipefail
+
+abort() {
+    echo "${1}, Aborting."
+    exit 1
+}
+
+backup() {
+    local bdir
+    bdir="$(mktemp -d /var/tmp/etcd-backup.XXXXXX)" || abort "failed to make backup directory"
+    trap 'rm -rf "$bdir"' EXIT
+
+    echo "Taking etcd backup"
+    /usr/local/bin/cluster-backup.sh "$bdir/backup" || abort "cluster-backup.sh failed"
+
+    echo "Encrypting etcd backup"
+    tar -C "$bdir/backup" -czf - . | openssl enc -aes-256-cbc -pbkdf2 -salt -pass env:ENCRYPTION_KEY -out "$bdir/backup.enc" || abort "failed to encrypt backup"
+
+    echo "Uploading etcd backup"
+    curl --fail --silent --show-error -X PUT \
+        -H "x-ms-blob-type: BlockBlob" \
+        -H "x-ms-version: 2020-04-08" \
+        --upload-file "$bdir/backup.enc" \
+        "$BLOB_URL" || abort "failed to upload backup"
+
+    echo "sha256: $(sha256sum "$bdir/backup.enc" | cut -d' ' -f1)"
+}
+
+prepare() {
+    local bdir
+    bdir="$(mktemp -d /var/tmp/etcd-restore.XXXXXX)" || abort "failed to make download directory"
+    trap 'rm -rf "$bdir"' EXIT
+
+    echo "Downloading etcd backup"
+    curl --fail --silent --show-error \
+        -H "x-ms-version: 2020-04-08" \
+        -o "$bdir/backup.enc" \
+        "$BLOB_URL" || abort "failed to download backup"
+
+    echo "Checking etcd backup integrity"
+    echo "$SHA256  $bdir/backup.enc" | sha256sum --check --status || abort "backup digest does not match"
+
+    echo "Decrypting etcd backup to $RESTORE_DIR"
+    rm -rf "$RESTORE_DIR"
+    mkdir -p "$RESTORE_DIR" || abort "failed to make restore directory"
+    openssl enc -d -aes-256-cbc -pbkdf2 -pass env:ENCRYPTION_KEY -in "$bdir/backup.enc" | tar -C "$RESTORE_DIR" -xzf - || abort "failed to decrypt backup"
+
+    ls "$RESTORE_DIR"/snapshot_*.db "$RESTORE_DIR"/static_kuberesources_*.tar.gz || abort "backup is incomplete"
+}
+
+stop() {
+    local bdir manifest
+    bdir="/var/lib/etcd-restore-backup/$(date +%Y%m%d%H%M%S)"
+    mkdir -p "$bdir" || abort "failed to make backup directory"
+
+    for manifest in etcd-pod.yaml kube-apiserver-pod.yaml; do
+        if [[ -f /etc/kubernetes/manifests/$manifest ]]; then
+            echo "Moving /etc/kubernetes/manifests/$manifest to $bdir"
+            mv "/etc/kubernetes/manifests/$manifest" "$bdir" || abort "failed to move $manifest"
+        fi
+    done
+
+    echo "Waiting for etcd to stop"
+    for _ in $(seq 60); do
+        crictl ps --name '^etcd$' -q | grep -q . || break
+        sleep 5
+    done
+    crictl ps --name '^etcd$' -q | grep -q . && abort "etcd did not stop"
+
+    if [[ -d /var/lib/etcd ]]; then
+        echo "Moving /var/lib/etcd to $bdir"
+        mv /var/lib/etcd "$bdir/etcd" || abort "failed to move /var/lib/etcd"
+    fi
+}
+
+restore() {
+    echo "Restoring etcd backup from $RESTORE_DIR"
+    /usr/local/bin/cluster-restore.sh "$RESTORE_DIR" || abort "cluster-restore.sh failed"
+    rm -rf "$RESTORE_DIR"
+
+    echo "Restarting kubelet"
+    systemctl restart kubelet.service
+}
+
+case "$ACTION" in
+    backup)
+        backup
+        ;;
+    prepare)
+        prepare
+        ;;
+    stop)
+        stop
+        ;;
+    restore)
+        restore
+        ;;
+    *)
+        abort "unknown ACTION ${ACTION}, no actions taken"
+        ;;
+esac
@@ -59,6 +59,46 @@ func (m *manager) clusterSPObjectID(ctx context.Context) error {
 
 	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
 		doc.OpenShiftCluster.Properties.ServicePrincipalProfile.SPObjectID = *clusterSPObjectID
+		doc.OpenShiftCluster.Properties.ClusterIdentities = clusterIdentities(&doc.OpenShiftCluster.Properties.ServicePrincipalProfile)
+		return nil
+	})
+	return err
+}
+
+// clusterIdentities returns the Azure identities used by cluster components.
+// Today every component authenticates to Azure using the cluster service
+// principal.
+func clusterIdentities(spp *api.ServicePrincipalProfile) []api.ClusterIdentity {
+	var identities []api.ClusterIdentity
+
+	for _, component := range []api.ClusterIdentityComponent{
+		api.ClusterIdentityComponentCloudControllerManager,
+		api.ClusterIdentityComponentIngress,
+		api.ClusterIdentityComponentMachineAPI,
+		api.ClusterIdentityComponentImageRegistry,
+		api.ClusterIdentityComponentDiskCSIDriver,
+		api.ClusterIdentityComponentFileCSIDriver,
+	} {
+		identities = append(identities, api.ClusterIdentity{
+			Component: component,
+			ClientID:  spp.ClientID,
+			ObjectID:  spp.SPObjectID,
+		})
+	}
+
+	return identities
+}
+
+// fixupClusterIdentities populates the cluster identities of clusters which
+// were created before they were recorded.
+func (m *manager) fixupClusterIdentities(ctx context.Context) error {
+	if len(m.doc.OpenShiftCluster.Properties.ClusterIdentities) > 0 {
+		return nil
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.ClusterIdentities = clusterIdentities(&doc.OpenShiftCluster.Properties.ServicePrincipalProfile)
 		return nil
 	})
 	return err
@@ -66,7 +106,7 @@ func (m *manager) clusterSPObjectID(ctx context.Context) error {
 
 func (m *manager) fixupClusterSPObjectID(ctx context.Context) error {
 	if m.doc.OpenShiftCluster.Properties.ServicePrincipalProfile.SPObjectID != "" {
-		return nil
+		return m.fixupClusterIdentities(ctx)
 	}
 
 	err := m.initializeClusterSPClients(ctx)
@@ -0,0 +1,99 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestFixupClusterIdentities(t *testing.T) {
+	ctx := context.Background()
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/microsoft.redhatopenshift/openshiftclusters/resourceName"
+
+	existing := []api.ClusterIdentity{
+		{
+			Component: api.ClusterIdentityComponentIngress,
+			ClientID:  "oldClientID",
+			ObjectID:  "oldObjectID",
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		identities     []api.ClusterIdentity
+		wantIdentities []api.ClusterIdentity
+	}{
+		{
+			name: "identities are populated",
+			wantIdentities: []api.ClusterIdentity{
+				{Component: api.ClusterIdentityComponentCloudControllerManager, ClientID: "clientID", ObjectID: "objectID"},
+				{Component: api.ClusterIdentityComponentIngress, ClientID: "clientID", ObjectID: "objectID"},
+				{Component: api.ClusterIdentityComponentMachineAPI, ClientID: "clientID", ObjectID: "objectID"},
+				{Component: api.ClusterIdentityComponentImageRegistry, ClientID: "clientID", ObjectID: "objectID"},
+				{Component: api.ClusterIdentityComponentDiskCSIDriver, ClientID: "clientID", ObjectID: "objectID"},
+				{Component: api.ClusterIdentityComponentFileCSIDriver, ClientID: "clientID", ObjectID: "objectID"},
+			},
+		},
+		{
+			name:           "existing identities are left alone",
+			identities:     existing,
+			wantIdentities: existing,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeOpenShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(fakeOpenShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateAdminUpdating,
+						ServicePrincipalProfile: api.ServicePrincipalProfile{
+							ClientID:   "clientID",
+							SPObjectID: "objectID",
+						},
+						ClusterIdentities: tt.identities,
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clusterdoc, err := fakeOpenShiftClustersDatabase.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				doc: clusterdoc,
+				db:  fakeOpenShiftClustersDatabase,
+			}
+
+			err = m.fixupClusterSPObjectID(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := fakeOpenShiftClustersDatabase.Get(ctx, strings.ToLower(resourceID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.wantIdentities, doc.OpenShiftCluster.Properties.ClusterIdentities) {
+				t.Error(doc.OpenShiftCluster.Properties.ClusterIdentities)
+			}
+		})
+	}
+}
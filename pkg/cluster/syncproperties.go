@@ -0,0 +1,282 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilgraph "github.com/Azure/ARO-RP/pkg/util/graph"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// propertyCorrection is a derived property of the cluster document which does
+// not match the live state of the cluster
+type propertyCorrection struct {
+	field   string
+	current string
+	live    string
+	apply   func(*api.OpenShiftClusterDocument)
+}
+
+func correction(field, current, live string, apply func(*api.OpenShiftClusterDocument)) []propertyCorrection {
+	if current == live {
+		return nil
+	}
+
+	return []propertyCorrection{{field: field, current: current, live: live, apply: apply}}
+}
+
+// syncClusterProperties re-reads the live Azure resources and cluster objects
+// and corrects the derived properties of the cluster document which have
+// drifted from them, e.g. after out-of-band changes.  It only runs when
+// requested by the SyncClusterProperties maintenance task.
+func (m *manager) syncClusterProperties(ctx context.Context) error {
+	var corrections []propertyCorrection
+
+	for _, f := range []func(context.Context) ([]propertyCorrection, error){
+		m.syncAPIServerIPs,
+		m.syncIngressIP,
+		m.syncEffectiveOutboundIPs,
+		m.syncEffectiveOutboundProfile,
+		m.syncClusterVersion,
+		m.syncServicePrincipalObjectID,
+	} {
+		c, err := f(ctx)
+		if err != nil {
+			return err
+		}
+		corrections = append(corrections, c...)
+	}
+
+	return m.applyPropertyCorrections(ctx, corrections)
+}
+
+func (m *manager) applyPropertyCorrections(ctx context.Context, corrections []propertyCorrection) error {
+	if len(corrections) == 0 {
+		m.log.Info("cluster properties match the live state")
+		return nil
+	}
+
+	for _, c := range corrections {
+		m.log.Infof("correcting %s from '%s' to '%s'", c.field, c.current, c.live)
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		for _, c := range corrections {
+			c.apply(doc)
+		}
+		return nil
+	})
+	return err
+}
+
+func (m *manager) syncAPIServerIPs(ctx context.Context) ([]propertyCorrection, error) {
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	lbName, err := m.internalLoadBalancerName()
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := m.loadBalancers.Get(ctx, resourceGroup, lbName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil || len(*lb.FrontendIPConfigurations) == 0 ||
+		(*lb.FrontendIPConfigurations)[0].PrivateIPAddress == nil {
+		return nil, fmt.Errorf("load balancer %s has no private frontend IP address", lbName)
+	}
+	intIP := *(*lb.FrontendIPConfigurations)[0].PrivateIPAddress
+
+	ip := intIP
+	if m.doc.OpenShiftCluster.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		ip = m.doc.OpenShiftCluster.Properties.APIServerProfile.IP
+
+		// only architecture version 2 clusters created after the DNS change
+		// have a -pip-v4 public IP address; see updateAPIIPEarly
+		if m.doc.OpenShiftCluster.Properties.ArchitectureVersion != api.ArchitectureVersionV2 {
+			m.log.Info("skipping apiserverProfile.ip: not supported on architecture version 1 clusters")
+		} else {
+			pip, err := m.publicIPAddresses.Get(ctx, resourceGroup, m.doc.OpenShiftCluster.Properties.InfraID+"-pip-v4", "")
+			if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+				m.log.Info("skipping apiserverProfile.ip: public IP address not found")
+			} else if err != nil {
+				return nil, err
+			} else {
+				ip = *pip.IPAddress
+			}
+		}
+	}
+
+	return append(
+		correction("apiserverProfile.intIp", m.doc.OpenShiftCluster.Properties.APIServerProfile.IntIP, intIP, func(doc *api.OpenShiftClusterDocument) {
+			doc.OpenShiftCluster.Properties.APIServerProfile.IntIP = intIP
+		}),
+		correction("apiserverProfile.ip", m.doc.OpenShiftCluster.Properties.APIServerProfile.IP, ip, func(doc *api.OpenShiftClusterDocument) {
+			doc.OpenShiftCluster.Properties.APIServerProfile.IP = ip
+		})...,
+	), nil
+}
+
+func (m *manager) syncIngressIP(ctx context.Context) ([]propertyCorrection, error) {
+	if !m.isIngressProfileAvailable() {
+		return nil, nil
+	}
+
+	var ip string
+	if clb := m.doc.OpenShiftCluster.Properties.NetworkProfile.CustomerLoadBalancerProfile; clb != nil {
+		// the default ingress is served by the customer load balancer, not by
+		// the router-default service
+		var err error
+		ip, err = m.customerLoadBalancerFrontendIP(ctx, clb.IngressFrontendIPConfiguration)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		svc, err := m.kubernetescli.CoreV1().Services("openshift-ingress").Get(ctx, "router-default", metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(svc.Status.LoadBalancer.Ingress) == 0 {
+			return nil, fmt.Errorf("routerIP not found")
+		}
+		ip = svc.Status.LoadBalancer.Ingress[0].IP
+	}
+
+	for _, p := range m.doc.OpenShiftCluster.Properties.IngressProfiles {
+		if p.Name == "default" {
+			return correction("ingressProfiles['default'].ip", p.IP, ip, func(doc *api.OpenShiftClusterDocument) {
+				for i := range doc.OpenShiftCluster.Properties.IngressProfiles {
+					if doc.OpenShiftCluster.Properties.IngressProfiles[i].Name == "default" {
+						doc.OpenShiftCluster.Properties.IngressProfiles[i].IP = ip
+					}
+				}
+			}), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *manager) syncEffectiveOutboundIPs(ctx context.Context) ([]propertyCorrection, error) {
+	lbp := m.doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile
+	if m.doc.OpenShiftCluster.Properties.NetworkProfile.OutboundType != api.OutboundTypeLoadbalancer ||
+		m.doc.OpenShiftCluster.Properties.ArchitectureVersion == api.ArchitectureVersionV1 ||
+		lbp == nil {
+		return nil, nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	lb, err := m.loadBalancers.Get(ctx, resourceGroup, m.doc.OpenShiftCluster.Properties.InfraID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	outboundIPs := getOutboundIPsFromLB(lb)
+	if !needsEffectiveOutboundIPsPatched(lbp.EffectiveOutboundIPs, outboundIPs) {
+		return nil, nil
+	}
+
+	current := make([]string, 0, len(lbp.EffectiveOutboundIPs))
+	for _, ip := range lbp.EffectiveOutboundIPs {
+		current = append(current, ip.ID)
+	}
+
+	live := make([]string, 0, len(outboundIPs))
+	effectiveOutboundIPs := make([]api.EffectiveOutboundIP, 0, len(outboundIPs))
+	for _, ip := range outboundIPs {
+		live = append(live, ip.ID)
+		effectiveOutboundIPs = append(effectiveOutboundIPs, api.EffectiveOutboundIP(ip))
+	}
+
+	return []propertyCorrection{{
+		field:   "networkProfile.loadBalancerProfile.effectiveOutboundIps",
+		current: strings.Join(current, ","),
+		live:    strings.Join(live, ","),
+		apply: func(doc *api.OpenShiftClusterDocument) {
+			doc.OpenShiftCluster.Properties.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = effectiveOutboundIPs
+		},
+	}}, nil
+}
+
+func (m *manager) syncClusterVersion(ctx context.Context) ([]propertyCorrection, error) {
+	cv, err := m.configcli.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	version := cv.Status.Desired.Version
+
+	return correction("clusterProfile.version", m.doc.OpenShiftCluster.Properties.ClusterProfile.Version, version, func(doc *api.OpenShiftClusterDocument) {
+		doc.OpenShiftCluster.Properties.ClusterProfile.Version = version
+	}), nil
+}
+
+// syncServicePrincipalObjectID looks up the object ID of the cluster service
+// principal, which changes if the service principal is recreated with the
+// same client ID, and refreshes the cluster identities which are derived
+// from it.  The lookup uses the cluster service principal's own credentials,
+// so failures are logged rather than failing the sync.
+func (m *manager) syncServicePrincipalObjectID(ctx context.Context) ([]propertyCorrection, error) {
+	spp := m.doc.OpenShiftCluster.Properties.ServicePrincipalProfile
+
+	if m.spGraphClient == nil {
+		err := m.initializeClusterSPClients(ctx)
+		if err != nil {
+			m.log.Printf("skipping servicePrincipalProfile.spObjectId: %v", err)
+			return nil, nil
+		}
+	}
+
+	objectID, err := utilgraph.GetServicePrincipalIDByAppID(ctx, m.spGraphClient, spp.ClientID)
+	if err != nil || objectID == nil {
+		m.log.Printf("skipping servicePrincipalProfile.spObjectId: no service principal found for application ID '%s': %v", spp.ClientID, err)
+		return nil, nil
+	}
+
+	return identityCorrections(m.doc.OpenShiftCluster, *objectID), nil
+}
+
+func identityCorrections(oc *api.OpenShiftCluster, objectID string) []propertyCorrection {
+	spp := oc.Properties.ServicePrincipalProfile
+	spp.SPObjectID = objectID
+	identities := clusterIdentities(&spp)
+
+	corrections := correction("servicePrincipalProfile.spObjectId", oc.Properties.ServicePrincipalProfile.SPObjectID, objectID, func(doc *api.OpenShiftClusterDocument) {
+		doc.OpenShiftCluster.Properties.ServicePrincipalProfile.SPObjectID = objectID
+	})
+
+	if !reflect.DeepEqual(oc.Properties.ClusterIdentities, identities) {
+		corrections = append(corrections, propertyCorrection{
+			field:   "clusterIdentities",
+			current: formatClusterIdentities(oc.Properties.ClusterIdentities),
+			live:    formatClusterIdentities(identities),
+			apply: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Properties.ClusterIdentities = identities
+			},
+		})
+	}
+
+	return corrections
+}
+
+func formatClusterIdentities(identities []api.ClusterIdentity) string {
+	s := make([]string, 0, len(identities))
+	for _, identity := range identities {
+		s = append(s, fmt.Sprintf("%s=%s/%s", identity.Component, identity.ClientID, identity.ObjectID))
+	}
+	return strings.Join(s, ",")
+}
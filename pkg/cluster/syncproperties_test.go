@@ -0,0 +1,348 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestSyncAPIServerIPs(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name                string
+		architectureVersion api.ArchitectureVersion
+		visibility          api.Visibility
+		mocks               func(*mock_network.MockLoadBalancersClient, *mock_network.MockPublicIPAddressesClient)
+		wantFields          []string
+		wantErr             string
+	}{
+		{
+			name:                "in sync",
+			architectureVersion: api.ArchitectureVersionV2,
+			visibility:          api.VisibilityPublic,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(internalLB("10.0.0.1"), nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v4", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("1.2.3.4"),
+						},
+					}, nil)
+			},
+		},
+		{
+			name:                "drifted, public",
+			architectureVersion: api.ArchitectureVersionV2,
+			visibility:          api.VisibilityPublic,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(internalLB("10.0.0.2"), nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v4", "").
+					Return(mgmtnetwork.PublicIPAddress{
+						PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+							IPAddress: to.StringPtr("5.6.7.8"),
+						},
+					}, nil)
+			},
+			wantFields: []string{"apiserverProfile.intIp", "apiserverProfile.ip"},
+		},
+		{
+			name:                "drifted, private",
+			architectureVersion: api.ArchitectureVersionV2,
+			visibility:          api.VisibilityPrivate,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(internalLB("10.0.0.2"), nil)
+			},
+			wantFields: []string{"apiserverProfile.intIp", "apiserverProfile.ip"},
+		},
+		{
+			name:                "public IP address not found",
+			architectureVersion: api.ArchitectureVersionV2,
+			visibility:          api.VisibilityPublic,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(internalLB("10.0.0.2"), nil)
+				publicIPAddresses.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-pip-v4", "").
+					Return(mgmtnetwork.PublicIPAddress{}, autorest.DetailedError{StatusCode: http.StatusNotFound})
+			},
+			wantFields: []string{"apiserverProfile.intIp"},
+		},
+		{
+			name:                "architecture version 1",
+			architectureVersion: api.ArchitectureVersionV1,
+			visibility:          api.VisibilityPublic,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal-lb", "").
+					Return(internalLB("10.0.0.2"), nil)
+			},
+			wantFields: []string{"apiserverProfile.intIp"},
+		},
+		{
+			name:                "load balancer has no frontend",
+			architectureVersion: api.ArchitectureVersionV2,
+			visibility:          api.VisibilityPublic,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().
+					Get(gomock.Any(), "clusterResourceGroup", "infra-internal", "").
+					Return(mgmtnetwork.LoadBalancer{}, nil)
+			},
+			wantErr: "load balancer infra-internal has no private frontend IP address",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			loadBalancers := mock_network.NewMockLoadBalancersClient(controller)
+			publicIPAddresses := mock_network.NewMockPublicIPAddressesClient(controller)
+			tt.mocks(loadBalancers, publicIPAddresses)
+
+			ip := "1.2.3.4"
+			if tt.visibility == api.VisibilityPrivate {
+				ip = "10.0.0.1"
+			}
+
+			m := &manager{
+				log:               logrus.NewEntry(logrus.StandardLogger()),
+				loadBalancers:     loadBalancers,
+				publicIPAddresses: publicIPAddresses,
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ArchitectureVersion: tt.architectureVersion,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/clusterResourceGroup",
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: tt.visibility,
+								IP:         ip,
+								IntIP:      "10.0.0.1",
+							},
+							InfraID: "infra",
+						},
+					},
+				},
+			}
+
+			corrections, err := m.syncAPIServerIPs(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			var fields []string
+			for _, c := range corrections {
+				fields = append(fields, c.field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Error(fields)
+			}
+		})
+	}
+}
+
+func internalLB(ip string) mgmtnetwork.LoadBalancer {
+	return mgmtnetwork.LoadBalancer{
+		LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+				{
+					FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr(ip),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSyncIngressIPAndClusterVersion(t *testing.T) {
+	ctx := context.Background()
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		doc: &api.OpenShiftClusterDocument{
+			OpenShiftCluster: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Version: "4.11.0",
+					},
+					IngressProfiles: []api.IngressProfile{
+						{
+							Name: "default",
+							IP:   "1.2.3.4",
+						},
+					},
+				},
+			},
+		},
+		kubernetescli: fake.NewSimpleClientset(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "router-default",
+				Namespace: "openshift-ingress",
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{
+						IP: "5.6.7.8",
+					}},
+				},
+			},
+		}),
+		configcli: configfake.NewSimpleClientset(&configv1.ClusterVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "version",
+			},
+			Status: configv1.ClusterVersionStatus{
+				Desired: configv1.Release{
+					Version: "4.11.44",
+				},
+			},
+		}),
+	}
+
+	ingress, err := m.syncIngressIP(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version, err := m.syncClusterVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrections := append(ingress, version...)
+	if len(corrections) != 2 {
+		t.Fatal(len(corrections))
+	}
+
+	for _, c := range corrections {
+		c.apply(m.doc)
+	}
+
+	if m.doc.OpenShiftCluster.Properties.IngressProfiles[0].IP != "5.6.7.8" {
+		t.Error(m.doc.OpenShiftCluster.Properties.IngressProfiles[0].IP)
+	}
+	if m.doc.OpenShiftCluster.Properties.ClusterProfile.Version != "4.11.44" {
+		t.Error(m.doc.OpenShiftCluster.Properties.ClusterProfile.Version)
+	}
+}
+
+func TestIdentityCorrections(t *testing.T) {
+	spp := api.ServicePrincipalProfile{
+		ClientID:   "clientId",
+		SPObjectID: "oldObjectId",
+	}
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ServicePrincipalProfile: spp,
+			ClusterIdentities:       clusterIdentities(&spp),
+		},
+	}
+
+	if corrections := identityCorrections(oc, "oldObjectId"); len(corrections) != 0 {
+		t.Error(corrections)
+	}
+
+	corrections := identityCorrections(oc, "newObjectId")
+	if len(corrections) != 2 {
+		t.Fatal(len(corrections))
+	}
+
+	doc := &api.OpenShiftClusterDocument{OpenShiftCluster: oc}
+	for _, c := range corrections {
+		c.apply(doc)
+	}
+
+	if oc.Properties.ServicePrincipalProfile.SPObjectID != "newObjectId" {
+		t.Error(oc.Properties.ServicePrincipalProfile.SPObjectID)
+	}
+	for _, identity := range oc.Properties.ClusterIdentities {
+		if identity.ObjectID != "newObjectId" {
+			t.Error(identity)
+		}
+	}
+}
+
+func TestApplyPropertyCorrections(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
+
+	dbOpenShiftClusters, dbClient := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+	checker := testdatabase.NewChecker()
+
+	doc := &api.OpenShiftClusterDocument{
+		Key: strings.ToLower(key),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID: key,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateAdminUpdating,
+				ClusterProfile: api.ClusterProfile{
+					Version: "4.11.0",
+				},
+			},
+		},
+	}
+	fixture.AddOpenShiftClusterDocuments(doc)
+
+	doc.Dequeues = 1
+	doc.OpenShiftCluster.Properties.ClusterProfile.Version = "4.11.44"
+	checker.AddOpenShiftClusterDocuments(doc)
+
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manager{
+		log: logrus.NewEntry(logrus.StandardLogger()),
+		db:  dbOpenShiftClusters,
+	}
+
+	m.doc, err = dbOpenShiftClusters.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.applyPropertyCorrections(ctx, correction("clusterProfile.version", "4.11.0", "4.11.44", func(doc *api.OpenShiftClusterDocument) {
+		doc.OpenShiftCluster.Properties.ClusterProfile.Version = "4.11.44"
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err = range checker.CheckOpenShiftClusters(dbClient) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,140 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const tagDriftMetricName = "backend.openshiftcluster.tags.drift.corrected"
+
+// reconcileTags sets the tags of the cluster resource on the cluster resource
+// group.  The cluster resource is authoritative: its tags, which ARM gives the
+// RP with every PUT and PATCH of the cluster and are held in the document, are
+// restored on the resource group if they were removed or changed there, and
+// are removed from it once they are removed from the cluster.  Other tags on
+// the resource group, e.g. tags applied by Azure Policy, are left alone and
+// are never copied into the document.  A customer-provided resource group is
+// left alone: its tags belong to the customer.
+func (m *manager) reconcileTags(ctx context.Context) error {
+	if m.isExistingResourceGroup() {
+		m.log.Print("skipping tags: existing resource group")
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(m.doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	group, err := m.resourceGroups.Get(ctx, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	groupTags := map[string]string{}
+	for k, v := range group.Tags {
+		if v != nil {
+			groupTags[k] = *v
+		}
+	}
+
+	clusterTags := tagsOrEmpty(m.doc.OpenShiftCluster.Tags)
+	tags := convergeTags(clusterTags, groupTags, m.doc.OpenShiftCluster.Properties.SyncedTags)
+
+	if !reflect.DeepEqual(tags, groupTags) {
+		m.log.Printf("correcting resource group tags from %v to %v", groupTags, tags)
+
+		patch := mgmtfeatures.ResourceGroupPatchable{
+			Tags: map[string]*string{},
+		}
+		for k, v := range tags {
+			patch.Tags[k] = to.StringPtr(v)
+		}
+
+		_, err = m.resourceGroups.Update(ctx, resourceGroup, patch)
+		if err != nil {
+			return err
+		}
+
+		m.metricsEmitter.EmitGauge(tagDriftMetricName, 1, map[string]string{
+			"target": "resourceGroup",
+		})
+	}
+
+	if reflect.DeepEqual(clusterTags, tagsOrEmpty(m.doc.OpenShiftCluster.Properties.SyncedTags)) {
+		return nil
+	}
+
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.SyncedTags = clusterTags
+		return nil
+	})
+	return err
+}
+
+// updateOperatorResourceTags sets the cluster's tags required by the tag
+// policy of its subscription on the operator's Cluster resource, from which
+// the operator keeps them on the Azure resources of the cluster.  This lets a
+// changed tag value take effect without waiting for the next update of the
+// operator.
+func (m *manager) updateOperatorResourceTags(ctx context.Context) error {
+	spec := deploy.ClusterResourceTagsSpec(m.doc.OpenShiftCluster, m.subscriptionDoc.TagPolicy)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := m.arocli.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(cluster.Spec.ResourceTags, spec) {
+			return nil
+		}
+
+		cluster.Spec.ResourceTags = spec
+		_, err = m.arocli.AroV1alpha1().Clusters().Update(ctx, cluster, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// convergeTags returns the tags which the resource group should have, given
+// the tags of the cluster, the tags of the resource group and the cluster tags
+// as last set on the resource group:
+//   - a cluster tag is set on the resource group, overwriting the value on the
+//     resource group;
+//   - a tag which was last set from the cluster but is no longer on the
+//     cluster is removed from the resource group;
+//   - any other tag on the resource group is kept.
+func convergeTags(clusterTags, groupTags, syncedTags map[string]string) map[string]string {
+	tags := map[string]string{}
+
+	for k, v := range groupTags {
+		if _, ok := syncedTags[k]; ok {
+			continue
+		}
+
+		tags[k] = v
+	}
+
+	for k, v := range clusterTags {
+		tags[k] = v
+	}
+
+	return tags
+}
+
+func tagsOrEmpty(tags map[string]string) map[string]string {
+	if tags == nil {
+		return map[string]string{}
+	}
+	return tags
+}
@@ -0,0 +1,333 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	arofake "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned/fake"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestConvergeTags(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		clusterTags map[string]string
+		groupTags   map[string]string
+		syncedTags  map[string]string
+		want        map[string]string
+	}{
+		{
+			name: "no tags",
+			want: map[string]string{},
+		},
+		{
+			name:        "in sync",
+			clusterTags: map[string]string{"owner": "team"},
+			groupTags:   map[string]string{"owner": "team"},
+			syncedTags:  map[string]string{"owner": "team"},
+			want:        map[string]string{"owner": "team"},
+		},
+		{
+			name:        "cluster tag is set on the resource group",
+			clusterTags: map[string]string{"owner": "team", "costcenter": "1234"},
+			groupTags:   map[string]string{"owner": "team"},
+			syncedTags:  map[string]string{"owner": "team"},
+			want:        map[string]string{"owner": "team", "costcenter": "1234"},
+		},
+		{
+			name:        "cluster tag removed from the resource group is restored",
+			clusterTags: map[string]string{"owner": "team"},
+			syncedTags:  map[string]string{"owner": "team"},
+			want:        map[string]string{"owner": "team"},
+		},
+		{
+			name:        "cluster tag value overwrites the resource group",
+			clusterTags: map[string]string{"owner": "team"},
+			groupTags:   map[string]string{"owner": "other"},
+			syncedTags:  map[string]string{"owner": "team"},
+			want:        map[string]string{"owner": "team"},
+		},
+		{
+			name:        "other resource group tag is kept",
+			clusterTags: map[string]string{"owner": "team"},
+			groupTags:   map[string]string{"owner": "team", "policy": "applied"},
+			syncedTags:  map[string]string{"owner": "team"},
+			want:        map[string]string{"owner": "team", "policy": "applied"},
+		},
+		{
+			name:       "tag removed from the cluster is removed from the resource group",
+			groupTags:  map[string]string{"owner": "team", "policy": "applied"},
+			syncedTags: map[string]string{"owner": "team"},
+			want:       map[string]string{"policy": "applied"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convergeTags(tt.clusterTags, tt.groupTags, tt.syncedTags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(got)
+			}
+		})
+	}
+}
+
+func TestReconcileTags(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName1"
+
+	for _, tt := range []struct {
+		name        string
+		doc         func(*api.OpenShiftClusterDocument)
+		mocks       func(*mock_features.MockResourceGroupsClient)
+		wantDoc     func(*api.OpenShiftClusterDocument)
+		wantMetrics bool
+	}{
+		{
+			name: "in sync",
+			doc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"owner": "team"}
+			},
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), "cluster-rg").
+					Return(mgmtfeatures.ResourceGroup{
+						Tags: map[string]*string{"owner": to.StringPtr("team")},
+					}, nil)
+			},
+			wantDoc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"owner": "team"}
+			},
+		},
+		{
+			name: "resource group drift is corrected, the document is left alone",
+			doc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"owner": "team"}
+			},
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), "cluster-rg").
+					Return(mgmtfeatures.ResourceGroup{
+						Tags: map[string]*string{
+							"owner": to.StringPtr("other"),
+							"env":   to.StringPtr("prod"),
+						},
+					}, nil)
+				resourceGroups.EXPECT().
+					Update(gomock.Any(), "cluster-rg", mgmtfeatures.ResourceGroupPatchable{
+						Tags: map[string]*string{
+							"owner": to.StringPtr("team"),
+							"env":   to.StringPtr("prod"),
+						},
+					}).
+					Return(mgmtfeatures.ResourceGroup{}, nil)
+			},
+			wantDoc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"owner": "team"}
+			},
+			wantMetrics: true,
+		},
+		{
+			name: "cluster tag changes are applied and recorded",
+			doc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"costcenter": "1234"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"owner": "team"}
+			},
+			mocks: func(resourceGroups *mock_features.MockResourceGroupsClient) {
+				resourceGroups.EXPECT().
+					Get(gomock.Any(), "cluster-rg").
+					Return(mgmtfeatures.ResourceGroup{
+						Tags: map[string]*string{"owner": to.StringPtr("team")},
+					}, nil)
+				resourceGroups.EXPECT().
+					Update(gomock.Any(), "cluster-rg", mgmtfeatures.ResourceGroupPatchable{
+						Tags: map[string]*string{"costcenter": to.StringPtr("1234")},
+					}).
+					Return(mgmtfeatures.ResourceGroup{}, nil)
+			},
+			wantDoc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"costcenter": "1234"}
+				doc.OpenShiftCluster.Properties.SyncedTags = map[string]string{"costcenter": "1234"}
+			},
+			wantMetrics: true,
+		},
+		{
+			name: "existing resource group is left alone",
+			doc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.ClusterProfile.ExistingResourceGroup = api.ExistingResourceGroupEnabled
+			},
+			wantDoc: func(doc *api.OpenShiftClusterDocument) {
+				doc.OpenShiftCluster.Tags = map[string]string{"owner": "team"}
+				doc.OpenShiftCluster.Properties.ClusterProfile.ExistingResourceGroup = api.ExistingResourceGroupEnabled
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resourceGroups := mock_features.NewMockResourceGroupsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(resourceGroups)
+			}
+
+			dbOpenShiftClusters, dbClient := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+			checker := testdatabase.NewChecker()
+
+			doc := func() *api.OpenShiftClusterDocument {
+				return &api.OpenShiftClusterDocument{
+					Key: strings.ToLower(key),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: key,
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateAdminUpdating,
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/cluster-rg",
+							},
+						},
+					},
+				}
+			}
+
+			initial := doc()
+			tt.doc(initial)
+			fixture.AddOpenShiftClusterDocuments(initial)
+
+			want := doc()
+			want.Dequeues = 1
+			tt.wantDoc(want)
+			checker.AddOpenShiftClusterDocuments(want)
+
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fm := newfakeMetricsEmitter()
+
+			m := &manager{
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				db:             dbOpenShiftClusters,
+				resourceGroups: resourceGroups,
+				metricsEmitter: fm,
+			}
+
+			m.doc, err = dbOpenShiftClusters.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = m.reconcileTags(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := fm.Metrics[tagDriftMetricName]; ok != tt.wantMetrics {
+				t.Errorf("got metrics %v", fm.Metrics)
+			}
+
+			for _, err = range checker.CheckOpenShiftClusters(dbClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUpdateOperatorResourceTags(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name         string
+		tags         map[string]string
+		tagPolicy    *api.TagPolicy
+		resourceTags *arov1alpha1.ResourceTagsSpec
+		want         *arov1alpha1.ResourceTagsSpec
+	}{
+		{
+			name: "no tag policy",
+			tags: map[string]string{"owner": "team"},
+		},
+		{
+			name:      "required tags set",
+			tags:      map[string]string{"owner": "team", "other": "value"},
+			tagPolicy: &api.TagPolicy{RequiredTags: []string{"owner"}},
+			want: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"owner": "team"},
+			},
+		},
+		{
+			name:      "required tag changed",
+			tags:      map[string]string{"owner": "other-team"},
+			tagPolicy: &api.TagPolicy{RequiredTags: []string{"owner"}},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"owner": "team"},
+			},
+			want: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"owner": "other-team"},
+			},
+		},
+		{
+			name: "tag policy removed",
+			tags: map[string]string{"owner": "team"},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"owner": "team"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			arocli := arofake.NewSimpleClientset(&arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					ResourceTags: tt.resourceTags,
+				},
+			})
+
+			m := &manager{
+				doc: &api.OpenShiftClusterDocument{
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Tags: tt.tags,
+					},
+				},
+				subscriptionDoc: &api.SubscriptionDocument{
+					TagPolicy: tt.tagPolicy,
+				},
+				arocli: arocli,
+			}
+
+			err := m.updateOperatorResourceTags(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cluster, err := arocli.AroV1alpha1().Clusters().Get(ctx, arov1alpha1.SingletonClusterName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cluster.Spec.ResourceTags, tt.want) {
+				t.Errorf("got %v, wanted %v", cluster.Spec.ResourceTags, tt.want)
+			}
+		})
+	}
+}
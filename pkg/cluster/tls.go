@@ -5,6 +5,7 @@ package cluster
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 
@@ -80,6 +81,10 @@ func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretI
 		return err
 	}
 
+	return ensureTLSSecret(ctx, secrets, certificateName, key, certs)
+}
+
+func ensureTLSSecret(ctx context.Context, secrets corev1client.SecretInterface, name string, key *rsa.PrivateKey, certs []*x509.Certificate) error {
 	b, err := x509.MarshalPKCS8PrivateKey(key)
 	if err != nil {
 		return err
@@ -92,7 +97,7 @@ func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretI
 
 	_, err = secrets.Create(ctx, &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: certificateName,
+			Name: name,
 		},
 		Data: map[string][]byte{
 			corev1.TLSCertKey:       cb,
@@ -102,7 +107,7 @@ func (m *manager) ensureSecret(ctx context.Context, secrets corev1client.SecretI
 	}, metav1.CreateOptions{})
 	if kerrors.IsAlreadyExists(err) {
 		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			s, err := secrets.Get(ctx, certificateName, metav1.GetOptions{})
+			s, err := secrets.Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,490 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/util/computeskus"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+)
+
+const (
+	machineSetLabel = "machine.openshift.io/cluster-api-machineset"
+
+	// workerMachineSetConcurrencyEnvVar, set from the
+	// workerMachineSetConcurrency RP configuration, overrides the number of
+	// worker machine sets which are created in parallel
+	workerMachineSetConcurrencyEnvVar = "WORKER_MACHINESET_CONCURRENCY"
+
+	defaultWorkerMachineSetConcurrency = 5
+)
+
+var (
+	// workerScaleStep is the number of workers added to a machine set at a
+	// time, so that Azure throttling the creation of the VMs holds up one
+	// stage rather than failing the whole scale operation
+	workerScaleStep = 10
+
+	workerScaleStageTimeout = 30 * time.Minute
+	workerScaleInterval     = 10 * time.Second
+
+	// workerScaleBackoff is how long to wait before replacing a machine whose
+	// creation was throttled by Azure
+	workerScaleBackoff = wait.Backoff{
+		Duration: 30 * time.Second,
+		Factor:   2,
+		Steps:    5,
+		Cap:      10 * time.Minute,
+	}
+)
+
+// additionalWorkerProfiles returns the worker profiles other than the default
+// one, which the installer provisions.  The RP provisions machine sets for
+// these once the cluster is up.
+func (m *manager) additionalWorkerProfiles() []api.WorkerProfile {
+	if len(m.doc.OpenShiftCluster.Properties.WorkerProfiles) < 2 {
+		return nil
+	}
+	return m.doc.OpenShiftCluster.Properties.WorkerProfiles[1:]
+}
+
+// workerProfileProximityPlacementGroupName returns the name of the proximity
+// placement group which is created for a worker profile which does not use an
+// existing one
+func workerProfileProximityPlacementGroupName(infraID string, wp *api.WorkerProfile) string {
+	return infraID + "-" + wp.Name + "-ppg"
+}
+
+// workerProfileAvailabilitySetName returns the name of the availability set
+// in which the VMs of a worker profile in a proximity placement group are
+// placed
+func workerProfileAvailabilitySetName(infraID string, wp *api.WorkerProfile) string {
+	return infraID + "-" + wp.Name + "-as"
+}
+
+// workerMachineSetConcurrency returns the number of worker machine sets which
+// are created in parallel
+func workerMachineSetConcurrency() int {
+	if concurrency, err := strconv.Atoi(os.Getenv(workerMachineSetConcurrencyEnvVar)); err == nil && concurrency > 0 {
+		return concurrency
+	}
+	return defaultWorkerMachineSetConcurrency
+}
+
+// ensureAdditionalWorkerMachineSets creates the machine sets of each
+// additional worker profile.  They are modelled on the installer's worker
+// machine sets, one per availability zone, with the profile's subnet, VM size,
+// disk and networking settings.  Machine sets which already exist are left alone, as
+// they belong to the customer once created.
+//
+// The machine sets are created in parallel, and the number created is
+// recorded in the cluster document as it goes.  Errors are aggregated: the
+// machine sets which could be created are, and a retry creates the remaining
+// ones.  The quota of the subscription was validated up front by the
+// frontend for the workers of all the worker profiles together.
+func (m *manager) ensureAdditionalWorkerMachineSets(ctx context.Context) error {
+	workerProfiles := m.additionalWorkerProfiles()
+	if len(workerProfiles) == 0 {
+		return nil
+	}
+
+	machinesets, err := m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	prefix := m.doc.OpenShiftCluster.Properties.InfraID + "-worker-"
+
+	var templates []machinev1beta1.MachineSet
+	for _, machineset := range machinesets.Items {
+		if strings.HasPrefix(machineset.Name, prefix) {
+			templates = append(templates, machineset)
+		}
+	}
+	if len(templates) == 0 {
+		return fmt.Errorf("no worker machine sets with prefix %q found", prefix)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	var want []*machinev1beta1.MachineSet
+	for _, wp := range workerProfiles {
+		acceleratedNetworking, err := m.workerProfileAcceleratedNetworking(&wp)
+		if err != nil {
+			return err
+		}
+
+		// VMs in a proximity placement group are placed through its
+		// availability set rather than spread across the zones, so a single
+		// machine set is created
+		if wp.ProximityPlacementGroupProfile != nil {
+			machineset, err := workerProfileMachineSet(&templates[0], &wp, m.doc.OpenShiftCluster.Properties.InfraID+"-"+wp.Name, wp.Count, acceleratedNetworking, workerProfileAvailabilitySetName(m.doc.OpenShiftCluster.Properties.InfraID, &wp))
+			if err != nil {
+				return err
+			}
+
+			want = append(want, machineset)
+			continue
+		}
+
+		for i := range templates {
+			// spread the workers across the zones, as the installer does
+			replicas := wp.Count / len(templates)
+			if i < wp.Count%len(templates) {
+				replicas++
+			}
+
+			machineset, err := workerProfileMachineSet(&templates[i], &wp, m.doc.OpenShiftCluster.Properties.InfraID+"-"+wp.Name+"-"+strings.TrimPrefix(templates[i].Name, prefix), replicas, acceleratedNetworking, "")
+			if err != nil {
+				return err
+			}
+
+			want = append(want, machineset)
+		}
+	}
+
+	err = m.setWorkerMachineSetsProgress(ctx, &api.WorkerMachineSetsProgress{Total: len(want)})
+	if err != nil {
+		return err
+	}
+
+	// mu serialises the updates of the progress, and so of m.doc
+	var mu sync.Mutex
+	var created int
+
+	err = forEachConcurrently(len(want), workerMachineSetConcurrency(), func(i int) error {
+		m.log.Printf("creating machineset %s", want[i].Name)
+		_, err := m.maocli.MachineV1beta1().MachineSets(want[i].Namespace).Create(ctx, want[i], metav1.CreateOptions{})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return fmt.Errorf("machineset %s: %w", want[i].Name, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		created++
+		return m.setWorkerMachineSetsProgress(ctx, &api.WorkerMachineSetsProgress{Created: created, Total: len(want)})
+	})
+	if err != nil {
+		return err
+	}
+
+	return m.setWorkerMachineSetsProgress(ctx, nil)
+}
+
+// setWorkerMachineSetsProgress records the progress of the creation of the
+// worker machine sets in the cluster document; nil clears it
+func (m *manager) setWorkerMachineSetsProgress(ctx context.Context, progress *api.WorkerMachineSetsProgress) error {
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.WorkerMachineSetsProgress = progress
+		return nil
+	})
+	return err
+}
+
+// forEachConcurrently runs f for each index below n, at most concurrency at
+// a time, and returns the aggregate of their errors
+func forEachConcurrently(n, concurrency int, f func(int) error) error {
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			errs[i] = f(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// workerProfileAcceleratedNetworking returns whether the VMs of the worker
+// profile use accelerated networking: as requested, or if unset, whenever
+// their VM size supports it
+func (m *manager) workerProfileAcceleratedNetworking(wp *api.WorkerProfile) (bool, error) {
+	switch wp.AcceleratedNetworking {
+	case api.AcceleratedNetworkingEnabled:
+		return true, nil
+	case api.AcceleratedNetworkingDisabled:
+		return false, nil
+	}
+
+	sku, err := m.env.VMSku(string(wp.VMSize))
+	if err != nil {
+		return false, err
+	}
+
+	return computeskus.SupportsAcceleratedNetworking(sku), nil
+}
+
+// workerProfileMachineSet returns a copy of the template machine set with the
+// given name and replicas, using the subnet, VM size and disk settings of the
+// worker profile and the given accelerated networking.  If an availability set
+// is given, the VMs are placed in it instead of the zone of the template.
+func workerProfileMachineSet(template *machinev1beta1.MachineSet, wp *api.WorkerProfile, name string, replicas int, acceleratedNetworking bool, availabilitySet string) (*machinev1beta1.MachineSet, error) {
+	if template.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return nil, fmt.Errorf("machine set %s has no provider spec", template.Name)
+	}
+
+	obj, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(template.Spec.Template.Spec.ProviderSpec.Value.Raw, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	providerSpec, ok := obj.(*machinev1beta1.AzureMachineProviderSpec)
+	if !ok {
+		return nil, fmt.Errorf("machine set %s: failed to read provider spec: %T", template.Name, obj)
+	}
+	providerSpec.APIVersion, providerSpec.Kind = gvk.ToAPIVersionAndKind()
+
+	_, subnetName, err := apisubnet.Split(wp.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	providerSpec.Subnet = subnetName
+	providerSpec.VMSize = string(wp.VMSize)
+	providerSpec.OSDisk.DiskSizeGB = int32(wp.DiskSizeGB)
+	providerSpec.AcceleratedNetworking = acceleratedNetworking
+	if wp.DiskStorageAccountType != "" {
+		providerSpec.OSDisk.ManagedDisk.StorageAccountType = string(wp.DiskStorageAccountType)
+	}
+	if wp.EncryptionAtHost == api.EncryptionAtHostEnabled {
+		providerSpec.SecurityProfile = &machinev1beta1.SecurityProfile{
+			EncryptionAtHost: to.BoolPtr(true),
+		}
+	} else {
+		providerSpec.SecurityProfile = nil
+	}
+	if availabilitySet != "" {
+		providerSpec.AvailabilitySet = availabilitySet
+		providerSpec.Zone = nil
+	}
+
+	raw, err := json.Marshal(providerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	machineset := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: template.Namespace,
+			Labels:    template.Labels,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+
+	machineset.Spec.Replicas = to.Int32Ptr(int32(replicas))
+	machineset.Spec.Selector.MatchLabels = copyLabels(template.Spec.Selector.MatchLabels, machineSetLabel, name)
+	machineset.Spec.Template.ObjectMeta.Labels = copyLabels(template.Spec.Template.ObjectMeta.Labels, machineSetLabel, name)
+	machineset.Spec.Template.Spec.ProviderSpec.Value = &kruntime.RawExtension{Raw: raw}
+
+	return machineset, nil
+}
+
+// copyLabels returns a copy of labels with key set to value
+func copyLabels(labels map[string]string, key, value string) map[string]string {
+	l := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[key] = value
+	return l
+}
+
+// scaleWorkerProfiles scales the worker machine sets to the counts requested
+// by an update of the worker profiles, recording the ready replicas in the
+// cluster document as it goes.  The quota of the subscription was validated
+// up front by the frontend.
+func (m *manager) scaleWorkerProfiles(ctx context.Context) error {
+	for _, s := range m.doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus {
+		err := m.scaleWorkerMachineSet(ctx, s.Name, s.TargetReplicas)
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus == nil {
+		return nil
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus = nil
+		return nil
+	})
+	return err
+}
+
+// scaleWorkerMachineSet scales a machine set to target replicas.  Scaling up
+// is done in stages of workerScaleStep replicas, each of which is waited for
+// before the next.
+func (m *manager) scaleWorkerMachineSet(ctx context.Context, name string, target int) error {
+	for {
+		machineset, err := m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		replicas := 1
+		if machineset.Spec.Replicas != nil {
+			replicas = int(*machineset.Spec.Replicas)
+		}
+
+		stage := target
+		if target-replicas > workerScaleStep {
+			stage = replicas + workerScaleStep
+		}
+
+		if stage != replicas {
+			m.log.Printf("scaling machineset %s from %d to %d replicas", name, replicas, stage)
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				machineset, err := m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+
+				machineset.Spec.Replicas = to.Int32Ptr(int32(stage))
+
+				_, err = m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").Update(ctx, machineset, metav1.UpdateOptions{})
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = m.waitForWorkerMachineSet(ctx, name, stage)
+		if err != nil {
+			return err
+		}
+
+		if stage == target {
+			return nil
+		}
+	}
+}
+
+// waitForWorkerMachineSet waits for a machine set to have replicas ready
+// machines.  Machines whose creation was throttled by Azure are deleted after
+// a backoff, so that the machine set creates them again; any other machine
+// failure fails the operation.
+func (m *manager) waitForWorkerMachineSet(ctx context.Context, name string, replicas int) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, workerScaleStageTimeout)
+	defer cancel()
+
+	backoff := workerScaleBackoff
+
+	return wait.PollImmediateUntil(workerScaleInterval, func() (bool, error) {
+		machineset, err := m.maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		err = m.setWorkerProfileScaleReplicas(ctx, name, int(machineset.Status.ReadyReplicas))
+		if err != nil {
+			return false, err
+		}
+
+		machines, err := m.maocli.MachineV1beta1().Machines("openshift-machine-api").List(ctx, metav1.ListOptions{
+			LabelSelector: machineSetLabel + "=" + name,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for _, machine := range machines.Items {
+			if machine.Status.Phase == nil || *machine.Status.Phase != "Failed" {
+				continue
+			}
+
+			var message string
+			if machine.Status.ErrorMessage != nil {
+				message = *machine.Status.ErrorMessage
+			}
+
+			if !isThrottlingError(message) {
+				return false, fmt.Errorf("machine %s failed: %s", machine.Name, message)
+			}
+
+			delay := backoff.Step()
+			m.log.Printf("creation of machine %s was throttled, replacing it in %s", machine.Name, delay)
+
+			select {
+			case <-time.After(delay):
+			case <-timeoutCtx.Done():
+				return false, timeoutCtx.Err()
+			}
+
+			err = m.maocli.MachineV1beta1().Machines(machine.Namespace).Delete(ctx, machine.Name, metav1.DeleteOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+
+		return machineset.Status.ObservedGeneration >= machineset.Generation &&
+			int(machineset.Status.Replicas) == replicas &&
+			int(machineset.Status.ReadyReplicas) == replicas, nil
+	}, timeoutCtx.Done())
+}
+
+// setWorkerProfileScaleReplicas records the ready replicas of a machine set in
+// the scale status of the cluster document
+func (m *manager) setWorkerProfileScaleReplicas(ctx context.Context, name string, replicas int) error {
+	for _, s := range m.doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus {
+		if s.Name == name && s.CurrentReplicas == replicas {
+			return nil
+		}
+	}
+
+	var err error
+	m.doc, err = m.db.PatchWithLease(ctx, m.doc.Key, func(doc *api.OpenShiftClusterDocument) error {
+		for i := range doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus {
+			if doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus[i].Name == name {
+				doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus[i].CurrentReplicas = replicas
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// isThrottlingError returns true if the error message of a machine shows that
+// the creation of its VM was throttled by Azure
+func isThrottlingError(message string) bool {
+	return strings.Contains(message, "StatusCode=429") ||
+		strings.Contains(message, "TooManyRequests")
+}
@@ -0,0 +1,486 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	machinefake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func testWorkerMachineSet(t *testing.T, name string) *machinev1beta1.MachineSet {
+	ms := testMachineSet(t, "openshift-machine-api", name, &machinev1beta1.AzureMachineProviderSpec{
+		VMSize: "Standard_D4s_v3",
+		Subnet: "worker",
+		Vnet:   "vnet",
+		Zone:   to.StringPtr("1"),
+		OSDisk: machinev1beta1.OSDisk{
+			DiskSizeGB: 128,
+			ManagedDisk: machinev1beta1.OSDiskManagedDiskParameters{
+				StorageAccountType: "Premium_LRS",
+			},
+		},
+	})
+	ms.Labels = map[string]string{
+		"machine.openshift.io/cluster-api-cluster": "infra",
+	}
+	ms.Spec.Replicas = to.Int32Ptr(1)
+	ms.Spec.Selector.MatchLabels = map[string]string{
+		"machine.openshift.io/cluster-api-cluster": "infra",
+		machineSetLabel: name,
+	}
+	ms.Spec.Template.ObjectMeta.Labels = map[string]string{
+		"machine.openshift.io/cluster-api-cluster":      "infra",
+		"machine.openshift.io/cluster-api-machine-role": "worker",
+		machineSetLabel: name,
+	}
+	return ms
+}
+
+func TestEnsureAdditionalWorkerMachineSets(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	infraSubnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/infra"
+
+	skuWithAcceleratedNetworking := func(supported string) *mgmtcompute.ResourceSku {
+		return &mgmtcompute.ResourceSku{
+			Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+				{Name: to.StringPtr("AcceleratedNetworkingEnabled"), Value: to.StringPtr(supported)},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name                      string
+		machineSets               []*machinev1beta1.MachineSet
+		acceleratedNetworking     api.AcceleratedNetworking
+		proximityPlacementGroup   *api.ProximityPlacementGroupProfile
+		mocks                     func(env *mock_env.MockInterface)
+		failCreate                []string
+		wantReplicas              map[string]int32
+		wantAcceleratedNetworking bool
+		wantAvailabilitySet       string
+		wantProgress              *api.WorkerMachineSetsProgress
+		wantErr                   string
+	}{
+		{
+			name: "creates a machine set per zone",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+				testWorkerMachineSet(t, "infra-worker-eastus2"),
+				testWorkerMachineSet(t, "infra-worker-eastus3"),
+			},
+			mocks: func(env *mock_env.MockInterface) {
+				env.EXPECT().VMSku(string(api.VMSizeStandardE8sV3)).
+					Return(skuWithAcceleratedNetworking("True"), nil)
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 2,
+				"infra-infra-eastus2": 1,
+				"infra-infra-eastus3": 1,
+			},
+			wantAcceleratedNetworking: true,
+		},
+		{
+			name: "accelerated networking defaults to disabled for unsupported VM sizes",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+			},
+			mocks: func(env *mock_env.MockInterface) {
+				env.EXPECT().VMSku(string(api.VMSizeStandardE8sV3)).
+					Return(skuWithAcceleratedNetworking("False"), nil)
+			},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 4,
+			},
+		},
+		{
+			name: "accelerated networking enabled",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+			},
+			acceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 4,
+			},
+			wantAcceleratedNetworking: true,
+		},
+		{
+			name: "accelerated networking disabled",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+			},
+			acceleratedNetworking: api.AcceleratedNetworkingDisabled,
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 4,
+			},
+		},
+		{
+			name: "creates a single machine set in the availability set of the proximity placement group",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+				testWorkerMachineSet(t, "infra-worker-eastus2"),
+				testWorkerMachineSet(t, "infra-worker-eastus3"),
+			},
+			acceleratedNetworking:   api.AcceleratedNetworkingEnabled,
+			proximityPlacementGroup: &api.ProximityPlacementGroupProfile{},
+			wantReplicas: map[string]int32{
+				"infra-infra": 4,
+			},
+			wantAcceleratedNetworking: true,
+			wantAvailabilitySet:       "infra-infra-as",
+		},
+		{
+			name: "existing machine sets are left alone",
+			machineSets: func() []*machinev1beta1.MachineSet {
+				existing := testWorkerMachineSet(t, "infra-infra-eastus1")
+				existing.Spec.Replicas = to.Int32Ptr(10)
+				return []*machinev1beta1.MachineSet{
+					testWorkerMachineSet(t, "infra-worker-eastus1"),
+					existing,
+				}
+			}(),
+			acceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus1": 10,
+			},
+		},
+		{
+			name: "errors are aggregated",
+			machineSets: []*machinev1beta1.MachineSet{
+				testWorkerMachineSet(t, "infra-worker-eastus1"),
+				testWorkerMachineSet(t, "infra-worker-eastus2"),
+				testWorkerMachineSet(t, "infra-worker-eastus3"),
+			},
+			acceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			failCreate:            []string{"infra-infra-eastus1", "infra-infra-eastus3"},
+			wantReplicas: map[string]int32{
+				"infra-infra-eastus2": 1,
+			},
+			wantAcceleratedNetworking: true,
+			wantProgress: &api.WorkerMachineSetsProgress{
+				Created: 1,
+				Total:   3,
+			},
+			wantErr: "[machineset infra-infra-eastus1: random error, machineset infra-infra-eastus3: random error]",
+		},
+		{
+			name:                  "no worker machine sets",
+			acceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			wantErr:               `no worker machine sets with prefix "infra-worker-" found`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			if tt.mocks != nil {
+				tt.mocks(env)
+			}
+
+			maocli := machinefake.NewSimpleClientset()
+			for _, ms := range tt.machineSets {
+				_, err := maocli.MachineV1beta1().MachineSets(ms.Namespace).Create(ctx, ms, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			maocli.PrependReactor("create", "machinesets", func(action ktesting.Action) (bool, kruntime.Object, error) {
+				ms := action.(ktesting.CreateAction).GetObject().(*machinev1beta1.MachineSet)
+				for _, name := range tt.failCreate {
+					if ms.Name == name {
+						return true, nil, errors.New("random error")
+					}
+				}
+				return false, nil, nil
+			})
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: key,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: key,
+					Properties: api.OpenShiftClusterProperties{
+						InfraID: "infra",
+						WorkerProfiles: []api.WorkerProfile{
+							{
+								Name: "worker",
+							},
+							{
+								Name:             "infra",
+								VMSize:           api.VMSizeStandardE8sV3,
+								DiskSizeGB:       256,
+								SubnetID:         infraSubnetID,
+								Count:            4,
+								EncryptionAtHost: api.EncryptionAtHostEnabled,
+
+								AcceleratedNetworking:          tt.acceleratedNetworking,
+								ProximityPlacementGroupProfile: tt.proximityPlacementGroup,
+							},
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := openShiftClustersDatabase.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				env:    env,
+				doc:    doc,
+				db:     openShiftClustersDatabase,
+				maocli: maocli,
+			}
+
+			err = m.ensureAdditionalWorkerMachineSets(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			doc, err = openShiftClustersDatabase.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(doc.OpenShiftCluster.Properties.WorkerMachineSetsProgress, tt.wantProgress) {
+				t.Errorf("progress %#v", doc.OpenShiftCluster.Properties.WorkerMachineSetsProgress)
+			}
+
+			for name, wantReplicas := range tt.wantReplicas {
+				ms, err := maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if *ms.Spec.Replicas != wantReplicas {
+					t.Errorf("%s: replicas %d", name, *ms.Spec.Replicas)
+				}
+				if ms.Spec.Selector.MatchLabels[machineSetLabel] != name || ms.Spec.Template.Labels[machineSetLabel] != name {
+					t.Errorf("%s: labels %v, %v", name, ms.Spec.Selector.MatchLabels, ms.Spec.Template.Labels)
+				}
+				if ms.Spec.Template.Labels["machine.openshift.io/cluster-api-machine-role"] != "worker" {
+					t.Errorf("%s: labels %v", name, ms.Spec.Template.Labels)
+				}
+
+				if name == "infra-infra-eastus1" && wantReplicas == 10 {
+					continue
+				}
+
+				obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(ms.Spec.Template.Spec.ProviderSpec.Value.Raw, nil, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				providerSpec := obj.(*machinev1beta1.AzureMachineProviderSpec)
+
+				if providerSpec.Subnet != "infra" ||
+					providerSpec.Vnet != "vnet" ||
+					providerSpec.VMSize != string(api.VMSizeStandardE8sV3) ||
+					providerSpec.OSDisk.DiskSizeGB != 256 ||
+					providerSpec.OSDisk.ManagedDisk.StorageAccountType != "Premium_LRS" ||
+					providerSpec.SecurityProfile == nil || !*providerSpec.SecurityProfile.EncryptionAtHost ||
+					providerSpec.AcceleratedNetworking != tt.wantAcceleratedNetworking {
+					t.Errorf("%s: provider spec %#v", name, providerSpec)
+				}
+
+				if providerSpec.AvailabilitySet != tt.wantAvailabilitySet ||
+					(tt.wantAvailabilitySet == "") != (providerSpec.Zone != nil) {
+					t.Errorf("%s: availability set %q, zone %v", name, providerSpec.AvailabilitySet, providerSpec.Zone)
+				}
+			}
+		})
+	}
+}
+
+func TestScaleWorkerProfiles(t *testing.T) {
+	ctx := context.Background()
+
+	const key = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/resourcename"
+
+	defer func(step int, timeout, interval time.Duration, backoff wait.Backoff) {
+		workerScaleStep, workerScaleStageTimeout, workerScaleInterval, workerScaleBackoff = step, timeout, interval, backoff
+	}(workerScaleStep, workerScaleStageTimeout, workerScaleInterval, workerScaleBackoff)
+	workerScaleStep = 2
+	workerScaleStageTimeout = 5 * time.Second
+	workerScaleInterval = time.Millisecond
+	workerScaleBackoff = wait.Backoff{Duration: time.Millisecond}
+
+	failedMachine := func(message string) *machinev1beta1.Machine {
+		return &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "infra-worker-eastus1-abcde",
+				Namespace: "openshift-machine-api",
+				Labels: map[string]string{
+					machineSetLabel: "infra-worker-eastus1",
+				},
+			},
+			Status: machinev1beta1.MachineStatus{
+				Phase:        to.StringPtr("Failed"),
+				ErrorMessage: to.StringPtr(message),
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		replicas        int32
+		target          int
+		machine         *machinev1beta1.Machine
+		wantStages      []int32
+		wantScale       []api.WorkerProfileScale
+		wantErr         string
+		wantMachineGone bool
+	}{
+		{
+			name:       "scales up in stages",
+			replicas:   1,
+			target:     6,
+			wantStages: []int32{3, 5, 6},
+		},
+		{
+			name:       "scales down at once",
+			replicas:   5,
+			target:     2,
+			wantStages: []int32{2},
+		},
+		{
+			name:            "throttled machine is replaced",
+			replicas:        1,
+			target:          2,
+			machine:         failedMachine(`failed to create VM: compute.VirtualMachinesClient#CreateOrUpdate: StatusCode=429 -- Original Error: Code="OperationNotAllowed"`),
+			wantStages:      []int32{2},
+			wantMachineGone: true,
+		},
+		{
+			name:       "failed machine fails the operation",
+			replicas:   1,
+			target:     2,
+			machine:    failedMachine("failed to create VM: QuotaExceeded"),
+			wantStages: []int32{2},
+			wantScale: []api.WorkerProfileScale{
+				{
+					Name:            "infra-worker-eastus1",
+					CurrentReplicas: 2,
+					TargetReplicas:  2,
+				},
+			},
+			wantErr: "machine infra-worker-eastus1-abcde failed: failed to create VM: QuotaExceeded",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := testWorkerMachineSet(t, "infra-worker-eastus1")
+			ms.Spec.Replicas = to.Int32Ptr(tt.replicas)
+			ms.Status.Replicas = tt.replicas
+			ms.Status.ReadyReplicas = tt.replicas
+
+			objects := []kruntime.Object{ms}
+			if tt.machine != nil {
+				objects = append(objects, tt.machine)
+			}
+			maocli := machinefake.NewSimpleClientset(objects...)
+
+			// the machine set controller brings up the machines at once
+			var stages []int32
+			maocli.PrependReactor("update", "machinesets", func(action ktesting.Action) (bool, kruntime.Object, error) {
+				ms := action.(ktesting.UpdateAction).GetObject().(*machinev1beta1.MachineSet)
+				stages = append(stages, *ms.Spec.Replicas)
+				ms.Status.Replicas = *ms.Spec.Replicas
+				ms.Status.ReadyReplicas = *ms.Spec.Replicas
+				return false, nil, nil
+			})
+
+			openShiftClustersDatabase, _ := testdatabase.NewFakeOpenShiftClusters()
+			fixture := testdatabase.NewFixture().WithOpenShiftClusters(openShiftClustersDatabase)
+			fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: key,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: key,
+					Properties: api.OpenShiftClusterProperties{
+						WorkerProfilesScaleStatus: []api.WorkerProfileScale{
+							{
+								Name:            "infra-worker-eastus1",
+								CurrentReplicas: int(tt.replicas),
+								TargetReplicas:  tt.target,
+							},
+						},
+					},
+				},
+			})
+			err := fixture.Create()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := openShiftClustersDatabase.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := &manager{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				doc:    doc,
+				db:     openShiftClustersDatabase,
+				maocli: maocli,
+			}
+
+			err = m.scaleWorkerProfiles(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(stages, tt.wantStages) {
+				t.Errorf("stages %v", stages)
+			}
+
+			ms, err = maocli.MachineV1beta1().MachineSets("openshift-machine-api").Get(ctx, "infra-worker-eastus1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int(*ms.Spec.Replicas) != tt.target {
+				t.Errorf("replicas %d", *ms.Spec.Replicas)
+			}
+
+			doc, err = openShiftClustersDatabase.Get(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus, tt.wantScale) {
+				t.Errorf("scale status %#v", doc.OpenShiftCluster.Properties.WorkerProfilesScaleStatus)
+			}
+
+			if tt.machine != nil {
+				_, err = maocli.MachineV1beta1().Machines("openshift-machine-api").Get(ctx, tt.machine.Name, metav1.GetOptions{})
+				if tt.wantMachineGone != kerrors.IsNotFound(err) {
+					t.Errorf("machine: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/metrics"
+)
+
+const (
+	circuitBreakerFailureThreshold = 5
+	circuitBreakerOpenDuration     = 30 * time.Second
+)
+
+// ErrCircuitBreakerOpen is returned instead of sending a request to Cosmos DB
+// while the circuit breaker is open
+var ErrCircuitBreakerOpen = errors.New("cosmosdb circuit breaker is open: the database is unhealthy, not sending request")
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case circuitBreakerOpen:
+		return "open"
+	case circuitBreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker tracks the health of the Cosmos DB connection.  After
+// circuitBreakerFailureThreshold consecutive failed requests it opens and
+// requests fail fast with ErrCircuitBreakerOpen.  After
+// circuitBreakerOpenDuration a single trial request is let through: if it
+// succeeds the breaker closes again, otherwise it stays open for another
+// circuitBreakerOpenDuration.
+type CircuitBreaker struct {
+	log *logrus.Entry
+	m   metrics.Emitter
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    circuitBreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker returns a new, closed, CircuitBreaker
+func NewCircuitBreaker(log *logrus.Entry, m metrics.Emitter) *CircuitBreaker {
+	return &CircuitBreaker{
+		log: log,
+		m:   m,
+		now: time.Now,
+	}
+}
+
+// Healthy returns false while the circuit breaker is open or half-open
+func (cb *CircuitBreaker) Healthy() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state == circuitBreakerClosed
+}
+
+// allow returns ErrCircuitBreakerOpen if a request must not be sent
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitBreakerOpen:
+		if cb.now().Sub(cb.openedAt) < circuitBreakerOpenDuration {
+			return ErrCircuitBreakerOpen
+		}
+		cb.setState(circuitBreakerHalfOpen)
+		fallthrough
+
+	case circuitBreakerHalfOpen:
+		// only one trial request at a time
+		if cb.trial {
+			return ErrCircuitBreakerOpen
+		}
+		cb.trial = true
+	}
+
+	return nil
+}
+
+// record records the outcome of a request which allow permitted
+func (cb *CircuitBreaker) record(success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trial = false
+
+	if success {
+		cb.failures = 0
+		cb.setState(circuitBreakerClosed)
+		return
+	}
+
+	cb.failures++
+	if cb.state == circuitBreakerHalfOpen || cb.failures >= circuitBreakerFailureThreshold {
+		cb.openedAt = cb.now()
+		cb.setState(circuitBreakerOpen)
+	}
+}
+
+// release releases a request which allow permitted without recording its
+// outcome
+func (cb *CircuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trial = false
+}
+
+// setState must be called with cb.mu held
+func (cb *CircuitBreaker) setState(state circuitBreakerState) {
+	if cb.state != state {
+		cb.log.Warnf("cosmosdb circuit breaker is %s", state)
+	}
+	cb.state = state
+}
+
+func (cb *CircuitBreaker) emitMetrics() {
+	var open int64
+	if !cb.Healthy() {
+		open = 1
+	}
+
+	cb.m.EmitGauge("client.cosmosdb.circuitbreaker.open", open, nil)
+}
+
+var _ http.RoundTripper = (*circuitBreakerRoundTripper)(nil)
+
+type circuitBreakerRoundTripper struct {
+	cb *CircuitBreaker
+	tr http.RoundTripper
+}
+
+func (t *circuitBreakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	defer t.cb.emitMetrics()
+
+	err := t.cb.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.tr.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// the caller gave up: this says nothing about the database
+		t.cb.release()
+	case err != nil:
+		t.cb.record(false)
+	default:
+		// throttling is retried by the client and is not a sign that the
+		// database is unhealthy
+		t.cb.record(resp.StatusCode < http.StatusInternalServerError)
+	}
+
+	return resp, err
+}
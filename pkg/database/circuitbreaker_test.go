@@ -0,0 +1,204 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+type testRoundTripper struct {
+	statusCode int
+	err        error
+	calls      int
+}
+
+func (rt *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	if rt.err != nil {
+		return nil, rt.err
+	}
+	return &http.Response{StatusCode: rt.statusCode}, nil
+}
+
+func TestCircuitBreakerRoundTripper(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name        string
+		ctx         func() context.Context
+		rt          *testRoundTripper
+		requests    int
+		elapsed     time.Duration
+		recover     bool
+		wantCalls   int
+		wantErr     error
+		wantHealthy bool
+	}{
+		{
+			name:        "healthy",
+			rt:          &testRoundTripper{statusCode: http.StatusOK},
+			requests:    10,
+			wantCalls:   10,
+			wantHealthy: true,
+		},
+		{
+			name:        "throttling does not open",
+			rt:          &testRoundTripper{statusCode: http.StatusTooManyRequests},
+			requests:    10,
+			wantCalls:   10,
+			wantHealthy: true,
+		},
+		{
+			name:        "below threshold",
+			rt:          &testRoundTripper{err: errors.New("connection refused")},
+			requests:    circuitBreakerFailureThreshold - 1,
+			wantCalls:   circuitBreakerFailureThreshold - 1,
+			wantErr:     errors.New("connection refused"),
+			wantHealthy: true,
+		},
+		{
+			name: "cancelled requests do not open",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(ctx)
+				cancel()
+				return ctx
+			},
+			rt:          &testRoundTripper{err: context.Canceled},
+			requests:    10,
+			wantCalls:   10,
+			wantErr:     context.Canceled,
+			wantHealthy: true,
+		},
+		{
+			name:      "opens on errors",
+			rt:        &testRoundTripper{err: errors.New("connection refused")},
+			requests:  10,
+			wantCalls: circuitBreakerFailureThreshold,
+			wantErr:   ErrCircuitBreakerOpen,
+		},
+		{
+			name:      "opens on server errors",
+			rt:        &testRoundTripper{statusCode: http.StatusServiceUnavailable},
+			requests:  10,
+			wantCalls: circuitBreakerFailureThreshold,
+			wantErr:   ErrCircuitBreakerOpen,
+		},
+		{
+			name:      "stays open before open duration",
+			rt:        &testRoundTripper{statusCode: http.StatusServiceUnavailable},
+			requests:  circuitBreakerFailureThreshold,
+			elapsed:   circuitBreakerOpenDuration - time.Second,
+			recover:   true,
+			wantCalls: circuitBreakerFailureThreshold,
+			wantErr:   ErrCircuitBreakerOpen,
+		},
+		{
+			name:        "closes after successful trial",
+			rt:          &testRoundTripper{statusCode: http.StatusServiceUnavailable},
+			requests:    circuitBreakerFailureThreshold,
+			elapsed:     circuitBreakerOpenDuration,
+			recover:     true,
+			wantCalls:   circuitBreakerFailureThreshold + 1,
+			wantHealthy: true,
+		},
+		{
+			name:      "reopens after failed trial",
+			rt:        &testRoundTripper{statusCode: http.StatusServiceUnavailable},
+			requests:  circuitBreakerFailureThreshold,
+			elapsed:   circuitBreakerOpenDuration,
+			wantCalls: circuitBreakerFailureThreshold + 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			m.EXPECT().EmitGauge("client.cosmosdb.circuitbreaker.open", gomock.Any(), nil).AnyTimes()
+
+			cb := NewCircuitBreaker(logrus.NewEntry(logrus.StandardLogger()), m)
+			cb.now = func() time.Time { return now }
+
+			rt := &circuitBreakerRoundTripper{cb: cb, tr: tt.rt}
+
+			reqCtx := ctx
+			if tt.ctx != nil {
+				reqCtx = tt.ctx()
+			}
+
+			req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, "https://localhost/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < tt.requests; i++ {
+				_, err = rt.RoundTrip(req)
+			}
+
+			if tt.elapsed > 0 {
+				cb.now = func() time.Time { return now.Add(tt.elapsed) }
+				if tt.recover {
+					tt.rt.statusCode = http.StatusOK
+				}
+				_, err = rt.RoundTrip(req)
+			}
+
+			if tt.rt.calls != tt.wantCalls {
+				t.Errorf("got %d calls, wanted %d", tt.rt.calls, tt.wantCalls)
+			}
+
+			if (err == nil) != (tt.wantErr == nil) || err != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("got error %v, wanted %v", err, tt.wantErr)
+			}
+
+			if cb.Healthy() != tt.wantHealthy {
+				t.Errorf("got healthy %t, wanted %t", cb.Healthy(), tt.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerSingleTrial(t *testing.T) {
+	now := time.Now()
+
+	cb := NewCircuitBreaker(logrus.NewEntry(logrus.StandardLogger()), nil)
+	cb.now = func() time.Time { return now }
+
+	for i := 0; i < circuitBreakerFailureThreshold; i++ {
+		err := cb.allow()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cb.record(false)
+	}
+
+	cb.now = func() time.Time { return now.Add(circuitBreakerOpenDuration) }
+
+	err := cb.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cb.allow()
+	if err != ErrCircuitBreakerOpen {
+		t.Errorf("got error %v, wanted %v", err, ErrCircuitBreakerOpen)
+	}
+
+	cb.release()
+
+	err = cb.allow()
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,101 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+const (
+	ClusterHealthListByKeyQuery = `SELECT * FROM ClusterHealth doc WHERE doc.key = @key`
+)
+
+type clusterHealth struct {
+	c cosmosdb.ClusterHealthDocumentClient
+}
+
+// ClusterHealth is the database interface for ClusterHealthDocuments
+type ClusterHealth interface {
+	RecordHealth(ctx context.Context, clusterID, key string, now time.Time, healthy bool) (*api.ClusterHealthDocument, error)
+	ListByKey(ctx context.Context, key string, since time.Time) (*api.ClusterHealthDocuments, error)
+}
+
+// NewClusterHealth returns a new ClusterHealth
+func NewClusterHealth(ctx context.Context, dbc cosmosdb.DatabaseClient, dbName string) (ClusterHealth, error) {
+	collc := cosmosdb.NewCollectionClient(dbc, dbName)
+
+	documentClient := cosmosdb.NewClusterHealthDocumentClient(collc, collClusterHealth)
+	return NewClusterHealthWithProvidedClient(documentClient), nil
+}
+
+func NewClusterHealthWithProvidedClient(client cosmosdb.ClusterHealthDocumentClient) ClusterHealth {
+	return &clusterHealth{
+		c: client,
+	}
+}
+
+// RecordHealth records the health of the cluster identified by clusterID (the
+// OpenShiftClusterDocument ID) and key, observed at time now.  It is called
+// only when the health changes or is due to be refreshed, not on every check.
+func (c *clusterHealth) RecordHealth(ctx context.Context, clusterID, key string, now time.Time, healthy bool) (*api.ClusterHealthDocument, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	return c.c.Create(ctx, key, &api.ClusterHealthDocument{
+		ID:  fmt.Sprintf("%s-%d", strings.ToLower(clusterID), now.Unix()),
+		Key: key,
+		ClusterHealth: &api.ClusterHealth{
+			Since:   int(now.Unix()),
+			Healthy: healthy,
+		},
+	}, nil)
+}
+
+// ListByKey returns the documents of the cluster identified by key which may
+// hold at or after since, sorted by the time at which they were observed.
+func (c *clusterHealth) ListByKey(ctx context.Context, key string, since time.Time) (*api.ClusterHealthDocuments, error) {
+	if key != strings.ToLower(key) {
+		return nil, fmt.Errorf("key %q is not lower case", key)
+	}
+
+	// the health is only recorded when it changes or is refreshed, so there
+	// are few documents per cluster: filter them here rather than in the query
+	docs, err := c.c.QueryAll(ctx, key, &cosmosdb.Query{
+		Query: ClusterHealthListByKeyQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@key",
+				Value: key,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	from := int(since.Add(-api.ClusterHealthValidity).Unix())
+
+	result := &api.ClusterHealthDocuments{}
+	if docs != nil {
+		for _, doc := range docs.ClusterHealthDocuments {
+			if doc.ClusterHealth != nil && doc.ClusterHealth.Since > from {
+				result.ClusterHealthDocuments = append(result.ClusterHealthDocuments, doc)
+			}
+		}
+	}
+	sort.Slice(result.ClusterHealthDocuments, func(i, j int) bool {
+		return result.ClusterHealthDocuments[i].ClusterHealth.Since < result.ClusterHealthDocuments[j].ClusterHealth.Since
+	})
+	result.Count = len(result.ClusterHealthDocuments)
+
+	return result, nil
+}
@@ -26,6 +26,7 @@ import (
 const (
 	collAsyncOperations   = "AsyncOperations"
 	collBilling           = "Billing"
+	collClusterHealth     = "ClusterHealth"
 	collClusterManager    = "ClusterManagerConfigurations"
 	collGateway           = "Gateway"
 	collMonitors          = "Monitors"
@@ -35,19 +36,27 @@ const (
 	collSubscriptions     = "Subscriptions"
 )
 
-func NewDatabaseClient(log *logrus.Entry, _env env.Core, authorizer cosmosdb.Authorizer, m metrics.Emitter, aead encryption.AEAD, databaseAccountName string) (cosmosdb.DatabaseClient, error) {
+// NewDatabaseClient returns a new database client.  If cb is not nil, requests
+// fail fast while it is open.
+func NewDatabaseClient(log *logrus.Entry, _env env.Core, authorizer cosmosdb.Authorizer, m metrics.Emitter, aead encryption.AEAD, databaseAccountName string, cb *CircuitBreaker) (cosmosdb.DatabaseClient, error) {
 	h, err := NewJSONHandle(aead)
 	if err != nil {
 		return nil, err
 	}
 
+	var tr http.RoundTripper = dbmetrics.New(log, &http.Transport{
+		// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
+		TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
+		MaxIdleConnsPerHost: 20,
+	}, m)
+
+	if cb != nil {
+		tr = &circuitBreakerRoundTripper{cb: cb, tr: tr}
+	}
+
 	c := &http.Client{
-		Transport: dbmetrics.New(log, &http.Transport{
-			// disable HTTP/2 for now: https://github.com/golang/go/issues/36026
-			TLSNextProto:        map[string]func(string, *tls.Conn) http.RoundTripper{},
-			MaxIdleConnsPerHost: 20,
-		}, m),
-		Timeout: 30 * time.Second,
+		Transport: tr,
+		Timeout:   30 * time.Second,
 	}
 
 	return cosmosdb.NewDatabaseClient(log, c, h, databaseAccountName+"."+_env.Environment().CosmosDBDNSSuffix, authorizer), nil
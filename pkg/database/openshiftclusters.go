@@ -8,21 +8,25 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/azure"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 const (
-	OpenShiftClustersDequeueQuery       = `SELECT * FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
+	OpenShiftClustersDequeueQuery       = `SELECT * FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000 AND (doc.openShiftCluster.properties.rpVersionPin ?? "") IN ("", @rpVersion)`
 	OpenShiftClustersQueueLengthQuery   = `SELECT VALUE COUNT(1) FROM OpenShiftClusters doc WHERE doc.openShiftCluster.properties.provisioningState IN ("Creating", "Deleting", "Updating", "AdminUpdating") AND (doc.leaseExpires ?? 0) < GetCurrentTimestamp() / 1000`
 	OpenShiftClustersGetQuery           = `SELECT * FROM OpenShiftClusters doc WHERE doc.key = @key`
 	OpenshiftClustersPrefixQuery        = `SELECT * FROM OpenShiftClusters doc WHERE STARTSWITH(doc.key, @prefix)`
 	OpenshiftClustersClientIdQuery      = `SELECT * FROM OpenShiftClusters doc WHERE doc.clientIdKey = @clientID`
 	OpenshiftClustersResourceGroupQuery = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterResourceGroupIdKey = @resourceGroupID`
+	OpenshiftClustersDomainQuery        = `SELECT * FROM OpenShiftClusters doc WHERE doc.clusterDomainKey = @domain OR ENDSWITH(doc.clusterDomainKey, CONCAT(".", @domain)) OR ENDSWITH(@domain, CONCAT(".", doc.clusterDomainKey))`
+	OpenshiftClustersSupportCaseQuery   = `SELECT * FROM OpenShiftClusters doc WHERE ARRAY_CONTAINS(doc.supportCaseIdKeys, @supportCaseID)`
 )
 
 type OpenShiftClusterDocumentMutator func(*api.OpenShiftClusterDocument) error
@@ -47,11 +51,14 @@ type OpenShiftClusters interface {
 	List(string) cosmosdb.OpenShiftClusterDocumentIterator
 	ListAll(context.Context) (*api.OpenShiftClusterDocuments, error)
 	ListByPrefix(string, string, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
+	ListBySupportCaseID(string, string) (cosmosdb.OpenShiftClusterDocumentIterator, error)
 	Dequeue(context.Context) (*api.OpenShiftClusterDocument, error)
 	Lease(context.Context, string) (*api.OpenShiftClusterDocument, error)
 	EndLease(context.Context, string, api.ProvisioningState, api.ProvisioningState, *string) (*api.OpenShiftClusterDocument, error)
+	DeferAdminUpdate(context.Context, string, time.Time) (*api.OpenShiftClusterDocument, error)
 	GetByClientID(ctx context.Context, partitionKey, clientID string) (*api.OpenShiftClusterDocuments, error)
 	GetByClusterResourceGroupID(ctx context.Context, partitionKey, resourceGroupID string) (*api.OpenShiftClusterDocuments, error)
+	GetByOverlappingClusterDomain(ctx context.Context, partitionKey, domain string) (*api.OpenShiftClusterDocuments, error)
 	NewUUID() string
 }
 
@@ -274,9 +281,39 @@ func (c *openShiftClusters) ListByPrefix(subscriptionID, prefix, continuation st
 	), nil
 }
 
+// ListBySupportCaseID lists the clusters of all subscriptions which have a
+// support annotation for the support case
+func (c *openShiftClusters) ListBySupportCaseID(supportCaseID, continuation string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
+	if supportCaseID != strings.ToLower(supportCaseID) {
+		return nil, fmt.Errorf("support case ID %q is not lower case", supportCaseID)
+	}
+
+	return c.c.Query(
+		"",
+		&cosmosdb.Query{
+			Query: OpenshiftClustersSupportCaseQuery,
+			Parameters: []cosmosdb.Parameter{
+				{
+					Name:  "@supportCaseID",
+					Value: supportCaseID,
+				},
+			},
+		},
+		&cosmosdb.Options{Continuation: continuation},
+	), nil
+}
+
 func (c *openShiftClusters) Dequeue(ctx context.Context) (*api.OpenShiftClusterDocument, error) {
+	// clusters pinned to another RP version are left for the backends
+	// running that version
 	i := c.c.Query("", &cosmosdb.Query{
 		Query: OpenShiftClustersDequeueQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@rpVersion",
+				Value: version.GitCommit,
+			},
+		},
 	}, nil)
 
 	for {
@@ -311,6 +348,8 @@ func (c *openShiftClusters) EndLease(ctx context.Context, key string, provisioni
 		doc.OpenShiftCluster.Properties.ProvisioningState = provisioningState
 		doc.OpenShiftCluster.Properties.FailedProvisioningState = failedProvisioningState
 		doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+		doc.OpenShiftCluster.Properties.OverrideMaintenanceWindow = false
+		doc.OpenShiftCluster.Properties.MaintenanceDeferredUntil = nil
 
 		doc.LeaseOwner = ""
 		doc.LeaseExpires = 0
@@ -327,6 +366,7 @@ func (c *openShiftClusters) EndLease(ctx context.Context, key string, provisioni
 
 			doc.CorrelationData = nil
 			doc.OpenShiftCluster.Properties.LastProvisioningState = ""
+			doc.OpenShiftCluster.Properties.OperationProgress = nil
 			doc.AsyncOperationID = ""
 		}
 
@@ -334,6 +374,21 @@ func (c *openShiftClusters) EndLease(ctx context.Context, key string, provisioni
 	}, nil)
 }
 
+// DeferAdminUpdate releases the lease on a document in AdminUpdating
+// provisioning state without ending the admin update, such that the document
+// is not dequeued again until the given time
+func (c *openShiftClusters) DeferAdminUpdate(ctx context.Context, key string, until time.Time) (*api.OpenShiftClusterDocument, error) {
+	return c.patchWithLease(ctx, key, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.MaintenanceDeferredUntil = &until
+
+		doc.LeaseOwner = ""
+		doc.LeaseExpires = int(until.Unix())
+		doc.Dequeues = 0
+
+		return nil
+	}, nil)
+}
+
 func (c *openShiftClusters) partitionKey(key string) (string, error) {
 	r, err := azure.ParseResourceID(key)
 	return r.SubscriptionID, err
@@ -370,3 +425,21 @@ func (c *openShiftClusters) GetByClusterResourceGroupID(ctx context.Context, par
 	}
 	return docs, nil
 }
+
+// GetByOverlappingClusterDomain returns the clusters whose domain is the same
+// as, a subdomain of, or a parent domain of the given domain
+func (c *openShiftClusters) GetByOverlappingClusterDomain(ctx context.Context, partitionKey, domain string) (*api.OpenShiftClusterDocuments, error) {
+	docs, err := c.c.QueryAll(ctx, partitionKey, &cosmosdb.Query{
+		Query: OpenshiftClustersDomainQuery,
+		Parameters: []cosmosdb.Parameter{
+			{
+				Name:  "@domain",
+				Value: domain,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
@@ -26,6 +26,7 @@ type Subscriptions interface {
 	Create(context.Context, *api.SubscriptionDocument) (*api.SubscriptionDocument, error)
 	Get(context.Context, string) (*api.SubscriptionDocument, error)
 	Update(context.Context, *api.SubscriptionDocument) (*api.SubscriptionDocument, error)
+	Patch(context.Context, string, func(*api.SubscriptionDocument) error) (*api.SubscriptionDocument, error)
 	ChangeFeed() cosmosdb.SubscriptionDocumentIterator
 	Dequeue(context.Context) (*api.SubscriptionDocument, error)
 	Lease(context.Context, string) (*api.SubscriptionDocument, error)
@@ -104,6 +105,10 @@ func (c *subscriptions) Get(ctx context.Context, id string) (*api.SubscriptionDo
 	return c.c.Get(ctx, id, id, nil)
 }
 
+func (c *subscriptions) Patch(ctx context.Context, id string, f func(*api.SubscriptionDocument) error) (*api.SubscriptionDocument, error) {
+	return c.patch(ctx, id, f, nil)
+}
+
 func (c *subscriptions) patch(ctx context.Context, id string, f func(*api.SubscriptionDocument) error, options *cosmosdb.Options) (*api.SubscriptionDocument, error) {
 	var doc *api.SubscriptionDocument
 
@@ -94,6 +94,7 @@ type Configuration struct {
 	RPMDSDNamespace                    *string                `json:"rpMdsdNamespace,omitempty" value:"required"`
 	RPNSGPortalSourceAddressPrefixes   []string               `json:"rpNsgPortalSourceAddressPrefixes,omitempty"`
 	RPParentDomainName                 *string                `json:"rpParentDomainName,omitempty" value:"required"`
+	RPPrivateEndpointsEnabled          *bool                  `json:"rpPrivateEndpointsEnabled,omitempty"`
 	RPVMSSCapacity                     *int                   `json:"rpVmssCapacity,omitempty"`
 	SSHPublicKey                       *string                `json:"sshPublicKey,omitempty"`
 	StorageAccountDomain               *string                `json:"storageAccountDomain,omitempty" value:"required"`
@@ -107,6 +108,17 @@ type Configuration struct {
 	DefaultInstallerPullspec *string `json:"clusterDefaultInstallerPullspec,omitempty"`
 	AdoptByHive              *string `json:"clustersAdoptByHive,omitempty"`
 	UseCheckAccess           *string `json:"useCheckAccess,omitempty"`
+
+	// SupportedRPVersions is a comma separated list of the RP versions
+	// (Git commits) to which clusters may be pinned, besides the version
+	// being deployed
+	SupportedRPVersions *string `json:"supportedRPVersions,omitempty"`
+
+	// RP tuning, which the RP defaults if unset
+	DNSRecordConcurrency        *string `json:"dnsRecordConcurrency,omitempty"`
+	RPListPageSize              *string `json:"rpListPageSize,omitempty"`
+	RPShutdownDrainPeriod       *string `json:"rpShutdownDrainPeriod,omitempty"`
+	WorkerMachineSetConcurrency *string `json:"workerMachineSetConcurrency,omitempty"`
 }
 
 // Note: if this configuration block is provided, all throughputs must be present and valid
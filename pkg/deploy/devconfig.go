@@ -205,6 +205,13 @@ func DevConfig(_env env.Core) (*Config, error) {
 			// TODO Remove when CheckAccess is completely rolled out
 			// Start enabling this in Dev
 			UseCheckAccess: to.StringPtr(os.Getenv("USE_CHECKACCESS")),
+
+			SupportedRPVersions: to.StringPtr(os.Getenv("ARO_SUPPORTED_RP_VERSIONS")),
+
+			DNSRecordConcurrency:        to.StringPtr(os.Getenv("DNS_RECORD_CONCURRENCY")),
+			RPListPageSize:              to.StringPtr(os.Getenv("RP_LIST_PAGE_SIZE")),
+			RPShutdownDrainPeriod:       to.StringPtr(os.Getenv("RP_SHUTDOWN_DRAIN_PERIOD")),
+			WorkerMachineSetConcurrency: to.StringPtr(os.Getenv("WORKER_MACHINESET_CONCURRENCY")),
 		},
 	}, nil
 }
@@ -8,6 +8,7 @@ import (
 	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2019-09-01/keyvault"
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	mgmtinsights "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	mgmtprivatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
 	"github.com/Azure/go-autorest/autorest/to"
 
@@ -170,3 +171,95 @@ func (g *generator) keyVault(name string, accessPolicies *[]mgmtkeyvault.AccessP
 		DependsOn:  dependsOn,
 	}
 }
+
+// privateDNSZone creates a private DNS zone.  zoneName, here and in the
+// related helpers below, is an ARM template expression evaluating to the zone
+// name, e.g. "variables('zoneName')"
+func (g *generator) privateDNSZone(zoneName string, condition interface{}) *arm.Resource {
+	return &arm.Resource{
+		Resource: &mgmtprivatedns.PrivateZone{
+			Name:     to.StringPtr("[" + zoneName + "]"),
+			Type:     to.StringPtr("Microsoft.Network/privateDnsZones"),
+			Location: to.StringPtr("global"),
+		},
+		Condition:  condition,
+		APIVersion: azureclient.APIVersion("Microsoft.Network/privateDnsZones"),
+	}
+}
+
+// privateDNSZoneVirtualNetworkLink links the private DNS zone zoneName to
+// vnetName so that hosts in the vnet resolve records in the zone
+func (g *generator) privateDNSZoneVirtualNetworkLink(zoneName, vnetName string, condition interface{}) *arm.Resource {
+	return &arm.Resource{
+		Resource: &mgmtprivatedns.VirtualNetworkLink{
+			VirtualNetworkLinkProperties: &mgmtprivatedns.VirtualNetworkLinkProperties{
+				VirtualNetwork: &mgmtprivatedns.SubResource{
+					ID: to.StringPtr("[resourceId('Microsoft.Network/virtualNetworks', '" + vnetName + "')]"),
+				},
+				RegistrationEnabled: to.BoolPtr(false),
+			},
+			Name:     to.StringPtr("[concat(" + zoneName + ", '/" + vnetName + "-link')]"),
+			Type:     to.StringPtr("Microsoft.Network/privateDnsZones/virtualNetworkLinks"),
+			Location: to.StringPtr("global"),
+		},
+		Condition:  condition,
+		APIVersion: azureclient.APIVersion("Microsoft.Network/privateDnsZones"),
+		DependsOn: []string{
+			"[resourceId('Microsoft.Network/privateDnsZones', " + zoneName + ")]",
+		},
+	}
+}
+
+// privateEndpoint creates a private endpoint in subnetID connecting to the
+// groupID sub-resource of resourceID, and registers its address in the private
+// DNS zone zoneName
+func (g *generator) privateEndpoint(name, subnetID, resourceID, groupID, zoneName string, condition interface{}, dependsOn []string) []*arm.Resource {
+	return []*arm.Resource{
+		{
+			Resource: &mgmtnetwork.PrivateEndpoint{
+				PrivateEndpointProperties: &mgmtnetwork.PrivateEndpointProperties{
+					Subnet: &mgmtnetwork.Subnet{
+						ID: &subnetID,
+					},
+					PrivateLinkServiceConnections: &[]mgmtnetwork.PrivateLinkServiceConnection{
+						{
+							Name: to.StringPtr(name),
+							PrivateLinkServiceConnectionProperties: &mgmtnetwork.PrivateLinkServiceConnectionProperties{
+								PrivateLinkServiceID: &resourceID,
+								GroupIds:             &[]string{groupID},
+							},
+						},
+					},
+				},
+				Name:     &name,
+				Type:     to.StringPtr("Microsoft.Network/privateEndpoints"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			Condition:  condition,
+			APIVersion: azureclient.APIVersion("Microsoft.Network"),
+			DependsOn:  dependsOn,
+		},
+		{
+			Resource: &mgmtnetwork.PrivateDNSZoneGroup{
+				PrivateDNSZoneGroupPropertiesFormat: &mgmtnetwork.PrivateDNSZoneGroupPropertiesFormat{
+					PrivateDNSZoneConfigs: &[]mgmtnetwork.PrivateDNSZoneConfig{
+						{
+							Name: to.StringPtr("default"),
+							PrivateDNSZonePropertiesFormat: &mgmtnetwork.PrivateDNSZonePropertiesFormat{
+								PrivateDNSZoneID: to.StringPtr("[resourceId('Microsoft.Network/privateDnsZones', " + zoneName + ")]"),
+							},
+						},
+					},
+				},
+				Name: to.StringPtr(name + "/default"),
+			},
+			Type:       "Microsoft.Network/privateEndpoints/privateDnsZoneGroups",
+			Condition:  condition,
+			APIVersion: azureclient.APIVersion("Microsoft.Network"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.Network/privateEndpoints', '" + name + "')]",
+				"[resourceId('Microsoft.Network/privateDnsZones', " + zoneName + ")]",
+			},
+		},
+	}
+}
@@ -485,12 +485,19 @@ func (g *generator) rpVMSS() *arm.Resource {
 		"rpMdsdConfigVersion",
 		"rpMdsdNamespace",
 		"rpParentDomainName",
+		"storageAccountDomain",
 
 		// TODO: Replace with Live Service Configuration in KeyVault
 		"clustersInstallViaHive",
 		"clustersAdoptByHive",
 		"clusterDefaultInstallerPullspec",
 		"useCheckAccess",
+		"supportedRPVersions",
+
+		"dnsRecordConcurrency",
+		"rpListPageSize",
+		"rpShutdownDrainPeriod",
+		"workerMachineSetConcurrency",
 	} {
 		parts = append(parts,
 			fmt.Sprintf("'%s=$(base64 -d <<<'''", strings.ToUpper(variable)),
@@ -1208,6 +1215,30 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
 			},
 		},
+		{
+			Resource: &mgmtdocumentdb.SQLContainerCreateUpdateParameters{
+				SQLContainerCreateUpdateProperties: &mgmtdocumentdb.SQLContainerCreateUpdateProperties{
+					Resource: &mgmtdocumentdb.SQLContainerResource{
+						ID: to.StringPtr("ClusterHealth"),
+						PartitionKey: &mgmtdocumentdb.ContainerPartitionKey{
+							Paths: &[]string{
+								"/key",
+							},
+							Kind: mgmtdocumentdb.PartitionKindHash,
+						},
+						DefaultTTL: to.Int32Ptr(30 * 86400), // 30 days, see api.ClusterHealthRetention
+					},
+					Options: &mgmtdocumentdb.CreateUpdateOptions{},
+				},
+				Name:     to.StringPtr("[concat(parameters('databaseAccountName'), '/', " + databaseName + ", '/ClusterHealth')]"),
+				Type:     to.StringPtr("Microsoft.DocumentDB/databaseAccounts/sqlDatabases/containers"),
+				Location: to.StringPtr("[resourceGroup().location]"),
+			},
+			APIVersion: azureclient.APIVersion("Microsoft.DocumentDB"),
+			DependsOn: []string{
+				"[resourceId('Microsoft.DocumentDB/databaseAccounts/sqlDatabases', parameters('databaseAccountName'), " + databaseName + ")]",
+			},
+		},
 		gateway,
 		{
 			Resource: &mgmtdocumentdb.SQLContainerCreateUpdateParameters{
@@ -1261,6 +1292,11 @@ func (g *generator) database(databaseName string, addDependsOn bool) []*arm.Reso
 										"/clientIdKey",
 									},
 								},
+								{
+									Paths: &[]string{
+										"/clusterDomainKey",
+									},
+								},
 							},
 						},
 					},
@@ -1534,3 +1570,49 @@ func (g *generator) rpVersionStorageAccount() []*arm.Resource {
 func (g *generator) rpStorageAccount() *arm.Resource {
 	return g.storageAccount("[substring(parameters('storageAccountDomain'), 0, indexOf(parameters('storageAccountDomain'), '.'))]", nil)
 }
+
+// rpPrivateEndpoints returns private endpoints, and the private DNS zones
+// needed to resolve them from rp-vnet, for the RP's key vaults and storage
+// account.  It is only deployed when rpPrivateEndpointsEnabled is set.
+func (g *generator) rpPrivateEndpoints() []*arm.Resource {
+	condition := "[parameters('rpPrivateEndpointsEnabled')]"
+	subnetID := "[resourceId('Microsoft.Network/virtualNetworks/subnets', 'rp-pe-vnet-001', 'rp-pe-subnet')]"
+
+	rs := []*arm.Resource{
+		g.privateDNSZone("variables('rpKeyvaultPrivateDnsZoneName')", condition),
+		g.privateDNSZoneVirtualNetworkLink("variables('rpKeyvaultPrivateDnsZoneName')", "rp-vnet", condition),
+		g.privateDNSZone("variables('rpStoragePrivateDnsZoneName')", condition),
+		g.privateDNSZoneVirtualNetworkLink("variables('rpStoragePrivateDnsZoneName')", "rp-vnet", condition),
+	}
+
+	for _, suffix := range []string{
+		env.ClusterKeyvaultSuffix,
+		env.DBTokenKeyvaultSuffix,
+		env.PortalKeyvaultSuffix,
+		env.ServiceKeyvaultSuffix,
+	} {
+		rs = append(rs, g.privateEndpoint(
+			"rp-keyvault"+suffix+"-pe",
+			subnetID,
+			"[resourceId('Microsoft.KeyVault/vaults', concat(parameters('keyvaultPrefix'), '"+suffix+"'))]",
+			"vault",
+			"variables('rpKeyvaultPrivateDnsZoneName')",
+			condition,
+			nil,
+		)...)
+	}
+
+	rs = append(rs, g.privateEndpoint(
+		"rp-storage-blob-pe",
+		subnetID,
+		"[resourceId('Microsoft.Storage/storageAccounts', substring(parameters('storageAccountDomain'), 0, indexOf(parameters('storageAccountDomain'), '.')))]",
+		"blob",
+		"variables('rpStoragePrivateDnsZoneName')",
+		condition,
+		[]string{
+			"[resourceId('Microsoft.Storage/storageAccounts', substring(parameters('storageAccountDomain'), 0, indexOf(parameters('storageAccountDomain'), '.')))]",
+		},
+	)...)
+
+	return rs
+}
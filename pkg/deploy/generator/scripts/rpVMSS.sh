@@ -281,10 +281,16 @@ MDM_NAMESPACE=RP
 MDSD_ENVIRONMENT='$MDSDENVIRONMENT'
 RP_FEATURES='$RPFEATURES'
 RPIMAGE='$RPIMAGE'
+STORAGE_ACCOUNT_DOMAIN='$STORAGEACCOUNTDOMAIN'
 ARO_INSTALL_VIA_HIVE='$CLUSTERSINSTALLVIAHIVE'
 ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC='$CLUSTERDEFAULTINSTALLERPULLSPEC'
 ARO_ADOPT_BY_HIVE='$CLUSTERSADOPTBYHIVE'
 USE_CHECKACCESS='$USECHECKACCESS'
+ARO_SUPPORTED_RP_VERSIONS='$SUPPORTEDRPVERSIONS'
+DNS_RECORD_CONCURRENCY='$DNSRECORDCONCURRENCY'
+RP_LIST_PAGE_SIZE='$RPLISTPAGESIZE'
+RP_SHUTDOWN_DRAIN_PERIOD='$RPSHUTDOWNDRAINPERIOD'
+WORKER_MACHINESET_CONCURRENCY='$WORKERMACHINESETCONCURRENCY'
 EOF
 
 cat >/etc/systemd/system/aro-rp.service <<'EOF'
@@ -320,10 +326,16 @@ ExecStart=/usr/bin/docker run \
   -e MDM_NAMESPACE \
   -e MDSD_ENVIRONMENT \
   -e RP_FEATURES \
+  -e STORAGE_ACCOUNT_DOMAIN \
   -e ARO_INSTALL_VIA_HIVE \
   -e ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC \
   -e ARO_ADOPT_BY_HIVE \
   -e USE_CHECKACCESS \
+  -e ARO_SUPPORTED_RP_VERSIONS \
+  -e DNS_RECORD_CONCURRENCY \
+  -e RP_LIST_PAGE_SIZE \
+  -e RP_SHUTDOWN_DRAIN_PERIOD \
+  -e WORKER_MACHINESET_CONCURRENCY \
   -m 2g \
   -p 443:8443 \
   -v /etc/aro-rp:/etc/aro-rp \
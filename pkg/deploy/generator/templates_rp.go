@@ -73,6 +73,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			"rpMdsdConfigVersion",
 			"rpMdsdNamespace",
 			"rpParentDomainName",
+			"rpPrivateEndpointsEnabled",
 			"rpVmssCapacity",
 			"sshPublicKey",
 			"storageAccountDomain",
@@ -86,13 +87,20 @@ func (g *generator) rpTemplate() *arm.Template {
 			"clusterDefaultInstallerPullspec",
 			"clustersAdoptByHive",
 			"useCheckAccess",
+			"supportedRPVersions",
+
+			"dnsRecordConcurrency",
+			"rpListPageSize",
+			"rpShutdownDrainPeriod",
+			"workerMachineSetConcurrency",
 		)
 	}
 
 	for _, param := range params {
 		p := &arm.TemplateParameter{Type: "string"}
 		switch param {
-		case "disableCosmosDBFirewall":
+		case "disableCosmosDBFirewall",
+			"rpPrivateEndpointsEnabled":
 			p.Type = "bool"
 			p.DefaultValue = false
 		case "ipRules":
@@ -143,7 +151,13 @@ func (g *generator) rpTemplate() *arm.Template {
 		case "clustersInstallViaHive",
 			"clustersAdoptByHive",
 			"clusterDefaultInstallerPullspec",
-			"useCheckAccess":
+			"useCheckAccess",
+			"supportedRPVersions":
+			p.DefaultValue = ""
+		case "dnsRecordConcurrency",
+			"rpListPageSize",
+			"rpShutdownDrainPeriod",
+			"workerMachineSetConcurrency":
 			p.DefaultValue = ""
 		}
 		t.Parameters[param] = p
@@ -164,6 +178,8 @@ func (g *generator) rpTemplate() *arm.Template {
 					// AKS subnet design: https://docs.google.com/document/d/1gTGSW5S4uN1vB2hqVFKYr-qp6n62WbkdQMrKg-qvPbE
 				},
 			},
+			"rpKeyvaultPrivateDnsZoneName": "[replace(concat('privatelink', environment().suffixes.keyvaultDns), '.vault.', '.vaultcore.')]",
+			"rpStoragePrivateDnsZoneName":  "[concat('privatelink.blob.', environment().suffixes.storage)]",
 		}
 
 		t.Resources = append(t.Resources,
@@ -178,6 +194,7 @@ func (g *generator) rpTemplate() *arm.Template {
 			g.rpLBAlert(33.0, 2, "rp-vnet-alert", "PT5M", "PT5M", "VipAvailability"))          // this will trigger only if the Azure network infrastructure between the loadBalancers and VMs is down for 3.5min
 		// more on alerts https://msazure.visualstudio.com/AzureRedHatOpenShift/_wiki/wikis/ARO.wiki/53765/WIP-Alerting
 		t.Resources = append(t.Resources, g.rpBillingContributorRbac()...)
+		t.Resources = append(t.Resources, g.rpPrivateEndpoints()...)
 
 		t.Resources = append(t.Resources,
 			g.virtualNetworkPeering("rp-vnet/peering-gateway-vnet", "[resourceId(parameters('gatewayResourceGroupName'), 'Microsoft.Network/virtualNetworks', 'gateway-vnet')]", false, false, nil),
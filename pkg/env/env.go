@@ -34,6 +34,8 @@ const (
 	FeatureRequireD2sV3Workers
 	FeatureDisableReadinessDelay
 	FeatureEnableOCMEndpoints
+	FeatureRequirePrivateEndpoints
+	FeatureEnableOperationEvents
 )
 
 const (
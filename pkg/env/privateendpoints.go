@@ -0,0 +1,60 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+const (
+	StorageAccountDomain = "STORAGE_ACCOUNT_DOMAIN"
+)
+
+type ipAddrResolver interface {
+	LookupIPAddr(context.Context, string) ([]net.IPAddr, error)
+}
+
+type contextDialer interface {
+	DialContext(context.Context, string, string) (net.Conn, error)
+}
+
+// validatePrivateEndpoints checks that the host of each of the given URIs
+// resolves exclusively to private addresses, i.e. that the RP will reach it via
+// a private endpoint, and that the endpoint accepts connections.  It is used to
+// fail fast at startup on a hardened RP deployment where private endpoints or
+// their DNS records are missing.
+func validatePrivateEndpoints(ctx context.Context, resolver ipAddrResolver, dialer contextDialer, uris ...string) error {
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return err
+		}
+
+		addrs, err := resolver.LookupIPAddr(ctx, u.Hostname())
+		if err != nil {
+			return fmt.Errorf("private endpoint for %s is not resolvable: %w", u.Hostname(), err)
+		}
+
+		if len(addrs) == 0 {
+			return fmt.Errorf("private endpoint for %s is not resolvable: no addresses returned", u.Hostname())
+		}
+
+		for _, addr := range addrs {
+			if !addr.IP.IsPrivate() {
+				return fmt.Errorf("%s resolves to non-private address %s: expected a private endpoint", u.Hostname(), addr.IP)
+			}
+		}
+
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0].IP.String(), "443"))
+		if err != nil {
+			return fmt.Errorf("private endpoint for %s is not reachable: %w", u.Hostname(), err)
+		}
+		conn.Close()
+	}
+
+	return nil
+}
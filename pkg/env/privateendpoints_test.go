@@ -0,0 +1,101 @@
+package env
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeResolver map[string][]net.IPAddr
+
+func (r fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+// fakeDialer accepts connections to the addresses in it
+type fakeDialer map[string]bool
+
+func (d fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if !d[address] {
+		return nil, errors.New("connection refused")
+	}
+	c, _ := net.Pipe()
+	return c, nil
+}
+
+func TestValidatePrivateEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name     string
+		resolver fakeResolver
+		dialer   fakeDialer
+		wantErr  string
+	}{
+		{
+			name: "all private",
+			resolver: fakeResolver{
+				"kv-svc.vault.azure.net": {{IP: net.ParseIP("10.0.4.5")}},
+				"kv-cls.vault.azure.net": {{IP: net.ParseIP("10.0.4.6")}},
+			},
+			dialer: fakeDialer{
+				"10.0.4.5:443": true,
+				"10.0.4.6:443": true,
+			},
+		},
+		{
+			name: "not reachable",
+			resolver: fakeResolver{
+				"kv-svc.vault.azure.net": {{IP: net.ParseIP("10.0.4.5")}},
+				"kv-cls.vault.azure.net": {{IP: net.ParseIP("10.0.4.6")}},
+			},
+			dialer: fakeDialer{
+				"10.0.4.5:443": true,
+			},
+			wantErr: "private endpoint for kv-cls.vault.azure.net is not reachable: connection refused",
+		},
+		{
+			name: "public address",
+			resolver: fakeResolver{
+				"kv-svc.vault.azure.net": {{IP: net.ParseIP("10.0.4.5")}},
+				"kv-cls.vault.azure.net": {{IP: net.ParseIP("20.1.2.3")}},
+			},
+			dialer: fakeDialer{
+				"10.0.4.5:443": true,
+			},
+			wantErr: "kv-cls.vault.azure.net resolves to non-private address 20.1.2.3: expected a private endpoint",
+		},
+		{
+			name: "not resolvable",
+			resolver: fakeResolver{
+				"kv-svc.vault.azure.net": {{IP: net.ParseIP("10.0.4.5")}},
+			},
+			dialer: fakeDialer{
+				"10.0.4.5:443": true,
+			},
+			wantErr: "private endpoint for kv-cls.vault.azure.net is not resolvable: no such host",
+		},
+		{
+			name: "no addresses",
+			resolver: fakeResolver{
+				"kv-svc.vault.azure.net": {},
+			},
+			wantErr: "private endpoint for kv-svc.vault.azure.net is not resolvable: no addresses returned",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrivateEndpoints(ctx, tt.resolver, tt.dialer, "https://kv-svc.vault.azure.net/", "https://kv-cls.vault.azure.net/")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -139,6 +139,27 @@ func newProd(ctx context.Context, log *logrus.Entry) (*prod, error) {
 	}
 	keyVaultPrefix := os.Getenv(KeyvaultPrefix)
 	serviceKeyvaultURI := keyvault.URI(p, ServiceKeyvaultSuffix, keyVaultPrefix)
+	clusterKeyvaultURI := keyvault.URI(p, ClusterKeyvaultSuffix, keyVaultPrefix)
+
+	if p.FeatureIsSet(FeatureRequirePrivateEndpoints) {
+		if err := ValidateVars(StorageAccountDomain); err != nil {
+			return nil, err
+		}
+
+		// the dbtoken and portal services run on the same VMs as the RP, so
+		// their key vaults are checked here too
+		err = validatePrivateEndpoints(ctx, net.DefaultResolver, &net.Dialer{Timeout: 10 * time.Second},
+			serviceKeyvaultURI,
+			clusterKeyvaultURI,
+			keyvault.URI(p, DBTokenKeyvaultSuffix, keyVaultPrefix),
+			keyvault.URI(p, PortalKeyvaultSuffix, keyVaultPrefix),
+			"https://"+os.Getenv(StorageAccountDomain)+"/",
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	p.serviceKeyvault = keyvault.NewManager(msiKVAuthorizer, serviceKeyvaultURI)
 
 	resourceSkusClient := compute.NewResourceSkusClient(p.Environment(), p.SubscriptionID(), msiAuthorizer)
@@ -158,7 +179,6 @@ func newProd(ctx context.Context, log *logrus.Entry) (*prod, error) {
 		return nil, err
 	}
 
-	clusterKeyvaultURI := keyvault.URI(p, ClusterKeyvaultSuffix, keyVaultPrefix)
 	p.clusterKeyvault = keyvault.NewManager(localFPKVAuthorizer, clusterKeyvaultURI)
 
 	clusterGenevaLoggingPrivateKey, clusterGenevaLoggingCertificates, err := p.serviceKeyvault.GetCertificateSecret(ctx, ClusterLoggingSecretName)
@@ -0,0 +1,133 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+const (
+	defaultAvailabilityWindow = 24 * time.Hour
+	minAvailabilityWindow     = time.Hour
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/availability
+func (f *frontend) getAdminOpenShiftClusterAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	b, err := f._getAdminOpenShiftClusterAvailability(ctx, r)
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterAvailability(ctx context.Context, r *http.Request) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	window := defaultAvailabilityWindow
+	if s := r.URL.Query().Get("window"); s != "" {
+		var err error
+		window, err = time.ParseDuration(s)
+		if err != nil || window < minAvailabilityWindow || window > api.ClusterHealthRetention {
+			return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "window",
+				"The provided window '%s' is invalid: it must be a duration between %s and %s.",
+				s, minAvailabilityWindow, api.ClusterHealthRetention)
+		}
+	}
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	now := f.now()
+	windowStart := now.Add(-window)
+
+	docs, err := f.dbClusterHealth.ListByKey(ctx, doc.Key, windowStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(clusterAvailability(docs, windowStart, now))
+}
+
+// clusterAvailability summarises docs, sorted by the time at which they were
+// observed, over the window from start to end.  Each recorded health holds
+// until the next record, or for at most api.ClusterHealthValidity; the rest of
+// the window is not observed.
+func clusterAvailability(docs *api.ClusterHealthDocuments, start, end time.Time) *admin.ClusterAvailability {
+	a := &admin.ClusterAvailability{
+		WindowStart: start.UTC(),
+		WindowEnd:   end.UTC(),
+	}
+
+	var observed, unhealthy time.Duration
+	var prevUntil time.Time
+	var prevUnhealthy bool
+
+	for i, doc := range docs.ClusterHealthDocuments {
+		since := time.Unix(int64(doc.ClusterHealth.Since), 0)
+
+		until := since.Add(api.ClusterHealthValidity)
+		if i+1 < len(docs.ClusterHealthDocuments) {
+			if next := time.Unix(int64(docs.ClusterHealthDocuments[i+1].ClusterHealth.Since), 0); next.Before(until) {
+				until = next
+			}
+		}
+
+		from, to := since, until
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			prevUnhealthy = false
+			continue
+		}
+
+		observed += to.Sub(from)
+		if !doc.ClusterHealth.Healthy {
+			unhealthy += to.Sub(from)
+
+			// an unhealthy record which follows on from an unhealthy one
+			// continues its degraded interval
+			if !prevUnhealthy || !prevUntil.Equal(since) {
+				a.DegradedIntervals++
+			}
+		}
+
+		prevUntil = until
+		prevUnhealthy = !doc.ClusterHealth.Healthy
+	}
+
+	a.ObservedDuration = observed.String()
+	a.UnhealthyDuration = unhealthy.String()
+	if observed > 0 {
+		uptime := 100 * float64(observed-unhealthy) / float64(observed)
+		a.UptimePercentage = &uptime
+	}
+
+	return a
+}
@@ -0,0 +1,170 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminGetOpenShiftClusterAvailability(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+
+	now := time.Date(2023, time.July, 1, 12, 30, 0, 0, time.UTC)
+
+	type record struct {
+		at      time.Time
+		healthy bool
+	}
+
+	// the cluster was unhealthy for two hours, then healthy for a day, after
+	// which it was not monitored until it was unhealthy for three hours
+	records := []record{
+		{at: now.Add(-50 * time.Hour), healthy: false},
+		{at: now.Add(-49 * time.Hour), healthy: false},
+		{at: now.Add(-48 * time.Hour), healthy: true},
+		{at: now.Add(-6 * time.Hour), healthy: false},
+		{at: now.Add(-3 * time.Hour), healthy: true},
+		{at: now.Add(-90 * time.Minute), healthy: true},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		query          string
+		fixture        func(*testdatabase.Fixture)
+		records        []record
+		wantStatusCode int
+		wantResponse   *admin.ClusterAvailability
+		wantError      string
+	}{
+		{
+			name: "default window",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+			},
+			records:        records,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterAvailability{
+				WindowStart:       now.Add(-24 * time.Hour),
+				WindowEnd:         now,
+				ObservedDuration:  "6h0m0s",
+				UnhealthyDuration: "3h0m0s",
+				UptimePercentage:  to.Float64Ptr(50),
+				DegradedIntervals: 1,
+			},
+		},
+		{
+			name:  "explicit window",
+			query: "?window=72h",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+			},
+			records:        records,
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterAvailability{
+				WindowStart:       now.Add(-72 * time.Hour),
+				WindowEnd:         now,
+				ObservedDuration:  "32h0m0s",
+				UnhealthyDuration: "5h0m0s",
+				UptimePercentage:  to.Float64Ptr(84.375),
+				DegradedIntervals: 2,
+			},
+		},
+		{
+			name: "no health history",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterAvailability{
+				WindowStart:       now.Add(-24 * time.Hour),
+				WindowEnd:         now,
+				ObservedDuration:  "0s",
+				UnhealthyDuration: "0s",
+			},
+		},
+		{
+			name:           "invalid window",
+			query:          "?window=invalid",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: window: The provided window 'invalid' is invalid: it must be a duration between 1h0m0s and 720h0m0s.",
+		},
+		{
+			name:           "window exceeds retention",
+			query:          "?window=1000h",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: window: The provided window '1000h' is invalid: it must be a duration between 1h0m0s and 720h0m0s.",
+		},
+		{
+			name:           "cluster not found",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithClusterHealth()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range tt.records {
+				_, err = ti.clusterHealthDatabase.RecordHealth(ctx, "id", strings.ToLower(resourceID), r.at, r.healthy)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, ti.clusterHealthDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/availability%s", resourceID, tt.query),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
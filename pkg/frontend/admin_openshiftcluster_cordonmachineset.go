@@ -0,0 +1,142 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+const (
+	machineAPINamespace = "openshift-machine-api"
+	machineSetLabel     = "machine.openshift.io/cluster-api-machineset"
+)
+
+var machineSetResource = schema.GroupVersionResource{
+	Group:    "machine.openshift.io",
+	Resource: "machinesets",
+}
+
+// machineSetCordonResult is returned by the cordonmachineset and
+// uncordonmachineset admin actions
+type machineSetCordonResult struct {
+	// Nodes are the nodes of the machine set which were cordoned or
+	// uncordoned
+	Nodes []string `json:"nodes"`
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/cordonmachineset
+func (f *frontend) postAdminOpenShiftClusterCordonMachineSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterCordonMachineSet(ctx, r, log, true)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/uncordonmachineset
+func (f *frontend) postAdminOpenShiftClusterUncordonMachineSet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postAdminOpenShiftClusterCordonMachineSet(ctx, r, log, false)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _postAdminOpenShiftClusterCordonMachineSet cordons or uncordons all the
+// nodes of a machine set.  Draining the nodes takes too long for an admin
+// action: it is done by the DrainMachineSet maintenance task.
+func (f *frontend) _postAdminOpenShiftClusterCordonMachineSet(ctx context.Context, r *http.Request, log *logrus.Entry, shouldCordon bool) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	machineSetName := r.URL.Query().Get("machineSetName")
+	err := validateAdminKubernetesObjects(r.Method, machineSetResource, machineAPINamespace, machineSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := machineSetNodes(ctx, k, machineSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &machineSetCordonResult{
+		Nodes: nodes,
+	}
+
+	for _, node := range nodes {
+		log.Infof("setting node %s unschedulable %t", node, shouldCordon)
+		err = k.CordonNode(ctx, node, shouldCordon)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.MarshalIndent(result, "", "    ")
+}
+
+// machineSetNodes returns the names of the nodes of the machine set's
+// machines.  Machines which don't have a node yet are skipped.
+func machineSetNodes(ctx context.Context, k adminactions.KubeActions, machineSetName string) ([]string, error) {
+	// return a not found error if the machine set doesn't exist
+	_, err := k.KubeGet(ctx, "MachineSet.machine.openshift.io", machineAPINamespace, machineSetName)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := k.KubeList(ctx, "Machine.machine.openshift.io", machineAPINamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var machines machinev1beta1.MachineList
+	err = json.Unmarshal(b, &machines)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []string{}
+	for _, machine := range machines.Items {
+		if machine.Labels[machineSetLabel] == machineSetName && machine.Status.NodeRef != nil {
+			nodes = append(nodes, machine.Status.NodeRef.Name)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
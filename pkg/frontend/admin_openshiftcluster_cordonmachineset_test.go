@@ -0,0 +1,161 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminCordonMachineSet(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	machineSetName := "aro-worker-australiasoutheast1"
+
+	ctx := context.Background()
+
+	machine := func(name, machineSet, nodeName string) machinev1beta1.Machine {
+		m := machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{machineSetLabel: machineSet},
+			},
+		}
+		if nodeName != "" {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: nodeName}
+		}
+		return m
+	}
+
+	b, err := json.Marshal(&machinev1beta1.MachineList{
+		Items: []machinev1beta1.Machine{
+			machine("m2", machineSetName, "node-2"),
+			machine("m1", machineSetName, "node-1"),
+			machine("m3", machineSetName, ""),
+			machine("other", "aro-worker-australiasoutheast2", "node-other"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineSetExists := func(k *mock_adminactions.MockKubeActions) {
+		k.EXPECT().KubeGet(gomock.Any(), "MachineSet.machine.openshift.io", "openshift-machine-api", machineSetName).Return(nil, nil)
+		k.EXPECT().KubeList(gomock.Any(), "Machine.machine.openshift.io", "openshift-machine-api").Return(b, nil)
+	}
+
+	type test struct {
+		name           string
+		action         string
+		mocks          func(*mock_adminactions.MockKubeActions)
+		wantStatusCode int
+		wantResponse   *machineSetCordonResult
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:   "nodes are cordoned",
+			action: "cordonmachineset",
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				machineSetExists(k)
+				k.EXPECT().CordonNode(gomock.Any(), "node-1", true).Return(nil)
+				k.EXPECT().CordonNode(gomock.Any(), "node-2", true).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &machineSetCordonResult{
+				Nodes: []string{"node-1", "node-2"},
+			},
+		},
+		{
+			name:   "nodes are uncordoned",
+			action: "uncordonmachineset",
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				machineSetExists(k)
+				k.EXPECT().CordonNode(gomock.Any(), "node-1", false).Return(nil)
+				k.EXPECT().CordonNode(gomock.Any(), "node-2", false).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &machineSetCordonResult{
+				Nodes: []string{"node-1", "node-2"},
+			},
+		},
+		{
+			name:   "machine set not found",
+			action: "cordonmachineset",
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "MachineSet.machine.openshift.io", "openshift-machine-api", machineSetName).
+					Return(nil, kerrors.NewNotFound(schema.GroupResource{Group: "machine.openshift.io", Resource: "machinesets"}, machineSetName))
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: machinesets.machine.openshift.io/" + machineSetName + ": machinesets.machine.openshift.io \"" + machineSetName + "\" not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			tt.mocks(k)
+
+			resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: fmt.Sprintf("/subscriptions/%s/resourceGroups/test-cluster", mockSubID),
+							},
+						},
+					},
+				})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/%s?machineSetName=%s", resourceID, tt.action, machineSetName),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -382,6 +382,7 @@ func TestAdminEtcdCertificateRenew(t *testing.T) {
 				ti.openShiftClustersDatabase,
 				ti.subscriptionsDatabase,
 				nil,
+				nil, nil,
 				api.APIs,
 				&noop.Noop{},
 				&noop.Noop{},
@@ -599,6 +600,7 @@ func TestAdminEtcdCertificateRecovery(t *testing.T) {
 				ti.openShiftClustersDatabase,
 				ti.subscriptionsDatabase,
 				nil,
+				nil, nil,
 				api.APIs,
 				&noop.Noop{},
 				&noop.Noop{},
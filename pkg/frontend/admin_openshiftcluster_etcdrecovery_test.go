@@ -167,6 +167,7 @@ func TestAdminEtcdRecovery(t *testing.T) {
 				ti.openShiftClustersDatabase,
 				ti.subscriptionsDatabase,
 				nil,
+				nil, nil,
 				api.APIs,
 				&noop.Noop{},
 				&noop.Noop{},
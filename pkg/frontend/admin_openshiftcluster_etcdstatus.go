@@ -0,0 +1,107 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+	"github.com/Azure/ARO-RP/pkg/util/restconfig"
+)
+
+func newEtcdClient(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster) (etcd.Client, error) {
+	restConfig, err := restconfig.RestConfig(env, oc)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return etcd.NewClient(log, restConfig, cli), nil
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/etcdstatus
+func (f *frontend) getAdminOpenShiftClusterEtcdStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	b, err := f._getAdminOpenShiftClusterEtcdStatus(ctx, r, log)
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminOpenShiftClusterEtcdStatus(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	etcdcli, err := f.getEtcdClient(ctx, r, log)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := etcdcli.Members(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := etcdMemberStatuses(ctx, etcdcli, members)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(statuses)
+}
+
+func (f *frontend) getEtcdClient(ctx context.Context, r *http.Request, log *logrus.Entry) (etcd.Client, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	return f.etcdClientFactory(log, f.env, doc.OpenShiftCluster)
+}
+
+// etcdMemberStatuses returns the status of each member
+func etcdMemberStatuses(ctx context.Context, etcdcli etcd.Client, members []etcd.Member) (*admin.EtcdMemberStatusList, error) {
+	statuses := &admin.EtcdMemberStatusList{
+		Members: make([]*admin.EtcdMemberStatus, 0, len(members)),
+	}
+	for _, member := range members {
+		status, err := etcdcli.Status(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses.Members = append(statuses.Members, &admin.EtcdMemberStatus{
+			Name:                    member.Name,
+			Leader:                  status.Leader,
+			DBSize:                  status.DBSize,
+			DBSizeInUse:             status.DBSizeInUse,
+			FragmentationPercentage: status.FragmentationPercentage(),
+		})
+	}
+
+	return statuses, nil
+}
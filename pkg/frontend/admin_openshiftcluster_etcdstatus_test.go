@@ -0,0 +1,127 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+	mock_etcd "github.com/Azure/ARO-RP/pkg/util/mocks/etcd"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminEtcdStatus(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+
+	members := []etcd.Member{
+		{Name: "etcd-master-0", IP: "10.0.0.6"},
+		{Name: "etcd-master-1", IP: "10.0.0.7"},
+		{Name: "etcd-master-2", IP: "10.0.0.8"},
+	}
+
+	status := func(leader bool, dbSize int64) *etcd.Status {
+		return &etcd.Status{
+			Leader:      leader,
+			DBSize:      dbSize,
+			DBSizeInUse: 100,
+		}
+	}
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+			},
+		})
+	}
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		path           string
+		fixture        func(*testdatabase.Fixture)
+		mocks          func(*mock_etcd.MockClient)
+		wantStatusCode int
+		wantResponse   *admin.EtcdMemberStatusList
+		wantError      string
+	}{
+		{
+			name:    "status",
+			method:  http.MethodGet,
+			path:    "etcdstatus",
+			fixture: fixture,
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[0]).Return(status(false, 400), nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[1]).Return(status(true, 200), nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[2]).Return(status(false, 100), nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.EtcdMemberStatusList{
+				Members: []*admin.EtcdMemberStatus{
+					{Name: "etcd-master-0", DBSize: 400, DBSizeInUse: 100, FragmentationPercentage: 75},
+					{Name: "etcd-master-1", Leader: true, DBSize: 200, DBSizeInUse: 100, FragmentationPercentage: 50},
+					{Name: "etcd-master-2", DBSize: 100, DBSizeInUse: 100},
+				},
+			},
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodGet,
+			path:           "etcdstatus",
+			mocks:          func(etcdcli *mock_etcd.MockClient) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			etcdcli := mock_etcd.NewMockClient(ti.controller)
+			tt.mocks(etcdcli)
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.etcdClientFactory = func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (etcd.Client, error) {
+				return etcdcli, nil
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/%s", resourceID, tt.path),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
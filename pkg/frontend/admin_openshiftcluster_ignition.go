@@ -0,0 +1,123 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/ignition"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/ignition?role={bootstrap,master,worker}
+func (f *frontend) getAdminOpenShiftClusterIgnition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	b, err := f._getAdminOpenShiftClusterIgnition(ctx, r, log)
+	adminReply(log, w, nil, b, err)
+}
+
+// _getAdminOpenShiftClusterIgnition returns the generated ignition config of
+// the given role with its secrets redacted, as described in ignition.Redact.
+// The bootstrap ignition is read from the cluster storage account and only
+// exists until the bootstrap node is removed; the master and worker ignition
+// is the rendered MachineConfig of the corresponding MachineConfigPool.
+func (f *frontend) _getAdminOpenShiftClusterIgnition(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	role := r.URL.Query().Get("role")
+	switch role {
+	case "bootstrap", "master", "worker":
+	default:
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "role",
+			"The provided role '%s' is invalid: must be one of bootstrap, master or worker.", role)
+	}
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	var b []byte
+	if role == "bootstrap" {
+		subscriptionDoc, err := f.getSubscriptionDocument(ctx, doc.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		azureActions, err := f.azureActionsFactory(log, f.env, doc.OpenShiftCluster, subscriptionDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err = azureActions.BootstrapIgnition(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		kubeActions, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err = renderedMachineConfigIgnition(ctx, kubeActions, role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ignition.RedactJSON(b)
+}
+
+// renderedMachineConfigIgnition returns the ignition config of the rendered
+// MachineConfig currently targeted by the named MachineConfigPool
+func renderedMachineConfigIgnition(ctx context.Context, kubeActions adminactions.KubeActions, pool string) ([]byte, error) {
+	b, err := kubeActions.KubeGet(ctx, "MachineConfigPool.machineconfiguration.openshift.io", "", pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var mcp mcv1.MachineConfigPool
+	err = json.Unmarshal(b, &mcp)
+	if err != nil {
+		return nil, err
+	}
+
+	if mcp.Spec.Configuration.Name == "" {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "",
+			"The MachineConfigPool '%s' does not have a rendered MachineConfig yet.", pool)
+	}
+
+	b, err = kubeActions.KubeGet(ctx, "MachineConfig.machineconfiguration.openshift.io", "", mcp.Spec.Configuration.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var mc mcv1.MachineConfig
+	err = json.Unmarshal(b, &mc)
+	if err != nil {
+		return nil, err
+	}
+
+	return mc.Spec.Config.Raw, nil
+}
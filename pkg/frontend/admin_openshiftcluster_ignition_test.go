@@ -0,0 +1,158 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+)
+
+func TestAdminGetOpenShiftClusterIgnition(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	mockTenantID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	ignitionConfig := []byte(`{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/var/lib/kubelet/config.json","contents":{"source":"data:,secret"}}]}}`)
+	redactedConfig := []byte(`{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/var/lib/kubelet/config.json","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}}]},"systemd":{}}` + "\n")
+
+	type test struct {
+		name           string
+		role           string
+		kubeMocks      func(*mock_adminactions.MockKubeActions)
+		azureMocks     func(*mock_adminactions.MockAzureActions)
+		wantStatusCode int
+		wantResponse   []byte
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "master ignition is redacted",
+			role: "master",
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().
+					KubeGet(gomock.Any(), "MachineConfigPool.machineconfiguration.openshift.io", "", "master").
+					Return([]byte(`{"spec":{"configuration":{"name":"rendered-master-1"}}}`), nil)
+				k.EXPECT().
+					KubeGet(gomock.Any(), "MachineConfig.machineconfiguration.openshift.io", "", "rendered-master-1").
+					Return([]byte(`{"spec":{"config":`+string(ignitionConfig)+`}}`), nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   redactedConfig,
+		},
+		{
+			name: "worker pool without a rendered config",
+			role: "worker",
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().
+					KubeGet(gomock.Any(), "MachineConfigPool.machineconfiguration.openshift.io", "", "worker").
+					Return([]byte(`{"spec":{}}`), nil)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The MachineConfigPool 'worker' does not have a rendered MachineConfig yet.",
+		},
+		{
+			name: "bootstrap ignition is redacted",
+			role: "bootstrap",
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().
+					BootstrapIgnition(gomock.Any()).
+					Return(ignitionConfig, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse:   redactedConfig,
+		},
+		{
+			name: "bootstrap ignition was removed",
+			role: "bootstrap",
+			azureMocks: func(a *mock_adminactions.MockAzureActions) {
+				a.EXPECT().
+					BootstrapIgnition(gomock.Any()).
+					Return(nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "The bootstrap ignition config was not found. It is removed together with the bootstrap node."))
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The bootstrap ignition config was not found. It is removed together with the bootstrap node.",
+		},
+		{
+			name:           "invalid role",
+			role:           "infra",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: role: The provided role 'infra' is invalid: must be one of bootstrap, master or worker.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if tt.kubeMocks != nil {
+				tt.kubeMocks(k)
+			}
+
+			a := mock_adminactions.NewMockAzureActions(ti.controller)
+			if tt.azureMocks != nil {
+				tt.azureMocks(a)
+			}
+
+			ti.fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   resourceID,
+					Name: "resourceName",
+					Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				},
+			})
+			ti.fixture.AddSubscriptionDocuments(&api.SubscriptionDocument{
+				ID: mockSubID,
+				Subscription: &api.Subscription{
+					State: api.SubscriptionStateRegistered,
+					Properties: &api.SubscriptionProperties{
+						TenantID: mockTenantID,
+					},
+				},
+			})
+
+			err := ti.buildFixtures(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error) {
+				return a, nil
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/ignition?role=%s", resourceID, tt.role),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
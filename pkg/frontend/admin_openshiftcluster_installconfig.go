@@ -0,0 +1,168 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/ignition"
+)
+
+const (
+	// the installer records the install-config in the install-config key
+	// of this ConfigMap
+	installConfigMapNamespace = "kube-system"
+	installConfigMapName      = "cluster-config-v1"
+	installConfigMapKey       = "install-config"
+
+	// installConfigClusterSpecific replaces the install-config values which
+	// identify the cluster, so that the install-configs of different
+	// clusters can be diffed
+	installConfigClusterSpecific = "[CLUSTER-SPECIFIC]"
+)
+
+// rxInstallConfigSecretKey matches the install-config keys whose values are
+// secrets, e.g. pullSecret
+var rxInstallConfigSecretKey = regexp.MustCompile(`(?i)(secret|password|token|credential)`)
+
+// installConfigClusterSpecificFields are the paths of the install-config
+// values which identify the cluster rather than configure it
+var installConfigClusterSpecificFields = [][]string{
+	{"metadata", "name"},
+	{"metadata", "creationTimestamp"},
+	{"baseDomain"},
+	{"sshKey"},
+	{"platform", "azure", "resourceGroupName"},
+	{"platform", "azure", "baseDomainResourceGroupName"},
+	{"platform", "azure", "networkResourceGroupName"},
+	{"platform", "azure", "virtualNetwork"},
+	{"platform", "azure", "controlPlaneSubnet"},
+	{"platform", "azure", "computeSubnet"},
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/installconfig
+func (f *frontend) getAdminOpenShiftClusterInstallConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	b, err := f._getAdminOpenShiftClusterInstallConfig(ctx, r, log)
+	adminReply(log, w, nil, b, err)
+}
+
+// _getAdminOpenShiftClusterInstallConfig returns the install-config which the
+// installer recorded in the cluster, redacted and normalized as described in
+// redactedInstallConfig, for fleet consistency audits
+func (f *frontend) _getAdminOpenShiftClusterInstallConfig(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	kubeActions, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := kubeActions.KubeGet(ctx, "ConfigMap", installConfigMapNamespace, installConfigMapName)
+	if err != nil {
+		return nil, err
+	}
+
+	var cm corev1.ConfigMap
+	err = json.Unmarshal(b, &cm)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := cm.Data[installConfigMapKey]
+	if !ok {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "",
+			"The ConfigMap '%s/%s' does not have an install-config.", installConfigMapNamespace, installConfigMapName)
+	}
+
+	installConfig, err := redactedInstallConfig([]byte(data))
+	if err != nil {
+		return nil, api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "",
+			"The install-config of the ConfigMap '%s/%s' could not be parsed.", installConfigMapNamespace, installConfigMapName)
+	}
+
+	return json.MarshalIndent(installConfig, "", "    ")
+}
+
+// redactedInstallConfig parses an install-config and returns it without
+// secrets, in a form which can be diffed against the install-configs of other
+// clusters:
+//
+// * the values of keys which look like secrets, e.g. pullSecret, and PEM
+// encoded private keys are replaced by ignition.Redacted
+//
+// * the values which identify the cluster, e.g. its name, base domain and
+// resource groups, are replaced by installConfigClusterSpecific
+//
+// The result marshals with its keys sorted.
+func redactedInstallConfig(b []byte) (map[string]interface{}, error) {
+	var installConfig map[string]interface{}
+	err := yaml.Unmarshal(b, &installConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	redactInstallConfigValue(installConfig)
+
+	for _, path := range installConfigClusterSpecificFields {
+		m := installConfig
+		for _, key := range path[:len(path)-1] {
+			m, _ = m[key].(map[string]interface{})
+		}
+		if v, found := m[path[len(path)-1]]; found && v != nil {
+			m[path[len(path)-1]] = installConfigClusterSpecific
+		}
+	}
+
+	return installConfig, nil
+}
+
+func redactInstallConfigValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if rxInstallConfigSecretKey.MatchString(key) && value != nil {
+				v[key] = ignition.Redacted
+				continue
+			}
+			v[key] = redactInstallConfigValue(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactInstallConfigValue(v[i])
+		}
+	case string:
+		if strings.Contains(v, "PRIVATE KEY-----") {
+			return ignition.Redacted
+		}
+	}
+	return v
+}
@@ -0,0 +1,268 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	configv1 "github.com/openshift/api/config/v1"
+	configv1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	instanceTypeLabel   = "node.kubernetes.io/instance-type"
+
+	// olmCopiedFromLabel is set on the copies of a ClusterServiceVersion
+	// which OLM makes in each namespace watched by the operator
+	olmCopiedFromLabel = "olm.copiedFrom"
+)
+
+// inventoryTimeout bounds the time spent collecting each part of the
+// inventory, so that a cluster whose API is unreachable doesn't hold the
+// request
+var inventoryTimeout = 30 * time.Second
+
+// inventoryMaxItems bounds the number of items returned in each list of the
+// inventory
+var inventoryMaxItems = 500
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/inventory
+func (f *frontend) getAdminOpenShiftClusterInventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminOpenShiftClusterInventory(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _getAdminOpenShiftClusterInventory returns the nodes, cluster operators and
+// installed operators of the cluster.  The parts of the inventory which can't
+// be collected, e.g. because the cluster's API is unreachable, are recorded
+// as errors in the returned snapshot rather than failing the request.
+func (f *frontend) _getAdminOpenShiftClusterInventory(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	inventory := &admin.ClusterInventory{
+		Version:          doc.OpenShiftCluster.Properties.ClusterProfile.Version,
+		Nodes:            []*admin.InventoryNode{},
+		ClusterOperators: []*admin.InventoryClusterOperator{},
+		Operators:        []*admin.InventoryOperator{},
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		log.Warn(err)
+		inventory.Errors = append(inventory.Errors, fmt.Sprintf("connecting to the cluster: %v", err))
+		return json.MarshalIndent(inventory, "", "    ")
+	}
+
+	for _, part := range []struct {
+		name    string
+		collect func(context.Context, adminactions.KubeActions, *admin.ClusterInventory) error
+	}{
+		{name: "cluster version", collect: inventoryClusterVersion},
+		{name: "nodes", collect: inventoryNodes},
+		{name: "cluster operators", collect: inventoryClusterOperators},
+		{name: "operators", collect: inventoryOperators},
+	} {
+		err = collectInventory(ctx, k, inventory, part.collect)
+		if err != nil {
+			log.Warnf("collecting %s: %v", part.name, err)
+			inventory.Errors = append(inventory.Errors, fmt.Sprintf("collecting %s: %v", part.name, err))
+		}
+	}
+
+	return json.MarshalIndent(inventory, "", "    ")
+}
+
+func collectInventory(ctx context.Context, k adminactions.KubeActions, inventory *admin.ClusterInventory, collect func(context.Context, adminactions.KubeActions, *admin.ClusterInventory) error) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, inventoryTimeout)
+	defer cancel()
+
+	return collect(timeoutCtx, k, inventory)
+}
+
+func inventoryClusterVersion(ctx context.Context, k adminactions.KubeActions, inventory *admin.ClusterInventory) error {
+	b, err := k.KubeGet(ctx, "ClusterVersion.config.openshift.io", "", "version")
+	if err != nil {
+		return err
+	}
+
+	var cv configv1.ClusterVersion
+	err = json.Unmarshal(b, &cv)
+	if err != nil {
+		return err
+	}
+
+	v, err := version.GetClusterVersion(&cv)
+	if err != nil {
+		return err
+	}
+
+	inventory.Version = v.String()
+
+	return nil
+}
+
+func inventoryNodes(ctx context.Context, k adminactions.KubeActions, inventory *admin.ClusterInventory) error {
+	b, err := k.KubeList(ctx, "Node", "")
+	if err != nil {
+		return err
+	}
+
+	var nodes corev1.NodeList
+	err = json.Unmarshal(b, &nodes)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+
+	for _, node := range nodes.Items {
+		if len(inventory.Nodes) == inventoryMaxItems {
+			inventory.Truncated = true
+			break
+		}
+
+		n := &admin.InventoryNode{
+			Name:           node.Name,
+			InstanceType:   node.Labels[instanceTypeLabel],
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+			OSImage:        node.Status.NodeInfo.OSImage,
+			Unschedulable:  node.Spec.Unschedulable,
+		}
+
+		for label := range node.Labels {
+			if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+				n.Roles = append(n.Roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+			}
+		}
+		sort.Strings(n.Roles)
+
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady {
+				n.Ready = c.Status == corev1.ConditionTrue
+			}
+		}
+
+		inventory.Nodes = append(inventory.Nodes, n)
+	}
+
+	return nil
+}
+
+func inventoryClusterOperators(ctx context.Context, k adminactions.KubeActions, inventory *admin.ClusterInventory) error {
+	b, err := k.KubeList(ctx, "ClusterOperator.config.openshift.io", "")
+	if err != nil {
+		return err
+	}
+
+	var cos configv1.ClusterOperatorList
+	err = json.Unmarshal(b, &cos)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(cos.Items, func(i, j int) bool { return cos.Items[i].Name < cos.Items[j].Name })
+
+	for _, co := range cos.Items {
+		if len(inventory.ClusterOperators) == inventoryMaxItems {
+			inventory.Truncated = true
+			break
+		}
+
+		o := &admin.InventoryClusterOperator{
+			Name:        co.Name,
+			Available:   configv1helpers.IsStatusConditionTrue(co.Status.Conditions, configv1.OperatorAvailable),
+			Progressing: configv1helpers.IsStatusConditionTrue(co.Status.Conditions, configv1.OperatorProgressing),
+			Degraded:    configv1helpers.IsStatusConditionTrue(co.Status.Conditions, configv1.OperatorDegraded),
+		}
+
+		for _, v := range co.Status.Versions {
+			if v.Name == "operator" {
+				o.Version = v.Version
+			}
+		}
+
+		inventory.ClusterOperators = append(inventory.ClusterOperators, o)
+	}
+
+	return nil
+}
+
+// inventoryOperators lists the ClusterServiceVersions installed by OLM.  The
+// OLM types aren't vendored, so the ClusterServiceVersions are read as
+// unstructured objects.
+func inventoryOperators(ctx context.Context, k adminactions.KubeActions, inventory *admin.ClusterInventory) error {
+	b, err := k.KubeList(ctx, "ClusterServiceVersion.operators.coreos.com", "")
+	if err != nil {
+		return err
+	}
+
+	var csvs unstructured.UnstructuredList
+	err = csvs.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(csvs.Items, func(i, j int) bool {
+		if csvs.Items[i].GetNamespace() != csvs.Items[j].GetNamespace() {
+			return csvs.Items[i].GetNamespace() < csvs.Items[j].GetNamespace()
+		}
+		return csvs.Items[i].GetName() < csvs.Items[j].GetName()
+	})
+
+	for _, csv := range csvs.Items {
+		if _, ok := csv.GetLabels()[olmCopiedFromLabel]; ok {
+			continue
+		}
+
+		if len(inventory.Operators) == inventoryMaxItems {
+			inventory.Truncated = true
+			break
+		}
+
+		o := &admin.InventoryOperator{
+			Namespace: csv.GetNamespace(),
+			Name:      csv.GetName(),
+		}
+		o.Version, _, _ = unstructured.NestedString(csv.Object, "spec", "version")
+		o.Phase, _, _ = unstructured.NestedString(csv.Object, "status", "phase")
+
+		inventory.Operators = append(inventory.Operators, o)
+	}
+
+	return nil
+}
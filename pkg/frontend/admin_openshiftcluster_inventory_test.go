@@ -0,0 +1,325 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminGetInventory(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+
+	ctx := context.Background()
+
+	marshal := func(v interface{}) []byte {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	node := func(name, role string, ready bool) corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"node-role.kubernetes.io/" + role:  "",
+					"node.kubernetes.io/instance-type": "Standard_D8s_v3",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+				NodeInfo: corev1.NodeSystemInfo{
+					KubeletVersion: "v1.25.7",
+					OSImage:        "Red Hat Enterprise Linux CoreOS",
+				},
+			},
+		}
+	}
+
+	clusterVersion := marshal(&configv1.ClusterVersion{
+		Status: configv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: "4.12.25"}},
+		},
+	})
+
+	nodes := marshal(&corev1.NodeList{
+		Items: []corev1.Node{
+			node("worker-1", "worker", false),
+			node("master-0", "master", true),
+		},
+	})
+
+	clusterOperators := marshal(&configv1.ClusterOperatorList{
+		Items: []configv1.ClusterOperator{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+				Status: configv1.ClusterOperatorStatus{
+					Conditions: []configv1.ClusterOperatorStatusCondition{
+						{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+						{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue},
+					},
+					Versions: []configv1.OperandVersion{
+						{Name: "operator", Version: "4.12.25"},
+						{Name: "ingress-controller", Version: "sha256:0000"},
+					},
+				},
+			},
+		},
+	})
+
+	csvs := []byte(`{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind": "ClusterServiceVersionList",
+		"items": [
+			{
+				"metadata": {"namespace": "openshift-logging", "name": "cluster-logging.v5.7.2"},
+				"spec": {"version": "5.7.2"},
+				"status": {"phase": "Succeeded"}
+			},
+			{
+				"metadata": {"namespace": "customer", "name": "cluster-logging.v5.7.2", "labels": {"olm.copiedFrom": "openshift-logging"}},
+				"spec": {"version": "5.7.2"},
+				"status": {"phase": "Succeeded"}
+			}
+		]
+	}`)
+
+	type test struct {
+		name           string
+		maxItems       int
+		kubeActionsErr error
+		mocks          func(*mock_adminactions.MockKubeActions)
+		wantStatusCode int
+		wantResponse   *admin.ClusterInventory
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "inventory is returned",
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "ClusterVersion.config.openshift.io", "", "version").Return(clusterVersion, nil)
+				k.EXPECT().KubeList(gomock.Any(), "Node", "").Return(nodes, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterOperator.config.openshift.io", "").Return(clusterOperators, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterServiceVersion.operators.coreos.com", "").Return(csvs, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterInventory{
+				Version: "4.12.25",
+				Nodes: []*admin.InventoryNode{
+					{
+						Name:           "master-0",
+						Roles:          []string{"master"},
+						InstanceType:   "Standard_D8s_v3",
+						KubeletVersion: "v1.25.7",
+						OSImage:        "Red Hat Enterprise Linux CoreOS",
+						Ready:          true,
+					},
+					{
+						Name:           "worker-1",
+						Roles:          []string{"worker"},
+						InstanceType:   "Standard_D8s_v3",
+						KubeletVersion: "v1.25.7",
+						OSImage:        "Red Hat Enterprise Linux CoreOS",
+					},
+				},
+				ClusterOperators: []*admin.InventoryClusterOperator{
+					{
+						Name:      "ingress",
+						Version:   "4.12.25",
+						Available: true,
+						Degraded:  true,
+					},
+				},
+				Operators: []*admin.InventoryOperator{
+					{
+						Namespace: "openshift-logging",
+						Name:      "cluster-logging.v5.7.2",
+						Version:   "5.7.2",
+						Phase:     "Succeeded",
+					},
+				},
+			},
+		},
+		{
+			name:     "lists are truncated",
+			maxItems: 1,
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "ClusterVersion.config.openshift.io", "", "version").Return(clusterVersion, nil)
+				k.EXPECT().KubeList(gomock.Any(), "Node", "").Return(nodes, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterOperator.config.openshift.io", "").Return(clusterOperators, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterServiceVersion.operators.coreos.com", "").Return(csvs, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterInventory{
+				Version: "4.12.25",
+				Nodes: []*admin.InventoryNode{
+					{
+						Name:           "master-0",
+						Roles:          []string{"master"},
+						InstanceType:   "Standard_D8s_v3",
+						KubeletVersion: "v1.25.7",
+						OSImage:        "Red Hat Enterprise Linux CoreOS",
+						Ready:          true,
+					},
+				},
+				ClusterOperators: []*admin.InventoryClusterOperator{
+					{
+						Name:      "ingress",
+						Version:   "4.12.25",
+						Available: true,
+						Degraded:  true,
+					},
+				},
+				Operators: []*admin.InventoryOperator{
+					{
+						Namespace: "openshift-logging",
+						Name:      "cluster-logging.v5.7.2",
+						Version:   "5.7.2",
+						Phase:     "Succeeded",
+					},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name: "partial inventory is returned if parts can't be collected",
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "ClusterVersion.config.openshift.io", "", "version").Return(nil, errors.New("context deadline exceeded"))
+				k.EXPECT().KubeList(gomock.Any(), "Node", "").Return(nodes, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterOperator.config.openshift.io", "").Return(clusterOperators, nil)
+				k.EXPECT().KubeList(gomock.Any(), "ClusterServiceVersion.operators.coreos.com", "").Return(nil, errors.New(`the server doesn't have a resource type "ClusterServiceVersion"`))
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterInventory{
+				Version: "4.12.0",
+				Nodes: []*admin.InventoryNode{
+					{
+						Name:           "master-0",
+						Roles:          []string{"master"},
+						InstanceType:   "Standard_D8s_v3",
+						KubeletVersion: "v1.25.7",
+						OSImage:        "Red Hat Enterprise Linux CoreOS",
+						Ready:          true,
+					},
+					{
+						Name:           "worker-1",
+						Roles:          []string{"worker"},
+						InstanceType:   "Standard_D8s_v3",
+						KubeletVersion: "v1.25.7",
+						OSImage:        "Red Hat Enterprise Linux CoreOS",
+					},
+				},
+				ClusterOperators: []*admin.InventoryClusterOperator{
+					{
+						Name:      "ingress",
+						Version:   "4.12.25",
+						Available: true,
+						Degraded:  true,
+					},
+				},
+				Operators: []*admin.InventoryOperator{},
+				Errors: []string{
+					"collecting cluster version: context deadline exceeded",
+					`collecting operators: the server doesn't have a resource type "ClusterServiceVersion"`,
+				},
+			},
+		},
+		{
+			name:           "partial inventory is returned if the cluster is unreachable",
+			kubeActionsErr: errors.New("dial tcp 10.0.0.1:6443: i/o timeout"),
+			mocks:          func(k *mock_adminactions.MockKubeActions) {},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterInventory{
+				Version:          "4.12.0",
+				Nodes:            []*admin.InventoryNode{},
+				ClusterOperators: []*admin.InventoryClusterOperator{},
+				Operators:        []*admin.InventoryOperator{},
+				Errors: []string{
+					"connecting to the cluster: dial tcp 10.0.0.1:6443: i/o timeout",
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.maxItems != 0 {
+				oldMaxItems := inventoryMaxItems
+				defer func() { inventoryMaxItems = oldMaxItems }()
+				inventoryMaxItems = tt.maxItems
+			}
+
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			tt.mocks(k)
+
+			resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								ResourceGroupID: fmt.Sprintf("/subscriptions/%s/resourceGroups/test-cluster", mockSubID),
+								Version:         "4.12.0",
+							},
+						},
+					},
+				})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, tt.kubeActionsErr
+			}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/inventory", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, "", tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
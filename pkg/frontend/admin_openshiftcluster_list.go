@@ -6,6 +6,7 @@ package frontend
 import (
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -14,12 +15,18 @@ import (
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
+// /admin/providers/{resourceProviderNamespace}/{resourceType}?supportCaseId={supportCaseId}
 func (f *frontend) getAdminOpenShiftClusters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
 	r.URL.Path = filepath.Dir(r.URL.Path)
 
+	supportCaseID := strings.ToLower(r.URL.Query().Get("supportCaseId"))
+
 	b, err := f._getOpenShiftClusters(ctx, log, r, f.apis[admin.APIVersion].OpenShiftClusterConverter, func(skipToken string) (cosmosdb.OpenShiftClusterDocumentIterator, error) {
+		if supportCaseID != "" {
+			return f.dbOpenShiftClusters.ListBySupportCaseID(supportCaseID, skipToken)
+		}
 		return f.dbOpenShiftClusters.List(skipToken), nil
 	})
 
@@ -26,6 +26,7 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 
 	type test struct {
 		name           string
+		query          string
 		wantEnriched   []string
 		throwsError    error
 		fixture        func(*testdatabase.Fixture)
@@ -89,6 +90,62 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "clusters linked to a support case",
+			query: "?supportCaseId=ICM-1",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					&api.OpenShiftClusterDocument{
+						Key:               strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName1")),
+						SupportCaseIDKeys: []string{"2307010010000001", "icm-1"},
+						OpenShiftCluster: &api.OpenShiftCluster{
+							ID:   testdatabase.GetResourcePath(mockSubID, "resourceName1"),
+							Name: "resourceName1",
+							Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+							Properties: api.OpenShiftClusterProperties{
+								SupportAnnotations: map[string]api.SupportAnnotation{
+									"2307010010000001": {Note: "failing upgrade"},
+									"icm-1":            {},
+								},
+							},
+						},
+					},
+					&api.OpenShiftClusterDocument{
+						Key:               strings.ToLower(testdatabase.GetResourcePath(otherMockSubID, "resourceName2")),
+						SupportCaseIDKeys: []string{"icm-10"},
+						OpenShiftCluster: &api.OpenShiftCluster{
+							ID:   testdatabase.GetResourcePath(otherMockSubID, "resourceName2"),
+							Name: "resourceName2",
+							Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						},
+					},
+					&api.OpenShiftClusterDocument{
+						Key: strings.ToLower(testdatabase.GetResourcePath(otherMockSubID, "resourceName3")),
+						OpenShiftCluster: &api.OpenShiftCluster{
+							ID:   testdatabase.GetResourcePath(otherMockSubID, "resourceName3"),
+							Name: "resourceName3",
+							Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						},
+					})
+			},
+			wantEnriched:   []string{testdatabase.GetResourcePath(mockSubID, "resourceName1")},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OpenShiftClusterList{
+				OpenShiftClusters: []*admin.OpenShiftCluster{
+					{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName1"),
+						Name: "resourceName1",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: admin.OpenShiftClusterProperties{
+							SupportAnnotations: map[string]admin.SupportAnnotation{
+								"2307010010000001": {Note: "failing upgrade"},
+								"icm-1":            {},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:           "no clusters found in db",
 			wantStatusCode: http.StatusOK,
@@ -118,7 +175,7 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 				ti.openShiftClustersClient.SetError(tt.throwsError)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, aead, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, aead, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -126,7 +183,7 @@ func TestAdminListOpenShiftCluster(t *testing.T) {
 			go f.Run(ctx, nil, nil)
 
 			resp, b, err := ti.request(http.MethodGet,
-				"https://server/admin/providers/Microsoft.RedHatOpenShift/openShiftClusters",
+				"https://server/admin/providers/Microsoft.RedHatOpenShift/openShiftClusters"+tt.query,
 				http.Header{
 					"Referer": []string{"https://mockrefererhost/"},
 				}, nil)
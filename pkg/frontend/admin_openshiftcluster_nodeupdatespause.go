@@ -0,0 +1,136 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+)
+
+const (
+	minNodeUpdatesPause = time.Hour
+	maxNodeUpdatesPause = 7 * 24 * time.Hour
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/nodeupdatespause
+func (f *frontend) postAdminOpenShiftClusterNodeUpdatesPause(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterNodeUpdatesPause(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterNodeUpdatesPause pauses the rollout of updates to
+// the worker nodes for the given duration, after which the operator resumes it
+// by itself.
+func (f *frontend) _postAdminOpenShiftClusterNodeUpdatesPause(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "reason", "The provided reason is invalid.")
+	}
+
+	duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+	if err != nil || duration < minNodeUpdatesPause || duration > maxNodeUpdatesPause {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "duration", "The provided duration '%s' is invalid: it must be between 1h and 168h.", r.URL.Query().Get("duration"))
+	}
+
+	return f.patchOpenShiftClusterNodeUpdatesPause(ctx, r, log, &api.NodeUpdatesPause{
+		Reason:      reason,
+		PausedUntil: f.now().UTC().Add(duration),
+	})
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/nodeupdatespause
+func (f *frontend) deleteAdminOpenShiftClusterNodeUpdatesPause(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f.patchOpenShiftClusterNodeUpdatesPause(ctx, r, log, nil)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) patchOpenShiftClusterNodeUpdatesPause(ctx context.Context, r *http.Request, log *logrus.Entry, pause *api.NodeUpdatesPause) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.NodeUpdatesPause = pause
+		return nil
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	if pause != nil {
+		log.Infof("paused node updates until %s: %s", pause.PausedUntil.Format(time.RFC3339), pause.Reason)
+	} else {
+		log.Info("removed node updates pause")
+	}
+
+	return f.updateClusterUpgradeSpec(ctx, log, doc.OpenShiftCluster)
+}
+
+// updateClusterUpgradeSpec updates the upgrade spec on the cluster's Cluster
+// resource straight away, rather than waiting for the next update of the
+// operator, so that the node updates are paused or resumed immediately
+func (f *frontend) updateClusterUpgradeSpec(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster) error {
+	k, err := f.kubeActionsFactory(log, f.env, oc)
+	if err != nil {
+		return err
+	}
+
+	b, err := k.KubeGet(ctx, "Cluster.aro.openshift.io", "", arov1alpha1.SingletonClusterName)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	err = obj.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	spec := deploy.ClusterUpgradeSpec(oc)
+	if spec == nil {
+		unstructured.RemoveNestedField(obj.Object, "spec", "upgrade")
+		return k.KubeCreateOrUpdate(ctx, obj)
+	}
+
+	upgrade, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return err
+	}
+
+	err = unstructured.SetNestedMap(obj.Object, upgrade, "spec", "upgrade")
+	if err != nil {
+		return err
+	}
+
+	return k.KubeCreateOrUpdate(ctx, obj)
+}
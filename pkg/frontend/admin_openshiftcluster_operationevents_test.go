@@ -0,0 +1,144 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminGetOpenShiftClusterOperationEvents(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+
+	start := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		wantStatusCode int
+		wantResponse   *admin.ClusterOperationEventList
+		wantError      string
+	}{
+		{
+			name: "operation history",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+					OperationHistory: []*api.OperationHistoryEntry{
+						{
+							InitialProvisioningState: api.ProvisioningStateCreating,
+							ProvisioningState:        api.ProvisioningStateSucceeded,
+							ClientPrincipalName:      "user@example.com",
+							CorrelationID:            "00000000-0000-0000-0000-000000000001",
+							StartTime:                start,
+							EndTime:                  start.Add(40 * time.Minute),
+							Version:                  "4.12.25",
+						},
+						{
+							InitialProvisioningState: api.ProvisioningStateAdminUpdating,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							ProvisioningState:        api.ProvisioningStateFailed,
+							StartTime:                start.Add(time.Hour),
+							EndTime:                  start.Add(time.Hour + 90*time.Second),
+							Error:                    "oh no!",
+							Version:                  "4.12.25",
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterOperationEventList{
+				Events: []*admin.ClusterOperationEvent{
+					{
+						SchemaVersion:   admin.ClusterOperationEventSchemaVersion,
+						ResourceID:      resourceID,
+						Operation:       admin.ClusterOperationCreate,
+						Actor:           "user@example.com",
+						CorrelationID:   "00000000-0000-0000-0000-000000000001",
+						StartTime:       start,
+						EndTime:         start.Add(40 * time.Minute),
+						DurationSeconds: 2400,
+						Outcome:         admin.ClusterOperationOutcomeSucceeded,
+						Version:         "4.12.25",
+					},
+					{
+						SchemaVersion:   admin.ClusterOperationEventSchemaVersion,
+						ResourceID:      resourceID,
+						Operation:       admin.ClusterOperationAdminUpdate,
+						MaintenanceTask: "Everything",
+						StartTime:       start.Add(time.Hour),
+						EndTime:         start.Add(time.Hour + 90*time.Second),
+						DurationSeconds: 90,
+						Outcome:         admin.ClusterOperationOutcomeFailed,
+						Error:           "oh no!",
+						Version:         "4.12.25",
+					},
+				},
+			},
+		},
+		{
+			name: "no operation history",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(resourceID),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: resourceID,
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.ClusterOperationEventList{
+				Events: []*admin.ClusterOperationEvent{},
+			},
+		},
+		{
+			name:           "cluster not found",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/operationevents", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
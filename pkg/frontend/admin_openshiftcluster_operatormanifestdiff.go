@@ -0,0 +1,130 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	"github.com/sirupsen/logrus"
+	extensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+	"github.com/Azure/ARO-RP/pkg/util/restconfig"
+)
+
+func newOperatorDeployer(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, tagPolicy *api.TagPolicy) (deploy.Operator, error) {
+	restConfig, err := restconfig.RestConfig(env, oc)
+	if err != nil {
+		return nil, err
+	}
+
+	arocli, err := aroclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	configcli, err := configclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	extensionscli, err := extensionsclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kubernetescli, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return deploy.New(log, env, oc, tagPolicy, arocli, configcli, extensionscli, kubernetescli)
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/operatormanifestdiff
+func (f *frontend) getAdminOpenShiftClusterOperatorManifestDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	b, err := f._getAdminOpenShiftClusterOperatorManifestDiff(ctx, r, log)
+	adminReply(log, w, nil, b, err)
+}
+
+// _getAdminOpenShiftClusterOperatorManifestDiff regenerates the ARO-owned
+// manifests which the current RP would apply to the cluster and reports how
+// the live objects differ from them.  Nothing in the cluster is changed.
+func (f *frontend) _getAdminOpenShiftClusterOperatorManifestDiff(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	subId := chi.URLParam(r, "subscriptionId")
+
+	subscription, err := f.dbSubscriptions.Get(ctx, subId)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, subscriptionNotFoundError(subId)
+	case err != nil:
+		return nil, err
+	}
+
+	deployer, err := f.operatorDeployerFactory(log, f.env, doc.OpenShiftCluster, subscription.TagPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := deployer.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &admin.OperatorManifestDiffList{
+		Manifests: make([]*admin.OperatorManifestDiff, 0, len(diffs)),
+	}
+
+	for _, diff := range diffs {
+		manifest := &admin.OperatorManifestDiff{
+			GroupKind: diff.GroupKind,
+			Namespace: diff.Namespace,
+			Name:      diff.Name,
+			Missing:   diff.Missing,
+		}
+
+		for _, field := range diff.Fields {
+			manifest.Fields = append(manifest.Fields, &admin.OperatorManifestFieldDiff{
+				Path:    field.Path,
+				Change:  string(field.Change),
+				Desired: field.Desired,
+				Actual:  field.Actual,
+			})
+		}
+
+		list.Manifests = append(list.Manifests, manifest)
+	}
+
+	return json.Marshal(list)
+}
@@ -0,0 +1,166 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
+	mock_deploy "github.com/Azure/ARO-RP/pkg/util/mocks/operator/deploy"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminOperatorManifestDiff(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+
+	fixture := func(f *testdatabase.Fixture) {
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+			},
+		})
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+			},
+		})
+	}
+
+	for _, tt := range []struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		mocks          func(*mock_deploy.MockOperator)
+		wantStatusCode int
+		wantResponse   *admin.OperatorManifestDiffList
+		wantError      string
+	}{
+		{
+			name:    "no differences",
+			fixture: fixture,
+			mocks: func(deployer *mock_deploy.MockOperator) {
+				deployer.EXPECT().Diff(gomock.Any()).Return(nil, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OperatorManifestDiffList{
+				Manifests: []*admin.OperatorManifestDiff{},
+			},
+		},
+		{
+			name:    "differences",
+			fixture: fixture,
+			mocks: func(deployer *mock_deploy.MockOperator) {
+				deployer.EXPECT().Diff(gomock.Any()).Return([]*dynamichelper.ObjectDiff{
+					{
+						GroupKind: "Deployment.apps",
+						Namespace: "openshift-azure-operator",
+						Name:      "aro-operator-master",
+						Fields: []dynamichelper.FieldDiff{
+							{
+								Path:    "spec.template.spec.containers[0].image",
+								Change:  dynamichelper.FieldChangeChanged,
+								Desired: "aro:2",
+								Actual:  "aro:1",
+							},
+						},
+					},
+					{
+						GroupKind: "ClusterRole.rbac.authorization.k8s.io",
+						Name:      "system:aro-operator-master",
+						Missing:   true,
+					},
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.OperatorManifestDiffList{
+				Manifests: []*admin.OperatorManifestDiff{
+					{
+						GroupKind: "Deployment.apps",
+						Namespace: "openshift-azure-operator",
+						Name:      "aro-operator-master",
+						Fields: []*admin.OperatorManifestFieldDiff{
+							{
+								Path:    "spec.template.spec.containers[0].image",
+								Change:  "Changed",
+								Desired: "aro:2",
+								Actual:  "aro:1",
+							},
+						},
+					},
+					{
+						GroupKind: "ClusterRole.rbac.authorization.k8s.io",
+						Name:      "system:aro-operator-master",
+						Missing:   true,
+					},
+				},
+			},
+		},
+		{
+			name:    "diff fails",
+			fixture: fixture,
+			mocks: func(deployer *mock_deploy.MockOperator) {
+				deployer.EXPECT().Diff(gomock.Any()).Return(nil, errors.New("random error"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+		{
+			name:           "cluster not found",
+			mocks:          func(deployer *mock_deploy.MockOperator) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			deployer := mock_deploy.NewMockOperator(ti.controller)
+			tt.mocks(deployer)
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.operatorDeployerFactory = func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.TagPolicy) (deploy.Operator, error) {
+				return deployer, nil
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/admin%s/operatormanifestdiff", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+// operatorReconcileAnnotation is set on the ARO Cluster object to trigger the
+// operator controllers which watch it
+const operatorReconcileAnnotation = "aro.openshift.io/force-reconcile"
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/operatorreconcile
+func (f *frontend) postAdminOpenShiftClusterOperatorReconcile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+	err := f._postAdminOpenShiftClusterOperatorReconcile(ctx, r, log)
+	if err == nil {
+		err = statusCodeError(http.StatusAccepted)
+	}
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterOperatorReconcile triggers the operator
+// controllers by annotating the ARO Cluster object instead of waiting for the
+// resync period.  The reconcile is asynchronous: the outcome is reported by
+// the operator conditions on the Cluster object.
+func (f *frontend) _postAdminOpenShiftClusterOperatorReconcile(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, resourceID)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	if doc.OpenShiftCluster.Properties.Quarantine != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "",
+			"The cluster is quarantined: the operator controllers are disabled.")
+	}
+
+	k, err := f.kubeActionsFactory(log, f.env, doc.OpenShiftCluster)
+	if err != nil {
+		return err
+	}
+
+	return annotateOperatorReconcile(ctx, k, f.now().UTC())
+}
+
+// annotateOperatorReconcile sets the reconcile annotation on the ARO Cluster
+// object; a new value is used each time so that the update is never a noop
+func annotateOperatorReconcile(ctx context.Context, k adminactions.KubeActions, now time.Time) error {
+	b, err := k.KubeGet(ctx, "Cluster.aro.openshift.io", "", arov1alpha1.SingletonClusterName)
+	if err != nil {
+		return err
+	}
+
+	cluster := &unstructured.Unstructured{}
+	err = cluster.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	annotations := cluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[operatorReconcileAnnotation] = now.Format(time.RFC3339Nano)
+	cluster.SetAnnotations(annotations)
+
+	return k.KubeCreateOrUpdate(ctx, cluster)
+}
@@ -0,0 +1,108 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminOperatorReconcile(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 1, 12, 30, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name           string
+		quarantined    bool
+		wantStatusCode int
+		wantError      string
+	}{
+		{
+			name:           "reconcile is triggered",
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name:           "quarantined cluster",
+			quarantined:    true,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : The cluster is quarantined: the operator controllers are disabled.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			doc := &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(resourceID),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID: resourceID,
+				},
+			}
+			if tt.quarantined {
+				doc.OpenShiftCluster.Properties.Quarantine = &api.Quarantine{Reason: "investigation"}
+			}
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(doc)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if !tt.quarantined {
+				k.EXPECT().
+					KubeGet(gomock.Any(), "Cluster.aro.openshift.io", "", "cluster").
+					Return([]byte(`{"apiVersion":"aro.openshift.io/v1alpha1","kind":"Cluster","metadata":{"name":"cluster","resourceVersion":"1"}}`), nil)
+				k.EXPECT().
+					KubeCreateOrUpdate(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, o *unstructured.Unstructured) error {
+						if got := o.GetAnnotations()[operatorReconcileAnnotation]; got != now.Format(time.RFC3339Nano) {
+							t.Errorf("got annotation %q", got)
+						}
+						return nil
+					})
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/operatorreconcile", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
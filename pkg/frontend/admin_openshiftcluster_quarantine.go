@@ -0,0 +1,113 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/quarantine
+func (f *frontend) postAdminOpenShiftClusterQuarantine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterQuarantine(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterQuarantine quarantines the cluster: the operator
+// controllers are disabled until the quarantine is removed.  Admin updates are
+// still run, as they are how SREs repair a quarantined cluster.
+func (f *frontend) _postAdminOpenShiftClusterQuarantine(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	reason := r.URL.Query().Get("reason")
+	if reason == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "reason", "The provided reason is invalid.")
+	}
+
+	return f.patchOpenShiftClusterQuarantine(ctx, r, log, &api.Quarantine{
+		Reason:        reason,
+		QuarantinedAt: f.now().UTC(),
+	})
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/quarantine
+func (f *frontend) deleteAdminOpenShiftClusterQuarantine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f.patchOpenShiftClusterQuarantine(ctx, r, log, nil)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) patchOpenShiftClusterQuarantine(ctx context.Context, r *http.Request, log *logrus.Entry, quarantine *api.Quarantine) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		doc.OpenShiftCluster.Properties.Quarantine = quarantine
+		return nil
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	if quarantine != nil {
+		log.Infof("quarantined cluster: %s", quarantine.Reason)
+	} else {
+		log.Info("removed cluster quarantine")
+	}
+
+	return f.updateClusterOperatorFlags(ctx, log, doc.OpenShiftCluster)
+}
+
+// updateClusterOperatorFlags updates the operator flags on the cluster's
+// Cluster resource straight away, rather than waiting for the next update of
+// the operator, so that the operator stops or resumes reconciling immediately
+func (f *frontend) updateClusterOperatorFlags(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster) error {
+	k, err := f.kubeActionsFactory(log, f.env, oc)
+	if err != nil {
+		return err
+	}
+
+	b, err := k.KubeGet(ctx, "Cluster.aro.openshift.io", "", arov1alpha1.SingletonClusterName)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	err = obj.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	err = unstructured.SetNestedStringMap(obj.Object, deploy.ClusterOperatorFlags(oc), "spec", "operatorFlags")
+	if err != nil {
+		return err
+	}
+
+	return k.KubeCreateOrUpdate(ctx, obj)
+}
@@ -0,0 +1,178 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminQuarantine(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 1, 12, 30, 0, 0, time.UTC)
+
+	flags := api.OperatorFlags{
+		"aro.alertwebhook.enabled": "true",
+		"rh.srep.muo.managed":      "true",
+	}
+
+	cluster := func(quarantine *api.Quarantine) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					OperatorFlags: flags,
+					Quarantine:    quarantine,
+				},
+			},
+		}
+	}
+
+	clusterCR := []byte(`{"apiVersion":"aro.openshift.io/v1alpha1","kind":"Cluster","metadata":{"name":"cluster"},"spec":{"operatorFlags":{"aro.alertwebhook.enabled":"true","rh.srep.muo.managed":"true"}}}`)
+
+	wantOperatorFlags := func(want map[string]string) func(*mock_adminactions.MockKubeActions) {
+		return func(k *mock_adminactions.MockKubeActions) {
+			k.EXPECT().KubeGet(gomock.Any(), "Cluster.aro.openshift.io", "", "cluster").Return(clusterCR, nil)
+			k.EXPECT().KubeCreateOrUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, obj *unstructured.Unstructured) error {
+				got, _, err := unstructured.NestedStringMap(obj.Object, "spec", "operatorFlags")
+				if err != nil {
+					return err
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					return fmt.Errorf("unexpected operator flags %v", got)
+				}
+				return nil
+			})
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		query          string
+		fixture        *api.OpenShiftClusterDocument
+		mocks          func(*mock_adminactions.MockKubeActions)
+		wantStatusCode int
+		wantError      string
+		wantDocument   *api.OpenShiftClusterDocument
+	}{
+		{
+			name:    "quarantine",
+			method:  http.MethodPost,
+			query:   "?reason=incident",
+			fixture: cluster(nil),
+			mocks: wantOperatorFlags(map[string]string{
+				"aro.alertwebhook.enabled": "false",
+				"rh.srep.muo.managed":      "true",
+			}),
+			wantStatusCode: http.StatusOK,
+			wantDocument:   cluster(&api.Quarantine{Reason: "incident", QuarantinedAt: now}),
+		},
+		{
+			name:           "quarantine without reason",
+			method:         http.MethodPost,
+			fixture:        cluster(nil),
+			mocks:          func(k *mock_adminactions.MockKubeActions) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: reason: The provided reason is invalid.",
+			wantDocument:   cluster(nil),
+		},
+		{
+			name:    "remove quarantine",
+			method:  http.MethodDelete,
+			fixture: cluster(&api.Quarantine{Reason: "incident", QuarantinedAt: now}),
+			mocks: wantOperatorFlags(map[string]string{
+				"aro.alertwebhook.enabled": "true",
+				"rh.srep.muo.managed":      "true",
+			}),
+			wantStatusCode: http.StatusOK,
+			wantDocument:   cluster(nil),
+		},
+		{
+			name:    "operator flags not updated",
+			method:  http.MethodPost,
+			query:   "?reason=incident",
+			fixture: cluster(nil),
+			mocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeGet(gomock.Any(), "Cluster.aro.openshift.io", "", "cluster").Return(nil, errors.New("connection refused"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+			wantDocument:   cluster(&api.Quarantine{Reason: "incident", QuarantinedAt: now}),
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodPost,
+			query:          "?reason=incident",
+			mocks:          func(k *mock_adminactions.MockKubeActions) {},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			tt.mocks(k)
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				if tt.fixture != nil {
+					f.AddOpenShiftClusterDocuments(tt.fixture)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/quarantine%s", resourceID, tt.query),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocument != nil {
+				ti.checker.AddOpenShiftClusterDocuments(tt.wantDocument)
+			}
+			for _, err := range ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
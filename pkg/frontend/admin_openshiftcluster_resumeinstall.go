@@ -0,0 +1,82 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/resumeinstall
+func (f *frontend) postAdminOpenShiftClusterResumeInstall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterResumeInstall(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterResumeInstall requeues a cluster whose creation
+// failed.  The backend resumes the install from the last completed step of
+// the failed phase, after validating its preconditions again.  Only installs
+// which failed with a transient error are resumable: otherwise the cluster
+// must be deleted and created again.
+func (f *frontend) _postAdminOpenShiftClusterResumeInstall(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+	subId, resourceProviderNamespace := chi.URLParam(r, "subscriptionId"), chi.URLParam(r, "resourceProviderNamespace")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	doc, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateFailed ||
+			doc.OpenShiftCluster.Properties.FailedProvisioningState != api.ProvisioningStateCreating {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "",
+				"Request is not allowed on cluster whose creation did not fail.")
+		}
+
+		install := doc.OpenShiftCluster.Properties.Install
+		if install == nil || !install.Resumable {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "",
+				"The install of the cluster failed permanently and cannot be resumed. Delete the cluster and retry the install.")
+		}
+
+		err := f.validateRPVersionPin(ctx, log, doc.OpenShiftCluster, doc.OpenShiftCluster.Properties.RPVersionPin)
+		if err != nil {
+			return err
+		}
+
+		install.Resumable = false
+
+		doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
+		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateCreating
+		doc.OpenShiftCluster.Properties.FailedProvisioningState = ""
+		doc.Dequeues = 0
+
+		doc.AsyncOperationID, err = f.newAsyncOperation(ctx, subId, resourceProviderNamespace, doc)
+		return err
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	case err != nil:
+		return err
+	}
+
+	log.Infof("resuming install in phase %s after %d completed steps",
+		doc.OpenShiftCluster.Properties.Install.Phase, len(doc.OpenShiftCluster.Properties.Install.CompletedSteps))
+	return nil
+}
@@ -0,0 +1,134 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminResumeInstall(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+
+	completedSteps := []string{"action.ensureResourceProvidersRegistered", "action.ensurePreconfiguredNSG"}
+
+	cluster := func(provisioningState, lastProvisioningState, failedProvisioningState api.ProvisioningState, install *api.Install) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(resourceID),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState:       provisioningState,
+					LastProvisioningState:   lastProvisioningState,
+					FailedProvisioningState: failedProvisioningState,
+					Install:                 install,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		fixture        *api.OpenShiftClusterDocument
+		wantStatusCode int
+		wantError      string
+		wantDocument   *api.OpenShiftClusterDocument
+		wantAsync      bool
+	}{
+		{
+			name:           "resumable install is requeued",
+			fixture:        cluster(api.ProvisioningStateFailed, "", api.ProvisioningStateCreating, &api.Install{CompletedSteps: completedSteps, Resumable: true}),
+			wantStatusCode: http.StatusOK,
+			wantDocument:   cluster(api.ProvisioningStateCreating, api.ProvisioningStateFailed, "", &api.Install{CompletedSteps: completedSteps}),
+			wantAsync:      true,
+		},
+		{
+			name:           "permanently failed install",
+			fixture:        cluster(api.ProvisioningStateFailed, "", api.ProvisioningStateCreating, &api.Install{CompletedSteps: completedSteps}),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : The install of the cluster failed permanently and cannot be resumed. Delete the cluster and retry the install.",
+			wantDocument:   cluster(api.ProvisioningStateFailed, "", api.ProvisioningStateCreating, &api.Install{CompletedSteps: completedSteps}),
+		},
+		{
+			name:           "failed update",
+			fixture:        cluster(api.ProvisioningStateFailed, "", api.ProvisioningStateUpdating, nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Request is not allowed on cluster whose creation did not fail.",
+			wantDocument:   cluster(api.ProvisioningStateFailed, "", api.ProvisioningStateUpdating, nil),
+		},
+		{
+			name:           "install in progress",
+			fixture:        cluster(api.ProvisioningStateCreating, "", "", &api.Install{Resumable: true}),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Request is not allowed on cluster whose creation did not fail.",
+			wantDocument:   cluster(api.ProvisioningStateCreating, "", "", &api.Install{Resumable: true}),
+		},
+		{
+			name:           "cluster not found",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithAsyncOperations().WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				if tt.fixture != nil {
+					f.AddOpenShiftClusterDocuments(tt.fixture)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server/admin%s/resumeinstall", resourceID),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocument != nil {
+				ti.checker.AddOpenShiftClusterDocuments(tt.wantDocument)
+			}
+			if tt.wantAsync {
+				ti.checker.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(resourceID),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateCreating,
+						ProvisioningState:        api.ProvisioningStateCreating,
+					},
+				})
+			}
+			for _, err := range ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient) {
+				t.Error(err)
+			}
+			for _, err := range ti.checker.CheckAsyncOperations(ti.asyncOperationsClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
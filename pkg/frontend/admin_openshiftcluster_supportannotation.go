@@ -0,0 +1,137 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+const maxSupportAnnotationNoteLength = 1024
+
+var rxSupportCaseID = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/supportannotation?supportCaseId={supportCaseId}&note={note}
+func (f *frontend) postAdminOpenShiftClusterSupportAnnotation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._postAdminOpenShiftClusterSupportAnnotation(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+// _postAdminOpenShiftClusterSupportAnnotation links the cluster to the
+// support case, replacing the note of an existing annotation for the case.
+// Unlike an admin update, this only changes the cluster document.
+func (f *frontend) _postAdminOpenShiftClusterSupportAnnotation(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	supportCaseID, err := supportCaseIDFromRequest(r)
+	if err != nil {
+		return err
+	}
+
+	note := r.URL.Query().Get("note")
+	if len(note) > maxSupportAnnotationNoteLength {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "note", "The provided note is invalid: it must be at most %d characters long.", maxSupportAnnotationNoteLength)
+	}
+
+	err = f.patchOpenShiftClusterSupportAnnotations(ctx, r, func(annotations map[string]api.SupportAnnotation) {
+		annotations[supportCaseID] = api.SupportAnnotation{
+			Note:        note,
+			AnnotatedAt: f.now().UTC(),
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("added support annotation for case %s", supportCaseID)
+	return nil
+}
+
+// /admin/subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/supportannotation?supportCaseId={supportCaseId}
+func (f *frontend) deleteAdminOpenShiftClusterSupportAnnotation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	err := f._deleteAdminOpenShiftClusterSupportAnnotation(ctx, r, log)
+
+	adminReply(log, w, nil, nil, err)
+}
+
+func (f *frontend) _deleteAdminOpenShiftClusterSupportAnnotation(ctx context.Context, r *http.Request, log *logrus.Entry) error {
+	supportCaseID, err := supportCaseIDFromRequest(r)
+	if err != nil {
+		return err
+	}
+
+	err = f.patchOpenShiftClusterSupportAnnotations(ctx, r, func(annotations map[string]api.SupportAnnotation) {
+		delete(annotations, supportCaseID)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("removed support annotation for case %s", supportCaseID)
+	return nil
+}
+
+func supportCaseIDFromRequest(r *http.Request) (string, error) {
+	supportCaseID := strings.ToLower(r.URL.Query().Get("supportCaseId"))
+	if !rxSupportCaseID.MatchString(supportCaseID) {
+		return "", api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "supportCaseId", "The provided support case ID '%s' is invalid.", supportCaseID)
+	}
+
+	return supportCaseID, nil
+}
+
+// patchOpenShiftClusterSupportAnnotations changes the support annotations of
+// the cluster and keeps the support case ID keys of the document, by which
+// the clusters linked to a support case are listed, in sync with them
+func (f *frontend) patchOpenShiftClusterSupportAnnotations(ctx context.Context, r *http.Request, mutate func(map[string]api.SupportAnnotation)) error {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	resourceID := strings.TrimPrefix(r.URL.Path, "/admin")
+
+	_, err := f.dbOpenShiftClusters.Patch(ctx, resourceID, func(doc *api.OpenShiftClusterDocument) error {
+		annotations := doc.OpenShiftCluster.Properties.SupportAnnotations
+		if annotations == nil {
+			annotations = map[string]api.SupportAnnotation{}
+		}
+
+		mutate(annotations)
+
+		doc.OpenShiftCluster.Properties.SupportAnnotations = nil
+		doc.SupportCaseIDKeys = nil
+		if len(annotations) > 0 {
+			doc.OpenShiftCluster.Properties.SupportAnnotations = annotations
+			for supportCaseID := range annotations {
+				doc.SupportCaseIDKeys = append(doc.SupportCaseIDKeys, supportCaseID)
+			}
+			sort.Strings(doc.SupportCaseIDKeys)
+		}
+
+		return nil
+	})
+	if cosmosdb.IsErrorStatusCode(err, http.StatusNotFound) {
+		return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "",
+			"The Resource '%s/%s' under resource group '%s' was not found.",
+			resType, resName, resGroupName)
+	}
+
+	return err
+}
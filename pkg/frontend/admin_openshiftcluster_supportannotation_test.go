@@ -0,0 +1,148 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminSupportAnnotation(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := testdatabase.GetResourcePath(mockSubID, "resourceName")
+	ctx := context.Background()
+	now := time.Date(2023, time.July, 1, 12, 30, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	cluster := func(annotations map[string]api.SupportAnnotation, supportCaseIDKeys ...string) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key:               strings.ToLower(resourceID),
+			SupportCaseIDKeys: supportCaseIDKeys,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: resourceID,
+				Properties: api.OpenShiftClusterProperties{
+					SupportAnnotations: annotations,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		query          string
+		fixture        *api.OpenShiftClusterDocument
+		wantStatusCode int
+		wantError      string
+		wantDocument   *api.OpenShiftClusterDocument
+	}{
+		{
+			name:           "add annotation",
+			method:         http.MethodPost,
+			query:          "?supportCaseId=2307010010000001&note=customer+reports+failing+upgrade",
+			fixture:        cluster(nil),
+			wantStatusCode: http.StatusOK,
+			wantDocument: cluster(map[string]api.SupportAnnotation{
+				"2307010010000001": {Note: "customer reports failing upgrade", AnnotatedAt: now},
+			}, "2307010010000001"),
+		},
+		{
+			name:   "add annotation to annotated cluster",
+			method: http.MethodPost,
+			query:  "?supportCaseId=ICM-1",
+			fixture: cluster(map[string]api.SupportAnnotation{
+				"2307010010000001": {Note: "customer reports failing upgrade", AnnotatedAt: earlier},
+			}, "2307010010000001"),
+			wantStatusCode: http.StatusOK,
+			wantDocument: cluster(map[string]api.SupportAnnotation{
+				"2307010010000001": {Note: "customer reports failing upgrade", AnnotatedAt: earlier},
+				"icm-1":            {AnnotatedAt: now},
+			}, "2307010010000001", "icm-1"),
+		},
+		{
+			name:   "remove annotation",
+			method: http.MethodDelete,
+			query:  "?supportCaseId=2307010010000001",
+			fixture: cluster(map[string]api.SupportAnnotation{
+				"2307010010000001": {Note: "customer reports failing upgrade", AnnotatedAt: earlier},
+			}, "2307010010000001"),
+			wantStatusCode: http.StatusOK,
+			wantDocument:   cluster(nil),
+		},
+		{
+			name:           "invalid support case ID",
+			method:         http.MethodPost,
+			query:          "?supportCaseId=case/1",
+			fixture:        cluster(nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: supportCaseId: The provided support case ID 'case/1' is invalid.",
+			wantDocument:   cluster(nil),
+		},
+		{
+			name:           "note too long",
+			method:         http.MethodPost,
+			query:          "?supportCaseId=2307010010000001&note=" + strings.Repeat("x", maxSupportAnnotationNoteLength+1),
+			fixture:        cluster(nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: note: The provided note is invalid: it must be at most 1024 characters long.",
+			wantDocument:   cluster(nil),
+		},
+		{
+			name:           "cluster not found",
+			method:         http.MethodPost,
+			query:          "?supportCaseId=2307010010000001",
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				if tt.fixture != nil {
+					f.AddOpenShiftClusterDocuments(tt.fixture)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.now = func() time.Time { return now }
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				fmt.Sprintf("https://server/admin%s/supportannotation%s", resourceID, tt.query),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocument != nil {
+				ti.checker.AddOpenShiftClusterDocuments(tt.wantDocument)
+			}
+			for _, err := range ti.checker.CheckOpenShiftClusters(ti.openShiftClustersClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+// /admin/versions/deprecatedclusters
+func (f *frontend) getAdminDeprecatedVersionClusters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._getAdminDeprecatedVersionClusters(ctx, log)
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminDeprecatedVersionClusters(ctx context.Context, log *logrus.Entry) ([]byte, error) {
+	matrix, err := f.getVersionMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]*admin.DeprecatedVersionCluster, 0)
+
+	i := f.dbOpenShiftClusters.List("")
+	for {
+		docs, err := i.Next(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+		if docs == nil {
+			break
+		}
+
+		for _, doc := range docs.OpenShiftClusterDocuments {
+			// clusters which are still being created have no version yet
+			if doc.OpenShiftCluster.Properties.ClusterProfile.Version == "" {
+				continue
+			}
+
+			c, err := deprecatedVersionCluster(matrix, doc.OpenShiftCluster)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+			if c != nil {
+				clusters = append(clusters, c)
+			}
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].MinorVersionsPastEndOfLife != clusters[j].MinorVersionsPastEndOfLife {
+			return clusters[i].MinorVersionsPastEndOfLife > clusters[j].MinorVersionsPastEndOfLife
+		}
+		return clusters[i].ResourceID < clusters[j].ResourceID
+	})
+
+	return json.MarshalIndent(&admin.DeprecatedVersionClusterList{Clusters: clusters}, "", "    ")
+}
+
+// deprecatedVersionCluster returns the cluster if its version is deprecated
+// or past end of life according to the version matrix, or nil otherwise.  A
+// minor version is past end of life if it is older than the oldest minor
+// version in the matrix, and deprecated if the version of the cluster or every
+// version of its minor version in the matrix is deprecated.  Minor versions
+// newer than those in the matrix are not reported.
+func deprecatedVersionCluster(matrix *api.OpenShiftVersionMatrix, oc *api.OpenShiftCluster) (*admin.DeprecatedVersionCluster, error) {
+	v, err := version.ParseVersion(oc.Properties.ClusterProfile.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &admin.DeprecatedVersionCluster{
+		ResourceID: oc.ID,
+		Version:    oc.Properties.ClusterProfile.Version,
+	}
+
+	var minorVersions int
+	minorDeprecated := true
+
+	for i, mv := range matrix.Versions {
+		w, err := version.ParseVersion(mv.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		// the matrix is sorted, so the first version is the oldest
+		if i == 0 && version.NewVersion(v.V[0], v.V[1]).Lt(version.NewVersion(w.V[0], w.V[1])) {
+			c.Status = admin.DeprecatedVersionStatusEndOfLife
+			c.MinorVersionsPastEndOfLife = int(w.V[1]) - int(v.V[1])
+			return c, nil
+		}
+
+		if w.V[0] != v.V[0] || w.V[1] != v.V[1] {
+			continue
+		}
+
+		if w.Eq(v) && mv.Status == api.OpenShiftVersionStatusDeprecated {
+			c.Status = admin.DeprecatedVersionStatusDeprecated
+			return c, nil
+		}
+
+		minorVersions++
+		if mv.Status != api.OpenShiftVersionStatusDeprecated {
+			minorDeprecated = false
+		}
+	}
+
+	if minorVersions > 0 && minorDeprecated {
+		c.Status = admin.DeprecatedVersionStatusDeprecated
+		return c, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,160 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminGetDeprecatedVersionClusters(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	enabled := func(v string, status api.OpenShiftVersionStatus) *api.OpenShiftVersion {
+		return &api.OpenShiftVersion{
+			Properties: api.OpenShiftVersionProperties{
+				Version: v,
+				Enabled: true,
+				Status:  status,
+			},
+		}
+	}
+
+	changeFeed := map[string]*api.OpenShiftVersion{
+		"4.10.40": enabled("4.10.40", api.OpenShiftVersionStatusDeprecated),
+		"4.10.54": enabled("4.10.54", api.OpenShiftVersionStatusDeprecated),
+		"4.11.40": enabled("4.11.40", api.OpenShiftVersionStatusDeprecated),
+		"4.11.44": enabled("4.11.44", api.OpenShiftVersionStatusUpgradeOnly),
+		"4.12.25": enabled("4.12.25", ""),
+	}
+
+	cluster := func(name, version string) *api.OpenShiftClusterDocument {
+		return &api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, name)),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   testdatabase.GetResourcePath(mockSubID, name),
+				Name: name,
+				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Version: version,
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		fixture        func(*testdatabase.Fixture)
+		throwsError    error
+		wantStatusCode int
+		wantResponse   *admin.DeprecatedVersionClusterList
+		wantError      string
+	}{
+		{
+			name: "clusters at deprecated and end of life versions",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(
+					cluster("supported", "4.12.25"),
+					cluster("supported-minor", "4.11.45"),
+					cluster("deprecated-version", "4.11.40"),
+					cluster("deprecated-minor", "4.10.3"),
+					cluster("end-of-life", "4.9.59"),
+					cluster("end-of-life-longest", "4.7.60"),
+					cluster("newer", "4.13.4"),
+					cluster("creating", ""),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.DeprecatedVersionClusterList{
+				Clusters: []*admin.DeprecatedVersionCluster{
+					{
+						ResourceID:                 testdatabase.GetResourcePath(mockSubID, "end-of-life-longest"),
+						Version:                    "4.7.60",
+						Status:                     admin.DeprecatedVersionStatusEndOfLife,
+						MinorVersionsPastEndOfLife: 3,
+					},
+					{
+						ResourceID:                 testdatabase.GetResourcePath(mockSubID, "end-of-life"),
+						Version:                    "4.9.59",
+						Status:                     admin.DeprecatedVersionStatusEndOfLife,
+						MinorVersionsPastEndOfLife: 1,
+					},
+					{
+						ResourceID: testdatabase.GetResourcePath(mockSubID, "deprecated-minor"),
+						Version:    "4.10.3",
+						Status:     admin.DeprecatedVersionStatusDeprecated,
+					},
+					{
+						ResourceID: testdatabase.GetResourcePath(mockSubID, "deprecated-version"),
+						Version:    "4.11.40",
+						Status:     admin.DeprecatedVersionStatusDeprecated,
+					},
+				},
+			},
+		},
+		{
+			name:           "no clusters",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &admin.DeprecatedVersionClusterList{
+				Clusters: []*admin.DeprecatedVersionCluster{},
+			},
+		},
+		{
+			name:           "internal error while iterating list",
+			throwsError:    &cosmosdb.Error{Code: "500", Message: "random error"},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithOpenShiftVersions()
+			defer ti.done()
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.throwsError != nil {
+				ti.openShiftClustersClient.SetError(tt.throwsError)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			f.mu.Lock()
+			f.enabledOcpVersions = changeFeed
+			f.mu.Unlock()
+
+			resp, b, err := ti.request(http.MethodGet,
+				"https://server/admin/versions/deprecatedclusters",
+				http.Header{
+					"Referer": []string{"https://mockrefererhost/"},
+				}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -43,6 +43,13 @@ func (f *frontend) putAdminOpenShiftVersion(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// prevent making the default installation version uninstallable
+	if ext.Properties.Version == version.DefaultInstallStream.Version.String() &&
+		ext.Properties.Status != "" && ext.Properties.Status != admin.OpenShiftVersionStatusInstallable {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.status", "You cannot make the default installation version %s.", ext.Properties.Status)
+		return
+	}
+
 	docs, err := f.dbOpenShiftVersions.ListAll(ctx)
 	if err != nil {
 		api.WriteError(w, http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
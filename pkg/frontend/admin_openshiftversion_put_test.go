@@ -209,6 +209,78 @@ func TestOpenShiftVersionPut(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "creating new upgrade-only version in some locations",
+			fixture: func(f *testdatabase.Fixture) {},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "f:f/g",
+					InstallerPullspec: "g:g/h",
+					Status:            admin.OpenShiftVersionStatusUpgradeOnly,
+					Locations:         []string{"EastUS", "westeurope"},
+				},
+			},
+			wantStatusCode: http.StatusCreated,
+			wantResponse: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "f:f/g",
+					InstallerPullspec: "g:g/h",
+					Status:            admin.OpenShiftVersionStatusUpgradeOnly,
+					Locations:         []string{"eastus", "westeurope"},
+				},
+			},
+			wantDocuments: []*api.OpenShiftVersionDocument{
+				{
+					ID: "07070707-0707-0707-0707-070707070001",
+					OpenShiftVersion: &api.OpenShiftVersion{
+						Properties: api.OpenShiftVersionProperties{
+							Version:           "4.10.1",
+							Enabled:           true,
+							OpenShiftPullspec: "f:f/g",
+							InstallerPullspec: "g:g/h",
+							Status:            api.OpenShiftVersionStatusUpgradeOnly,
+							Locations:         []string{"eastus", "westeurope"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "creating new version with invalid status",
+			fixture: func(f *testdatabase.Fixture) {},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "f:f/g",
+					InstallerPullspec: "g:g/h",
+					Status:            "Retired",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.status: The provided status 'Retired' is invalid.",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
+		{
+			name:    "creating new version with invalid location",
+			fixture: func(f *testdatabase.Fixture) {},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           "4.10.1",
+					Enabled:           true,
+					OpenShiftPullspec: "f:f/g",
+					InstallerPullspec: "g:g/h",
+					Locations:         []string{"eastus", "west europe"},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.locations[1]: The provided location 'west europe' is invalid.",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
 		{
 			name:           "creating new version needs body",
 			fixture:        func(f *testdatabase.Fixture) {},
@@ -255,6 +327,22 @@ func TestOpenShiftVersionPut(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "can not make default install version upgrade-only",
+			fixture: func(f *testdatabase.Fixture) {},
+			body: &admin.OpenShiftVersion{
+				Properties: admin.OpenShiftVersionProperties{
+					Version:           version.DefaultInstallStream.Version.String(),
+					Enabled:           true,
+					OpenShiftPullspec: "c:c/d",
+					InstallerPullspec: "d:d/e",
+					Status:            admin.OpenShiftVersionStatusUpgradeOnly,
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: properties.status: You cannot make the default installation version UpgradeOnly.",
+			wantDocuments:  []*api.OpenShiftVersionDocument{},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ti := newTestInfra(t).WithOpenShiftVersions()
@@ -265,7 +353,7 @@ func TestOpenShiftVersionPut(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, ti.openShiftVersionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, ti.openShiftVersionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
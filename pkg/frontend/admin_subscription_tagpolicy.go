@@ -0,0 +1,131 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// /admin/subscriptions/{subscriptionId}/tagpolicy
+func (f *frontend) getAdminSubscriptionTagPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	b, err := f._getAdminSubscriptionTagPolicy(ctx, r)
+
+	adminReply(log, w, nil, b, err)
+}
+
+func (f *frontend) _getAdminSubscriptionTagPolicy(ctx context.Context, r *http.Request) ([]byte, error) {
+	subId := chi.URLParam(r, "subscriptionId")
+
+	doc, err := f.dbSubscriptions.Get(ctx, subId)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, subscriptionNotFoundError(subId)
+	case err != nil:
+		return nil, err
+	}
+
+	return json.MarshalIndent(tagPolicyToExternal(doc.TagPolicy), "", "    ")
+}
+
+// /admin/subscriptions/{subscriptionId}/tagpolicy
+func (f *frontend) putAdminSubscriptionTagPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+
+	b, err := f._putAdminSubscriptionTagPolicy(ctx, r, log)
+
+	adminReply(log, w, nil, b, err)
+}
+
+// _putAdminSubscriptionTagPolicy sets the tags which clusters created in the
+// subscription must have.  Existing clusters are not validated against the
+// policy, but their required tags are propagated to their managed resources
+// on their next admin update.
+func (f *frontend) _putAdminSubscriptionTagPolicy(ctx context.Context, r *http.Request, log *logrus.Entry) ([]byte, error) {
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	subId := chi.URLParam(r, "subscriptionId")
+
+	var ext *admin.TagPolicy
+	err := json.Unmarshal(body, &ext)
+	if err != nil || ext == nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized.")
+	}
+
+	err = validateTagPolicy(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagPolicy *api.TagPolicy
+	if len(ext.RequiredTags) > 0 {
+		tagPolicy = &api.TagPolicy{
+			RequiredTags: ext.RequiredTags,
+		}
+	}
+
+	doc, err := f.dbSubscriptions.Patch(ctx, subId, func(doc *api.SubscriptionDocument) error {
+		doc.TagPolicy = tagPolicy
+		return nil
+	})
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, subscriptionNotFoundError(subId)
+	case err != nil:
+		return nil, err
+	}
+
+	log.Infof("set tag policy: required tags %v", ext.RequiredTags)
+
+	return json.MarshalIndent(tagPolicyToExternal(doc.TagPolicy), "", "    ")
+}
+
+// validateTagPolicy validates the names of the required tags against the
+// Azure restrictions on tag names.
+func validateTagPolicy(ext *admin.TagPolicy) error {
+	seen := map[string]struct{}{}
+
+	for i, name := range ext.RequiredTags {
+		if name == "" || len(name) > 512 || strings.ContainsAny(name, `<>%&\?/`) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "requiredTags["+strconv.Itoa(i)+"]", "The provided tag name '%s' is invalid.", name)
+		}
+
+		if _, ok := seen[strings.ToLower(name)]; ok {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "requiredTags["+strconv.Itoa(i)+"]", "The provided tag name '%s' is duplicated.", name)
+		}
+		seen[strings.ToLower(name)] = struct{}{}
+	}
+
+	return nil
+}
+
+func tagPolicyToExternal(tagPolicy *api.TagPolicy) *admin.TagPolicy {
+	ext := &admin.TagPolicy{
+		RequiredTags: []string{},
+	}
+
+	if tagPolicy != nil {
+		ext.RequiredTags = append(ext.RequiredTags, tagPolicy.RequiredTags...)
+	}
+
+	return ext
+}
+
+func subscriptionNotFoundError(subId string) error {
+	return api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "", "The subscription '%s' could not be found.", subId)
+}
@@ -0,0 +1,146 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/admin"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestAdminSubscriptionTagPolicy(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	subscription := func(tagPolicy *api.TagPolicy) *api.SubscriptionDocument {
+		return &api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: "11111111-1111-1111-1111-111111111111",
+				},
+			},
+			TagPolicy: tagPolicy,
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		body           interface{}
+		fixture        *api.SubscriptionDocument
+		wantStatusCode int
+		wantResponse   *admin.TagPolicy
+		wantError      string
+		wantDocument   *api.SubscriptionDocument
+	}{
+		{
+			name:           "get policy",
+			method:         http.MethodGet,
+			fixture:        subscription(&api.TagPolicy{RequiredTags: []string{"owner"}}),
+			wantStatusCode: http.StatusOK,
+			wantResponse:   &admin.TagPolicy{RequiredTags: []string{"owner"}},
+			wantDocument:   subscription(&api.TagPolicy{RequiredTags: []string{"owner"}}),
+		},
+		{
+			name:           "get unset policy",
+			method:         http.MethodGet,
+			fixture:        subscription(nil),
+			wantStatusCode: http.StatusOK,
+			wantResponse:   &admin.TagPolicy{RequiredTags: []string{}},
+			wantDocument:   subscription(nil),
+		},
+		{
+			name:           "set policy",
+			method:         http.MethodPut,
+			body:           &admin.TagPolicy{RequiredTags: []string{"owner", "costCenter"}},
+			fixture:        subscription(nil),
+			wantStatusCode: http.StatusOK,
+			wantResponse:   &admin.TagPolicy{RequiredTags: []string{"owner", "costCenter"}},
+			wantDocument:   subscription(&api.TagPolicy{RequiredTags: []string{"owner", "costCenter"}}),
+		},
+		{
+			name:           "remove policy",
+			method:         http.MethodPut,
+			body:           &admin.TagPolicy{RequiredTags: []string{}},
+			fixture:        subscription(&api.TagPolicy{RequiredTags: []string{"owner"}}),
+			wantStatusCode: http.StatusOK,
+			wantResponse:   &admin.TagPolicy{RequiredTags: []string{}},
+			wantDocument:   subscription(nil),
+		},
+		{
+			name:           "invalid tag name",
+			method:         http.MethodPut,
+			body:           &admin.TagPolicy{RequiredTags: []string{"owner", "cost/center"}},
+			fixture:        subscription(nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: requiredTags[1]: The provided tag name 'cost/center' is invalid.",
+			wantDocument:   subscription(nil),
+		},
+		{
+			name:           "duplicate tag name",
+			method:         http.MethodPut,
+			body:           &admin.TagPolicy{RequiredTags: []string{"owner", "Owner"}},
+			fixture:        subscription(nil),
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: requiredTags[1]: The provided tag name 'Owner' is duplicated.",
+			wantDocument:   subscription(nil),
+		},
+		{
+			name:           "subscription not found",
+			method:         http.MethodPut,
+			body:           &admin.TagPolicy{RequiredTags: []string{"owner"}},
+			wantStatusCode: http.StatusNotFound,
+			wantError:      "404: NotFound: : The subscription '00000000-0000-0000-0000-000000000000' could not be found.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithSubscriptions()
+			defer ti.done()
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				if tt.fixture != nil {
+					f.AddSubscriptionDocuments(tt.fixture)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			resp, b, err := ti.request(tt.method,
+				"https://server/admin/subscriptions/"+mockSubID+"/tagpolicy",
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantDocument != nil {
+				ti.checker.AddSubscriptionDocuments(tt.wantDocument)
+			}
+			for _, err := range ti.checker.CheckSubscriptions(ti.subscriptionsClient) {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ type AzureActions interface {
 	VMSerialConsole(ctx context.Context, w http.ResponseWriter, log *logrus.Entry, vmName string) error
 	AppLensGetDetector(ctx context.Context, detectorId string) ([]byte, error)
 	AppLensListDetectors(ctx context.Context) ([]byte, error)
+	BootstrapIgnition(ctx context.Context) ([]byte, error)
 }
 
 type azureActions struct {
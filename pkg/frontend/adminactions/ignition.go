@@ -0,0 +1,71 @@
+package adminactions
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	azstorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/date"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// BootstrapIgnition returns the bootstrap ignition config which the installer
+// uploaded to the ignition container of the cluster storage account.  The
+// container is deleted once the bootstrap node is removed.
+func (a *azureActions) BootstrapIgnition(ctx context.Context) ([]byte, error) {
+	c, err := a.clusterStorageContainer(ctx, "ignition", mgmtstorage.R)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := c.GetBlobReference("bootstrap.ign").Get(nil)
+	if serr, ok := err.(azstorage.AzureStorageServiceError); ok && serr.StatusCode == http.StatusNotFound {
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeNotFound, "",
+			"The bootstrap ignition config was not found. It is removed together with the bootstrap node.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// clusterStorageContainer returns the named container of the cluster storage
+// account, accessed with a short lived SAS token granting permissions
+func (a *azureActions) clusterStorageContainer(ctx context.Context, name string, permissions mgmtstorage.Permissions) (*azstorage.Container, error) {
+	clusterRGName := stringutils.LastTokenByte(a.oc.Properties.ClusterProfile.ResourceGroupID, '/')
+	accountName := "cluster" + a.oc.Properties.StorageSuffix
+
+	t := time.Now().UTC().Truncate(time.Second)
+	res, err := a.storageAccounts.ListAccountSAS(
+		ctx, clusterRGName, accountName, mgmtstorage.AccountSasParameters{
+			Services:               mgmtstorage.B,
+			ResourceTypes:          mgmtstorage.SignedResourceTypes("co"),
+			Permissions:            permissions,
+			Protocols:              mgmtstorage.HTTPS,
+			SharedAccessStartTime:  &date.Time{Time: t},
+			SharedAccessExpiryTime: &date.Time{Time: t.Add(time.Hour)},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := url.ParseQuery(*res.AccountSasToken)
+	if err != nil {
+		return nil, err
+	}
+
+	blobService := azstorage.NewAccountSASClient(accountName, v, (*a.env.Environment()).Environment).GetBlobService()
+
+	return blobService.GetContainerReference(name), nil
+}
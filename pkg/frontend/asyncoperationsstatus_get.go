@@ -5,6 +5,7 @@ package frontend
 
 import (
 	"context"
+	"math"
 	"net/http"
 
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -57,6 +58,11 @@ func (f *frontend) _getAsyncOperationsStatus(ctx context.Context, r *http.Reques
 		asyncdoc.AsyncOperation.ProvisioningState = asyncdoc.AsyncOperation.InitialProvisioningState
 		asyncdoc.AsyncOperation.EndTime = nil
 		asyncdoc.AsyncOperation.Error = nil
+
+		if doc.OpenShiftCluster != nil {
+			asyncdoc.AsyncOperation.Properties = api.NewAsyncOperationProperties(doc.OpenShiftCluster)
+			asyncdoc.AsyncOperation.PercentComplete = percentComplete(doc.OpenShiftCluster)
+		}
 	}
 
 	asyncdoc.AsyncOperation.MissingFields = api.MissingFields{}
@@ -74,3 +80,21 @@ func (f *frontend) _getAsyncOperationsStatus(ctx context.Context, r *http.Reques
 
 	return b, nil
 }
+
+// percentComplete estimates the progress of the operation running on the
+// cluster from the steps it has completed.  The two install phases are
+// weighted equally.
+func percentComplete(oc *api.OpenShiftCluster) float64 {
+	progress := oc.Properties.OperationProgress
+	if progress == nil || progress.TotalSteps == 0 {
+		return 0
+	}
+
+	percent := float64(progress.CompletedSteps) / float64(progress.TotalSteps)
+
+	if oc.Properties.ProvisioningState == api.ProvisioningStateCreating && oc.Properties.Install != nil {
+		percent = (float64(oc.Properties.Install.Phase) + percent) / float64(api.InstallPhaseRemoveBootstrap+1)
+	}
+
+	return math.Round(percent * 100)
+}
@@ -101,6 +101,145 @@ func TestGetAsyncOperationsStatus(t *testing.T) {
 				StartTime:         mockOpStartTime,
 			},
 		},
+		{
+			name: "operation and cluster exist in db - install in progress",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					ID:                  mockOpID,
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperation: &api.AsyncOperation{
+						ID:                       "fakeoppath",
+						Name:                     mockOpID,
+						InitialProvisioningState: api.ProvisioningStateCreating,
+						ProvisioningState:        api.ProvisioningStateCreating,
+						StartTime:                mockOpStartTime,
+					},
+				})
+
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperationID: mockOpID,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateCreating,
+							Install: &api.Install{
+								Phase: api.InstallPhaseRemoveBootstrap,
+							},
+							OperationProgress: &api.OperationProgress{
+								Step:           "DeployingOperator",
+								CompletedSteps: 5,
+								TotalSteps:     10,
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.AsyncOperation{
+				ID:                "fakeoppath",
+				Name:              mockOpID,
+				ProvisioningState: api.ProvisioningStateCreating,
+				StartTime:         mockOpStartTime,
+				PercentComplete:   75,
+				Properties: &api.AsyncOperationProperties{
+					Phase:          "InstallPhaseRemoveBootstrap",
+					Step:           "DeployingOperator",
+					CompletedSteps: 5,
+					TotalSteps:     10,
+				},
+			},
+		},
+		{
+			name: "operation and cluster exist in db - update in progress",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					ID:                  mockOpID,
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperation: &api.AsyncOperation{
+						ID:                       "fakeoppath",
+						Name:                     mockOpID,
+						InitialProvisioningState: api.ProvisioningStateUpdating,
+						ProvisioningState:        api.ProvisioningStateUpdating,
+						StartTime:                mockOpStartTime,
+					},
+				})
+
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperationID: mockOpID,
+					OpenShiftCluster: &api.OpenShiftCluster{
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateUpdating,
+							OperationProgress: &api.OperationProgress{
+								Step:           "StartingVirtualMachines",
+								CompletedSteps: 1,
+								TotalSteps:     3,
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.AsyncOperation{
+				ID:                "fakeoppath",
+				Name:              mockOpID,
+				ProvisioningState: api.ProvisioningStateUpdating,
+				StartTime:         mockOpStartTime,
+				PercentComplete:   33,
+				Properties: &api.AsyncOperationProperties{
+					Step:           "StartingVirtualMachines",
+					CompletedSteps: 1,
+					TotalSteps:     3,
+				},
+			},
+		},
+		{
+			name: "operation failed - the failed step is returned",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					ID:                  mockOpID,
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+					AsyncOperation: &api.AsyncOperation{
+						ID:                       "fakeoppath",
+						Name:                     mockOpID,
+						InitialProvisioningState: api.ProvisioningStateUpdating,
+						ProvisioningState:        api.ProvisioningStateFailed,
+						StartTime:                mockOpStartTime,
+						EndTime:                  &mockOpEndTime,
+						Properties: &api.AsyncOperationProperties{
+							Step:           "StartingVirtualMachines",
+							CompletedSteps: 1,
+							TotalSteps:     3,
+						},
+						Error: &api.CloudErrorBody{
+							Code:    api.CloudErrorCodeInternalServerError,
+							Message: "Some error.",
+						},
+					},
+				})
+
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resource1")),
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &api.AsyncOperation{
+				ID:                "fakeoppath",
+				Name:              mockOpID,
+				ProvisioningState: api.ProvisioningStateFailed,
+				StartTime:         mockOpStartTime,
+				EndTime:           &mockOpEndTime,
+				Properties: &api.AsyncOperationProperties{
+					Step:           "StartingVirtualMachines",
+					CompletedSteps: 1,
+					TotalSteps:     3,
+				},
+				Error: &api.CloudErrorBody{
+					Code:    api.CloudErrorCodeInternalServerError,
+					Message: "Some error.",
+				},
+			},
+		},
 		{
 			name:           "operation not found in db",
 			wantStatusCode: http.StatusNotFound,
@@ -183,7 +322,7 @@ func TestGetAsyncOperationsStatus(t *testing.T) {
 				ti.asyncOperationsClient.SetError(tt.dbError)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
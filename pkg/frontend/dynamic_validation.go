@@ -0,0 +1,33 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/validate"
+)
+
+type DynamicValidator interface {
+	ValidateDynamic(ctx context.Context, environment env.Interface, subscription *api.SubscriptionDocument, oc *api.OpenShiftCluster) ([]error, error)
+}
+
+type dynamicValidator struct {
+	log *logrus.Entry
+}
+
+// ValidateDynamic runs the validations the backend runs before installing the
+// cluster, returning all their failures
+func (d dynamicValidator) ValidateDynamic(ctx context.Context, environment env.Interface, subscription *api.SubscriptionDocument, oc *api.OpenShiftCluster) ([]error, error) {
+	fpAuthorizer, err := environment.FPAuthorizer(subscription.Subscription.Properties.TenantID, environment.Environment().ResourceManagerScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return validate.NewOpenShiftClusterDynamicValidator(d.log, environment, oc, subscription, fpAuthorizer).DynamicAll(ctx)
+}
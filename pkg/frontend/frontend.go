@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,9 +28,11 @@ import (
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 	"github.com/Azure/ARO-RP/pkg/hive"
 	"github.com/Azure/ARO-RP/pkg/metrics"
+	"github.com/Azure/ARO-RP/pkg/operator/deploy"
 	"github.com/Azure/ARO-RP/pkg/util/bucket"
 	"github.com/Azure/ARO-RP/pkg/util/clusterdata"
 	"github.com/Azure/ARO-RP/pkg/util/encryption"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
 	"github.com/Azure/ARO-RP/pkg/util/heartbeat"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/recover"
@@ -45,6 +48,18 @@ type kubeActionsFactory func(*logrus.Entry, env.Interface, *api.OpenShiftCluster
 
 type azureActionsFactory func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.SubscriptionDocument) (adminactions.AzureActions, error)
 
+type etcdClientFactory func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (etcd.Client, error)
+
+type operatorDeployerFactory func(*logrus.Entry, env.Interface, *api.OpenShiftCluster, *api.TagPolicy) (deploy.Operator, error)
+
+const (
+	defaultShutdownDrainPeriod = 80 * time.Second
+
+	// shutdownTimeout bounds how long in-flight requests are given to
+	// complete once the drain period has elapsed
+	shutdownTimeout = time.Minute
+)
+
 type frontend struct {
 	auditLog *logrus.Entry
 	baseLog  *logrus.Entry
@@ -62,6 +77,8 @@ type frontend struct {
 	dbOpenShiftClusters           database.OpenShiftClusters
 	dbSubscriptions               database.Subscriptions
 	dbOpenShiftVersions           database.OpenShiftVersions
+	dbClusterHealth               database.ClusterHealth
+	dbCircuitBreaker              *database.CircuitBreaker
 
 	enabledOcpVersions map[string]*api.OpenShiftVersion
 	apis               map[string]*api.Version
@@ -71,13 +88,16 @@ type frontend struct {
 
 	aead encryption.AEAD
 
-	hiveClusterManager  hive.ClusterManager
-	kubeActionsFactory  kubeActionsFactory
-	azureActionsFactory azureActionsFactory
+	hiveClusterManager      hive.ClusterManager
+	kubeActionsFactory      kubeActionsFactory
+	azureActionsFactory     azureActionsFactory
+	etcdClientFactory       etcdClientFactory
+	operatorDeployerFactory operatorDeployerFactory
 
 	skuValidator       SkuValidator
 	quotaValidator     QuotaValidator
 	providersValidator ProvidersValidator
+	dynamicValidator   DynamicValidator
 
 	clusterEnricher clusterdata.BestEffortEnricher
 
@@ -86,6 +106,14 @@ type frontend struct {
 
 	bucketAllocator bucket.Allocator
 
+	// listPageSize is the maximum number of clusters returned per page of a
+	// list response
+	listPageSize int
+
+	// shutdownDrainPeriod is how long the frontend reports not ready before
+	// it stops accepting connections during shutdown
+	shutdownDrainPeriod time.Duration
+
 	startTime time.Time
 	ready     atomic.Value
 
@@ -116,6 +144,8 @@ func NewFrontend(ctx context.Context,
 	dbOpenShiftClusters database.OpenShiftClusters,
 	dbSubscriptions database.Subscriptions,
 	dbOpenShiftVersions database.OpenShiftVersions,
+	dbClusterHealth database.ClusterHealth,
+	dbCircuitBreaker *database.CircuitBreaker,
 	apis map[string]*api.Version,
 	m metrics.Emitter,
 	clusterm metrics.Emitter,
@@ -125,6 +155,16 @@ func NewFrontend(ctx context.Context,
 	azureActionsFactory azureActionsFactory,
 	enricher clusterdata.BestEffortEnricher,
 ) (*frontend, error) {
+	listPageSize, err := listPageSizeFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownDrainPeriod, err := shutdownDrainPeriodFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
 	f := &frontend{
 		logMiddleware: middleware.LogMiddleware{
 			EnvironmentName: _env.Environment().Name,
@@ -152,6 +192,8 @@ func NewFrontend(ctx context.Context,
 		dbOpenShiftClusters:           dbOpenShiftClusters,
 		dbSubscriptions:               dbSubscriptions,
 		dbOpenShiftVersions:           dbOpenShiftVersions,
+		dbClusterHealth:               dbClusterHealth,
+		dbCircuitBreaker:              dbCircuitBreaker,
 		apis:                          apis,
 		m:                             middleware.MetricsMiddleware{Emitter: m},
 		maintenanceMiddleware:         middleware.MaintenanceMiddleware{Emitter: clusterm},
@@ -159,10 +201,13 @@ func NewFrontend(ctx context.Context,
 		hiveClusterManager:            hiveClusterManager,
 		kubeActionsFactory:            kubeActionsFactory,
 		azureActionsFactory:           azureActionsFactory,
+		etcdClientFactory:             newEtcdClient,
+		operatorDeployerFactory:       newOperatorDeployer,
 
 		quotaValidator:     quotaValidator{},
 		skuValidator:       skuValidator{},
-		providersValidator: providersValidator{},
+		providersValidator: providersValidator{log: baseLog},
+		dynamicValidator:   dynamicValidator{log: baseLog},
 
 		clusterEnricher: enricher,
 
@@ -170,6 +215,9 @@ func NewFrontend(ctx context.Context,
 
 		bucketAllocator: &bucket.Random{},
 
+		listPageSize:        listPageSize,
+		shutdownDrainPeriod: shutdownDrainPeriod,
+
 		startTime: time.Now(),
 
 		now:                          time.Now,
@@ -260,6 +308,10 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 					r.Post("/listcredentials", f.postOpenShiftClusterCredentials)
 
 					r.Post("/listadmincredentials", f.postOpenShiftClusterKubeConfigCredentials)
+
+					r.Post("/validate", f.postOpenShiftClusterValidate)
+
+					r.Post("/exporttemplate", f.postOpenShiftClusterExportTemplate)
 				})
 
 				r.Get("/detectors", f.listAppLensDetectors)
@@ -284,6 +336,8 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 				r.Get("/operationresults/{operationId}", f.getAsyncOperationResult)
 
 				r.Get("/openshiftversions", f.listInstallVersions)
+
+				r.Get("/openshiftversionmatrix", f.getOpenShiftVersionMatrix)
 			})
 		})
 	})
@@ -294,10 +348,14 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 		r.Route("/versions", func(r chi.Router) {
 			r.Get("/", f.getAdminOpenShiftVersions)
 			r.Put("/", f.putAdminOpenShiftVersion)
+			r.Get("/deprecatedclusters", f.getAdminDeprecatedVersionClusters)
 		})
 		r.Get("/supportedvmsizes", f.supportedvmsizes)
 
 		r.Route("/subscriptions/{subscriptionId}", func(r chi.Router) {
+			r.Get("/tagpolicy", f.getAdminSubscriptionTagPolicy)
+			r.Put("/tagpolicy", f.putAdminSubscriptionTagPolicy)
+
 			r.Route("/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}", func(r chi.Router) {
 				// Etcd recovery
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/etcdrecovery", f.postAdminOpenShiftClusterEtcdRecovery)
@@ -326,6 +384,12 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 
 				r.Get("/skus", f.getAdminOpenShiftClusterVMResizeOptions)
 
+				r.Get("/availability", f.getAdminOpenShiftClusterAvailability)
+
+				r.Get("/inventory", f.getAdminOpenShiftClusterInventory)
+
+				r.Get("/operationevents", f.getAdminOpenShiftClusterOperationEvents)
+
 				// We don't emit unplanned maintenance signal for resize since it is only used for planned maintenance
 				r.Post("/resize", f.postAdminOpenShiftClusterVMResize)
 
@@ -335,7 +399,31 @@ func (f *frontend) chiAuthenticatedRoutes(router chi.Router) {
 
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/drainnode", f.postAdminOpenShiftClusterDrainNode)
 
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/cordonmachineset", f.postAdminOpenShiftClusterCordonMachineSet)
+
+				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/uncordonmachineset", f.postAdminOpenShiftClusterUncordonMachineSet)
+
 				r.With(f.maintenanceMiddleware.UnplannedMaintenanceSignal).Post("/etcdcertificaterenew", f.postAdminOpenShiftClusterEtcdCertificateRenew)
+
+				r.Get("/etcdstatus", f.getAdminOpenShiftClusterEtcdStatus)
+
+				r.Get("/operatormanifestdiff", f.getAdminOpenShiftClusterOperatorManifestDiff)
+				r.Post("/operatorreconcile", f.postAdminOpenShiftClusterOperatorReconcile)
+
+				r.Get("/ignition", f.getAdminOpenShiftClusterIgnition)
+
+				r.Get("/installconfig", f.getAdminOpenShiftClusterInstallConfig)
+
+				r.Post("/resumeinstall", f.postAdminOpenShiftClusterResumeInstall)
+
+				r.Post("/quarantine", f.postAdminOpenShiftClusterQuarantine)
+				r.Delete("/quarantine", f.deleteAdminOpenShiftClusterQuarantine)
+
+				r.Post("/nodeupdatespause", f.postAdminOpenShiftClusterNodeUpdatesPause)
+				r.Delete("/nodeupdatespause", f.deleteAdminOpenShiftClusterNodeUpdatesPause)
+
+				r.Post("/supportannotation", f.postAdminOpenShiftClusterSupportAnnotation)
+				r.Delete("/supportannotation", f.deleteAdminOpenShiftClusterSupportAnnotation)
 			})
 		})
 
@@ -380,33 +468,25 @@ func (f *frontend) Run(ctx context.Context, stop <-chan struct{}, done chan<- st
 	defer recover.Panic(f.baseLog)
 	go f.changefeed(ctx)
 
+	f.s = &http.Server{
+		Handler:     middleware.Lowercase(f.setupRouter()),
+		ReadTimeout: 10 * time.Second,
+		IdleTimeout: 2 * time.Minute,
+		ErrorLog:    log.New(f.baseLog.Writer(), "", 0),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
 	if stop != nil {
 		go func() {
 			defer recover.Panic(f.baseLog)
 
 			<-stop
 
-			if !f.env.FeatureIsSet(env.FeatureDisableReadinessDelay) {
-				// mark not ready and wait for ((#probes + 1) * interval + longest
-				// connection timeout + margin) to stop receiving new connections
-				f.baseLog.Print("marking not ready and waiting 80 seconds")
-				f.ready.Store(false)
-				time.Sleep(80 * time.Second)
-			}
-
-			f.baseLog.Print("exiting")
+			f.shutdown()
 			close(done)
 		}()
 	}
 
-	f.s = &http.Server{
-		Handler:     middleware.Lowercase(f.setupRouter()),
-		ReadTimeout: 10 * time.Second,
-		IdleTimeout: 2 * time.Minute,
-		ErrorLog:    log.New(f.baseLog.Writer(), "", 0),
-		BaseContext: func(net.Listener) context.Context { return ctx },
-	}
-
 	go heartbeat.EmitHeartbeat(f.baseLog, f.m, "frontend.heartbeat", stop, f.checkReady)
 
 	err := f.s.Serve(f.l)
@@ -415,6 +495,47 @@ func (f *frontend) Run(ctx context.Context, stop <-chan struct{}, done chan<- st
 	}
 }
 
+// shutdown marks the frontend not ready, waits for the load balancer to stop
+// sending it new connections and then waits for in-flight requests to finish
+func (f *frontend) shutdown() {
+	f.ready.Store(false)
+
+	if !f.env.FeatureIsSet(env.FeatureDisableReadinessDelay) {
+		// wait for ((#probes + 1) * interval + longest connection timeout +
+		// margin) to stop receiving new connections
+		f.baseLog.Printf("marking not ready and waiting %s", f.shutdownDrainPeriod)
+		time.Sleep(f.shutdownDrainPeriod)
+	}
+
+	f.baseLog.Print("waiting for in-flight requests to complete")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := f.s.Shutdown(ctx)
+	if err != nil {
+		f.baseLog.Error(err)
+	}
+
+	f.baseLog.Print("exiting")
+}
+
+// shutdownDrainPeriodFromEnvironment returns the shutdown drain period set by
+// the RP_SHUTDOWN_DRAIN_PERIOD environment variable (the rpShutdownDrainPeriod
+// RP configuration), or the default if it isn't set
+func shutdownDrainPeriodFromEnvironment() (time.Duration, error) {
+	v := os.Getenv("RP_SHUTDOWN_DRAIN_PERIOD")
+	if v == "" {
+		return defaultShutdownDrainPeriod, nil
+	}
+
+	drainPeriod, err := time.ParseDuration(v)
+	if err != nil || drainPeriod < 0 {
+		return 0, fmt.Errorf("invalid RP_SHUTDOWN_DRAIN_PERIOD %q: must be a non-negative duration", v)
+	}
+
+	return drainPeriod, nil
+}
+
 func adminReply(log *logrus.Entry, w http.ResponseWriter, header http.Header, b []byte, err error) {
 	if apiErr, ok := err.(kerrors.APIStatus); ok {
 		status := apiErr.Status()
@@ -7,12 +7,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
@@ -25,6 +27,8 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	"github.com/Azure/ARO-RP/test/util/listener"
 	testlog "github.com/Azure/ARO-RP/test/util/log"
 )
 
@@ -231,3 +235,121 @@ func routeIsAllLowercase(route chi.Route) bool {
 
 	return true
 }
+
+func TestShutdown(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().FeatureIsSet(env.FeatureDisableReadinessDelay).AnyTimes().Return(false)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	l := listener.NewListener()
+	f := &frontend{
+		baseLog: logrus.NewEntry(logrus.StandardLogger()),
+		env:     _env,
+		s: &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-release
+			}),
+		},
+		shutdownDrainPeriod: 10 * time.Millisecond,
+	}
+	f.ready.Store(true)
+
+	go f.s.Serve(l)
+
+	cli := &http.Client{
+		Transport: &http.Transport{
+			DialContext: l.DialContext,
+		},
+	}
+
+	respErr := make(chan error)
+	go func() {
+		resp, err := cli.Get("http://server/")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+			}
+		}
+		respErr <- err
+	}()
+
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		f.shutdown()
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if f.ready.Load().(bool) {
+		t.Error("frontend still ready")
+	}
+
+	select {
+	case <-done:
+		t.Fatal("shutdown completed with a request in flight")
+	default:
+	}
+
+	close(release)
+
+	err := <-respErr
+	if err != nil {
+		t.Error(err)
+	}
+
+	<-done
+}
+
+func TestShutdownDrainPeriodFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: defaultShutdownDrainPeriod,
+		},
+		{
+			name:  "set",
+			value: "2m",
+			want:  2 * time.Minute,
+		},
+		{
+			name:  "zero",
+			value: "0s",
+		},
+		{
+			name:    "not a duration",
+			value:   "80",
+			wantErr: `invalid RP_SHUTDOWN_DRAIN_PERIOD "80": must be a non-negative duration`,
+		},
+		{
+			name:    "negative",
+			value:   "-1s",
+			wantErr: `invalid RP_SHUTDOWN_DRAIN_PERIOD "-1s": must be a non-negative duration`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RP_SHUTDOWN_DRAIN_PERIOD", tt.value)
+
+			got, err := shutdownDrainPeriodFromEnvironment()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
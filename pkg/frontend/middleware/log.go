@@ -12,11 +12,16 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/admin"
 	utillog "github.com/Azure/ARO-RP/pkg/util/log"
 	"github.com/Azure/ARO-RP/pkg/util/log/audit"
+	"github.com/Azure/ARO-RP/pkg/util/tracing"
 	"github.com/Azure/ARO-RP/pkg/util/uuid"
 )
 
@@ -65,11 +70,22 @@ func (l LogMiddleware) Log(h http.Handler) http.Handler {
 		r.Body = &logReadCloser{ReadCloser: r.Body}
 		w = &logResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
+		// continue the caller's trace if it sent a traceparent header
+		ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, "frontend "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", r.Method),
+				attribute.String("http.target", r.URL.Path),
+			),
+		)
+
 		correlationData := &api.CorrelationData{
 			ClientRequestID: r.Header.Get("X-Ms-Client-Request-Id"),
 			CorrelationID:   r.Header.Get("X-Ms-Correlation-Request-Id"),
 			RequestID:       uuid.DefaultGenerator.Generate(),
 			RequestTime:     t,
+			TraceParent:     tracing.TraceParent(ctx),
 		}
 
 		if r.URL.Query().Get(api.APIVersionKey) == admin.APIVersion || isAdminOp(r) {
@@ -86,7 +102,6 @@ func (l LogMiddleware) Log(h http.Handler) http.Handler {
 		log = utillog.EnrichWithPath(log, r.URL.Path)
 		log = utillog.EnrichWithCorrelationData(log, correlationData)
 
-		ctx := r.Context()
 		ctx = context.WithValue(ctx, ContextKeyLog, log)
 		ctx = context.WithValue(ctx, ContextKeyCorrelationData, correlationData)
 
@@ -148,6 +163,13 @@ func (l LogMiddleware) Log(h http.Handler) http.Handler {
 
 		defer func() {
 			statusCode := w.(*logResponseWriter).statusCode
+
+			span.SetAttributes(attribute.Int("http.status_code", statusCode))
+			if statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(statusCode))
+			}
+			span.End()
+
 			log.WithFields(logrus.Fields{
 				"body_read_bytes":      r.Body.(*logReadCloser).bytes,
 				"body_written_bytes":   w.(*logResponseWriter).bytes,
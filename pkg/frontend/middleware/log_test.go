@@ -6,8 +6,12 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
 )
 
 func TestAuditTargetResourceData(t *testing.T) {
@@ -142,3 +146,34 @@ func TestIsAdminOp(t *testing.T) {
 		}
 	}
 }
+
+func TestLogTraceParent(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	h, log := testlog.New()
+	_, auditLog := testlog.NewAudit()
+
+	var correlationData *api.CorrelationData
+	handler := LogMiddleware{
+		AuditLog: auditLog,
+		BaseLog:  log,
+	}.Log(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationData = r.Context().Value(ContextKeyCorrelationData).(*api.CorrelationData)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/subscriptions/sub", nil)
+	r.Header.Set("Traceparent", traceParent)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	// the global tracer provider is the no-op provider in tests, which
+	// propagates the caller's span
+	if correlationData.TraceParent != traceParent {
+		t.Errorf("got traceparent %q", correlationData.TraceParent)
+	}
+
+	for _, e := range h.AllEntries() {
+		if e.Data["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("%q: got trace_id %v", e.Message, e.Data["trace_id"])
+		}
+	}
+}
@@ -5,17 +5,28 @@ package frontend
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/feature"
+	utilnamespace "github.com/Azure/ARO-RP/pkg/util/namespace"
 )
 
+// activeWorkloadsTimeout bounds the lookup of the customer workloads of a
+// cluster which is about to be deleted
+const activeWorkloadsTimeout = 30 * time.Second
+
 func (f *frontend) deleteOpenShiftCluster(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
@@ -38,16 +49,32 @@ func (f *frontend) deleteOpenShiftCluster(w http.ResponseWriter, r *http.Request
 func (f *frontend) _deleteOpenShiftCluster(ctx context.Context, r *http.Request, header *http.Header, doc *api.OpenShiftClusterDocument) error {
 	correlationData := r.Context().Value(middleware.ContextKeyCorrelationData).(*api.CorrelationData)
 
-	_, err := f.validateSubscriptionState(ctx, doc.Key, api.SubscriptionStateRegistered, api.SubscriptionStateWarned, api.SubscriptionStateSuspended)
+	subscription, err := f.validateSubscriptionState(ctx, doc.Key, api.SubscriptionStateRegistered, api.SubscriptionStateWarned, api.SubscriptionStateSuspended)
 	if err != nil {
 		return err
 	}
 
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	cancelDeferredAdminUpdate(log, doc)
+
 	err = validateTerminalProvisioningState(doc.OpenShiftCluster.Properties.ProvisioningState)
 	if err != nil {
 		return err
 	}
 
+	err = f.validateRPVersionPin(ctx, log, doc.OpenShiftCluster, doc.OpenShiftCluster.Properties.RPVersionPin)
+	if err != nil {
+		return err
+	}
+
+	if feature.IsRegisteredForFeature(subscription.Subscription.Properties, api.FeatureFlagWorkloadDeletionProtection) &&
+		!strings.EqualFold(r.URL.Query().Get("force"), "true") {
+		err = f.validateNoActiveWorkloads(ctx, log, doc.OpenShiftCluster)
+		if err != nil {
+			return err
+		}
+	}
+
 	doc.OpenShiftCluster.Properties.LastProvisioningState = doc.OpenShiftCluster.Properties.ProvisioningState
 	doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateDeleting
 	doc.CorrelationData = correlationData
@@ -76,3 +103,60 @@ func (f *frontend) _deleteOpenShiftCluster(ctx context.Context, r *http.Request,
 
 	return nil
 }
+
+// validateNoActiveWorkloads refuses the deletion of a cluster which has
+// running pods outside of the platform namespaces.  If the cluster cannot be
+// reached, e.g. because its installation failed, the deletion goes ahead.
+func (f *frontend) validateNoActiveWorkloads(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster) error {
+	namespaces, err := f.activeWorkloadNamespaces(ctx, log, oc)
+	if err != nil {
+		log.Warnf("skipping the active workloads check: %v", err)
+		return nil
+	}
+
+	if len(namespaces) == 0 {
+		return nil
+	}
+
+	return api.NewCloudError(http.StatusConflict, api.CloudErrorCodeRequestNotAllowed, "",
+		"The cluster has running workloads in the namespaces '%s'. Remove them, or set the force=true query parameter to delete the cluster anyway.", strings.Join(namespaces, "', '"))
+}
+
+// activeWorkloadNamespaces returns the sorted namespaces, other than the
+// platform ones, which have running pods
+func (f *frontend) activeWorkloadNamespaces(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, activeWorkloadsTimeout)
+	defer cancel()
+
+	k, err := f.kubeActionsFactory(log, f.env, oc)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := k.KubeList(ctx, "Pod", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var pods corev1.PodList
+	err = json.Unmarshal(b, &pods)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]struct{}{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || utilnamespace.IsPlatformNamespace(pod.Namespace) {
+			continue
+		}
+		m[pod.Namespace] = struct{}{}
+	}
+
+	namespaces := make([]string, 0, len(m))
+	for namespace := range m {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
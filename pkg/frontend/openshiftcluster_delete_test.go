@@ -5,14 +5,25 @@ package frontend
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/adminactions"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_adminactions "github.com/Azure/ARO-RP/pkg/util/mocks/adminactions"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
 )
 
@@ -20,11 +31,124 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 	ctx := context.Background()
 
 	mockSubID := "00000000-0000-0000-0000-000000000000"
+	deferredUntil := time.Date(2023, time.July, 8, 22, 0, 0, 0, time.UTC)
+
+	// protectedFixture adds a succeeded cluster in a subscription which is
+	// registered for the workload deletion protection
+	protectedFixture := func(f *testdatabase.Fixture) {
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: "11111111-1111-1111-1111-111111111111",
+					RegisteredFeatures: []api.RegisteredFeatureProfile{
+						{
+							Name:  api.FeatureFlagWorkloadDeletionProtection,
+							State: "Registered",
+						},
+					},
+				},
+			},
+		})
+		f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+				Name: "resourceName",
+				Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateSucceeded,
+				},
+			},
+		})
+	}
+
+	protectedDocuments := func(deleting bool) func(*testdatabase.Checker) {
+		return func(c *testdatabase.Checker) {
+			doc := &api.OpenShiftClusterDocument{
+				Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+				OpenShiftCluster: &api.OpenShiftCluster{
+					ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+					Name: "resourceName",
+					Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+					Properties: api.OpenShiftClusterProperties{
+						ProvisioningState: api.ProvisioningStateSucceeded,
+					},
+				},
+			}
+			if deleting {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateDeleting,
+						ProvisioningState:        api.ProvisioningStateDeleting,
+					},
+				})
+				doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateDeleting
+				doc.OpenShiftCluster.Properties.LastProvisioningState = api.ProvisioningStateSucceeded
+			}
+			c.AddOpenShiftClusterDocuments(doc)
+		}
+	}
+
+	// podList returns the marshalled pods of a 4.12 cluster, which run in
+	// platform namespaces missing from the fixed list of OpenShift namespaces,
+	// e.g. kube-system or those of optional operators, and the given pods
+	podList := func(pods ...corev1.Pod) []byte {
+		pod := func(namespace, name string, phase corev1.PodPhase) corev1.Pod {
+			return corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+				},
+				Status: corev1.PodStatus{
+					Phase: phase,
+				},
+			}
+		}
+
+		list := corev1.PodList{
+			Items: append([]corev1.Pod{
+				pod("openshift-etcd", "etcd-master-0", corev1.PodRunning),
+				pod("openshift-ingress", "router-default-5d9f8c7b9-x7k2p", corev1.PodRunning),
+				pod("kube-system", "konnectivity-agent-9zq4v", corev1.PodRunning),
+				pod("openshift-authentication", "oauth-openshift-7c9d6f4b8-2mxlw", corev1.PodRunning),
+				pod("openshift-ovn-kubernetes", "ovnkube-node-6hk8j", corev1.PodRunning),
+				pod("openshift-route-controller-manager", "route-controller-manager-6b8f9d-4qzpt", corev1.PodRunning),
+				pod("openshift-gitops", "openshift-gitops-server-6f7d8c9b5-lp2nr", corev1.PodRunning),
+				pod("openshift-kube-apiserver", "installer-7-master-0", corev1.PodSucceeded),
+			}, pods...),
+		}
+
+		b, err := json.Marshal(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	customerPods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "frontend"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "billing", Name: "api"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: "job"},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
 
 	type test struct {
 		name           string
 		resourceID     string
+		query          string
 		fixture        func(*testdatabase.Fixture)
+		kubeMocks      func(*mock_adminactions.MockKubeActions)
 		dbError        error
 		wantDocuments  func(*testdatabase.Checker)
 		wantStatusCode int
@@ -83,6 +207,149 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 			wantStatusCode: http.StatusAccepted,
 			wantAsync:      true,
 		},
+		{
+			name:       "cluster with a deferred admin update",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:        api.ProvisioningStateAdminUpdating,
+							LastProvisioningState:    api.ProvisioningStateSucceeded,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							MaintenanceDeferredUntil: &deferredUntil,
+						},
+					},
+				})
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddAsyncOperationDocuments(&api.AsyncOperationDocument{
+					OpenShiftClusterKey: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					AsyncOperation: &api.AsyncOperation{
+						InitialProvisioningState: api.ProvisioningStateDeleting,
+						ProvisioningState:        api.ProvisioningStateDeleting,
+					},
+				})
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:     api.ProvisioningStateDeleting,
+							LastProvisioningState: api.ProvisioningStateSucceeded,
+							LastAdminUpdateError:  "admin update deferred to the maintenance window was cancelled by a subsequent operation",
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+		},
+		{
+			name:       "cluster with an admin update in progress",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:        strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					LeaseOwner: "backend",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:        api.ProvisioningStateAdminUpdating,
+							LastProvisioningState:    api.ProvisioningStateSucceeded,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							MaintenanceDeferredUntil: &deferredUntil,
+						},
+					},
+				})
+			},
+			wantDocuments: func(c *testdatabase.Checker) {
+				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:        api.ProvisioningStateAdminUpdating,
+							LastProvisioningState:    api.ProvisioningStateSucceeded,
+							MaintenanceTask:          api.MaintenanceTaskEverything,
+							MaintenanceDeferredUntil: &deferredUntil,
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: RequestNotAllowed: : Request is not allowed in provisioningState 'AdminUpdating'.",
+		},
+		{
+			name:       "protected cluster with customer workloads",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    protectedFixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeList(gomock.Any(), "Pod", "").Return(podList(customerPods...), nil)
+			},
+			wantDocuments:  protectedDocuments(false),
+			wantStatusCode: http.StatusConflict,
+			wantError:      "409: RequestNotAllowed: : The cluster has running workloads in the namespaces 'billing', 'shop'. Remove them, or set the force=true query parameter to delete the cluster anyway.",
+		},
+		{
+			name:           "protected cluster with customer workloads, forced",
+			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			query:          "&force=true",
+			fixture:        protectedFixture,
+			wantDocuments:  protectedDocuments(true),
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+		},
+		{
+			name:       "protected cluster without customer workloads",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    protectedFixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeList(gomock.Any(), "Pod", "").Return(podList(), nil)
+			},
+			wantDocuments:  protectedDocuments(true),
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+		},
+		{
+			name:       "protected cluster which cannot be reached",
+			resourceID: testdatabase.GetResourcePath(mockSubID, "resourceName"),
+			fixture:    protectedFixture,
+			kubeMocks: func(k *mock_adminactions.MockKubeActions) {
+				k.EXPECT().KubeList(gomock.Any(), "Pod", "").Return(nil, errors.New("connection refused"))
+			},
+			wantDocuments:  protectedDocuments(true),
+			wantStatusCode: http.StatusAccepted,
+			wantAsync:      true,
+		},
 		{
 			name:           "cluster not found in db",
 			resourceID:     testdatabase.GetResourcePath(mockSubID, "resourceName"),
@@ -114,7 +381,14 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 				ti.subscriptionsClient.SetError(tt.dbError)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			k := mock_adminactions.NewMockKubeActions(ti.controller)
+			if tt.kubeMocks != nil {
+				tt.kubeMocks(k)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, func(*logrus.Entry, env.Interface, *api.OpenShiftCluster) (adminactions.KubeActions, error) {
+				return k, nil
+			}, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -122,7 +396,7 @@ func TestDeleteOpenShiftCluster(t *testing.T) {
 			go f.Run(ctx, nil, nil)
 
 			resp, b, err := ti.request(http.MethodDelete,
-				"https://server"+tt.resourceID+"?api-version=2020-04-30",
+				"https://server"+tt.resourceID+"?api-version=2020-04-30"+tt.query,
 				nil, nil)
 			if err != nil {
 				t.Error(err)
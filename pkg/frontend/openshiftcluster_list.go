@@ -7,8 +7,11 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -19,6 +22,28 @@ import (
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
 )
 
+const (
+	defaultListPageSize = 10
+	maxListPageSize     = 1000
+)
+
+// listPageSizeFromEnvironment returns the list page size set by the
+// RP_LIST_PAGE_SIZE environment variable (the rpListPageSize RP
+// configuration), or the default if it isn't set
+func listPageSizeFromEnvironment() (int, error) {
+	v := os.Getenv("RP_LIST_PAGE_SIZE")
+	if v == "" {
+		return defaultListPageSize, nil
+	}
+
+	pageSize, err := strconv.Atoi(v)
+	if err != nil || pageSize < 1 || pageSize > maxListPageSize {
+		return 0, fmt.Errorf("invalid RP_LIST_PAGE_SIZE %q: must be between 1 and %d", v, maxListPageSize)
+	}
+
+	return pageSize, nil
+}
+
 func (f *frontend) getOpenShiftClusters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
@@ -39,15 +64,20 @@ func (f *frontend) getOpenShiftClusters(w http.ResponseWriter, r *http.Request)
 func (f *frontend) _getOpenShiftClusters(ctx context.Context, log *logrus.Entry, r *http.Request, converter api.OpenShiftClusterConverter, lister func(string) (cosmosdb.OpenShiftClusterDocumentIterator, error)) ([]byte, error) {
 	skipToken, err := f.parseSkipToken(r.URL.String())
 	if err != nil {
-		return nil, err
+		log.Info(err)
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "$skipToken", "The provided $skipToken is invalid.")
 	}
 
+	// the skip token wraps the Cosmos DB query continuation, which points
+	// after the last document returned rather than at an offset, so
+	// documents which are created or deleted between pages neither cause
+	// others to be skipped nor returned twice
 	i, err := lister(skipToken)
 	if err != nil {
 		return nil, err
 	}
 
-	docs, err := i.Next(ctx, 10)
+	docs, err := i.Next(ctx, f.listPageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
 func makeDoc(num int) *api.OpenShiftClusterDocument {
@@ -49,6 +51,7 @@ func TestListOpenShiftCluster(t *testing.T) {
 		name           string
 		fixture        func(*testdatabase.Fixture)
 		dbError        error
+		pageSize       int
 		skipToken      string
 		wantEnriched   []string
 		wantStatusCode int
@@ -150,6 +153,44 @@ func TestListOpenShiftCluster(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "custom page size",
+			fixture: func(f *testdatabase.Fixture) {
+				var docs []*api.OpenShiftClusterDocument
+				for i := 1; i < 12; i++ {
+					docs = append(docs, makeDoc(i))
+				}
+				f.AddOpenShiftClusterDocuments(docs...)
+			},
+			pageSize: 3,
+			wantEnriched: []string{
+				testdatabase.GetResourcePath(mockSubID, "resourceName01"),
+				testdatabase.GetResourcePath(mockSubID, "resourceName02"),
+				testdatabase.GetResourcePath(mockSubID, "resourceName03"),
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func() *v20200430.OpenShiftClusterList {
+				var docs []*v20200430.OpenShiftCluster
+				for i := 1; i <= 3; i++ {
+					docs = append(docs, &v20200430.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, fmt.Sprintf("resourceName%02d", i)),
+						Name: fmt.Sprintf("resourceName%02d", i),
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+					})
+				}
+
+				return &v20200430.OpenShiftClusterList{
+					OpenShiftClusters: docs,
+					NextLink:          "https://mockrefererhost/?%24skipToken=" + url.QueryEscape(base64.StdEncoding.EncodeToString([]byte("FAKE3"))),
+				}
+			},
+		},
+		{
+			name:           "invalid pagination token",
+			skipToken:      "invalid",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: $skipToken: The provided $skipToken is invalid.",
+		},
 		{
 			name:           "no clusters found in db",
 			wantStatusCode: http.StatusOK,
@@ -192,11 +233,15 @@ func TestListOpenShiftCluster(t *testing.T) {
 
 					aead := testdatabase.NewFakeAEAD()
 
-					f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, aead, nil, nil, nil, ti.enricher)
+					f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, aead, nil, nil, nil, ti.enricher)
 					if err != nil {
 						t.Fatal(err)
 					}
 
+					if tt.pageSize != 0 {
+						f.listPageSize = tt.pageSize
+					}
+
 					go f.Run(ctx, nil, nil)
 
 					resp, b, err := ti.request(http.MethodGet,
@@ -222,3 +267,43 @@ func TestListOpenShiftCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestListPageSizeFromEnvironment(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		value   string
+		want    int
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: defaultListPageSize,
+		},
+		{
+			name:  "set",
+			value: "50",
+			want:  50,
+		},
+		{
+			name:    "not a number",
+			value:   "lots",
+			wantErr: `invalid RP_LIST_PAGE_SIZE "lots": must be between 1 and 1000`,
+		},
+		{
+			name:    "too large",
+			value:   "1001",
+			wantErr: `invalid RP_LIST_PAGE_SIZE "1001": must be between 1 and 1000`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RP_LIST_PAGE_SIZE", tt.value)
+
+			got, err := listPageSizeFromEnvironment()
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if got != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
 	"github.com/Azure/ARO-RP/pkg/util/feature"
 	"github.com/Azure/ARO-RP/pkg/util/version"
 )
@@ -93,6 +94,8 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 
 	doc.CorrelationData = correlationData
 
+	cancelDeferredAdminUpdate(log, doc)
+
 	err = validateTerminalProvisioningState(doc.OpenShiftCluster.Properties.ProvisioningState)
 	if err != nil {
 		return nil, err
@@ -151,6 +154,13 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 		ext = converter.ToExternal(doc.OpenShiftCluster)
 	}
 
+	if isCreate {
+		err = prefillFromTemplate(f.apis[apiVersion].OpenShiftClusterTemplateConverter, ext, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = json.Unmarshal(body, &ext)
 	if err != nil {
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
@@ -170,6 +180,9 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 	}
 
 	oldID, oldName, oldType, oldSystemData := doc.OpenShiftCluster.ID, doc.OpenShiftCluster.Name, doc.OpenShiftCluster.Type, doc.OpenShiftCluster.SystemData
+	tags := doc.OpenShiftCluster.Tags
+	workerProfiles := doc.OpenShiftCluster.Properties.WorkerProfiles
+	rpVersionPin := doc.OpenShiftCluster.Properties.RPVersionPin
 	converter.ToInternal(ext, doc.OpenShiftCluster)
 	doc.OpenShiftCluster.ID, doc.OpenShiftCluster.Name, doc.OpenShiftCluster.Type, doc.OpenShiftCluster.SystemData = oldID, oldName, oldType, oldSystemData
 
@@ -189,8 +202,17 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 
 		doc.ClusterResourceGroupIDKey = strings.ToLower(doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID)
 		doc.ClientIDKey = strings.ToLower(doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientID)
+		doc.ClusterDomainKey, err = dns.ClusterDomainKey(f.env, doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+		if err != nil {
+			return nil, err
+		}
 		doc.OpenShiftCluster.Properties.ProvisioningState = api.ProvisioningStateCreating
 
+		err = f.validateClusterDomainOverlap(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+
 		doc.Bucket, err = f.bucketAllocator.Allocate()
 		if err != nil {
 			return nil, err
@@ -202,6 +224,21 @@ func (f *frontend) _putOrPatchOpenShiftCluster(ctx context.Context, log *logrus.
 			doc.OpenShiftCluster.Properties.NetworkProfile.PreconfiguredNSG = api.PreconfiguredNSGEnabled
 		}
 	} else {
+		err = validateRequiredTagsKept(subscription, tags, doc.OpenShiftCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		err = f.validateWorkerProfilesScale(ctx, subscription, doc.OpenShiftCluster, workerProfiles)
+		if err != nil {
+			return nil, err
+		}
+
+		err = f.validateRPVersionPin(ctx, log, doc.OpenShiftCluster, rpVersionPin)
+		if err != nil {
+			return nil, err
+		}
+
 		setUpdateProvisioningState(doc, apiVersion)
 	}
 
@@ -287,6 +324,11 @@ func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.Sub
 		return err
 	}
 
+	err = validateRequiredTags(subscription, cluster)
+	if err != nil {
+		return err
+	}
+
 	err = f.skuValidator.ValidateVMSku(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, cluster)
 	if err != nil {
 		return err
@@ -305,6 +347,60 @@ func (f *frontend) ValidateNewCluster(ctx context.Context, subscription *api.Sub
 	return nil
 }
 
+// validateRequiredTags rejects the creation of a cluster which lacks a tag
+// required by the tag policy of its subscription
+func validateRequiredTags(subscription *api.SubscriptionDocument, cluster *api.OpenShiftCluster) error {
+	_, missing := subscription.TagPolicy.Match(cluster.Tags)
+	if len(missing) > 0 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "tags", "The tag '%s' is required by the tag policy of the subscription.", missing[0])
+	}
+
+	return nil
+}
+
+// validateRequiredTagsKept rejects an update of a cluster which removes a tag
+// required by the tag policy of its subscription.  Clusters which lacked the
+// tag before, e.g. as they were created before the policy, may still be
+// updated without it.
+func validateRequiredTagsKept(subscription *api.SubscriptionDocument, oldTags map[string]string, cluster *api.OpenShiftCluster) error {
+	had, _ := subscription.TagPolicy.Match(oldTags)
+	_, missing := subscription.TagPolicy.Match(cluster.Tags)
+
+	for _, name := range missing {
+		for k := range had {
+			if strings.EqualFold(k, name) {
+				return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "tags", "The tag '%s' is required by the tag policy of the subscription.", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cancelDeferredAdminUpdate cancels an admin update which the backend has
+// deferred to the cluster's maintenance window and not yet started, so that
+// it does not block the customer's operations until the window opens.  An
+// admin may request the update again, with OverrideMaintenanceWindow if it is
+// urgent.
+func cancelDeferredAdminUpdate(log *logrus.Entry, doc *api.OpenShiftClusterDocument) {
+	if doc.OpenShiftCluster.Properties.ProvisioningState != api.ProvisioningStateAdminUpdating ||
+		doc.OpenShiftCluster.Properties.MaintenanceDeferredUntil == nil ||
+		doc.LeaseOwner != "" {
+		return
+	}
+
+	log.Printf("cancelling admin update deferred until %s", doc.OpenShiftCluster.Properties.MaintenanceDeferredUntil.UTC().Format(time.RFC3339))
+
+	doc.OpenShiftCluster.Properties.ProvisioningState = doc.OpenShiftCluster.Properties.LastProvisioningState
+	doc.OpenShiftCluster.Properties.LastProvisioningState = ""
+	doc.OpenShiftCluster.Properties.MaintenanceTask = ""
+	doc.OpenShiftCluster.Properties.MaintenanceTaskParameters = nil
+	doc.OpenShiftCluster.Properties.OverrideMaintenanceWindow = false
+	doc.OpenShiftCluster.Properties.MaintenanceDeferredUntil = nil
+	doc.OpenShiftCluster.Properties.LastAdminUpdateError = "admin update deferred to the maintenance window was cancelled by a subsequent operation"
+	doc.LeaseExpires = 0
+}
+
 // setUpdateProvisioningState Sets either the admin update or update provisioning state
 func setUpdateProvisioningState(doc *api.OpenShiftClusterDocument, apiVersion string) {
 	switch apiVersion {
@@ -727,7 +727,7 @@ func TestPutOrPatchOpenShiftClusterAdminAPI(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -841,6 +841,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			name: "create a new cluster",
 			request: func(oc *v20200430.OpenShiftCluster) {
 				oc.Properties.ClusterProfile.Version = defaultVersion
+				oc.Properties.ClusterProfile.Domain = "example"
 			},
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
@@ -864,8 +865,9 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 					},
 				})
 				c.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
-					Key:    strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
-					Bucket: 1,
+					Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					ClusterDomainKey: "example.aro.example",
+					Bucket:           1,
 					OpenShiftCluster: &api.OpenShiftCluster{
 						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
 						Name: "resourceName",
@@ -877,6 +879,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 							CreatedAt:           mockCurrentTime,
 							CreatedBy:           version.GitCommit,
 							ClusterProfile: api.ClusterProfile{
+								Domain:               "example",
 								Version:              defaultVersion,
 								FipsValidatedModules: api.FipsValidatedModulesDisabled,
 							},
@@ -910,6 +913,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				Properties: v20200430.OpenShiftClusterProperties{
 					ProvisioningState: v20200430.ProvisioningStateCreating,
 					ClusterProfile: v20200430.ClusterProfile{
+						Domain:  "example",
 						Version: defaultVersion,
 					},
 				},
@@ -937,6 +941,56 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			wantStatusCode:      http.StatusBadRequest,
 			wantError:           "400: InvalidParameter: : The provided VM SKU something is not supported.",
 		},
+		{
+			name: "create a new cluster missing a required tag",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Version = defaultVersion
+				oc.Tags = map[string]string{"costcenter": "1234"}
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+					TagPolicy: &api.TagPolicy{
+						RequiredTags: []string{"CostCenter", "owner"},
+					},
+				})
+			},
+			changeFeed:     defaultVersionChangeFeed,
+			wantEnriched:   []string{},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: tags: The tag 'owner' is required by the tag policy of the subscription.",
+		},
+		{
+			name: "create a new cluster with an empty required tag",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Version = defaultVersion
+				oc.Tags = map[string]string{"owner": ""}
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+					TagPolicy: &api.TagPolicy{
+						RequiredTags: []string{"owner"},
+					},
+				})
+			},
+			changeFeed:     defaultVersionChangeFeed,
+			wantEnriched:   []string{},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidParameter: tags: The tag 'owner' is required by the tag policy of the subscription.",
+		},
 		{
 			name: "create a new cluster quota fails",
 			request: func(oc *v20200430.OpenShiftCluster) {
@@ -1197,6 +1251,57 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update a cluster removing a required tag",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "changed"
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+					TagPolicy: &api.TagPolicy{
+						RequiredTags: []string{"owner"},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Tags: map[string]string{"Owner": "team"},
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								PullSecret:           `{"will":"be-kept"}`,
+								FipsValidatedModules: api.FipsValidatedModulesDisabled,
+							},
+							ServicePrincipalProfile: api.ServicePrincipalProfile{
+								ClientSecret: "will-be-kept",
+							},
+							NetworkProfile: api.NetworkProfile{
+								SoftwareDefinedNetwork: api.SoftwareDefinedNetworkOpenShiftSDN,
+								OutboundType:           api.OutboundTypeLoadbalancer,
+								PreconfiguredNSG:       api.PreconfiguredNSGDisabled,
+							},
+							MasterProfile: api.MasterProfile{
+								EncryptionAtHost: api.EncryptionAtHostDisabled,
+							},
+							OperatorFlags: api.OperatorFlags{},
+						},
+					},
+				})
+			},
+			wantSystemDataEnriched: true,
+			wantStatusCode:         http.StatusBadRequest,
+			wantError:              "400: InvalidParameter: tags: The tag 'owner' is required by the tag policy of the subscription.",
+		},
 		{
 			name: "update a cluster from failed during update",
 			request: func(oc *v20200430.OpenShiftCluster) {
@@ -1679,6 +1784,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			request: func(oc *v20200430.OpenShiftCluster) {
 				oc.Properties.ServicePrincipalProfile.ClientID = mockSubID
 				oc.Properties.ClusterProfile.ResourceGroupID = fmt.Sprintf("/subscriptions/%s/resourcegroups/aro-vjb21wca", mockSubID)
+				oc.Properties.ClusterProfile.Domain = "example"
 			},
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
@@ -1726,6 +1832,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			name: "creating cluster failing when provided client ID is not unique",
 			request: func(oc *v20200430.OpenShiftCluster) {
 				oc.Properties.ServicePrincipalProfile.ClientID = mockSubID
+				oc.Properties.ClusterProfile.Domain = "example"
 			},
 			fixture: func(f *testdatabase.Fixture) {
 				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
@@ -1768,6 +1875,44 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 			wantStatusCode:         http.StatusBadRequest,
 			wantError:              fmt.Sprintf("400: DuplicateClientID: : The provided client ID '%s' is already in use by a cluster.", mockSubID),
 		},
+		{
+			name: "creating cluster failing when provided domain overlaps another cluster's domain",
+			request: func(oc *v20200430.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "Cluster.Example.com"
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "otherResourceName")),
+					ClusterDomainKey: "example.com",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "otherResourceName"),
+						Name: "otherResourceName",
+						Type: "Microsoft.RedHatOpenShift/openShiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								Domain:               "example.com",
+								Version:              defaultVersion,
+								FipsValidatedModules: api.FipsValidatedModulesDisabled,
+							},
+						},
+					},
+				})
+			},
+			changeFeed:             defaultVersionChangeFeed,
+			wantSystemDataEnriched: true,
+			wantStatusCode:         http.StatusBadRequest,
+			wantError:              "400: DuplicateDomain: properties.clusterProfile.domain: The provided domain 'Cluster.Example.com' overlaps with the domain 'example.com' of another cluster in the subscription.",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ti := newTestInfra(t).
@@ -1794,7 +1939,7 @@ func TestPutOrPatchOpenShiftCluster(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -2122,7 +2267,7 @@ func TestPutOrPatchOpenShiftClusterValidated(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, ti.openShiftVersionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
 			if err != nil {
 				t.Fatal(err)
 			}
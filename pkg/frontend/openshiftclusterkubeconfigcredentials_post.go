@@ -6,11 +6,14 @@ package frontend
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
@@ -30,6 +33,18 @@ func (f *frontend) postOpenShiftClusterKubeConfigCredentials(w http.ResponseWrit
 		return
 	}
 
+	format := api.AdminCredentialsFormatKubeconfig
+	if s := r.URL.Query().Get("format"); s != "" {
+		switch {
+		case strings.EqualFold(s, string(api.AdminCredentialsFormatKubeconfig)):
+		case strings.EqualFold(s, string(api.AdminCredentialsFormatStructured)) && f.apis[apiVersion].OpenShiftClusterAdminCredentialsConverter != nil:
+			format = api.AdminCredentialsFormatStructured
+		default:
+			api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "format", "The provided format '%s' is invalid.", s)
+			return
+		}
+	}
+
 	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
 	if len(body) > 0 && !json.Valid(body) {
 		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized.")
@@ -38,12 +53,12 @@ func (f *frontend) postOpenShiftClusterKubeConfigCredentials(w http.ResponseWrit
 
 	r.URL.Path = filepath.Dir(r.URL.Path)
 
-	b, err := f._postOpenShiftClusterKubeConfigCredentials(ctx, r, f.apis[apiVersion].OpenShiftClusterAdminKubeconfigConverter)
+	b, err := f._postOpenShiftClusterKubeConfigCredentials(ctx, r, format, f.apis[apiVersion].OpenShiftClusterAdminKubeconfigConverter, f.apis[apiVersion].OpenShiftClusterAdminCredentialsConverter)
 
 	reply(log, w, nil, b, err)
 }
 
-func (f *frontend) _postOpenShiftClusterKubeConfigCredentials(ctx context.Context, r *http.Request, converter api.OpenShiftClusterAdminKubeconfigConverter) ([]byte, error) {
+func (f *frontend) _postOpenShiftClusterKubeConfigCredentials(ctx context.Context, r *http.Request, format api.AdminCredentialsFormat, converter api.OpenShiftClusterAdminKubeconfigConverter, credentialsConverter api.OpenShiftClusterAdminCredentialsConverter) ([]byte, error) {
 	resourceType := chi.URLParam(r, "resourceType")
 	resourceName := chi.URLParam(r, "resourceName")
 	resourceGroupName := chi.URLParam(r, "resourceGroupName")
@@ -68,8 +83,48 @@ func (f *frontend) _postOpenShiftClusterKubeConfigCredentials(ctx context.Contex
 		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "", "Request is not allowed in provisioningState '%s'.", doc.OpenShiftCluster.Properties.ProvisioningState)
 	}
 
+	if format == api.AdminCredentialsFormatStructured {
+		creds, err := adminCredentialsFromKubeconfig(doc.OpenShiftCluster.Properties.UserAdminKubeconfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(credentialsConverter.ToExternal(creds), "", "    ")
+	}
+
 	doc.OpenShiftCluster.Properties.ClusterProfile.PullSecret = ""
 	doc.OpenShiftCluster.Properties.ServicePrincipalProfile.ClientSecret = ""
 
 	return json.MarshalIndent(converter.ToExternal(doc.OpenShiftCluster), "", "    ")
 }
+
+// adminCredentialsFromKubeconfig derives the structured admin credentials from
+// the user admin kubeconfig, so that every format returns the same credentials
+func adminCredentialsFromKubeconfig(kubeconfig api.SecureBytes) (*api.OpenShiftClusterAdminCredentials, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kubectx := config.Contexts[config.CurrentContext]
+	if kubectx == nil {
+		return nil, fmt.Errorf("kubeconfig context %q not found", config.CurrentContext)
+	}
+
+	cluster := config.Clusters[kubectx.Cluster]
+	if cluster == nil {
+		return nil, fmt.Errorf("kubeconfig cluster %q not found", kubectx.Cluster)
+	}
+
+	authInfo := config.AuthInfos[kubectx.AuthInfo]
+	if authInfo == nil {
+		return nil, fmt.Errorf("kubeconfig user %q not found", kubectx.AuthInfo)
+	}
+
+	return &api.OpenShiftClusterAdminCredentials{
+		Kubeconfig:        kubeconfig,
+		APIServerURL:      cluster.Server,
+		ClientCertificate: authInfo.ClientCertificateData,
+		ClientKey:         authInfo.ClientKeyData,
+	}, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/api/v20210901preview"
+	"github.com/Azure/ARO-RP/pkg/api/v20230701preview"
 	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
 	"github.com/Azure/ARO-RP/pkg/metrics/noop"
 	testdatabase "github.com/Azure/ARO-RP/test/database"
@@ -22,6 +23,7 @@ func TestPostOpenShiftClusterKubeConfigCredentials(t *testing.T) {
 
 	apis := map[string]*api.Version{
 		"2021-09-01-preview": api.APIs["2021-09-01-preview"],
+		"2023-07-01-preview": api.APIs["2023-07-01-preview"],
 		"no-credentials": {
 			OpenShiftClusterConverter:       api.APIs["2021-09-01-preview"].OpenShiftClusterConverter,
 			OpenShiftClusterStaticValidator: api.APIs["2021-09-01-preview"].OpenShiftClusterStaticValidator,
@@ -31,14 +33,34 @@ func TestPostOpenShiftClusterKubeConfigCredentials(t *testing.T) {
 	mockSubID := "00000000-0000-0000-0000-000000000000"
 	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
 
+	kubeconfig := api.SecureBytes(`apiVersion: v1
+clusters:
+- cluster:
+    server: https://api.cluster.location.aroapp.io:6443
+  name: cluster
+contexts:
+- context:
+    cluster: cluster
+    user: system:admin
+  name: system:admin
+current-context: system:admin
+kind: Config
+users:
+- name: system:admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`)
+
 	type test struct {
 		name           string
 		resourceID     string
 		apiVersion     string
+		format         string
 		fixture        func(*testdatabase.Fixture)
 		dbError        error
 		wantStatusCode int
-		wantResponse   func(*test) *v20210901preview.OpenShiftClusterAdminKubeconfig
+		wantResponse   func(*test) interface{}
 		wantError      string
 	}
 
@@ -70,12 +92,130 @@ func TestPostOpenShiftClusterKubeConfigCredentials(t *testing.T) {
 				})
 			},
 			wantStatusCode: http.StatusOK,
-			wantResponse: func(tt *test) *v20210901preview.OpenShiftClusterAdminKubeconfig {
+			wantResponse: func(tt *test) interface{} {
 				return &v20210901preview.OpenShiftClusterAdminKubeconfig{
 					Kubeconfig: []byte("{kubeconfig}"),
 				}
 			},
 		},
+		{
+			name:       "kubeconfig format",
+			resourceID: resourceID,
+			format:     "kubeconfig",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:   api.ProvisioningStateSucceeded,
+							UserAdminKubeconfig: api.SecureBytes("{kubeconfig}"),
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func(tt *test) interface{} {
+				return &v20210901preview.OpenShiftClusterAdminKubeconfig{
+					Kubeconfig: []byte("{kubeconfig}"),
+				}
+			},
+		},
+		{
+			name:       "structured format",
+			resourceID: resourceID,
+			apiVersion: "2023-07-01-preview",
+			format:     "Structured",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:   api.ProvisioningStateSucceeded,
+							UserAdminKubeconfig: kubeconfig,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: func(tt *test) interface{} {
+				return &v20230701preview.OpenShiftClusterAdminKubeconfig{
+					Kubeconfig:        kubeconfig,
+					APIServerURL:      "https://api.cluster.location.aroapp.io:6443",
+					ClientCertificate: []byte("cert"),
+					ClientKey:         []byte("key"),
+				}
+			},
+		},
+		{
+			name:       "structured format, cluster exists in db in creating state",
+			resourceID: resourceID,
+			apiVersion: "2023-07-01-preview",
+			format:     "Structured",
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:   testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name: "resourceName",
+						Type: "Microsoft.RedHatOpenShift/openshiftClusters",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState:   api.ProvisioningStateCreating,
+							UserAdminKubeconfig: kubeconfig,
+						},
+					},
+				})
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: api.SubscriptionStateRegistered,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: RequestNotAllowed: : Request is not allowed in provisioningState 'Creating'.`,
+		},
+		{
+			name:           "structured format is not supported in the API version",
+			resourceID:     resourceID,
+			format:         "Structured",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: format: The provided format 'Structured' is invalid.`,
+		},
+		{
+			name:           "invalid format",
+			resourceID:     resourceID,
+			apiVersion:     "2023-07-01-preview",
+			format:         "yaml",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidParameter: format: The provided format 'yaml' is invalid.`,
+		},
 		{
 			name:           "credentials request is not allowed in the API version",
 			resourceID:     resourceID,
@@ -241,7 +381,7 @@ func TestPostOpenShiftClusterKubeConfigCredentials(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -253,9 +393,12 @@ func TestPostOpenShiftClusterKubeConfigCredentials(t *testing.T) {
 				reqAPIVersion = tt.apiVersion
 			}
 
-			resp, b, err := ti.request(http.MethodPost,
-				fmt.Sprintf("https://server%s/listAdminCredentials?api-version=%s", tt.resourceID, reqAPIVersion),
-				nil, nil)
+			url := fmt.Sprintf("https://server%s/listAdminCredentials?api-version=%s", tt.resourceID, reqAPIVersion)
+			if tt.format != "" {
+				url += "&format=" + tt.format
+			}
+
+			resp, b, err := ti.request(http.MethodPost, url, nil, nil)
 			if err != nil {
 				t.Error(err)
 			}
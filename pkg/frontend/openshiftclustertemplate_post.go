@@ -0,0 +1,85 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+)
+
+// /subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/exporttemplate?api-version={api-version}
+func (f *frontend) postOpenShiftClusterExportTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	resourceType := chi.URLParam(r, "resourceType")
+	resourceProviderNamespace := chi.URLParam(r, "resourceProviderNamespace")
+
+	apiVersion := r.URL.Query().Get(api.APIVersionKey)
+	if f.apis[apiVersion].OpenShiftClusterTemplateConverter == nil {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidResourceType, "", "The resource type '%s' could not be found in the namespace '%s' for api version '%s'.", resourceType, resourceProviderNamespace, apiVersion)
+		return
+	}
+
+	body := r.Context().Value(middleware.ContextKeyBody).([]byte)
+	if len(body) > 0 && !json.Valid(body) {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized.")
+		return
+	}
+
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postOpenShiftClusterExportTemplate(ctx, r, f.apis[apiVersion].OpenShiftClusterTemplateConverter)
+
+	reply(log, w, nil, b, err)
+}
+
+func (f *frontend) _postOpenShiftClusterExportTemplate(ctx context.Context, r *http.Request, converter api.OpenShiftClusterTemplateConverter) ([]byte, error) {
+	resType, resName, resGroupName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName"), chi.URLParam(r, "resourceGroupName")
+
+	_, err := f.validateSubscriptionState(ctx, r.URL.Path, api.SubscriptionStateRegistered)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := f.dbOpenShiftClusters.Get(ctx, r.URL.Path)
+	switch {
+	case cosmosdb.IsErrorStatusCode(err, http.StatusNotFound):
+		return nil, api.NewCloudError(http.StatusNotFound, api.CloudErrorCodeResourceNotFound, "", "The Resource '%s/%s' under resource group '%s' was not found.", resType, resName, resGroupName)
+	case err != nil:
+		return nil, err
+	}
+
+	return json.MarshalIndent(converter.ToExternal(doc.OpenShiftCluster), "", "    ")
+}
+
+// prefillFromTemplate sets the properties of the external cluster ext which
+// are set by the template of the request body, if any.  Errors unmarshalling
+// the body are left to the caller, which unmarshals the body over ext next.
+func prefillFromTemplate(converter api.OpenShiftClusterTemplateConverter, ext interface{}, body []byte) error {
+	if converter == nil {
+		return nil
+	}
+
+	var request struct {
+		Properties struct {
+			Template json.RawMessage `json:"template,omitempty"`
+		} `json:"properties,omitempty"`
+	}
+
+	err := json.Unmarshal(body, &request)
+	if err != nil || len(request.Properties.Template) == 0 || string(request.Properties.Template) == "null" {
+		return nil
+	}
+
+	return converter.Prefill(ext, request.Properties.Template)
+}
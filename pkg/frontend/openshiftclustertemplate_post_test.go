@@ -0,0 +1,352 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/v20230701preview"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPostOpenShiftClusterExportTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	apis := map[string]*api.Version{
+		"2023-07-01-preview": api.APIs["2023-07-01-preview"],
+		"no-template": {
+			OpenShiftClusterConverter:       api.APIs["2023-07-01-preview"].OpenShiftClusterConverter,
+			OpenShiftClusterStaticValidator: api.APIs["2023-07-01-preview"].OpenShiftClusterStaticValidator,
+		},
+	}
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	resourceID := fmt.Sprintf("/subscriptions/%s/resourcegroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/resourceName", mockSubID)
+
+	subscriptionFixture := func(f *testdatabase.Fixture) {
+		f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+			ID: mockSubID,
+			Subscription: &api.Subscription{
+				State: api.SubscriptionStateRegistered,
+				Properties: &api.SubscriptionProperties{
+					TenantID: "11111111-1111-1111-1111-111111111111",
+				},
+			},
+		})
+	}
+
+	for _, tt := range []struct {
+		name           string
+		apiVersion     string
+		fixture        func(*testdatabase.Fixture)
+		dbError        error
+		wantStatusCode int
+		wantResponse   *v20230701preview.OpenShiftClusterTemplate
+		wantError      string
+	}{
+		{
+			name: "cluster exists in db",
+			fixture: func(f *testdatabase.Fixture) {
+				subscriptionFixture(f)
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key: strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID:       testdatabase.GetResourcePath(mockSubID, "resourceName"),
+						Name:     "resourceName",
+						Type:     "Microsoft.RedHatOpenShift/openshiftClusters",
+						Location: "eastus",
+						Properties: api.OpenShiftClusterProperties{
+							ProvisioningState: api.ProvisioningStateSucceeded,
+							ClusterProfile: api.ClusterProfile{
+								PullSecret:           "{}",
+								Domain:               "cluster.example.com",
+								Version:              "4.12.25",
+								ResourceGroupID:      fmt.Sprintf("/subscriptions/%s/resourcegroups/clusterResourceGroup", mockSubID),
+								FipsValidatedModules: api.FipsValidatedModulesEnabled,
+							},
+							ConsoleProfile: api.ConsoleProfile{
+								URL: "https://console-openshift-console.apps.cluster.example.com/",
+							},
+							ServicePrincipalProfile: api.ServicePrincipalProfile{
+								ClientID:     "clientId",
+								ClientSecret: "clientSecret",
+							},
+							NetworkProfile: api.NetworkProfile{
+								PodCIDR:      "10.128.0.0/14",
+								ServiceCIDR:  "172.30.0.0/16",
+								OutboundType: api.OutboundTypeLoadbalancer,
+								LoadBalancerProfile: &api.LoadBalancerProfile{
+									ManagedOutboundIPs: &api.ManagedOutboundIPs{
+										Count: 2,
+									},
+									EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+										{ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/clusterResourceGroup/providers/Microsoft.Network/publicIPAddresses/ip"},
+									},
+								},
+							},
+							MasterProfile: api.MasterProfile{
+								VMSize:              api.VMSizeStandardD8sV3,
+								SubnetID:            fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master", mockSubID),
+								EncryptionAtHost:    api.EncryptionAtHostEnabled,
+								DiskEncryptionSetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Compute/diskEncryptionSets/des", mockSubID),
+							},
+							WorkerProfiles: []api.WorkerProfile{
+								{
+									Name:       "worker",
+									VMSize:     api.VMSizeStandardD4sV3,
+									DiskSizeGB: 128,
+									SubnetID:   fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker", mockSubID),
+									Count:      3,
+									ProximityPlacementGroupProfile: &api.ProximityPlacementGroupProfile{
+										ID: fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Compute/proximityPlacementGroups/ppg", mockSubID),
+									},
+								},
+							},
+							WorkerProfilesStatus: []api.WorkerProfile{
+								{
+									Name:       "resourcename-abcde-worker-eastus1",
+									VMSize:     api.VMSizeStandardD4sV3,
+									DiskSizeGB: 128,
+									SubnetID:   fmt.Sprintf("/subscriptions/%s/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker", mockSubID),
+									Count:      1,
+								},
+							},
+							APIServerProfile: api.APIServerProfile{
+								Visibility: api.VisibilityPrivate,
+								URL:        "https://api.cluster.example.com:6443/",
+								IP:         "10.0.0.4",
+							},
+							IngressProfiles: []api.IngressProfile{
+								{
+									Name:       "default",
+									Visibility: api.VisibilityPublic,
+									IP:         "1.2.3.4",
+								},
+							},
+							UpgradeProfile: &api.UpgradeProfile{
+								LastNodeName:   "resourcename-abcde-worker-eastus1-xyz12",
+								MaxUnavailable: "2",
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterTemplate{
+				Properties: v20230701preview.OpenShiftClusterProperties{
+					ClusterProfile: v20230701preview.ClusterProfile{
+						Version:              "4.12.25",
+						FipsValidatedModules: v20230701preview.FipsValidatedModulesEnabled,
+					},
+					NetworkProfile: v20230701preview.NetworkProfile{
+						PodCIDR:      "10.128.0.0/14",
+						ServiceCIDR:  "172.30.0.0/16",
+						OutboundType: v20230701preview.OutboundTypeLoadbalancer,
+						LoadBalancerProfile: &v20230701preview.LoadBalancerProfile{
+							ManagedOutboundIPs: &v20230701preview.ManagedOutboundIPs{
+								Count: 2,
+							},
+						},
+					},
+					MasterProfile: v20230701preview.MasterProfile{
+						VMSize:           v20230701preview.VMSize(api.VMSizeStandardD8sV3),
+						EncryptionAtHost: v20230701preview.EncryptionAtHostEnabled,
+					},
+					WorkerProfiles: []v20230701preview.WorkerProfile{
+						{
+							Name:                           "worker",
+							VMSize:                         v20230701preview.VMSize(api.VMSizeStandardD4sV3),
+							DiskSizeGB:                     128,
+							Count:                          3,
+							ProximityPlacementGroupProfile: &v20230701preview.ProximityPlacementGroupProfile{},
+						},
+					},
+					APIServerProfile: v20230701preview.APIServerProfile{
+						Visibility: v20230701preview.VisibilityPrivate,
+					},
+					IngressProfiles: []v20230701preview.IngressProfile{
+						{
+							Name:       "default",
+							Visibility: v20230701preview.VisibilityPublic,
+						},
+					},
+					UpgradeProfile: &v20230701preview.UpgradeProfile{
+						MaxUnavailable: "2",
+					},
+				},
+			},
+		},
+		{
+			name:           "template export is not allowed in the API version",
+			apiVersion:     "no-template",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      `400: InvalidResourceType: : The resource type 'openshiftclusters' could not be found in the namespace 'microsoft.redhatopenshift' for api version 'no-template'.`,
+		},
+		{
+			name:           "cluster not found in db",
+			fixture:        subscriptionFixture,
+			wantStatusCode: http.StatusNotFound,
+			wantError:      `404: ResourceNotFound: : The Resource 'openshiftclusters/resourcename' under resource group 'resourcegroup' was not found.`,
+		},
+		{
+			name:           "internal error",
+			dbError:        &cosmosdb.Error{Code: "500", Message: "oh no!"},
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      `500: InternalServerError: : Internal server error.`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithOpenShiftClusters().WithSubscriptions()
+			defer ti.done()
+
+			if tt.dbError != nil {
+				ti.subscriptionsClient.SetError(tt.dbError)
+				ti.openShiftClustersClient.SetError(tt.dbError)
+			}
+
+			err := ti.buildFixtures(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go f.Run(ctx, nil, nil)
+
+			reqAPIVersion := "2023-07-01-preview"
+			if tt.apiVersion != "" {
+				reqAPIVersion = tt.apiVersion
+			}
+
+			resp, b, err := ti.request(http.MethodPost,
+				fmt.Sprintf("https://server%s/exporttemplate?api-version=%s", resourceID, reqAPIVersion),
+				nil, nil)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var wantResponse interface{}
+			if tt.wantResponse != nil {
+				wantResponse = tt.wantResponse
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPrefillFromTemplate(t *testing.T) {
+	converter := api.APIs["2023-07-01-preview"].OpenShiftClusterTemplateConverter
+
+	for _, tt := range []struct {
+		name        string
+		noConverter bool
+		body        string
+		want        func(*v20230701preview.OpenShiftCluster)
+		wantErr     string
+	}{
+		{
+			name: "no template",
+			body: `{"properties": {"masterProfile": {"vmSize": "Standard_D8s_v3"}}}`,
+		},
+		{
+			name: "null template",
+			body: `{"properties": {"template": null}}`,
+		},
+		{
+			name: "template",
+			body: `{"properties": {"template": {"properties": {"clusterProfile": {"version": "4.12.25"}, "masterProfile": {"vmSize": "Standard_D16s_v3", "encryptionAtHost": "Enabled"}, "workerProfiles": [{"name": "worker", "vmSize": "Standard_D4s_v3", "count": 3}]}}}}`,
+			want: func(oc *v20230701preview.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Version = "4.12.25"
+				oc.Properties.MasterProfile = v20230701preview.MasterProfile{
+					VMSize:           "Standard_D16s_v3",
+					EncryptionAtHost: v20230701preview.EncryptionAtHostEnabled,
+				}
+				oc.Properties.WorkerProfiles = []v20230701preview.WorkerProfile{
+					{Name: "worker", VMSize: "Standard_D4s_v3", Count: 3},
+				}
+			},
+		},
+		{
+			name:        "API version without templates",
+			noConverter: true,
+			body:        `{"properties": {"template": {"properties": {"clusterProfile": {"version": "4.12.25"}}}}}`,
+		},
+		{
+			name:    "template with an unknown property",
+			body:    `{"properties": {"template": {"properties": {"masterProfile": {"vmSize": "Standard_D16s_v3", "vmSku": "Standard_D16s_v3"}}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it does not match the schema of api version '2023-07-01-preview'.",
+		},
+		{
+			name:    "template with a wrongly typed property",
+			body:    `{"properties": {"template": {"properties": {"workerProfiles": {"name": "worker"}}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it does not match the schema of api version '2023-07-01-preview'.",
+		},
+		{
+			name:    "template with a secret",
+			body:    `{"properties": {"template": {"properties": {"clusterProfile": {"pullSecret": "{}"}}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it sets secrets, resource identifiers or read-only properties. Set them in the request instead.",
+		},
+		{
+			name:    "template with a resource identifier",
+			body:    `{"properties": {"template": {"properties": {"workerProfiles": [{"name": "worker", "subnetId": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker"}]}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it sets secrets, resource identifiers or read-only properties. Set them in the request instead.",
+		},
+		{
+			name:    "template with a proximity placement group",
+			body:    `{"properties": {"template": {"properties": {"workerProfiles": [{"name": "worker", "proximityPlacementGroupProfile": {"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet/providers/Microsoft.Compute/proximityPlacementGroups/ppg"}}]}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it sets secrets, resource identifiers or read-only properties. Set them in the request instead.",
+		},
+		{
+			name:    "nested template",
+			body:    `{"properties": {"template": {"properties": {"template": {}}}}}`,
+			wantErr: "400: InvalidParameter: properties.template: The provided template is invalid: it sets secrets, resource identifiers or read-only properties. Set them in the request instead.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := converter
+			if tt.noConverter {
+				c = nil
+			}
+
+			oc := &v20230701preview.OpenShiftCluster{
+				Properties: v20230701preview.OpenShiftClusterProperties{
+					ProvisioningState: v20230701preview.ProvisioningStateSucceeded,
+				},
+			}
+			want := &v20230701preview.OpenShiftCluster{
+				Properties: v20230701preview.OpenShiftClusterProperties{
+					ProvisioningState: v20230701preview.ProvisioningStateSucceeded,
+				},
+			}
+			if tt.want != nil {
+				tt.want(want)
+			}
+
+			err := prefillFromTemplate(c, oc, []byte(tt.body))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && !reflect.DeepEqual(oc, want) {
+				t.Error(deep.Equal(oc, want))
+			}
+		})
+	}
+}
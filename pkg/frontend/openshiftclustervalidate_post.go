@@ -0,0 +1,191 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	"github.com/Azure/ARO-RP/pkg/env"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+)
+
+// /subscriptions/{subscriptionId}/resourcegroups/{resourceGroupName}/providers/{resourceProviderNamespace}/{resourceType}/{resourceName}/validate?api-version={api-version}
+func (f *frontend) postOpenShiftClusterValidate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	resourceType := chi.URLParam(r, "resourceType")
+	resourceProviderNamespace := chi.URLParam(r, "resourceProviderNamespace")
+
+	apiVersion := r.URL.Query().Get(api.APIVersionKey)
+	if f.apis[apiVersion].OpenShiftClusterValidationFindingsConverter == nil {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidResourceType, "", "The resource type '%s' could not be found in the namespace '%s' for api version '%s'.", resourceType, resourceProviderNamespace, apiVersion)
+		return
+	}
+
+	r.URL.Path = filepath.Dir(r.URL.Path)
+
+	b, err := f._postOpenShiftClusterValidate(ctx, log, r, f.apis[apiVersion])
+
+	reply(log, w, nil, b, err)
+}
+
+// _postOpenShiftClusterValidate validates a proposed cluster without creating
+// anything.  It runs the validations run when the cluster is created, static
+// and dynamic, and returns all their failures as findings.  Resource provider
+// registration isn't checked, as it would register the missing providers.
+func (f *frontend) _postOpenShiftClusterValidate(ctx context.Context, log *logrus.Entry, r *http.Request, apis *api.Version) ([]byte, error) {
+	body := ctx.Value(middleware.ContextKeyBody).([]byte)
+	resType, resName := chi.URLParam(r, "resourceType"), chi.URLParam(r, "resourceName")
+	path := r.URL.Path
+
+	subscription, err := f.validateSubscriptionState(ctx, path, api.SubscriptionStateRegistered)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := apis.OpenShiftClusterConverter
+	staticValidator := apis.OpenShiftClusterStaticValidator
+	findingsConverter := apis.OpenShiftClusterValidationFindingsConverter
+
+	doc := &api.OpenShiftClusterDocument{
+		Key: strings.ToLower(path),
+		OpenShiftCluster: &api.OpenShiftCluster{
+			ID:   path,
+			Name: resName,
+			Type: resType,
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: api.ProvisioningStateSucceeded,
+			},
+		},
+	}
+
+	if !f.env.IsLocalDevelopmentMode() /* not local dev or CI */ {
+		doc.OpenShiftCluster.Properties.FeatureProfile.GatewayEnabled = true
+	}
+
+	ext := converter.ToExternal(doc.OpenShiftCluster)
+	err = prefillFromTemplate(apis.OpenShiftClusterTemplateConverter, ext, body)
+	if err != nil {
+		return marshalValidationFindings(findingsConverter, []api.ValidationFinding{validationFinding(log, err)})
+	}
+
+	err = json.Unmarshal(body, &ext)
+	if err != nil {
+		return nil, api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidRequestContent, "", "The request content was invalid and could not be deserialized: %q.", err)
+	}
+
+	converter.ToInternal(ext, doc.OpenShiftCluster)
+	doc.OpenShiftCluster.ID, doc.OpenShiftCluster.Name, doc.OpenShiftCluster.Type = path, resName, resType
+
+	var findings []api.ValidationFinding
+
+	// the remaining validations rely on the cluster being well formed
+	err = staticValidator.Static(ext, nil, f.env.Location(), f.env.Domain(), f.env.FeatureIsSet(env.FeatureRequireD2sV3Workers), path)
+	if err != nil {
+		return marshalValidationFindings(findingsConverter, append(findings, validationFinding(log, err)))
+	}
+
+	add := func(err error) {
+		if err != nil {
+			findings = append(findings, validationFinding(log, err))
+		}
+	}
+
+	findings = append(findings, admissionWebhookFindings(doc.OpenShiftCluster)...)
+
+	add(f.skuValidator.ValidateVMSku(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, doc.OpenShiftCluster))
+	add(f.quotaValidator.ValidateQuota(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, doc.OpenShiftCluster))
+	add(f.validateInstallVersion(ctx, doc.OpenShiftCluster))
+
+	// an invalid domain is already reported by the static validation
+	doc.ClusterDomainKey, err = dns.ClusterDomainKey(f.env, doc.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	if err == nil {
+		add(f.validateClusterDomainOverlap(ctx, doc))
+	}
+
+	api.SetDefaults(doc)
+
+	failures, err := f.dynamicValidator.ValidateDynamic(ctx, f.env, subscription, doc.OpenShiftCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range failures {
+		add(err)
+	}
+
+	return marshalValidationFindings(findingsConverter, findings)
+}
+
+// validationFinding returns the finding for a validation failure.  Only
+// CloudErrors are definite failures: any other error means the validation
+// could not be completed, and its details aren't returned to the customer.
+func validationFinding(log *logrus.Entry, err error) api.ValidationFinding {
+	if cloudErr, ok := err.(*api.CloudError); ok && cloudErr.CloudErrorBody != nil {
+		return api.ValidationFinding{
+			Severity: api.ValidationSeverityError,
+			Code:     cloudErr.Code,
+			Target:   cloudErr.Target,
+			Message:  cloudErr.Message,
+		}
+	}
+
+	log.Warn(err)
+	return api.ValidationFinding{
+		Severity: api.ValidationSeverityWarning,
+		Code:     api.CloudErrorCodeInternalServerError,
+		Message:  "A validation could not be completed. It will be run again when the cluster is created.",
+	}
+}
+
+// admissionWebhookFindings warns of the admission webhooks which fail closed
+// on objects in the core namespaces.  They are allowed, but if the webhook
+// service is unavailable, e.g. during an upgrade, the cluster may not recover.
+func admissionWebhookFindings(oc *api.OpenShiftCluster) []api.ValidationFinding {
+	var findings []api.ValidationFinding
+
+	for i, p := range oc.Properties.AdmissionWebhookProfiles {
+		o, err := validate.DecodeAdmissionWebhookConfiguration(p.Configuration)
+		if err != nil { // already validated statically
+			continue
+		}
+
+		for _, name := range validate.AdmissionWebhooksBlockingCoreNamespaces(o) {
+			findings = append(findings, api.ValidationFinding{
+				Severity: api.ValidationSeverityWarning,
+				Code:     api.CloudErrorCodeInvalidParameter,
+				Target:   fmt.Sprintf("properties.admissionWebhookProfiles[%d].configuration", i),
+				Message:  fmt.Sprintf("The admission webhook '%s' has failurePolicy Fail and applies to the core namespaces of the cluster. If it is unavailable, the cluster may not be able to recover. Set failurePolicy to Ignore or exclude the default, kube-* and openshift-* namespaces with a namespaceSelector.", name),
+			})
+		}
+	}
+
+	return findings
+}
+
+func marshalValidationFindings(converter api.OpenShiftClusterValidationFindingsConverter, findings []api.ValidationFinding) ([]byte, error) {
+	result := &api.ValidationFindings{
+		Status:   api.ValidationStatusSucceeded,
+		Findings: findings,
+	}
+
+	for _, finding := range findings {
+		if finding.Severity == api.ValidationSeverityError {
+			result.Status = api.ValidationStatusFailed
+		}
+	}
+
+	return json.MarshalIndent(converter.ToExternal(result), "", "    ")
+}
@@ -0,0 +1,286 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	v20230701preview "github.com/Azure/ARO-RP/pkg/api/v20230701preview"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	mock_frontend "github.com/Azure/ARO-RP/pkg/util/mocks/frontend"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+type errorOpenShiftClusterValidator struct {
+	err error
+}
+
+func (v *errorOpenShiftClusterValidator) Static(interface{}, *api.OpenShiftCluster, string, string, bool, string) error {
+	return v.err
+}
+
+func TestPostOpenShiftClusterValidate(t *testing.T) {
+	ctx := context.Background()
+
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	defaultVersion := version.DefaultInstallStream.Version.String()
+
+	type test struct {
+		name           string
+		request        func(*v20230701preview.OpenShiftCluster)
+		state          api.SubscriptionState
+		staticErr      error
+		skuErr         error
+		quotaErr       error
+		dynamicErrs    []error
+		dynamicErr     error
+		fixture        func(*testdatabase.Fixture)
+		wantStatusCode int
+		apiVersion     string
+		wantResponse   *v20230701preview.OpenShiftClusterValidationFindings
+		wantError      string
+	}
+
+	for _, tt := range []*test{
+		{
+			name:           "valid",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterValidationFindings{
+				Status:   v20230701preview.ValidationStatusSucceeded,
+				Findings: []v20230701preview.ValidationFinding{},
+			},
+		},
+		{
+			name:           "invalid cluster stops validation",
+			staticErr:      api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.clusterProfile.domain", "The provided domain '-' is invalid."),
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterValidationFindings{
+				Status: v20230701preview.ValidationStatusFailed,
+				Findings: []v20230701preview.ValidationFinding{
+					{
+						Severity: v20230701preview.ValidationSeverityError,
+						Code:     api.CloudErrorCodeInvalidParameter,
+						Target:   "properties.clusterProfile.domain",
+						Message:  "The provided domain '-' is invalid.",
+					},
+				},
+			},
+		},
+		{
+			name: "all findings are returned",
+			request: func(oc *v20230701preview.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Version = "4.1.1"
+			},
+			quotaErr: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeQuotaExceeded, "", "Resource quota of cores exceeded. Maximum allowed: 0, Current in use: 0, Additional requested: 36."),
+			dynamicErrs: []error{
+				api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidLinkedVNet, "properties.masterProfile.subnetId", "The provided subnet is invalid."),
+				errors.New("connection reset by peer"),
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterValidationFindings{
+				Status: v20230701preview.ValidationStatusFailed,
+				Findings: []v20230701preview.ValidationFinding{
+					{
+						Severity: v20230701preview.ValidationSeverityError,
+						Code:     api.CloudErrorCodeQuotaExceeded,
+						Message:  "Resource quota of cores exceeded. Maximum allowed: 0, Current in use: 0, Additional requested: 36.",
+					},
+					{
+						Severity: v20230701preview.ValidationSeverityError,
+						Code:     api.CloudErrorCodeInvalidParameter,
+						Target:   "properties.clusterProfile.version",
+						Message:  "The requested OpenShift version '4.1.1' is invalid.",
+					},
+					{
+						Severity: v20230701preview.ValidationSeverityError,
+						Code:     api.CloudErrorCodeInvalidLinkedVNet,
+						Target:   "properties.masterProfile.subnetId",
+						Message:  "The provided subnet is invalid.",
+					},
+					{
+						Severity: v20230701preview.ValidationSeverityWarning,
+						Code:     api.CloudErrorCodeInternalServerError,
+						Message:  "A validation could not be completed. It will be run again when the cluster is created.",
+					},
+				},
+			},
+		},
+		{
+			name: "domain overlapping another cluster",
+			request: func(oc *v20230701preview.OpenShiftCluster) {
+				oc.Properties.ClusterProfile.Domain = "cluster.example.com"
+			},
+			fixture: func(f *testdatabase.Fixture) {
+				f.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+					Key:              "/subscriptions/" + mockSubID + "/resourcegroups/resourcegroup/providers/microsoft.redhatopenshift/openshiftclusters/other",
+					ClusterDomainKey: "example.com",
+					OpenShiftCluster: &api.OpenShiftCluster{
+						ID: testdatabase.GetResourcePath(mockSubID, "other"),
+						Properties: api.OpenShiftClusterProperties{
+							ClusterProfile: api.ClusterProfile{
+								Domain: "example.com",
+							},
+						},
+					},
+				})
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterValidationFindings{
+				Status: v20230701preview.ValidationStatusFailed,
+				Findings: []v20230701preview.ValidationFinding{
+					{
+						Severity: v20230701preview.ValidationSeverityError,
+						Code:     api.CloudErrorCodeDuplicateDomain,
+						Target:   "properties.clusterProfile.domain",
+						Message:  "The provided domain 'cluster.example.com' overlaps with the domain 'example.com' of another cluster in the subscription.",
+					},
+				},
+			},
+		},
+		{
+			name: "admission webhook failing closed on the core namespaces",
+			request: func(oc *v20230701preview.OpenShiftCluster) {
+				oc.Properties.AdmissionWebhookProfiles = []v20230701preview.AdmissionWebhookProfile{
+					{
+						Configuration: `{"apiVersion": "admissionregistration.k8s.io/v1", "kind": "ValidatingWebhookConfiguration", "metadata": {"name": "policy"}, "webhooks": [{"name": "policy.example.com", "clientConfig": {"url": "https://policy.example.com/validate"}, "rules": [{"operations": ["CREATE"], "apiGroups": [""], "apiVersions": ["v1"], "resources": ["pods"]}], "sideEffects": "None", "admissionReviewVersions": ["v1"]}]}`,
+					},
+				}
+			},
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftClusterValidationFindings{
+				Status: v20230701preview.ValidationStatusSucceeded,
+				Findings: []v20230701preview.ValidationFinding{
+					{
+						Severity: v20230701preview.ValidationSeverityWarning,
+						Code:     api.CloudErrorCodeInvalidParameter,
+						Target:   "properties.admissionWebhookProfiles[0].configuration",
+						Message:  "The admission webhook 'policy.example.com' has failurePolicy Fail and applies to the core namespaces of the cluster. If it is unavailable, the cluster may not be able to recover. Set failurePolicy to Ignore or exclude the default, kube-* and openshift-* namespaces with a namespaceSelector.",
+					},
+				},
+			},
+		},
+		{
+			name:           "dynamic validation could not be run",
+			dynamicErr:     errors.New("random error"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantError:      "500: InternalServerError: : Internal server error.",
+		},
+		{
+			name:           "subscription not registered",
+			state:          api.SubscriptionStateWarned,
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidSubscriptionState: : Request is not allowed in subscription in state 'Warned'.",
+		},
+		{
+			name:           "api version without the validate action",
+			apiVersion:     "2020-04-30",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidResourceType: : The resource type 'openshiftclusters' could not be found in the namespace 'microsoft.redhatopenshift' for api version '2020-04-30'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).
+				WithOpenShiftClusters().
+				WithSubscriptions()
+			defer ti.done()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			if tt.apiVersion == "" {
+				tt.apiVersion = "2023-07-01-preview"
+			}
+
+			if tt.state == "" {
+				tt.state = api.SubscriptionStateRegistered
+			}
+
+			err := ti.buildFixtures(func(f *testdatabase.Fixture) {
+				f.AddSubscriptionDocuments(&api.SubscriptionDocument{
+					ID: mockSubID,
+					Subscription: &api.Subscription{
+						State: tt.state,
+						Properties: &api.SubscriptionProperties{
+							TenantID: "11111111-1111-1111-1111-111111111111",
+						},
+					},
+				})
+				if tt.fixture != nil {
+					tt.fixture(f)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			apis := map[string]*api.Version{
+				"2023-07-01-preview": {
+					OpenShiftClusterConverter:                   api.APIs["2023-07-01-preview"].OpenShiftClusterConverter,
+					OpenShiftClusterStaticValidator:             &errorOpenShiftClusterValidator{err: tt.staticErr},
+					OpenShiftClusterValidationFindingsConverter: api.APIs["2023-07-01-preview"].OpenShiftClusterValidationFindingsConverter,
+				},
+				"2020-04-30": {
+					OpenShiftClusterConverter:       api.APIs["2020-04-30"].OpenShiftClusterConverter,
+					OpenShiftClusterStaticValidator: &errorOpenShiftClusterValidator{err: tt.staticErr},
+				},
+			}
+
+			mockSkuValidator := mock_frontend.NewMockSkuValidator(controller)
+			mockQuotaValidator := mock_frontend.NewMockQuotaValidator(controller)
+			mockDynamicValidator := mock_frontend.NewMockDynamicValidator(controller)
+			if tt.staticErr == nil && tt.wantStatusCode != http.StatusBadRequest {
+				mockSkuValidator.EXPECT().ValidateVMSku(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.skuErr)
+				mockQuotaValidator.EXPECT().ValidateQuota(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.quotaErr)
+				mockDynamicValidator.EXPECT().ValidateDynamic(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.dynamicErrs, tt.dynamicErr)
+			}
+
+			f, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, ti.asyncOperationsDatabase, ti.clusterManagerDatabase, ti.openShiftClustersDatabase, ti.subscriptionsDatabase, nil, nil, nil, apis, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, ti.enricher)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f.skuValidator = mockSkuValidator
+			f.quotaValidator = mockQuotaValidator
+			f.dynamicValidator = mockDynamicValidator
+
+			go f.Run(ctx, nil, nil)
+			f.mu.Lock()
+			f.enabledOcpVersions = map[string]*api.OpenShiftVersion{
+				defaultVersion: {
+					Properties: api.OpenShiftVersionProperties{
+						Version: defaultVersion,
+						Enabled: true,
+					},
+				},
+			}
+			f.mu.Unlock()
+
+			oc := &v20230701preview.OpenShiftCluster{}
+			if tt.request != nil {
+				tt.request(oc)
+			}
+
+			resp, b, err := ti.request(http.MethodPost,
+				"https://server"+testdatabase.GetResourcePath(mockSubID, "resourceName")+"/validate?api-version="+tt.apiVersion,
+				http.Header{
+					"Content-Type": []string{"application/json"},
+				}, oc)
+			if err != nil {
+				t.Error(err)
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, tt.wantResponse)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
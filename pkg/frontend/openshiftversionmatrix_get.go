@@ -0,0 +1,105 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/frontend/middleware"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+func (f *frontend) getOpenShiftVersionMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctx.Value(middleware.ContextKeyLog).(*logrus.Entry)
+	apiVersion := r.URL.Query().Get(api.APIVersionKey)
+	resourceProviderNamespace := chi.URLParam(r, "resourceProviderNamespace")
+	if f.apis[apiVersion].OpenShiftVersionMatrixConverter == nil {
+		api.WriteError(w, http.StatusBadRequest, api.CloudErrorCodeInvalidResourceType, "", "The endpoint could not be found in the namespace '%s' for api version '%s'.", resourceProviderNamespace, apiVersion)
+		return
+	}
+
+	matrix, err := f.getVersionMatrix(ctx)
+	if err != nil {
+		reply(log, w, nil, nil, err)
+		return
+	}
+
+	converter := f.apis[apiVersion].OpenShiftVersionMatrixConverter
+
+	b, err := json.MarshalIndent(converter.ToExternal(matrix), "", "    ")
+	reply(log, w, nil, b, err)
+}
+
+// getVersionMatrix returns the enabled versions whose release images are
+// available in this region, sorted by version.  For each version, the
+// upgrade versions are the later, non-deprecated versions which the update
+// matrix allows clusters at the version to be updated to.
+func (f *frontend) getVersionMatrix(ctx context.Context) (*api.OpenShiftVersionMatrix, error) {
+	type entry struct {
+		version *version.Version
+		status  api.OpenShiftVersionStatus
+	}
+
+	entries := make([]entry, 0)
+
+	f.mu.RLock()
+	for _, v := range f.enabledOcpVersions {
+		if !v.Properties.IsAvailableIn(f.env.Location()) {
+			continue
+		}
+
+		vsn, err := version.ParseVersion(v.Properties.Version)
+		if err != nil {
+			f.mu.RUnlock()
+			return nil, err
+		}
+
+		entries = append(entries, entry{version: vsn, status: v.Properties.GetStatus()})
+	}
+	f.mu.RUnlock()
+
+	if len(entries) == 0 {
+		entries = append(entries, entry{
+			version: version.DefaultInstallStream.Version,
+			status:  api.OpenShiftVersionStatusInstallable,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].version.Lt(entries[j].version)
+	})
+
+	matrix := &api.OpenShiftVersionMatrix{
+		Versions: make([]api.OpenShiftVersionMatrixVersion, 0, len(entries)),
+	}
+
+	for i, from := range entries {
+		upgradeVersions := make([]string, 0)
+		for _, to := range entries[i+1:] {
+			if to.status == api.OpenShiftVersionStatusDeprecated {
+				continue
+			}
+
+			if version.ValidateUpdate(version.UpdateMatrix, from.version, to.version) == nil {
+				upgradeVersions = append(upgradeVersions, to.version.String())
+			}
+		}
+
+		matrix.Versions = append(matrix.Versions, api.OpenShiftVersionMatrixVersion{
+			Version:         from.version.String(),
+			Status:          from.status,
+			UpgradeVersions: upgradeVersions,
+		})
+	}
+
+	return matrix, nil
+}
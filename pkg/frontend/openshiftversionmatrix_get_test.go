@@ -0,0 +1,143 @@
+package frontend
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/v20230701preview"
+	"github.com/Azure/ARO-RP/pkg/metrics/noop"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+func TestGetOpenShiftVersionMatrix(t *testing.T) {
+	mockSubID := "00000000-0000-0000-0000-000000000000"
+	ctx := context.Background()
+
+	enabled := func(v string, status api.OpenShiftVersionStatus, locations ...string) *api.OpenShiftVersion {
+		return &api.OpenShiftVersion{
+			Properties: api.OpenShiftVersionProperties{
+				Version:   v,
+				Enabled:   true,
+				Status:    status,
+				Locations: locations,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		changeFeed     map[string]*api.OpenShiftVersion
+		apiVersion     string
+		wantStatusCode int
+		wantResponse   *v20230701preview.OpenShiftVersionMatrix
+		wantError      string
+	}{
+		{
+			name: "versions with their upgrade versions",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.10.40": enabled("4.10.40", api.OpenShiftVersionStatusDeprecated),
+				"4.10.54": enabled("4.10.54", api.OpenShiftVersionStatusDeprecated),
+				"4.11.44": enabled("4.11.44", api.OpenShiftVersionStatusUpgradeOnly),
+				"4.12.10": enabled("4.12.10", ""),
+				"4.12.25": enabled("4.12.25", api.OpenShiftVersionStatusInstallable, "eastus", "westeurope"),
+				"4.12.40": enabled("4.12.40", "", "westeurope"),
+			},
+			apiVersion:     "2023-07-01-preview",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftVersionMatrix{
+				Versions: []v20230701preview.OpenShiftVersionMatrixVersion{
+					{
+						Version:         "4.10.40",
+						Status:          v20230701preview.OpenShiftVersionStatusDeprecated,
+						UpgradeVersions: []string{"4.11.44"},
+					},
+					{
+						Version:         "4.10.54",
+						Status:          v20230701preview.OpenShiftVersionStatusDeprecated,
+						UpgradeVersions: []string{"4.11.44"},
+					},
+					{
+						Version:         "4.11.44",
+						Status:          v20230701preview.OpenShiftVersionStatusUpgradeOnly,
+						UpgradeVersions: []string{"4.12.10", "4.12.25"},
+					},
+					{
+						Version:         "4.12.10",
+						Status:          v20230701preview.OpenShiftVersionStatusInstallable,
+						UpgradeVersions: []string{"4.12.25"},
+					},
+					{
+						Version:         "4.12.25",
+						Status:          v20230701preview.OpenShiftVersionStatusInstallable,
+						UpgradeVersions: []string{},
+					},
+				},
+			},
+		},
+		{
+			name:           "no versions in the cache returns the default install version",
+			changeFeed:     map[string]*api.OpenShiftVersion{},
+			apiVersion:     "2023-07-01-preview",
+			wantStatusCode: http.StatusOK,
+			wantResponse: &v20230701preview.OpenShiftVersionMatrix{
+				Versions: []v20230701preview.OpenShiftVersionMatrixVersion{
+					{
+						Version:         version.DefaultInstallStream.Version.String(),
+						Status:          v20230701preview.OpenShiftVersionStatusInstallable,
+						UpgradeVersions: []string{},
+					},
+				},
+			},
+		},
+		{
+			name:           "api version without the endpoint",
+			apiVersion:     "2022-09-04",
+			wantStatusCode: http.StatusBadRequest,
+			wantError:      "400: InvalidResourceType: : The endpoint could not be found in the namespace 'microsoft.redhatopenshift' for api version '2022-09-04'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newTestInfra(t).WithSubscriptions().WithOpenShiftVersions()
+			defer ti.done()
+
+			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, ti.openShiftVersionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			go frontend.Run(ctx, nil, nil)
+
+			frontend.mu.Lock()
+			frontend.enabledOcpVersions = tt.changeFeed
+			frontend.mu.Unlock()
+
+			resp, b, err := ti.request(http.MethodGet,
+				fmt.Sprintf("https://server/subscriptions/%s/providers/Microsoft.RedHatOpenShift/locations/%s/openshiftversionmatrix?api-version=%s", mockSubID, ti.env.Location(), tt.apiVersion),
+				nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want []byte
+			if tt.wantResponse != nil {
+				want, err = json.MarshalIndent(tt.wantResponse, "", "    ")
+				if err != nil {
+					t.Fatal(err)
+				}
+				want = append(want, '\n')
+			}
+
+			err = validateResponse(resp, b, tt.wantStatusCode, tt.wantError, want)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
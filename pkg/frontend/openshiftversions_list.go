@@ -33,12 +33,16 @@ func (f *frontend) listInstallVersions(w http.ResponseWriter, r *http.Request) {
 	reply(log, w, nil, b, err)
 }
 
+// getEnabledInstallVersions returns the enabled versions which new clusters
+// can be installed at in this region
 func (f *frontend) getEnabledInstallVersions(ctx context.Context) []*api.OpenShiftVersion {
 	versions := make([]*api.OpenShiftVersion, 0)
 
 	f.mu.RLock()
 	for _, v := range f.enabledOcpVersions {
-		versions = append(versions, v)
+		if isInstallableIn(v, f.env.Location()) {
+			versions = append(versions, v)
+		}
 	}
 	f.mu.RUnlock()
 
@@ -52,3 +56,10 @@ func (f *frontend) getEnabledInstallVersions(ctx context.Context) []*api.OpenShi
 
 	return versions
 }
+
+// isInstallableIn returns true if new clusters can be installed at the version
+// in location
+func isInstallableIn(v *api.OpenShiftVersion, location string) bool {
+	return v.Properties.GetStatus() == api.OpenShiftVersionStatusInstallable &&
+		v.Properties.IsAvailableIn(location)
+}
@@ -78,6 +78,43 @@ func TestListInstallVersions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "versions which are not installable in the region are filtered",
+			changeFeed: map[string]*api.OpenShiftVersion{
+				"4.11.44": {
+					Properties: api.OpenShiftVersionProperties{
+						Version: "4.11.44",
+						Enabled: true,
+						Status:  api.OpenShiftVersionStatusUpgradeOnly,
+					},
+				},
+				"4.12.25": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:   "4.12.25",
+						Enabled:   true,
+						Locations: []string{"eastus", "westeurope"},
+					},
+				},
+				"4.12.40": {
+					Properties: api.OpenShiftVersionProperties{
+						Version:   "4.12.40",
+						Enabled:   true,
+						Locations: []string{"westeurope"},
+					},
+				},
+			},
+			apiVersion:     "2022-09-04",
+			wantStatusCode: http.StatusOK,
+			wantResponse: v20220904.OpenShiftVersionList{
+				OpenShiftVersions: []*v20220904.OpenShiftVersion{
+					{
+						Properties: v20220904.OpenShiftVersionProperties{
+							Version: "4.12.25",
+						},
+					},
+				},
+			},
+		},
 		{
 			name:           "api does not exist",
 			apiVersion:     "invalid",
@@ -89,7 +126,7 @@ func TestListInstallVersions(t *testing.T) {
 			ti := newTestInfra(t).WithSubscriptions().WithOpenShiftVersions()
 			defer ti.done()
 
-			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, ti.openShiftVersionsDatabase, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
+			frontend, err := NewFrontend(ctx, ti.audit, ti.log, ti.env, nil, nil, nil, nil, ti.openShiftVersionsDatabase, nil, nil, api.APIs, &noop.Noop{}, &noop.Noop{}, nil, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
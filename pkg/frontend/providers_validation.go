@@ -5,27 +5,21 @@ package frontend
 
 import (
 	"context"
-	"net/http"
 
-	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/sirupsen/logrus"
 
-	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/resourceproviders"
 )
 
 type ProvidersValidator interface {
 	ValidateProviders(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string) error
 }
 
-type providersValidator struct{}
-
-var requiredResourceProviders = []string{
-	"Microsoft.Authorization",
-	"Microsoft.Compute",
-	"Microsoft.Network",
-	"Microsoft.Storage",
+type providersValidator struct {
+	log *logrus.Entry
 }
 
 func (p providersValidator) ValidateProviders(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string) error {
@@ -36,26 +30,22 @@ func (p providersValidator) ValidateProviders(ctx context.Context, azEnv *azurec
 
 	providersClient := features.NewProvidersClient(azEnv, subscriptionID, fpAuthorizer)
 
-	return validateProviders(ctx, providersClient)
+	return validateProviders(ctx, p.log, providersClient)
 }
 
-func validateProviders(ctx context.Context, providersClient features.ProvidersClient) error {
-	providers, err := providersClient.List(ctx, nil, "")
+// validateProviders requests registration of any required resource providers
+// which are not registered.  Providers whose registration is in progress are
+// accepted: the backend waits for their registration to complete before
+// installing the cluster.
+func validateProviders(ctx context.Context, log *logrus.Entry, providersClient features.ProvidersClient) error {
+	unregistered, registering, err := resourceproviders.Unregistered(ctx, providersClient)
 	if err != nil {
 		return err
 	}
 
-	providerMap := make(map[string]mgmtfeatures.Provider, len(providers))
-
-	for _, provider := range providers {
-		providerMap[*provider.Namespace] = provider
-	}
-
-	for _, provider := range requiredResourceProviders {
-		if providerMap[provider].RegistrationState == nil ||
-			*providerMap[provider].RegistrationState != "Registered" {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceProviderNotRegistered, "", "The resource provider '%s' is not registered.", provider)
-		}
+	failed := resourceproviders.Register(ctx, log, providersClient, unregistered, registering)
+	if len(failed) > 0 {
+		return resourceproviders.NotRegisteredError(failed)
 	}
 
 	return nil
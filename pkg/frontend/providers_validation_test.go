@@ -11,6 +11,7 @@ import (
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 
 	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
 )
@@ -18,96 +19,79 @@ import (
 func TestValidateProviders(t *testing.T) {
 	ctx := context.Background()
 
+	providers := func(states map[string]string) []mgmtfeatures.Provider {
+		providers := []mgmtfeatures.Provider{
+			{
+				Namespace:         to.StringPtr("otherRegisteredProvider"),
+				RegistrationState: to.StringPtr("Registered"),
+			},
+			{
+				Namespace:         to.StringPtr("otherNotRegisteredProvider"),
+				RegistrationState: to.StringPtr("NotRegistered"),
+			},
+		}
+		for _, namespace := range []string{"Microsoft.Authorization", "Microsoft.Compute", "Microsoft.Network", "Microsoft.Storage"} {
+			state, found := states[namespace]
+			if !found {
+				state = "Registered"
+			}
+			if state == "" {
+				continue
+			}
+			providers = append(providers, mgmtfeatures.Provider{
+				Namespace:         to.StringPtr(namespace),
+				RegistrationState: to.StringPtr(state),
+			})
+		}
+		return providers
+	}
+
 	for _, tt := range []struct {
 		name            string
 		mockProviders   []mgmtfeatures.Provider
 		mockProviderErr error
+		mocks           func(*mock_features.MockProvidersClient)
 		wantErr         string
 	}{
 		{
-			name: "pass",
-			mockProviders: []mgmtfeatures.Provider{
-				{
-					Namespace:         to.StringPtr("Microsoft.Authorization"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Compute"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Network"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Storage"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherRegisteredProvider"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherNotRegisteredProvider"),
-					RegistrationState: to.StringPtr("NotRegistered"),
-				},
+			name:          "pass",
+			mockProviders: providers(nil),
+		},
+		{
+			name:          "pass: compute registered on demand",
+			mockProviders: providers(map[string]string{"Microsoft.Compute": "NotRegistered"}),
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					Register(gomock.Any(), "Microsoft.Compute").
+					Return(mgmtfeatures.Provider{}, nil)
 			},
 		},
 		{
-			name: "fail: compute not registered",
-			mockProviders: []mgmtfeatures.Provider{
-				{
-					Namespace:         to.StringPtr("Microsoft.Authorization"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Compute"),
-					RegistrationState: to.StringPtr("NotRegistered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Network"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Storage"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherRegisteredProvider"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherNotRegisteredProvider"),
-					RegistrationState: to.StringPtr("NotRegistered"),
-				},
+			name:          "pass: compute registering",
+			mockProviders: providers(map[string]string{"Microsoft.Compute": "Registering"}),
+		},
+		{
+			name:          "fail: compute not registered",
+			mockProviders: providers(map[string]string{"Microsoft.Compute": "NotRegistered"}),
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					Register(gomock.Any(), "Microsoft.Compute").
+					Return(mgmtfeatures.Provider{}, errors.New("forbidden"))
 			},
 			wantErr: "400: ResourceProviderNotRegistered: : The resource provider 'Microsoft.Compute' is not registered.",
 		},
 		{
-			name: "fail: storage missing",
-			mockProviders: []mgmtfeatures.Provider{
-				{
-					Namespace:         to.StringPtr("Microsoft.Authorization"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Compute"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("Microsoft.Network"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherRegisteredProvider"),
-					RegistrationState: to.StringPtr("Registered"),
-				},
-				{
-					Namespace:         to.StringPtr("otherNotRegisteredProvider"),
-					RegistrationState: to.StringPtr("NotRegistered"),
-				},
+			name:          "fail: compute not registered and storage missing",
+			mockProviders: providers(map[string]string{"Microsoft.Compute": "NotRegistered", "Microsoft.Storage": ""}),
+			mocks: func(providersClient *mock_features.MockProvidersClient) {
+				providersClient.EXPECT().
+					Register(gomock.Any(), "Microsoft.Compute").
+					Return(mgmtfeatures.Provider{}, errors.New("forbidden"))
+				providersClient.EXPECT().
+					Register(gomock.Any(), "Microsoft.Storage").
+					Return(mgmtfeatures.Provider{}, errors.New("forbidden"))
 			},
-			wantErr: "400: ResourceProviderNotRegistered: : The resource provider 'Microsoft.Storage' is not registered.",
+			wantErr: "400: ResourceProviderNotRegistered: : The resource providers 'Microsoft.Compute', 'Microsoft.Storage' are not registered.",
 		},
 		{
 			name:            "error case",
@@ -125,7 +109,11 @@ func TestValidateProviders(t *testing.T) {
 				List(gomock.Any(), nil, "").
 				Return(tt.mockProviders, tt.mockProviderErr)
 
-			err := validateProviders(ctx, providersClient)
+			if tt.mocks != nil {
+				tt.mocks(providersClient)
+			}
+
+			err := validateProviders(ctx, logrus.NewEntry(logrus.StandardLogger()), providersClient)
 			if err != nil && err.Error() != tt.wantErr ||
 				err == nil && tt.wantErr != "" {
 				t.Error(err)
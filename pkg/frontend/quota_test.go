@@ -22,9 +22,10 @@ func TestValidateQuota(t *testing.T) {
 	ctx := context.Background()
 
 	type test struct {
-		name    string
-		mocks   func(*test, *mock_compute.MockUsageClient, *mock_network.MockUsageClient)
-		wantErr string
+		name                     string
+		additionalWorkerProfiles []api.WorkerProfile
+		mocks                    func(*test, *mock_compute.MockUsageClient, *mock_network.MockUsageClient)
+		wantErr                  string
 	}
 	for _, tt := range []*test{
 		{
@@ -92,6 +93,29 @@ func TestValidateQuota(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name: "not enough cores for the workers of all worker profiles",
+			additionalWorkerProfiles: []api.WorkerProfile{
+				{
+					VMSize: "Standard_D8s_v3",
+					Count:  2,
+				},
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 212, Current in use: 100, Additional requested: 128.",
+			mocks: func(tt *test, cuc *mock_compute.MockUsageClient, nuc *mock_network.MockUsageClient) {
+				cuc.EXPECT().
+					List(ctx, "ocLocation").
+					Return([]mgmtcompute.Usage{
+						{
+							Name: &mgmtcompute.UsageName{
+								Value: to.StringPtr("cores"),
+							},
+							CurrentValue: to.Int32Ptr(100),
+							Limit:        to.Int64Ptr(212),
+						},
+					}, nil)
+			},
+		},
 		{
 			name:    "not enough virtualMachines",
 			wantErr: "400: ResourceQuotaExceeded: : Resource quota of virtualMachines exceeded. Maximum allowed: 114, Current in use: 101, Additional requested: 14.",
@@ -183,12 +207,12 @@ func TestValidateQuota(t *testing.T) {
 					MasterProfile: api.MasterProfile{
 						VMSize: "Standard_D8s_v3",
 					},
-					WorkerProfiles: []api.WorkerProfile{
+					WorkerProfiles: append([]api.WorkerProfile{
 						{
 							VMSize: "Standard_D8s_v3",
 							Count:  10,
 						},
-					},
+					}, tt.additionalWorkerProfiles...),
 				},
 			}
 
@@ -197,3 +221,86 @@ func TestValidateQuota(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateScaleQuota(t *testing.T) {
+	ctx := context.Background()
+
+	usages := []mgmtcompute.Usage{
+		{
+			Name: &mgmtcompute.UsageName{
+				Value: to.StringPtr("cores"),
+			},
+			CurrentValue: to.Int32Ptr(100),
+			Limit:        to.Int64Ptr(148),
+		},
+		{
+			Name: &mgmtcompute.UsageName{
+				Value: to.StringPtr("virtualMachines"),
+			},
+			CurrentValue: to.Int32Ptr(100),
+			Limit:        to.Int64Ptr(106),
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		workerProfiles []api.WorkerProfile
+		wantErr        string
+	}{
+		{
+			name: "allow when the added workers fit",
+			workerProfiles: []api.WorkerProfile{
+				{
+					VMSize: "Standard_D8s_v3",
+					Count:  4,
+				},
+				{
+					VMSize: "Standard_D4s_v3",
+					Count:  2,
+				},
+			},
+		},
+		{
+			name: "not enough cores",
+			workerProfiles: []api.WorkerProfile{
+				{
+					VMSize: "Standard_D8s_v3",
+					Count:  4,
+				},
+				{
+					VMSize: "Standard_D8s_v3",
+					Count:  3,
+				},
+			},
+			wantErr: "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 148, Current in use: 100, Additional requested: 56.",
+		},
+		{
+			name: "unsupported VM size",
+			workerProfiles: []api.WorkerProfile{
+				{
+					VMSize: "Standard_A1",
+					Count:  1,
+				},
+			},
+			wantErr: "400: InvalidParameter: : The provided VM SKU Standard_A1 is not supported.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			computeUsageClient := mock_compute.NewMockUsageClient(controller)
+			computeUsageClient.EXPECT().
+				List(ctx, "ocLocation").
+				Return(usages, nil).
+				MaxTimes(1)
+
+			oc := &api.OpenShiftCluster{
+				Location: "ocLocation",
+			}
+
+			err := validateScaleQuota(ctx, oc, tt.workerProfiles, computeUsageClient)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 type QuotaValidator interface {
 	ValidateQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster) error
+	ValidateScaleQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster, workerProfiles []api.WorkerProfile) error
 }
 
 type quotaValidator struct{}
@@ -50,6 +51,21 @@ func (q quotaValidator) ValidateQuota(ctx context.Context, azEnv *azureclient.AR
 	return validateQuota(ctx, oc, spNetworkUsage, spComputeUsage)
 }
 
+// ValidateScaleQuota checks usage quotas vs. resources required by the workers
+// added to the given worker profiles when the cluster is scaled, so that a
+// scale operation is rejected up front rather than stopping part way.  Each
+// worker profile's Count is the number of workers added.
+func (q quotaValidator) ValidateScaleQuota(ctx context.Context, azEnv *azureclient.AROEnvironment, environment env.Interface, subscriptionID, tenantID string, oc *api.OpenShiftCluster, workerProfiles []api.WorkerProfile) error {
+	fpAuthorizer, err := environment.FPAuthorizer(tenantID, environment.Environment().ResourceManagerScope)
+	if err != nil {
+		return err
+	}
+
+	spComputeUsage := compute.NewUsageClient(azEnv, subscriptionID, fpAuthorizer)
+
+	return validateScaleQuota(ctx, oc, workerProfiles, spComputeUsage)
+}
+
 func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage network.UsageClient, spComputeUsage compute.UsageClient) error {
 	// If ValidateQuota runs outside install process, we should skip quota validation
 	requiredResources := map[string]int{}
@@ -59,8 +75,9 @@ func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage
 		return err
 	}
 
+	// the machine sets of all the worker profiles are created at once, so
+	// their workers must fit in the quota together
 	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
-	//worker node resource calculation
 	for _, w := range workerProfiles {
 		err := addRequiredResources(requiredResources, w.VMSize, w.Count)
 		if err != nil {
@@ -77,18 +94,11 @@ func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage
 	// rationale:
 	// 1. if the Usage API doesn't send a limit because a resource is no longer limited, RP will continue cluster creation without impact
 	// 2. if the Usage API doesn't send a limit that is still enforced, cluster creation will fail on the backend and we will get an error in the RP logs
-	computeUsages, err := spComputeUsage.List(ctx, oc.Location)
+	err = validateComputeQuota(ctx, oc.Location, requiredResources, spComputeUsage)
 	if err != nil {
 		return err
 	}
 
-	for _, usage := range computeUsages {
-		required, present := requiredResources[*usage.Name.Value]
-		if present && int64(required) > (*usage.Limit-int64(*usage.CurrentValue)) {
-			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *usage.Name.Value, *usage.Limit, *usage.CurrentValue, required)
-		}
-	}
-
 	netUsages, err := spNetworkUsage.List(ctx, oc.Location)
 	if err != nil {
 		return err
@@ -103,3 +113,32 @@ func validateQuota(ctx context.Context, oc *api.OpenShiftCluster, spNetworkUsage
 
 	return nil
 }
+
+func validateScaleQuota(ctx context.Context, oc *api.OpenShiftCluster, workerProfiles []api.WorkerProfile, spComputeUsage compute.UsageClient) error {
+	requiredResources := map[string]int{}
+
+	for _, w := range workerProfiles {
+		err := addRequiredResources(requiredResources, w.VMSize, w.Count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateComputeQuota(ctx, oc.Location, requiredResources, spComputeUsage)
+}
+
+func validateComputeQuota(ctx context.Context, location string, requiredResources map[string]int, spComputeUsage compute.UsageClient) error {
+	computeUsages, err := spComputeUsage.List(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	for _, usage := range computeUsages {
+		required, present := requiredResources[*usage.Name.Value]
+		if present && int64(required) > (*usage.Limit-int64(*usage.CurrentValue)) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of %s exceeded. Maximum allowed: %d, Current in use: %d, Additional requested: %d.", *usage.Name.Value, *usage.Limit, *usage.CurrentValue, required)
+		}
+	}
+
+	return nil
+}
@@ -14,7 +14,9 @@ import (
 // checkReady checks the ready status of the frontend to make it consistent
 // across the /healthz/ready endpoint and emitted metrics.   We wait for 2
 // minutes before indicating health.  This ensures that there will be a gap in
-// our health metric if we crash or restart.
+// our health metric if we crash or restart.  We are not ready while the
+// database circuit breaker is open, so that we are taken out of rotation
+// rather than failing requests.
 func (f *frontend) checkReady() bool {
 	if !f.env.FeatureIsSet(env.FeatureDisableReadinessDelay) &&
 		time.Since(f.startTime) < 2*time.Minute {
@@ -25,6 +27,7 @@ func (f *frontend) checkReady() bool {
 
 	return ok &&
 		f.ready.Load().(bool) &&
+		(f.dbCircuitBreaker == nil || f.dbCircuitBreaker.Healthy()) &&
 		f.env.ArmClientAuthorizer().IsReady() &&
 		f.env.AdminClientAuthorizer().IsReady()
 }
@@ -80,6 +80,8 @@ type testInfra struct {
 	subscriptionsDatabase     database.Subscriptions
 	openShiftVersionsClient   *cosmosdb.FakeOpenShiftVersionDocumentClient
 	openShiftVersionsDatabase database.OpenShiftVersions
+	clusterHealthClient       *cosmosdb.FakeClusterHealthDocumentClient
+	clusterHealthDatabase     database.ClusterHealth
 }
 
 func newTestInfra(t *testing.T) *testInfra {
@@ -185,6 +187,11 @@ func (ti *testInfra) WithClusterManagerConfigurations() *testInfra {
 	return ti
 }
 
+func (ti *testInfra) WithClusterHealth() *testInfra {
+	ti.clusterHealthDatabase, ti.clusterHealthClient = testdatabase.NewFakeClusterHealth()
+	return ti
+}
+
 func (ti *testInfra) done() {
 	ti.controller.Finish()
 	ti.cli.CloseIdleConnections()
@@ -20,19 +20,23 @@ import (
 
 func TestValidateVMSku(t *testing.T) {
 	for _, tt := range []struct {
-		name                  string
-		restrictions          mgmtcompute.ResourceSkuRestrictionsReasonCode
-		restrictionLocation   *[]string
-		restrictedZones       []string
-		workerProfile1Sku     string
-		workerProfile2Sku     string
-		masterProfileSku      string
-		availableSku          string
-		availableSku2         string
-		restrictedSku         string
-		resourceSkusClientErr error
-		wpStatus              bool
-		wantErr               string
+		name                           string
+		restrictions                   mgmtcompute.ResourceSkuRestrictionsReasonCode
+		restrictionLocation            *[]string
+		restrictedZones                []string
+		workerProfile1Sku              string
+		workerProfile2Sku              string
+		masterProfileSku               string
+		availableSku                   string
+		availableSku2                  string
+		restrictedSku                  string
+		resourceSkusClientErr          error
+		wpStatus                       bool
+		diskType                       api.DiskStorageAccountType
+		premiumIO                      bool
+		acceleratedNetworking          api.AcceleratedNetworking
+		acceleratedNetworkingSupported bool
+		wantErr                        string
 	}{
 		{
 			name:              "worker and master skus are valid",
@@ -146,6 +150,58 @@ func TestValidateVMSku(t *testing.T) {
 			restrictedSku:     "Standard_L80",
 			wantErr:           "400: InvalidParameter: properties.masterProfile.VMSize: The selected SKU 'Standard_L80' is restricted in region 'eastus' for selected subscription",
 		},
+		{
+			name:              "premium disk requested, sku supports premium disks",
+			workerProfile1Sku: "Standard_D4s_v2",
+			workerProfile2Sku: "Standard_D4s_v2",
+			masterProfileSku:  "Standard_D4s_v2",
+			availableSku:      "Standard_D4s_v2",
+			diskType:          api.DiskStorageAccountTypePremiumLRS,
+			premiumIO:         true,
+		},
+		{
+			name:              "premium disk requested, sku does not support premium disks",
+			workerProfile1Sku: "Standard_D4_v2",
+			workerProfile2Sku: "Standard_D4_v2",
+			masterProfileSku:  "Standard_D4_v2",
+			availableSku:      "Standard_D4_v2",
+			diskType:          api.DiskStorageAccountTypePremiumLRS,
+			wantErr:           "400: InvalidParameter: properties.workerProfiles[0].diskStorageAccountType: The selected SKU 'Standard_D4_v2' does not support disk storage account type 'Premium_LRS'",
+		},
+		{
+			name:              "standard SSD disk requested, sku does not support premium disks",
+			workerProfile1Sku: "Standard_D4_v2",
+			workerProfile2Sku: "Standard_D4_v2",
+			masterProfileSku:  "Standard_D4_v2",
+			availableSku:      "Standard_D4_v2",
+			diskType:          api.DiskStorageAccountTypeStandardSSDLRS,
+		},
+		{
+			name:                           "accelerated networking requested, sku supports accelerated networking",
+			workerProfile1Sku:              "Standard_D4s_v2",
+			workerProfile2Sku:              "Standard_D4s_v2",
+			masterProfileSku:               "Standard_D4s_v2",
+			availableSku:                   "Standard_D4s_v2",
+			acceleratedNetworking:          api.AcceleratedNetworkingEnabled,
+			acceleratedNetworkingSupported: true,
+		},
+		{
+			name:                  "accelerated networking requested, sku does not support accelerated networking",
+			workerProfile1Sku:     "Standard_D4s_v2",
+			workerProfile2Sku:     "Standard_D4s_v2",
+			masterProfileSku:      "Standard_D4s_v2",
+			availableSku:          "Standard_D4s_v2",
+			acceleratedNetworking: api.AcceleratedNetworkingEnabled,
+			wantErr:               "400: InvalidParameter: properties.workerProfiles[0].acceleratedNetworking: The selected SKU 'Standard_D4s_v2' does not support accelerated networking",
+		},
+		{
+			name:                  "accelerated networking disabled, sku does not support accelerated networking",
+			workerProfile1Sku:     "Standard_D4s_v2",
+			workerProfile2Sku:     "Standard_D4s_v2",
+			masterProfileSku:      "Standard_D4s_v2",
+			availableSku:          "Standard_D4s_v2",
+			acceleratedNetworking: api.AcceleratedNetworkingDisabled,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.restrictedZones == nil {
@@ -160,10 +216,14 @@ func TestValidateVMSku(t *testing.T) {
 				Properties: api.OpenShiftClusterProperties{
 					WorkerProfiles: []api.WorkerProfile{
 						{
-							VMSize: api.VMSize(tt.workerProfile1Sku),
+							VMSize:                 api.VMSize(tt.workerProfile1Sku),
+							DiskStorageAccountType: tt.diskType,
+							AcceleratedNetworking:  tt.acceleratedNetworking,
 						},
 						{
-							VMSize: api.VMSize(tt.workerProfile2Sku),
+							VMSize:                 api.VMSize(tt.workerProfile2Sku),
+							DiskStorageAccountType: tt.diskType,
+							AcceleratedNetworking:  tt.acceleratedNetworking,
 						},
 					},
 					MasterProfile: api.MasterProfile{
@@ -212,6 +272,18 @@ func TestValidateVMSku(t *testing.T) {
 				},
 			}
 
+			if tt.premiumIO {
+				skus[0].Capabilities = &[]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("PremiumIO"), Value: to.StringPtr("True")},
+				}
+			}
+
+			if tt.acceleratedNetworkingSupported {
+				skus[0].Capabilities = &[]mgmtcompute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("AcceleratedNetworkingEnabled"), Value: to.StringPtr("True")},
+				}
+			}
+
 			if tt.wpStatus {
 				oc.Properties.WorkerProfiles = nil
 				oc.Properties.WorkerProfilesStatus = []api.WorkerProfile{
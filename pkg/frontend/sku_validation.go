@@ -63,6 +63,16 @@ func validateVMSku(ctx context.Context, oc *api.OpenShiftCluster, resourceSkusCl
 		if err != nil {
 			return err
 		}
+
+		err = checkSKUDiskStorageAccountType(filteredSkus, fmt.Sprintf("properties.workerProfiles[%d].diskStorageAccountType", i), workerProfileSku, workerprofile.DiskStorageAccountType)
+		if err != nil {
+			return err
+		}
+
+		err = checkSKUAcceleratedNetworking(filteredSkus, fmt.Sprintf("properties.workerProfiles[%d].acceleratedNetworking", i), workerProfileSku, workerprofile.AcceleratedNetworking)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -83,3 +93,31 @@ func checkSKUAvailability(skus map[string]*mgmtcompute.ResourceSku, location, pa
 
 	return nil
 }
+
+// checkSKUDiskStorageAccountType ensures that premium disks are only requested
+// for SKUs which support them
+func checkSKUDiskStorageAccountType(skus map[string]*mgmtcompute.ResourceSku, path, vmsize string, storageAccountType api.DiskStorageAccountType) error {
+	if storageAccountType != api.DiskStorageAccountTypePremiumLRS {
+		return nil
+	}
+
+	if computeskus.SupportedOSDisk(skus[vmsize]) != string(api.DiskStorageAccountTypePremiumLRS) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The selected SKU '%v' does not support disk storage account type '%v'", vmsize, storageAccountType)
+	}
+
+	return nil
+}
+
+// checkSKUAcceleratedNetworking ensures that accelerated networking is only
+// requested for SKUs which support it
+func checkSKUAcceleratedNetworking(skus map[string]*mgmtcompute.ResourceSku, path, vmsize string, acceleratedNetworking api.AcceleratedNetworking) error {
+	if acceleratedNetworking != api.AcceleratedNetworkingEnabled {
+		return nil
+	}
+
+	if !computeskus.SupportsAcceleratedNetworking(skus[vmsize]) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The selected SKU '%v' does not support accelerated networking", vmsize)
+	}
+
+	return nil
+}
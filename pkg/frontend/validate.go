@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -72,9 +73,44 @@ func (f *frontend) validateOpenShiftUniqueKey(ctx context.Context, doc *api.Open
 	if docs.Count != 0 {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDuplicateResourceGroup, "", "The provided resource group '%s' already contains a cluster.", doc.OpenShiftCluster.Properties.ClusterProfile.ResourceGroupID)
 	}
+	err = f.validateClusterDomainOverlap(ctx, doc)
+	if err != nil {
+		return err
+	}
 	return api.NewCloudError(http.StatusInternalServerError, api.CloudErrorCodeInternalServerError, "", "Internal server error.")
 }
 
+// validateClusterDomainOverlap rejects a domain which is the same as, a
+// subdomain of or a parent domain of the domain of another cluster in the
+// subscription, as the clusters' DNS records would conflict.  Clusters
+// created before the key was introduced are found once it is backfilled.
+func (f *frontend) validateClusterDomainOverlap(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
+	if doc.ClusterDomainKey == "" {
+		return nil
+	}
+
+	// the document isn't created yet, so its partition key isn't set
+	r, err := azure.ParseResourceID(doc.Key)
+	if err != nil {
+		return err
+	}
+
+	docs, err := f.dbOpenShiftClusters.GetByOverlappingClusterDomain(ctx, r.SubscriptionID, doc.ClusterDomainKey)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range docs.OpenShiftClusterDocuments {
+		if d.Key == doc.Key {
+			continue
+		}
+
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDuplicateDomain, "properties.clusterProfile.domain", "The provided domain '%s' overlaps with the domain '%s' of another cluster in the subscription.", doc.OpenShiftCluster.Properties.ClusterProfile.Domain, d.OpenShiftCluster.Properties.ClusterProfile.Domain)
+	}
+
+	return nil
+}
+
 // rxKubernetesString is weaker than Kubernetes validation, but strong enough to
 // prevent mischief
 var rxKubernetesString = regexp.MustCompile(`(?i)^[-a-z0-9.]{0,255}$`)
@@ -201,7 +237,9 @@ func validateAdminMasterVMSize(vmSize string) error {
 	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "", "The provided vmSize '%s' is unsupported for master.", vmSize)
 }
 
-// validateInstallVersion validates the install version set in the clusterprofile.version
+// validateInstallVersion validates the install version set in the clusterprofile.version,
+// and that the requested networkprofile.softwareDefinedNetwork and securityProfile
+// can be installed with it
 // TODO convert this into static validation instead of this receiver function in the validation for frontend.
 func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShiftCluster) error {
 	// If this request is from an older API or the user never specified
@@ -212,12 +250,125 @@ func (f *frontend) validateInstallVersion(ctx context.Context, oc *api.OpenShift
 	}
 
 	f.mu.RLock()
-	_, ok := f.enabledOcpVersions[oc.Properties.ClusterProfile.Version]
+	ov, ok := f.enabledOcpVersions[oc.Properties.ClusterProfile.Version]
 	f.mu.RUnlock()
 
-	if !ok || !validate.RxInstallVersion.MatchString(oc.Properties.ClusterProfile.Version) {
+	if !ok || !isInstallableIn(ov, f.env.Location()) || !validate.RxInstallVersion.MatchString(oc.Properties.ClusterProfile.Version) {
 		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.clusterProfile.version", "The requested OpenShift version '%s' is invalid.", oc.Properties.ClusterProfile.Version)
 	}
 
+	// an empty softwareDefinedNetwork leaves the choice to the installer
+	if oc.Properties.NetworkProfile.SoftwareDefinedNetwork != "" {
+		v, err := version.ParseVersion(oc.Properties.ClusterProfile.Version)
+		if err != nil {
+			return err
+		}
+
+		err = version.ValidateSoftwareDefinedNetwork(version.SoftwareDefinedNetworks, oc.Properties.NetworkProfile.SoftwareDefinedNetwork, v)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.networkProfile.softwareDefinedNetwork", "The requested softwareDefinedNetwork '%s' is invalid for OpenShift version '%s': %s.", oc.Properties.NetworkProfile.SoftwareDefinedNetwork, oc.Properties.ClusterProfile.Version, err)
+		}
+	}
+
+	if oc.Properties.SecurityProfile != nil && oc.Properties.SecurityProfile.DefaultSeccompProfile == api.SeccompProfileRuntimeDefault {
+		v, err := version.ParseVersion(oc.Properties.ClusterProfile.Version)
+		if err != nil {
+			return err
+		}
+
+		err = version.ValidateSeccompDefault(version.SeccompDefaultMinVersion, v)
+		if err != nil {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.securityProfile.defaultSeccompProfile", "The requested default seccomp profile '%s' is invalid for OpenShift version '%s': %s.", oc.Properties.SecurityProfile.DefaultSeccompProfile, oc.Properties.ClusterProfile.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRPVersionPin validates that an RP version pin which is set or
+// changed by the request is of an RP version which is deployed in the region.
+// A pin which was set earlier to a version which is no longer deployed is
+// cleared instead, since no backend would ever dequeue the operation.
+// rpVersionPin is the stored pin before the request.
+func (f *frontend) validateRPVersionPin(ctx context.Context, log *logrus.Entry, oc *api.OpenShiftCluster, rpVersionPin string) error {
+	pin := oc.Properties.RPVersionPin
+	if pin == "" {
+		return nil
+	}
+
+	supported, err := f.env.LiveConfig().SupportedRPVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range supported {
+		if pin == v {
+			return nil
+		}
+	}
+
+	if pin != rpVersionPin {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.rpVersionPin", "The provided RP version '%s' is not supported.", pin)
+	}
+
+	log.Warnf("clearing pin to unsupported RP version %s", pin)
+	oc.Properties.RPVersionPin = ""
+
+	return nil
+}
+
+// validateWorkerProfilesScale records a scale operation for each worker
+// profile whose count is changed by an update, and validates that the quota of
+// the subscription covers all of the workers added, so that the operation is
+// rejected up front rather than leaving the workers partially scaled.  On
+// update the worker profiles are those of the worker machine sets read by the
+// cluster enricher; workerProfiles are the stored ones before the update.
+func (f *frontend) validateWorkerProfilesScale(ctx context.Context, subscription *api.SubscriptionDocument, oc *api.OpenShiftCluster, workerProfiles []api.WorkerProfile) error {
+	enriched := oc.Properties.WorkerProfilesStatus != nil
+	if enriched {
+		workerProfiles = oc.Properties.WorkerProfilesStatus
+	}
+
+	current := make(map[string]api.WorkerProfile, len(workerProfiles))
+	for _, wp := range workerProfiles {
+		current[wp.Name] = wp
+	}
+
+	var scale []api.WorkerProfileScale
+	var added []api.WorkerProfile
+	for _, wp := range oc.Properties.WorkerProfiles {
+		c, found := current[wp.Name]
+		if !found || wp.Count == c.Count {
+			continue
+		}
+
+		if !enriched {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeRequestNotAllowed, "properties.workerProfiles['"+wp.Name+"'].count", "The worker machine sets of the cluster could not be read to scale them. Retry the request.")
+		}
+
+		scale = append(scale, api.WorkerProfileScale{
+			Name:            wp.Name,
+			CurrentReplicas: c.Count,
+			TargetReplicas:  wp.Count,
+		})
+
+		if wp.Count > c.Count {
+			added = append(added, api.WorkerProfile{
+				Name:   wp.Name,
+				VMSize: c.VMSize,
+				Count:  wp.Count - c.Count,
+			})
+		}
+	}
+
+	if len(added) > 0 {
+		err := f.quotaValidator.ValidateScaleQuota(ctx, f.env.Environment(), f.env, subscription.ID, subscription.Subscription.Properties.TenantID, oc, added)
+		if err != nil {
+			return err
+		}
+	}
+
+	oc.Properties.WorkerProfilesScaleStatus = scale
+
 	return nil
 }
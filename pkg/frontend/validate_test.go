@@ -4,13 +4,24 @@ package frontend
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/dns"
+	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
+	mock_frontend "github.com/Azure/ARO-RP/pkg/util/mocks/frontend"
+	"github.com/Azure/ARO-RP/pkg/util/version"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	"github.com/Azure/ARO-RP/test/util/testliveconfig"
 )
 
 func TestValidateAdminKubernetesPodLogs(t *testing.T) {
@@ -265,3 +276,428 @@ func TestValidateAdminMasterVMSize(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateInstallVersion(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().Location().AnyTimes().Return("eastus")
+
+	f := &frontend{
+		env: _env,
+		enabledOcpVersions: map[string]*api.OpenShiftVersion{
+			"4.12.25": {},
+			"4.15.3":  {},
+			"4.9.59":  {},
+			"4.11.44": {
+				Properties: api.OpenShiftVersionProperties{
+					Status: api.OpenShiftVersionStatusUpgradeOnly,
+				},
+			},
+			"4.12.40": {
+				Properties: api.OpenShiftVersionProperties{
+					Locations: []string{"westeurope"},
+				},
+			},
+			"4.13.10": {
+				Properties: api.OpenShiftVersionProperties{
+					Status:    api.OpenShiftVersionStatusInstallable,
+					Locations: []string{"westeurope", "EastUS"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		test                   string
+		version                string
+		softwareDefinedNetwork api.SoftwareDefinedNetwork
+		securityProfile        *api.SecurityProfile
+		wantErr                string
+	}{
+		{
+			test:    "enabled version",
+			version: "4.12.25",
+		},
+		{
+			test:    "version is not enabled",
+			version: "4.12.1",
+			wantErr: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.12.1' is invalid.",
+		},
+		{
+			test:    "version is upgrade-only",
+			version: "4.11.44",
+			wantErr: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.11.44' is invalid.",
+		},
+		{
+			test:    "version is not available in the region",
+			version: "4.12.40",
+			wantErr: "400: InvalidParameter: properties.clusterProfile.version: The requested OpenShift version '4.12.40' is invalid.",
+		},
+		{
+			test:    "version is available in the region",
+			version: "4.13.10",
+		},
+		{
+			test:                   "softwareDefinedNetwork is supported",
+			version:                "4.15.3",
+			softwareDefinedNetwork: api.SoftwareDefinedNetworkOVNKubernetes,
+		},
+		{
+			test:                   "softwareDefinedNetwork is unsupported",
+			version:                "4.15.3",
+			softwareDefinedNetwork: api.SoftwareDefinedNetworkOpenShiftSDN,
+			wantErr:                "400: InvalidParameter: properties.networkProfile.softwareDefinedNetwork: The requested softwareDefinedNetwork 'OpenShiftSDN' is invalid for OpenShift version '4.15.3': installing clusters with softwareDefinedNetwork OpenShiftSDN is supported before version 4.15.0.",
+		},
+		{
+			test:    "default seccomp profile is supported",
+			version: "4.12.25",
+			securityProfile: &api.SecurityProfile{
+				DefaultSeccompProfile: api.SeccompProfileRuntimeDefault,
+			},
+		},
+		{
+			test:    "default seccomp profile is not supported at the version",
+			version: "4.9.59",
+			securityProfile: &api.SecurityProfile{
+				DefaultSeccompProfile: api.SeccompProfileRuntimeDefault,
+			},
+			wantErr: "400: InvalidParameter: properties.securityProfile.defaultSeccompProfile: The requested default seccomp profile 'RuntimeDefault' is invalid for OpenShift version '4.9.59': the RuntimeDefault seccomp profile can be made the default from version 4.12.0.",
+		},
+		{
+			test:    "SELinux booleans only",
+			version: "4.9.59",
+			securityProfile: &api.SecurityProfile{
+				SELinuxBooleans: []string{"deny_ptrace"},
+			},
+		},
+	} {
+		t.Run(tt.test, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Version: tt.version,
+					},
+					NetworkProfile: api.NetworkProfile{
+						SoftwareDefinedNetwork: tt.softwareDefinedNetwork,
+					},
+					SecurityProfile: tt.securityProfile,
+				},
+			}
+
+			err := f.validateInstallVersion(ctx, oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateClusterDomainOverlap(t *testing.T) {
+	ctx := context.Background()
+
+	const mockSubID = "00000000-0000-0000-0000-000000000000"
+
+	dbOpenShiftClusters, _ := testdatabase.NewFakeOpenShiftClusters()
+	fixture := testdatabase.NewFixture().WithOpenShiftClusters(dbOpenShiftClusters)
+	for domain, domainKey := range map[string]string{
+		"example.com":         "example.com",
+		"cluster.contoso.com": "cluster.contoso.com",
+		"cluster":             "cluster.eastus.aroapp.io",
+	} {
+		key := strings.ToLower(testdatabase.GetResourcePath(mockSubID, domain))
+		fixture.AddOpenShiftClusterDocuments(&api.OpenShiftClusterDocument{
+			Key:              key,
+			ClusterDomainKey: domainKey,
+			OpenShiftCluster: &api.OpenShiftCluster{
+				ID: key,
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain: domain,
+					},
+				},
+			},
+		})
+	}
+
+	err := fixture.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().Domain().AnyTimes().Return("eastus.aroapp.io")
+
+	f := &frontend{
+		env:                 _env,
+		dbOpenShiftClusters: dbOpenShiftClusters,
+	}
+
+	for _, tt := range []struct {
+		test    string
+		domain  string
+		wantErr string
+	}{
+		{
+			test:   "managed domain",
+			domain: "example",
+		},
+		{
+			test:    "managed domain in use",
+			domain:  "cluster",
+			wantErr: "400: DuplicateDomain: properties.clusterProfile.domain: The provided domain 'cluster' overlaps with the domain 'cluster' of another cluster in the subscription.",
+		},
+		{
+			test:   "unrelated domain",
+			domain: "cluster.fabrikam.com",
+		},
+		{
+			test:   "domain sharing a suffix but not a parent",
+			domain: "notexample.com",
+		},
+		{
+			test:    "same domain",
+			domain:  "Example.com",
+			wantErr: "400: DuplicateDomain: properties.clusterProfile.domain: The provided domain 'Example.com' overlaps with the domain 'example.com' of another cluster in the subscription.",
+		},
+		{
+			test:    "subdomain",
+			domain:  "apps.example.com",
+			wantErr: "400: DuplicateDomain: properties.clusterProfile.domain: The provided domain 'apps.example.com' overlaps with the domain 'example.com' of another cluster in the subscription.",
+		},
+		{
+			test:    "parent domain",
+			domain:  "contoso.com",
+			wantErr: "400: DuplicateDomain: properties.clusterProfile.domain: The provided domain 'contoso.com' overlaps with the domain 'cluster.contoso.com' of another cluster in the subscription.",
+		},
+	} {
+		t.Run(tt.test, func(t *testing.T) {
+			domainKey, err := dns.ClusterDomainKey(_env, tt.domain)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc := &api.OpenShiftClusterDocument{
+				Key:              strings.ToLower(testdatabase.GetResourcePath(mockSubID, "resourceName")),
+				ClusterDomainKey: domainKey,
+				OpenShiftCluster: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						ClusterProfile: api.ClusterProfile{
+							Domain: tt.domain,
+						},
+					},
+				},
+			}
+
+			err = f.validateClusterDomainOverlap(ctx, doc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateWorkerProfilesScale(t *testing.T) {
+	ctx := context.Background()
+
+	subscription := &api.SubscriptionDocument{
+		ID: "00000000-0000-0000-0000-000000000000",
+		Subscription: &api.Subscription{
+			Properties: &api.SubscriptionProperties{
+				TenantID: "11111111-1111-1111-1111-111111111111",
+			},
+		},
+	}
+
+	machineSets := []api.WorkerProfile{
+		{
+			Name:   "cluster-worker-eastus1",
+			VMSize: api.VMSizeStandardD4sV3,
+			Count:  2,
+		},
+		{
+			Name:   "cluster-worker-eastus2",
+			VMSize: api.VMSizeStandardD4sV3,
+			Count:  2,
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		notEnriched bool
+		counts      []int
+		wantAdded   []api.WorkerProfile
+		quotaErr    error
+		wantScale   []api.WorkerProfileScale
+		wantErr     string
+	}{
+		{
+			name:   "counts unchanged",
+			counts: []int{2, 2},
+		},
+		{
+			name:   "scale up and down",
+			counts: []int{10, 1},
+			wantAdded: []api.WorkerProfile{
+				{
+					Name:   "cluster-worker-eastus1",
+					VMSize: api.VMSizeStandardD4sV3,
+					Count:  8,
+				},
+			},
+			wantScale: []api.WorkerProfileScale{
+				{
+					Name:            "cluster-worker-eastus1",
+					CurrentReplicas: 2,
+					TargetReplicas:  10,
+				},
+				{
+					Name:            "cluster-worker-eastus2",
+					CurrentReplicas: 2,
+					TargetReplicas:  1,
+				},
+			},
+		},
+		{
+			name:   "scale down does not need quota",
+			counts: []int{2, 0},
+			wantScale: []api.WorkerProfileScale{
+				{
+					Name:            "cluster-worker-eastus2",
+					CurrentReplicas: 2,
+					TargetReplicas:  0,
+				},
+			},
+		},
+		{
+			name:   "quota exceeded",
+			counts: []int{20, 20},
+			wantAdded: []api.WorkerProfile{
+				{
+					Name:   "cluster-worker-eastus1",
+					VMSize: api.VMSizeStandardD4sV3,
+					Count:  18,
+				},
+				{
+					Name:   "cluster-worker-eastus2",
+					VMSize: api.VMSizeStandardD4sV3,
+					Count:  18,
+				},
+			},
+			quotaErr: api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceQuotaExceeded, "", "Resource quota of cores exceeded. Maximum allowed: 100, Current in use: 16, Additional requested: 144."),
+			wantErr:  "400: ResourceQuotaExceeded: : Resource quota of cores exceeded. Maximum allowed: 100, Current in use: 16, Additional requested: 144.",
+		},
+		{
+			name:        "machine sets could not be read",
+			notEnriched: true,
+			counts:      []int{10, 2},
+			wantErr:     "400: RequestNotAllowed: properties.workerProfiles['cluster-worker-eastus1'].count: The worker machine sets of the cluster could not be read to scale them. Retry the request.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().Environment().AnyTimes()
+
+			quotaValidator := mock_frontend.NewMockQuotaValidator(controller)
+			if tt.wantAdded != nil {
+				quotaValidator.EXPECT().
+					ValidateScaleQuota(gomock.Any(), gomock.Any(), gomock.Any(), subscription.ID, subscription.Subscription.Properties.TenantID, gomock.Any(), tt.wantAdded).
+					Return(tt.quotaErr)
+			}
+
+			f := &frontend{
+				env:            _env,
+				quotaValidator: quotaValidator,
+			}
+
+			oc := &api.OpenShiftCluster{}
+			if !tt.notEnriched {
+				oc.Properties.WorkerProfilesStatus = machineSets
+			}
+			for i, count := range tt.counts {
+				wp := machineSets[i]
+				wp.Count = count
+				oc.Properties.WorkerProfiles = append(oc.Properties.WorkerProfiles, wp)
+			}
+
+			err := f.validateWorkerProfilesScale(ctx, subscription, oc, machineSets)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && !reflect.DeepEqual(oc.Properties.WorkerProfilesScaleStatus, tt.wantScale) {
+				t.Error(oc.Properties.WorkerProfilesScaleStatus)
+			}
+		})
+	}
+}
+
+func TestValidateRPVersionPin(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name         string
+		rpVersionPin string
+		pin          string
+		wantPin      string
+		wantErr      string
+	}{
+		{
+			name: "no pin",
+		},
+		{
+			name:    "pin to the running version",
+			pin:     version.GitCommit,
+			wantPin: version.GitCommit,
+		},
+		{
+			name:    "pin to another deployed version",
+			pin:     "deployedsha",
+			wantPin: "deployedsha",
+		},
+		{
+			name:    "pin to an unsupported version",
+			pin:     "othersha",
+			wantErr: "400: InvalidParameter: properties.rpVersionPin: The provided RP version 'othersha' is not supported.",
+		},
+		{
+			name:         "pin changed to an unsupported version",
+			rpVersionPin: "deployedsha",
+			pin:          "othersha",
+			wantErr:      "400: InvalidParameter: properties.rpVersionPin: The provided RP version 'othersha' is not supported.",
+		},
+		{
+			name:         "existing pin to an unsupported version is cleared",
+			rpVersionPin: "othersha",
+			pin:          "othersha",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().LiveConfig().AnyTimes().Return(testliveconfig.WithSupportedRPVersions(testliveconfig.NewTestLiveConfig(false, false, false), "deployedsha"))
+
+			f := &frontend{
+				env: _env,
+			}
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					RPVersionPin: tt.pin,
+				},
+			}
+
+			err := f.validateRPVersionPin(ctx, logrus.NewEntry(logrus.StandardLogger()), oc, tt.rpVersionPin)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if tt.wantErr == "" && oc.Properties.RPVersionPin != tt.wantPin {
+				t.Error(oc.Properties.RPVersionPin)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 var aroOperatorConditionsExpected = map[string]operatorv1.ConditionStatus{
 	arov1alpha1.InternetReachableFromMaster: operatorv1.ConditionTrue,
 	arov1alpha1.InternetReachableFromWorker: operatorv1.ConditionTrue,
+	arov1alpha1.PullSecretValid:             operatorv1.ConditionTrue,
 }
 
 func (mon *Monitor) emitAroOperatorConditions(ctx context.Context) error {
@@ -24,6 +24,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/metrics"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
 	"github.com/Azure/ARO-RP/pkg/util/steps"
 )
 
@@ -41,10 +42,15 @@ type Monitor struct {
 	mcocli     mcoclient.Interface
 	m          metrics.Emitter
 	arocli     aroclient.Interface
+	etcdcli    etcd.Client
 
 	ocpclientset  client.Client
 	hiveclientset client.Client
 
+	// apiServerHealthy is set by Monitor if the API server healthz check
+	// returned 200
+	apiServerHealthy bool
+
 	// access below only via the helper functions in cache.go
 	cache struct {
 		cos   *configv1.ClusterOperatorList
@@ -124,6 +130,7 @@ func NewMonitor(log *logrus.Entry, restConfig *rest.Config, oc *api.OpenShiftClu
 		maocli:        maocli,
 		mcocli:        mcocli,
 		arocli:        arocli,
+		etcdcli:       etcd.NewClient(log, restConfig, cli),
 		m:             m,
 		ocpclientset:  ocpclientset,
 		hiveclientset: hiveclientset,
@@ -167,6 +174,8 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		errs = append(errs, err)
 		mon.emitFailureToGatherMetric(steps.FriendlyName(mon.emitAPIServerHealthzCode), err)
 	}
+	mon.apiServerHealthy = statusCode == http.StatusOK
+
 	// If API is not returning 200, fallback to checking ping and short circuit the rest of the checks
 	if statusCode != http.StatusOK {
 		err := mon.emitAPIServerPingCode(ctx)
@@ -199,8 +208,13 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 		mon.emitHiveRegistrationStatus,
 		mon.emitOperatorFlagsAndSupportBanner,
 		mon.emitPucmState,
+		mon.emitQuarantine,
 		mon.emitCertificateExpirationStatuses,
 		mon.emitEtcdCertificateExpiry,
+		mon.emitEtcdStatus,
+		mon.emitEtcdMaintenance,
+		mon.emitSubnetAddresses,
+		mon.emitIPAddressDrift,
 		mon.emitPrometheusAlerts, // at the end for now because it's the slowest/least reliable
 	} {
 		err = f(ctx)
@@ -214,6 +228,12 @@ func (mon *Monitor) Monitor(ctx context.Context) (errs []error) {
 	return
 }
 
+// APIServerHealthy returns whether the API server healthz check made by the
+// last call to Monitor succeeded
+func (mon *Monitor) APIServerHealthy() bool {
+	return mon.apiServerHealthy
+}
+
 func (mon *Monitor) emitFailureToGatherMetric(friendlyFuncName string, err error) {
 	mon.log.Printf("%s: %s", friendlyFuncName, err)
 	mon.emitGauge("monitor.clustererrors", 1, map[string]string{"monitor": friendlyFuncName})
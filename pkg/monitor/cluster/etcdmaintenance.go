@@ -0,0 +1,45 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/etcdmaintenance"
+)
+
+// emitEtcdMaintenance emits the state of the scheduled etcd maintenance kept
+// by the operator, so that defragmentations which are late or failing can be
+// alerted on.  Nothing is emitted if no maintenance is set.
+func (mon *Monitor) emitEtcdMaintenance(ctx context.Context) error {
+	cm, err := mon.cli.CoreV1().ConfigMaps(operator.Namespace).Get(ctx, etcdmaintenance.ConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if schedule := cm.Data[etcdmaintenance.KeyDefragSchedule]; schedule != "" {
+		if last, err := time.Parse(time.RFC3339, cm.Data[etcdmaintenance.KeyLastDefragTime]); err == nil {
+			mon.emitGauge("etcd.maintenance.lastdefrag", int64(time.Since(last).Seconds()), map[string]string{
+				"schedule": schedule,
+				"result":   cm.Data[etcdmaintenance.KeyLastDefragResult],
+			})
+		}
+
+		if next, err := time.Parse(time.RFC3339, cm.Data[etcdmaintenance.KeyNextDefragTime]); err == nil {
+			mon.emitGauge("etcd.maintenance.nextdefrag", int64(time.Until(next).Seconds()), map[string]string{
+				"schedule": schedule,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/etcdmaintenance"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitEtcdMaintenance(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name  string
+		data  map[string]string
+		mocks func(*mock_metrics.MockEmitter)
+	}{
+		{
+			name: "no maintenance set",
+		},
+		{
+			name: "failed defragmentation",
+			data: map[string]string{
+				etcdmaintenance.KeyDefragSchedule:   "0 3 * * 0",
+				etcdmaintenance.KeyNextDefragTime:   time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+				etcdmaintenance.KeyLastDefragTime:   time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+				etcdmaintenance.KeyLastDefragResult: "Failed",
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("etcd.maintenance.lastdefrag", gomock.Any(), map[string]string{
+					"schedule": "0 3 * * 0",
+					"result":   "Failed",
+				})
+				m.EXPECT().EmitGauge("etcd.maintenance.nextdefrag", gomock.Any(), map[string]string{
+					"schedule": "0 3 * * 0",
+				})
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var objects []runtime.Object
+			if tt.data != nil {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: operator.Namespace,
+						Name:      etcdmaintenance.ConfigMapName,
+					},
+					Data: tt.data,
+				})
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				cli: fake.NewSimpleClientset(objects...),
+				m:   m,
+			}
+
+			err := mon.emitEtcdMaintenance(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
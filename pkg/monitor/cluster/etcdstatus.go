@@ -0,0 +1,39 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+)
+
+// emitEtcdStatus emits the database size of each etcd member, and the part of
+// it which is in use, so that fragmentation can be alerted on.  The database
+// size changes slowly, and port forwarding to each member is costly, so it
+// is only emitted hourly.
+func (mon *Monitor) emitEtcdStatus(ctx context.Context) error {
+	if !mon.hourlyRun {
+		return nil
+	}
+
+	members, err := mon.etcdcli.Members(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		status, err := mon.etcdcli.Status(ctx, member)
+		if err != nil {
+			return err
+		}
+
+		mon.emitGauge("etcd.dbsize", status.DBSize, map[string]string{
+			"member": member.Name,
+		})
+		mon.emitGauge("etcd.dbsizeinuse", status.DBSizeInUse, map[string]string{
+			"member": member.Name,
+		})
+	}
+
+	return nil
+}
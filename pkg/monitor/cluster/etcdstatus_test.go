@@ -0,0 +1,49 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+	mock_etcd "github.com/Azure/ARO-RP/pkg/util/mocks/etcd"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitEtcdStatus(t *testing.T) {
+	ctx := context.Background()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	members := []etcd.Member{
+		{Name: "etcd-master-0", IP: "10.0.0.6"},
+		{Name: "etcd-master-1", IP: "10.0.0.7"},
+	}
+
+	etcdcli := mock_etcd.NewMockClient(controller)
+	etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+	etcdcli.EXPECT().Status(gomock.Any(), members[0]).Return(&etcd.Status{DBSize: 400, DBSizeInUse: 100}, nil)
+	etcdcli.EXPECT().Status(gomock.Any(), members[1]).Return(&etcd.Status{Leader: true, DBSize: 200, DBSizeInUse: 150}, nil)
+
+	m := mock_metrics.NewMockEmitter(controller)
+	m.EXPECT().EmitGauge("etcd.dbsize", int64(400), map[string]string{"member": "etcd-master-0"})
+	m.EXPECT().EmitGauge("etcd.dbsizeinuse", int64(100), map[string]string{"member": "etcd-master-0"})
+	m.EXPECT().EmitGauge("etcd.dbsize", int64(200), map[string]string{"member": "etcd-master-1"})
+	m.EXPECT().EmitGauge("etcd.dbsizeinuse", int64(150), map[string]string{"member": "etcd-master-1"})
+
+	mon := &Monitor{
+		hourlyRun: true,
+		etcdcli:   etcdcli,
+		m:         m,
+	}
+
+	err := mon.emitEtcdStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,108 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/ipaddresses"
+)
+
+// emitIPAddressDrift compares the IP addresses in the cluster document with
+// the live ones, as polled by the operator, and emits the addresses which
+// have drifted.  Customers allowlist these addresses, so stale ones must be
+// corrected with the SyncClusterProperties maintenance task.
+func (mon *Monitor) emitIPAddressDrift(ctx context.Context) error {
+	cm, err := mon.cli.CoreV1().ConfigMaps(operator.Namespace).Get(ctx, ipaddresses.ConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var live ipaddresses.IPAddresses
+	err = json.Unmarshal([]byte(cm.Data[ipaddresses.KeyAddresses]), &live)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range ipAddressDrift(mon.oc, &live) {
+		mon.log.Warnf("%s is '%s' in the cluster document but '%s' on the cluster", d.field, d.current, d.live)
+		mon.emitGauge("cluster.ipaddresses.drift", 1, map[string]string{
+			"field": d.field,
+		})
+	}
+
+	return nil
+}
+
+type ipAddressDrifted struct {
+	field   string
+	current string
+	live    string
+}
+
+// ipAddressDrift returns the IP addresses of the cluster document which
+// differ from the live ones.  Live addresses which are not known are not
+// compared.
+func ipAddressDrift(oc *api.OpenShiftCluster, live *ipaddresses.IPAddresses) (drift []ipAddressDrifted) {
+	compare := func(field, current, live string) {
+		if live != "" && current != live {
+			drift = append(drift, ipAddressDrifted{field: field, current: current, live: live})
+		}
+	}
+
+	compare("apiserverProfile.intIp", oc.Properties.APIServerProfile.IntIP, live.APIServerPrivateIP)
+
+	if oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		compare("apiserverProfile.ip", oc.Properties.APIServerProfile.IP, live.APIServerPublicIP)
+	} else {
+		compare("apiserverProfile.ip", oc.Properties.APIServerProfile.IP, live.APIServerPrivateIP)
+	}
+
+	// the default ingress is served by the customer load balancer if there is
+	// one, which the operator does not poll
+	if oc.Properties.NetworkProfile.CustomerLoadBalancerProfile == nil {
+		for _, p := range oc.Properties.IngressProfiles {
+			if p.Name == "default" {
+				compare("ingressProfiles['default'].ip", p.IP, live.IngressIP)
+			}
+		}
+	}
+
+	if lbp := oc.Properties.NetworkProfile.LoadBalancerProfile; lbp != nil &&
+		oc.Properties.NetworkProfile.OutboundType == api.OutboundTypeLoadbalancer &&
+		oc.Properties.ArchitectureVersion == api.ArchitectureVersionV2 &&
+		live.OutboundIPs != nil {
+		current := make([]string, 0, len(lbp.EffectiveOutboundIPs))
+		for _, ip := range lbp.EffectiveOutboundIPs {
+			current = append(current, ip.ID)
+		}
+
+		compare("networkProfile.loadBalancerProfile.effectiveOutboundIps", joinIDs(current), joinIDs(live.OutboundIPs))
+	}
+
+	return drift
+}
+
+// joinIDs returns the sorted, lower cased resource IDs joined by commas, so
+// that lists of IDs can be compared regardless of order and case
+func joinIDs(ids []string) string {
+	lower := make([]string, 0, len(ids))
+	for _, id := range ids {
+		lower = append(lower, strings.ToLower(id))
+	}
+	sort.Strings(lower)
+
+	return strings.Join(lower, ",")
+}
@@ -0,0 +1,163 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/ipaddresses"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEmitIPAddressDrift(t *testing.T) {
+	ctx := context.Background()
+
+	pipID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-infra/providers/Microsoft.Network/publicIPAddresses/"
+
+	cluster := func() *api.OpenShiftCluster {
+		return &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				ArchitectureVersion: api.ArchitectureVersionV2,
+				APIServerProfile: api.APIServerProfile{
+					Visibility: api.VisibilityPublic,
+					IP:         "1.2.3.4",
+					IntIP:      "10.0.0.4",
+				},
+				IngressProfiles: []api.IngressProfile{
+					{
+						Name: "default",
+						IP:   "5.6.7.8",
+					},
+				},
+				NetworkProfile: api.NetworkProfile{
+					OutboundType: api.OutboundTypeLoadbalancer,
+					LoadBalancerProfile: &api.LoadBalancerProfile{
+						EffectiveOutboundIPs: []api.EffectiveOutboundIP{
+							{ID: pipID + "infra-pip-v4"},
+							{ID: pipID + "Outbound"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		oc        func() *api.OpenShiftCluster
+		addresses string
+		mocks     func(*mock_metrics.MockEmitter)
+		wantErr   string
+	}{
+		{
+			name: "not polled yet",
+			oc:   cluster,
+		},
+		{
+			name:      "no drift",
+			oc:        cluster,
+			addresses: `{"apiServerPrivateIp":"10.0.0.4","apiServerPublicIp":"1.2.3.4","ingressIp":"5.6.7.8","outboundIps":["` + pipID + `outbound","` + pipID + `infra-pip-v4"]}`,
+		},
+		{
+			name:      "unknown addresses are not compared",
+			oc:        cluster,
+			addresses: `{"apiServerPrivateIp":"10.0.0.4"}`,
+		},
+		{
+			name:      "drift",
+			oc:        cluster,
+			addresses: `{"apiServerPrivateIp":"10.0.0.5","apiServerPublicIp":"1.2.3.5","ingressIp":"5.6.7.9","outboundIps":["` + pipID + `infra-pip-v4"]}`,
+			mocks: func(m *mock_metrics.MockEmitter) {
+				for _, field := range []string{
+					"apiserverProfile.intIp",
+					"apiserverProfile.ip",
+					"ingressProfiles['default'].ip",
+					"networkProfile.loadBalancerProfile.effectiveOutboundIps",
+				} {
+					m.EXPECT().EmitGauge("cluster.ipaddresses.drift", int64(1), map[string]string{
+						"field": field,
+					})
+				}
+			},
+		},
+		{
+			name: "private cluster with user defined routing",
+			oc: func() *api.OpenShiftCluster {
+				oc := cluster()
+				oc.Properties.APIServerProfile.Visibility = api.VisibilityPrivate
+				oc.Properties.APIServerProfile.IP = "10.0.0.4"
+				oc.Properties.NetworkProfile.OutboundType = api.OutboundTypeUserDefinedRouting
+				return oc
+			},
+			addresses: `{"apiServerPrivateIp":"10.0.0.5","ingressIp":"5.6.7.8"}`,
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("cluster.ipaddresses.drift", int64(1), map[string]string{
+					"field": "apiserverProfile.intIp",
+				})
+				m.EXPECT().EmitGauge("cluster.ipaddresses.drift", int64(1), map[string]string{
+					"field": "apiserverProfile.ip",
+				})
+			},
+		},
+		{
+			name: "customer load balancer",
+			oc: func() *api.OpenShiftCluster {
+				oc := cluster()
+				oc.Properties.NetworkProfile.CustomerLoadBalancerProfile = &api.CustomerLoadBalancerProfile{}
+				return oc
+			},
+			addresses: `{"apiServerPrivateIp":"10.0.0.4","apiServerPublicIp":"1.2.3.4","ingressIp":"10.0.2.4"}`,
+		},
+		{
+			name:      "invalid addresses",
+			oc:        cluster,
+			addresses: "{",
+			wantErr:   "unexpected end of JSON input",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var objects []runtime.Object
+			if tt.addresses != "" {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: operator.Namespace,
+						Name:      ipaddresses.ConfigMapName,
+					},
+					Data: map[string]string{
+						ipaddresses.KeyAddresses: tt.addresses,
+					},
+				})
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				log: logrus.NewEntry(logrus.StandardLogger()),
+				oc:  tt.oc(),
+				cli: fake.NewSimpleClientset(objects...),
+				m:   m,
+			}
+
+			err := mon.emitIPAddressDrift(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
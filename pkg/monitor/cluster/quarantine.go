@@ -0,0 +1,21 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+)
+
+// emitQuarantine emits a metric for clusters on which automated operations are
+// paused, so that they are visible on dashboards.  The reason is free text and
+// is left out of the dimensions; it is returned by the admin API.
+func (mon *Monitor) emitQuarantine(ctx context.Context) error {
+	if mon.oc.Properties.Quarantine == nil {
+		return nil
+	}
+
+	mon.emitGauge("cluster.quarantined", 1, nil)
+
+	return nil
+}
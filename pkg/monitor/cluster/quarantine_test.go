@@ -0,0 +1,61 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+)
+
+func TestEmitQuarantine(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		quarantine *api.Quarantine
+		wantEmit   bool
+	}{
+		{
+			name: "not quarantined",
+		},
+		{
+			name: "quarantined",
+			quarantine: &api.Quarantine{
+				Reason:        "incident",
+				QuarantinedAt: time.Date(2023, time.July, 1, 12, 30, 0, 0, time.UTC),
+			},
+			wantEmit: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			m := mock_metrics.NewMockEmitter(controller)
+			mon := &Monitor{
+				m: m,
+				oc: &api.OpenShiftCluster{
+					Properties: api.OpenShiftClusterProperties{
+						Quarantine: tt.quarantine,
+					},
+				},
+			}
+
+			if tt.wantEmit {
+				m.EXPECT().EmitGauge("cluster.quarantined", int64(1), map[string]string{})
+			}
+
+			err := mon.emitQuarantine(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
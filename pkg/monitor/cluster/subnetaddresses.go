@@ -0,0 +1,46 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/subnetaddresses"
+)
+
+// emitSubnetAddresses emits the free addresses of each cluster subnet, as
+// polled by the operator, so that subnets can be grown or clusters stopped
+// from scaling before they run out of addresses
+func (mon *Monitor) emitSubnetAddresses(ctx context.Context) error {
+	cm, err := mon.cli.CoreV1().ConfigMaps(operator.Namespace).Get(ctx, subnetaddresses.ConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var subnets []subnetaddresses.SubnetAddresses
+	err = json.Unmarshal([]byte(cm.Data[subnetaddresses.KeySubnets]), &subnets)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range subnets {
+		mon.emitGauge("subnet.addresses.free", int64(s.Free), map[string]string{
+			"subnetId": s.ResourceID,
+		})
+		mon.emitGauge("subnet.addresses.usedpercent", int64(s.UsedPercent()), map[string]string{
+			"subnetId":  s.ResourceID,
+			"threshold": cm.Data[subnetaddresses.KeyThreshold],
+		})
+	}
+
+	return nil
+}
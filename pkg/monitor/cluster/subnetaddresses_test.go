@@ -0,0 +1,89 @@
+package cluster
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/subnetaddresses"
+	mock_metrics "github.com/Azure/ARO-RP/pkg/util/mocks/metrics"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEmitSubnetAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	subnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker"
+
+	for _, tt := range []struct {
+		name    string
+		data    map[string]string
+		mocks   func(*mock_metrics.MockEmitter)
+		wantErr string
+	}{
+		{
+			name: "not polled yet",
+		},
+		{
+			name: "subnet usage",
+			data: map[string]string{
+				subnetaddresses.KeyThreshold: "80",
+				subnetaddresses.KeySubnets:   `[{"resourceId":"` + subnetID + `","total":507,"free":20}]`,
+			},
+			mocks: func(m *mock_metrics.MockEmitter) {
+				m.EXPECT().EmitGauge("subnet.addresses.free", int64(20), map[string]string{
+					"subnetId": subnetID,
+				})
+				m.EXPECT().EmitGauge("subnet.addresses.usedpercent", int64(96), map[string]string{
+					"subnetId":  subnetID,
+					"threshold": "80",
+				})
+			},
+		},
+		{
+			name: "invalid usage",
+			data: map[string]string{
+				subnetaddresses.KeySubnets: "{",
+			},
+			wantErr: "unexpected end of JSON input",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var objects []runtime.Object
+			if tt.data != nil {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: operator.Namespace,
+						Name:      subnetaddresses.ConfigMapName,
+					},
+					Data: tt.data,
+				})
+			}
+
+			m := mock_metrics.NewMockEmitter(controller)
+			if tt.mocks != nil {
+				tt.mocks(m)
+			}
+
+			mon := &Monitor{
+				cli: fake.NewSimpleClientset(objects...),
+				m:   m,
+			}
+
+			err := mon.emitSubnetAddresses(ctx)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -30,6 +30,7 @@ type monitor struct {
 	dbMonitors          database.Monitors
 	dbOpenShiftClusters database.OpenShiftClusters
 	dbSubscriptions     database.Subscriptions
+	dbClusterHealth     database.ClusterHealth
 
 	m        metrics.Emitter
 	clusterm metrics.Emitter
@@ -54,7 +55,7 @@ type Runnable interface {
 	Run(context.Context) error
 }
 
-func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager) Runnable {
+func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Monitors, dbOpenShiftClusters database.OpenShiftClusters, dbSubscriptions database.Subscriptions, dbClusterHealth database.ClusterHealth, m, clusterm metrics.Emitter, liveConfig liveconfig.Manager) Runnable {
 	return &monitor{
 		baseLog: log,
 		dialer:  dialer,
@@ -62,6 +63,7 @@ func NewMonitor(log *logrus.Entry, dialer proxy.Dialer, dbMonitors database.Moni
 		dbMonitors:          dbMonitors,
 		dbOpenShiftClusters: dbOpenShiftClusters,
 		dbSubscriptions:     dbSubscriptions,
+		dbClusterHealth:     dbClusterHealth,
 
 		m:        m,
 		clusterm: clusterm,
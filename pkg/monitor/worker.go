@@ -195,6 +195,8 @@ func (mon *monitor) worker(stop <-chan struct{}, delay time.Duration, id string)
 
 	h := time.Now().Hour()
 
+	var health *healthRecord
+
 out:
 	for {
 		mon.mu.RLock()
@@ -212,7 +214,7 @@ out:
 		// cached metrics in the remaining minutes
 
 		if sub != nil && sub.Subscription != nil && sub.Subscription.State != api.SubscriptionStateSuspended && sub.Subscription.State != api.SubscriptionStateWarned {
-			mon.workOne(context.Background(), log, v.doc, newh != h)
+			health = mon.workOne(context.Background(), log, v.doc, newh != h, health)
 		}
 
 		select {
@@ -227,15 +229,16 @@ out:
 	log.Debug("stopping monitoring")
 }
 
-// workOne checks the API server health of a cluster
-func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, hourlyRun bool) {
+// workOne checks the API server health of a cluster.  It returns the last
+// health recorded for the cluster, given the one recorded before.
+func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, hourlyRun bool, health *healthRecord) *healthRecord {
 	ctx, cancel := context.WithTimeout(ctx, 50*time.Second)
 	defer cancel()
 
 	restConfig, err := restconfig.RestConfig(mon.dialer, doc.OpenShiftCluster)
 	if err != nil {
 		log.Error(err)
-		return
+		return health
 	}
 
 	// once sharding is implemented, we will have the shard set on the api.OpenShiftClusterDocument
@@ -252,8 +255,36 @@ func (mon *monitor) workOne(ctx context.Context, log *logrus.Entry, doc *api.Ope
 		mon.m.EmitGauge("monitor.cluster.failedworker", 1, map[string]string{
 			"resourceId": doc.OpenShiftCluster.ID,
 		})
-		return
+		return health
 	}
 
 	c.Monitor(ctx)
+
+	return mon.recordHealth(ctx, log, doc, health, time.Now(), c.APIServerHealthy())
+}
+
+// healthRecord is the last health of a cluster recorded by its worker
+type healthRecord struct {
+	healthy  bool
+	recorded time.Time
+}
+
+// recordHealth records the health of the cluster in the database if there is
+// no previous record, if the health changed since, or if the previous record
+// is due to be refreshed.  It returns the last record.
+func (mon *monitor) recordHealth(ctx context.Context, log *logrus.Entry, doc *api.OpenShiftClusterDocument, last *healthRecord, now time.Time, healthy bool) *healthRecord {
+	if last != nil && last.healthy == healthy && now.Sub(last.recorded) < api.ClusterHealthRefreshInterval {
+		return last
+	}
+
+	_, err := mon.dbClusterHealth.RecordHealth(ctx, doc.ID, doc.Key, now, healthy)
+	if err != nil {
+		log.Error(err)
+		return last
+	}
+
+	return &healthRecord{
+		healthy:  healthy,
+		recorded: now,
+	}
 }
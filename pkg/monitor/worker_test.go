@@ -0,0 +1,96 @@
+package monitor
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	testdatabase "github.com/Azure/ARO-RP/test/database"
+)
+
+func TestRecordHealth(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2023, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	doc := &api.OpenShiftClusterDocument{
+		ID:  "id",
+		Key: "key",
+	}
+
+	for _, tt := range []struct {
+		name        string
+		last        *healthRecord
+		healthy     bool
+		dbErr       error
+		want        *healthRecord
+		wantRecords int
+	}{
+		{
+			name:        "first check is recorded",
+			healthy:     true,
+			want:        &healthRecord{healthy: true, recorded: now},
+			wantRecords: 1,
+		},
+		{
+			name:    "unchanged health is not recorded",
+			last:    &healthRecord{healthy: true, recorded: now.Add(-time.Hour)},
+			healthy: true,
+			want:    &healthRecord{healthy: true, recorded: now.Add(-time.Hour)},
+		},
+		{
+			name:        "changed health is recorded",
+			last:        &healthRecord{healthy: true, recorded: now.Add(-time.Hour)},
+			healthy:     false,
+			want:        &healthRecord{healthy: false, recorded: now},
+			wantRecords: 1,
+		},
+		{
+			name:        "unchanged health is refreshed",
+			last:        &healthRecord{healthy: true, recorded: now.Add(-api.ClusterHealthRefreshInterval)},
+			healthy:     true,
+			want:        &healthRecord{healthy: true, recorded: now},
+			wantRecords: 1,
+		},
+		{
+			name:    "failure to record keeps the last record",
+			last:    &healthRecord{healthy: true, recorded: now.Add(-time.Hour)},
+			healthy: false,
+			dbErr:   errors.New("cosmos is down"),
+			want:    &healthRecord{healthy: true, recorded: now.Add(-time.Hour)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClusterHealth, client := testdatabase.NewFakeClusterHealth()
+			if tt.dbErr != nil {
+				client.SetError(tt.dbErr)
+			}
+
+			mon := &monitor{
+				dbClusterHealth: dbClusterHealth,
+			}
+
+			got := mon.recordHealth(ctx, logrus.NewEntry(logrus.StandardLogger()), doc, tt.last, now, tt.healthy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, wanted %#v", got, tt.want)
+			}
+
+			client.SetError(nil)
+			docs, err := client.ListAll(ctx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(docs.ClusterHealthDocuments) != tt.wantRecords {
+				t.Errorf("got %d records, wanted %d", len(docs.ClusterHealthDocuments), tt.wantRecords)
+			}
+		})
+	}
+}
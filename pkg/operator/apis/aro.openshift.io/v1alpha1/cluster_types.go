@@ -22,6 +22,7 @@ const (
 	InternetReachableFromWorker = "InternetReachableFromWorker"
 	MachineValid                = "MachineValid"
 	ServicePrincipalValid       = "ServicePrincipalValid"
+	PullSecretValid             = "PullSecretValid"
 
 	ManagedUpgradeOperatorStatus = "ManagedUpgradeOperatorStatus"
 
@@ -29,6 +30,8 @@ const (
 	DefaultIngressCertificate = "DefaultIngressCertificate"
 	DefaultClusterDNS         = "DefaultClusterDNS"
 	GuardRailsStatus          = "GuardRailsStatus"
+
+	NSGRulesRepaired = "NSGRulesRepaired"
 )
 
 // AllConditionTypes is a operator conditions currently in use, any condition not in this list is not
@@ -39,10 +42,12 @@ func AllConditionTypes() []string {
 		InternetReachableFromWorker,
 		MachineValid,
 		ServicePrincipalValid,
+		PullSecretValid,
 		ManagedUpgradeOperatorStatus,
 		DefaultIngressCertificate,
 		DefaultClusterDNS,
 		GuardRailsStatus,
+		NSGRulesRepaired,
 	}
 }
 
@@ -110,16 +115,223 @@ type ClusterSpec struct {
 	InternetChecker          InternetCheckerSpec `json:"internetChecker,omitempty"`
 	VnetID                   string              `json:"vnetId,omitempty"`
 	APIIntIP                 string              `json:"apiIntIP,omitempty"`
+	APIServerVisibility      string              `json:"apiServerVisibility,omitempty"`
 	IngressIP                string              `json:"ingressIP,omitempty"`
 	GatewayDomains           []string            `json:"gatewayDomains,omitempty"`
 	GatewayPrivateEndpointIP string              `json:"gatewayPrivateEndpointIP,omitempty"`
 	Banner                   Banner              `json:"banner,omitempty"`
 	ServiceSubnets           []string            `json:"serviceSubnets,omitempty"`
+	// TimeZone is the tz database name of the time zone of the cluster nodes
+	TimeZone string `json:"timeZone,omitempty"`
+	// ProjectTemplate, if set, is the default resource quota and limit range
+	// of projects requested by users
+	ProjectTemplate *ProjectTemplateSpec `json:"projectTemplate,omitempty"`
+	// IdentityProvider, if set, is the OpenID Connect identity provider of
+	// the cluster OAuth server.  Its client secret is in the operator secret.
+	IdentityProvider *IdentityProviderSpec `json:"identityProvider,omitempty"`
+	// RegistryMirrors are the pull-through caches through which images of
+	// upstream registries are pulled
+	RegistryMirrors []RegistryMirrorSpec `json:"registryMirrors,omitempty"`
+	// MaxPods, if set, is the maximum number of pods per node
+	MaxPods int `json:"maxPods,omitempty"`
+	// DefaultStorageClass, if set, is the only default storage class of the
+	// cluster
+	DefaultStorageClass *DefaultStorageClassSpec `json:"defaultStorageClass,omitempty"`
+	// AzureFileCSI, if set, is the Azure Files storage class for
+	// ReadWriteMany volumes
+	AzureFileCSI *AzureFileCSISpec `json:"azureFileCSI,omitempty"`
+
+	// Monitoring, if set, is the retention and storage of the platform
+	// Prometheus
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Upgrade, if set, is the order in which and the pace at which the worker
+	// nodes are updated during upgrades
+	Upgrade *UpgradeSpec `json:"upgrade,omitempty"`
+
+	// NodeEviction, if set, are the kubelet eviction thresholds of the nodes
+	NodeEviction *NodeEvictionSpec `json:"nodeEviction,omitempty"`
+
+	// SecurityProfile, if set, is the default seccomp profile and the SELinux
+	// booleans of the nodes
+	SecurityProfile *SecurityProfileSpec `json:"securityProfile,omitempty"`
+
+	// ResourceTags, if set, are the tags which are kept on the Azure resources
+	// of the cluster
+	ResourceTags *ResourceTagsSpec `json:"resourceTags,omitempty"`
+
+	// LogForwarding, if set, is the off-cluster destination to which the
+	// cluster logs are forwarded.  Its shared key is in the operator secret.
+	LogForwarding *LogForwardingSpec `json:"logForwarding,omitempty"`
+
+	// AdmissionWebhooks are the ValidatingWebhookConfigurations and
+	// MutatingWebhookConfigurations registered on the cluster, in JSON or YAML
+	AdmissionWebhooks []string `json:"admissionWebhooks,omitempty"`
+
+	// DefaultNodeSelector, if set, is the comma separated list of key=value
+	// node labels to which the pods of projects without a node selector of
+	// their own are constrained
+	DefaultNodeSelector string `json:"defaultNodeSelector,omitempty"`
+
+	// EgressFirewall, if set, is the egress allowed from the customer
+	// namespaces; any other egress from them is denied
+	EgressFirewall *EgressFirewallSpec `json:"egressFirewall,omitempty"`
+
+	// CustomerLoadBalancerID, if set, is the resource ID of the customer load
+	// balancer whose backend pools of the cluster are kept in sync with the
+	// nodes
+	CustomerLoadBalancerID string `json:"customerLoadBalancerId,omitempty"`
 
 	// OperatorFlags defines feature gates for the ARO Operator
 	OperatorFlags OperatorFlags `json:"operatorflags,omitempty"`
 }
 
+// ProjectTemplateSpec defines the default resource quota and limit range of
+// projects requested by users.  Values are Kubernetes resource quantities.
+type ProjectTemplateSpec struct {
+	// ResourceQuota maps quota resources to their hard limits
+	ResourceQuota map[string]string `json:"resourceQuota,omitempty"`
+	// DefaultLimits maps container resources to their default limits
+	DefaultLimits map[string]string `json:"defaultLimits,omitempty"`
+	// DefaultRequests maps container resources to their default requests
+	DefaultRequests map[string]string `json:"defaultRequests,omitempty"`
+}
+
+// IdentityProviderSpec defines an OpenID Connect identity provider
+type IdentityProviderSpec struct {
+	// Name is the name of the identity provider
+	Name string `json:"name,omitempty"`
+	// Issuer is the issuer URL of the identity provider
+	Issuer string `json:"issuer,omitempty"`
+	// ClientID is the client ID of the application registered with the
+	// identity provider
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// RegistryMirrorSpec defines a pull-through cache from which images of an
+// upstream registry are pulled
+type RegistryMirrorSpec struct {
+	// Source is the upstream registry, e.g. docker.io
+	Source string `json:"source,omitempty"`
+	// Mirror is the pull-through cache, e.g. myregistry.azurecr.io/docker-hub
+	Mirror string `json:"mirror,omitempty"`
+}
+
+// DefaultStorageClassSpec defines the default storage class of the cluster
+type DefaultStorageClassSpec struct {
+	// Name is the name of the storage class
+	Name string `json:"name,omitempty"`
+	// Parameters, if set, are the Azure Disk CSI driver parameters of the
+	// storage class, which is created by the operator.  Otherwise the storage
+	// class must already exist.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AzureFileCSISpec defines a storage class provisioning Azure Files shares
+// with the Azure File CSI driver
+type AzureFileCSISpec struct {
+	// StorageClassName is the name of the storage class
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// SKUName, if set, is the SKU of the storage accounts of the file shares.
+	// Otherwise the driver defaults to Standard_LRS.
+	SKUName string `json:"skuName,omitempty"`
+}
+
+// MonitoringSpec defines the retention and persistent storage of the platform
+// Prometheus
+type MonitoringSpec struct {
+	// Retention, if set, is the time for which metrics are kept
+	Retention string `json:"retention,omitempty"`
+	// StorageSize, if set, is the size of the persistent volume of each
+	// Prometheus replica.  Otherwise Prometheus uses ephemeral storage.
+	StorageSize string `json:"storageSize,omitempty"`
+	// StorageClassName, if set, is the storage class of the persistent
+	// volumes.  Otherwise the default storage class is used.
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// UpgradeSpec defines the order in which and the pace at which the worker
+// nodes are updated during upgrades
+type UpgradeSpec struct {
+	// LastNodeName is the name of the worker node which is updated after all
+	// the other worker nodes
+	LastNodeName string `json:"lastNodeName,omitempty"`
+	// MaxUnavailable, if set, is the number, e.g. 2, or the percentage, e.g.
+	// 10%, of worker nodes which are updated at the same time
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+	// PausedUntil, if set, pauses the rollout of updates to the worker nodes
+	// until the given time
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty"`
+}
+
+// NodeEvictionSpec defines the kubelet eviction thresholds of the nodes
+type NodeEvictionSpec struct {
+	// Hard, if set, are the hard eviction thresholds.  Signals which are not
+	// set keep their platform thresholds.
+	Hard *EvictionThresholdsSpec `json:"hard,omitempty"`
+	// Soft, if set, are the soft eviction thresholds
+	Soft *EvictionThresholdsSpec `json:"soft,omitempty"`
+	// SoftGracePeriod is how long a soft eviction threshold must be met
+	// before pods are evicted
+	SoftGracePeriod string `json:"softGracePeriod,omitempty"`
+}
+
+// EvictionThresholdsSpec defines the eviction thresholds of the kubelet
+// eviction signals
+type EvictionThresholdsSpec struct {
+	MemoryAvailable  string `json:"memoryAvailable,omitempty"`
+	NodeFSAvailable  string `json:"nodefsAvailable,omitempty"`
+	ImageFSAvailable string `json:"imagefsAvailable,omitempty"`
+}
+
+// SecurityProfileSpec defines the seccomp and SELinux defaults of the nodes
+type SecurityProfileSpec struct {
+	// DefaultSeccompProfile is the seccomp profile of containers which do not
+	// set one: Unconfined or RuntimeDefault
+	DefaultSeccompProfile string `json:"defaultSeccompProfile,omitempty"`
+	// SELinuxBooleans are the SELinux booleans to turn on
+	SELinuxBooleans []string `json:"seLinuxBooleans,omitempty"`
+}
+
+// ResourceTagsSpec defines the tags which are kept on the Azure resources of
+// the cluster
+type ResourceTagsSpec struct {
+	// Tags are the tags, keyed by name
+	Tags map[string]string `json:"tags,omitempty"`
+	// ClusterResourcesOnly, if set, limits the tags to the resources of the
+	// cluster, as the cluster resource group was provided by the customer
+	ClusterResourcesOnly bool `json:"clusterResourcesOnly,omitempty"`
+}
+
+// LogForwardingSpec defines the destination of the forwarded cluster logs
+type LogForwardingSpec struct {
+	// Type is the type of the destination, Syslog or AzureMonitor
+	Type string `json:"type,omitempty"`
+	// URL is the URL of the syslog server
+	URL string `json:"url,omitempty"`
+	// WorkspaceID is the ID of the Log Analytics workspace
+	WorkspaceID string `json:"workspaceId,omitempty"`
+	// LogTypes are the types of logs which are forwarded; all of them if
+	// empty
+	LogTypes []string `json:"logTypes,omitempty"`
+}
+
+// EgressFirewallSpec defines the destinations outside the cluster to which
+// the pods of the customer namespaces may connect
+type EgressFirewallSpec struct {
+	// AllowedDestinations are the destinations which are allowed
+	AllowedDestinations []EgressDestination `json:"allowedDestinations,omitempty"`
+}
+
+// EgressDestination defines a destination outside the cluster, either an IP
+// range or a DNS name
+type EgressDestination struct {
+	// CIDR is the IP range of the destination
+	CIDR string `json:"cidr,omitempty"`
+	// DNSName is the DNS name of the destination
+	DNSName string `json:"dnsName,omitempty"`
+}
+
 // Banner defines if a Banner should be shown to the customer
 type Banner struct {
 	Content BannerContent `json:"content,omitempty"`
@@ -0,0 +1,222 @@
+package admissionwebhooks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "AdmissionWebhooks"
+
+	controllerEnabled = "aro.admissionwebhooks.enabled"
+
+	managedByLabel = "aro.openshift.io/admissionwebhook"
+)
+
+// Reconciler registers the admission webhook configurations of the customer
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the labelled webhook configurations,
+// and if any of them changes, reconciles the webhook configurations
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.reconcileAdmissionWebhooks(ctx, instance.Spec.AdmissionWebhooks)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) reconcileAdmissionWebhooks(ctx context.Context, configurations []string) error {
+	validating := map[string]struct{}{}
+	mutating := map[string]struct{}{}
+
+	for _, configuration := range configurations {
+		o, err := validate.DecodeAdmissionWebhookConfiguration(configuration)
+		if err != nil {
+			return fmt.Errorf("admission webhook configuration is invalid: %w", err)
+		}
+
+		for _, name := range validate.AdmissionWebhooksBlockingCoreNamespaces(o) {
+			r.Log.Warnf("admission webhook %s has failurePolicy Fail and applies to the core namespaces", name)
+		}
+
+		switch o := o.(type) {
+		case *admissionregistrationv1.ValidatingWebhookConfiguration:
+			validating[o.Name] = struct{}{}
+			err = r.ensureValidatingWebhookConfiguration(ctx, o)
+		case *admissionregistrationv1.MutatingWebhookConfiguration:
+			mutating[o.Name] = struct{}{}
+			err = r.ensureMutatingWebhookConfiguration(ctx, o)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.deleteUnwanted(ctx, validating, mutating)
+}
+
+// ensureValidatingWebhookConfiguration creates want, or updates the webhooks
+// of the existing configuration.  The API server defaults unset fields of
+// the webhooks, so the update may be a noop.
+func (r *Reconciler) ensureValidatingWebhookConfiguration(ctx context.Context, want *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	want.Labels = map[string]string{managedByLabel: "true"}
+
+	have := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: want.Name}, have)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, want)
+	}
+	if err != nil {
+		return err
+	}
+
+	if have.Labels[managedByLabel] != "true" {
+		return fmt.Errorf("validating webhook configuration %s already exists and is not managed by the ARO operator", want.Name)
+	}
+
+	if equality.Semantic.DeepEqual(have.Webhooks, want.Webhooks) {
+		return nil
+	}
+
+	have.Webhooks = want.Webhooks
+	return r.Client.Update(ctx, have)
+}
+
+// ensureMutatingWebhookConfiguration is ensureValidatingWebhookConfiguration
+// for MutatingWebhookConfigurations
+func (r *Reconciler) ensureMutatingWebhookConfiguration(ctx context.Context, want *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	want.Labels = map[string]string{managedByLabel: "true"}
+
+	have := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: want.Name}, have)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, want)
+	}
+	if err != nil {
+		return err
+	}
+
+	if have.Labels[managedByLabel] != "true" {
+		return fmt.Errorf("mutating webhook configuration %s already exists and is not managed by the ARO operator", want.Name)
+	}
+
+	if equality.Semantic.DeepEqual(have.Webhooks, want.Webhooks) {
+		return nil
+	}
+
+	have.Webhooks = want.Webhooks
+	return r.Client.Update(ctx, have)
+}
+
+// deleteUnwanted deletes the labelled webhook configurations which aren't in
+// validating or mutating
+func (r *Reconciler) deleteUnwanted(ctx context.Context, validating, mutating map[string]struct{}) error {
+	vwcs := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	err := r.Client.List(ctx, vwcs, client.MatchingLabels{managedByLabel: "true"})
+	if err != nil {
+		return err
+	}
+
+	for i := range vwcs.Items {
+		if _, ok := validating[vwcs.Items[i].Name]; ok {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, &vwcs.Items[i])
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	mwcs := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	err = r.Client.List(ctx, mwcs, client.MatchingLabels{managedByLabel: "true"})
+	if err != nil {
+		return err
+	}
+
+	for i := range mwcs.Items {
+		if _, ok := mutating[mwcs.Items[i].Name]; ok {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, &mwcs.Items[i])
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	managedPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetLabels()[managedByLabel] == "true"
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &admissionregistrationv1.ValidatingWebhookConfiguration{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(managedPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &admissionregistrationv1.MutatingWebhookConfiguration{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(managedPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
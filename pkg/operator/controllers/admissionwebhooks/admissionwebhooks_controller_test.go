@@ -0,0 +1,216 @@
+package admissionwebhooks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+const (
+	policyConfiguration   = `{"apiVersion": "admissionregistration.k8s.io/v1", "kind": "ValidatingWebhookConfiguration", "metadata": {"name": "policy"}, "webhooks": [{"name": "policy.example.com", "clientConfig": {"url": "https://policy.example.com/validate"}, "rules": [{"operations": ["CREATE"], "apiGroups": ["apps"], "apiVersions": ["v1"], "resources": ["deployments"]}], "failurePolicy": "Ignore", "sideEffects": "None", "admissionReviewVersions": ["v1"]}]}`
+	defaultsConfiguration = `{"apiVersion": "admissionregistration.k8s.io/v1", "kind": "MutatingWebhookConfiguration", "metadata": {"name": "defaults"}, "webhooks": [{"name": "defaults.example.com", "clientConfig": {"url": "https://defaults.example.com/mutate"}, "rules": [{"operations": ["CREATE"], "apiGroups": [""], "apiVersions": ["v1"], "resources": ["pods"]}], "failurePolicy": "Ignore", "sideEffects": "None", "admissionReviewVersions": ["v1"]}]}`
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	cluster := func(enabled string, configurations ...string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				AdmissionWebhooks: configurations,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	validatingWebhookConfiguration := func(name string, labels map[string]string, url string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name: name + ".example.com",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						URL: &url,
+					},
+				},
+			},
+		}
+	}
+
+	managed := map[string]string{managedByLabel: "true"}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+		wantValidating []string
+		wantMutating   []string
+		wantPolicyURL  string
+	}{
+		{
+			name: "disabled",
+			objects: []client.Object{
+				cluster("false", policyConfiguration),
+			},
+		},
+		{
+			name: "no webhooks",
+			objects: []client.Object{
+				cluster("true"),
+				validatingWebhookConfiguration("customer", nil, "https://customer.example.com"),
+			},
+			wantConditions: defaultConditions,
+			wantValidating: []string{"customer"},
+		},
+		{
+			name: "webhooks are created",
+			objects: []client.Object{
+				cluster("true", policyConfiguration, defaultsConfiguration),
+			},
+			wantConditions: defaultConditions,
+			wantValidating: []string{"policy"},
+			wantMutating:   []string{"defaults"},
+			wantPolicyURL:  "https://policy.example.com/validate",
+		},
+		{
+			name: "changed webhooks are reverted",
+			objects: []client.Object{
+				cluster("true", policyConfiguration),
+				validatingWebhookConfiguration("policy", managed, "https://changed.example.com"),
+			},
+			wantConditions: defaultConditions,
+			wantValidating: []string{"policy"},
+			wantPolicyURL:  "https://policy.example.com/validate",
+		},
+		{
+			name: "removed webhooks are deleted",
+			objects: []client.Object{
+				cluster("true", defaultsConfiguration),
+				validatingWebhookConfiguration("policy", managed, "https://policy.example.com/validate"),
+				validatingWebhookConfiguration("customer", nil, "https://customer.example.com"),
+			},
+			wantConditions: defaultConditions,
+			wantValidating: []string{"customer"},
+			wantMutating:   []string{"defaults"},
+		},
+		{
+			name: "webhook of the customer is left alone",
+			objects: []client.Object{
+				cluster("true", policyConfiguration),
+				validatingWebhookConfiguration("policy", nil, "https://customer.example.com"),
+			},
+			wantErrMsg:     "validating webhook configuration policy already exists and is not managed by the ARO operator",
+			wantConditions: degraded("validating webhook configuration policy already exists and is not managed by the ARO operator"),
+			wantValidating: []string{"policy"},
+			wantPolicyURL:  "https://customer.example.com",
+		},
+		{
+			name: "invalid configuration",
+			objects: []client.Object{
+				cluster("true", "{"),
+			},
+			wantErrMsg:     "admission webhook configuration is invalid: it is not valid JSON or YAML",
+			wantConditions: degraded("admission webhook configuration is invalid: it is not valid JSON or YAML"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			vwcs := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+			err = client.List(ctx, vwcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var validating []string
+			for _, vwc := range vwcs.Items {
+				validating = append(validating, vwc.Name)
+				if vwc.Name == "policy" && *vwc.Webhooks[0].ClientConfig.URL != tt.wantPolicyURL {
+					t.Errorf("got policy url %s, want %s", *vwc.Webhooks[0].ClientConfig.URL, tt.wantPolicyURL)
+				}
+			}
+			sort.Strings(validating)
+			if !reflect.DeepEqual(validating, tt.wantValidating) {
+				t.Errorf("got validating webhook configurations %v, want %v", validating, tt.wantValidating)
+			}
+
+			mwcs := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+			err = client.List(ctx, mwcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var mutating []string
+			for _, mwc := range mwcs.Items {
+				mutating = append(mutating, mwc.Name)
+				if mwc.Labels[managedByLabel] != "true" {
+					t.Errorf("got mutating webhook configuration labels %v", mwc.Labels)
+				}
+			}
+			if !reflect.DeepEqual(mutating, tt.wantMutating) {
+				t.Errorf("got mutating webhook configurations %v, want %v", mutating, tt.wantMutating)
+			}
+		})
+	}
+}
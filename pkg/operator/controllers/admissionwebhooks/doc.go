@@ -0,0 +1,37 @@
+package admissionwebhooks
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package registers the admission webhooks which the
+customer configured at install on the cluster:
+
+* The customer sets admissionWebhookProfiles on the cluster, each holding a
+  ValidatingWebhookConfiguration or MutatingWebhookConfiguration of API
+  version admissionregistration.k8s.io/v1.  The RP checks that they are well
+  formed, and warns through the validate endpoint of webhooks which fail
+  closed on the core namespaces.
+
+* The RP copies the configurations to the AdmissionWebhooks field on the ARO
+  Cluster object.
+
+* The Reconciler creates each configuration, labelled
+  aro.openshift.io/admissionwebhook, and reverts changes made to its webhooks
+  by hand.  Labelled configurations which are no longer in the
+  AdmissionWebhooks field are deleted.  If a configuration of the same name
+  was created by the customer, the controller is Degraded and leaves it alone.
+
+A webhook with failurePolicy Fail which applies to the core namespaces is
+registered, but logged as a warning: if its service is unavailable, the
+cluster may not be able to recover.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.admissionwebhooks.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the webhook configurations
+  according to the AdmissionWebhooks field on the ARO Cluster object
+
+*/
@@ -0,0 +1,137 @@
+package apiserveraudit
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "APIServerAudit"
+
+	controllerEnabled = "aro.apiserveraudit.enabled"
+	controllerProfile = "aro.apiserveraudit.profile"
+
+	// Kubernetes object name
+	apiServerResource = "cluster"
+)
+
+// Reconciler sets the audit profile of the apiserver config from the
+// aro.apiserveraudit.profile operator flag
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	profile, err := desiredProfile(instance.Spec.OperatorFlags)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, nil
+	}
+
+	if profile == "" {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	apiserver := &configv1.APIServer{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: apiServerResource}, apiserver)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	if apiserver.Spec.Audit.Profile != profile {
+		if profile != configv1.DefaultAuditProfileType {
+			r.Log.Warnf("setting apiserver audit profile %s: request and response bodies will be logged, increasing audit log storage and apiserver load", profile)
+		}
+
+		apiserver.Spec.Audit.Profile = profile
+		err = r.Client.Update(ctx, apiserver)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// desiredProfile returns the audit profile set in the operator flags, or an
+// empty string if the current profile should be kept
+func desiredProfile(flags arov1alpha1.OperatorFlags) (configv1.AuditProfileType, error) {
+	profile := configv1.AuditProfileType(flags.GetWithDefault(controllerProfile, ""))
+
+	switch profile {
+	case "",
+		configv1.DefaultAuditProfileType,
+		configv1.WriteRequestBodiesAuditProfileType,
+		configv1.AllRequestBodiesAuditProfileType:
+		return profile, nil
+	}
+
+	return "", fmt.Errorf("invalid %s %q: must be one of %s, %s or %s", controllerProfile, profile,
+		configv1.DefaultAuditProfileType, configv1.WriteRequestBodiesAuditProfileType, configv1.AllRequestBodiesAuditProfileType)
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	apiServerPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == apiServerResource
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.APIServer{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(apiServerPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,176 @@
+package apiserveraudit
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	apiServer := func(profile configv1.AuditProfileType) *configv1.APIServer {
+		return &configv1.APIServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: apiServerResource,
+			},
+			Spec: configv1.APIServerSpec{
+				Audit: configv1.Audit{
+					Profile: profile,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		apiServer       *configv1.APIServer
+		wantProfile     configv1.AuditProfileType
+		wantErr         string
+		startConditions []operatorv1.OperatorCondition
+		wantConditions  []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "false",
+				controllerProfile: string(configv1.AllRequestBodiesAuditProfileType),
+			},
+			apiServer:       apiServer(configv1.DefaultAuditProfileType),
+			wantProfile:     configv1.DefaultAuditProfileType,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "profile unset, current profile kept",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			apiServer:       apiServer(configv1.WriteRequestBodiesAuditProfileType),
+			wantProfile:     configv1.WriteRequestBodiesAuditProfileType,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "profile set",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+				controllerProfile: string(configv1.AllRequestBodiesAuditProfileType),
+			},
+			apiServer:       apiServer(configv1.DefaultAuditProfileType),
+			wantProfile:     configv1.AllRequestBodiesAuditProfileType,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "profile reset to Default",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+				controllerProfile: string(configv1.DefaultAuditProfileType),
+			},
+			apiServer:       apiServer(configv1.WriteRequestBodiesAuditProfileType),
+			wantProfile:     configv1.DefaultAuditProfileType,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "invalid profile",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+				controllerProfile: string(configv1.NoneAuditProfileType),
+			},
+			apiServer:       apiServer(configv1.DefaultAuditProfileType),
+			wantProfile:     configv1.DefaultAuditProfileType,
+			startConditions: defaultConditions,
+			wantConditions:  degraded(`invalid aro.apiserveraudit.profile "None": must be one of Default, WriteRequestBodies or AllRequestBodies`),
+		},
+		{
+			name: "apiserver config not found",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+				controllerProfile: string(configv1.AllRequestBodiesAuditProfileType),
+			},
+			wantErr:         `apiservers.config.openshift.io "cluster" not found`,
+			startConditions: defaultConditions,
+			wantConditions:  degraded(`apiservers.config.openshift.io "cluster" not found`),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			objects := []client.Object{instance}
+			if tt.apiServer != nil {
+				objects = append(objects, tt.apiServer)
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if tt.apiServer == nil {
+				return
+			}
+
+			apiserver := &configv1.APIServer{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: apiServerResource}, apiserver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if apiserver.Spec.Audit.Profile != tt.wantProfile {
+				t.Errorf("got profile %s, wanted %s", apiserver.Spec.Audit.Profile, tt.wantProfile)
+			}
+		})
+	}
+}
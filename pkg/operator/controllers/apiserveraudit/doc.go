@@ -0,0 +1,33 @@
+package apiserveraudit
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package sets the audit log policy profile of the
+OpenShift-provided API servers in the apiserver.config.openshift.io/cluster
+manifest.
+
+There are two flags which control the operations performed by this controller:
+
+aro.apiserveraudit.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the audit profile
+
+aro.apiserveraudit.profile:
+- When unset or empty, the controller leaves the current audit profile alone
+- When set to Default, WriteRequestBodies or AllRequestBodies, the controller
+  will ensure the apiserver audit profile matches
+- Any other value is rejected and the controller reports itself degraded
+
+The WriteRequestBodies and AllRequestBodies profiles also log request and
+response payloads, which considerably increases the volume of audit logs
+written to the master nodes and the load on the API servers.  AllRequestBodies
+in particular logs every read request, and should only be used when compliance
+requires it.
+
+More information on the apiserver audit profiles can be found here:
+https://docs.openshift.com/container-platform/4.12/security/audit-log-policy-config.html
+
+*/
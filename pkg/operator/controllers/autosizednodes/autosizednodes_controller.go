@@ -5,6 +5,7 @@ package autosizednodes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -13,12 +14,14 @@ import (
 	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/Azure/ARO-RP/pkg/api"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 )
 
@@ -53,14 +56,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	r.log.Infof("Config changed, autoSize: %t\n", aro.Spec.OperatorFlags.GetSimpleBoolean(ControllerEnabled))
+	autoSize := aro.Spec.OperatorFlags.GetSimpleBoolean(ControllerEnabled)
+	r.log.Infof("Config changed, autoSize: %t, maxPods: %d\n", autoSize, aro.Spec.MaxPods)
 
 	// key is used to locate the object in the etcd
 	key := types.NamespacedName{
 		Name: configName,
 	}
 
-	if !aro.Spec.OperatorFlags.GetSimpleBoolean(ControllerEnabled) {
+	seccompDefault := aro.Spec.SecurityProfile != nil && aro.Spec.SecurityProfile.DefaultSeccompProfile == string(api.SeccompProfileRuntimeDefault)
+
+	if !autoSize && aro.Spec.MaxPods == 0 && aro.Spec.NodeEviction == nil && !seccompDefault {
 		// defaults to deleting the config
 		config := mcv1.KubeletConfig{
 			ObjectMeta: metav1.ObjectMeta{
@@ -74,7 +80,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	defaultConfig := makeConfig()
+	defaultConfig := makeConfig(autoSize, aro.Spec.MaxPods, aro.Spec.NodeEviction, seccompDefault)
 
 	var config mcv1.KubeletConfig
 	err = r.client.Get(ctx, key, &config)
@@ -118,13 +124,16 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func makeConfig() mcv1.KubeletConfig {
-	return mcv1.KubeletConfig{
+// makeConfig returns the KubeletConfig.  All of the kubelet configuration set
+// by the operator belongs in it: the machine config operator renders each
+// KubeletConfig of a pool into a complete kubelet configuration, so a second
+// KubeletConfig would override this one rather than add to it.
+func makeConfig(autoSize bool, maxPods int, eviction *arov1alpha1.NodeEvictionSpec, seccompDefault bool) mcv1.KubeletConfig {
+	config := mcv1.KubeletConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: configName,
 		},
 		Spec: mcv1.KubeletConfigSpec{
-			AutoSizingReserved: to.BoolPtr(true),
 			MachineConfigPoolSelector: &metav1.LabelSelector{
 				MatchExpressions: []metav1.LabelSelectorRequirement{
 					{
@@ -135,4 +144,82 @@ func makeConfig() mcv1.KubeletConfig {
 			},
 		},
 	}
+
+	if autoSize {
+		config.Spec.AutoSizingReserved = to.BoolPtr(true)
+	}
+
+	kubeletConfig := map[string]interface{}{}
+
+	if maxPods != 0 {
+		kubeletConfig["maxPods"] = maxPods
+	}
+
+	if eviction != nil {
+		// the kubelet doesn't merge a partial evictionHard with its defaults,
+		// so the hard thresholds are always set in full
+		kubeletConfig["evictionHard"] = evictionHard(eviction.Hard)
+
+		soft := evictionThresholds(eviction.Soft)
+		if len(soft) > 0 {
+			gracePeriods := map[string]string{}
+			for signal := range soft {
+				gracePeriods[signal] = eviction.SoftGracePeriod
+			}
+			kubeletConfig["evictionSoft"] = soft
+			kubeletConfig["evictionSoftGracePeriod"] = gracePeriods
+		}
+	}
+
+	if seccompDefault {
+		kubeletConfig["seccompDefault"] = true
+	}
+
+	if len(kubeletConfig) > 0 {
+		// marshalling a map of strings, ints and bools can't fail
+		raw, _ := json.Marshal(kubeletConfig)
+		config.Spec.KubeletConfig = &kruntime.RawExtension{
+			Raw: raw,
+		}
+	}
+
+	return config
+}
+
+// evictionHard returns the platform hard eviction thresholds, overridden by
+// the given ones
+func evictionHard(t *arov1alpha1.EvictionThresholdsSpec) map[string]string {
+	thresholds := evictionThresholds(&arov1alpha1.EvictionThresholdsSpec{
+		MemoryAvailable:  api.PlatformEvictionHard.MemoryAvailable,
+		NodeFSAvailable:  api.PlatformEvictionHard.NodeFSAvailable,
+		ImageFSAvailable: api.PlatformEvictionHard.ImageFSAvailable,
+	})
+	thresholds["nodefs.inodesFree"] = "5%"
+
+	for signal, threshold := range evictionThresholds(t) {
+		thresholds[signal] = threshold
+	}
+
+	return thresholds
+}
+
+// evictionThresholds returns the thresholds which are set, keyed by their
+// kubelet eviction signal
+func evictionThresholds(t *arov1alpha1.EvictionThresholdsSpec) map[string]string {
+	thresholds := map[string]string{}
+	if t == nil {
+		return thresholds
+	}
+
+	for signal, threshold := range map[string]string{
+		"memory.available":  t.MemoryAvailable,
+		"nodefs.available":  t.NodeFSAvailable,
+		"imagefs.available": t.ImageFSAvailable,
+	} {
+		if threshold != "" {
+			thresholds[signal] = threshold
+		}
+	}
+
+	return thresholds
 }
@@ -28,13 +28,20 @@ import (
 )
 
 func TestAutosizednodesReconciler(t *testing.T) {
-	aro := func(autoSizeEnabled bool) *arov1alpha1.Cluster {
+	aro := func(autoSizeEnabled bool, maxPods int, eviction ...*arov1alpha1.NodeEvictionSpec) *arov1alpha1.Cluster {
+		var nodeEviction *arov1alpha1.NodeEvictionSpec
+		if len(eviction) > 0 {
+			nodeEviction = eviction[0]
+		}
+
 		return &arov1alpha1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "aro",
 				Namespace: "openshift-azure-operator",
 			},
 			Spec: arov1alpha1.ClusterSpec{
+				MaxPods:      maxPods,
+				NodeEviction: nodeEviction,
 				OperatorFlags: arov1alpha1.OperatorFlags{
 					ControllerEnabled: strconv.FormatBool(autoSizeEnabled),
 				},
@@ -43,7 +50,31 @@ func TestAutosizednodesReconciler(t *testing.T) {
 	}
 
 	emptyConfig := mcv1.KubeletConfig{}
-	config := makeConfig()
+	config := makeConfig(true, 0, nil, false)
+	maxPodsConfig := makeConfig(false, 500, nil, false)
+	autoSizeMaxPodsConfig := makeConfig(true, 500, nil, false)
+
+	eviction := &arov1alpha1.NodeEvictionSpec{
+		Hard: &arov1alpha1.EvictionThresholdsSpec{
+			MemoryAvailable: "500Mi",
+		},
+		Soft: &arov1alpha1.EvictionThresholdsSpec{
+			MemoryAvailable: "1Gi",
+			NodeFSAvailable: "15%",
+		},
+		SoftGracePeriod: "1m30s",
+	}
+	evictionConfig := makeConfig(false, 500, eviction, false)
+	seccompDefaultConfig := makeConfig(false, 0, nil, true)
+
+	seccompDefaultCluster := aro(false, 0)
+	seccompDefaultCluster.Spec.SecurityProfile = &arov1alpha1.SecurityProfileSpec{
+		DefaultSeccompProfile: "RuntimeDefault",
+	}
+	unconfinedCluster := aro(false, 0)
+	unconfinedCluster.Spec.SecurityProfile = &arov1alpha1.SecurityProfileSpec{
+		DefaultSeccompProfile: "Unconfined",
+	}
 
 	tests := []struct {
 		name       string
@@ -53,30 +84,56 @@ func TestAutosizednodesReconciler(t *testing.T) {
 	}{
 		{
 			name:       "is not needed",
-			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false)).Build(),
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false, 0)).Build(),
 			wantConfig: &emptyConfig,
 			wantErrMsg: kerrors.NewNotFound(mcv1.Resource("kubeletconfigs"), "dynamic-node").Error(),
 		},
 		{
 			name:       "is needed and not present already",
-			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true)).Build(),
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true, 0)).Build(),
 			wantConfig: &config,
 		},
 		{
 			name:       "is needed and present already",
-			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true), &config).Build(),
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true, 0), &config).Build(),
 			wantConfig: &config,
 		},
 		{
 			name:       "is not needed and is present",
-			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false), &config).Build(),
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false, 0), &config).Build(),
+			wantConfig: &emptyConfig,
+			wantErrMsg: kerrors.NewNotFound(mcv1.Resource("kubeletconfigs"), "dynamic-node").Error(),
+		},
+		{
+			name:       "max pods is set",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false, 500)).Build(),
+			wantConfig: &maxPodsConfig,
+		},
+		{
+			name:       "max pods is set with auto sized nodes",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(true, 500), &config).Build(),
+			wantConfig: &autoSizeMaxPodsConfig,
+		},
+		{
+			name:       "eviction thresholds are set",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(aro(false, 500, eviction)).Build(),
+			wantConfig: &evictionConfig,
+		},
+		{
+			name:       "default seccomp profile is RuntimeDefault",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(seccompDefaultCluster).Build(),
+			wantConfig: &seccompDefaultConfig,
+		},
+		{
+			name:       "default seccomp profile is Unconfined",
+			client:     fake.NewClientBuilder().WithRuntimeObjects(unconfinedCluster, &seccompDefaultConfig).Build(),
 			wantConfig: &emptyConfig,
 			wantErrMsg: kerrors.NewNotFound(mcv1.Resource("kubeletconfigs"), "dynamic-node").Error(),
 		},
 		{
 			name: "is needed and config got modified",
 			client: fake.NewClientBuilder().WithRuntimeObjects(
-				aro(true),
+				aro(true, 0),
 				&mcv1.KubeletConfig{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: configName,
@@ -127,3 +184,53 @@ func TestAutosizednodesReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeConfigEviction(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		eviction *arov1alpha1.NodeEvictionSpec
+		want     string
+	}{
+		{
+			name:     "platform hard thresholds are kept",
+			eviction: &arov1alpha1.NodeEvictionSpec{},
+			want:     `{"evictionHard":{"imagefs.available":"15%","memory.available":"100Mi","nodefs.available":"10%","nodefs.inodesFree":"5%"}}`,
+		},
+		{
+			name: "hard and soft thresholds",
+			eviction: &arov1alpha1.NodeEvictionSpec{
+				Hard: &arov1alpha1.EvictionThresholdsSpec{
+					MemoryAvailable:  "500Mi",
+					ImageFSAvailable: "10%",
+				},
+				Soft: &arov1alpha1.EvictionThresholdsSpec{
+					MemoryAvailable: "1Gi",
+				},
+				SoftGracePeriod: "1m30s",
+			},
+			want: `{"evictionHard":{"imagefs.available":"10%","memory.available":"500Mi","nodefs.available":"10%","nodefs.inodesFree":"5%"},"evictionSoft":{"memory.available":"1Gi"},"evictionSoftGracePeriod":{"memory.available":"1m30s"}}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			config := makeConfig(false, 0, tt.eviction, false)
+			if config.Spec.KubeletConfig == nil {
+				t.Fatal("no kubelet config")
+			}
+			if string(config.Spec.KubeletConfig.Raw) != tt.want {
+				t.Errorf("got %s, want %s", config.Spec.KubeletConfig.Raw, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeConfigSeccompDefault(t *testing.T) {
+	config := makeConfig(false, 250, nil, true)
+	if config.Spec.KubeletConfig == nil {
+		t.Fatal("no kubelet config")
+	}
+
+	want := `{"maxPods":250,"seccompDefault":true}`
+	if string(config.Spec.KubeletConfig.Raw) != want {
+		t.Errorf("got %s, want %s", config.Spec.KubeletConfig.Raw, want)
+	}
+}
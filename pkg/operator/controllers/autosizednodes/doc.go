@@ -7,3 +7,23 @@ package autosizednodes
 // that tells machine-config-operator to turn on auto sized nodes feature
 // the code that is executed by the mco:
 // - https://github.com/openshift/machine-config-operator/blob/fbc4d8e46a7746442f4de3651113d2181d458b12/templates/common/_base/files/kubelet-auto-sizing.yaml
+//
+// The same KubeletConfig sets the maximum number of pods per node, if the
+// MaxPods field is set on the ARO Cluster object.  The RP copies it from
+// networkProfile.maxPods at cluster create time.  The configuration is kept
+// even if auto sized nodes are disabled.
+//
+// The kubelet eviction thresholds are set in the same KubeletConfig if the
+// NodeEviction field is set; the RP copies it from nodeEvictionProfile.  The
+// hard thresholds are always written in full, with the platform thresholds
+// for the signals which are not set, as the kubelet would otherwise not
+// evict on them at all.  Changing the KubeletConfig causes a rolling reboot
+// of the nodes by the machine config operator.
+//
+// If the default seccomp profile in the SecurityProfile field is
+// RuntimeDefault, the same KubeletConfig sets seccompDefault, so that the
+// kubelet applies the runtime/default seccomp profile to containers which do
+// not set one.  The default security context constraints are not changed:
+// restricted-v2 already requires runtime/default, and containers which need
+// to run unconfined must set the Unconfined seccomp profile explicitly and be
+// admitted by a security context constraint which allows it, e.g. privileged.
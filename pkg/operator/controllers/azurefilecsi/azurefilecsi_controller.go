@@ -0,0 +1,200 @@
+package azurefilecsi
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "AzureFileCSI"
+
+	controllerEnabled = "aro.azurefilecsi.enabled"
+
+	provisioner = string(operatorv1.AzureFileCSIDriver)
+
+	// managedByLabel marks the storage classes created by this controller
+	managedByLabel = "aro.openshift.io/azurefilecsi"
+)
+
+// mountOptions are the mount options recommended for Azure Files SMB shares
+// used by containers
+var mountOptions = []string{
+	"mfsymlinks",
+	"cache=strict",
+	"nosharesock",
+	"actimeo=30",
+}
+
+// Reconciler ensures the Azure Files storage class of the cluster
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object, the storage classes and the Azure File
+// ClusterCSIDriver, and if they change, reconciles the Azure Files storage
+// class
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.AzureFileCSI == nil {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.ensureDriverManaged(ctx)
+	if err == nil {
+		err = r.ensureStorageClass(ctx, instance.Spec.AzureFileCSI)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// ensureDriverManaged ensures that the cluster storage operator deploys the
+// Azure File CSI driver
+func (r *Reconciler) ensureDriverManaged(ctx context.Context) error {
+	driver := &operatorv1.ClusterCSIDriver{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: provisioner}, driver)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &operatorv1.ClusterCSIDriver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: provisioner,
+			},
+			Spec: operatorv1.ClusterCSIDriverSpec{
+				OperatorSpec: operatorv1.OperatorSpec{
+					ManagementState: operatorv1.Managed,
+				},
+			},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if driver.Spec.ManagementState == operatorv1.Managed {
+		return nil
+	}
+
+	driver.Spec.ManagementState = operatorv1.Managed
+	return r.Client.Update(ctx, driver)
+}
+
+// ensureStorageClass creates the storage class, or recreates it if it has
+// drifted
+func (r *Reconciler) ensureStorageClass(ctx context.Context, spec *arov1alpha1.AzureFileCSISpec) error {
+	want := newStorageClass(spec)
+
+	sc := &storagev1.StorageClass{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: spec.StorageClassName}, sc)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, want)
+	}
+	if err != nil {
+		return err
+	}
+
+	if sc.Labels[managedByLabel] != "true" {
+		return fmt.Errorf("storage class %q already exists and is not managed by the ARO operator", spec.StorageClassName)
+	}
+
+	if sc.Provisioner == want.Provisioner &&
+		reflect.DeepEqual(sc.Parameters, want.Parameters) &&
+		reflect.DeepEqual(sc.MountOptions, want.MountOptions) {
+		return nil
+	}
+
+	// StorageClass parameters and mount options are immutable
+	err = r.Client.Delete(ctx, sc)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return r.Client.Create(ctx, want)
+}
+
+func newStorageClass(spec *arov1alpha1.AzureFileCSISpec) *storagev1.StorageClass {
+	var parameters map[string]string
+	if spec.SKUName != "" {
+		parameters = map[string]string{
+			"skuName": spec.SKUName,
+		}
+	}
+
+	volumeBindingMode := storagev1.VolumeBindingImmediate
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.StorageClassName,
+			Labels: map[string]string{
+				managedByLabel: "true",
+			},
+		},
+		Provisioner:          provisioner,
+		VolumeBindingMode:    &volumeBindingMode,
+		AllowVolumeExpansion: to.BoolPtr(true),
+		ReclaimPolicy:        &reclaimPolicy,
+		MountOptions:         mountOptions,
+		Parameters:           parameters,
+	}
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(&source.Kind{Type: &storagev1.StorageClass{}}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &operatorv1.ClusterCSIDriver{}}, &handler.EnqueueRequestForObject{}).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,210 @@
+package azurefilecsi
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	cluster := func(enabled string, spec *arov1alpha1.AzureFileCSISpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				AzureFileCSI: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	storageClass := func(name string, labels, parameters map[string]string) *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Provisioner:  provisioner,
+			MountOptions: mountOptions,
+			Parameters:   parameters,
+		}
+	}
+
+	clusterCSIDriver := func(managementState operatorv1.ManagementState) *operatorv1.ClusterCSIDriver {
+		return &operatorv1.ClusterCSIDriver{
+			ObjectMeta: metav1.ObjectMeta{Name: provisioner},
+			Spec: operatorv1.ClusterCSIDriverSpec{
+				OperatorSpec: operatorv1.OperatorSpec{
+					ManagementState: managementState,
+				},
+			},
+		}
+	}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		d := defaultDegraded
+		d.Status = operatorv1.ConditionTrue
+		d.Message = message
+		return []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, d}
+	}
+
+	spec := &arov1alpha1.AzureFileCSISpec{StorageClassName: "azurefile-rwx", SKUName: "Premium_LRS"}
+
+	tests := []struct {
+		name                string
+		objects             []client.Object
+		wantErrMsg          string
+		wantConditions      []operatorv1.OperatorCondition
+		wantManagementState operatorv1.ManagementState
+		wantParameters      map[string]string
+		wantNoStorageClass  bool
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", spec),
+				clusterCSIDriver(operatorv1.Removed),
+			},
+			wantConditions:      defaultConditions,
+			wantManagementState: operatorv1.Removed,
+			wantNoStorageClass:  true,
+		},
+		{
+			name: "no azure files storage class is configured",
+			objects: []client.Object{
+				cluster("true", nil),
+			},
+			wantConditions:     defaultConditions,
+			wantNoStorageClass: true,
+		},
+		{
+			name: "driver is managed and storage class is created",
+			objects: []client.Object{
+				cluster("true", spec),
+				clusterCSIDriver(operatorv1.Removed),
+			},
+			wantConditions:      defaultConditions,
+			wantManagementState: operatorv1.Managed,
+			wantParameters:      map[string]string{"skuName": "Premium_LRS"},
+		},
+		{
+			name: "missing driver is created",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.AzureFileCSISpec{StorageClassName: "azurefile-rwx"}),
+			},
+			wantConditions:      defaultConditions,
+			wantManagementState: operatorv1.Managed,
+		},
+		{
+			name: "storage class drift is reverted",
+			objects: []client.Object{
+				cluster("true", spec),
+				clusterCSIDriver(operatorv1.Managed),
+				storageClass("azurefile-rwx", map[string]string{managedByLabel: "true"}, map[string]string{"skuName": "Standard_LRS"}),
+			},
+			wantConditions:      defaultConditions,
+			wantManagementState: operatorv1.Managed,
+			wantParameters:      map[string]string{"skuName": "Premium_LRS"},
+		},
+		{
+			name: "storage class of the customer is not replaced",
+			objects: []client.Object{
+				cluster("true", spec),
+				clusterCSIDriver(operatorv1.Managed),
+				storageClass("azurefile-rwx", nil, map[string]string{"skuName": "Standard_LRS"}),
+			},
+			wantErrMsg:          `storage class "azurefile-rwx" already exists and is not managed by the ARO operator`,
+			wantConditions:      degraded(`storage class "azurefile-rwx" already exists and is not managed by the ARO operator`),
+			wantManagementState: operatorv1.Managed,
+			wantParameters:      map[string]string{"skuName": "Standard_LRS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+			if tt.wantErrMsg != "" && tt.wantConditions == nil {
+				return
+			}
+
+			if tt.wantManagementState != "" {
+				driver := &operatorv1.ClusterCSIDriver{}
+				err = client.Get(ctx, types.NamespacedName{Name: provisioner}, driver)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if driver.Spec.ManagementState != tt.wantManagementState {
+					t.Errorf("got management state %q, want %q", driver.Spec.ManagementState, tt.wantManagementState)
+				}
+			}
+
+			sc := &storagev1.StorageClass{}
+			err = client.Get(ctx, types.NamespacedName{Name: "azurefile-rwx"}, sc)
+			if tt.wantNoStorageClass {
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("got error %v, want not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sc.Provisioner != provisioner {
+				t.Errorf("got provisioner %q", sc.Provisioner)
+			}
+			if !reflect.DeepEqual(sc.MountOptions, mountOptions) {
+				t.Errorf("got mount options %#v", sc.MountOptions)
+			}
+			if !reflect.DeepEqual(sc.Parameters, tt.wantParameters) {
+				t.Errorf("got parameters %#v, want %#v", sc.Parameters, tt.wantParameters)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package azurefilecsi
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package provides a storage class for ReadWriteMany
+volumes backed by Azure Files.
+
+The RP copies the azureFileCsiProfile of the cluster to the AzureFileCSI field
+on the ARO Cluster object.  The Reconciler ensures that the cluster storage
+operator manages the Azure File CSI driver and creates a storage class of the
+driver with the chosen storage account SKU.  The driver creates the storage
+accounts of the volumes in the cluster resource group as the cluster service
+principal.  StorageClass parameters are immutable, so a storage class created by
+the operator which has drifted is deleted and recreated; existing persistent
+volumes are not affected.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.azurefilecsi.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the Azure Files storage class
+  according to the AzureFileCSI field on the ARO Cluster object
+
+If the AzureFileCSI field is not set the controller noops.  A storage class
+which was created before the profile was removed is left in place, as
+persistent volumes may still refer to it.
+
+*/
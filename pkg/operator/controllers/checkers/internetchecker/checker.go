@@ -7,8 +7,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	utilproxy "github.com/Azure/ARO-RP/pkg/util/proxy"
 )
 
 type simpleHTTPClient interface {
@@ -16,7 +21,7 @@ type simpleHTTPClient interface {
 }
 
 type internetChecker interface {
-	Check(URLs []string) error
+	Check(proxy *configv1.Proxy, URLs []string) error
 }
 
 // checker evaluates our capability to create new
@@ -24,31 +29,45 @@ type internetChecker interface {
 type checker struct {
 	checkTimeout time.Duration
 	httpClient   simpleHTTPClient
+
+	// proxy is set from the cluster proxy config at the start of each Check.
+	// Checks are never run concurrently.
+	proxy func(*http.Request) (*url.URL, error)
 }
 
 func newInternetChecker() *checker {
-	return &checker{
+	r := &checker{
 		checkTimeout: time.Minute,
-		httpClient: &http.Client{
-			Transport: &http.Transport{
-				// We set DisableKeepAlives for two reasons:
-				//
-				// 1. If we're talking HTTP/2 and the remote end blackholes traffic,
-				// Go has a bug whereby it doesn't reset the connection after a
-				// timeout (https://github.com/golang/go/issues/36026).  If this
-				// happens, we never have a chance to get healthy.  We have
-				// specifically seen this with gcs.prod.monitoring.core.windows.net
-				// in Korea Central, which currently has a bad server which when we
-				// hit it causes our cluster creations to fail.
-				//
-				// 2. We *want* to evaluate our capability to successfully create
-				// *new* connections to internet endpoints anyway.
-				DisableKeepAlives: true,
+		proxy:        utilproxy.ProxyFunc(nil),
+	}
+
+	r.httpClient = &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return r.proxy(req)
 			},
+			// We set DisableKeepAlives for two reasons:
+			//
+			// 1. If we're talking HTTP/2 and the remote end blackholes traffic,
+			// Go has a bug whereby it doesn't reset the connection after a
+			// timeout (https://github.com/golang/go/issues/36026).  If this
+			// happens, we never have a chance to get healthy.  We have
+			// specifically seen this with gcs.prod.monitoring.core.windows.net
+			// in Korea Central, which currently has a bad server which when we
+			// hit it causes our cluster creations to fail.
+			//
+			// 2. We *want* to evaluate our capability to successfully create
+			// *new* connections to internet endpoints anyway.
+			DisableKeepAlives: true,
 		},
 	}
+
+	return r
 }
-func (r *checker) Check(URLs []string) error {
+
+func (r *checker) Check(proxy *configv1.Proxy, URLs []string) error {
+	r.proxy = utilproxy.ProxyFunc(proxy)
+
 	ch := make(chan error)
 	checkCount := 0
 	for _, url := range URLs {
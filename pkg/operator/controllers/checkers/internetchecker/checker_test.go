@@ -8,12 +8,15 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"reflect"
 	"syscall"
 	"testing"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
@@ -105,8 +108,49 @@ func TestCheck(t *testing.T) {
 				checkTimeout: 100 * time.Millisecond,
 				httpClient:   &testClient{responses: test.responses},
 			}
-			err := r.Check([]string{urltocheck})
+			err := r.Check(nil, []string{urltocheck})
 			utilerror.AssertErrorMessage(t, err, test.wantErr)
 		})
 	}
 }
+
+func TestCheckUsesClusterProxy(t *testing.T) {
+	proxied := make(chan string, 10)
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a request sent to a proxy carries the absolute URL
+		proxied <- r.Method + " " + r.URL.String()
+	}))
+	defer proxyServer.Close()
+
+	directServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer directServer.Close()
+
+	r := newInternetChecker()
+	r.checkTimeout = 6 * time.Second
+
+	err := r.Check(&configv1.Proxy{
+		Status: configv1.ProxyStatus{
+			HTTPProxy: proxyServer.URL,
+		},
+	}, []string{"http://not-used-in-test.invalid/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a later check with no proxy configured connects directly
+	err = r.Check(nil, []string{directServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	close(proxied)
+
+	var got []string
+	for req := range proxied {
+		got = append(got, req)
+	}
+
+	if !reflect.DeepEqual(got, []string{"HEAD http://not-used-in-test.invalid/"}) {
+		t.Error(got)
+	}
+}
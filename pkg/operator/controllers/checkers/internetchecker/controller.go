@@ -7,14 +7,18 @@ import (
 	"context"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	checkercommon "github.com/Azure/ARO-RP/pkg/operator/controllers/checkers/common"
@@ -26,6 +30,7 @@ import (
 // from the annotation below.
 // +kubebuilder:rbac:groups=aro.openshift.io,resources=clusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=aro.openshift.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get;list;watch
 
 const (
 	ControllerName = "InternetChecker"
@@ -65,8 +70,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return r.reconcileDisabled(ctx)
 	}
 
+	// the checks must go through the cluster-wide egress proxy, if there is
+	// one, as the customer's firewall may block direct connections
+	proxy := &configv1.Proxy{}
+	err = r.client.Get(ctx, types.NamespacedName{Name: "cluster"}, proxy)
+	if kerrors.IsNotFound(err) {
+		proxy = nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	r.log.Debug("running")
-	checkErr := r.checker.Check(instance.Spec.InternetChecker.URLs)
+	checkErr := r.checker.Check(proxy, instance.Spec.InternetChecker.URLs)
 	condition := r.condition(checkErr)
 
 	err = conditions.SetCondition(ctx, r.client, condition, r.role)
@@ -126,8 +141,18 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return o.GetName() == arov1alpha1.SingletonClusterName
 	})
 
+	proxyPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == "cluster"
+	})
+
+	// re-run the checks straight away when the proxy config changes
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate))
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.Proxy{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(proxyPredicate),
+		)
 
 	return builder.Named(ControllerName).Complete(r)
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,10 +27,10 @@ import (
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
-type fakeChecker func(URLs []string) error
+type fakeChecker func(proxy *configv1.Proxy, URLs []string) error
 
-func (fc fakeChecker) Check(URLs []string) error {
-	return fc(URLs)
+func (fc fakeChecker) Check(proxy *configv1.Proxy, URLs []string) error {
+	return fc(proxy, URLs)
 }
 
 func TestReconcile(t *testing.T) {
@@ -90,12 +91,25 @@ func TestReconcile(t *testing.T) {
 						instance.Spec.OperatorFlags[checkercommon.ControllerEnabled] = "false"
 					}
 
-					clientFake := fake.NewClientBuilder().WithObjects(instance).Build()
+					clusterProxy := &configv1.Proxy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+						Status: configv1.ProxyStatus{
+							HTTPSProxy: "http://proxy.example.com:3128",
+						},
+					}
+
+					clientFake := fake.NewClientBuilder().WithObjects(instance, clusterProxy).Build()
 
 					r := &Reconciler{
 						log:  utillog.GetLogger(),
 						role: testRole,
-						checker: fakeChecker(func(URLs []string) error {
+						checker: fakeChecker(func(proxy *configv1.Proxy, URLs []string) error {
+							if proxy == nil || proxy.Status.HTTPSProxy != clusterProxy.Status.HTTPSProxy {
+								t.Error(proxy)
+							}
+
 							if !reflect.DeepEqual(urlsToCheck, URLs) {
 								t.Error(cmp.Diff(urlsToCheck, URLs))
 							}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,6 +23,9 @@ import (
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
 )
 
 const (
@@ -128,8 +132,13 @@ type azCloudProviderConfig struct {
 }
 
 // CloudProviderConfigReconciler reconciles the openshift-config/cloud-provider-config ConfigMap
+// and the credentials in the kube-system/azure-cloud-provider Secret
 type CloudProviderConfigReconciler struct {
 	base.AROController
+
+	credentials        func(ctx context.Context) (*clusterauthorizer.Credentials, error)
+	getTokenCredential func(azEnv *azureclient.AROEnvironment, credentials *clusterauthorizer.Credentials) (azcore.TokenCredential, error)
+	newSPValidator     func(azEnv *azureclient.AROEnvironment) dynamic.ServicePrincipalValidator
 }
 
 func NewReconciler(Log *logrus.Entry, client client.Client) *CloudProviderConfigReconciler {
@@ -139,6 +148,14 @@ func NewReconciler(Log *logrus.Entry, client client.Client) *CloudProviderConfig
 			Client: client,
 			Name:   ControllerName,
 		},
+
+		credentials: func(ctx context.Context) (*clusterauthorizer.Credentials, error) {
+			return clusterauthorizer.AzCredentials(ctx, client)
+		},
+		getTokenCredential: clusterauthorizer.GetTokenCredential,
+		newSPValidator: func(azEnv *azureclient.AROEnvironment) dynamic.ServicePrincipalValidator {
+			return dynamic.NewServicePrincipalValidator(Log, azEnv, dynamic.AuthorizerClusterServicePrincipal)
+		},
 	}
 }
 
@@ -157,7 +174,12 @@ func (r *CloudProviderConfigReconciler) Reconcile(ctx context.Context, request c
 	}
 
 	r.Log.Debug("running")
-	return reconcile.Result{}, r.updateCloudProviderConfig(ctx)
+	err = r.updateCloudProviderConfig(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, r.reconcileIdentity(ctx, instance)
 }
 
 // SetupWithManager setup our manager
@@ -172,6 +194,11 @@ func (r *CloudProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 		return o.GetName() == cloudProviderConfigName.Name && o.GetNamespace() == cloudProviderConfigName.Namespace
 	})
 
+	secretPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return (o.GetName() == clusterauthorizer.AzureCredentialSecretName && o.GetNamespace() == clusterauthorizer.AzureCredentialSecretNameSpace) ||
+			(o.GetName() == cloudProviderSecretName.Name && o.GetNamespace() == cloudProviderSecretName.Namespace)
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
 		Watches(
@@ -179,6 +206,11 @@ func (r *CloudProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 			&handler.EnqueueRequestForObject{},
 			builder.WithPredicates(cloudProviderConfigPredicate),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(secretPredicate),
+		).
 		Named(ControllerName).
 		Complete(r)
 }
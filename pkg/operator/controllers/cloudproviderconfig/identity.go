@@ -0,0 +1,123 @@
+package cloudproviderconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"time"
+
+	"github.com/ghodss/yaml"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// cloudProviderSecretName is the secret from which the in-tree cloud provider
+// of the kube-apiserver and kube-controller-manager reads its credentials
+var cloudProviderSecretName = types.NamespacedName{Name: "azure-cloud-provider", Namespace: "kube-system"}
+
+// reconcileIdentity brings the service principal credentials in the
+// kube-system/azure-cloud-provider secret in line with the azure-credentials
+// secret, as the RP does when the cluster service principal is updated.  The
+// credentials are validated before they are written so that a broken secret
+// does not break the cloud provider.  Only the keys already present in the
+// cloud config are updated, so clusters whose cloud provider doesn't use a
+// client secret are left alone.  Once the secret is changed the
+// kube-apiserver and kube-controller-manager are redeployed to pick it up.
+func (r *CloudProviderConfigReconciler) reconcileIdentity(ctx context.Context, instance *arov1alpha1.Cluster) error {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, cloudProviderSecretName, secret)
+	if kerrors.IsNotFound(err) {
+		// we are not in control if the secret is not present
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cf map[string]interface{}
+	err = yaml.Unmarshal(secret.Data["cloud-config"], &cf)
+	if err != nil {
+		return err
+	}
+
+	clientID, hasClientID := cf["aadClientId"].(string)
+	clientSecret, hasClientSecret := cf["aadClientSecret"].(string)
+	if !hasClientID || !hasClientSecret {
+		return nil
+	}
+
+	azCred, err := r.credentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if clientID == string(azCred.ClientID) && clientSecret == string(azCred.ClientSecret) {
+		r.Log.Debug("kube-system/azure-cloud-provider credentials are up to date no changes needed")
+		return nil
+	}
+
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return err
+	}
+
+	spTokenCredential, err := r.getTokenCredential(&azEnv, azCred)
+	if err != nil {
+		return err
+	}
+
+	err = r.newSPValidator(&azEnv).ValidateServicePrincipal(ctx, spTokenCredential)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("updating kube-system/azure-cloud-provider credentials")
+	cf["aadClientId"] = string(azCred.ClientID)
+	cf["aadClientSecret"] = string(azCred.ClientSecret)
+
+	secret.Data["cloud-config"], err = yaml.Marshal(cf)
+	if err != nil {
+		return err
+	}
+
+	err = r.Client.Update(ctx, secret)
+	if err != nil {
+		return err
+	}
+
+	return r.redeployCloudProviderConsumers(ctx)
+}
+
+// redeployCloudProviderConsumers forces a new revision of the kube-apiserver
+// and kube-controller-manager static pods, so that they read the updated
+// cloud provider credentials
+func (r *CloudProviderConfigReconciler) redeployCloudProviderConsumers(ctx context.Context) error {
+	reason := "Credential rotation " + time.Now().UTC().String()
+
+	r.Log.Info("redeploying the kube-apiserver")
+	kubeAPIServer := &operatorv1.KubeAPIServer{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, kubeAPIServer)
+	if err != nil {
+		return err
+	}
+	kubeAPIServer.Spec.ForceRedeploymentReason = reason
+	err = r.Client.Update(ctx, kubeAPIServer)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("redeploying the kube-controller-manager")
+	kubeControllerManager := &operatorv1.KubeControllerManager{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, kubeControllerManager)
+	if err != nil {
+		return err
+	}
+	kubeControllerManager.Spec.ForceRedeploymentReason = reason
+	return r.Client.Update(ctx, kubeControllerManager)
+}
@@ -0,0 +1,197 @@
+package cloudproviderconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	mock_dynamic "github.com/Azure/ARO-RP/pkg/util/mocks/dynamic"
+	"github.com/Azure/ARO-RP/pkg/validate/dynamic"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+type fakeTokenCredential struct{}
+
+func (c fakeTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{}, nil
+}
+
+func TestReconcileIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	credentials := &clusterauthorizer.Credentials{
+		ClientID:     []byte("new-client-id"),
+		ClientSecret: []byte("new-client-secret"),
+		TenantID:     []byte("tenant-id"),
+	}
+
+	for _, tt := range []struct {
+		name            string
+		cloudConfig     string
+		validator       func(controller *gomock.Controller) dynamic.ServicePrincipalValidator
+		wantCloudConfig string
+		wantRedeployed  bool
+		wantErr         string
+	}{
+		{
+			name:            "credentials are up to date",
+			cloudConfig:     "aadClientId: new-client-id\naadClientSecret: new-client-secret\n",
+			wantCloudConfig: "aadClientId: new-client-id\naadClientSecret: new-client-secret\n",
+		},
+		{
+			name:            "cloud config without a client secret is not changed",
+			cloudConfig:     "aadClientId: identity-client-id\nuseManagedIdentityExtension: true\n",
+			wantCloudConfig: "aadClientId: identity-client-id\nuseManagedIdentityExtension: true\n",
+		},
+		{
+			name:        "rotated credentials are validated and applied",
+			cloudConfig: "aadClientId: old-client-id\naadClientSecret: old-client-secret\n",
+			validator: func(controller *gomock.Controller) dynamic.ServicePrincipalValidator {
+				validator := mock_dynamic.NewMockDynamic(controller)
+				validator.EXPECT().ValidateServicePrincipal(gomock.Any(), &fakeTokenCredential{})
+				return validator
+			},
+			wantCloudConfig: "aadClientId: new-client-id\naadClientSecret: new-client-secret\n",
+			wantRedeployed:  true,
+		},
+		{
+			name:        "credentials which do not authenticate are not applied",
+			cloudConfig: "aadClientId: old-client-id\naadClientSecret: old-client-secret\n",
+			validator: func(controller *gomock.Controller) dynamic.ServicePrincipalValidator {
+				validator := mock_dynamic.NewMockDynamic(controller)
+				validator.EXPECT().ValidateServicePrincipal(gomock.Any(), &fakeTokenCredential{}).
+					Return(errors.New("fake validation error"))
+				return validator
+			},
+			wantCloudConfig: "aadClientId: old-client-id\naadClientSecret: old-client-secret\n",
+			wantErr:         "fake validation error",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var validatorMock dynamic.ServicePrincipalValidator
+			if tt.validator != nil {
+				validatorMock = tt.validator(controller)
+			}
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					AZEnvironment: azure.PublicCloud.Name,
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						controllerEnabled: "true",
+					},
+				},
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().
+				WithObjects(
+					instance,
+					&corev1.ConfigMap{
+						ObjectMeta: cmMetadata,
+						Data: map[string]string{
+							"config": `{"disableOutboundSNAT":true}`,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      cloudProviderSecretName.Name,
+							Namespace: cloudProviderSecretName.Namespace,
+						},
+						Data: map[string][]byte{
+							"cloud-config": []byte(tt.cloudConfig),
+						},
+					},
+					&operatorv1.KubeAPIServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+					&operatorv1.KubeControllerManager{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+				).
+				Build()
+
+			r := &CloudProviderConfigReconciler{
+				AROController: base.AROController{
+					Log:    logrus.NewEntry(logrus.StandardLogger()),
+					Client: clientFake,
+					Name:   ControllerName,
+				},
+				credentials: func(ctx context.Context) (*clusterauthorizer.Credentials, error) {
+					return credentials, nil
+				},
+				getTokenCredential: func(*azureclient.AROEnvironment, *clusterauthorizer.Credentials) (azcore.TokenCredential, error) {
+					return &fakeTokenCredential{}, nil
+				},
+				newSPValidator: func(azEnv *azureclient.AROEnvironment) dynamic.ServicePrincipalValidator {
+					return validatorMock
+				},
+			}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			secret := &corev1.Secret{}
+			err = clientFake.Get(ctx, cloudProviderSecretName, secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(secret.Data["cloud-config"]) != tt.wantCloudConfig {
+				t.Errorf("got cloud config %q, wanted %q", string(secret.Data["cloud-config"]), tt.wantCloudConfig)
+			}
+
+			kubeAPIServer := &operatorv1.KubeAPIServer{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: "cluster"}, kubeAPIServer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kubeControllerManager := &operatorv1.KubeControllerManager{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: "cluster"}, kubeControllerManager)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if redeployed := kubeAPIServer.Spec.ForceRedeploymentReason != "" && kubeControllerManager.Spec.ForceRedeploymentReason != ""; redeployed != tt.wantRedeployed {
+				t.Errorf("got redeployed %t, wanted %t", redeployed, tt.wantRedeployed)
+			}
+		})
+	}
+}
+
+func TestReconcileIdentityWithoutSecret(t *testing.T) {
+	ctx := context.Background()
+
+	r := &CloudProviderConfigReconciler{
+		AROController: base.AROController{
+			Log:    logrus.NewEntry(logrus.StandardLogger()),
+			Client: ctrlfake.NewClientBuilder().Build(),
+			Name:   ControllerName,
+		},
+	}
+
+	err := r.reconcileIdentity(ctx, &arov1alpha1.Cluster{})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -21,8 +21,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/version"
@@ -37,8 +39,9 @@ const (
 
 // The default set of status change reasons.
 const (
-	reasonAsExpected   = "AsExpected"
-	reasonInitializing = "Initializing"
+	reasonAsExpected        = "AsExpected"
+	reasonInitializing      = "Initializing"
+	reasonUnsupportedUpdate = "UnsupportedUpdate"
 )
 
 type Reconciler struct {
@@ -78,10 +81,62 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, r.setClusterOperatorStatus(ctx, co, instance)
+	upgradeable, err := r.upgradeableCondition(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, r.setClusterOperatorStatus(ctx, co, instance, upgradeable)
+}
+
+// upgradeableCondition returns the Upgradeable condition of the operator.
+// The cluster version operator doesn't start an update to another minor
+// version while a ClusterOperator is not Upgradeable, so this holds back the
+// updates which the RP's update matrix doesn't support where they are
+// requested, with the supported next versions in the message.  It returns
+// nil if the version of the cluster isn't known yet, or isn't in the matrix.
+func (r *Reconciler) upgradeableCondition(ctx context.Context) (*configv1.ClusterOperatorStatusCondition, error) {
+	cv := &configv1.ClusterVersion{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: "version"}, cv)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := version.GetClusterVersion(cv)
+	if err != nil {
+		// the cluster is still installing
+		return nil, nil
+	}
+
+	if _, found := version.UpdateMatrix[current.MinorVersion()]; !found {
+		return nil, nil
+	}
+
+	next := version.NewVersion(current.V[0], current.V[1]+1)
+
+	err = version.ValidateUpdate(version.UpdateMatrix, current, next)
+	if err != nil {
+		return &configv1.ClusterOperatorStatusCondition{
+			Type:               configv1.OperatorUpgradeable,
+			Status:             configv1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reasonUnsupportedUpdate,
+			Message:            err.Error(),
+		}, nil
+	}
+
+	return &configv1.ClusterOperatorStatusCondition{
+		Type:               configv1.OperatorUpgradeable,
+		Status:             configv1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonAsExpected,
+	}, nil
 }
 
-func (r *Reconciler) setClusterOperatorStatus(ctx context.Context, originalClusterOperatorObj *configv1.ClusterOperator, cluster *arov1alpha1.Cluster) error {
+func (r *Reconciler) setClusterOperatorStatus(ctx context.Context, originalClusterOperatorObj *configv1.ClusterOperator, cluster *arov1alpha1.Cluster, upgradeable *configv1.ClusterOperatorStatusCondition) error {
 	clusterOperatorObj := originalClusterOperatorObj.DeepCopy()
 
 	configv1helpers.SetStatusCondition(&clusterOperatorObj.Status.Conditions, status.UnionClusterCondition("Available", operatorv1.ConditionTrue, nil, cluster.Status.Conditions...))
@@ -95,6 +150,12 @@ func (r *Reconciler) setClusterOperatorStatus(ctx context.Context, originalClust
 		Reason:             reasonAsExpected,
 	})
 
+	if upgradeable != nil {
+		configv1helpers.SetStatusCondition(&clusterOperatorObj.Status.Conditions, *upgradeable)
+	} else {
+		configv1helpers.RemoveStatusCondition(&clusterOperatorObj.Status.Conditions, configv1.OperatorUpgradeable)
+	}
+
 	operatorv1helpers.SetOperandVersion(&clusterOperatorObj.Status.Versions, configv1.OperandVersion{Name: "operator", Version: version.GitCommit})
 
 	if equality.Semantic.DeepEqual(clusterOperatorObj, originalClusterOperatorObj) {
@@ -167,9 +228,20 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return o.GetName() == arov1alpha1.SingletonClusterName
 	})
 
+	clusterVersionPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == "version"
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
 		Owns(&configv1.ClusterOperator{}).
+		Watches(
+			&source.Kind{Type: &configv1.ClusterVersion{}},
+			handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: arov1alpha1.SingletonClusterName}}}
+			}),
+			builder.WithPredicates(clusterVersionPredicate),
+		).
 		Named(ControllerName).
 		Complete(r)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	configv1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -272,3 +273,86 @@ func TestConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgradeableCondition(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name            string
+		history         []configv1.UpdateHistory
+		wantUpgradeable *configv1.ClusterOperatorStatusCondition
+	}{
+		{
+			name: "next minor version is supported",
+			history: []configv1.UpdateHistory{
+				{State: configv1.CompletedUpdate, Version: "4.10.40"},
+			},
+			wantUpgradeable: &configv1.ClusterOperatorStatusCondition{
+				Type:               configv1.OperatorUpgradeable,
+				Status:             configv1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "AsExpected",
+			},
+		},
+		{
+			name: "next minor version is not supported",
+			history: []configv1.UpdateHistory{
+				{State: configv1.PartialUpdate, Version: "4.13.1"},
+				{State: configv1.CompletedUpdate, Version: "4.12.25"},
+			},
+			wantUpgradeable: &configv1.ClusterOperatorStatusCondition{
+				Type:               configv1.OperatorUpgradeable,
+				Status:             configv1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "UnsupportedUpdate",
+				Message:            "updating clusters from version 4.12.25 to version 4.13.0 is not supported; supported next versions are: 4.12",
+			},
+		},
+		{
+			name: "version not in the update matrix",
+			history: []configv1.UpdateHistory{
+				{State: configv1.CompletedUpdate, Version: "4.8.11"},
+			},
+		},
+		{
+			name: "cluster still installing",
+			history: []configv1.UpdateHistory{
+				{State: configv1.PartialUpdate, Version: "4.12.25"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			clientFake := ctrlfake.NewClientBuilder().
+				WithObjects(
+					&arov1alpha1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+					},
+					&configv1.ClusterVersion{
+						ObjectMeta: metav1.ObjectMeta{Name: "version"},
+						Status: configv1.ClusterVersionStatus{
+							History: tt.history,
+						},
+					},
+				).
+				Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			operator := &configv1.ClusterOperator{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: clusterOperatorName}, operator)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			upgradeable := configv1helpers.FindStatusCondition(operator.Status.Conditions, configv1.OperatorUpgradeable)
+			if diff := cmp.Diff(tt.wantUpgradeable, upgradeable, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
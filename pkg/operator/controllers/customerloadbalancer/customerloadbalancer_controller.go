@@ -0,0 +1,200 @@
+package customerloadbalancer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	"github.com/Azure/ARO-RP/pkg/util/loadbalancer"
+)
+
+const (
+	ControllerName = "CustomerLoadBalancer"
+
+	controllerEnabled = "aro.customerloadbalancer.enabled"
+)
+
+// requeueInterval is how often the backend pools are checked.  The customer
+// load balancer can be changed out of band, which the controller cannot
+// watch.
+const requeueInterval = 10 * time.Minute
+
+// Reconciler keeps the backend pools of the cluster on the customer load
+// balancer in sync with the nodes
+type Reconciler struct {
+	base.AROController
+
+	newLoadBalancersClient func(ctx context.Context, instance *arov1alpha1.Cluster, subscriptionID string) (network.LoadBalancersClient, error)
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	r := &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+	r.newLoadBalancersClient = r.loadBalancersClient
+
+	return r
+}
+
+// Reconcile registers the internal IP addresses of the master nodes in the
+// API server backend pool and those of the other nodes in the ingress backend
+// pool of the customer load balancer
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.CustomerLoadBalancerID == "" {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.reconcileBackendAddressPools(ctx, instance)
+	if err != nil {
+		// hold back further Azure calls if this one was throttled
+		clusterauthorizer.OperatorRateLimiter.Observe(err)
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// loadBalancersClient returns a load balancers client authorized as the
+// cluster service principal
+func (r *Reconciler) loadBalancersClient(ctx context.Context, instance *arov1alpha1.Cluster, subscriptionID string) (network.LoadBalancersClient, error) {
+	err := clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return nil, err
+	}
+
+	azRefreshAuthorizer, err := clusterauthorizer.NewAzRefreshableAuthorizer(r.Log, &azEnv, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azRefreshAuthorizer.NewRefreshableAuthorizerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return network.NewLoadBalancersClient(&azEnv, subscriptionID, authorizer), nil
+}
+
+// reconcileBackendAddressPools updates the backend pools which the RP created
+// on the customer load balancer.  Pools which don't exist are left alone: the
+// RP creates them together with the probes and rules which use them, and
+// removes them when the cluster is deleted.
+func (r *Reconciler) reconcileBackendAddressPools(ctx context.Context, instance *arov1alpha1.Cluster) error {
+	nodes := &corev1.NodeList{}
+	err := r.Client.List(ctx, nodes)
+	if err != nil {
+		return err
+	}
+
+	masterIPs, workerIPs := loadbalancer.NodeIPs(nodes.Items)
+
+	resource, err := azure.ParseResourceID(instance.Spec.CustomerLoadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	loadBalancers, err := r.newLoadBalancersClient(ctx, instance, resource.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	lb, err := loadBalancers.Get(ctx, resource.ResourceGroup, resource.ResourceName, "")
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	for name, ips := range map[string][]string{
+		loadbalancer.APIServerBackendAddressPoolName(instance.Spec.InfraID): masterIPs,
+		loadbalancer.IngressBackendAddressPoolName(instance.Spec.InfraID):   workerIPs,
+	} {
+		if loadbalancer.HasBackendAddressPool(&lb, name) {
+			changed = loadbalancer.EnsureBackendAddressPool(&lb, name, instance.Spec.VnetID, ips) || changed
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	r.Log.Printf("updating the backend pools of customer load balancer %s", resource.String())
+	return loadBalancers.CreateOrUpdateAndWait(ctx, resource.ResourceGroup, resource.ResourceName, lb)
+}
+
+// nodeAddressesChangedPredicate passes the updates of nodes whose addresses
+// changed.  Nodes update their status every few minutes, which must not cause
+// a call to Azure each time.
+var nodeAddressesChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		return !reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+	},
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, nodeAddressesChangedPredicate)),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
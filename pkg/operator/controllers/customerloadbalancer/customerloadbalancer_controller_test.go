@@ -0,0 +1,246 @@
+package customerloadbalancer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	vnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet"
+	lbID := "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/customer-rg/providers/Microsoft.Network/loadBalancers/customer-lb"
+
+	node := func(name, ip string, master bool) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeHostName, Address: name},
+					{Type: corev1.NodeInternalIP, Address: ip},
+				},
+			},
+		}
+		if master {
+			node.Labels["node-role.kubernetes.io/master"] = ""
+		} else {
+			node.Labels["node-role.kubernetes.io/worker"] = ""
+		}
+		return node
+	}
+
+	pool := func(name string, ips ...string) mgmtnetwork.BackendAddressPool {
+		addresses := []mgmtnetwork.LoadBalancerBackendAddress{}
+		for _, ip := range ips {
+			addresses = append(addresses, mgmtnetwork.LoadBalancerBackendAddress{
+				LoadBalancerBackendAddressPropertiesFormat: &mgmtnetwork.LoadBalancerBackendAddressPropertiesFormat{
+					VirtualNetwork: &mgmtnetwork.SubResource{
+						ID: to.StringPtr(vnetID),
+					},
+					IPAddress: to.StringPtr(ip),
+				},
+				Name: to.StringPtr(name + "-" + ip),
+			})
+		}
+		return mgmtnetwork.BackendAddressPool{
+			BackendAddressPoolPropertiesFormat: &mgmtnetwork.BackendAddressPoolPropertiesFormat{
+				LoadBalancerBackendAddresses: &addresses,
+			},
+			Name: to.StringPtr(name),
+		}
+	}
+
+	lb := func(pools ...mgmtnetwork.BackendAddressPool) mgmtnetwork.LoadBalancer {
+		return mgmtnetwork.LoadBalancer{
+			ID: to.StringPtr(lbID),
+			LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+				BackendAddressPools: &pools,
+			},
+		}
+	}
+
+	nodes := []*corev1.Node{
+		node("master-0", "10.0.0.4", true),
+		node("master-1", "10.0.0.5", true),
+		node("worker-0", "10.0.1.4", false),
+		node("worker-1", "10.0.1.5", false),
+	}
+
+	for _, tt := range []struct {
+		name                   string
+		flags                  arov1alpha1.OperatorFlags
+		customerLoadBalancerID string
+		mocks                  func(*mock_network.MockLoadBalancersClient)
+		wantRequeue            time.Duration
+		wantErr                string
+		wantConditions         []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "false",
+			},
+			customerLoadBalancerID: lbID,
+			wantConditions:         defaultConditions,
+		},
+		{
+			name: "no customer load balancer",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "backend pools in sync",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			customerLoadBalancerID: lbID,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "customer-rg", "customer-lb", "").Return(lb(
+					pool("customer-pool", "192.168.0.4"),
+					pool("infra-api", "10.0.0.5", "10.0.0.4"),
+					pool("infra-ingress", "10.0.1.4", "10.0.1.5"),
+				), nil)
+			},
+			wantRequeue:    requeueInterval,
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "worker added and master replaced",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			customerLoadBalancerID: lbID,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "customer-rg", "customer-lb", "").Return(lb(
+					pool("customer-pool", "192.168.0.4"),
+					pool("infra-api", "10.0.0.4", "10.0.0.6"),
+					pool("infra-ingress", "10.0.1.4"),
+				), nil)
+				loadBalancers.EXPECT().CreateOrUpdateAndWait(gomock.Any(), "customer-rg", "customer-lb", lb(
+					pool("customer-pool", "192.168.0.4"),
+					pool("infra-api", "10.0.0.4", "10.0.0.5"),
+					pool("infra-ingress", "10.0.1.4", "10.0.1.5"),
+				)).Return(nil)
+			},
+			wantRequeue:    requeueInterval,
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "backend pools removed",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			customerLoadBalancerID: lbID,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "customer-rg", "customer-lb", "").Return(lb(
+					pool("customer-pool", "192.168.0.4"),
+				), nil)
+			},
+			wantRequeue:    requeueInterval,
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "azure error",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			customerLoadBalancerID: lbID,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "customer-rg", "customer-lb", "").Return(mgmtnetwork.LoadBalancer{}, errors.New("broken"))
+			},
+			wantErr:        "broken",
+			wantConditions: degraded("broken"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			loadBalancers := mock_network.NewMockLoadBalancersClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(loadBalancers)
+			}
+
+			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(&arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					InfraID:                "infra",
+					VnetID:                 vnetID,
+					CustomerLoadBalancerID: tt.customerLoadBalancerID,
+					OperatorFlags:          tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: defaultConditions,
+				},
+			})
+			for _, node := range nodes {
+				clientBuilder = clientBuilder.WithObjects(node)
+			}
+			clientFake := clientBuilder.Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			r.newLoadBalancersClient = func(ctx context.Context, instance *arov1alpha1.Cluster, subscriptionID string) (network.LoadBalancersClient, error) {
+				if subscriptionID != "11111111-1111-1111-1111-111111111111" {
+					t.Errorf("got subscription %s", subscriptionID)
+				}
+				return loadBalancers, nil
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got requeue after %s, wanted %s", result.RequeueAfter, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package customerloadbalancer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package keeps the backend pools of a cluster which was
+created with a customer load balancer in sync with its nodes.  The RP creates
+the <infraID>-api and <infraID>-ingress backend pools on the load balancer,
+together with their probes and load balancing rules, during the installation,
+and sets the load balancer in the customerLoadBalancerId of the Cluster
+resource.
+
+The controller registers the internal IP addresses of the master nodes in the
+API server pool and those of the other nodes in the ingress pool whenever a
+node is added, removed or relabelled, and every ten minutes in case the load
+balancer was changed out of band.  Pools which don't exist, e.g. because the
+RP removed them while deleting the cluster, are not recreated.
+
+The load balancer is updated as the cluster service principal, which the RP
+checks has read and write access to it when the cluster is created.
+
+These flags control the operations performed by this controller:
+
+aro.customerloadbalancer.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will keep the backend pools in sync with the nodes
+
+*/
@@ -0,0 +1,181 @@
+package defaultnodeselector
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "DefaultNodeSelector"
+
+	controllerEnabled = "aro.defaultnodeselector.enabled"
+
+	schedulerConfigName = "cluster"
+
+	// managedByAnnotation marks a default node selector set by the controller
+	managedByAnnotation = "aro.openshift.io/defaultnodeselector"
+
+	masterRoleLabel = "node-role.kubernetes.io/master"
+)
+
+// Reconciler sets the cluster-wide default node selector of the scheduler
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object, the scheduler configuration and the node
+// labels, and if any of them changes, reconciles the default node selector
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	if instance.Spec.DefaultNodeSelector == "" {
+		err = r.removeDefaultNodeSelector(ctx)
+	} else {
+		err = r.ensureDefaultNodeSelector(ctx, instance.Spec.DefaultNodeSelector)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) ensureDefaultNodeSelector(ctx context.Context, selector string) error {
+	scheduler := &configv1.Scheduler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: schedulerConfigName}, scheduler)
+	if err != nil {
+		return err
+	}
+
+	_, managed := scheduler.Annotations[managedByAnnotation]
+
+	// don't override a default node selector set up by the customer
+	if current := scheduler.Spec.DefaultNodeSelector; current != "" && current != selector && !managed {
+		return fmt.Errorf("default node selector is already set to %q", current)
+	}
+
+	if scheduler.Spec.DefaultNodeSelector == selector && managed {
+		return nil
+	}
+
+	err = r.checkWorkerNodesMatch(ctx, selector)
+	if err != nil {
+		return err
+	}
+
+	if scheduler.Annotations == nil {
+		scheduler.Annotations = map[string]string{}
+	}
+	scheduler.Annotations[managedByAnnotation] = "true"
+	scheduler.Spec.DefaultNodeSelector = selector
+	return r.Client.Update(ctx, scheduler)
+}
+
+// checkWorkerNodesMatch returns an error unless at least one worker node has
+// the labels of selector
+func (r *Reconciler) checkWorkerNodesMatch(ctx context.Context, selector string) error {
+	set, err := labels.ConvertSelectorToLabelsMap(selector)
+	if err != nil {
+		return fmt.Errorf("default node selector %q is invalid: %w", selector, err)
+	}
+
+	nodes := &corev1.NodeList{}
+	err = r.Client.List(ctx, nodes, client.MatchingLabels(set))
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[masterRoleLabel]; !ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("default node selector %q matches no worker node", selector)
+}
+
+// removeDefaultNodeSelector removes the default node selector if the
+// controller set it.  A default node selector set up by the customer is left
+// alone.
+func (r *Reconciler) removeDefaultNodeSelector(ctx context.Context) error {
+	scheduler := &configv1.Scheduler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: schedulerConfigName}, scheduler)
+	if err != nil {
+		return err
+	}
+
+	if _, managed := scheduler.Annotations[managedByAnnotation]; !managed {
+		return nil
+	}
+
+	delete(scheduler.Annotations, managedByAnnotation)
+	scheduler.Spec.DefaultNodeSelector = ""
+	return r.Client.Update(ctx, scheduler)
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	schedulerConfigPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == schedulerConfigName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.Scheduler{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(schedulerConfigPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
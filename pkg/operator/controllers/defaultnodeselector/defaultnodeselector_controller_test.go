@@ -0,0 +1,214 @@
+package defaultnodeselector
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	cluster := func(enabled, selector string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				DefaultNodeSelector: selector,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	// the scheduler configuration as created from the installer manifest
+	scheduler := func(selector string, managed bool) *configv1.Scheduler {
+		s := &configv1.Scheduler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: schedulerConfigName,
+			},
+			Spec: configv1.SchedulerSpec{
+				Profile:             configv1.HighNodeUtilization,
+				DefaultNodeSelector: selector,
+			},
+		}
+		if managed {
+			s.Annotations = map[string]string{managedByAnnotation: "true"}
+		}
+		return s
+	}
+
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}
+	}
+
+	master := node("master-0", map[string]string{masterRoleLabel: "", "region": "east"})
+	appWorker := node("worker-0", map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/app": ""})
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		wantErrMsg   string
+		wantSelector string
+		wantManaged  bool
+		wantConds    []operatorv1.OperatorCondition
+	}{
+		{
+			name: "disabled",
+			objects: []client.Object{
+				cluster("false", "node-role.kubernetes.io/app="),
+				scheduler("", false),
+				appWorker,
+			},
+		},
+		{
+			name: "no default node selector",
+			objects: []client.Object{
+				cluster("true", ""),
+				scheduler("", false),
+			},
+			wantConds: defaultConditions,
+		},
+		{
+			name: "default node selector is set",
+			objects: []client.Object{
+				cluster("true", "node-role.kubernetes.io/app="),
+				scheduler("", false),
+				master,
+				appWorker,
+			},
+			wantSelector: "node-role.kubernetes.io/app=",
+			wantManaged:  true,
+			wantConds:    defaultConditions,
+		},
+		{
+			name: "changed default node selector is reverted",
+			objects: []client.Object{
+				cluster("true", "node-role.kubernetes.io/app="),
+				scheduler("region=west", true),
+				appWorker,
+			},
+			wantSelector: "node-role.kubernetes.io/app=",
+			wantManaged:  true,
+			wantConds:    defaultConditions,
+		},
+		{
+			name: "default node selector matches no worker node",
+			objects: []client.Object{
+				cluster("true", "region=east"),
+				scheduler("", false),
+				master,
+				appWorker,
+			},
+			wantErrMsg: `default node selector "region=east" matches no worker node`,
+			wantConds:  degraded(`default node selector "region=east" matches no worker node`),
+		},
+		{
+			name: "default node selector of the customer is left alone",
+			objects: []client.Object{
+				cluster("true", "node-role.kubernetes.io/app="),
+				scheduler("region=west", false),
+				appWorker,
+			},
+			wantErrMsg:   `default node selector is already set to "region=west"`,
+			wantSelector: "region=west",
+			wantConds:    degraded(`default node selector is already set to "region=west"`),
+		},
+		{
+			name: "removed default node selector is cleared",
+			objects: []client.Object{
+				cluster("true", ""),
+				scheduler("node-role.kubernetes.io/app=", true),
+			},
+			wantConds: defaultConditions,
+		},
+		{
+			name: "default node selector of the customer is not cleared",
+			objects: []client.Object{
+				cluster("true", ""),
+				scheduler("region=west", false),
+			},
+			wantSelector: "region=west",
+			wantConds:    defaultConditions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConds != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConds)
+			}
+
+			s := &configv1.Scheduler{}
+			err = client.Get(ctx, types.NamespacedName{Name: schedulerConfigName}, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if s.Spec.DefaultNodeSelector != tt.wantSelector {
+				t.Errorf("got default node selector %q, want %q", s.Spec.DefaultNodeSelector, tt.wantSelector)
+			}
+			if _, managed := s.Annotations[managedByAnnotation]; managed != tt.wantManaged {
+				t.Errorf("got managed %v, want %v", managed, tt.wantManaged)
+			}
+			if s.Spec.Profile != configv1.HighNodeUtilization {
+				t.Errorf("got scheduler profile %q", s.Spec.Profile)
+			}
+		})
+	}
+}
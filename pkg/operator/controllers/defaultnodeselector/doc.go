@@ -0,0 +1,46 @@
+package defaultnodeselector
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package configures the cluster-wide default node
+selector, which keeps the workloads of the customer off the control plane and
+infra nodes by default.  The data path is:
+
+* The customer sets schedulerProfile.defaultNodeSelector on the cluster, a
+  comma separated list of key=value node labels.  The RP checks that it is
+  well formed and does not select the control plane or infra nodes, and
+  copies it to the DefaultNodeSelector field on the ARO Cluster object.
+
+* The Reconciler checks that at least one worker node has the labels, so that
+  the workloads of new projects are not left unschedulable, then sets
+  spec.defaultNodeSelector of the cluster config.openshift.io Scheduler object
+  and annotates the object as managed by ARO.  If no worker node has the
+  labels, the controller is Degraded and the Scheduler object is not changed;
+  it reconciles again when node labels change.
+
+The Scheduler object is created by the installer from its
+cluster-scheduler-02-config.yml manifest, which sets mastersSchedulable and
+may set a scheduler profile.  The controller never creates or replaces the
+object and only changes spec.defaultNodeSelector, so the other fields keep
+the values of the installer manifest.  If the customer has already set a
+default node selector by hand, the controller leaves it alone and is
+Degraded.
+
+The default node selector applies to the pods of projects which do not have an
+openshift.io/node-selector annotation of their own.  The platform namespaces
+have an empty annotation, so the platform pods are not affected.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.defaultnodeselector.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the default node selector
+  according to the DefaultNodeSelector field on the ARO Cluster object
+
+If the DefaultNodeSelector field is empty the controller removes the default
+node selector, if it set it.
+
+*/
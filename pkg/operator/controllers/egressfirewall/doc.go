@@ -0,0 +1,44 @@
+package egressfirewall
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package restricts the egress of the customer
+namespaces to the destinations which the customer allowed, complementing the
+NSG based egress lockdown with a cluster-native policy.
+
+* The customer sets egressFirewallProfile on the cluster, a list of CIDRs and
+  DNS names.  The RP copies it to the EgressFirewall field on the ARO Cluster
+  object.
+
+* The Reconciler ensures an egress firewall named default in every customer
+  namespace: an EgressFirewall (k8s.ovn.org/v1) on OVNKubernetes clusters, or
+  an EgressNetworkPolicy (network.openshift.io/v1) on OpenShiftSDN clusters.
+  It allows each destination in turn, then denies 0.0.0.0/0.  Namespaces
+  which have an egress firewall of their own, created by the customer, are
+  left alone.
+
+* Egress of the platform namespaces (default, openshift, kube-* and
+  openshift-*) is never restricted, so the egress required by the cluster
+  itself is unaffected.  However, pods reach the API servers on the host
+  network of the master nodes, which the egress firewall does apply to.  The
+  controller warns if the API server endpoints are not within an allowed CIDR,
+  as pods in the customer namespaces which use the Kubernetes API would fail.
+
+The egress firewalls aren't watched, as their kind depends on the network
+type of the cluster.  The controller requeues instead, so that changes made
+to them by hand are reverted.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.egressfirewall.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the egress firewalls
+  according to the EgressFirewall field on the ARO Cluster object
+
+If the EgressFirewall field is empty the controller removes the egress
+firewalls which it created, and egress is not restricted.
+
+*/
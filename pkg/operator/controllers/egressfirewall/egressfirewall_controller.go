@@ -0,0 +1,325 @@
+package egressfirewall
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/namespace"
+)
+
+const (
+	ControllerName = "EgressFirewall"
+
+	controllerEnabled = "aro.egressfirewall.enabled"
+
+	managedByLabel = "aro.openshift.io/egressfirewall"
+
+	// egressFirewallName is the name of the egress firewall of each
+	// namespace.  OVN-Kubernetes only enforces an EgressFirewall of this name.
+	egressFirewallName = "default"
+)
+
+var (
+	egressFirewallGVK      = schema.GroupVersionKind{Group: "k8s.ovn.org", Version: "v1", Kind: "EgressFirewall"}
+	egressNetworkPolicyGVK = schema.GroupVersionKind{Group: "network.openshift.io", Version: "v1", Kind: "EgressNetworkPolicy"}
+)
+
+// Reconciler ensures the egress firewall of every customer namespace, which
+// allows egress to the destinations chosen by the customer only
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the namespaces, and reconciles the
+// egress firewall of every customer namespace.  The egress firewalls
+// themselves aren't watched, as their kind depends on the network type of the
+// cluster; changes to them are reverted periodically.
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.reconcileEgressFirewalls(ctx, instance.Spec.EgressFirewall)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	if instance.Spec.EgressFirewall == nil {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: time.Hour}, nil
+}
+
+func (r *Reconciler) reconcileEgressFirewalls(ctx context.Context, spec *arov1alpha1.EgressFirewallSpec) error {
+	gvk, err := r.egressFirewallGVK(ctx)
+	if err != nil {
+		return err
+	}
+
+	if spec != nil {
+		err = r.warnIfAPIServerBlocked(ctx, spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	// a namespace may have a single egress firewall.  Those of the customer
+	// are left alone, along with their namespace.
+	existing := map[string]*unstructured.Unstructured{}
+	customer := map[string]struct{}{}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err = r.Client.List(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		o := &list.Items[i]
+		if isARO(o) {
+			existing[o.GetNamespace()] = o
+		} else {
+			customer[o.GetNamespace()] = struct{}{}
+		}
+	}
+
+	if spec == nil {
+		for _, o := range existing {
+			r.Log.Infof("deleting egress firewall of namespace %s", o.GetNamespace())
+			err = r.Client.Delete(ctx, o)
+			if err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	err = r.Client.List(ctx, namespaces)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, ns := range namespaces.Items {
+		if namespace.IsPlatformNamespace(ns.Name) || ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		if _, found := customer[ns.Name]; found {
+			// OpenShiftSDN does not support a second EgressNetworkPolicy in
+			// a namespace, so ours gives way
+			if o := existing[ns.Name]; o != nil {
+				r.Log.Infof("deleting egress firewall of namespace %s, which has its own", ns.Name)
+				err = r.Client.Delete(ctx, o)
+			}
+		} else {
+			err = r.ensureEgressFirewall(ctx, existing[ns.Name], egressFirewall(gvk, ns.Name, spec))
+		}
+		if err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// egressFirewallGVK returns the kind of egress firewall which the network
+// plugin of the cluster enforces
+func (r *Reconciler) egressFirewallGVK(ctx context.Context) (schema.GroupVersionKind, error) {
+	network := &configv1.Network{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, network)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+
+	switch network.Spec.NetworkType {
+	case "OVNKubernetes":
+		return egressFirewallGVK, nil
+	case "OpenShiftSDN":
+		return egressNetworkPolicyGVK, nil
+	}
+
+	return schema.GroupVersionKind{}, fmt.Errorf("network type %q does not support egress firewalls", network.Spec.NetworkType)
+}
+
+// warnIfAPIServerBlocked warns if the API servers aren't allowed.  Pods reach
+// them on the host network of the master nodes, which the egress firewall
+// applies to, so pods which use the Kubernetes API would fail.
+func (r *Reconciler) warnIfAPIServerBlocked(ctx context.Context, spec *arov1alpha1.EgressFirewallSpec) error {
+	endpoints := &corev1.Endpoints{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "kubernetes"}, endpoints)
+	if err != nil {
+		return err
+	}
+
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if !allows(spec, net.ParseIP(address.IP)) {
+				r.Log.Warnf("egress firewall does not allow the API server %s: pods in the customer namespaces will not be able to use the Kubernetes API", address.IP)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureEgressFirewall creates want, or updates have to it
+func (r *Reconciler) ensureEgressFirewall(ctx context.Context, have, want *unstructured.Unstructured) error {
+	if have == nil {
+		r.Log.Infof("creating egress firewall of namespace %s", want.GetNamespace())
+		return r.Client.Create(ctx, want)
+	}
+
+	if reflect.DeepEqual(have.Object["spec"], want.Object["spec"]) {
+		return nil
+	}
+
+	r.Log.Infof("updating egress firewall of namespace %s", want.GetNamespace())
+	have.Object["spec"] = want.Object["spec"]
+	return r.Client.Update(ctx, have)
+}
+
+// egressFirewall returns the egress firewall of kind gvk which allows the
+// destinations of spec from namespace, and denies any other egress.  The
+// rules of EgressFirewall and EgressNetworkPolicy objects have the same
+// schema.
+func egressFirewall(gvk schema.GroupVersionKind, namespace string, spec *arov1alpha1.EgressFirewallSpec) *unstructured.Unstructured {
+	rules := make([]interface{}, 0, len(spec.AllowedDestinations)+1)
+	for _, d := range spec.AllowedDestinations {
+		to := map[string]interface{}{}
+		if d.CIDR != "" {
+			to["cidrSelector"] = d.CIDR
+		} else {
+			to["dnsName"] = d.DNSName
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"type": "Allow",
+			"to":   to,
+		})
+	}
+
+	rules = append(rules, map[string]interface{}{
+		"type": "Deny",
+		"to": map[string]interface{}{
+			"cidrSelector": "0.0.0.0/0",
+		},
+	})
+
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(gvk)
+	o.SetName(egressFirewallName)
+	o.SetNamespace(namespace)
+	o.SetLabels(map[string]string{
+		managedByLabel: "true",
+	})
+	o.Object["spec"] = map[string]interface{}{
+		"egress": rules,
+	}
+	return o
+}
+
+// allows returns true if ip is in one of the CIDRs allowed by spec.  DNS
+// names aren't resolved.
+func allows(spec *arov1alpha1.EgressFirewallSpec, ip net.IP) bool {
+	for _, d := range spec.AllowedDestinations {
+		_, ipnet, err := net.ParseCIDR(d.CIDR)
+		if err == nil && ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isARO returns true if o was created by this controller
+func isARO(o *unstructured.Unstructured) bool {
+	return o.GetLabels()[managedByLabel] == "true"
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	// only new customer namespaces need an egress firewall
+	namespacePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return !namespace.IsPlatformNamespace(e.Object.GetName())
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			// every reconcile covers all namespaces, so events are collapsed
+			// into a single request
+			handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: arov1alpha1.SingletonClusterName}}}
+			}),
+			builder.WithPredicates(namespacePredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
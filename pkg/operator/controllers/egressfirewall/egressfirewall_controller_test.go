@@ -0,0 +1,301 @@
+package egressfirewall
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+	testlog "github.com/Azure/ARO-RP/test/util/log"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	spec := &arov1alpha1.EgressFirewallSpec{
+		AllowedDestinations: []arov1alpha1.EgressDestination{
+			{CIDR: "10.0.0.0/22"},
+			{DNSName: "www.example.com"},
+		},
+	}
+
+	cluster := func(enabled string, spec *arov1alpha1.EgressFirewallSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				EgressFirewall: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	network := func(networkType string) *configv1.Network {
+		return &configv1.Network{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec: configv1.NetworkSpec{
+				NetworkType: networkType,
+			},
+		}
+	}
+
+	apiServerEndpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kubernetes"},
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{IP: "10.0.0.6"},
+					{IP: "10.0.0.7"},
+					{IP: "10.0.0.8"},
+				},
+			},
+		},
+	}
+
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "customer"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-monitoring"}},
+	}
+
+	customerObject := func(o *unstructured.Unstructured, name string) *unstructured.Unstructured {
+		o.SetName(name)
+		o.SetLabels(nil)
+		return o
+	}
+
+	drifted := egressFirewall(egressFirewallGVK, "customer", &arov1alpha1.EgressFirewallSpec{})
+
+	for _, tt := range []struct {
+		name           string
+		objects        []client.Object
+		wantErr        string
+		wantConditions []operatorv1.OperatorCondition
+		wantObjects    []*unstructured.Unstructured
+		wantWarnings   int
+	}{
+		{
+			name:    "no cluster",
+			wantErr: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: append([]client.Object{
+				cluster("false", spec),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "no egress firewall",
+			objects: append([]client.Object{
+				cluster("true", nil),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "egress firewalls are created on OVNKubernetes",
+			objects: append([]client.Object{
+				cluster("true", spec),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				egressFirewall(egressFirewallGVK, "customer", spec),
+				egressFirewall(egressFirewallGVK, "other", spec),
+			},
+		},
+		{
+			name: "egress network policies are created on OpenShiftSDN",
+			objects: append([]client.Object{
+				cluster("true", spec),
+				network("OpenShiftSDN"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				egressFirewall(egressNetworkPolicyGVK, "customer", spec),
+				egressFirewall(egressNetworkPolicyGVK, "other", spec),
+			},
+		},
+		{
+			name: "drift is reverted and customer egress firewall is left alone",
+			objects: append([]client.Object{
+				cluster("true", spec),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+				drifted,
+				customerObject(egressFirewall(egressFirewallGVK, "other", &arov1alpha1.EgressFirewallSpec{}), "default"),
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				egressFirewall(egressFirewallGVK, "customer", spec),
+				customerObject(egressFirewall(egressFirewallGVK, "other", &arov1alpha1.EgressFirewallSpec{}), "default"),
+			},
+		},
+		{
+			name: "egress network policy gives way to the customer's",
+			objects: append([]client.Object{
+				cluster("true", spec),
+				network("OpenShiftSDN"),
+				apiServerEndpoints,
+				egressFirewall(egressNetworkPolicyGVK, "other", spec),
+				customerObject(egressFirewall(egressNetworkPolicyGVK, "other", &arov1alpha1.EgressFirewallSpec{}), "mine"),
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				egressFirewall(egressNetworkPolicyGVK, "customer", spec),
+				customerObject(egressFirewall(egressNetworkPolicyGVK, "other", &arov1alpha1.EgressFirewallSpec{}), "mine"),
+			},
+		},
+		{
+			name: "blocked API servers are warned about",
+			objects: append([]client.Object{
+				cluster("true", &arov1alpha1.EgressFirewallSpec{
+					AllowedDestinations: []arov1alpha1.EgressDestination{
+						{CIDR: "10.0.0.6/31"},
+					},
+				}),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				egressFirewall(egressFirewallGVK, "customer", &arov1alpha1.EgressFirewallSpec{
+					AllowedDestinations: []arov1alpha1.EgressDestination{
+						{CIDR: "10.0.0.6/31"},
+					},
+				}),
+				egressFirewall(egressFirewallGVK, "other", &arov1alpha1.EgressFirewallSpec{
+					AllowedDestinations: []arov1alpha1.EgressDestination{
+						{CIDR: "10.0.0.6/31"},
+					},
+				}),
+			},
+			wantWarnings: 1,
+		},
+		{
+			name: "egress firewalls are removed",
+			objects: append([]client.Object{
+				cluster("true", nil),
+				network("OVNKubernetes"),
+				apiServerEndpoints,
+				egressFirewall(egressFirewallGVK, "customer", spec),
+				customerObject(egressFirewall(egressFirewallGVK, "other", spec), "default"),
+			}, namespaces...),
+			wantConditions: defaultConditions,
+			wantObjects: []*unstructured.Unstructured{
+				customerObject(egressFirewall(egressFirewallGVK, "other", spec), "default"),
+			},
+		},
+		{
+			name: "unsupported network type is degraded",
+			objects: append([]client.Object{
+				cluster("true", spec),
+				network("Kuryr"),
+				apiServerEndpoints,
+			}, namespaces...),
+			wantErr:        `network type "Kuryr" does not support egress firewalls`,
+			wantConditions: degraded(`network type "Kuryr" does not support egress firewalls`),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			h, log := testlog.New()
+			r := NewReconciler(log, client)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			var got []*unstructured.Unstructured
+			for _, gvk := range []schema.GroupVersionKind{egressFirewallGVK, egressNetworkPolicyGVK} {
+				list := &unstructured.UnstructuredList{}
+				list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+				err = client.List(ctx, list)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range list.Items {
+					got = append(got, &list.Items[i])
+				}
+			}
+
+			if len(got) != len(tt.wantObjects) {
+				t.Fatalf("got %d egress firewalls, want %d", len(got), len(tt.wantObjects))
+			}
+			for i, want := range tt.wantObjects {
+				if got[i].GetKind() != want.GetKind() || got[i].GetNamespace() != want.GetNamespace() || got[i].GetName() != want.GetName() {
+					t.Errorf("got %s %s/%s, want %s %s/%s", got[i].GetKind(), got[i].GetNamespace(), got[i].GetName(), want.GetKind(), want.GetNamespace(), want.GetName())
+				}
+				if !reflect.DeepEqual(got[i].Object["spec"], want.Object["spec"]) {
+					t.Errorf("got spec %#v", got[i].Object["spec"])
+				}
+				if isARO(got[i]) != isARO(want) {
+					t.Errorf("got labels %v", got[i].GetLabels())
+				}
+			}
+
+			var warnings int
+			for _, e := range h.AllEntries() {
+				if e.Level == logrus.WarnLevel {
+					warnings++
+				}
+			}
+			if warnings != tt.wantWarnings {
+				t.Errorf("got %d warnings, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
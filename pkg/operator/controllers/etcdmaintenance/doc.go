@@ -0,0 +1,37 @@
+package etcdmaintenance
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package defragments the etcd members on a schedule, so
+that the database of busy clusters is kept small proactively rather than by
+running the EtcdDefragmentation admin update once fragmentation is alerted on.
+The compaction of etcd is left to the platform.
+
+Scheduled defragmentations work like the EtcdDefragmentation admin update: the members
+are defragmented one at a time, followers first and the leader last, and not
+at all, or no further, if any member is unhealthy.  A failed defragmentation
+is not retried before its next scheduled time, and scheduled times which pass
+while the operator is not running are skipped.
+
+The state of the maintenance, i.e. the times of the last and the next
+defragmentation and the result of the last one, is kept in the etcd-maintenance
+config map in the operator namespace, from which the monitor emits the
+etcd.maintenance metrics.
+
+These flags control the operations performed by this controller:
+
+aro.etcdmaintenance.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the etcd maintenance
+
+aro.etcdmaintenance.defragschedule:
+- A cron schedule in UTC, e.g. "0 3 * * 0" for Sundays at 03:00, which runs
+  at most daily; when unset, etcd is not defragmented on a schedule
+
+Any value out of bounds is rejected and the controller reports itself
+degraded.
+
+*/
@@ -0,0 +1,293 @@
+package etcdmaintenance
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+)
+
+const (
+	ControllerName = "EtcdMaintenance"
+
+	controllerEnabled = "aro.etcdmaintenance.enabled"
+)
+
+// The state of the maintenance is kept in a config map in the operator
+// namespace, from which the monitor emits its metrics
+const (
+	ConfigMapName = "etcd-maintenance"
+
+	KeyDefragSchedule   = "defragSchedule"
+	KeyNextDefragTime   = "nextDefragTime"
+	KeyLastDefragTime   = "lastDefragTime"
+	KeyLastDefragResult = "lastDefragResult"
+
+	DefragResultSucceeded = "Succeeded"
+	DefragResultFailed    = "Failed"
+)
+
+// defragHealthTimeout is how long we wait for all etcd members to report
+// healthy after defragmenting a member, before giving up on the rest
+var defragHealthTimeout = 5 * time.Minute
+
+var defragHealthInterval = 10 * time.Second
+
+// Reconciler defragments the etcd members on a schedule, according to the aro.etcdmaintenance operator flags
+type Reconciler struct {
+	base.AROController
+
+	etcdcli etcd.Client
+
+	// start is when the controller started; scheduled defragmentations
+	// which were missed before are skipped
+	start time.Time
+	now   func() time.Time
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client, etcdcli etcd.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+		etcdcli: etcdcli,
+		start:   time.Now(),
+		now:     time.Now,
+	}
+}
+
+// Reconcile watches the ARO object, and requeues itself when the next
+// defragmentation is due
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	config, err := operator.EtcdMaintenance(instance.Spec.OperatorFlags)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, nil
+	}
+
+	cm, err := r.getConfigMap(ctx)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+	data := map[string]string{}
+	for k, v := range cm.Data {
+		data[k] = v
+	}
+
+	now := r.now()
+
+	requeueAfter, defragErr := r.defragment(ctx, instance.Spec.OperatorFlags[operator.EtcdDefragScheduleFlag], config, data, now)
+
+	// the state is saved even if the maintenance failed, so that a failed
+	// defragmentation is not retried before its next scheduled time
+	err = r.saveConfigMap(ctx, cm, data)
+	if err == nil {
+		err = defragErr
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// defragment defragments the etcd members when the schedule is due.  It
+// returns when the next defragmentation is due.
+func (r *Reconciler) defragment(ctx context.Context, schedule string, config *operator.EtcdMaintenanceConfig, data map[string]string, now time.Time) (time.Duration, error) {
+	if config.DefragSchedule == nil {
+		delete(data, KeyDefragSchedule)
+		delete(data, KeyNextDefragTime)
+		return 0, nil
+	}
+	data[KeyDefragSchedule] = schedule
+
+	from := r.start
+	if last, err := time.Parse(time.RFC3339, data[KeyLastDefragTime]); err == nil && last.After(from) {
+		from = last
+	}
+
+	next := config.DefragSchedule.Next(from.UTC())
+	if now.Before(next) {
+		data[KeyNextDefragTime] = next.UTC().Format(time.RFC3339)
+		return next.Sub(now), nil
+	}
+
+	err := r.defragmentMembers(ctx)
+
+	data[KeyLastDefragTime] = now.UTC().Format(time.RFC3339)
+	data[KeyLastDefragResult] = DefragResultSucceeded
+	if err != nil {
+		data[KeyLastDefragResult] = DefragResultFailed
+	}
+
+	next = config.DefragSchedule.Next(now.UTC())
+	data[KeyNextDefragTime] = next.UTC().Format(time.RFC3339)
+
+	return next.Sub(now), err
+}
+
+// defragmentMembers defragments the etcd members one at a time, followers
+// first and the leader last, like the EtcdDefragmentation admin update.  It refuses to
+// start, and stops, if any member is unhealthy.
+func (r *Reconciler) defragmentMembers(ctx context.Context) error {
+	members, err := r.etcdcli.Members(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.membersHealthy(ctx, members)
+	if err != nil {
+		return fmt.Errorf("etcd is not healthy, not defragmenting: %w", err)
+	}
+
+	var leader string
+	for _, member := range members {
+		status, err := r.etcdcli.Status(ctx, member)
+		if err != nil {
+			return err
+		}
+		if status.Leader {
+			leader = member.Name
+		}
+	}
+
+	for _, member := range etcd.LeaderLast(members, leader) {
+		err = r.etcdcli.Defragment(ctx, member)
+		if err != nil {
+			return fmt.Errorf("defragmentation of etcd member %s failed: %w", member.Name, err)
+		}
+
+		err = r.waitMembersHealthy(ctx, members)
+		if err != nil {
+			return fmt.Errorf("etcd did not become healthy after defragmenting member %s, not defragmenting the remaining members: %w", member.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) membersHealthy(ctx context.Context, members []etcd.Member) error {
+	if len(members) == 0 {
+		return errors.New("no etcd members found")
+	}
+
+	for _, member := range members {
+		err := r.etcdcli.Health(ctx, member)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) waitMembersHealthy(ctx context.Context, members []etcd.Member) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, defragHealthTimeout)
+	defer cancel()
+
+	var err error
+	pollErr := wait.PollImmediateUntil(defragHealthInterval, func() (bool, error) {
+		err = r.membersHealthy(ctx, members)
+		return err == nil, nil
+	}, timeoutCtx.Done())
+	if pollErr != nil && err != nil {
+		return err
+	}
+
+	return pollErr
+}
+
+func (r *Reconciler) getConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+	if kerrors.IsNotFound(err) {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: operator.Namespace,
+				Name:      ConfigMapName,
+			},
+		}, nil
+	}
+
+	return cm, err
+}
+
+// saveConfigMap saves the state of the maintenance, deleting the config map
+// if there is no maintenance left to track
+func (r *Reconciler) saveConfigMap(ctx context.Context, cm *corev1.ConfigMap, data map[string]string) error {
+	if len(data) == len(cm.Data) {
+		changed := false
+		for k, v := range data {
+			if current, ok := cm.Data[k]; !ok || current != v {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	switch {
+	case cm.ResourceVersion == "":
+		cm.Data = data
+		return r.Client.Create(ctx, cm)
+	case len(data) == 0:
+		return r.Client.Delete(ctx, cm)
+	default:
+		cm.Data = data
+		return r.Client.Update(ctx, cm)
+	}
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,258 @@
+package etcdmaintenance
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/etcd"
+	mock_etcd "github.com/Azure/ARO-RP/pkg/util/mocks/etcd"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: operator.Namespace,
+				Name:      ConfigMapName,
+			},
+			Data: data,
+		}
+	}
+
+	// the operator started the day before, and it is now 03:00:30 UTC
+	start := time.Date(2023, time.May, 31, 2, 0, 0, 0, time.UTC)
+	now := time.Date(2023, time.June, 1, 3, 0, 30, 0, time.UTC)
+
+	members := []etcd.Member{
+		{Name: "etcd-master-0", IP: "10.0.0.6"},
+		{Name: "etcd-master-1", IP: "10.0.0.7"},
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		configMap       *corev1.ConfigMap
+		mocks           func(*mock_etcd.MockClient)
+		wantData        map[string]string
+		wantRequeue     time.Duration
+		wantErr         string
+		wantConditions  []operatorv1.OperatorCondition
+		startConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                    "false",
+				"aro.etcdmaintenance.defragschedule": "0 3 * * *",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "maintenance unset, platform behaviour kept",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "defragmentation unset after being set",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			configMap: configMap(map[string]string{
+				KeyDefragSchedule:   "0 3 * * *",
+				KeyNextDefragTime:   "2023-06-02T03:00:00Z",
+				KeyLastDefragTime:   "2023-06-01T03:00:00Z",
+				KeyLastDefragResult: "Succeeded",
+			}),
+			wantData: map[string]string{
+				KeyLastDefragTime:   "2023-06-01T03:00:00Z",
+				KeyLastDefragResult: "Succeeded",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "defragmentation not due",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                    "true",
+				"aro.etcdmaintenance.defragschedule": "0 3 * * *",
+			},
+			configMap: configMap(map[string]string{
+				KeyDefragSchedule:   "0 3 * * *",
+				KeyLastDefragTime:   "2023-06-01T03:00:00Z",
+				KeyLastDefragResult: "Succeeded",
+			}),
+			wantData: map[string]string{
+				KeyDefragSchedule:   "0 3 * * *",
+				KeyNextDefragTime:   "2023-06-02T03:00:00Z",
+				KeyLastDefragTime:   "2023-06-01T03:00:00Z",
+				KeyLastDefragResult: "Succeeded",
+			},
+			wantRequeue:     24*time.Hour - 30*time.Second,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "defragmentation due",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                    "true",
+				"aro.etcdmaintenance.defragschedule": "0 3 * * *",
+			},
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Health(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[0]).Return(&etcd.Status{Leader: true}, nil)
+				etcdcli.EXPECT().Status(gomock.Any(), members[1]).Return(&etcd.Status{}, nil)
+				gomock.InOrder(
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[1]).Return(nil),
+					etcdcli.EXPECT().Defragment(gomock.Any(), members[0]).Return(nil),
+				)
+			},
+			wantData: map[string]string{
+				KeyDefragSchedule:   "0 3 * * *",
+				KeyNextDefragTime:   "2023-06-02T03:00:00Z",
+				KeyLastDefragTime:   "2023-06-01T03:00:30Z",
+				KeyLastDefragResult: "Succeeded",
+			},
+			wantRequeue:     24*time.Hour - 30*time.Second,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "defragmentation due, etcd unhealthy",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                    "true",
+				"aro.etcdmaintenance.defragschedule": "0 3 * * *",
+			},
+			mocks: func(etcdcli *mock_etcd.MockClient) {
+				etcdcli.EXPECT().Members(gomock.Any()).Return(members, nil)
+				etcdcli.EXPECT().Health(gomock.Any(), members[0]).Return(errors.New("etcd member etcd-master-0 is unhealthy: raft"))
+			},
+			wantData: map[string]string{
+				KeyDefragSchedule:   "0 3 * * *",
+				KeyNextDefragTime:   "2023-06-02T03:00:00Z",
+				KeyLastDefragTime:   "2023-06-01T03:00:30Z",
+				KeyLastDefragResult: "Failed",
+			},
+			wantErr:         "etcd is not healthy, not defragmenting: etcd member etcd-master-0 is unhealthy: raft",
+			startConditions: defaultConditions,
+			wantConditions:  degraded("etcd is not healthy, not defragmenting: etcd member etcd-master-0 is unhealthy: raft"),
+		},
+		{
+			name: "invalid schedule",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                    "true",
+				"aro.etcdmaintenance.defragschedule": "@hourly",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  degraded("invalid aro.etcdmaintenance.defragschedule '@hourly': the schedule must run at most once every 24h0m0s"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			etcdcli := mock_etcd.NewMockClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(etcdcli)
+			}
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			objects := []client.Object{instance}
+			if tt.configMap != nil {
+				objects = append(objects, tt.configMap)
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(objects...).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake, etcdcli)
+			r.start = start
+			r.now = func() time.Time { return now }
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got requeue after %s, wanted %s", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			if tt.flags[controllerEnabled] == "false" {
+				return
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = clientFake.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+			if tt.wantData == nil {
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("config map %v", cm.Data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cm.Data, tt.wantData) {
+				t.Errorf("got %v, wanted %v", cm.Data, tt.wantData)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package identityprovider
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package configures the OpenID Connect identity provider
+which the customer chose at install time in the cluster OAuth server.  The
+data path is:
+
+* The customer sets identityProviderProfile on the cluster.  The RP stores the
+  client secret encrypted in the cluster document, and never returns it.
+
+* The RP copies the name, issuer and client ID of the identity provider to the
+  IdentityProvider field on the ARO Cluster object, and the client secret to
+  the identityProviderClientSecret key of the operator secret
+  (openshift-azure-operator/cluster), so that it is not readable from the
+  Cluster object.
+
+* The Reconciler copies the client secret to the
+  aro-identity-provider-client-secret Secret in the openshift-config
+  namespace, where the OAuth server reads it.
+
+* The Reconciler ensures the identity provider which refers to that Secret in
+  the config.openshift.io OAuth object.  Other identity providers, which the
+  customer may add after install, are left alone; if one of them has the same
+  name the controller is Degraded.
+
+The controller watches the OAuth object and the Secrets, so changes made to
+them by hand are reverted.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.identityprovider.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the identity provider
+  according to the IdentityProvider field on the ARO Cluster object
+
+If the IdentityProvider field is empty the controller removes the identity
+provider and its Secret, if it created them.
+
+*/
@@ -0,0 +1,280 @@
+package identityprovider
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "IdentityProvider"
+
+	controllerEnabled = "aro.identityprovider.enabled"
+
+	// ClientSecretKey is the key of the identity provider client secret in
+	// the operator secret
+	ClientSecretKey = "identityProviderClientSecret"
+
+	oauthConfigName = "cluster"
+
+	clientSecretName      = "aro-identity-provider-client-secret"
+	clientSecretNamespace = "openshift-config"
+	clientSecretDataKey   = "clientSecret"
+)
+
+// Reconciler ensures the customer OpenID Connect identity provider in the
+// cluster OAuth configuration
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object, the operator secret, the OAuth
+// configuration and the identity provider client secret, and if any of them
+// changes, reconciles the identity provider
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	if instance.Spec.IdentityProvider == nil {
+		err = r.removeIdentityProvider(ctx)
+	} else {
+		err = r.ensureIdentityProvider(ctx, instance.Spec.IdentityProvider)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) ensureIdentityProvider(ctx context.Context, spec *arov1alpha1.IdentityProviderSpec) error {
+	operatorSecret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: operator.SecretName}, operatorSecret)
+	if err != nil {
+		return err
+	}
+
+	clientSecret := operatorSecret.Data[ClientSecretKey]
+	if len(clientSecret) == 0 {
+		return fmt.Errorf("operator secret has no %s", ClientSecretKey)
+	}
+
+	oauth := &configv1.OAuth{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: oauthConfigName}, oauth)
+	if err != nil {
+		return err
+	}
+
+	// don't override an identity provider set up by the customer
+	for i := range oauth.Spec.IdentityProviders {
+		if oauth.Spec.IdentityProviders[i].Name == spec.Name && !isARO(&oauth.Spec.IdentityProviders[i]) {
+			return fmt.Errorf("identity provider %q is already configured", spec.Name)
+		}
+	}
+
+	err = r.createOrUpdateClientSecret(ctx, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	want := identityProvider(spec)
+
+	identityProviders := make([]configv1.IdentityProvider, 0, len(oauth.Spec.IdentityProviders)+1)
+	var found bool
+	for i := range oauth.Spec.IdentityProviders {
+		idp := oauth.Spec.IdentityProviders[i]
+		if isARO(&idp) {
+			if found {
+				continue
+			}
+			found = true
+			idp = want
+		}
+		identityProviders = append(identityProviders, idp)
+	}
+	if !found {
+		identityProviders = append(identityProviders, want)
+	}
+
+	if reflect.DeepEqual(oauth.Spec.IdentityProviders, identityProviders) {
+		return nil
+	}
+
+	oauth.Spec.IdentityProviders = identityProviders
+	return r.Client.Update(ctx, oauth)
+}
+
+func (r *Reconciler) createOrUpdateClientSecret(ctx context.Context, clientSecret []byte) error {
+	data := map[string][]byte{
+		clientSecretDataKey: clientSecret,
+	}
+
+	s := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: clientSecretNamespace, Name: clientSecretName}, s)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clientSecretName,
+				Namespace: clientSecretNamespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(s.Data, data) {
+		return nil
+	}
+
+	s.Data = data
+	return r.Client.Update(ctx, s)
+}
+
+// removeIdentityProvider removes the ARO identity provider from the OAuth
+// configuration and deletes its client secret.  Identity providers set up by
+// the customer are left alone.
+func (r *Reconciler) removeIdentityProvider(ctx context.Context) error {
+	oauth := &configv1.OAuth{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: oauthConfigName}, oauth)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		identityProviders := make([]configv1.IdentityProvider, 0, len(oauth.Spec.IdentityProviders))
+		for i := range oauth.Spec.IdentityProviders {
+			if !isARO(&oauth.Spec.IdentityProviders[i]) {
+				identityProviders = append(identityProviders, oauth.Spec.IdentityProviders[i])
+			}
+		}
+
+		if len(identityProviders) != len(oauth.Spec.IdentityProviders) {
+			oauth.Spec.IdentityProviders = identityProviders
+			err = r.Client.Update(ctx, oauth)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = r.Client.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clientSecretName,
+			Namespace: clientSecretNamespace,
+		},
+	})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// identityProvider returns the OAuth identity provider for spec.  The claims
+// and scopes suit Azure AD as well as other OpenID Connect providers.
+func identityProvider(spec *arov1alpha1.IdentityProviderSpec) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name:          spec.Name,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeOpenID,
+			OpenID: &configv1.OpenIDIdentityProvider{
+				ClientID: spec.ClientID,
+				ClientSecret: configv1.SecretNameReference{
+					Name: clientSecretName,
+				},
+				ExtraScopes: []string{"email", "profile"},
+				Issuer:      spec.Issuer,
+				Claims: configv1.OpenIDClaims{
+					PreferredUsername: []string{"preferred_username", "email"},
+					Name:              []string{"name"},
+					Email:             []string{"email"},
+				},
+			},
+		},
+	}
+}
+
+// isARO returns true if idp is the identity provider managed by this
+// controller, i.e. the OpenID identity provider which refers to its client
+// secret
+func isARO(idp *configv1.IdentityProvider) bool {
+	return idp.Type == configv1.IdentityProviderTypeOpenID &&
+		idp.OpenID != nil &&
+		idp.OpenID.ClientSecret.Name == clientSecretName
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	oauthConfigPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == oauthConfigName
+	})
+
+	secretPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return (o.GetNamespace() == operator.Namespace && o.GetName() == operator.SecretName) ||
+			(o.GetNamespace() == clientSecretNamespace && o.GetName() == clientSecretName)
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.OAuth{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(oauthConfigPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(secretPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
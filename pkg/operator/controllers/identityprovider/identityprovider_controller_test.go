@@ -0,0 +1,251 @@
+package identityprovider
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	spec := &arov1alpha1.IdentityProviderSpec{
+		Name:     "AzureAD",
+		Issuer:   "https://login.microsoftonline.com/tenant/v2.0",
+		ClientID: "clientId",
+	}
+
+	cluster := func(enabled string, spec *arov1alpha1.IdentityProviderSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				IdentityProvider: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	operatorSecret := func(clientSecret string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      operator.SecretName,
+				Namespace: operator.Namespace,
+			},
+			Data: map[string][]byte{},
+		}
+		if clientSecret != "" {
+			s.Data[ClientSecretKey] = []byte(clientSecret)
+		}
+		return s
+	}
+
+	clientSecret := func(value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clientSecretName,
+				Namespace: clientSecretNamespace,
+			},
+			Data: map[string][]byte{
+				clientSecretDataKey: []byte(value),
+			},
+		}
+	}
+
+	customerIdentityProvider := func(name string) configv1.IdentityProvider {
+		return configv1.IdentityProvider{
+			Name: name,
+			IdentityProviderConfig: configv1.IdentityProviderConfig{
+				Type: configv1.IdentityProviderTypeHTPasswd,
+				HTPasswd: &configv1.HTPasswdIdentityProvider{
+					FileData: configv1.SecretNameReference{Name: "htpasswd"},
+				},
+			},
+		}
+	}
+
+	driftedIdentityProvider := identityProvider(spec)
+	driftedIdentityProvider.OpenID.Issuer = "https://issuer.example.com"
+
+	oauthConfig := func(identityProviders ...configv1.IdentityProvider) *configv1.OAuth {
+		return &configv1.OAuth{
+			ObjectMeta: metav1.ObjectMeta{Name: oauthConfigName},
+			Spec: configv1.OAuthSpec{
+				IdentityProviders: identityProviders,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		objects               []client.Object
+		wantErrMsg            string
+		wantConditions        []operatorv1.OperatorCondition
+		wantIdentityProviders []string
+		wantClientSecret      string
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", spec),
+				operatorSecret("secret"),
+				oauthConfig(),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "identity provider is created",
+			objects: []client.Object{
+				cluster("true", spec),
+				operatorSecret("secret"),
+				oauthConfig(customerIdentityProvider("htpasswd")),
+			},
+			wantConditions:        defaultConditions,
+			wantIdentityProviders: []string{"htpasswd", "AzureAD"},
+			wantClientSecret:      "secret",
+		},
+		{
+			name: "identity provider drift is reverted",
+			objects: []client.Object{
+				cluster("true", spec),
+				operatorSecret("rotated"),
+				oauthConfig(driftedIdentityProvider, customerIdentityProvider("htpasswd")),
+				clientSecret("secret"),
+			},
+			wantConditions:        defaultConditions,
+			wantIdentityProviders: []string{"AzureAD", "htpasswd"},
+			wantClientSecret:      "rotated",
+		},
+		{
+			name: "customer identity provider with the same name is left alone",
+			objects: []client.Object{
+				cluster("true", spec),
+				operatorSecret("secret"),
+				oauthConfig(customerIdentityProvider("AzureAD")),
+			},
+			wantErrMsg:            `identity provider "AzureAD" is already configured`,
+			wantConditions:        degraded(`identity provider "AzureAD" is already configured`),
+			wantIdentityProviders: []string{"AzureAD"},
+		},
+		{
+			name: "missing client secret is degraded",
+			objects: []client.Object{
+				cluster("true", spec),
+				operatorSecret(""),
+				oauthConfig(),
+			},
+			wantErrMsg:     "operator secret has no identityProviderClientSecret",
+			wantConditions: degraded("operator secret has no identityProviderClientSecret"),
+		},
+		{
+			name: "identity provider is removed",
+			objects: []client.Object{
+				cluster("true", nil),
+				operatorSecret(""),
+				oauthConfig(customerIdentityProvider("htpasswd"), identityProvider(spec)),
+				clientSecret("secret"),
+			},
+			wantConditions:        defaultConditions,
+			wantIdentityProviders: []string{"htpasswd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			oauth := &configv1.OAuth{}
+			err = client.Get(ctx, types.NamespacedName{Name: oauthConfigName}, oauth)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for i := range oauth.Spec.IdentityProviders {
+				idp := &oauth.Spec.IdentityProviders[i]
+				names = append(names, idp.Name)
+				if isARO(idp) && !reflect.DeepEqual(*idp, identityProvider(spec)) {
+					t.Errorf("got identity provider %#v", idp)
+				}
+			}
+			if len(names) != len(tt.wantIdentityProviders) {
+				t.Fatalf("got identity providers %v, want %v", names, tt.wantIdentityProviders)
+			}
+			for i := range names {
+				if names[i] != tt.wantIdentityProviders[i] {
+					t.Errorf("got identity providers %v, want %v", names, tt.wantIdentityProviders)
+				}
+			}
+
+			s := &corev1.Secret{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: clientSecretNamespace, Name: clientSecretName}, s)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if got := string(s.Data[clientSecretDataKey]); got != tt.wantClientSecret {
+				t.Errorf("got client secret %q, want %q", got, tt.wantClientSecret)
+			}
+		})
+	}
+}
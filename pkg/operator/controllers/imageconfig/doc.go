@@ -20,6 +20,9 @@ If aro.imageconfig.enabled=true and manifest contains AllowedRegistries:
 If aro.imageconfig.enabled=true and manifest contains Blocked Registries:
 - The controller will ensure the required registries are NOT part of blocked registries
 
+The required registries include the mirrors of the RegistryMirrors field on the
+ARO Cluster object, which the RegistryMirror controller configures.
+
 If aro.imageconfig.enabled=true and manifest contains both AllowedRegistries & BlockedRegistries:
 - The controller will fail silently and not requeue as this is not a supported action
 
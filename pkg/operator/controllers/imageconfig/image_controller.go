@@ -16,8 +16,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
@@ -48,6 +50,7 @@ func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
 }
 
 // watches the ARO object for changes and reconciles image.config.openshift.io/cluster object.
+// The required registries include the mirrors of any registry mirrors.
 // - If blockedRegistries is not nil, makes sure required registries are not added
 // - If AllowedRegistries is not nil, makes sure required registries are added
 // - Fails fast if both are not nil, unsupported
@@ -69,6 +72,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		// Not returning error as it will requeue again
 		return reconcile.Result{}, nil
 	}
+	for _, m := range instance.Spec.RegistryMirrors {
+		requiredRegistries = append(requiredRegistries, m.Mirror)
+	}
 
 	// Get image.config yaml
 	imageconfig := &configv1.Image{}
@@ -127,8 +133,19 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return o.GetName() == imageConfigResource
 	})
 
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	// the ARO object and the image config are both named "cluster", so the
+	// ARO object can be enqueued as it is
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&configv1.Image{}, builder.WithPredicates(imagePredicate)).
+		Watches(
+			&source.Kind{Type: &arov1alpha1.Cluster{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(aroClusterPredicate),
+		).
 		Named(ControllerName).
 		Complete(r)
 }
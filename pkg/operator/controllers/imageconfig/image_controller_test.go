@@ -112,6 +112,50 @@ func TestImageConfigReconciler(t *testing.T) {
 			startConditions: defaultConditions,
 			wantConditions:  defaultConditions,
 		},
+		{
+			name: "registry mirrors are added to allowedRegistries",
+			instance: &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+				Spec: arov1alpha1.ClusterSpec{
+					ACRDomain:     "arointsvc.azurecr.io",
+					AZEnvironment: azureclient.PublicCloud.Environment.Name,
+					OperatorFlags: arov1alpha1.OperatorFlags{
+						controllerEnabled: strconv.FormatBool(true),
+					},
+					Location: "eastus",
+					RegistryMirrors: []arov1alpha1.RegistryMirrorSpec{
+						{
+							Source: "docker.io",
+							Mirror: "example.azurecr.io/docker.io",
+						},
+					},
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: defaultConditions,
+				},
+			},
+			image: &configv1.Image{
+				ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+				Spec: configv1.ImageSpec{
+					RegistrySources: configv1.RegistrySources{
+						AllowedRegistries: []string{
+							"quay.io",
+							"example.azurecr.io/docker.io",
+						},
+					},
+				},
+			},
+			wantRegistrySources: configv1.RegistrySources{
+				AllowedRegistries: []string{
+					"quay.io",
+					"arointsvc.azurecr.io",
+					"arointsvc.eastus.data.azurecr.io",
+					"example.azurecr.io/docker.io",
+				},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
 		{
 			name: "blockedRegistries exists, function should delete registries",
 			image: &configv1.Image{
@@ -5,10 +5,18 @@ package ingress
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -23,14 +31,37 @@ import (
 const (
 	ControllerName = "IngressControllerARO"
 
-	controllerEnabled                   = "aro.ingress.enabled"
+	controllerEnabled      = "aro.ingress.enabled"
+	controllerReplicas     = "aro.ingress.replicas"
+	controllerNodeSelector = "aro.ingress.nodeselector"
+	controllerTolerations  = "aro.ingress.tolerations"
+
 	openshiftIngressControllerNamespace = "openshift-ingress-operator"
 	openshiftIngressControllerName      = "default"
 	minimumReplicas                     = 2
+
+	// placementRequeueInterval is how often a placement which does not
+	// match enough nodes is checked again, as nodes are not watched
+	placementRequeueInterval = 5 * time.Minute
+
+	// defaultNodeSelector matches the nodes the openshift ingress operator
+	// schedules router pods on when no node placement is set
+	defaultNodeSelector = "node-role.kubernetes.io/worker="
+
+	// managedByAnnotation marks a node placement set by the controller
+	managedByAnnotation = "aro.openshift.io/ingress-nodeplacement"
 )
 
 // Reconciler spots openshift ingress controllers has abnormal replica counts (less than 2)
-// when happens, it tries to rescale the controller to 2 replicas, i.e., the minimum required replicas
+// when happens, it tries to rescale the controller to 2 replicas, i.e., the minimum required replicas.
+//
+// If the aro.ingress.replicas, aro.ingress.nodeselector or
+// aro.ingress.tolerations operator flags are set, it also enforces the
+// corresponding replica count and node placement on the default ingress
+// controller, provided that enough nodes match the placement.  Otherwise the
+// configuration is not applied and the controller reports the reason in its
+// Progressing condition.  When the node placement flags are cleared, the node
+// placement set by the controller is removed, restoring the default placement.
 type Reconciler struct {
 	base.AROController
 }
@@ -65,8 +96,60 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, err
 	}
 
-	if ingress.Spec.Replicas != nil && *ingress.Spec.Replicas < minimumReplicas {
+	replicas, nodePlacement, err := desiredConfig(instance.Spec.OperatorFlags)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, nil
+	}
+
+	if replicas != nil || nodePlacement != nil {
+		warning, err := r.validatePlacement(ctx, replicas, nodePlacement)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+
+		// the configuration is kept as it is until enough nodes match the
+		// placement, which is a warning rather than a failure
+		if warning != "" {
+			r.Log.Warn(warning)
+			r.SetProgressing(ctx, "not applying the ingress configuration: "+warning)
+			r.ClearDegraded(ctx)
+			return reconcile.Result{RequeueAfter: placementRequeueInterval}, nil
+		}
+	}
+
+	changed := false
+	if replicas != nil {
+		if ingress.Spec.Replicas == nil || *ingress.Spec.Replicas != *replicas {
+			ingress.Spec.Replicas = replicas
+			changed = true
+		}
+	} else if ingress.Spec.Replicas != nil && *ingress.Spec.Replicas < minimumReplicas {
 		ingress.Spec.Replicas = to.Int32Ptr(minimumReplicas)
+		changed = true
+	}
+
+	_, managed := ingress.Annotations[managedByAnnotation]
+	if nodePlacement != nil {
+		if !equality.Semantic.DeepEqual(ingress.Spec.NodePlacement, nodePlacement) || !managed {
+			if ingress.Annotations == nil {
+				ingress.Annotations = map[string]string{}
+			}
+			ingress.Annotations[managedByAnnotation] = "true"
+			ingress.Spec.NodePlacement = nodePlacement
+			changed = true
+		}
+	} else if managed {
+		// a node placement set up by the customer is left alone
+		delete(ingress.Annotations, managedByAnnotation)
+		ingress.Spec.NodePlacement = nil
+		changed = true
+	}
+
+	if changed {
 		err := r.Client.Update(ctx, ingress)
 		if err != nil {
 			r.Log.Error(err)
@@ -79,6 +162,117 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	return reconcile.Result{}, nil
 }
 
+// validatePlacement checks that the nodes matched by the desired node
+// placement can host the desired number of router replicas.  It returns a
+// warning if they cannot.
+func (r *Reconciler) validatePlacement(ctx context.Context, replicas *int32, nodePlacement *operatorv1.NodePlacement) (string, error) {
+	selector, err := labels.ConvertSelectorToLabelsMap(defaultNodeSelector)
+	if err != nil {
+		return "", err
+	}
+	if nodePlacement != nil && nodePlacement.NodeSelector != nil {
+		selector = nodePlacement.NodeSelector.MatchLabels
+	}
+
+	nodes := &corev1.NodeList{}
+	err = r.Client.List(ctx, nodes, client.MatchingLabels(selector))
+	if err != nil {
+		return "", err
+	}
+
+	if len(nodes.Items) == 0 {
+		return fmt.Sprintf("ingress node selector %q matches no nodes", labels.FormatLabels(selector)), nil
+	}
+
+	if replicas != nil && int(*replicas) > len(nodes.Items) {
+		return fmt.Sprintf("ingress replica count %d exceeds the %d nodes matching node selector %q", *replicas, len(nodes.Items), labels.FormatLabels(selector)), nil
+	}
+
+	return "", nil
+}
+
+// desiredConfig parses the ingress operator flags.  It returns nil values for
+// flags which are unset, in which case the current behaviour is kept.
+func desiredConfig(flags arov1alpha1.OperatorFlags) (*int32, *operatorv1.NodePlacement, error) {
+	var replicas *int32
+	if v := flags.GetWithDefault(controllerReplicas, ""); v != "" {
+		i, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || i < minimumReplicas {
+			return nil, nil, fmt.Errorf("invalid %s %q: must be an integer of at least %d", controllerReplicas, v, minimumReplicas)
+		}
+		replicas = to.Int32Ptr(int32(i))
+	}
+
+	var nodePlacement *operatorv1.NodePlacement
+	if v := flags.GetWithDefault(controllerNodeSelector, ""); v != "" {
+		selector, err := labels.ConvertSelectorToLabelsMap(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s %q: %w", controllerNodeSelector, v, err)
+		}
+
+		nodePlacement = &operatorv1.NodePlacement{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+		}
+	}
+
+	if v := flags.GetWithDefault(controllerTolerations, ""); v != "" {
+		tolerations, err := parseTolerations(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s %q: %w", controllerTolerations, v, err)
+		}
+
+		if nodePlacement == nil {
+			nodePlacement = &operatorv1.NodePlacement{}
+		}
+		nodePlacement.Tolerations = tolerations
+	}
+
+	return replicas, nodePlacement, nil
+}
+
+// parseTolerations parses a comma separated list of tolerations in the same
+// format as `kubectl taint`, i.e. key[=value]:effect.  Tolerations without a
+// value use the Exists operator.
+func parseTolerations(s string) ([]corev1.Toleration, error) {
+	var tolerations []corev1.Toleration
+
+	for _, t := range strings.Split(s, ",") {
+		keyValue, effect, found := strings.Cut(t, ":")
+		if !found {
+			return nil, fmt.Errorf("toleration %q has no effect", t)
+		}
+
+		switch corev1.TaintEffect(effect) {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("toleration %q has unknown effect %q", t, effect)
+		}
+
+		toleration := corev1.Toleration{
+			Effect: corev1.TaintEffect(effect),
+		}
+
+		key, value, found := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("toleration %q has no key", t)
+		}
+		toleration.Key = key
+
+		if found {
+			toleration.Operator = corev1.TolerationOpEqual
+			toleration.Value = value
+		} else {
+			toleration.Operator = corev1.TolerationOpExists
+		}
+
+		tolerations = append(tolerations, toleration)
+	}
+
+	return tolerations, nil
+}
+
 // SetupWithManager setup the mananger for openshift ingress controller resource
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
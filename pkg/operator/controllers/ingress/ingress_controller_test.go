@@ -5,15 +5,18 @@ package ingress
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
@@ -29,8 +32,8 @@ func TestReconciler(t *testing.T) {
 	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
 	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
 
-	fakeCluster := func(controllerEnabledFlag string) *arov1alpha1.Cluster {
-		return &arov1alpha1.Cluster{
+	fakeCluster := func(controllerEnabledFlag string, flags map[string]string) *arov1alpha1.Cluster {
+		cluster := &arov1alpha1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: arov1alpha1.SingletonClusterName,
 			},
@@ -40,13 +43,70 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		}
+		for k, v := range flags {
+			cluster.Spec.OperatorFlags[k] = v
+		}
+		return cluster
+	}
+
+	fakeNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}
+	}
+
+	workerLabels := map[string]string{"node-role.kubernetes.io/worker": ""}
+	infraLabels := map[string]string{"node-role.kubernetes.io/infra": ""}
+
+	defaultIngressController := func() *operatorv1.IngressController {
+		return &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      openshiftIngressControllerName,
+				Namespace: openshiftIngressControllerNamespace,
+			},
+			Spec: operatorv1.IngressControllerSpec{
+				Replicas: to.Int32Ptr(minimumReplicas),
+			},
+		}
+	}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	progressing := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeProgressing,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+			defaultDegraded,
+		}
 	}
 
 	tests := []struct {
 		name                  string
 		controllerEnabledFlag string
+		flags                 map[string]string
+		nodes                 []client.Object
 		ingressController     *operatorv1.IngressController
 		expectedReplica       int32
+		expectedNodePlacement *operatorv1.NodePlacement
 		expectedError         string
 		startConditions       []operatorv1.OperatorCondition
 		wantConditions        []operatorv1.OperatorCondition
@@ -155,16 +215,131 @@ func TestReconciler(t *testing.T) {
 			},
 			wantConditions: defaultConditions,
 		},
+		{
+			name:                  "replica count flag scales the ingress controller",
+			controllerEnabledFlag: "true",
+			flags:                 map[string]string{controllerReplicas: "3"},
+			nodes: []client.Object{
+				fakeNode("worker-1", workerLabels),
+				fakeNode("worker-2", workerLabels),
+				fakeNode("worker-3", workerLabels),
+			},
+			ingressController: defaultIngressController(),
+			expectedReplica:   3,
+			startConditions:   defaultConditions,
+			wantConditions:    defaultConditions,
+		},
+		{
+			name:                  "replica count flag exceeding matching nodes is not applied",
+			controllerEnabledFlag: "true",
+			flags:                 map[string]string{controllerReplicas: "3"},
+			nodes: []client.Object{
+				fakeNode("worker-1", workerLabels),
+				fakeNode("worker-2", workerLabels),
+				fakeNode("infra-1", infraLabels),
+			},
+			ingressController: defaultIngressController(),
+			expectedReplica:   minimumReplicas,
+			startConditions:   defaultConditions,
+			wantConditions:    progressing(`not applying the ingress configuration: ingress replica count 3 exceeds the 2 nodes matching node selector "node-role.kubernetes.io/worker="`),
+		},
+		{
+			name:                  "invalid replica count flag",
+			controllerEnabledFlag: "true",
+			flags:                 map[string]string{controllerReplicas: "1"},
+			ingressController:     defaultIngressController(),
+			expectedReplica:       minimumReplicas,
+			startConditions:       defaultConditions,
+			wantConditions:        degraded(`invalid aro.ingress.replicas "1": must be an integer of at least 2`),
+		},
+		{
+			name:                  "node placement flags are applied",
+			controllerEnabledFlag: "true",
+			flags: map[string]string{
+				controllerNodeSelector: "node-role.kubernetes.io/infra=",
+				controllerTolerations:  "node-role.kubernetes.io/infra:NoSchedule",
+			},
+			nodes: []client.Object{
+				fakeNode("infra-1", infraLabels),
+				fakeNode("infra-2", infraLabels),
+			},
+			ingressController: defaultIngressController(),
+			expectedReplica:   minimumReplicas,
+			expectedNodePlacement: &operatorv1.NodePlacement{
+				NodeSelector: &metav1.LabelSelector{
+					MatchLabels: infraLabels,
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name:                  "node placement set by the controller is removed when the flags are cleared",
+			controllerEnabledFlag: "true",
+			ingressController: func() *operatorv1.IngressController {
+				ingress := defaultIngressController()
+				ingress.Annotations = map[string]string{managedByAnnotation: "true"}
+				ingress.Spec.NodePlacement = &operatorv1.NodePlacement{
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: infraLabels,
+					},
+				}
+				return ingress
+			}(),
+			expectedReplica: minimumReplicas,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name:                  "node placement set by the customer is kept",
+			controllerEnabledFlag: "true",
+			ingressController: func() *operatorv1.IngressController {
+				ingress := defaultIngressController()
+				ingress.Spec.NodePlacement = &operatorv1.NodePlacement{
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: infraLabels,
+					},
+				}
+				return ingress
+			}(),
+			expectedReplica: minimumReplicas,
+			expectedNodePlacement: &operatorv1.NodePlacement{
+				NodeSelector: &metav1.LabelSelector{
+					MatchLabels: infraLabels,
+				},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name:                  "node selector matching no nodes is not applied",
+			controllerEnabledFlag: "true",
+			flags:                 map[string]string{controllerNodeSelector: "node-role.kubernetes.io/infra="},
+			nodes: []client.Object{
+				fakeNode("worker-1", workerLabels),
+			},
+			ingressController: defaultIngressController(),
+			expectedReplica:   minimumReplicas,
+			startConditions:   defaultConditions,
+			wantConditions:    progressing(`not applying the ingress configuration: ingress node selector "node-role.kubernetes.io/infra=" matches no nodes`),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			clusterMock := fakeCluster(tt.controllerEnabledFlag)
+			clusterMock := fakeCluster(tt.controllerEnabledFlag, tt.flags)
 			if len(tt.startConditions) > 0 {
 				clusterMock.Status.Conditions = append(clusterMock.Status.Conditions, tt.startConditions...)
 			}
 
-			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(clusterMock)
+			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(clusterMock).WithObjects(tt.nodes...)
 			if tt.ingressController != nil {
 				clientBuilder = clientBuilder.WithObjects(tt.ingressController)
 			}
@@ -188,6 +363,60 @@ func TestReconciler(t *testing.T) {
 				if *ingress.Spec.Replicas != tt.expectedReplica {
 					t.Errorf("incorrect replica count, expect: %d, got: %d", tt.expectedReplica, *ingress.Spec.Replicas)
 				}
+				if !reflect.DeepEqual(ingress.Spec.NodePlacement, tt.expectedNodePlacement) {
+					t.Errorf("incorrect node placement, expect: %v, got: %v", tt.expectedNodePlacement, ingress.Spec.NodePlacement)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTolerations(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		s       string
+		want    []corev1.Toleration
+		wantErr string
+	}{
+		{
+			name: "key, value and effect",
+			s:    "dedicated=ingress:NoSchedule,node-role.kubernetes.io/infra:NoExecute",
+			want: []corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "ingress",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:      "node-role.kubernetes.io/infra",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+			},
+		},
+		{
+			name:    "missing effect",
+			s:       "dedicated=ingress",
+			wantErr: `toleration "dedicated=ingress" has no effect`,
+		},
+		{
+			name:    "unknown effect",
+			s:       "dedicated:Never",
+			wantErr: `toleration "dedicated:Never" has unknown effect "Never"`,
+		},
+		{
+			name:    "missing key",
+			s:       "=ingress:NoSchedule",
+			wantErr: `toleration "=ingress:NoSchedule" has no key`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTolerations(tt.s)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
 			}
 		})
 	}
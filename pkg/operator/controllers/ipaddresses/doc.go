@@ -0,0 +1,33 @@
+package ipaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package tracks the live IP addresses of the cluster,
+so that the monitor can detect when the addresses in the cluster document
+have gone stale.  Customers allowlist these addresses in their firewalls, and
+the load balancers and public IP addresses behind them can be changed out of
+band.
+
+The controller polls every ten minutes:
+
+- the private API server address, from the internal load balancer
+- the public API server address, from the -pip-v4 public IP address
+- the outbound addresses, from the outbound rule of the public load balancer
+- the default ingress address, from the router-default service
+
+Addresses which cannot be found, e.g. the public ones of architecture version
+1 clusters, are left out.  They are kept in the ip-addresses config map in the
+operator namespace, from which the monitor emits the cluster.ipaddresses.drift
+metric for each address which differs from the cluster document.  The
+document is corrected by the SyncClusterProperties maintenance task.
+
+These flags control the operations performed by this controller:
+
+aro.ipaddresses.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will track the IP addresses of the cluster
+
+*/
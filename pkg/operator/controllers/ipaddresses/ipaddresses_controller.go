@@ -0,0 +1,304 @@
+package ipaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	ControllerName = "IPAddresses"
+
+	controllerEnabled = "aro.ipaddresses.enabled"
+)
+
+// The live IP addresses of the cluster are kept in a config map in the
+// operator namespace, from which the monitor compares them with the cluster
+// document
+const (
+	ConfigMapName = "ip-addresses"
+
+	KeyAddresses = "addresses"
+)
+
+// requeueInterval is how often the load balancers are polled.  They can be
+// changed out of band, which the controller cannot watch.
+const requeueInterval = 10 * time.Minute
+
+const outboundRuleV4 = "outbound-rule-v4"
+
+// IPAddresses are the live IP addresses of the cluster, as kept in the config
+// map.  Addresses which could not be found are left empty.
+type IPAddresses struct {
+	APIServerPrivateIP string `json:"apiServerPrivateIp,omitempty"`
+	APIServerPublicIP  string `json:"apiServerPublicIp,omitempty"`
+	IngressIP          string `json:"ingressIp,omitempty"`
+
+	// OutboundIPs are the resource IDs of the public IP addresses of the
+	// outbound rule of the public load balancer, or nil if it has none
+	OutboundIPs []string `json:"outboundIps,omitempty"`
+}
+
+// Reconciler tracks the live IP addresses of the cluster
+type Reconciler struct {
+	base.AROController
+
+	newAzureClients func(ctx context.Context, instance *arov1alpha1.Cluster) (network.LoadBalancersClient, network.PublicIPAddressesClient, error)
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	r := &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+	r.newAzureClients = r.azureClients
+
+	return r
+}
+
+// Reconcile polls the IP addresses of the cluster load balancers and the
+// default ingress and saves them for the monitor
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	addresses, err := r.getIPAddresses(ctx, instance)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	err = r.saveConfigMap(ctx, addresses)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// azureClients returns the load balancer and public IP address clients
+// authorized as the cluster service principal
+func (r *Reconciler) azureClients(ctx context.Context, instance *arov1alpha1.Cluster) (network.LoadBalancersClient, network.PublicIPAddressesClient, error) {
+	err := clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resource, err := azure.ParseResourceID(instance.Spec.ResourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	azRefreshAuthorizer, err := clusterauthorizer.NewAzRefreshableAuthorizer(r.Log, &azEnv, r.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authorizer, err := azRefreshAuthorizer.NewRefreshableAuthorizerToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return network.NewLoadBalancersClient(&azEnv, resource.SubscriptionID, authorizer),
+		network.NewPublicIPAddressesClient(&azEnv, resource.SubscriptionID, authorizer),
+		nil
+}
+
+// getIPAddresses reads the private API server address from the internal load
+// balancer, the public API server address and the outbound addresses from the
+// public IP addresses of the cluster resource group, and the ingress address
+// from the router-default service
+func (r *Reconciler) getIPAddresses(ctx context.Context, instance *arov1alpha1.Cluster) (*IPAddresses, error) {
+	loadBalancers, publicIPAddresses, err := r.newAzureClients(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceGroup := stringutils.LastTokenByte(instance.Spec.ClusterResourceGroupID, '/')
+	addresses := &IPAddresses{}
+
+	internalLBName := instance.Spec.InfraID + "-internal"
+	if instance.Spec.ArchitectureVersion == 0 {
+		internalLBName = instance.Spec.InfraID + "-internal-lb"
+	}
+
+	lb, err := loadBalancers.Get(ctx, resourceGroup, internalLBName, "")
+	if err != nil {
+		// hold back further Azure calls if this one was throttled
+		clusterauthorizer.OperatorRateLimiter.Observe(err)
+		return nil, err
+	}
+
+	if lb.LoadBalancerPropertiesFormat != nil && lb.FrontendIPConfigurations != nil && len(*lb.FrontendIPConfigurations) > 0 &&
+		(*lb.FrontendIPConfigurations)[0].PrivateIPAddress != nil {
+		addresses.APIServerPrivateIP = *(*lb.FrontendIPConfigurations)[0].PrivateIPAddress
+	}
+
+	// only architecture version 2 clusters have a public load balancer named
+	// after the infra ID and a -pip-v4 public IP address
+	if instance.Spec.ArchitectureVersion != 0 {
+		pip, err := publicIPAddresses.Get(ctx, resourceGroup, instance.Spec.InfraID+"-pip-v4", "")
+		if err != nil && !isNotFound(err) {
+			clusterauthorizer.OperatorRateLimiter.Observe(err)
+			return nil, err
+		}
+		if err == nil && pip.PublicIPAddressPropertiesFormat != nil && pip.IPAddress != nil {
+			addresses.APIServerPublicIP = *pip.IPAddress
+		}
+
+		lb, err = loadBalancers.Get(ctx, resourceGroup, instance.Spec.InfraID, "")
+		if err != nil && !isNotFound(err) {
+			clusterauthorizer.OperatorRateLimiter.Observe(err)
+			return nil, err
+		}
+		if err == nil {
+			addresses.OutboundIPs = outboundIPs(&lb)
+		}
+	}
+
+	svc := &corev1.Service{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: "openshift-ingress", Name: "router-default"}, svc)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	// the router-default service does not exist if the default ingress is
+	// served by a customer load balancer
+	if err == nil && len(svc.Status.LoadBalancer.Ingress) > 0 {
+		addresses.IngressIP = svc.Status.LoadBalancer.Ingress[0].IP
+	}
+
+	return addresses, nil
+}
+
+// outboundIPs returns the resource IDs of the public IP addresses of the IPv4
+// outbound rule of the load balancer
+func outboundIPs(lb *mgmtnetwork.LoadBalancer) []string {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil || lb.OutboundRules == nil {
+		return nil
+	}
+
+	fipConfigs := map[string]mgmtnetwork.FrontendIPConfiguration{}
+	for _, fipConfig := range *lb.FrontendIPConfigurations {
+		if fipConfig.ID != nil {
+			fipConfigs[*fipConfig.ID] = fipConfig
+		}
+	}
+
+	var ids []string
+	for _, rule := range *lb.OutboundRules {
+		if rule.Name == nil || *rule.Name != outboundRuleV4 ||
+			rule.OutboundRulePropertiesFormat == nil || rule.OutboundRulePropertiesFormat.FrontendIPConfigurations == nil {
+			continue
+		}
+
+		for _, ref := range *rule.OutboundRulePropertiesFormat.FrontendIPConfigurations {
+			if ref.ID == nil {
+				continue
+			}
+			fipConfig, ok := fipConfigs[*ref.ID]
+			if ok && fipConfig.FrontendIPConfigurationPropertiesFormat != nil &&
+				fipConfig.PublicIPAddress != nil && fipConfig.PublicIPAddress.ID != nil {
+				ids = append(ids, *fipConfig.PublicIPAddress.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+func isNotFound(err error) bool {
+	detailedErr, ok := err.(autorest.DetailedError)
+	return ok && detailedErr.StatusCode == http.StatusNotFound
+}
+
+// saveConfigMap saves the live IP addresses for the monitor
+func (r *Reconciler) saveConfigMap(ctx context.Context, addresses *IPAddresses) error {
+	b, err := json.Marshal(addresses)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{
+		KeyAddresses: string(b),
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: operator.Namespace,
+				Name:      ConfigMapName,
+			},
+			Data: data,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm.Data[KeyAddresses] == data[KeyAddresses] {
+		return nil
+	}
+
+	cm.Data = data
+	return r.Client.Update(ctx, cm)
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Named(ControllerName).
+		Complete(r)
+}
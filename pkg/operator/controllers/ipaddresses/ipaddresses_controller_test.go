@@ -0,0 +1,273 @@
+package ipaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	resourceGroupID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/aro-infra"
+	lbID := resourceGroupID + "/providers/Microsoft.Network/loadBalancers/infra"
+	pipID := resourceGroupID + "/providers/Microsoft.Network/publicIPAddresses/"
+
+	internalLB := mgmtnetwork.LoadBalancer{
+		LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+				{
+					FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+						PrivateIPAddress: to.StringPtr("10.0.0.4"),
+					},
+				},
+			},
+		},
+	}
+
+	publicLB := mgmtnetwork.LoadBalancer{
+		LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+				{
+					ID: to.StringPtr(lbID + "/frontendIPConfigurations/public-lb-ip-v4"),
+					FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &mgmtnetwork.PublicIPAddress{ID: to.StringPtr(pipID + "infra-pip-v4")},
+					},
+				},
+				{
+					ID: to.StringPtr(lbID + "/frontendIPConfigurations/outbound"),
+					FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &mgmtnetwork.PublicIPAddress{ID: to.StringPtr(pipID + "outbound")},
+					},
+				},
+				{
+					ID: to.StringPtr(lbID + "/frontendIPConfigurations/ingress"),
+					FrontendIPConfigurationPropertiesFormat: &mgmtnetwork.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &mgmtnetwork.PublicIPAddress{ID: to.StringPtr(pipID + "ingress")},
+					},
+				},
+			},
+			OutboundRules: &[]mgmtnetwork.OutboundRule{
+				{
+					Name: to.StringPtr(outboundRuleV4),
+					OutboundRulePropertiesFormat: &mgmtnetwork.OutboundRulePropertiesFormat{
+						FrontendIPConfigurations: &[]mgmtnetwork.SubResource{
+							{ID: to.StringPtr(lbID + "/frontendIPConfigurations/public-lb-ip-v4")},
+							{ID: to.StringPtr(lbID + "/frontendIPConfigurations/outbound")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	publicIP := mgmtnetwork.PublicIPAddress{
+		PublicIPAddressPropertiesFormat: &mgmtnetwork.PublicIPAddressPropertiesFormat{
+			IPAddress: to.StringPtr("1.2.3.4"),
+		},
+	}
+
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound}
+
+	routerDefault := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "openshift-ingress",
+			Name:      "router-default",
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "5.6.7.8"}},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name                string
+		flags               arov1alpha1.OperatorFlags
+		architectureVersion int
+		noRouter            bool
+		mocks               func(*mock_network.MockLoadBalancersClient, *mock_network.MockPublicIPAddressesClient)
+		wantAddresses       string
+		wantRequeue         time.Duration
+		wantErr             string
+		wantConditions      []operatorv1.OperatorCondition
+		startConditions     []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "false",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "architecture version 2",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			architectureVersion: 1,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra-internal", "").Return(internalLB, nil)
+				publicIPAddresses.EXPECT().Get(gomock.Any(), "aro-infra", "infra-pip-v4", "").Return(publicIP, nil)
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra", "").Return(publicLB, nil)
+			},
+			wantAddresses:   `{"apiServerPrivateIp":"10.0.0.4","apiServerPublicIp":"1.2.3.4","ingressIp":"5.6.7.8","outboundIps":["` + pipID + `infra-pip-v4","` + pipID + `outbound"]}`,
+			wantRequeue:     requeueInterval,
+			startConditions: degraded("broken"),
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "architecture version 2, private cluster without router",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			architectureVersion: 1,
+			noRouter:            true,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra-internal", "").Return(internalLB, nil)
+				publicIPAddresses.EXPECT().Get(gomock.Any(), "aro-infra", "infra-pip-v4", "").Return(mgmtnetwork.PublicIPAddress{}, notFound)
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra", "").Return(mgmtnetwork.LoadBalancer{}, notFound)
+			},
+			wantAddresses:   `{"apiServerPrivateIp":"10.0.0.4"}`,
+			wantRequeue:     requeueInterval,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "architecture version 1",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra-internal-lb", "").Return(internalLB, nil)
+			},
+			wantAddresses:   `{"apiServerPrivateIp":"10.0.0.4","ingressIp":"5.6.7.8"}`,
+			wantRequeue:     requeueInterval,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "azure error",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			architectureVersion: 1,
+			mocks: func(loadBalancers *mock_network.MockLoadBalancersClient, publicIPAddresses *mock_network.MockPublicIPAddressesClient) {
+				loadBalancers.EXPECT().Get(gomock.Any(), "aro-infra", "infra-internal", "").Return(internalLB, nil)
+				publicIPAddresses.EXPECT().Get(gomock.Any(), "aro-infra", "infra-pip-v4", "").Return(mgmtnetwork.PublicIPAddress{}, errors.New("broken"))
+			},
+			wantErr:         "broken",
+			startConditions: defaultConditions,
+			wantConditions:  degraded("broken"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			loadBalancers := mock_network.NewMockLoadBalancersClient(controller)
+			publicIPAddresses := mock_network.NewMockPublicIPAddressesClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(loadBalancers, publicIPAddresses)
+			}
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					ResourceID:             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+					ClusterResourceGroupID: resourceGroupID,
+					InfraID:                "infra",
+					ArchitectureVersion:    tt.architectureVersion,
+					OperatorFlags:          tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			builder := ctrlfake.NewClientBuilder().WithObjects(instance)
+			if !tt.noRouter {
+				builder = builder.WithObjects(routerDefault)
+			}
+			clientFake := builder.Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			r.newAzureClients = func(context.Context, *arov1alpha1.Cluster) (network.LoadBalancersClient, network.PublicIPAddressesClient, error) {
+				return loadBalancers, publicIPAddresses, nil
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got requeue after %s, wanted %s", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = clientFake.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+			if tt.wantAddresses == "" {
+				if err == nil {
+					t.Errorf("config map %v", cm.Data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantData := map[string]string{KeyAddresses: tt.wantAddresses}
+			if !reflect.DeepEqual(cm.Data, wantData) {
+				t.Errorf("got %v, wanted %v", cm.Data, wantData)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package logforwarding
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package forwards the cluster logs to the off-cluster
+destination which the customer chose, a syslog server or a Log Analytics
+workspace.  The logs are collected and forwarded by the OpenShift Logging
+operator, which the customer must install on the cluster.  The data path is:
+
+* The customer sets logForwardingProfile on the cluster.  The RP stores the
+  shared key of the workspace encrypted in the cluster document, and never
+  returns it.
+
+* The RP copies the type, URL, workspace ID and log types of the destination
+  to the LogForwarding field on the ARO Cluster object, and the shared key to
+  the logForwardingSharedKey key of the operator secret
+  (openshift-azure-operator/cluster), so that it is not readable from the
+  Cluster object.
+
+* The Reconciler copies the shared key to the aro-log-forwarding Secret in the
+  openshift-logging namespace, where the collector reads it.
+
+* The Reconciler ensures the ClusterLogging and ClusterLogForwarder objects
+  named instance in the openshift-logging namespace.  The ClusterLogForwarder
+  has one output for the destination and one pipeline which sends the chosen
+  log types to it.  A ClusterLogging object created by the customer is reused;
+  if the customer created the ClusterLogForwarder the controller is Degraded.
+
+The logging objects aren't watched, as their CRDs don't exist until the
+OpenShift Logging operator is installed.  The controller requeues instead, so
+that changes made to them by hand are reverted.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.logforwarding.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the log forwarding according
+  to the LogForwarding field on the ARO Cluster object
+
+If the LogForwarding field is empty the controller removes the logging objects
+and the Secret, if it created them.
+
+*/
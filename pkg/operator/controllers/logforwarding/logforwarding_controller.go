@@ -0,0 +1,369 @@
+package logforwarding
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "LogForwarding"
+
+	controllerEnabled = "aro.logforwarding.enabled"
+
+	// SharedKeyKey is the key of the Log Analytics workspace shared key in
+	// the operator secret
+	SharedKeyKey = "logForwardingSharedKey"
+
+	managedByLabel = "aro.openshift.io/logforwarding"
+
+	loggingNamespace = "openshift-logging"
+	loggingName      = "instance"
+
+	sharedKeySecretName  = "aro-log-forwarding"
+	sharedKeySecretKey   = "shared_key"
+	forwardingOutputName = "aro-log-forwarding"
+
+	typeSyslog       = "Syslog"
+	typeAzureMonitor = "AzureMonitor"
+)
+
+var (
+	clusterLoggingGVK      = schema.GroupVersionKind{Group: "logging.openshift.io", Version: "v1", Kind: "ClusterLogging"}
+	clusterLogForwarderGVK = schema.GroupVersionKind{Group: "logging.openshift.io", Version: "v1", Kind: "ClusterLogForwarder"}
+)
+
+// Reconciler ensures the ClusterLogForwarder which forwards the cluster logs
+// to the destination chosen by the customer
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the Secrets, and if any of them
+// changes, reconciles the log forwarding.  The logging objects themselves
+// aren't watched: their CRDs don't exist until the OpenShift Logging operator
+// is installed, and watching them would stop the manager starting.
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	if instance.Spec.LogForwarding == nil {
+		err = r.removeLogForwarding(ctx)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	err = r.ensureLogForwarding(ctx, instance.Spec.LogForwarding)
+	if meta.IsNoMatchError(err) {
+		err = fmt.Errorf("log forwarding requires the OpenShift Logging operator to be installed: %w", err)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{RequeueAfter: time.Hour}, nil
+}
+
+func (r *Reconciler) ensureLogForwarding(ctx context.Context, spec *arov1alpha1.LogForwardingSpec) error {
+	// don't override a log forwarder set up by the customer
+	clf, err := r.getLoggingObject(ctx, clusterLogForwarderGVK)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !isARO(clf) {
+		return fmt.Errorf("cluster log forwarder %s/%s is already configured", loggingNamespace, loggingName)
+	}
+
+	output, err := r.output(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	// a ClusterLogging object set up by the customer is reused, as it is what
+	// deploys the collectors which the log forwarder configures
+	_, err = r.getLoggingObject(ctx, clusterLoggingGVK)
+	if kerrors.IsNotFound(err) {
+		err = r.Client.Create(ctx, clusterLogging())
+	}
+	if err != nil {
+		return err
+	}
+
+	want := clusterLogForwarder(output, inputRefs(spec.LogTypes))
+
+	if clf == nil {
+		return r.Client.Create(ctx, want)
+	}
+
+	if reflect.DeepEqual(clf.Object["spec"], want.Object["spec"]) {
+		return nil
+	}
+
+	clf.Object["spec"] = want.Object["spec"]
+	return r.Client.Update(ctx, clf)
+}
+
+// output returns the ClusterLogForwarder output for spec, and ensures or
+// deletes the Secret holding the shared key of the workspace accordingly
+func (r *Reconciler) output(ctx context.Context, spec *arov1alpha1.LogForwardingSpec) (map[string]interface{}, error) {
+	switch spec.Type {
+	case typeSyslog:
+		err := r.deleteSharedKeySecret(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"name": forwardingOutputName,
+			"type": "syslog",
+			"url":  spec.URL,
+			"syslog": map[string]interface{}{
+				"rfc": "RFC5424",
+			},
+		}, nil
+
+	case typeAzureMonitor:
+		operatorSecret := &corev1.Secret{}
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: operator.SecretName}, operatorSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		sharedKey := operatorSecret.Data[SharedKeyKey]
+		if len(sharedKey) == 0 {
+			return nil, fmt.Errorf("operator secret has no %s", SharedKeyKey)
+		}
+
+		err = r.createOrUpdateSharedKeySecret(ctx, sharedKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"name": forwardingOutputName,
+			"type": "azureMonitor",
+			"azureMonitor": map[string]interface{}{
+				"customerId": spec.WorkspaceID,
+				"logType":    operator.LogForwardingAzureMonitorLogType,
+			},
+			"secret": map[string]interface{}{
+				"name": sharedKeySecretName,
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("log forwarding type %q is not supported", spec.Type)
+}
+
+func (r *Reconciler) createOrUpdateSharedKeySecret(ctx context.Context, sharedKey []byte) error {
+	data := map[string][]byte{
+		sharedKeySecretKey: sharedKey,
+	}
+
+	s := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: loggingNamespace, Name: sharedKeySecretName}, s)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sharedKeySecretName,
+				Namespace: loggingNamespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(s.Data, data) {
+		return nil
+	}
+
+	s.Data = data
+	return r.Client.Update(ctx, s)
+}
+
+func (r *Reconciler) deleteSharedKeySecret(ctx context.Context) error {
+	err := r.Client.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sharedKeySecretName,
+			Namespace: loggingNamespace,
+		},
+	})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// removeLogForwarding deletes the logging objects and the shared key Secret,
+// if they exist.  Logging objects created by the customer are left alone.
+func (r *Reconciler) removeLogForwarding(ctx context.Context) error {
+	for _, gvk := range []schema.GroupVersionKind{clusterLogForwarderGVK, clusterLoggingGVK} {
+		o, err := r.getLoggingObject(ctx, gvk)
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if !isARO(o) {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, o)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return r.deleteSharedKeySecret(ctx)
+}
+
+func (r *Reconciler) getLoggingObject(ctx context.Context, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(gvk)
+
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: loggingNamespace, Name: loggingName}, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// clusterLogging returns the ClusterLogging object which deploys the vector
+// collectors on every node
+func clusterLogging() *unstructured.Unstructured {
+	o := loggingObject(clusterLoggingGVK)
+	o.Object["spec"] = map[string]interface{}{
+		"managementState": "Managed",
+		"collection": map[string]interface{}{
+			"type": "vector",
+		},
+	}
+	return o
+}
+
+// clusterLogForwarder returns the ClusterLogForwarder object which sends the
+// logs of inputRefs to output
+func clusterLogForwarder(output map[string]interface{}, inputRefs []interface{}) *unstructured.Unstructured {
+	o := loggingObject(clusterLogForwarderGVK)
+	o.Object["spec"] = map[string]interface{}{
+		"outputs": []interface{}{output},
+		"pipelines": []interface{}{
+			map[string]interface{}{
+				"name":       forwardingOutputName,
+				"inputRefs":  inputRefs,
+				"outputRefs": []interface{}{forwardingOutputName},
+			},
+		},
+	}
+	return o
+}
+
+func loggingObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(gvk)
+	o.SetName(loggingName)
+	o.SetNamespace(loggingNamespace)
+	o.SetLabels(map[string]string{
+		managedByLabel: "true",
+	})
+	return o
+}
+
+// inputRefs returns the ClusterLogForwarder inputs of logTypes, e.g.
+// application for Application; all of them if logTypes is empty
+func inputRefs(logTypes []string) []interface{} {
+	if len(logTypes) == 0 {
+		logTypes = []string{"Application", "Infrastructure", "Audit"}
+	}
+
+	refs := make([]interface{}, 0, len(logTypes))
+	for _, t := range logTypes {
+		refs = append(refs, strings.ToLower(t))
+	}
+	return refs
+}
+
+// isARO returns true if o was created by this controller
+func isARO(o *unstructured.Unstructured) bool {
+	return o.GetLabels()[managedByLabel] == "true"
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	secretPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return (o.GetNamespace() == operator.Namespace && o.GetName() == operator.SecretName) ||
+			(o.GetNamespace() == loggingNamespace && o.GetName() == sharedKeySecretName)
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(secretPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,301 @@
+package logforwarding
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	syslogSpec := &arov1alpha1.LogForwardingSpec{
+		Type:     "Syslog",
+		URL:      "tls://syslog.example.com:6514",
+		LogTypes: []string{"Audit"},
+	}
+
+	azureMonitorSpec := &arov1alpha1.LogForwardingSpec{
+		Type:        "AzureMonitor",
+		WorkspaceID: "11111111-1111-1111-1111-111111111111",
+	}
+
+	cluster := func(enabled string, spec *arov1alpha1.LogForwardingSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				LogForwarding: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	operatorSecret := func(sharedKey string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      operator.SecretName,
+				Namespace: operator.Namespace,
+			},
+			Data: map[string][]byte{},
+		}
+		if sharedKey != "" {
+			s.Data[SharedKeyKey] = []byte(sharedKey)
+		}
+		return s
+	}
+
+	sharedKeySecret := func(value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sharedKeySecretName,
+				Namespace: loggingNamespace,
+			},
+			Data: map[string][]byte{
+				sharedKeySecretKey: []byte(value),
+			},
+		}
+	}
+
+	syslogOutput := map[string]interface{}{
+		"name": forwardingOutputName,
+		"type": "syslog",
+		"url":  "tls://syslog.example.com:6514",
+		"syslog": map[string]interface{}{
+			"rfc": "RFC5424",
+		},
+	}
+
+	azureMonitorOutput := map[string]interface{}{
+		"name": forwardingOutputName,
+		"type": "azureMonitor",
+		"azureMonitor": map[string]interface{}{
+			"customerId": "11111111-1111-1111-1111-111111111111",
+			"logType":    "AROClusterLogs",
+		},
+		"secret": map[string]interface{}{
+			"name": sharedKeySecretName,
+		},
+	}
+
+	customerObject := func(o *unstructured.Unstructured) *unstructured.Unstructured {
+		o.SetLabels(nil)
+		return o
+	}
+
+	driftedForwarder := clusterLogForwarder(syslogOutput, []interface{}{"application"})
+
+	tests := []struct {
+		name                string
+		objects             []client.Object
+		wantErrMsg          string
+		wantConditions      []operatorv1.OperatorCondition
+		wantForwarder       *unstructured.Unstructured
+		wantARO             bool
+		wantClusterLogging  bool
+		wantSharedKeySecret string
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", syslogSpec),
+				operatorSecret(""),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "syslog forwarding is created",
+			objects: []client.Object{
+				cluster("true", syslogSpec),
+				operatorSecret(""),
+			},
+			wantConditions:     defaultConditions,
+			wantForwarder:      clusterLogForwarder(syslogOutput, []interface{}{"audit"}),
+			wantARO:            true,
+			wantClusterLogging: true,
+		},
+		{
+			name: "azure monitor forwarding is created",
+			objects: []client.Object{
+				cluster("true", azureMonitorSpec),
+				operatorSecret("c2hhcmVka2V5"),
+			},
+			wantConditions:      defaultConditions,
+			wantForwarder:       clusterLogForwarder(azureMonitorOutput, []interface{}{"application", "infrastructure", "audit"}),
+			wantARO:             true,
+			wantClusterLogging:  true,
+			wantSharedKeySecret: "c2hhcmVka2V5",
+		},
+		{
+			name: "forwarding drift is reverted",
+			objects: []client.Object{
+				cluster("true", syslogSpec),
+				operatorSecret(""),
+				driftedForwarder,
+				clusterLogging(),
+				sharedKeySecret("c2hhcmVka2V5"),
+			},
+			wantConditions:     defaultConditions,
+			wantForwarder:      clusterLogForwarder(syslogOutput, []interface{}{"audit"}),
+			wantARO:            true,
+			wantClusterLogging: true,
+		},
+		{
+			name: "customer cluster logging is reused",
+			objects: []client.Object{
+				cluster("true", syslogSpec),
+				operatorSecret(""),
+				customerObject(clusterLogging()),
+			},
+			wantConditions:     defaultConditions,
+			wantForwarder:      clusterLogForwarder(syslogOutput, []interface{}{"audit"}),
+			wantARO:            true,
+			wantClusterLogging: true,
+		},
+		{
+			name: "customer log forwarder is left alone",
+			objects: []client.Object{
+				cluster("true", syslogSpec),
+				operatorSecret(""),
+				customerObject(clusterLogForwarder(azureMonitorOutput, []interface{}{"application"})),
+			},
+			wantErrMsg:     "cluster log forwarder openshift-logging/instance is already configured",
+			wantConditions: degraded("cluster log forwarder openshift-logging/instance is already configured"),
+			wantForwarder:  clusterLogForwarder(azureMonitorOutput, []interface{}{"application"}),
+		},
+		{
+			name: "missing shared key is degraded",
+			objects: []client.Object{
+				cluster("true", azureMonitorSpec),
+				operatorSecret(""),
+			},
+			wantErrMsg:     "operator secret has no logForwardingSharedKey",
+			wantConditions: degraded("operator secret has no logForwardingSharedKey"),
+		},
+		{
+			name: "forwarding is removed",
+			objects: []client.Object{
+				cluster("true", nil),
+				operatorSecret(""),
+				clusterLogForwarder(azureMonitorOutput, []interface{}{"application"}),
+				clusterLogging(),
+				sharedKeySecret("c2hhcmVka2V5"),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "customer objects are not removed",
+			objects: []client.Object{
+				cluster("true", nil),
+				operatorSecret(""),
+				customerObject(clusterLogForwarder(azureMonitorOutput, []interface{}{"application"})),
+				customerObject(clusterLogging()),
+			},
+			wantConditions:     defaultConditions,
+			wantForwarder:      clusterLogForwarder(azureMonitorOutput, []interface{}{"application"}),
+			wantClusterLogging: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			clf, err := r.getLoggingObject(ctx, clusterLogForwarderGVK)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.wantForwarder == nil && clf != nil:
+				t.Errorf("got log forwarder %#v", clf.Object)
+			case tt.wantForwarder != nil && clf == nil:
+				t.Error("log forwarder not found")
+			case tt.wantForwarder != nil:
+				if !reflect.DeepEqual(clf.Object["spec"], tt.wantForwarder.Object["spec"]) {
+					t.Errorf("got log forwarder spec %#v", clf.Object["spec"])
+				}
+				if isARO(clf) != tt.wantARO {
+					t.Errorf("got log forwarder labels %v", clf.GetLabels())
+				}
+			}
+
+			_, err = r.getLoggingObject(ctx, clusterLoggingGVK)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if got := err == nil; got != tt.wantClusterLogging {
+				t.Errorf("got cluster logging %v, want %v", got, tt.wantClusterLogging)
+			}
+
+			s := &corev1.Secret{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: loggingNamespace, Name: sharedKeySecretName}, s)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if got := string(s.Data[sharedKeySecretKey]); got != tt.wantSharedKeySecret {
+				t.Errorf("got shared key %q, want %q", got, tt.wantSharedKeySecret)
+			}
+		})
+	}
+}
@@ -6,11 +6,14 @@ package monitoring
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
 
 	"github.com/ghodss/yaml"
 	"github.com/sirupsen/logrus"
 	"github.com/ugorji/go/codec"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -84,11 +87,11 @@ func (r *MonitoringReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	}
 
 	r.Log.Debug("running")
-	for _, f := range []func(context.Context) (ctrl.Result, error){
+	for _, f := range []func(context.Context, *arov1alpha1.MonitoringSpec) (ctrl.Result, error){
 		r.reconcileConfiguration,
 		r.reconcilePVC, // TODO(mj): This should be removed once we don't have PVC anymore
 	} {
-		result, err := f(ctx)
+		result, err := f(ctx, instance.Spec.Monitoring)
 		if err != nil {
 			r.Log.Error(err)
 			r.SetDegraded(ctx, err)
@@ -96,10 +99,17 @@ func (r *MonitoringReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		}
 	}
 
+	r.ClearConditions(ctx)
 	return reconcile.Result{}, nil
 }
 
-func (r *MonitoringReconciler) reconcilePVC(ctx context.Context) (ctrl.Result, error) {
+// reconcilePVC deletes the persistent volume claims of Prometheus unless
+// persistent storage is configured
+func (r *MonitoringReconciler) reconcilePVC(ctx context.Context, spec *arov1alpha1.MonitoringSpec) (ctrl.Result, error) {
+	if spec != nil && spec.StorageSize != "" {
+		return reconcile.Result{}, nil
+	}
+
 	pvcList := &corev1.PersistentVolumeClaimList{}
 	selector, _ := labels.Parse(prometheusLabels)
 	err := r.Client.List(ctx, pvcList, &client.ListOptions{
@@ -119,7 +129,34 @@ func (r *MonitoringReconciler) reconcilePVC(ctx context.Context) (ctrl.Result, e
 	return reconcile.Result{}, nil
 }
 
-func (r *MonitoringReconciler) reconcileConfiguration(ctx context.Context) (ctrl.Result, error) {
+// reconcileConfiguration sets the retention and storage of Prometheus to the
+// ones configured on the ARO Cluster object, or removes them if there are
+// none
+func (r *MonitoringReconciler) reconcileConfiguration(ctx context.Context, spec *arov1alpha1.MonitoringSpec) (ctrl.Result, error) {
+	var retention string
+	var volumeClaimTemplate *json.RawMessage
+	if spec != nil {
+		retention = spec.Retention
+
+		if spec.StorageSize != "" {
+			if spec.StorageClassName != "" {
+				err := r.Client.Get(ctx, types.NamespacedName{Name: spec.StorageClassName}, &storagev1.StorageClass{})
+				if kerrors.IsNotFound(err) {
+					return reconcile.Result{}, fmt.Errorf("storage class %q not found", spec.StorageClassName)
+				}
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+
+			var err error
+			volumeClaimTemplate, err = prometheusVolumeClaimTemplate(spec)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	cm, isCreate, err := r.monitoringConfigMap(ctx)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -148,13 +185,17 @@ func (r *MonitoringReconciler) reconcileConfiguration(ctx context.Context) (ctrl
 		changed = true
 	}
 
-	if configData.PrometheusK8s.Retention != "" {
-		configData.PrometheusK8s.Retention = ""
+	if configData.PrometheusK8s.Retention != retention {
+		configData.PrometheusK8s.Retention = retention
 		changed = true
 	}
 
-	if configData.PrometheusK8s.VolumeClaimTemplate != nil {
-		configData.PrometheusK8s.VolumeClaimTemplate = nil
+	equal, err := rawJSONEqual(configData.PrometheusK8s.VolumeClaimTemplate, volumeClaimTemplate)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !equal {
+		configData.PrometheusK8s.VolumeClaimTemplate = volumeClaimTemplate
 		changed = true
 	}
 
@@ -184,6 +225,54 @@ func (r *MonitoringReconciler) reconcileConfiguration(ctx context.Context) (ctrl
 	return reconcile.Result{}, err
 }
 
+// prometheusVolumeClaimTemplate returns the volume claim template of the
+// persistent volumes of Prometheus
+func prometheusVolumeClaimTemplate(spec *arov1alpha1.MonitoringSpec) (*json.RawMessage, error) {
+	type resources struct {
+		Requests map[string]string `json:"requests"`
+	}
+
+	var template struct {
+		Spec struct {
+			StorageClassName string    `json:"storageClassName,omitempty"`
+			Resources        resources `json:"resources"`
+		} `json:"spec"`
+	}
+	template.Spec.StorageClassName = spec.StorageClassName
+	template.Spec.Resources.Requests = map[string]string{
+		string(corev1.ResourceStorage): spec.StorageSize,
+	}
+
+	b, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := json.RawMessage(b)
+	return &raw, nil
+}
+
+// rawJSONEqual compares JSON documents regardless of their formatting and key
+// order
+func rawJSONEqual(a, b *json.RawMessage) (bool, error) {
+	if a == nil || b == nil {
+		return a == b, nil
+	}
+
+	var av, bv interface{}
+	err := json.Unmarshal(*a, &av)
+	if err != nil {
+		return false, err
+	}
+
+	err = json.Unmarshal(*b, &bv)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(av, bv), nil
+}
+
 func (r *MonitoringReconciler) monitoringConfigMap(ctx context.Context) (*corev1.ConfigMap, bool, error) {
 	cm := &corev1.ConfigMap{}
 	err := r.Client.Get(ctx, monitoringName, cm)
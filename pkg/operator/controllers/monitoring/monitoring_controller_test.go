@@ -13,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/ugorji/go/codec"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -24,6 +25,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/cmp"
 	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
 	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
 )
 
 var (
@@ -36,10 +38,19 @@ func TestReconcileMonitoringConfig(t *testing.T) {
 	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
 	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
 	log := logrus.NewEntry(logrus.StandardLogger())
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		d := defaultDegraded
+		d.Status = operatorv1.ConditionTrue
+		d.Message = message
+		return []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, d}
+	}
 	type test struct {
 		name           string
+		monitoring     *arov1alpha1.MonitoringSpec
+		storageClasses []client.Object
 		configMap      *corev1.ConfigMap
 		wantConfig     string
+		wantErrMsg     string
 		wantConditions []operatorv1.OperatorCondition
 	}
 
@@ -146,6 +157,81 @@ somethingElse:
 `,
 			wantConditions: defaultConditions,
 		},
+		{
+			name: "configured retention and storage are set and extra fields are preserved",
+			monitoring: &arov1alpha1.MonitoringSpec{
+				Retention:        "30d",
+				StorageSize:      "100Gi",
+				StorageClassName: "managed-csi",
+			},
+			storageClasses: []client.Object{
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "managed-csi"}},
+			},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: cmMetadata,
+				Data: map[string]string{
+					"config.yaml": `
+prometheusK8s:
+  extraField: prometheus
+  retention: 1d
+alertmanagerMain:
+  volumeClaimTemplate: {}
+`,
+				},
+			},
+			wantConfig: `
+prometheusK8s:
+  extraField: prometheus
+  retention: 30d
+  volumeClaimTemplate:
+    spec:
+      resources:
+        requests:
+          storage: 100Gi
+      storageClassName: managed-csi
+`,
+			wantConditions: defaultConditions,
+		},
+		{
+			name:       "configured retention without storage is set",
+			monitoring: &arov1alpha1.MonitoringSpec{Retention: "7d"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: cmMetadata,
+				Data: map[string]string{
+					"config.yaml": `
+prometheusK8s:
+  volumeClaimTemplate: {}
+`,
+				},
+			},
+			wantConfig: `
+prometheusK8s:
+  retention: 7d
+`,
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "missing storage class degrades the controller",
+			monitoring: &arov1alpha1.MonitoringSpec{
+				StorageSize:      "100Gi",
+				StorageClassName: "managed-csi",
+			},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: cmMetadata,
+				Data: map[string]string{
+					"config.yaml": `
+prometheusK8s:
+  retention: 1d
+`,
+				},
+			},
+			wantConfig: `
+prometheusK8s:
+  retention: 1d
+`,
+			wantErrMsg:     `storage class "managed-csi" not found`,
+			wantConditions: degraded(`storage class "managed-csi" not found`),
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -154,14 +240,18 @@ somethingElse:
 				ObjectMeta: metav1.ObjectMeta{
 					Name: arov1alpha1.SingletonClusterName,
 				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: defaultConditions,
+				},
 				Spec: arov1alpha1.ClusterSpec{
+					Monitoring: tt.monitoring,
 					OperatorFlags: arov1alpha1.OperatorFlags{
 						controllerEnabled: "true",
 					},
 				},
 			}
 
-			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(instance)
+			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(instance).WithObjects(tt.storageClasses...)
 			if tt.configMap != nil {
 				clientBuilder.WithObjects(tt.configMap)
 			}
@@ -170,6 +260,7 @@ somethingElse:
 				AROController: base.AROController{
 					Log:    log,
 					Client: clientBuilder.Build(),
+					Name:   ControllerName,
 				},
 				jsonHandle: new(codec.JsonHandle),
 			}
@@ -178,9 +269,8 @@ somethingElse:
 			request.Namespace = "openshift-monitoring"
 
 			_, err := r.Reconcile(ctx, request)
-			if err != nil {
-				t.Fatal(err)
-			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			utilconditions.AssertControllerConditions(t, ctx, r.Client, tt.wantConditions)
 
 			cm := &corev1.ConfigMap{}
 			err = r.Client.Get(ctx, types.NamespacedName{Namespace: "openshift-monitoring", Name: "cluster-monitoring-config"}, cm)
@@ -201,8 +291,28 @@ func TestReconcilePVC(t *testing.T) {
 	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
 	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
 	volumeMode := corev1.PersistentVolumeFilesystem
+	prometheusPVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-monitoring",
+				Labels: map[string]string{
+					"app":        "prometheus",
+					"prometheus": "k8s",
+				},
+				ResourceVersion: "1",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				VolumeMode: &volumeMode,
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimPending,
+			},
+		}
+	}
 	tests := []struct {
 		name           string
+		monitoring     *arov1alpha1.MonitoringSpec
 		pvcs           []client.Object
 		want           []corev1.PersistentVolumeClaim
 		wantConditions []operatorv1.OperatorCondition
@@ -278,6 +388,17 @@ func TestReconcilePVC(t *testing.T) {
 			},
 			wantConditions: defaultConditions,
 		},
+		{
+			name:       "Should preserve the prometheus PVCs when persistent storage is configured",
+			monitoring: &arov1alpha1.MonitoringSpec{StorageSize: "100Gi"},
+			pvcs: []client.Object{
+				prometheusPVC("prometheus-k8s-db-prometheus-k8s-0"),
+			},
+			want: []corev1.PersistentVolumeClaim{
+				*prometheusPVC("prometheus-k8s-db-prometheus-k8s-0"),
+			},
+			wantConditions: defaultConditions,
+		},
 	}
 
 	for _, tt := range tests {
@@ -289,6 +410,7 @@ func TestReconcilePVC(t *testing.T) {
 					Name: arov1alpha1.SingletonClusterName,
 				},
 				Spec: arov1alpha1.ClusterSpec{
+					Monitoring: tt.monitoring,
 					OperatorFlags: arov1alpha1.OperatorFlags{
 						controllerEnabled: "true",
 					},
@@ -0,0 +1,37 @@
+package operatorresources
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package sets the resource requests and limits of the
+aro-operator container of the aro-operator-master and aro-operator-worker
+deployments.  By default the operator runs without requests or limits; large
+clusters can give it more headroom by setting them.
+
+It also sets the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of
+the container from the status of the cluster-wide proxy, so that all the
+egress of the operator, e.g. to Azure and to the internet checker's URLs, goes
+through the proxy.  The operator restarts with the new values when the proxy
+changes.
+
+The following flags control the operations performed by this controller:
+
+aro.operatorresources.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the operator deployments
+
+aro.operator.<role>.resources.<requests|limits>.<cpu|memory>, where role is
+master or worker, e.g. aro.operator.master.resources.limits.memory:
+- When unset or empty, the request or limit is not set
+- Otherwise the value is a Kubernetes quantity.  CPU must be between 10m and 4
+  and memory between 64Mi and 8Gi, and a limit must not be less than the
+  corresponding request.  Invalid values are rejected by the admin API; if
+  they reach the cluster regardless, the controller reports itself degraded
+  and leaves the deployments alone
+
+The RP applies the same values when it deploys the operator, so changing the
+flags through an admin update takes effect either way.
+
+*/
@@ -0,0 +1,172 @@
+package operatorresources
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	utilproxy "github.com/Azure/ARO-RP/pkg/util/proxy"
+)
+
+const (
+	ControllerName = "OperatorResources"
+
+	controllerEnabled = "aro.operatorresources.enabled"
+
+	containerName = "aro-operator"
+)
+
+var roles = []string{pkgoperator.RoleMaster, pkgoperator.RoleWorker}
+
+// Reconciler keeps the resource requests and limits of the ARO operator
+// deployments in line with the aro.operator.<role>.resources.* operator
+// flags, and their proxy environment variables in line with the cluster-wide
+// proxy, so that they can be changed without an admin update.  The RP sets
+// the same values when it deploys the operator.
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	// validate the flags of every role before changing anything
+	desired := make(map[string]corev1.ResourceRequirements, len(roles))
+	for _, role := range roles {
+		desired[role], err = pkgoperator.Resources(instance.Spec.OperatorFlags, role)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	proxy := &configv1.Proxy{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, proxy)
+	if kerrors.IsNotFound(err) {
+		proxy = nil
+	} else if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	env := utilproxy.EnvVars(proxy)
+
+	for _, role := range roles {
+		resources := desired[role]
+
+		deployment := &appsv1.Deployment{}
+		err = r.Client.Get(ctx, types.NamespacedName{Namespace: pkgoperator.Namespace, Name: deploymentName(role)}, deployment)
+		if err != nil {
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+
+		changed := false
+		for i := range deployment.Spec.Template.Spec.Containers {
+			c := &deployment.Spec.Template.Spec.Containers[i]
+			if c.Name != containerName {
+				continue
+			}
+
+			if !equality.Semantic.DeepEqual(c.Resources, resources) {
+				c.Resources = resources
+				changed = true
+			}
+
+			if utilproxy.SetEnvVars(c, env) {
+				changed = true
+			}
+		}
+
+		if changed {
+			r.Log.Infof("updating resources and proxy of deployment %s", deployment.Name)
+			err = r.Client.Update(ctx, deployment)
+			if err != nil {
+				r.Log.Error(err)
+				r.SetDegraded(ctx, err)
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func deploymentName(role string) string {
+	return "aro-operator-" + role
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	proxyPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == "cluster"
+	})
+
+	operatorDeploymentPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetNamespace() == pkgoperator.Namespace &&
+			(o.GetName() == deploymentName(pkgoperator.RoleMaster) || o.GetName() == deploymentName(pkgoperator.RoleWorker))
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &appsv1.Deployment{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(operatorDeploymentPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &configv1.Proxy{}},
+			handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: arov1alpha1.SingletonClusterName}}}
+			}),
+			builder.WithPredicates(proxyPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
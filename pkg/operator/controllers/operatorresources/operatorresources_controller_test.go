@@ -0,0 +1,251 @@
+package operatorresources
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgoperator "github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/cmp"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	fakeDeployment := func(role string, resources corev1.ResourceRequirements) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deploymentName(role),
+				Namespace: pkgoperator.Namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:      containerName,
+								Resources: resources,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	masterResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           map[string]string
+		proxy           *configv1.Proxy
+		deployments     []*appsv1.Deployment
+		wantResources   map[string]corev1.ResourceRequirements
+		wantEnv         []corev1.EnvVar
+		wantErr         string
+		startConditions []operatorv1.OperatorCondition
+		wantConditions  []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: map[string]string{
+				controllerEnabled: "false",
+				"aro.operator.master.resources.limits.memory": "2Gi",
+			},
+			deployments: []*appsv1.Deployment{
+				fakeDeployment(pkgoperator.RoleMaster, corev1.ResourceRequirements{}),
+				fakeDeployment(pkgoperator.RoleWorker, corev1.ResourceRequirements{}),
+			},
+			wantResources: map[string]corev1.ResourceRequirements{
+				pkgoperator.RoleMaster: {},
+				pkgoperator.RoleWorker: {},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "resources set from the flags",
+			flags: map[string]string{
+				controllerEnabled: "true",
+				"aro.operator.master.resources.requests.memory": "512Mi",
+				"aro.operator.master.resources.limits.memory":   "2Gi",
+			},
+			deployments: []*appsv1.Deployment{
+				fakeDeployment(pkgoperator.RoleMaster, corev1.ResourceRequirements{}),
+				fakeDeployment(pkgoperator.RoleWorker, corev1.ResourceRequirements{}),
+			},
+			wantResources: map[string]corev1.ResourceRequirements{
+				pkgoperator.RoleMaster: masterResources,
+				pkgoperator.RoleWorker: {},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "resources removed when the flags are unset",
+			flags: map[string]string{
+				controllerEnabled: "true",
+			},
+			deployments: []*appsv1.Deployment{
+				fakeDeployment(pkgoperator.RoleMaster, masterResources),
+				fakeDeployment(pkgoperator.RoleWorker, corev1.ResourceRequirements{}),
+			},
+			wantResources: map[string]corev1.ResourceRequirements{
+				pkgoperator.RoleMaster: {},
+				pkgoperator.RoleWorker: {},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "proxy environment set from the cluster proxy",
+			flags: map[string]string{
+				controllerEnabled: "true",
+			},
+			proxy: &configv1.Proxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster",
+				},
+				Status: configv1.ProxyStatus{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    ".cluster.local,10.128.0.0/14",
+				},
+			},
+			deployments: []*appsv1.Deployment{
+				fakeDeployment(pkgoperator.RoleMaster, corev1.ResourceRequirements{}),
+				fakeDeployment(pkgoperator.RoleWorker, corev1.ResourceRequirements{}),
+			},
+			wantResources: map[string]corev1.ResourceRequirements{
+				pkgoperator.RoleMaster: {},
+				pkgoperator.RoleWorker: {},
+			},
+			wantEnv: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "NO_PROXY", Value: ".cluster.local,10.128.0.0/14"},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "invalid flags",
+			flags: map[string]string{
+				controllerEnabled: "true",
+				"aro.operator.worker.resources.requests.cpu": "100",
+			},
+			deployments: []*appsv1.Deployment{
+				fakeDeployment(pkgoperator.RoleMaster, masterResources),
+				fakeDeployment(pkgoperator.RoleWorker, corev1.ResourceRequirements{}),
+			},
+			wantResources: map[string]corev1.ResourceRequirements{
+				pkgoperator.RoleMaster: masterResources,
+				pkgoperator.RoleWorker: {},
+			},
+			startConditions: defaultConditions,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				defaultProgressing,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            "invalid aro.operator.worker.resources.requests.cpu '100': must be between 10m and 4",
+				},
+			},
+		},
+		{
+			name: "deployment not found",
+			flags: map[string]string{
+				controllerEnabled: "true",
+			},
+			wantErr:         `deployments.apps "aro-operator-master" not found`,
+			startConditions: defaultConditions,
+			wantConditions: []operatorv1.OperatorCondition{
+				defaultAvailable,
+				defaultProgressing,
+				{
+					Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+					Status:             operatorv1.ConditionTrue,
+					LastTransitionTime: transitionTime,
+					Message:            `deployments.apps "aro-operator-master" not found`,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			clientBuilder := ctrlfake.NewClientBuilder().WithObjects(cluster)
+			for _, deployment := range tt.deployments {
+				clientBuilder = clientBuilder.WithObjects(deployment)
+			}
+			if tt.proxy != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.proxy)
+			}
+			clientFake := clientBuilder.Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			ctx := context.Background()
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			for role, want := range tt.wantResources {
+				deployment := &appsv1.Deployment{}
+				err = clientFake.Get(ctx, types.NamespacedName{Namespace: pkgoperator.Namespace, Name: deploymentName(role)}, deployment)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(want, deployment.Spec.Template.Spec.Containers[0].Resources); diff != "" {
+					t.Errorf("%s: %s", role, diff)
+				}
+
+				if diff := cmp.Diff(tt.wantEnv, deployment.Spec.Template.Spec.Containers[0].Env); diff != "" {
+					t.Errorf("%s: %s", role, diff)
+				}
+			}
+		})
+	}
+}
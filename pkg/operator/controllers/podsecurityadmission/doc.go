@@ -0,0 +1,52 @@
+package podsecurityadmission
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package tightens the Pod Security Admission level
+enforced on customer namespaces, so that privileged pods can be restricted
+cluster-wide.
+
+OpenShift enforces the privileged level, i.e. nothing, by default and leaves
+the pods to be restricted by security context constraints.  When a level is
+set, the controller sets the pod-security.kubernetes.io/enforce label of
+every customer namespace to it, unless the namespace already enforces a
+stricter level.  It keeps the previous value of the label in the
+aro.openshift.io/pod-security-enforce-previous annotation, and restores it
+when the level is unset.  While a namespace is managed, changes to its label
+are reverted.
+
+System namespaces are never labelled: default, openshift, and any namespace
+prefixed with kube- or openshift-, including those of operators installed
+from OperatorHub.  The platform runs privileged workloads in them.
+
+Impact on existing workloads: Pod Security Admission is only checked when a
+pod is created, so pods which are already running are not evicted.  However,
+any pod which does not meet the level is rejected from then on, including the
+replacement pods of deployments, daemonsets and statefulsets on rollouts,
+node drains and cluster upgrades.  Workloads which run as root, use host
+namespaces, host paths or privileged containers, or (with restricted) do not
+drop all capabilities and set a seccomp profile, will stop being scheduled.
+The kube-apiserver logs and returns warnings naming the existing pods which
+violate a namespace's new level when it is labelled.  Before tightening,
+check the workloads of each customer namespace with a server-side dry run:
+
+  oc label --dry-run=server --overwrite ns <namespace> \
+    pod-security.kubernetes.io/enforce=restricted
+
+Namespaces which must keep running such workloads can be labelled with
+pod-security.kubernetes.io/enforce=privileged only after the level is unset.
+
+These flags control the operations performed by this controller:
+
+aro.podsecurityadmission.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the customer namespaces
+
+aro.podsecurityadmission.level:
+- baseline or restricted; when unset, the platform behaviour is kept and any
+  labels set by the controller are restored
+
+*/
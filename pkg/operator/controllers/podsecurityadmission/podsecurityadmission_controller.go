@@ -0,0 +1,211 @@
+package podsecurityadmission
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/namespace"
+)
+
+const (
+	ControllerName = "PodSecurityAdmission"
+
+	controllerEnabled = "aro.podsecurityadmission.enabled"
+
+	// enforceLabel is the namespace label setting the Pod Security Admission
+	// level which pods must meet to be admitted
+	enforceLabel = "pod-security.kubernetes.io/enforce"
+
+	// previousAnnotation marks the namespaces whose enforce label was set by
+	// the controller, and keeps the label's previous value to restore it
+	// when the level is unset.  It is empty if the label was not set.
+	previousAnnotation = "aro.openshift.io/pod-security-enforce-previous"
+)
+
+// levels orders the Pod Security Admission levels from the loosest to the
+// strictest
+var levels = map[string]int{
+	"privileged": 0,
+	operator.PodSecurityAdmissionLevelBaseline:   1,
+	operator.PodSecurityAdmissionLevelRestricted: 2,
+}
+
+// Reconciler tightens the Pod Security Admission level enforced on customer
+// namespaces
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the namespaces, and sets the enforce
+// label of every customer namespace which is looser than the configured level
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	level, err := operator.PodSecurityAdmissionLevel(instance.Spec.OperatorFlags)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	err = r.Client.List(ctx, namespaces)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	var errs []string
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if namespace.IsPlatformNamespace(ns.Name) || ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		err = r.reconcileNamespace(ctx, ns, level)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		err = fmt.Errorf(strings.Join(errs, "\n"))
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// reconcileNamespace tightens the enforce label of the namespace to level, or
+// restores it if level is empty and the label was set by the controller
+func (r *Reconciler) reconcileNamespace(ctx context.Context, ns *corev1.Namespace, level string) error {
+	current, labelled := ns.Labels[enforceLabel]
+	previous, managed := ns.Annotations[previousAnnotation]
+
+	switch {
+	case level == "" && !managed:
+		return nil
+
+	case level == "":
+		r.Log.Infof("restoring pod security enforcement of namespace %s", ns.Name)
+		if previous == "" {
+			delete(ns.Labels, enforceLabel)
+		} else {
+			if ns.Labels == nil {
+				ns.Labels = map[string]string{}
+			}
+			ns.Labels[enforceLabel] = previous
+		}
+		delete(ns.Annotations, previousAnnotation)
+
+	case current == level:
+		return nil
+
+	case labelled && !managed && stricter(current, level):
+		// the customer tightened the namespace further themselves
+		return nil
+
+	default:
+		r.Log.Infof("enforcing pod security level %s on namespace %s", level, ns.Name)
+		if !managed {
+			if ns.Annotations == nil {
+				ns.Annotations = map[string]string{}
+			}
+			ns.Annotations[previousAnnotation] = current
+		}
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[enforceLabel] = level
+	}
+
+	return r.Client.Update(ctx, ns)
+}
+
+// stricter returns true if level a is stricter than level b.  Unknown levels
+// are treated as the loosest.
+func stricter(a, b string) bool {
+	return levels[a] > levels[b]
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	// only namespaces which are created or whose enforcement may have been
+	// changed are of interest
+	namespacePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return !namespace.IsPlatformNamespace(e.Object.GetName())
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !namespace.IsPlatformNamespace(e.ObjectNew.GetName()) &&
+				(e.ObjectOld.GetLabels()[enforceLabel] != e.ObjectNew.GetLabels()[enforceLabel] ||
+					e.ObjectOld.GetAnnotations()[previousAnnotation] != e.ObjectNew.GetAnnotations()[previousAnnotation])
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			// every reconcile covers all namespaces, so events are collapsed
+			// into a single request
+			handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: arov1alpha1.SingletonClusterName}}}
+			}),
+			builder.WithPredicates(namespacePredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
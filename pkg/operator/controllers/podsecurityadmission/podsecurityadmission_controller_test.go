@@ -0,0 +1,230 @@
+package podsecurityadmission
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	// state is the enforce label of a namespace, and whether and with which
+	// previous value it is managed by the controller
+	type state struct {
+		enforce  string
+		managed  bool
+		previous string
+	}
+
+	namespace := func(name string, s state) *corev1.Namespace {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"team": "a"},
+			},
+		}
+		if s.enforce != "" {
+			ns.Labels[enforceLabel] = s.enforce
+		}
+		if s.managed {
+			ns.Annotations = map[string]string{previousAnnotation: s.previous}
+		}
+		return ns
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		namespaces      map[string]state
+		wantNamespaces  map[string]state
+		wantErr         string
+		wantConditions  []operatorv1.OperatorCondition
+		startConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                "false",
+				"aro.podsecurityadmission.level": "restricted",
+			},
+			namespaces: map[string]state{
+				"customer": {},
+			},
+			wantNamespaces: map[string]state{
+				"customer": {},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "level unset, platform behaviour kept",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			namespaces: map[string]state{
+				"customer": {enforce: "privileged"},
+			},
+			wantNamespaces: map[string]state{
+				"customer": {enforce: "privileged"},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "restricted, system namespaces left alone",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                "true",
+				"aro.podsecurityadmission.level": "restricted",
+			},
+			namespaces: map[string]state{
+				"customer":             {},
+				"privileged":           {enforce: "privileged"},
+				"default":              {},
+				"kube-system":          {},
+				"openshift-gitops":     {},
+				"openshift-monitoring": {enforce: "privileged"},
+			},
+			wantNamespaces: map[string]state{
+				"customer":             {enforce: "restricted", managed: true},
+				"privileged":           {enforce: "restricted", managed: true, previous: "privileged"},
+				"default":              {},
+				"kube-system":          {},
+				"openshift-gitops":     {},
+				"openshift-monitoring": {enforce: "privileged"},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "baseline, stricter namespace left alone and managed namespace loosened",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                "true",
+				"aro.podsecurityadmission.level": "baseline",
+			},
+			namespaces: map[string]state{
+				"strict":  {enforce: "restricted"},
+				"managed": {enforce: "restricted", managed: true},
+			},
+			wantNamespaces: map[string]state{
+				"strict":  {enforce: "restricted"},
+				"managed": {enforce: "baseline", managed: true},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "level unset after being set, labels restored",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			namespaces: map[string]state{
+				"customer":   {enforce: "restricted", managed: true},
+				"privileged": {enforce: "restricted", managed: true, previous: "privileged"},
+			},
+			wantNamespaces: map[string]state{
+				"customer":   {},
+				"privileged": {enforce: "privileged"},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "invalid level",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:                "true",
+				"aro.podsecurityadmission.level": "privileged",
+			},
+			namespaces: map[string]state{
+				"customer": {},
+			},
+			wantNamespaces: map[string]state{
+				"customer": {},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  degraded("invalid aro.podsecurityadmission.level 'privileged': must be baseline or restricted"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					OperatorFlags: tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			builder := ctrlfake.NewClientBuilder().WithObjects(instance)
+			for name, s := range tt.namespaces {
+				builder = builder.WithObjects(namespace(name, s))
+			}
+			clientFake := builder.Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			for name, want := range tt.wantNamespaces {
+				ns := &corev1.Namespace{}
+				err = clientFake.Get(ctx, types.NamespacedName{Name: name}, ns)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				previous, managed := ns.Annotations[previousAnnotation]
+				got := state{
+					enforce:  ns.Labels[enforceLabel],
+					managed:  managed,
+					previous: previous,
+				}
+				if got != want {
+					t.Errorf("%s: got %#v, wanted %#v", name, got, want)
+				}
+				if ns.Labels["team"] != "a" {
+					t.Errorf("%s: got labels %v", name, ns.Labels)
+				}
+			}
+		})
+	}
+}
@@ -61,6 +61,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, err
 	}
 
+	err = clusterauthorizer.OperatorRateLimiter.Configure(clusterInstance.Spec.OperatorFlags)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Get endpoints from operator
 	azEnv, err := azureclient.EnvironmentFromName(clusterInstance.Spec.AZEnvironment)
 	if err != nil {
@@ -97,6 +102,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		if thisErr != nil {
 			// Reconcile all features even if there is an error in some of them
 			err = thisErr
+			clusterauthorizer.OperatorRateLimiter.Observe(err)
 			r.log.Errorf("error reconciling %q: %s", f.Name(), err)
 		}
 	}
@@ -0,0 +1,39 @@
+package projecttemplate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package gives the projects which users request a
+default resource quota and limit range.  The data path is:
+
+* The customer sets projectTemplateProfile on the cluster, and the RP copies it
+  to the ProjectTemplate field on the ARO Cluster object.
+
+* The Reconciler ensures the aro-project-request Template exists in the
+  openshift-config namespace.  Besides the Project and admin RoleBinding of
+  the default OpenShift project request template, the Template creates a
+  default-quota ResourceQuota and a default-limits LimitRange in each new
+  project.
+
+* The Reconciler points spec.projectRequestTemplate of the cluster
+  config.openshift.io Project object at the Template.  If the customer has
+  already configured a different project request template, the controller
+  leaves it alone and is Degraded.
+
+The Template only applies to projects requested after it is configured;
+existing projects are not changed.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.projecttemplate.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the Template and the project
+  configuration according to the ProjectTemplate field on the ARO Cluster
+  object
+
+If the ProjectTemplate field is empty the controller removes the Template and
+its reference from the project configuration, if it created them.
+
+*/
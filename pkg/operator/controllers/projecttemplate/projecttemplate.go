@@ -0,0 +1,176 @@
+package projecttemplate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"fmt"
+
+	projectv1 "github.com/openshift/api/project/v1"
+	templatev1 "github.com/openshift/api/template/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+)
+
+const (
+	templateName      = "aro-project-request"
+	templateNamespace = "openshift-config"
+
+	resourceQuotaName = "default-quota"
+	limitRangeName    = "default-limits"
+)
+
+// projectRequestTemplate returns the project request template which creates
+// the default resource quota and limit range of spec in new projects.  Apart
+// from those, it matches the output of oc adm create-bootstrap-project-template.
+func projectRequestTemplate(spec *arov1alpha1.ProjectTemplateSpec) (*templatev1.Template, error) {
+	objects := []kruntime.Object{
+		&projectv1.Project{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: projectv1.GroupVersion.String(),
+				Kind:       "Project",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "${PROJECT_NAME}",
+				Annotations: map[string]string{
+					"openshift.io/description":  "${PROJECT_DESCRIPTION}",
+					"openshift.io/display-name": "${PROJECT_DISPLAYNAME}",
+					"openshift.io/requester":    "${PROJECT_REQUESTING_USER}",
+				},
+			},
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "RoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "admin",
+				Namespace: "${PROJECT_NAME}",
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "admin",
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.UserKind,
+					Name:     "${PROJECT_ADMIN_USER}",
+				},
+			},
+		},
+	}
+
+	if len(spec.ResourceQuota) > 0 {
+		hard, err := resourceList(spec.ResourceQuota, validate.ResourceQuotaResourceIsValid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource quota: %w", err)
+		}
+
+		objects = append(objects, &corev1.ResourceQuota{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "ResourceQuota",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceQuotaName,
+				Namespace: "${PROJECT_NAME}",
+			},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: hard,
+			},
+		})
+	}
+
+	if len(spec.DefaultLimits) > 0 || len(spec.DefaultRequests) > 0 {
+		limits, err := resourceList(spec.DefaultLimits, validate.LimitRangeResourceIsValid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default limits: %w", err)
+		}
+
+		requests, err := resourceList(spec.DefaultRequests, validate.LimitRangeResourceIsValid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default requests: %w", err)
+		}
+
+		objects = append(objects, &corev1.LimitRange{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "LimitRange",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      limitRangeName,
+				Namespace: "${PROJECT_NAME}",
+			},
+			Spec: corev1.LimitRangeSpec{
+				Limits: []corev1.LimitRangeItem{
+					{
+						Type:           corev1.LimitTypeContainer,
+						Default:        limits,
+						DefaultRequest: requests,
+					},
+				},
+			},
+		})
+	}
+
+	template := &templatev1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      templateName,
+			Namespace: templateNamespace,
+		},
+		Parameters: []templatev1.Parameter{
+			{Name: "PROJECT_NAME"},
+			{Name: "PROJECT_DISPLAYNAME"},
+			{Name: "PROJECT_DESCRIPTION"},
+			{Name: "PROJECT_ADMIN_USER"},
+			{Name: "PROJECT_REQUESTING_USER"},
+		},
+	}
+
+	// the objects are serialised here rather than left in Object so that the
+	// template compares equal to the one read back from the API server
+	for _, o := range objects {
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, err
+		}
+
+		template.Objects = append(template.Objects, kruntime.RawExtension{Raw: b})
+	}
+
+	return template, nil
+}
+
+// resourceList parses quantities into a ResourceList, returning an error if
+// a resource isn't valid or a quantity doesn't parse
+func resourceList(quantities map[string]string, valid func(string) bool) (corev1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+
+	rl := make(corev1.ResourceList, len(quantities))
+	for name, value := range quantities {
+		if !valid(name) {
+			return nil, fmt.Errorf("invalid resource %q", name)
+		}
+
+		q, err := resource.ParseQuantity(value)
+		if err != nil || q.Sign() < 0 {
+			return nil, fmt.Errorf("invalid quantity %q for resource %q", value, name)
+		}
+
+		rl[corev1.ResourceName(name)] = q
+	}
+
+	return rl, nil
+}
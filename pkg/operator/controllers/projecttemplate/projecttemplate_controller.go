@@ -0,0 +1,191 @@
+package projecttemplate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	configv1 "github.com/openshift/api/config/v1"
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "ProjectTemplate"
+
+	controllerEnabled = "aro.projecttemplate.enabled"
+
+	projectConfigName = "cluster"
+)
+
+// Reconciler ensures the project request template which gives new projects
+// a default resource quota and limit range
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object, the project configuration and the ARO
+// project request template, and if any of them changes, reconciles the
+// template and the project configuration
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	if instance.Spec.ProjectTemplate == nil {
+		err = r.removeProjectTemplate(ctx)
+	} else {
+		err = r.ensureProjectTemplate(ctx, instance.Spec.ProjectTemplate)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) ensureProjectTemplate(ctx context.Context, spec *arov1alpha1.ProjectTemplateSpec) error {
+	template, err := projectRequestTemplate(spec)
+	if err != nil {
+		return err
+	}
+
+	project := &configv1.Project{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: projectConfigName}, project)
+	if err != nil {
+		return err
+	}
+
+	// don't override a project request template set up by the customer
+	if name := project.Spec.ProjectRequestTemplate.Name; name != "" && name != templateName {
+		return fmt.Errorf("project request template is already set to %q", name)
+	}
+
+	err = r.createOrUpdateTemplate(ctx, template)
+	if err != nil {
+		return err
+	}
+
+	if project.Spec.ProjectRequestTemplate.Name == templateName {
+		return nil
+	}
+
+	project.Spec.ProjectRequestTemplate.Name = templateName
+	return r.Client.Update(ctx, project)
+}
+
+func (r *Reconciler) createOrUpdateTemplate(ctx context.Context, template *templatev1.Template) error {
+	old := &templatev1.Template{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: template.Namespace, Name: template.Name}, old)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, template)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(old.Objects, template.Objects) &&
+		reflect.DeepEqual(old.Parameters, template.Parameters) {
+		return nil
+	}
+
+	old.Objects = template.Objects
+	old.Parameters = template.Parameters
+	return r.Client.Update(ctx, old)
+}
+
+// removeProjectTemplate removes the ARO project request template and its
+// reference from the project configuration.  A template set up by the
+// customer is left alone.
+func (r *Reconciler) removeProjectTemplate(ctx context.Context) error {
+	project := &configv1.Project{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: projectConfigName}, project)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && project.Spec.ProjectRequestTemplate.Name == templateName {
+		project.Spec.ProjectRequestTemplate.Name = ""
+		err = r.Client.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.Client.Delete(ctx, &templatev1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      templateName,
+			Namespace: templateNamespace,
+		},
+	})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	projectConfigPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == projectConfigName
+	})
+
+	aroTemplatePredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetNamespace() == templateNamespace && o.GetName() == templateName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &configv1.Project{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(projectConfigPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &templatev1.Template{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(aroTemplatePredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,216 @@
+package projecttemplate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	spec := &arov1alpha1.ProjectTemplateSpec{
+		ResourceQuota: map[string]string{
+			"pods": "20",
+		},
+	}
+
+	cluster := func(enabled string, spec *arov1alpha1.ProjectTemplateSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				ProjectTemplate: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	projectConfig := func(templateName string) *configv1.Project {
+		return &configv1.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: projectConfigName},
+			Spec: configv1.ProjectSpec{
+				ProjectRequestTemplate: configv1.TemplateReference{
+					Name: templateName,
+				},
+			},
+		}
+	}
+
+	aroTemplate := &templatev1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      templateName,
+			Namespace: templateNamespace,
+		},
+		Objects: []kruntime.RawExtension{
+			{Raw: []byte(`{}`)},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		objects             []client.Object
+		wantErrMsg          string
+		wantConditions      []operatorv1.OperatorCondition
+		wantRequestTemplate string
+		wantTemplate        bool
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", spec),
+				projectConfig(""),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "project template is created",
+			objects: []client.Object{
+				cluster("true", spec),
+				projectConfig(""),
+			},
+			wantConditions:      defaultConditions,
+			wantRequestTemplate: templateName,
+			wantTemplate:        true,
+		},
+		{
+			name: "project template is updated",
+			objects: []client.Object{
+				cluster("true", spec),
+				projectConfig(templateName),
+				aroTemplate.DeepCopy(),
+			},
+			wantConditions:      defaultConditions,
+			wantRequestTemplate: templateName,
+			wantTemplate:        true,
+		},
+		{
+			name: "customer project template is left alone",
+			objects: []client.Object{
+				cluster("true", spec),
+				projectConfig("customer-project-request"),
+			},
+			wantErrMsg:          `project request template is already set to "customer-project-request"`,
+			wantConditions:      degraded(`project request template is already set to "customer-project-request"`),
+			wantRequestTemplate: "customer-project-request",
+		},
+		{
+			name: "invalid project template is degraded",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.ProjectTemplateSpec{
+					DefaultLimits: map[string]string{
+						"cpu": "lots",
+					},
+				}),
+				projectConfig(""),
+			},
+			wantErrMsg:     `invalid default limits: invalid quantity "lots" for resource "cpu"`,
+			wantConditions: degraded(`invalid default limits: invalid quantity "lots" for resource "cpu"`),
+		},
+		{
+			name: "project template is removed",
+			objects: []client.Object{
+				cluster("true", nil),
+				projectConfig(templateName),
+				aroTemplate.DeepCopy(),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "customer project template is not removed",
+			objects: []client.Object{
+				cluster("true", nil),
+				projectConfig("customer-project-request"),
+			},
+			wantConditions:      defaultConditions,
+			wantRequestTemplate: "customer-project-request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			project := &configv1.Project{}
+			err = client.Get(ctx, types.NamespacedName{Name: projectConfigName}, project)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if project.Spec.ProjectRequestTemplate.Name != tt.wantRequestTemplate {
+				t.Errorf("got project request template %q, want %q", project.Spec.ProjectRequestTemplate.Name, tt.wantRequestTemplate)
+			}
+
+			template := &templatev1.Template{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: templateNamespace, Name: templateName}, template)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if gotTemplate := err == nil; gotTemplate != tt.wantTemplate {
+				t.Fatalf("got template %v, want %v", gotTemplate, tt.wantTemplate)
+			}
+			if tt.wantTemplate && len(template.Objects) != 3 {
+				t.Errorf("got %d template objects, want 3", len(template.Objects))
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package projecttemplate
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestProjectRequestTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		spec        *arov1alpha1.ProjectTemplateSpec
+		wantKinds   []string
+		wantHard    corev1.ResourceList
+		wantLimits  corev1.ResourceList
+		wantRequest corev1.ResourceList
+		wantErr     string
+	}{
+		{
+			name: "resource quota and limit range",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				ResourceQuota: map[string]string{
+					"pods":         "20",
+					"requests.cpu": "4",
+				},
+				DefaultLimits: map[string]string{
+					"memory": "1Gi",
+				},
+				DefaultRequests: map[string]string{
+					"cpu": "100m",
+				},
+			},
+			wantKinds: []string{"Project", "RoleBinding", "ResourceQuota", "LimitRange"},
+			wantHard: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("20"),
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+			wantLimits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			wantRequest: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			},
+		},
+		{
+			name: "resource quota only",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				ResourceQuota: map[string]string{
+					"count/deployments.apps": "10",
+				},
+			},
+			wantKinds: []string{"Project", "RoleBinding", "ResourceQuota"},
+			wantHard: corev1.ResourceList{
+				"count/deployments.apps": resource.MustParse("10"),
+			},
+		},
+		{
+			name: "limit range only",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				DefaultRequests: map[string]string{
+					"ephemeral-storage": "1Gi",
+				},
+			},
+			wantKinds: []string{"Project", "RoleBinding", "LimitRange"},
+			wantRequest: corev1.ResourceList{
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+			},
+		},
+		{
+			name: "invalid resource quota resource",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				ResourceQuota: map[string]string{
+					"requests.gpu": "1",
+				},
+			},
+			wantErr: `invalid resource quota: invalid resource "requests.gpu"`,
+		},
+		{
+			name: "invalid default request resource",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				DefaultRequests: map[string]string{
+					"pods": "1",
+				},
+			},
+			wantErr: `invalid default requests: invalid resource "pods"`,
+		},
+		{
+			name: "negative quantity",
+			spec: &arov1alpha1.ProjectTemplateSpec{
+				ResourceQuota: map[string]string{
+					"limits.memory": "-1Gi",
+				},
+			},
+			wantErr: `invalid resource quota: invalid quantity "-1Gi" for resource "limits.memory"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := projectRequestTemplate(tt.spec)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			if template.Name != templateName || template.Namespace != templateNamespace {
+				t.Errorf("got template %s/%s", template.Namespace, template.Name)
+			}
+			if len(template.Parameters) != 5 {
+				t.Errorf("got %d parameters, want 5", len(template.Parameters))
+			}
+
+			var kinds []string
+			for _, o := range template.Objects {
+				var tm metav1.TypeMeta
+				err := json.Unmarshal(o.Raw, &tm)
+				if err != nil {
+					t.Fatal(err)
+				}
+				kinds = append(kinds, tm.Kind)
+
+				switch tm.Kind {
+				case "ResourceQuota":
+					var rq corev1.ResourceQuota
+					err := json.Unmarshal(o.Raw, &rq)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if rq.Namespace != "${PROJECT_NAME}" {
+						t.Errorf("got namespace %q", rq.Namespace)
+					}
+					assertResourceList(t, "hard", rq.Spec.Hard, tt.wantHard)
+
+				case "LimitRange":
+					var lr corev1.LimitRange
+					err := json.Unmarshal(o.Raw, &lr)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(lr.Spec.Limits) != 1 || lr.Spec.Limits[0].Type != corev1.LimitTypeContainer {
+						t.Fatalf("got limits %v", lr.Spec.Limits)
+					}
+					assertResourceList(t, "default", lr.Spec.Limits[0].Default, tt.wantLimits)
+					assertResourceList(t, "defaultRequest", lr.Spec.Limits[0].DefaultRequest, tt.wantRequest)
+				}
+			}
+
+			if len(kinds) != len(tt.wantKinds) {
+				t.Fatalf("got kinds %v, want %v", kinds, tt.wantKinds)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.wantKinds[i] {
+					t.Errorf("got kinds %v, want %v", kinds, tt.wantKinds)
+				}
+			}
+		})
+	}
+}
+
+func assertResourceList(t *testing.T, name string, got, want corev1.ResourceList) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("%s: got %v, want %v", name, got, want)
+		return
+	}
+	for k, q := range want {
+		if gotq, ok := got[k]; !ok || gotq.Cmp(q) != 0 {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+}
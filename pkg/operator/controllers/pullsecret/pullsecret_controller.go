@@ -8,13 +8,17 @@ package pullsecret
 // this controllers ensures valid ARO secret for Azure mirror with
 // openshift images
 // It also signals presense of Red Hat image registry keys in a
-// cluster.status.RedHatKeysPresent field.
+// cluster.status.RedHatKeysPresent field, and sets the PullSecretValid
+// condition to false for a while whenever it has had to repair the pull
+// secret, so that tampering with it can be tracked.
 
 import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +34,7 @@ import (
 
 	"github.com/Azure/ARO-RP/pkg/operator"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
 	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
 )
 
@@ -38,6 +43,12 @@ const (
 
 	controllerEnabled = "aro.pullsecret.enabled"
 	controllerManaged = "aro.pullsecret.managed"
+
+	// repairedConditionDuration is how long the PullSecretValid condition is
+	// kept false after the pull secret has been repaired
+	repairedConditionDuration = time.Hour
+
+	conditionReasonRepaired = "Repaired"
 )
 
 var pullSecretName = types.NamespacedName{Name: "pull-secret", Namespace: "openshift-config"}
@@ -87,7 +98,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 
 	// reconcile global pull secret
 	// detects if the global pull secret is broken and fixes it by using backup managed by ARO operator
-	if instance.Spec.OperatorFlags.GetSimpleBoolean(controllerManaged) {
+	managed := instance.Spec.OperatorFlags.GetSimpleBoolean(controllerManaged)
+	var repaired bool
+	if managed {
 		operatorSecret := &corev1.Secret{}
 		err = r.client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: operator.SecretName}, operatorSecret)
 		if err != nil {
@@ -95,7 +108,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		}
 
 		// fix pull secret if its broken to have at least the ARO pull secret
-		userSecret, err = r.ensureGlobalPullSecret(ctx, operatorSecret, userSecret)
+		userSecret, repaired, err = r.ensureGlobalPullSecret(ctx, operatorSecret, userSecret)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -110,6 +123,46 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	err = r.client.Update(ctx, instance)
+	if err != nil || !managed {
+		return reconcile.Result{}, err
+	}
+
+	return r.reconcileCondition(ctx, instance, repaired)
+}
+
+// reconcileCondition sets the PullSecretValid condition.  After the pull
+// secret has been repaired, the condition is kept false for
+// repairedConditionDuration, even though the pull secret is valid again, so
+// that monitoring picks up the repair.
+func (r *Reconciler) reconcileCondition(ctx context.Context, instance *arov1alpha1.Cluster, repaired bool) (ctrl.Result, error) {
+	if repaired {
+		r.log.Warn("pull secret was missing the ARO registry auths and has been repaired")
+
+		err := conditions.SetCondition(ctx, r.client, &operatorv1.OperatorCondition{
+			Type:    arov1alpha1.PullSecretValid,
+			Status:  operatorv1.ConditionFalse,
+			Message: "The pull secret was missing the ARO registry auths and has been repaired",
+			Reason:  conditionReasonRepaired,
+		}, operator.RoleMaster)
+		return reconcile.Result{RequeueAfter: repairedConditionDuration}, err
+	}
+
+	for _, c := range instance.Status.Conditions {
+		if c.Type == arov1alpha1.PullSecretValid &&
+			c.Status == operatorv1.ConditionFalse &&
+			c.Reason == conditionReasonRepaired {
+			if remaining := repairedConditionDuration - time.Since(c.LastTransitionTime.Time); remaining > 0 {
+				return reconcile.Result{RequeueAfter: remaining}, nil
+			}
+		}
+	}
+
+	err := conditions.SetCondition(ctx, r.client, &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.PullSecretValid,
+		Status:  operatorv1.ConditionTrue,
+		Message: "The pull secret contains the ARO registry auths",
+		Reason:  "CheckDone",
+	}, operator.RoleMaster)
 	return reconcile.Result{}, err
 }
 
@@ -140,9 +193,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 // ensureGlobalPullSecret checks the state of the pull secrets, in case of missing or broken ARO pull secret
 // it replaces it with working one from controller Secret
 // it takes care only for ARO pull secret, it does not touch the customer keys
-func (r *Reconciler) ensureGlobalPullSecret(ctx context.Context, operatorSecret, userSecret *corev1.Secret) (secret *corev1.Secret, err error) {
+// it returns whether the pull secret had to be repaired
+func (r *Reconciler) ensureGlobalPullSecret(ctx context.Context, operatorSecret, userSecret *corev1.Secret) (secret *corev1.Secret, repaired bool, err error) {
 	if operatorSecret == nil {
-		return nil, errors.New("nil operator secret, cannot verify userData integrity")
+		return nil, false, errors.New("nil operator secret, cannot verify userData integrity")
 	}
 
 	recreate := false
@@ -172,12 +226,12 @@ func (r *Reconciler) ensureGlobalPullSecret(ctx context.Context, operatorSecret,
 
 	fixedData, update, err := pullsecret.Merge(string(secret.Data[corev1.DockerConfigJsonKey]), string(operatorSecret.Data[corev1.DockerConfigJsonKey]))
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// update is true for any case when ARO keys are fixed, meaning no need to double check for recreation
 	if !update {
-		return userSecret, nil
+		return userSecret, false, nil
 	}
 
 	secret.Data[corev1.DockerConfigJsonKey] = []byte(fixedData)
@@ -188,15 +242,15 @@ func (r *Reconciler) ensureGlobalPullSecret(ctx context.Context, operatorSecret,
 		// this call happens only when there is a need to change, it has no significant impact on performance
 		err := r.client.Delete(ctx, secret)
 		if err != nil && !kerrors.IsNotFound(err) {
-			return nil, err
+			return nil, false, err
 		}
 
 		err = r.client.Create(ctx, secret)
-		return secret, err
+		return secret, true, err
 	}
 
 	err = r.client.Update(ctx, secret)
-	return secret, err
+	return secret, true, err
 }
 
 // parseRedHatKeys unmarshal and extract following RH keys from pull-secret:
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -384,6 +386,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 		operatorPullSecret *corev1.Secret
 		pullSecret         *corev1.Secret
 		wantSecret         *corev1.Secret
+		wantRepaired       bool
 		wantError          string
 	}{
 		{
@@ -467,6 +470,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name: "Red Hat key added should merge in",
@@ -507,6 +511,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name: "Pull secret empty",
@@ -543,6 +548,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name:          "Secret missing",
@@ -570,6 +576,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name: "Red Hat Key present but secret type broken",
@@ -614,7 +621,8 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
-			wantError: "",
+			wantRepaired: true,
+			wantError:    "",
 		},
 		{
 			name: "Secret auth key broken broken",
@@ -659,6 +667,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name: "Secret not parseable",
@@ -696,6 +705,7 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				},
 				Type: corev1.SecretTypeDockerConfigJson,
 			},
+			wantRepaired: true,
 		},
 		{
 			name: "Operator secret not parseable",
@@ -770,12 +780,102 @@ func TestEnsureGlobalPullSecret(t *testing.T) {
 				client: clientBuilder.Build(),
 			}
 
-			s, err := r.ensureGlobalPullSecret(ctx, tt.operatorPullSecret, tt.pullSecret)
+			s, repaired, err := r.ensureGlobalPullSecret(ctx, tt.operatorPullSecret, tt.pullSecret)
 			utilerror.AssertErrorMessage(t, err, tt.wantError)
 
+			if repaired != tt.wantRepaired {
+				t.Errorf("got repaired %v, wanted %v", repaired, tt.wantRepaired)
+			}
+
 			if !reflect.DeepEqual(s, tt.wantSecret) {
 				t.Fatalf(cmp.Diff(s, tt.wantSecret))
 			}
 		})
 	}
 }
+
+func TestReconcileCondition(t *testing.T) {
+	repairedCondition := func(transitionTime time.Time) operatorv1.OperatorCondition {
+		return operatorv1.OperatorCondition{
+			Type:               arov1alpha1.PullSecretValid,
+			Status:             operatorv1.ConditionFalse,
+			LastTransitionTime: metav1.Time{Time: transitionTime},
+			Message:            "The pull secret was missing the ARO registry auths and has been repaired",
+			Reason:             conditionReasonRepaired,
+		}
+	}
+
+	for _, tt := range []struct {
+		name             string
+		repaired         bool
+		startConditions  []operatorv1.OperatorCondition
+		wantStatus       operatorv1.ConditionStatus
+		wantRequeueAfter bool
+	}{
+		{
+			name:       "pull secret intact",
+			wantStatus: operatorv1.ConditionTrue,
+		},
+		{
+			name:             "pull secret repaired",
+			repaired:         true,
+			wantStatus:       operatorv1.ConditionFalse,
+			wantRequeueAfter: true,
+		},
+		{
+			name:             "pull secret repaired recently",
+			startConditions:  []operatorv1.OperatorCondition{repairedCondition(time.Now().Add(-time.Minute))},
+			wantStatus:       operatorv1.ConditionFalse,
+			wantRequeueAfter: true,
+		},
+		{
+			name:            "pull secret repaired a while ago",
+			startConditions: []operatorv1.OperatorCondition{repairedCondition(time.Now().Add(-2 * repairedConditionDuration))},
+			wantStatus:      operatorv1.ConditionTrue,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(instance).Build()
+
+			r := &Reconciler{
+				log:    logrus.NewEntry(logrus.StandardLogger()),
+				client: clientFake,
+			}
+
+			result, err := r.reconcileCondition(ctx, instance, tt.repaired)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.wantRequeueAfter {
+				t.Errorf("got requeueAfter %v", result.RequeueAfter)
+			}
+
+			cluster := &arov1alpha1.Cluster{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var status operatorv1.ConditionStatus
+			for _, c := range cluster.Status.Conditions {
+				if c.Type == arov1alpha1.PullSecretValid {
+					status = c.Status
+				}
+			}
+
+			if status != tt.wantStatus {
+				t.Errorf("got condition status %q, wanted %q", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package registrymirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package configures the cluster to pull the images of
+upstream registries, e.g. docker.io, through the pull-through caches which the
+customer chose, e.g. Azure Container Registry cache rules.
+
+The RP copies the registryMirrorProfiles of the cluster to the RegistryMirrors
+field on the ARO Cluster object.  The Reconciler ensures the
+aro-registry-mirrors ImageContentSourcePolicy, which makes CRI-O try the
+mirror of a registry first and fall back to the registry itself if the mirror
+is unavailable.  The machine config operator rolls the resulting
+registries.conf out to the nodes.
+
+An ImageContentSourcePolicy is used rather than an ImageDigestMirrorSet and
+ImageTagMirrorSet, which need OpenShift 4.13 or later.  Like any
+ImageContentSourcePolicy it only applies to images pulled by digest: images
+pulled by tag are still pulled from the upstream registry.
+
+Pull-through caches must be allowed by the cluster image configuration; the
+ImageConfig controller adds them to allowedRegistries and removes them from
+blockedRegistries.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.registrymirror.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the ImageContentSourcePolicy
+  according to the RegistryMirrors field on the ARO Cluster object
+
+If the RegistryMirrors field is empty the controller removes the
+ImageContentSourcePolicy.
+
+*/
@@ -0,0 +1,138 @@
+package registrymirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "RegistryMirror"
+
+	controllerEnabled = "aro.registrymirror.enabled"
+
+	policyName = "aro-registry-mirrors"
+)
+
+// Reconciler ensures the ImageContentSourcePolicy which mirrors upstream
+// registries to their pull-through caches
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the ImageContentSourcePolicy, and if
+// either changes, reconciles the ImageContentSourcePolicy
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	if len(instance.Spec.RegistryMirrors) == 0 {
+		err = r.removePolicy(ctx)
+	} else {
+		err = r.ensurePolicy(ctx, instance.Spec.RegistryMirrors)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) ensurePolicy(ctx context.Context, mirrors []arov1alpha1.RegistryMirrorSpec) error {
+	var digestMirrors []operatorv1alpha1.RepositoryDigestMirrors
+	for _, m := range mirrors {
+		digestMirrors = append(digestMirrors, operatorv1alpha1.RepositoryDigestMirrors{
+			Source:  m.Source,
+			Mirrors: []string{m.Mirror},
+		})
+	}
+
+	icsp := &operatorv1alpha1.ImageContentSourcePolicy{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: policyName}, icsp)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &operatorv1alpha1.ImageContentSourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: policyName},
+			Spec:       operatorv1alpha1.ImageContentSourcePolicySpec{RepositoryDigestMirrors: digestMirrors},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(icsp.Spec.RepositoryDigestMirrors, digestMirrors) {
+		return nil
+	}
+
+	icsp.Spec.RepositoryDigestMirrors = digestMirrors
+	return r.Client.Update(ctx, icsp)
+}
+
+// removePolicy deletes the ImageContentSourcePolicy, if it exists.  Policies
+// created by the customer are left alone.
+func (r *Reconciler) removePolicy(ctx context.Context) error {
+	err := r.Client.Delete(ctx, &operatorv1alpha1.ImageContentSourcePolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName}})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	policyPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == policyName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &operatorv1alpha1.ImageContentSourcePolicy{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(policyPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
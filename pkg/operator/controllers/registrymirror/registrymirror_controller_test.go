@@ -0,0 +1,181 @@
+package registrymirror
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	mirrors := []arov1alpha1.RegistryMirrorSpec{
+		{
+			Source: "docker.io",
+			Mirror: "example.azurecr.io/docker.io",
+		},
+		{
+			Source: "quay.io/customer",
+			Mirror: "example.azurecr.io/quay.io/customer",
+		},
+	}
+
+	cluster := func(enabled string, mirrors []arov1alpha1.RegistryMirrorSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				RegistryMirrors: mirrors,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	wantDigestMirrors := []operatorv1alpha1.RepositoryDigestMirrors{
+		{
+			Source:  "docker.io",
+			Mirrors: []string{"example.azurecr.io/docker.io"},
+		},
+		{
+			Source:  "quay.io/customer",
+			Mirrors: []string{"example.azurecr.io/quay.io/customer"},
+		},
+	}
+
+	driftedPolicy := &operatorv1alpha1.ImageContentSourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: policyName},
+		Spec: operatorv1alpha1.ImageContentSourcePolicySpec{
+			RepositoryDigestMirrors: []operatorv1alpha1.RepositoryDigestMirrors{
+				{
+					Source:  "docker.io",
+					Mirrors: []string{"other.azurecr.io/docker.io"},
+				},
+			},
+		},
+	}
+
+	customerPolicy := &operatorv1alpha1.ImageContentSourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "customer"},
+	}
+
+	tests := []struct {
+		name               string
+		objects            []client.Object
+		wantErrMsg         string
+		wantConditions     []operatorv1.OperatorCondition
+		wantPolicy         bool
+		wantCustomerPolicy bool
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", mirrors),
+			},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "policy is created",
+			objects: []client.Object{
+				cluster("true", mirrors),
+			},
+			wantConditions: defaultConditions,
+			wantPolicy:     true,
+		},
+		{
+			name: "policy drift is reverted",
+			objects: []client.Object{
+				cluster("true", mirrors),
+				driftedPolicy,
+			},
+			wantConditions: defaultConditions,
+			wantPolicy:     true,
+		},
+		{
+			name: "policy is removed, customer policies are left alone",
+			objects: []client.Object{
+				cluster("true", nil),
+				driftedPolicy,
+				customerPolicy,
+			},
+			wantConditions:     defaultConditions,
+			wantCustomerPolicy: true,
+		},
+		{
+			name: "no mirrors and no policy",
+			objects: []client.Object{
+				cluster("true", nil),
+			},
+			wantConditions: defaultConditions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			icsp := &operatorv1alpha1.ImageContentSourcePolicy{}
+			err = client.Get(ctx, types.NamespacedName{Name: policyName}, icsp)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if tt.wantPolicy != (err == nil) {
+				t.Errorf("got ImageContentSourcePolicy error %v", err)
+			}
+			if tt.wantPolicy && !reflect.DeepEqual(icsp.Spec.RepositoryDigestMirrors, wantDigestMirrors) {
+				t.Errorf("got repository digest mirrors %#v", icsp.Spec.RepositoryDigestMirrors)
+			}
+
+			err = client.Get(ctx, types.NamespacedName{Name: "customer"}, &operatorv1alpha1.ImageContentSourcePolicy{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if tt.wantCustomerPolicy != (err == nil) {
+				t.Errorf("got customer ImageContentSourcePolicy error %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package resourcetags
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package keeps the tags required by the tag policy of
+the cluster's subscription on the Azure resources in the cluster resource
+group, so that the resources can be attributed to their owner.  The RP sets
+the tags, with the values of the cluster's tags, in the resourceTags of the
+Cluster resource whenever it updates the operator.
+
+The controller checks the resources every hour and whenever the Cluster
+resource changes, so that resources created after the install, e.g. by the
+machine API, are tagged, and tags which are removed or changed are restored.
+Other tags on the resources are left alone.  If the cluster resource group was
+provided by the customer, only the resources of the cluster are tagged.
+
+These flags control the operations performed by this controller:
+
+aro.resourcetags.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the tags of the resources
+
+*/
@@ -0,0 +1,258 @@
+package resourcetags
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	imageregistryv1 "github.com/openshift/api/imageregistry/v1"
+	"github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+const (
+	ControllerName = "ResourceTags"
+
+	controllerEnabled = "aro.resourcetags.enabled"
+)
+
+// requeueInterval is how often the resources are checked.  Resources are
+// created, e.g. by the machine API, and their tags changed without the
+// controller being notified.
+const requeueInterval = time.Hour
+
+// Reconciler keeps the required tags on the Azure resources of the cluster
+type Reconciler struct {
+	base.AROController
+
+	newResourcesClient func(ctx context.Context, instance *arov1alpha1.Cluster) (features.ResourcesClient, error)
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	r := &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+	r.newResourcesClient = r.resourcesClient
+
+	return r
+}
+
+// Reconcile sets the tags of the Cluster resource's spec on the resources in
+// the cluster resource group
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.ResourceTags == nil || len(instance.Spec.ResourceTags.Tags) == 0 {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.reconcileResourceTags(ctx, instance)
+	if err != nil {
+		// hold back further Azure calls if this one was throttled
+		clusterauthorizer.OperatorRateLimiter.Observe(err)
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// resourcesClient returns a resources client authorized as the cluster
+// service principal
+func (r *Reconciler) resourcesClient(ctx context.Context, instance *arov1alpha1.Cluster) (features.ResourcesClient, error) {
+	err := clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := azure.ParseResourceID(instance.Spec.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	azRefreshAuthorizer, err := clusterauthorizer.NewAzRefreshableAuthorizer(r.Log, &azEnv, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azRefreshAuthorizer.NewRefreshableAuthorizerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return features.NewResourcesClient(&azEnv, resource.SubscriptionID, authorizer), nil
+}
+
+// reconcileResourceTags sets the tags on the resources in the cluster
+// resource group.  Other tags on the resources are left alone.  If the
+// resource group was provided by the customer, only the resources of the
+// cluster are tagged.
+func (r *Reconciler) reconcileResourceTags(ctx context.Context, instance *arov1alpha1.Cluster) error {
+	var registryAccountName string
+	if instance.Spec.ResourceTags.ClusterResourcesOnly {
+		rc := &imageregistryv1.Config{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: "cluster"}, rc)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && rc.Spec.Storage.Azure != nil {
+			registryAccountName = rc.Spec.Storage.Azure.AccountName
+		}
+	}
+
+	resources, err := r.newResourcesClient(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := stringutils.LastTokenByte(instance.Spec.ClusterResourceGroupID, '/')
+
+	list, err := resources.ListByResourceGroup(ctx, resourceGroup, "", "", nil)
+	if err != nil {
+		return err
+	}
+
+	for i, resource := range list {
+		if instance.Spec.ResourceTags.ClusterResourcesOnly && !isClusterResource(instance, registryAccountName, &list[i]) {
+			continue
+		}
+
+		apiVersion := azureclient.APIVersion(*resource.Type)
+		if apiVersion == "" {
+			r.Log.Infof("skipping tags of resource %s: unknown type", *resource.ID)
+			continue
+		}
+
+		tags, changed := setTags(resource.Tags, instance.Spec.ResourceTags.Tags)
+		if !changed {
+			continue
+		}
+
+		r.Log.Printf("setting required tags on resource %s", *resource.ID)
+		err = resources.UpdateByIDAndWait(ctx, *resource.ID, apiVersion, mgmtfeatures.GenericResource{
+			Tags: tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setTags returns the tags of a resource with the given tags set on them, and
+// whether they changed.  Tag names are case-insensitive: a tag which the
+// resource has under a differently cased name is renamed.
+func setTags(resourceTags map[string]*string, tags map[string]string) (map[string]*string, bool) {
+	result := map[string]*string{}
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+
+	changed := false
+	for k, v := range tags {
+		for existing := range result {
+			if strings.EqualFold(existing, k) && existing != k {
+				result[k] = result[existing]
+				delete(result, existing)
+				changed = true
+			}
+		}
+
+		if result[k] == nil || *result[k] != v {
+			result[k] = to.StringPtr(v)
+			changed = true
+		}
+	}
+
+	return result, changed
+}
+
+// isClusterResource returns true if a resource in the cluster resource group
+// belongs to the cluster.  The RP, the installer and the machine API name
+// their resources after the infra ID or tag them as owned by it; the only
+// exceptions are the cluster storage accounts, which have random names.
+func isClusterResource(instance *arov1alpha1.Cluster, registryAccountName string, resource *mgmtfeatures.GenericResourceExpanded) bool {
+	if resource.Name == nil {
+		return false
+	}
+
+	infraID := instance.Spec.InfraID
+	if infraID != "" && strings.HasPrefix(strings.ToLower(*resource.Name), strings.ToLower(infraID)) {
+		return true
+	}
+
+	if instance.Spec.StorageSuffix != "" &&
+		strings.EqualFold(*resource.Name, "cluster"+instance.Spec.StorageSuffix) {
+		return true
+	}
+
+	if registryAccountName != "" && strings.EqualFold(*resource.Name, registryAccountName) {
+		return true
+	}
+
+	if infraID == "" {
+		return false
+	}
+
+	for k, v := range resource.Tags {
+		if (strings.EqualFold(k, "kubernetes.io_cluster."+infraID) || strings.EqualFold(k, "kubernetes.io-cluster-"+infraID)) &&
+			v != nil && strings.EqualFold(*v, "owned") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,238 @@
+package resourcetags
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	imageregistryv1 "github.com/openshift/api/imageregistry/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+	mock_features "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/features"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	resourceID := func(name string) string {
+		return "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/cluster-rg/providers/Microsoft.Network/networkInterfaces/" + name
+	}
+
+	resource := func(name string, tags map[string]*string) mgmtfeatures.GenericResourceExpanded {
+		return mgmtfeatures.GenericResourceExpanded{
+			ID:   to.StringPtr(resourceID(name)),
+			Name: to.StringPtr(name),
+			Type: to.StringPtr("Microsoft.Network/networkInterfaces"),
+			Tags: tags,
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		resourceTags    *arov1alpha1.ResourceTagsSpec
+		mocks           func(*mock_features.MockResourcesClient)
+		wantRequeue     time.Duration
+		wantErr         string
+		wantConditions  []operatorv1.OperatorCondition
+		startConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "false",
+			},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"Owner": "team"},
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "no resource tags",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "resource tags set",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"Owner": "team"},
+			},
+			mocks: func(resources *mock_features.MockResourcesClient) {
+				resources.EXPECT().ListByResourceGroup(gomock.Any(), "cluster-rg", "", "", nil).Return([]mgmtfeatures.GenericResourceExpanded{
+					resource("infra-nic", map[string]*string{"kubernetes.io_cluster.infra": to.StringPtr("owned")}),
+					resource("tagged-nic", map[string]*string{"Owner": to.StringPtr("team")}),
+					resource("renamed-nic", map[string]*string{"owner": to.StringPtr("team")}),
+					resource("changed-nic", map[string]*string{"Owner": to.StringPtr("someone")}),
+					{
+						ID:   to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/cluster-rg/providers/Microsoft.Unknown/things/thing"),
+						Type: to.StringPtr("Microsoft.Unknown/things"),
+					},
+				}, nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("infra-nic"), "2020-08-01", mgmtfeatures.GenericResource{
+					Tags: map[string]*string{
+						"kubernetes.io_cluster.infra": to.StringPtr("owned"),
+						"Owner":                       to.StringPtr("team"),
+					},
+				}).Return(nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("renamed-nic"), "2020-08-01", mgmtfeatures.GenericResource{
+					Tags: map[string]*string{
+						"Owner": to.StringPtr("team"),
+					},
+				}).Return(nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("changed-nic"), "2020-08-01", mgmtfeatures.GenericResource{
+					Tags: map[string]*string{
+						"Owner": to.StringPtr("team"),
+					},
+				}).Return(nil)
+			},
+			wantRequeue:     requeueInterval,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "existing resource group",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags:                 map[string]string{"owner": "team"},
+				ClusterResourcesOnly: true,
+			},
+			mocks: func(resources *mock_features.MockResourcesClient) {
+				resources.EXPECT().ListByResourceGroup(gomock.Any(), "cluster-rg", "", "", nil).Return([]mgmtfeatures.GenericResourceExpanded{
+					resource("infra-nic", nil),
+					resource("imageregistryaccount", nil),
+					resource("customer-nic", nil),
+				}, nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("infra-nic"), "2020-08-01", mgmtfeatures.GenericResource{
+					Tags: map[string]*string{
+						"owner": to.StringPtr("team"),
+					},
+				}).Return(nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("imageregistryaccount"), "2020-08-01", mgmtfeatures.GenericResource{
+					Tags: map[string]*string{
+						"owner": to.StringPtr("team"),
+					},
+				}).Return(nil)
+			},
+			wantRequeue:     requeueInterval,
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "azure error",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			resourceTags: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"Owner": "team"},
+			},
+			mocks: func(resources *mock_features.MockResourcesClient) {
+				resources.EXPECT().ListByResourceGroup(gomock.Any(), "cluster-rg", "", "", nil).Return([]mgmtfeatures.GenericResourceExpanded{
+					resource("infra-nic", nil),
+				}, nil)
+				resources.EXPECT().UpdateByIDAndWait(gomock.Any(), resourceID("infra-nic"), "2020-08-01", gomock.Any()).Return(errors.New("broken"))
+			},
+			wantErr:         "broken",
+			startConditions: defaultConditions,
+			wantConditions:  degraded("broken"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			resources := mock_features.NewMockResourcesClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(resources)
+			}
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					ResourceID:             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+					ClusterResourceGroupID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/cluster-rg",
+					InfraID:                "infra",
+					ResourceTags:           tt.resourceTags,
+					OperatorFlags:          tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(
+				instance,
+				&imageregistryv1.Config{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "cluster",
+					},
+					Spec: imageregistryv1.ImageRegistrySpec{
+						Storage: imageregistryv1.ImageRegistryConfigStorage{
+							Azure: &imageregistryv1.ImageRegistryConfigStorageAzure{
+								AccountName: "imageregistryaccount",
+							},
+						},
+					},
+				},
+			).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			r.newResourcesClient = func(context.Context, *arov1alpha1.Cluster) (features.ResourcesClient, error) {
+				return resources, nil
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got requeue after %s, wanted %s", result.RequeueAfter, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package selinux
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package ensures that MachineConfig objects exist and
+are correctly configured to set the SELinux booleans of the cluster VMs.
+The data path is:
+
+* The customer sets securityProfile.seLinuxBooleans at cluster create time,
+  and the RP copies it to the SecurityProfile field on the ARO Cluster object.
+
+* The Reconciler ensures a 99-%s-aro-selinux MachineConfig exists for each
+  MachineConfigPool.
+
+* The MachineConfigs lay down aro-selinux-booleans.service, which turns the
+  booleans on with setsebool at boot, before the kubelet starts.
+
+The Reconciler watches the ARO Cluster object, the MachineConfigPools and the
+99-%s-aro-selinux MachineConfigs, so that newly created pools get the SELinux
+configuration and changes to the MachineConfigs are reverted.
+
+The booleans only restrict what processes on the nodes may do: deny_ptrace
+stops containers from tracing other processes, which breaks debuggers and
+profilers running in pods, and deny_execmem stops unconfined processes from
+mapping memory which is both writable and executable, which breaks some
+just-in-time compilers.  The SELinux contexts of customer workloads and the
+default security context constraints are not changed, as they are managed by
+the platform.
+
+The default seccomp profile in the same field is set through the kubelet
+configuration by the autosizednodes controller.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.selinux.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the MachineConfigs if
+  SELinux booleans are set on the ARO Cluster object
+
+If no SELinux booleans are set the nodes keep the platform SELinux policy and
+the controller does nothing.
+
+*/
@@ -0,0 +1,111 @@
+package selinux
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/coreos/go-semver/semver"
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	seLinuxBooleansUnitFileName = "aro-selinux-booleans.service"
+)
+
+//go:embed scripts/aro-selinux-booleans.service.gotmpl
+var seLinuxBooleansUnitFile string
+
+func seLinuxBooleansService(booleans []string) (string, error) {
+	t := template.Must(template.New(seLinuxBooleansUnitFileName).Parse(seLinuxBooleansUnitFile))
+	buf := &bytes.Buffer{}
+
+	err := t.ExecuteTemplate(buf, seLinuxBooleansUnitFileName, &struct {
+		Booleans []string
+	}{
+		Booleans: booleans,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func ignition3Config(booleans []string) (*ign3types.Config, error) {
+	service, err := seLinuxBooleansService(booleans)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ign3types.Config{
+		Ignition: ign3types.Ignition{
+			// This Ignition Config version should be kept up to date with the default
+			// rendered Ignition Config version from the Machine Config Operator version
+			// on the lowest OCP version we support (4.7).
+			Version: semver.Version{
+				Major: 3,
+				Minor: 2,
+			}.String(),
+		},
+		Systemd: ign3types.Systemd{
+			Units: []ign3types.Unit{
+				{
+					Contents: &service,
+					Enabled:  to.BoolPtr(true),
+					Name:     seLinuxBooleansUnitFileName,
+				},
+			},
+		},
+	}, nil
+}
+
+func seLinuxMachineConfig(booleans []string, role string) (*mcv1.MachineConfig, error) {
+	ignConfig, err := ignition3Config(booleans)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(ignConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// canonicalise the machineconfig payload the same way as MCO
+	var i interface{}
+	err = json.Unmarshal(b, &i)
+	if err != nil {
+		return nil, err
+	}
+
+	rawExt := runtime.RawExtension{}
+	rawExt.Raw, err = json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-aro-selinux", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcv1.MachineConfigSpec{
+			Config: rawExt,
+		},
+	}, nil
+}
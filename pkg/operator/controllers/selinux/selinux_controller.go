@@ -0,0 +1,155 @@
+package selinux
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
+)
+
+const (
+	ControllerName = "SELinux"
+
+	controllerEnabled = "aro.selinux.enabled"
+)
+
+var rxAROSELinux = regexp.MustCompile("^99-(.*)-aro-selinux$")
+
+// Reconciler ensures the 99-%s-aro-selinux MachineConfigs which set the node
+// SELinux booleans
+type Reconciler struct {
+	base.AROController
+
+	dh dynamichelper.Interface
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client, dh dynamichelper.Interface) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+		dh: dh,
+	}
+}
+
+// Reconcile watches the ARO object, the MachineConfigPools and the ARO
+// SELinux MachineConfigs, and if any of them changes, reconciles all the
+// 99-%s-aro-selinux machineconfigs
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.SecurityProfile == nil || len(instance.Spec.SecurityProfile.SELinuxBooleans) == 0 {
+		r.Log.Debug("no SELinux booleans set")
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	for _, b := range instance.Spec.SecurityProfile.SELinuxBooleans {
+		if !validate.SELinuxBooleanIsValid(b) {
+			err = fmt.Errorf("invalid SELinux boolean %q", b)
+			r.Log.Error(err)
+			r.SetDegraded(ctx, err)
+			return reconcile.Result{}, err
+		}
+	}
+
+	mcps := &mcv1.MachineConfigPoolList{}
+	err = r.Client.List(ctx, mcps)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	err = reconcileMachineConfigs(ctx, instance, r.dh, mcps.Items...)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	aroSELinuxPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return rxAROSELinux.MatchString(o.GetName())
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &mcv1.MachineConfigPool{}},
+			&handler.EnqueueRequestForObject{},
+		).
+		Watches(
+			&source.Kind{Type: &mcv1.MachineConfig{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(aroSELinuxPredicate),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
+
+func reconcileMachineConfigs(ctx context.Context, instance *arov1alpha1.Cluster, dh dynamichelper.Interface, mcps ...mcv1.MachineConfigPool) error {
+	var resources []kruntime.Object
+	for _, mcp := range mcps {
+		if mcp.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		resource, err := seLinuxMachineConfig(instance.Spec.SecurityProfile.SELinuxBooleans, mcp.Name)
+		if err != nil {
+			return err
+		}
+
+		err = dynamichelper.SetControllerReferences([]kruntime.Object{resource}, &mcp)
+		if err != nil {
+			return err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	err := dynamichelper.Prepare(resources)
+	if err != nil {
+		return err
+	}
+
+	return dh.Ensure(ctx, resources...)
+}
@@ -0,0 +1,149 @@
+package selinux
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	mock_dynamichelper "github.com/Azure/ARO-RP/pkg/util/mocks/dynamichelper"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	cluster := func(enabled string, booleans ...string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				SecurityProfile: &arov1alpha1.SecurityProfileSpec{
+					SELinuxBooleans: booleans,
+				},
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		mocks          func(mdh *mock_dynamichelper.MockInterface)
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			mocks:      func(mdh *mock_dynamichelper.MockInterface) {},
+			wantErrMsg: "clusters.aro.openshift.io \"cluster\" not found",
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", "deny_ptrace"),
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "no SELinux booleans does nothing",
+			objects: []client.Object{
+				cluster("true"),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "invalid SELinux boolean is degraded",
+			objects: []client.Object{
+				cluster("true", "deny_ptrace", "container_manage_cgroup"),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantErrMsg:     `invalid SELinux boolean "container_manage_cgroup"`,
+			wantConditions: degraded(`invalid SELinux boolean "container_manage_cgroup"`),
+		},
+		{
+			name: "MachineConfigPools create ARO SELinux MachineConfigs",
+			objects: []client.Object{
+				cluster("true", "deny_ptrace"),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				},
+			},
+			mocks: func(mdh *mock_dynamichelper.MockInterface) {
+				mdh.EXPECT().Ensure(gomock.Any(), gomock.AssignableToTypeOf(&mcv1.MachineConfig{}), gomock.AssignableToTypeOf(&mcv1.MachineConfig{})).Times(1)
+			},
+			wantConditions: defaultConditions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			dh := mock_dynamichelper.NewMockInterface(controller)
+			tt.mocks(dh)
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+				dh,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package selinux
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+)
+
+func TestSELinuxMachineConfig(t *testing.T) {
+	mc, err := seLinuxMachineConfig([]string{"deny_ptrace", "deny_execmem"}, "worker")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mc.Name != "99-worker-aro-selinux" {
+		t.Error(mc.Name)
+	}
+	if !reflect.DeepEqual(mc.Labels, map[string]string{"machineconfiguration.openshift.io/role": "worker"}) {
+		t.Error(mc.Labels)
+	}
+
+	var ign ign3types.Config
+	err = json.Unmarshal(mc.Spec.Config.Raw, &ign)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ign.Ignition.Version != "3.2.0" {
+		t.Error(ign.Ignition.Version)
+	}
+	if len(ign.Systemd.Units) != 1 {
+		t.Fatal(len(ign.Systemd.Units))
+	}
+
+	unit := ign.Systemd.Units[0]
+	if unit.Name != seLinuxBooleansUnitFileName {
+		t.Error(unit.Name)
+	}
+	if unit.Enabled == nil || !*unit.Enabled {
+		t.Error("unit not enabled")
+	}
+	if unit.Contents == nil || !strings.Contains(*unit.Contents, "ExecStart=/usr/sbin/setsebool deny_ptrace=on deny_execmem=on\n") {
+		t.Error(unit.Contents)
+	}
+}
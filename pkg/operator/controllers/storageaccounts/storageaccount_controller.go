@@ -74,6 +74,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 
 	r.log.Debug("running")
 
+	err = clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Get endpoints from operator
 	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
 	if err != nil {
@@ -106,7 +111,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		storage:     storage.NewAccountsClient(&azEnv, resource.SubscriptionID, authorizer),
 	}
 
-	return reconcile.Result{}, manager.reconcileAccounts(ctx)
+	err = manager.reconcileAccounts(ctx)
+	clusterauthorizer.OperatorRateLimiter.Observe(err)
+
+	return reconcile.Result{}, err
 }
 
 // SetupWithManager creates the controller
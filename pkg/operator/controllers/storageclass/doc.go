@@ -0,0 +1,33 @@
+package storageclass
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package makes the storage class which the customer
+chose the only default storage class of the cluster.
+
+The RP copies the defaultStorageClassProfile of the cluster to the
+DefaultStorageClass field on the ARO Cluster object.  If the profile has
+parameters, the Reconciler ensures a storage class of the Azure Disk CSI driver
+with those parameters.  StorageClass parameters are immutable, so a storage
+class created by the operator whose parameters have drifted is deleted and
+recreated; existing persistent volumes are not affected.  If the profile has no
+parameters, the storage class, e.g. managed-csi, must already exist.
+
+The Reconciler then annotates the storage class as the default and removes the
+default annotation from every other storage class, so that there is only one
+default.  Storage classes are watched so that a storage class marked default
+by the customer is reverted.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.storageclass.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the default storage class
+  according to the DefaultStorageClass field on the ARO Cluster object
+
+If the DefaultStorageClass field is not set the controller noops.
+
+*/
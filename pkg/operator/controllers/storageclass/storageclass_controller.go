@@ -0,0 +1,203 @@
+package storageclass
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+)
+
+const (
+	ControllerName = "StorageClass"
+
+	controllerEnabled = "aro.storageclass.enabled"
+
+	provisioner = "disk.csi.azure.com"
+
+	// managedByLabel marks the storage classes created by this controller
+	managedByLabel = "aro.openshift.io/storageclass"
+
+	isDefaultClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaIsDefaultClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// Reconciler ensures the default storage class of the cluster
+type Reconciler struct {
+	base.AROController
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+}
+
+// Reconcile watches the ARO object and the storage classes, and if they
+// change, reconciles the default storage class
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if instance.Spec.DefaultStorageClass == nil {
+		r.ClearConditions(ctx)
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	err = r.ensureStorageClass(ctx, instance.Spec.DefaultStorageClass)
+	if err == nil {
+		err = r.ensureOnlyDefault(ctx, instance.Spec.DefaultStorageClass.Name)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return reconcile.Result{}, nil
+}
+
+// ensureStorageClass creates the storage class if it has parameters, or
+// checks that it exists otherwise
+func (r *Reconciler) ensureStorageClass(ctx context.Context, spec *arov1alpha1.DefaultStorageClassSpec) error {
+	sc := &storagev1.StorageClass{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: spec.Name}, sc)
+	if len(spec.Parameters) == 0 {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("storage class %q not found", spec.Name)
+		}
+		return err
+	}
+
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, newStorageClass(spec))
+	}
+	if err != nil {
+		return err
+	}
+
+	if sc.Labels[managedByLabel] != "true" {
+		return fmt.Errorf("storage class %q already exists and is not managed by the ARO operator", spec.Name)
+	}
+
+	if sc.Provisioner == provisioner && reflect.DeepEqual(sc.Parameters, spec.Parameters) {
+		return nil
+	}
+
+	// StorageClass parameters are immutable
+	err = r.Client.Delete(ctx, sc)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return r.Client.Create(ctx, newStorageClass(spec))
+}
+
+// ensureOnlyDefault annotates the storage class as the default and removes
+// the default annotations from every other storage class
+func (r *Reconciler) ensureOnlyDefault(ctx context.Context, name string) error {
+	scs := &storagev1.StorageClassList{}
+	err := r.Client.List(ctx, scs)
+	if err != nil {
+		return err
+	}
+
+	for i := range scs.Items {
+		sc := &scs.Items[i]
+
+		changed := false
+		if sc.Name == name {
+			if sc.Annotations[isDefaultClassAnnotation] != "true" {
+				if sc.Annotations == nil {
+					sc.Annotations = map[string]string{}
+				}
+				sc.Annotations[isDefaultClassAnnotation] = "true"
+				changed = true
+			}
+		} else {
+			for _, a := range []string{isDefaultClassAnnotation, betaIsDefaultClassAnnotation} {
+				if sc.Annotations[a] == "true" {
+					sc.Annotations[a] = "false"
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		err = r.Client.Update(ctx, sc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newStorageClass(spec *arov1alpha1.DefaultStorageClassSpec) *storagev1.StorageClass {
+	volumeBindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.Name,
+			Labels: map[string]string{
+				managedByLabel: "true",
+			},
+			Annotations: map[string]string{
+				isDefaultClassAnnotation: "true",
+			},
+		},
+		Provisioner:          provisioner,
+		VolumeBindingMode:    &volumeBindingMode,
+		AllowVolumeExpansion: to.BoolPtr(true),
+		ReclaimPolicy:        &reclaimPolicy,
+		Parameters:           spec.Parameters,
+	}
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(&source.Kind{Type: &storagev1.StorageClass{}}, &handler.EnqueueRequestForObject{}). // to revert a customer's default storage class
+		Named(ControllerName).
+		Complete(r)
+}
@@ -0,0 +1,200 @@
+package storageclass
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	parameters := map[string]string{
+		"skuName":     "Premium_ZRS",
+		"cachingMode": "ReadOnly",
+	}
+
+	cluster := func(enabled string, spec *arov1alpha1.DefaultStorageClassSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				DefaultStorageClass: spec,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	storageClass := func(name string, annotations, labels, parameters map[string]string) *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+				Labels:      labels,
+			},
+			Provisioner: provisioner,
+			Parameters:  parameters,
+		}
+	}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		d := defaultDegraded
+		d.Status = operatorv1.ConditionTrue
+		d.Message = message
+		return []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, d}
+	}
+
+	managedCSI := storageClass("managed-csi", map[string]string{isDefaultClassAnnotation: "true"}, nil, nil)
+	customerDefault := storageClass("customer", map[string]string{betaIsDefaultClassAnnotation: "true"}, nil, nil)
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+		wantDefaults   map[string]bool
+		wantParameters map[string]string
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", &arov1alpha1.DefaultStorageClassSpec{Name: "premium", Parameters: parameters}),
+				managedCSI,
+			},
+			wantConditions: defaultConditions,
+			wantDefaults:   map[string]bool{"managed-csi": true},
+		},
+		{
+			name: "no default storage class is configured",
+			objects: []client.Object{
+				cluster("true", nil),
+				managedCSI,
+				customerDefault,
+			},
+			wantConditions: defaultConditions,
+			wantDefaults:   map[string]bool{"managed-csi": true, "customer": true},
+		},
+		{
+			name: "storage class is created and made the only default",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.DefaultStorageClassSpec{Name: "premium", Parameters: parameters}),
+				managedCSI,
+				customerDefault,
+			},
+			wantConditions: defaultConditions,
+			wantDefaults:   map[string]bool{"premium": true},
+			wantParameters: parameters,
+		},
+		{
+			name: "storage class drift is reverted",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.DefaultStorageClassSpec{Name: "premium", Parameters: parameters}),
+				storageClass("premium", nil, map[string]string{managedByLabel: "true"}, map[string]string{"skuName": "Standard_LRS"}),
+			},
+			wantConditions: defaultConditions,
+			wantDefaults:   map[string]bool{"premium": true},
+			wantParameters: parameters,
+		},
+		{
+			name: "existing storage class is made the only default",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.DefaultStorageClassSpec{Name: "customer"}),
+				managedCSI,
+				customerDefault,
+			},
+			wantConditions: defaultConditions,
+			wantDefaults:   map[string]bool{"customer": true},
+		},
+		{
+			name: "existing storage class is not found",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.DefaultStorageClassSpec{Name: "premium"}),
+				managedCSI,
+			},
+			wantErrMsg:     `storage class "premium" not found`,
+			wantConditions: degraded(`storage class "premium" not found`),
+			wantDefaults:   map[string]bool{"managed-csi": true},
+		},
+		{
+			name: "storage class of the customer is not replaced",
+			objects: []client.Object{
+				cluster("true", &arov1alpha1.DefaultStorageClassSpec{Name: "customer", Parameters: parameters}),
+				managedCSI,
+				customerDefault,
+			},
+			wantErrMsg:     `storage class "customer" already exists and is not managed by the ARO operator`,
+			wantConditions: degraded(`storage class "customer" already exists and is not managed by the ARO operator`),
+			wantDefaults:   map[string]bool{"managed-csi": true, "customer": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions != nil {
+				utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+			}
+
+			scs := &storagev1.StorageClassList{}
+			err = client.List(ctx, scs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defaults := map[string]bool{}
+			for _, sc := range scs.Items {
+				if sc.Annotations[isDefaultClassAnnotation] == "true" || sc.Annotations[betaIsDefaultClassAnnotation] == "true" {
+					defaults[sc.Name] = true
+				}
+				if tt.wantParameters != nil && sc.Name == "premium" && !reflect.DeepEqual(sc.Parameters, tt.wantParameters) {
+					t.Errorf("got parameters %#v", sc.Parameters)
+				}
+			}
+			if tt.wantDefaults == nil {
+				tt.wantDefaults = map[string]bool{}
+			}
+			if !reflect.DeepEqual(defaults, tt.wantDefaults) {
+				t.Errorf("got default storage classes %v, want %v", defaults, tt.wantDefaults)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package subnetaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package tracks how many addresses are free in each
+subnet used by the cluster's machines, so that a cluster can be acted on
+before it runs out of addresses.  Every NIC, internal load balancer and
+private endpoint in a subnet takes an address, so scaling up can exhaust a
+subnet and leave new nodes unschedulable.
+
+The controller polls the subnets every ten minutes.  When the share of the
+usable addresses of a subnet in use reaches the threshold, the controller
+reports itself degraded with the subnet ID and the number of free addresses.
+The usage of each subnet is kept in the subnet-addresses config map in the
+operator namespace, from which the monitor emits the subnet.addresses
+metrics.
+
+These flags control the operations performed by this controller:
+
+aro.subnetaddresses.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will track the addresses of the subnets
+
+aro.subnetaddresses.threshold:
+- The percentage of addresses in use at which a subnet is reported, an
+  integer between 50 and 99; 80 when unset
+
+*/
@@ -0,0 +1,269 @@
+package subnetaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
+)
+
+const (
+	ControllerName = "SubnetAddresses"
+
+	controllerEnabled = "aro.subnetaddresses.enabled"
+)
+
+// The usage of the subnets is kept in a config map in the operator namespace,
+// from which the monitor emits its metrics
+const (
+	ConfigMapName = "subnet-addresses"
+
+	KeyThreshold = "threshold"
+	KeySubnets   = "subnets"
+)
+
+// requeueInterval is how often the subnets are polled.  Addresses are taken
+// as nodes and pods are added, which the controller does not watch.
+const requeueInterval = 10 * time.Minute
+
+// SubnetAddresses is the address usage of a cluster subnet, as kept in the
+// config map
+type SubnetAddresses struct {
+	ResourceID string `json:"resourceId"`
+	Total      int    `json:"total"`
+	Free       int    `json:"free"`
+}
+
+// UsedPercent returns the percentage of the usable addresses in use
+func (s *SubnetAddresses) UsedPercent() int {
+	if s.Total <= 0 {
+		return 100
+	}
+
+	return (s.Total - s.Free) * 100 / s.Total
+}
+
+// Reconciler tracks the free addresses of the cluster subnets
+type Reconciler struct {
+	base.AROController
+
+	newSubnetManager func(ctx context.Context, instance *arov1alpha1.Cluster) (subnet.Manager, error)
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	r := &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+	}
+	r.newSubnetManager = r.subnetManager
+
+	return r
+}
+
+// Reconcile polls the address usage of the subnets of the cluster machines
+// and reports the subnets which are running out of addresses
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	threshold, err := operator.SubnetAddressesThreshold(instance.Spec.OperatorFlags)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, nil
+	}
+
+	subnets, err := r.getSubnetAddresses(ctx, instance)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	err = r.saveConfigMap(ctx, threshold, subnets)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	var exhausted []string
+	for _, s := range subnets {
+		if s.UsedPercent() >= threshold {
+			exhausted = append(exhausted, fmt.Sprintf("subnet %s has %d of %d addresses free, %d%% are in use", s.ResourceID, s.Free, s.Total, s.UsedPercent()))
+		}
+	}
+
+	if len(exhausted) > 0 {
+		err = fmt.Errorf("%s (threshold %d%%)", strings.Join(exhausted, "\n"), threshold)
+		r.Log.Warn(err)
+		r.SetDegraded(ctx, err)
+	} else {
+		r.ClearConditions(ctx)
+	}
+
+	return reconcile.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// subnetManager returns a subnet manager authorized as the cluster service
+// principal
+func (r *Reconciler) subnetManager(ctx context.Context, instance *arov1alpha1.Cluster) (subnet.Manager, error) {
+	err := clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := azure.ParseResourceID(instance.Spec.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	azRefreshAuthorizer, err := clusterauthorizer.NewAzRefreshableAuthorizer(r.Log, &azEnv, r.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azRefreshAuthorizer.NewRefreshableAuthorizerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return subnet.NewManager(&azEnv, resource.SubscriptionID, authorizer), nil
+}
+
+// getSubnetAddresses returns the address usage of each subnet used by the
+// cluster machines, sorted by resource ID
+func (r *Reconciler) getSubnetAddresses(ctx context.Context, instance *arov1alpha1.Cluster) ([]SubnetAddresses, error) {
+	resource, err := azure.ParseResourceID(instance.Spec.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	machineSubnets, err := subnet.NewKubeManager(r.Client, resource.SubscriptionID).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(machineSubnets) == 0 {
+		return nil, nil
+	}
+
+	manager, err := r.newSubnetManager(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := make([]SubnetAddresses, 0, len(machineSubnets))
+	for _, s := range machineSubnets {
+		usage, err := manager.GetAddressUsage(ctx, s.ResourceID)
+		if err != nil {
+			// hold back further Azure calls if this one was throttled
+			clusterauthorizer.OperatorRateLimiter.Observe(err)
+			return nil, err
+		}
+
+		subnets = append(subnets, SubnetAddresses{
+			ResourceID: s.ResourceID,
+			Total:      usage.Total,
+			Free:       usage.Free(),
+		})
+	}
+
+	sort.Slice(subnets, func(i, j int) bool {
+		return strings.ToLower(subnets[i].ResourceID) < strings.ToLower(subnets[j].ResourceID)
+	})
+
+	return subnets, nil
+}
+
+// saveConfigMap saves the usage of the subnets for the monitor
+func (r *Reconciler) saveConfigMap(ctx context.Context, threshold int, subnets []SubnetAddresses) error {
+	if subnets == nil {
+		subnets = []SubnetAddresses{}
+	}
+
+	b, err := json.Marshal(subnets)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{
+		KeyThreshold: strconv.Itoa(threshold),
+		KeySubnets:   string(b),
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: operator.Namespace,
+				Name:      ConfigMapName,
+			},
+			Data: data,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm.Data[KeyThreshold] == data[KeyThreshold] && cm.Data[KeySubnets] == data[KeySubnets] {
+		return nil
+	}
+
+	cm.Data = data
+	return r.Client.Update(ctx, cm)
+}
+
+// SetupWithManager setup the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Named(ControllerName).
+		Complete(r)
+}
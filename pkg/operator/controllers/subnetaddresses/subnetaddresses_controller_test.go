@@ -0,0 +1,209 @@
+package subnetaddresses
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	mock_subnet "github.com/Azure/ARO-RP/pkg/util/mocks/subnet"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	masterSubnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/master"
+	workerSubnetID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/vnet-rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/worker"
+
+	machine := func(name, role, subnetName string) *machinev1beta1.Machine {
+		return &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-machine-api",
+				Labels:    map[string]string{"machine.openshift.io/cluster-api-machine-role": role},
+			},
+			Spec: machinev1beta1.MachineSpec{
+				ProviderSpec: machinev1beta1.ProviderSpec{
+					Value: &kruntime.RawExtension{
+						Raw: []byte(`{"networkResourceGroup":"vnet-rg","vnet":"vnet","subnet":"` + subnetName + `"}`),
+					},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		flags           arov1alpha1.OperatorFlags
+		mocks           func(*mock_subnet.MockManager)
+		wantData        map[string]string
+		wantRequeue     time.Duration
+		wantErr         string
+		wantConditions  []operatorv1.OperatorCondition
+		startConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name: "controller disabled",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "false",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "subnets below the threshold",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			mocks: func(manager *mock_subnet.MockManager) {
+				manager.EXPECT().GetAddressUsage(gomock.Any(), workerSubnetID).Return(&subnet.AddressUsage{Total: 507, Used: 300}, nil)
+				manager.EXPECT().GetAddressUsage(gomock.Any(), masterSubnetID).Return(&subnet.AddressUsage{Total: 27, Used: 3}, nil)
+			},
+			wantData: map[string]string{
+				KeyThreshold: "80",
+				KeySubnets:   `[{"resourceId":"` + masterSubnetID + `","total":27,"free":24},{"resourceId":"` + workerSubnetID + `","total":507,"free":207}]`,
+			},
+			wantRequeue:     requeueInterval,
+			startConditions: degraded("subnet " + workerSubnetID + " has 20 of 507 addresses free, 96% are in use (threshold 80%)"),
+			wantConditions:  defaultConditions,
+		},
+		{
+			name: "subnet above the threshold",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:               "true",
+				"aro.subnetaddresses.threshold": "50",
+			},
+			mocks: func(manager *mock_subnet.MockManager) {
+				manager.EXPECT().GetAddressUsage(gomock.Any(), workerSubnetID).Return(&subnet.AddressUsage{Total: 507, Used: 300}, nil)
+				manager.EXPECT().GetAddressUsage(gomock.Any(), masterSubnetID).Return(&subnet.AddressUsage{Total: 27, Used: 3}, nil)
+			},
+			wantData: map[string]string{
+				KeyThreshold: "50",
+				KeySubnets:   `[{"resourceId":"` + masterSubnetID + `","total":27,"free":24},{"resourceId":"` + workerSubnetID + `","total":507,"free":207}]`,
+			},
+			wantRequeue:     requeueInterval,
+			startConditions: defaultConditions,
+			wantConditions:  degraded("subnet " + workerSubnetID + " has 207 of 507 addresses free, 59% are in use (threshold 50%)"),
+		},
+		{
+			name: "azure error",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled: "true",
+			},
+			mocks: func(manager *mock_subnet.MockManager) {
+				manager.EXPECT().GetAddressUsage(gomock.Any(), workerSubnetID).Return(nil, errors.New("broken"))
+			},
+			wantErr:         "broken",
+			startConditions: defaultConditions,
+			wantConditions:  degraded("broken"),
+		},
+		{
+			name: "invalid threshold",
+			flags: arov1alpha1.OperatorFlags{
+				controllerEnabled:               "true",
+				"aro.subnetaddresses.threshold": "100",
+			},
+			startConditions: defaultConditions,
+			wantConditions:  degraded("invalid aro.subnetaddresses.threshold '100': must be an integer percentage between 50 and 99"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			manager := mock_subnet.NewMockManager(controller)
+			if tt.mocks != nil {
+				tt.mocks(manager)
+			}
+
+			instance := &arov1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: arov1alpha1.SingletonClusterName,
+				},
+				Spec: arov1alpha1.ClusterSpec{
+					ResourceID:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+					OperatorFlags: tt.flags,
+				},
+				Status: arov1alpha1.ClusterStatus{
+					Conditions: tt.startConditions,
+				},
+			}
+
+			clientFake := ctrlfake.NewClientBuilder().WithObjects(
+				instance,
+				machine("master-0", "master", "master"),
+				machine("worker-0", "worker", "worker"),
+				machine("worker-1", "worker", "worker"),
+			).Build()
+
+			r := NewReconciler(logrus.NewEntry(logrus.StandardLogger()), clientFake)
+			r.newSubnetManager = func(context.Context, *arov1alpha1.Cluster) (subnet.Manager, error) {
+				return manager, nil
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			utilconditions.AssertControllerConditions(t, ctx, clientFake, tt.wantConditions)
+
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got requeue after %s, wanted %s", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = clientFake.Get(ctx, types.NamespacedName{Namespace: operator.Namespace, Name: ConfigMapName}, cm)
+			if tt.wantData == nil {
+				if err == nil {
+					t.Errorf("config map %v", cm.Data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cm.Data, tt.wantData) {
+				t.Errorf("got %v, wanted %v", cm.Data, tt.wantData)
+			}
+		})
+	}
+}
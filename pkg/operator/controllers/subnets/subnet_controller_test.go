@@ -8,17 +8,22 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/Azure/ARO-RP/pkg/api"
 	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
 	mock_subnet "github.com/Azure/ARO-RP/pkg/util/mocks/subnet"
 	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
 	"github.com/Azure/ARO-RP/pkg/util/subnet"
@@ -445,3 +450,170 @@ func TestReconcileManager(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureNSGSecurityRules(t *testing.T) {
+	log := logrus.NewEntry(logrus.StandardLogger())
+
+	customerRule := mgmtnetwork.SecurityRule{
+		SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
+			Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
+			SourcePortRange:          to.StringPtr("*"),
+			DestinationPortRange:     to.StringPtr("22"),
+			SourceAddressPrefix:      to.StringPtr("10.0.0.0/8"),
+			DestinationAddressPrefix: to.StringPtr("*"),
+			Access:                   mgmtnetwork.SecurityRuleAccessAllow,
+			Priority:                 to.Int32Ptr(200),
+			Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
+		},
+		Name: to.StringPtr("customer_ssh_in"),
+	}
+
+	apiServerRule := func() mgmtnetwork.SecurityRule {
+		return subnet.RequiredSecurityRules(api.VisibilityPublic)[0]
+	}
+
+	repairedCondition := func(age time.Duration) func(*arov1alpha1.Cluster) {
+		return func(instance *arov1alpha1.Cluster) {
+			instance.Status.Conditions = append(instance.Status.Conditions, operatorv1.OperatorCondition{
+				Type:               arov1alpha1.NSGRulesRepaired,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-age)),
+			})
+		}
+	}
+
+	rulesPresent := func() *[]mgmtnetwork.SecurityRule {
+		rule := apiServerRule()
+		rule.ID = to.StringPtr(nsgv2ResourceId + "/securityRules/apiserver_in")
+		rule.ProvisioningState = mgmtnetwork.Succeeded
+		return &[]mgmtnetwork.SecurityRule{customerRule, rule}
+	}
+
+	for _, tt := range []struct {
+		name          string
+		instance      func(*arov1alpha1.Cluster)
+		rules         func() *[]mgmtnetwork.SecurityRule
+		wantRules     func() *[]mgmtnetwork.SecurityRule
+		wantCondition bool
+	}{
+		{
+			name: "Architecture V1 - no change",
+			instance: func(instance *arov1alpha1.Cluster) {
+				instance.Spec.ArchitectureVersion = int(api.ArchitectureVersionV1)
+			},
+		},
+		{
+			name: "private API server - no change",
+			instance: func(instance *arov1alpha1.Cluster) {
+				instance.Spec.APIServerVisibility = string(api.VisibilityPrivate)
+			},
+		},
+		{
+			name:  "rules present - no change",
+			rules: rulesPresent,
+		},
+		{
+			name:          "rules present after a recent repair - condition kept",
+			instance:      repairedCondition(10 * time.Minute),
+			rules:         rulesPresent,
+			wantCondition: true,
+		},
+		{
+			name:     "rules present after an old repair - condition cleared",
+			instance: repairedCondition(2 * time.Hour),
+			rules:    rulesPresent,
+		},
+		{
+			name: "rule removed - repaired",
+			rules: func() *[]mgmtnetwork.SecurityRule {
+				return &[]mgmtnetwork.SecurityRule{customerRule}
+			},
+			wantRules: func() *[]mgmtnetwork.SecurityRule {
+				return &[]mgmtnetwork.SecurityRule{customerRule, apiServerRule()}
+			},
+			wantCondition: true,
+		},
+		{
+			name: "no rules - repaired",
+			rules: func() *[]mgmtnetwork.SecurityRule {
+				return nil
+			},
+			wantRules: func() *[]mgmtnetwork.SecurityRule {
+				return &[]mgmtnetwork.SecurityRule{apiServerRule()}
+			},
+			wantCondition: true,
+		},
+		{
+			name: "rule modified - repaired",
+			rules: func() *[]mgmtnetwork.SecurityRule {
+				rule := apiServerRule()
+				rule.Access = mgmtnetwork.SecurityRuleAccessDeny
+				rule.Priority = to.Int32Ptr(4000)
+				return &[]mgmtnetwork.SecurityRule{rule, customerRule}
+			},
+			wantRules: func() *[]mgmtnetwork.SecurityRule {
+				return &[]mgmtnetwork.SecurityRule{apiServerRule(), customerRule}
+			},
+			wantCondition: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			securityGroups := mock_network.NewMockSecurityGroupsClient(controller)
+			if tt.rules != nil {
+				nsg := mgmtnetwork.SecurityGroup{
+					SecurityGroupPropertiesFormat: &mgmtnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: tt.rules(),
+					},
+				}
+				securityGroups.EXPECT().Get(gomock.Any(), clusterResourceGroupName, infraId+apisubnet.NSGSuffixV2, "").Return(nsg, nil)
+			}
+			if tt.wantRules != nil {
+				securityGroups.EXPECT().CreateOrUpdateAndWait(gomock.Any(), clusterResourceGroupName, infraId+apisubnet.NSGSuffixV2, mgmtnetwork.SecurityGroup{
+					SecurityGroupPropertiesFormat: &mgmtnetwork.SecurityGroupPropertiesFormat{
+						SecurityRules: tt.wantRules(),
+					},
+				}).Return(nil)
+			}
+
+			instance := getValidClusterInstance(true, true, true)
+			instance.Spec.ArchitectureVersion = int(api.ArchitectureVersionV2)
+			instance.Spec.APIServerVisibility = string(api.VisibilityPublic)
+			if tt.instance != nil {
+				tt.instance(instance)
+			}
+
+			clientFake := fake.NewClientBuilder().WithObjects(instance).Build()
+			r := reconcileManager{
+				log:            log,
+				client:         clientFake,
+				instance:       instance,
+				subscriptionID: subscriptionId,
+				securityGroups: securityGroups,
+			}
+
+			err := r.ensureNSGSecurityRules(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cluster := &arov1alpha1.Cluster{}
+			err = clientFake.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if conditions.IsTrue(cluster.Status.Conditions, arov1alpha1.NSGRulesRepaired) != tt.wantCondition {
+				t.Errorf("got conditions %#v", cluster.Status.Conditions)
+			}
+
+			if _, ok := cluster.Annotations[AnnotationTimestamp]; ok != (tt.wantRules != nil) {
+				t.Errorf("got annotations %#v", cluster.Annotations)
+			}
+		})
+	}
+}
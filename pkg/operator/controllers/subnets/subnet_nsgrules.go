@@ -0,0 +1,162 @@
+package subnets
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+	"github.com/Azure/ARO-RP/pkg/operator"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/conditions"
+	"github.com/Azure/ARO-RP/pkg/util/subnet"
+)
+
+// nsgRulesRepairedExpiry is how long the NSGRulesRepaired condition stays
+// true once the security rules are back in place
+const nsgRulesRepairedExpiry = time.Hour
+
+// ensureNSGSecurityRules repairs the security rules which the RP programs on
+// the cluster NSG if they have been removed or modified.  Rules are matched by
+// name and rules added by the customer are left untouched.
+func (r *reconcileManager) ensureNSGSecurityRules(ctx context.Context) error {
+	architectureVersion := api.ArchitectureVersion(r.instance.Spec.ArchitectureVersion)
+
+	// architecture version 1 NSGs are created by the installer
+	if architectureVersion != api.ArchitectureVersionV2 {
+		return nil
+	}
+
+	required := subnet.RequiredSecurityRules(api.Visibility(r.instance.Spec.APIServerVisibility))
+	if len(required) == 0 {
+		return nil
+	}
+
+	nsgID, err := apisubnet.NetworkSecurityGroupIDExpanded(architectureVersion, r.instance.Spec.ClusterResourceGroupID, r.instance.Spec.InfraID, false)
+	if err != nil {
+		return err
+	}
+
+	resource, err := azure.ParseResourceID(nsgID)
+	if err != nil {
+		return err
+	}
+
+	nsg, err := r.securityGroups.Get(ctx, resource.ResourceGroup, resource.ResourceName, "")
+	if err != nil {
+		return err
+	}
+	if nsg.SecurityGroupPropertiesFormat == nil {
+		return fmt.Errorf("received nil, expected a value in securityGroupProperties when trying to Get NSG %s", nsgID)
+	}
+
+	var rules []mgmtnetwork.SecurityRule
+	if nsg.SecurityRules != nil {
+		rules = *nsg.SecurityRules
+	}
+
+	var repaired []string
+	for _, want := range required {
+		i := securityRuleIndex(rules, *want.Name)
+		switch {
+		case i == -1:
+			rules = append(rules, want)
+		case !securityRuleMatches(rules[i], want):
+			rules[i] = want
+		default:
+			continue
+		}
+		repaired = append(repaired, *want.Name)
+	}
+
+	if len(repaired) == 0 {
+		return r.expireNSGRulesRepaired(ctx)
+	}
+
+	r.log.Infof("Repairing security rules %s on NSG %s", strings.Join(repaired, ", "), nsgID)
+	nsg.SecurityRules = &rules
+	err = r.securityGroups.CreateOrUpdateAndWait(ctx, resource.ResourceGroup, resource.ResourceName, nsg)
+	if err != nil {
+		return err
+	}
+
+	err = r.updateReconcileSubnetAnnotation(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conditions.SetCondition(ctx, r.client, &operatorv1.OperatorCondition{
+		Type:    arov1alpha1.NSGRulesRepaired,
+		Status:  operatorv1.ConditionTrue,
+		Message: fmt.Sprintf("Repaired security rules %s on NSG %s", strings.Join(repaired, ", "), resource.ResourceName),
+		Reason:  "RulesRepaired",
+	}, operator.RoleMaster)
+}
+
+// expireNSGRulesRepaired sets the NSGRulesRepaired condition back to false
+// once it has been true for nsgRulesRepairedExpiry, so that it only reports
+// recent tampering
+func (r *reconcileManager) expireNSGRulesRepaired(ctx context.Context) error {
+	for _, cond := range r.instance.Status.Conditions {
+		if cond.Type != arov1alpha1.NSGRulesRepaired {
+			continue
+		}
+
+		if cond.Status != operatorv1.ConditionTrue || time.Since(cond.LastTransitionTime.Time) < nsgRulesRepairedExpiry {
+			return nil
+		}
+
+		return conditions.SetCondition(ctx, r.client, &operatorv1.OperatorCondition{
+			Type:    arov1alpha1.NSGRulesRepaired,
+			Status:  operatorv1.ConditionFalse,
+			Message: "The security rules required by the RP are in place",
+			Reason:  "RulesInPlace",
+		}, operator.RoleMaster)
+	}
+
+	return nil
+}
+
+func securityRuleIndex(rules []mgmtnetwork.SecurityRule, name string) int {
+	for i, rule := range rules {
+		if rule.Name != nil && strings.EqualFold(*rule.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// securityRuleMatches compares the properties which the RP sets: read-only
+// properties such as the etag and provisioning state are ignored
+func securityRuleMatches(rule, want mgmtnetwork.SecurityRule) bool {
+	if rule.SecurityRulePropertiesFormat == nil {
+		return false
+	}
+
+	p, w := rule.SecurityRulePropertiesFormat, want.SecurityRulePropertiesFormat
+
+	return strings.EqualFold(string(p.Protocol), string(w.Protocol)) &&
+		stringPtrEqual(p.SourcePortRange, w.SourcePortRange) &&
+		stringPtrEqual(p.DestinationPortRange, w.DestinationPortRange) &&
+		stringPtrEqual(p.SourceAddressPrefix, w.SourceAddressPrefix) &&
+		stringPtrEqual(p.DestinationAddressPrefix, w.DestinationAddressPrefix) &&
+		strings.EqualFold(string(p.Access), string(w.Access)) &&
+		strings.EqualFold(string(p.Direction), string(w.Direction)) &&
+		p.Priority != nil && *p.Priority == *w.Priority
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return strings.EqualFold(*a, *b)
+}
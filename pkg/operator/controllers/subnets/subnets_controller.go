@@ -23,6 +23,7 @@ import (
 
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
 	"github.com/Azure/ARO-RP/pkg/util/clusterauthorizer"
 	"github.com/Azure/ARO-RP/pkg/util/subnet"
 )
@@ -51,8 +52,9 @@ type reconcileManager struct {
 	instance       *arov1alpha1.Cluster
 	subscriptionID string
 
-	subnets     subnet.Manager
-	kubeSubnets subnet.KubeManager
+	subnets        subnet.Manager
+	kubeSubnets    subnet.KubeManager
+	securityGroups network.SecurityGroupsClient
 }
 
 // NewReconciler creates a new Reconciler
@@ -63,7 +65,8 @@ func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
 	}
 }
 
-// Reconcile fixes the Network Security Groups
+// Reconcile fixes the Network Security Groups and their required security
+// rules
 func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	instance := &arov1alpha1.Cluster{}
 	err := r.client.Get(ctx, types.NamespacedName{Name: arov1alpha1.SingletonClusterName}, instance)
@@ -83,6 +86,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		return reconcile.Result{}, nil
 	}
 
+	err = clusterauthorizer.OperatorRateLimiter.Configure(instance.Spec.OperatorFlags)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Get endpoints from the operator
 	azEnv, err := azureclient.EnvironmentFromName(instance.Spec.AZEnvironment)
 	if err != nil {
@@ -112,9 +120,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 		subscriptionID: resource.SubscriptionID,
 		kubeSubnets:    subnet.NewKubeManager(r.client, resource.SubscriptionID),
 		subnets:        subnet.NewManager(&azEnv, resource.SubscriptionID, authorizer),
+		securityGroups: network.NewSecurityGroupsClient(&azEnv, resource.SubscriptionID, authorizer),
+	}
+
+	err = manager.reconcileSubnets(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// the NSG is not watched, so its security rules are checked
+	// periodically
+	if instance.Spec.OperatorFlags.GetSimpleBoolean(controllerNSGManaged) {
+		return reconcile.Result{RequeueAfter: nsgRulesRepairedExpiry}, nil
 	}
 
-	return reconcile.Result{}, manager.reconcileSubnets(ctx)
+	return reconcile.Result{}, nil
 }
 
 func (r *reconcileManager) reconcileSubnets(ctx context.Context) error {
@@ -124,6 +144,11 @@ func (r *reconcileManager) reconcileSubnets(ctx context.Context) error {
 	}
 
 	var combinedErrors []string
+	addError := func(err error) {
+		// hold back further Azure calls if this one was throttled
+		clusterauthorizer.OperatorRateLimiter.Observe(err)
+		combinedErrors = append(combinedErrors, err.Error())
+	}
 
 	// This potentially calls an update twice for the same loop, but this is the price
 	// to pay for keeping logic split, separate, and simple
@@ -131,18 +156,25 @@ func (r *reconcileManager) reconcileSubnets(ctx context.Context) error {
 		if r.instance.Spec.OperatorFlags.GetSimpleBoolean(controllerNSGManaged) {
 			err = r.ensureSubnetNSG(ctx, s)
 			if err != nil {
-				combinedErrors = append(combinedErrors, err.Error())
+				addError(err)
 			}
 		}
 
 		if r.instance.Spec.OperatorFlags.GetSimpleBoolean(controllerServiceEndpointManaged) {
 			err = r.ensureSubnetServiceEndpoints(ctx, s)
 			if err != nil {
-				combinedErrors = append(combinedErrors, err.Error())
+				addError(err)
 			}
 		}
 	}
 
+	if r.instance.Spec.OperatorFlags.GetSimpleBoolean(controllerNSGManaged) {
+		err = r.ensureNSGSecurityRules(ctx)
+		if err != nil {
+			addError(err)
+		}
+	}
+
 	if len(combinedErrors) > 0 {
 		return fmt.Errorf(strings.Join(combinedErrors, "\n"))
 	}
@@ -0,0 +1,40 @@
+package timeconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package ensures that MachineConfig objects exist and
+are correctly configured to set the time configuration of the cluster VMs.
+The data path is:
+
+* The customer sets clusterProfile.timeZone at cluster create time, and the RP
+  copies it to the TimeZone field on the ARO Cluster object.
+
+* The Reconciler ensures a 99-%s-aro-time MachineConfig exists for each
+  MachineConfigPool.
+
+* The MachineConfigs lay down aro-timezone.service, which sets the time zone
+  of the VM with timedatectl at boot, before the kubelet starts.
+
+The Reconciler watches the ARO Cluster object, the MachineConfigPools and the
+99-%s-aro-time MachineConfigs, so that newly created pools get the time
+configuration and changes to the MachineConfigs are reverted.
+
+All of the node time configuration belongs in the 99-%s-aro-time
+MachineConfigs, so that further settings such as chrony NTP servers can be
+added to the same generated ignition config instead of introducing
+MachineConfigs which conflict with each other.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.timeconfig.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the MachineConfigs if the
+  TimeZone field is set on the ARO Cluster object
+
+If the TimeZone field is empty the nodes are left at their default time zone,
+UTC, and the controller does nothing.
+
+*/
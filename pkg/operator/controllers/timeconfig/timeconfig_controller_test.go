@@ -0,0 +1,147 @@
+package timeconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	mock_dynamichelper "github.com/Azure/ARO-RP/pkg/util/mocks/dynamichelper"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	transitionTime := metav1.Time{Time: time.Now()}
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		return []operatorv1.OperatorCondition{
+			defaultAvailable,
+			defaultProgressing,
+			{
+				Type:               ControllerName + "Controller" + operatorv1.OperatorStatusTypeDegraded,
+				Status:             operatorv1.ConditionTrue,
+				LastTransitionTime: transitionTime,
+				Message:            message,
+			},
+		}
+	}
+
+	cluster := func(enabled, timeZone string) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				TimeZone: timeZone,
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		mocks          func(mdh *mock_dynamichelper.MockInterface)
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			mocks:      func(mdh *mock_dynamichelper.MockInterface) {},
+			wantErrMsg: "clusters.aro.openshift.io \"cluster\" not found",
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", "Europe/London"),
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "no time zone does nothing",
+			objects: []client.Object{
+				cluster("true", ""),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantConditions: defaultConditions,
+		},
+		{
+			name: "invalid time zone is degraded",
+			objects: []client.Object{
+				cluster("true", "Europe/Atlantis"),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+			},
+			mocks:          func(mdh *mock_dynamichelper.MockInterface) {},
+			wantErrMsg:     `invalid time zone "Europe/Atlantis"`,
+			wantConditions: degraded(`invalid time zone "Europe/Atlantis"`),
+		},
+		{
+			name: "MachineConfigPools create ARO time MachineConfigs",
+			objects: []client.Object{
+				cluster("true", "Europe/London"),
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "master"},
+				},
+				&mcv1.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				},
+			},
+			mocks: func(mdh *mock_dynamichelper.MockInterface) {
+				mdh.EXPECT().Ensure(gomock.Any(), gomock.AssignableToTypeOf(&mcv1.MachineConfig{}), gomock.AssignableToTypeOf(&mcv1.MachineConfig{})).Times(1)
+			},
+			wantConditions: defaultConditions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			dh := mock_dynamichelper.NewMockInterface(controller)
+			tt.mocks(dh)
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+				dh,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package timeconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+)
+
+func TestTimeConfigMachineConfig(t *testing.T) {
+	mc, err := timeConfigMachineConfig("Europe/London", "worker")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mc.Name != "99-worker-aro-time" {
+		t.Error(mc.Name)
+	}
+	if !reflect.DeepEqual(mc.Labels, map[string]string{"machineconfiguration.openshift.io/role": "worker"}) {
+		t.Error(mc.Labels)
+	}
+
+	var ign ign3types.Config
+	err = json.Unmarshal(mc.Spec.Config.Raw, &ign)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ign.Ignition.Version != "3.2.0" {
+		t.Error(ign.Ignition.Version)
+	}
+	if len(ign.Systemd.Units) != 1 {
+		t.Fatal(len(ign.Systemd.Units))
+	}
+
+	unit := ign.Systemd.Units[0]
+	if unit.Name != timeZoneUnitFileName {
+		t.Error(unit.Name)
+	}
+	if unit.Enabled == nil || !*unit.Enabled {
+		t.Error("unit not enabled")
+	}
+	if unit.Contents == nil || !strings.Contains(*unit.Contents, "ExecStart=/usr/bin/timedatectl set-timezone Europe/London\n") {
+		t.Error(unit.Contents)
+	}
+}
@@ -0,0 +1,56 @@
+package upgradeorder
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+/*
+
+The controller in this package has a designated worker node updated after all
+the other worker nodes during upgrades, so that a critical singleton workload
+running on it is disrupted as late as possible.
+
+The MachineConfigPools update their nodes in an order of their own choosing,
+so the node is moved to a MachineConfigPool of its own.  The data path is:
+
+* The customer sets upgradeProfile.lastNodeName, and the RP copies it to the
+  Upgrade field on the ARO Cluster object.
+
+* The Reconciler validates that the node exists and is a worker, and labels it
+  with node-role.kubernetes.io/aro-last-update.
+
+* The Reconciler ensures the aro-last-update MachineConfigPool, which selects
+  the labelled node and the MachineConfigs of the worker pool as well as its
+  own.
+
+* The Reconciler keeps the aro-last-update pool paused for as long as the
+  worker pool isn't fully updated, so that the node is only updated once all
+  the other worker nodes are.
+
+The Reconciler also sets the update strategy of the worker pool, which
+throttles the node reboots of large clusters:
+
+* The customer sets upgradeProfile.maxUnavailable, and an SRE may pause the
+  node updates for at most a week with the nodeupdatespause admin action.
+  The RP copies them to the Upgrade field on the ARO Cluster object.
+
+* The Reconciler sets maxUnavailable and paused on the worker pool, and
+  requeues itself to lift a pause when it expires.  It annotates the pool
+  with aro.openshift.io/updateStrategy, and once no strategy is set it only
+  resets a pool which it annotated, so that the platform behaviour is kept
+  by default.
+
+The Reconciler watches the ARO Cluster object, the worker and aro-last-update
+MachineConfigPools and the labels of the nodes.
+
+There is one flag which controls the operations performed by this controller:
+
+aro.upgradeorder.enabled:
+- When set to false, the controller will noop and not perform any further action
+- When set to true, the controller will reconcile the update strategy of the
+  worker pool and the aro-last-update MachineConfigPool according to the
+  Upgrade field on the ARO Cluster object
+
+If the Upgrade field is not set, the label is removed from the node, which
+returns to the worker pool, and the aro-last-update pool is deleted.
+
+*/
@@ -0,0 +1,346 @@
+package upgradeorder
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/base"
+	"github.com/Azure/ARO-RP/pkg/util/ready"
+)
+
+const (
+	ControllerName = "UpgradeOrder"
+
+	controllerEnabled = "aro.upgradeorder.enabled"
+
+	workerPoolName = "worker"
+	lastPoolName   = "aro-last-update"
+
+	masterRoleLabel = "node-role.kubernetes.io/master"
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+	lastRoleLabel   = "node-role.kubernetes.io/" + lastPoolName
+
+	machineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+
+	// annotationUpdateStrategy marks the worker pool while its update
+	// strategy is set by the Reconciler, so that it is only reset if the
+	// Reconciler set it
+	annotationUpdateStrategy = "aro.openshift.io/updateStrategy"
+)
+
+// Reconciler has the last node of the ARO Cluster object updated after the
+// other worker nodes, and sets the update strategy of the worker pool
+type Reconciler struct {
+	base.AROController
+
+	now func() time.Time
+}
+
+func NewReconciler(log *logrus.Entry, client client.Client) *Reconciler {
+	return &Reconciler{
+		AROController: base.AROController{
+			Log:    log,
+			Client: client,
+			Name:   ControllerName,
+		},
+		now: time.Now,
+	}
+}
+
+// Reconcile watches the ARO object, the worker and aro-last-update
+// MachineConfigPools and the nodes, and if they change, reconciles the update
+// strategy of the worker pool, the aro-last-update MachineConfigPool and the
+// node in it
+func (r *Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	instance, err := r.GetCluster(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !instance.Spec.OperatorFlags.GetSimpleBoolean(controllerEnabled) {
+		r.Log.Debug("controller is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	r.Log.Debug("running")
+	result, err := r.ensureWorkerPoolStrategy(ctx, instance.Spec.Upgrade)
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	if instance.Spec.Upgrade == nil || instance.Spec.Upgrade.LastNodeName == "" {
+		err = r.removeLastPool(ctx)
+	} else {
+		err = r.ensureLastPool(ctx, instance.Spec.Upgrade.LastNodeName)
+	}
+	if err != nil {
+		r.Log.Error(err)
+		r.SetDegraded(ctx, err)
+		return reconcile.Result{}, err
+	}
+
+	r.ClearConditions(ctx)
+	return result, nil
+}
+
+// ensureWorkerPoolStrategy sets the maximum number of unavailable nodes and
+// the pause of the worker pool.  A pause until a given time is lifted by
+// requeueing the request when it expires.  If no strategy is set, the worker
+// pool is reset to the platform behaviour, but only if the Reconciler set it.
+func (r *Reconciler) ensureWorkerPoolStrategy(ctx context.Context, spec *arov1alpha1.UpgradeSpec) (reconcile.Result, error) {
+	var result reconcile.Result
+	var maxUnavailable *intstr.IntOrString
+	var paused bool
+
+	if spec != nil {
+		if spec.MaxUnavailable != "" {
+			v := intstr.Parse(spec.MaxUnavailable)
+			maxUnavailable = &v
+		}
+
+		if spec.PausedUntil != nil {
+			if now := r.now(); now.Before(spec.PausedUntil.Time) {
+				paused = true
+				result.RequeueAfter = spec.PausedUntil.Sub(now)
+			}
+		}
+	}
+
+	managed := maxUnavailable != nil || paused
+
+	pool := &mcv1.MachineConfigPool{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: workerPoolName}, pool)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	_, wasManaged := pool.Annotations[annotationUpdateStrategy]
+	if !managed && !wasManaged {
+		return result, nil
+	}
+
+	if managed == wasManaged &&
+		reflect.DeepEqual(pool.Spec.MaxUnavailable, maxUnavailable) &&
+		pool.Spec.Paused == paused {
+		return result, nil
+	}
+
+	if managed {
+		if pool.Annotations == nil {
+			pool.Annotations = map[string]string{}
+		}
+		pool.Annotations[annotationUpdateStrategy] = ""
+	} else {
+		delete(pool.Annotations, annotationUpdateStrategy)
+	}
+
+	if pool.Spec.Paused != paused {
+		r.Log.Infof("setting %s pool paused to %t", workerPoolName, paused)
+	}
+
+	pool.Spec.MaxUnavailable = maxUnavailable
+	pool.Spec.Paused = paused
+	return result, r.Client.Update(ctx, pool)
+}
+
+// ensureLastPool moves the node to the aro-last-update pool, which is paused
+// until the worker pool is updated
+func (r *Reconciler) ensureLastPool(ctx context.Context, nodeName string) error {
+	node := &corev1.Node{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: nodeName}, node)
+	if kerrors.IsNotFound(err) {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, isMaster := node.Labels[masterRoleLabel]
+	_, isWorker := node.Labels[workerRoleLabel]
+	if isMaster || !isWorker {
+		return fmt.Errorf("node %q is not a worker node", nodeName)
+	}
+
+	workerPool := &mcv1.MachineConfigPool{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: workerPoolName}, workerPool)
+	if err != nil {
+		return err
+	}
+
+	err = r.ensurePool(ctx, !machineConfigPoolIsUpdated(workerPool))
+	if err != nil {
+		return err
+	}
+
+	err = r.unlabelNodes(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+
+	if _, found := node.Labels[lastRoleLabel]; found {
+		return nil
+	}
+
+	r.Log.Infof("moving node %s to the %s pool", nodeName, lastPoolName)
+	node.Labels[lastRoleLabel] = ""
+	return r.Client.Update(ctx, node)
+}
+
+// ensurePool creates or updates the aro-last-update pool.  It selects the
+// MachineConfigs of the worker pool so that its node is configured like the
+// other workers.
+func (r *Reconciler) ensurePool(ctx context.Context, paused bool) error {
+	want := mcv1.MachineConfigPoolSpec{
+		MachineConfigSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      machineConfigRoleLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{workerPoolName, lastPoolName},
+				},
+			},
+		},
+		NodeSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				lastRoleLabel: "",
+			},
+		},
+		Paused: paused,
+	}
+
+	pool := &mcv1.MachineConfigPool{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: lastPoolName}, pool)
+	if kerrors.IsNotFound(err) {
+		return r.Client.Create(ctx, &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: lastPoolName,
+			},
+			Spec: want,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(pool.Spec.MachineConfigSelector, want.MachineConfigSelector) &&
+		reflect.DeepEqual(pool.Spec.NodeSelector, want.NodeSelector) &&
+		pool.Spec.Paused == want.Paused {
+		return nil
+	}
+
+	if pool.Spec.Paused != want.Paused {
+		r.Log.Infof("setting %s pool paused to %t", lastPoolName, want.Paused)
+	}
+
+	pool.Spec.MachineConfigSelector = want.MachineConfigSelector
+	pool.Spec.NodeSelector = want.NodeSelector
+	pool.Spec.Paused = want.Paused
+	return r.Client.Update(ctx, pool)
+}
+
+// removeLastPool returns the node to the worker pool and deletes the
+// aro-last-update pool
+func (r *Reconciler) removeLastPool(ctx context.Context) error {
+	err := r.unlabelNodes(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	pool := &mcv1.MachineConfigPool{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: lastPoolName}, pool)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.Log.Infof("deleting the %s pool", lastPoolName)
+	err = r.Client.Delete(ctx, pool)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// unlabelNodes returns the nodes other than keep from the aro-last-update
+// pool to the worker pool
+func (r *Reconciler) unlabelNodes(ctx context.Context, keep string) error {
+	nodes := &corev1.NodeList{}
+	err := r.Client.List(ctx, nodes, client.HasLabels{lastRoleLabel})
+	if err != nil {
+		return err
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Name == keep {
+			continue
+		}
+
+		r.Log.Infof("returning node %s to the %s pool", node.Name, workerPoolName)
+		delete(node.Labels, lastRoleLabel)
+		err = r.Client.Update(ctx, node)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// machineConfigPoolIsUpdated returns true if all the nodes of the pool run its
+// current configuration
+func machineConfigPoolIsUpdated(mcp *mcv1.MachineConfigPool) bool {
+	return mcp.Spec.Configuration.Name == mcp.Status.Configuration.Name &&
+		ready.MachineConfigPoolIsReady(mcp)
+}
+
+// SetupWithManager setup our manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	aroClusterPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == arov1alpha1.SingletonClusterName
+	})
+
+	machineConfigPoolPredicate := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == workerPoolName || o.GetName() == lastPoolName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arov1alpha1.Cluster{}, builder.WithPredicates(aroClusterPredicate)).
+		Watches(
+			&source.Kind{Type: &mcv1.MachineConfigPool{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(machineConfigPoolPredicate),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Node{}},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Named(ControllerName).
+		Complete(r)
+}
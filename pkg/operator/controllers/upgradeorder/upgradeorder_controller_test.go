@@ -0,0 +1,376 @@
+package upgradeorder
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	_ "github.com/Azure/ARO-RP/pkg/util/scheme"
+	utilconditions "github.com/Azure/ARO-RP/test/util/conditions"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestReconciler(t *testing.T) {
+	defaultAvailable := utilconditions.ControllerDefaultAvailable(ControllerName)
+	defaultProgressing := utilconditions.ControllerDefaultProgressing(ControllerName)
+	defaultDegraded := utilconditions.ControllerDefaultDegraded(ControllerName)
+	defaultConditions := []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, defaultDegraded}
+
+	cluster := func(enabled string, lastNodeName string) *arov1alpha1.Cluster {
+		c := &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Status: arov1alpha1.ClusterStatus{
+				Conditions: defaultConditions,
+			},
+			Spec: arov1alpha1.ClusterSpec{
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: enabled,
+				},
+			},
+		}
+		if lastNodeName != "" {
+			c.Spec.Upgrade = &arov1alpha1.UpgradeSpec{LastNodeName: lastNodeName}
+		}
+		return c
+	}
+
+	node := func(name string, roles ...string) *corev1.Node {
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+		}
+		for _, role := range roles {
+			n.Labels["node-role.kubernetes.io/"+role] = ""
+		}
+		return n
+	}
+
+	workerPool := func(updated bool) *mcv1.MachineConfigPool {
+		mcp := &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: workerPoolName},
+			Spec: mcv1.MachineConfigPoolSpec{
+				Configuration: mcv1.MachineConfigPoolStatusConfiguration{
+					ObjectReference: corev1.ObjectReference{Name: "rendered-worker-2"},
+				},
+			},
+			Status: mcv1.MachineConfigPoolStatus{
+				Configuration: mcv1.MachineConfigPoolStatusConfiguration{
+					ObjectReference: corev1.ObjectReference{Name: "rendered-worker-2"},
+				},
+				MachineCount:        2,
+				UpdatedMachineCount: 2,
+				ReadyMachineCount:   2,
+			},
+		}
+		if !updated {
+			mcp.Status.Configuration.Name = "rendered-worker-1"
+			mcp.Status.UpdatedMachineCount = 1
+		}
+		return mcp
+	}
+
+	lastPool := func(paused bool) *mcv1.MachineConfigPool {
+		return &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: lastPoolName},
+			Spec: mcv1.MachineConfigPoolSpec{
+				Paused: paused,
+			},
+		}
+	}
+
+	degraded := func(message string) []operatorv1.OperatorCondition {
+		d := defaultDegraded
+		d.Status = operatorv1.ConditionTrue
+		d.Message = message
+		return []operatorv1.OperatorCondition{defaultAvailable, defaultProgressing, d}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		wantErrMsg     string
+		wantConditions []operatorv1.OperatorCondition
+		wantLastNodes  []string
+		wantNoPool     bool
+		wantPaused     bool
+	}{
+		{
+			name:       "no cluster",
+			objects:    []client.Object{},
+			wantErrMsg: `clusters.aro.openshift.io "cluster" not found`,
+		},
+		{
+			name: "controller disabled",
+			objects: []client.Object{
+				cluster("false", "worker-1"),
+				node("worker-1", "worker"),
+				workerPool(true),
+			},
+			wantConditions: defaultConditions,
+			wantNoPool:     true,
+		},
+		{
+			name: "node is moved to a pool updated after the worker pool",
+			objects: []client.Object{
+				cluster("true", "worker-1"),
+				node("worker-1", "worker"),
+				node("worker-2", "worker"),
+				workerPool(true),
+			},
+			wantConditions: defaultConditions,
+			wantLastNodes:  []string{"worker-1"},
+		},
+		{
+			name: "pool is paused while the worker pool is updating",
+			objects: []client.Object{
+				cluster("true", "worker-1"),
+				node("worker-1", "worker", lastPoolName),
+				workerPool(false),
+				lastPool(false),
+			},
+			wantConditions: defaultConditions,
+			wantLastNodes:  []string{"worker-1"},
+			wantPaused:     true,
+		},
+		{
+			name: "pool is unpaused once the worker pool is updated",
+			objects: []client.Object{
+				cluster("true", "worker-1"),
+				node("worker-1", "worker", lastPoolName),
+				workerPool(true),
+				lastPool(true),
+			},
+			wantConditions: defaultConditions,
+			wantLastNodes:  []string{"worker-1"},
+		},
+		{
+			name: "previous last node is returned to the worker pool",
+			objects: []client.Object{
+				cluster("true", "worker-2"),
+				node("worker-1", "worker", lastPoolName),
+				node("worker-2", "worker"),
+				workerPool(true),
+				lastPool(false),
+			},
+			wantConditions: defaultConditions,
+			wantLastNodes:  []string{"worker-2"},
+		},
+		{
+			name: "node is not found",
+			objects: []client.Object{
+				cluster("true", "worker-3"),
+				node("worker-1", "worker"),
+				workerPool(true),
+			},
+			wantErrMsg:     `node "worker-3" not found`,
+			wantConditions: degraded(`node "worker-3" not found`),
+			wantNoPool:     true,
+		},
+		{
+			name: "node is a master",
+			objects: []client.Object{
+				cluster("true", "master-0"),
+				node("master-0", "master", "worker"),
+				workerPool(true),
+			},
+			wantErrMsg:     `node "master-0" is not a worker node`,
+			wantConditions: degraded(`node "master-0" is not a worker node`),
+			wantNoPool:     true,
+		},
+		{
+			name: "pool is removed when no last node is configured",
+			objects: []client.Object{
+				cluster("true", ""),
+				node("worker-1", "worker", lastPoolName),
+				workerPool(true),
+				lastPool(true),
+			},
+			wantConditions: defaultConditions,
+			wantNoPool:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(tt.objects...).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+
+			utilerror.AssertErrorMessage(t, err, tt.wantErrMsg)
+			if tt.wantConditions == nil {
+				return
+			}
+			utilconditions.AssertControllerConditions(t, ctx, client, tt.wantConditions)
+
+			nodes := &corev1.NodeList{}
+			err = client.List(ctx, nodes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var lastNodes []string
+			for _, n := range nodes.Items {
+				if _, found := n.Labels[lastRoleLabel]; found {
+					lastNodes = append(lastNodes, n.Name)
+				}
+			}
+			if len(lastNodes) != len(tt.wantLastNodes) || (len(lastNodes) > 0 && lastNodes[0] != tt.wantLastNodes[0]) {
+				t.Errorf("got last nodes %v, want %v", lastNodes, tt.wantLastNodes)
+			}
+
+			pool := &mcv1.MachineConfigPool{}
+			err = client.Get(ctx, types.NamespacedName{Name: lastPoolName}, pool)
+			if tt.wantNoPool {
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("got error %v, want not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if pool.Spec.Paused != tt.wantPaused {
+				t.Errorf("got paused %t, want %t", pool.Spec.Paused, tt.wantPaused)
+			}
+			if _, found := pool.Spec.NodeSelector.MatchLabels[lastRoleLabel]; !found {
+				t.Errorf("got node selector %#v", pool.Spec.NodeSelector)
+			}
+		})
+	}
+}
+
+func TestReconcilerWorkerPoolStrategy(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	maxUnavailable := intstr.FromString("10%")
+
+	cluster := func(upgrade *arov1alpha1.UpgradeSpec) *arov1alpha1.Cluster {
+		return &arov1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: arov1alpha1.SingletonClusterName},
+			Spec: arov1alpha1.ClusterSpec{
+				OperatorFlags: arov1alpha1.OperatorFlags{
+					controllerEnabled: "true",
+				},
+				Upgrade: upgrade,
+			},
+		}
+	}
+
+	workerPool := func(annotated bool, maxUnavailable *intstr.IntOrString, paused bool) *mcv1.MachineConfigPool {
+		mcp := &mcv1.MachineConfigPool{
+			ObjectMeta: metav1.ObjectMeta{Name: workerPoolName},
+			Spec: mcv1.MachineConfigPoolSpec{
+				MaxUnavailable: maxUnavailable,
+				Paused:         paused,
+			},
+		}
+		if annotated {
+			mcp.Annotations = map[string]string{annotationUpdateStrategy: ""}
+		}
+		return mcp
+	}
+
+	for _, tt := range []struct {
+		name               string
+		upgrade            *arov1alpha1.UpgradeSpec
+		pool               *mcv1.MachineConfigPool
+		wantAnnotated      bool
+		wantMaxUnavailable *intstr.IntOrString
+		wantPaused         bool
+		wantRequeueAfter   time.Duration
+	}{
+		{
+			name:       "pool paused by someone else is left alone",
+			pool:       workerPool(false, nil, true),
+			wantPaused: true,
+		},
+		{
+			name:               "strategy is set",
+			upgrade:            &arov1alpha1.UpgradeSpec{MaxUnavailable: "10%"},
+			pool:               workerPool(false, nil, false),
+			wantAnnotated:      true,
+			wantMaxUnavailable: &maxUnavailable,
+		},
+		{
+			name:             "pool is paused until the pause expires",
+			upgrade:          &arov1alpha1.UpgradeSpec{PausedUntil: &metav1.Time{Time: now.Add(time.Hour)}},
+			pool:             workerPool(false, nil, false),
+			wantAnnotated:    true,
+			wantPaused:       true,
+			wantRequeueAfter: time.Hour,
+		},
+		{
+			name:    "pool is unpaused once the pause expires",
+			upgrade: &arov1alpha1.UpgradeSpec{PausedUntil: &metav1.Time{Time: now.Add(-time.Hour)}},
+			pool:    workerPool(true, nil, true),
+		},
+		{
+			name:    "strategy is reset when it is no longer set",
+			upgrade: &arov1alpha1.UpgradeSpec{},
+			pool:    workerPool(true, &maxUnavailable, true),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := ctrlfake.NewClientBuilder().
+				WithObjects(cluster(tt.upgrade), tt.pool).
+				Build()
+
+			r := NewReconciler(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+			)
+			r.now = func() time.Time { return now }
+
+			ctx := context.Background()
+			result, err := r.Reconcile(ctx, ctrl.Request{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.RequeueAfter != tt.wantRequeueAfter {
+				t.Errorf("got requeue after %s, want %s", result.RequeueAfter, tt.wantRequeueAfter)
+			}
+
+			pool := &mcv1.MachineConfigPool{}
+			err = client.Get(ctx, types.NamespacedName{Name: workerPoolName}, pool)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, annotated := pool.Annotations[annotationUpdateStrategy]; annotated != tt.wantAnnotated {
+				t.Errorf("got annotated %t, want %t", annotated, tt.wantAnnotated)
+			}
+			if !reflect.DeepEqual(pool.Spec.MaxUnavailable, tt.wantMaxUnavailable) {
+				t.Errorf("got max unavailable %v, want %v", pool.Spec.MaxUnavailable, tt.wantMaxUnavailable)
+			}
+			if pool.Spec.Paused != tt.wantPaused {
+				t.Errorf("got paused %t, want %t", pool.Spec.Paused, tt.wantPaused)
+			}
+		})
+	}
+}
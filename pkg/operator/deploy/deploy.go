@@ -13,7 +13,9 @@ import (
 	"text/template"
 	"time"
 
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	extensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -36,8 +38,11 @@ import (
 	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	aroclient "github.com/Azure/ARO-RP/pkg/operator/clientset/versioned"
 	"github.com/Azure/ARO-RP/pkg/operator/controllers/genevalogging"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/identityprovider"
+	"github.com/Azure/ARO-RP/pkg/operator/controllers/logforwarding"
 	"github.com/Azure/ARO-RP/pkg/util/dynamichelper"
 	utilpem "github.com/Azure/ARO-RP/pkg/util/pem"
+	utilproxy "github.com/Azure/ARO-RP/pkg/util/proxy"
 	"github.com/Azure/ARO-RP/pkg/util/pullsecret"
 	"github.com/Azure/ARO-RP/pkg/util/ready"
 	"github.com/Azure/ARO-RP/pkg/util/restconfig"
@@ -51,20 +56,23 @@ type Operator interface {
 	IsReady(context.Context) (bool, error)
 	IsRunningDesiredVersion(context.Context) (bool, error)
 	RenewMDSDCertificate(context.Context) error
+	Diff(context.Context) ([]*dynamichelper.ObjectDiff, error)
 }
 
 type operator struct {
-	log *logrus.Entry
-	env env.Interface
-	oc  *api.OpenShiftCluster
+	log       *logrus.Entry
+	env       env.Interface
+	oc        *api.OpenShiftCluster
+	tagPolicy *api.TagPolicy
 
 	arocli        aroclient.Interface
+	configcli     configclient.Interface
 	extensionscli extensionsclient.Interface
 	kubernetescli kubernetes.Interface
 	dh            dynamichelper.Interface
 }
 
-func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, arocli aroclient.Interface, extensionscli extensionsclient.Interface, kubernetescli kubernetes.Interface) (Operator, error) {
+func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, tagPolicy *api.TagPolicy, arocli aroclient.Interface, configcli configclient.Interface, extensionscli extensionsclient.Interface, kubernetescli kubernetes.Interface) (Operator, error) {
 	restConfig, err := restconfig.RestConfig(env, oc)
 	if err != nil {
 		return nil, err
@@ -75,11 +83,13 @@ func New(log *logrus.Entry, env env.Interface, oc *api.OpenShiftCluster, arocli
 	}
 
 	return &operator{
-		log: log,
-		env: env,
-		oc:  oc,
+		log:       log,
+		env:       env,
+		oc:        oc,
+		tagPolicy: tagPolicy,
 
 		arocli:        arocli,
+		configcli:     configcli,
 		extensionscli: extensionscli,
 		kubernetescli: kubernetescli,
 		dh:            dh,
@@ -159,10 +169,66 @@ func (o *operator) createObjects() ([]kruntime.Object, error) {
 		objects = append(objects, obj)
 	}
 
+	err = o.setOperatorResources(objects)
+	if err != nil {
+		return nil, err
+	}
+
 	return objects, nil
 }
 
-func (o *operator) resources() ([]kruntime.Object, error) {
+// setOperatorResources sets the resource requests and limits of the operator
+// deployments from the operator flags.  The operator keeps them in line with
+// the flags afterwards.
+func (o *operator) setOperatorResources(objects []kruntime.Object) error {
+	for _, obj := range objects {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		resources, err := pkgoperator.Resources(o.oc.Properties.OperatorFlags, strings.TrimPrefix(deployment.Name, "aro-operator-"))
+		if err != nil {
+			return err
+		}
+
+		for i := range deployment.Spec.Template.Spec.Containers {
+			deployment.Spec.Template.Spec.Containers[i].Resources = resources
+		}
+	}
+
+	return nil
+}
+
+// setOperatorProxy sets the proxy environment variables of the operator
+// deployments from the cluster-wide proxy, so that all the egress of the
+// operator, e.g. to Azure, goes through it.  The operator keeps them in line
+// with the proxy afterwards.
+func (o *operator) setOperatorProxy(ctx context.Context, objects []kruntime.Object) error {
+	proxy, err := o.configcli.ConfigV1().Proxies().Get(ctx, "cluster", metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		proxy = nil
+	} else if err != nil {
+		return err
+	}
+
+	env := utilproxy.EnvVars(proxy)
+
+	for _, obj := range objects {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		for i := range deployment.Spec.Template.Spec.Containers {
+			utilproxy.SetEnvVars(&deployment.Spec.Template.Spec.Containers[i], env)
+		}
+	}
+
+	return nil
+}
+
+func (o *operator) resources(ctx context.Context) ([]kruntime.Object, error) {
 	// first static resources from Assets
 
 	results, err := o.createObjects()
@@ -170,6 +236,11 @@ func (o *operator) resources() ([]kruntime.Object, error) {
 		return nil, err
 	}
 
+	err = o.setOperatorProxy(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+
 	// then dynamic resources
 	key, cert := o.env.ClusterGenevaLoggingSecret()
 	gcsKeyBytes, err := utilpem.Encode(key)
@@ -234,6 +305,8 @@ func (o *operator) resources() ([]kruntime.Object, error) {
 				MonitoringGCSNamespace:   o.env.ClusterGenevaLoggingNamespace(),
 			},
 			ServiceSubnets: serviceSubnets,
+			TimeZone:       o.oc.Properties.ClusterProfile.TimeZone,
+			MaxPods:        o.oc.Properties.NetworkProfile.MaxPods,
 			InternetChecker: arov1alpha1.InternetCheckerSpec{
 				URLs: []string{
 					fmt.Sprintf("https://%s/", o.env.ACRDomain()),
@@ -244,13 +317,111 @@ func (o *operator) resources() ([]kruntime.Object, error) {
 			},
 
 			APIIntIP:                 o.oc.Properties.APIServerProfile.IntIP,
+			APIServerVisibility:      string(o.oc.Properties.APIServerProfile.Visibility),
 			IngressIP:                ingressIP,
 			GatewayPrivateEndpointIP: o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP,
 			// Update the OperatorFlags from the version in the RP
-			OperatorFlags: arov1alpha1.OperatorFlags(o.oc.Properties.OperatorFlags),
+			OperatorFlags: ClusterOperatorFlags(o.oc),
 		},
 	}
 
+	if o.oc.Properties.ProjectTemplateProfile != nil {
+		cluster.Spec.ProjectTemplate = &arov1alpha1.ProjectTemplateSpec{
+			ResourceQuota:   o.oc.Properties.ProjectTemplateProfile.ResourceQuota,
+			DefaultLimits:   o.oc.Properties.ProjectTemplateProfile.DefaultLimits,
+			DefaultRequests: o.oc.Properties.ProjectTemplateProfile.DefaultRequests,
+		}
+	}
+
+	if o.oc.Properties.IdentityProviderProfile != nil {
+		cluster.Spec.IdentityProvider = &arov1alpha1.IdentityProviderSpec{
+			Name:     o.oc.Properties.IdentityProviderProfile.Name,
+			Issuer:   o.oc.Properties.IdentityProviderProfile.Issuer,
+			ClientID: o.oc.Properties.IdentityProviderProfile.ClientID,
+		}
+	}
+
+	for _, p := range o.oc.Properties.RegistryMirrorProfiles {
+		cluster.Spec.RegistryMirrors = append(cluster.Spec.RegistryMirrors, arov1alpha1.RegistryMirrorSpec{
+			Source: p.Source,
+			Mirror: p.Mirror,
+		})
+	}
+
+	if o.oc.Properties.DefaultStorageClassProfile != nil {
+		cluster.Spec.DefaultStorageClass = &arov1alpha1.DefaultStorageClassSpec{
+			Name:       o.oc.Properties.DefaultStorageClassProfile.Name,
+			Parameters: o.oc.Properties.DefaultStorageClassProfile.Parameters,
+		}
+	}
+
+	if o.oc.Properties.AzureFileCSIProfile != nil {
+		cluster.Spec.AzureFileCSI = &arov1alpha1.AzureFileCSISpec{
+			StorageClassName: o.oc.Properties.AzureFileCSIProfile.StorageClassName,
+			SKUName:          o.oc.Properties.AzureFileCSIProfile.SKUName,
+		}
+	}
+
+	if o.oc.Properties.MonitoringProfile != nil {
+		cluster.Spec.Monitoring = &arov1alpha1.MonitoringSpec{
+			Retention:        o.oc.Properties.MonitoringProfile.Retention,
+			StorageSize:      o.oc.Properties.MonitoringProfile.StorageSize,
+			StorageClassName: o.oc.Properties.MonitoringProfile.StorageClassName,
+		}
+	}
+
+	cluster.Spec.Upgrade = ClusterUpgradeSpec(o.oc)
+
+	if o.oc.Properties.NodeEvictionProfile != nil {
+		cluster.Spec.NodeEviction = &arov1alpha1.NodeEvictionSpec{
+			Hard:            evictionThresholdsSpec(o.oc.Properties.NodeEvictionProfile.Hard),
+			Soft:            evictionThresholdsSpec(o.oc.Properties.NodeEvictionProfile.Soft),
+			SoftGracePeriod: o.oc.Properties.NodeEvictionProfile.SoftGracePeriod,
+		}
+	}
+
+	if o.oc.Properties.SecurityProfile != nil {
+		cluster.Spec.SecurityProfile = &arov1alpha1.SecurityProfileSpec{
+			DefaultSeccompProfile: string(o.oc.Properties.SecurityProfile.DefaultSeccompProfile),
+			SELinuxBooleans:       o.oc.Properties.SecurityProfile.SELinuxBooleans,
+		}
+	}
+
+	cluster.Spec.ResourceTags = ClusterResourceTagsSpec(o.oc, o.tagPolicy)
+
+	for _, p := range o.oc.Properties.AdmissionWebhookProfiles {
+		cluster.Spec.AdmissionWebhooks = append(cluster.Spec.AdmissionWebhooks, p.Configuration)
+	}
+
+	if o.oc.Properties.SchedulerProfile != nil {
+		cluster.Spec.DefaultNodeSelector = o.oc.Properties.SchedulerProfile.DefaultNodeSelector
+	}
+
+	if o.oc.Properties.EgressFirewallProfile != nil {
+		cluster.Spec.EgressFirewall = &arov1alpha1.EgressFirewallSpec{}
+		for _, d := range o.oc.Properties.EgressFirewallProfile.AllowedDestinations {
+			cluster.Spec.EgressFirewall.AllowedDestinations = append(cluster.Spec.EgressFirewall.AllowedDestinations, arov1alpha1.EgressDestination{
+				CIDR:    d.CIDR,
+				DNSName: d.DNSName,
+			})
+		}
+	}
+
+	if o.oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+		cluster.Spec.CustomerLoadBalancerID = o.oc.Properties.NetworkProfile.CustomerLoadBalancerProfile.ID
+	}
+
+	if o.oc.Properties.LogForwardingProfile != nil {
+		cluster.Spec.LogForwarding = &arov1alpha1.LogForwardingSpec{
+			Type:        string(o.oc.Properties.LogForwardingProfile.Type),
+			URL:         o.oc.Properties.LogForwardingProfile.URL,
+			WorkspaceID: o.oc.Properties.LogForwardingProfile.WorkspaceID,
+		}
+		for _, t := range o.oc.Properties.LogForwardingProfile.LogTypes {
+			cluster.Spec.LogForwarding.LogTypes = append(cluster.Spec.LogForwarding.LogTypes, string(t))
+		}
+	}
+
 	if o.oc.Properties.FeatureProfile.GatewayEnabled && o.oc.Properties.NetworkProfile.GatewayPrivateEndpointIP != "" {
 		cluster.Spec.GatewayDomains = append(o.env.GatewayDomains(), o.oc.Properties.ImageRegistryStorageAccountName+".blob."+o.env.Environment().StorageEndpointSuffix)
 	} else {
@@ -259,25 +430,102 @@ func (o *operator) resources() ([]kruntime.Object, error) {
 	}
 
 	// create a secret here for genevalogging, later we will copy it to
-	// the genevalogging namespace.
-	return append(results,
-		&corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      pkgoperator.SecretName,
-				Namespace: pkgoperator.Namespace,
-			},
-			Data: map[string][]byte{
-				genevalogging.GenevaCertName: gcsCertBytes,
-				genevalogging.GenevaKeyName:  gcsKeyBytes,
-				corev1.DockerConfigJsonKey:   []byte(ps),
-			},
+	// the genevalogging namespace.  The identity provider client secret and
+	// the log forwarding shared key are likewise copied to the
+	// openshift-config and openshift-logging namespaces.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pkgoperator.SecretName,
+			Namespace: pkgoperator.Namespace,
+		},
+		Data: map[string][]byte{
+			genevalogging.GenevaCertName: gcsCertBytes,
+			genevalogging.GenevaKeyName:  gcsKeyBytes,
+			corev1.DockerConfigJsonKey:   []byte(ps),
 		},
-		cluster,
-	), nil
+	}
+
+	if o.oc.Properties.IdentityProviderProfile != nil {
+		secret.Data[identityprovider.ClientSecretKey] = []byte(o.oc.Properties.IdentityProviderProfile.ClientSecret)
+	}
+
+	if o.oc.Properties.LogForwardingProfile != nil && o.oc.Properties.LogForwardingProfile.SharedKey != "" {
+		secret.Data[logforwarding.SharedKeyKey] = []byte(o.oc.Properties.LogForwardingProfile.SharedKey)
+	}
+
+	return append(results, secret, cluster), nil
+}
+
+func evictionThresholdsSpec(t *api.EvictionThresholds) *arov1alpha1.EvictionThresholdsSpec {
+	if t == nil {
+		return nil
+	}
+
+	return &arov1alpha1.EvictionThresholdsSpec{
+		MemoryAvailable:  t.MemoryAvailable,
+		NodeFSAvailable:  t.NodeFSAvailable,
+		ImageFSAvailable: t.ImageFSAvailable,
+	}
+}
+
+// ClusterResourceTagsSpec returns the resource tags spec to set on the Cluster
+// resource: the cluster's tags which are required by the tag policy of its
+// subscription, which the operator keeps on the Azure resources of the
+// cluster.
+func ClusterResourceTagsSpec(oc *api.OpenShiftCluster, tagPolicy *api.TagPolicy) *arov1alpha1.ResourceTagsSpec {
+	tags, _ := tagPolicy.Match(oc.Tags)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return &arov1alpha1.ResourceTagsSpec{
+		Tags:                 tags,
+		ClusterResourcesOnly: oc.Properties.ClusterProfile.ExistingResourceGroup == api.ExistingResourceGroupEnabled,
+	}
+}
+
+// ClusterUpgradeSpec returns the upgrade spec to set on the Cluster resource.
+// An admin pause of the node updates is carried over until it expires, which
+// the operator enforces itself so that it does not depend on a later update
+// of the Cluster resource.
+func ClusterUpgradeSpec(oc *api.OpenShiftCluster) *arov1alpha1.UpgradeSpec {
+	if oc.Properties.UpgradeProfile == nil && oc.Properties.NodeUpdatesPause == nil {
+		return nil
+	}
+
+	spec := &arov1alpha1.UpgradeSpec{}
+
+	if oc.Properties.UpgradeProfile != nil {
+		spec.LastNodeName = oc.Properties.UpgradeProfile.LastNodeName
+		spec.MaxUnavailable = oc.Properties.UpgradeProfile.MaxUnavailable
+	}
+
+	if oc.Properties.NodeUpdatesPause != nil {
+		spec.PausedUntil = &metav1.Time{Time: oc.Properties.NodeUpdatesPause.PausedUntil}
+	}
+
+	return spec
+}
+
+// ClusterOperatorFlags returns the operator flags to set on the Cluster
+// resource.  While the cluster is quarantined every controller is disabled, so
+// that the operator makes no automated changes to the cluster; the flags in the
+// cluster document are left untouched so that they are restored when the
+// quarantine is removed.
+func ClusterOperatorFlags(oc *api.OpenShiftCluster) arov1alpha1.OperatorFlags {
+	flags := make(arov1alpha1.OperatorFlags, len(oc.Properties.OperatorFlags))
+	for k, v := range oc.Properties.OperatorFlags {
+		if oc.Properties.Quarantine != nil && strings.HasSuffix(k, ".enabled") {
+			v = "false"
+		}
+		flags[k] = v
+	}
+
+	return flags
 }
 
 func (o *operator) CreateOrUpdate(ctx context.Context) error {
-	resources, err := o.resources()
+	resources, err := o.resources(ctx)
 	if err != nil {
 		return err
 	}
@@ -365,6 +613,22 @@ func (o *operator) CreateOrUpdate(ctx context.Context) error {
 	return nil
 }
 
+// Diff regenerates the resources which CreateOrUpdate would apply and returns
+// how they differ from the objects in the cluster, without changing anything
+func (o *operator) Diff(ctx context.Context) ([]*dynamichelper.ObjectDiff, error) {
+	resources, err := o.resources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dynamichelper.Prepare(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.dh.Diff(ctx, resources...)
+}
+
 func (o *operator) RenewMDSDCertificate(ctx context.Context) error {
 	key, cert := o.env.ClusterGenevaLoggingSecret()
 	gcsKeyBytes, err := utilpem.Encode(key)
@@ -7,14 +7,19 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	configfake "github.com/openshift/client-go/config/clientset/versioned/fake"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/Azure/ARO-RP/pkg/api"
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
 	"github.com/Azure/ARO-RP/pkg/util/cmp"
 	mock_env "github.com/Azure/ARO-RP/pkg/util/mocks/env"
 	utilerror "github.com/Azure/ARO-RP/test/util/error"
@@ -200,6 +205,177 @@ func TestCreateDeploymentData(t *testing.T) {
 	}
 }
 
+func TestClusterOperatorFlags(t *testing.T) {
+	flags := api.OperatorFlags{
+		"aro.alertwebhook.enabled":     "true",
+		"aro.imageconfig.enabled":      "false",
+		"aro.azuresubnets.nsg.managed": "true",
+		"rh.srep.muo.managed":          "true",
+	}
+
+	for _, tt := range []struct {
+		name       string
+		quarantine *api.Quarantine
+		want       arov1alpha1.OperatorFlags
+	}{
+		{
+			name: "not quarantined",
+			want: arov1alpha1.OperatorFlags{
+				"aro.alertwebhook.enabled":     "true",
+				"aro.imageconfig.enabled":      "false",
+				"aro.azuresubnets.nsg.managed": "true",
+				"rh.srep.muo.managed":          "true",
+			},
+		},
+		{
+			name:       "quarantined, controllers disabled",
+			quarantine: &api.Quarantine{Reason: "incident"},
+			want: arov1alpha1.OperatorFlags{
+				"aro.alertwebhook.enabled":     "false",
+				"aro.imageconfig.enabled":      "false",
+				"aro.azuresubnets.nsg.managed": "true",
+				"rh.srep.muo.managed":          "true",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					OperatorFlags: flags,
+					Quarantine:    tt.quarantine,
+				},
+			}
+
+			got := ClusterOperatorFlags(oc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+
+			if oc.Properties.OperatorFlags["aro.alertwebhook.enabled"] != "true" {
+				t.Error("cluster document operator flags were modified")
+			}
+		})
+	}
+}
+
+func TestClusterUpgradeSpec(t *testing.T) {
+	pausedUntil := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name             string
+		upgradeProfile   *api.UpgradeProfile
+		nodeUpdatesPause *api.NodeUpdatesPause
+		want             *arov1alpha1.UpgradeSpec
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "upgrade profile",
+			upgradeProfile: &api.UpgradeProfile{
+				LastNodeName:   "worker-1",
+				MaxUnavailable: "10%",
+			},
+			want: &arov1alpha1.UpgradeSpec{
+				LastNodeName:   "worker-1",
+				MaxUnavailable: "10%",
+			},
+		},
+		{
+			name: "paused by an admin",
+			nodeUpdatesPause: &api.NodeUpdatesPause{
+				Reason:      "incident",
+				PausedUntil: pausedUntil,
+			},
+			want: &arov1alpha1.UpgradeSpec{
+				PausedUntil: &metav1.Time{Time: pausedUntil},
+			},
+		},
+		{
+			name: "upgrade profile and paused by an admin",
+			upgradeProfile: &api.UpgradeProfile{
+				MaxUnavailable: "2",
+			},
+			nodeUpdatesPause: &api.NodeUpdatesPause{
+				Reason:      "incident",
+				PausedUntil: pausedUntil,
+			},
+			want: &arov1alpha1.UpgradeSpec{
+				MaxUnavailable: "2",
+				PausedUntil:    &metav1.Time{Time: pausedUntil},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					UpgradeProfile:   tt.upgradeProfile,
+					NodeUpdatesPause: tt.nodeUpdatesPause,
+				},
+			}
+
+			got := ClusterUpgradeSpec(oc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestClusterResourceTagsSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		tags                  map[string]string
+		tagPolicy             *api.TagPolicy
+		existingResourceGroup api.ExistingResourceGroup
+		want                  *arov1alpha1.ResourceTagsSpec
+	}{
+		{
+			name: "no tag policy",
+			tags: map[string]string{"owner": "team"},
+		},
+		{
+			name:      "no required tags on the cluster",
+			tags:      map[string]string{"other": "value"},
+			tagPolicy: &api.TagPolicy{RequiredTags: []string{"owner"}},
+		},
+		{
+			name:      "required tags",
+			tags:      map[string]string{"Owner": "team", "other": "value"},
+			tagPolicy: &api.TagPolicy{RequiredTags: []string{"owner"}},
+			want: &arov1alpha1.ResourceTagsSpec{
+				Tags: map[string]string{"Owner": "team"},
+			},
+		},
+		{
+			name:                  "required tags in an existing resource group",
+			tags:                  map[string]string{"owner": "team"},
+			tagPolicy:             &api.TagPolicy{RequiredTags: []string{"owner"}},
+			existingResourceGroup: api.ExistingResourceGroupEnabled,
+			want: &arov1alpha1.ResourceTagsSpec{
+				Tags:                 map[string]string{"owner": "team"},
+				ClusterResourcesOnly: true,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{
+				Tags: tt.tags,
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						ExistingResourceGroup: tt.existingResourceGroup,
+					},
+				},
+			}
+
+			got := ClusterResourceTagsSpec(oc, tt.tagPolicy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestOperatorVersion(t *testing.T) {
 	type test struct {
 		name         string
@@ -278,6 +454,124 @@ func TestOperatorVersion(t *testing.T) {
 	}
 }
 
+func TestOperatorResources(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_env := mock_env.NewMockInterface(controller)
+	_env.EXPECT().AROOperatorImage().AnyTimes().Return("defaultaroimagefromenv")
+	_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
+
+	o := &operator{
+		oc: &api.OpenShiftCluster{
+			Properties: api.OpenShiftClusterProperties{
+				OperatorFlags: api.OperatorFlags{
+					"aro.operator.master.resources.requests.memory": "512Mi",
+					"aro.operator.worker.resources.limits.cpu":      "1",
+				},
+			},
+		},
+		env: _env,
+	}
+
+	staticResources, err := o.createObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]corev1.ResourceRequirements{
+		"aro-operator-master": {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		"aro-operator-worker": {
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	for _, i := range staticResources {
+		if d, ok := i.(*appsv1.Deployment); ok {
+			if diff := cmp.Diff(want[d.Name], d.Spec.Template.Spec.Containers[0].Resources); diff != "" {
+				t.Errorf("%s: %s", d.Name, diff)
+			}
+		}
+	}
+}
+
+func TestOperatorProxy(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := &configv1.Proxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Status: configv1.ProxyStatus{
+			HTTPSProxy: "http://proxy.example.com:3128",
+			NoProxy:    ".cluster.local,10.128.0.0/14",
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		proxy   *configv1.Proxy
+		wantEnv []corev1.EnvVar
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:  "proxy",
+			proxy: proxy,
+			wantEnv: []corev1.EnvVar{
+				{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "NO_PROXY", Value: ".cluster.local,10.128.0.0/14"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			_env := mock_env.NewMockInterface(controller)
+			_env.EXPECT().AROOperatorImage().AnyTimes().Return("defaultaroimagefromenv")
+			_env.EXPECT().IsLocalDevelopmentMode().AnyTimes().Return(false)
+
+			configcli := configfake.NewSimpleClientset()
+			if tt.proxy != nil {
+				configcli = configfake.NewSimpleClientset(tt.proxy)
+			}
+
+			o := &operator{
+				oc:        &api.OpenShiftCluster{},
+				env:       _env,
+				configcli: configcli,
+			}
+
+			staticResources, err := o.createObjects()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = o.setOperatorProxy(ctx, staticResources)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var deployments int
+			for _, i := range staticResources {
+				if d, ok := i.(*appsv1.Deployment); ok {
+					deployments++
+					if !reflect.DeepEqual(d.Spec.Template.Spec.Containers[0].Env, tt.wantEnv) {
+						t.Errorf("%s: got %#v, wanted %#v", d.Name, d.Spec.Template.Spec.Containers[0].Env, tt.wantEnv)
+					}
+				}
+			}
+			if deployments != 2 {
+				t.Errorf("found %d deployments, not 2", deployments)
+			}
+		})
+	}
+}
+
 func TestCheckOperatorDeploymentVersion(t *testing.T) {
 	ctx := context.Background()
 	for _, tt := range []struct {
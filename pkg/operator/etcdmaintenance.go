@@ -0,0 +1,66 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+const EtcdDefragScheduleFlag = "aro.etcdmaintenance.defragschedule"
+
+// minEtcdDefragInterval bounds the defragmentation schedule.  Defragmentation
+// makes each member unavailable for its duration, so it runs at most daily.
+const minEtcdDefragInterval = 24 * time.Hour
+
+// EtcdMaintenanceConfig is the etcd maintenance set by the operator flags
+type EtcdMaintenanceConfig struct {
+	// DefragSchedule is nil if etcd is not defragmented on a schedule
+	DefragSchedule cron.Schedule
+}
+
+// EtcdMaintenance returns the etcd maintenance set by the operator flags.
+// Flags which are not set keep the platform behaviour.
+func EtcdMaintenance(flags map[string]string) (*EtcdMaintenanceConfig, error) {
+	c := &EtcdMaintenanceConfig{}
+
+	if v := flags[EtcdDefragScheduleFlag]; v != "" {
+		schedule, err := cron.ParseStandard(v)
+		if err == nil {
+			err = checkEtcdDefragSchedule(schedule)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %v", EtcdDefragScheduleFlag, v, err)
+		}
+		c.DefragSchedule = schedule
+	}
+
+	return c, nil
+}
+
+// checkEtcdDefragSchedule returns an error if the schedule never runs, or
+// runs more than once within minEtcdDefragInterval at any point of a year
+func checkEtcdDefragSchedule(schedule cron.Schedule) error {
+	start := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	prev := schedule.Next(start)
+	if prev.IsZero() {
+		return fmt.Errorf("the schedule never runs")
+	}
+
+	for prev.Before(start.AddDate(1, 0, 0)) {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(prev) < minEtcdDefragInterval {
+			return fmt.Errorf("the schedule must run at most once every %s", minEtcdDefragInterval)
+		}
+		prev = next
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestEtcdMaintenance(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		flags        map[string]string
+		wantSchedule bool
+		wantErr      string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "weekly defragmentation",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "0 3 * * 0",
+			},
+			wantSchedule: true,
+		},
+		{
+			name: "daily defragmentation",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "@daily",
+			},
+			wantSchedule: true,
+		},
+		{
+			name: "invalid defragmentation schedule",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "every sunday",
+			},
+			wantErr: "invalid aro.etcdmaintenance.defragschedule 'every sunday': Expected exactly 5 fields, found 2: every sunday",
+		},
+		{
+			name: "defragmentation schedule too frequent",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "0 */12 * * *",
+			},
+			wantErr: "invalid aro.etcdmaintenance.defragschedule '0 */12 * * *': the schedule must run at most once every 24h0m0s",
+		},
+		{
+			name: "defragmentation schedule too frequent on some days",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "0 3,4 * * 1",
+			},
+			wantErr: "invalid aro.etcdmaintenance.defragschedule '0 3,4 * * 1': the schedule must run at most once every 24h0m0s",
+		},
+		{
+			name: "defragmentation schedule which never runs",
+			flags: map[string]string{
+				EtcdDefragScheduleFlag: "0 3 30 2 *",
+			},
+			wantErr: "invalid aro.etcdmaintenance.defragschedule '0 3 30 2 *': the schedule never runs",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := EtcdMaintenance(tt.flags)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+
+			if (c.DefragSchedule != nil) != tt.wantSchedule {
+				t.Errorf("got schedule %v", c.DefragSchedule)
+			}
+		})
+	}
+}
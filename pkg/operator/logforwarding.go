@@ -0,0 +1,9 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+// LogForwardingAzureMonitorLogType is the Log-Type of the records which are
+// forwarded to Azure Monitor, i.e. they are stored in the AROClusterLogs_CL
+// table of the Log Analytics workspace
+const LogForwardingAzureMonitorLogType = "AROClusterLogs"
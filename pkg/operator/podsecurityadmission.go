@@ -0,0 +1,29 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+)
+
+const PodSecurityAdmissionLevelFlag = "aro.podsecurityadmission.level"
+
+// The Pod Security Admission levels which the enforcement of customer
+// namespaces may be tightened to.  The privileged level of the platform is
+// not one of them, as it would not tighten anything.
+const (
+	PodSecurityAdmissionLevelBaseline   = "baseline"
+	PodSecurityAdmissionLevelRestricted = "restricted"
+)
+
+// PodSecurityAdmissionLevel returns the Pod Security Admission level enforced
+// on customer namespaces, or an empty string if it is left to the platform
+func PodSecurityAdmissionLevel(flags map[string]string) (string, error) {
+	switch v := flags[PodSecurityAdmissionLevelFlag]; v {
+	case "", PodSecurityAdmissionLevelBaseline, PodSecurityAdmissionLevelRestricted:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid %s '%s': must be %s or %s", PodSecurityAdmissionLevelFlag, v, PodSecurityAdmissionLevelBaseline, PodSecurityAdmissionLevelRestricted)
+	}
+}
@@ -0,0 +1,46 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestPodSecurityAdmissionLevel(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		flags   map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "restricted",
+			flags: map[string]string{
+				PodSecurityAdmissionLevelFlag: "restricted",
+			},
+			want: PodSecurityAdmissionLevelRestricted,
+		},
+		{
+			name: "privileged",
+			flags: map[string]string{
+				PodSecurityAdmissionLevelFlag: "privileged",
+			},
+			wantErr: "invalid aro.podsecurityadmission.level 'privileged': must be baseline or restricted",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := PodSecurityAdmissionLevel(tt.flags)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if level != tt.want {
+				t.Error(level)
+			}
+		})
+	}
+}
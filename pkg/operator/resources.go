@@ -0,0 +1,76 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// resourceBounds are the values which the operator container resource
+// requests and limits may be set to.  Below the minimums the operator cannot
+// run reliably; the maximums stop a typo from making the operator pods
+// unschedulable.
+var resourceBounds = map[corev1.ResourceName][2]resource.Quantity{
+	corev1.ResourceCPU:    {resource.MustParse("10m"), resource.MustParse("4")},
+	corev1.ResourceMemory: {resource.MustParse("64Mi"), resource.MustParse("8Gi")},
+}
+
+var resourceNames = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// ResourcesFlag returns the name of the operator flag which sets the resource
+// request or limit of the operator container of the given role, e.g.
+// aro.operator.master.resources.limits.memory.
+func ResourcesFlag(role, kind string, name corev1.ResourceName) string {
+	return fmt.Sprintf("aro.operator.%s.resources.%s.%s", role, kind, name)
+}
+
+// Resources returns the resource requests and limits of the operator
+// container of the given role, as set by the operator flags.  Requests and
+// limits which are not set are left empty, which is the default.
+func Resources(flags map[string]string, role string) (corev1.ResourceRequirements, error) {
+	var requirements corev1.ResourceRequirements
+
+	for _, kind := range []string{"requests", "limits"} {
+		for _, name := range resourceNames {
+			flag := ResourcesFlag(role, kind, name)
+
+			v := flags[flag]
+			if v == "" {
+				continue
+			}
+
+			q, err := resource.ParseQuantity(v)
+			if err != nil {
+				return corev1.ResourceRequirements{}, fmt.Errorf("invalid %s '%s': %w", flag, v, err)
+			}
+
+			bounds := resourceBounds[name]
+			if q.Cmp(bounds[0]) < 0 || q.Cmp(bounds[1]) > 0 {
+				return corev1.ResourceRequirements{}, fmt.Errorf("invalid %s '%s': must be between %s and %s", flag, v, bounds[0].String(), bounds[1].String())
+			}
+
+			list := &requirements.Requests
+			if kind == "limits" {
+				list = &requirements.Limits
+			}
+			if *list == nil {
+				*list = corev1.ResourceList{}
+			}
+			(*list)[name] = q
+		}
+	}
+
+	for _, name := range resourceNames {
+		request, foundRequest := requirements.Requests[name]
+		limit, foundLimit := requirements.Limits[name]
+		if foundRequest && foundLimit && request.Cmp(limit) > 0 {
+			return corev1.ResourceRequirements{}, fmt.Errorf("invalid %s '%s': must not be less than %s '%s'", ResourcesFlag(role, "limits", name), limit.String(), ResourcesFlag(role, "requests", name), request.String())
+		}
+	}
+
+	return requirements, nil
+}
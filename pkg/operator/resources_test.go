@@ -0,0 +1,85 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/Azure/ARO-RP/pkg/util/cmp"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestResources(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		flags   map[string]string
+		want    corev1.ResourceRequirements
+		wantErr string
+	}{
+		{
+			name: "unset",
+			flags: map[string]string{
+				"aro.operator.worker.resources.limits.cpu": "1",
+			},
+		},
+		{
+			name: "requests and limits",
+			flags: map[string]string{
+				"aro.operator.master.resources.requests.cpu":    "100m",
+				"aro.operator.master.resources.requests.memory": "512Mi",
+				"aro.operator.master.resources.limits.memory":   "2Gi",
+			},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+		{
+			name: "invalid quantity",
+			flags: map[string]string{
+				"aro.operator.master.resources.requests.cpu": "lots",
+			},
+			wantErr: `invalid aro.operator.master.resources.requests.cpu 'lots': quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+		},
+		{
+			name: "below the minimum",
+			flags: map[string]string{
+				"aro.operator.master.resources.requests.cpu": "1m",
+			},
+			wantErr: `invalid aro.operator.master.resources.requests.cpu '1m': must be between 10m and 4`,
+		},
+		{
+			name: "above the maximum",
+			flags: map[string]string{
+				"aro.operator.master.resources.limits.memory": "16Gi",
+			},
+			wantErr: `invalid aro.operator.master.resources.limits.memory '16Gi': must be between 64Mi and 8Gi`,
+		},
+		{
+			name: "limit less than request",
+			flags: map[string]string{
+				"aro.operator.master.resources.requests.memory": "1Gi",
+				"aro.operator.master.resources.limits.memory":   "512Mi",
+			},
+			wantErr: `invalid aro.operator.master.resources.limits.memory '512Mi': must not be less than aro.operator.master.resources.requests.memory '1Gi'`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resources(tt.flags, RoleMaster)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
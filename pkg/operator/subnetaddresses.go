@@ -0,0 +1,40 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const SubnetAddressesThresholdFlag = "aro.subnetaddresses.threshold"
+
+// DefaultSubnetAddressesThreshold is the percentage of the addresses of a
+// cluster subnet in use above which the cluster is reported as running out of
+// addresses, unless set by the operator flag
+const DefaultSubnetAddressesThreshold = 80
+
+// The bounds of the threshold.  Below the minimum most clusters would be
+// reported; above the maximum there is too little left to act on.
+const (
+	minSubnetAddressesThreshold = 50
+	maxSubnetAddressesThreshold = 99
+)
+
+// SubnetAddressesThreshold returns the percentage of the addresses of a
+// cluster subnet in use above which the cluster is reported as running out of
+// addresses
+func SubnetAddressesThreshold(flags map[string]string) (int, error) {
+	v := flags[SubnetAddressesThresholdFlag]
+	if v == "" {
+		return DefaultSubnetAddressesThreshold, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < minSubnetAddressesThreshold || n > maxSubnetAddressesThreshold {
+		return 0, fmt.Errorf("invalid %s '%s': must be an integer percentage between %d and %d", SubnetAddressesThresholdFlag, v, minSubnetAddressesThreshold, maxSubnetAddressesThreshold)
+	}
+
+	return n, nil
+}
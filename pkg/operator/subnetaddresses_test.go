@@ -0,0 +1,54 @@
+package operator
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestSubnetAddressesThreshold(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		flags   map[string]string
+		want    int
+		wantErr string
+	}{
+		{
+			name: "unset",
+			want: DefaultSubnetAddressesThreshold,
+		},
+		{
+			name: "set",
+			flags: map[string]string{
+				SubnetAddressesThresholdFlag: "90",
+			},
+			want: 90,
+		},
+		{
+			name: "not an integer",
+			flags: map[string]string{
+				SubnetAddressesThresholdFlag: "90%",
+			},
+			wantErr: "invalid aro.subnetaddresses.threshold '90%': must be an integer percentage between 50 and 99",
+		},
+		{
+			name: "too low",
+			flags: map[string]string{
+				SubnetAddressesThresholdFlag: "10",
+			},
+			wantErr: "invalid aro.subnetaddresses.threshold '10': must be an integer percentage between 50 and 99",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold, err := SubnetAddressesThreshold(tt.flags)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if threshold != tt.want {
+				t.Error(threshold)
+			}
+		})
+	}
+}
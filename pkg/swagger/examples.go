@@ -168,12 +168,18 @@ func (g *generator) generateExamples(outputDir string, s *Swagger) error {
 						body = g.exampleOpenShiftClusterCredentialsResponse()
 					case "#/definitions/OpenShiftClusterAdminKubeconfig":
 						body = g.exampleOpenShiftClusterAdminKubeconfigResponse()
+					case "#/definitions/OpenShiftClusterValidationFindings":
+						body = g.exampleOpenShiftClusterValidationFindingsResponse()
+					case "#/definitions/OpenShiftClusterTemplate":
+						body = g.exampleOpenShiftClusterTemplateResponse()
 					case "#/definitions/OpenShiftClusterList":
 						body = g.exampleOpenShiftClusterListResponse()
 					case "#/definitions/OperationList":
 						body = g.exampleOperationListResponse()
 					case "#/definitions/OpenShiftVersionList":
 						body = g.exampleOpenShiftVersionListResponse()
+					case "#/definitions/OpenShiftVersionMatrix":
+						body = g.exampleOpenShiftVersionMatrixResponse()
 					}
 				}
 
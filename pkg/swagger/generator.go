@@ -25,36 +25,42 @@ const apiv20230701previewPath = "github.com/Azure/ARO-RP/pkg/api/v20230701previe
 const apiv20230904Path = "github.com/Azure/ARO-RP/pkg/api/v20230904"
 
 type generator struct {
-	exampleSyncSetPutParameter                     func() interface{}
-	exampleSyncSetPatchParameter                   func() interface{}
-	exampleSyncSetResponse                         func() interface{}
-	exampleSyncSetListResponse                     func() interface{}
-	exampleMachinePoolPutParameter                 func() interface{}
-	exampleMachinePoolPatchParameter               func() interface{}
-	exampleMachinePoolResponse                     func() interface{}
-	exampleMachinePoolListResponse                 func() interface{}
-	exampleSyncIdentityProviderPutParameter        func() interface{}
-	exampleSyncIdentityProviderPatchParameter      func() interface{}
-	exampleSyncIdentityProviderResponse            func() interface{}
-	exampleSyncIdentityProviderListResponse        func() interface{}
-	exampleSecretPutParameter                      func() interface{}
-	exampleSecretPatchParameter                    func() interface{}
-	exampleSecretResponse                          func() interface{}
-	exampleSecretListResponse                      func() interface{}
-	exampleOpenShiftClusterPutParameter            func() interface{}
-	exampleOpenShiftClusterPatchParameter          func() interface{}
-	exampleOpenShiftClusterResponse                func() interface{}
-	exampleOpenShiftClusterGetResponse             func() interface{}
-	exampleOpenShiftClusterPutOrPatchResponse      func() interface{}
-	exampleOpenShiftClusterCredentialsResponse     func() interface{}
-	exampleOpenShiftClusterAdminKubeconfigResponse func() interface{}
-	exampleOpenShiftClusterListResponse            func() interface{}
-	exampleOpenShiftVersionListResponse            func() interface{}
-	exampleOperationListResponse                   func() interface{}
+	exampleSyncSetPutParameter                        func() interface{}
+	exampleSyncSetPatchParameter                      func() interface{}
+	exampleSyncSetResponse                            func() interface{}
+	exampleSyncSetListResponse                        func() interface{}
+	exampleMachinePoolPutParameter                    func() interface{}
+	exampleMachinePoolPatchParameter                  func() interface{}
+	exampleMachinePoolResponse                        func() interface{}
+	exampleMachinePoolListResponse                    func() interface{}
+	exampleSyncIdentityProviderPutParameter           func() interface{}
+	exampleSyncIdentityProviderPatchParameter         func() interface{}
+	exampleSyncIdentityProviderResponse               func() interface{}
+	exampleSyncIdentityProviderListResponse           func() interface{}
+	exampleSecretPutParameter                         func() interface{}
+	exampleSecretPatchParameter                       func() interface{}
+	exampleSecretResponse                             func() interface{}
+	exampleSecretListResponse                         func() interface{}
+	exampleOpenShiftClusterPutParameter               func() interface{}
+	exampleOpenShiftClusterPatchParameter             func() interface{}
+	exampleOpenShiftClusterResponse                   func() interface{}
+	exampleOpenShiftClusterGetResponse                func() interface{}
+	exampleOpenShiftClusterPutOrPatchResponse         func() interface{}
+	exampleOpenShiftClusterCredentialsResponse        func() interface{}
+	exampleOpenShiftClusterAdminKubeconfigResponse    func() interface{}
+	exampleOpenShiftClusterValidationFindingsResponse func() interface{}
+	exampleOpenShiftClusterTemplateResponse           func() interface{}
+	exampleOpenShiftClusterListResponse               func() interface{}
+	exampleOpenShiftVersionListResponse               func() interface{}
+	exampleOpenShiftVersionMatrixResponse             func() interface{}
+	exampleOperationListResponse                      func() interface{}
 
 	systemData           bool
 	kubeConfig           bool
+	validate             bool
+	exportTemplate       bool
 	installVersionList   bool
+	versionMatrix        bool
 	clusterManager       bool
 	workerProfilesStatus bool
 	xmsEnum              []string
@@ -177,39 +183,45 @@ var apis = map[string]*generator{
 		kubeConfig:         true,
 	},
 	apiv20230701previewPath: {
-		exampleSyncSetPutParameter:                     v20230701preview.ExampleSyncSetPutParameter,
-		exampleSyncSetPatchParameter:                   v20230701preview.ExampleSyncSetPatchParameter,
-		exampleSyncSetResponse:                         v20230701preview.ExampleSyncSetResponse,
-		exampleSyncSetListResponse:                     v20230701preview.ExampleSyncSetListResponse,
-		exampleMachinePoolPutParameter:                 v20230701preview.ExampleMachinePoolPutParameter,
-		exampleMachinePoolPatchParameter:               v20230701preview.ExampleMachinePoolPatchParameter,
-		exampleMachinePoolResponse:                     v20230701preview.ExampleMachinePoolResponse,
-		exampleMachinePoolListResponse:                 v20230701preview.ExampleMachinePoolListResponse,
-		exampleSyncIdentityProviderPutParameter:        v20230701preview.ExampleSyncIdentityProviderPutParameter,
-		exampleSyncIdentityProviderPatchParameter:      v20230701preview.ExampleSyncIdentityProviderPatchParameter,
-		exampleSyncIdentityProviderResponse:            v20230701preview.ExampleSyncIdentityProviderResponse,
-		exampleSyncIdentityProviderListResponse:        v20230701preview.ExampleSyncIdentityProviderListResponse,
-		exampleSecretPutParameter:                      v20230701preview.ExampleSecretPutParameter,
-		exampleSecretPatchParameter:                    v20230701preview.ExampleSecretPatchParameter,
-		exampleSecretResponse:                          v20230701preview.ExampleSecretResponse,
-		exampleSecretListResponse:                      v20230701preview.ExampleSecretListResponse,
-		exampleOpenShiftClusterPutParameter:            v20230701preview.ExampleOpenShiftClusterPutParameter,
-		exampleOpenShiftClusterPatchParameter:          v20230701preview.ExampleOpenShiftClusterPatchParameter,
-		exampleOpenShiftClusterResponse:                v20230701preview.ExampleOpenShiftClusterResponse,
-		exampleOpenShiftClusterCredentialsResponse:     v20230701preview.ExampleOpenShiftClusterCredentialsResponse,
-		exampleOpenShiftClusterListResponse:            v20230701preview.ExampleOpenShiftClusterListResponse,
-		exampleOpenShiftClusterAdminKubeconfigResponse: v20230701preview.ExampleOpenShiftClusterAdminKubeconfigResponse,
-		exampleOpenShiftVersionListResponse:            v20230701preview.ExampleOpenShiftVersionListResponse,
-		exampleOperationListResponse:                   api.ExampleOperationListResponse,
+		exampleSyncSetPutParameter:                        v20230701preview.ExampleSyncSetPutParameter,
+		exampleSyncSetPatchParameter:                      v20230701preview.ExampleSyncSetPatchParameter,
+		exampleSyncSetResponse:                            v20230701preview.ExampleSyncSetResponse,
+		exampleSyncSetListResponse:                        v20230701preview.ExampleSyncSetListResponse,
+		exampleMachinePoolPutParameter:                    v20230701preview.ExampleMachinePoolPutParameter,
+		exampleMachinePoolPatchParameter:                  v20230701preview.ExampleMachinePoolPatchParameter,
+		exampleMachinePoolResponse:                        v20230701preview.ExampleMachinePoolResponse,
+		exampleMachinePoolListResponse:                    v20230701preview.ExampleMachinePoolListResponse,
+		exampleSyncIdentityProviderPutParameter:           v20230701preview.ExampleSyncIdentityProviderPutParameter,
+		exampleSyncIdentityProviderPatchParameter:         v20230701preview.ExampleSyncIdentityProviderPatchParameter,
+		exampleSyncIdentityProviderResponse:               v20230701preview.ExampleSyncIdentityProviderResponse,
+		exampleSyncIdentityProviderListResponse:           v20230701preview.ExampleSyncIdentityProviderListResponse,
+		exampleSecretPutParameter:                         v20230701preview.ExampleSecretPutParameter,
+		exampleSecretPatchParameter:                       v20230701preview.ExampleSecretPatchParameter,
+		exampleSecretResponse:                             v20230701preview.ExampleSecretResponse,
+		exampleSecretListResponse:                         v20230701preview.ExampleSecretListResponse,
+		exampleOpenShiftClusterPutParameter:               v20230701preview.ExampleOpenShiftClusterPutParameter,
+		exampleOpenShiftClusterPatchParameter:             v20230701preview.ExampleOpenShiftClusterPatchParameter,
+		exampleOpenShiftClusterResponse:                   v20230701preview.ExampleOpenShiftClusterResponse,
+		exampleOpenShiftClusterCredentialsResponse:        v20230701preview.ExampleOpenShiftClusterCredentialsResponse,
+		exampleOpenShiftClusterListResponse:               v20230701preview.ExampleOpenShiftClusterListResponse,
+		exampleOpenShiftClusterAdminKubeconfigResponse:    v20230701preview.ExampleOpenShiftClusterAdminKubeconfigResponse,
+		exampleOpenShiftClusterValidationFindingsResponse: v20230701preview.ExampleOpenShiftClusterValidationFindingsResponse,
+		exampleOpenShiftClusterTemplateResponse:           v20230701preview.ExampleOpenShiftClusterTemplateResponse,
+		exampleOpenShiftVersionListResponse:               v20230701preview.ExampleOpenShiftVersionListResponse,
+		exampleOpenShiftVersionMatrixResponse:             v20230701preview.ExampleOpenShiftVersionMatrixResponse,
+		exampleOperationListResponse:                      api.ExampleOperationListResponse,
 
-		xmsEnum:            []string{"EncryptionAtHost", "FipsValidatedModules", "SoftwareDefinedNetwork", "Visibility", "OutboundType"},
+		xmsEnum:            []string{"EncryptionAtHost", "FipsValidatedModules", "SoftwareDefinedNetwork", "Visibility", "OutboundType", "ValidationSeverity", "ValidationStatus", "OpenShiftVersionStatus", "SeccompProfile", "AcceleratedNetworking"},
 		xmsSecretList:      []string{"kubeconfig", "kubeadminPassword", "secretResources"},
 		xmsIdentifiers:     []string{},
 		commonTypesVersion: "v3",
 		systemData:         true,
 		clusterManager:     true,
 		installVersionList: true,
+		versionMatrix:      true,
 		kubeConfig:         true,
+		validate:           true,
+		exportTemplate:     true,
 	},
 	apiv20230904Path: {
 		exampleSyncSetPutParameter:                     v20230904.ExampleSyncSetPutParameter,
@@ -116,6 +116,32 @@ func Run(api, outputDir string) error {
 		}
 	}
 
+	if g.validate {
+		s.Paths["/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/validate"] = &PathItem{
+			Post: &Operation{
+				Tags:        []string{"OpenShiftClusters"},
+				Summary:     "Validates a proposed OpenShift cluster with the specified subscription, resource group and resource name.",
+				Description: "The operation runs the validations run when the cluster is created and returns all their findings.  Nothing is created.",
+				OperationID: "OpenShiftClusters_Validate",
+				Parameters:  g.populateParameters(4, "OpenShiftCluster", "OpenShift cluster"),
+				Responses:   g.populateResponses("OpenShiftClusterValidationFindings", false, http.StatusOK),
+			},
+		}
+	}
+
+	if g.exportTemplate {
+		s.Paths["/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/exportTemplate"] = &PathItem{
+			Post: &Operation{
+				Tags:        []string{"OpenShiftClusters"},
+				Summary:     "Exports the configuration of an OpenShift cluster with the specified subscription, resource group and resource name as a template.",
+				Description: "The operation returns the creatable configuration of the cluster, without its secrets and the identifiers of the resources it uses.  The template can be passed in the template property when creating a cluster.",
+				OperationID: "OpenShiftClusters_ExportTemplate",
+				Parameters:  g.populateParameters(3, "OpenShiftCluster", "OpenShift cluster"),
+				Responses:   g.populateResponses("OpenShiftClusterTemplate", false, http.StatusOK),
+			},
+		}
+	}
+
 	if g.installVersionList {
 		s.Paths["/subscriptions/{subscriptionId}/providers/Microsoft.RedHatOpenShift/locations/{location}/openshiftversions"] = &PathItem{
 			Get: &Operation{
@@ -132,6 +158,19 @@ func Run(api, outputDir string) error {
 		}
 	}
 
+	if g.versionMatrix {
+		s.Paths["/subscriptions/{subscriptionId}/providers/Microsoft.RedHatOpenShift/locations/{location}/openshiftversionmatrix"] = &PathItem{
+			Get: &Operation{
+				Tags:        []string{"OpenShiftVersionMatrix"},
+				Summary:     "Gets the OpenShift versions supported in the specified location.",
+				Description: "The operation returns the supported OpenShift versions, whether each is installable, upgrade-only or deprecated, and the versions clusters at each of them can be upgraded to.",
+				OperationID: "OpenShiftVersionMatrix_Get",
+				Parameters:  g.populateParameters(6, "OpenShiftVersionMatrix", "OpenShift Version Matrix"),
+				Responses:   g.populateResponses("OpenShiftVersionMatrix", false, http.StatusOK),
+			},
+		}
+	}
+
 	if g.clusterManager {
 		g.populateChildResourcePaths(s.Paths, "Microsoft.RedHatOpenShift", "openShiftCluster", "syncSet", "SyncSet")
 		g.populateChildResourcePaths(s.Paths, "Microsoft.RedHatOpenShift", "openShiftCluster", "machinePool", "MachinePool")
@@ -146,10 +185,22 @@ func Run(api, outputDir string) error {
 		names = append(names, "OpenShiftClusterAdminKubeconfig")
 	}
 
+	if g.validate {
+		names = append(names, "OpenShiftClusterValidationFindings")
+	}
+
+	if g.exportTemplate {
+		names = append(names, "OpenShiftClusterTemplate")
+	}
+
 	if g.installVersionList {
 		names = append(names, "OpenShiftVersionList")
 	}
 
+	if g.versionMatrix {
+		names = append(names, "OpenShiftVersionMatrix")
+	}
+
 	if g.clusterManager {
 		// This needs to be the top level struct
 		// in most cases, the "list" struct (a collection of resources)
@@ -150,6 +150,22 @@ func (tw *typeWalker) schemaFromType(t types.Type, deps map[*types.Named]struct{
 					properties.ReadOnly = true
 				}
 
+				if field.Name() == "WorkerProfilesScaleStatus" {
+					properties.ReadOnly = true
+				}
+
+				if field.Name() == "ClusterIdentities" {
+					properties.ReadOnly = true
+				}
+
+				if field.Name() == "WorkerMachineSetsProgress" {
+					properties.ReadOnly = true
+				}
+
+				if field.Name() == "EffectiveOutboundProfile" {
+					properties.ReadOnly = true
+				}
+
 				ns := NameSchema{
 					Name:   name,
 					Schema: properties,
@@ -0,0 +1,34 @@
+package compute
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// ProximityPlacementGroupsClient is a minimal interface for azure ProximityPlacementGroupsClient
+type ProximityPlacementGroupsClient interface {
+	Get(ctx context.Context, resourceGroupName string, proximityPlacementGroupName string, includeColocationStatus string) (result mgmtcompute.ProximityPlacementGroup, err error)
+}
+
+type proximityPlacementGroupsClient struct {
+	mgmtcompute.ProximityPlacementGroupsClient
+}
+
+var _ ProximityPlacementGroupsClient = &proximityPlacementGroupsClient{}
+
+// NewProximityPlacementGroupsClient creates a new ProximityPlacementGroupsClient
+func NewProximityPlacementGroupsClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) ProximityPlacementGroupsClient {
+	client := mgmtcompute.NewProximityPlacementGroupsClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &proximityPlacementGroupsClient{
+		ProximityPlacementGroupsClient: client,
+	}
+}
@@ -15,6 +15,7 @@ type VirtualMachinesClientAddons interface {
 	CreateOrUpdateAndWait(ctx context.Context, resourceGroupName string, VMName string, parameters mgmtcompute.VirtualMachine) error
 	DeleteAndWait(ctx context.Context, resourceGroupName string, VMName string, forceDeletion *bool) error
 	RedeployAndWait(ctx context.Context, resourceGroupName string, VMName string) error
+	RestartAndWait(ctx context.Context, resourceGroupName string, VMName string) error
 	StartAndWait(ctx context.Context, resourceGroupName string, VMName string) error
 	StopAndWait(ctx context.Context, resourceGroupName string, VMName string, deallocateVM bool) error
 	List(ctx context.Context, resourceGroupName string) (result []mgmtcompute.VirtualMachine, err error)
@@ -47,6 +48,15 @@ func (c *virtualMachinesClient) RedeployAndWait(ctx context.Context, resourceGro
 	return future.WaitForCompletionRef(ctx, c.Client)
 }
 
+func (c *virtualMachinesClient) RestartAndWait(ctx context.Context, resourceGroupName string, VMName string) error {
+	future, err := c.Restart(ctx, resourceGroupName, VMName)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.Client)
+}
+
 func (c *virtualMachinesClient) StartAndWait(ctx context.Context, resourceGroupName string, VMName string) error {
 	future, err := c.Start(ctx, resourceGroupName, VMName)
 	if err != nil {
@@ -4,6 +4,8 @@ package features
 // Licensed under the Apache License 2.0.
 
 import (
+	"context"
+
 	mgmtfeatures "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-07-01/features"
 	"github.com/Azure/go-autorest/autorest"
 
@@ -12,6 +14,7 @@ import (
 
 // ProvidersClient is a minimal interface for azure ProvidersClient
 type ProvidersClient interface {
+	Register(ctx context.Context, resourceProviderNamespace string) (mgmtfeatures.Provider, error)
 	ProvidersClientAddons
 }
 
@@ -17,6 +17,7 @@ import (
 type ResourceGroupsClient interface {
 	Get(ctx context.Context, resourceGroupName string) (result mgmtfeatures.ResourceGroup, err error)
 	CreateOrUpdate(ctx context.Context, resourceGroupName string, parameters mgmtfeatures.ResourceGroup) (result mgmtfeatures.ResourceGroup, err error)
+	Update(ctx context.Context, resourceGroupName string, parameters mgmtfeatures.ResourceGroupPatchable) (result mgmtfeatures.ResourceGroup, err error)
 	Delete(ctx context.Context, resourceGroupName string) (result mgmtfeatures.ResourceGroupsDeleteFuture, err error)
 	ResourceGroupsClientAddons
 }
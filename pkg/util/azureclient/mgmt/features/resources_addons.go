@@ -14,6 +14,7 @@ import (
 type ResourcesClientAddons interface {
 	Client() autorest.Client
 	ListByResourceGroup(ctx context.Context, resourceGroupName string, filter string, expand string, top *int32) ([]mgmtfeatures.GenericResourceExpanded, error)
+	UpdateByIDAndWait(ctx context.Context, resourceID string, APIVersion string, parameters mgmtfeatures.GenericResource) error
 }
 
 func (c *resourcesClient) Client() autorest.Client {
@@ -36,3 +37,12 @@ func (c *resourcesClient) ListByResourceGroup(ctx context.Context, resourceGroup
 
 	return resources, nil
 }
+
+func (c *resourcesClient) UpdateByIDAndWait(ctx context.Context, resourceID string, APIVersion string, parameters mgmtfeatures.GenericResource) error {
+	future, err := c.ResourcesClient.UpdateByID(ctx, resourceID, APIVersion, parameters)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.ResourcesClient.Client)
+}
@@ -0,0 +1,34 @@
+package network
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// NatGatewaysClient is a minimal interface for azure NatGatewaysClient
+type NatGatewaysClient interface {
+	Get(ctx context.Context, resourceGroupName string, natGatewayName string, expand string) (result mgmtnetwork.NatGateway, err error)
+}
+
+type natGatewaysClient struct {
+	mgmtnetwork.NatGatewaysClient
+}
+
+var _ NatGatewaysClient = &natGatewaysClient{}
+
+// NewNatGatewaysClient creates a new NatGatewaysClient
+func NewNatGatewaysClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) NatGatewaysClient {
+	client := mgmtnetwork.NewNatGatewaysClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &natGatewaysClient{
+		NatGatewaysClient: client,
+	}
+}
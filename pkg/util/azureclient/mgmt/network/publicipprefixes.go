@@ -0,0 +1,34 @@
+package network
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// PublicIPPrefixesClient is a minimal interface for azure PublicIPPrefixesClient
+type PublicIPPrefixesClient interface {
+	Get(ctx context.Context, resourceGroupName string, publicIPPrefixName string, expand string) (result mgmtnetwork.PublicIPPrefix, err error)
+}
+
+type publicIPPrefixesClient struct {
+	mgmtnetwork.PublicIPPrefixesClient
+}
+
+var _ PublicIPPrefixesClient = &publicIPPrefixesClient{}
+
+// NewPublicIPPrefixesClient creates a new PublicIPPrefixesClient
+func NewPublicIPPrefixesClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) PublicIPPrefixesClient {
+	client := mgmtnetwork.NewPublicIPPrefixesClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+
+	return &publicIPPrefixesClient{
+		PublicIPPrefixesClient: client,
+	}
+}
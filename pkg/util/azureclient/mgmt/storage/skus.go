@@ -0,0 +1,33 @@
+package storage
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/Azure/ARO-RP/pkg/util/azureclient"
+)
+
+// SkusClient is a minimal interface for azure SkusClient
+type SkusClient interface {
+	List(ctx context.Context) (result mgmtstorage.SkuListResult, err error)
+}
+
+type skusClient struct {
+	mgmtstorage.SkusClient
+}
+
+var _ SkusClient = &skusClient{}
+
+// NewSkusClient returns a new SkusClient
+func NewSkusClient(environment *azureclient.AROEnvironment, subscriptionID string, authorizer autorest.Authorizer) SkusClient {
+	client := mgmtstorage.NewSkusClientWithBaseURI(environment.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = authorizer
+	return &skusClient{
+		SkusClient: client,
+	}
+}
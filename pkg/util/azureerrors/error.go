@@ -5,7 +5,10 @@ package azureerrors
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -106,3 +109,17 @@ func ResourceGroupNotFound(err error) bool {
 	}
 	return false
 }
+
+// RetryAfter returns true if the error is, or wraps, a response which was
+// throttled by Azure, along with the delay requested by its Retry-After
+// header.  The delay is zero if the header is missing or malformed.
+func RetryAfter(err error) (time.Duration, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if detailedErr, ok := err.(autorest.DetailedError); ok &&
+			detailedErr.Response != nil &&
+			detailedErr.Response.StatusCode == http.StatusTooManyRequests {
+			return autorest.GetRetryAfter(detailedErr.Response, 0), true
+		}
+	}
+	return 0, false
+}
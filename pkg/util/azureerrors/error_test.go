@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -120,3 +121,69 @@ func TestIsDeploymentActiveError(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryAfter(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		err            error
+		wantRetryAfter time.Duration
+		want           bool
+	}{
+		{
+			name: "Another error",
+			err:  errors.New("something happened"),
+		},
+		{
+			name: "Not throttled",
+			err: autorest.DetailedError{
+				Response: &http.Response{
+					StatusCode: http.StatusNotFound,
+				},
+				PackageType: "network.SubnetsClient",
+				Method:      "Get",
+				StatusCode:  http.StatusNotFound,
+				Message:     "Failure responding to request",
+			},
+		},
+		{
+			name: "Throttled with Retry-After",
+			err: autorest.DetailedError{
+				Response: &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header: http.Header{
+						"Retry-After": []string{"17"},
+					},
+				},
+				PackageType: "network.SubnetsClient",
+				Method:      "Get",
+				StatusCode:  http.StatusTooManyRequests,
+				Message:     "Failure responding to request",
+			},
+			wantRetryAfter: 17 * time.Second,
+			want:           true,
+		},
+		{
+			name: "Throttled without Retry-After",
+			err: autorest.DetailedError{
+				Response: &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+				},
+				PackageType: "network.SubnetsClient",
+				Method:      "Get",
+				StatusCode:  http.StatusTooManyRequests,
+				Message:     "Failure responding to request",
+			},
+			want: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			retryAfter, got := RetryAfter(autorest.NewErrorWithError(tt.err, "", "", nil, ""))
+			if got != tt.want {
+				t.Error(got)
+			}
+			if retryAfter != tt.wantRetryAfter {
+				t.Error(retryAfter)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ type azRefreshableAuthorizer struct {
 	log              *logrus.Entry
 	azureEnvironment *azureclient.AROEnvironment
 	client           client.Client
+	rateLimiter      *RateLimiter
 
 	getTokenCredential func(*azureclient.AROEnvironment, *Credentials) (azcore.TokenCredential, error)
 }
@@ -48,6 +49,7 @@ func NewAzRefreshableAuthorizer(log *logrus.Entry, azEnv *azureclient.AROEnviron
 		log:                log,
 		azureEnvironment:   azEnv,
 		client:             client,
+		rateLimiter:        OperatorRateLimiter,
 		getTokenCredential: GetTokenCredential,
 	}, nil
 }
@@ -67,7 +69,7 @@ func (a *azRefreshableAuthorizer) NewRefreshableAuthorizerToken(ctx context.Cont
 
 	scopes := []string{a.azureEnvironment.ResourceManagerScope}
 
-	return azidext.NewTokenCredentialAdapter(tokenCredential, scopes), nil
+	return a.rateLimiter.Authorizer(azidext.NewTokenCredentialAdapter(tokenCredential, scopes)), nil
 }
 
 func GetTokenCredential(environment *azureclient.AROEnvironment, credentials *Credentials) (azcore.TokenCredential, error) {
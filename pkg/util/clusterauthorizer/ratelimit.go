@@ -0,0 +1,184 @@
+package clusterauthorizer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	"github.com/Azure/ARO-RP/pkg/util/azureerrors"
+)
+
+const (
+	rateLimitQPS   = "aro.azureclient.qps"
+	rateLimitBurst = "aro.azureclient.burst"
+
+	defaultRateLimitQPS   = 3
+	defaultRateLimitBurst = 10
+
+	// defaultRetryAfter is used when Azure throttles a call without saying
+	// for how long
+	defaultRetryAfter = 10 * time.Second
+)
+
+var throttledTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "aro_operator_azure_throttled_total",
+	Help: "Number of Azure API calls made by the ARO operator which were throttled.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(throttledTotal)
+}
+
+// OperatorRateLimiter is shared by all the operator controllers which call
+// Azure with the cluster service principal, so that their calls are smoothed
+// against the same budget.
+//
+// Only the Azure Resource Manager calls of the track1 clients built with
+// NewAzRefreshableAuthorizer are limited.  These are the only Azure calls the
+// operator makes besides the token requests to Azure AD of the service
+// principal checker and the cloud provider config controller, which are not
+// counted against the Resource Manager limits and are not limited.
+//
+// The limiter only learns that Azure throttled a call when the caller passes
+// the error to Observe, so every controller using NewAzRefreshableAuthorizer
+// must do so for the errors of its Azure calls.  A controller which doesn't is
+// still limited to the shared rate, but doesn't hold back the others when it
+// is throttled.
+var OperatorRateLimiter = NewRateLimiter(defaultRateLimitQPS, defaultRateLimitBurst)
+
+// RateLimiter is a token bucket limiting the rate of Azure API calls.  When
+// Azure throttles a call, all calls are held back until its Retry-After has
+// passed.
+type RateLimiter struct {
+	mu             sync.Mutex
+	limiter        *rate.Limiter
+	throttledUntil time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter returns a new RateLimiter allowing qps calls per second with
+// bursts of up to burst calls.
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(qps), burst),
+		now:     time.Now,
+	}
+}
+
+// Configure sets the limits from the aro.azureclient.qps and
+// aro.azureclient.burst operator flags, falling back to the defaults when
+// they are unset.
+func (l *RateLimiter) Configure(flags arov1alpha1.OperatorFlags) error {
+	qps := float64(defaultRateLimitQPS)
+	if v := flags.GetWithDefault(rateLimitQPS, ""); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 {
+			return fmt.Errorf("invalid %s %q: must be a positive number", rateLimitQPS, v)
+		}
+		qps = f
+	}
+
+	burst := defaultRateLimitBurst
+	if v := flags.GetWithDefault(rateLimitBurst, ""); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 1 {
+			return fmt.Errorf("invalid %s %q: must be an integer of at least 1", rateLimitBurst, v)
+		}
+		burst = i
+	}
+
+	if l.limiter.Limit() != rate.Limit(qps) {
+		l.limiter.SetLimit(rate.Limit(qps))
+	}
+	if l.limiter.Burst() != burst {
+		l.limiter.SetBurst(burst)
+	}
+
+	return nil
+}
+
+// Wait blocks until a call may be made, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	delay := l.throttledUntil.Sub(l.now())
+	l.mu.Unlock()
+
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return l.limiter.Wait(ctx)
+}
+
+// Observe inspects an error returned by an Azure call.  If the call was
+// throttled, it holds back all further calls until the Retry-After of the
+// response has passed and returns true.
+func (l *RateLimiter) Observe(err error) bool {
+	retryAfter, ok := azureerrors.RetryAfter(err)
+	if !ok {
+		return false
+	}
+	if retryAfter <= 0 {
+		retryAfter = defaultRetryAfter
+	}
+
+	throttledTotal.Inc()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(retryAfter); until.After(l.throttledUntil) {
+		l.throttledUntil = until
+	}
+
+	return true
+}
+
+// Authorizer returns an autorest.Authorizer which waits on the RateLimiter
+// before authorizing each request.  The retries of the autorest senders resend
+// the prepared request, so they are not limited again; they already back off
+// on the Retry-After of a throttled response.
+func (l *RateLimiter) Authorizer(authorizer autorest.Authorizer) autorest.Authorizer {
+	return &rateLimitedAuthorizer{
+		Authorizer: authorizer,
+		limiter:    l,
+	}
+}
+
+type rateLimitedAuthorizer struct {
+	autorest.Authorizer
+	limiter *RateLimiter
+}
+
+func (a *rateLimitedAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return a.Authorizer.WithAuthorization()(autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			return r, a.limiter.Wait(r.Context())
+		}))
+	}
+}
@@ -0,0 +1,184 @@
+package clusterauthorizer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"golang.org/x/time/rate"
+
+	arov1alpha1 "github.com/Azure/ARO-RP/pkg/operator/apis/aro.openshift.io/v1alpha1"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRateLimiterConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		flags     arov1alpha1.OperatorFlags
+		wantQPS   rate.Limit
+		wantBurst int
+		wantErr   string
+	}{
+		{
+			name:      "defaults",
+			flags:     arov1alpha1.OperatorFlags{},
+			wantQPS:   defaultRateLimitQPS,
+			wantBurst: defaultRateLimitBurst,
+		},
+		{
+			name: "configured",
+			flags: arov1alpha1.OperatorFlags{
+				rateLimitQPS:   "0.5",
+				rateLimitBurst: "4",
+			},
+			wantQPS:   0.5,
+			wantBurst: 4,
+		},
+		{
+			name: "invalid qps",
+			flags: arov1alpha1.OperatorFlags{
+				rateLimitQPS: "0",
+			},
+			wantQPS:   1,
+			wantBurst: 1,
+			wantErr:   `invalid aro.azureclient.qps "0": must be a positive number`,
+		},
+		{
+			name: "invalid burst",
+			flags: arov1alpha1.OperatorFlags{
+				rateLimitBurst: "lots",
+			},
+			wantQPS:   1,
+			wantBurst: 1,
+			wantErr:   `invalid aro.azureclient.burst "lots": must be an integer of at least 1`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewRateLimiter(1, 1)
+
+			err := l.Configure(tt.flags)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if l.limiter.Limit() != tt.wantQPS {
+				t.Error(l.limiter.Limit())
+			}
+			if l.limiter.Burst() != tt.wantBurst {
+				t.Error(l.limiter.Burst())
+			}
+		})
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	now := time.Now()
+
+	throttled := func(retryAfter string) error {
+		resp := &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{},
+		}
+		if retryAfter != "" {
+			resp.Header.Set(autorest.HeaderRetryAfter, retryAfter)
+		}
+		return autorest.NewErrorWithError(errors.New("throttled"), "network.SubnetsClient", "Get", resp, "Failure responding to request")
+	}
+
+	for _, tt := range []struct {
+		name               string
+		throttledUntil     time.Time
+		err                error
+		want               bool
+		wantThrottledUntil time.Time
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name: "other error",
+			err:  errors.New("something happened"),
+		},
+		{
+			name:               "throttled with Retry-After",
+			err:                throttled("30"),
+			want:               true,
+			wantThrottledUntil: now.Add(30 * time.Second),
+		},
+		{
+			name:               "throttled without Retry-After",
+			err:                throttled(""),
+			want:               true,
+			wantThrottledUntil: now.Add(defaultRetryAfter),
+		},
+		{
+			name:               "throttled for less than already held back",
+			throttledUntil:     now.Add(time.Minute),
+			err:                throttled("30"),
+			want:               true,
+			wantThrottledUntil: now.Add(time.Minute),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewRateLimiter(1, 1)
+			l.now = func() time.Time { return now }
+			l.throttledUntil = tt.throttledUntil
+
+			got := l.Observe(tt.err)
+			if got != tt.want {
+				t.Error(got)
+			}
+			if !l.throttledUntil.Equal(tt.wantThrottledUntil) {
+				t.Error(l.throttledUntil)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	t.Run("held back while throttled", func(t *testing.T) {
+		l := NewRateLimiter(math.MaxFloat64, 1)
+		l.throttledUntil = time.Now().Add(time.Minute)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := l.Wait(ctx)
+		if err != context.DeadlineExceeded {
+			t.Error(err)
+		}
+	})
+
+	t.Run("not held back once Retry-After has passed", func(t *testing.T) {
+		l := NewRateLimiter(math.MaxFloat64, 1)
+		l.throttledUntil = time.Now().Add(-time.Second)
+
+		err := l.Wait(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestRateLimitedAuthorizer(t *testing.T) {
+	l := NewRateLimiter(math.MaxFloat64, 1)
+	l.throttledUntil = time.Now().Add(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = autorest.Prepare(req, l.Authorizer(autorest.NullAuthorizer{}).WithAuthorization())
+	if err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+}
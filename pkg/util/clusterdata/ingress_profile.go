@@ -94,16 +94,49 @@ func (ip ingressProfileEnricher) Enrich(
 		ingressProfiles[i].Visibility = visibility
 	}
 
-	sort.Slice(ingressProfiles, func(i, j int) bool { return ingressProfiles[i].Name < ingressProfiles[j].Name })
-
 	oc.Lock.Lock()
 	defer oc.Lock.Unlock()
 
+	// The domain and serving certificate of additional ingress profiles
+	// provisioned by the RP can't be recovered from the cluster, so carry
+	// them over.  Keep such profiles even if their IngressController doesn't
+	// exist (yet).
+	for _, current := range oc.Properties.IngressProfiles {
+		var found bool
+		for i := range ingressProfiles {
+			if ingressProfiles[i].Name == current.Name {
+				ingressProfiles[i].Domain = current.Domain
+				ingressProfiles[i].ServingCertificate = current.ServingCertificate
+				found = true
+				break
+			}
+		}
+		if !found {
+			ingressProfiles = append(ingressProfiles, current)
+		}
+	}
+
+	sort.Slice(ingressProfiles, func(i, j int) bool { return ingressProfiles[i].Name < ingressProfiles[j].Name })
+
 	oc.Properties.IngressProfiles = ingressProfiles
 
 	return nil
 }
 
 func (ip ingressProfileEnricher) SetDefaults(oc *api.OpenShiftCluster) {
-	oc.Properties.IngressProfiles = nil
+	// drop everything which is read from the cluster, but keep the
+	// configuration of additional ingress profiles provisioned by the RP
+	var ingressProfiles []api.IngressProfile
+	for _, p := range oc.Properties.IngressProfiles {
+		if p.Domain != "" {
+			ingressProfiles = append(ingressProfiles, api.IngressProfile{
+				Name:               p.Name,
+				Visibility:         p.Visibility,
+				Domain:             p.Domain,
+				ServingCertificate: p.ServingCertificate,
+			})
+		}
+	}
+
+	oc.Properties.IngressProfiles = ingressProfiles
 }
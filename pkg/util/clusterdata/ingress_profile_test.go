@@ -29,6 +29,7 @@ func TestIngressProfilesEnricherTask(t *testing.T) {
 	owningIngressLabel := "ingresscontroller.operator.openshift.io/owning-ingresscontroller"
 	for _, tt := range []struct {
 		name        string
+		oc          *api.OpenShiftCluster
 		operatorcli operatorclient.Interface
 		kubecli     kubernetes.Interface
 		wantOc      *api.OpenShiftCluster
@@ -301,9 +302,102 @@ func TestIngressProfilesEnricherTask(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "additional ingress profile configuration kept",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					IngressProfiles: []api.IngressProfile{
+						{
+							Name:       "default",
+							Visibility: api.VisibilityPublic,
+							IP:         "x.x.x.x",
+						},
+						{
+							Name:               "internal",
+							Visibility:         api.VisibilityPrivate,
+							IP:                 "z.z.z.z",
+							Domain:             "internal.example.com",
+							ServingCertificate: "certificate",
+						},
+						{
+							Name:               "pending",
+							Visibility:         api.VisibilityPrivate,
+							Domain:             "pending.example.com",
+							ServingCertificate: "certificate",
+						},
+					},
+				},
+			},
+			operatorcli: operatorfake.NewSimpleClientset(
+				&operatorv1.IngressController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "default",
+						Namespace: oioNamespace,
+					},
+				},
+				&operatorv1.IngressController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "internal",
+						Namespace: oioNamespace,
+					},
+					Spec: operatorv1.IngressControllerSpec{
+						EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+							LoadBalancer: &operatorv1.LoadBalancerStrategy{
+								Scope: operatorv1.InternalLoadBalancer,
+							},
+						},
+					},
+				},
+			),
+			kubecli: fake.NewSimpleClientset(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "router-internal",
+					Namespace: oiNamespace,
+					Labels: map[string]string{
+						"app":              "router",
+						owningIngressLabel: "internal",
+					},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{
+								IP: "y.y.y.y",
+							},
+						},
+					},
+				},
+			}),
+			wantOc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					IngressProfiles: []api.IngressProfile{
+						{
+							Name:       "default",
+							Visibility: api.VisibilityPublic,
+						},
+						{
+							Name:               "internal",
+							Visibility:         api.VisibilityPrivate,
+							IP:                 "y.y.y.y",
+							Domain:             "internal.example.com",
+							ServingCertificate: "certificate",
+						},
+						{
+							Name:               "pending",
+							Visibility:         api.VisibilityPrivate,
+							Domain:             "pending.example.com",
+							ServingCertificate: "certificate",
+						},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			oc := &api.OpenShiftCluster{}
+			oc := tt.oc
+			if oc == nil {
+				oc = &api.OpenShiftCluster{}
+			}
 			e := ingressProfileEnricher{}
 			e.SetDefaults(oc)
 
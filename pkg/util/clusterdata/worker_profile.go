@@ -78,6 +78,7 @@ func (ce machineClientEnricher) Enrich(
 
 		workerProfiles[i].VMSize = api.VMSize(machineProviderSpec.VMSize)
 		workerProfiles[i].DiskSizeGB = int(machineProviderSpec.OSDisk.DiskSizeGB)
+		workerProfiles[i].DiskStorageAccountType = api.DiskStorageAccountType(machineProviderSpec.OSDisk.ManagedDisk.StorageAccountType)
 		workerProfiles[i].SubnetID = fmt.Sprintf(
 			"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s",
 			r.SubscriptionID, machineProviderSpec.NetworkResourceGroup, machineProviderSpec.Vnet, machineProviderSpec.Subnet,
@@ -92,6 +93,13 @@ func (ce machineClientEnricher) Enrich(
 
 		workerProfiles[i].EncryptionAtHost = encryptionAtHost
 
+		acceleratedNetworking := api.AcceleratedNetworkingDisabled
+		if machineProviderSpec.AcceleratedNetworking {
+			acceleratedNetworking = api.AcceleratedNetworkingEnabled
+		}
+
+		workerProfiles[i].AcceleratedNetworking = acceleratedNetworking
+
 		if machineProviderSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			workerProfiles[i].DiskEncryptionSetID = machineProviderSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID
 		}
@@ -156,9 +156,13 @@ func validProvSpec() machinev1beta1.ProviderSpec {
     "apiVersion": "machine.openshift.io/v1beta1",
     "kind": "AzureMachineProviderSpec",
     "osDisk": {
-        "diskSizeGB": 512
+        "diskSizeGB": 512,
+        "managedDisk": {
+            "storageAccountType": "Premium_LRS"
+        }
     },
     "vmSize": "Standard_D4s_v3",
+    "acceleratedNetworking": true,
     "networkResourceGroup": "%s",
     "vnet": "%s",
     "subnet": "%s"
@@ -206,20 +210,24 @@ func validWorkerProfile() []api.WorkerProfile {
 
 	return []api.WorkerProfile{
 		{
-			Name:             "fake-worker-profile-1",
-			VMSize:           api.VMSizeStandardD4sV3,
-			DiskSizeGB:       512,
-			EncryptionAtHost: api.EncryptionAtHostDisabled,
-			SubnetID:         workerSubnetID,
-			Count:            1,
+			Name:                   "fake-worker-profile-1",
+			VMSize:                 api.VMSizeStandardD4sV3,
+			DiskSizeGB:             512,
+			EncryptionAtHost:       api.EncryptionAtHostDisabled,
+			SubnetID:               workerSubnetID,
+			Count:                  1,
+			DiskStorageAccountType: api.DiskStorageAccountTypePremiumLRS,
+			AcceleratedNetworking:  api.AcceleratedNetworkingEnabled,
 		},
 		{
-			Name:             "fake-worker-profile-2",
-			VMSize:           api.VMSizeStandardD4sV3,
-			DiskSizeGB:       512,
-			EncryptionAtHost: api.EncryptionAtHostDisabled,
-			SubnetID:         workerSubnetID,
-			Count:            1,
+			Name:                   "fake-worker-profile-2",
+			VMSize:                 api.VMSizeStandardD4sV3,
+			DiskSizeGB:             512,
+			EncryptionAtHost:       api.EncryptionAtHostDisabled,
+			SubnetID:               workerSubnetID,
+			Count:                  1,
+			DiskStorageAccountType: api.DiskStorageAccountTypePremiumLRS,
+			AcceleratedNetworking:  api.AcceleratedNetworkingEnabled,
 		},
 	}
 }
@@ -13,6 +13,8 @@ const (
 	standardDisk          = "StandardSSD_LRS"
 	premiumDisk           = "Premium_LRS"
 	premiumDiskCapability = "PremiumIO"
+
+	acceleratedNetworkingCapability = "AcceleratedNetworkingEnabled"
 )
 
 // Zones returns zone information for the resource SKU
@@ -91,3 +93,9 @@ func SupportedOSDisk(vmSku *mgmtcompute.ResourceSku) string {
 	}
 	return standardDisk
 }
+
+// SupportsAcceleratedNetworking returns true if VMs of the given resource SKU
+// can use accelerated networking
+func SupportsAcceleratedNetworking(vmSku *mgmtcompute.ResourceSku) bool {
+	return HasCapability(vmSku, acceleratedNetworkingCapability)
+}
@@ -294,3 +294,37 @@ func TestSupportedOSDisk(t *testing.T) {
 		})
 	}
 }
+
+func TestSupportsAcceleratedNetworking(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		capability string
+		want       bool
+	}{
+		{
+			name:       "Accelerated networking supported on VMSize",
+			capability: "True",
+			want:       true,
+		},
+		{
+			name:       "Accelerated networking not supported on VMSize",
+			capability: "False",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceSku := &mgmtcompute.ResourceSku{
+				Capabilities: &[]mgmtcompute.ResourceSkuCapabilities{
+					{
+						Name:  to.StringPtr(acceleratedNetworkingCapability),
+						Value: &tt.capability,
+					},
+				},
+			}
+
+			result := SupportsAcceleratedNetworking(resourceSku)
+			if result != tt.want {
+				t.Errorf("got %v but want %v", result, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/api"
@@ -20,35 +21,67 @@ import (
 
 const (
 	resourceID = "resourceId"
+
+	// defaultTTL is the TTL in seconds of the cluster DNS records if the
+	// customer did not choose one
+	defaultTTL = 300
 )
 
 type Manager interface {
 	Create(context.Context, *api.OpenShiftCluster) error
 	Update(context.Context, *api.OpenShiftCluster, string) error
 	CreateOrUpdateRouter(context.Context, *api.OpenShiftCluster, string) error
+	CreateOrUpdateAdditionalRouters(context.Context, *api.OpenShiftCluster) error
 	Delete(context.Context, *api.OpenShiftCluster) error
 }
 
 type manager struct {
 	env        env.Interface
 	recordsets dns.RecordSetsClient
+
+	// zone is the customer's DNS zone in which the records are created.  If
+	// it is nil, the records of managed domains are created in the RP zone.
+	zone *azure.Resource
+
+	// concurrency is the number of records which are created, updated or
+	// deleted in parallel
+	concurrency int
 }
 
 func NewManager(env env.Interface, localFPAuthorizer autorest.Authorizer) Manager {
 	return &manager{
 		env: env,
 
-		recordsets: dns.NewRecordSetsClient(env.Environment(), env.SubscriptionID(), localFPAuthorizer),
+		recordsets:  dns.NewRecordSetsClient(env.Environment(), env.SubscriptionID(), localFPAuthorizer),
+		concurrency: recordConcurrency(),
+	}
+}
+
+// NewZoneManager returns a Manager which creates the records in the
+// customer's DNS zone zoneID, which may be in another subscription than the
+// cluster, using authorizer
+func NewZoneManager(env env.Interface, zoneID string, authorizer autorest.Authorizer) (Manager, error) {
+	zone, err := azure.ParseResourceID(zoneID)
+	if err != nil {
+		return nil, err
 	}
+
+	return &manager{
+		env: env,
+
+		recordsets:  dns.NewRecordSetsClient(env.Environment(), zone.SubscriptionID, authorizer),
+		zone:        &zone,
+		concurrency: recordConcurrency(),
+	}, nil
 }
 
 func (m *manager) Create(ctx context.Context, oc *api.OpenShiftCluster) error {
-	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
-	if err != nil || prefix == "" {
+	apiName, _, err := m.recordNames(oc.Properties.ClusterProfile.Domain)
+	if err != nil || apiName == "" {
 		return err
 	}
 
-	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A)
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), apiName, mgmtdns.A)
 	if err == nil {
 		if rs.Metadata[resourceID] == nil || *rs.Metadata[resourceID] != oc.ID {
 			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeDuplicateDomain, "", "The provided domain '%s' is already in use by a cluster.", oc.Properties.ClusterProfile.Domain)
@@ -69,38 +102,40 @@ func (m *manager) Create(ctx context.Context, oc *api.OpenShiftCluster) error {
 }
 
 func (m *manager) Update(ctx context.Context, oc *api.OpenShiftCluster, ip string) error {
-	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
-	if err != nil || prefix == "" {
+	apiName, _, err := m.recordNames(oc.Properties.ClusterProfile.Domain)
+	if err != nil || apiName == "" {
 		return err
 	}
 
-	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A)
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), apiName, mgmtdns.A)
 	if err != nil {
 		return err
 	}
 
 	if rs.Metadata[resourceID] == nil || *rs.Metadata[resourceID] != oc.ID {
-		return fmt.Errorf("recordset %q already registered", "api."+prefix)
+		return fmt.Errorf("recordset %q already registered", apiName)
 	}
 
 	return m.createOrUpdate(ctx, oc, ip, *rs.Etag, "")
 }
 
 func (m *manager) CreateOrUpdateRouter(ctx context.Context, oc *api.OpenShiftCluster, routerIP string) error {
-	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
-	if err != nil || prefix == "" {
+	_, appsName, err := m.recordNames(oc.Properties.ClusterProfile.Domain)
+	if err != nil || appsName == "" {
 		return err
 	}
 
 	var isCreate bool
-	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "*.apps."+prefix, mgmtdns.A)
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), appsName, mgmtdns.A)
 	if detailedErr, ok := err.(autorest.DetailedError); ok &&
 		detailedErr.StatusCode == http.StatusNotFound {
 		isCreate = true
 	}
 
-	// If record exists and routerIP already match - skip CreateOrUpdate
-	if err == nil && !isCreate {
+	ttl := recordTTL(oc)
+
+	// If record exists and routerIP and TTL already match - skip CreateOrUpdate
+	if err == nil && !isCreate && rs.TTL != nil && *rs.TTL == ttl && rs.ARecords != nil {
 		for _, a := range *rs.ARecords {
 			if *a.Ipv4Address == routerIP {
 				return nil
@@ -108,9 +143,9 @@ func (m *manager) CreateOrUpdateRouter(ctx context.Context, oc *api.OpenShiftClu
 		}
 	}
 
-	_, err = m.recordsets.CreateOrUpdate(ctx, m.env.ResourceGroup(), m.env.Domain(), "*.apps."+prefix, mgmtdns.A, mgmtdns.RecordSet{
+	_, err = m.recordsets.CreateOrUpdate(ctx, m.resourceGroup(), m.zoneName(), appsName, mgmtdns.A, mgmtdns.RecordSet{
 		RecordSetProperties: &mgmtdns.RecordSetProperties{
-			TTL: to.Int64Ptr(300),
+			TTL: &ttl,
 			ARecords: &[]mgmtdns.ARecord{
 				{
 					Ipv4Address: &routerIP,
@@ -123,12 +158,19 @@ func (m *manager) CreateOrUpdateRouter(ctx context.Context, oc *api.OpenShiftClu
 }
 
 func (m *manager) Delete(ctx context.Context, oc *api.OpenShiftCluster) error {
-	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
-	if err != nil || prefix == "" {
+	apiName, appsName, err := m.recordNames(oc.Properties.ClusterProfile.Domain)
+	if err != nil || apiName == "" {
 		return err
 	}
 
-	rs, err := m.recordsets.Get(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A)
+	err = m.forEachRecord(ctx, m.additionalRouterRecords(oc), func(ctx context.Context, r record) error {
+		return m.deleteOwnedRecord(ctx, oc, r)
+	})
+	if err != nil {
+		return err
+	}
+
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), apiName, mgmtdns.A)
 	if detailedErr, ok := err.(autorest.DetailedError); ok &&
 		detailedErr.StatusCode == http.StatusNotFound {
 		return nil
@@ -141,19 +183,19 @@ func (m *manager) Delete(ctx context.Context, oc *api.OpenShiftCluster) error {
 		return nil
 	}
 
-	_, err = m.recordsets.Delete(ctx, m.env.ResourceGroup(), m.env.Domain(), "*.apps."+prefix, mgmtdns.A, "")
+	_, err = m.recordsets.Delete(ctx, m.resourceGroup(), m.zoneName(), appsName, mgmtdns.A, "")
 	if err != nil {
 		return err
 	}
 
-	_, err = m.recordsets.Delete(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A, *rs.Etag)
+	_, err = m.recordsets.Delete(ctx, m.resourceGroup(), m.zoneName(), apiName, mgmtdns.A, *rs.Etag)
 
 	return err
 }
 
 func (m *manager) createOrUpdate(ctx context.Context, oc *api.OpenShiftCluster, ip, ifMatch, ifNoneMatch string) error {
-	prefix, err := m.managedDomainPrefix(oc.Properties.ClusterProfile.Domain)
-	if err != nil || prefix == "" {
+	apiName, _, err := m.recordNames(oc.Properties.ClusterProfile.Domain)
+	if err != nil || apiName == "" {
 		return err
 	}
 
@@ -162,7 +204,7 @@ func (m *manager) createOrUpdate(ctx context.Context, oc *api.OpenShiftCluster,
 			Metadata: map[string]*string{
 				resourceID: &oc.ID,
 			},
-			TTL: to.Int64Ptr(300),
+			TTL: to.Int64Ptr(recordTTL(oc)),
 		},
 	}
 
@@ -174,11 +216,72 @@ func (m *manager) createOrUpdate(ctx context.Context, oc *api.OpenShiftCluster,
 		}
 	}
 
-	_, err = m.recordsets.CreateOrUpdate(ctx, m.env.ResourceGroup(), m.env.Domain(), "api."+prefix, mgmtdns.A, rs, ifMatch, ifNoneMatch)
+	_, err = m.recordsets.CreateOrUpdate(ctx, m.resourceGroup(), m.zoneName(), apiName, mgmtdns.A, rs, ifMatch, ifNoneMatch)
 
 	return err
 }
 
+// recordTTL returns the TTL of the cluster DNS records
+func recordTTL(oc *api.OpenShiftCluster) int64 {
+	if oc.Properties.ClusterProfile.DNSRecordTTL != 0 {
+		return int64(oc.Properties.ClusterProfile.DNSRecordTTL)
+	}
+
+	return defaultTTL
+}
+
+func (m *manager) resourceGroup() string {
+	if m.zone != nil {
+		return m.zone.ResourceGroup
+	}
+	return m.env.ResourceGroup()
+}
+
+func (m *manager) zoneName() string {
+	if m.zone != nil {
+		return m.zone.ResourceName
+	}
+	return m.env.Domain()
+}
+
+// recordNames returns the names, relative to the zone, of the API and ingress
+// records of the cluster domain.  If we don't create the records, it returns
+// empty strings.
+func (m *manager) recordNames(clusterDomain string) (string, string, error) {
+	if m.zone == nil {
+		prefix, err := m.managedDomainPrefix(clusterDomain)
+		if err != nil || prefix == "" {
+			return "", "", err
+		}
+		return "api." + prefix, "*.apps." + prefix, nil
+	}
+
+	// belt and braces: validation should already ensure this
+	prefix, ok := m.zoneRelativeName(clusterDomain)
+	if !ok {
+		return "", "", fmt.Errorf("domain %q is not in DNS zone %q", clusterDomain, m.zone.ResourceName)
+	}
+
+	if prefix == "" {
+		return "api", "*.apps", nil
+	}
+	return "api." + prefix, "*.apps." + prefix, nil
+}
+
+// zoneRelativeName returns the name of domain relative to the customer's DNS
+// zone, which is empty at the zone apex, and whether the domain is in the
+// zone
+func (m *manager) zoneRelativeName(domain string) (string, bool) {
+	if strings.EqualFold(domain, m.zone.ResourceName) {
+		return "", true
+	}
+	if !strings.HasSuffix(strings.ToLower(domain), "."+strings.ToLower(m.zone.ResourceName)) {
+		return "", false
+	}
+
+	return domain[:len(domain)-len(m.zone.ResourceName)-1], true
+}
+
 func (m *manager) managedDomainPrefix(clusterDomain string) (string, error) {
 	managedDomain, err := ManagedDomain(m.env, clusterDomain)
 	if err != nil || managedDomain == "" {
@@ -206,3 +309,19 @@ func ManagedDomain(env env.Interface, domain string) (string, error) {
 	}
 	return domain + "." + env.Domain(), nil
 }
+
+// ClusterDomainKey returns the fully qualified, lower case domain of a
+// cluster, by which clusters with the same or overlapping domains are found
+// in the database
+func ClusterDomainKey(env env.Interface, domain string) (string, error) {
+	managedDomain, err := ManagedDomain(env, domain)
+	if err != nil {
+		return "", err
+	}
+
+	if managedDomain != "" {
+		domain = managedDomain
+	}
+
+	return strings.ToLower(domain), nil
+}
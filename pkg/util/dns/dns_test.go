@@ -11,6 +11,7 @@ import (
 
 	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 
@@ -69,6 +70,35 @@ func TestCreate(t *testing.T) {
 					Return(mgmtdns.RecordSet{}, nil)
 			},
 		},
+		{
+			name: "managed, new record with custom TTL",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain:       "domain",
+						DNSRecordTTL: 30,
+					},
+				},
+			},
+			mocks: func(tt *test, recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					})
+
+				recordsets.EXPECT().
+					CreateOrUpdate(ctx, "rpResourcegroup", "domain", "api.domain", mgmtdns.A, mgmtdns.RecordSet{
+						RecordSetProperties: &mgmtdns.RecordSetProperties{
+							Metadata: map[string]*string{
+								resourceID: to.StringPtr(tt.oc.ID),
+							},
+							TTL: to.Int64Ptr(30),
+						},
+					}, "", "*").
+					Return(mgmtdns.RecordSet{}, nil)
+			},
+		},
 		{
 			name: "managed, our record already exists",
 			oc:   managedOc,
@@ -347,6 +377,45 @@ func TestCreateOrUpdateRouter(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name:     "managed, update TTL missmatch",
+			routerIP: "1.2.3.4",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ClusterProfile: api.ClusterProfile{
+						Domain:       "domain",
+						DNSRecordTTL: 30,
+					},
+				},
+			},
+			mocks: func(tt *test, recordsets *mock_dns.MockRecordSetsClient) {
+				recordsets.EXPECT().
+					Get(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A).
+					Return(mgmtdns.RecordSet{
+						RecordSetProperties: &mgmtdns.RecordSetProperties{
+							TTL: to.Int64Ptr(300),
+							ARecords: &[]mgmtdns.ARecord{
+								{
+									Ipv4Address: to.StringPtr(tt.routerIP),
+								},
+							},
+						},
+					}, nil)
+
+				recordsets.EXPECT().
+					CreateOrUpdate(ctx, "rpResourcegroup", "domain", "*.apps.domain", mgmtdns.A, mgmtdns.RecordSet{
+						RecordSetProperties: &mgmtdns.RecordSetProperties{
+							TTL: to.Int64Ptr(30),
+							ARecords: &[]mgmtdns.ARecord{
+								{
+									Ipv4Address: to.StringPtr(tt.routerIP),
+								},
+							},
+						},
+					}, "", "").
+					Return(mgmtdns.RecordSet{}, nil)
+			},
+		},
 		{
 			name:     "managed, update missmatch",
 			routerIP: "2.2.3.4",
@@ -579,6 +648,45 @@ func TestManagedDomain(t *testing.T) {
 	}
 }
 
+func TestClusterDomainKey(t *testing.T) {
+	for _, tt := range []struct {
+		domain  string
+		want    string
+		wantErr string
+	}{
+		{
+			domain: "foo",
+			want:   "foo.eastus.aroapp.io",
+		},
+		{
+			domain: "Foo.eastus.aroapp.io",
+			want:   "foo.eastus.aroapp.io",
+		},
+		{
+			domain: "Cluster.Example.com",
+			want:   "cluster.example.com",
+		},
+		{
+			domain:  "foo.",
+			wantErr: `invalid domain "foo."`,
+		},
+	} {
+		t.Run(tt.domain, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().Domain().AnyTimes().Return("eastus.aroapp.io")
+
+			got, err := ClusterDomainKey(env, tt.domain)
+			if got != tt.want {
+				t.Error(got)
+			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestManagedDomainPrefix(t *testing.T) {
 	for _, tt := range []struct {
 		domain  string
@@ -630,3 +738,64 @@ func TestManagedDomainPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordNames(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		zone     *azure.Resource
+		domain   string
+		wantAPI  string
+		wantApps string
+		wantErr  string
+	}{
+		{
+			name:     "managed domain",
+			domain:   "foo.domain",
+			wantAPI:  "api.foo",
+			wantApps: "*.apps.foo",
+		},
+		{
+			name:   "custom domain",
+			domain: "cluster.example.com",
+		},
+		{
+			name:     "customer zone",
+			zone:     &azure.Resource{ResourceGroup: "customerResourcegroup", ResourceName: "example.com"},
+			domain:   "cluster.example.com",
+			wantAPI:  "api.cluster",
+			wantApps: "*.apps.cluster",
+		},
+		{
+			name:     "customer zone apex",
+			zone:     &azure.Resource{ResourceGroup: "customerResourcegroup", ResourceName: "example.com"},
+			domain:   "Example.com",
+			wantAPI:  "api",
+			wantApps: "*.apps",
+		},
+		{
+			name:    "domain outside customer zone",
+			zone:    &azure.Resource{ResourceGroup: "customerResourcegroup", ResourceName: "example.com"},
+			domain:  "cluster.ample.com",
+			wantErr: `domain "cluster.ample.com" is not in DNS zone "example.com"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			env := mock_env.NewMockInterface(controller)
+			env.EXPECT().Domain().AnyTimes().Return("domain")
+
+			m := &manager{
+				env:  env,
+				zone: tt.zone,
+			}
+
+			gotAPI, gotApps, err := m.recordNames(tt.domain)
+			if gotAPI != tt.wantAPI || gotApps != tt.wantApps {
+				t.Error(gotAPI, gotApps)
+			}
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
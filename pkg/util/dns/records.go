@@ -0,0 +1,184 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+const (
+	// concurrencyEnvVar, set from the dnsRecordConcurrency RP configuration,
+	// overrides the number of DNS records which are created, updated or
+	// deleted in parallel
+	concurrencyEnvVar = "DNS_RECORD_CONCURRENCY"
+
+	defaultConcurrency = 5
+)
+
+// record is an A record of the cluster, relative to the zone
+type record struct {
+	name string
+	ip   string
+}
+
+// recordConcurrency returns the number of DNS records which are created,
+// updated or deleted in parallel
+func recordConcurrency() int {
+	if concurrency, err := strconv.Atoi(os.Getenv(concurrencyEnvVar)); err == nil && concurrency > 0 {
+		return concurrency
+	}
+	return defaultConcurrency
+}
+
+// CreateOrUpdateAdditionalRouters creates or updates the wildcard records of
+// the additional ingress profiles whose domain is in the customer's DNS zone,
+// in parallel.  The records of ingress profiles whose router IP isn't known
+// yet are skipped.  Errors are aggregated: the records which could be created
+// are, and a retry creates the remaining ones.
+func (m *manager) CreateOrUpdateAdditionalRouters(ctx context.Context, oc *api.OpenShiftCluster) error {
+	return m.forEachRecord(ctx, m.additionalRouterRecords(oc), func(ctx context.Context, r record) error {
+		if r.ip == "" {
+			return nil
+		}
+		return m.createOrUpdateOwnedRecord(ctx, oc, r)
+	})
+}
+
+// additionalRouterRecords returns the wildcard records of the additional
+// ingress profiles whose domain is in the customer's DNS zone.  The customer
+// manages the records of domains outside the zone.
+func (m *manager) additionalRouterRecords(oc *api.OpenShiftCluster) []record {
+	if m.zone == nil {
+		return nil
+	}
+
+	var records []record
+	for _, p := range oc.Properties.IngressProfiles {
+		if p.Name == "default" || p.Domain == "" {
+			continue
+		}
+
+		name, ok := m.zoneRelativeName(p.Domain)
+		if !ok {
+			continue
+		}
+
+		if name == "" {
+			name = "*"
+		} else {
+			name = "*." + name
+		}
+
+		records = append(records, record{name: name, ip: p.IP})
+	}
+
+	return records
+}
+
+// createOrUpdateOwnedRecord creates or updates the record, which is marked as
+// belonging to the cluster.  A record which belongs to another resource is
+// left alone.
+func (m *manager) createOrUpdateOwnedRecord(ctx context.Context, oc *api.OpenShiftCluster, r record) error {
+	ttl := recordTTL(oc)
+
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), r.name, mgmtdns.A)
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		err = nil
+	} else if err == nil {
+		if rs.Metadata[resourceID] == nil || *rs.Metadata[resourceID] != oc.ID {
+			return fmt.Errorf("recordset %q already registered", r.name)
+		}
+
+		if rs.TTL != nil && *rs.TTL == ttl && rs.ARecords != nil &&
+			len(*rs.ARecords) == 1 && *(*rs.ARecords)[0].Ipv4Address == r.ip {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = m.recordsets.CreateOrUpdate(ctx, m.resourceGroup(), m.zoneName(), r.name, mgmtdns.A, mgmtdns.RecordSet{
+		RecordSetProperties: &mgmtdns.RecordSetProperties{
+			Metadata: map[string]*string{
+				resourceID: &oc.ID,
+			},
+			TTL: to.Int64Ptr(ttl),
+			ARecords: &[]mgmtdns.ARecord{
+				{
+					Ipv4Address: to.StringPtr(r.ip),
+				},
+			},
+		},
+	}, "", "")
+
+	return err
+}
+
+// deleteOwnedRecord deletes the record if it belongs to the cluster
+func (m *manager) deleteOwnedRecord(ctx context.Context, oc *api.OpenShiftCluster, r record) error {
+	rs, err := m.recordsets.Get(ctx, m.resourceGroup(), m.zoneName(), r.name, mgmtdns.A)
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if rs.Metadata[resourceID] == nil || *rs.Metadata[resourceID] != oc.ID {
+		return nil
+	}
+
+	_, err = m.recordsets.Delete(ctx, m.resourceGroup(), m.zoneName(), r.name, mgmtdns.A, *rs.Etag)
+
+	return err
+}
+
+// forEachRecord runs f for each record, at most m.concurrency at a time, and
+// returns the aggregate of their errors
+func (m *manager) forEachRecord(ctx context.Context, records []record, f func(context.Context, record) error) error {
+	concurrency := m.concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(records))
+
+	var wg sync.WaitGroup
+	for i := range records {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := f(ctx, records[i])
+			if err != nil {
+				errs[i] = fmt.Errorf("recordset %q: %w", records[i].name, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,237 @@
+package dns
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	mgmtdns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// fakeRecordSets is an in-memory RecordSetsClient.  CreateOrUpdate of the
+// record sets in fail fails once.
+type fakeRecordSets struct {
+	mu         sync.Mutex
+	recordSets map[string]mgmtdns.RecordSet
+	fail       map[string]bool
+	calls      int
+	inFlight   int
+	maxFlight  int
+}
+
+func (f *fakeRecordSets) CreateOrUpdate(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType, parameters mgmtdns.RecordSet, ifMatch string, ifNoneMatch string) (mgmtdns.RecordSet, error) {
+	f.mu.Lock()
+	f.calls++
+	f.inFlight++
+	if f.inFlight > f.maxFlight {
+		f.maxFlight = f.inFlight
+	}
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.fail[relativeRecordSetName] {
+		delete(f.fail, relativeRecordSetName)
+		return mgmtdns.RecordSet{}, errors.New("connection reset by peer")
+	}
+
+	parameters.Etag = to.StringPtr("etag")
+	f.recordSets[relativeRecordSetName] = parameters
+
+	return parameters, nil
+}
+
+func (f *fakeRecordSets) Delete(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType, ifMatch string) (autorest.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.recordSets, relativeRecordSetName)
+
+	return autorest.Response{}, nil
+}
+
+func (f *fakeRecordSets) Get(ctx context.Context, resourceGroupName string, zoneName string, relativeRecordSetName string, recordType mgmtdns.RecordType) (mgmtdns.RecordSet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rs, ok := f.recordSets[relativeRecordSetName]
+	if !ok {
+		return mgmtdns.RecordSet{}, autorest.DetailedError{
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	return rs, nil
+}
+
+func (f *fakeRecordSets) ips() map[string]string {
+	ips := map[string]string{}
+	for name, rs := range f.recordSets {
+		ips[name] = *(*rs.ARecords)[0].Ipv4Address
+	}
+	return ips
+}
+
+func TestCreateOrUpdateAdditionalRouters(t *testing.T) {
+	ctx := context.Background()
+
+	oc := &api.OpenShiftCluster{
+		ID: "/subscriptions/subscriptionId/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+		Properties: api.OpenShiftClusterProperties{
+			IngressProfiles: []api.IngressProfile{
+				{Name: "default", IP: "1.1.1.1"},
+				{Name: "apex", Domain: "example.com", IP: "2.2.2.2"},
+				{Name: "internal", Domain: "internal.example.com", IP: "3.3.3.3"},
+				{Name: "shop", Domain: "shop.example.com", IP: "4.4.4.4"},
+				{Name: "pending", Domain: "pending.example.com"},
+				{Name: "other", Domain: "other.com", IP: "5.5.5.5"},
+			},
+		},
+	}
+
+	wantIPs := map[string]string{
+		"*":          "2.2.2.2",
+		"*.internal": "3.3.3.3",
+		"*.shop":     "4.4.4.4",
+	}
+
+	recordsets := &fakeRecordSets{
+		recordSets: map[string]mgmtdns.RecordSet{},
+		fail: map[string]bool{
+			"*.internal": true,
+			"*.shop":     true,
+		},
+	}
+
+	m := &manager{
+		recordsets:  recordsets,
+		zone:        &azure.Resource{ResourceGroup: "zoneResourceGroup", ResourceName: "example.com"},
+		concurrency: 2,
+	}
+
+	err := m.CreateOrUpdateAdditionalRouters(ctx, oc)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var failed []string
+	for _, err := range err.(interface{ Errors() []error }).Errors() {
+		failed = append(failed, err.Error())
+	}
+	sort.Strings(failed)
+	if !reflect.DeepEqual(failed, []string{
+		`recordset "*.internal": connection reset by peer`,
+		`recordset "*.shop": connection reset by peer`,
+	}) {
+		t.Error(failed)
+	}
+
+	if !reflect.DeepEqual(recordsets.ips(), map[string]string{"*": "2.2.2.2"}) {
+		t.Error(recordsets.ips())
+	}
+
+	// the retry creates the remaining records and leaves the existing one
+	// alone
+	recordsets.calls = 0
+
+	err = m.CreateOrUpdateAdditionalRouters(ctx, oc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(recordsets.ips(), wantIPs) {
+		t.Error(recordsets.ips())
+	}
+
+	if recordsets.calls != 2 {
+		t.Errorf("got %d CreateOrUpdate calls on retry, wanted 2", recordsets.calls)
+	}
+
+	if recordsets.maxFlight > m.concurrency {
+		t.Errorf("got %d concurrent CreateOrUpdate calls, wanted at most %d", recordsets.maxFlight, m.concurrency)
+	}
+
+	for name, rs := range recordsets.recordSets {
+		if *rs.Metadata[resourceID] != oc.ID || *rs.TTL != defaultTTL {
+			t.Errorf("recordset %s: got %#v", name, rs.RecordSetProperties)
+		}
+	}
+
+	// a record of another resource is left alone
+	recordsets.recordSets["*.shop"] = mgmtdns.RecordSet{
+		RecordSetProperties: &mgmtdns.RecordSetProperties{
+			Metadata: map[string]*string{resourceID: to.StringPtr("other")},
+			ARecords: &[]mgmtdns.ARecord{{Ipv4Address: to.StringPtr("9.9.9.9")}},
+		},
+	}
+
+	err = m.CreateOrUpdateAdditionalRouters(ctx, oc)
+	if err == nil || err.Error() != `recordset "*.shop": recordset "*.shop" already registered` {
+		t.Error(err)
+	}
+
+	err = m.Delete(ctx, &api.OpenShiftCluster{
+		ID: oc.ID,
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile:  api.ClusterProfile{Domain: "cluster.example.com"},
+			IngressProfiles: oc.Properties.IngressProfiles,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(recordsets.ips(), map[string]string{"*.shop": "9.9.9.9"}) {
+		t.Error(recordsets.ips())
+	}
+}
+
+func TestRecordConcurrency(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		env  string
+		want int
+	}{
+		{
+			name: "default",
+			want: defaultConcurrency,
+		},
+		{
+			name: "overridden",
+			env:  "10",
+			want: 10,
+		},
+		{
+			name: "invalid override",
+			env:  "-1",
+			want: defaultConcurrency,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(concurrencyEnvVar, tt.env)
+
+			if got := recordConcurrency(); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package dynamichelper
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+type FieldChange string
+
+const (
+	// FieldChangeAdded means that the field is set in the desired object but
+	// not in the cluster
+	FieldChangeAdded FieldChange = "Added"
+	// FieldChangeRemoved means that the field is set in the cluster but not in
+	// the desired object
+	FieldChangeRemoved FieldChange = "Removed"
+	// FieldChangeChanged means that the field is set in both but differs
+	FieldChangeChanged FieldChange = "Changed"
+)
+
+// ObjectDiff describes how an object in the cluster differs from its desired
+// state.
+type ObjectDiff struct {
+	GroupKind string
+	Namespace string
+	Name      string
+
+	// Missing is true if the object does not exist in the cluster
+	Missing bool
+
+	Fields []FieldDiff
+}
+
+// FieldDiff describes a single differing field.  Desired and Actual are not
+// set for Secrets.
+type FieldDiff struct {
+	Path    string
+	Change  FieldChange
+	Desired interface{}
+	Actual  interface{}
+}
+
+// Diff compares one or more objects with their state in the cluster and
+// returns the objects which Ensure would create or update.  The comparison
+// uses the same defaulting and fix-ups as Ensure, so fields which Ensure
+// leaves alone are not reported.  The status and all metadata other than
+// labels and annotations are ignored.
+func (dh *dynamicHelper) Diff(ctx context.Context, objs ...kruntime.Object) ([]*ObjectDiff, error) {
+	var diffs []*ObjectDiff
+
+	for _, o := range objs {
+		// unstructured objects are only used for the guardrails policies,
+		// which are never updated in place
+		if _, ok := o.(*unstructured.Unstructured); ok {
+			continue
+		}
+
+		diff, err := dh.diffOne(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+
+		if diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
+func (dh *dynamicHelper) diffOne(ctx context.Context, new kruntime.Object) (*ObjectDiff, error) {
+	// merge defaults and fixes up the desired object in place
+	new = new.DeepCopyObject()
+
+	gvks, _, err := scheme.Scheme.ObjectKinds(new)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := gvks[0]
+
+	gvr, err := dh.Resolve(gvk.GroupKind().String(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := meta.Accessor(new)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ObjectDiff{
+		GroupKind: gvk.GroupKind().String(),
+		Namespace: acc.GetNamespace(),
+		Name:      acc.GetName(),
+	}
+
+	old, err := dh.restcli.Get().AbsPath(makeURLSegments(gvr, acc.GetNamespace(), acc.GetName())...).Do(ctx).Get()
+	if kerrors.IsNotFound(err) {
+		diff.Missing = true
+		return diff, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, changed, _, err := dh.mergeWithLogic(acc.GetName(), gvk.GroupKind().String(), old, new)
+	if err != nil || !changed {
+		return nil, err
+	}
+
+	diff.Fields, err = diffObjects(old, candidate)
+	if err != nil || len(diff.Fields) == 0 {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// diffObjects returns the fields which differ between the actual (old) and
+// desired (new) objects
+func diffObjects(old, new kruntime.Object) ([]FieldDiff, error) {
+	actual, err := kruntime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, err := kruntime.DefaultUnstructuredConverter.ToUnstructured(new)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := diffFields("", comparableFields(desired), comparableFields(actual))
+
+	// as in merge, don't show the contents of Secrets
+	if _, ok := old.(*corev1.Secret); ok {
+		for i := range fields {
+			fields[i].Desired, fields[i].Actual = nil, nil
+		}
+	}
+
+	return fields, nil
+}
+
+// comparableFields drops the fields of an unstructured object which are owned
+// by the API server or by other controllers
+func comparableFields(o map[string]interface{}) map[string]interface{} {
+	o = kruntime.DeepCopyJSON(o)
+
+	delete(o, "status")
+
+	if metadata, ok := o["metadata"].(map[string]interface{}); ok {
+		for k := range metadata {
+			if k != "labels" && k != "annotations" {
+				delete(metadata, k)
+			}
+		}
+	}
+
+	return o
+}
+
+func diffFields(path string, desired, actual interface{}) []FieldDiff {
+	switch desired := desired.(type) {
+	case map[string]interface{}:
+		if actual, ok := actual.(map[string]interface{}); ok {
+			return diffMaps(path, desired, actual)
+		}
+
+	case []interface{}:
+		if actual, ok := actual.([]interface{}); ok {
+			return diffSlices(path, desired, actual)
+		}
+	}
+
+	if reflect.DeepEqual(desired, actual) {
+		return nil
+	}
+
+	return []FieldDiff{{Path: path, Change: FieldChangeChanged, Desired: desired, Actual: actual}}
+}
+
+func diffMaps(path string, desired, actual map[string]interface{}) []FieldDiff {
+	keys := make([]string, 0, len(desired)+len(actual))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	for k := range actual {
+		if _, found := desired[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var fields []FieldDiff
+	for _, k := range keys {
+		d, inDesired := desired[k]
+		a, inActual := actual[k]
+
+		switch {
+		case !inActual:
+			fields = append(fields, FieldDiff{Path: fieldPath(path, k), Change: FieldChangeAdded, Desired: d})
+		case !inDesired:
+			fields = append(fields, FieldDiff{Path: fieldPath(path, k), Change: FieldChangeRemoved, Actual: a})
+		default:
+			fields = append(fields, diffFields(fieldPath(path, k), d, a)...)
+		}
+	}
+
+	return fields
+}
+
+func diffSlices(path string, desired, actual []interface{}) []FieldDiff {
+	var fields []FieldDiff
+	for i := 0; i < len(desired) || i < len(actual); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+
+		switch {
+		case i >= len(actual):
+			fields = append(fields, FieldDiff{Path: elementPath, Change: FieldChangeAdded, Desired: desired[i]})
+		case i >= len(desired):
+			fields = append(fields, FieldDiff{Path: elementPath, Change: FieldChangeRemoved, Actual: actual[i]})
+		default:
+			fields = append(fields, diffFields(elementPath, desired[i], actual[i])...)
+		}
+	}
+
+	return fields
+}
+
+// fieldPath appends a key to a path, quoting keys such as annotation names
+// which would otherwise be ambiguous
+func fieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./[]") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
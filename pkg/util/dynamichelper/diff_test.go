@@ -0,0 +1,123 @@
+package dynamichelper
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/Azure/ARO-RP/pkg/util/cmp"
+)
+
+func TestDiffObjects(t *testing.T) {
+	deployment := func(image string, labels map[string]string, args ...string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "aro-operator-master",
+				Namespace:       "openshift-azure-operator",
+				Labels:          labels,
+				ResourceVersion: "1",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "aro-operator",
+								Image: image,
+								Args:  args,
+							},
+						},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas: 1,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		old  kruntime.Object
+		new  kruntime.Object
+		want []FieldDiff
+	}{
+		{
+			name: "no changes",
+			old:  deployment("aro:1", nil, "operator", "master"),
+			new:  deployment("aro:1", nil, "operator", "master"),
+		},
+		{
+			name: "status and metadata are ignored",
+			old:  deployment("aro:1", nil),
+			new: func() kruntime.Object {
+				d := deployment("aro:1", nil)
+				d.ResourceVersion = "2"
+				d.Status.Replicas = 3
+				return d
+			}(),
+		},
+		{
+			name: "changed fields",
+			old:  deployment("aro:1", map[string]string{"app.kubernetes.io/name": "aro", "example.com/customer": "true"}, "operator"),
+			new:  deployment("aro:2", map[string]string{"app.kubernetes.io/name": "aro", "version": "2"}, "operator", "master"),
+			want: []FieldDiff{
+				{
+					Path:   `metadata.labels["example.com/customer"]`,
+					Change: FieldChangeRemoved,
+					Actual: "true",
+				},
+				{
+					Path:    "metadata.labels.version",
+					Change:  FieldChangeAdded,
+					Desired: "2",
+				},
+				{
+					Path:    "spec.template.spec.containers[0].args[1]",
+					Change:  FieldChangeAdded,
+					Desired: "master",
+				},
+				{
+					Path:    "spec.template.spec.containers[0].image",
+					Change:  FieldChangeChanged,
+					Desired: "aro:2",
+					Actual:  "aro:1",
+				},
+			},
+		},
+		{
+			name: "secret values are not shown",
+			old: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Data:       map[string][]byte{"key": []byte("old")},
+			},
+			new: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Data:       map[string][]byte{"key": []byte("new")},
+			},
+			want: []FieldDiff{
+				{
+					Path:   "data.key",
+					Change: FieldChangeChanged,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffObjects(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ type Interface interface {
 	EnsureDeleted(ctx context.Context, groupKind, namespace, name string) error
 	EnsureDeletedGVR(ctx context.Context, groupKind, namespace, name, optionalVersion string) error
 	Ensure(ctx context.Context, objs ...kruntime.Object) error
+	Diff(ctx context.Context, objs ...kruntime.Object) ([]*ObjectDiff, error)
 	IsConstraintTemplateReady(ctx context.Context, name string) (bool, error)
 }
 
@@ -0,0 +1,292 @@
+package etcd
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/Azure/ARO-RP/pkg/util/portforward"
+)
+
+const (
+	namespace = "openshift-etcd"
+
+	clientPort = "2379"
+
+	// the client certificate and CA bundle maintained by the etcd operator
+	clientSecretName     = "etcd-client"
+	caBundleName         = "etcd-ca-bundle"
+	caBundleKey          = "ca-bundle.crt"
+	memberLabelSelector  = "app=etcd"
+	memberContainerName  = "etcd"
+	defragmentPathSuffix = "/v3/maintenance/defragment"
+	statusPathSuffix     = "/v3/maintenance/status"
+	healthPathSuffix     = "/health"
+)
+
+// Member is an etcd member, identified by the static pod which runs it
+type Member struct {
+	Name string
+	IP   string
+}
+
+// Status is the status reported by an etcd member
+type Status struct {
+	MemberID    string
+	Leader      bool
+	DBSize      int64
+	DBSizeInUse int64
+}
+
+// FragmentationPercentage returns the percentage of the database which is
+// unused and would be reclaimed by a defragmentation
+func (s *Status) FragmentationPercentage() float64 {
+	if s.DBSize == 0 {
+		return 0
+	}
+
+	return 100 * float64(s.DBSize-s.DBSizeInUse) / float64(s.DBSize)
+}
+
+// Client talks to the etcd members of a cluster through the kubernetes API
+// server port forwarding, using the etcd operator's client certificate
+type Client interface {
+	Members(ctx context.Context) ([]Member, error)
+	Health(ctx context.Context, member Member) error
+	Status(ctx context.Context, member Member) (*Status, error)
+	Defragment(ctx context.Context, member Member) error
+}
+
+type client struct {
+	log        *logrus.Entry
+	restconfig *rest.Config
+	cli        kubernetes.Interface
+}
+
+// NewClient returns a new Client.  The etcd client certificate is fetched from
+// the cluster on each call.
+func NewClient(log *logrus.Entry, restconfig *rest.Config, cli kubernetes.Interface) Client {
+	return &client{
+		log:        log,
+		restconfig: restconfig,
+		cli:        cli,
+	}
+}
+
+// Members returns the etcd members, sorted by name
+func (c *client) Members(ctx context.Context) ([]Member, error) {
+	pods, err := c.cli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: memberLabelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []Member
+	for _, pod := range pods.Items {
+		if !hasContainer(&pod, memberContainerName) {
+			continue
+		}
+
+		members = append(members, Member{
+			Name: pod.Name,
+			IP:   pod.Status.PodIP,
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+
+	return members, nil
+}
+
+// Health returns an error if the member doesn't report itself healthy
+func (c *client) Health(ctx context.Context, member Member) error {
+	var health struct {
+		Health string `json:"health"`
+		Reason string `json:"reason"`
+	}
+
+	err := c.do(ctx, member, http.MethodGet, healthPathSuffix, &health)
+	if err != nil {
+		return err
+	}
+
+	if health.Health != "true" {
+		return fmt.Errorf("etcd member %s is unhealthy: %s", member.Name, health.Reason)
+	}
+
+	return nil
+}
+
+// Status returns the status of the member
+func (c *client) Status(ctx context.Context, member Member) (*Status, error) {
+	// int64 fields are encoded as strings by the etcd grpc gateway
+	var status struct {
+		Header struct {
+			MemberID string `json:"member_id"`
+		} `json:"header"`
+		Leader      string `json:"leader"`
+		DBSize      string `json:"dbSize"`
+		DBSizeInUse string `json:"dbSizeInUse"`
+	}
+
+	err := c.do(ctx, member, http.MethodPost, statusPathSuffix, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Status{
+		MemberID: status.Header.MemberID,
+		Leader:   status.Header.MemberID != "" && status.Header.MemberID == status.Leader,
+	}
+
+	s.DBSize, err = parseInt(status.DBSize)
+	if err != nil {
+		return nil, err
+	}
+
+	s.DBSizeInUse, err = parseInt(status.DBSizeInUse)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Defragment defragments the database of the member.  The member does not
+// serve requests while it is being defragmented.
+func (c *client) Defragment(ctx context.Context, member Member) error {
+	c.log.Infof("defragmenting etcd member %s", member.Name)
+	return c.do(ctx, member, http.MethodPost, defragmentPathSuffix, nil)
+}
+
+func (c *client) do(ctx context.Context, member Member, method, path string, out interface{}) error {
+	if member.IP == "" {
+		return fmt.Errorf("etcd member %s has no IP address", member.Name)
+	}
+
+	tlsConfig, err := c.tlsConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the serving certificate of each member is valid for its node IP
+	tlsConfig.ServerName = member.IP
+
+	hc := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return portforward.DialContext(ctx, c.log, c.restconfig, namespace, member.Name, clientPort)
+			},
+			TLSClientConfig: tlsConfig,
+			// HACK: without this, keepalive connections don't get closed,
+			// resulting in excessive open TCP connections, lots of
+			// goroutines not exiting and memory not being freed.
+			DisableKeepAlives: true,
+		},
+	}
+
+	var body io.Reader
+	if method == http.MethodPost {
+		body = bytes.NewReader([]byte("{}"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "https://"+net.JoinHostPort(member.IP, clientPort)+path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd member %s returned unexpected status code %d", member.Name, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *client) tlsConfig(ctx context.Context) (*tls.Config, error) {
+	s, err := c.cli.CoreV1().Secrets(namespace).Get(ctx, clientSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := c.cli.CoreV1().ConfigMaps(namespace).Get(ctx, caBundleName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(cm.Data[caBundleKey])) {
+		return nil, errors.New("etcd CA bundle contains no certificates")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+	}, nil
+}
+
+// LeaderLast returns the members in the order in which they are taken down
+// one at a time: the followers first and the leader last, so that the
+// cluster holds only one leader election
+func LeaderLast(members []Member, leader string) []Member {
+	ordered := make([]Member, 0, len(members))
+	for _, member := range members {
+		if member.Name != leader {
+			ordered = append(ordered, member)
+		}
+	}
+	for _, member := range members {
+		if member.Name == leader {
+			ordered = append(ordered, member)
+		}
+	}
+
+	return ordered
+}
+
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
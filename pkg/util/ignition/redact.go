@@ -0,0 +1,208 @@
+package ignition
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	ign3types "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/vincent-petithory/dataurl"
+)
+
+// Redacted replaces secret values in redacted ignition configs, matching the
+// marker used when API documents are logged
+const Redacted = "[REDACTED]"
+
+var redactedSource = "data:," + url.PathEscape(Redacted)
+
+// sensitivePaths, sensitivePathSuffixes and sensitivePathSubstrings identify
+// files whose contents are always redacted: pull secrets, kubeconfigs,
+// certificates and keys
+var (
+	sensitivePaths = []string{
+		"/root/.docker/config.json",
+		"/var/lib/kubelet/config.json",
+	}
+
+	sensitivePathSuffixes = []string{
+		".crt",
+		".key",
+		".pem",
+	}
+
+	sensitivePathSubstrings = []string{
+		"kubeconfig",
+	}
+)
+
+// sensitiveContents identify file and systemd unit contents which are
+// redacted wherever they are found: PEM encoded certificates and keys, pull
+// secrets, Kubernetes secret manifests, kubeconfig credentials and service
+// principal secrets
+var sensitiveContents = []string{
+	"-----BEGIN ",
+	`"auths"`,
+	"kind: Secret",
+	`"kind":"Secret"`,
+	"client-key-data",
+	"aadClientSecret",
+	"azure_client_secret",
+}
+
+// Redact strips the secrets from an ignition config so that it can be
+// inspected by support.  The following are replaced by Redacted:
+//
+//   - the contents, and anything appended, of files at a sensitive path, of
+//     files whose contents match a sensitive pattern and of files with
+//     compressed contents, which are not inspected.  Their compression and
+//     verification hash are cleared.
+//   - the contents of systemd units and drop-ins which match a sensitive
+//     pattern.
+//   - the certificate authorities trusted by ignition.
+//   - the values of all HTTP headers used to fetch remote resources.
+//   - the query string, which may carry a SAS token, of remote sources.
+//   - user password hashes.
+//
+// SSH authorized keys are public and are kept.  config is modified in place.
+func Redact(config *ign3types.Config) {
+	for i := range config.Ignition.Config.Merge {
+		redactResource(&config.Ignition.Config.Merge[i], false)
+	}
+	redactResource(&config.Ignition.Config.Replace, false)
+
+	for i := range config.Ignition.Security.TLS.CertificateAuthorities {
+		redactResource(&config.Ignition.Security.TLS.CertificateAuthorities[i], true)
+	}
+
+	for i := range config.Passwd.Users {
+		if config.Passwd.Users[i].PasswordHash != nil {
+			config.Passwd.Users[i].PasswordHash = to.StringPtr(Redacted)
+		}
+	}
+
+	for i := range config.Storage.Files {
+		f := &config.Storage.Files[i]
+
+		sensitive := isSensitivePath(f.Path) || isSensitiveResource(&f.Contents)
+		for j := range f.Append {
+			sensitive = sensitive || isSensitiveResource(&f.Append[j])
+		}
+
+		redactResource(&f.Contents, sensitive)
+		for j := range f.Append {
+			redactResource(&f.Append[j], sensitive)
+		}
+	}
+
+	for i := range config.Systemd.Units {
+		u := &config.Systemd.Units[i]
+
+		if u.Contents != nil && isSensitiveContents(*u.Contents) {
+			u.Contents = to.StringPtr(Redacted)
+		}
+
+		for j := range u.Dropins {
+			if u.Dropins[j].Contents != nil && isSensitiveContents(*u.Dropins[j].Contents) {
+				u.Dropins[j].Contents = to.StringPtr(Redacted)
+			}
+		}
+	}
+}
+
+// RedactJSON parses a marshalled ignition config, strips its secrets as
+// described in Redact and marshals it again.  Fields unknown to ignition are
+// dropped.
+func RedactJSON(b []byte) ([]byte, error) {
+	var config ign3types.Config
+	err := json.Unmarshal(b, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	Redact(&config)
+
+	return json.Marshal(&config)
+}
+
+func redactResource(r *ign3types.Resource, sensitive bool) {
+	for i := range r.HTTPHeaders {
+		r.HTTPHeaders[i].Value = to.StringPtr(Redacted)
+	}
+
+	if r.Source == nil {
+		return
+	}
+
+	if sensitive {
+		r.Source = to.StringPtr(redactedSource)
+		r.Compression = nil
+		r.Verification.Hash = nil
+		return
+	}
+
+	if strings.HasPrefix(*r.Source, "data:") {
+		return
+	}
+
+	u, err := url.Parse(*r.Source)
+	if err != nil {
+		r.Source = to.StringPtr(Redacted)
+		return
+	}
+
+	if u.RawQuery != "" {
+		u.RawQuery = url.QueryEscape(Redacted)
+		r.Source = to.StringPtr(u.String())
+	}
+}
+
+// isSensitiveResource returns true if the resource holds data which must be
+// redacted.  Compressed data is not inspected and is always redacted.
+func isSensitiveResource(r *ign3types.Resource) bool {
+	if r.Source == nil || !strings.HasPrefix(*r.Source, "data:") {
+		return false
+	}
+
+	if r.Compression != nil && *r.Compression != "" {
+		return true
+	}
+
+	du, err := dataurl.DecodeString(*r.Source)
+	if err != nil {
+		return true
+	}
+
+	return isSensitiveContents(string(du.Data))
+}
+
+func isSensitivePath(p string) bool {
+	for _, sensitive := range sensitivePaths {
+		if p == sensitive {
+			return true
+		}
+	}
+	for _, suffix := range sensitivePathSuffixes {
+		if strings.HasSuffix(p, suffix) {
+			return true
+		}
+	}
+	for _, substring := range sensitivePathSubstrings {
+		if strings.Contains(p, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSensitiveContents(contents string) bool {
+	for _, sensitive := range sensitiveContents {
+		if strings.Contains(contents, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package ignition
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestRedactJSON(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		config  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "plain files and units are kept",
+			config: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/hostname","contents":{"source":"data:,master-0"}}]},"systemd":{"units":[{"name":"custom.service","contents":"[Unit]\n"}]}}`,
+			want:   `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/etc/hostname","user":{},"contents":{"source":"data:,master-0","verification":{}}}]},"systemd":{"units":[{"contents":"[Unit]\n","name":"custom.service"}]}}`,
+		},
+		{
+			name:   "files at sensitive paths are redacted",
+			config: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/var/lib/kubelet/config.json","contents":{"source":"data:,secret"}},{"path":"/opt/openshift/tls/admin.key","contents":{"source":"data:,secret","verification":{"hash":"sha512-00"}}},{"path":"/opt/openshift/auth/kubeconfig-kubelet","contents":{"source":"data:,secret"}}]}}`,
+			want:   `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/var/lib/kubelet/config.json","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}},{"group":{},"path":"/opt/openshift/tls/admin.key","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}},{"group":{},"path":"/opt/openshift/auth/kubeconfig-kubelet","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}}]},"systemd":{}}`,
+		},
+		{
+			name:   "files with sensitive contents are redacted",
+			config: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/opt/openshift/openshift/99_cloud-creds-secret.yaml","contents":{"source":"data:;base64,a2luZDogU2VjcmV0Cg=="}},{"path":"/etc/custom","contents":{"source":"data:,plain"},"append":[{"source":"data:,%22auths%22"}]}]}}`,
+			want:   `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/opt/openshift/openshift/99_cloud-creds-secret.yaml","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}},{"group":{},"path":"/etc/custom","user":{},"append":[{"source":"data:,%5BREDACTED%5D","verification":{}}],"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}}]},"systemd":{}}`,
+		},
+		{
+			name:   "compressed files are redacted",
+			config: `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/custom","contents":{"compression":"gzip","source":"data:;base64,H4sIAAAAAAAA"}}]}}`,
+			want:   `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/etc/custom","user":{},"contents":{"source":"data:,%5BREDACTED%5D","verification":{}}}]},"systemd":{}}`,
+		},
+		{
+			name:   "remote sources, headers and certificate authorities are redacted",
+			config: `{"ignition":{"version":"3.2.0","config":{"merge":[{"source":"https://api-int.cluster.example.com:22623/config/master","httpHeaders":[{"name":"Authorization","value":"Bearer token"}]}]},"security":{"tls":{"certificateAuthorities":[{"source":"data:,cert"}]}}},"storage":{"files":[{"path":"/etc/custom","contents":{"source":"https://storage.example.com/ignition/custom?sv=2019&sig=secret"}}]}}`,
+			want:   `{"ignition":{"config":{"merge":[{"httpHeaders":[{"name":"Authorization","value":"[REDACTED]"}],"source":"https://api-int.cluster.example.com:22623/config/master","verification":{}}],"replace":{"verification":{}}},"proxy":{},"security":{"tls":{"certificateAuthorities":[{"source":"data:,%5BREDACTED%5D","verification":{}}]}},"timeouts":{},"version":"3.2.0"},"passwd":{},"storage":{"files":[{"group":{},"path":"/etc/custom","user":{},"contents":{"source":"https://storage.example.com/ignition/custom?%5BREDACTED%5D","verification":{}}}]},"systemd":{}}`,
+		},
+		{
+			name:   "password hashes and sensitive units are redacted, ssh keys are kept",
+			config: `{"ignition":{"version":"3.2.0"},"passwd":{"users":[{"name":"core","passwordHash":"$6$hash","sshAuthorizedKeys":["ssh-rsa AAAA"]}]},"systemd":{"units":[{"name":"custom.service","contents":"Environment=aadClientSecret=secret\n","dropins":[{"name":"10-env.conf","contents":"[Service]\n"}]}]}}`,
+			want:   `{"ignition":{"config":{"replace":{"verification":{}}},"proxy":{},"security":{"tls":{}},"timeouts":{},"version":"3.2.0"},"passwd":{"users":[{"name":"core","passwordHash":"[REDACTED]","sshAuthorizedKeys":["ssh-rsa AAAA"]}]},"storage":{},"systemd":{"units":[{"contents":"[REDACTED]","dropins":[{"contents":"[Service]\n","name":"10-env.conf"}],"name":"custom.service"}]}}`,
+		},
+		{
+			name:    "invalid json",
+			config:  `{`,
+			wantErr: "unexpected end of JSON input",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := RedactJSON([]byte(tt.config))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if string(b) != tt.want {
+				t.Errorf("got %s, wanted %s", string(b), tt.want)
+			}
+		})
+	}
+}
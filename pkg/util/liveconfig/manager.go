@@ -18,6 +18,7 @@ const (
 	hiveDefaultPullSpecEnvVar = "ARO_HIVE_DEFAULT_INSTALLER_PULLSPEC"
 	hiveAdoptEnableEnvVar     = "ARO_ADOPT_BY_HIVE"
 	useCheckAccess            = "USE_CHECKACCESS"
+	supportedRPVersionsEnvVar = "ARO_SUPPORTED_RP_VERSIONS"
 )
 
 type Manager interface {
@@ -26,6 +27,10 @@ type Manager interface {
 	AdoptByHive(context.Context) (bool, error)
 	UseCheckAccess(context.Context) (bool, error)
 
+	// SupportedRPVersions returns the RP versions to which clusters may be
+	// pinned, starting with the version of the running RP
+	SupportedRPVersions(context.Context) ([]string, error)
+
 	// Allows overriding the default installer pullspec for Prod, if the OpenShiftVersions database is not populated
 	DefaultInstallerPullSpecOverride(context.Context) string
 }
@@ -0,0 +1,37 @@
+package liveconfig
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/util/version"
+)
+
+func (p *prod) SupportedRPVersions(ctx context.Context) ([]string, error) {
+	return supportedRPVersions(), nil
+}
+
+func (d *dev) SupportedRPVersions(ctx context.Context) ([]string, error) {
+	return supportedRPVersions(), nil
+}
+
+// supportedRPVersions returns the version of the running RP followed by the
+// versions listed in ARO_SUPPORTED_RP_VERSIONS, which the RP deployment sets
+// to the other versions which are deployed in the region
+func supportedRPVersions() []string {
+	// TODO: Replace with RP Live Service Config (KeyVault)
+	versions := []string{version.GitCommit}
+
+	for _, v := range strings.Split(os.Getenv(supportedRPVersionsEnvVar), ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && v != version.GitCommit {
+			versions = append(versions, v)
+		}
+	}
+
+	return versions
+}
@@ -0,0 +1,130 @@
+package loadbalancer
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"sort"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// APIServerBackendAddressPoolName returns the name of the backend pool of the
+// master nodes on a customer load balancer
+func APIServerBackendAddressPoolName(infraID string) string {
+	return infraID + "-api"
+}
+
+// IngressBackendAddressPoolName returns the name of the backend pool of the
+// worker nodes on a customer load balancer
+func IngressBackendAddressPoolName(infraID string) string {
+	return infraID + "-ingress"
+}
+
+// NodeIPs returns the internal IP addresses of the master nodes and of the
+// other nodes.  Nodes without an internal IP address are left out.
+func NodeIPs(nodes []corev1.Node) (masterIPs, workerIPs []string) {
+	for i := range nodes {
+		ip := nodeInternalIP(&nodes[i])
+		if ip == "" {
+			continue
+		}
+
+		if _, ok := nodes[i].Labels["node-role.kubernetes.io/master"]; ok {
+			masterIPs = append(masterIPs, ip)
+		} else {
+			workerIPs = append(workerIPs, ip)
+		}
+	}
+
+	return masterIPs, workerIPs
+}
+
+func nodeInternalIP(node *corev1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+// HasBackendAddressPool returns true if the load balancer has the backend
+// pool name
+func HasBackendAddressPool(lb *mgmtnetwork.LoadBalancer, name string) bool {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.BackendAddressPools == nil {
+		return false
+	}
+
+	for _, pool := range *lb.BackendAddressPools {
+		if pool.Name != nil && strings.EqualFold(*pool.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnsureBackendAddressPool ensures that the IP based backend pool name holds
+// exactly the ips, creating it if needed, and reports whether it changed
+func EnsureBackendAddressPool(lb *mgmtnetwork.LoadBalancer, name, vnetID string, ips []string) bool {
+	sort.Strings(ips)
+
+	addresses := make([]mgmtnetwork.LoadBalancerBackendAddress, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, mgmtnetwork.LoadBalancerBackendAddress{
+			LoadBalancerBackendAddressPropertiesFormat: &mgmtnetwork.LoadBalancerBackendAddressPropertiesFormat{
+				VirtualNetwork: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(vnetID),
+				},
+				IPAddress: to.StringPtr(ip),
+			},
+			Name: to.StringPtr(name + "-" + ip),
+		})
+	}
+
+	if lb.LoadBalancerPropertiesFormat == nil {
+		lb.LoadBalancerPropertiesFormat = &mgmtnetwork.LoadBalancerPropertiesFormat{}
+	}
+
+	if lb.BackendAddressPools == nil {
+		lb.BackendAddressPools = &[]mgmtnetwork.BackendAddressPool{}
+	}
+
+	for i, pool := range *lb.BackendAddressPools {
+		if pool.Name == nil || !strings.EqualFold(*pool.Name, name) {
+			continue
+		}
+
+		var current []string
+		if pool.BackendAddressPoolPropertiesFormat != nil && pool.LoadBalancerBackendAddresses != nil {
+			for _, address := range *pool.LoadBalancerBackendAddresses {
+				if address.LoadBalancerBackendAddressPropertiesFormat != nil && address.IPAddress != nil {
+					current = append(current, *address.IPAddress)
+				}
+			}
+		}
+		sort.Strings(current)
+
+		if strings.Join(current, ",") == strings.Join(ips, ",") {
+			return false
+		}
+
+		if (*lb.BackendAddressPools)[i].BackendAddressPoolPropertiesFormat == nil {
+			(*lb.BackendAddressPools)[i].BackendAddressPoolPropertiesFormat = &mgmtnetwork.BackendAddressPoolPropertiesFormat{}
+		}
+		(*lb.BackendAddressPools)[i].LoadBalancerBackendAddresses = &addresses
+		return true
+	}
+
+	*lb.BackendAddressPools = append(*lb.BackendAddressPools, mgmtnetwork.BackendAddressPool{
+		BackendAddressPoolPropertiesFormat: &mgmtnetwork.BackendAddressPoolPropertiesFormat{
+			LoadBalancerBackendAddresses: &addresses,
+		},
+		Name: to.StringPtr(name),
+	})
+	return true
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/Azure/ARO-RP/pkg/api"
 	"github.com/Azure/ARO-RP/pkg/util/log/audit"
 	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+	"github.com/Azure/ARO-RP/pkg/util/tracing"
 )
 
 var (
@@ -137,12 +138,18 @@ func EnrichWithCorrelationData(log *logrus.Entry, correlationData *api.Correlati
 		return log
 	}
 
-	return log.WithFields(logrus.Fields{
+	fields := logrus.Fields{
 		"correlation_id":        correlationData.CorrelationID,
 		"client_request_id":     correlationData.ClientRequestID,
 		"request_id":            correlationData.RequestID,
 		"client_principal_name": correlationData.ClientPrincipalName,
-	})
+	}
+
+	if traceID := tracing.TraceID(correlationData.TraceParent); traceID != "" {
+		fields["trace_id"] = traceID
+	}
+
+	return log.WithFields(fields)
 }
 
 // EnrichWithResourceID sets log fields based on a resource ID
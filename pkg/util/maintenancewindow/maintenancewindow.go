@@ -0,0 +1,78 @@
+package maintenancewindow
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/api/validate"
+)
+
+var weekdays = map[api.Weekday]time.Weekday{
+	api.WeekdaySunday:    time.Sunday,
+	api.WeekdayMonday:    time.Monday,
+	api.WeekdayTuesday:   time.Tuesday,
+	api.WeekdayWednesday: time.Wednesday,
+	api.WeekdayThursday:  time.Thursday,
+	api.WeekdayFriday:    time.Friday,
+	api.WeekdaySaturday:  time.Saturday,
+}
+
+// Next returns when an operation restricted to the maintenance window mw may
+// start: now if the window is open, otherwise the start of the next window.
+// A nil window is always open.
+func Next(mw *api.MaintenanceWindow, now time.Time) (time.Time, error) {
+	if mw == nil {
+		return now, nil
+	}
+
+	loc, err := time.LoadLocation(mw.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	start, err := time.Parse(validate.MaintenanceWindowStartTimeLayout, mw.StartTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, day := range mw.Days {
+		weekday, ok := weekdays[day]
+		if !ok {
+			return time.Time{}, fmt.Errorf("invalid maintenance window day %q", day)
+		}
+		days[weekday] = true
+	}
+	if len(days) == 0 {
+		return time.Time{}, errors.New("maintenance window has no days")
+	}
+
+	duration := time.Duration(mw.DurationHours) * time.Hour
+	local := now.In(loc)
+
+	// a window which opened yesterday may still be open.  Windows are visited
+	// in order, so the first one which is open or opens after now is the one
+	// we want.
+	for i := -1; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		if !days[day.Weekday()] {
+			continue
+		}
+
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		if now.Before(opens) {
+			return opens, nil
+		}
+		if now.Before(opens.Add(duration)) {
+			return now, nil
+		}
+	}
+
+	// unreachable: a window opens at least once a week
+	return time.Time{}, errors.New("no maintenance window found")
+}
@@ -0,0 +1,132 @@
+package maintenancewindow
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Saturday 22:00 - Sunday 04:00 UTC
+	weekend := &api.MaintenanceWindow{
+		Days:          []api.Weekday{api.WeekdaySaturday},
+		StartTime:     "22:00",
+		DurationHours: 6,
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mw      *api.MaintenanceWindow
+		now     time.Time
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "no window",
+			now:  time.Date(2023, time.July, 5, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.July, 5, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "before the window",
+			mw:   weekend,
+			now:  time.Date(2023, time.July, 5, 12, 0, 0, 0, time.UTC), // Wednesday
+			want: time.Date(2023, time.July, 8, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "window open",
+			mw:   weekend,
+			now:  time.Date(2023, time.July, 8, 23, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.July, 8, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "window which opened yesterday still open",
+			mw:   weekend,
+			now:  time.Date(2023, time.July, 9, 3, 59, 0, 0, time.UTC),
+			want: time.Date(2023, time.July, 9, 3, 59, 0, 0, time.UTC),
+		},
+		{
+			name: "window just closed",
+			mw:   weekend,
+			now:  time.Date(2023, time.July, 9, 4, 0, 0, 0, time.UTC),
+			want: time.Date(2023, time.July, 15, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "later the same day",
+			mw: &api.MaintenanceWindow{
+				Days:          []api.Weekday{api.WeekdayMonday, api.WeekdayWednesday},
+				StartTime:     "18:30",
+				DurationHours: 1,
+			},
+			now:  time.Date(2023, time.July, 5, 12, 0, 0, 0, time.UTC), // Wednesday
+			want: time.Date(2023, time.July, 5, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			mw: &api.MaintenanceWindow{
+				Days:          []api.Weekday{api.WeekdaySunday},
+				StartTime:     "01:00",
+				DurationHours: 2,
+				TimeZone:      "Europe/Berlin",
+			},
+			now:  time.Date(2023, time.July, 8, 23, 30, 0, 0, time.UTC), // Sunday 01:30 in Berlin
+			want: time.Date(2023, time.July, 8, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone, next window",
+			mw: &api.MaintenanceWindow{
+				Days:          []api.Weekday{api.WeekdaySunday},
+				StartTime:     "01:00",
+				DurationHours: 2,
+				TimeZone:      "Europe/Berlin",
+			},
+			now:  time.Date(2023, time.July, 9, 1, 0, 0, 0, time.UTC), // Sunday 03:00 in Berlin
+			want: time.Date(2023, time.July, 16, 1, 0, 0, 0, berlin),
+		},
+		{
+			name: "invalid time zone",
+			mw: &api.MaintenanceWindow{
+				Days:          []api.Weekday{api.WeekdaySunday},
+				StartTime:     "01:00",
+				DurationHours: 2,
+				TimeZone:      "Mars/Olympus_Mons",
+			},
+			wantErr: "unknown time zone Mars/Olympus_Mons",
+		},
+		{
+			name: "invalid day",
+			mw: &api.MaintenanceWindow{
+				Days:          []api.Weekday{"Funday"},
+				StartTime:     "01:00",
+				DurationHours: 2,
+			},
+			wantErr: `invalid maintenance window day "Funday"`,
+		},
+		{
+			name: "no days",
+			mw: &api.MaintenanceWindow{
+				StartTime:     "01:00",
+				DurationHours: 2,
+			},
+			wantErr: "maintenance window has no days",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Next(tt.mw, tt.now)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, wanted %s", got, tt.want)
+			}
+		})
+	}
+}
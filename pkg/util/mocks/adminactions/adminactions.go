@@ -255,6 +255,21 @@ func (mr *MockAzureActionsMockRecorder) AppLensListDetectors(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppLensListDetectors", reflect.TypeOf((*MockAzureActions)(nil).AppLensListDetectors), arg0)
 }
 
+// BootstrapIgnition mocks base method.
+func (m *MockAzureActions) BootstrapIgnition(arg0 context.Context) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapIgnition", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BootstrapIgnition indicates an expected call of BootstrapIgnition.
+func (mr *MockAzureActionsMockRecorder) BootstrapIgnition(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapIgnition", reflect.TypeOf((*MockAzureActions)(nil).BootstrapIgnition), arg0)
+}
+
 // GroupResourceList mocks base method.
 func (m *MockAzureActions) GroupResourceList(arg0 context.Context) ([]features.GenericResourceExpanded, error) {
 	m.ctrl.T.Helper()
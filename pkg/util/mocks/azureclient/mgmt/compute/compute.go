@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute (interfaces: DisksClient,ResourceSkusClient,VirtualMachinesClient,UsageClient,VirtualMachineScaleSetVMsClient,VirtualMachineScaleSetsClient,DiskEncryptionSetsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute (interfaces: DisksClient,ResourceSkusClient,VirtualMachinesClient,UsageClient,VirtualMachineScaleSetVMsClient,VirtualMachineScaleSetsClient,DiskEncryptionSetsClient,ProximityPlacementGroupsClient)
 
 // Package mock_compute is a generated GoMock package.
 package mock_compute
@@ -197,6 +197,20 @@ func (mr *MockVirtualMachinesClientMockRecorder) RedeployAndWait(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeployAndWait", reflect.TypeOf((*MockVirtualMachinesClient)(nil).RedeployAndWait), arg0, arg1, arg2)
 }
 
+// RestartAndWait mocks base method.
+func (m *MockVirtualMachinesClient) RestartAndWait(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestartAndWait", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestartAndWait indicates an expected call of RestartAndWait.
+func (mr *MockVirtualMachinesClientMockRecorder) RestartAndWait(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartAndWait", reflect.TypeOf((*MockVirtualMachinesClient)(nil).RestartAndWait), arg0, arg1, arg2)
+}
+
 // StartAndWait mocks base method.
 func (m *MockVirtualMachinesClient) StartAndWait(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -419,3 +433,41 @@ func (mr *MockDiskEncryptionSetsClientMockRecorder) Get(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDiskEncryptionSetsClient)(nil).Get), arg0, arg1, arg2)
 }
+
+// MockProximityPlacementGroupsClient is a mock of ProximityPlacementGroupsClient interface.
+type MockProximityPlacementGroupsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProximityPlacementGroupsClientMockRecorder
+}
+
+// MockProximityPlacementGroupsClientMockRecorder is the mock recorder for MockProximityPlacementGroupsClient.
+type MockProximityPlacementGroupsClientMockRecorder struct {
+	mock *MockProximityPlacementGroupsClient
+}
+
+// NewMockProximityPlacementGroupsClient creates a new mock instance.
+func NewMockProximityPlacementGroupsClient(ctrl *gomock.Controller) *MockProximityPlacementGroupsClient {
+	mock := &MockProximityPlacementGroupsClient{ctrl: ctrl}
+	mock.recorder = &MockProximityPlacementGroupsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProximityPlacementGroupsClient) EXPECT() *MockProximityPlacementGroupsClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockProximityPlacementGroupsClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (compute.ProximityPlacementGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(compute.ProximityPlacementGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProximityPlacementGroupsClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProximityPlacementGroupsClient)(nil).Get), arg0, arg1, arg2, arg3)
+}
@@ -145,6 +145,21 @@ func (mr *MockProvidersClientMockRecorder) List(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProvidersClient)(nil).List), arg0, arg1, arg2)
 }
 
+// Register mocks base method.
+func (m *MockProvidersClient) Register(arg0 context.Context, arg1 string) (features.Provider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	ret0, _ := ret[0].(features.Provider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockProvidersClientMockRecorder) Register(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockProvidersClient)(nil).Register), arg0, arg1)
+}
+
 // MockResourceGroupsClient is a mock of ResourceGroupsClient interface.
 type MockResourceGroupsClient struct {
 	ctrl     *gomock.Controller
@@ -242,6 +257,21 @@ func (mr *MockResourceGroupsClientMockRecorder) List(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockResourceGroupsClient)(nil).List), arg0, arg1, arg2)
 }
 
+// Update mocks base method.
+func (m *MockResourceGroupsClient) Update(arg0 context.Context, arg1 string, arg2 features.ResourceGroupPatchable) (features.ResourceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(features.ResourceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResourceGroupsClientMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResourceGroupsClient)(nil).Update), arg0, arg1, arg2)
+}
+
 // MockResourcesClient is a mock of ResourcesClient interface.
 type MockResourcesClient struct {
 	ctrl     *gomock.Controller
@@ -323,3 +353,17 @@ func (mr *MockResourcesClientMockRecorder) ListByResourceGroup(arg0, arg1, arg2,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByResourceGroup", reflect.TypeOf((*MockResourcesClient)(nil).ListByResourceGroup), arg0, arg1, arg2, arg3, arg4)
 }
+
+// UpdateByIDAndWait mocks base method.
+func (m *MockResourcesClient) UpdateByIDAndWait(arg0 context.Context, arg1, arg2 string, arg3 features.GenericResource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByIDAndWait", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByIDAndWait indicates an expected call of UpdateByIDAndWait.
+func (mr *MockResourcesClientMockRecorder) UpdateByIDAndWait(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByIDAndWait", reflect.TypeOf((*MockResourcesClient)(nil).UpdateByIDAndWait), arg0, arg1, arg2, arg3)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network (interfaces: InterfacesClient,LoadBalancersClient,PrivateEndpointsClient,PrivateLinkServicesClient,PublicIPAddressesClient,LoadBalancerBackendAddressPoolsClient,RouteTablesClient,SubnetsClient,VirtualNetworksClient,SecurityGroupsClient,VirtualNetworkPeeringsClient,UsageClient,FlowLogsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network (interfaces: InterfacesClient,LoadBalancersClient,PrivateEndpointsClient,PrivateLinkServicesClient,PublicIPAddressesClient,PublicIPPrefixesClient,LoadBalancerBackendAddressPoolsClient,NatGatewaysClient,RouteTablesClient,SubnetsClient,VirtualNetworksClient,SecurityGroupsClient,VirtualNetworkPeeringsClient,UsageClient,FlowLogsClient)
 
 // Package mock_network is a generated GoMock package.
 package mock_network
@@ -360,6 +360,44 @@ func (mr *MockPublicIPAddressesClientMockRecorder) List(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPublicIPAddressesClient)(nil).List), arg0, arg1)
 }
 
+// MockPublicIPPrefixesClient is a mock of PublicIPPrefixesClient interface.
+type MockPublicIPPrefixesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicIPPrefixesClientMockRecorder
+}
+
+// MockPublicIPPrefixesClientMockRecorder is the mock recorder for MockPublicIPPrefixesClient.
+type MockPublicIPPrefixesClientMockRecorder struct {
+	mock *MockPublicIPPrefixesClient
+}
+
+// NewMockPublicIPPrefixesClient creates a new mock instance.
+func NewMockPublicIPPrefixesClient(ctrl *gomock.Controller) *MockPublicIPPrefixesClient {
+	mock := &MockPublicIPPrefixesClient{ctrl: ctrl}
+	mock.recorder = &MockPublicIPPrefixesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicIPPrefixesClient) EXPECT() *MockPublicIPPrefixesClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPublicIPPrefixesClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.PublicIPPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(network.PublicIPPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPublicIPPrefixesClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPublicIPPrefixesClient)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
 // MockLoadBalancerBackendAddressPoolsClient is a mock of LoadBalancerBackendAddressPoolsClient interface.
 type MockLoadBalancerBackendAddressPoolsClient struct {
 	ctrl     *gomock.Controller
@@ -398,6 +436,44 @@ func (mr *MockLoadBalancerBackendAddressPoolsClientMockRecorder) Get(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoadBalancerBackendAddressPoolsClient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
+// MockNatGatewaysClient is a mock of NatGatewaysClient interface.
+type MockNatGatewaysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNatGatewaysClientMockRecorder
+}
+
+// MockNatGatewaysClientMockRecorder is the mock recorder for MockNatGatewaysClient.
+type MockNatGatewaysClientMockRecorder struct {
+	mock *MockNatGatewaysClient
+}
+
+// NewMockNatGatewaysClient creates a new mock instance.
+func NewMockNatGatewaysClient(ctrl *gomock.Controller) *MockNatGatewaysClient {
+	mock := &MockNatGatewaysClient{ctrl: ctrl}
+	mock.recorder = &MockNatGatewaysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNatGatewaysClient) EXPECT() *MockNatGatewaysClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockNatGatewaysClient) Get(arg0 context.Context, arg1, arg2, arg3 string) (network.NatGateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(network.NatGateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNatGatewaysClientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNatGatewaysClient)(nil).Get), arg0, arg1, arg2, arg3)
+}
+
 // MockRouteTablesClient is a mock of RouteTablesClient interface.
 type MockRouteTablesClient struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/policyinsights (interfaces: PolicyRestrictionsClient)
+
+// Package mock_policyinsights is a generated GoMock package.
+package mock_policyinsights
+
+import (
+	context "context"
+	reflect "reflect"
+
+	policyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPolicyRestrictionsClient is a mock of PolicyRestrictionsClient interface.
+type MockPolicyRestrictionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyRestrictionsClientMockRecorder
+}
+
+// MockPolicyRestrictionsClientMockRecorder is the mock recorder for MockPolicyRestrictionsClient.
+type MockPolicyRestrictionsClientMockRecorder struct {
+	mock *MockPolicyRestrictionsClient
+}
+
+// NewMockPolicyRestrictionsClient creates a new mock instance.
+func NewMockPolicyRestrictionsClient(ctrl *gomock.Controller) *MockPolicyRestrictionsClient {
+	mock := &MockPolicyRestrictionsClient{ctrl: ctrl}
+	mock.recorder = &MockPolicyRestrictionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyRestrictionsClient) EXPECT() *MockPolicyRestrictionsClientMockRecorder {
+	return m.recorder
+}
+
+// CheckAtResourceGroupScope mocks base method.
+func (m *MockPolicyRestrictionsClient) CheckAtResourceGroupScope(arg0 context.Context, arg1, arg2 string, arg3 policyinsights.CheckRestrictionsRequest) (policyinsights.CheckRestrictionsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAtResourceGroupScope", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(policyinsights.CheckRestrictionsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAtResourceGroupScope indicates an expected call of CheckAtResourceGroupScope.
+func (mr *MockPolicyRestrictionsClientMockRecorder) CheckAtResourceGroupScope(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAtResourceGroupScope", reflect.TypeOf((*MockPolicyRestrictionsClient)(nil).CheckAtResourceGroupScope), arg0, arg1, arg2, arg3)
+}
+
+// CheckAtSubscriptionScope mocks base method.
+func (m *MockPolicyRestrictionsClient) CheckAtSubscriptionScope(arg0 context.Context, arg1 string, arg2 policyinsights.CheckRestrictionsRequest) (policyinsights.CheckRestrictionsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAtSubscriptionScope", arg0, arg1, arg2)
+	ret0, _ := ret[0].(policyinsights.CheckRestrictionsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAtSubscriptionScope indicates an expected call of CheckAtSubscriptionScope.
+func (mr *MockPolicyRestrictionsClientMockRecorder) CheckAtSubscriptionScope(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAtSubscriptionScope", reflect.TypeOf((*MockPolicyRestrictionsClient)(nil).CheckAtSubscriptionScope), arg0, arg1, arg2)
+}
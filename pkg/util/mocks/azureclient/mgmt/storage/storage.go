@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage (interfaces: AccountsClient)
+// Source: github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage (interfaces: AccountsClient,SkusClient)
 
 // Package mock_storage is a generated GoMock package.
 package mock_storage
@@ -80,6 +80,21 @@ func (mr *MockAccountsClientMockRecorder) ListKeys(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockAccountsClient)(nil).ListKeys), arg0, arg1, arg2, arg3)
 }
 
+// ListServiceSAS mocks base method.
+func (m *MockAccountsClient) ListServiceSAS(arg0 context.Context, arg1, arg2 string, arg3 storage.ServiceSasParameters) (storage.ListServiceSasResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceSAS", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(storage.ListServiceSasResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceSAS indicates an expected call of ListServiceSAS.
+func (mr *MockAccountsClientMockRecorder) ListServiceSAS(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceSAS", reflect.TypeOf((*MockAccountsClient)(nil).ListServiceSAS), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockAccountsClient) Update(arg0 context.Context, arg1, arg2 string, arg3 storage.AccountUpdateParameters) (storage.Account, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +109,41 @@ func (mr *MockAccountsClientMockRecorder) Update(arg0, arg1, arg2, arg3 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccountsClient)(nil).Update), arg0, arg1, arg2, arg3)
 }
+
+// MockSkusClient is a mock of SkusClient interface.
+type MockSkusClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSkusClientMockRecorder
+}
+
+// MockSkusClientMockRecorder is the mock recorder for MockSkusClient.
+type MockSkusClientMockRecorder struct {
+	mock *MockSkusClient
+}
+
+// NewMockSkusClient creates a new mock instance.
+func NewMockSkusClient(ctrl *gomock.Controller) *MockSkusClient {
+	mock := &MockSkusClient{ctrl: ctrl}
+	mock.recorder = &MockSkusClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSkusClient) EXPECT() *MockSkusClientMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSkusClient) List(arg0 context.Context) (storage.SkuListResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(storage.SkuListResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSkusClientMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSkusClient)(nil).List), arg0)
+}
@@ -50,6 +50,20 @@ func (mr *MockManagerMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockManager)(nil).Create), arg0, arg1)
 }
 
+// CreateOrUpdateAdditionalRouters mocks base method.
+func (m *MockManager) CreateOrUpdateAdditionalRouters(arg0 context.Context, arg1 *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateAdditionalRouters", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateAdditionalRouters indicates an expected call of CreateOrUpdateAdditionalRouters.
+func (mr *MockManagerMockRecorder) CreateOrUpdateAdditionalRouters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateAdditionalRouters", reflect.TypeOf((*MockManager)(nil).CreateOrUpdateAdditionalRouters), arg0, arg1)
+}
+
 // CreateOrUpdateRouter mocks base method.
 func (m *MockManager) CreateOrUpdateRouter(arg0 context.Context, arg1 *api.OpenShiftCluster, arg2 string) error {
 	m.ctrl.T.Helper()
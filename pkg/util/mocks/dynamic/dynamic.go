@@ -75,6 +75,48 @@ func (m *MockDynamic) EXPECT() *MockDynamicMockRecorder {
 	return m.recorder
 }
 
+// ValidateAzureFileCSI mocks base method.
+func (m *MockDynamic) ValidateAzureFileCSI(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAzureFileCSI", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAzureFileCSI indicates an expected call of ValidateAzureFileCSI.
+func (mr *MockDynamicMockRecorder) ValidateAzureFileCSI(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAzureFileCSI", reflect.TypeOf((*MockDynamic)(nil).ValidateAzureFileCSI), ctx, oc)
+}
+
+// ValidateCustomerLoadBalancer mocks base method.
+func (m *MockDynamic) ValidateCustomerLoadBalancer(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCustomerLoadBalancer", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCustomerLoadBalancer indicates an expected call of ValidateCustomerLoadBalancer.
+func (mr *MockDynamicMockRecorder) ValidateCustomerLoadBalancer(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCustomerLoadBalancer", reflect.TypeOf((*MockDynamic)(nil).ValidateCustomerLoadBalancer), ctx, oc)
+}
+
+// ValidateDNSZone mocks base method.
+func (m *MockDynamic) ValidateDNSZone(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDNSZone", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateDNSZone indicates an expected call of ValidateDNSZone.
+func (mr *MockDynamicMockRecorder) ValidateDNSZone(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDNSZone", reflect.TypeOf((*MockDynamic)(nil).ValidateDNSZone), ctx, oc)
+}
+
 // ValidateDiskEncryptionSets mocks base method.
 func (m *MockDynamic) ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -103,6 +145,20 @@ func (mr *MockDynamicMockRecorder) ValidateEncryptionAtHost(ctx, oc interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateEncryptionAtHost", reflect.TypeOf((*MockDynamic)(nil).ValidateEncryptionAtHost), ctx, oc)
 }
 
+// ValidateIdentityProvider mocks base method.
+func (m *MockDynamic) ValidateIdentityProvider(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateIdentityProvider", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateIdentityProvider indicates an expected call of ValidateIdentityProvider.
+func (mr *MockDynamicMockRecorder) ValidateIdentityProvider(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateIdentityProvider", reflect.TypeOf((*MockDynamic)(nil).ValidateIdentityProvider), ctx, oc)
+}
+
 // ValidateLoadBalancerProfile mocks base method.
 func (m *MockDynamic) ValidateLoadBalancerProfile(ctx context.Context, oc *api.OpenShiftCluster) error {
 	m.ctrl.T.Helper()
@@ -117,6 +173,34 @@ func (mr *MockDynamicMockRecorder) ValidateLoadBalancerProfile(ctx, oc interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLoadBalancerProfile", reflect.TypeOf((*MockDynamic)(nil).ValidateLoadBalancerProfile), ctx, oc)
 }
 
+// ValidateLogForwarding mocks base method.
+func (m *MockDynamic) ValidateLogForwarding(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateLogForwarding", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateLogForwarding indicates an expected call of ValidateLogForwarding.
+func (mr *MockDynamicMockRecorder) ValidateLogForwarding(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLogForwarding", reflect.TypeOf((*MockDynamic)(nil).ValidateLogForwarding), ctx, oc)
+}
+
+// ValidatePolicyRestrictions mocks base method.
+func (m *MockDynamic) ValidatePolicyRestrictions(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePolicyRestrictions", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePolicyRestrictions indicates an expected call of ValidatePolicyRestrictions.
+func (mr *MockDynamicMockRecorder) ValidatePolicyRestrictions(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePolicyRestrictions", reflect.TypeOf((*MockDynamic)(nil).ValidatePolicyRestrictions), ctx, oc)
+}
+
 // ValidatePreConfiguredNSGs mocks base method.
 func (m *MockDynamic) ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
 	m.ctrl.T.Helper()
@@ -131,6 +215,34 @@ func (mr *MockDynamicMockRecorder) ValidatePreConfiguredNSGs(ctx, oc, subnets in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePreConfiguredNSGs", reflect.TypeOf((*MockDynamic)(nil).ValidatePreConfiguredNSGs), ctx, oc, subnets)
 }
 
+// ValidateProximityPlacementGroups mocks base method.
+func (m *MockDynamic) ValidateProximityPlacementGroups(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateProximityPlacementGroups", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateProximityPlacementGroups indicates an expected call of ValidateProximityPlacementGroups.
+func (mr *MockDynamicMockRecorder) ValidateProximityPlacementGroups(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateProximityPlacementGroups", reflect.TypeOf((*MockDynamic)(nil).ValidateProximityPlacementGroups), ctx, oc)
+}
+
+// ValidateRegistryMirrors mocks base method.
+func (m *MockDynamic) ValidateRegistryMirrors(ctx context.Context, oc *api.OpenShiftCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRegistryMirrors", ctx, oc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateRegistryMirrors indicates an expected call of ValidateRegistryMirrors.
+func (mr *MockDynamicMockRecorder) ValidateRegistryMirrors(ctx, oc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRegistryMirrors", reflect.TypeOf((*MockDynamic)(nil).ValidateRegistryMirrors), ctx, oc)
+}
+
 // ValidateServicePrincipal mocks base method.
 func (m *MockDynamic) ValidateServicePrincipal(ctx context.Context, spTokenCredential azcore.TokenCredential) error {
 	m.ctrl.T.Helper()
@@ -145,6 +257,20 @@ func (mr *MockDynamicMockRecorder) ValidateServicePrincipal(ctx, spTokenCredenti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateServicePrincipal", reflect.TypeOf((*MockDynamic)(nil).ValidateServicePrincipal), ctx, spTokenCredential)
 }
 
+// ValidateSubnetConflicts mocks base method.
+func (m *MockDynamic) ValidateSubnetConflicts(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSubnetConflicts", ctx, oc, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSubnetConflicts indicates an expected call of ValidateSubnetConflicts.
+func (mr *MockDynamicMockRecorder) ValidateSubnetConflicts(ctx, oc, subnets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSubnetConflicts", reflect.TypeOf((*MockDynamic)(nil).ValidateSubnetConflicts), ctx, oc, subnets)
+}
+
 // ValidateSubnets mocks base method.
 func (m *MockDynamic) ValidateSubnets(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
 	m.ctrl.T.Helper()
@@ -177,3 +303,17 @@ func (mr *MockDynamicMockRecorder) ValidateVnet(ctx, location, subnets interface
 	varargs := append([]interface{}{ctx, location, subnets}, additionalCIDRs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVnet", reflect.TypeOf((*MockDynamic)(nil).ValidateVnet), varargs...)
 }
+
+// ValidateVnetPeerings mocks base method.
+func (m *MockDynamic) ValidateVnetPeerings(ctx context.Context, oc *api.OpenShiftCluster, subnets []dynamic.Subnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVnetPeerings", ctx, oc, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVnetPeerings indicates an expected call of ValidateVnetPeerings.
+func (mr *MockDynamicMockRecorder) ValidateVnetPeerings(ctx, oc, subnets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVnetPeerings", reflect.TypeOf((*MockDynamic)(nil).ValidateVnetPeerings), ctx, oc, subnets)
+}
@@ -10,6 +10,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	dynamichelper "github.com/Azure/ARO-RP/pkg/util/dynamichelper"
 )
 
 // MockInterface is a mock of Interface interface.
@@ -35,6 +37,26 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// Diff mocks base method.
+func (m *MockInterface) Diff(arg0 context.Context, arg1 ...runtime.Object) ([]*dynamichelper.ObjectDiff, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Diff", varargs...)
+	ret0, _ := ret[0].([]*dynamichelper.ObjectDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockInterfaceMockRecorder) Diff(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockInterface)(nil).Diff), varargs...)
+}
+
 // Ensure mocks base method.
 func (m *MockInterface) Ensure(arg0 context.Context, arg1 ...runtime.Object) error {
 	m.ctrl.T.Helper()
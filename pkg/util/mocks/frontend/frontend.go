@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Azure/ARO-RP/pkg/frontend (interfaces: StreamResponder,QuotaValidator,SkuValidator,ProvidersValidator)
+// Source: github.com/Azure/ARO-RP/pkg/frontend (interfaces: StreamResponder,QuotaValidator,SkuValidator,ProvidersValidator,DynamicValidator)
 
 // Package mock_frontend is a generated GoMock package.
 package mock_frontend
@@ -102,6 +102,20 @@ func (mr *MockQuotaValidatorMockRecorder) ValidateQuota(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateQuota", reflect.TypeOf((*MockQuotaValidator)(nil).ValidateQuota), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// ValidateScaleQuota mocks base method.
+func (m *MockQuotaValidator) ValidateScaleQuota(arg0 context.Context, arg1 *azureclient.AROEnvironment, arg2 env.Interface, arg3, arg4 string, arg5 *api.OpenShiftCluster, arg6 []api.WorkerProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateScaleQuota", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateScaleQuota indicates an expected call of ValidateScaleQuota.
+func (mr *MockQuotaValidatorMockRecorder) ValidateScaleQuota(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateScaleQuota", reflect.TypeOf((*MockQuotaValidator)(nil).ValidateScaleQuota), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // MockSkuValidator is a mock of SkuValidator interface.
 type MockSkuValidator struct {
 	ctrl     *gomock.Controller
@@ -175,3 +189,41 @@ func (mr *MockProvidersValidatorMockRecorder) ValidateProviders(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateProviders", reflect.TypeOf((*MockProvidersValidator)(nil).ValidateProviders), arg0, arg1, arg2, arg3, arg4)
 }
+
+// MockDynamicValidator is a mock of DynamicValidator interface.
+type MockDynamicValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockDynamicValidatorMockRecorder
+}
+
+// MockDynamicValidatorMockRecorder is the mock recorder for MockDynamicValidator.
+type MockDynamicValidatorMockRecorder struct {
+	mock *MockDynamicValidator
+}
+
+// NewMockDynamicValidator creates a new mock instance.
+func NewMockDynamicValidator(ctrl *gomock.Controller) *MockDynamicValidator {
+	mock := &MockDynamicValidator{ctrl: ctrl}
+	mock.recorder = &MockDynamicValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDynamicValidator) EXPECT() *MockDynamicValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateDynamic mocks base method.
+func (m *MockDynamicValidator) ValidateDynamic(arg0 context.Context, arg1 env.Interface, arg2 *api.SubscriptionDocument, arg3 *api.OpenShiftCluster) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDynamic", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateDynamic indicates an expected call of ValidateDynamic.
+func (mr *MockDynamicValidatorMockRecorder) ValidateDynamic(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDynamic", reflect.TypeOf((*MockDynamicValidator)(nil).ValidateDynamic), arg0, arg1, arg2, arg3)
+}
@@ -9,6 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+
+	dynamichelper "github.com/Azure/ARO-RP/pkg/util/dynamichelper"
 )
 
 // MockOperator is a mock of Operator interface.
@@ -48,6 +50,21 @@ func (mr *MockOperatorMockRecorder) CreateOrUpdate(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockOperator)(nil).CreateOrUpdate), arg0)
 }
 
+// Diff mocks base method.
+func (m *MockOperator) Diff(arg0 context.Context) ([]*dynamichelper.ObjectDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", arg0)
+	ret0, _ := ret[0].([]*dynamichelper.ObjectDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockOperatorMockRecorder) Diff(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockOperator)(nil).Diff), arg0)
+}
+
 // IsReady mocks base method.
 func (m *MockOperator) IsReady(arg0 context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -6,7 +6,9 @@ package mock_storage
 
 import (
 	context "context"
+	url "net/url"
 	reflect "reflect"
+	time "time"
 
 	storage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
 	storage0 "github.com/Azure/azure-sdk-for-go/storage"
@@ -50,3 +52,18 @@ func (mr *MockManagerMockRecorder) BlobService(arg0, arg1, arg2, arg3, arg4 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlobService", reflect.TypeOf((*MockManager)(nil).BlobService), arg0, arg1, arg2, arg3, arg4)
 }
+
+// ContainerSAS mocks base method.
+func (m *MockManager) ContainerSAS(arg0 context.Context, arg1, arg2, arg3 string, arg4 storage.Permissions, arg5 time.Duration) (url.Values, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContainerSAS", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(url.Values)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContainerSAS indicates an expected call of ContainerSAS.
+func (mr *MockManagerMockRecorder) ContainerSAS(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContainerSAS", reflect.TypeOf((*MockManager)(nil).ContainerSAS), arg0, arg1, arg2, arg3, arg4, arg5)
+}
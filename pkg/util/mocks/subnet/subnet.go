@@ -80,6 +80,21 @@ func (mr *MockManagerMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockManager)(nil).Get), arg0, arg1)
 }
 
+// GetAddressUsage mocks base method.
+func (m *MockManager) GetAddressUsage(arg0 context.Context, arg1 string) (*subnet.AddressUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressUsage", arg0, arg1)
+	ret0, _ := ret[0].(*subnet.AddressUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressUsage indicates an expected call of GetAddressUsage.
+func (mr *MockManagerMockRecorder) GetAddressUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressUsage", reflect.TypeOf((*MockManager)(nil).GetAddressUsage), arg0, arg1)
+}
+
 // GetAll mocks base method.
 func (m *MockManager) GetAll(arg0 context.Context, arg1 []string) ([]*network.Subnet, error) {
 	m.ctrl.T.Helper()
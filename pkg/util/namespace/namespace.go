@@ -3,6 +3,8 @@ package namespace
 // Copyright (c) Microsoft Corporation.
 // Licensed under the Apache License 2.0.
 
+import "strings"
+
 // IsOpenShiftNamespace returns true if ns is a namespace in the defined hardcoded map.
 // We should only add new namespaces into this hardcoded list but never delete
 // the existing ones in the namespace list to avoid backward compatibility issues.
@@ -79,3 +81,14 @@ func IsOpenShiftNamespace(ns string) bool {
 	_, ok := nsmap[ns]
 	return ok
 }
+
+// IsPlatformNamespace returns true if ns is reserved for the platform: the
+// default and openshift namespaces, and any namespace prefixed with kube- or
+// openshift-.  Unlike IsOpenShiftNamespace it matches the namespaces of
+// optional operators and of future releases as well.
+func IsPlatformNamespace(ns string) bool {
+	return ns == "default" ||
+		ns == "openshift" ||
+		strings.HasPrefix(ns, "kube-") ||
+		strings.HasPrefix(ns, "openshift-")
+}
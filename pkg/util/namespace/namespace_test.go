@@ -77,3 +77,38 @@ func TestIsOpenShiftNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPlatformNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		namespace string
+		want      bool
+	}{
+		{
+			namespace: "default",
+			want:      true,
+		},
+		{
+			namespace: "kube-system",
+			want:      true,
+		},
+		{
+			namespace: "openshift-gitops",
+			want:      true,
+		},
+		{
+			namespace: "customer",
+			want:      false,
+		},
+		{
+			namespace: "kubeflow",
+			want:      false,
+		},
+	} {
+		t.Run(tt.namespace, func(t *testing.T) {
+			got := IsPlatformNamespace(tt.namespace)
+			if tt.want != got {
+				t.Error(got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package proxy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// EnvVars returns the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables which route the Go HTTP clients of a container through the
+// cluster-wide egress proxy.  Like ProxyFunc it uses the proxy status.  It
+// returns nil if proxy is nil or no proxy is configured.
+func EnvVars(proxy *configv1.Proxy) []corev1.EnvVar {
+	if proxy == nil || (proxy.Status.HTTPProxy == "" && proxy.Status.HTTPSProxy == "") {
+		return nil
+	}
+
+	var env []corev1.EnvVar
+	for _, v := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxy.Status.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: proxy.Status.HTTPSProxy},
+		{Name: "NO_PROXY", Value: proxy.Status.NoProxy},
+	} {
+		if v.Value != "" {
+			env = append(env, v)
+		}
+	}
+
+	return env
+}
+
+// SetEnvVars replaces the proxy environment variables of the container, in
+// either case, with env, leaving its other environment variables alone.  It
+// returns true if it changed the container.
+func SetEnvVars(c *corev1.Container, env []corev1.EnvVar) bool {
+	var want []corev1.EnvVar
+	for _, v := range c.Env {
+		switch v.Name {
+		case "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy":
+			continue
+		}
+		want = append(want, v)
+	}
+	want = append(want, env...)
+
+	if equality.Semantic.DeepEqual(c.Env, want) {
+		return false
+	}
+
+	c.Env = want
+	return true
+}
@@ -0,0 +1,112 @@
+package proxy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEnvVars(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		proxy *configv1.Proxy
+		want  []corev1.EnvVar
+	}{
+		{
+			name: "no proxy object",
+		},
+		{
+			name:  "no proxy configured",
+			proxy: &configv1.Proxy{Status: configv1.ProxyStatus{NoProxy: "10.128.0.0/14"}},
+		},
+		{
+			name: "proxy configured",
+			proxy: &configv1.Proxy{
+				Status: configv1.ProxyStatus{
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    ".cluster.local,10.128.0.0/14",
+				},
+			},
+			want: []corev1.EnvVar{
+				{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "NO_PROXY", Value: ".cluster.local,10.128.0.0/14"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EnvVars(tt.proxy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, wanted %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetEnvVars(t *testing.T) {
+	proxyEnv := []corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "NO_PROXY", Value: ".cluster.local"},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		env         []corev1.EnvVar
+		proxyEnv    []corev1.EnvVar
+		want        []corev1.EnvVar
+		wantChanged bool
+	}{
+		{
+			name: "no proxy, no env",
+		},
+		{
+			name:        "proxy added after the other variables",
+			env:         []corev1.EnvVar{{Name: "RP_MODE", Value: "development"}},
+			proxyEnv:    proxyEnv,
+			want:        append([]corev1.EnvVar{{Name: "RP_MODE", Value: "development"}}, proxyEnv...),
+			wantChanged: true,
+		},
+		{
+			name:     "unchanged",
+			env:      proxyEnv,
+			proxyEnv: proxyEnv,
+			want:     proxyEnv,
+		},
+		{
+			name: "stale and lower case variables replaced",
+			env: []corev1.EnvVar{
+				{Name: "http_proxy", Value: "http://old.example.com:3128"},
+				{Name: "HTTPS_PROXY", Value: "http://old.example.com:3128"},
+				{Name: "RP_MODE", Value: "development"},
+			},
+			proxyEnv:    proxyEnv,
+			want:        append([]corev1.EnvVar{{Name: "RP_MODE", Value: "development"}}, proxyEnv...),
+			wantChanged: true,
+		},
+		{
+			name: "proxy removed",
+			env: []corev1.EnvVar{
+				{Name: "RP_MODE", Value: "development"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+			},
+			want:        []corev1.EnvVar{{Name: "RP_MODE", Value: "development"}},
+			wantChanged: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &corev1.Container{Env: tt.env}
+
+			changed := SetEnvVars(c, tt.proxyEnv)
+			if changed != tt.wantChanged {
+				t.Errorf("got changed %v, wanted %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(c.Env, tt.want) {
+				t.Errorf("got %#v, wanted %#v", c.Env, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package proxy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// ProxyFunc returns a function suitable for http.Transport.Proxy which routes
+// requests through the cluster-wide egress proxy.  It uses the proxy status,
+// which the cluster network operator populates with the effective
+// httpProxy/httpsProxy and a noProxy list which includes the cluster
+// networks.  If proxy is nil or no proxy is configured, requests are sent
+// directly.
+func ProxyFunc(proxy *configv1.Proxy) func(*http.Request) (*url.URL, error) {
+	if proxy == nil {
+		return func(*http.Request) (*url.URL, error) { return nil, nil }
+	}
+
+	httpProxy := proxy.Status.HTTPProxy
+	httpsProxy := proxy.Status.HTTPSProxy
+	noProxy := strings.Split(proxy.Status.NoProxy, ",")
+
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL := httpProxy
+		if req.URL.Scheme == "https" {
+			proxyURL = httpsProxy
+		}
+
+		if proxyURL == "" || !useProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+
+		return parseProxyURL(proxyURL)
+	}
+}
+
+// parseProxyURL parses a proxy URL, defaulting the scheme to http as the
+// cluster network operator does
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	if !strings.Contains(proxyURL, "://") {
+		proxyURL = "http://" + proxyURL
+	}
+
+	return url.Parse(proxyURL)
+}
+
+// useProxy returns false if host matches an entry in noProxy.  Entries may be
+// "*", IP addresses, CIDRs or domain names; a domain name matches itself and
+// its subdomains, with or without a leading dot.  Loopback addresses are never
+// proxied.
+func useProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+
+		switch {
+		case entry == "":
+			continue
+
+		case entry == "*":
+			return false
+
+		case strings.Contains(entry, "/"):
+			_, cidr, err := net.ParseCIDR(entry)
+			if err == nil && ip != nil && cidr.Contains(ip) {
+				return false
+			}
+
+		case net.ParseIP(entry) != nil:
+			if ip != nil && ip.Equal(net.ParseIP(entry)) {
+				return false
+			}
+
+		default:
+			entry = strings.TrimPrefix(entry, ".")
+			if host == entry || strings.HasSuffix(host, "."+entry) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
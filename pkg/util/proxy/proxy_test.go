@@ -0,0 +1,102 @@
+package proxy
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"net/http"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestProxyFunc(t *testing.T) {
+	proxy := &configv1.Proxy{
+		Status: configv1.ProxyStatus{
+			HTTPProxy:  "http://proxy.example.com:3128",
+			HTTPSProxy: "proxy.example.com:3129",
+			NoProxy:    ".cluster.local, internal.example.com,10.128.0.0/14,172.30.0.1",
+		},
+	}
+
+	for _, tt := range []struct {
+		name  string
+		proxy *configv1.Proxy
+		url   string
+		want  string
+	}{
+		{
+			name: "no proxy configured",
+			url:  "https://management.azure.com/",
+		},
+		{
+			name:  "http",
+			proxy: proxy,
+			url:   "http://example.com/",
+			want:  "http://proxy.example.com:3128",
+		},
+		{
+			name:  "https, scheme defaulted",
+			proxy: proxy,
+			url:   "https://management.azure.com/",
+			want:  "http://proxy.example.com:3129",
+		},
+		{
+			name:  "no proxy domain",
+			proxy: proxy,
+			url:   "https://kubernetes.default.svc.cluster.local/",
+		},
+		{
+			name:  "no proxy subdomain",
+			proxy: proxy,
+			url:   "https://api.internal.example.com:6443/",
+		},
+		{
+			name:  "no proxy does not match other domains",
+			proxy: proxy,
+			url:   "https://notinternal.example.com/",
+			want:  "http://proxy.example.com:3129",
+		},
+		{
+			name:  "no proxy cidr",
+			proxy: proxy,
+			url:   "http://10.129.0.5:8080/",
+		},
+		{
+			name:  "no proxy ip",
+			proxy: proxy,
+			url:   "https://172.30.0.1/",
+		},
+		{
+			name:  "loopback",
+			proxy: proxy,
+			url:   "http://127.0.0.1:8080/",
+		},
+		{
+			name: "wildcard",
+			proxy: &configv1.Proxy{
+				Status: configv1.ProxyStatus{
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    "*",
+				},
+			},
+			url: "https://management.azure.com/",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ProxyFunc(tt.proxy)(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got == nil && tt.want != "" || got != nil && got.String() != tt.want {
+				t.Error(got)
+			}
+		})
+	}
+}
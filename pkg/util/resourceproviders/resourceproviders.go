@@ -0,0 +1,92 @@
+package resourceproviders
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/features"
+)
+
+// Required lists the Azure resource providers which must be registered in the
+// customer subscription for a cluster to be installed
+var Required = []string{
+	"Microsoft.Authorization",
+	"Microsoft.Compute",
+	"Microsoft.Network",
+	"Microsoft.Storage",
+}
+
+const (
+	stateRegistered  = "Registered"
+	stateRegistering = "Registering"
+)
+
+// Unregistered returns the required resource providers which are not
+// registered, together with the subset of those whose registration is already
+// in progress
+func Unregistered(ctx context.Context, providersClient features.ProvidersClient) (unregistered []string, registering map[string]bool, err error) {
+	providers, err := providersClient.List(ctx, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	states := make(map[string]string, len(providers))
+	for _, provider := range providers {
+		if provider.Namespace != nil && provider.RegistrationState != nil {
+			states[strings.ToLower(*provider.Namespace)] = *provider.RegistrationState
+		}
+	}
+
+	registering = map[string]bool{}
+	for _, provider := range Required {
+		state := states[strings.ToLower(provider)]
+		if state == stateRegistered {
+			continue
+		}
+
+		unregistered = append(unregistered, provider)
+		if state == stateRegistering {
+			registering[provider] = true
+		}
+	}
+
+	return unregistered, registering, nil
+}
+
+// Register requests registration of the given resource providers whose
+// registration is not already in progress, and returns those for which the
+// request failed, for example because we are not permitted to register
+// resource providers in the subscription
+func Register(ctx context.Context, log *logrus.Entry, providersClient features.ProvidersClient, unregistered []string, registering map[string]bool) (failed []string) {
+	for _, provider := range unregistered {
+		if registering[provider] {
+			continue
+		}
+
+		log.Printf("registering resource provider %s", provider)
+		_, err := providersClient.Register(ctx, provider)
+		if err != nil {
+			log.Warnf("failed to register resource provider %s: %v", provider, err)
+			failed = append(failed, provider)
+		}
+	}
+
+	return failed
+}
+
+// NotRegisteredError returns a CloudError listing the given unregistered
+// resource providers
+func NotRegisteredError(unregistered []string) error {
+	if len(unregistered) == 1 {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceProviderNotRegistered, "", "The resource provider '%s' is not registered.", unregistered[0])
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeResourceProviderNotRegistered, "", "The resource providers '%s' are not registered.", strings.Join(unregistered, "', '"))
+}
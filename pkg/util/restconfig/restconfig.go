@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 
 	machnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/rest"
@@ -51,17 +52,93 @@ func RestConfig(dialer proxy.Dialer, oc *api.OpenShiftCluster) (*rest.Config, er
 	return restconfig, nil
 }
 
+// DialContext returns a dial function which connects to the cluster API.  It
+// dials the API server private endpoint whatever the host of the address is,
+// as that is the only address of the cluster which is reachable from the RP
+// virtual network.  If the cluster has DNS overrides, the host is first
+// resolved using them, and the connection is refused if they resolve it to
+// any other address.
 func DialContext(dialer proxy.Dialer, oc *api.OpenShiftCluster) func(ctx context.Context, network, address string) (net.Conn, error) {
 	return func(ctx context.Context, network, address string) (net.Conn, error) {
 		if network != "tcp" {
 			return nil, fmt.Errorf("unimplemented network %q", network)
 		}
 
-		_, port, err := net.SplitHostPort(address)
+		host, port, err := net.SplitHostPort(address)
 		if err != nil {
 			return nil, err
 		}
 
-		return dialer.DialContext(ctx, network, oc.Properties.NetworkProfile.APIServerPrivateEndpointIP+":"+port)
+		ip := oc.Properties.NetworkProfile.APIServerPrivateEndpointIP
+		if oc.Properties.DNSOverrides != nil {
+			addrs, err := resolve(ctx, oc.Properties.DNSOverrides, host)
+			if err != nil {
+				return nil, err
+			}
+			if len(addrs) > 0 && !containsIP(addrs, ip) {
+				return nil, fmt.Errorf("%s resolves to %s using the DNS overrides, which is not the API server private endpoint %s", host, strings.Join(addrs, ", "), ip)
+			}
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
 	}
 }
+
+// containsIP returns true if one of addrs is the IP address ip
+func containsIP(addrs []string, ip string) bool {
+	for _, addr := range addrs {
+		if net.ParseIP(addr).Equal(net.ParseIP(ip)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the IP addresses of host according to the DNS overrides, or
+// nil if they do not cover host
+func resolve(ctx context.Context, overrides *api.DNSOverrides, host string) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return nil, nil
+	}
+
+	for _, h := range overrides.Hosts {
+		if strings.EqualFold(h.Hostname, host) {
+			return []string{h.IPAddress}, nil
+		}
+	}
+
+	if len(overrides.Nameservers) == 0 {
+		return nil, nil
+	}
+
+	var errs []string
+	for _, nameserver := range overrides.Nameservers {
+		addrs, err := lookupHost(ctx, nameserver, host)
+		if err == nil && len(addrs) > 0 {
+			return addrs, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("no addresses for %s", host)
+		}
+		errs = append(errs, fmt.Sprintf("nameserver %s: %v", nameserver, err))
+	}
+
+	return nil, fmt.Errorf("could not resolve %s using the DNS overrides: %s", host, strings.Join(errs, "; "))
+}
+
+// lookupHost resolves host using nameserver, which is an IP address with an
+// optional port
+var lookupHost = func(ctx context.Context, nameserver, host string) ([]string, error) {
+	if net.ParseIP(nameserver) != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, nameserver)
+		},
+	}
+
+	return r.LookupHost(ctx, host)
+}
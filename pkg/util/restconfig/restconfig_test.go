@@ -5,6 +5,7 @@ package restconfig
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -18,6 +19,8 @@ func TestDialContext(t *testing.T) {
 	for _, tt := range []struct {
 		name                       string
 		apiServerPrivateEndpointIP string
+		dnsOverrides               *api.DNSOverrides
+		lookupHost                 func(context.Context, string, string) ([]string, error)
 		dialNetwork                string
 		dialAddress                string
 		wantAddress                string
@@ -30,6 +33,104 @@ func TestDialContext(t *testing.T) {
 			dialNetwork:                "tcp",
 			wantAddress:                "10.0.4.6:6443",
 		},
+		{
+			name:                       "host override",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Hosts: []api.DNSHostOverride{
+					{Hostname: "API.cluster.example.com", IPAddress: "10.0.4.6"},
+				},
+				Nameservers: []string{"10.1.0.10"},
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantAddress: "10.0.4.6:6443",
+		},
+		{
+			name:                       "host override is not the private endpoint",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Hosts: []api.DNSHostOverride{
+					{Hostname: "api.cluster.example.com", IPAddress: "10.1.0.4"},
+				},
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantErr:     "api.cluster.example.com resolves to 10.1.0.4 using the DNS overrides, which is not the API server private endpoint 10.0.4.6",
+		},
+		{
+			name:                       "nameserver override",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Hosts: []api.DNSHostOverride{
+					{Hostname: "api-int.cluster.example.com", IPAddress: "10.1.0.4"},
+				},
+				Nameservers: []string{"10.1.0.10", "10.1.0.11:5353"},
+			},
+			lookupHost: func(ctx context.Context, nameserver, host string) ([]string, error) {
+				if nameserver == "10.1.0.10" {
+					return nil, errors.New("i/o timeout")
+				}
+				if nameserver != "10.1.0.11:5353" || host != "api.cluster.example.com" {
+					return nil, errors.New("unexpected lookup")
+				}
+				return []string{"fd00::4", "10.0.4.6"}, nil
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantAddress: "10.0.4.6:6443",
+		},
+		{
+			name:                       "nameserver override is not the private endpoint",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Nameservers: []string{"10.1.0.10"},
+			},
+			lookupHost: func(ctx context.Context, nameserver, host string) ([]string, error) {
+				return []string{"203.0.113.4", "10.1.0.5"}, nil
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantErr:     "api.cluster.example.com resolves to 203.0.113.4, 10.1.0.5 using the DNS overrides, which is not the API server private endpoint 10.0.4.6",
+		},
+		{
+			name:                       "nameserver override fails",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Nameservers: []string{"10.1.0.10", "10.1.0.11"},
+			},
+			lookupHost: func(ctx context.Context, nameserver, host string) ([]string, error) {
+				if nameserver == "10.1.0.10" {
+					return nil, errors.New("i/o timeout")
+				}
+				return nil, nil
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantErr:     "could not resolve api.cluster.example.com using the DNS overrides: nameserver 10.1.0.10: i/o timeout; nameserver 10.1.0.11: no addresses for api.cluster.example.com",
+		},
+		{
+			name:                       "overrides do not cover the host",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Hosts: []api.DNSHostOverride{
+					{Hostname: "api-int.cluster.example.com", IPAddress: "10.1.0.4"},
+				},
+			},
+			dialAddress: "api.cluster.example.com:6443",
+			dialNetwork: "tcp",
+			wantAddress: "10.0.4.6:6443",
+		},
+		{
+			name:                       "overrides are not used for IP addresses",
+			apiServerPrivateEndpointIP: "10.0.4.6",
+			dnsOverrides: &api.DNSOverrides{
+				Nameservers: []string{"10.1.0.10"},
+			},
+			dialAddress: "1.1.1.1:6443",
+			dialNetwork: "tcp",
+			wantAddress: "10.0.4.6:6443",
+		},
 		{
 			name:                       "invalid address",
 			apiServerPrivateEndpointIP: "10.0.4.6",
@@ -49,6 +150,16 @@ func TestDialContext(t *testing.T) {
 			controller := gomock.NewController(t)
 			defer controller.Finish()
 
+			oldLookupHost := lookupHost
+			defer func() { lookupHost = oldLookupHost }()
+			lookupHost = func(context.Context, string, string) ([]string, error) {
+				t.Fatal("unexpected lookup")
+				return nil, nil
+			}
+			if tt.lookupHost != nil {
+				lookupHost = tt.lookupHost
+			}
+
 			testCtx := context.WithValue(context.Background(), struct{ nonEmptyCtx string }{}, 1)
 
 			dialer := mock_proxy.NewMockDialer(controller)
@@ -61,6 +172,7 @@ func TestDialContext(t *testing.T) {
 					NetworkProfile: api.NetworkProfile{
 						APIServerPrivateEndpointIP: tt.apiServerPrivateEndpointIP,
 					},
+					DNSOverrides: tt.dnsOverrides,
 				},
 			}
 			dial := DialContext(dialer, oc)
@@ -0,0 +1,63 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Name returns the name of the step, by which its completion is recorded
+func Name(s Step) string {
+	return s.metricsName()
+}
+
+// OnCompletion returns a Step which runs s and then, if s succeeded, the
+// action function `f`, e.g. to record that s completed.  The Step has the same
+// name as s, so it can replace s without changing logs or metrics.
+func OnCompletion(s Step, f actionFunction) Step {
+	return completionStep{
+		Step: s,
+		f:    f,
+	}
+}
+
+type completionStep struct {
+	Step
+	f actionFunction
+}
+
+func (s completionStep) run(ctx context.Context, log *logrus.Entry) error {
+	err := s.Step.run(ctx, log)
+	if err != nil {
+		return err
+	}
+
+	return s.f(ctx)
+}
+
+// OnStart returns a Step which runs the action function `f`, e.g. to record
+// that s is running, and then, if f succeeded, s.  Like OnCompletion, the Step
+// has the same name as s.
+func OnStart(s Step, f actionFunction) Step {
+	return startStep{
+		Step: s,
+		f:    f,
+	}
+}
+
+type startStep struct {
+	Step
+	f actionFunction
+}
+
+func (s startStep) run(ctx context.Context, log *logrus.Entry) error {
+	err := s.f(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Step.run(ctx, log)
+}
@@ -0,0 +1,115 @@
+package steps
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestOnCompletion(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		step          Step
+		wantCompleted bool
+		wantErr       string
+	}{
+		{
+			name:          "successful step is completed",
+			step:          Action(successfulFunc),
+			wantCompleted: true,
+		},
+		{
+			name:    "failing step is not completed",
+			step:    Action(failingFunc),
+			wantErr: "oh no!",
+		},
+		{
+			name:          "successful condition is completed",
+			step:          Condition(alwaysTrueCondition, time.Second, true),
+			wantCompleted: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var completed bool
+			s := OnCompletion(tt.step, func(context.Context) error {
+				completed = true
+				return nil
+			})
+
+			if s.String() != tt.step.String() {
+				t.Errorf("got name %s, want %s", s, tt.step)
+			}
+			if Name(s) != Name(tt.step) {
+				t.Errorf("got metrics name %s, want %s", Name(s), Name(tt.step))
+			}
+
+			err := s.run(context.Background(), logrus.NewEntry(logrus.StandardLogger()))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if completed != tt.wantCompleted {
+				t.Errorf("got completed %t, want %t", completed, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestOnStart(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		step        Step
+		startErr    error
+		wantStarted bool
+		wantErr     string
+	}{
+		{
+			name:        "step is started",
+			step:        Action(successfulFunc),
+			wantStarted: true,
+		},
+		{
+			name:        "failing step is started",
+			step:        Action(failingFunc),
+			wantStarted: true,
+			wantErr:     "oh no!",
+		},
+		{
+			name:     "step is not run if the start action fails",
+			step:     Action(failingFunc),
+			startErr: errors.New("could not start"),
+			wantErr:  "could not start",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var started bool
+			s := OnStart(tt.step, func(context.Context) error {
+				if tt.startErr != nil {
+					return tt.startErr
+				}
+				started = true
+				return nil
+			})
+
+			if s.String() != tt.step.String() {
+				t.Errorf("got name %s, want %s", s, tt.step)
+			}
+			if Name(s) != Name(tt.step) {
+				t.Errorf("got metrics name %s, want %s", Name(s), Name(tt.step))
+			}
+
+			err := s.run(context.Background(), logrus.NewEntry(logrus.StandardLogger()))
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if started != tt.wantStarted {
+				t.Errorf("got started %t, want %t", started, tt.wantStarted)
+			}
+		})
+	}
+}
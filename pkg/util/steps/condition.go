@@ -93,6 +93,10 @@ func (c conditionStep) run(ctx context.Context, log *logrus.Entry) error {
 		return cnd, cndErr
 	}, timeoutCtx.Done())
 
+	// timeoutCtx is also done when ctx is: don't report that as a timeout
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err != nil && !c.fail {
 		log.Warnf("step %s failed but has configured 'fail=%t'. Continuing. Error: %s", c, c.fail, err.Error())
 		return nil
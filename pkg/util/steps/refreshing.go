@@ -62,7 +62,7 @@ func (s *authorizationRefreshingActionStep) run(ctx context.Context, log *logrus
 	// step is called again after runner.pollInterval. If we have timed out or
 	// any other error is returned, the error from the step is returned
 	// directly.
-	return wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
 		// We use the outer context, not the timeout context, as we do not want
 		// to time out the condition function itself, only stop retrying once
 		// timeoutCtx's timeout has fired.
@@ -83,6 +83,12 @@ func (s *authorizationRefreshingActionStep) run(ctx context.Context, log *logrus
 		}
 		return true, err
 	}, timeoutCtx.Done())
+
+	// timeoutCtx is also done when ctx is: don't report that as a timeout
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
 }
 
 func (s *authorizationRefreshingActionStep) String() string {
@@ -5,6 +5,8 @@ package steps
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
@@ -14,6 +16,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	msgraph_errors "github.com/Azure/ARO-RP/pkg/util/graph/graphsdk/models/odataerrors"
+	"github.com/Azure/ARO-RP/pkg/util/tracing"
 )
 
 // FriendlyName returns a "friendly" stringified name of the given func.
@@ -41,16 +44,54 @@ type Step interface {
 	metricsName() string
 }
 
+// CancelledError is returned by Run when its context is cancelled or times
+// out, so that an aborted run can be told apart from a failed step.  It wraps
+// the error of the context.
+type CancelledError struct {
+	step Step
+	err  error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("step %s was cancelled: %v", e.step, e.err)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.err
+}
+
+// IsCancelled returns true if err was returned by Run because its context was
+// done
+func IsCancelled(err error) bool {
+	var cancelledErr *CancelledError
+	return errors.As(err, &cancelledErr)
+}
+
 // Run executes the provided steps in order until one fails or all steps
-// are completed. Errors from failed steps are returned directly.
+// are completed. Errors from failed steps are returned directly.  If ctx is
+// done, no further steps are run and a *CancelledError is returned.
 // time cost for each step run will be recorded for metrics usage
 func Run(ctx context.Context, log *logrus.Entry, pollInterval time.Duration, steps []Step, now func() time.Time) (map[string]int64, error) {
 	stepTimeRun := make(map[string]int64)
 	for _, step := range steps {
+		if ctx.Err() != nil {
+			log.Warnf("not running step %s: %s", step, ctx.Err())
+			return nil, &CancelledError{step: step, err: ctx.Err()}
+		}
+
 		log.Infof("running step %s", step)
 
 		startTime := time.Now()
-		err := step.run(ctx, log)
+		stepCtx, span := tracing.Tracer().Start(ctx, "step "+step.metricsName())
+		err := step.run(stepCtx, log)
+		tracing.EndSpan(span, err)
+
+		// A step aborted by the context may return any error, or none, so
+		// look at the context rather than at the error
+		if ctx.Err() != nil {
+			log.Warnf("step %s was cancelled: %s", step, ctx.Err())
+			return nil, &CancelledError{step: step, err: ctx.Err()}
+		}
 
 		if err != nil {
 			log.Errorf("step %s encountered error: %s", step, err.Error())
@@ -277,3 +277,144 @@ func TestStepMetricsNameFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestStepRunnerCancellation(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		step        func(cancel context.CancelFunc) Step
+		wantEntries []map[string]types.GomegaMatcher
+	}{
+		{
+			name: "An Action which observes the cancelled context stops the run",
+			step: func(cancel context.CancelFunc) Step {
+				return Action(func(ctx context.Context) error {
+					cancel()
+					<-ctx.Done()
+					return ctx.Err()
+				})
+			},
+			wantEntries: []map[string]types.GomegaMatcher{
+				{
+					"msg":   gomega.MatchRegexp(`^running step \[Action .*TestStepRunnerCancellation.*\]$`),
+					"level": gomega.Equal(logrus.InfoLevel),
+				},
+				{
+					"msg":   gomega.MatchRegexp(`^step \[Action .*TestStepRunnerCancellation.*\] was cancelled: context canceled$`),
+					"level": gomega.Equal(logrus.WarnLevel),
+				},
+			},
+		},
+		{
+			name: "A Condition stops polling when the context is cancelled",
+			step: func(cancel context.CancelFunc) Step {
+				return &conditionStep{
+					f: func(ctx context.Context) (bool, error) {
+						cancel()
+						return false, nil
+					},
+					fail:         true,
+					pollInterval: time.Hour,
+					timeout:      time.Hour,
+				}
+			},
+			wantEntries: []map[string]types.GomegaMatcher{
+				{
+					"msg":   gomega.MatchRegexp(`^running step \[Condition .*TestStepRunnerCancellation.*, timeout 1h0m0s\]$`),
+					"level": gomega.Equal(logrus.InfoLevel),
+				},
+				{
+					"msg":   gomega.MatchRegexp(`^step \[Condition .*TestStepRunnerCancellation.*, timeout 1h0m0s\] was cancelled: context canceled$`),
+					"level": gomega.Equal(logrus.WarnLevel),
+				},
+			},
+		},
+		{
+			name: "A Condition with fail=false does not continue when the context is cancelled",
+			step: func(cancel context.CancelFunc) Step {
+				return &conditionStep{
+					f: func(ctx context.Context) (bool, error) {
+						cancel()
+						return false, nil
+					},
+					fail:         false,
+					pollInterval: time.Hour,
+					timeout:      time.Hour,
+				}
+			},
+			wantEntries: []map[string]types.GomegaMatcher{
+				{
+					"msg":   gomega.MatchRegexp(`^running step \[Condition .*TestStepRunnerCancellation.*, timeout 1h0m0s\]$`),
+					"level": gomega.Equal(logrus.InfoLevel),
+				},
+				{
+					"msg":   gomega.MatchRegexp(`^step \[Condition .*TestStepRunnerCancellation.*, timeout 1h0m0s\] was cancelled: context canceled$`),
+					"level": gomega.Equal(logrus.WarnLevel),
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			h, log := testlog.New()
+
+			var ranAfterCancel bool
+			steps := []Step{
+				tt.step(cancel),
+				Action(func(context.Context) error {
+					ranAfterCancel = true
+					return nil
+				}),
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := Run(ctx, log, 25*time.Millisecond, steps, currentTimeFunc)
+				done <- err
+			}()
+
+			var err error
+			select {
+			case err = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("run did not terminate after the context was cancelled")
+			}
+
+			if !IsCancelled(err) {
+				t.Errorf("expected a cancelled error, got %v", err)
+			}
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected error to wrap %v, got %v", context.Canceled, err)
+			}
+			if ranAfterCancel {
+				t.Error("step after cancellation was run")
+			}
+
+			err = testlog.AssertLoggingOutput(h, tt.wantEntries)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestStepRunnerAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	h, log := testlog.New()
+
+	_, err := Run(ctx, log, 25*time.Millisecond, []Step{Action(failingFunc)}, currentTimeFunc)
+	utilerror.AssertErrorMessage(t, err, "step [Action github.com/Azure/ARO-RP/pkg/util/steps.failingFunc] was cancelled: context canceled")
+
+	err = testlog.AssertLoggingOutput(h, []map[string]types.GomegaMatcher{
+		{
+			"msg":   gomega.Equal("not running step [Action github.com/Azure/ARO-RP/pkg/util/steps.failingFunc]: context canceled"),
+			"level": gomega.Equal(logrus.WarnLevel),
+		},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	azstorage "github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/Azure/ARO-RP/pkg/env"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage"
@@ -19,6 +20,7 @@ import (
 
 type Manager interface {
 	BlobService(ctx context.Context, resourceGroup, account string, p mgmtstorage.Permissions, r mgmtstorage.SignedResourceTypes) (*azstorage.BlobStorageClient, error)
+	ContainerSAS(ctx context.Context, resourceGroup, account, container string, p mgmtstorage.Permissions, validity time.Duration) (url.Values, error)
 }
 
 type manager struct {
@@ -56,3 +58,23 @@ func (m *manager) BlobService(ctx context.Context, resourceGroup, account string
 
 	return &blobcli, nil
 }
+
+// ContainerSAS returns a service SAS token which grants p on the named
+// container only, and expires after validity.  It is meant for tokens which
+// are handed to the cluster, unlike the account SAS used by BlobService.
+func (m *manager) ContainerSAS(ctx context.Context, resourceGroup, account, container string, p mgmtstorage.Permissions, validity time.Duration) (url.Values, error) {
+	t := time.Now().UTC().Truncate(time.Second)
+	res, err := m.storageAccounts.ListServiceSAS(ctx, resourceGroup, account, mgmtstorage.ServiceSasParameters{
+		CanonicalizedResource:  to.StringPtr("/blob/" + account + "/" + container),
+		Resource:               mgmtstorage.SignedResourceC,
+		Permissions:            p,
+		Protocols:              mgmtstorage.HTTPS,
+		SharedAccessStartTime:  &date.Time{Time: t},
+		SharedAccessExpiryTime: &date.Time{Time: t.Add(validity)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return url.ParseQuery(*res.ServiceSasToken)
+}
@@ -0,0 +1,42 @@
+package subnet
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// APIServerSecurityRuleName is the name of the NSG rule which allows inbound
+// traffic to the API server load balancer
+const APIServerSecurityRuleName = "apiserver_in"
+
+// RequiredSecurityRules returns the security rules which the RP programs on
+// the NSG of architecture version 2 clusters.  The API server load balancer
+// health probe targets the same port as apiserver_in; for private clusters
+// it is allowed by the AllowAzureLoadBalancerInBound default rule, which
+// cannot be removed.
+func RequiredSecurityRules(visibility api.Visibility) []mgmtnetwork.SecurityRule {
+	if visibility != api.VisibilityPublic {
+		return nil
+	}
+
+	return []mgmtnetwork.SecurityRule{
+		{
+			SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
+				Protocol:                 mgmtnetwork.SecurityRuleProtocolTCP,
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationPortRange:     to.StringPtr("6443"),
+				SourceAddressPrefix:      to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr("*"),
+				Access:                   mgmtnetwork.SecurityRuleAccessAllow,
+				Priority:                 to.Int32Ptr(120),
+				Direction:                mgmtnetwork.SecurityRuleDirectionInbound,
+			},
+			Name: to.StringPtr(APIServerSecurityRuleName),
+		},
+	}
+}
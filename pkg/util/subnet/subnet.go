@@ -23,10 +23,27 @@ type Subnet struct {
 	IsMaster   bool
 }
 
+// AddressUsage is the number of usable addresses of a subnet, i.e. excluding
+// those reserved by Azure, and how many of them are allocated
+type AddressUsage struct {
+	Total int
+	Used  int
+}
+
+// Free returns the number of addresses which can still be allocated
+func (u *AddressUsage) Free() int {
+	return u.Total - u.Used
+}
+
+// azureReservedAddresses is the number of addresses Azure reserves in every
+// subnet: the first four and the broadcast address
+const azureReservedAddresses = 5
+
 type Manager interface {
 	Get(ctx context.Context, subnetID string) (*mgmtnetwork.Subnet, error)
 	GetAll(ctx context.Context, subnetIds []string) ([]*mgmtnetwork.Subnet, error)
 	GetHighestFreeIP(ctx context.Context, subnetID string) (string, error)
+	GetAddressUsage(ctx context.Context, subnetID string) (*AddressUsage, error)
 	CreateOrUpdate(ctx context.Context, subnetID string, subnet *mgmtnetwork.Subnet) error
 	CreateOrUpdateFromIds(ctx context.Context, subnetIds []string, gatewayEnabled bool) error
 }
@@ -108,6 +125,37 @@ func (m *manager) GetHighestFreeIP(ctx context.Context, subnetID string) (string
 	return "", nil
 }
 
+// GetAddressUsage returns the number of usable and allocated addresses of the
+// linked subnet.  Every IP configuration using the subnet holds an address.
+func (m *manager) GetAddressUsage(ctx context.Context, subnetID string) (*AddressUsage, error) {
+	subnet, err := m.get(ctx, subnetID, "ipConfigurations")
+	if err != nil {
+		return nil, err
+	}
+
+	_, subnetCIDR, err := net.ParseCIDR(*subnet.AddressPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &AddressUsage{}
+	if addresses := int(cidr.AddressCount(subnetCIDR)); addresses > azureReservedAddresses {
+		usage.Total = addresses - azureReservedAddresses
+	}
+
+	allocated := map[string]struct{}{}
+	if subnet.IPConfigurations != nil {
+		for _, ipconfig := range *subnet.IPConfigurations {
+			if ipconfig.IPConfigurationPropertiesFormat != nil && ipconfig.PrivateIPAddress != nil {
+				allocated[*ipconfig.PrivateIPAddress] = struct{}{}
+			}
+		}
+	}
+	usage.Used = len(allocated)
+
+	return usage, nil
+}
+
 // CreateOrUpdate updates the linked subnet
 func (m *manager) CreateOrUpdate(ctx context.Context, subnetID string, subnet *mgmtnetwork.Subnet) error {
 	vnetID, subnetName, err := apisubnet.Split(subnetID)
@@ -194,6 +194,91 @@ func TestGetGetHighestFreeIP(t *testing.T) {
 	}
 }
 
+func TestGetAddressUsage(t *testing.T) {
+	ctx := context.Background()
+
+	type test struct {
+		name      string
+		mocks     func(*test, *mock_network.MockSubnetsClient)
+		wantUsage *AddressUsage
+		wantErr   string
+	}
+
+	for _, tt := range []*test{
+		{
+			name: "unused",
+			mocks: func(tt *test, subnets *mock_network.MockSubnetsClient) {
+				subnets.EXPECT().
+					Get(ctx, "vnetResourceGroup", "vnet", "subnet", "ipConfigurations").
+					Return(mgmtnetwork.Subnet{
+						SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+							AddressPrefix: to.StringPtr("10.0.0.0/27"),
+						},
+					}, nil)
+			},
+			wantUsage: &AddressUsage{Total: 27},
+		},
+		{
+			name: "used, with a configuration without an address",
+			mocks: func(tt *test, subnets *mock_network.MockSubnetsClient) {
+				subnets.EXPECT().
+					Get(ctx, "vnetResourceGroup", "vnet", "subnet", "ipConfigurations").
+					Return(mgmtnetwork.Subnet{
+						SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+							AddressPrefix: to.StringPtr("10.0.0.0/27"),
+							IPConfigurations: &[]mgmtnetwork.IPConfiguration{
+								{
+									IPConfigurationPropertiesFormat: &mgmtnetwork.IPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("10.0.0.4"),
+									},
+								},
+								{
+									IPConfigurationPropertiesFormat: &mgmtnetwork.IPConfigurationPropertiesFormat{
+										PrivateIPAddress: to.StringPtr("10.0.0.5"),
+									},
+								},
+								{
+									IPConfigurationPropertiesFormat: &mgmtnetwork.IPConfigurationPropertiesFormat{},
+								},
+							},
+						},
+					}, nil)
+			},
+			wantUsage: &AddressUsage{Total: 27, Used: 2},
+		},
+		{
+			name: "broken",
+			mocks: func(tt *test, subnets *mock_network.MockSubnetsClient) {
+				subnets.EXPECT().
+					Get(ctx, "vnetResourceGroup", "vnet", "subnet", "ipConfigurations").
+					Return(mgmtnetwork.Subnet{}, fmt.Errorf("broken"))
+			},
+			wantErr: "broken",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			subnets := mock_network.NewMockSubnetsClient(controller)
+			if tt.mocks != nil {
+				tt.mocks(tt, subnets)
+			}
+
+			m := &manager{
+				subnets: subnets,
+			}
+
+			usage, err := m.GetAddressUsage(ctx, "/subscriptions/subscriptionId/resourceGroups/vnetResourceGroup/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet")
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+
+			if !reflect.DeepEqual(usage, tt.wantUsage) {
+				t.Error(usage)
+			}
+		})
+	}
+}
+
 func TestCreateOrUpdate(t *testing.T) {
 	ctx := context.Background()
 
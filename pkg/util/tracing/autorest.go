@@ -0,0 +1,81 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+
+	autoresttracing "github.com/Azure/go-autorest/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ autoresttracing.Tracer = (*autorestTracer)(nil)
+
+type autorestTracer struct {
+	next autoresttracing.Tracer
+}
+
+// NewAutorestTracer returns an autorest tracer which starts a client span for
+// each Azure SDK call and propagates the trace to Azure in the traceparent
+// header, then passes the call on to next, if set.  autorest supports only
+// one registered tracer, so this wraps the metrics tracer.
+func NewAutorestTracer(next autoresttracing.Tracer) autoresttracing.Tracer {
+	return &autorestTracer{
+		next: next,
+	}
+}
+
+func (t *autorestTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	var rt http.RoundTripper = base
+	if t.next != nil {
+		rt = t.next.NewTransport(base)
+	}
+
+	return &transport{RoundTripper: rt}
+}
+
+func (t *autorestTracer) StartSpan(ctx context.Context, name string) context.Context {
+	ctx, _ = Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+
+	if t.next != nil {
+		ctx = t.next.StartSpan(ctx, name)
+	}
+
+	return ctx
+}
+
+func (t *autorestTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	if t.next != nil {
+		t.next.EndSpan(ctx, httpStatusCode, err)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if httpStatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", httpStatusCode))
+	}
+	if err == nil && httpStatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(httpStatusCode))
+	}
+	EndSpan(span, err)
+}
+
+// transport adds the traceparent header of the span in the request context
+// to outgoing requests
+type transport struct {
+	http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if trace.SpanContextFromContext(req.Context()).IsValid() {
+		// RoundTrippers mustn't modify the request
+		req = req.Clone(req.Context())
+		propagation.TraceContext{}.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	}
+
+	return t.RoundTripper.RoundTrip(req)
+}
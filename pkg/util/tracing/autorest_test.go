@@ -0,0 +1,107 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeTracer struct {
+	started, ended int
+	statusCode     int
+}
+
+func (t *fakeTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return base
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name string) context.Context {
+	t.started++
+	return ctx
+}
+
+func (t *fakeTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	t.ended++
+	t.statusCode = httpStatusCode
+}
+
+func TestAutorestTracer(t *testing.T) {
+	tp, err := NewTracerProvider(logrus.NewEntry(logrus.StandardLogger()), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotTraceParent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceParent = r.Header.Get("Traceparent")
+	}))
+	defer ts.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "step")
+
+	next := &fakeTracer{}
+	at := NewAutorestTracer(next)
+
+	// the global tracer provider is the no-op provider in tests, which
+	// propagates the parent span rather than starting a new one
+	ctx = at.StartSpan(ctx, "network.VirtualNetworksClient.Get")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli := &http.Client{Transport: at.NewTransport(http.DefaultTransport.(*http.Transport))}
+	resp, err := cli.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if req.Header.Get("Traceparent") != "" {
+		t.Error("request was modified")
+	}
+	if gotTraceParent != TraceParent(ctx) {
+		t.Errorf("got traceparent %q, want %q", gotTraceParent, TraceParent(ctx))
+	}
+	if TraceID(gotTraceParent) != parent.SpanContext().TraceID().String() {
+		t.Error("traceparent is not in the parent span's trace")
+	}
+
+	at.EndSpan(ctx, http.StatusNotFound, errors.New("not found"))
+
+	if next.started != 1 || next.ended != 1 || next.statusCode != http.StatusNotFound {
+		t.Errorf("next tracer: %#v", next)
+	}
+}
+
+func TestTransportWithoutSpan(t *testing.T) {
+	var gotTraceParent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceParent = r.Header.Get("Traceparent")
+	}))
+	defer ts.Close()
+
+	cli := &http.Client{Transport: NewAutorestTracer(nil).NewTransport(http.DefaultTransport.(*http.Transport))}
+	resp, err := cli.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if gotTraceParent != "" {
+		t.Error(gotTraceParent)
+	}
+
+	if trace.SpanContextFromContext(context.Background()).IsValid() {
+		t.Error("background context has a span")
+	}
+}
@@ -0,0 +1,294 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "aro-rp"
+
+	exportQueueSize = 4096
+	exportBatchSize = 256
+	exportInterval  = 5 * time.Second
+	exportTimeout   = 10 * time.Second
+)
+
+var _ trace.TracerProvider = (*TracerProvider)(nil)
+
+// TracerProvider is a minimal OpenTelemetry tracer provider.  It always
+// generates trace and span IDs, so that the trace ID can be logged for
+// correlation even when no collector is configured.  If a collector URL is
+// set, finished spans are exported to it in the Zipkin v2 JSON format, which
+// the OpenTelemetry collector's zipkin receiver accepts.
+type TracerProvider struct {
+	log          *logrus.Entry
+	collectorURL string
+	cli          *http.Client
+
+	spans chan *span
+	now   func() time.Time
+}
+
+// NewTracerProvider returns a new TracerProvider which exports spans to
+// collectorURL, typically the value of OTEL_EXPORTER_ZIPKIN_ENDPOINT.  If
+// collectorURL is empty, spans are not exported.
+func NewTracerProvider(log *logrus.Entry, collectorURL string) (*TracerProvider, error) {
+	if collectorURL != "" {
+		u, err := url.Parse(collectorURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid trace collector URL %q", collectorURL)
+		}
+	}
+
+	return &TracerProvider{
+		log:          log,
+		collectorURL: collectorURL,
+		cli: &http.Client{
+			Timeout: exportTimeout,
+		},
+		spans: make(chan *span, exportQueueSize),
+		now:   time.Now,
+	}, nil
+}
+
+// Tracer returns a tracer which starts spans of this TracerProvider
+func (tp *TracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return &tracer{tp: tp, name: name}
+}
+
+// Run exports the finished spans to the collector in batches until stop is
+// closed, then exports the spans which are still queued.  If no collector is
+// configured it returns immediately.
+func (tp *TracerProvider) Run(stop <-chan struct{}) {
+	if tp.collectorURL == "" {
+		return
+	}
+
+	t := time.NewTicker(exportInterval)
+	defer t.Stop()
+
+	batch := make([]*span, 0, exportBatchSize)
+
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		err := tp.export(context.Background(), batch)
+		if err != nil {
+			tp.log.Warnf("failed to export %d spans: %v", len(batch), err)
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case s := <-tp.spans:
+			batch = append(batch, s)
+			if len(batch) == exportBatchSize {
+				export()
+			}
+
+		case <-t.C:
+			export()
+
+		case <-stop:
+			for {
+				select {
+				case s := <-tp.spans:
+					batch = append(batch, s)
+					if len(batch) == exportBatchSize {
+						export()
+					}
+				default:
+					export()
+					return
+				}
+			}
+		}
+	}
+}
+
+// enqueue queues a finished span for export.  Spans are dropped rather than
+// blocking the caller if the collector can't keep up.
+func (tp *TracerProvider) enqueue(s *span) {
+	if tp.collectorURL == "" {
+		return
+	}
+
+	select {
+	case tp.spans <- s:
+	default:
+		tp.log.Debugf("trace export queue full, dropping span %s", s.name)
+	}
+}
+
+type tracer struct {
+	tp   *TracerProvider
+	name string
+}
+
+// Start starts a span which is a child of the span in ctx, or the root of a
+// new trace if ctx has no valid span
+func (t *tracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(options...)
+
+	parent := trace.SpanContextFromContext(ctx)
+	if config.NewRoot() {
+		parent = trace.SpanContext{}
+	}
+
+	traceID := parent.TraceID()
+	if !parent.IsValid() {
+		traceID = newTraceID()
+	}
+
+	s := &span{
+		tp:   t.tp,
+		name: name,
+		sc: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     newSpanID(),
+			TraceFlags: trace.FlagsSampled,
+			TraceState: parent.TraceState(),
+		}),
+		kind:       config.SpanKind(),
+		start:      config.Timestamp(),
+		attributes: config.Attributes(),
+	}
+	if parent.IsValid() {
+		s.parentID = parent.SpanID()
+	}
+	if s.start.IsZero() {
+		s.start = t.tp.now()
+	}
+
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type event struct {
+	time time.Time
+	name string
+}
+
+type span struct {
+	tp *TracerProvider
+
+	mu         sync.Mutex
+	name       string
+	sc         trace.SpanContext
+	parentID   trace.SpanID
+	kind       trace.SpanKind
+	start      time.Time
+	end        time.Time
+	attributes []attribute.KeyValue
+	events     []event
+	status     codes.Code
+	statusDesc string
+}
+
+var _ trace.Span = (*span)(nil)
+
+func (s *span) End(options ...trace.SpanEndOption) {
+	config := trace.NewSpanEndConfig(options...)
+
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	s.end = config.Timestamp()
+	if s.end.IsZero() {
+		s.end = s.tp.now()
+	}
+	s.mu.Unlock()
+
+	s.tp.enqueue(s)
+}
+
+func (s *span) AddEvent(name string, options ...trace.EventOption) {
+	config := trace.NewEventConfig(options...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event{time: config.Timestamp(), name: name})
+}
+
+func (s *span) IsRecording() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.end.IsZero()
+}
+
+func (s *span) RecordError(err error, options ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+
+	s.AddEvent("exception: "+err.Error(), options...)
+}
+
+func (s *span) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+func (s *span) SetStatus(code codes.Code, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// as in the OpenTelemetry SDK, Ok is final and Unset doesn't override
+	if s.status == codes.Ok || code < s.status {
+		return
+	}
+
+	s.status = code
+	s.statusDesc = ""
+	if code == codes.Error {
+		s.statusDesc = description
+	}
+}
+
+func (s *span) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = name
+}
+
+func (s *span) SetAttributes(kv ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *span) TracerProvider() trace.TracerProvider {
+	return s.tp
+}
+
+func newTraceID() (id trace.TraceID) {
+	_, _ = rand.Read(id[:])
+	return
+}
+
+func newSpanID() (id trace.SpanID) {
+	_, _ = rand.Read(id[:])
+	return
+}
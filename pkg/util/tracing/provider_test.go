@@ -0,0 +1,221 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		collectorURL string
+		wantErr      string
+	}{
+		{
+			name: "no collector",
+		},
+		{
+			name:         "collector",
+			collectorURL: "http://localhost:9411/api/v2/spans",
+		},
+		{
+			name:         "no scheme",
+			collectorURL: "localhost:9411",
+			wantErr:      `invalid trace collector URL "localhost:9411"`,
+		},
+		{
+			name:         "no host",
+			collectorURL: "https:///api/v2/spans",
+			wantErr:      `invalid trace collector URL "https:///api/v2/spans"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTracerProvider(logrus.NewEntry(logrus.StandardLogger()), tt.collectorURL)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTracerStart(t *testing.T) {
+	tp, err := NewTracerProvider(logrus.NewEntry(logrus.StandardLogger()), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer := tp.Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	if !root.SpanContext().IsValid() {
+		t.Fatal("root span context is invalid")
+	}
+	if root.(*span).parentID.IsValid() {
+		t.Error("root span has a parent")
+	}
+
+	_, child := tracer.Start(ctx, "child")
+	if child.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Error("child span is in a different trace")
+	}
+	if child.(*span).parentID != root.SpanContext().SpanID() {
+		t.Error("child span's parent is not the root span")
+	}
+
+	_, newRoot := tracer.Start(ctx, "new root", trace.WithNewRoot())
+	if newRoot.SpanContext().TraceID() == root.SpanContext().TraceID() {
+		t.Error("new root span is in the same trace")
+	}
+
+	// a remote parent, as the backend sees it
+	remoteCtx := ContextWithTraceParent(context.Background(), TraceParent(ctx))
+	_, remoteChild := tracer.Start(remoteCtx, "remote child")
+	if remoteChild.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Error("remote child span is in a different trace")
+	}
+	if remoteChild.(*span).parentID != root.SpanContext().SpanID() {
+		t.Error("remote child span's parent is not the root span")
+	}
+
+	if !root.IsRecording() {
+		t.Error("span is not recording before End")
+	}
+	root.End()
+	if root.IsRecording() {
+		t.Error("span is recording after End")
+	}
+}
+
+func TestExport(t *testing.T) {
+	var got []zipkinSpan
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/spans" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	tp, err := NewTracerProvider(logrus.NewEntry(logrus.StandardLogger()), ts.URL+"/api/v2/spans")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Unix(1000, 0)
+	tp.now = func() time.Time { return start.Add(1500 * time.Microsecond) }
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "backend Creating",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attribute.String("resource_id", "/subscriptions/sub")),
+	)
+	_, child := tp.Tracer("test").Start(ctx, "step action.ensureResourceGroup", trace.WithTimestamp(start))
+	child.RecordError(errors.New("failed"), trace.WithTimestamp(start.Add(time.Millisecond)))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	root.End()
+
+	stop := make(chan struct{})
+	close(stop)
+	tp.Run(stop)
+
+	traceID := root.SpanContext().TraceID().String()
+	want := []zipkinSpan{
+		{
+			TraceID:   traceID,
+			ID:        child.SpanContext().SpanID().String(),
+			ParentID:  root.SpanContext().SpanID().String(),
+			Name:      "step action.ensureResourceGroup",
+			Timestamp: 1000000000,
+			Duration:  1500,
+			LocalEndpoint: zipkinEndpoint{
+				ServiceName: serviceName,
+			},
+			Annotations: []zipkinAnnotation{
+				{Timestamp: 1000001000, Value: "exception: failed"},
+			},
+			Tags: map[string]string{
+				"otel.status_code": "ERROR",
+				"error":            "failed",
+			},
+		},
+		{
+			TraceID:   traceID,
+			ID:        root.SpanContext().SpanID().String(),
+			Name:      "backend Creating",
+			Kind:      "CONSUMER",
+			Timestamp: 1000000000,
+			Duration:  1500,
+			LocalEndpoint: zipkinEndpoint{
+				ServiceName: serviceName,
+			},
+			Tags: map[string]string{
+				"resource_id": "/subscriptions/sub",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		b1, _ := json.Marshal(got)
+		b2, _ := json.Marshal(want)
+		t.Errorf("got %s, want %s", b1, b2)
+	}
+}
+
+func TestExportWithoutCollector(t *testing.T) {
+	tp, err := NewTracerProvider(logrus.NewEntry(logrus.StandardLogger()), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, s := tp.Tracer("test").Start(context.Background(), "frontend PUT")
+	s.End()
+
+	if len(tp.spans) != 0 {
+		t.Errorf("%d spans queued", len(tp.spans))
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := ContextWithTraceParent(context.Background(), traceParent)
+	if got := TraceParent(ctx); got != traceParent {
+		t.Error(got)
+	}
+
+	if got := TraceID(traceParent); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Error(got)
+	}
+
+	for _, invalid := range []string{"", "garbage", strings.Replace(traceParent, "4bf92f3577b34da6a3ce929d0e0e4736", strings.Repeat("0", 32), 1)} {
+		if got := TraceID(invalid); got != "" {
+			t.Errorf("%q: %s", invalid, got)
+		}
+		if got := TraceParent(ContextWithTraceParent(context.Background(), invalid)); got != "" {
+			t.Errorf("%q: %s", invalid, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/Azure/ARO-RP"
+
+	traceParentHeader = "traceparent"
+)
+
+// Tracer returns the tracer of the RP components, from the global tracer
+// provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx, or the empty
+// string if ctx has no valid span
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// ContextWithTraceParent returns a copy of ctx with the remote span described
+// by the W3C traceParent, so that spans started from it join the trace.  If
+// traceParent is invalid, ctx is returned unchanged.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{traceParentHeader: traceParent})
+}
+
+// TraceID returns the trace ID of the W3C traceParent, or the empty string if
+// traceParent is invalid
+func TraceID(traceParent string) string {
+	sc := trace.SpanContextFromContext(ContextWithTraceParent(context.Background(), traceParent))
+	if !sc.IsValid() {
+		return ""
+	}
+
+	return sc.TraceID().String()
+}
+
+// EndSpan records err, if any, on span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,121 @@
+package tracing
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// zipkinSpan is a span in the Zipkin v2 JSON format.  Times are in
+// microseconds.
+type zipkinSpan struct {
+	TraceID       string             `json:"traceId"`
+	ID            string             `json:"id"`
+	ParentID      string             `json:"parentId,omitempty"`
+	Name          string             `json:"name"`
+	Kind          string             `json:"kind,omitempty"`
+	Timestamp     int64              `json:"timestamp"`
+	Duration      int64              `json:"duration"`
+	LocalEndpoint zipkinEndpoint     `json:"localEndpoint"`
+	Annotations   []zipkinAnnotation `json:"annotations,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+var zipkinKinds = map[trace.SpanKind]string{
+	trace.SpanKindServer:   "SERVER",
+	trace.SpanKindClient:   "CLIENT",
+	trace.SpanKindProducer: "PRODUCER",
+	trace.SpanKindConsumer: "CONSUMER",
+}
+
+func (s *span) zipkin() *zipkinSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zs := &zipkinSpan{
+		TraceID:   s.sc.TraceID().String(),
+		ID:        s.sc.SpanID().String(),
+		Name:      s.name,
+		Kind:      zipkinKinds[s.kind],
+		Timestamp: s.start.UnixMicro(),
+		Duration:  s.end.Sub(s.start).Microseconds(),
+		LocalEndpoint: zipkinEndpoint{
+			ServiceName: serviceName,
+		},
+	}
+
+	if s.parentID.IsValid() {
+		zs.ParentID = s.parentID.String()
+	}
+
+	for _, e := range s.events {
+		zs.Annotations = append(zs.Annotations, zipkinAnnotation{
+			Timestamp: e.time.UnixMicro(),
+			Value:     e.name,
+		})
+	}
+
+	if len(s.attributes) > 0 || s.status == codes.Error {
+		zs.Tags = make(map[string]string, len(s.attributes)+2)
+	}
+	for _, kv := range s.attributes {
+		zs.Tags[string(kv.Key)] = kv.Value.Emit()
+	}
+	if s.status == codes.Error {
+		zs.Tags["otel.status_code"] = "ERROR"
+		zs.Tags["error"] = s.statusDesc
+	}
+
+	return zs
+}
+
+// export posts spans to the collector
+func (tp *TracerProvider) export(ctx context.Context, spans []*span) error {
+	zss := make([]*zipkinSpan, 0, len(spans))
+	for _, s := range spans {
+		zss = append(zss, s.zipkin())
+	}
+
+	b, err := json.Marshal(zss)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tp.collectorURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tp.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d from trace collector", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+)
+
+// SeccompDefaultMinVersion is the first version at which the kubelet can
+// apply the RuntimeDefault seccomp profile to containers which do not set one
+var SeccompDefaultMinVersion = NewVersion(4, 12)
+
+// ValidateSeccompDefault returns an error if the RuntimeDefault seccomp
+// profile can't be made the default at version v, given the first version min
+// supporting it
+func ValidateSeccompDefault(min, v *Version) error {
+	if v.Lt(min) {
+		return fmt.Errorf("the RuntimeDefault seccomp profile can be made the default from version %s", min)
+	}
+
+	return nil
+}
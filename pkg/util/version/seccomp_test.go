@@ -0,0 +1,37 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateSeccompDefault(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		v       *Version
+		wantErr string
+	}{
+		{
+			name: "at minimum version",
+			v:    NewVersion(4, 12, 0),
+		},
+		{
+			name: "after minimum version",
+			v:    NewVersion(4, 13, 10),
+		},
+		{
+			name:    "before minimum version",
+			v:       NewVersion(4, 11, 44),
+			wantErr: "the RuntimeDefault seccomp profile can be made the default from version 4.12.0",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSeccompDefault(NewVersion(4, 12), tt.v)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
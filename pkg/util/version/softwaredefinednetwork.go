@@ -0,0 +1,45 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// SoftwareDefinedNetworkRange is the range of versions, from Min inclusive to
+// Max exclusive, which clusters can be installed at with a software defined
+// network.  A nil bound is unbounded.
+type SoftwareDefinedNetworkRange struct {
+	Min *Version
+	Max *Version
+}
+
+// SoftwareDefinedNetworks describes the software defined networks which
+// clusters can be installed with.  OpenShiftSDN is not available for new
+// installs from 4.15 onwards.
+var SoftwareDefinedNetworks = map[api.SoftwareDefinedNetwork]SoftwareDefinedNetworkRange{
+	api.SoftwareDefinedNetworkOVNKubernetes: {},
+	api.SoftwareDefinedNetworkOpenShiftSDN:  {Max: NewVersion(4, 15)},
+}
+
+// ValidateSoftwareDefinedNetwork returns an error if sdns does not allow a
+// cluster to be installed at version v with software defined network sdn.
+func ValidateSoftwareDefinedNetwork(sdns map[api.SoftwareDefinedNetwork]SoftwareDefinedNetworkRange, sdn api.SoftwareDefinedNetwork, v *Version) error {
+	r, found := sdns[sdn]
+	if !found {
+		return fmt.Errorf("installing clusters with softwareDefinedNetwork %s is not supported", sdn)
+	}
+
+	if r.Min != nil && v.Lt(r.Min) {
+		return fmt.Errorf("installing clusters with softwareDefinedNetwork %s is supported from version %s", sdn, r.Min)
+	}
+
+	if r.Max != nil && !v.Lt(r.Max) {
+		return fmt.Errorf("installing clusters with softwareDefinedNetwork %s is supported before version %s", sdn, r.Max)
+	}
+
+	return nil
+}
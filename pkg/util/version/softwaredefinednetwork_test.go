@@ -0,0 +1,59 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateSoftwareDefinedNetwork(t *testing.T) {
+	sdns := map[api.SoftwareDefinedNetwork]SoftwareDefinedNetworkRange{
+		api.SoftwareDefinedNetworkOVNKubernetes: {Min: NewVersion(4, 11)},
+		api.SoftwareDefinedNetworkOpenShiftSDN:  {Max: NewVersion(4, 15)},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		sdn     api.SoftwareDefinedNetwork
+		v       *Version
+		wantErr string
+	}{
+		{
+			name: "at minimum version",
+			sdn:  api.SoftwareDefinedNetworkOVNKubernetes,
+			v:    NewVersion(4, 11, 0),
+		},
+		{
+			name:    "before minimum version",
+			sdn:     api.SoftwareDefinedNetworkOVNKubernetes,
+			v:       NewVersion(4, 10, 63),
+			wantErr: "installing clusters with softwareDefinedNetwork OVNKubernetes is supported from version 4.11.0",
+		},
+		{
+			name: "before maximum version",
+			sdn:  api.SoftwareDefinedNetworkOpenShiftSDN,
+			v:    NewVersion(4, 14, 9),
+		},
+		{
+			name:    "at maximum version",
+			sdn:     api.SoftwareDefinedNetworkOpenShiftSDN,
+			v:       NewVersion(4, 15, 0),
+			wantErr: "installing clusters with softwareDefinedNetwork OpenShiftSDN is supported before version 4.15.0",
+		},
+		{
+			name:    "unknown softwareDefinedNetwork",
+			sdn:     "Calico",
+			v:       NewVersion(4, 12, 25),
+			wantErr: "installing clusters with softwareDefinedNetwork Calico is not supported",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSoftwareDefinedNetwork(sdns, tt.sdn, tt.v)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
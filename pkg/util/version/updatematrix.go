@@ -0,0 +1,36 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpdateMatrix describes, for each minor version a cluster can be at, the
+// minor versions to which this RP supports an update of the cluster being
+// requested.  Moving further than one minor at a time is not supported.
+var UpdateMatrix = map[string][]string{
+	"4.9":  {"4.9", "4.10"},
+	"4.10": {"4.10", "4.11"},
+	"4.11": {"4.11", "4.12"},
+	"4.12": {"4.12"},
+}
+
+// ValidateUpdate returns an error if matrix does not allow a cluster at
+// version from to be updated to version to.
+func ValidateUpdate(matrix map[string][]string, from, to *Version) error {
+	next, found := matrix[from.MinorVersion()]
+	if !found {
+		return fmt.Errorf("updating clusters from version %s is not supported", from)
+	}
+
+	for _, minor := range next {
+		if minor == to.MinorVersion() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("updating clusters from version %s to version %s is not supported; supported next versions are: %s", from, to, strings.Join(next, ", "))
+}
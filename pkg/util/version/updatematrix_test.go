@@ -0,0 +1,58 @@
+package version
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"testing"
+
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateUpdate(t *testing.T) {
+	matrix := map[string][]string{
+		"4.10": {"4.10", "4.11"},
+		"4.11": {"4.11"},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		from    *Version
+		to      *Version
+		wantErr string
+	}{
+		{
+			name: "same minor version",
+			from: NewVersion(4, 10, 40),
+			to:   NewVersion(4, 10, 45),
+		},
+		{
+			name: "next minor version",
+			from: NewVersion(4, 10, 40),
+			to:   NewVersion(4, 11, 2),
+		},
+		{
+			name:    "skipped minor version",
+			from:    NewVersion(4, 10, 40),
+			to:      NewVersion(4, 12, 1),
+			wantErr: "updating clusters from version 4.10.40 to version 4.12.1 is not supported; supported next versions are: 4.10, 4.11",
+		},
+		{
+			name:    "downgrade",
+			from:    NewVersion(4, 11, 2),
+			to:      NewVersion(4, 10, 40),
+			wantErr: "updating clusters from version 4.11.2 to version 4.10.40 is not supported; supported next versions are: 4.11",
+		},
+		{
+			name:    "unknown current version",
+			from:    NewVersion(4, 7, 1),
+			to:      NewVersion(4, 7, 1),
+			wantErr: "updating clusters from version 4.7.1 is not supported",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdate(matrix, tt.from, tt.to)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
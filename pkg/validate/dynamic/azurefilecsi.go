@@ -0,0 +1,133 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// defaultAzureFileSKUName is the storage account SKU with which the Azure File
+// CSI driver creates file shares if the storage class doesn't set one
+const defaultAzureFileSKUName = mgmtstorage.StandardLRS
+
+// azureFileCSIActions are the actions which the Azure File CSI driver needs to
+// create the storage accounts and file shares of its volumes in the cluster
+// resource group
+var azureFileCSIActions = []string{
+	"Microsoft.Storage/storageAccounts/read",
+	"Microsoft.Storage/storageAccounts/write",
+	"Microsoft.Storage/storageAccounts/listKeys/action",
+	"Microsoft.Storage/storageAccounts/fileServices/shares/read",
+	"Microsoft.Storage/storageAccounts/fileServices/shares/write",
+	"Microsoft.Storage/storageAccounts/fileServices/shares/delete",
+}
+
+// ValidateAzureFileCSI validates that the storage account SKU of the Azure
+// Files storage class is available in the cluster location and that the Azure
+// File CSI driver, which authenticates as the cluster service principal, can
+// manage storage in the cluster resource group.  The RP grants the service
+// principal its permissions on the cluster resource group during the
+// installation, so they are only checked once the cluster exists.
+func (dv *dynamic) ValidateAzureFileCSI(ctx context.Context, oc *api.OpenShiftCluster) error {
+	p := oc.Properties.AzureFileCSIProfile
+	if p == nil {
+		return nil
+	}
+
+	dv.log.Print("ValidateAzureFileCSI")
+
+	skuName := mgmtstorage.SkuName(p.SKUName)
+	if skuName == "" {
+		skuName = defaultAzureFileSKUName
+	}
+
+	err := dv.validateAzureFileSKU(ctx, oc.Location, skuName)
+	if err != nil {
+		return err
+	}
+
+	if oc.Properties.ProvisioningState == api.ProvisioningStateCreating {
+		return nil
+	}
+
+	resourceGroup := stringutils.LastTokenByte(oc.Properties.ClusterProfile.ResourceGroupID, '/')
+
+	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+	if dv.authorizerType == AuthorizerClusterServicePrincipal {
+		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	}
+
+	err = dv.validateActions(ctx, &azure.Resource{
+		SubscriptionID: dv.subscriptionID,
+		ResourceGroup:  resourceGroup,
+	}, azureFileCSIActions)
+	if err == wait.ErrWaitTimeout {
+		return api.NewCloudError(http.StatusBadRequest, errCode, "properties.azureFileCsiProfile", "The %s service principal does not have the permissions required by the Azure File CSI driver on resource group '%s'.", dv.authorizerType, resourceGroup)
+	}
+
+	return err
+}
+
+// validateAzureFileSKU checks that storage accounts of the SKU which support
+// Azure Files, i.e. general purpose v2 accounts for standard SKUs and file
+// storage accounts for premium ones, can be created in the location
+func (dv *dynamic) validateAzureFileSKU(ctx context.Context, location string, skuName mgmtstorage.SkuName) error {
+	kind := mgmtstorage.StorageV2
+	if strings.HasPrefix(string(skuName), "Premium_") {
+		kind = mgmtstorage.FileStorage
+	}
+
+	skus, err := dv.storageSkus.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if skus.Value != nil {
+		for _, sku := range *skus.Value {
+			if sku.Name == skuName && sku.Kind == kind &&
+				sku.ResourceType != nil && strings.EqualFold(*sku.ResourceType, "storageAccounts") &&
+				isAvailableInLocation(sku, location) {
+				return nil
+			}
+		}
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.azureFileCsiProfile.skuName", "The provided SKU '%s' is not available in location '%s'.", skuName, location)
+}
+
+// isAvailableInLocation returns true if the storage SKU is offered in the
+// location and isn't restricted there for the subscription
+func isAvailableInLocation(sku mgmtstorage.SkuInformation, location string) bool {
+	if sku.Locations == nil || !containsLocation(*sku.Locations, location) {
+		return false
+	}
+
+	if sku.Restrictions != nil {
+		for _, restriction := range *sku.Restrictions {
+			if restriction.Values != nil && containsLocation(*restriction.Values, location) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func containsLocation(locations []string, location string) bool {
+	for _, l := range locations {
+		if strings.EqualFold(l, location) {
+			return true
+		}
+	}
+	return false
+}
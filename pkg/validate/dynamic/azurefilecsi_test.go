@@ -0,0 +1,169 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	mgmtstorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_storage "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/storage"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateAzureFileCSI(t *testing.T) {
+	subscriptionID := "0000000-0000-0000-0000-000000000000"
+	resourceGroupID := "/subscriptions/" + subscriptionID + "/resourceGroups/cluster-rg"
+
+	skus := mgmtstorage.SkuListResult{
+		Value: &[]mgmtstorage.SkuInformation{
+			{
+				Name:         mgmtstorage.StandardLRS,
+				Kind:         mgmtstorage.StorageV2,
+				ResourceType: to.StringPtr("storageAccounts"),
+				Locations:    &[]string{"eastus"},
+			},
+			{
+				Name:         mgmtstorage.PremiumZRS,
+				Kind:         mgmtstorage.BlockBlobStorage,
+				ResourceType: to.StringPtr("storageAccounts"),
+				Locations:    &[]string{"eastus"},
+			},
+			{
+				Name:         mgmtstorage.PremiumLRS,
+				Kind:         mgmtstorage.FileStorage,
+				ResourceType: to.StringPtr("storageAccounts"),
+				Locations:    &[]string{"eastus"},
+				Restrictions: &[]mgmtstorage.Restriction{{
+					Type:       to.StringPtr("location"),
+					Values:     &[]string{"eastus"},
+					ReasonCode: mgmtstorage.NotAvailableForSubscription,
+				}},
+			},
+		},
+	}
+
+	newOC := func(provisioningState api.ProvisioningState, skuName string) *api.OpenShiftCluster {
+		return &api.OpenShiftCluster{
+			Location: "eastus",
+			Properties: api.OpenShiftClusterProperties{
+				ProvisioningState: provisioningState,
+				ClusterProfile: api.ClusterProfile{
+					ResourceGroupID: resourceGroupID,
+				},
+				AzureFileCSIProfile: &api.AzureFileCSIProfile{
+					StorageClassName: "azurefile-rwx",
+					SKUName:          skuName,
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *api.OpenShiftCluster
+		mocks   func(*mock_storage.MockSkusClient, *mock_authorization.MockPermissionsClient, context.CancelFunc)
+		wantErr string
+	}{
+		{
+			name: "no azure file storage class",
+			oc:   &api.OpenShiftCluster{},
+		},
+		{
+			name: "default sku is available on create",
+			oc:   newOC(api.ProvisioningStateCreating, ""),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(skus, nil)
+			},
+		},
+		{
+			name: "sku is not available for file shares",
+			oc:   newOC(api.ProvisioningStateCreating, "Premium_ZRS"),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(skus, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.skuName: The provided SKU 'Premium_ZRS' is not available in location 'eastus'.",
+		},
+		{
+			name: "sku is restricted in the location",
+			oc:   newOC(api.ProvisioningStateCreating, "Premium_LRS"),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(skus, nil)
+			},
+			wantErr: "400: InvalidParameter: properties.azureFileCsiProfile.skuName: The provided SKU 'Premium_LRS' is not available in location 'eastus'.",
+		},
+		{
+			name: "sku list fails",
+			oc:   newOC(api.ProvisioningStateCreating, ""),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(mgmtstorage.SkuListResult{}, errors.New("fakeerr"))
+			},
+			wantErr: "fakeerr",
+		},
+		{
+			name: "service principal has storage permissions on update",
+			oc:   newOC(api.ProvisioningStateUpdating, "Standard_LRS"),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(skus, nil)
+				permissions.EXPECT().
+					ListForResourceGroup(gomock.Any(), "cluster-rg").
+					Return([]mgmtauthorization.Permission{{
+						Actions:    &[]string{"*"},
+						NotActions: &[]string{},
+					}}, nil)
+			},
+		},
+		{
+			name: "service principal lacks storage permissions on update",
+			oc:   newOC(api.ProvisioningStateUpdating, "Standard_LRS"),
+			mocks: func(storageSkus *mock_storage.MockSkusClient, permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+				storageSkus.EXPECT().List(gomock.Any()).Return(skus, nil)
+				permissions.EXPECT().
+					ListForResourceGroup(gomock.Any(), "cluster-rg").
+					Do(func(arg0, arg1 interface{}) {
+						cancel()
+					}).
+					Return([]mgmtauthorization.Permission{{
+						Actions:    &[]string{"*"},
+						NotActions: &[]string{"Microsoft.Storage/storageAccounts/listKeys/action"},
+					}}, nil)
+			},
+			wantErr: "400: InvalidServicePrincipalPermissions: properties.azureFileCsiProfile: The cluster service principal does not have the permissions required by the Azure File CSI driver on resource group 'cluster-rg'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			storageSkus := mock_storage.NewMockSkusClient(controller)
+			permissions := mock_authorization.NewMockPermissionsClient(controller)
+
+			if tt.mocks != nil {
+				tt.mocks(storageSkus, permissions, cancel)
+			}
+
+			dv := &dynamic{
+				authorizerType: AuthorizerClusterServicePrincipal,
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				subscriptionID: subscriptionID,
+				permissions:    permissions,
+				storageSkus:    storageSkus,
+			}
+
+			err := dv.ValidateAzureFileCSI(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
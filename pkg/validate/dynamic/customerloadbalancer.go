@@ -0,0 +1,149 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ValidateCustomerLoadBalancer validates that the RP can register the backends
+// of the cluster on the customer's load balancer, which must be a Standard load
+// balancer in the cluster location with the frontend IP configurations of the
+// profile, and that the ports the RP needs on the frontends are free
+func (dv *dynamic) ValidateCustomerLoadBalancer(ctx context.Context, oc *api.OpenShiftCluster) error {
+	p := oc.Properties.NetworkProfile.CustomerLoadBalancerProfile
+	if p == nil {
+		return nil
+	}
+
+	dv.log.Print("ValidateCustomerLoadBalancer")
+
+	path := "properties.networkProfile.customerLoadBalancerProfile"
+
+	r, err := azure.ParseResourceID(p.ID)
+	if err != nil {
+		return err
+	}
+
+	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+	if dv.authorizerType == AuthorizerClusterServicePrincipal {
+		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	}
+
+	err = dv.validateActions(ctx, &r, []string{
+		"Microsoft.Network/loadBalancers/read",
+		"Microsoft.Network/loadBalancers/write",
+	})
+	if err == wait.ErrWaitTimeout {
+		return api.NewCloudError(http.StatusBadRequest, errCode, path+".id", "The %s service principal does not have Network Contributor permission on load balancer '%s'.", dv.authorizerType, r.String())
+	}
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The load balancer '%s' could not be found.", r.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	lb, err := dv.loadBalancers.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The load balancer '%s' could not be found.", r.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	if lb.Sku == nil || lb.Sku.Name != mgmtnetwork.LoadBalancerSkuNameStandard {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The load balancer '%s' is invalid: must have the Standard SKU.", r.String())
+	}
+
+	if lb.Location == nil || !strings.EqualFold(*lb.Location, oc.Location) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path+".id", "The load balancer location '%s' must match the cluster location '%s'.", to.String(lb.Location), oc.Location)
+	}
+
+	for _, f := range []struct {
+		path  string
+		name  string
+		ports []int32
+	}{
+		{
+			path:  path + ".apiServerFrontendIpConfiguration",
+			name:  p.APIServerFrontendIPConfiguration,
+			ports: []int32{6443},
+		},
+		{
+			path:  path + ".ingressFrontendIpConfiguration",
+			name:  p.IngressFrontendIPConfiguration,
+			ports: []int32{80, 443},
+		},
+	} {
+		if !hasFrontendIPConfiguration(&lb, f.name) {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, f.path, "The load balancer '%s' is invalid: it has no frontend IP configuration '%s'.", r.String(), f.name)
+		}
+
+		if port, rule := conflictingLoadBalancingRule(&lb, f.name, f.ports, oc.Properties.InfraID); rule != "" {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, f.path, "The load balancer '%s' is invalid: the load balancing rule '%s' already uses port %d of frontend IP configuration '%s'.", r.String(), rule, port, f.name)
+		}
+	}
+
+	return nil
+}
+
+func hasFrontendIPConfiguration(lb *mgmtnetwork.LoadBalancer, name string) bool {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil {
+		return false
+	}
+
+	for _, f := range *lb.FrontendIPConfigurations {
+		if f.Name != nil && strings.EqualFold(*f.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conflictingLoadBalancingRule returns a load balancing rule, and its port,
+// which uses one of the ports of the frontend.  The rules which the RP created
+// for the cluster are prefixed with its infraID and don't conflict.
+func conflictingLoadBalancingRule(lb *mgmtnetwork.LoadBalancer, frontend string, ports []int32, infraID string) (int32, string) {
+	if lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancingRules == nil {
+		return 0, ""
+	}
+
+	for _, rule := range *lb.LoadBalancingRules {
+		if rule.Name == nil || rule.LoadBalancingRulePropertiesFormat == nil ||
+			rule.FrontendIPConfiguration == nil || rule.FrontendIPConfiguration.ID == nil ||
+			rule.FrontendPort == nil {
+			continue
+		}
+
+		if infraID != "" && strings.HasPrefix(*rule.Name, infraID+"-") {
+			continue
+		}
+
+		if !strings.HasSuffix(strings.ToLower(*rule.FrontendIPConfiguration.ID), "/frontendipconfigurations/"+strings.ToLower(frontend)) {
+			continue
+		}
+
+		for _, port := range ports {
+			if *rule.FrontendPort == port {
+				return port, *rule.Name
+			}
+		}
+	}
+
+	return 0, ""
+}
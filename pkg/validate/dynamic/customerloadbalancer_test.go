@@ -0,0 +1,169 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateCustomerLoadBalancer(t *testing.T) {
+	fakeLBID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.Network/loadBalancers/frontdoor"
+	fakeLBR, err := azure.ParseResourceID(fakeLBID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oc := &api.OpenShiftCluster{
+		Location: "eastus",
+		Properties: api.OpenShiftClusterProperties{
+			InfraID: "infra",
+			NetworkProfile: api.NetworkProfile{
+				CustomerLoadBalancerProfile: &api.CustomerLoadBalancerProfile{
+					ID:                               fakeLBID,
+					APIServerFrontendIPConfiguration: "api",
+					IngressFrontendIPConfiguration:   "ingress",
+				},
+			},
+		},
+	}
+
+	rule := func(name, frontend string, port int32) mgmtnetwork.LoadBalancingRule {
+		return mgmtnetwork.LoadBalancingRule{
+			Name: to.StringPtr(name),
+			LoadBalancingRulePropertiesFormat: &mgmtnetwork.LoadBalancingRulePropertiesFormat{
+				FrontendIPConfiguration: &mgmtnetwork.SubResource{
+					ID: to.StringPtr(fakeLBID + "/frontendIPConfigurations/" + frontend),
+				},
+				FrontendPort: to.Int32Ptr(port),
+			},
+		}
+	}
+
+	validLB := func() mgmtnetwork.LoadBalancer {
+		return mgmtnetwork.LoadBalancer{
+			Location: to.StringPtr("eastus"),
+			Sku: &mgmtnetwork.LoadBalancerSku{
+				Name: mgmtnetwork.LoadBalancerSkuNameStandard,
+			},
+			LoadBalancerPropertiesFormat: &mgmtnetwork.LoadBalancerPropertiesFormat{
+				FrontendIPConfigurations: &[]mgmtnetwork.FrontendIPConfiguration{
+					{Name: to.StringPtr("api")},
+					{Name: to.StringPtr("ingress")},
+				},
+				LoadBalancingRules: &[]mgmtnetwork.LoadBalancingRule{
+					rule("other", "ingress", 8443),
+					rule("infra-api", "api", 6443),
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		oc      *api.OpenShiftCluster
+		lb      func(*mgmtnetwork.LoadBalancer)
+		lbErr   error
+		wantErr string
+	}{
+		{
+			name: "no customer load balancer",
+			oc:   &api.OpenShiftCluster{},
+		},
+		{
+			name: "valid",
+			oc:   oc,
+		},
+		{
+			name:    "load balancer not found",
+			oc:      oc,
+			lbErr:   autorest.DetailedError{StatusCode: http.StatusNotFound},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.id: The load balancer '%s' could not be found.", fakeLBID),
+		},
+		{
+			name: "basic sku",
+			oc:   oc,
+			lb: func(lb *mgmtnetwork.LoadBalancer) {
+				lb.Sku.Name = mgmtnetwork.LoadBalancerSkuNameBasic
+			},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.id: The load balancer '%s' is invalid: must have the Standard SKU.", fakeLBID),
+		},
+		{
+			name: "other location",
+			oc:   oc,
+			lb: func(lb *mgmtnetwork.LoadBalancer) {
+				lb.Location = to.StringPtr("westus")
+			},
+			wantErr: "400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.id: The load balancer location 'westus' must match the cluster location 'eastus'.",
+		},
+		{
+			name: "frontend missing",
+			oc:   oc,
+			lb: func(lb *mgmtnetwork.LoadBalancer) {
+				*lb.FrontendIPConfigurations = (*lb.FrontendIPConfigurations)[:1]
+			},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.ingressFrontendIpConfiguration: The load balancer '%s' is invalid: it has no frontend IP configuration 'ingress'.", fakeLBID),
+		},
+		{
+			name: "port in use",
+			oc:   oc,
+			lb: func(lb *mgmtnetwork.LoadBalancer) {
+				*lb.LoadBalancingRules = append(*lb.LoadBalancingRules, rule("https", "INGRESS", 443))
+			},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.networkProfile.customerLoadBalancerProfile.ingressFrontendIpConfiguration: The load balancer '%s' is invalid: the load balancing rule 'https' already uses port 443 of frontend IP configuration 'ingress'.", fakeLBID),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			permissionsClient := mock_authorization.NewMockPermissionsClient(controller)
+			loadBalancersClient := mock_network.NewMockLoadBalancersClient(controller)
+
+			if tt.oc.Properties.NetworkProfile.CustomerLoadBalancerProfile != nil {
+				permissionsClient.EXPECT().
+					ListForResource(gomock.Any(), fakeLBR.ResourceGroup, fakeLBR.Provider, "", fakeLBR.ResourceType, fakeLBR.ResourceName).
+					Return([]mgmtauthorization.Permission{{
+						Actions:    &[]string{"Microsoft.Network/loadBalancers/*"},
+						NotActions: &[]string{},
+					}}, nil)
+
+				lb := validLB()
+				if tt.lb != nil {
+					tt.lb(&lb)
+				}
+				loadBalancersClient.EXPECT().
+					Get(gomock.Any(), fakeLBR.ResourceGroup, fakeLBR.ResourceName, "").
+					Return(lb, tt.lbErr)
+			}
+
+			dv := &dynamic{
+				authorizerType: AuthorizerFirstParty,
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				permissions:    permissionsClient,
+				loadBalancers:  loadBalancersClient,
+			}
+
+			err := dv.ValidateCustomerLoadBalancer(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
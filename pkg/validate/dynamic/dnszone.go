@@ -0,0 +1,62 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
+)
+
+// ValidateDNSZone validates that we can manage the cluster records in the
+// customer's DNS zone, which may be in another subscription than the cluster
+func (dv *dynamic) ValidateDNSZone(ctx context.Context, oc *api.OpenShiftCluster) error {
+	if oc.Properties.ClusterProfile.DNSZoneID == "" {
+		return nil
+	}
+
+	dv.log.Print("ValidateDNSZone")
+
+	path := "properties.clusterProfile.dnsZoneId"
+
+	r, err := azure.ParseResourceID(oc.Properties.ClusterProfile.DNSZoneID)
+	if err != nil {
+		return err
+	}
+
+	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+	if dv.authorizerType == AuthorizerClusterServicePrincipal {
+		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	}
+
+	// permissions are listed in the subscription of the zone
+	zoneDv := *dv
+	if !strings.EqualFold(r.SubscriptionID, dv.subscriptionID) {
+		zoneDv.permissions = authorization.NewPermissionsClient(dv.azEnv, r.SubscriptionID, dv.authorizer)
+	}
+
+	err = zoneDv.validateActions(ctx, &r, []string{
+		"Microsoft.Network/dnsZones/read",
+		"Microsoft.Network/dnsZones/A/read",
+		"Microsoft.Network/dnsZones/A/write",
+		"Microsoft.Network/dnsZones/A/delete",
+	})
+
+	if err == wait.ErrWaitTimeout {
+		return api.NewCloudError(http.StatusBadRequest, errCode, path, "The %s service principal does not have DNS Zone Contributor permission on DNS zone '%s'.", dv.authorizerType, r.String())
+	}
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The DNS zone '%s' could not be found.", r.String())
+	}
+
+	return err
+}
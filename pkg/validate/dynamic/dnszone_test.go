@@ -0,0 +1,132 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateDNSZone(t *testing.T) {
+	fakeZoneID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.Network/dnsZones/example.com"
+	fakeZoneR, err := azure.ParseResourceID(fakeZoneID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				Domain:    "cluster.example.com",
+				DNSZoneID: fakeZoneID,
+			},
+		},
+	}
+
+	for _, authorizerType := range []AuthorizerType{AuthorizerClusterServicePrincipal, AuthorizerFirstParty} {
+		wantErrCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+		if authorizerType == AuthorizerClusterServicePrincipal {
+			wantErrCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+		}
+
+		t.Run(string(authorizerType), func(t *testing.T) {
+			for _, tt := range []struct {
+				name    string
+				oc      *api.OpenShiftCluster
+				mocks   func(permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc)
+				wantErr string
+			}{
+				{
+					name: "no dns zone provided",
+					oc:   &api.OpenShiftCluster{},
+				},
+				{
+					name: "valid permissions",
+					oc:   oc,
+					mocks: func(permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+						permissions.EXPECT().
+							ListForResource(gomock.Any(), fakeZoneR.ResourceGroup, fakeZoneR.Provider, "", fakeZoneR.ResourceType, fakeZoneR.ResourceName).
+							Return([]mgmtauthorization.Permission{{
+								Actions:    &[]string{"Microsoft.Network/dnsZones/*"},
+								NotActions: &[]string{},
+							}}, nil)
+					},
+				},
+				{
+					name: "invalid permissions",
+					oc:   oc,
+					mocks: func(permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+						permissions.EXPECT().
+							ListForResource(gomock.Any(), fakeZoneR.ResourceGroup, fakeZoneR.Provider, "", fakeZoneR.ResourceType, fakeZoneR.ResourceName).
+							Do(func(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) {
+								cancel()
+							}).
+							Return([]mgmtauthorization.Permission{{
+								Actions:    &[]string{"Microsoft.Network/dnsZones/read"},
+								NotActions: &[]string{},
+							}}, nil)
+					},
+					wantErr: fmt.Sprintf("400: %s: properties.clusterProfile.dnsZoneId: The %s service principal does not have DNS Zone Contributor permission on DNS zone '%s'.", wantErrCode, authorizerType, fakeZoneID),
+				},
+				{
+					name: "dns zone not found",
+					oc:   oc,
+					mocks: func(permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+						permissions.EXPECT().
+							ListForResource(gomock.Any(), fakeZoneR.ResourceGroup, fakeZoneR.Provider, "", fakeZoneR.ResourceType, fakeZoneR.ResourceName).
+							Return(nil, autorest.DetailedError{StatusCode: http.StatusNotFound})
+					},
+					wantErr: fmt.Sprintf("400: InvalidParameter: properties.clusterProfile.dnsZoneId: The DNS zone '%s' could not be found.", fakeZoneID),
+				},
+				{
+					name: "unhandled permissions error",
+					oc:   oc,
+					mocks: func(permissions *mock_authorization.MockPermissionsClient, cancel context.CancelFunc) {
+						permissions.EXPECT().
+							ListForResource(gomock.Any(), fakeZoneR.ResourceGroup, fakeZoneR.Provider, "", fakeZoneR.ResourceType, fakeZoneR.ResourceName).
+							Return(nil, errors.New("fakeerr"))
+					},
+					wantErr: "fakeerr",
+				},
+			} {
+				t.Run(tt.name, func(t *testing.T) {
+					ctx, cancel := context.WithCancel(context.Background())
+					defer cancel()
+
+					controller := gomock.NewController(t)
+					defer controller.Finish()
+
+					permissionsClient := mock_authorization.NewMockPermissionsClient(controller)
+
+					if tt.mocks != nil {
+						tt.mocks(permissionsClient, cancel)
+					}
+
+					dv := &dynamic{
+						authorizerType: authorizerType,
+						log:            logrus.NewEntry(logrus.StandardLogger()),
+						subscriptionID: fakeZoneR.SubscriptionID,
+						permissions:    permissionsClient,
+					}
+
+					err := dv.ValidateDNSZone(ctx, tt.oc)
+					utilerror.AssertErrorMessage(t, err, tt.wantErr)
+				})
+			}
+		})
+	}
+}
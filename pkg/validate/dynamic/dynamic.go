@@ -14,6 +14,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/apparentlymart/go-cidr/cidr"
@@ -28,6 +29,8 @@ import (
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/authorization"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/compute"
 	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/network"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/policyinsights"
+	"github.com/Azure/ARO-RP/pkg/util/azureclient/mgmt/storage"
 	"github.com/Azure/ARO-RP/pkg/util/permissions"
 	"github.com/Azure/ARO-RP/pkg/util/steps"
 	"github.com/Azure/ARO-RP/pkg/util/token"
@@ -75,7 +78,17 @@ type Dynamic interface {
 	ValidateDiskEncryptionSets(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateEncryptionAtHost(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidateLoadBalancerProfile(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateCustomerLoadBalancer(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateProximityPlacementGroups(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidatePolicyRestrictions(ctx context.Context, oc *api.OpenShiftCluster) error
 	ValidatePreConfiguredNSGs(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidateIdentityProvider(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateRegistryMirrors(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateLogForwarding(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateDNSZone(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateAzureFileCSI(ctx context.Context, oc *api.OpenShiftCluster) error
+	ValidateVnetPeerings(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
+	ValidateSubnetConflicts(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error
 }
 
 type dynamic struct {
@@ -86,15 +99,25 @@ type dynamic struct {
 	checkAccessSubjectInfoCred azcore.TokenCredential
 	env                        env.Interface
 	azEnv                      *azureclient.AROEnvironment
+	subscriptionID             string
+	authorizer                 autorest.Authorizer
 
 	permissions                           authorization.PermissionsClient
 	virtualNetworks                       virtualNetworksGetClient
+	securityGroups                        network.SecurityGroupsClient
+	routeTables                           network.RouteTablesClient
+	loadBalancers                         network.LoadBalancersClient
 	diskEncryptionSets                    compute.DiskEncryptionSetsClient
+	proximityPlacementGroups              compute.ProximityPlacementGroupsClient
 	resourceSkusClient                    compute.ResourceSkusClient
+	storageSkus                           storage.SkusClient
 	spComputeUsage                        compute.UsageClient
 	spNetworkUsage                        network.UsageClient
 	loadBalancerBackendAddressPoolsClient network.LoadBalancerBackendAddressPoolsClient
+	policyRestrictions                    policyinsights.PolicyRestrictionsClient
 	pdpClient                             remotepdp.RemotePDPClient
+	externalClient                        *http.Client
+	externalDial                          func(ctx context.Context, network, address string) (net.Conn, error)
 }
 
 type AuthorizerType string
@@ -121,6 +144,8 @@ func NewValidator(
 		authorizerType:             authorizerType,
 		env:                        env,
 		azEnv:                      azEnv,
+		subscriptionID:             subscriptionID,
+		authorizer:                 authorizer,
 		checkAccessSubjectInfoCred: cred,
 
 		spComputeUsage: compute.NewUsageClient(azEnv, subscriptionID, authorizer),
@@ -129,10 +154,18 @@ func NewValidator(
 		virtualNetworks: newVirtualNetworksCache(
 			network.NewVirtualNetworksClient(azEnv, subscriptionID, authorizer),
 		),
+		securityGroups:                        network.NewSecurityGroupsClient(azEnv, subscriptionID, authorizer),
+		routeTables:                           network.NewRouteTablesClient(azEnv, subscriptionID, authorizer),
+		loadBalancers:                         network.NewLoadBalancersClient(azEnv, subscriptionID, authorizer),
 		diskEncryptionSets:                    compute.NewDiskEncryptionSetsClient(azEnv, subscriptionID, authorizer),
+		proximityPlacementGroups:              compute.NewProximityPlacementGroupsClient(azEnv, subscriptionID, authorizer),
 		resourceSkusClient:                    compute.NewResourceSkusClient(azEnv, subscriptionID, authorizer),
+		storageSkus:                           storage.NewSkusClient(azEnv, subscriptionID, authorizer),
+		policyRestrictions:                    policyinsights.NewPolicyRestrictionsClient(azEnv, subscriptionID, authorizer),
 		pdpClient:                             pdpClient,
 		loadBalancerBackendAddressPoolsClient: network.NewLoadBalancerBackendAddressPoolsClient(azEnv, subscriptionID, authorizer),
+		externalClient:                        newExternalClient(),
+		externalDial:                          newExternalDialer().DialContext,
 	}
 }
 
@@ -391,6 +424,8 @@ func (dv *dynamic) validateNatGatewayPermissions(ctx context.Context, s Subnet)
 	return err
 }
 
+// validateActions checks that the actions are permitted on the resource r,
+// or on its resource group if r has no provider
 func (dv *dynamic) validateActions(ctx context.Context, r *azure.Resource, actions []string) error {
 	// ARM has a 5 minute cache around role assignment creation, so wait one minute longer
 	timeoutCtx, cancel := context.WithTimeout(ctx, 6*time.Minute)
@@ -417,14 +452,20 @@ type closure struct {
 // usingListPermissions is how the current check is done
 func (c closure) usingListPermissions() (bool, error) {
 	c.dv.log.Debug("retry validateActions with ListPermissions")
-	perms, err := c.dv.permissions.ListForResource(
-		c.ctx,
-		c.resource.ResourceGroup,
-		c.resource.Provider,
-		"",
-		c.resource.ResourceType,
-		c.resource.ResourceName,
-	)
+	var perms []mgmtauthorization.Permission
+	var err error
+	if c.resource.Provider == "" {
+		perms, err = c.dv.permissions.ListForResourceGroup(c.ctx, c.resource.ResourceGroup)
+	} else {
+		perms, err = c.dv.permissions.ListForResource(
+			c.ctx,
+			c.resource.ResourceGroup,
+			c.resource.Provider,
+			"",
+			c.resource.ResourceType,
+			c.resource.ResourceName,
+		)
+	}
 	if err != nil {
 		return false, err
 	}
@@ -474,7 +515,12 @@ func (c closure) usingCheckAccessV2() (bool, error) {
 		c.oid = &oid
 	}
 
-	authReq := createAuthorizationRequest(*c.oid, c.resource.String(), c.actions...)
+	resourceID := c.resource.String()
+	if c.resource.Provider == "" {
+		resourceID = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.resource.SubscriptionID, c.resource.ResourceGroup)
+	}
+
+	authReq := createAuthorizationRequest(*c.oid, resourceID, c.actions...)
 	results, err := c.dv.pdpClient.CheckAccess(c.ctx, authReq)
 	if err != nil {
 		c.dv.log.Error("Unexpected error when calling CheckAccessV2: ", err)
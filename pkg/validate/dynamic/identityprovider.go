@@ -0,0 +1,108 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// maxOpenIDConfigurationSize limits how much of the OpenID configuration of an
+// identity provider is read
+const maxOpenIDConfigurationSize = 1 << 20
+
+// errNonPublicAddress is returned when connecting to an address provided by
+// the customer which is not public
+var errNonPublicAddress = errors.New("refusing to connect to non-public address")
+
+// newExternalDialer returns the dialer with which endpoints provided by the
+// customer are connected to.  Since the customer chooses the address,
+// connections to addresses which are not public are refused.
+func newExternalDialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+				return fmt.Errorf("%w %s", errNonPublicAddress, address)
+			}
+
+			return nil
+		},
+	}
+}
+
+// newExternalClient returns the client with which endpoints provided by the
+// customer, e.g. the OpenID configuration of an identity provider, are
+// fetched
+func newExternalClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         newExternalDialer().DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		Timeout: 30 * time.Second,
+	}
+}
+
+// ValidateIdentityProvider checks that the issuer of the identity provider is
+// reachable and serves an OpenID configuration for itself
+func (dv *dynamic) ValidateIdentityProvider(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateIdentityProvider")
+
+	p := oc.Properties.IdentityProviderProfile
+	if p == nil {
+		return nil
+	}
+
+	path := "properties.identityProviderProfile.issuer"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := dv.externalClient.Do(req)
+	if err != nil {
+		dv.log.Info(err)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided issuer '%s' could not be reached.", p.Issuer)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided issuer '%s' is invalid: its OpenID configuration returned status code %d.", p.Issuer, resp.StatusCode)
+	}
+
+	var config struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+	}
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxOpenIDConfigurationSize)).Decode(&config)
+	if err != nil || config.AuthorizationEndpoint == "" || config.TokenEndpoint == "" {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided issuer '%s' is invalid: its OpenID configuration could not be read.", p.Issuer)
+	}
+
+	if config.Issuer != p.Issuer {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided issuer '%s' is invalid: it does not match the issuer '%s' of its OpenID configuration.", p.Issuer, config.Issuer)
+	}
+
+	return nil
+}
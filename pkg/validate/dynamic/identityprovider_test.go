@@ -0,0 +1,106 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateIdentityProvider(t *testing.T) {
+	var handler http.HandlerFunc
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/v2.0/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	issuer := server.URL + "/tenant/v2.0"
+
+	openIDConfiguration := func(issuer string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"issuer":%q,"authorization_endpoint":%q,"token_endpoint":%q}`, issuer, issuer+"/authorize", issuer+"/token")
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		issuer  string
+		client  *http.Client
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "no identity provider",
+		},
+		{
+			name:    "valid",
+			issuer:  issuer,
+			handler: openIDConfiguration(issuer),
+		},
+		{
+			name:    "issuer not found",
+			issuer:  server.URL + "/other",
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer '" + server.URL + "/other' is invalid: its OpenID configuration returned status code 404.",
+		},
+		{
+			name:   "invalid openid configuration",
+			issuer: issuer,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"issuer":`)
+			},
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer '" + issuer + "' is invalid: its OpenID configuration could not be read.",
+		},
+		{
+			name:    "issuer mismatch",
+			issuer:  issuer,
+			handler: openIDConfiguration("https://issuer.example.com"),
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer '" + issuer + "' is invalid: it does not match the issuer 'https://issuer.example.com' of its OpenID configuration.",
+		},
+		{
+			name:    "non-public address refused",
+			issuer:  issuer,
+			client:  newExternalClient(),
+			handler: openIDConfiguration(issuer),
+			wantErr: "400: InvalidParameter: properties.identityProviderProfile.issuer: The provided issuer '" + issuer + "' could not be reached.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			handler = tt.handler
+
+			oc := &api.OpenShiftCluster{}
+			if tt.issuer != "" {
+				oc.Properties.IdentityProviderProfile = &api.IdentityProviderProfile{
+					Name:     "AzureAD",
+					Issuer:   tt.issuer,
+					ClientID: "clientId",
+				}
+			}
+
+			client := tt.client
+			if client == nil {
+				client = server.Client()
+			}
+
+			dv := &dynamic{
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				externalClient: client,
+			}
+
+			err := dv.ValidateIdentityProvider(context.Background(), oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/operator"
+)
+
+// azureMonitorDataCollectorURL is the HTTP Data Collector API endpoint of a
+// Log Analytics workspace, to which the cluster logs are forwarded
+var azureMonitorDataCollectorURL = "https://%s.ods.opinsights.azure.com/api/logs?api-version=2016-04-01"
+
+// ValidateLogForwarding checks that the destination of the forwarded logs can
+// be reached.  A syslog server whose address isn't public may be reachable
+// from the cluster network only, so it isn't checked, nor is one over udp.  A
+// workspace is sent an empty batch of records, which checks the shared key.
+func (dv *dynamic) ValidateLogForwarding(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateLogForwarding")
+
+	p := oc.Properties.LogForwardingProfile
+	if p == nil {
+		return nil
+	}
+
+	switch p.Type {
+	case api.LogForwardingTypeSyslog:
+		return dv.validateSyslog(ctx, p)
+	case api.LogForwardingTypeAzureMonitor:
+		return dv.validateAzureMonitor(ctx, p)
+	}
+
+	return nil
+}
+
+func (dv *dynamic) validateSyslog(ctx context.Context, p *api.LogForwardingProfile) error {
+	path := "properties.logForwardingProfile.url"
+
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "udp" {
+		return nil
+	}
+
+	conn, err := dv.externalDial(ctx, "tcp", u.Host)
+	if errors.Is(err, errNonPublicAddress) {
+		dv.log.Info(err)
+		return nil
+	}
+	if err != nil {
+		dv.log.Info(err)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided syslog URL '%s' could not be reached.", p.URL)
+	}
+
+	return conn.Close()
+}
+
+func (dv *dynamic) validateAzureMonitor(ctx context.Context, p *api.LogForwardingProfile) error {
+	path := "properties.logForwardingProfile.workspaceId"
+
+	key, err := base64.StdEncoding.DecodeString(string(p.SharedKey))
+	if err != nil {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.logForwardingProfile.sharedKey", "The provided shared key is invalid: it must be base64 encoded.")
+	}
+
+	body := "[]"
+	date := time.Now().UTC().Format(http.TimeFormat)
+
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "POST\n%d\napplication/json\nx-ms-date:%s\n/api/logs", len(body), date)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(azureMonitorDataCollectorURL, p.WorkspaceID), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "SharedKey "+p.WorkspaceID+":"+signature)
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Log-Type", operator.LogForwardingAzureMonitorLogType)
+	req.Header.Set("x-ms-date", date)
+
+	resp, err := dv.externalClient.Do(req)
+	if err != nil {
+		dv.log.Info(err)
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided workspace ID '%s' could not be reached.", p.WorkspaceID)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden:
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, "properties.logForwardingProfile.sharedKey", "The provided shared key is not valid for the workspace '%s'.", p.WorkspaceID)
+	}
+
+	return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided workspace ID '%s' is invalid: the workspace returned status code %d.", p.WorkspaceID, resp.StatusCode)
+}
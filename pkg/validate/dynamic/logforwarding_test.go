@@ -0,0 +1,147 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateLogForwarding(t *testing.T) {
+	const (
+		workspaceID = "11111111-1111-1111-1111-111111111111"
+		sharedKey   = "c2hhcmVka2V5"
+	)
+
+	// the workspace accepts requests signed with sharedKey only
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("workspace") != workspaceID {
+			http.NotFound(w, r)
+			return
+		}
+
+		key, _ := base64.StdEncoding.DecodeString(sharedKey)
+		mac := hmac.New(sha256.New, key)
+		fmt.Fprintf(mac, "POST\n%d\napplication/json\nx-ms-date:%s\n/api/logs", r.ContentLength, r.Header.Get("x-ms-date"))
+		if r.Header.Get("Authorization") != "SharedKey "+workspaceID+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)) ||
+			r.Header.Get("Log-Type") != "AROClusterLogs" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}))
+	defer server.Close()
+
+	defer func(u string) { azureMonitorDataCollectorURL = u }(azureMonitorDataCollectorURL)
+	azureMonitorDataCollectorURL = server.URL + "/api/logs?workspace=%s"
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	for _, tt := range []struct {
+		name    string
+		profile *api.LogForwardingProfile
+		dial    func(ctx context.Context, network, address string) (net.Conn, error)
+		wantErr string
+	}{
+		{
+			name: "no log forwarding",
+		},
+		{
+			name: "syslog reachable",
+			profile: &api.LogForwardingProfile{
+				Type: api.LogForwardingTypeSyslog,
+				URL:  "tcp://" + listener.Addr().String(),
+			},
+		},
+		{
+			name: "syslog not reachable",
+			profile: &api.LogForwardingProfile{
+				Type: api.LogForwardingTypeSyslog,
+				URL:  "tls://" + closed.Addr().String(),
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.url: The provided syslog URL 'tls://" + closed.Addr().String() + "' could not be reached.",
+		},
+		{
+			name: "syslog over udp not checked",
+			profile: &api.LogForwardingProfile{
+				Type: api.LogForwardingTypeSyslog,
+				URL:  "udp://" + closed.Addr().String(),
+			},
+		},
+		{
+			name: "syslog at non-public address not checked",
+			profile: &api.LogForwardingProfile{
+				Type: api.LogForwardingTypeSyslog,
+				URL:  "tcp://" + closed.Addr().String(),
+			},
+			dial: newExternalDialer().DialContext,
+		},
+		{
+			name: "workspace valid",
+			profile: &api.LogForwardingProfile{
+				Type:        api.LogForwardingTypeAzureMonitor,
+				WorkspaceID: workspaceID,
+				SharedKey:   sharedKey,
+			},
+		},
+		{
+			name: "shared key not valid",
+			profile: &api.LogForwardingProfile{
+				Type:        api.LogForwardingTypeAzureMonitor,
+				WorkspaceID: workspaceID,
+				SharedKey:   "b3RoZXJrZXk=",
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.sharedKey: The provided shared key is not valid for the workspace '" + workspaceID + "'.",
+		},
+		{
+			name: "workspace not found",
+			profile: &api.LogForwardingProfile{
+				Type:        api.LogForwardingTypeAzureMonitor,
+				WorkspaceID: "22222222-2222-2222-2222-222222222222",
+				SharedKey:   sharedKey,
+			},
+			wantErr: "400: InvalidParameter: properties.logForwardingProfile.workspaceId: The provided workspace ID '22222222-2222-2222-2222-222222222222' is invalid: the workspace returned status code 404.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oc := &api.OpenShiftCluster{}
+			oc.Properties.LogForwardingProfile = tt.profile
+
+			dial := tt.dial
+			if dial == nil {
+				dial = (&net.Dialer{}).DialContext
+			}
+
+			dv := &dynamic{
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				externalClient: server.Client(),
+				externalDial:   dial,
+			}
+
+			err := dv.ValidateLogForwarding(context.Background(), oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mgmtpolicyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/util/stringutils"
+)
+
+// policyResource is a resource which the installation creates.  Its content
+// is partial: the names of most resources derive from the infra ID, which is
+// not known yet, so only the properties which deny policies commonly match on
+// are set.
+type policyResource struct {
+	apiVersion string
+	content    map[string]interface{}
+}
+
+func (r *policyResource) resourceType() string {
+	return r.content["type"].(string)
+}
+
+// ValidatePolicyRestrictions evaluates the resources which the installation
+// creates against the deny policy assignments of the subscription and of the
+// cluster resource group, so that the customer learns about the denials
+// before the installation starts rather than from a failed deployment.  The
+// evaluation is best effort: if the policy restrictions API fails, the check
+// is skipped.
+func (dv *dynamic) ValidatePolicyRestrictions(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidatePolicyRestrictions")
+
+	resourceGroup := stringutils.LastTokenByte(oc.Properties.ClusterProfile.ResourceGroupID, '/')
+	resources := policyResources(oc)
+
+	var details []api.CloudErrorBody
+	denied := map[string]bool{}
+
+	for i := 0; i < len(resources); i++ {
+		r := resources[i]
+
+		request := mgmtpolicyinsights.CheckRestrictionsRequest{
+			ResourceDetails: &mgmtpolicyinsights.CheckRestrictionsResourceDetails{
+				ResourceContent: r.content,
+				APIVersion:      &r.apiVersion,
+			},
+		}
+
+		var result mgmtpolicyinsights.CheckRestrictionsResult
+		var err error
+		if resourceGroup != "" {
+			result, err = dv.policyRestrictions.CheckAtResourceGroupScope(ctx, dv.subscriptionID, resourceGroup, request)
+			if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+				// the RP creates the resource group: evaluate it and the
+				// resources in it against the subscription assignments
+				resources = append(resources, policyResource{
+					apiVersion: "2019-07-01",
+					content: map[string]interface{}{
+						"type":      "Microsoft.Resources/resourceGroups",
+						"name":      resourceGroup,
+						"location":  oc.Location,
+						"managedBy": oc.ID,
+					},
+				})
+				resourceGroup = ""
+			}
+		}
+		if resourceGroup == "" {
+			result, err = dv.policyRestrictions.CheckAtSubscriptionScope(ctx, dv.subscriptionID, request)
+		}
+		if err != nil {
+			dv.log.Warnf("skipping the policy restrictions check: %v", err)
+			return nil
+		}
+
+		if result.ContentEvaluationResult == nil || result.ContentEvaluationResult.PolicyEvaluations == nil {
+			continue
+		}
+
+		for _, evaluation := range *result.ContentEvaluationResult.PolicyEvaluations {
+			if !strings.EqualFold(to.String(evaluation.EvaluationResult), "NonCompliant") || evaluation.PolicyInfo == nil {
+				continue
+			}
+
+			assignment := to.String(evaluation.PolicyInfo.PolicyAssignmentID)
+			key := strings.ToLower(r.resourceType() + "/" + assignment)
+			if denied[key] {
+				continue
+			}
+			denied[key] = true
+
+			details = append(details, api.CloudErrorBody{
+				Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+				Message: fmt.Sprintf("The policy assignment '%s' (policy definition '%s') denies the creation of resources of type '%s'.", assignment, to.String(evaluation.PolicyInfo.PolicyDefinitionID), r.resourceType()),
+			})
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &api.CloudError{
+		StatusCode: http.StatusBadRequest,
+		CloudErrorBody: &api.CloudErrorBody{
+			Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+			Message: "Azure Policy denies resources which the cluster installation creates. Review the listed policy assignments, or exempt the cluster resource group from them, before creating the cluster.",
+			Details: details,
+		},
+	}
+}
+
+// policyResources returns the resources which the installation creates in
+// the cluster resource group
+func policyResources(oc *api.OpenShiftCluster) []policyResource {
+	resources := []policyResource{
+		{
+			apiVersion: "2019-06-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Storage/storageAccounts",
+				"location": oc.Location,
+				"kind":     "StorageV2",
+				"sku": map[string]interface{}{
+					"name": "Standard_LRS",
+				},
+				"properties": map[string]interface{}{
+					"supportsHttpsTrafficOnly": true,
+					"allowBlobPublicAccess":    false,
+					"minimumTlsVersion":        "TLS1_2",
+				},
+			},
+		},
+		{
+			apiVersion: "2020-08-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Network/loadBalancers",
+				"location": oc.Location,
+				"sku": map[string]interface{}{
+					"name": "Standard",
+				},
+			},
+		},
+		{
+			apiVersion: "2020-08-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Network/networkInterfaces",
+				"location": oc.Location,
+			},
+		},
+		{
+			apiVersion: "2020-08-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Network/privateLinkServices",
+				"location": oc.Location,
+			},
+		},
+		{
+			apiVersion: "2020-08-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Network/privateEndpoints",
+				"location": oc.Location,
+			},
+		},
+	}
+
+	// the outbound rules of the public load balancer need a public IP address
+	// unless the egress is user defined
+	if oc.Properties.NetworkProfile.OutboundType != api.OutboundTypeUserDefinedRouting ||
+		oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+		resources = append(resources, policyResource{
+			apiVersion: "2020-08-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Network/publicIPAddresses",
+				"location": oc.Location,
+				"sku": map[string]interface{}{
+					"name": "Standard",
+				},
+				"properties": map[string]interface{}{
+					"publicIPAllocationMethod": "Static",
+				},
+			},
+		})
+	}
+
+	vmSizes := []api.VMSize{oc.Properties.MasterProfile.VMSize}
+	workerProfiles, _ := api.GetEnrichedWorkerProfiles(oc.Properties)
+	for _, wp := range workerProfiles {
+		vmSizes = append(vmSizes, wp.VMSize)
+	}
+
+	seen := map[api.VMSize]bool{}
+	for _, vmSize := range vmSizes {
+		if vmSize == "" || seen[vmSize] {
+			continue
+		}
+		seen[vmSize] = true
+
+		resources = append(resources, policyResource{
+			apiVersion: "2020-06-01",
+			content: map[string]interface{}{
+				"type":     "Microsoft.Compute/virtualMachines",
+				"location": oc.Location,
+				"properties": map[string]interface{}{
+					"hardwareProfile": map[string]interface{}{
+						"vmSize": string(vmSize),
+					},
+				},
+			},
+		})
+	}
+
+	return resources
+}
@@ -0,0 +1,159 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	mgmtpolicyinsights "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_policyinsights "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/policyinsights"
+)
+
+func TestValidatePolicyRestrictions(t *testing.T) {
+	ctx := context.Background()
+
+	subscriptionID := "00000000-0000-0000-0000-000000000000"
+
+	oc := &api.OpenShiftCluster{
+		ID:       "/subscriptions/" + subscriptionID + "/resourceGroups/resourceGroup/providers/Microsoft.RedHatOpenShift/openShiftClusters/cluster",
+		Location: "eastus",
+		Properties: api.OpenShiftClusterProperties{
+			ClusterProfile: api.ClusterProfile{
+				ResourceGroupID: "/subscriptions/" + subscriptionID + "/resourceGroups/aro-cluster",
+			},
+			MasterProfile: api.MasterProfile{
+				VMSize: api.VMSizeStandardD8sV3,
+			},
+			WorkerProfiles: []api.WorkerProfile{
+				{VMSize: api.VMSizeStandardD4sV3},
+				{VMSize: api.VMSizeStandardD4sV3},
+			},
+		},
+	}
+
+	// storage account, load balancer, network interface, private link
+	// service, private endpoint, public IP address and two VM sizes
+	const resources = 8
+
+	resourceType := func(request mgmtpolicyinsights.CheckRestrictionsRequest) string {
+		return request.ResourceDetails.ResourceContent.(map[string]interface{})["type"].(string)
+	}
+
+	denyLoadBalancers := func(_ context.Context, _ string, request mgmtpolicyinsights.CheckRestrictionsRequest) (mgmtpolicyinsights.CheckRestrictionsResult, error) {
+		if resourceType(request) != "Microsoft.Network/loadBalancers" {
+			return mgmtpolicyinsights.CheckRestrictionsResult{}, nil
+		}
+
+		evaluation := mgmtpolicyinsights.PolicyEvaluationResult{
+			EvaluationResult: to.StringPtr("NonCompliant"),
+			PolicyInfo: &mgmtpolicyinsights.PolicyReference{
+				PolicyAssignmentID: to.StringPtr("/subscriptions/" + subscriptionID + "/providers/Microsoft.Authorization/policyAssignments/no-lb"),
+				PolicyDefinitionID: to.StringPtr("/providers/Microsoft.Authorization/policyDefinitions/no-lb"),
+			},
+		}
+
+		return mgmtpolicyinsights.CheckRestrictionsResult{
+			ContentEvaluationResult: &mgmtpolicyinsights.CheckRestrictionsResultContentEvaluationResult{
+				PolicyEvaluations: &[]mgmtpolicyinsights.PolicyEvaluationResult{evaluation, evaluation},
+			},
+		}, nil
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_policyinsights.MockPolicyRestrictionsClient)
+		wantErr error
+	}{
+		{
+			name: "existing resource group, nothing denied",
+			mocks: func(policyRestrictions *mock_policyinsights.MockPolicyRestrictionsClient) {
+				policyRestrictions.EXPECT().
+					CheckAtResourceGroupScope(gomock.Any(), subscriptionID, "aro-cluster", gomock.Any()).
+					Times(resources).
+					Return(mgmtpolicyinsights.CheckRestrictionsResult{}, nil)
+			},
+		},
+		{
+			name: "resource group created by the RP, nothing denied",
+			mocks: func(policyRestrictions *mock_policyinsights.MockPolicyRestrictionsClient) {
+				policyRestrictions.EXPECT().
+					CheckAtResourceGroupScope(gomock.Any(), subscriptionID, "aro-cluster", gomock.Any()).
+					Return(mgmtpolicyinsights.CheckRestrictionsResult{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					})
+				policyRestrictions.EXPECT().
+					CheckAtSubscriptionScope(gomock.Any(), subscriptionID, gomock.Any()).
+					Times(resources + 1).
+					DoAndReturn(func(_ context.Context, _ string, request mgmtpolicyinsights.CheckRestrictionsRequest) (mgmtpolicyinsights.CheckRestrictionsResult, error) {
+						if resourceType(request) == "Microsoft.Resources/resourceGroups" &&
+							request.ResourceDetails.ResourceContent.(map[string]interface{})["name"] != "aro-cluster" {
+							t.Error(request.ResourceDetails.ResourceContent)
+						}
+						return mgmtpolicyinsights.CheckRestrictionsResult{}, nil
+					})
+			},
+		},
+		{
+			name: "load balancers denied",
+			mocks: func(policyRestrictions *mock_policyinsights.MockPolicyRestrictionsClient) {
+				policyRestrictions.EXPECT().
+					CheckAtResourceGroupScope(gomock.Any(), subscriptionID, "aro-cluster", gomock.Any()).
+					Times(resources).
+					DoAndReturn(func(ctx context.Context, subscriptionID, _ string, request mgmtpolicyinsights.CheckRestrictionsRequest) (mgmtpolicyinsights.CheckRestrictionsResult, error) {
+						return denyLoadBalancers(ctx, subscriptionID, request)
+					})
+			},
+			wantErr: &api.CloudError{
+				StatusCode: http.StatusBadRequest,
+				CloudErrorBody: &api.CloudErrorBody{
+					Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+					Message: "Azure Policy denies resources which the cluster installation creates. Review the listed policy assignments, or exempt the cluster resource group from them, before creating the cluster.",
+					Details: []api.CloudErrorBody{
+						{
+							Code:    api.CloudErrorCodeRequestDisallowedByPolicy,
+							Message: "The policy assignment '/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyAssignments/no-lb' (policy definition '/providers/Microsoft.Authorization/policyDefinitions/no-lb') denies the creation of resources of type 'Microsoft.Network/loadBalancers'.",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "policy restrictions API fails",
+			mocks: func(policyRestrictions *mock_policyinsights.MockPolicyRestrictionsClient) {
+				policyRestrictions.EXPECT().
+					CheckAtResourceGroupScope(gomock.Any(), subscriptionID, "aro-cluster", gomock.Any()).
+					Return(mgmtpolicyinsights.CheckRestrictionsResult{}, errors.New("random error"))
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			policyRestrictions := mock_policyinsights.NewMockPolicyRestrictionsClient(controller)
+			tt.mocks(policyRestrictions)
+
+			dv := &dynamic{
+				log:                logrus.NewEntry(logrus.StandardLogger()),
+				subscriptionID:     subscriptionID,
+				policyRestrictions: policyRestrictions,
+			}
+
+			err := dv.ValidatePolicyRestrictions(ctx, oc)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !reflect.DeepEqual(err, tt.wantErr) {
+				t.Error(err)
+			}
+		})
+	}
+}
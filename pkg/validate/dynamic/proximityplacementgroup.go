@@ -0,0 +1,89 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ValidateProximityPlacementGroups validates that the existing proximity
+// placement groups of the worker profiles can be used by the cluster: they must
+// be Standard proximity placement groups in the cluster location.  Proximity
+// placement groups which the RP creates are not validated
+func (dv *dynamic) ValidateProximityPlacementGroups(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateProximityPlacementGroups")
+
+	for i, wp := range oc.Properties.WorkerProfiles {
+		if wp.ProximityPlacementGroupProfile == nil || wp.ProximityPlacementGroupProfile.ID == "" {
+			continue
+		}
+
+		err := dv.validateProximityPlacementGroup(ctx, wp.ProximityPlacementGroupProfile.ID, oc.Location, fmt.Sprintf("properties.workerProfiles[%d].proximityPlacementGroupProfile.id", i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dv *dynamic) validateProximityPlacementGroup(ctx context.Context, id, location, path string) error {
+	r, err := azure.ParseResourceID(id)
+	if err != nil {
+		return err
+	}
+
+	errCode := api.CloudErrorCodeInvalidResourceProviderPermissions
+	if dv.authorizerType == AuthorizerClusterServicePrincipal {
+		errCode = api.CloudErrorCodeInvalidServicePrincipalPermissions
+	}
+
+	err = dv.validateActions(ctx, &r, []string{
+		"Microsoft.Compute/proximityPlacementGroups/read",
+		"Microsoft.Compute/proximityPlacementGroups/write",
+	})
+	if err == wait.ErrWaitTimeout {
+		return api.NewCloudError(http.StatusBadRequest, errCode, path, "The %s service principal does not have Virtual Machine Contributor permission on proximity placement group '%s'.", dv.authorizerType, r.String())
+	}
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The proximity placement group '%s' could not be found.", r.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	ppg, err := dv.proximityPlacementGroups.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		detailedErr.StatusCode == http.StatusNotFound {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The proximity placement group '%s' could not be found.", r.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	if ppg.Location == nil || !strings.EqualFold(*ppg.Location, location) {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The proximity placement group location '%s' must match the cluster location '%s'.", to.String(ppg.Location), location)
+	}
+
+	// an unset type means Standard
+	if ppg.ProximityPlacementGroupProperties != nil &&
+		ppg.ProximityPlacementGroupType != "" &&
+		ppg.ProximityPlacementGroupType != mgmtcompute.Standard {
+		return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The proximity placement group '%s' is invalid: must have the Standard type.", r.String())
+	}
+
+	return nil
+}
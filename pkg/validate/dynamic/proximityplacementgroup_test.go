@@ -0,0 +1,169 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mgmtcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	mgmtauthorization "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-09-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_authorization "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/authorization"
+	mock_compute "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/compute"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateProximityPlacementGroups(t *testing.T) {
+	fakePPGID := "/subscriptions/0000000-0000-0000-0000-000000000000/resourceGroups/fakeRG/providers/Microsoft.Compute/proximityPlacementGroups/infra"
+	fakePPGR, err := azure.ParseResourceID(fakePPGID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oc := &api.OpenShiftCluster{
+		Location: "eastus",
+		Properties: api.OpenShiftClusterProperties{
+			WorkerProfiles: []api.WorkerProfile{
+				{
+					Name: "worker",
+				},
+				{
+					Name: "infra",
+					ProximityPlacementGroupProfile: &api.ProximityPlacementGroupProfile{
+						ID: fakePPGID,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		oc          *api.OpenShiftCluster
+		actions     []string
+		ppg         func(*mgmtcompute.ProximityPlacementGroup)
+		ppgErr      error
+		wantErr     string
+		noAzureCall bool
+	}{
+		{
+			name: "no proximity placement group",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					WorkerProfiles: []api.WorkerProfile{{Name: "worker"}},
+				},
+			},
+			noAzureCall: true,
+		},
+		{
+			name: "proximity placement group created by the RP",
+			oc: &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					WorkerProfiles: []api.WorkerProfile{
+						{Name: "worker"},
+						{Name: "infra", ProximityPlacementGroupProfile: &api.ProximityPlacementGroupProfile{}},
+					},
+				},
+			},
+			noAzureCall: true,
+		},
+		{
+			name: "valid",
+			oc:   oc,
+		},
+		{
+			name: "missing permissions",
+			oc:   oc,
+			// the context is cancelled when the permissions are listed, as
+			// validateActions retries until it is done
+			actions: []string{"Microsoft.Compute/proximityPlacementGroups/read"},
+			wantErr: fmt.Sprintf("400: InvalidResourceProviderPermissions: properties.workerProfiles[1].proximityPlacementGroupProfile.id: The resource provider service principal does not have Virtual Machine Contributor permission on proximity placement group '%s'.", fakePPGID),
+		},
+		{
+			name:    "proximity placement group not found",
+			oc:      oc,
+			ppgErr:  autorest.DetailedError{StatusCode: http.StatusNotFound},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.workerProfiles[1].proximityPlacementGroupProfile.id: The proximity placement group '%s' could not be found.", fakePPGID),
+		},
+		{
+			name: "other location",
+			oc:   oc,
+			ppg: func(ppg *mgmtcompute.ProximityPlacementGroup) {
+				ppg.Location = to.StringPtr("westus")
+			},
+			wantErr: "400: InvalidParameter: properties.workerProfiles[1].proximityPlacementGroupProfile.id: The proximity placement group location 'westus' must match the cluster location 'eastus'.",
+		},
+		{
+			name: "ultra type",
+			oc:   oc,
+			ppg: func(ppg *mgmtcompute.ProximityPlacementGroup) {
+				ppg.ProximityPlacementGroupType = mgmtcompute.Ultra
+			},
+			wantErr: fmt.Sprintf("400: InvalidParameter: properties.workerProfiles[1].proximityPlacementGroupProfile.id: The proximity placement group '%s' is invalid: must have the Standard type.", fakePPGID),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			permissionsClient := mock_authorization.NewMockPermissionsClient(controller)
+			proximityPlacementGroupsClient := mock_compute.NewMockProximityPlacementGroupsClient(controller)
+
+			if !tt.noAzureCall {
+				actions := tt.actions
+				if actions == nil {
+					actions = []string{"Microsoft.Compute/proximityPlacementGroups/*"}
+				}
+				permissionsClient.EXPECT().
+					ListForResource(gomock.Any(), fakePPGR.ResourceGroup, fakePPGR.Provider, "", fakePPGR.ResourceType, fakePPGR.ResourceName).
+					Do(func(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) {
+						if tt.actions != nil {
+							cancel()
+						}
+					}).
+					Return([]mgmtauthorization.Permission{{
+						Actions:    &actions,
+						NotActions: &[]string{},
+					}}, nil).
+					AnyTimes()
+
+				ppg := mgmtcompute.ProximityPlacementGroup{
+					Location: to.StringPtr("eastus"),
+					ProximityPlacementGroupProperties: &mgmtcompute.ProximityPlacementGroupProperties{
+						ProximityPlacementGroupType: mgmtcompute.Standard,
+					},
+				}
+				if tt.ppg != nil {
+					tt.ppg(&ppg)
+				}
+				proximityPlacementGroupsClient.EXPECT().
+					Get(gomock.Any(), fakePPGR.ResourceGroup, fakePPGR.ResourceName, "").
+					Return(ppg, tt.ppgErr).
+					AnyTimes()
+			}
+
+			dv := &dynamic{
+				authorizerType:           AuthorizerFirstParty,
+				log:                      logrus.NewEntry(logrus.StandardLogger()),
+				permissions:              permissionsClient,
+				proximityPlacementGroups: proximityPlacementGroupsClient,
+			}
+
+			err := dv.ValidateProximityPlacementGroups(ctx, tt.oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
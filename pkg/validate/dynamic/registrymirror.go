@@ -0,0 +1,45 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+// ValidateRegistryMirrors checks that each registry mirror is reachable and
+// serves the registry API.  A registry which requires authentication answers
+// 401 Unauthorized, which is fine: the nodes authenticate with the pull
+// secret.
+func (dv *dynamic) ValidateRegistryMirrors(ctx context.Context, oc *api.OpenShiftCluster) error {
+	dv.log.Print("ValidateRegistryMirrors")
+
+	for i, p := range oc.Properties.RegistryMirrorProfiles {
+		path := fmt.Sprintf("properties.registryMirrorProfiles[%d].mirror", i)
+
+		host, _, _ := strings.Cut(p.Mirror, "/")
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/v2/", nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := dv.externalClient.Do(req)
+		if err != nil {
+			dv.log.Info(err)
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided mirror '%s' could not be reached.", p.Mirror)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+			return api.NewCloudError(http.StatusBadRequest, api.CloudErrorCodeInvalidParameter, path, "The provided mirror '%s' is invalid: its registry API returned status code %d.", p.Mirror, resp.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateRegistryMirrors(t *testing.T) {
+	var statusCode int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	mirror := strings.TrimPrefix(server.URL, "https://") + "/docker.io"
+
+	for _, tt := range []struct {
+		name       string
+		mirrors    []string
+		client     *http.Client
+		statusCode int
+		wantErr    string
+	}{
+		{
+			name: "no registry mirrors",
+		},
+		{
+			name:       "valid",
+			mirrors:    []string{mirror},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "valid, authentication required",
+			mirrors:    []string{mirror},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "not a registry",
+			mirrors:    []string{mirror, mirror},
+			statusCode: http.StatusNotFound,
+			wantErr:    "400: InvalidParameter: properties.registryMirrorProfiles[0].mirror: The provided mirror '" + mirror + "' is invalid: its registry API returned status code 404.",
+		},
+		{
+			name:       "non-public address refused",
+			mirrors:    []string{mirror},
+			client:     newExternalClient(),
+			statusCode: http.StatusOK,
+			wantErr:    "400: InvalidParameter: properties.registryMirrorProfiles[0].mirror: The provided mirror '" + mirror + "' could not be reached.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode = tt.statusCode
+
+			oc := &api.OpenShiftCluster{}
+			for _, m := range tt.mirrors {
+				oc.Properties.RegistryMirrorProfiles = append(oc.Properties.RegistryMirrorProfiles, api.RegistryMirrorProfile{
+					Source: "docker.io",
+					Mirror: m,
+				})
+			}
+
+			client := tt.client
+			if client == nil {
+				client = server.Client()
+			}
+
+			dv := &dynamic{
+				log:            logrus.NewEntry(logrus.StandardLogger()),
+				externalClient: client,
+			}
+
+			err := dv.ValidateRegistryMirrors(context.Background(), oc)
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
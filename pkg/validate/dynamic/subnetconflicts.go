@@ -0,0 +1,455 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+)
+
+var (
+	errMsgNSGRuleConflicts = "The provided subnet '%s' is invalid: rule '%s' of network security group '%s' denies %s."
+	errMsgRTRouteConflicts = "The provided subnet '%s' is invalid: route '%s' of route table '%s' drops the traffic to '%s'."
+)
+
+// internet is the address of the traffic from outside the virtual network in
+// a required flow
+const internet = "Internet"
+
+// requiredFlow is traffic which the cluster needs a subnet's network security
+// group to allow.  The sources and destinations are the CIDRs of cluster
+// subnets, or internet.
+type requiredFlow struct {
+	description  string
+	direction    mgmtnetwork.SecurityRuleDirection
+	port         int
+	sources      []string
+	destinations []string
+}
+
+// requiredFlows returns the traffic which the cluster needs the network
+// security group of a subnet with the given prefixes to allow
+func requiredFlows(oc *api.OpenShiftCluster, isMaster bool, subnetPrefixes, masterPrefixes, clusterPrefixes []string) []requiredFlow {
+	flows := []requiredFlow{
+		{
+			description:  "outbound traffic to the API server",
+			direction:    mgmtnetwork.SecurityRuleDirectionOutbound,
+			port:         6443,
+			sources:      subnetPrefixes,
+			destinations: masterPrefixes,
+		},
+		{
+			description:  "inbound traffic to the kubelet",
+			direction:    mgmtnetwork.SecurityRuleDirectionInbound,
+			port:         10250,
+			sources:      clusterPrefixes,
+			destinations: subnetPrefixes,
+		},
+	}
+
+	if isMaster {
+		flows = append(flows,
+			requiredFlow{
+				description:  "inbound traffic to the API server",
+				direction:    mgmtnetwork.SecurityRuleDirectionInbound,
+				port:         6443,
+				sources:      clusterPrefixes,
+				destinations: subnetPrefixes,
+			},
+			requiredFlow{
+				description:  "inbound traffic to the machine config server",
+				direction:    mgmtnetwork.SecurityRuleDirectionInbound,
+				port:         22623,
+				sources:      clusterPrefixes,
+				destinations: subnetPrefixes,
+			},
+		)
+
+		if oc.Properties.APIServerProfile.Visibility == api.VisibilityPublic {
+			flows = append(flows, requiredFlow{
+				description:  "inbound traffic from the internet to the public API server",
+				direction:    mgmtnetwork.SecurityRuleDirectionInbound,
+				port:         6443,
+				sources:      []string{internet},
+				destinations: subnetPrefixes,
+			})
+		}
+	} else {
+		for _, p := range oc.Properties.IngressProfiles {
+			if p.Visibility == api.VisibilityPublic {
+				flows = append(flows, requiredFlow{
+					description:  "inbound traffic from the internet to the public ingress",
+					direction:    mgmtnetwork.SecurityRuleDirectionInbound,
+					port:         443,
+					sources:      []string{internet},
+					destinations: subnetPrefixes,
+				})
+				break
+			}
+		}
+	}
+
+	return flows
+}
+
+// ValidateSubnetConflicts validates that the network security groups and
+// route tables attached to the cluster subnets don't block traffic which the
+// cluster needs.  Only rules and routes which certainly block the traffic are
+// rejected: a deny rule which only applies to part of the traffic, or which
+// is preceded by an allow rule for any of it, can't be known to conflict.
+func (dv *dynamic) ValidateSubnetConflicts(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	dv.log.Print("ValidateSubnetConflicts")
+
+	subnetByID, err := dv.createSubnetMapByID(ctx, subnets)
+	if err != nil {
+		return err
+	}
+
+	var clusterPrefixes []string
+	for _, ss := range subnetByID {
+		clusterPrefixes = append(clusterPrefixes, subnetAddressPrefixes(ss)...)
+	}
+
+	masterPrefixes := subnetAddressPrefixes(subnetByID[oc.Properties.MasterProfile.SubnetID])
+
+	for _, s := range uniqueSubnetSlice(subnets) {
+		ss := subnetByID[s.ID]
+		if ss.SubnetPropertiesFormat == nil {
+			continue
+		}
+
+		if subnetHasNSGAttached(ss) {
+			isMaster := strings.EqualFold(s.ID, oc.Properties.MasterProfile.SubnetID)
+			flows := requiredFlows(oc, isMaster, subnetAddressPrefixes(ss), masterPrefixes, clusterPrefixes)
+
+			err = dv.validateNSGRules(ctx, s, *ss.NetworkSecurityGroup.ID, flows)
+			if err != nil {
+				return err
+			}
+		}
+
+		if ss.RouteTable != nil && ss.RouteTable.ID != nil {
+			err = dv.validateRoutes(ctx, s, *ss.RouteTable.ID, clusterPrefixes)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNSGRules returns an error if, for the traffic between a source and
+// a destination of one of the flows, the network security group has a deny
+// rule which applies to all of the traffic and no allow rule of higher
+// priority which applies to any of it
+func (dv *dynamic) validateNSGRules(ctx context.Context, s Subnet, nsgID string, flows []requiredFlow) error {
+	r, err := azure.ParseResourceID(nsgID)
+	if err != nil {
+		return err
+	}
+
+	nsg, err := dv.securityGroups.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if err != nil {
+		return err
+	}
+
+	if nsg.SecurityGroupPropertiesFormat == nil || nsg.SecurityRules == nil {
+		return nil
+	}
+
+	rules := make([]mgmtnetwork.SecurityRule, 0, len(*nsg.SecurityRules))
+	for _, rule := range *nsg.SecurityRules {
+		if rule.SecurityRulePropertiesFormat != nil && rule.Priority != nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return *rules[i].Priority < *rules[j].Priority })
+
+	for _, flow := range flows {
+		for _, source := range flow.sources {
+			for _, destination := range flow.destinations {
+				rule := denyingRule(rules, flow, source, destination)
+				if rule == nil {
+					continue
+				}
+
+				var name string
+				if rule.Name != nil {
+					name = *rule.Name
+				}
+
+				return api.NewCloudError(
+					http.StatusBadRequest,
+					api.CloudErrorCodeInvalidLinkedVNet,
+					s.Path,
+					errMsgNSGRuleConflicts,
+					s.ID,
+					name,
+					nsgID,
+					flow.description,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// denyingRule returns the rule which certainly denies the traffic of the flow
+// from the source to the destination, if there is one.  rules must be sorted
+// by priority.
+func denyingRule(rules []mgmtnetwork.SecurityRule, flow requiredFlow, source, destination string) *mgmtnetwork.SecurityRule {
+	for i, rule := range rules {
+		if rule.Direction != flow.direction ||
+			!protocolIncludesTCP(rule.Protocol) ||
+			!portRangesContain(stringAndSlice(rule.DestinationPortRange, rule.DestinationPortRanges), flow.port) {
+			continue
+		}
+
+		sourcePrefixes := stringAndSlice(rule.SourceAddressPrefix, rule.SourceAddressPrefixes)
+		destinationPrefixes := stringAndSlice(rule.DestinationAddressPrefix, rule.DestinationAddressPrefixes)
+
+		if rule.Access == mgmtnetwork.SecurityRuleAccessAllow {
+			// application security groups can't be resolved to addresses, so
+			// they may contain the source or destination
+			if (rule.SourceApplicationSecurityGroups != nil || prefixesOverlap(sourcePrefixes, source)) &&
+				(rule.DestinationApplicationSecurityGroups != nil || prefixesOverlap(destinationPrefixes, destination)) {
+				// some of the traffic may be allowed
+				return nil
+			}
+			continue
+		}
+
+		if portRangesContainAll(stringAndSlice(rule.SourcePortRange, rule.SourcePortRanges)) &&
+			prefixesContain(sourcePrefixes, source) &&
+			prefixesContain(destinationPrefixes, destination) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+func protocolIncludesTCP(protocol mgmtnetwork.SecurityRuleProtocol) bool {
+	return protocol == mgmtnetwork.SecurityRuleProtocolAsterisk ||
+		strings.EqualFold(string(protocol), string(mgmtnetwork.SecurityRuleProtocolTCP))
+}
+
+// prefixesContain returns true if one of the address prefixes of a rule, e.g.
+// "*", "VirtualNetwork" or "10.0.0.0/16", contains all the addresses of the
+// flow address, i.e. internet or a cluster subnet CIDR
+func prefixesContain(prefixes []string, address string) bool {
+	for _, prefix := range prefixes {
+		switch {
+		case prefix == "*" || prefix == "0.0.0.0/0":
+			return true
+		case strings.EqualFold(prefix, internet):
+			if address == internet {
+				return true
+			}
+		case strings.EqualFold(prefix, "VirtualNetwork"):
+			if address != internet {
+				return true
+			}
+		default:
+			prefixNet := parsePrefix(prefix)
+			_, addressNet, err := net.ParseCIDR(address)
+			if prefixNet == nil || err != nil {
+				continue
+			}
+
+			prefixOnes, _ := prefixNet.Mask.Size()
+			addressOnes, _ := addressNet.Mask.Size()
+			if prefixOnes <= addressOnes && prefixNet.Contains(addressNet.IP) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prefixesOverlap returns true if one of the address prefixes of a rule may
+// contain some of the addresses of the flow address.  Service tags other than
+// VirtualNetwork and Internet are assumed to overlap.
+func prefixesOverlap(prefixes []string, address string) bool {
+	for _, prefix := range prefixes {
+		if prefixesContain([]string{prefix}, address) {
+			return true
+		}
+
+		if strings.EqualFold(prefix, internet) || strings.EqualFold(prefix, "VirtualNetwork") {
+			continue
+		}
+
+		prefixNet := parsePrefix(prefix)
+		if prefixNet == nil {
+			return true
+		}
+
+		if address == internet {
+			if !prefixNet.IP.IsPrivate() {
+				return true
+			}
+			continue
+		}
+
+		_, addressNet, err := net.ParseCIDR(address)
+		if err != nil || addressNet.Contains(prefixNet.IP) || prefixNet.Contains(addressNet.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrefix parses an address prefix of a rule, which is a CIDR or a single
+// IP address.  It returns nil for service tags.
+func parsePrefix(prefix string) *net.IPNet {
+	_, ipnet, err := net.ParseCIDR(prefix)
+	if err == nil {
+		return ipnet
+	}
+
+	ip := net.ParseIP(prefix)
+	if ip == nil {
+		return nil
+	}
+
+	if ip.To4() != nil {
+		return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+// validateRoutes returns an error if a route of the route table drops the
+// traffic to the internet or to the cluster subnets
+func (dv *dynamic) validateRoutes(ctx context.Context, s Subnet, rtID string, clusterPrefixes []string) error {
+	r, err := azure.ParseResourceID(rtID)
+	if err != nil {
+		return err
+	}
+
+	rt, err := dv.routeTables.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if err != nil {
+		return err
+	}
+
+	if rt.RouteTablePropertiesFormat == nil || rt.Routes == nil {
+		return nil
+	}
+
+	for _, route := range *rt.Routes {
+		if route.RoutePropertiesFormat == nil || route.AddressPrefix == nil ||
+			route.NextHopType != mgmtnetwork.RouteNextHopTypeNone {
+			continue
+		}
+
+		// service tags can't be compared
+		_, routeNet, err := net.ParseCIDR(*route.AddressPrefix)
+		if err != nil {
+			continue
+		}
+
+		conflicts := routeNet.String() == "0.0.0.0/0"
+		for _, prefix := range clusterPrefixes {
+			_, clusterNet, err := net.ParseCIDR(prefix)
+			if err != nil {
+				return err
+			}
+
+			if routeNet.Contains(clusterNet.IP) || clusterNet.Contains(routeNet.IP) {
+				conflicts = true
+			}
+		}
+
+		if conflicts {
+			var name string
+			if route.Name != nil {
+				name = *route.Name
+			}
+
+			return api.NewCloudError(
+				http.StatusBadRequest,
+				api.CloudErrorCodeInvalidLinkedRouteTable,
+				s.Path,
+				errMsgRTRouteConflicts,
+				s.ID,
+				name,
+				rtID,
+				*route.AddressPrefix,
+			)
+		}
+	}
+
+	return nil
+}
+
+func subnetAddressPrefixes(ss *mgmtnetwork.Subnet) []string {
+	if ss.SubnetPropertiesFormat == nil {
+		return nil
+	}
+
+	return stringAndSlice(ss.AddressPrefix, ss.AddressPrefixes)
+}
+
+func stringAndSlice(s *string, slice *[]string) []string {
+	var values []string
+	if s != nil && *s != "" {
+		values = append(values, *s)
+	}
+	if slice != nil {
+		values = append(values, *slice...)
+	}
+	return values
+}
+
+// portRangesContain returns true if one of the port ranges, e.g. "*", "443"
+// or "1-65535", contains the port
+func portRangesContain(portRanges []string, port int) bool {
+	for _, pr := range portRanges {
+		if pr == "*" {
+			return true
+		}
+
+		from, to, found := strings.Cut(pr, "-")
+		if !found {
+			to = from
+		}
+
+		fromPort, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			continue
+		}
+		toPort, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			continue
+		}
+
+		if fromPort <= port && port <= toPort {
+			return true
+		}
+	}
+	return false
+}
+
+// portRangesContainAll returns true if the port ranges contain every port
+func portRangesContainAll(portRanges []string) bool {
+	for _, pr := range portRanges {
+		if pr == "*" || strings.TrimSpace(pr) == "0-65535" || strings.TrimSpace(pr) == "1-65535" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateSubnetConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	masterNSGID := resourceGroupID + "/providers/Microsoft.Network/networkSecurityGroups/masterNSG"
+
+	rule := func(name string, priority int32, access mgmtnetwork.SecurityRuleAccess, direction mgmtnetwork.SecurityRuleDirection, source, destination, ports string) mgmtnetwork.SecurityRule {
+		return mgmtnetwork.SecurityRule{
+			Name: to.StringPtr(name),
+			SecurityRulePropertiesFormat: &mgmtnetwork.SecurityRulePropertiesFormat{
+				Priority:                 to.Int32Ptr(priority),
+				Access:                   access,
+				Direction:                direction,
+				Protocol:                 mgmtnetwork.SecurityRuleProtocolAsterisk,
+				SourceAddressPrefix:      to.StringPtr(source),
+				SourcePortRange:          to.StringPtr("*"),
+				DestinationAddressPrefix: to.StringPtr(destination),
+				DestinationPortRange:     to.StringPtr(ports),
+			},
+		}
+	}
+
+	// customer NSGs set some of the fields differently to the rules above
+	withProtocol := func(r mgmtnetwork.SecurityRule, protocol mgmtnetwork.SecurityRuleProtocol) mgmtnetwork.SecurityRule {
+		r.Protocol = protocol
+		return r
+	}
+
+	withSourcePrefixes := func(r mgmtnetwork.SecurityRule, prefixes ...string) mgmtnetwork.SecurityRule {
+		r.SourceAddressPrefix = to.StringPtr("")
+		r.SourceAddressPrefixes = &prefixes
+		return r
+	}
+
+	withSourcePorts := func(r mgmtnetwork.SecurityRule, ports string) mgmtnetwork.SecurityRule {
+		r.SourcePortRange = to.StringPtr(ports)
+		return r
+	}
+
+	withSourceASG := func(r mgmtnetwork.SecurityRule) mgmtnetwork.SecurityRule {
+		r.SourceAddressPrefix = nil
+		r.SourceApplicationSecurityGroups = &[]mgmtnetwork.ApplicationSecurityGroup{
+			{ID: to.StringPtr(resourceGroupID + "/providers/Microsoft.Network/applicationSecurityGroups/cluster")},
+		}
+		return r
+	}
+
+	nsg := func(rules ...mgmtnetwork.SecurityRule) mgmtnetwork.SecurityGroup {
+		return mgmtnetwork.SecurityGroup{
+			SecurityGroupPropertiesFormat: &mgmtnetwork.SecurityGroupPropertiesFormat{
+				SecurityRules: &rules,
+			},
+		}
+	}
+
+	route := func(name, addressPrefix string, nextHopType mgmtnetwork.RouteNextHopType) mgmtnetwork.Route {
+		return mgmtnetwork.Route{
+			Name: to.StringPtr(name),
+			RoutePropertiesFormat: &mgmtnetwork.RoutePropertiesFormat{
+				AddressPrefix: to.StringPtr(addressPrefix),
+				NextHopType:   nextHopType,
+			},
+		}
+	}
+
+	rt := func(routes ...mgmtnetwork.Route) mgmtnetwork.RouteTable {
+		return mgmtnetwork.RouteTable{
+			RouteTablePropertiesFormat: &mgmtnetwork.RouteTablePropertiesFormat{
+				Routes: &routes,
+			},
+		}
+	}
+
+	vnet := mgmtnetwork.VirtualNetwork{
+		ID: to.StringPtr(vnetID),
+		VirtualNetworkPropertiesFormat: &mgmtnetwork.VirtualNetworkPropertiesFormat{
+			Subnets: &[]mgmtnetwork.Subnet{
+				{
+					ID: to.StringPtr(masterSubnet),
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						AddressPrefix:        to.StringPtr("10.0.0.0/24"),
+						NetworkSecurityGroup: &mgmtnetwork.SecurityGroup{ID: to.StringPtr(masterNSGID)},
+					},
+				},
+				{
+					ID: to.StringPtr(workerSubnet),
+					SubnetPropertiesFormat: &mgmtnetwork.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.1.0/24"),
+						RouteTable:    &mgmtnetwork.RouteTable{ID: to.StringPtr(workerRtID)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name    string
+		nsg     mgmtnetwork.SecurityGroup
+		rt      mgmtnetwork.RouteTable
+		wantErr string
+	}{
+		{
+			name: "pass: no conflicting rules or routes",
+			nsg: nsg(
+				rule("deny-ssh", 100, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "22"),
+				rule("deny-from-office", 110, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "192.168.0.0/16", "*", "*"),
+			),
+			rt: rt(
+				route("to-firewall", "0.0.0.0/0", mgmtnetwork.RouteNextHopTypeVirtualAppliance),
+				route("blackhole-other", "192.168.0.0/16", mgmtnetwork.RouteNextHopTypeNone),
+			),
+		},
+		{
+			name: "pass: blanket deny is preceded by an allow rule",
+			nsg: nsg(
+				rule("deny-all", 4000, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+				rule("allow-cluster", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "VirtualNetwork", "VirtualNetwork", "*"),
+				rule("allow-api", 110, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "Internet", "*", "6443"),
+			),
+			rt: rt(),
+		},
+		{
+			name: "pass: cluster subnets are allowed by CIDR before everything is denied",
+			nsg: nsg(
+				rule("allow-master", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "10.0.0.0/24", "10.0.0.0/24", "*"),
+				rule("allow-workers", 110, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "10.0.1.0/24", "10.0.0.0/24", "*"),
+				withProtocol(rule("allow-api", 120, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "Internet", "10.0.0.0/24", "6443"), "Tcp"),
+				rule("deny-all-inbound", 4096, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+				rule("deny-all-outbound", 4096, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionOutbound, "*", "*", "*"),
+				withProtocol(rule("allow-cluster-outbound", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionOutbound, "10.0.0.0/16", "10.0.0.0/16", "*"), "Tcp"),
+			),
+			rt: rt(),
+		},
+		{
+			name: "pass: public API server is only allowed from the customer's network",
+			nsg: nsg(
+				rule("allow-vnet", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "VirtualNetwork", "VirtualNetwork", "*"),
+				withSourcePrefixes(rule("allow-office", 110, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "", "*", "6443"), "203.0.113.0/24", "198.51.100.10"),
+				rule("deny-internet", 4000, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "Internet", "*", "*"),
+			),
+			rt: rt(),
+		},
+		{
+			name: "pass: deny rules only apply to some of the traffic",
+			nsg: nsg(
+				withSourcePrefixes(rule("deny-compromised-hosts", 100, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "", "*", "*"), "10.0.1.4", "10.0.1.5"),
+				withProtocol(rule("deny-udp", 110, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"), "Udp"),
+				withSourcePorts(rule("deny-low-source-ports", 120, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"), "0-1023"),
+				rule("deny-to-other-subnet", 130, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "10.0.2.0/24", "*"),
+				rule("deny-other-ports", 140, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "3389"),
+				rule("allow-all", 150, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+			),
+			rt: rt(),
+		},
+		{
+			name: "pass: cluster is allowed by application security group",
+			nsg: nsg(
+				withSourceASG(rule("allow-cluster", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "", "*", "*")),
+				rule("allow-internet", 110, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "Internet", "*", "6443"),
+				rule("deny-all", 4096, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+			),
+			rt: rt(),
+		},
+		{
+			name: "fail: blanket deny blocks the API server",
+			nsg: nsg(
+				rule("deny-all", 4000, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+				rule("allow-cluster", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "VirtualNetwork", "VirtualNetwork", "*"),
+			),
+			rt:      rt(),
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: rule 'deny-all' of network security group '" + masterNSGID + "' denies inbound traffic from the internet to the public API server.",
+		},
+		{
+			name: "fail: deny rule blocks the kubelet",
+			nsg: nsg(
+				rule("deny-range", 100, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "VirtualNetwork", "*", "10000-20000"),
+			),
+			rt:      rt(),
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: rule 'deny-range' of network security group '" + masterNSGID + "' denies inbound traffic to the kubelet.",
+		},
+		{
+			name: "fail: deny rule blocks the worker subnet",
+			nsg: nsg(
+				rule("allow-master", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "10.0.0.0/24", "*", "*"),
+				withProtocol(rule("deny-workers", 110, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "10.0.1.0/24", "*", "10250"), "Tcp"),
+			),
+			rt:      rt(),
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: rule 'deny-workers' of network security group '" + masterNSGID + "' denies inbound traffic to the kubelet.",
+		},
+		{
+			name: "fail: allow rule for other addresses is followed by a blanket deny",
+			nsg: nsg(
+				rule("allow-onprem", 100, mgmtnetwork.SecurityRuleAccessAllow, mgmtnetwork.SecurityRuleDirectionInbound, "192.168.0.0/16", "*", "*"),
+				rule("deny-all", 4096, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionInbound, "*", "*", "*"),
+			),
+			rt:      rt(),
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: rule 'deny-all' of network security group '" + masterNSGID + "' denies inbound traffic to the kubelet.",
+		},
+		{
+			name: "fail: deny rule blocks the outbound traffic to the API server",
+			nsg: nsg(
+				rule("deny-vnet-outbound", 100, mgmtnetwork.SecurityRuleAccessDeny, mgmtnetwork.SecurityRuleDirectionOutbound, "*", "VirtualNetwork", "*"),
+			),
+			rt:      rt(),
+			wantErr: "400: InvalidLinkedVNet: properties.masterProfile.subnetId: The provided subnet '" + masterSubnet + "' is invalid: rule 'deny-vnet-outbound' of network security group '" + masterNSGID + "' denies outbound traffic to the API server.",
+		},
+		{
+			name: "fail: route drops the traffic to the internet",
+			nsg:  nsg(),
+			rt: rt(
+				route("blackhole", "0.0.0.0/0", mgmtnetwork.RouteNextHopTypeNone),
+			),
+			wantErr: "400: InvalidLinkedRouteTable: properties.workerProfile.subnetId: The provided subnet '" + workerSubnet + "' is invalid: route 'blackhole' of route table '" + workerRtID + "' drops the traffic to '0.0.0.0/0'.",
+		},
+		{
+			name: "fail: route drops the traffic to the master subnet",
+			nsg:  nsg(),
+			rt: rt(
+				route("blackhole", "10.0.0.0/16", mgmtnetwork.RouteNextHopTypeNone),
+			),
+			wantErr: "400: InvalidLinkedRouteTable: properties.workerProfile.subnetId: The provided subnet '" + workerSubnet + "' is invalid: route 'blackhole' of route table '" + workerRtID + "' drops the traffic to '10.0.0.0/16'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			oc := &api.OpenShiftCluster{
+				Properties: api.OpenShiftClusterProperties{
+					ProvisioningState: api.ProvisioningStateSucceeded,
+					MasterProfile: api.MasterProfile{
+						SubnetID: masterSubnet,
+					},
+					APIServerProfile: api.APIServerProfile{
+						Visibility: api.VisibilityPublic,
+					},
+				},
+			}
+
+			vnetClient := mock_network.NewMockVirtualNetworksClient(controller)
+			securityGroupsClient := mock_network.NewMockSecurityGroupsClient(controller)
+			routeTablesClient := mock_network.NewMockRouteTablesClient(controller)
+
+			vnetClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, vnetName, "").
+				Return(vnet, nil).
+				AnyTimes()
+			securityGroupsClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "masterNSG", "").
+				Return(tt.nsg, nil)
+			routeTablesClient.EXPECT().
+				Get(gomock.Any(), resourceGroupName, "workerRt", "").
+				Return(tt.rt, nil).
+				MaxTimes(1)
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				virtualNetworks: vnetClient,
+				securityGroups:  securityGroupsClient,
+				routeTables:     routeTablesClient,
+			}
+
+			err := dv.ValidateSubnetConflicts(ctx, oc, []Subnet{
+				{ID: masterSubnet, Path: masterSubnetPath},
+				{ID: workerSubnet, Path: workerSubnetPath},
+			})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPortRangesContain(t *testing.T) {
+	for _, tt := range []struct {
+		portRanges []string
+		want       bool
+	}{
+		{portRanges: []string{"*"}, want: true},
+		{portRanges: []string{"443", "6443"}, want: true},
+		{portRanges: []string{"6000-7000"}, want: true},
+		{portRanges: []string{"443", "6444-7000"}},
+		{portRanges: []string{"invalid"}},
+		{},
+	} {
+		if got := portRangesContain(tt.portRanges, 6443); got != tt.want {
+			t.Errorf("%v: got %t, want %t", tt.portRanges, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	apisubnet "github.com/Azure/ARO-RP/pkg/api/util/subnet"
+)
+
+type clusterCIDR struct {
+	name string
+	cidr string
+}
+
+// ValidateVnetPeerings validates that the pod and service CIDRs of the cluster
+// don't overlap the address spaces of the vnets peered with the cluster vnet.
+// If a peered vnet, e.g. a hub, is in the cluster subscription and can be
+// read, the address spaces of the vnets peered with it, e.g. the other
+// spokes, are checked too.
+func (dv *dynamic) ValidateVnetPeerings(ctx context.Context, oc *api.OpenShiftCluster, subnets []Subnet) error {
+	dv.log.Print("ValidateVnetPeerings")
+
+	clusterCIDRs := []clusterCIDR{
+		{name: "podCidr", cidr: oc.Properties.NetworkProfile.PodCIDR},
+		{name: "serviceCidr", cidr: oc.Properties.NetworkProfile.ServiceCIDR},
+	}
+
+	vnetIDs := map[string]string{}
+	for _, s := range uniqueSubnetSlice(subnets) {
+		vnetID, _, err := apisubnet.Split(s.ID)
+		if err != nil {
+			return err
+		}
+		vnetIDs[strings.ToLower(vnetID)] = vnetID
+	}
+
+	for _, vnetID := range vnetIDs {
+		vnetr, err := azure.ParseResourceID(vnetID)
+		if err != nil {
+			return err
+		}
+
+		vnet, err := dv.virtualNetworks.Get(ctx, vnetr.ResourceGroup, vnetr.ResourceName, "")
+		if err != nil {
+			return err
+		}
+
+		for _, peering := range vnetPeerings(&vnet) {
+			err = validateNoOverlapWithPeering(clusterCIDRs, peering)
+			if err != nil {
+				return err
+			}
+
+			remoteVnet, err := dv.getPeeredVnet(ctx, peering)
+			if err != nil {
+				return err
+			}
+			if remoteVnet == nil {
+				continue
+			}
+
+			for _, remotePeering := range vnetPeerings(remoteVnet) {
+				// the peering back to the cluster vnet
+				if remotePeering.RemoteVirtualNetwork != nil &&
+					remotePeering.RemoteVirtualNetwork.ID != nil &&
+					strings.EqualFold(*remotePeering.RemoteVirtualNetwork.ID, vnetID) {
+					continue
+				}
+
+				err = validateNoOverlapWithPeering(clusterCIDRs, remotePeering)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getPeeredVnet returns the remote vnet of the peering, or nil if it isn't in
+// the cluster subscription or can't be read
+func (dv *dynamic) getPeeredVnet(ctx context.Context, peering mgmtnetwork.VirtualNetworkPeering) (*mgmtnetwork.VirtualNetwork, error) {
+	if peering.RemoteVirtualNetwork == nil || peering.RemoteVirtualNetwork.ID == nil {
+		return nil, nil
+	}
+
+	r, err := azure.ParseResourceID(*peering.RemoteVirtualNetwork.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(r.SubscriptionID, dv.subscriptionID) {
+		return nil, nil
+	}
+
+	vnet, err := dv.virtualNetworks.Get(ctx, r.ResourceGroup, r.ResourceName, "")
+	if detailedErr, ok := err.(autorest.DetailedError); ok &&
+		(detailedErr.StatusCode == http.StatusForbidden || detailedErr.StatusCode == http.StatusNotFound) {
+		dv.log.Printf("skipping peerings of vnet %s: %v", r.String(), err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &vnet, nil
+}
+
+func vnetPeerings(vnet *mgmtnetwork.VirtualNetwork) []mgmtnetwork.VirtualNetworkPeering {
+	if vnet.VirtualNetworkPropertiesFormat == nil || vnet.VirtualNetworkPeerings == nil {
+		return nil
+	}
+
+	var peerings []mgmtnetwork.VirtualNetworkPeering
+	for _, peering := range *vnet.VirtualNetworkPeerings {
+		if peering.VirtualNetworkPeeringPropertiesFormat != nil {
+			peerings = append(peerings, peering)
+		}
+	}
+
+	return peerings
+}
+
+func validateNoOverlapWithPeering(clusterCIDRs []clusterCIDR, peering mgmtnetwork.VirtualNetworkPeering) error {
+	if peering.RemoteAddressSpace == nil || peering.RemoteAddressSpace.AddressPrefixes == nil {
+		return nil
+	}
+
+	var remoteVnetID string
+	if peering.RemoteVirtualNetwork != nil && peering.RemoteVirtualNetwork.ID != nil {
+		remoteVnetID = *peering.RemoteVirtualNetwork.ID
+	}
+
+	for _, prefix := range *peering.RemoteAddressSpace.AddressPrefixes {
+		_, remote, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range clusterCIDRs {
+			_, local, err := net.ParseCIDR(c.cidr)
+			if err != nil {
+				return err
+			}
+
+			if local.Contains(remote.IP) || remote.Contains(local.IP) {
+				return api.NewCloudError(
+					http.StatusBadRequest,
+					api.CloudErrorCodeInvalidLinkedVNet,
+					"properties.networkProfile."+c.name,
+					"The provided %s '%s' overlaps with the address range '%s' of the peered vnet '%s'.",
+					c.name,
+					c.cidr,
+					prefix,
+					remoteVnetID,
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package dynamic
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mgmtnetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	mock_network "github.com/Azure/ARO-RP/pkg/util/mocks/azureclient/mgmt/network"
+	utilerror "github.com/Azure/ARO-RP/test/util/error"
+)
+
+func TestValidateVnetPeerings(t *testing.T) {
+	ctx := context.Background()
+
+	hubID := resourceGroupID + "/providers/Microsoft.Network/virtualNetworks/hub"
+	otherSubscriptionHubID := "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/hub/providers/Microsoft.Network/virtualNetworks/hub"
+	spokeID := resourceGroupID + "/providers/Microsoft.Network/virtualNetworks/spoke"
+
+	oc := &api.OpenShiftCluster{
+		Properties: api.OpenShiftClusterProperties{
+			NetworkProfile: api.NetworkProfile{
+				PodCIDR:     "10.128.0.0/14",
+				ServiceCIDR: "172.30.0.0/16",
+			},
+		},
+	}
+
+	peering := func(remoteID string, addressPrefixes ...string) mgmtnetwork.VirtualNetworkPeering {
+		return mgmtnetwork.VirtualNetworkPeering{
+			VirtualNetworkPeeringPropertiesFormat: &mgmtnetwork.VirtualNetworkPeeringPropertiesFormat{
+				RemoteVirtualNetwork: &mgmtnetwork.SubResource{ID: to.StringPtr(remoteID)},
+				RemoteAddressSpace: &mgmtnetwork.AddressSpace{
+					AddressPrefixes: &addressPrefixes,
+				},
+			},
+		}
+	}
+
+	vnet := func(peerings ...mgmtnetwork.VirtualNetworkPeering) mgmtnetwork.VirtualNetwork {
+		return mgmtnetwork.VirtualNetwork{
+			VirtualNetworkPropertiesFormat: &mgmtnetwork.VirtualNetworkPropertiesFormat{
+				VirtualNetworkPeerings: &peerings,
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		mocks   func(*mock_network.MockVirtualNetworksClient)
+		wantErr string
+	}{
+		{
+			name: "pass: no peerings",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(), nil)
+			},
+		},
+		{
+			name: "pass: peered vnet in another subscription does not overlap",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(peering(otherSubscriptionHubID, "10.0.0.0/16")), nil)
+			},
+		},
+		{
+			name: "pass: hub and other spokes do not overlap",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(peering(hubID, "10.0.0.0/16")), nil)
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "hub", "").
+					Return(vnet(
+						peering(vnetID, "10.128.0.0/14"),
+						peering(spokeID, "10.1.0.0/16"),
+					), nil)
+			},
+		},
+		{
+			name: "pass: hub can not be read",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(peering(hubID, "10.0.0.0/16")), nil)
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "hub", "").
+					Return(mgmtnetwork.VirtualNetwork{}, autorest.DetailedError{StatusCode: http.StatusForbidden})
+			},
+		},
+		{
+			name: "fail: peered vnet overlaps the pod CIDR",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(peering(otherSubscriptionHubID, "10.0.0.0/16", "10.130.0.0/24")), nil)
+			},
+			wantErr: "400: InvalidLinkedVNet: properties.networkProfile.podCidr: The provided podCidr '10.128.0.0/14' overlaps with the address range '10.130.0.0/24' of the peered vnet '" + otherSubscriptionHubID + "'.",
+		},
+		{
+			name: "fail: other spoke overlaps the service CIDR",
+			mocks: func(vnetClient *mock_network.MockVirtualNetworksClient) {
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, vnetName, "").
+					Return(vnet(peering(hubID, "10.0.0.0/16")), nil)
+				vnetClient.EXPECT().
+					Get(gomock.Any(), resourceGroupName, "hub", "").
+					Return(vnet(peering(spokeID, "172.16.0.0/12")), nil)
+			},
+			wantErr: "400: InvalidLinkedVNet: properties.networkProfile.serviceCidr: The provided serviceCidr '172.30.0.0/16' overlaps with the address range '172.16.0.0/12' of the peered vnet '" + spokeID + "'.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			vnetClient := mock_network.NewMockVirtualNetworksClient(controller)
+			tt.mocks(vnetClient)
+
+			dv := &dynamic{
+				log:             logrus.NewEntry(logrus.StandardLogger()),
+				subscriptionID:  subscriptionID,
+				virtualNetworks: vnetClient,
+			}
+
+			err := dv.ValidateVnetPeerings(ctx, oc, []Subnet{{ID: masterSubnet}, {ID: workerSubnet}})
+			utilerror.AssertErrorMessage(t, err, tt.wantErr)
+		})
+	}
+}
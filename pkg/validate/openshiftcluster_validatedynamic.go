@@ -28,6 +28,7 @@ import (
 // OpenShiftClusterDynamicValidator is the dynamic validator interface
 type OpenShiftClusterDynamicValidator interface {
 	Dynamic(context.Context) error
+	DynamicAll(context.Context) ([]error, error)
 }
 
 // NewOpenShiftClusterDynamicValidator creates a new OpenShiftClusterDynamicValidator
@@ -115,8 +116,27 @@ func ensureAccessTokenClaims(ctx context.Context, spTokenCredential azcore.Token
 	return err
 }
 
-// Dynamic validates an OpenShift cluster
+// Dynamic validates an OpenShift cluster, returning the first failure
 func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
+	failures, err := dv.validate(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		return failures[0]
+	}
+
+	return nil
+}
+
+// DynamicAll validates an OpenShift cluster, returning all the failures.  An
+// error is returned only if the validation could not be run.
+func (dv *openShiftClusterDynamicValidator) DynamicAll(ctx context.Context) ([]error, error) {
+	return dv.validate(ctx, false)
+}
+
+func (dv *openShiftClusterDynamicValidator) validate(ctx context.Context, failFast bool) ([]error, error) {
 	// Get all subnets
 	subnets := []dynamic.Subnet{{
 		ID:   dv.oc.Properties.MasterProfile.SubnetID,
@@ -139,7 +159,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 	useCheckAccess, err := dv.env.LiveConfig().UseCheckAccess(ctx)
 	dv.log.Info("USE_CHECKACCESS: ", useCheckAccess)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if useCheckAccess || feature.IsRegisteredForFeature(
@@ -151,7 +171,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		var err error
 		fpClientCred, err = dv.env.FPNewClientCertificateCredential(dv.subscriptionDoc.Subscription.Properties.TenantID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		spClientCred, err = azidentity.NewClientSecretCredential(
@@ -161,7 +181,7 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 			nil,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		aroEnv := dv.env.Environment()
@@ -177,68 +197,120 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 	spTokenCredential, err := azidentity.NewClientSecretCredential(
 		tenantID, spp.ClientID, string(spp.ClientSecret), options)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var failures []error
+
+	// stop records a validation failure and reports whether validation
+	// should stop
+	stop := func(err error) bool {
+		if err == nil {
+			return false
+		}
+		failures = append(failures, err)
+		return failFast
 	}
 
 	scopes := []string{dv.env.Environment().ResourceManagerScope}
 	err = ensureAccessTokenClaims(ctx, spTokenCredential, scopes)
-	if err != nil {
-		return err
+	if stop(err) {
+		return failures, nil
 	}
-	spAuthorizer := azidext.NewTokenCredentialAdapter(spTokenCredential, scopes)
 
-	spDynamic := dynamic.NewValidator(
-		dv.log,
-		dv.env,
-		dv.env.Environment(),
-		dv.subscriptionDoc.ID,
-		spAuthorizer,
-		spp.ClientID,
-		dynamic.AuthorizerClusterServicePrincipal,
-		spClientCred,
-		pdpClient,
-	)
+	// the service principal validations can't run if it can't authenticate
+	if err == nil {
+		spAuthorizer := azidext.NewTokenCredentialAdapter(spTokenCredential, scopes)
 
-	// SP validation
-	err = spDynamic.ValidateServicePrincipal(ctx, spTokenCredential)
-	if err != nil {
-		return err
-	}
+		spDynamic := dynamic.NewValidator(
+			dv.log,
+			dv.env,
+			dv.env.Environment(),
+			dv.subscriptionDoc.ID,
+			spAuthorizer,
+			spp.ClientID,
+			dynamic.AuthorizerClusterServicePrincipal,
+			spClientCred,
+			pdpClient,
+		)
 
-	err = spDynamic.ValidateVnet(
-		ctx,
-		dv.oc.Location,
-		subnets,
-		dv.oc.Properties.NetworkProfile.PodCIDR,
-		dv.oc.Properties.NetworkProfile.ServiceCIDR,
-	)
-	if err != nil {
-		return err
-	}
+		// SP validation
+		if stop(spDynamic.ValidateServicePrincipal(ctx, spTokenCredential)) {
+			return failures, nil
+		}
 
-	err = spDynamic.ValidateSubnets(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
-	}
+		if stop(spDynamic.ValidateVnet(
+			ctx,
+			dv.oc.Location,
+			subnets,
+			dv.oc.Properties.NetworkProfile.PodCIDR,
+			dv.oc.Properties.NetworkProfile.ServiceCIDR,
+		)) {
+			return failures, nil
+		}
 
-	err = spDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
+		if stop(spDynamic.ValidateVnetPeerings(ctx, dv.oc, subnets)) {
+			return failures, nil
+		}
 
-	err = spDynamic.ValidateEncryptionAtHost(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
+		if stop(spDynamic.ValidateSubnets(ctx, dv.oc, subnets)) {
+			return failures, nil
+		}
 
-	err = spDynamic.ValidateLoadBalancerProfile(ctx, dv.oc)
-	if err != nil {
-		return err
-	}
+		if !feature.IsRegisteredForFeature(
+			dv.subscriptionDoc.Subscription.Properties,
+			api.FeatureFlagSkipSubnetConflictValidation,
+		) {
+			if stop(spDynamic.ValidateSubnetConflicts(ctx, dv.oc, subnets)) {
+				return failures, nil
+			}
+		}
 
-	err = spDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
+		if stop(spDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateProximityPlacementGroups(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateEncryptionAtHost(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateLoadBalancerProfile(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		// the operator keeps the backends on the customer load balancer in
+		// sync with the nodes
+		if stop(spDynamic.ValidateCustomerLoadBalancer(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateIdentityProvider(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateRegistryMirrors(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateLogForwarding(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateDNSZone(ctx, dv.oc)) {
+			return failures, nil
+		}
+
+		if stop(spDynamic.ValidateAzureFileCSI(ctx, dv.oc)) {
+			return failures, nil
+		}
 	}
 
 	// FP validation
@@ -254,26 +326,38 @@ func (dv *openShiftClusterDynamicValidator) Dynamic(ctx context.Context) error {
 		pdpClient,
 	)
 
-	err = fpDynamic.ValidateVnet(
+	if stop(fpDynamic.ValidateVnet(
 		ctx,
 		dv.oc.Location,
 		subnets,
 		dv.oc.Properties.NetworkProfile.PodCIDR,
 		dv.oc.Properties.NetworkProfile.ServiceCIDR,
-	)
-	if err != nil {
-		return err
+	)) {
+		return failures, nil
 	}
 
-	err = fpDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)
-	if err != nil {
-		return err
+	if stop(fpDynamic.ValidateDiskEncryptionSets(ctx, dv.oc)) {
+		return failures, nil
 	}
 
-	err = fpDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)
-	if err != nil {
-		return err
+	// the RP places the availability sets of the worker profiles in the
+	// proximity placement groups
+	if stop(fpDynamic.ValidateProximityPlacementGroups(ctx, dv.oc)) {
+		return failures, nil
 	}
 
-	return nil
+	if stop(fpDynamic.ValidatePreConfiguredNSGs(ctx, dv.oc, subnets)) {
+		return failures, nil
+	}
+
+	// the RP registers the backends on the customer load balancer
+	if stop(fpDynamic.ValidateCustomerLoadBalancer(ctx, dv.oc)) {
+		return failures, nil
+	}
+
+	if stop(fpDynamic.ValidatePolicyRestrictions(ctx, dv.oc)) {
+		return failures, nil
+	}
+
+	return failures, nil
 }
@@ -16,35 +16,60 @@
 
 try:
     from ._models_py3 import APIServerProfile
+    from ._models_py3 import AdmissionWebhookProfile
+    from ._models_py3 import AzureFileCSIProfile
     from ._models_py3 import CloudErrorBody
+    from ._models_py3 import ClusterIdentity
     from ._models_py3 import ClusterProfile
     from ._models_py3 import ConsoleProfile
+    from ._models_py3 import CustomerLoadBalancerProfile
+    from ._models_py3 import DefaultStorageClassProfile
     from ._models_py3 import Display
     from ._models_py3 import EffectiveOutboundIP
+    from ._models_py3 import EffectiveOutboundIPPrefix
+    from ._models_py3 import EffectiveOutboundProfile
+    from ._models_py3 import EgressDestination
+    from ._models_py3 import EgressFirewallProfile
+    from ._models_py3 import EvictionThresholds
+    from ._models_py3 import IdentityProviderProfile
     from ._models_py3 import IngressProfile
     from ._models_py3 import LoadBalancerProfile
+    from ._models_py3 import LogForwardingProfile
     from ._models_py3 import MachinePool
     from ._models_py3 import MachinePoolList
     from ._models_py3 import MachinePoolUpdate
+    from ._models_py3 import MaintenanceWindow
+    from ._models_py3 import ManagedOutboundIPPrefix
     from ._models_py3 import ManagedOutboundIPs
     from ._models_py3 import MasterProfile
+    from ._models_py3 import MonitoringProfile
     from ._models_py3 import NetworkProfile
+    from ._models_py3 import NodeEvictionProfile
     from ._models_py3 import OpenShiftCluster
     from ._models_py3 import OpenShiftClusterAdminKubeconfig
     from ._models_py3 import OpenShiftClusterCredentials
     from ._models_py3 import OpenShiftClusterList
+    from ._models_py3 import OpenShiftClusterTemplate
     from ._models_py3 import OpenShiftClusterUpdate
+    from ._models_py3 import OpenShiftClusterValidationFindings
     from ._models_py3 import OpenShiftVersion
     from ._models_py3 import OpenShiftVersionList
+    from ._models_py3 import OpenShiftVersionMatrix
+    from ._models_py3 import OpenShiftVersionMatrixVersion
     from ._models_py3 import Operation
     from ._models_py3 import OperationList
     from ._models_py3 import OutboundIP
     from ._models_py3 import OutboundIPPrefix
+    from ._models_py3 import ProjectTemplateProfile
+    from ._models_py3 import ProximityPlacementGroupProfile
     from ._models_py3 import ProxyResource
+    from ._models_py3 import RegistryMirrorProfile
     from ._models_py3 import Resource
+    from ._models_py3 import SchedulerProfile
     from ._models_py3 import Secret
     from ._models_py3 import SecretList
     from ._models_py3 import SecretUpdate
+    from ._models_py3 import SecurityProfile
     from ._models_py3 import ServicePrincipalProfile
     from ._models_py3 import SyncIdentityProvider
     from ._models_py3 import SyncIdentityProviderList
@@ -54,38 +79,67 @@ try:
     from ._models_py3 import SyncSetUpdate
     from ._models_py3 import SystemData
     from ._models_py3 import TrackedResource
+    from ._models_py3 import UpgradeProfile
+    from ._models_py3 import ValidationFinding
+    from ._models_py3 import WorkerMachineSetsProgress
     from ._models_py3 import WorkerProfile
+    from ._models_py3 import WorkerProfileScale
 except (SyntaxError, ImportError):
     from ._models import APIServerProfile  # type: ignore
+    from ._models import AdmissionWebhookProfile  # type: ignore
+    from ._models import AzureFileCSIProfile  # type: ignore
     from ._models import CloudErrorBody  # type: ignore
+    from ._models import ClusterIdentity  # type: ignore
     from ._models import ClusterProfile  # type: ignore
     from ._models import ConsoleProfile  # type: ignore
+    from ._models import CustomerLoadBalancerProfile  # type: ignore
+    from ._models import DefaultStorageClassProfile  # type: ignore
     from ._models import Display  # type: ignore
     from ._models import EffectiveOutboundIP  # type: ignore
+    from ._models import EffectiveOutboundIPPrefix  # type: ignore
+    from ._models import EffectiveOutboundProfile  # type: ignore
+    from ._models import EgressDestination  # type: ignore
+    from ._models import EgressFirewallProfile  # type: ignore
+    from ._models import EvictionThresholds  # type: ignore
+    from ._models import IdentityProviderProfile  # type: ignore
     from ._models import IngressProfile  # type: ignore
     from ._models import LoadBalancerProfile  # type: ignore
+    from ._models import LogForwardingProfile  # type: ignore
     from ._models import MachinePool  # type: ignore
     from ._models import MachinePoolList  # type: ignore
     from ._models import MachinePoolUpdate  # type: ignore
+    from ._models import MaintenanceWindow  # type: ignore
+    from ._models import ManagedOutboundIPPrefix  # type: ignore
     from ._models import ManagedOutboundIPs  # type: ignore
     from ._models import MasterProfile  # type: ignore
+    from ._models import MonitoringProfile  # type: ignore
     from ._models import NetworkProfile  # type: ignore
+    from ._models import NodeEvictionProfile  # type: ignore
     from ._models import OpenShiftCluster  # type: ignore
     from ._models import OpenShiftClusterAdminKubeconfig  # type: ignore
     from ._models import OpenShiftClusterCredentials  # type: ignore
     from ._models import OpenShiftClusterList  # type: ignore
+    from ._models import OpenShiftClusterTemplate  # type: ignore
     from ._models import OpenShiftClusterUpdate  # type: ignore
+    from ._models import OpenShiftClusterValidationFindings  # type: ignore
     from ._models import OpenShiftVersion  # type: ignore
     from ._models import OpenShiftVersionList  # type: ignore
+    from ._models import OpenShiftVersionMatrix  # type: ignore
+    from ._models import OpenShiftVersionMatrixVersion  # type: ignore
     from ._models import Operation  # type: ignore
     from ._models import OperationList  # type: ignore
     from ._models import OutboundIP  # type: ignore
     from ._models import OutboundIPPrefix  # type: ignore
+    from ._models import ProjectTemplateProfile  # type: ignore
+    from ._models import ProximityPlacementGroupProfile  # type: ignore
     from ._models import ProxyResource  # type: ignore
+    from ._models import RegistryMirrorProfile  # type: ignore
     from ._models import Resource  # type: ignore
+    from ._models import SchedulerProfile  # type: ignore
     from ._models import Secret  # type: ignore
     from ._models import SecretList  # type: ignore
     from ._models import SecretUpdate  # type: ignore
+    from ._models import SecurityProfile  # type: ignore
     from ._models import ServicePrincipalProfile  # type: ignore
     from ._models import SyncIdentityProvider  # type: ignore
     from ._models import SyncIdentityProviderList  # type: ignore
@@ -95,48 +149,91 @@ except (SyntaxError, ImportError):
     from ._models import SyncSetUpdate  # type: ignore
     from ._models import SystemData  # type: ignore
     from ._models import TrackedResource  # type: ignore
+    from ._models import UpgradeProfile  # type: ignore
+    from ._models import ValidationFinding  # type: ignore
+    from ._models import WorkerMachineSetsProgress  # type: ignore
     from ._models import WorkerProfile  # type: ignore
+    from ._models import WorkerProfileScale  # type: ignore
 
 from ._azure_red_hat_open_shift_client_enums import (
+    AcceleratedNetworking,
+    AdminCredentialsFormat,
+    ClusterIdentityComponent,
     CreatedByType,
+    DiskStorageAccountType,
+    EffectiveOutboundMethod,
     EncryptionAtHost,
+    ExistingResourceGroup,
     FipsValidatedModules,
+    LogForwardingType,
+    LogType,
+    OpenShiftVersionStatus,
     OutboundType,
     ProvisioningState,
+    SeccompProfile,
+    SoftwareDefinedNetwork,
+    ValidationSeverity,
+    ValidationStatus,
     Visibility,
+    Weekday,
 )
 
 __all__ = [
     'APIServerProfile',
+    'AdmissionWebhookProfile',
+    'AzureFileCSIProfile',
     'CloudErrorBody',
+    'ClusterIdentity',
     'ClusterProfile',
     'ConsoleProfile',
+    'CustomerLoadBalancerProfile',
+    'DefaultStorageClassProfile',
     'Display',
     'EffectiveOutboundIP',
+    'EffectiveOutboundIPPrefix',
+    'EffectiveOutboundProfile',
+    'EgressDestination',
+    'EgressFirewallProfile',
+    'EvictionThresholds',
+    'IdentityProviderProfile',
     'IngressProfile',
     'LoadBalancerProfile',
+    'LogForwardingProfile',
     'MachinePool',
     'MachinePoolList',
     'MachinePoolUpdate',
+    'MaintenanceWindow',
+    'ManagedOutboundIPPrefix',
     'ManagedOutboundIPs',
     'MasterProfile',
+    'MonitoringProfile',
     'NetworkProfile',
+    'NodeEvictionProfile',
     'OpenShiftCluster',
     'OpenShiftClusterAdminKubeconfig',
     'OpenShiftClusterCredentials',
     'OpenShiftClusterList',
+    'OpenShiftClusterTemplate',
     'OpenShiftClusterUpdate',
+    'OpenShiftClusterValidationFindings',
     'OpenShiftVersion',
     'OpenShiftVersionList',
+    'OpenShiftVersionMatrix',
+    'OpenShiftVersionMatrixVersion',
     'Operation',
     'OperationList',
     'OutboundIP',
     'OutboundIPPrefix',
+    'ProjectTemplateProfile',
+    'ProximityPlacementGroupProfile',
     'ProxyResource',
+    'RegistryMirrorProfile',
     'Resource',
+    'SchedulerProfile',
     'Secret',
     'SecretList',
     'SecretUpdate',
+    'SecurityProfile',
     'ServicePrincipalProfile',
     'SyncIdentityProvider',
     'SyncIdentityProviderList',
@@ -146,11 +243,29 @@ __all__ = [
     'SyncSetUpdate',
     'SystemData',
     'TrackedResource',
+    'UpgradeProfile',
+    'ValidationFinding',
+    'WorkerMachineSetsProgress',
     'WorkerProfile',
+    'WorkerProfileScale',
+    'AcceleratedNetworking',
+    'AdminCredentialsFormat',
+    'ClusterIdentityComponent',
     'CreatedByType',
+    'DiskStorageAccountType',
+    'EffectiveOutboundMethod',
     'EncryptionAtHost',
+    'ExistingResourceGroup',
     'FipsValidatedModules',
+    'LogForwardingType',
+    'LogType',
+    'OpenShiftVersionStatus',
     'OutboundType',
     'ProvisioningState',
+    'SeccompProfile',
+    'SoftwareDefinedNetwork',
+    'ValidationSeverity',
+    'ValidationStatus',
     'Visibility',
+    'Weekday',
 ]
@@ -19,6 +19,29 @@ from six import with_metaclass
 from azure.core import CaseInsensitiveEnumMeta
 
 
+class AcceleratedNetworking(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """AcceleratedNetworking represents accelerated networking state
+    """
+
+    ENABLED = "Enabled"
+    DISABLED = "Disabled"
+
+class AdminCredentialsFormat(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+
+    KUBECONFIG = "Kubeconfig"
+    STRUCTURED = "Structured"
+
+class ClusterIdentityComponent(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """ClusterIdentityComponent represents the cluster component which uses an identity.
+    """
+
+    CLOUD_CONTROLLER_MANAGER = "CloudControllerManager"
+    INGRESS = "Ingress"
+    MACHINE_API = "MachineAPI"
+    IMAGE_REGISTRY = "ImageRegistry"
+    DISK_CSI_DRIVER = "DiskCSIDriver"
+    FILE_CSI_DRIVER = "FileCSIDriver"
+
 class CreatedByType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """The type of identity that created the resource.
     """
@@ -28,6 +51,22 @@ class CreatedByType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     MANAGED_IDENTITY = "ManagedIdentity"
     KEY = "Key"
 
+class DiskStorageAccountType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """DiskStorageAccountType represents the storage account type of a managed disk
+    """
+
+    PREMIUM_LRS = "Premium_LRS"
+    STANDARD_SSD_LRS = "StandardSSD_LRS"
+
+class EffectiveOutboundMethod(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """EffectiveOutboundMethod represents the outbound connectivity method which the worker nodes
+    actually use.
+    """
+
+    LOAD_BALANCER = "LoadBalancer"
+    NAT_GATEWAY = "NATGateway"
+    USER_DEFINED_ROUTING = "UserDefinedRouting"
+
 class EncryptionAtHost(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """EncryptionAtHost represents encryption at host state
     """
@@ -35,6 +74,14 @@ class EncryptionAtHost(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     DISABLED = "Disabled"
     ENABLED = "Enabled"
 
+class ExistingResourceGroup(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """ExistingResourceGroup determines if the cluster resource group is created by the customer
+    rather than the RP.
+    """
+
+    DISABLED = "Disabled"
+    ENABLED = "Enabled"
+
 class FipsValidatedModules(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """FipsValidatedModules determines if FIPS is used.
     """
@@ -42,6 +89,29 @@ class FipsValidatedModules(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     DISABLED = "Disabled"
     ENABLED = "Enabled"
 
+class LogForwardingType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """LogForwardingType represents the type of a log forwarding destination.
+    """
+
+    SYSLOG = "Syslog"
+    AZURE_MONITOR = "AzureMonitor"
+
+class LogType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """LogType represents a type of cluster logs.
+    """
+
+    APPLICATION = "Application"
+    INFRASTRUCTURE = "Infrastructure"
+    AUDIT = "Audit"
+
+class OpenShiftVersionStatus(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """OpenShiftVersionStatus represents the status of an OpenShift version.
+    """
+
+    DEPRECATED = "Deprecated"
+    INSTALLABLE = "Installable"
+    UPGRADE_ONLY = "UpgradeOnly"
+
 class OutboundType(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """The outbound routing strategy used to provide your cluster egress to the internet.
     """
@@ -61,9 +131,49 @@ class ProvisioningState(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     SUCCEEDED = "Succeeded"
     UPDATING = "Updating"
 
+class SeccompProfile(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """SeccompProfile represents the seccomp profile of containers which do not set one.
+    """
+
+    UNCONFINED = "Unconfined"
+    RUNTIME_DEFAULT = "RuntimeDefault"
+
+class SoftwareDefinedNetwork(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """SoftwareDefinedNetwork represents the software defined network (SDN) of a cluster.
+    """
+
+    OVN_KUBERNETES = "OVNKubernetes"
+    OPEN_SHIFT_SDN = "OpenShiftSDN"
+
+class ValidationSeverity(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """ValidationSeverity represents the severity of a validation finding.
+    """
+
+    ERROR = "Error"
+    WARNING = "Warning"
+
+class ValidationStatus(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """ValidationStatus represents the status of a validation.
+    """
+
+    FAILED = "Failed"
+    SUCCEEDED = "Succeeded"
+
 class Visibility(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
     """Visibility represents visibility.
     """
 
     PRIVATE = "Private"
     PUBLIC = "Public"
+
+class Weekday(with_metaclass(CaseInsensitiveEnumMeta, str, Enum)):
+    """Weekday represents a day of the week.
+    """
+
+    FRIDAY = "Friday"
+    MONDAY = "Monday"
+    SATURDAY = "Saturday"
+    SUNDAY = "Sunday"
+    THURSDAY = "Thursday"
+    TUESDAY = "Tuesday"
+    WEDNESDAY = "Wednesday"
@@ -61,6 +61,67 @@ class APIServerProfile(msrest.serialization.Model):
         self.ip = ip
 
 
+class AdmissionWebhookProfile(msrest.serialization.Model):
+    """AdmissionWebhookProfile represents a ValidatingWebhookConfiguration or MutatingWebhookConfiguration which is registered on the cluster.
+
+    :ivar configuration: The ValidatingWebhookConfiguration or MutatingWebhookConfiguration of API
+     version admissionregistration.k8s.io/v1, in JSON or YAML.
+    :vartype configuration: str
+    """
+
+    _attribute_map = {
+        'configuration': {'key': 'configuration', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        configuration: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword configuration: The ValidatingWebhookConfiguration or MutatingWebhookConfiguration of
+         API version admissionregistration.k8s.io/v1, in JSON or YAML.
+        :paramtype configuration: str
+        """
+        super(AdmissionWebhookProfile, self).__init__(**kwargs)
+        self.configuration = configuration
+
+
+class AzureFileCSIProfile(msrest.serialization.Model):
+    """AzureFileCSIProfile represents a storage class which provisions Azure Files shares with the Azure File CSI driver, for volumes with the ReadWriteMany access mode.
+
+    :ivar storage_class_name: The name of the storage class, e.g. azurefile-rwx.
+    :vartype storage_class_name: str
+    :ivar sku_name: The SKU of the storage accounts of the file shares, e.g. Standard_ZRS or
+     Premium_LRS.  It must be available in the cluster location.  Defaults to Standard_LRS.
+    :vartype sku_name: str
+    """
+
+    _attribute_map = {
+        'storage_class_name': {'key': 'storageClassName', 'type': 'str'},
+        'sku_name': {'key': 'skuName', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        storage_class_name: Optional[str] = None,
+        sku_name: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword storage_class_name: The name of the storage class, e.g. azurefile-rwx.
+        :paramtype storage_class_name: str
+        :keyword sku_name: The SKU of the storage accounts of the file shares, e.g. Standard_ZRS or
+         Premium_LRS.  It must be available in the cluster location.  Defaults to Standard_LRS.
+        :paramtype sku_name: str
+        """
+        super(AzureFileCSIProfile, self).__init__(**kwargs)
+        self.storage_class_name = storage_class_name
+        self.sku_name = sku_name
+
+
 class CloudErrorBody(msrest.serialization.Model):
     """CloudErrorBody represents the body of a cloud error.
 
@@ -113,6 +174,51 @@ class CloudErrorBody(msrest.serialization.Model):
         self.details = details
 
 
+class ClusterIdentity(msrest.serialization.Model):
+    """ClusterIdentity represents an Azure identity used by a cluster component.
+
+    :ivar component: The cluster component which uses the identity. Possible values include:
+     "CloudControllerManager", "DiskCSIDriver", "FileCSIDriver", "ImageRegistry", "Ingress",
+     "MachineAPI".
+    :vartype component: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterIdentityComponent
+    :ivar client_id: The client ID of the identity.
+    :vartype client_id: str
+    :ivar object_id: The object ID of the identity.
+    :vartype object_id: str
+    """
+
+    _attribute_map = {
+        'component': {'key': 'component', 'type': 'str'},
+        'client_id': {'key': 'clientId', 'type': 'str'},
+        'object_id': {'key': 'objectId', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        component: Optional[Union[str, "ClusterIdentityComponent"]] = None,
+        client_id: Optional[str] = None,
+        object_id: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword component: The cluster component which uses the identity. Possible values include:
+         "CloudControllerManager", "DiskCSIDriver", "FileCSIDriver", "ImageRegistry", "Ingress",
+         "MachineAPI".
+        :paramtype component: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterIdentityComponent
+        :keyword client_id: The client ID of the identity.
+        :paramtype client_id: str
+        :keyword object_id: The object ID of the identity.
+        :paramtype object_id: str
+        """
+        super(ClusterIdentity, self).__init__(**kwargs)
+        self.component = component
+        self.client_id = client_id
+        self.object_id = object_id
+
+
 class ClusterProfile(msrest.serialization.Model):
     """ClusterProfile represents a cluster profile.
 
@@ -128,6 +234,28 @@ class ClusterProfile(msrest.serialization.Model):
      include: "Disabled", "Enabled".
     :vartype fips_validated_modules: str or
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.FipsValidatedModules
+    :ivar dns_record_ttl: The TTL in seconds of the cluster API and ingress DNS records, if the
+     cluster domain is managed by the RP.
+    :vartype dns_record_ttl: int
+    :ivar resource_name_template: A template for the names of the resources which the RP creates
+     in the cluster resource group, such as the load balancers, network interfaces and disks. The
+     placeholder {name} is replaced with the cluster name, and a stable random suffix is always
+     appended to keep the names unique.
+    :vartype resource_name_template: str
+    :ivar existing_resource_group: If the cluster resource group already exists. The resource
+     group must be empty and in the same location as the cluster. When the cluster is deleted, the
+     resources which belong to the cluster are deleted but the resource group itself is not.
+     Possible values include: "Disabled", "Enabled".
+    :vartype existing_resource_group: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ExistingResourceGroup
+    :ivar time_zone: The time zone of the cluster nodes, as a tz database name such as
+     Europe/London. If not specified, the nodes use UTC.
+    :vartype time_zone: str
+    :ivar dns_zone_id: The resource ID of an Azure DNS zone, which may be in another subscription,
+     in which to create the cluster API and ingress DNS records. Only valid with a custom domain,
+     which must be the zone or within it. The cluster service principal must have DNS Zone
+     Contributor on the zone.
+    :vartype dns_zone_id: str
     """
 
     _attribute_map = {
@@ -136,6 +264,11 @@ class ClusterProfile(msrest.serialization.Model):
         'version': {'key': 'version', 'type': 'str'},
         'resource_group_id': {'key': 'resourceGroupId', 'type': 'str'},
         'fips_validated_modules': {'key': 'fipsValidatedModules', 'type': 'str'},
+        'dns_record_ttl': {'key': 'dnsRecordTtl', 'type': 'int'},
+        'resource_name_template': {'key': 'resourceNameTemplate', 'type': 'str'},
+        'existing_resource_group': {'key': 'existingResourceGroup', 'type': 'str'},
+        'time_zone': {'key': 'timeZone', 'type': 'str'},
+        'dns_zone_id': {'key': 'dnsZoneId', 'type': 'str'},
     }
 
     def __init__(
@@ -146,6 +279,11 @@ class ClusterProfile(msrest.serialization.Model):
         version: Optional[str] = None,
         resource_group_id: Optional[str] = None,
         fips_validated_modules: Optional[Union[str, "FipsValidatedModules"]] = None,
+        dns_record_ttl: Optional[int] = None,
+        resource_name_template: Optional[str] = None,
+        existing_resource_group: Optional[Union[str, "ExistingResourceGroup"]] = None,
+        time_zone: Optional[str] = None,
+        dns_zone_id: Optional[str] = None,
         **kwargs
     ):
         """
@@ -161,6 +299,28 @@ class ClusterProfile(msrest.serialization.Model):
          include: "Disabled", "Enabled".
         :paramtype fips_validated_modules: str or
          ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.FipsValidatedModules
+        :keyword dns_record_ttl: The TTL in seconds of the cluster API and ingress DNS records, if
+         the cluster domain is managed by the RP.
+        :paramtype dns_record_ttl: int
+        :keyword resource_name_template: A template for the names of the resources which the RP
+         creates in the cluster resource group, such as the load balancers, network interfaces and
+         disks. The placeholder {name} is replaced with the cluster name, and a stable random suffix
+         is always appended to keep the names unique.
+        :paramtype resource_name_template: str
+        :keyword existing_resource_group: If the cluster resource group already exists. The
+         resource group must be empty and in the same location as the cluster. When the cluster is
+         deleted, the resources which belong to the cluster are deleted but the resource group
+         itself is not. Possible values include: "Disabled", "Enabled".
+        :paramtype existing_resource_group: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ExistingResourceGroup
+        :keyword time_zone: The time zone of the cluster nodes, as a tz database name such as
+         Europe/London. If not specified, the nodes use UTC.
+        :paramtype time_zone: str
+        :keyword dns_zone_id: The resource ID of an Azure DNS zone, which may be in another
+         subscription, in which to create the cluster API and ingress DNS records. Only valid with
+         a custom domain, which must be the zone or within it. The cluster service principal must
+         have DNS Zone Contributor on the zone.
+        :paramtype dns_zone_id: str
         """
         super(ClusterProfile, self).__init__(**kwargs)
         self.pull_secret = pull_secret
@@ -168,6 +328,11 @@ class ClusterProfile(msrest.serialization.Model):
         self.version = version
         self.resource_group_id = resource_group_id
         self.fips_validated_modules = fips_validated_modules
+        self.dns_record_ttl = dns_record_ttl
+        self.resource_name_template = resource_name_template
+        self.existing_resource_group = existing_resource_group
+        self.time_zone = time_zone
+        self.dns_zone_id = dns_zone_id
 
 
 class ConsoleProfile(msrest.serialization.Model):
@@ -195,6 +360,85 @@ class ConsoleProfile(msrest.serialization.Model):
         self.url = url
 
 
+class CustomerLoadBalancerProfile(msrest.serialization.Model):
+    """CustomerLoadBalancerProfile represents an existing Standard load balancer, in the subscription and location of the cluster, on which the RP registers the backends of the API server and the default ingress.  The API server and the default ingress must have Private visibility.
+
+    :ivar id: The resource ID of the load balancer.
+    :vartype id: str
+    :ivar api_server_frontend_ip_configuration: The name of the frontend IP configuration of the
+     load balancer which serves the API server on port 6443.
+    :vartype api_server_frontend_ip_configuration: str
+    :ivar ingress_frontend_ip_configuration: The name of the frontend IP configuration of the load
+     balancer which serves the default ingress on ports 80 and 443.
+    :vartype ingress_frontend_ip_configuration: str
+    """
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'api_server_frontend_ip_configuration': {'key': 'apiServerFrontendIpConfiguration', 'type': 'str'},
+        'ingress_frontend_ip_configuration': {'key': 'ingressFrontendIpConfiguration', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        id: Optional[str] = None,
+        api_server_frontend_ip_configuration: Optional[str] = None,
+        ingress_frontend_ip_configuration: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword id: The resource ID of the load balancer.
+        :paramtype id: str
+        :keyword api_server_frontend_ip_configuration: The name of the frontend IP configuration of
+         the load balancer which serves the API server on port 6443.
+        :paramtype api_server_frontend_ip_configuration: str
+        :keyword ingress_frontend_ip_configuration: The name of the frontend IP configuration of the
+         load balancer which serves the default ingress on ports 80 and 443.
+        :paramtype ingress_frontend_ip_configuration: str
+        """
+        super(CustomerLoadBalancerProfile, self).__init__(**kwargs)
+        self.id = id
+        self.api_server_frontend_ip_configuration = api_server_frontend_ip_configuration
+        self.ingress_frontend_ip_configuration = ingress_frontend_ip_configuration
+
+
+class DefaultStorageClassProfile(msrest.serialization.Model):
+    """DefaultStorageClassProfile represents the default storage class of the cluster.  Any other storage class marked as default is unmarked.
+
+    :ivar name: The name of the storage class.  If no parameters are provided, it must be an
+     existing storage class, e.g. managed-csi or managed-csi-encrypted-cmk.
+    :vartype name: str
+    :ivar parameters: The Azure Disk CSI driver parameters of the storage class, e.g. skuName or
+     cachingMode.  If provided, a storage class with these parameters is created.
+    :vartype parameters: dict[str, str]
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'parameters': {'key': 'parameters', 'type': '{str}'},
+    }
+
+    def __init__(
+        self,
+        *,
+        name: Optional[str] = None,
+        parameters: Optional[Dict[str, str]] = None,
+        **kwargs
+    ):
+        """
+        :keyword name: The name of the storage class.  If no parameters are provided, it must be an
+         existing storage class, e.g. managed-csi or managed-csi-encrypted-cmk.
+        :paramtype name: str
+        :keyword parameters: The Azure Disk CSI driver parameters of the storage class, e.g. skuName
+         or cachingMode.  If provided, a storage class with these parameters is created.
+        :paramtype parameters: dict[str, str]
+        """
+        super(DefaultStorageClassProfile, self).__init__(**kwargs)
+        self.name = name
+        self.parameters = parameters
+
+
 class Display(msrest.serialization.Model):
     """Display represents the display details of an operation.
 
@@ -266,6 +510,253 @@ class EffectiveOutboundIP(msrest.serialization.Model):
         self.id = id
 
 
+class EffectiveOutboundIPPrefix(msrest.serialization.Model):
+    """EffectiveOutboundIPPrefix represents the effective outbound IP prefix of the cluster public load balancer.
+
+    :ivar id: The resource ID of the public IP prefix.
+    :vartype id: str
+    :ivar ip_prefix: The CIDR of the public IP prefix.
+    :vartype ip_prefix: str
+    """
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+        'ip_prefix': {'key': 'ipPrefix', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        id: Optional[str] = None,
+        ip_prefix: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword id: The resource ID of the public IP prefix.
+        :paramtype id: str
+        :keyword ip_prefix: The CIDR of the public IP prefix.
+        :paramtype ip_prefix: str
+        """
+        super(EffectiveOutboundIPPrefix, self).__init__(**kwargs)
+        self.id = id
+        self.ip_prefix = ip_prefix
+
+
+class EffectiveOutboundProfile(msrest.serialization.Model):
+    """EffectiveOutboundProfile represents the outbound connectivity method which the worker nodes actually use.  A default route of the route table of the worker subnet to anything but the internet takes precedence over a NAT gateway on the subnet, which takes precedence over the outbound rules of the public load balancer.
+
+    :ivar method: The outbound connectivity method. Possible values include: "LoadBalancer",
+     "NATGateway", "UserDefinedRouting".
+    :vartype method: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EffectiveOutboundMethod
+    :ivar outbound_ip_addresses: The public IP addresses, and the public IP prefixes in CIDR
+     notation, from which the egress originates.  Not set if the egress is user defined.
+    :vartype outbound_ip_addresses: list[str]
+    :ivar nat_gateway_id: The resource ID of the NAT gateway of the worker subnet, if any.
+    :vartype nat_gateway_id: str
+    :ivar route_table_id: The resource ID of the route table of the worker subnet, if any.
+    :vartype route_table_id: str
+    :ivar next_hop_ip_address: The next hop IP address of the default route of the route table, if
+     the egress is user defined through a virtual appliance.
+    :vartype next_hop_ip_address: str
+    """
+
+    _attribute_map = {
+        'method': {'key': 'method', 'type': 'str'},
+        'outbound_ip_addresses': {'key': 'outboundIpAddresses', 'type': '[str]'},
+        'nat_gateway_id': {'key': 'natGatewayId', 'type': 'str'},
+        'route_table_id': {'key': 'routeTableId', 'type': 'str'},
+        'next_hop_ip_address': {'key': 'nextHopIpAddress', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        method: Optional[Union[str, "EffectiveOutboundMethod"]] = None,
+        outbound_ip_addresses: Optional[List[str]] = None,
+        nat_gateway_id: Optional[str] = None,
+        route_table_id: Optional[str] = None,
+        next_hop_ip_address: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword method: The outbound connectivity method. Possible values include: "LoadBalancer",
+         "NATGateway", "UserDefinedRouting".
+        :paramtype method: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EffectiveOutboundMethod
+        :keyword outbound_ip_addresses: The public IP addresses, and the public IP prefixes in CIDR
+         notation, from which the egress originates.  Not set if the egress is user defined.
+        :paramtype outbound_ip_addresses: list[str]
+        :keyword nat_gateway_id: The resource ID of the NAT gateway of the worker subnet, if any.
+        :paramtype nat_gateway_id: str
+        :keyword route_table_id: The resource ID of the route table of the worker subnet, if any.
+        :paramtype route_table_id: str
+        :keyword next_hop_ip_address: The next hop IP address of the default route of the route table,
+         if the egress is user defined through a virtual appliance.
+        :paramtype next_hop_ip_address: str
+        """
+        super(EffectiveOutboundProfile, self).__init__(**kwargs)
+        self.method = method
+        self.outbound_ip_addresses = outbound_ip_addresses
+        self.nat_gateway_id = nat_gateway_id
+        self.route_table_id = route_table_id
+        self.next_hop_ip_address = next_hop_ip_address
+
+
+class EgressDestination(msrest.serialization.Model):
+    """EgressDestination represents a destination outside the cluster.  Exactly one of cidr and dnsName must be set.
+
+    :ivar cidr: The IP range of the destination, e.g. 203.0.113.0/24.
+    :vartype cidr: str
+    :ivar dns_name: The DNS name of the destination, e.g. www.example.com.
+    :vartype dns_name: str
+    """
+
+    _attribute_map = {
+        'cidr': {'key': 'cidr', 'type': 'str'},
+        'dns_name': {'key': 'dnsName', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        cidr: Optional[str] = None,
+        dns_name: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword cidr: The IP range of the destination, e.g. 203.0.113.0/24.
+        :paramtype cidr: str
+        :keyword dns_name: The DNS name of the destination, e.g. www.example.com.
+        :paramtype dns_name: str
+        """
+        super(EgressDestination, self).__init__(**kwargs)
+        self.cidr = cidr
+        self.dns_name = dns_name
+
+
+class EgressFirewallProfile(msrest.serialization.Model):
+    """EgressFirewallProfile represents the destinations outside the cluster to which the pods of the customer namespaces may connect.  Egress to any other destination is denied.
+
+    :ivar allowed_destinations: The allowed destinations.  The API servers of the cluster should be
+     allowed, as pods which use the Kubernetes API cannot reach them otherwise.
+    :vartype allowed_destinations:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressDestination]
+    """
+
+    _attribute_map = {
+        'allowed_destinations': {'key': 'allowedDestinations', 'type': '[EgressDestination]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        allowed_destinations: Optional[List["EgressDestination"]] = None,
+        **kwargs
+    ):
+        """
+        :keyword allowed_destinations: The allowed destinations.  The API servers of the cluster
+         should be allowed, as pods which use the Kubernetes API cannot reach them otherwise.
+        :paramtype allowed_destinations:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressDestination]
+        """
+        super(EgressFirewallProfile, self).__init__(**kwargs)
+        self.allowed_destinations = allowed_destinations
+
+
+class EvictionThresholds(msrest.serialization.Model):
+    """EvictionThresholds represents the eviction thresholds of the kubelet eviction signals.  Each threshold is a quantity, e.g. 500Mi, or a percentage of the capacity, e.g. 10%.
+
+    :ivar memory_available: The threshold of the memory.available signal.
+    :vartype memory_available: str
+    :ivar nodefs_available: The threshold of the nodefs.available signal.
+    :vartype nodefs_available: str
+    :ivar imagefs_available: The threshold of the imagefs.available signal.
+    :vartype imagefs_available: str
+    """
+
+    _attribute_map = {
+        'memory_available': {'key': 'memoryAvailable', 'type': 'str'},
+        'nodefs_available': {'key': 'nodefsAvailable', 'type': 'str'},
+        'imagefs_available': {'key': 'imagefsAvailable', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        memory_available: Optional[str] = None,
+        nodefs_available: Optional[str] = None,
+        imagefs_available: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword memory_available: The threshold of the memory.available signal.
+        :paramtype memory_available: str
+        :keyword nodefs_available: The threshold of the nodefs.available signal.
+        :paramtype nodefs_available: str
+        :keyword imagefs_available: The threshold of the imagefs.available signal.
+        :paramtype imagefs_available: str
+        """
+        super(EvictionThresholds, self).__init__(**kwargs)
+        self.memory_available = memory_available
+        self.nodefs_available = nodefs_available
+        self.imagefs_available = imagefs_available
+
+
+class IdentityProviderProfile(msrest.serialization.Model):
+    """IdentityProviderProfile represents an OpenID Connect identity provider, e.g. Azure AD, which is configured in the cluster OAuth server at install time.
+
+    :ivar name: The name of the identity provider, which is shown on the login page and prefixes the
+     names of its identities.
+    :vartype name: str
+    :ivar issuer: The issuer URL of the identity provider, e.g.
+     https://login.microsoftonline.com/<tenant id>/v2.0.  It must use https and serve
+     /.well-known/openid-configuration.
+    :vartype issuer: str
+    :ivar client_id: The client ID of the application registered with the identity provider.
+    :vartype client_id: str
+    :ivar client_secret: The client secret of the application registered with the identity
+     provider. It is not returned in responses.
+    :vartype client_secret: str
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'issuer': {'key': 'issuer', 'type': 'str'},
+        'client_id': {'key': 'clientId', 'type': 'str'},
+        'client_secret': {'key': 'clientSecret', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        name: Optional[str] = None,
+        issuer: Optional[str] = None,
+        client_id: Optional[str] = None,
+        client_secret: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword name: The name of the identity provider, which is shown on the login page and
+         prefixes the names of its identities.
+        :paramtype name: str
+        :keyword issuer: The issuer URL of the identity provider, e.g.
+         https://login.microsoftonline.com/<tenant id>/v2.0.  It must use https and serve
+         /.well-known/openid-configuration.
+        :paramtype issuer: str
+        :keyword client_id: The client ID of the application registered with the identity provider.
+        :paramtype client_id: str
+        :keyword client_secret: The client secret of the application registered with the identity
+         provider. It is not returned in responses.
+        :paramtype client_secret: str
+        """
+        super(IdentityProviderProfile, self).__init__(**kwargs)
+        self.name = name
+        self.issuer = issuer
+        self.client_id = client_id
+        self.client_secret = client_secret
+
+
 class IngressProfile(msrest.serialization.Model):
     """IngressProfile represents an ingress profile.
 
@@ -275,12 +766,18 @@ class IngressProfile(msrest.serialization.Model):
     :vartype visibility: str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.Visibility
     :ivar ip: The IP of the ingress.
     :vartype ip: str
+    :ivar domain: The domain of additional ingress profiles. It is not set on the default ingress profile.
+    :vartype domain: str
+    :ivar serving_certificate: The PEM encoded private key and certificate chain served for *.<domain> by additional ingress profiles. It is not returned in responses.
+    :vartype serving_certificate: str
     """
 
     _attribute_map = {
         'name': {'key': 'name', 'type': 'str'},
         'visibility': {'key': 'visibility', 'type': 'str'},
         'ip': {'key': 'ip', 'type': 'str'},
+        'domain': {'key': 'domain', 'type': 'str'},
+        'serving_certificate': {'key': 'servingCertificate', 'type': 'str'},
     }
 
     def __init__(
@@ -289,6 +786,8 @@ class IngressProfile(msrest.serialization.Model):
         name: Optional[str] = None,
         visibility: Optional[Union[str, "Visibility"]] = None,
         ip: Optional[str] = None,
+        domain: Optional[str] = None,
+        serving_certificate: Optional[str] = None,
         **kwargs
     ):
         """
@@ -298,11 +797,17 @@ class IngressProfile(msrest.serialization.Model):
         :paramtype visibility: str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.Visibility
         :keyword ip: The IP of the ingress.
         :paramtype ip: str
+        :keyword domain: The domain of additional ingress profiles. It is not set on the default ingress profile.
+        :paramtype domain: str
+        :keyword serving_certificate: The PEM encoded private key and certificate chain served for *.<domain> by additional ingress profiles. It is not returned in responses.
+        :paramtype serving_certificate: str
         """
         super(IngressProfile, self).__init__(**kwargs)
         self.name = name
         self.visibility = visibility
         self.ip = ip
+        self.domain = domain
+        self.serving_certificate = serving_certificate
 
 
 class LoadBalancerProfile(msrest.serialization.Model):
@@ -318,6 +823,15 @@ class LoadBalancerProfile(msrest.serialization.Model):
      balancer.
     :vartype effective_outbound_ips:
      list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EffectiveOutboundIP]
+    :ivar managed_outbound_ip_prefix: The desired managed outbound IP prefix for the cluster public
+     load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of
+     individual managed outbound IPs.
+    :vartype managed_outbound_ip_prefix:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ManagedOutboundIPPrefix
+    :ivar effective_outbound_ip_prefix: The effective outbound IP prefix of the public load
+     balancer.
+    :vartype effective_outbound_ip_prefix:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EffectiveOutboundIPPrefix
     :ivar outbound_ips: The desired outbound IP resources for the cluster load balancer.
     :vartype outbound_ips: list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OutboundIP]
     :ivar outbound_ip_prefixes: The desired outbound IP Prefix resources for the cluster load
@@ -331,11 +845,14 @@ class LoadBalancerProfile(msrest.serialization.Model):
 
     _validation = {
         'effective_outbound_ips': {'readonly': True},
+        'effective_outbound_ip_prefix': {'readonly': True},
     }
 
     _attribute_map = {
         'managed_outbound_ips': {'key': 'managedOutboundIps', 'type': 'ManagedOutboundIPs'},
         'effective_outbound_ips': {'key': 'effectiveOutboundIps', 'type': '[EffectiveOutboundIP]'},
+        'managed_outbound_ip_prefix': {'key': 'managedOutboundIpPrefix', 'type': 'ManagedOutboundIPPrefix'},
+        'effective_outbound_ip_prefix': {'key': 'effectiveOutboundIpPrefix', 'type': 'EffectiveOutboundIPPrefix'},
         'outbound_ips': {'key': 'outboundIps', 'type': '[OutboundIP]'},
         'outbound_ip_prefixes': {'key': 'outboundIpPrefixes', 'type': '[OutboundIPPrefix]'},
         'allocated_outbound_ports': {'key': 'allocatedOutboundPorts', 'type': 'int'},
@@ -345,6 +862,7 @@ class LoadBalancerProfile(msrest.serialization.Model):
         self,
         *,
         managed_outbound_ips: Optional["ManagedOutboundIPs"] = None,
+        managed_outbound_ip_prefix: Optional["ManagedOutboundIPPrefix"] = None,
         outbound_ips: Optional[List["OutboundIP"]] = None,
         outbound_ip_prefixes: Optional[List["OutboundIPPrefix"]] = None,
         allocated_outbound_ports: Optional[int] = None,
@@ -355,6 +873,11 @@ class LoadBalancerProfile(msrest.serialization.Model):
          balancer.
         :paramtype managed_outbound_ips:
          ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ManagedOutboundIPs
+        :keyword managed_outbound_ip_prefix: The desired managed outbound IP prefix for the cluster
+         public load balancer. If set, the outbound rule uses all of the IPs in the prefix instead of
+         individual managed outbound IPs.
+        :paramtype managed_outbound_ip_prefix:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ManagedOutboundIPPrefix
         :keyword outbound_ips: The desired outbound IP resources for the cluster load balancer.
         :paramtype outbound_ips:
          list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OutboundIP]
@@ -369,6 +892,8 @@ class LoadBalancerProfile(msrest.serialization.Model):
         super(LoadBalancerProfile, self).__init__(**kwargs)
         self.managed_outbound_ips = managed_outbound_ips
         self.effective_outbound_ips = None
+        self.managed_outbound_ip_prefix = managed_outbound_ip_prefix
+        self.effective_outbound_ip_prefix = None
         self.outbound_ips = outbound_ips
         self.outbound_ip_prefixes = outbound_ip_prefixes
         self.allocated_outbound_ports = allocated_outbound_ports
@@ -419,6 +944,33 @@ class Resource(msrest.serialization.Model):
         self.system_data = None
 
 
+class ProximityPlacementGroupProfile(msrest.serialization.Model):
+    """ProximityPlacementGroupProfile represents the proximity placement group of the VMs of a worker profile.
+
+    :ivar id: The resource ID of an existing proximity placement group.  If unset, a proximity
+     placement group is created in the cluster resource group.
+    :vartype id: str
+    """
+
+    _attribute_map = {
+        'id': {'key': 'id', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        id: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword id: The resource ID of an existing proximity placement group.  If unset, a proximity
+         placement group is created in the cluster resource group.
+        :paramtype id: str
+        """
+        super(ProximityPlacementGroupProfile, self).__init__(**kwargs)
+        self.id = id
+
+
 class ProxyResource(Resource):
     """The resource model definition for a Azure Resource Manager proxy resource. It will not have tags and a location.
 
@@ -460,6 +1012,69 @@ class ProxyResource(Resource):
         super(ProxyResource, self).__init__(**kwargs)
 
 
+class LogForwardingProfile(msrest.serialization.Model):
+    """LogForwardingProfile represents the off-cluster destination to which the cluster logs are forwarded by the OpenShift Logging operator, which must be installed on the cluster.
+
+    :ivar type: The type of the destination. Possible values include: "Syslog", "AzureMonitor".
+    :vartype type: str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingType
+    :ivar url: The URL of the syslog server, e.g. tls://syslog.example.com:6514.  The scheme is
+     tcp, tls or udp.  Only used by the Syslog type.
+    :vartype url: str
+    :ivar workspace_id: The ID of the Log Analytics workspace to which the logs are sent.  Only
+     used by the AzureMonitor type.
+    :vartype workspace_id: str
+    :ivar shared_key: The primary or secondary key of the Log Analytics workspace.  Only used by
+     the AzureMonitor type.  It is not returned in responses.
+    :vartype shared_key: str
+    :ivar log_types: The types of logs which are forwarded.  If omitted, all of them are forwarded.
+    :vartype log_types: list[str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogType]
+    """
+
+    _attribute_map = {
+        'type': {'key': 'type', 'type': 'str'},
+        'url': {'key': 'url', 'type': 'str'},
+        'workspace_id': {'key': 'workspaceId', 'type': 'str'},
+        'shared_key': {'key': 'sharedKey', 'type': 'str'},
+        'log_types': {'key': 'logTypes', 'type': '[str]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        type: Optional[Union[str, "LogForwardingType"]] = None,
+        url: Optional[str] = None,
+        workspace_id: Optional[str] = None,
+        shared_key: Optional[str] = None,
+        log_types: Optional[List[Union[str, "LogType"]]] = None,
+        **kwargs
+    ):
+        """
+        :keyword type: The type of the destination. Possible values include: "Syslog",
+         "AzureMonitor".
+        :paramtype type: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingType
+        :keyword url: The URL of the syslog server, e.g. tls://syslog.example.com:6514.  The scheme
+         is tcp, tls or udp.  Only used by the Syslog type.
+        :paramtype url: str
+        :keyword workspace_id: The ID of the Log Analytics workspace to which the logs are sent.
+         Only used by the AzureMonitor type.
+        :paramtype workspace_id: str
+        :keyword shared_key: The primary or secondary key of the Log Analytics workspace.  Only used
+         by the AzureMonitor type.  It is not returned in responses.
+        :paramtype shared_key: str
+        :keyword log_types: The types of logs which are forwarded.  If omitted, all of them are
+         forwarded.
+        :paramtype log_types: list[str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogType]
+        """
+        super(LogForwardingProfile, self).__init__(**kwargs)
+        self.type = type
+        self.url = url
+        self.workspace_id = workspace_id
+        self.shared_key = shared_key
+        self.log_types = log_types
+
+
 class MachinePool(ProxyResource):
     """MachinePool represents a MachinePool.
 
@@ -576,6 +1191,84 @@ class MachinePoolUpdate(msrest.serialization.Model):
         self.resources = resources
 
 
+class MaintenanceWindow(msrest.serialization.Model):
+    """MaintenanceWindow represents the weekly window in which automated maintenance of the cluster may start.  Maintenance requested outside the window is deferred to the start of the next window, unless it is urgent.
+
+    :ivar days: The days of the week on which the window opens.
+    :vartype days: list[str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.Weekday]
+    :ivar start_time: The time of day at which the window opens, in HH:MM 24 hour format.
+    :vartype start_time: str
+    :ivar duration_hours: The duration of the window in hours.  Allowed values are in the range
+     of 1 - 24.
+    :vartype duration_hours: int
+    :ivar time_zone: The IANA time zone of the start time, e.g. Europe/Berlin.  Defaults to UTC.
+    :vartype time_zone: str
+    """
+
+    _attribute_map = {
+        'days': {'key': 'days', 'type': '[str]'},
+        'start_time': {'key': 'startTime', 'type': 'str'},
+        'duration_hours': {'key': 'durationHours', 'type': 'int'},
+        'time_zone': {'key': 'timeZone', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        days: Optional[List[Union[str, "Weekday"]]] = None,
+        start_time: Optional[str] = None,
+        duration_hours: Optional[int] = None,
+        time_zone: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword days: The days of the week on which the window opens.
+        :paramtype days: list[str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.Weekday]
+        :keyword start_time: The time of day at which the window opens, in HH:MM 24 hour format.
+        :paramtype start_time: str
+        :keyword duration_hours: The duration of the window in hours.  Allowed values are in the
+         range of 1 - 24.
+        :paramtype duration_hours: int
+        :keyword time_zone: The IANA time zone of the start time, e.g. Europe/Berlin.  Defaults to
+         UTC.
+        :paramtype time_zone: str
+        """
+        super(MaintenanceWindow, self).__init__(**kwargs)
+        self.days = days
+        self.start_time = start_time
+        self.duration_hours = duration_hours
+        self.time_zone = time_zone
+
+
+class ManagedOutboundIPPrefix(msrest.serialization.Model):
+    """ManagedOutboundIPPrefix represents the desired managed outbound IP prefix for the cluster public load balancer.
+
+    :ivar prefix_length: PrefixLength represents the length of the IPv4 public IP prefix created
+     and managed by Azure for the cluster public load balancer.  Allowed values are in the range of
+     28 - 31.
+    :vartype prefix_length: int
+    """
+
+    _attribute_map = {
+        'prefix_length': {'key': 'prefixLength', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        *,
+        prefix_length: Optional[int] = None,
+        **kwargs
+    ):
+        """
+        :keyword prefix_length: PrefixLength represents the length of the IPv4 public IP prefix
+         created and managed by Azure for the cluster public load balancer.  Allowed values are in the
+         range of 28 - 31.
+        :paramtype prefix_length: int
+        """
+        super(ManagedOutboundIPPrefix, self).__init__(**kwargs)
+        self.prefix_length = prefix_length
+
+
 class ManagedOutboundIPs(msrest.serialization.Model):
     """ManagedOutboundIPs represents the desired managed outbound IPs for the cluster public load balancer.
 
@@ -657,27 +1350,100 @@ class MasterProfile(msrest.serialization.Model):
         self.disk_encryption_set_id = disk_encryption_set_id
 
 
+class MonitoringProfile(msrest.serialization.Model):
+    """MonitoringProfile represents the retention and persistent storage of the platform Prometheus of the cluster monitoring stack.
+
+    :ivar retention: The time for which metrics are kept, e.g. 30d, between 1d and 90d.  Retention
+     beyond 15d requires persistent storage.  Defaults to 15d.
+    :vartype retention: str
+    :ivar storage_size: The size of the persistent volume of each Prometheus replica, e.g. 100Gi,
+     between 10Gi and 1Ti.  If omitted, Prometheus uses ephemeral storage and its metrics are lost
+     when its pods are rescheduled.
+    :vartype storage_size: str
+    :ivar storage_class_name: The storage class of the persistent volumes, e.g. managed-csi.  It
+     must provision Azure disks.  Defaults to the default storage class of the cluster.
+    :vartype storage_class_name: str
+    """
+
+    _attribute_map = {
+        'retention': {'key': 'retention', 'type': 'str'},
+        'storage_size': {'key': 'storageSize', 'type': 'str'},
+        'storage_class_name': {'key': 'storageClassName', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        retention: Optional[str] = None,
+        storage_size: Optional[str] = None,
+        storage_class_name: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword retention: The time for which metrics are kept, e.g. 30d, between 1d and 90d.
+         Retention beyond 15d requires persistent storage.  Defaults to 15d.
+        :paramtype retention: str
+        :keyword storage_size: The size of the persistent volume of each Prometheus replica, e.g.
+         100Gi, between 10Gi and 1Ti.  If omitted, Prometheus uses ephemeral storage and its metrics
+         are lost when its pods are rescheduled.
+        :paramtype storage_size: str
+        :keyword storage_class_name: The storage class of the persistent volumes, e.g. managed-csi.
+         It must provision Azure disks.  Defaults to the default storage class of the cluster.
+        :paramtype storage_class_name: str
+        """
+        super(MonitoringProfile, self).__init__(**kwargs)
+        self.retention = retention
+        self.storage_size = storage_size
+        self.storage_class_name = storage_class_name
+
+
 class NetworkProfile(msrest.serialization.Model):
     """NetworkProfile represents a network profile.
 
+    Variables are only populated by the server, and will be ignored when sending a request.
+
     :ivar pod_cidr: The CIDR used for OpenShift/Kubernetes Pods.
     :vartype pod_cidr: str
     :ivar service_cidr: The CIDR used for OpenShift/Kubernetes Services.
     :vartype service_cidr: str
+    :ivar software_defined_network: The software defined network (SDN) to use when installing the
+     cluster. If not specified, the default for the OpenShift version is used. Possible values
+     include: "OVNKubernetes", "OpenShiftSDN".
+    :vartype software_defined_network: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SoftwareDefinedNetwork
     :ivar outbound_type: The OutboundType used for egress traffic. Possible values include:
      "Loadbalancer", "UserDefinedRouting".
     :vartype outbound_type: str or
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OutboundType
+    :ivar max_pods: The maximum number of pods per node. If not specified, the kubelet default of
+     250 is used.
+    :vartype max_pods: int
     :ivar load_balancer_profile: The cluster load balancer profile.
     :vartype load_balancer_profile:
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LoadBalancerProfile
+    :ivar customer_load_balancer_profile: An existing load balancer which fronts the API server and
+     the default ingress instead of the managed load balancer frontends.
+    :vartype customer_load_balancer_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.CustomerLoadBalancerProfile
+    :ivar effective_outbound_profile: How the egress of the worker nodes reaches the internet, as
+     found on the live Azure resources.
+    :vartype effective_outbound_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EffectiveOutboundProfile
     """
 
+    _validation = {
+        'effective_outbound_profile': {'readonly': True},
+    }
+
     _attribute_map = {
         'pod_cidr': {'key': 'podCidr', 'type': 'str'},
         'service_cidr': {'key': 'serviceCidr', 'type': 'str'},
+        'software_defined_network': {'key': 'softwareDefinedNetwork', 'type': 'str'},
         'outbound_type': {'key': 'outboundType', 'type': 'str'},
+        'max_pods': {'key': 'maxPods', 'type': 'int'},
         'load_balancer_profile': {'key': 'loadBalancerProfile', 'type': 'LoadBalancerProfile'},
+        'customer_load_balancer_profile': {'key': 'customerLoadBalancerProfile', 'type': 'CustomerLoadBalancerProfile'},
+        'effective_outbound_profile': {'key': 'effectiveOutboundProfile', 'type': 'EffectiveOutboundProfile'},
     }
 
     def __init__(
@@ -685,8 +1451,11 @@ class NetworkProfile(msrest.serialization.Model):
         *,
         pod_cidr: Optional[str] = None,
         service_cidr: Optional[str] = None,
+        software_defined_network: Optional[Union[str, "SoftwareDefinedNetwork"]] = None,
         outbound_type: Optional[Union[str, "OutboundType"]] = None,
+        max_pods: Optional[int] = None,
         load_balancer_profile: Optional["LoadBalancerProfile"] = None,
+        customer_load_balancer_profile: Optional["CustomerLoadBalancerProfile"] = None,
         **kwargs
     ):
         """
@@ -694,19 +1463,35 @@ class NetworkProfile(msrest.serialization.Model):
         :paramtype pod_cidr: str
         :keyword service_cidr: The CIDR used for OpenShift/Kubernetes Services.
         :paramtype service_cidr: str
+        :keyword software_defined_network: The software defined network (SDN) to use when installing
+         the cluster. If not specified, the default for the OpenShift version is used. Possible values
+         include: "OVNKubernetes", "OpenShiftSDN".
+        :paramtype software_defined_network: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SoftwareDefinedNetwork
         :keyword outbound_type: The OutboundType used for egress traffic. Possible values include:
          "Loadbalancer", "UserDefinedRouting".
         :paramtype outbound_type: str or
          ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OutboundType
+        :keyword max_pods: The maximum number of pods per node. If not specified, the kubelet default of
+         250 is used.
+        :paramtype max_pods: int
         :keyword load_balancer_profile: The cluster load balancer profile.
         :paramtype load_balancer_profile:
          ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LoadBalancerProfile
+        :keyword customer_load_balancer_profile: An existing load balancer which fronts the API server
+         and the default ingress instead of the managed load balancer frontends.
+        :paramtype customer_load_balancer_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.CustomerLoadBalancerProfile
         """
         super(NetworkProfile, self).__init__(**kwargs)
         self.pod_cidr = pod_cidr
         self.service_cidr = service_cidr
+        self.software_defined_network = software_defined_network
         self.outbound_type = outbound_type
+        self.max_pods = max_pods
         self.load_balancer_profile = load_balancer_profile
+        self.customer_load_balancer_profile = customer_load_balancer_profile
+        self.effective_outbound_profile = None
 
 
 class TrackedResource(Resource):
@@ -768,6 +1553,51 @@ class TrackedResource(Resource):
         self.location = location
 
 
+class NodeEvictionProfile(msrest.serialization.Model):
+    """NodeEvictionProfile represents the kubelet eviction thresholds of the nodes of the cluster.  Changing them causes a rolling reboot of the nodes.
+
+    :ivar hard: The hard eviction thresholds, at which pods are evicted immediately.  A threshold
+     which is omitted keeps its platform value.
+    :vartype hard: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EvictionThresholds
+    :ivar soft: The soft eviction thresholds, at which pods are evicted gracefully once the
+     threshold has been met for the soft grace period.
+    :vartype soft: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EvictionThresholds
+    :ivar soft_grace_period: How long a soft eviction threshold must be met before pods are
+     evicted, e.g. 1m30s.  Required if soft thresholds are set.
+    :vartype soft_grace_period: str
+    """
+
+    _attribute_map = {
+        'hard': {'key': 'hard', 'type': 'EvictionThresholds'},
+        'soft': {'key': 'soft', 'type': 'EvictionThresholds'},
+        'soft_grace_period': {'key': 'softGracePeriod', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        hard: Optional["EvictionThresholds"] = None,
+        soft: Optional["EvictionThresholds"] = None,
+        soft_grace_period: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword hard: The hard eviction thresholds, at which pods are evicted immediately.  A
+         threshold which is omitted keeps its platform value.
+        :paramtype hard: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EvictionThresholds
+        :keyword soft: The soft eviction thresholds, at which pods are evicted gracefully once the
+         threshold has been met for the soft grace period.
+        :paramtype soft: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EvictionThresholds
+        :keyword soft_grace_period: How long a soft eviction threshold must be met before pods are
+         evicted, e.g. 1m30s.  Required if soft thresholds are set.
+        :paramtype soft_grace_period: str
+        """
+        super(NodeEvictionProfile, self).__init__(**kwargs)
+        self.hard = hard
+        self.soft = soft
+        self.soft_grace_period = soft_grace_period
+
+
 class OpenShiftCluster(TrackedResource):
     """OpenShiftCluster represents an Azure Red Hat OpenShift cluster.
 
@@ -801,6 +1631,10 @@ class OpenShiftCluster(TrackedResource):
     :ivar service_principal_profile: The cluster service principal profile.
     :vartype service_principal_profile:
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ServicePrincipalProfile
+    :ivar cluster_identities: The Azure identities used by the cluster components, to which Azure
+     RBAC and policy can be scoped.
+    :vartype cluster_identities:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterIdentity]
     :ivar network_profile: The cluster network profile.
     :vartype network_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NetworkProfile
     :ivar master_profile: The cluster master profile.
@@ -808,12 +1642,75 @@ class OpenShiftCluster(TrackedResource):
     :ivar worker_profiles: The cluster worker profiles.
     :vartype worker_profiles:
      list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfile]
+    :ivar worker_profiles_scale_status: The progress of the scale operations of the cluster worker
+     profiles.
+    :vartype worker_profiles_scale_status:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfileScale]
+    :ivar worker_machine_sets_progress: The progress of the creation of the machine sets of the
+     additional worker profiles during install.
+    :vartype worker_machine_sets_progress:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerMachineSetsProgress
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+    :ivar maintenance_window: The cluster maintenance window.
+    :vartype maintenance_window:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+    :ivar project_template_profile: The default resource quota and limit range of new projects.
+    :vartype project_template_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+    :ivar identity_provider_profile: The OpenID Connect identity provider with which users log in
+     to the cluster.
+    :vartype identity_provider_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+    :ivar registry_mirror_profiles: The pull-through caches through which images of upstream
+     registries are pulled by digest.
+    :vartype registry_mirror_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+    :ivar default_storage_class_profile: The storage class which is the default storage class of
+     the cluster.
+    :vartype default_storage_class_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+    :ivar azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.  If
+     omitted, no Azure Files storage class is created.
+    :vartype azure_file_csi_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+    :ivar monitoring_profile: The retention and storage of the platform Prometheus.  If omitted, the
+     platform defaults are used.
+    :vartype monitoring_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+    :ivar upgrade_profile: How the worker nodes are updated during upgrades.  If omitted, the
+     machine config pool chooses the order and the pace of the updates.
+    :vartype upgrade_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+    :ivar node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted, the
+     platform thresholds are used.
+    :vartype node_eviction_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+    :ivar security_profile: The default seccomp profile and the SELinux booleans of the nodes.
+     Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+    :vartype security_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+    :ivar log_forwarding_profile: The off-cluster destination to which the cluster logs are
+     forwarded.  If omitted, logs are not forwarded.
+    :vartype log_forwarding_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+    :ivar admission_webhook_profiles: The admission webhook configurations which are registered on
+     the cluster.  If omitted, none are registered.
+    :vartype admission_webhook_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+    :ivar scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+     workloads are not constrained to any nodes by default.
+    :vartype scheduler_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+    :ivar egress_firewall_profile: The destinations outside the cluster to which the pods of the
+     customer namespaces may connect.  If omitted, egress is not restricted.
+    :vartype egress_firewall_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+    :ivar template: A template exported from a cluster, whose properties pre-fill those which the
+     request does not set.  Only used when the cluster is created; it is not returned in responses.
+    :vartype template: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterTemplate
     """
 
     _validation = {
@@ -822,6 +1719,9 @@ class OpenShiftCluster(TrackedResource):
         'type': {'readonly': True},
         'system_data': {'readonly': True},
         'location': {'required': True},
+        'cluster_identities': {'readonly': True},
+        'worker_profiles_scale_status': {'readonly': True},
+        'worker_machine_sets_progress': {'readonly': True},
     }
 
     _attribute_map = {
@@ -835,11 +1735,29 @@ class OpenShiftCluster(TrackedResource):
         'cluster_profile': {'key': 'properties.clusterProfile', 'type': 'ClusterProfile'},
         'console_profile': {'key': 'properties.consoleProfile', 'type': 'ConsoleProfile'},
         'service_principal_profile': {'key': 'properties.servicePrincipalProfile', 'type': 'ServicePrincipalProfile'},
+        'cluster_identities': {'key': 'properties.clusterIdentities', 'type': '[ClusterIdentity]'},
         'network_profile': {'key': 'properties.networkProfile', 'type': 'NetworkProfile'},
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
+        'worker_profiles_scale_status': {'key': 'properties.workerProfilesScaleStatus', 'type': '[WorkerProfileScale]'},
+        'worker_machine_sets_progress': {'key': 'properties.workerMachineSetsProgress', 'type': 'WorkerMachineSetsProgress'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'maintenance_window': {'key': 'properties.maintenanceWindow', 'type': 'MaintenanceWindow'},
+        'project_template_profile': {'key': 'properties.projectTemplateProfile', 'type': 'ProjectTemplateProfile'},
+        'identity_provider_profile': {'key': 'properties.identityProviderProfile', 'type': 'IdentityProviderProfile'},
+        'registry_mirror_profiles': {'key': 'properties.registryMirrorProfiles', 'type': '[RegistryMirrorProfile]'},
+        'default_storage_class_profile': {'key': 'properties.defaultStorageClassProfile', 'type': 'DefaultStorageClassProfile'},
+        'azure_file_csi_profile': {'key': 'properties.azureFileCsiProfile', 'type': 'AzureFileCSIProfile'},
+        'monitoring_profile': {'key': 'properties.monitoringProfile', 'type': 'MonitoringProfile'},
+        'upgrade_profile': {'key': 'properties.upgradeProfile', 'type': 'UpgradeProfile'},
+        'node_eviction_profile': {'key': 'properties.nodeEvictionProfile', 'type': 'NodeEvictionProfile'},
+        'security_profile': {'key': 'properties.securityProfile', 'type': 'SecurityProfile'},
+        'log_forwarding_profile': {'key': 'properties.logForwardingProfile', 'type': 'LogForwardingProfile'},
+        'admission_webhook_profiles': {'key': 'properties.admissionWebhookProfiles', 'type': '[AdmissionWebhookProfile]'},
+        'scheduler_profile': {'key': 'properties.schedulerProfile', 'type': 'SchedulerProfile'},
+        'egress_firewall_profile': {'key': 'properties.egressFirewallProfile', 'type': 'EgressFirewallProfile'},
+        'template': {'key': 'properties.template', 'type': 'OpenShiftClusterTemplate'},
     }
 
     def __init__(
@@ -856,6 +1774,21 @@ class OpenShiftCluster(TrackedResource):
         worker_profiles: Optional[List["WorkerProfile"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        maintenance_window: Optional["MaintenanceWindow"] = None,
+        project_template_profile: Optional["ProjectTemplateProfile"] = None,
+        identity_provider_profile: Optional["IdentityProviderProfile"] = None,
+        registry_mirror_profiles: Optional[List["RegistryMirrorProfile"]] = None,
+        default_storage_class_profile: Optional["DefaultStorageClassProfile"] = None,
+        azure_file_csi_profile: Optional["AzureFileCSIProfile"] = None,
+        monitoring_profile: Optional["MonitoringProfile"] = None,
+        upgrade_profile: Optional["UpgradeProfile"] = None,
+        node_eviction_profile: Optional["NodeEvictionProfile"] = None,
+        security_profile: Optional["SecurityProfile"] = None,
+        log_forwarding_profile: Optional["LogForwardingProfile"] = None,
+        admission_webhook_profiles: Optional[List["AdmissionWebhookProfile"]] = None,
+        scheduler_profile: Optional["SchedulerProfile"] = None,
+        egress_firewall_profile: Optional["EgressFirewallProfile"] = None,
+        template: Optional["OpenShiftClusterTemplate"] = None,
         **kwargs
     ):
         """
@@ -890,17 +1823,94 @@ class OpenShiftCluster(TrackedResource):
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+        :keyword maintenance_window: The cluster maintenance window.
+        :paramtype maintenance_window:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+        :keyword project_template_profile: The default resource quota and limit range of new
+         projects.
+        :paramtype project_template_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+        :keyword identity_provider_profile: The OpenID Connect identity provider with which users
+         log in to the cluster.
+        :paramtype identity_provider_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+        :keyword registry_mirror_profiles: The pull-through caches through which images of upstream
+         registries are pulled by digest.
+        :paramtype registry_mirror_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+        :keyword default_storage_class_profile: The storage class which is the default storage
+         class of the cluster.
+        :paramtype default_storage_class_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+        :keyword azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.
+         If omitted, no Azure Files storage class is created.
+        :paramtype azure_file_csi_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+        :keyword monitoring_profile: The retention and storage of the platform Prometheus.  If
+         omitted, the platform defaults are used.
+        :paramtype monitoring_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+        :keyword upgrade_profile: How the worker nodes are updated during upgrades.  If omitted,
+         the machine config pool chooses the order and the pace of the updates.
+        :paramtype upgrade_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+        :keyword node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted,
+         the platform thresholds are used.
+        :paramtype node_eviction_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+        :keyword security_profile: The default seccomp profile and the SELinux booleans of the
+         nodes.  Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+        :paramtype security_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+        :keyword log_forwarding_profile: The off-cluster destination to which the cluster logs are
+         forwarded.  If omitted, logs are not forwarded.
+        :paramtype log_forwarding_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+        :keyword admission_webhook_profiles: The admission webhook configurations which are
+         registered on the cluster.  If omitted, none are registered.
+        :paramtype admission_webhook_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+        :keyword scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+         workloads are not constrained to any nodes by default.
+        :paramtype scheduler_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+        :keyword egress_firewall_profile: The destinations outside the cluster to which the pods of
+         the customer namespaces may connect.  If omitted, egress is not restricted.
+        :paramtype egress_firewall_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+        :keyword template: A template exported from a cluster, whose properties pre-fill those which
+         the request does not set.  Only used when the cluster is created; it is not returned in
+         responses.
+        :paramtype template: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterTemplate
         """
         super(OpenShiftCluster, self).__init__(tags=tags, location=location, **kwargs)
         self.provisioning_state = provisioning_state
         self.cluster_profile = cluster_profile
         self.console_profile = console_profile
         self.service_principal_profile = service_principal_profile
+        self.cluster_identities = None
         self.network_profile = network_profile
         self.master_profile = master_profile
         self.worker_profiles = worker_profiles
+        self.worker_profiles_scale_status = None
+        self.worker_machine_sets_progress = None
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.maintenance_window = maintenance_window
+        self.project_template_profile = project_template_profile
+        self.identity_provider_profile = identity_provider_profile
+        self.registry_mirror_profiles = registry_mirror_profiles
+        self.default_storage_class_profile = default_storage_class_profile
+        self.azure_file_csi_profile = azure_file_csi_profile
+        self.monitoring_profile = monitoring_profile
+        self.upgrade_profile = upgrade_profile
+        self.node_eviction_profile = node_eviction_profile
+        self.security_profile = security_profile
+        self.log_forwarding_profile = log_forwarding_profile
+        self.admission_webhook_profiles = admission_webhook_profiles
+        self.scheduler_profile = scheduler_profile
+        self.egress_firewall_profile = egress_firewall_profile
+        self.template = template
 
 
 class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
@@ -908,24 +1918,50 @@ class OpenShiftClusterAdminKubeconfig(msrest.serialization.Model):
 
     :ivar kubeconfig: The base64-encoded kubeconfig file.
     :vartype kubeconfig: str
+    :ivar api_server_url: The URL to access the cluster API server.  Only returned in the
+     Structured format.
+    :vartype api_server_url: str
+    :ivar client_certificate: The base64-encoded PEM client certificate.  Only returned in the
+     Structured format.
+    :vartype client_certificate: str
+    :ivar client_key: The base64-encoded PEM client key.  Only returned in the Structured format.
+    :vartype client_key: str
     """
 
     _attribute_map = {
         'kubeconfig': {'key': 'kubeconfig', 'type': 'str'},
+        'api_server_url': {'key': 'apiServerUrl', 'type': 'str'},
+        'client_certificate': {'key': 'clientCertificate', 'type': 'str'},
+        'client_key': {'key': 'clientKey', 'type': 'str'},
     }
 
     def __init__(
         self,
         *,
         kubeconfig: Optional[str] = None,
+        api_server_url: Optional[str] = None,
+        client_certificate: Optional[str] = None,
+        client_key: Optional[str] = None,
         **kwargs
     ):
         """
         :keyword kubeconfig: The base64-encoded kubeconfig file.
         :paramtype kubeconfig: str
+        :keyword api_server_url: The URL to access the cluster API server.  Only returned in the
+         Structured format.
+        :paramtype api_server_url: str
+        :keyword client_certificate: The base64-encoded PEM client certificate.  Only returned in the
+         Structured format.
+        :paramtype client_certificate: str
+        :keyword client_key: The base64-encoded PEM client key.  Only returned in the Structured
+         format.
+        :paramtype client_key: str
         """
         super(OpenShiftClusterAdminKubeconfig, self).__init__(**kwargs)
         self.kubeconfig = kubeconfig
+        self.api_server_url = api_server_url
+        self.client_certificate = client_certificate
+        self.client_key = client_key
 
 
 class OpenShiftClusterCredentials(msrest.serialization.Model):
@@ -970,26 +2006,304 @@ class OpenShiftClusterList(msrest.serialization.Model):
     """
 
     _attribute_map = {
-        'value': {'key': 'value', 'type': '[OpenShiftCluster]'},
-        'next_link': {'key': 'nextLink', 'type': 'str'},
+        'value': {'key': 'value', 'type': '[OpenShiftCluster]'},
+        'next_link': {'key': 'nextLink', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        value: Optional[List["OpenShiftCluster"]] = None,
+        next_link: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword value: The list of OpenShift clusters.
+        :paramtype value: list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftCluster]
+        :keyword next_link: The link used to get the next page of operations.
+        :paramtype next_link: str
+        """
+        super(OpenShiftClusterList, self).__init__(**kwargs)
+        self.value = value
+        self.next_link = next_link
+
+
+class OpenShiftClusterTemplate(msrest.serialization.Model):
+    """OpenShiftClusterTemplate represents the creatable configuration of an OpenShift cluster, without
+    its secrets and the identifiers of the resources it uses.  It can be passed when creating a
+    cluster to pre-fill the properties which the request does not set.
+
+    Variables are only populated by the server, and will be ignored when sending a request.
+
+    :ivar system_data: The system meta data relating to this resource.
+    :vartype system_data: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SystemData
+    :ivar provisioning_state: The cluster provisioning state. Possible values include:
+     "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+    :vartype provisioning_state: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProvisioningState
+    :ivar cluster_profile: The cluster profile.
+    :vartype cluster_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterProfile
+    :ivar console_profile: The console profile.
+    :vartype console_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ConsoleProfile
+    :ivar service_principal_profile: The cluster service principal profile.
+    :vartype service_principal_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ServicePrincipalProfile
+    :ivar cluster_identities: The Azure identities used by the cluster components, to which Azure
+     RBAC and policy can be scoped.
+    :vartype cluster_identities:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterIdentity]
+    :ivar network_profile: The cluster network profile.
+    :vartype network_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NetworkProfile
+    :ivar master_profile: The cluster master profile.
+    :vartype master_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MasterProfile
+    :ivar worker_profiles: The cluster worker profiles.
+    :vartype worker_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfile]
+    :ivar worker_profiles_scale_status: The progress of the scale operations of the cluster worker
+     profiles.
+    :vartype worker_profiles_scale_status:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfileScale]
+    :ivar worker_machine_sets_progress: The progress of the creation of the machine sets of the
+     additional worker profiles during install.
+    :vartype worker_machine_sets_progress:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerMachineSetsProgress
+    :ivar apiserver_profile: The cluster API server profile.
+    :vartype apiserver_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.APIServerProfile
+    :ivar ingress_profiles: The cluster ingress profiles.
+    :vartype ingress_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+    :ivar maintenance_window: The cluster maintenance window.
+    :vartype maintenance_window:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+    :ivar project_template_profile: The default resource quota and limit range of new projects.
+    :vartype project_template_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+    :ivar identity_provider_profile: The OpenID Connect identity provider with which users log in
+     to the cluster.
+    :vartype identity_provider_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+    :ivar registry_mirror_profiles: The pull-through caches through which images of upstream
+     registries are pulled by digest.
+    :vartype registry_mirror_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+    :ivar default_storage_class_profile: The storage class which is the default storage class of
+     the cluster.
+    :vartype default_storage_class_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+    :ivar azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.  If
+     omitted, no Azure Files storage class is created.
+    :vartype azure_file_csi_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+    :ivar monitoring_profile: The retention and storage of the platform Prometheus.  If omitted, the
+     platform defaults are used.
+    :vartype monitoring_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+    :ivar upgrade_profile: How the worker nodes are updated during upgrades.  If omitted, the
+     machine config pool chooses the order and the pace of the updates.
+    :vartype upgrade_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+    :ivar node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted, the
+     platform thresholds are used.
+    :vartype node_eviction_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+    :ivar security_profile: The default seccomp profile and the SELinux booleans of the nodes.
+     Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+    :vartype security_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+    :ivar log_forwarding_profile: The off-cluster destination to which the cluster logs are
+     forwarded.  If omitted, logs are not forwarded.
+    :vartype log_forwarding_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+    :ivar admission_webhook_profiles: The admission webhook configurations which are registered on
+     the cluster.  If omitted, none are registered.
+    :vartype admission_webhook_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+    :ivar scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+     workloads are not constrained to any nodes by default.
+    :vartype scheduler_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+    :ivar egress_firewall_profile: The destinations outside the cluster to which the pods of the
+     customer namespaces may connect.  If omitted, egress is not restricted.
+    :vartype egress_firewall_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+    """
+
+    _validation = {
+        'system_data': {'readonly': True},
+        'cluster_identities': {'readonly': True},
+        'worker_profiles_scale_status': {'readonly': True},
+        'worker_machine_sets_progress': {'readonly': True},
+    }
+
+    _attribute_map = {
+        'system_data': {'key': 'systemData', 'type': 'SystemData'},
+        'provisioning_state': {'key': 'properties.provisioningState', 'type': 'str'},
+        'cluster_profile': {'key': 'properties.clusterProfile', 'type': 'ClusterProfile'},
+        'console_profile': {'key': 'properties.consoleProfile', 'type': 'ConsoleProfile'},
+        'service_principal_profile': {'key': 'properties.servicePrincipalProfile', 'type': 'ServicePrincipalProfile'},
+        'cluster_identities': {'key': 'properties.clusterIdentities', 'type': '[ClusterIdentity]'},
+        'network_profile': {'key': 'properties.networkProfile', 'type': 'NetworkProfile'},
+        'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
+        'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
+        'worker_profiles_scale_status': {'key': 'properties.workerProfilesScaleStatus', 'type': '[WorkerProfileScale]'},
+        'worker_machine_sets_progress': {'key': 'properties.workerMachineSetsProgress', 'type': 'WorkerMachineSetsProgress'},
+        'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
+        'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'maintenance_window': {'key': 'properties.maintenanceWindow', 'type': 'MaintenanceWindow'},
+        'project_template_profile': {'key': 'properties.projectTemplateProfile', 'type': 'ProjectTemplateProfile'},
+        'identity_provider_profile': {'key': 'properties.identityProviderProfile', 'type': 'IdentityProviderProfile'},
+        'registry_mirror_profiles': {'key': 'properties.registryMirrorProfiles', 'type': '[RegistryMirrorProfile]'},
+        'default_storage_class_profile': {'key': 'properties.defaultStorageClassProfile', 'type': 'DefaultStorageClassProfile'},
+        'azure_file_csi_profile': {'key': 'properties.azureFileCsiProfile', 'type': 'AzureFileCSIProfile'},
+        'monitoring_profile': {'key': 'properties.monitoringProfile', 'type': 'MonitoringProfile'},
+        'upgrade_profile': {'key': 'properties.upgradeProfile', 'type': 'UpgradeProfile'},
+        'node_eviction_profile': {'key': 'properties.nodeEvictionProfile', 'type': 'NodeEvictionProfile'},
+        'security_profile': {'key': 'properties.securityProfile', 'type': 'SecurityProfile'},
+        'log_forwarding_profile': {'key': 'properties.logForwardingProfile', 'type': 'LogForwardingProfile'},
+        'admission_webhook_profiles': {'key': 'properties.admissionWebhookProfiles', 'type': '[AdmissionWebhookProfile]'},
+        'scheduler_profile': {'key': 'properties.schedulerProfile', 'type': 'SchedulerProfile'},
+        'egress_firewall_profile': {'key': 'properties.egressFirewallProfile', 'type': 'EgressFirewallProfile'},
     }
 
     def __init__(
         self,
         *,
-        value: Optional[List["OpenShiftCluster"]] = None,
-        next_link: Optional[str] = None,
+        provisioning_state: Optional[Union[str, "ProvisioningState"]] = None,
+        cluster_profile: Optional["ClusterProfile"] = None,
+        console_profile: Optional["ConsoleProfile"] = None,
+        service_principal_profile: Optional["ServicePrincipalProfile"] = None,
+        network_profile: Optional["NetworkProfile"] = None,
+        master_profile: Optional["MasterProfile"] = None,
+        worker_profiles: Optional[List["WorkerProfile"]] = None,
+        apiserver_profile: Optional["APIServerProfile"] = None,
+        ingress_profiles: Optional[List["IngressProfile"]] = None,
+        maintenance_window: Optional["MaintenanceWindow"] = None,
+        project_template_profile: Optional["ProjectTemplateProfile"] = None,
+        identity_provider_profile: Optional["IdentityProviderProfile"] = None,
+        registry_mirror_profiles: Optional[List["RegistryMirrorProfile"]] = None,
+        default_storage_class_profile: Optional["DefaultStorageClassProfile"] = None,
+        azure_file_csi_profile: Optional["AzureFileCSIProfile"] = None,
+        monitoring_profile: Optional["MonitoringProfile"] = None,
+        upgrade_profile: Optional["UpgradeProfile"] = None,
+        node_eviction_profile: Optional["NodeEvictionProfile"] = None,
+        security_profile: Optional["SecurityProfile"] = None,
+        log_forwarding_profile: Optional["LogForwardingProfile"] = None,
+        admission_webhook_profiles: Optional[List["AdmissionWebhookProfile"]] = None,
+        scheduler_profile: Optional["SchedulerProfile"] = None,
+        egress_firewall_profile: Optional["EgressFirewallProfile"] = None,
         **kwargs
     ):
         """
-        :keyword value: The list of OpenShift clusters.
-        :paramtype value: list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftCluster]
-        :keyword next_link: The link used to get the next page of operations.
-        :paramtype next_link: str
-        """
-        super(OpenShiftClusterList, self).__init__(**kwargs)
-        self.value = value
-        self.next_link = next_link
+        :keyword provisioning_state: The cluster provisioning state. Possible values include:
+         "AdminUpdating", "Cancelled", "Creating", "Deleting", "Failed", "Succeeded", "Updating".
+        :paramtype provisioning_state: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProvisioningState
+        :keyword cluster_profile: The cluster profile.
+        :paramtype cluster_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterProfile
+        :keyword console_profile: The console profile.
+        :paramtype console_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ConsoleProfile
+        :keyword service_principal_profile: The cluster service principal profile.
+        :paramtype service_principal_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ServicePrincipalProfile
+        :keyword network_profile: The cluster network profile.
+        :paramtype network_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NetworkProfile
+        :keyword master_profile: The cluster master profile.
+        :paramtype master_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MasterProfile
+        :keyword worker_profiles: The cluster worker profiles.
+        :paramtype worker_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfile]
+        :keyword apiserver_profile: The cluster API server profile.
+        :paramtype apiserver_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.APIServerProfile
+        :keyword ingress_profiles: The cluster ingress profiles.
+        :paramtype ingress_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+        :keyword maintenance_window: The cluster maintenance window.
+        :paramtype maintenance_window:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+        :keyword project_template_profile: The default resource quota and limit range of new
+         projects.
+        :paramtype project_template_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+        :keyword identity_provider_profile: The OpenID Connect identity provider with which users
+         log in to the cluster.
+        :paramtype identity_provider_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+        :keyword registry_mirror_profiles: The pull-through caches through which images of upstream
+         registries are pulled by digest.
+        :paramtype registry_mirror_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+        :keyword default_storage_class_profile: The storage class which is the default storage
+         class of the cluster.
+        :paramtype default_storage_class_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+        :keyword azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.
+         If omitted, no Azure Files storage class is created.
+        :paramtype azure_file_csi_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+        :keyword monitoring_profile: The retention and storage of the platform Prometheus.  If
+         omitted, the platform defaults are used.
+        :paramtype monitoring_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+        :keyword upgrade_profile: How the worker nodes are updated during upgrades.  If omitted,
+         the machine config pool chooses the order and the pace of the updates.
+        :paramtype upgrade_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+        :keyword node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted,
+         the platform thresholds are used.
+        :paramtype node_eviction_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+        :keyword security_profile: The default seccomp profile and the SELinux booleans of the
+         nodes.  Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+        :paramtype security_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+        :keyword log_forwarding_profile: The off-cluster destination to which the cluster logs are
+         forwarded.  If omitted, logs are not forwarded.
+        :paramtype log_forwarding_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+        :keyword admission_webhook_profiles: The admission webhook configurations which are
+         registered on the cluster.  If omitted, none are registered.
+        :paramtype admission_webhook_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+        :keyword scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+         workloads are not constrained to any nodes by default.
+        :paramtype scheduler_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+        :keyword egress_firewall_profile: The destinations outside the cluster to which the pods of
+         the customer namespaces may connect.  If omitted, egress is not restricted.
+        :paramtype egress_firewall_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+        """
+        super(OpenShiftClusterTemplate, self).__init__(**kwargs)
+        self.system_data = None
+        self.provisioning_state = provisioning_state
+        self.cluster_profile = cluster_profile
+        self.console_profile = console_profile
+        self.service_principal_profile = service_principal_profile
+        self.cluster_identities = None
+        self.network_profile = network_profile
+        self.master_profile = master_profile
+        self.worker_profiles = worker_profiles
+        self.worker_profiles_scale_status = None
+        self.worker_machine_sets_progress = None
+        self.apiserver_profile = apiserver_profile
+        self.ingress_profiles = ingress_profiles
+        self.maintenance_window = maintenance_window
+        self.project_template_profile = project_template_profile
+        self.identity_provider_profile = identity_provider_profile
+        self.registry_mirror_profiles = registry_mirror_profiles
+        self.default_storage_class_profile = default_storage_class_profile
+        self.azure_file_csi_profile = azure_file_csi_profile
+        self.monitoring_profile = monitoring_profile
+        self.upgrade_profile = upgrade_profile
+        self.node_eviction_profile = node_eviction_profile
+        self.security_profile = security_profile
+        self.log_forwarding_profile = log_forwarding_profile
+        self.admission_webhook_profiles = admission_webhook_profiles
+        self.scheduler_profile = scheduler_profile
+        self.egress_firewall_profile = egress_firewall_profile
 
 
 class OpenShiftClusterUpdate(msrest.serialization.Model):
@@ -1012,6 +2326,10 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
     :ivar service_principal_profile: The cluster service principal profile.
     :vartype service_principal_profile:
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ServicePrincipalProfile
+    :ivar cluster_identities: The Azure identities used by the cluster components, to which Azure
+     RBAC and policy can be scoped.
+    :vartype cluster_identities:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ClusterIdentity]
     :ivar network_profile: The cluster network profile.
     :vartype network_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NetworkProfile
     :ivar master_profile: The cluster master profile.
@@ -1019,16 +2337,82 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
     :ivar worker_profiles: The cluster worker profiles.
     :vartype worker_profiles:
      list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfile]
+    :ivar worker_profiles_scale_status: The progress of the scale operations of the cluster worker
+     profiles.
+    :vartype worker_profiles_scale_status:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerProfileScale]
+    :ivar worker_machine_sets_progress: The progress of the creation of the machine sets of the
+     additional worker profiles during install.
+    :vartype worker_machine_sets_progress:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.WorkerMachineSetsProgress
     :ivar apiserver_profile: The cluster API server profile.
     :vartype apiserver_profile:
      ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.APIServerProfile
     :ivar ingress_profiles: The cluster ingress profiles.
     :vartype ingress_profiles:
      list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+    :ivar maintenance_window: The cluster maintenance window.
+    :vartype maintenance_window:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+    :ivar project_template_profile: The default resource quota and limit range of new projects.
+    :vartype project_template_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+    :ivar identity_provider_profile: The OpenID Connect identity provider with which users log in
+     to the cluster.
+    :vartype identity_provider_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+    :ivar registry_mirror_profiles: The pull-through caches through which images of upstream
+     registries are pulled by digest.
+    :vartype registry_mirror_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+    :ivar default_storage_class_profile: The storage class which is the default storage class of
+     the cluster.
+    :vartype default_storage_class_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+    :ivar azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.  If
+     omitted, no Azure Files storage class is created.
+    :vartype azure_file_csi_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+    :ivar monitoring_profile: The retention and storage of the platform Prometheus.  If omitted, the
+     platform defaults are used.
+    :vartype monitoring_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+    :ivar upgrade_profile: How the worker nodes are updated during upgrades.  If omitted, the
+     machine config pool chooses the order and the pace of the updates.
+    :vartype upgrade_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+    :ivar node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted, the
+     platform thresholds are used.
+    :vartype node_eviction_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+    :ivar security_profile: The default seccomp profile and the SELinux booleans of the nodes.
+     Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+    :vartype security_profile: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+    :ivar log_forwarding_profile: The off-cluster destination to which the cluster logs are
+     forwarded.  If omitted, logs are not forwarded.
+    :vartype log_forwarding_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+    :ivar admission_webhook_profiles: The admission webhook configurations which are registered on
+     the cluster.  If omitted, none are registered.
+    :vartype admission_webhook_profiles:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+    :ivar scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+     workloads are not constrained to any nodes by default.
+    :vartype scheduler_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+    :ivar egress_firewall_profile: The destinations outside the cluster to which the pods of the
+     customer namespaces may connect.  If omitted, egress is not restricted.
+    :vartype egress_firewall_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+    :ivar template: A template exported from a cluster, whose properties pre-fill those which the
+     request does not set.  Only used when the cluster is created; it is not returned in responses.
+    :vartype template: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterTemplate
     """
 
     _validation = {
         'system_data': {'readonly': True},
+        'cluster_identities': {'readonly': True},
+        'worker_profiles_scale_status': {'readonly': True},
+        'worker_machine_sets_progress': {'readonly': True},
     }
 
     _attribute_map = {
@@ -1038,11 +2422,29 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         'cluster_profile': {'key': 'properties.clusterProfile', 'type': 'ClusterProfile'},
         'console_profile': {'key': 'properties.consoleProfile', 'type': 'ConsoleProfile'},
         'service_principal_profile': {'key': 'properties.servicePrincipalProfile', 'type': 'ServicePrincipalProfile'},
+        'cluster_identities': {'key': 'properties.clusterIdentities', 'type': '[ClusterIdentity]'},
         'network_profile': {'key': 'properties.networkProfile', 'type': 'NetworkProfile'},
         'master_profile': {'key': 'properties.masterProfile', 'type': 'MasterProfile'},
         'worker_profiles': {'key': 'properties.workerProfiles', 'type': '[WorkerProfile]'},
+        'worker_profiles_scale_status': {'key': 'properties.workerProfilesScaleStatus', 'type': '[WorkerProfileScale]'},
+        'worker_machine_sets_progress': {'key': 'properties.workerMachineSetsProgress', 'type': 'WorkerMachineSetsProgress'},
         'apiserver_profile': {'key': 'properties.apiserverProfile', 'type': 'APIServerProfile'},
         'ingress_profiles': {'key': 'properties.ingressProfiles', 'type': '[IngressProfile]'},
+        'maintenance_window': {'key': 'properties.maintenanceWindow', 'type': 'MaintenanceWindow'},
+        'project_template_profile': {'key': 'properties.projectTemplateProfile', 'type': 'ProjectTemplateProfile'},
+        'identity_provider_profile': {'key': 'properties.identityProviderProfile', 'type': 'IdentityProviderProfile'},
+        'registry_mirror_profiles': {'key': 'properties.registryMirrorProfiles', 'type': '[RegistryMirrorProfile]'},
+        'default_storage_class_profile': {'key': 'properties.defaultStorageClassProfile', 'type': 'DefaultStorageClassProfile'},
+        'azure_file_csi_profile': {'key': 'properties.azureFileCsiProfile', 'type': 'AzureFileCSIProfile'},
+        'monitoring_profile': {'key': 'properties.monitoringProfile', 'type': 'MonitoringProfile'},
+        'upgrade_profile': {'key': 'properties.upgradeProfile', 'type': 'UpgradeProfile'},
+        'node_eviction_profile': {'key': 'properties.nodeEvictionProfile', 'type': 'NodeEvictionProfile'},
+        'security_profile': {'key': 'properties.securityProfile', 'type': 'SecurityProfile'},
+        'log_forwarding_profile': {'key': 'properties.logForwardingProfile', 'type': 'LogForwardingProfile'},
+        'admission_webhook_profiles': {'key': 'properties.admissionWebhookProfiles', 'type': '[AdmissionWebhookProfile]'},
+        'scheduler_profile': {'key': 'properties.schedulerProfile', 'type': 'SchedulerProfile'},
+        'egress_firewall_profile': {'key': 'properties.egressFirewallProfile', 'type': 'EgressFirewallProfile'},
+        'template': {'key': 'properties.template', 'type': 'OpenShiftClusterTemplate'},
     }
 
     def __init__(
@@ -1058,6 +2460,21 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         worker_profiles: Optional[List["WorkerProfile"]] = None,
         apiserver_profile: Optional["APIServerProfile"] = None,
         ingress_profiles: Optional[List["IngressProfile"]] = None,
+        maintenance_window: Optional["MaintenanceWindow"] = None,
+        project_template_profile: Optional["ProjectTemplateProfile"] = None,
+        identity_provider_profile: Optional["IdentityProviderProfile"] = None,
+        registry_mirror_profiles: Optional[List["RegistryMirrorProfile"]] = None,
+        default_storage_class_profile: Optional["DefaultStorageClassProfile"] = None,
+        azure_file_csi_profile: Optional["AzureFileCSIProfile"] = None,
+        monitoring_profile: Optional["MonitoringProfile"] = None,
+        upgrade_profile: Optional["UpgradeProfile"] = None,
+        node_eviction_profile: Optional["NodeEvictionProfile"] = None,
+        security_profile: Optional["SecurityProfile"] = None,
+        log_forwarding_profile: Optional["LogForwardingProfile"] = None,
+        admission_webhook_profiles: Optional[List["AdmissionWebhookProfile"]] = None,
+        scheduler_profile: Optional["SchedulerProfile"] = None,
+        egress_firewall_profile: Optional["EgressFirewallProfile"] = None,
+        template: Optional["OpenShiftClusterTemplate"] = None,
         **kwargs
     ):
         """
@@ -1090,6 +2507,65 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         :keyword ingress_profiles: The cluster ingress profiles.
         :paramtype ingress_profiles:
          list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IngressProfile]
+        :keyword maintenance_window: The cluster maintenance window.
+        :paramtype maintenance_window:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MaintenanceWindow
+        :keyword project_template_profile: The default resource quota and limit range of new
+         projects.
+        :paramtype project_template_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProjectTemplateProfile
+        :keyword identity_provider_profile: The OpenID Connect identity provider with which users
+         log in to the cluster.
+        :paramtype identity_provider_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.IdentityProviderProfile
+        :keyword registry_mirror_profiles: The pull-through caches through which images of upstream
+         registries are pulled by digest.
+        :paramtype registry_mirror_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.RegistryMirrorProfile]
+        :keyword default_storage_class_profile: The storage class which is the default storage
+         class of the cluster.
+        :paramtype default_storage_class_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DefaultStorageClassProfile
+        :keyword azure_file_csi_profile: The Azure Files storage class for ReadWriteMany volumes.
+         If omitted, no Azure Files storage class is created.
+        :paramtype azure_file_csi_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AzureFileCSIProfile
+        :keyword monitoring_profile: The retention and storage of the platform Prometheus.  If
+         omitted, the platform defaults are used.
+        :paramtype monitoring_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.MonitoringProfile
+        :keyword upgrade_profile: How the worker nodes are updated during upgrades.  If omitted,
+         the machine config pool chooses the order and the pace of the updates.
+        :paramtype upgrade_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.UpgradeProfile
+        :keyword node_eviction_profile: The kubelet eviction thresholds of the nodes.  If omitted,
+         the platform thresholds are used.
+        :paramtype node_eviction_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.NodeEvictionProfile
+        :keyword security_profile: The default seccomp profile and the SELinux booleans of the
+         nodes.  Can only be set at cluster create time.  If omitted, the platform behaviour is kept.
+        :paramtype security_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SecurityProfile
+        :keyword log_forwarding_profile: The off-cluster destination to which the cluster logs are
+         forwarded.  If omitted, logs are not forwarded.
+        :paramtype log_forwarding_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.LogForwardingProfile
+        :keyword admission_webhook_profiles: The admission webhook configurations which are
+         registered on the cluster.  If omitted, none are registered.
+        :paramtype admission_webhook_profiles:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdmissionWebhookProfile]
+        :keyword scheduler_profile: The cluster-wide default node selector of workloads.  If omitted,
+         workloads are not constrained to any nodes by default.
+        :paramtype scheduler_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SchedulerProfile
+        :keyword egress_firewall_profile: The destinations outside the cluster to which the pods of
+         the customer namespaces may connect.  If omitted, egress is not restricted.
+        :paramtype egress_firewall_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.EgressFirewallProfile
+        :keyword template: A template exported from a cluster, whose properties pre-fill those which
+         the request does not set.  Only used when the cluster is created; it is not returned in
+         responses.
+        :paramtype template: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterTemplate
         """
         super(OpenShiftClusterUpdate, self).__init__(**kwargs)
         self.tags = tags
@@ -1098,11 +2574,68 @@ class OpenShiftClusterUpdate(msrest.serialization.Model):
         self.cluster_profile = cluster_profile
         self.console_profile = console_profile
         self.service_principal_profile = service_principal_profile
+        self.cluster_identities = None
         self.network_profile = network_profile
         self.master_profile = master_profile
         self.worker_profiles = worker_profiles
+        self.worker_profiles_scale_status = None
+        self.worker_machine_sets_progress = None
         self.apiserver_profile = apiserver_profile
         self.ingress_profiles = ingress_profiles
+        self.maintenance_window = maintenance_window
+        self.project_template_profile = project_template_profile
+        self.identity_provider_profile = identity_provider_profile
+        self.registry_mirror_profiles = registry_mirror_profiles
+        self.default_storage_class_profile = default_storage_class_profile
+        self.azure_file_csi_profile = azure_file_csi_profile
+        self.monitoring_profile = monitoring_profile
+        self.upgrade_profile = upgrade_profile
+        self.node_eviction_profile = node_eviction_profile
+        self.security_profile = security_profile
+        self.log_forwarding_profile = log_forwarding_profile
+        self.admission_webhook_profiles = admission_webhook_profiles
+        self.scheduler_profile = scheduler_profile
+        self.egress_firewall_profile = egress_firewall_profile
+        self.template = template
+
+
+class OpenShiftClusterValidationFindings(msrest.serialization.Model):
+    """OpenShiftClusterValidationFindings represents the findings of validating a proposed OpenShift
+    cluster.
+
+    :ivar status: The status of the validation.  Failed if any finding has severity Error. Possible
+     values include: "Failed", "Succeeded".
+    :vartype status: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationStatus
+    :ivar findings: The findings of the validation.
+    :vartype findings:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationFinding]
+    """
+
+    _attribute_map = {
+        'status': {'key': 'status', 'type': 'str'},
+        'findings': {'key': 'findings', 'type': '[ValidationFinding]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        status: Optional[Union[str, "ValidationStatus"]] = None,
+        findings: Optional[List["ValidationFinding"]] = None,
+        **kwargs
+    ):
+        """
+        :keyword status: The status of the validation.  Failed if any finding has severity Error.
+         Possible values include: "Failed", "Succeeded".
+        :paramtype status: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationStatus
+        :keyword findings: The findings of the validation.
+        :paramtype findings:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationFinding]
+        """
+        super(OpenShiftClusterValidationFindings, self).__init__(**kwargs)
+        self.status = status
+        self.findings = findings
 
 
 class OpenShiftVersion(ProxyResource):
@@ -1186,6 +2719,81 @@ class OpenShiftVersionList(msrest.serialization.Model):
         self.next_link = next_link
 
 
+class OpenShiftVersionMatrix(msrest.serialization.Model):
+    """OpenShiftVersionMatrix represents the OpenShift versions supported in a location and the
+    versions clusters at each of them can be upgraded to.
+
+    :ivar value: The supported versions, sorted by version.
+    :vartype value:
+     list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftVersionMatrixVersion]
+    """
+
+    _attribute_map = {
+        'value': {'key': 'value', 'type': '[OpenShiftVersionMatrixVersion]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        value: Optional[List["OpenShiftVersionMatrixVersion"]] = None,
+        **kwargs
+    ):
+        """
+        :keyword value: The supported versions, sorted by version.
+        :paramtype value:
+         list[~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftVersionMatrixVersion]
+        """
+        super(OpenShiftVersionMatrix, self).__init__(**kwargs)
+        self.value = value
+
+
+class OpenShiftVersionMatrixVersion(msrest.serialization.Model):
+    """OpenShiftVersionMatrixVersion represents an OpenShift version supported in a location.
+
+    :ivar version: The version.
+    :vartype version: str
+    :ivar status: The status of the version.  Installable means that clusters can be installed at
+     the version.  UpgradeOnly means that existing clusters can be upgraded to the version but not
+     installed at it.  Deprecated means that clusters at the version should be upgraded off it.
+     Possible values include: "Deprecated", "Installable", "UpgradeOnly".
+    :vartype status: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftVersionStatus
+    :ivar upgrade_versions: The versions clusters at the version can be upgraded to.
+    :vartype upgrade_versions: list[str]
+    """
+
+    _attribute_map = {
+        'version': {'key': 'version', 'type': 'str'},
+        'status': {'key': 'status', 'type': 'str'},
+        'upgrade_versions': {'key': 'upgradeVersions', 'type': '[str]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        version: Optional[str] = None,
+        status: Optional[Union[str, "OpenShiftVersionStatus"]] = None,
+        upgrade_versions: Optional[List[str]] = None,
+        **kwargs
+    ):
+        """
+        :keyword version: The version.
+        :paramtype version: str
+        :keyword status: The status of the version.  Installable means that clusters can be installed
+         at the version.  UpgradeOnly means that existing clusters can be upgraded to the version but
+         not installed at it.  Deprecated means that clusters at the version should be upgraded off
+         it. Possible values include: "Deprecated", "Installable", "UpgradeOnly".
+        :paramtype status: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftVersionStatus
+        :keyword upgrade_versions: The versions clusters at the version can be upgraded to.
+        :paramtype upgrade_versions: list[str]
+        """
+        super(OpenShiftVersionMatrixVersion, self).__init__(**kwargs)
+        self.version = version
+        self.status = status
+        self.upgrade_versions = upgrade_versions
+
+
 class Operation(msrest.serialization.Model):
     """Operation represents an RP operation.
 
@@ -1309,6 +2917,118 @@ class OutboundIPPrefix(msrest.serialization.Model):
         self.id = id
 
 
+class ProjectTemplateProfile(msrest.serialization.Model):
+    """ProjectTemplateProfile represents the default resource quota and limit range which are created in each project requested by a user.  Values are Kubernetes resource quantities, e.g. 500m or 2Gi.
+
+    :ivar resource_quota: The hard limits of the default resource quota, keyed by quota resource,
+     e.g. pods, requests.cpu or count/deployments.apps.
+    :vartype resource_quota: dict[str, str]
+    :ivar default_limits: The default container resource limits of the default limit range, keyed
+     by cpu, memory or ephemeral-storage.
+    :vartype default_limits: dict[str, str]
+    :ivar default_requests: The default container resource requests of the default limit range,
+     keyed by cpu, memory or ephemeral-storage.  Requests may not exceed the corresponding default
+     limits.
+    :vartype default_requests: dict[str, str]
+    """
+
+    _attribute_map = {
+        'resource_quota': {'key': 'resourceQuota', 'type': '{str}'},
+        'default_limits': {'key': 'defaultLimits', 'type': '{str}'},
+        'default_requests': {'key': 'defaultRequests', 'type': '{str}'},
+    }
+
+    def __init__(
+        self,
+        *,
+        resource_quota: Optional[Dict[str, str]] = None,
+        default_limits: Optional[Dict[str, str]] = None,
+        default_requests: Optional[Dict[str, str]] = None,
+        **kwargs
+    ):
+        """
+        :keyword resource_quota: The hard limits of the default resource quota, keyed by quota
+         resource, e.g. pods, requests.cpu or count/deployments.apps.
+        :paramtype resource_quota: dict[str, str]
+        :keyword default_limits: The default container resource limits of the default limit range,
+         keyed by cpu, memory or ephemeral-storage.
+        :paramtype default_limits: dict[str, str]
+        :keyword default_requests: The default container resource requests of the default limit
+         range, keyed by cpu, memory or ephemeral-storage.  Requests may not exceed the corresponding
+         default limits.
+        :paramtype default_requests: dict[str, str]
+        """
+        super(ProjectTemplateProfile, self).__init__(**kwargs)
+        self.resource_quota = resource_quota
+        self.default_limits = default_limits
+        self.default_requests = default_requests
+
+
+class RegistryMirrorProfile(msrest.serialization.Model):
+    """RegistryMirrorProfile represents a pull-through cache, e.g. an Azure Container Registry cache rule, from which the cluster pulls the images of an upstream registry by digest, falling back to the upstream registry if the cache is unavailable.
+
+    :ivar source: The upstream registry, e.g. docker.io, optionally followed by a repository path,
+     e.g. docker.io/library.
+    :vartype source: str
+    :ivar mirror: The pull-through cache, e.g. myregistry.azurecr.io/docker-hub, optionally
+     followed by a repository path.  It must be reachable over https.
+    :vartype mirror: str
+    """
+
+    _attribute_map = {
+        'source': {'key': 'source', 'type': 'str'},
+        'mirror': {'key': 'mirror', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        source: Optional[str] = None,
+        mirror: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword source: The upstream registry, e.g. docker.io, optionally followed by a repository
+         path, e.g. docker.io/library.
+        :paramtype source: str
+        :keyword mirror: The pull-through cache, e.g. myregistry.azurecr.io/docker-hub, optionally
+         followed by a repository path.  It must be reachable over https.
+        :paramtype mirror: str
+        """
+        super(RegistryMirrorProfile, self).__init__(**kwargs)
+        self.source = source
+        self.mirror = mirror
+
+
+class SchedulerProfile(msrest.serialization.Model):
+    """SchedulerProfile represents the configuration of the cluster scheduler.
+
+    :ivar default_node_selector: The node labels, e.g. node-role.kubernetes.io/app=,region=east, to
+     whose nodes the pods of projects without a node selector of their own are constrained.  At
+     least one worker node must have the labels.
+    :vartype default_node_selector: str
+    """
+
+    _attribute_map = {
+        'default_node_selector': {'key': 'defaultNodeSelector', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        default_node_selector: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword default_node_selector: The node labels, e.g. node-role.kubernetes.io/app=,region=east,
+         to whose nodes the pods of projects without a node selector of their own are constrained.  At
+         least one worker node must have the labels.
+        :paramtype default_node_selector: str
+        """
+        super(SchedulerProfile, self).__init__(**kwargs)
+        self.default_node_selector = default_node_selector
+
+
 class Secret(ProxyResource):
     """Secret represents a secret.
 
@@ -1425,6 +3145,56 @@ class SecretUpdate(msrest.serialization.Model):
         self.secret_resources = secret_resources
 
 
+class SecurityProfile(msrest.serialization.Model):
+    """SecurityProfile represents the seccomp and SELinux defaults of the nodes of the cluster.
+
+    :ivar default_seccomp_profile: The seccomp profile of containers which do not set one.
+     Unconfined, the platform behaviour, runs them without a seccomp profile.  RuntimeDefault
+     applies the default profile of the container runtime; containers which need system calls it
+     blocks must then set the Unconfined seccomp profile explicitly and be admitted by a security
+     context constraint which allows it.  Supported from OpenShift 4.12. Possible values include:
+     "Unconfined", "RuntimeDefault".
+    :vartype default_seccomp_profile: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SeccompProfile
+    :ivar se_linux_booleans: The SELinux booleans to turn on: deny_ptrace, which prevents
+     processes from tracing others, including debuggers, and deny_execmem, which prevents
+     unconfined processes from using writable and executable memory, including some just-in-time
+     compilers.
+    :vartype se_linux_booleans: list[str]
+    """
+
+    _attribute_map = {
+        'default_seccomp_profile': {'key': 'defaultSeccompProfile', 'type': 'str'},
+        'se_linux_booleans': {'key': 'seLinuxBooleans', 'type': '[str]'},
+    }
+
+    def __init__(
+        self,
+        *,
+        default_seccomp_profile: Optional[Union[str, "SeccompProfile"]] = None,
+        se_linux_booleans: Optional[List[str]] = None,
+        **kwargs
+    ):
+        """
+        :keyword default_seccomp_profile: The seccomp profile of containers which do not set one.
+         Unconfined, the platform behaviour, runs them without a seccomp profile.  RuntimeDefault
+         applies the default profile of the container runtime; containers which need system calls it
+         blocks must then set the Unconfined seccomp profile explicitly and be admitted by a security
+         context constraint which allows it.  Supported from OpenShift 4.12. Possible values include:
+         "Unconfined", "RuntimeDefault".
+        :paramtype default_seccomp_profile: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.SeccompProfile
+        :keyword se_linux_booleans: The SELinux booleans to turn on: deny_ptrace, which prevents
+         processes from tracing others, including debuggers, and deny_execmem, which prevents
+         unconfined processes from using writable and executable memory, including some just-in-time
+         compilers.
+        :paramtype se_linux_booleans: list[str]
+        """
+        super(SecurityProfile, self).__init__(**kwargs)
+        self.default_seccomp_profile = default_seccomp_profile
+        self.se_linux_booleans = se_linux_booleans
+
+
 class ServicePrincipalProfile(msrest.serialization.Model):
     """ServicePrincipalProfile represents a service principal profile.
 
@@ -1759,6 +3529,128 @@ class SystemData(msrest.serialization.Model):
         self.last_modified_at = last_modified_at
 
 
+class UpgradeProfile(msrest.serialization.Model):
+    """UpgradeProfile represents how the worker nodes of the cluster are updated during upgrades.
+
+    :ivar last_node_name: The name of a worker node which is updated after all the other worker
+     nodes, e.g. to keep a critical singleton workload running for as long as possible.
+    :vartype last_node_name: str
+    :ivar max_unavailable: The number, e.g. 2, or the percentage, e.g. 10%, of worker nodes which
+     may be unavailable at the same time while they are updated, between 1 and 20 nodes or 1% and
+     50%.  Defaults to the platform value of 1 node.
+    :vartype max_unavailable: str
+    """
+
+    _attribute_map = {
+        'last_node_name': {'key': 'lastNodeName', 'type': 'str'},
+        'max_unavailable': {'key': 'maxUnavailable', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        last_node_name: Optional[str] = None,
+        max_unavailable: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword last_node_name: The name of a worker node which is updated after all the other
+         worker nodes, e.g. to keep a critical singleton workload running for as long as possible.
+        :paramtype last_node_name: str
+        :keyword max_unavailable: The number, e.g. 2, or the percentage, e.g. 10%, of worker nodes
+         which may be unavailable at the same time while they are updated, between 1 and 20 nodes or
+         1% and 50%.  Defaults to the platform value of 1 node.
+        :paramtype max_unavailable: str
+        """
+        super(UpgradeProfile, self).__init__(**kwargs)
+        self.last_node_name = last_node_name
+        self.max_unavailable = max_unavailable
+
+
+class ValidationFinding(msrest.serialization.Model):
+    """ValidationFinding represents a failed validation.
+
+    :ivar severity: The severity of the finding.  Error means that creating the cluster would fail.
+     Warning means that the validation could not be completed. Possible values include: "Error",
+     "Warning".
+    :vartype severity: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationSeverity
+    :ivar code: The error code of the finding.
+    :vartype code: str
+    :ivar target: The property the finding refers to.
+    :vartype target: str
+    :ivar message: The message of the finding.
+    :vartype message: str
+    """
+
+    _attribute_map = {
+        'severity': {'key': 'severity', 'type': 'str'},
+        'code': {'key': 'code', 'type': 'str'},
+        'target': {'key': 'target', 'type': 'str'},
+        'message': {'key': 'message', 'type': 'str'},
+    }
+
+    def __init__(
+        self,
+        *,
+        severity: Optional[Union[str, "ValidationSeverity"]] = None,
+        code: Optional[str] = None,
+        target: Optional[str] = None,
+        message: Optional[str] = None,
+        **kwargs
+    ):
+        """
+        :keyword severity: The severity of the finding.  Error means that creating the cluster would
+         fail.  Warning means that the validation could not be completed. Possible values include:
+         "Error", "Warning".
+        :paramtype severity: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ValidationSeverity
+        :keyword code: The error code of the finding.
+        :paramtype code: str
+        :keyword target: The property the finding refers to.
+        :paramtype target: str
+        :keyword message: The message of the finding.
+        :paramtype message: str
+        """
+        super(ValidationFinding, self).__init__(**kwargs)
+        self.severity = severity
+        self.code = code
+        self.target = target
+        self.message = message
+
+
+class WorkerMachineSetsProgress(msrest.serialization.Model):
+    """WorkerMachineSetsProgress represents the progress of the creation of the worker machine sets.
+
+    :ivar created: The number of worker machine sets created.
+    :vartype created: int
+    :ivar total: The number of worker machine sets to create.
+    :vartype total: int
+    """
+
+    _attribute_map = {
+        'created': {'key': 'created', 'type': 'int'},
+        'total': {'key': 'total', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        *,
+        created: Optional[int] = None,
+        total: Optional[int] = None,
+        **kwargs
+    ):
+        """
+        :keyword created: The number of worker machine sets created.
+        :paramtype created: int
+        :keyword total: The number of worker machine sets to create.
+        :paramtype total: int
+        """
+        super(WorkerMachineSetsProgress, self).__init__(**kwargs)
+        self.created = created
+        self.total = total
+
+
 class WorkerProfile(msrest.serialization.Model):
     """WorkerProfile represents a worker profile.
 
@@ -1779,6 +3671,21 @@ class WorkerProfile(msrest.serialization.Model):
     :ivar disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
      applicable.
     :vartype disk_encryption_set_id: str
+    :ivar disk_storage_account_type: The storage account type of the worker VM OS disks.  Only
+     supported for additional worker profiles.  If unset, Premium_LRS is used when supported by the
+     worker VM size, StandardSSD_LRS otherwise. Possible values include: "Premium_LRS",
+     "StandardSSD_LRS".
+    :vartype disk_storage_account_type: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DiskStorageAccountType
+    :ivar accelerated_networking: Whether the worker VM network interfaces use accelerated
+     networking.  If unset, it is enabled when supported by the worker VM size. Possible values
+     include: "Enabled", "Disabled".
+    :vartype accelerated_networking: str or
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AcceleratedNetworking
+    :ivar proximity_placement_group_profile: The proximity placement group of the worker VMs.  If
+     unset, the worker VMs are spread across the availability zones of the region.
+    :vartype proximity_placement_group_profile:
+     ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProximityPlacementGroupProfile
     """
 
     _attribute_map = {
@@ -1789,6 +3696,9 @@ class WorkerProfile(msrest.serialization.Model):
         'count': {'key': 'count', 'type': 'int'},
         'encryption_at_host': {'key': 'encryptionAtHost', 'type': 'str'},
         'disk_encryption_set_id': {'key': 'diskEncryptionSetId', 'type': 'str'},
+        'disk_storage_account_type': {'key': 'diskStorageAccountType', 'type': 'str'},
+        'accelerated_networking': {'key': 'acceleratedNetworking', 'type': 'str'},
+        'proximity_placement_group_profile': {'key': 'proximityPlacementGroupProfile', 'type': 'ProximityPlacementGroupProfile'},
     }
 
     def __init__(
@@ -1801,6 +3711,9 @@ class WorkerProfile(msrest.serialization.Model):
         count: Optional[int] = None,
         encryption_at_host: Optional[Union[str, "EncryptionAtHost"]] = None,
         disk_encryption_set_id: Optional[str] = None,
+        disk_storage_account_type: Optional[Union[str, "DiskStorageAccountType"]] = None,
+        accelerated_networking: Optional[Union[str, "AcceleratedNetworking"]] = None,
+        proximity_placement_group_profile: Optional["ProximityPlacementGroupProfile"] = None,
         **kwargs
     ):
         """
@@ -1821,6 +3734,21 @@ class WorkerProfile(msrest.serialization.Model):
         :keyword disk_encryption_set_id: The resource ID of an associated DiskEncryptionSet, if
          applicable.
         :paramtype disk_encryption_set_id: str
+        :keyword disk_storage_account_type: The storage account type of the worker VM OS disks.  Only
+         supported for additional worker profiles.  If unset, Premium_LRS is used when supported by
+         the worker VM size, StandardSSD_LRS otherwise. Possible values include: "Premium_LRS",
+         "StandardSSD_LRS".
+        :paramtype disk_storage_account_type: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.DiskStorageAccountType
+        :keyword accelerated_networking: Whether the worker VM network interfaces use accelerated
+         networking.  If unset, it is enabled when supported by the worker VM size. Possible values
+         include: "Enabled", "Disabled".
+        :paramtype accelerated_networking: str or
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AcceleratedNetworking
+        :keyword proximity_placement_group_profile: The proximity placement group of the worker VMs.
+         If unset, the worker VMs are spread across the availability zones of the region.
+        :paramtype proximity_placement_group_profile:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.ProximityPlacementGroupProfile
         """
         super(WorkerProfile, self).__init__(**kwargs)
         self.name = name
@@ -1830,3 +3758,45 @@ class WorkerProfile(msrest.serialization.Model):
         self.count = count
         self.encryption_at_host = encryption_at_host
         self.disk_encryption_set_id = disk_encryption_set_id
+        self.disk_storage_account_type = disk_storage_account_type
+        self.accelerated_networking = accelerated_networking
+        self.proximity_placement_group_profile = proximity_placement_group_profile
+
+
+class WorkerProfileScale(msrest.serialization.Model):
+    """WorkerProfileScale represents the scale operation of a worker profile.
+
+    :ivar name: The worker profile name.
+    :vartype name: str
+    :ivar current_replicas: The number of ready worker VMs.
+    :vartype current_replicas: int
+    :ivar target_replicas: The number of worker VMs requested.
+    :vartype target_replicas: int
+    """
+
+    _attribute_map = {
+        'name': {'key': 'name', 'type': 'str'},
+        'current_replicas': {'key': 'currentReplicas', 'type': 'int'},
+        'target_replicas': {'key': 'targetReplicas', 'type': 'int'},
+    }
+
+    def __init__(
+        self,
+        *,
+        name: Optional[str] = None,
+        current_replicas: Optional[int] = None,
+        target_replicas: Optional[int] = None,
+        **kwargs
+    ):
+        """
+        :keyword name: The worker profile name.
+        :paramtype name: str
+        :keyword current_replicas: The number of ready worker VMs.
+        :paramtype current_replicas: int
+        :keyword target_replicas: The number of worker VMs requested.
+        :paramtype target_replicas: int
+        """
+        super(WorkerProfileScale, self).__init__(**kwargs)
+        self.name = name
+        self.current_replicas = current_replicas
+        self.target_replicas = target_replicas
@@ -16,6 +16,7 @@
 
 from ._operations import Operations
 from ._open_shift_versions_operations import OpenShiftVersionsOperations
+from ._open_shift_version_matrix_operations import OpenShiftVersionMatrixOperations
 from ._open_shift_clusters_operations import OpenShiftClustersOperations
 from ._machine_pools_operations import MachinePoolsOperations
 from ._secrets_operations import SecretsOperations
@@ -25,6 +26,7 @@ from ._sync_sets_operations import SyncSetsOperations
 __all__ = [
     'Operations',
     'OpenShiftVersionsOperations',
+    'OpenShiftVersionMatrixOperations',
     'OpenShiftClustersOperations',
     'MachinePoolsOperations',
     'SecretsOperations',
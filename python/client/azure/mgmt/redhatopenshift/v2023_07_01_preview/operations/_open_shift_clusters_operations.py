@@ -263,6 +263,43 @@ def build_update_request_initial(
     )
 
 
+def build_export_template_request(
+    subscription_id,  # type: str
+    resource_group_name,  # type: str
+    resource_name,  # type: str
+    **kwargs  # type: Any
+):
+    # type: (...) -> HttpRequest
+    api_version = kwargs.pop('api_version', "2023-07-01-preview")  # type: str
+
+    accept = "application/json"
+    # Construct URL
+    _url = kwargs.pop("template_url", "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/exportTemplate")  # pylint: disable=line-too-long
+    path_format_arguments = {
+        "subscriptionId": _SERIALIZER.url("subscription_id", subscription_id, 'str', min_length=1),
+        "resourceGroupName": _SERIALIZER.url("resource_group_name", resource_group_name, 'str', max_length=90, min_length=1),
+        "resourceName": _SERIALIZER.url("resource_name", resource_name, 'str'),
+    }
+
+    _url = _format_url_section(_url, **path_format_arguments)
+
+    # Construct parameters
+    _query_parameters = kwargs.pop("params", {})  # type: Dict[str, Any]
+    _query_parameters['api-version'] = _SERIALIZER.query("api_version", api_version, 'str')
+
+    # Construct headers
+    _header_parameters = kwargs.pop("headers", {})  # type: Dict[str, Any]
+    _header_parameters['Accept'] = _SERIALIZER.header("accept", accept, 'str')
+
+    return HttpRequest(
+        method="POST",
+        url=_url,
+        params=_query_parameters,
+        headers=_header_parameters,
+        **kwargs
+    )
+
+
 def build_list_admin_credentials_request(
     subscription_id,  # type: str
     resource_group_name,  # type: str
@@ -271,6 +308,7 @@ def build_list_admin_credentials_request(
 ):
     # type: (...) -> HttpRequest
     api_version = kwargs.pop('api_version', "2023-07-01-preview")  # type: str
+    format = kwargs.pop('format', None)  # type: Optional[Union[str, "_models.AdminCredentialsFormat"]]
 
     accept = "application/json"
     # Construct URL
@@ -286,6 +324,8 @@ def build_list_admin_credentials_request(
     # Construct parameters
     _query_parameters = kwargs.pop("params", {})  # type: Dict[str, Any]
     _query_parameters['api-version'] = _SERIALIZER.query("api_version", api_version, 'str')
+    if format is not None:
+        _query_parameters['format'] = _SERIALIZER.query("format", format, 'str')
 
     # Construct headers
     _header_parameters = kwargs.pop("headers", {})  # type: Dict[str, Any]
@@ -336,6 +376,46 @@ def build_list_credentials_request(
         **kwargs
     )
 
+
+def build_validate_request(
+    subscription_id,  # type: str
+    resource_group_name,  # type: str
+    resource_name,  # type: str
+    **kwargs  # type: Any
+):
+    # type: (...) -> HttpRequest
+    api_version = kwargs.pop('api_version', "2023-07-01-preview")  # type: str
+    content_type = kwargs.pop('content_type', None)  # type: Optional[str]
+
+    accept = "application/json"
+    # Construct URL
+    _url = kwargs.pop("template_url", "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/validate")  # pylint: disable=line-too-long
+    path_format_arguments = {
+        "subscriptionId": _SERIALIZER.url("subscription_id", subscription_id, 'str', min_length=1),
+        "resourceGroupName": _SERIALIZER.url("resource_group_name", resource_group_name, 'str', max_length=90, min_length=1),
+        "resourceName": _SERIALIZER.url("resource_name", resource_name, 'str'),
+    }
+
+    _url = _format_url_section(_url, **path_format_arguments)
+
+    # Construct parameters
+    _query_parameters = kwargs.pop("params", {})  # type: Dict[str, Any]
+    _query_parameters['api-version'] = _SERIALIZER.query("api_version", api_version, 'str')
+
+    # Construct headers
+    _header_parameters = kwargs.pop("headers", {})  # type: Dict[str, Any]
+    if content_type is not None:
+        _header_parameters['Content-Type'] = _SERIALIZER.header("content_type", content_type, 'str')
+    _header_parameters['Accept'] = _SERIALIZER.header("accept", accept, 'str')
+
+    return HttpRequest(
+        method="POST",
+        url=_url,
+        params=_query_parameters,
+        headers=_header_parameters,
+        **kwargs
+    )
+
 # fmt: on
 class OpenShiftClustersOperations(object):
     """OpenShiftClustersOperations operations.
@@ -951,11 +1031,76 @@ class OpenShiftClustersOperations(object):
 
     begin_update.metadata = {'url': "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}"}  # type: ignore
 
+    @distributed_trace
+    def export_template(
+        self,
+        resource_group_name,  # type: str
+        resource_name,  # type: str
+        **kwargs  # type: Any
+    ):
+        # type: (...) -> "_models.OpenShiftClusterTemplate"
+        """Exports the configuration of an OpenShift cluster with the specified subscription, resource
+        group and resource name as a template.
+
+        The operation returns the creatable configuration of the cluster, without its secrets and the
+        identifiers of the resources it uses.  The template can be passed in the template property when
+        creating a cluster.
+
+        :param resource_group_name: The name of the resource group. The name is case insensitive.
+        :type resource_group_name: str
+        :param resource_name: The name of the OpenShift cluster resource.
+        :type resource_name: str
+        :keyword callable cls: A custom type or function that will be passed the direct response
+        :return: OpenShiftClusterTemplate, or the result of cls(response)
+        :rtype: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterTemplate
+        :raises: ~azure.core.exceptions.HttpResponseError
+        """
+        cls = kwargs.pop('cls', None)  # type: ClsType["_models.OpenShiftClusterTemplate"]
+        error_map = {
+            401: ClientAuthenticationError, 404: ResourceNotFoundError, 409: ResourceExistsError
+        }
+        error_map.update(kwargs.pop('error_map', {}))
+
+        api_version = kwargs.pop('api_version', "2023-07-01-preview")  # type: str
+
+        
+        request = build_export_template_request(
+            subscription_id=self._config.subscription_id,
+            resource_group_name=resource_group_name,
+            resource_name=resource_name,
+            api_version=api_version,
+            template_url=self.export_template.metadata['url'],
+        )
+        request = _convert_request(request)
+        request.url = self._client.format_url(request.url)
+
+        pipeline_response = self._client._pipeline.run(  # pylint: disable=protected-access
+            request,
+            stream=False,
+            **kwargs
+        )
+        response = pipeline_response.http_response
+
+        if response.status_code not in [200]:
+            map_error(status_code=response.status_code, response=response, error_map=error_map)
+            raise HttpResponseError(response=response, error_format=ARMErrorFormat)
+
+        deserialized = self._deserialize('OpenShiftClusterTemplate', pipeline_response)
+
+        if cls:
+            return cls(pipeline_response, deserialized, {})
+
+        return deserialized
+
+    export_template.metadata = {'url': "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/exportTemplate"}  # type: ignore
+
+
     @distributed_trace
     def list_admin_credentials(
         self,
         resource_group_name,  # type: str
         resource_name,  # type: str
+        format=None,  # type: Optional[Union[str, "_models.AdminCredentialsFormat"]]
         **kwargs  # type: Any
     ):
         # type: (...) -> "_models.OpenShiftClusterAdminKubeconfig"
@@ -968,6 +1113,10 @@ class OpenShiftClustersOperations(object):
         :type resource_group_name: str
         :param resource_name: The name of the OpenShift cluster resource.
         :type resource_name: str
+        :param format: The format in which the admin credentials are returned.  Kubeconfig (the
+         default) returns the kubeconfig only.  Structured additionally returns the API server URL and
+         the client certificate and key.
+        :type format: str or ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.AdminCredentialsFormat
         :keyword callable cls: A custom type or function that will be passed the direct response
         :return: OpenShiftClusterAdminKubeconfig, or the result of cls(response)
         :rtype: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterAdminKubeconfig
@@ -987,6 +1136,7 @@ class OpenShiftClustersOperations(object):
             resource_group_name=resource_group_name,
             resource_name=resource_name,
             api_version=api_version,
+            format=format,
             template_url=self.list_admin_credentials.metadata['url'],
         )
         request = _convert_request(request)
@@ -1074,3 +1224,74 @@ class OpenShiftClustersOperations(object):
 
     list_credentials.metadata = {'url': "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/listCredentials"}  # type: ignore
 
+
+    @distributed_trace
+    def validate(
+        self,
+        resource_group_name,  # type: str
+        resource_name,  # type: str
+        parameters,  # type: "_models.OpenShiftCluster"
+        **kwargs  # type: Any
+    ):
+        # type: (...) -> "_models.OpenShiftClusterValidationFindings"
+        """Validates a proposed OpenShift cluster with the specified subscription, resource group and
+        resource name.
+
+        The operation runs the validations run when the cluster is created and returns all their
+        findings.  Nothing is created.
+
+        :param resource_group_name: The name of the resource group. The name is case insensitive.
+        :type resource_group_name: str
+        :param resource_name: The name of the OpenShift cluster resource.
+        :type resource_name: str
+        :param parameters: The OpenShift cluster resource.
+        :type parameters: ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftCluster
+        :keyword callable cls: A custom type or function that will be passed the direct response
+        :return: OpenShiftClusterValidationFindings, or the result of cls(response)
+        :rtype:
+         ~azure.mgmt.redhatopenshift.v2023_07_01_preview.models.OpenShiftClusterValidationFindings
+        :raises: ~azure.core.exceptions.HttpResponseError
+        """
+        cls = kwargs.pop('cls', None)  # type: ClsType["_models.OpenShiftClusterValidationFindings"]
+        error_map = {
+            401: ClientAuthenticationError, 404: ResourceNotFoundError, 409: ResourceExistsError
+        }
+        error_map.update(kwargs.pop('error_map', {}))
+
+        api_version = kwargs.pop('api_version', "2023-07-01-preview")  # type: str
+        content_type = kwargs.pop('content_type', "application/json")  # type: Optional[str]
+
+        _json = self._serialize.body(parameters, 'OpenShiftCluster')
+
+        request = build_validate_request(
+            subscription_id=self._config.subscription_id,
+            resource_group_name=resource_group_name,
+            resource_name=resource_name,
+            api_version=api_version,
+            content_type=content_type,
+            json=_json,
+            template_url=self.validate.metadata['url'],
+        )
+        request = _convert_request(request)
+        request.url = self._client.format_url(request.url)
+
+        pipeline_response = self._client._pipeline.run(  # pylint: disable=protected-access
+            request,
+            stream=False,
+            **kwargs
+        )
+        response = pipeline_response.http_response
+
+        if response.status_code not in [200]:
+            map_error(status_code=response.status_code, response=response, error_map=error_map)
+            raise HttpResponseError(response=response, error_format=ARMErrorFormat)
+
+        deserialized = self._deserialize('OpenShiftClusterValidationFindings', pipeline_response)
+
+        if cls:
+            return cls(pipeline_response, deserialized, {})
+
+        return deserialized
+
+    validate.metadata = {'url': "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.RedHatOpenShift/openShiftClusters/{resourceName}/validate"}  # type: ignore
+
@@ -0,0 +1,31 @@
+package database
+
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache License 2.0.
+
+import (
+	"context"
+
+	"github.com/Azure/ARO-RP/pkg/api"
+	"github.com/Azure/ARO-RP/pkg/database"
+	"github.com/Azure/ARO-RP/pkg/database/cosmosdb"
+)
+
+func injectClusterHealth(c *cosmosdb.FakeClusterHealthDocumentClient) {
+	c.SetQueryHandler(database.ClusterHealthListByKeyQuery, fakeClusterHealthListByKeyQuery)
+}
+
+func fakeClusterHealthListByKeyQuery(client cosmosdb.ClusterHealthDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.ClusterHealthDocumentRawIterator {
+	input, err := client.ListAll(context.Background(), nil)
+	if err != nil {
+		return cosmosdb.NewFakeClusterHealthDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.ClusterHealthDocument
+	for _, r := range input.ClusterHealthDocuments {
+		if r.Key == query.Parameters[0].Value {
+			results = append(results, r)
+		}
+	}
+	return cosmosdb.NewFakeClusterHealthDocumentIterator(results, 0)
+}
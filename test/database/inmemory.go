@@ -45,6 +45,13 @@ func NewFakeBilling() (db database.Billing, client *cosmosdb.FakeBillingDocument
 	return db, client
 }
 
+func NewFakeClusterHealth() (db database.ClusterHealth, client *cosmosdb.FakeClusterHealthDocumentClient) {
+	client = cosmosdb.NewFakeClusterHealthDocumentClient(jsonHandle)
+	injectClusterHealth(client)
+	db = database.NewClusterHealthWithProvidedClient(client)
+	return db, client
+}
+
 func NewFakeAsyncOperations() (db database.AsyncOperations, client *cosmosdb.FakeAsyncOperationDocumentClient) {
 	uuid := deterministicuuid.NewTestUUIDGenerator(deterministicuuid.ASYNCOPERATIONS)
 	client = cosmosdb.NewFakeAsyncOperationDocumentClient(jsonHandle)
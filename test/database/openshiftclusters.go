@@ -61,10 +61,18 @@ func fakeOpenShiftClustersQueueLengthQuery(client cosmosdb.OpenShiftClusterDocum
 }
 
 func fakeOpenShiftClustersDequeueQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
-	docs, err := getQueuedOpenShiftDocuments(client)
+	queued, err := getQueuedOpenShiftDocuments(client)
 	if err != nil {
 		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
 	}
+
+	var docs []*api.OpenShiftClusterDocument
+	for _, r := range queued {
+		pin := r.OpenShiftCluster.Properties.RPVersionPin
+		if pin == "" || pin == query.Parameters[0].Value {
+			docs = append(docs, r)
+		}
+	}
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(docs, 0)
 }
 
@@ -99,6 +107,34 @@ func fakeOpenshiftClustersMatchQuery(client cosmosdb.OpenShiftClusterDocumentCli
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
 }
 
+func fakeOpenshiftClustersDomainQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	domain := query.Parameters[0].Value
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		if r.ClusterDomainKey == "" {
+			continue
+		}
+		if r.ClusterDomainKey == domain ||
+			strings.HasSuffix(r.ClusterDomainKey, "."+domain) ||
+			strings.HasSuffix(domain, "."+r.ClusterDomainKey) {
+			results = append(results, r)
+		}
+	}
+
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+}
+
 func fakeOpenShiftClustersGetAllDocuments(client cosmosdb.OpenShiftClusterDocumentClient) ([]*api.OpenShiftClusterDocument, error) {
 	input, err := client.ListAll(context.Background(), nil)
 	if err != nil {
@@ -136,18 +172,48 @@ func fakeOpenshiftClustersPrefixQuery(client cosmosdb.OpenShiftClusterDocumentCl
 	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
 }
 
+func fakeOpenshiftClustersSupportCaseQuery(client cosmosdb.OpenShiftClusterDocumentClient, query *cosmosdb.Query, options *cosmosdb.Options) cosmosdb.OpenShiftClusterDocumentRawIterator {
+	startingIndex, err := fakeOpenShiftClustersGetContinuation(options)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	docs, err := fakeOpenShiftClustersGetAllDocuments(client)
+	if err != nil {
+		return cosmosdb.NewFakeOpenShiftClusterDocumentErroringRawIterator(err)
+	}
+
+	var results []*api.OpenShiftClusterDocument
+	for _, r := range docs {
+		for _, k := range r.SupportCaseIDKeys {
+			if k == query.Parameters[0].Value {
+				results = append(results, r)
+				break
+			}
+		}
+	}
+
+	return cosmosdb.NewFakeOpenShiftClusterDocumentIterator(results, startingIndex)
+}
+
 func fakeOpenShiftClustersRenewLeaseTrigger(ctx context.Context, doc *api.OpenShiftClusterDocument) error {
 	doc.LeaseExpires = int(time.Now().Unix()) + 60
 	return nil
 }
 
 func openShiftClusterConflictChecker(one *api.OpenShiftClusterDocument, two *api.OpenShiftClusterDocument) bool {
+	if one.ID == two.ID {
+		return false
+	}
 	if one.ClusterResourceGroupIDKey != "" && two.ClusterResourceGroupIDKey != "" && one.ClusterResourceGroupIDKey == two.ClusterResourceGroupIDKey {
 		return true
 	}
 	if one.ClientIDKey != "" && two.ClientIDKey != "" && one.ClientIDKey == two.ClientIDKey {
 		return true
 	}
+	if one.ClusterDomainKey != "" && two.ClusterDomainKey != "" && one.ClusterDomainKey == two.ClusterDomainKey {
+		return true
+	}
 	return false
 }
 
@@ -157,7 +223,9 @@ func injectOpenShiftClusters(c *cosmosdb.FakeOpenShiftClusterDocumentClient) {
 	c.SetQueryHandler(database.OpenShiftClustersGetQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersClientIdQuery, fakeOpenshiftClustersMatchQuery)
 	c.SetQueryHandler(database.OpenshiftClustersResourceGroupQuery, fakeOpenshiftClustersMatchQuery)
+	c.SetQueryHandler(database.OpenshiftClustersDomainQuery, fakeOpenshiftClustersDomainQuery)
 	c.SetQueryHandler(database.OpenshiftClustersPrefixQuery, fakeOpenshiftClustersPrefixQuery)
+	c.SetQueryHandler(database.OpenshiftClustersSupportCaseQuery, fakeOpenshiftClustersSupportCaseQuery)
 
 	c.SetTriggerHandler("renewLease", fakeOpenShiftClustersRenewLeaseTrigger)
 
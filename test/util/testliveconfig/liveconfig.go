@@ -10,12 +10,15 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/Azure/ARO-RP/pkg/util/liveconfig"
+	"github.com/Azure/ARO-RP/pkg/util/version"
 )
 
 type testLiveConfig struct {
 	adoptByHive    bool
 	installViaHive bool
 	useCheckAccess bool
+
+	supportedRPVersions []string
 }
 
 func (t *testLiveConfig) HiveRestConfig(ctx context.Context, shard int) (*rest.Config, error) {
@@ -37,6 +40,18 @@ func (t *testLiveConfig) UseCheckAccess(ctx context.Context) (bool, error) {
 	return t.useCheckAccess, nil
 }
 
+func (t *testLiveConfig) SupportedRPVersions(ctx context.Context) ([]string, error) {
+	return append([]string{version.GitCommit}, t.supportedRPVersions...), nil
+}
+
+// WithSupportedRPVersions adds RP versions to those returned by
+// SupportedRPVersions
+func WithSupportedRPVersions(m liveconfig.Manager, versions ...string) liveconfig.Manager {
+	t := m.(*testLiveConfig)
+	t.supportedRPVersions = append(t.supportedRPVersions, versions...)
+	return t
+}
+
 func (t *testLiveConfig) DefaultInstallerPullSpecOverride(ctx context.Context) string {
 	if t.installViaHive {
 		return "example/pull:spec"